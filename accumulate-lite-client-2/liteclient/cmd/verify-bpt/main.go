@@ -2,47 +2,15 @@ package main
 
 import (
 	"bytes"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"time"
-)
-
-func combineHashes(left, right []byte) []byte {
-	h := sha256.New()
-	h.Write(left)
-	h.Write(right)
-	return h.Sum(nil)
-}
 
-func VerifyBPTProof(start []byte, entries []interface{}, anchor []byte) bool {
-	current := start
-	
-	for _, entry := range entries {
-		e := entry.(map[string]interface{})
-		hashStr := e["hash"].(string)
-		hash, _ := hex.DecodeString(hashStr)
-		
-		// Check if this entry is from the right
-		isRight := false
-		if r, ok := e["right"]; ok {
-			isRight = r.(bool)
-		}
-		
-		// Combine hashes based on position
-		if isRight {
-			current = combineHashes(current, hash)
-		} else {
-			current = combineHashes(hash, current)
-		}
-	}
-	
-	return bytes.Equal(current, anchor)
-}
+	"github.com/certen/certen-protocol/services/validator/accumulate-lite-client-2/liteclient/verifier"
+)
 
 func main() {
 	fmt.Println("================================================================================")
@@ -100,27 +68,27 @@ func main() {
 				fmt.Println("\n✅ BPT Proof Found!")
 				
 				// Extract proof components
-				startStr := receipt["start"].(string)
-				anchorStr := receipt["anchor"].(string)
-				entries := receipt["entries"].([]interface{})
-				
-				start, _ := hex.DecodeString(startStr)
-				anchor, _ := hex.DecodeString(anchorStr)
-				
-				fmt.Printf("  • Start (Account State): %s\n", startStr[:16]+"...")
-				fmt.Printf("  • Anchor (BPT Root):     %s\n", anchorStr[:16]+"...")
-				fmt.Printf("  • Proof Length:          %d entries\n", len(entries))
-				
-				if localBlock, ok := receipt["localBlock"]; ok {
-					fmt.Printf("  • Block Height:          %.0f\n", localBlock.(float64))
+				receiptJSON, _ := json.Marshal(receipt)
+				bpt, err := verifier.ParseBPTReceipt(receiptJSON)
+				if err != nil {
+					fmt.Printf("❌ Invalid receipt: %v\n", err)
+					continue
+				}
+
+				fmt.Printf("  • Start (Account State): %x...\n", bpt.Start[:min(8, len(bpt.Start))])
+				fmt.Printf("  • Anchor (BPT Root):     %x...\n", bpt.Anchor[:min(8, len(bpt.Anchor))])
+				fmt.Printf("  • Proof Length:          %d entries\n", len(bpt.Entries))
+
+				if bpt.LocalBlock > 0 {
+					fmt.Printf("  • Block Height:          %d\n", bpt.LocalBlock)
 				}
-				if localBlockTime, ok := receipt["localBlockTime"]; ok {
-					fmt.Printf("  • Block Time:            %s\n", localBlockTime)
+				if bpt.LocalBlockTime != "" {
+					fmt.Printf("  • Block Time:            %s\n", bpt.LocalBlockTime)
 				}
 				
 				// Verify the proof
 				fmt.Print("\n🔍 Verifying Merkle Proof... ")
-				if VerifyBPTProof(start, entries, anchor) {
+				if bpt.Verify() {
 					fmt.Println("✅ VALID!")
 					fmt.Println("  The account state is cryptographically proven to exist in the BPT")
 					successCount++
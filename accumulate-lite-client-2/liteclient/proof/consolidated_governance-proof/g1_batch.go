@@ -0,0 +1,258 @@
+// Copyright 2025 The Accumulate Authors
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CERTEN Governance Proof - G1 Batch Proving
+// This file implements G1 proofs for many (account, txhash) pairs in one call
+// Items run concurrently over one shared client; identical queries (key page main chain,
+// signature chain counts, ...) are sent once per batch and their responses shared
+
+// DefaultG1BatchConcurrency bounds how many items of a batch are proven at once
+const DefaultG1BatchConcurrency = 8
+
+// G1BatchItem identifies one transaction of a batch
+type G1BatchItem struct {
+	Account string `json:"account"`            // Account URL
+	TxHash  string `json:"txhash"`             // Transaction hash
+	KeyPage string `json:"key_page,omitempty"` // Overrides the batch key page when set
+}
+
+// G1BatchResult is the outcome of one batch item
+type G1BatchResult struct {
+	Item   G1BatchItem `json:"item"`
+	Result *G1Result   `json:"result,omitempty"`
+	Err    error       `json:"-"`
+	Error  string      `json:"error,omitempty"` // Err as text for JSON output
+}
+
+// G1BatchStats reports how many queries the batch shared
+type G1BatchStats struct {
+	Items         int           `json:"items"`
+	Failed        int           `json:"failed"`
+	Queries       int64         `json:"queries"`        // Queries sent to the underlying client
+	SharedQueries int64         `json:"shared_queries"` // Queries answered from another item's query
+	Duration      time.Duration `json:"duration"`
+}
+
+// G1BatchOutput is the result of a --batch run of the CLI
+type G1BatchOutput struct {
+	Results []G1BatchResult `json:"results"`
+	Stats   G1BatchStats    `json:"stats"`
+}
+
+// G1BatchProver proves G1 for many transactions sharing one RPC client
+type G1BatchProver struct {
+	client       RPCClientInterface
+	workDir      string
+	sigbytesPath string
+	concurrency  int
+}
+
+// NewG1BatchProver creates a batch prover. client is normally a CachedRPCClient;
+// each item writes its artifacts under workDir/batch_<index>.
+func NewG1BatchProver(client RPCClientInterface, workDir string, sigbytesPath string, concurrency int) *G1BatchProver {
+	if concurrency <= 0 {
+		concurrency = DefaultG1BatchConcurrency
+	}
+	return &G1BatchProver{
+		client:       client,
+		workDir:      workDir,
+		sigbytesPath: sigbytesPath,
+		concurrency:  concurrency,
+	}
+}
+
+// ProveG1Batch proves every item using template for the shared request fields (key
+// page, signing domain, chain, endpoint). Results are returned in input order; a
+// failed item carries its error and does not stop the rest of the batch.
+func (b *G1BatchProver) ProveG1Batch(ctx context.Context, template G1Request, items []G1BatchItem) ([]G1BatchResult, G1BatchStats) {
+	startTime := time.Now()
+	client := newBatchRPCClient(b.client)
+	results := make([]G1BatchResult, len(items))
+
+	fmt.Printf("[G1-BATCH] Proving %d transactions (concurrency %d)\n", len(items), b.concurrency)
+
+	sem := make(chan struct{}, b.concurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		results[i].Item = item
+
+		wg.Add(1)
+		go func(i int, item G1BatchItem) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i].Err = ctx.Err()
+				return
+			}
+			results[i].Result, results[i].Err = b.proveItem(ctx, client, template, i, item)
+		}(i, item)
+	}
+	wg.Wait()
+
+	stats := G1BatchStats{Items: len(items), Duration: time.Since(startTime)}
+	stats.Queries, stats.SharedQueries = client.Stats()
+	for i := range results {
+		if results[i].Err != nil {
+			results[i].Error = results[i].Err.Error()
+			stats.Failed++
+		}
+	}
+
+	fmt.Printf("[G1-BATCH] Batch complete in %v: %d/%d proven, %d queries sent, %d shared\n",
+		stats.Duration, stats.Items-stats.Failed, stats.Items, stats.Queries, stats.SharedQueries)
+
+	return results, stats
+}
+
+// LoadG1BatchItems reads a batch file: a JSON array of {account, txhash, key_page}
+func LoadG1BatchItems(path string) ([]G1BatchItem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %v", err)
+	}
+
+	var items []G1BatchItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse batch file: %v", err)
+	}
+	if len(items) == 0 {
+		return nil, ValidationError{Msg: "batch file contains no items"}
+	}
+
+	return items, nil
+}
+
+// proveItem proves one batch item with its own artifact directory
+func (b *G1BatchProver) proveItem(ctx context.Context, client RPCClientInterface, template G1Request, index int, item G1BatchItem) (*G1Result, error) {
+	if item.Account == "" || item.TxHash == "" {
+		return nil, ValidationError{Msg: "batch item requires an account and a transaction hash"}
+	}
+
+	workDir := filepath.Join(b.workDir, fmt.Sprintf("batch_%03d", index))
+	am, err := NewArtifactManager(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	request := template
+	request.Account = item.Account
+	request.TxHash = item.TxHash
+	request.WorkDir = workDir
+	if item.KeyPage != "" {
+		request.KeyPage = item.KeyPage
+	}
+	if request.KeyPage == "" {
+		return nil, ValidationError{Msg: "batch item requires a key page"}
+	}
+
+	return NewG1Layer(client, am, b.sigbytesPath).ProveG1(ctx, request)
+}
+
+// =============================================================================
+// Batch RPC Client
+// =============================================================================
+
+// batchRPCClient shares query responses across the items of one batch. Unlike
+// CachedRPCClient it also covers QueryRaw, which the artifact manager uses for every
+// proof query, and it coalesces identical queries that are in flight at the same time.
+// It lives only as long as the batch, so every item sees the same chain state.
+type batchRPCClient struct {
+	client  RPCClientInterface
+	mu      sync.Mutex
+	calls   map[string]*batchCall
+	queries int64
+	shared  int64
+}
+
+// batchCall is one query, pending until done is closed
+type batchCall struct {
+	done     chan struct{}
+	response map[string]interface{}
+	raw      []byte
+	err      error
+}
+
+func newBatchRPCClient(client RPCClientInterface) *batchRPCClient {
+	return &batchRPCClient{
+		client: client,
+		calls:  make(map[string]*batchCall),
+	}
+}
+
+// Query performs a query once per batch
+func (c *batchRPCClient) Query(ctx context.Context, scope string, query map[string]interface{}) (map[string]interface{}, error) {
+	call := c.do(ctx, "query", scope, query, func(call *batchCall) {
+		call.response, call.err = c.client.Query(ctx, scope, query)
+	})
+	return call.response, call.err
+}
+
+// QueryRaw performs a raw query once per batch
+func (c *batchRPCClient) QueryRaw(ctx context.Context, scope string, query map[string]interface{}) ([]byte, error) {
+	call := c.do(ctx, "raw", scope, query, func(call *batchCall) {
+		call.raw, call.err = c.client.QueryRaw(ctx, scope, query)
+	})
+	return call.raw, call.err
+}
+
+// GetEndpoint returns the RPC endpoint
+func (c *batchRPCClient) GetEndpoint() string {
+	return c.client.GetEndpoint()
+}
+
+// Stats returns the number of queries sent and the number answered from another call
+func (c *batchRPCClient) Stats() (queries, shared int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queries, c.shared
+}
+
+// do runs fn for the first caller of a query and hands its result to every later
+// caller. Failed queries are forgotten so a later item can retry them.
+func (c *batchRPCClient) do(ctx context.Context, kind, scope string, query map[string]interface{}, fn func(*batchCall)) *batchCall {
+	key := kind + ":" + GetRPCCache().generateCacheKey(scope, query)
+
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.shared++
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call
+		case <-ctx.Done():
+			return &batchCall{err: ctx.Err()}
+		}
+	}
+	call := &batchCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.queries++
+	c.mu.Unlock()
+
+	fn(call)
+
+	if call.err != nil {
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+	}
+	close(call.done)
+	return call
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 The Accumulate Authors
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CERTEN Governance Proof - G3 Layer (Outcome Binding + Cross-Chain Anchor Binding)
+// This file implements G3-level governance proofs
+// G3 includes G2 and additionally binds the target-chain anchor transaction back to the
+// Accumulate outcome, so a single proof covers authorization, outcome and anchoring
+
+// crossChainBindingDomain separates G3 binding hashes from other CERTEN hashes
+const crossChainBindingDomain = "CERTEN_G3_CROSS_CHAIN_BINDING_V1"
+
+// =============================================================================
+// G3 Proof Layer
+// =============================================================================
+
+// G3Layer implements G3 governance proofs (Outcome Binding + Cross-Chain Anchor Binding)
+type G3Layer struct {
+	g2Layer    *G2Layer
+	httpClient *http.Client
+}
+
+// NewG3Layer creates a new G3 proof layer
+func NewG3Layer(client RPCClientInterface, artifactManager *ArtifactManager, sigbytesPath string, goModDir string, goVerifyPath string) *G3Layer {
+	return &G3Layer{
+		g2Layer:    NewG2Layer(client, artifactManager, sigbytesPath, goModDir, goVerifyPath),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ProveG3 generates G3 proof for outcome binding plus cross-chain anchor binding
+func (g3 *G3Layer) ProveG3(ctx context.Context, request G3Request) (*G3Result, error) {
+	fmt.Printf("[G3] Starting G3 proof generation\n")
+
+	if err := g3.VerifyG3Prerequisites(request); err != nil {
+		return nil, err
+	}
+
+	// Step 1: Generate G2 proof as foundation
+	g2Result, err := g3.g2Layer.ProveG2(ctx, request.G2Request)
+	if err != nil {
+		return nil, fmt.Errorf("G2 proof failed: %v", err)
+	}
+	if !g2Result.G2ProofComplete {
+		return nil, ValidationError{Msg: "G3 requires a complete G2 outcome binding"}
+	}
+
+	fmt.Printf("[G3] G2 foundation established\n")
+
+	// Step 2: Bind the Accumulate outcome to the anchor transaction
+	binding, err := BuildCrossChainBinding(g2Result, request.TargetChain, request.AnchorTxHash, request.AnchorBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Check the anchor transaction on the target chain when an RPC is configured
+	anchorConfigFailure := request.TargetRPC == nil || *request.TargetRPC == ""
+	if anchorConfigFailure {
+		binding.AnchorVerification = "target chain RPC not configured"
+	} else {
+		if err := g3.verifyAnchorReceipt(ctx, *request.TargetRPC, binding.AnchorTxHash, binding.AnchorBlockNumber); err != nil {
+			return nil, ValidationError{Msg: fmt.Sprintf("G3 anchor verification failed: %v", err)}
+		}
+		binding.AnchorVerified = true
+		binding.AnchorVerification = "receipt status and block confirmed via target chain RPC"
+	}
+
+	result := &G3Result{
+		G2Result:          *g2Result,
+		CrossChainBinding: *binding,
+		G3ProofComplete:   true,
+	}
+
+	if anchorConfigFailure {
+		fmt.Printf("[G3] [WARNING] Anchor not checked on %s (no target chain RPC configured)\n", binding.TargetChain)
+	}
+	fmt.Printf("[G3] G3 proof complete:\n")
+	fmt.Printf("[G3]   Target chain: %s\n", binding.TargetChain)
+	fmt.Printf("[G3]   Anchor tx: %s (block %d)\n", binding.AnchorTxHash, binding.AnchorBlockNumber)
+	fmt.Printf("[G3]   Anchor verified: %t\n", binding.AnchorVerified)
+	fmt.Printf("[G3]   Binding hash: %s\n", binding.BindingHash)
+
+	return result, nil
+}
+
+// VerifyG3Prerequisites checks if G3 proof can be attempted
+func (g3 *G3Layer) VerifyG3Prerequisites(request G3Request) error {
+	if request.KeyPage == "" {
+		return ValidationError{Msg: "G3 proof requires valid key page"}
+	}
+	if request.TargetChain == "" {
+		return ValidationError{Msg: "G3 proof requires a target chain"}
+	}
+	if request.AnchorTxHash == "" {
+		return ValidationError{Msg: "G3 proof requires an anchor transaction hash"}
+	}
+	if request.AnchorBlockNumber <= 0 {
+		return ValidationError{Msg: "G3 proof requires a positive anchor block number"}
+	}
+	return nil
+}
+
+// BuildCrossChainBinding binds a G2 outcome to a target-chain anchor transaction
+func BuildCrossChainBinding(g2Result *G2Result, targetChain, anchorTxHash string, anchorBlockNumber int64) (*CrossChainBinding, error) {
+	hv := HexValidator{}
+
+	accumTxHash, err := hv.RequireHex32(g2Result.TxHash, "accumulate tx hash")
+	if err != nil {
+		return nil, err
+	}
+	anchorTx, err := hv.RequireHex32(anchorTxHash, "anchor tx hash")
+	if err != nil {
+		return nil, err
+	}
+
+	binding := &CrossChainBinding{
+		AccumTxHash:       accumTxHash,
+		ExecWitness:       strings.ToLower(g2Result.ExecWitness),
+		TargetChain:       strings.ToLower(targetChain),
+		AnchorTxHash:      anchorTx,
+		AnchorBlockNumber: anchorBlockNumber,
+	}
+	binding.BindingHash = ComputeCrossChainBindingHash(binding.AccumTxHash, binding.TargetChain, binding.AnchorTxHash, binding.AnchorBlockNumber)
+	return binding, nil
+}
+
+// ComputeCrossChainBindingHash computes the G3 binding hash:
+// SHA256(domain || accumTxHash || len(targetChain) || targetChain || anchorTxHash || blockNumber)
+// Hashes are raw 32-byte values; the length and block number are big-endian uint64.
+func ComputeCrossChainBindingHash(accumTxHash, targetChain, anchorTxHash string, anchorBlockNumber int64) string {
+	accum, _ := hex.DecodeString(accumTxHash)
+	anchor, _ := hex.DecodeString(anchorTxHash)
+
+	h := sha256.New()
+	h.Write([]byte(crossChainBindingDomain))
+	h.Write(accum)
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(targetChain)))
+	h.Write(n[:])
+	h.Write([]byte(targetChain))
+	h.Write(anchor)
+	binary.BigEndian.PutUint64(n[:], uint64(anchorBlockNumber))
+	h.Write(n[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// verifyAnchorReceipt checks the anchor transaction succeeded in the expected block
+// using eth_getTransactionReceipt on an EVM JSON-RPC endpoint
+func (g3 *G3Layer) verifyAnchorReceipt(ctx context.Context, endpoint, anchorTxHash string, anchorBlockNumber int64) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_getTransactionReceipt",
+		"params":  []string{"0x" + anchorTxHash},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g3.httpClient.Do(req)
+	if err != nil {
+		return RPCError{Msg: fmt.Sprintf("target chain RPC failed: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return RPCError{Msg: fmt.Sprintf("failed to read target chain response: %v", err)}
+	}
+
+	var rpcResp struct {
+		Result *anchorReceipt `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return RPCError{Msg: fmt.Sprintf("invalid target chain response: %v", err)}
+	}
+	if rpcResp.Error != nil {
+		return RPCError{Msg: fmt.Sprintf("target chain RPC error: %s", rpcResp.Error.Message)}
+	}
+	return checkAnchorReceipt(rpcResp.Result, anchorBlockNumber)
+}
+
+// anchorReceipt holds the fields G3 reads from an EVM transaction receipt
+type anchorReceipt struct {
+	Status      string `json:"status"`
+	BlockNumber string `json:"blockNumber"`
+}
+
+// checkAnchorReceipt validates the status and block of an anchor receipt
+func checkAnchorReceipt(receipt *anchorReceipt, anchorBlockNumber int64) error {
+	if receipt == nil {
+		return fmt.Errorf("anchor transaction not found (not mined yet?)")
+	}
+	if receipt.Status != "0x1" {
+		return fmt.Errorf("anchor transaction reverted (status %s)", receipt.Status)
+	}
+	block, err := strconv.ParseInt(strings.TrimPrefix(receipt.BlockNumber, "0x"), 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid receipt block number %q", receipt.BlockNumber)
+	}
+	if block != anchorBlockNumber {
+		return fmt.Errorf("anchor transaction mined in block %d, expected %d", block, anchorBlockNumber)
+	}
+	return nil
+}
@@ -26,11 +26,13 @@ const (
 
 USAGE:
   govproof [OPTIONS] <account> <txhash>
+  govproof --level G1 --batch <file> [OPTIONS]
 
 PROOF LEVELS:
   G0  Inclusion and Finality Only (No Governance)
   G1  Governance Correctness (Default)
   G2  Governance Correctness + Outcome Binding
+  G3  Outcome Binding + Cross-Chain Anchor Binding
 
 EXAMPLES:
   # G0 proof (inclusion only)
@@ -39,9 +41,15 @@ EXAMPLES:
   # G1 proof (governance correctness)
   govproof --level G1 --keypage acc://example.acme/page/1 acc://example.acme main 1234567890abcdef...
 
+  # G1 proofs for many transactions, sharing queries (file: [{"account", "txhash", "key_page"}])
+  govproof --level G1 --keypage acc://example.acme/page/1 --batch txs.json
+
   # G2 proof (governance + outcome binding)
   govproof --level G2 --keypage acc://example.acme/page/1 --gomoddir ./verifier --goverify ./verify.go acc://example.acme main 1234567890abcdef...
 
+  # G3 proof (outcome bound to the Ethereum anchor transaction)
+  govproof --level G3 --keypage acc://example.acme/page/1 --target-chain ethereum --anchor-tx 0xabcdef... --anchor-block 19000000 --target-rpc https://eth.example acc://example.acme main 1234567890abcdef...
+
 OPTIONS:`
 )
 
@@ -59,6 +67,10 @@ type CLIConfig struct {
 	KeyPage       string
 	SigningDomain string
 
+	// G1 batch options
+	BatchFile        string // JSON array of G1BatchItem; replaces <account> <txhash>
+	BatchConcurrency int
+
 	// G2+ options
 	GoModDir        string
 	GoVerifyPath    string
@@ -66,6 +78,12 @@ type CLIConfig struct {
 	SigbytesPath    string
 	ExpectEntryHash string
 
+	// G3 options
+	TargetChain       string
+	AnchorTxHash      string
+	AnchorBlockNumber int64
+	TargetRPC         string
+
 	// RPC configuration
 	V3Endpoint string
 	UseHTTP    bool
@@ -160,15 +178,21 @@ func parseFlags() (*CLIConfig, error) {
 	}
 
 	// Define flags
-	flag.StringVar(&config.Level, "level", config.Level, "Proof level: G0, G1, G2")
+	flag.StringVar(&config.Level, "level", config.Level, "Proof level: G0, G1, G2, G3")
 	flag.StringVar(&config.KeyPage, "keypage", "", "Key page URL (required for G1+)")
 	flag.StringVar(&config.KeyPage, "page", "", "Key page URL (alias for --keypage)")
 	flag.StringVar(&config.SigningDomain, "signing-domain", config.SigningDomain, "Signing domain for signature verification")
+	flag.StringVar(&config.BatchFile, "batch", "", "G1 batch file: JSON array of {account, txhash, key_page}")
+	flag.IntVar(&config.BatchConcurrency, "batch-concurrency", DefaultG1BatchConcurrency, "Batch items proven concurrently")
 	flag.StringVar(&config.GoModDir, "gomoddir", "", "Go module directory for G2 verifier")
 	flag.StringVar(&config.GoVerifyPath, "goverify", "", "Path to Go verifier tool/source (deprecated, use --txhash)")
 	flag.StringVar(&config.TxHashPath, "txhash", "", "Path to txhash tool for G2 payload verification")
 	flag.StringVar(&config.SigbytesPath, "sigbytes", "", "Path to sigbytes tool")
 	flag.StringVar(&config.ExpectEntryHash, "expect-entry", "", "Expected entry hash for effect verification")
+	flag.StringVar(&config.TargetChain, "target-chain", "ethereum", "Anchor chain for G3 cross-chain binding")
+	flag.StringVar(&config.AnchorTxHash, "anchor-tx", "", "Anchor transaction hash on the target chain (required for G3)")
+	flag.Int64Var(&config.AnchorBlockNumber, "anchor-block", 0, "Block number of the anchor transaction (required for G3)")
+	flag.StringVar(&config.TargetRPC, "target-rpc", "", "Target chain JSON-RPC endpoint for G3 anchor verification")
 	flag.StringVar(&config.V3Endpoint, "endpoint", config.V3Endpoint, "Accumulate v3 RPC endpoint")
 	flag.StringVar(&config.TestNetwork, "v3", "", "Test network: devnet, testnet, mainnet")
 	flag.BoolVar(&config.UseHTTP, "http", config.UseHTTP, "Use HTTP client")
@@ -214,6 +238,11 @@ func parseFlags() (*CLIConfig, error) {
 		if config.TestKeyPage != "" {
 			config.KeyPage = config.TestKeyPage
 		}
+	} else if config.BatchFile != "" {
+		// Batch mode: accounts and hashes come from the batch file
+		if len(flag.Args()) > 0 {
+			return nil, fmt.Errorf("--batch does not take <account> <txhash> arguments")
+		}
 	} else {
 		// Normal mode: parse positional arguments
 		args := flag.Args()
@@ -250,16 +279,31 @@ func parseFlags() (*CLIConfig, error) {
 func validateConfig(config *CLIConfig) error {
 	// Validate proof level
 	level := strings.ToUpper(config.Level)
-	if level != "G0" && level != "G1" && level != "G2" {
-		return fmt.Errorf("invalid proof level: %s (must be G0, G1, or G2)", config.Level)
+	if level != "G0" && level != "G1" && level != "G2" && level != "G3" {
+		return fmt.Errorf("invalid proof level: %s (must be G0, G1, G2, or G3)", config.Level)
 	}
 	config.Level = level
 
-	// Validate G1+ requirements
-	if (level == "G1" || level == "G2") && config.KeyPage == "" {
+	// Validate G1+ requirements (batch items may carry their own key page)
+	if level != "G0" && config.KeyPage == "" && config.BatchFile == "" {
 		return fmt.Errorf("G1+ proofs require --keypage")
 	}
 
+	// Validate batch requirements
+	if config.BatchFile != "" && level != "G1" {
+		return fmt.Errorf("--batch is only supported for G1 proofs")
+	}
+
+	// Validate G3 requirements
+	if level == "G3" {
+		if config.AnchorTxHash == "" {
+			return fmt.Errorf("G3 proofs require --anchor-tx")
+		}
+		if config.AnchorBlockNumber <= 0 {
+			return fmt.Errorf("G3 proofs require a positive --anchor-block")
+		}
+	}
+
 	// Validate working directory
 	if config.WorkDir == "" {
 		wd, err := os.Getwd()
@@ -306,9 +350,15 @@ func runGovernanceProof(config *CLIConfig) error {
 	case "G0":
 		result, proofErr = generateG0Proof(ctx, config, rpcClient, artifactManager)
 	case "G1":
-		result, proofErr = generateG1Proof(ctx, config, rpcClient, artifactManager)
+		if config.BatchFile != "" {
+			result, proofErr = generateG1BatchProof(ctx, config, rpcClient)
+		} else {
+			result, proofErr = generateG1Proof(ctx, config, rpcClient, artifactManager)
+		}
 	case "G2":
 		result, proofErr = generateG2Proof(ctx, config, rpcClient, artifactManager)
+	case "G3":
+		result, proofErr = generateG3Proof(ctx, config, rpcClient, artifactManager)
 	default:
 		return fmt.Errorf("unsupported proof level: %s", config.Level)
 	}
@@ -414,15 +464,91 @@ func generateG1Proof(ctx context.Context, config *CLIConfig, client RPCClientInt
 	return result, nil
 }
 
+// generateG1BatchProof generates G1 proofs for every transaction of the batch file.
+// Items share one batch client, so key page and signature chain queries common to
+// several transactions are sent once.
+func generateG1BatchProof(ctx context.Context, config *CLIConfig, client RPCClientInterface) (*G1BatchOutput, error) {
+	items, err := LoadG1BatchItems(config.BatchFile)
+	if err != nil {
+		return nil, err
+	}
+
+	template := G1Request{
+		G0Request: G0Request{
+			Chain:      config.Chain,
+			V3Endpoint: config.V3Endpoint,
+		},
+		KeyPage:       config.KeyPage,
+		SigningDomain: config.SigningDomain,
+	}
+
+	prover := NewG1BatchProver(client, config.WorkDir, config.SigbytesPath, config.BatchConcurrency)
+	results, stats := prover.ProveG1Batch(ctx, template, items)
+
+	return &G1BatchOutput{Results: results, Stats: stats}, nil
+}
+
 // generateG2Proof generates G2 proof (Governance + Outcome Binding)
 func generateG2Proof(ctx context.Context, config *CLIConfig, client RPCClientInterface, am *ArtifactManager) (*G2Result, error) {
-	// Use TxHashPath for G2 payload verification, fallback to GoVerifyPath for backwards compatibility
-	txHashToolPath := config.TxHashPath
-	if txHashToolPath == "" {
-		txHashToolPath = config.GoVerifyPath
+	g2Layer := NewG2Layer(client, am, config.SigbytesPath, config.GoModDir, txHashToolPath(config))
+
+	result, err := g2Layer.ProveG2(ctx, buildG2Request(config))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Verbose {
+		fmt.Printf("[G2] Payload verified: %t\n", result.PayloadVerified)
+		fmt.Printf("[G2] Effect verified: %t\n", result.EffectVerified)
+		fmt.Printf("[G2] G2 complete: %t\n", result.G2ProofComplete)
+		fmt.Printf("[G2] Security level: %s\n", result.SecurityLevel)
 	}
-	g2Layer := NewG2Layer(client, am, config.SigbytesPath, config.GoModDir, txHashToolPath)
 
+	return result, nil
+}
+
+// generateG3Proof generates G3 proof (Outcome Binding + Cross-Chain Anchor Binding)
+func generateG3Proof(ctx context.Context, config *CLIConfig, client RPCClientInterface, am *ArtifactManager) (*G3Result, error) {
+	g3Layer := NewG3Layer(client, am, config.SigbytesPath, config.GoModDir, txHashToolPath(config))
+
+	var targetRPC *string
+	if config.TargetRPC != "" {
+		targetRPC = &config.TargetRPC
+	}
+
+	request := G3Request{
+		G2Request:         buildG2Request(config),
+		TargetChain:       config.TargetChain,
+		AnchorTxHash:      config.AnchorTxHash,
+		AnchorBlockNumber: config.AnchorBlockNumber,
+		TargetRPC:         targetRPC,
+	}
+
+	result, err := g3Layer.ProveG3(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Verbose {
+		fmt.Printf("[G3] Anchor verified: %t\n", result.CrossChainBinding.AnchorVerified)
+		fmt.Printf("[G3] Binding hash: %s\n", result.CrossChainBinding.BindingHash)
+		fmt.Printf("[G3] G3 complete: %t\n", result.G3ProofComplete)
+	}
+
+	return result, nil
+}
+
+// txHashToolPath returns the txhash tool for G2+ payload verification, falling back
+// to GoVerifyPath for backwards compatibility
+func txHashToolPath(config *CLIConfig) string {
+	if config.TxHashPath != "" {
+		return config.TxHashPath
+	}
+	return config.GoVerifyPath
+}
+
+// buildG2Request builds the G2 request shared by G2 and G3 proofs
+func buildG2Request(config *CLIConfig) G2Request {
 	var expectEntryHash *string
 	if config.ExpectEntryHash != "" {
 		expectEntryHash = &config.ExpectEntryHash
@@ -438,7 +564,7 @@ func generateG2Proof(ctx context.Context, config *CLIConfig, client RPCClientInt
 		sigbytesPath = &config.SigbytesPath
 	}
 
-	request := G2Request{
+	return G2Request{
 		G1Request: G1Request{
 			G0Request: G0Request{
 				Account:    config.Account,
@@ -454,20 +580,6 @@ func generateG2Proof(ctx context.Context, config *CLIConfig, client RPCClientInt
 		SigbytesPath:    sigbytesPath,
 		ExpectEntryHash: expectEntryHash,
 	}
-
-	result, err := g2Layer.ProveG2(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	if config.Verbose {
-		fmt.Printf("[G2] Payload verified: %t\n", result.PayloadVerified)
-		fmt.Printf("[G2] Effect verified: %t\n", result.EffectVerified)
-		fmt.Printf("[G2] G2 complete: %t\n", result.G2ProofComplete)
-		fmt.Printf("[G2] Security level: %s\n", result.SecurityLevel)
-	}
-
-	return result, nil
 }
 
 // outputResult outputs the proof result
@@ -486,8 +598,12 @@ func outputResult(config *CLIConfig, result interface{}) error {
 			printG0Result(config, r)
 		case *G1Result:
 			printG1Result(config, r)
+		case *G1BatchOutput:
+			printG1BatchOutput(config, r)
 		case *G2Result:
 			printG2Result(config, r)
+		case *G3Result:
+			printG3Result(config, r)
 		default:
 			return fmt.Errorf("unknown result type: %T", result)
 		}
@@ -535,6 +651,24 @@ func printG1Result(config *CLIConfig, result *G1Result) {
 	}
 }
 
+// printG1BatchOutput prints a G1 batch summary in human-readable format
+func printG1BatchOutput(config *CLIConfig, output *G1BatchOutput) {
+	if !config.Quiet {
+		fmt.Printf("\n=== G1 BATCH RESULT ===\n")
+		for i, item := range output.Results {
+			if item.Err != nil {
+				fmt.Printf("[%d] %s %s: FAILED: %v\n", i, item.Item.Account, SafeTruncate(item.Item.TxHash, 16), item.Err)
+				continue
+			}
+			fmt.Printf("[%d] %s %s: authorization verified: %t\n", i, item.Item.Account, SafeTruncate(item.Item.TxHash, 16),
+				item.Result.ThresholdSatisfied && item.Result.TimingValid && item.Result.ExecutionSuccess)
+		}
+		fmt.Printf("Proven: %d/%d\n", output.Stats.Items-output.Stats.Failed, output.Stats.Items)
+		fmt.Printf("Queries Sent: %d (shared: %d)\n", output.Stats.Queries, output.Stats.SharedQueries)
+		fmt.Printf("========================\n")
+	}
+}
+
 // printG2Result prints G2 result in human-readable format
 func printG2Result(config *CLIConfig, result *G2Result) {
 	if !config.Quiet {
@@ -558,6 +692,28 @@ func printG2Result(config *CLIConfig, result *G2Result) {
 	}
 }
 
+// printG3Result prints G3 result in human-readable format
+func printG3Result(config *CLIConfig, result *G3Result) {
+	if !config.Quiet {
+		binding := result.CrossChainBinding
+		fmt.Printf("\n=== G3 PROOF RESULT ===\n")
+		fmt.Printf("Proof Level: G3 (Outcome Binding + Cross-Chain Anchor Binding)\n")
+		fmt.Printf("TX_HASH: %s\n", result.TxHash)
+		fmt.Printf("Principal: %s\n", result.Principal)
+		fmt.Printf("Authorization Verified: %t\n", result.ThresholdSatisfied && result.TimingValid && result.ExecutionSuccess)
+		fmt.Printf("G2 Complete: %t\n", result.G2ProofComplete)
+		fmt.Printf("Security Level: %s\n", result.SecurityLevel)
+		fmt.Printf("Execution Witness: %s\n", binding.ExecWitness)
+		fmt.Printf("Target Chain: %s\n", binding.TargetChain)
+		fmt.Printf("Anchor TX: %s\n", binding.AnchorTxHash)
+		fmt.Printf("Anchor Block: %d\n", binding.AnchorBlockNumber)
+		fmt.Printf("Anchor Verified: %t (%s)\n", binding.AnchorVerified, binding.AnchorVerification)
+		fmt.Printf("Binding Hash: %s\n", binding.BindingHash)
+		fmt.Printf("G3 Complete: %t\n", result.G3ProofComplete)
+		fmt.Printf("========================\n")
+	}
+}
+
 // printVersion prints version information
 func printVersion() {
 	fmt.Printf("%s %s\n", AppName, AppVersion)
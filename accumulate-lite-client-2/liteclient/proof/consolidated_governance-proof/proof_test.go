@@ -11,6 +11,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -555,6 +557,230 @@ func TestErrorHandling(t *testing.T) {
 	})
 }
 
+// TestG3CrossChainBinding tests G3 binding of an outcome to its anchor transaction
+func TestG3CrossChainBinding(t *testing.T) {
+	fixtures := createTestFixtures()
+	anchorTx := "aaaabbbbccccddddeeeeffff0000111122223333444455556666777788889999"
+
+	g2Result := &G2Result{G2ProofComplete: true}
+	g2Result.TxHash = fixtures.SampleTxHash
+	g2Result.ExecWitness = fixtures.SampleExecWitness
+
+	t.Run("BindingHash", func(t *testing.T) {
+		binding, err := BuildCrossChainBinding(g2Result, "Ethereum", "0x"+strings.ToUpper(anchorTx), 19000000)
+		if err != nil {
+			t.Fatalf("Failed to build binding: %v", err)
+		}
+		if binding.AnchorTxHash != anchorTx || binding.TargetChain != "ethereum" {
+			t.Errorf("Expected normalized anchor fields, got %s on %s", binding.AnchorTxHash, binding.TargetChain)
+		}
+		if binding.ExecWitness != fixtures.SampleExecWitness {
+			t.Errorf("Expected execution witness %s, got %s", fixtures.SampleExecWitness, binding.ExecWitness)
+		}
+
+		// Changing any bound field must change the binding hash
+		variants := []string{
+			ComputeCrossChainBindingHash(anchorTx, "ethereum", anchorTx, 19000000),
+			ComputeCrossChainBindingHash(fixtures.SampleTxHash, "base", anchorTx, 19000000),
+			ComputeCrossChainBindingHash(fixtures.SampleTxHash, "ethereum", fixtures.SampleTxHash, 19000000),
+			ComputeCrossChainBindingHash(fixtures.SampleTxHash, "ethereum", anchorTx, 19000001),
+		}
+		for i, variant := range variants {
+			if variant == binding.BindingHash {
+				t.Errorf("Variant %d produced the same binding hash", i)
+			}
+		}
+	})
+
+	t.Run("InvalidAnchorHash", func(t *testing.T) {
+		if _, err := BuildCrossChainBinding(g2Result, "ethereum", "0x1234", 19000000); err == nil {
+			t.Error("Expected error for short anchor tx hash")
+		}
+	})
+
+	t.Run("AnchorReceipt", func(t *testing.T) {
+		if err := checkAnchorReceipt(&anchorReceipt{Status: "0x1", BlockNumber: "0x121eac0"}, 19000000); err != nil {
+			t.Errorf("Expected receipt to verify: %v", err)
+		}
+
+		invalid := map[string]*anchorReceipt{
+			"NotMined":   nil,
+			"Reverted":   {Status: "0x0", BlockNumber: "0x121eac0"},
+			"WrongBlock": {Status: "0x1", BlockNumber: "0x121eac1"},
+		}
+		for name, receipt := range invalid {
+			if err := checkAnchorReceipt(receipt, 19000000); err == nil {
+				t.Errorf("%s: expected receipt check to fail", name)
+			}
+		}
+	})
+
+	t.Run("Prerequisites", func(t *testing.T) {
+		g3 := &G3Layer{}
+		request := G3Request{TargetChain: "ethereum", AnchorTxHash: anchorTx, AnchorBlockNumber: 19000000}
+		if err := g3.VerifyG3Prerequisites(request); err == nil {
+			t.Error("Expected error without key page")
+		}
+		request.KeyPage = fixtures.SampleKeyPage
+		if err := g3.VerifyG3Prerequisites(request); err != nil {
+			t.Errorf("Expected prerequisites to pass: %v", err)
+		}
+		request.AnchorBlockNumber = 0
+		if err := g3.VerifyG3Prerequisites(request); err == nil {
+			t.Error("Expected error without anchor block")
+		}
+	})
+}
+
+// countingRPCClient counts the queries that reach it; every query fails when fail is set
+type countingRPCClient struct {
+	mu    sync.Mutex
+	calls map[string]int
+	fail  bool
+}
+
+func (c *countingRPCClient) count(scope string) error {
+	time.Sleep(10 * time.Millisecond) // Keep concurrent callers in flight together
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[scope]++
+	if c.fail {
+		return RPCError{Msg: "endpoint unavailable"}
+	}
+	return nil
+}
+
+func (c *countingRPCClient) Query(ctx context.Context, scope string, query map[string]interface{}) (map[string]interface{}, error) {
+	if err := c.count(scope); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"result": map[string]interface{}{"count": float64(1)}}, nil
+}
+
+func (c *countingRPCClient) QueryRaw(ctx context.Context, scope string, query map[string]interface{}) ([]byte, error) {
+	if err := c.count(scope); err != nil {
+		return nil, err
+	}
+	return []byte(`{"result":{"count":1}}`), nil
+}
+
+func (c *countingRPCClient) GetEndpoint() string { return "test" }
+
+// TestG1Batch tests batched G1 proving and query sharing across batch items
+func TestG1Batch(t *testing.T) {
+	fixtures := createTestFixtures()
+
+	t.Run("SharedQueries", func(t *testing.T) {
+		base := &countingRPCClient{calls: make(map[string]int)}
+		client := newBatchRPCClient(base)
+		query := map[string]interface{}{"queryType": "chain", "name": "main"}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.QueryRaw(context.Background(), fixtures.SampleKeyPage, query); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if _, err := client.Query(context.Background(), fixtures.SampleKeyPage, query); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		// One raw and one structured query reach the endpoint; the other nine are shared
+		if base.calls[fixtures.SampleKeyPage] != 2 {
+			t.Errorf("Expected 2 queries to reach the endpoint, got %d", base.calls[fixtures.SampleKeyPage])
+		}
+		if queries, shared := client.Stats(); queries != 2 || shared != 9 {
+			t.Errorf("Expected 2 queries and 9 shared, got %d and %d", queries, shared)
+		}
+	})
+
+	t.Run("FailedQueriesRetried", func(t *testing.T) {
+		base := &countingRPCClient{calls: make(map[string]int), fail: true}
+		client := newBatchRPCClient(base)
+		for i := 0; i < 2; i++ {
+			if _, err := client.QueryRaw(context.Background(), fixtures.SampleKeyPage, nil); err == nil {
+				t.Fatal("Expected the query error")
+			}
+		}
+		if base.calls[fixtures.SampleKeyPage] != 2 {
+			t.Errorf("Expected the failed query to be retried, got %d calls", base.calls[fixtures.SampleKeyPage])
+		}
+	})
+
+	t.Run("InputOrderAndItemErrors", func(t *testing.T) {
+		base := &countingRPCClient{calls: make(map[string]int), fail: true}
+		prover := NewG1BatchProver(base, createTestWorkDir(t), "", 2)
+
+		items := []G1BatchItem{
+			{Account: fixtures.SampleAccount, TxHash: fixtures.SampleTxHash},
+			{Account: fixtures.SampleAccount},
+			{Account: fixtures.SampleAccount, TxHash: fixtures.SampleTxHash, KeyPage: fixtures.SampleKeyPage},
+		}
+		template := G1Request{KeyPage: fixtures.SampleKeyPage}
+		template.V3Endpoint = "test"
+
+		results, stats := prover.ProveG1Batch(context.Background(), template, items)
+		if len(results) != len(items) {
+			t.Fatalf("Expected %d results, got %d", len(items), len(results))
+		}
+		for i, result := range results {
+			if result.Item != items[i] {
+				t.Errorf("Result %d is out of order: %+v", i, result.Item)
+			}
+			if result.Err == nil || result.Result != nil {
+				t.Errorf("Result %d: expected an item error", i)
+			}
+		}
+		if _, ok := results[1].Err.(ValidationError); !ok {
+			t.Errorf("Expected a validation error for the item without a hash, got %v", results[1].Err)
+		}
+		if stats.Items != 3 || stats.Failed != 3 {
+			t.Errorf("Unexpected stats: %+v", stats)
+		}
+		if results[1].Error != results[1].Err.Error() {
+			t.Errorf("Expected the item error in JSON output, got %q", results[1].Error)
+		}
+	})
+
+	t.Run("LoadBatchFile", func(t *testing.T) {
+		workDir := createTestWorkDir(t)
+		write := func(name, content string) string {
+			path := workDir + "/" + name
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write batch file: %v", err)
+			}
+			return path
+		}
+
+		items, err := LoadG1BatchItems(write("batch.json", fmt.Sprintf(
+			`[{"account":%q,"txhash":%q},{"account":%q,"txhash":%q,"key_page":%q}]`,
+			fixtures.SampleAccount, fixtures.SampleTxHash, fixtures.SampleAccount, fixtures.SampleTxHash, fixtures.SampleKeyPage)))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(items) != 2 || items[0].KeyPage != "" || items[1].KeyPage != fixtures.SampleKeyPage {
+			t.Errorf("Unexpected items: %+v", items)
+		}
+
+		for name, content := range map[string]string{
+			"empty.json":   `[]`,
+			"invalid.json": `{"account":"acc://x"}`,
+		} {
+			if _, err := LoadG1BatchItems(write(name, content)); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+		if _, err := LoadG1BatchItems(workDir + "/missing.json"); err == nil {
+			t.Error("Expected an error for a missing batch file")
+		}
+	})
+}
+
 // TestEdgeCases tests edge cases and boundary conditions
 func TestEdgeCases(t *testing.T) {
 	t.Run("EmptyInputHandling", func(t *testing.T) {
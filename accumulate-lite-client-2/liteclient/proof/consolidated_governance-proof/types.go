@@ -28,6 +28,8 @@ const (
 	ProofLevelG1 ProofLevel = "G1"
 	// ProofLevelG2 - Governance Correctness + Outcome Binding
 	ProofLevelG2 ProofLevel = "G2"
+	// ProofLevelG3 - Outcome Binding + Cross-Chain Anchor Binding
+	ProofLevelG3 ProofLevel = "G3"
 )
 
 // Specification version for CERTEN compliance
@@ -324,6 +326,28 @@ type G2Result struct {
 	SecurityLevel    string      `json:"security_level"`    // Security level description
 }
 
+// CrossChainBinding ties an Accumulate transaction to the target-chain anchor
+// transaction that committed it, closing the loop from outcome to anchor
+
+type CrossChainBinding struct {
+	AccumTxHash        string `json:"accum_tx_hash"`       // Accumulate TX_HASH proven by G2
+	ExecWitness        string `json:"exec_witness"`        // Execution witness of the outcome
+	TargetChain        string `json:"target_chain"`        // Anchor chain (e.g. "ethereum")
+	AnchorTxHash       string `json:"anchor_tx_hash"`      // Target-chain anchor transaction hash
+	AnchorBlockNumber  int64  `json:"anchor_block_number"` // Block that mined the anchor transaction
+	BindingHash        string `json:"binding_hash"`        // SHA256 over the bound fields
+	AnchorVerified     bool   `json:"anchor_verified"`     // Anchor receipt checked on the target chain
+	AnchorVerification string `json:"anchor_verification"` // How the anchor was (or was not) checked
+}
+
+// G3Result represents G3 proof result (Outcome Binding + Cross-Chain Anchor Binding)
+
+type G3Result struct {
+	G2Result                            // Inherit all G2 results
+	CrossChainBinding CrossChainBinding `json:"cross_chain_binding"` // Accumulate outcome to anchor binding
+	G3ProofComplete   bool              `json:"g3_proof_complete"`   // G3 proof completion flag
+}
+
 // =============================================================================
 // Error Types
 // =============================================================================
@@ -382,4 +406,14 @@ type G2Request struct {
 	GoModDir        *string `json:"gomoddir,omitempty"`        // Go module directory
 	SigbytesPath    *string `json:"sigbytes_path,omitempty"`   // Path to sigbytes tool
 	ExpectEntryHash *string `json:"expect_entry_hash,omitempty"` // Expected entry hash for effect verification
+}
+
+// G3Request represents a request for G3 proof
+
+type G3Request struct {
+	G2Request                 // Inherit G2 request
+	TargetChain       string  `json:"target_chain"`         // Anchor chain name
+	AnchorTxHash      string  `json:"anchor_tx_hash"`       // Target-chain anchor transaction hash
+	AnchorBlockNumber int64   `json:"anchor_block_number"`  // Block that mined the anchor transaction
+	TargetRPC         *string `json:"target_rpc,omitempty"` // EVM JSON-RPC endpoint for the anchor check
 }
\ No newline at end of file
@@ -0,0 +1,112 @@
+// Copyright 2025 The Accumulate Authors
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// BPTReceiptEntry is a single Merkle path node of a BPT receipt
+type BPTReceiptEntry struct {
+	Hash  []byte // Sibling hash
+	Right bool   // Sibling is on the right: SHA256(current || hash)
+}
+
+// BPTReceipt is an account state proof against a BPT root, as returned by
+// a v3 account query with includeReceipt
+type BPTReceipt struct {
+	Start          []byte            // Account state hash
+	Entries        []BPTReceiptEntry // Merkle path from start to anchor
+	Anchor         []byte            // BPT root
+	LocalBlock     uint64            // Block height the receipt was produced at
+	LocalBlockTime string            // Block time the receipt was produced at
+}
+
+// bptReceiptJSON is the wire format of a receipt (hex-encoded hashes)
+type bptReceiptJSON struct {
+	Start   string `json:"start"`
+	Anchor  string `json:"anchor"`
+	Entries []struct {
+		Hash  string `json:"hash"`
+		Right bool   `json:"right"`
+	} `json:"entries"`
+	LocalBlock     uint64 `json:"localBlock"`
+	LocalBlockTime string `json:"localBlockTime"`
+}
+
+// ParseBPTReceipt decodes a receipt from its JSON wire format
+func ParseBPTReceipt(data []byte) (*BPTReceipt, error) {
+	var raw bptReceiptJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse receipt: %w", err)
+	}
+
+	start, err := hex.DecodeString(raw.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start hash: %w", err)
+	}
+	anchor, err := hex.DecodeString(raw.Anchor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid anchor hash: %w", err)
+	}
+
+	entries := make([]BPTReceiptEntry, 0, len(raw.Entries))
+	for i, e := range raw.Entries {
+		hash, err := hex.DecodeString(e.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash in entry %d: %w", i, err)
+		}
+		entries = append(entries, BPTReceiptEntry{Hash: hash, Right: e.Right})
+	}
+
+	return &BPTReceipt{
+		Start:          start,
+		Entries:        entries,
+		Anchor:         anchor,
+		LocalBlock:     raw.LocalBlock,
+		LocalBlockTime: raw.LocalBlockTime,
+	}, nil
+}
+
+// Verify reports whether the receipt's path leads from its start to its anchor
+func (r *BPTReceipt) Verify() bool {
+	return VerifyBPTProof(r.Start, r.Entries, r.Anchor)
+}
+
+// CombineHashes returns SHA256(left || right)
+func CombineHashes(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// ComputeBPTRoot applies a Merkle path to a start hash and returns the resulting root
+func ComputeBPTRoot(start []byte, entries []BPTReceiptEntry) []byte {
+	current := start
+	for _, entry := range entries {
+		if entry.Right {
+			current = CombineHashes(current, entry.Hash)
+		} else {
+			current = CombineHashes(entry.Hash, current)
+		}
+	}
+	return current
+}
+
+// VerifyBPTProof reports whether the Merkle path leads from start to anchor.
+// An empty path is valid only when start equals anchor; empty hashes never verify.
+func VerifyBPTProof(start []byte, entries []BPTReceiptEntry, anchor []byte) bool {
+	if len(start) == 0 || len(anchor) == 0 {
+		return false
+	}
+	return bytes.Equal(ComputeBPTRoot(start, entries), anchor)
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The Accumulate Authors
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+// bpt_test.go
+//
+// Tests for BPT receipt verification against recorded receipt fixtures.
+// Fixtures live in testdata/bpt and use the v3 receipt wire format.
+
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadBPTFixture reads and parses a receipt fixture from testdata/bpt
+func loadBPTFixture(t *testing.T, name string) *BPTReceipt {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "bpt", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+	receipt, err := ParseBPTReceipt(data)
+	if err != nil {
+		t.Fatalf("Failed to parse fixture %s: %v", name, err)
+	}
+	return receipt
+}
+
+// TestBPTProofFixtures verifies each recorded fixture against its expected outcome
+func TestBPTProofFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		valid   bool
+	}{
+		{"valid.json", true},
+		{"empty_path.json", true},
+		{"empty_path_mismatch.json", false},
+		{"tampered_entry.json", false},
+		{"tampered_start.json", false},
+		{"wrong_order.json", false},
+		{"flipped_direction.json", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			receipt := loadBPTFixture(t, tt.fixture)
+			if got := receipt.Verify(); got != tt.valid {
+				t.Errorf("Verify() = %v, expected %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+// TestParseBPTReceipt tests decoding of the receipt wire format
+func TestParseBPTReceipt(t *testing.T) {
+	t.Run("Fixture fields", func(t *testing.T) {
+		receipt := loadBPTFixture(t, "valid.json")
+		if len(receipt.Start) != 32 || len(receipt.Anchor) != 32 {
+			t.Errorf("Expected 32-byte start and anchor, got %d and %d", len(receipt.Start), len(receipt.Anchor))
+		}
+		if len(receipt.Entries) != 6 {
+			t.Fatalf("Expected 6 entries, got %d", len(receipt.Entries))
+		}
+		// Entries without a "right" field are left siblings
+		if !receipt.Entries[0].Right || receipt.Entries[1].Right {
+			t.Error("Entry direction flags not decoded correctly")
+		}
+		if receipt.LocalBlock != 41233 {
+			t.Errorf("Expected local block 41233, got %d", receipt.LocalBlock)
+		}
+	})
+
+	t.Run("Invalid hex", func(t *testing.T) {
+		bad := []string{
+			`{"start": "zz", "anchor": "00", "entries": []}`,
+			`{"start": "00", "anchor": "zz", "entries": []}`,
+			`{"start": "00", "anchor": "00", "entries": [{"hash": "0g"}]}`,
+			`not json`,
+		}
+		for _, data := range bad {
+			if _, err := ParseBPTReceipt([]byte(data)); err == nil {
+				t.Errorf("Expected error parsing %q", data)
+			}
+		}
+	})
+}
+
+// TestCombineHashes tests the hash combination order
+func TestCombineHashes(t *testing.T) {
+	left := sha256.Sum256([]byte("left"))
+	right := sha256.Sum256([]byte("right"))
+
+	expected := sha256.Sum256(append(append([]byte{}, left[:]...), right[:]...))
+	if got := CombineHashes(left[:], right[:]); !bytes.Equal(got, expected[:]) {
+		t.Errorf("CombineHashes() = %x, expected %x", got, expected)
+	}
+	if bytes.Equal(CombineHashes(left[:], right[:]), CombineHashes(right[:], left[:])) {
+		t.Error("CombineHashes should not be commutative")
+	}
+}
+
+// TestVerifyBPTProof_EmptyHashes tests that missing start or anchor never verifies
+func TestVerifyBPTProof_EmptyHashes(t *testing.T) {
+	receipt := loadBPTFixture(t, "valid.json")
+
+	if VerifyBPTProof(nil, receipt.Entries, receipt.Anchor) {
+		t.Error("Expected empty start to fail verification")
+	}
+	if VerifyBPTProof(receipt.Start, receipt.Entries, nil) {
+		t.Error("Expected empty anchor to fail verification")
+	}
+	if VerifyBPTProof(nil, nil, nil) {
+		t.Error("Expected empty proof to fail verification")
+	}
+}
+
+// TestVerifyBPTProof_Truncated tests that dropping path entries invalidates the proof
+func TestVerifyBPTProof_Truncated(t *testing.T) {
+	receipt := loadBPTFixture(t, "valid.json")
+
+	for n := 0; n < len(receipt.Entries); n++ {
+		if VerifyBPTProof(receipt.Start, receipt.Entries[:n], receipt.Anchor) {
+			t.Errorf("Expected path truncated to %d entries to fail verification", n)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Certen Protocol
+//
+// Register Validator CLI
+// Registers this validator's BLS public key and voting power on the CertenAnchorV3 contract
+//
+// Reads ETHEREUM_URL, ETH_CHAIN_ID, ETH_PRIVATE_KEY, CERTEN_CONTRACT_ADDRESS, VALIDATOR_ID,
+// COMETBFT_CHAIN_ID and BLS_KEY_PATH like the validator itself, so the registered BLS key
+// is the one the validator signs with. registerValidator is owner-only: ETH_PRIVATE_KEY
+// must belong to the contract owner. Does nothing if the validator is already registered.
+//
+// Usage:
+//   register-validator -voting-power 100 [-validator-address 0x...] [-config validator.yaml]
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/certen/independant-validator/pkg/config"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+	"github.com/certen/independant-validator/pkg/ethereum"
+	"github.com/certen/independant-validator/pkg/execution/contracts"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		votingPower      = flag.Int64("voting-power", 0, "Voting power to register (required)")
+		validatorAddress = flag.String("validator-address", "", "Validator address to register (default: the ETH_PRIVATE_KEY address)")
+		validatorID      = flag.String("validator-id", "", "Validator ID (overrides VALIDATOR_ID env var)")
+		configPath       = flag.String("config", "", "YAML configuration file (environment variables override its values)")
+		timeout          = flag.Duration("timeout", 5*time.Minute, "How long to wait for the transaction to be mined")
+	)
+	flag.Parse()
+
+	if *votingPower <= 0 {
+		return fmt.Errorf("-voting-power must be positive")
+	}
+	cfg, err := config.LoadWithFile(*configPath)
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+	if *validatorID != "" {
+		cfg.ValidatorID = *validatorID
+	}
+	if len(cfg.EthereumEndpoints()) == 0 {
+		return fmt.Errorf("ETHEREUM_URL is required")
+	}
+	if cfg.EthPrivateKey == "" {
+		return fmt.Errorf("ETH_PRIVATE_KEY is required")
+	}
+	if !common.IsHexAddress(cfg.CertenContractAddress) {
+		return fmt.Errorf("CERTEN_CONTRACT_ADDRESS is missing or invalid: %q", cfg.CertenContractAddress)
+	}
+
+	signer, err := ethereum.GetPublicAddress(cfg.EthPrivateKey)
+	if err != nil {
+		return fmt.Errorf("derive signer address: %w", err)
+	}
+	validator := signer
+	if *validatorAddress != "" {
+		if !common.IsHexAddress(*validatorAddress) {
+			return fmt.Errorf("invalid -validator-address %q", *validatorAddress)
+		}
+		validator = common.HexToAddress(*validatorAddress)
+	}
+
+	// Same key path and derivation as the validator at startup
+	blsKeyPath := os.Getenv("BLS_KEY_PATH")
+	if blsKeyPath == "" {
+		blsKeyPath = filepath.Join("data", fmt.Sprintf("bls_key_%s.hex", cfg.ValidatorID))
+	}
+	blsKeyManager, err := bls.InitializeValidatorBLSKey(cfg.ValidatorID, cfg.ChainID, blsKeyPath)
+	if err != nil {
+		return fmt.Errorf("initialize BLS key: %w", err)
+	}
+	blsPublicKey := blsKeyManager.GetPublicKeyBytes()
+
+	client, err := ethereum.NewClientWithFallbacks(cfg.EthereumEndpoints(), cfg.EthChainID)
+	if err != nil {
+		return fmt.Errorf("connect to Ethereum: %w", err)
+	}
+	defer client.Close()
+
+	contract, err := contracts.NewCertenAnchorV3Wrapper(common.HexToAddress(cfg.CertenContractAddress), client.GetClient())
+	if err != nil {
+		return fmt.Errorf("bind CertenAnchorV3: %w", err)
+	}
+	opts, err := client.CreateTransactor(cfg.EthPrivateKey)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	opts.Context = ctx
+
+	fmt.Printf("Validator:     %s (%s)\n", validator.Hex(), cfg.ValidatorID)
+	fmt.Printf("Contract:      %s\n", cfg.CertenContractAddress)
+	fmt.Printf("BLS key:       0x%x (%s)\n", blsPublicKey, blsKeyPath)
+	fmt.Printf("Voting power:  %d\n", *votingPower)
+
+	existing, tx, err := contract.RegisterValidatorIfNeeded(opts, validator, big.NewInt(*votingPower), blsPublicKey)
+	if err != nil {
+		return fmt.Errorf("register validator: %w", err)
+	}
+	if tx == nil {
+		fmt.Printf("Already registered with voting power %s; nothing to do\n", existing.VotingPower)
+		if !bytes.Equal(existing.BLSPublicKey, blsPublicKey) {
+			fmt.Printf("WARNING: the registered BLS key 0x%x differs from this validator's key\n", existing.BLSPublicKey)
+		}
+		return nil
+	}
+
+	fmt.Printf("Submitted:     %s\n", tx.Hash().Hex())
+	receipt, err := contracts.WaitForConfirmation(ctx, tx, client.GetClient())
+	if err != nil {
+		return fmt.Errorf("wait for %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("registerValidator transaction %s reverted in block %d", tx.Hash().Hex(), receipt.BlockNumber)
+	}
+	fmt.Printf("Registered in block %d: %s\n", receipt.BlockNumber, tx.Hash().Hex())
+	return nil
+}
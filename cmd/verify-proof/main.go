@@ -0,0 +1,288 @@
+// Copyright 2025 Certen Protocol
+//
+// Verify Proof CLI
+// Checks a stored Certen proof against the CertenAnchorV3 contract without running a validator
+//
+// Reads a proof in the executeComprehensiveProof request format ({"anchor_id": ..., "proof_bundle": {...}})
+// or a bare proof bundle, in which case its batch_id is the anchor ID. The on-chain anchor ID is
+// derived from the anchor ID and the bundle timestamp like the validator does when submitting,
+// unless -anchor-id gives it directly. Calls verifyCertenProofDetailed and prints the result of
+// each component check, whether the anchor exists and whether its proof was executed.
+//
+// The RPC endpoint and contract default to ETHEREUM_URL and CERTEN_CONTRACT_ADDRESS.
+// Exits with status 2 if the anchor does not exist or a component check fails.
+//
+// Usage:
+//   verify-proof -proof proof.json [-rpc https://...] [-contract 0x...] [-anchor-id 0x...] [-json]
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+	"github.com/certen/independant-validator/pkg/execution/contracts"
+)
+
+// errVerificationFailed is returned when the proof does not verify on-chain
+var errVerificationFailed = errors.New("proof verification failed")
+
+// verifyReport is the outcome of verifying one proof
+type verifyReport struct {
+	Contract      string                          `json:"contract"`
+	AnchorID      string                          `json:"anchor_id"`
+	AnchorExists  bool                            `json:"anchor_exists"`
+	AnchorValid   bool                            `json:"anchor_valid"`
+	ProofExecuted bool                            `json:"proof_executed"`
+	Checks        *contracts.VerificationResultV3 `json:"checks,omitempty"`
+	FailedChecks  []string                        `json:"failed_checks,omitempty"`
+	Verified      bool                            `json:"verified"`
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, errVerificationFailed) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		proofPath    = flag.String("proof", "", "Proof JSON file, or - for stdin (required)")
+		rpcURL       = flag.String("rpc", os.Getenv("ETHEREUM_URL"), "Ethereum JSON-RPC endpoint")
+		contractAddr = flag.String("contract", os.Getenv("CERTEN_CONTRACT_ADDRESS"), "CertenAnchorV3 contract address")
+		anchorIDHex  = flag.String("anchor-id", "", "On-chain anchor ID as 0x-prefixed bytes32 (default: derived from the proof)")
+		jsonOutput   = flag.Bool("json", false, "Print the result as JSON")
+		timeout      = flag.Duration("timeout", 30*time.Second, "Timeout for the contract calls")
+	)
+	flag.Parse()
+
+	if *proofPath == "" {
+		return fmt.Errorf("-proof is required")
+	}
+	if *rpcURL == "" {
+		return fmt.Errorf("-rpc or ETHEREUM_URL is required")
+	}
+	if !common.IsHexAddress(*contractAddr) {
+		return fmt.Errorf("-contract or CERTEN_CONTRACT_ADDRESS is missing or invalid: %q", *contractAddr)
+	}
+
+	data, err := readProof(*proofPath)
+	if err != nil {
+		return err
+	}
+	proofAnchorID, bundle, err := parseProof(data)
+	if err != nil {
+		return err
+	}
+	anchorID, err := onChainAnchorID(proofAnchorID, bundle, *anchorIDHex)
+	if err != nil {
+		return err
+	}
+	proof := bundle.ToContractProof()
+	if proof == nil {
+		return fmt.Errorf("failed to convert proof bundle to contract format")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, *rpcURL)
+	if err != nil {
+		return fmt.Errorf("connect to Ethereum: %w", err)
+	}
+	defer client.Close()
+
+	contract, err := contracts.NewCertenAnchorV3Wrapper(common.HexToAddress(*contractAddr), client)
+	if err != nil {
+		return fmt.Errorf("bind CertenAnchorV3: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	report := &verifyReport{
+		Contract: common.HexToAddress(*contractAddr).Hex(),
+		AnchorID: "0x" + hex.EncodeToString(anchorID[:]),
+	}
+	if report.AnchorExists, err = contract.AnchorExists(opts, anchorID); err != nil {
+		return fmt.Errorf("anchorExists: %w", err)
+	}
+	if report.AnchorExists {
+		stored, err := contract.GetAnchorFull(opts, anchorID)
+		if err != nil {
+			return fmt.Errorf("get anchor: %w", err)
+		}
+		report.AnchorValid = stored.Valid
+		report.ProofExecuted = stored.ProofExecuted
+
+		if report.Checks, err = contract.VerifyProofDetailed(opts, anchorID, toBindingProof(proof)); err != nil {
+			return fmt.Errorf("verifyCertenProofDetailed: %w", err)
+		}
+		report.FailedChecks = failedChecks(report.Checks)
+		report.Verified = len(report.FailedChecks) == 0
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printReport(report)
+	}
+
+	if !report.AnchorExists {
+		return fmt.Errorf("%w: anchor %s does not exist", errVerificationFailed, report.AnchorID)
+	}
+	if !report.Verified {
+		return fmt.Errorf("%w: %s", errVerificationFailed, strings.Join(report.FailedChecks, ", "))
+	}
+	return nil
+}
+
+// readProof reads a proof file, or stdin when path is -
+func readProof(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read proof: %w", err)
+	}
+	return data, nil
+}
+
+// parseProof decodes an executeComprehensiveProof request or a bare proof bundle
+// and returns the anchor ID and proof bundle
+func parseProof(data []byte) (string, *anchor.ProofBundle, error) {
+	var req anchor.ExecuteComprehensiveProofRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return "", nil, fmt.Errorf("decode proof: %w", err)
+	}
+	if req.ProofBundle == nil {
+		// A bare proof bundle is anchored under its batch ID
+		var bundle anchor.ProofBundle
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			return "", nil, fmt.Errorf("decode proof bundle: %w", err)
+		}
+		req = anchor.ExecuteComprehensiveProofRequest{AnchorID: bundle.BatchID, ProofBundle: &bundle}
+	}
+	if req.AnchorID == "" {
+		return "", nil, fmt.Errorf("proof has no anchor_id or batch_id")
+	}
+	return req.AnchorID, req.ProofBundle, nil
+}
+
+// onChainAnchorID returns the anchor ID the validator submits the proof under: the
+// anchor ID hashed with the bundle timestamp, unless override gives it as bytes32
+func onChainAnchorID(anchorID string, bundle *anchor.ProofBundle, override string) ([32]byte, error) {
+	if override != "" {
+		id, err := parseBytes32(override)
+		if err != nil {
+			return id, fmt.Errorf("invalid -anchor-id: %w", err)
+		}
+		return id, nil
+	}
+	return anchor.GenerateBundleIDBytes32(anchorID, bundle.Timestamp.Unix()), nil
+}
+
+// parseBytes32 decodes a 0x-prefixed 32-byte hex string
+func parseBytes32(s string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return out, err
+	}
+	if len(b) != len(out) {
+		return out, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	copy(out[:], b)
+	return out, nil
+}
+
+// toBindingProof converts a contract proof to the generated binding type, which has the same layout
+func toBindingProof(p *anchor.ContractCertenProof) contracts.CertenProofV3 {
+	return contracts.CertenProofV3{
+		TransactionHash: p.TransactionHash,
+		MerkleRoot:      p.MerkleRoot,
+		ProofHashes:     p.ProofHashes,
+		LeafHash:        p.LeafHash,
+		GovernanceProof: contracts.GovernanceProofV3(p.GovernanceProof),
+		BlsProof:        contracts.BLSProofV3(p.BlsProof),
+		Commitments:     contracts.CommitmentV3(p.Commitments),
+		ExpirationTime:  p.ExpirationTime,
+		Metadata:        p.Metadata,
+	}
+}
+
+// failedChecks returns the names of the component checks that did not pass
+func failedChecks(r *contracts.VerificationResultV3) []string {
+	var failed []string
+	for _, c := range componentChecks(r) {
+		if !c.passed {
+			failed = append(failed, c.name)
+		}
+	}
+	return failed
+}
+
+type componentCheck struct {
+	name   string
+	passed bool
+}
+
+// componentChecks lists the checks of verifyCertenProofDetailed in contract order
+func componentChecks(r *contracts.VerificationResultV3) []componentCheck {
+	return []componentCheck{
+		{"merkle", r.MerkleVerified},
+		{"governance", r.GovernanceVerified},
+		{"bls", r.BLSVerified},
+		{"commitment", r.CommitmentVerified},
+		{"timestamp", r.TimestampValid},
+		{"nonce", r.NonceValid},
+	}
+}
+
+func printReport(r *verifyReport) {
+	fmt.Printf("Contract:        %s\n", r.Contract)
+	fmt.Printf("Anchor ID:       %s\n", r.AnchorID)
+	fmt.Printf("Anchor exists:   %t\n", r.AnchorExists)
+	if !r.AnchorExists {
+		fmt.Println("Result:          FAIL (anchor not found)")
+		return
+	}
+	fmt.Printf("Anchor valid:    %t\n", r.AnchorValid)
+	fmt.Printf("Proof executed:  %t\n", r.ProofExecuted)
+	fmt.Println("Checks:")
+	for _, c := range componentChecks(r.Checks) {
+		status := "PASS"
+		if !c.passed {
+			status = "FAIL"
+		}
+		fmt.Printf("  %-12s   %s\n", c.name, status)
+	}
+	if r.Verified {
+		fmt.Println("Result:          PASS")
+	} else {
+		fmt.Printf("Result:          FAIL (%s)\n", strings.Join(r.FailedChecks, ", "))
+	}
+}
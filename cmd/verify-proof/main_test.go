@@ -0,0 +1,89 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the verify-proof CLI
+// Tests proof file parsing and on-chain anchor ID derivation
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+)
+
+func TestParseProof(t *testing.T) {
+	bundle := anchor.ProofBundle{
+		BundleID:  "bundle-1",
+		BatchID:   "batch-1",
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+	}
+	bare, _ := json.Marshal(bundle)
+	wrapped, _ := json.Marshal(anchor.ExecuteComprehensiveProofRequest{AnchorID: "anchor-1", ProofBundle: &bundle})
+	noID, _ := json.Marshal(anchor.ProofBundle{BundleID: "bundle-1"})
+
+	tests := []struct {
+		name     string
+		data     []byte
+		anchorID string
+		wantErr  string
+	}{
+		{name: "request wrapper", data: wrapped, anchorID: "anchor-1"},
+		{name: "bare bundle uses batch id", data: bare, anchorID: "batch-1"},
+		{name: "bare bundle without batch id", data: noID, wantErr: "no anchor_id or batch_id"},
+		{name: "invalid json", data: []byte("{"), wantErr: "decode proof"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anchorID, got, err := parseProof(tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseProof: %v", err)
+			}
+			if anchorID != tt.anchorID {
+				t.Errorf("anchor ID = %q, want %q", anchorID, tt.anchorID)
+			}
+			if got.BundleID != bundle.BundleID || !got.Timestamp.Equal(bundle.Timestamp) {
+				t.Errorf("bundle = %+v, want %+v", got, bundle)
+			}
+		})
+	}
+}
+
+func TestOnChainAnchorID(t *testing.T) {
+	bundle := &anchor.ProofBundle{Timestamp: time.Unix(1700000000, 0)}
+
+	// Derived like the validator does when submitting the proof
+	got, err := onChainAnchorID("anchor-1", bundle, "")
+	if err != nil {
+		t.Fatalf("onChainAnchorID: %v", err)
+	}
+	if want := anchor.GenerateBundleIDBytes32("anchor-1", 1700000000); got != want {
+		t.Errorf("derived ID = %x, want %x", got, want)
+	}
+	if other, _ := onChainAnchorID("anchor-1", &anchor.ProofBundle{Timestamp: time.Unix(1700000001, 0)}, ""); other == got {
+		t.Error("derived ID does not depend on the bundle timestamp")
+	}
+
+	// An explicit -anchor-id wins over the proof
+	var explicit [32]byte
+	explicit[31] = 0x2a
+	got, err = onChainAnchorID("anchor-1", bundle, "0x"+hex.EncodeToString(explicit[:]))
+	if err != nil || got != explicit {
+		t.Errorf("override = %x, %v; want %x", got, err, explicit)
+	}
+
+	for _, bad := range []string{"0x1234", "not-hex"} {
+		if _, err := onChainAnchorID("anchor-1", bundle, bad); err == nil || !strings.Contains(err.Error(), "invalid -anchor-id") {
+			t.Errorf("override %q: err = %v, want invalid -anchor-id", bad, err)
+		}
+	}
+}
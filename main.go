@@ -3,17 +3,18 @@ package main
 import (
     "context"
     "crypto/ed25519"
-    "crypto/rand"
     "crypto/sha256"
     "encoding/hex"
     "encoding/json"
     "flag"
     "fmt"
     "log"
+    "math/big"
     "net/http"
     "os"
     "os/signal"
     "path/filepath"
+    "sort"
     "strings"
     "sync"
     "syscall"
@@ -24,6 +25,7 @@ import (
 
     "github.com/certen/independant-validator/pkg/accumulate"
     "github.com/certen/independant-validator/pkg/anchor"
+    "github.com/certen/independant-validator/pkg/anchor_proof"
     "github.com/certen/independant-validator/pkg/attestation"
     attestationStrategy "github.com/certen/independant-validator/pkg/attestation/strategy"
     "github.com/certen/independant-validator/pkg/batch"
@@ -35,7 +37,9 @@ import (
     "github.com/certen/independant-validator/pkg/execution"
     "github.com/certen/independant-validator/pkg/firestore"
     "github.com/certen/independant-validator/pkg/intent"
+    "github.com/certen/independant-validator/pkg/keys"
     "github.com/certen/independant-validator/pkg/ledger"
+    "github.com/certen/independant-validator/pkg/metrics"
     "github.com/certen/independant-validator/pkg/proof"
     "github.com/certen/independant-validator/pkg/server"
     "github.com/certen/independant-validator/pkg/strategy"
@@ -76,6 +80,26 @@ func (w *LedgerStoreWrapper) LoadIntentLastBlock() (uint64, error) {
     return w.store.LoadIntentLastBlock()
 }
 
+func (w *LedgerStoreWrapper) SaveIntentPartitionLastBlocks(heights map[string]uint64) error {
+    return w.store.SaveIntentPartitionLastBlocks(heights)
+}
+
+func (w *LedgerStoreWrapper) LoadIntentPartitionLastBlocks(partitions []string) (map[string]uint64, error) {
+    return w.store.LoadIntentPartitionLastBlocks(partitions)
+}
+
+func (w *LedgerStoreWrapper) SaveIntentDedupSet(data []byte) error {
+    return w.store.SaveIntentDedupSet(data)
+}
+
+func (w *LedgerStoreWrapper) LoadIntentDedupSet() ([]byte, error) {
+    return w.store.LoadIntentDedupSet()
+}
+
+// version is the validator software version, set at build time with
+// -ldflags "-X main.version=<version>"
+var version = "dev"
+
 // HealthStatus tracks the health of various components for the /health endpoint
 // Per E.2 remediation: Proper degradation handling with explicit status tracking
 // Per F.2 remediation: Enhanced health check with all component tracking
@@ -84,15 +108,34 @@ type HealthStatus struct {
     Phase         string `json:"phase"`
     Consensus     string `json:"consensus"`
     Database      string `json:"database"`       // "connected", "disconnected"
-    Ethereum      string `json:"ethereum"`       // "connected", "disconnected"
+    Ethereum      string `json:"ethereum"`       // "connected", "reconnecting", "disconnected"
     Accumulate    string `json:"accumulate"`     // "connected", "disconnected"
     BatchSystem   string `json:"batch_system"`   // "active", "disabled"
     ProofCycle    string `json:"proof_cycle"`    // "active", "disabled"
+    GovernanceVerifier string `json:"governance_verifier"` // "configured", "not_configured", "unknown"
+    Peers         string `json:"peers"`          // "ok", "below_minimum", "disabled", "unknown"
+    ProofVerification string `json:"proof_verification"` // "ok", "failing", "unknown"
+    ProofVerificationFailures int `json:"proof_verification_failures"` // On-chain failures within the health window
+    AnchorState   string `json:"anchor_state"`   // "consistent", "discrepancies", "unknown"
+    AnchorDiscrepancies int64 `json:"anchor_discrepancies"` // Open anchor record vs on-chain discrepancies
+    ConnectedPeers int   `json:"connected_peers"`
+    MinPeers      int    `json:"min_peers"`
     UptimeSeconds int64  `json:"uptime_seconds"` // Seconds since startup
     startTime     time.Time
+    govVerifier   *anchor.GovernanceVerifierMonitor
+    cycleStats    func() execution.CycleLimiterStats
+    auditTip      func() execution.AuditTip
+    proofFailures func() batch.VerificationFailureStats
+    anchorDiscrepancies func() int64
     mu            sync.RWMutex
 }
 
+// detailedConsensusProgress is the consensus_progress section of /health/detailed
+type detailedConsensusProgress struct {
+    *consensus.ConsensusMetrics
+    Error string `json:"error,omitempty"`
+}
+
 // Global health status - updated during startup and runtime
 var healthStatus = &HealthStatus{
     Status:      "starting",
@@ -103,6 +146,10 @@ var healthStatus = &HealthStatus{
     Accumulate:  "unknown",
     BatchSystem: "unknown",
     ProofCycle:  "unknown",
+    GovernanceVerifier: "unknown",
+    Peers:       "unknown",
+    ProofVerification: "unknown",
+    AnchorState: "unknown",
     startTime:   time.Now(),
 }
 
@@ -141,6 +188,92 @@ func (h *HealthStatus) SetProofCycle(status string) {
     h.updateOverallStatus()
 }
 
+// SetGovernanceVerifier records the on-chain governance verifier status.
+// It is informational: governance proof handling follows GOVERNANCE_VERIFIER_POLICY.
+func (h *HealthStatus) SetGovernanceVerifier(monitor *anchor.GovernanceVerifierMonitor, status string) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.govVerifier = monitor
+    h.GovernanceVerifier = status
+}
+
+// SetPeers records the connected CometBFT peer count against the configured minimum
+// A minimum of 0 leaves peer connectivity out of the overall status
+func (h *HealthStatus) SetPeers(count, minPeers int) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.ConnectedPeers = count
+    h.MinPeers = minPeers
+    if minPeers <= 0 {
+        h.Peers = "disabled"
+    } else if count < minPeers {
+        h.Peers = "below_minimum"
+    } else {
+        h.Peers = "ok"
+    }
+    h.updateOverallStatus()
+}
+
+// SetProofCycleStats registers the source of proof cycle concurrency stats
+func (h *HealthStatus) SetProofCycleStats(stats func() execution.CycleLimiterStats) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.cycleStats = stats
+}
+
+// SetAuditTip registers the source of the proof-cycle audit chain tip
+func (h *HealthStatus) SetAuditTip(tip func() execution.AuditTip) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.auditTip = tip
+}
+
+// SetProofFailureStats registers the source of on-chain proof verification failure stats
+func (h *HealthStatus) SetProofFailureStats(stats func() batch.VerificationFailureStats) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.proofFailures = stats
+    h.refreshProofVerification()
+}
+
+// refreshProofVerification re-reads the failure stats, which age out of the health
+// window without a setter being called; the caller holds mu for writing
+func (h *HealthStatus) refreshProofVerification() {
+    if h.proofFailures == nil {
+        return
+    }
+    h.ProofVerificationFailures = h.proofFailures().Recent
+    if h.ProofVerificationFailures > 0 {
+        h.ProofVerification = "failing"
+    } else {
+        h.ProofVerification = "ok"
+    }
+    h.updateOverallStatus()
+}
+
+// SetAnchorDiscrepancySource registers the source of open anchor state discrepancies
+func (h *HealthStatus) SetAnchorDiscrepancySource(open func() int64) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.anchorDiscrepancies = open
+    h.refreshAnchorState()
+}
+
+// refreshAnchorState re-reads the open discrepancy count, which changes with each
+// reconciliation sweep; the caller holds mu for writing
+func (h *HealthStatus) refreshAnchorState() {
+    if h.anchorDiscrepancies == nil {
+        return
+    }
+    h.AnchorDiscrepancies = h.anchorDiscrepancies()
+    if h.AnchorDiscrepancies > 0 {
+        h.AnchorState = "discrepancies"
+    } else {
+        h.AnchorState = "consistent"
+    }
+    h.updateOverallStatus()
+}
+
 func (h *HealthStatus) updateOverallStatus() {
     // F.2 remediation: Determine overall status based on all component states
     // Critical components: Database, Ethereum, Accumulate
@@ -153,7 +286,8 @@ func (h *HealthStatus) updateOverallStatus() {
     }
 
     // Check for degraded state (non-critical components)
-    if h.Database == "disconnected" || h.BatchSystem == "disabled" || h.ProofCycle == "disabled" {
+    if h.Database == "disconnected" || h.Ethereum == "reconnecting" || h.BatchSystem == "disabled" || h.ProofCycle == "disabled" ||
+       h.Peers == "below_minimum" || h.ProofVerification == "failing" || h.AnchorState == "discrepancies" {
         h.Status = "degraded"
         return
     }
@@ -165,10 +299,32 @@ func (h *HealthStatus) updateOverallStatus() {
     }
 }
 
+// Summary returns the overall status and component states for the identity endpoint
+func (h *HealthStatus) Summary() interface{} {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.refreshProofVerification()
+    h.refreshAnchorState()
+    return map[string]interface{}{
+        "status":         h.Status,
+        "database":       h.Database,
+        "ethereum":       h.Ethereum,
+        "accumulate":     h.Accumulate,
+        "batch_system":   h.BatchSystem,
+        "proof_cycle":    h.ProofCycle,
+        "peers":          h.Peers,
+        "proof_verification": h.ProofVerification,
+        "anchor_state":   h.AnchorState,
+        "uptime_seconds": int64(time.Since(h.startTime).Seconds()),
+    }
+}
+
 func (h *HealthStatus) ToJSON() []byte {
     h.mu.Lock()
     // Update uptime before serializing
     h.UptimeSeconds = int64(time.Since(h.startTime).Seconds())
+    h.refreshProofVerification()
+    h.refreshAnchorState()
     h.mu.Unlock()
 
     h.mu.RLock()
@@ -186,6 +342,7 @@ func main() {
     // Parse CLI flags
     var (
         validatorID = flag.String("validator-id", "", "Validator ID (overrides VALIDATOR_ID env var)")
+        configPath  = flag.String("config", "", "YAML configuration file (environment variables override its values)")
         showHelp    = flag.Bool("help", false, "Show help message")
     )
     flag.Parse()
@@ -199,11 +356,14 @@ func main() {
 
     log.Printf("🚀 Starting Certen BFT Validator with full consensus capabilities...")
 
-    // Load configuration
-    cfg, err := config.Load()
+    // Load configuration: defaults < config file < environment < CLI flags
+    cfg, err := config.LoadWithFile(*configPath)
     if err != nil {
         log.Fatal("Failed to load configuration:", err)
     }
+    if *configPath != "" {
+        log.Printf("📋 Configuration file: %s (environment variables take precedence)", *configPath)
+    }
 
     // LedgerStore is now created and managed within the ABCI application
     // No need for separate initialization here
@@ -220,12 +380,18 @@ func main() {
         return "VALIDATOR_ID env var"
     }())
 
+    // Ordered shutdown: components register stop hooks into stages as they are created
+    shutdown := NewShutdownSequence(cfg.ShutdownStageTimeout, log.New(log.Writer(), "[Shutdown] ", log.LstdFlags))
+    shutdown.SetTimeout(ShutdownFlushBatches, cfg.ShutdownFlushTimeout)
+    shutdown.SetTimeout(ShutdownFinishAnchoring, cfg.ShutdownAnchorTimeout)
+
     // ==========================================================================
     // PHASE 5: Initialize PostgreSQL Database Connection
     // Per Implementation Plan: Wire batch system with real Merkle roots
     // Per E.2 remediation: Proper degradation handling with DatabaseRequired flag
     // ==========================================================================
     log.Println("🗄️ [Phase 5] Connecting to PostgreSQL database...")
+    var dbBreaker *database.CircuitBreaker
     dbClient, err := database.NewClient(cfg, database.WithLogger(
         log.New(log.Writer(), "[Database] ", log.LstdFlags),
     ))
@@ -244,12 +410,43 @@ func main() {
     } else {
         log.Println("✅ [Phase 5] Connected to PostgreSQL database")
         healthStatus.SetDatabase("connected")
+        shutdown.Register(ShutdownCloseDatabase, "postgres", func(ctx context.Context) error {
+            return dbClient.Close()
+        })
 
         // Run migrations
         if err := dbClient.MigrateUp(context.Background()); err != nil {
             log.Printf("⚠️ [Phase 5] Database migration failed: %v", err)
             // Migration failure is a warning, not a fatal error
         }
+
+        // Fail batch operations fast while the database is unreachable, recover automatically
+        if cfg.DBBreakerEnabled {
+            dbBreaker, err = database.NewCircuitBreaker(dbClient, &database.CircuitBreakerConfig{
+                FailureThreshold: cfg.DBBreakerFailures,
+                CheckInterval:    cfg.DBBreakerCheckInterval,
+                PingTimeout:      cfg.DBBreakerPingTimeout,
+                Logger:           log.New(log.Writer(), "[DBBreaker] ", log.LstdFlags),
+            })
+            if err != nil {
+                log.Fatalf("❌ [Phase 5] Invalid database circuit breaker: %v", err)
+            }
+            dbBreaker.SetStateHandler(func(state database.BreakerState) {
+                healthStatus.SetDatabase(string(state))
+                if state == database.BreakerDisconnected {
+                    healthStatus.SetBatchSystem("disabled")
+                } else {
+                    healthStatus.SetBatchSystem("active")
+                }
+            })
+            dbBreaker.Start()
+            shutdown.Register(ShutdownStopTrackers, "database-circuit-breaker", func(ctx context.Context) error {
+                dbBreaker.Stop()
+                return nil
+            })
+            log.Printf("✅ Database circuit breaker enabled (opens after %d failed pings, checked every %s)",
+                cfg.DBBreakerFailures, cfg.DBBreakerCheckInterval)
+        }
     }
 
     // ==========================================================================
@@ -257,7 +454,7 @@ func main() {
     // Per Data Collection & Management Plan: Sync proof cycle progress to Firestore
     // ==========================================================================
     var firestoreClient *firestore.Client
-    var firestoreSyncService *firestore.SyncService
+    var firestoreSyncService firestore.Syncer = firestore.NullSyncService{}
 
     if cfg.FirestoreEnabled {
         log.Println("🔥 [Firestore] Initializing Firestore client for real-time UI sync...")
@@ -275,6 +472,9 @@ func main() {
             log.Printf("   Real-time UI sync DISABLED - web app will not receive status updates")
         } else {
             log.Println("✅ [Firestore] Connected to Firestore")
+            shutdown.Register(ShutdownCloseClients, "firestore", func(ctx context.Context) error {
+                return firestoreClient.Close()
+            })
 
             // Create sync service
             syncCfg := &firestore.SyncServiceConfig{
@@ -283,10 +483,11 @@ func main() {
                 Logger:         log.New(log.Writer(), "[FirestoreSync] ", log.LstdFlags),
                 IntentCacheTTL: 5 * time.Minute,
             }
-            firestoreSyncService, firestoreErr = firestore.NewSyncService(syncCfg)
+            syncService, firestoreErr := firestore.NewSyncService(syncCfg)
             if firestoreErr != nil {
                 log.Printf("⚠️ [Firestore] Failed to create sync service: %v", firestoreErr)
             } else {
+                firestoreSyncService = syncService
                 log.Println("✅ [Firestore] Sync service initialized - will sync proof cycle events")
             }
         }
@@ -308,24 +509,139 @@ func main() {
     }
     healthStatus.SetAccumulate("connected")
     log.Println("✅ Connected to Accumulate network")
+    shutdown.Register(ShutdownCloseClients, "accumulate", func(ctx context.Context) error {
+        return accClient.Close()
+    })
 
     // Initialize Ethereum client
     log.Println("🔗 Connecting to Ethereum network...")
-    ethClient, err := ethereum.NewClient(cfg.EthereumURL, cfg.EthChainID)
+    ethClient, err := ethereum.NewClientWithFallbacks(cfg.EthereumEndpoints(), cfg.EthChainID)
     if err != nil {
         healthStatus.SetEthereum("disconnected")
         log.Fatal("Failed to connect to Ethereum:", err)
     }
+    reconnectPolicy := ethereum.DefaultReconnectPolicy()
+    reconnectPolicy.FailoverThreshold = cfg.EthFailoverThreshold
+    reconnectPolicy.PrimaryRetryInterval = cfg.EthPrimaryRetryInterval
+    if err := ethClient.SetReconnectPolicy(reconnectPolicy); err != nil {
+        log.Fatal("Invalid Ethereum failover policy: ", err)
+    }
+    if endpoints := cfg.EthereumEndpoints(); len(endpoints) > 1 {
+        log.Printf("✅ Ethereum failover enabled: %d endpoints, using %s", len(endpoints), ethClient.ActiveEndpoint())
+    }
     healthStatus.SetEthereum("connected")
+    // Connection errors re-dial the endpoint with backoff; the health status follows along
+    ethClient.SetConnectionStateHandler(func(state ethereum.ConnectionState) {
+        healthStatus.SetEthereum(string(state))
+    })
     log.Println("✅ Connected to Ethereum network")
+    shutdown.Register(ShutdownCloseClients, "ethereum", func(ctx context.Context) error {
+        ethClient.Close()
+        return nil
+    })
+
+    // Fail fast on contract addresses that are empty, zero or have no deployed code
+    if cfg.ValidateContractAddresses {
+        if err := checkContractAddresses(cfg, ethClient); err != nil {
+            log.Fatal("Contract address check failed: ", err)
+        }
+        log.Println("✅ Contract addresses have deployed code")
+    }
+
+    // Validator maintenance: restore a registration removed for maintenance before joining consensus
+    var validatorMaintenance *anchor.ValidatorMaintenance
+    if cfg.ValidatorMaintenanceEnabled {
+        validatorMaintenance, err = newValidatorMaintenance(cfg, ethClient)
+        if err != nil {
+            log.Fatal("Failed to initialize validator maintenance: ", err)
+        }
+        restored, err := validatorMaintenance.Reregister(context.Background())
+        if err != nil {
+            log.Fatal("Failed to re-register validator after maintenance: ", err)
+        }
+        if restored != nil {
+            log.Printf("✅ Validator %s re-registered after maintenance (%s)", restored.ValidatorAddress, restored.Reason)
+        }
+        log.Printf("🛠️ Validator maintenance enabled for %s (min remaining validators: %d)",
+            validatorMaintenance.ValidatorAddress().Hex(), cfg.ValidatorMaintenanceMinRemaining)
+    }
 
     // Initialize BFT validator node and consensus
     log.Printf("🔐 Initializing BFT Validator Node (%s) with full consensus capabilities...", cfg.ValidatorID)
-    validatorNode, batchComponents, err := startValidator(cfg, accClient, ethClient, dbClient, firestoreSyncService)
+    // Proof-work partitioning: proofs this validator generates as owner are served to peers
+    var sharedProofCache *intent.SharedProofCache
+    if cfg.ProofWorkPartitioning {
+        sharedProofCache = intent.NewSharedProofCache(30 * time.Minute)
+    }
+
+    // Validator identity: keys and extra chains are filled in by startValidator
+    identity := server.ValidatorIdentity{
+        ValidatorID: cfg.ValidatorID,
+        Version:     version,
+    }
+    identity.AddChain(server.IdentityChain{
+        ChainID:         fmt.Sprintf("%d", cfg.EthChainID),
+        Network:         cfg.NetworkName,
+        ContractAddress: cfg.CertenContractAddress,
+        Primary:         true,
+    })
+    if cfg.EthPrivateKey != "" {
+        if ethAddress, err := ethereum.GetPublicAddress(cfg.EthPrivateKey); err == nil {
+            identity.EthereumAddress = ethAddress.Hex()
+        }
+    }
+
+    // Prometheus batch/proof metrics, injected into the batch system and proof cycle orchestrator
+    var batchMetrics *metrics.Registry
+    if cfg.MetricsEnabled {
+        batchMetrics = metrics.NewRegistry()
+    }
+
+    // Context for background tasks, cancelled when shutdown begins so loops started
+    // with it stop before batches are drained
+    ctx, cancel := context.WithCancel(context.Background())
+
+    validatorNode, batchComponents, err := startValidator(ctx, cfg, accClient, ethClient, dbClient, firestoreSyncService, sharedProofCache, &identity, batchMetrics, shutdown)
     if err != nil {
         log.Fatal("Failed to initialize BFT validator node:", err)
     }
 
+    // Shutdown signal; the maintenance endpoint also raises it after deregistering
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+    // Attestation peer reload: SIGHUP or POST /api/attestations/peers/reload re-read
+    // ATTESTATION_PEERS, ATTESTATION_PEER_KEYS and ATTESTATION_REQUIRED_COUNT from the
+    // environment and --config file
+    reloadAttestationPeers := func() (attestation.PeerReloadResult, error) {
+        if batchComponents == nil || batchComponents.AttestationService == nil {
+            return attestation.PeerReloadResult{}, fmt.Errorf("attestation service not available")
+        }
+        reloaded, err := config.LoadWithFile(*configPath)
+        if err != nil {
+            return attestation.PeerReloadResult{}, fmt.Errorf("reload configuration: %w", err)
+        }
+        peerKeys, err := attestation.DecodePeerKeys(reloaded.AttestationPeerKeys)
+        if err != nil {
+            return attestation.PeerReloadResult{}, fmt.Errorf("reload attestation peer keys: %w", err)
+        }
+        batchComponents.AttestationService.SetPeerKeys(peerKeys)
+        return batchComponents.AttestationService.ReloadPeers(reloaded.AttestationPeers, reloaded.AttestationRequiredCount), nil
+    }
+    hangup := make(chan os.Signal, 1)
+    signal.Notify(hangup, syscall.SIGHUP)
+    go func() {
+        for range hangup {
+            log.Printf("🔄 SIGHUP received, reloading attestation peers")
+            if result, err := reloadAttestationPeers(); err != nil {
+                log.Printf("⚠️ Attestation peer reload failed, keeping the current peers: %v", err)
+            } else {
+                log.Printf("✅ Attestation peers reloaded: %d peers (%d added, %d removed), %d attestations required",
+                    len(result.Peers), len(result.Added), len(result.Removed), result.RequiredCount)
+            }
+        }
+    }()
+
     // HTTP server with ledger query endpoints
     mux := http.NewServeMux()
 
@@ -343,6 +659,12 @@ func main() {
         w.Write(healthStatus.ToJSON())
     })
 
+    // Prometheus metrics endpoint - batch, proof and anchor counters (METRICS_ENABLED)
+    if batchMetrics != nil {
+        mux.Handle("/metrics", batchMetrics.Handler())
+        log.Printf("✅ Prometheus metrics endpoint configured: GET /metrics")
+    }
+
     // Detailed health endpoint - Per Implementation Plan: Batch-aware health status
     // This endpoint provides comprehensive health information including batch system state
     mux.HandleFunc("/health/detailed", func(w http.ResponseWriter, r *http.Request) {
@@ -353,11 +675,20 @@ func main() {
             Status            string                 `json:"status"`
             Phase             string                 `json:"phase"`
             Consensus         string                 `json:"consensus"`
+            ConsensusProgress detailedConsensusProgress `json:"consensus_progress"`
             Database          string                 `json:"database"`
             Ethereum          string                 `json:"ethereum"`
             Accumulate        string                 `json:"accumulate"`
             BatchSystem       string                 `json:"batch_system"`
             ProofCycle        string                 `json:"proof_cycle"`
+            Peers             string                 `json:"peers"`
+            ConnectedPeers    int                    `json:"connected_peers"`
+            MinPeers          int                    `json:"min_peers"`
+            AnchorState       string                 `json:"anchor_state"`
+            AnchorDiscrepancies int64                `json:"anchor_discrepancies"`
+            GovernanceVerifier *anchor.GovernanceVerifierHealth `json:"governance_verifier,omitempty"`
+            ProofCycles       *execution.CycleLimiterStats `json:"proof_cycles,omitempty"`
+            AuditTip          *execution.AuditTip    `json:"audit_tip,omitempty"`
             UptimeSeconds     int64                  `json:"uptime_seconds"`
             BatchDetails      map[string]interface{} `json:"batch_details"`
             StatusExplanation string                 `json:"status_explanation"`
@@ -370,13 +701,46 @@ func main() {
             Accumulate:    healthStatus.Accumulate,
             BatchSystem:   healthStatus.BatchSystem,
             ProofCycle:    healthStatus.ProofCycle,
+            Peers:         healthStatus.Peers,
+            ConnectedPeers: healthStatus.ConnectedPeers,
+            MinPeers:      healthStatus.MinPeers,
+            AnchorState:   healthStatus.AnchorState,
+            AnchorDiscrepancies: healthStatus.AnchorDiscrepancies,
             UptimeSeconds: int64(time.Since(healthStatus.startTime).Seconds()),
             BatchDetails:  make(map[string]interface{}),
         }
 
+        healthStatus.mu.RLock()
+        govMonitor := healthStatus.govVerifier
+        cycleStats := healthStatus.cycleStats
+        auditTip := healthStatus.auditTip
+        healthStatus.mu.RUnlock()
+        if govMonitor != nil {
+            govHealth := govMonitor.Health()
+            detailed.GovernanceVerifier = &govHealth
+        }
+        if cycleStats != nil {
+            stats := cycleStats()
+            detailed.ProofCycles = &stats
+        }
+        if auditTip != nil {
+            tip := auditTip()
+            detailed.AuditTip = &tip
+        }
+
+        // Consensus progress: height, round, step, peers and last commit
+        consensusCtx, cancelConsensus := context.WithTimeout(r.Context(), 2*time.Second)
+        progress, err := validatorNode.GetConsensusMetrics(consensusCtx, cfg.HealthConsensusStallWindow)
+        cancelConsensus()
+        if err != nil {
+            detailed.ConsensusProgress.Error = err.Error()
+        } else {
+            detailed.ConsensusProgress.ConsensusMetrics = progress
+        }
+
         // Add batch system details if available
         if batchComponents != nil && batchComponents.Collector != nil {
-            batchInterval := 15 * time.Minute
+            batchInterval := cfg.OnCadenceBatchInterval
 
             // Get on-cadence batch info
             onCadenceInfo := batchComponents.Collector.GetOnCadenceBatchInfo()
@@ -393,11 +757,11 @@ func main() {
                     "remaining_seconds":      int64(remaining.Seconds()),
                     "is_delay_expected":      true,
                     "price_tier":             "$0.05/proof",
-                    "status_message":         "On-cadence batch delays up to 15 minutes are normal operation.",
+                    "status_message":         fmt.Sprintf("On-cadence batch delays up to %s are normal operation.", batchInterval),
                 }
 
                 // Check if batch is stalled (beyond expected + grace period)
-                if onCadenceInfo.Age > (batchInterval + 5*time.Minute) {
+                if onCadenceInfo.Age > (batchInterval + cfg.BatchStallGracePeriod) {
                     detailed.BatchDetails["on_cadence_warning"] = "Batch age exceeds expected window. May require investigation."
                 }
             }
@@ -422,7 +786,7 @@ func main() {
             }
 
             // Get batch system health status
-            batchHealth := batch.GetBatchSystemHealth(onCadenceInfo, onDemandInfo, batchInterval)
+            batchHealth := batch.GetBatchSystemHealth(onCadenceInfo, onDemandInfo, batchInterval, cfg.BatchStallGracePeriod)
             detailed.BatchDetails["system_health"] = map[string]interface{}{
                 "overall_status":         batchHealth.OverallStatus,
                 "on_cadence_status":      batchHealth.OnCadenceStatus,
@@ -437,8 +801,8 @@ func main() {
         case "ok":
             detailed.StatusExplanation = "All systems operational. Batch system is functioning normally."
         case "degraded":
-            detailed.StatusExplanation = "System is operational but some components are degraded. " +
-                "On-cadence batch delays up to 15 minutes are expected and do not indicate a problem."
+            detailed.StatusExplanation = fmt.Sprintf("System is operational but some components are degraded. "+
+                "On-cadence batch delays up to %s are expected and do not indicate a problem.", cfg.OnCadenceBatchInterval)
         case "error":
             detailed.StatusExplanation = "One or more critical components have failed. Investigation required."
         default:
@@ -470,6 +834,8 @@ func main() {
             ledgerHandlers := server.NewLedgerHandlers(ledgerProvider.GetLedgerStore(), ledgerProvider.GetChainID())
             mux.HandleFunc("/api/system-ledger", ledgerHandlers.HandleSystemLedger)
             mux.HandleFunc("/api/anchor-ledger", ledgerHandlers.HandleAnchorLedger)
+            mux.HandleFunc("/api/system-ledger/snapshot", ledgerHandlers.HandleSystemLedgerSnapshot)
+            mux.HandleFunc("/api/anchor-ledger/snapshot", ledgerHandlers.HandleAnchorLedgerSnapshot)
             mux.HandleFunc("/api/ledger/status", ledgerHandlers.HandleLedgerStatus)
             log.Printf("✅ Ledger query endpoints configured at /api/*")
         }
@@ -488,12 +854,57 @@ func main() {
             log.New(log.Writer(), "[BatchAPI] ", log.LstdFlags),
         )
 
+        batchHandlers.SetBatchTiming(cfg.OnCadenceBatchInterval, cfg.BatchStallGracePeriod)
+        if dbBreaker != nil {
+            batchComponents.Collector.SetDatabaseBreaker(dbBreaker)
+            batchHandlers.SetDatabaseBreaker(dbBreaker)
+        }
+        if batchComponents.GasWindow != nil {
+            batchHandlers.SetGasWindow(batchComponents.GasWindow)
+        }
+        if batchComponents.ReceiptSigner != nil {
+            batchHandlers.SetReceiptSigner(batchComponents.ReceiptSigner, database.AnchorFinality(cfg.AnchorReceiptFinality))
+            log.Printf("✅ Anchor receipt endpoint registered at /api/anchors/{id}/receipt")
+        }
+        if batchComponents.OnDemandAbandoner != nil {
+            batchHandlers.SetOnDemandAbandoner(batchComponents.OnDemandAbandoner)
+        }
+        if batchComponents.AnchorStateReconciler != nil {
+            batchHandlers.SetAnchorStateReconciler(batchComponents.AnchorStateReconciler)
+        }
+        if batchComponents.VerificationFailures != nil {
+            batchHandlers.SetVerificationFailureRecorder(batchComponents.VerificationFailures)
+        }
+        if cfg.FeeOracleEnabled {
+            feeOracle, err := newFeeOracle(cfg, ethClient)
+            if err != nil {
+                log.Printf("⚠️ Fee oracle disabled, cost endpoints use static pricing: %v", err)
+            } else {
+                batchHandlers.SetFeeOracle(feeOracle, uint64(cfg.FeeOracleBatchGas), cfg.FeeOracleOnCadenceProofs)
+                batchComponents.Processor.SetFeeOracle(feeOracle)
+                log.Printf("✅ Fee oracle enabled for cost endpoints (source=%s, cache=%s)", cfg.FeeOracleSource, cfg.FeeOracleCacheTTL)
+            }
+        }
+
         // On-demand anchor endpoint (Priority 2.1)
         mux.HandleFunc("/api/anchors/on-demand", batchHandlers.HandleOnDemandAnchor)
+        if cfg.OnDemandRateLimit > 0 {
+            batchHandlers.SetOnDemandRateLimiter(
+                server.NewTokenBucketRateLimiter(float64(cfg.OnDemandRateLimit), cfg.OnDemandRateBurst),
+                cfg.OnDemandRateLimitBy...)
+            log.Printf("✅ On-demand anchor rate limit: %d/min, burst %d, by %s",
+                cfg.OnDemandRateLimit, cfg.OnDemandRateBurst, strings.Join(cfg.OnDemandRateLimitBy, ","))
+        }
 
         // Batch status endpoints
         mux.HandleFunc("/api/batches/current", batchHandlers.HandleBatchInfo)
+        mux.HandleFunc("/api/batches/abandoned", batchHandlers.HandleGetAbandonedOnDemand)
         mux.HandleFunc("/api/batches/", batchHandlers.HandleBatchStatus)
+        if cfg.AdminAPIToken != "" {
+            // Operators re-anchor failed batches without restarting the service
+            batchHandlers.SetRetryToken(cfg.AdminAPIToken)
+            log.Printf("✅ Failed batch retry endpoint configured: POST /api/batches/:id/retry")
+        }
 
         // Proof retrieval endpoints (Priority 3.1)
         mux.HandleFunc("/api/proofs/by-tx/", batchHandlers.HandleGetProofByTxHash)
@@ -501,12 +912,15 @@ func main() {
         mux.HandleFunc("/api/proofs/", batchHandlers.HandleGetProof)
 
         // Anchor retrieval endpoints
+        mux.HandleFunc("/api/anchors/reconciliation", batchHandlers.HandleGetAnchorReconciliation)
+        mux.HandleFunc("/api/anchors/failures", batchHandlers.HandleGetAnchorFailures)
         mux.HandleFunc("/api/anchors/by-batch/", batchHandlers.HandleGetAnchorByBatch)
         mux.HandleFunc("/api/anchors/", batchHandlers.HandleGetAnchor)
 
         // Cost tracking endpoints (Priority 3.2)
         mux.HandleFunc("/api/costs", batchHandlers.HandleGetCostStatistics)
         mux.HandleFunc("/api/costs/estimate", batchHandlers.HandleEstimateCost)
+        mux.HandleFunc("/api/costs/history", batchHandlers.HandleGetCostHistory)
 
         // Multi-Validator Attestation endpoints (Priority 3.1)
         if batchComponents.AttestationService != nil {
@@ -522,12 +936,18 @@ func main() {
             mux.HandleFunc("/api/attestations/status/", attestationHandlers.HandleGetAttestationStatus)
             mux.HandleFunc("/api/attestations/bundle/", attestationHandlers.HandleGetAttestationBundle)
             mux.HandleFunc("/api/attestations/peers", attestationHandlers.HandleGetPeers)
+            mux.HandleFunc("/api/attestations/peers/reload", attestationHandlers.HandleReloadPeers)
 
             log.Printf("✅ [Phase 5] Multi-validator attestation endpoints configured:")
             log.Printf("   - POST /api/attestations/request  (receive attestation from peer)")
             log.Printf("   - GET  /api/attestations/status/:id (attestation status)")
             log.Printf("   - GET  /api/attestations/bundle/:id (attestation bundle)")
-            log.Printf("   - GET  /api/attestations/peers     (configured peers)")
+            log.Printf("   - GET  /api/attestations/peers     (configured peers and health)")
+            if cfg.AdminAPIToken != "" {
+                // Operators change peers without restarting the validator
+                attestationHandlers.SetPeerReloader(cfg.AdminAPIToken, reloadAttestationPeers)
+                log.Printf("   - POST /api/attestations/peers/reload (reload peers from configuration, admin)")
+            }
         }
 
         // NEW: Comprehensive Proof Artifact API (v1 endpoints)
@@ -536,14 +956,23 @@ func main() {
             cfg.ValidatorID,
             log.New(log.Writer(), "[ProofAPI] ", log.LstdFlags),
         )
+        proofHandlers.SetInclusionRechecker(batchComponents.InclusionRechecker)
+        proofHandlers.SetUsageMeter(batchComponents.UsageMeter)
+        proofHandlers.SetVerificationFailureRecorder(batchComponents.VerificationFailures)
+        if chainVerifier, err := batch.NewProofChainVerifier(batch.NewProofChainStore(batchComponents.Repos)); err == nil {
+            proofHandlers.SetProofChainVerifier(chainVerifier)
+        }
+        proofHandlers.SetProofCycleEvents(batchComponents.ProofCycleEvents)
 
         // Proof discovery endpoints
         mux.HandleFunc("/api/v1/proofs/tx/", proofHandlers.HandleGetProofByTxHash)
         mux.HandleFunc("/api/v1/proofs/account/", proofHandlers.HandleGetProofsByAccount)
         mux.HandleFunc("/api/v1/proofs/batch/", proofHandlers.HandleGetProofsByBatch)
         mux.HandleFunc("/api/v1/proofs/anchor/", proofHandlers.HandleGetProofsByAnchor)
+        mux.HandleFunc("/api/v1/proofs/blocks", proofHandlers.HandleGetProofsByBlockRange)
         mux.HandleFunc("/api/v1/proofs/query", proofHandlers.HandleQueryProofs)
         mux.HandleFunc("/api/v1/proofs/sync", proofHandlers.HandleSyncProofs)
+        mux.HandleFunc("/api/v1/proofs/verification-failures", proofHandlers.HandleGetVerificationFailures)
 
         // Proof detail endpoints (must be registered last due to path matching)
         mux.HandleFunc("/api/v1/proofs/", proofHandlers.HandleGetProofByID)
@@ -551,34 +980,147 @@ func main() {
         // Batch statistics endpoint
         mux.HandleFunc("/api/v1/batches/", proofHandlers.HandleGetBatchStats)
 
+        // Account usage endpoint
+        mux.HandleFunc("/api/v1/accounts/", proofHandlers.HandleGetAccountUsage)
+
+        // Anchor gas price history
+        mux.HandleFunc("/api/v1/reports/gas-history", proofHandlers.HandleGetGasHistory)
+
         log.Printf("✅ [Phase 5] Comprehensive proof artifact API v1 endpoints configured:")
         log.Printf("   - GET  /api/v1/proofs/tx/:hash      (proof by tx hash)")
         log.Printf("   - GET  /api/v1/proofs/account/:url  (proofs by account)")
         log.Printf("   - GET  /api/v1/proofs/batch/:id     (proofs by batch)")
         log.Printf("   - GET  /api/v1/proofs/anchor/:hash  (proofs by anchor)")
+        log.Printf("   - GET  /api/v1/proofs/blocks?chain=&from=&to= (proofs by anchor block range)")
         log.Printf("   - POST /api/v1/proofs/query         (filtered query)")
-        log.Printf("   - GET  /api/v1/proofs/sync          (sync for auditing)")
+        log.Printf("   - GET  /api/v1/proofs/sync          (NDJSON sync stream for auditing)")
+        log.Printf("   - GET  /api/v1/proofs/verification-failures (on-chain verification failures)")
         log.Printf("   - GET  /api/v1/proofs/:id           (full proof details)")
+        log.Printf("   - GET  /api/v1/proofs/:id/verify    (on-chain check breakdown)")
+        log.Printf("   - POST /api/v1/proofs/:id/onchain-verify (on-chain verification)")
+        log.Printf("   - POST /api/v1/proofs/:id/verify-chain (L1-L4 and anchor chain verification)")
+        log.Printf("   - GET  /api/v1/proofs/:id/stream    (live proof cycle progress, SSE)")
+        log.Printf("   - GET  /api/v1/proofs/:id/bundle    (self-contained Merkle proof export)")
         log.Printf("   - GET  /api/v1/batches/:id/stats    (batch statistics)")
+        log.Printf("   - GET  /api/v1/accounts/:url/usage  (account proof usage)")
+        log.Printf("   - GET  /api/v1/reports/gas-history  (anchor gas price history)")
 
         log.Printf("✅ [Phase 5] Batch and proof API endpoints configured:")
         log.Printf("   - POST /api/anchors/on-demand  (immediate anchoring ~$0.25/proof)")
+        log.Printf("   - POST /api/anchors/on-demand?dryRun=true (gas estimate, nothing submitted)")
+        log.Printf("   - GET  /api/anchors/failures   (on-chain verification failures by check)")
         log.Printf("   - GET  /api/batches/current    (current batch status)")
         log.Printf("   - GET  /api/proofs/by-tx/:hash (proof by transaction)")
         log.Printf("   - GET  /api/proofs/by-account/:url (proofs by account)")
         log.Printf("   - GET  /api/costs              (cost structure)")
         log.Printf("   - GET  /api/costs/estimate     (estimate anchoring cost)")
+        log.Printf("   - GET  /api/costs/history      (anchor spend per day and validator)")
     } else {
         log.Printf("⚠️ [Phase 5] Batch API endpoints not available - database not connected")
     }
 
+    // Proof-work partitioning: shared proof endpoint for peer validators
+    if sharedProofCache != nil {
+        intentHandlers := server.NewIntentHandlers(
+            sharedProofCache,
+            cfg.ValidatorID,
+            log.New(log.Writer(), "[IntentAPI] ", log.LstdFlags),
+        )
+        mux.HandleFunc("/api/intents/shared-proofs/", intentHandlers.HandleGetSharedProof)
+        log.Printf("✅ Shared proof endpoint configured: GET /api/intents/shared-proofs/:intentID")
+    }
+
+    // Validator identity: self-description for registries, dashboards and peers
+    if cfg.IdentityEndpointEnabled {
+        if batchComponents != nil && batchComponents.Collector != nil {
+            identity.ProofClasses = []string{string(database.ProofClassOnCadence), string(database.ProofClassOnDemand)}
+        }
+        for capability, enabled := range map[string]bool{
+            "unified_orchestrator":    cfg.UseUnifiedOrchestrator,
+            "multi_chain":             cfg.EnableMultiChain,
+            "proof_work_partitioning": cfg.ProofWorkPartitioning,
+            "proof_metering":          cfg.ProofMeteringEnabled,
+            "anchor_receipts":         cfg.AnchorReceiptsEnabled,
+            "gas_window":              cfg.GasWindowEnabled,
+            "firestore_sync":          cfg.FirestoreEnabled,
+        } {
+            if enabled {
+                identity.Capabilities = append(identity.Capabilities, capability)
+            }
+        }
+        sort.Strings(identity.Capabilities)
+
+        identityHandlers := server.NewIdentityHandlers(
+            identity,
+            healthStatus.Summary,
+            log.New(log.Writer(), "[IdentityAPI] ", log.LstdFlags),
+        )
+        mux.HandleFunc("/api/v1/identity", identityHandlers.HandleGetIdentity)
+        log.Printf("✅ Identity endpoint configured: GET /api/v1/identity")
+    }
+
+    // Admin diagnostics: effective runtime configuration with secrets redacted
+    if cfg.AdminAPIToken != "" {
+        adminHandlers := server.NewAdminHandlers(
+            cfg.AdminAPIToken,
+            func() map[string]interface{} { return configSnapshot(cfg, batchComponents) },
+            log.New(log.Writer(), "[AdminAPI] ", log.LstdFlags),
+        )
+        mux.HandleFunc("/api/admin/config", adminHandlers.HandleGetConfig)
+        log.Printf("✅ Admin config endpoint configured: GET /api/admin/config")
+    }
+
+    // Write-back key rotation: admin-only switch of the Accumulate write-back signing key
+    if cfg.AdminAPIToken != "" && batchComponents != nil && batchComponents.WriteBackSubmitter != nil {
+        keyRotationHandlers := server.NewKeyRotationHandlers(
+            batchComponents.WriteBackSubmitter,
+            cfg.AdminAPIToken,
+            log.New(log.Writer(), "[KeyRotationAPI] ", log.LstdFlags),
+        )
+        mux.HandleFunc("/api/admin/writeback-key", keyRotationHandlers.HandleWriteBackKey)
+        log.Printf("✅ Write-back key rotation endpoint configured: GET/POST /api/admin/writeback-key")
+    }
+
+    // Validator maintenance: admin-only deregistration followed by a graceful shutdown
+    if validatorMaintenance != nil {
+        maintenanceHandlers := server.NewMaintenanceHandlers(
+            validatorMaintenance,
+            cfg.ValidatorMaintenanceAdminToken,
+            func() { quit <- syscall.SIGTERM },
+            log.New(log.Writer(), "[MaintenanceAPI] ", log.LstdFlags),
+        )
+        mux.HandleFunc("/api/admin/maintenance", maintenanceHandlers.HandleMaintenance)
+        log.Printf("✅ Validator maintenance endpoint configured: GET/POST /api/admin/maintenance")
+    }
+
     httpServer := &http.Server{
         Addr:    cfg.ListenAddr,
         Handler: mux,
     }
 
-    // Context for background tasks
-    ctx, cancel := context.WithCancel(context.Background())
+    // Attestation mTLS: serve HTTPS and verify validator client certificates when presented
+    attestationTLS := attestation.TLSConfig{
+        CertFile: cfg.AttestationTLSCert,
+        KeyFile:  cfg.AttestationTLSKey,
+        CABundle: cfg.AttestationCABundle,
+    }
+    if attestationTLS.Enabled() {
+        tlsConfig, err := attestationTLS.ServerTLSConfig()
+        if err != nil {
+            log.Fatalf("Failed to configure attestation mTLS: %v", err)
+        }
+        httpServer.TLSConfig = tlsConfig
+        log.Printf("✅ Attestation mTLS enabled - API served over HTTPS, peer certificates verified against %s", cfg.AttestationCABundle)
+    } else {
+        log.Printf("⚠️ Attestation mTLS not configured - API served over plain HTTP")
+    }
+
+    // Stop accepting API requests first, then cancel background services
+    shutdown.Register(ShutdownStopIntake, "http-server", httpServer.Shutdown)
+    shutdown.Register(ShutdownStopIntake, "background-context", func(ctx context.Context) error {
+        cancel()
+        return nil
+    })
 
     // Start internal validator services (execution queue, etc)
     go validatorNode.Start(ctx)
@@ -591,35 +1133,22 @@ func main() {
     // Start HTTP API
     go func() {
         log.Printf("🌐 BFT Validator API listening on %s", cfg.ListenAddr)
-        if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+        serve := httpServer.ListenAndServe
+        if httpServer.TLSConfig != nil {
+            serve = func() error { return httpServer.ListenAndServeTLS("", "") }
+        }
+        if err := serve(); err != nil && err != http.ErrServerClosed {
             log.Fatal("Failed to start HTTP server:", err)
         }
     }()
 
     // Wait for shutdown signal
-    quit := make(chan os.Signal, 1)
-    signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
     <-quit
 
     log.Printf("🛑 Shutting down BFT Validator...")
 
-    // Cancel background services
-    cancel()
-
-    // Graceful HTTP shutdown
-    shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-    defer shutdownCancel()
-
-    if err := httpServer.Shutdown(shutdownCtx); err != nil {
-        log.Printf("HTTP server shutdown error: %v", err)
-    }
-
-    // Close Firestore client
-    if firestoreClient != nil {
-        if err := firestoreClient.Close(); err != nil {
-            log.Printf("Firestore client close error: %v", err)
-        }
-    }
+    // stop intake → flush batches → finish anchoring → stop trackers → close database → close clients
+    shutdown.Run()
 
     log.Printf("✅ BFT Validator stopped")
 }
@@ -632,78 +1161,303 @@ type BatchComponents struct {
     OnDemandHandler      *batch.OnDemandHandler
     ConfirmationTracker  *batch.ConfirmationTracker
     AttestationService   *attestation.Service
+    InclusionRechecker   *batch.InclusionRechecker // On-chain proof checks with batch inclusion re-check
+    UsageMeter           *batch.UsageMeter       // Per-account proof metering (nil when disabled)
+    ReceiptSigner        *anchor_proof.AttestationSigner // Anchor receipt signing (nil when disabled)
+    GasWindow            *batch.GasWindow        // Gas-aware on-cadence anchoring (nil when disabled)
+    VerificationFailures *batch.VerificationFailureRecorder // ProofVerificationFailed events (nil without event watching)
+    OnDemandAbandoner    *batch.OnDemandAbandoner // Abandons on-demand batches that never anchor (nil when disabled)
+    VotingPower          *anchor.VotingPowerTracker // On-chain voting power used in BLS proof data (nil when disabled)
+    AnchorStateReconciler *batch.AnchorStateReconciler // Flags anchor records that disagree with the chain (nil when disabled)
+    ProofCycleEvents     *execution.ProofCycleEventBus // Live proof cycle stage transitions for API streams
+    WriteBackSubmitter   *execution.AccumulateSubmitterImpl // Accumulate write-back signing (nil with the null submitter)
     Repos                *database.Repositories
-    FirestoreSyncService *firestore.SyncService // Real-time UI sync
+    FirestoreSyncService firestore.Syncer // Real-time UI sync (NullSyncService when disabled)
 }
 
-// loadOrGenerateEd25519Key securely loads or generates an Ed25519 private key
-// E.5 remediation: Never derive keys from validator ID - use proper key management
-func loadOrGenerateEd25519Key(cfg *config.Config) (ed25519.PrivateKey, error) {
-    // Determine key file path
-    keyPath := cfg.Ed25519KeyPath
-    if keyPath == "" {
-        // Default to data directory
-        dataDir := cfg.DataDir
-        if dataDir == "" {
-            dataDir = "./data"
+// checkContractAddresses verifies the anchor contract address, and the verifier
+// addresses when configured, point at deployed contracts on the Ethereum network
+func checkContractAddresses(cfg *config.Config, ethClient *ethereum.Client) error {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    contracts := []struct {
+        env      string
+        address  string
+        optional bool
+    }{
+        {"CERTEN_CONTRACT_ADDRESS", cfg.CertenContractAddress, false},
+        {"BLS_ZK_VERIFIER_ADDRESS", cfg.BLSZKVerifierAddress, true},
+        {"GOVERNANCE_VERIFIER_ADDRESS", cfg.GovernanceVerifierAddress, true},
+    }
+    for _, c := range contracts {
+        if c.optional && c.address == "" {
+            continue
+        }
+        if err := ethClient.CheckContractAddress(ctx, c.address); err != nil {
+            return fmt.Errorf("%s: %w", c.env, err)
         }
-        keyPath = filepath.Join(dataDir, "ed25519_key.hex")
     }
+    return nil
+}
 
-    // Ensure directory exists
-    keyDir := filepath.Dir(keyPath)
-    if err := os.MkdirAll(keyDir, 0700); err != nil {
-        return nil, fmt.Errorf("create key directory %s: %w", keyDir, err)
+// configSnapshot reports the effective runtime configuration of the batch system,
+// orchestrator, confirmation tracker and attestation service, plus the redacted
+// environment configuration
+func configSnapshot(cfg *config.Config, components *BatchComponents) map[string]interface{} {
+    snapshot := map[string]interface{}{
+        "version": version,
+        "feature_flags": map[string]bool{
+            "unified_orchestrator":    cfg.UseUnifiedOrchestrator,
+            "multi_chain":             cfg.EnableMultiChain,
+            "unified_tables":          cfg.EnableUnifiedTables,
+            "fallback_to_legacy":      cfg.FallbackToLegacy,
+            "proof_work_partitioning": cfg.ProofWorkPartitioning,
+            "proof_metering":          cfg.ProofMeteringEnabled,
+            "proof_auto_regenerate":   cfg.ProofAutoRegenerate,
+            "anchor_receipts":         cfg.AnchorReceiptsEnabled,
+            "gas_window":              cfg.GasWindowEnabled,
+            "fee_escalation":          cfg.OnDemandFeeEscalationEnabled,
+            "stuck_tx_replacement":    cfg.AnchorStuckTxReplaceEnabled,
+            "on_demand_abandonment":   cfg.OnDemandAbandonEnabled,
+            "proof_archival":          cfg.ProofRetentionDays > 0,
+            "anchor_state_reconcile":  cfg.AnchorStateReconcileEnabled,
+            "prometheus_metrics":      cfg.MetricsEnabled,
+            "synthetic_transactions":  cfg.SyntheticTxEnabled,
+            "merkle_root_guard":       cfg.AnchorMerkleRootGuard,
+            "governance_batch_dedup":  cfg.GovernanceBatchDedup,
+            "governance_result_cache": cfg.GovProofCacheSize > 0,
+            "firestore_sync":          cfg.FirestoreEnabled,
+            "validator_maintenance":   cfg.ValidatorMaintenanceEnabled,
+            "fee_oracle":              cfg.FeeOracleEnabled,
+        },
+        "orchestrator": map[string]interface{}{
+            "unified":                     cfg.UseUnifiedOrchestrator,
+            "default_target_chain":        cfg.DefaultTargetChain,
+            "max_concurrent_proof_cycles": cfg.MaxConcurrentProofCycles,
+            "audit_log_enabled":           cfg.AuditLogPath != "",
+        },
+        "environment": cfg.Redacted(),
     }
 
-    var privateKey ed25519.PrivateKey
-
-    if _, err := os.Stat(keyPath); os.IsNotExist(err) {
-        // Generate new secure random key
-        log.Printf("🔑 Generating new Ed25519 key...")
-        _, privateKey, err = ed25519.GenerateKey(rand.Reader)
-        if err != nil {
-            return nil, fmt.Errorf("generate ed25519 key: %w", err)
+    if components != nil {
+        batchSystem := map[string]interface{}{}
+        if components.Scheduler != nil {
+            batchSystem["scheduler"] = map[string]interface{}{
+                "interval":     components.Scheduler.GetInterval().String(),
+                "phase_offset": components.Scheduler.GetPhaseOffset().String(),
+                "state":        components.Scheduler.State(),
+            }
         }
-
-        // Save to file with restrictive permissions (owner read/write only)
-        keyHex := hex.EncodeToString(privateKey)
-        if err := os.WriteFile(keyPath, []byte(keyHex), 0600); err != nil {
-            return nil, fmt.Errorf("save ed25519 key to %s: %w", keyPath, err)
+        if components.Collector != nil {
+            batchSystem["collector"] = components.Collector.Settings()
         }
-        log.Printf("✅ Generated and saved new Ed25519 key: %s", keyPath)
-    } else {
-        // Load existing key
-        log.Printf("🔑 Loading existing Ed25519 key from %s...", keyPath)
-        data, err := os.ReadFile(keyPath)
-        if err != nil {
-            return nil, fmt.Errorf("read ed25519 key from %s: %w", keyPath, err)
+        if components.Processor != nil {
+            batchSystem["processor"] = components.Processor.Settings()
         }
+        if components.GasWindow != nil {
+            batchSystem["gas_window"] = components.GasWindow.Status()
+        }
+        if components.OnDemandAbandoner != nil {
+            batchSystem["on_demand_abandoner"] = components.OnDemandAbandoner.Status()
+        }
+        if components.AnchorStateReconciler != nil {
+            batchSystem["anchor_state_reconciler"] = components.AnchorStateReconciler.Status()
+        }
+        snapshot["batch_system"] = batchSystem
+        if components.VotingPower != nil {
+            snapshot["voting_power"] = components.VotingPower.Status()
+        }
+        if components.ConfirmationTracker != nil {
+            snapshot["confirmation_tracker"] = components.ConfirmationTracker.Settings()
+        }
+        if components.AttestationService != nil {
+            snapshot["attestation_service"] = components.AttestationService.Settings()
+        }
+    }
+    return snapshot
+}
 
-        keyBytes, err := hex.DecodeString(strings.TrimSpace(string(data)))
-        if err != nil {
-            return nil, fmt.Errorf("decode ed25519 key from %s: %w", keyPath, err)
+// newValidatorMaintenance builds the maintenance controller for this validator's
+// Ethereum account against the anchor contract's validator set
+func newValidatorMaintenance(cfg *config.Config, ethClient *ethereum.Client) (*anchor.ValidatorMaintenance, error) {
+    if cfg.EthPrivateKey == "" {
+        return nil, fmt.Errorf("ETH_PRIVATE_KEY is required for validator maintenance")
+    }
+    validatorAddress, err := ethereum.GetPublicAddress(cfg.EthPrivateKey)
+    if err != nil {
+        return nil, fmt.Errorf("derive validator address: %w", err)
+    }
+    registry, err := anchor.NewContractValidatorRegistry(ethClient, cfg.CertenContractAddress, cfg.EthPrivateKey, 0)
+    if err != nil {
+        return nil, err
+    }
+    return anchor.NewValidatorMaintenance(registry, anchor.MaintenanceConfig{
+        ValidatorAddress:       validatorAddress,
+        StatePath:              cfg.ValidatorMaintenanceStatePath,
+        MinRemainingValidators: cfg.ValidatorMaintenanceMinRemaining,
+        Logger:                 log.New(log.Writer(), "[Maintenance] ", log.LstdFlags),
+    })
+}
+
+// newFeeOracle builds the oracle pricing the cost endpoints: gas price from the node,
+// ETH/USD from the configured Chainlink aggregator or HTTP endpoint
+func newFeeOracle(cfg *config.Config, ethClient *ethereum.Client) (*ethereum.CachedFeeOracle, error) {
+    var feed ethereum.PriceFeed
+    var err error
+    switch cfg.FeeOracleSource {
+    case "chainlink":
+        feed, err = ethereum.NewChainlinkPriceFeed(ethClient, cfg.FeeOracleChainlinkAddress)
+    case "http":
+        feed, err = ethereum.NewHTTPPriceFeed(cfg.FeeOracleHTTPURL, cfg.FeeOracleHTTPPricePath, 10*time.Second)
+    default:
+        err = fmt.Errorf("unknown FEE_ORACLE_SOURCE %q", cfg.FeeOracleSource)
+    }
+    if err != nil {
+        return nil, err
+    }
+    return ethereum.NewCachedFeeOracle(ethClient.GetGasPrice, feed, cfg.FeeOracleCacheTTL)
+}
+
+// newVotingPowerTracker builds the tracker of this validator's on-chain voting power
+// and reads its startup value
+func newVotingPowerTracker(cfg *config.Config, ethClient *ethereum.Client) (*anchor.VotingPowerTracker, error) {
+    if cfg.EthPrivateKey == "" || cfg.CertenContractAddress == "" {
+        return nil, fmt.Errorf("ETH_PRIVATE_KEY and CERTEN_CONTRACT_ADDRESS are required for voting power tracking")
+    }
+    policy, err := anchor.ParseVotingPowerPolicy(cfg.VotingPowerChangePolicy)
+    if err != nil {
+        return nil, fmt.Errorf("invalid VOTING_POWER_CHANGE_POLICY: %w", err)
+    }
+    validatorAddress, err := ethereum.GetPublicAddress(cfg.EthPrivateKey)
+    if err != nil {
+        return nil, fmt.Errorf("derive validator address: %w", err)
+    }
+    registry, err := anchor.NewContractValidatorRegistry(ethClient, cfg.CertenContractAddress, cfg.EthPrivateKey, 0)
+    if err != nil {
+        return nil, err
+    }
+    tracker, err := anchor.NewVotingPowerTracker(registry, &anchor.VotingPowerTrackerConfig{
+        ValidatorAddress: validatorAddress,
+        Policy:           policy,
+        Logger:           log.New(log.Writer(), "[VotingPower] ", log.LstdFlags),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    // A failed read leaves the assumed values in place until a ValidatorRegistered event arrives
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+    if err := tracker.Refresh(ctx); err != nil {
+        log.Printf("⚠️ Could not read on-chain voting power at startup: %v", err)
+    }
+    return tracker, nil
+}
+
+// newValidatorSetSyncer builds the syncer of the anchor contract's validator set. This
+// validator is identified by VALIDATOR_ID, others by VALIDATOR_SET_IDS or their address.
+func newValidatorSetSyncer(cfg *config.Config, ethClient *ethereum.Client) (*execution.ValidatorSetSyncer, error) {
+    if cfg.CertenContractAddress == "" {
+        return nil, fmt.Errorf("CERTEN_CONTRACT_ADDRESS is required for validator set sync")
+    }
+    validatorIDs, err := execution.ParseValidatorIDs(cfg.ValidatorSetIDs)
+    if err != nil {
+        return nil, fmt.Errorf("invalid VALIDATOR_SET_IDS: %w", err)
+    }
+    if cfg.EthPrivateKey != "" {
+        if self, err := ethereum.GetPublicAddress(cfg.EthPrivateKey); err == nil {
+            validatorIDs[self] = cfg.ValidatorID
         }
+    }
+    registry, err := anchor.NewContractValidatorRegistry(ethClient, cfg.CertenContractAddress, cfg.EthPrivateKey, 0)
+    if err != nil {
+        return nil, err
+    }
+    return execution.NewValidatorSetSyncer(registry, &execution.ValidatorSetSyncerConfig{
+        RefreshInterval: cfg.ValidatorSetSyncInterval,
+        ValidatorIDs:    validatorIDs,
+        Logger:          log.New(log.Writer(), "[ValidatorSetSync] ", log.LstdFlags),
+    })
+}
 
-        if len(keyBytes) != ed25519.PrivateKeySize {
-            return nil, fmt.Errorf("invalid ed25519 key size: expected %d, got %d", ed25519.PrivateKeySize, len(keyBytes))
+// attestationValidatorSet converts the synced on-chain validator set into the set BLS
+// attestations are aggregated against, with the configured voting power threshold;
+// validators without a BLS key are left out
+func attestationValidatorSet(vs *execution.ValidatorSet, numerator, denominator int) (*attestation.ValidatorSet, error) {
+    var validators []attestation.RegisteredValidator
+    for _, v := range vs.Validators {
+        if !v.Active || len(v.BLSPublicKey) == 0 || v.VotingPower == nil || v.VotingPower.Sign() <= 0 {
+            continue
         }
+        validators = append(validators, attestation.RegisteredValidator{
+            ValidatorID:  v.ID,
+            Address:      v.Address,
+            VotingPower:  v.VotingPower,
+            BLSPublicKey: v.BLSPublicKey,
+        })
+    }
+    return attestation.NewValidatorSet(validators, uint64(numerator), uint64(denominator))
+}
+
+// executorValidatorSet converts the on-chain validator set into the set block executors
+// are selected from, weighted by each validator's registered voting power
+func executorValidatorSet(vs *execution.ValidatorSet) []consensus.BFTValidatorInfo {
+    set := make([]consensus.BFTValidatorInfo, 0, len(vs.Validators))
+    for _, v := range vs.Validators {
+        if v.VotingPower == nil || !v.VotingPower.IsInt64() {
+            log.Printf("⚠️ Validator %s has a voting power executor selection cannot use: %v", v.ID, v.VotingPower)
+            continue
+        }
+        set = append(set, consensus.BFTValidatorInfo{
+            ValidatorID: v.ID,
+            PublicKey:   v.BLSPublicKey,
+            VotingPower: v.VotingPower.Int64(),
+            IsActive:    v.Active,
+            Address:     v.Address.Hex(),
+        })
+    }
+    return set
+}
 
-        privateKey = ed25519.PrivateKey(keyBytes)
-        log.Printf("✅ Loaded existing Ed25519 key from %s", keyPath)
+// loadValidatorKey opens the validator's Ed25519 key with the configured backend:
+// a key file (generated on first start) or a Cloud KMS key that never leaves the KMS
+// E.5 remediation: Never derive keys from validator ID - use proper key management
+func loadValidatorKey(ctx context.Context, cfg *config.Config) (keys.KeyProvider, error) {
+    // Determine key file path
+    keyPath := cfg.Ed25519KeyPath
+    if keyPath == "" {
+        // Default to data directory
+        dataDir := cfg.DataDir
+        if dataDir == "" {
+            dataDir = "./data"
+        }
+        keyPath = filepath.Join(dataDir, "ed25519_key.hex")
     }
 
-    return privateKey, nil
+    return keys.New(ctx, keys.Config{
+        Backend:            cfg.KeyBackend,
+        FilePath:           keyPath,
+        KMSKeyName:         cfg.GCPKMSKeyName,
+        KMSCredentialsFile: cfg.GCPKMSCredentialsFile,
+        SignTimeout:        cfg.GCPKMSSignTimeout,
+    })
 }
 
 // startValidator wires all components and returns a fully configured BFT validator
 // Returns the validator, batch components (if enabled), and any error
+// ctx is the background context; the batch scheduler and confirmation tracker stop when it is cancelled
 func startValidator(
+    ctx context.Context,
     cfg *config.Config,
     accClient accumulate.Client,
     ethClient *ethereum.Client,
     dbClient *database.Client,
-    firestoreSyncService *firestore.SyncService,
+    firestoreSyncService firestore.Syncer,
+    sharedProofCache *intent.SharedProofCache,
+    identity *server.ValidatorIdentity,
+    batchMetrics *metrics.Registry,
+    shutdown *ShutdownSequence,
 ) (*consensus.BFTValidator, *BatchComponents, error) {
     // Base validator info used for BFT validator set
     validatorInfo := consensus.BFTValidatorInfo{
@@ -719,18 +1473,24 @@ func startValidator(
         ByzantineFaultTolerance: 0.33,
         ConsensusTimeout:        10 * time.Second,
         MinVotingPower:          1,
-        ExecutorSelectionSeed:   []byte(cfg.ValidatorID),
+        ExecutorSelectionSeed:   []byte(cfg.ChainID), // shared by all validators so they select the same executor
     }
 
     // E.5 remediation: Secure Ed25519 key loading from file or generation
     // NEVER derive keys from validator ID - that's cryptographically weak
-    privateKey, err := loadOrGenerateEd25519Key(cfg)
+    validatorKey, err := loadValidatorKey(ctx, cfg)
     if err != nil {
         return nil, nil, fmt.Errorf("failed to load/generate Ed25519 key: %w", err)
     }
-    publicKey := privateKey.Public().(ed25519.PublicKey)
+    // Only file-backed keys expose raw bytes; components that still need them are skipped otherwise
+    privateKey, hasRawKey := keys.PrivateKey(validatorKey)
+    publicKey := validatorKey.PublicKey()
     validatorInfo.PublicKey = publicKey
     log.Printf("✅ Ed25519 key loaded: public key = %s...", hex.EncodeToString(publicKey)[:16])
+    if kmsKey, ok := validatorKey.(*keys.GCPKMSKeyProvider); ok {
+        log.Printf("🔐 Ed25519 key held in Cloud KMS: %s (BLS key remains file-backed)", kmsKey.KeyName())
+    }
+    identity.Ed25519PublicKey = hex.EncodeToString(publicKey)
 
     // --- Proof generator wiring (REAL lite client) ---
     // Note: Some legacy components still require the concrete type
@@ -827,11 +1587,41 @@ func startValidator(
 
     // --- REAL CometBFT engine wiring (unified engine) ---
     log.Printf("🚀 Initializing unified BFT consensus with real CometBFT networking: %s", cfg.ValidatorID)
-    cometEngine, err := consensus.NewUnifiedCometBFTEngine(cfg.ValidatorID)
+    cometEngine, err := consensus.NewUnifiedCometBFTEngine(cfg.ValidatorID, consensus.StateSyncConfig{
+        SnapshotDir:        cfg.StateSyncSnapshotDir,
+        SnapshotInterval:   uint64(cfg.StateSyncSnapshotInterval),
+        SnapshotKeepRecent: cfg.StateSyncSnapshotKeepRecent,
+        Enable:             cfg.StateSyncEnable,
+        RPCServers:         cfg.StateSyncRPCServers,
+        TrustHeight:        cfg.StateSyncTrustHeight,
+        TrustHash:          cfg.StateSyncTrustHash,
+        TrustPeriod:        cfg.StateSyncTrustPeriod,
+    })
     if err != nil {
         return nil, nil, fmt.Errorf("failed to create unified CometBFT engine: %w", err)
     }
 
+    // Consensus peer connectivity is part of health: an isolated validator is degraded
+    if cfg.HealthMinPeers > 0 {
+        peerMonitorCfg := consensus.DefaultHealthMonitorConfig()
+        peerMonitorCfg.MinPeers = cfg.HealthMinPeers
+        peerMonitorCfg.CheckInterval = cfg.HealthPeerCheckInterval
+        peerMonitor := consensus.NewConsensusHealthMonitor(peerMonitorCfg, cometEngine)
+        peerMonitor.SetOnPeerCountChange(func(count int) {
+            healthStatus.SetPeers(count, peerMonitor.MinPeers())
+        })
+        if err := peerMonitor.Start(); err != nil {
+            log.Printf("⚠️ Consensus peer health monitor not started: %v", err)
+        } else {
+            shutdown.Register(ShutdownStopTrackers, "consensus-health-monitor", func(ctx context.Context) error {
+                peerMonitor.Stop()
+                return nil
+            })
+        }
+    } else {
+        healthStatus.SetPeers(0, 0)
+    }
+
     // Initialize BLS key for validator consensus
     // Keys are derived deterministically from validator ID or loaded from file
     // Key storage path can be set via BLS_KEY_PATH env var, defaults to ./data/bls_key.hex
@@ -844,6 +1634,7 @@ func startValidator(
         return nil, nil, fmt.Errorf("failed to initialize BLS key: %w", err)
     }
     blsPubKeyHex := blsKeyManager.GetPublicKeyHex()
+    identity.BLSPublicKey = blsPubKeyHex
     log.Printf("✅ BLS key initialized: %s...%s (path: %s)",
         blsPubKeyHex[:16],
         blsPubKeyHex[len(blsPubKeyHex)-8:],
@@ -871,33 +1662,45 @@ func startValidator(
     if govWorkDir == "" {
         govWorkDir = filepath.Join("data", "gov_proofs")
     }
-    cliGovProofGen, govErr := proof.NewCLIGovernanceProofGenerator(
-        govProofPath,
-        cfg.AccumulateURL,
-        govWorkDir,
-        60*time.Second,
-    )
-    if govErr != nil {
-        log.Printf("⚠️ [GOV-PROOF] CLI governance proof generator init failed: %v (proofs will be skipped)", govErr)
-        // Fall back to in-process generator
-        governanceProofGen = proof.NewInProcessGovernanceGenerator(
+    var govResultCache *proof.GovernanceResultCache
+    if cfg.GovProofCacheSize > 0 {
+        govResultCache = proof.NewGovernanceResultCache(cfg.GovProofCacheSize, cfg.GovProofCacheTTL)
+        log.Printf("✅ Governance proof result cache enabled (size=%d, ttl=%s)", cfg.GovProofCacheSize, cfg.GovProofCacheTTL)
+    }
+    // The govproof CLI is only needed when explicitly configured; otherwise proofs are
+    // generated in-process with the native G0/G1/G2 implementation
+    var cliGovProofGen *proof.CLIGovernanceProofGenerator
+    if govProofPath != "" {
+        var govErr error
+        cliGovProofGen, govErr = proof.NewCLIGovernanceProofGenerator(
+            govProofPath,
             cfg.AccumulateURL,
             govWorkDir,
             60*time.Second,
         )
-        log.Printf("✅ In-process governance proof generator initialized (G0/G1/G2)")
-    } else {
+        if govErr != nil {
+            log.Printf("⚠️ [GOV-PROOF] CLI governance proof generator init failed: %v (falling back to in-process proofs)", govErr)
+            cliGovProofGen = nil
+        }
+    }
+    if cliGovProofGen != nil {
         // Set txhash path for G2 payload verification
         if txhashPath != "" {
             cliGovProofGen.SetTxHashPath(txhashPath)
             log.Printf("✅ TxHash tool configured for G2 payload verification: %s", txhashPath)
         }
+        cliGovProofGen.SetResultCache(govResultCache)
         governanceProofGen = cliGovProofGen
-        if govProofPath != "" {
-            log.Printf("✅ CLI governance proof generator initialized: %s", govProofPath)
-        } else {
-            log.Printf("✅ Governance proof generator initialized (CLI not configured, using stub)")
-        }
+        log.Printf("✅ CLI governance proof generator initialized: %s", govProofPath)
+    } else {
+        inProcessGovProofGen := proof.NewInProcessGovernanceGenerator(
+            cfg.AccumulateURL,
+            govWorkDir,
+            60*time.Second,
+        )
+        inProcessGovProofGen.SetResultCache(govResultCache)
+        governanceProofGen = inProcessGovProofGen
+        log.Printf("✅ In-process governance proof generator initialized (G0/G1/G2/G3)")
     }
 
     // Create BFT validator with engine injection (NEW SIGNATURE)
@@ -907,7 +1710,7 @@ func startValidator(
         consensusParams,
         cfg.ValidatorID,
         cfg.ChainID, // CometBFT chain ID from config
-        privateKey,
+        validatorKey,
         anchorWrapper,
         proofGenerator,
         governanceProofGen, // G0/G1/G2 governance proof generator (runs AFTER L1-L4)
@@ -925,6 +1728,7 @@ func startValidator(
 
     // --- Create anchor manager now that engine is configured ---
     var anchorManager *anchor.AnchorManager
+    var votingPowerTracker *anchor.VotingPowerTracker
     if ledgerProvider := cometEngine.GetLedgerStoreProvider(); ledgerProvider != nil && ledgerProvider.GetLedgerStore() != nil {
         anchorLogger := log.New(log.Writer(), "[AnchorManager] ", log.LstdFlags)
         anchorManager, err = anchor.NewAnchorManager(liteClientAdapter, cfg, proofGenerator, ledgerProvider.GetLedgerStore(), anchorLogger)
@@ -934,12 +1738,116 @@ func startValidator(
         // Now create the wrapper with the real anchor manager
         anchorWrapper = execution.NewAnchorManagerWrapper(anchorManager)
         log.Printf("✅ AnchorManager created with LedgerStore integration")
+        anchorManager.SetProofSizeLimits(anchor.ProofSizeLimits{
+            MaxProofHashes:   cfg.AnchorMaxProofHashes,
+            MaxCalldataBytes: cfg.AnchorMaxCalldataBytes,
+        })
+        anchorManager.SetMerkleRootGuard(cfg.AnchorMerkleRootGuard)
+        if cfg.ShadowMode {
+            anchorManager.SetShadowMode(true)
+            log.Printf("🕶️ SHADOW MODE - anchors and proofs are estimated and recorded as shadow, never sent")
+        }
+        anchorManager.SetAnchorRetryPolicy(anchor.AnchorRetryPolicy{
+            MaxAttempts:    cfg.AnchorRetryMaxAttempts,
+            InitialBackoff: cfg.AnchorRetryInitialBackoff,
+            MaxBackoff:     cfg.AnchorRetryMaxBackoff,
+        })
+        if cfg.OnDemandFeeEscalationEnabled {
+            anchorManager.SetFeeEscalation(&ethereum.FeeEscalationSchedule{
+                Interval:        cfg.OnDemandFeeEscalationInterval,
+                BumpPercent:     cfg.OnDemandFeeEscalationBumpPercent,
+                MaxReplacements: cfg.OnDemandFeeEscalationMaxSteps,
+                MaxGasPrice:     new(big.Int).Mul(big.NewInt(cfg.OnDemandFeeEscalationMaxGwei), big.NewInt(1e9)),
+            })
+            log.Printf("✅ On-demand fee escalation enabled (every %s, +%d%%, max %d replacements, ceiling %d gwei)",
+                cfg.OnDemandFeeEscalationInterval, cfg.OnDemandFeeEscalationBumpPercent,
+                cfg.OnDemandFeeEscalationMaxSteps, cfg.OnDemandFeeEscalationMaxGwei)
+        }
+        if cfg.AnchorStuckTxReplaceEnabled {
+            anchorManager.SetStuckTxReplacement(&ethereum.FeeEscalationSchedule{
+                Interval:        cfg.AnchorStuckTxTimeout,
+                BumpPercent:     cfg.AnchorStuckTxBumpPercent,
+                MaxReplacements: cfg.AnchorStuckTxMaxBumps,
+                MaxGasPrice:     new(big.Int).Mul(big.NewInt(cfg.AnchorStuckTxMaxGwei), big.NewInt(1e9)),
+            })
+            log.Printf("✅ Stuck anchor transaction replacement enabled (after %s, +%d%%, max %d bumps, ceiling %d gwei)",
+                cfg.AnchorStuckTxTimeout, cfg.AnchorStuckTxBumpPercent,
+                cfg.AnchorStuckTxMaxBumps, cfg.AnchorStuckTxMaxGwei)
+        }
+        if cfg.DebugProofOverrides != "" {
+            overrides, err := anchor.ParseProofFieldOverrides(cfg.DebugProofOverrides)
+            if err != nil {
+                return nil, nil, fmt.Errorf("invalid DEBUG_PROOF_OVERRIDES: %w", err)
+            }
+            if err := anchorManager.SetProofFieldOverrides(overrides); err != nil {
+                return nil, nil, fmt.Errorf("DEBUG_PROOF_OVERRIDES: %w", err)
+            }
+            log.Printf("🚨🚨🚨 DEBUG PROOF FIELD OVERRIDES ACTIVE - DO NOT RUN AGAINST A PRODUCTION VERIFIER: %s", overrides)
+        }
+        if cfg.VotingPowerTrackingEnabled {
+            votingPowerTracker, err = newVotingPowerTracker(cfg, ethClient)
+            if err != nil {
+                log.Printf("⚠️ Voting power tracking disabled: %v", err)
+            } else {
+                anchorManager.SetVotingPowerTracker(votingPowerTracker)
+                log.Printf("✅ Voting power tracking enabled (policy=%s)", votingPowerTracker.Status().Policy)
+            }
+        }
+
+        // Check the contract's governance verifier at startup and periodically
+        govPolicy, err := anchor.ParseGovernanceVerifierPolicy(cfg.GovernanceVerifierPolicy)
+        if err != nil {
+            return nil, nil, fmt.Errorf("invalid GOVERNANCE_VERIFIER_POLICY: %w", err)
+        }
+        govMonitor, err := anchorManager.StartGovernanceVerifierMonitor(context.Background(), govPolicy, cfg.GovernanceVerifierCheckInterval)
+        if err != nil {
+            log.Printf("⚠️ Governance verifier monitor not started: %v", err)
+        } else {
+            govMonitor.SetOnStatusChange(func(h anchor.GovernanceVerifierHealth) {
+                healthStatus.SetGovernanceVerifier(govMonitor, h.Status)
+            })
+            healthStatus.SetGovernanceVerifier(govMonitor, govMonitor.Health().Status)
+            shutdown.Register(ShutdownStopTrackers, "governance-verifier-monitor", func(ctx context.Context) error {
+                govMonitor.Stop()
+                return nil
+            })
+        }
     } else {
         return nil, nil, fmt.Errorf("ABCI application or ledger store not available for anchor manager")
     }
 
     log.Printf("✅ Unified BFT consensus with real CometBFT networking active for validator: %s", cfg.ValidatorID)
 
+    // Validator set registered on-chain: sizes the consensus quorum and weighs attestations
+    var validatorSetSyncer *execution.ValidatorSetSyncer
+    if cfg.ValidatorSetSyncEnabled {
+        if syncer, err := newValidatorSetSyncer(cfg, ethClient); err != nil {
+            log.Printf("⚠️ Validator set sync disabled: %v", err)
+        } else {
+            validatorSetSyncer = syncer
+            if err := validatorSetSyncer.Start(context.Background()); err != nil {
+                log.Printf("⚠️ Initial validator set sync failed, using the configured validator set until it succeeds: %v", err)
+            } else {
+                status := validatorSetSyncer.Status()
+                log.Printf("✅ Validator set synced from contract: %d validators, total voting power %s (refresh every %s)",
+                    status.ValidatorCount, status.TotalVotingPower, cfg.ValidatorSetSyncInterval)
+            }
+
+            // Block executors are selected from the on-chain set, weighted by voting power
+            if synced := validatorSetSyncer.Current(); synced != nil {
+                validator.SetExecutorSet(executorValidatorSet(synced))
+            }
+            validatorSetSyncer.OnChange(func(vs *execution.ValidatorSet) {
+                validator.SetExecutorSet(executorValidatorSet(vs))
+            })
+
+            shutdown.Register(ShutdownStopTrackers, "validator-set-sync", func(ctx context.Context) error {
+                validatorSetSyncer.Stop()
+                return nil
+            })
+        }
+    }
+
     // ==========================================================================
     // PHASE 5: Wire Batch System for Real Merkle Roots
     // Per Implementation Plan: Connect batch collector/processor to AnchorManager
@@ -954,16 +1862,42 @@ func startValidator(
         // Wire repositories to ValidatorApp for consensus persistence
         // This enables the ABCI Commit() function to persist consensus entries and batch attestations
         cometEngine.SetValidatorRepositories(repos)
-        cometEngine.SetValidatorCount(7) // 7 validators in the network
+        validatorCount := 7 // 7 validators in the network until the on-chain set is known
+        if validatorSetSyncer != nil {
+            if synced := validatorSetSyncer.Current(); synced != nil {
+                validatorCount = synced.ValidatorCount
+            }
+            validatorSetSyncer.OnChange(func(vs *execution.ValidatorSet) {
+                cometEngine.SetValidatorCount(vs.ValidatorCount)
+            })
+        }
+        cometEngine.SetValidatorCount(validatorCount)
         log.Println("✅ [Phase 5] Database repositories wired to ValidatorApp for consensus persistence")
 
+        // Merkle leaf layout expected by the anchoring contract; collector, processor and
+        // regenerator must agree or rebuilt roots will not match anchored ones
+        leafEncodings, err := batch.ParseLeafEncodingSelector(cfg.BatchLeafEncoding, cfg.BatchLeafEncodingOverrides)
+        if err != nil {
+            return nil, nil, fmt.Errorf("invalid BATCH_LEAF_ENCODING configuration: %w", err)
+        }
+        leafEncoding := leafEncodings.For(fmt.Sprintf("%d", cfg.EthChainID), cfg.CertenContractAddress)
+        log.Printf("🌿 [Phase 5] Merkle leaf encoding: %s", leafEncoding)
+
         // Create batch collector configuration
         collectorCfg := &batch.CollectorConfig{
             ValidatorID:  cfg.ValidatorID,
             MaxBatchSize: 1000,             // Max 1000 txs per batch
-            BatchTimeout: 15 * time.Minute, // ~15 min batches per whitepaper
+            BatchTimeout: cfg.OnCadenceBatchInterval, // ON_CADENCE_BATCH_INTERVAL, default 15m per whitepaper
             MaxOnDemand:  5,                // Small on-demand batches for immediate anchoring
+            LeafEncoding: leafEncoding,
+            DeterministicBatchID: cfg.DeterministicBatchID, // DETERMINISTIC_BATCH_ID, default random
+            DedupCacheSize: cfg.BatchDedupCacheSize,
+            DedupCacheTTL:  cfg.BatchDedupCacheTTL,
             Logger:       log.New(log.Writer(), "[BatchCollector] ", log.LstdFlags),
+            Metrics:      batchMetrics,
+        }
+        if cfg.DeterministicBatchID {
+            log.Printf("🆔 [Phase 5] Deterministic batch IDs enabled: batches are renamed to a content-derived ID on close")
         }
 
         // Create batch collector
@@ -973,12 +1907,37 @@ func startValidator(
         }
         log.Println("✅ [Phase 5] Batch collector created")
 
+        // Per-account proof metering with optional quotas
+        var usageMeter *batch.UsageMeter
+        if cfg.ProofMeteringEnabled {
+            windows, err := batch.ParseUsageWindows(cfg.ProofQuotaWindows)
+            if err != nil {
+                return nil, nil, fmt.Errorf("invalid PROOF_QUOTA_WINDOWS: %w", err)
+            }
+            quotaAction, err := batch.ParseQuotaAction(cfg.ProofQuotaAction)
+            if err != nil {
+                return nil, nil, fmt.Errorf("invalid PROOF_QUOTA_ACTION: %w", err)
+            }
+            usageMeter, err = batch.NewUsageMeter(repos.Usage, &batch.UsageMeterConfig{
+                Windows:   windows,
+                Action:    quotaAction,
+                MaxQueued: cfg.ProofQuotaMaxQueued,
+                Logger:    log.New(log.Writer(), "[UsageMeter] ", log.LstdFlags),
+            })
+            if err != nil {
+                return nil, nil, fmt.Errorf("failed to create usage meter: %w", err)
+            }
+            collector.SetUsageMeter(usageMeter)
+            log.Printf("✅ Proof usage metering enabled (windows: %s, quotas: %v, action: %s)",
+                cfg.ProofQuotaWindows, usageMeter.QuotasEnabled(), quotaAction)
+        }
+
         // Create anchor adapter that bridges batch.Processor to AnchorManager
         // This uses the REAL Merkle roots from closed batches
         anchorManagerWrapper := batch.NewAnchorManagerWrapper(func(ctx context.Context, batchID string, merkleRoot, opCommit, crossCommit, govRoot []byte,
-            txCount int, accumHeight int64, accumHash, targetChain, validatorID string) (
+            txCount int, accumHeight int64, accumHash, targetChain, validatorID string, urgent bool) (
             txHash string, blockNumber int64, blockHash string, gasUsed int64,
-            gasPriceWei, totalCostWei string, success bool, err error) {
+            gasPriceWei, totalCostWei string, fees batch.AnchorFees, success, shadow bool, err error) {
 
             // Call the real AnchorManager's CreateBatchAnchorOnChain
             req := &anchor.AnchorOnChainRequest{
@@ -992,19 +1951,77 @@ func startValidator(
                 AccumulateHash:       accumHash,
                 TargetChain:          targetChain,
                 ValidatorID:          validatorID,
+                Urgent:               urgent,
             }
             result, err := anchorManager.CreateBatchAnchorOnChain(ctx, req)
             if err != nil {
-                return "", 0, "", 0, "", "", false, err
+                return "", 0, "", 0, "", "", batch.AnchorFees{}, false, false, err
+            }
+            fees = batch.AnchorFees{
+                BaseFeeWei:     result.BaseFeeWei,
+                PriorityFeeWei: result.PriorityFeeWei,
+                FeeStrategy:    result.FeeStrategy,
+                GasBumped:      result.GasBumped,
+            }
+            for _, step := range result.FeeEscalation {
+                fees.Escalation = append(fees.Escalation, batch.FeeEscalationStep{
+                    Attempt:     step.Attempt,
+                    TxHash:      step.TxHash,
+                    GasPriceWei: step.GasPrice.String(),
+                    SubmittedAt: step.SubmittedAt,
+                })
             }
             return result.TxHash, result.BlockNumber, result.BlockHash,
-                result.GasUsed, result.GasPriceWei, result.TotalCostWei, result.Success, nil
+                result.GasUsed, result.GasPriceWei, result.TotalCostWei, fees, result.Success, result.Shadow, nil
         })
 
         // Wire the ExecuteComprehensiveProofOnChain function to enable Ethereum proof execution
         // Per CRITICAL-001: This MUST be set for comprehensive proofs to be submitted on-chain
         anchorManagerWrapper.SetExecuteProofFunc(anchorManager.ExecuteComprehensiveProofOnChain)
         log.Println("✅ [Phase 5] ExecuteComprehensiveProofOnChain wired to anchor manager")
+        anchorManagerWrapper.SetVerifyProofFunc(anchorManager.VerifyComprehensiveProofOnChain)
+        anchorManagerWrapper.SetLookupAnchorFunc(anchorManager.LookupBatchAnchorOnChain)
+        anchorManagerWrapper.SetEstimateFunc(func(ctx context.Context, req *batch.AnchorOnChainRequest) (*batch.AnchorCostEstimate, error) {
+            estimate, err := anchorManager.EstimateBatchAnchorOnChain(ctx, &anchor.AnchorOnChainRequest{
+                BatchID:              req.BatchID,
+                MerkleRoot:           req.MerkleRoot,
+                OperationCommitment:  req.OperationCommitment,
+                CrossChainCommitment: req.CrossChainCommitment,
+                GovernanceRoot:       req.GovernanceRoot,
+                TxCount:              req.TxCount,
+                AccumulateHeight:     req.AccumulateHeight,
+                AccumulateHash:       req.AccumulateHash,
+                TargetChain:          req.TargetChain,
+                ValidatorID:          req.ValidatorID,
+                Urgent:               req.Urgent,
+            })
+            if err != nil {
+                return nil, err
+            }
+            return &batch.AnchorCostEstimate{
+                TargetChain:  estimate.ChainName,
+                GasLimit:     estimate.GasLimit,
+                GasPriceWei:  estimate.GasPrice.String(),
+                TotalCostWei: estimate.TotalCost.String(),
+            }, nil
+        })
+        anchorManagerWrapper.SetAnchorStateFunc(func(ctx context.Context, batchID string) (*batch.OnChainAnchorState, error) {
+            exists, stored, stats, err := anchorManager.GetBatchAnchorState(ctx, batchID)
+            if err != nil {
+                return nil, err
+            }
+            state := &batch.OnChainAnchorState{Exists: exists}
+            if stored != nil {
+                state.Valid = stored.Valid
+                state.OperationCommitment = stored.OperationCommitment[:]
+                state.AccumulateHeight = int64(stored.AccumulateBlockHeight)
+            }
+            if stats != nil {
+                state.Verifications = stats.Total
+                state.SuccessfulVerifications = stats.Successful
+            }
+            return state, nil
+        })
 
         anchorAdapter := batch.NewAnchorAdapter(
             anchorManagerWrapper,
@@ -1012,6 +2029,25 @@ func startValidator(
         )
         log.Println("✅ [Phase 5] Anchor adapter created for real Merkle root anchoring")
 
+        // On-chain proof verification with an optional batch inclusion re-check of failing proofs
+        inclusionRechecker, err := batch.NewInclusionRechecker(
+            batch.NewProofRegenerationStore(repos),
+            anchorAdapter,
+            &batch.InclusionRecheckerConfig{
+                AutoRegenerate: cfg.ProofAutoRegenerate,
+                ProofCooldown:  cfg.ProofRegenerateCooldown,
+                MaxPerHour:     cfg.ProofRegenerateMaxPerHour,
+                ValidatorID:    cfg.ValidatorID,
+                LeafEncoding:   leafEncoding,
+                Logger:         log.New(log.Writer(), "[InclusionRecheck] ", log.LstdFlags),
+            },
+        )
+        if err != nil {
+            return nil, nil, fmt.Errorf("failed to create inclusion rechecker: %w", err)
+        }
+        log.Printf("✅ Inclusion rechecker created (auto-rebuild: %v, max %d/hour)",
+            cfg.ProofAutoRegenerate, cfg.ProofRegenerateMaxPerHour)
+
         // Create batch processor configuration
         processorCfg := &batch.ProcessorConfig{
             ValidatorID:     cfg.ValidatorID,
@@ -1020,6 +2056,11 @@ func startValidator(
             NetworkName:     cfg.NetworkName, // From NETWORK_NAME env var, defaults to "devnet"
             ContractAddress: cfg.CertenContractAddress,
             Logger:          log.New(log.Writer(), "[BatchProcessor] ", log.LstdFlags),
+            AvailableConfirmations: cfg.AnchorAvailableConfirmations,
+            RequiredConfirmations:  cfg.AnchorFinalConfirmations,
+            LeafEncoding:           leafEncoding,
+            GovernanceBatchDedup:   cfg.GovernanceBatchDedup,
+            Metrics:                batchMetrics,
         }
 
         // Create batch processor
@@ -1029,10 +2070,57 @@ func startValidator(
         }
         log.Println("✅ [Phase 5] Batch processor created")
 
+        // Reconcile batches left closed/failed by a crash after their anchor landed on-chain,
+        // so they are marked anchored instead of being re-submitted. Batches interrupted while
+        // anchoring are marked failed and re-anchored once the batch system is wired.
+        var interruptedBatches []uuid.UUID
+        if cfg.AnchorReconcileOnStartup {
+            reconciler, err := batch.NewAnchorReconciler(repos.Batches, anchorManagerWrapper, &batch.AnchorReconcilerConfig{
+                MaxAge: cfg.AnchorReconcileMaxAge,
+                Logger: log.New(log.Writer(), "[AnchorReconciler] ", log.LstdFlags),
+            })
+            if err != nil {
+                return nil, nil, fmt.Errorf("failed to create anchor reconciler: %w", err)
+            }
+            reconcileCtx, cancelReconcile := context.WithTimeout(context.Background(), 2*time.Minute)
+            report, err := reconciler.Reconcile(reconcileCtx)
+            cancelReconcile()
+            if err != nil {
+                log.Printf("⚠️ Anchor reconciliation failed: %v", err)
+            } else {
+                log.Printf("✅ Anchor reconciliation: %d batches checked, %d already anchored on-chain, %d interrupted while anchoring",
+                    report.Checked, len(report.MarkedAnchored), len(report.Interrupted))
+                interruptedBatches = report.Interrupted
+            }
+        }
+
+        // Periodically audit anchor records against the contract and flag discrepancies
+        // (missing, invalidated or mismatched anchors)
+        var anchorStateReconciler *batch.AnchorStateReconciler
+        if cfg.AnchorStateReconcileEnabled {
+            anchorStateReconciler, err = batch.NewAnchorStateReconciler(repos.Anchors, anchorManagerWrapper, &batch.AnchorStateReconcilerConfig{
+                SampleSize:    cfg.AnchorStateReconcileSampleSize,
+                CheckInterval: cfg.AnchorStateReconcileInterval,
+                MinAge:        cfg.AnchorStateReconcileMinAge,
+                Logger:        log.New(log.Writer(), "[AnchorStateReconciler] ", log.LstdFlags),
+            })
+            if err != nil {
+                return nil, nil, fmt.Errorf("failed to create anchor state reconciler: %w", err)
+            }
+            anchorStateReconciler.Start(context.Background())
+            shutdown.Register(ShutdownStopTrackers, "anchor-state-reconciler", func(ctx context.Context) error {
+                anchorStateReconciler.Stop()
+                return nil
+            })
+            healthStatus.SetAnchorDiscrepancySource(anchorStateReconciler.OpenDiscrepancies)
+            log.Printf("✅ Anchor state reconciliation enabled (every %s, sample %d)",
+                cfg.AnchorStateReconcileInterval, cfg.AnchorStateReconcileSampleSize)
+        }
+
         // Wire Firestore sync service to batch collector and processor
-        if firestoreSyncService != nil {
-            collector.SetFirestoreSyncService(firestoreSyncService)
-            processor.SetFirestoreSyncService(firestoreSyncService)
+        collector.SetFirestoreSyncService(firestoreSyncService)
+        processor.SetFirestoreSyncService(firestoreSyncService)
+        if firestoreSyncService.IsEnabled() {
             log.Println("✅ [Firestore] Sync service wired to batch collector and processor")
         }
 
@@ -1040,13 +2128,32 @@ func startValidator(
         // See below after attestation service initialization
 
         // Create scheduler configuration
+        // Process the closed batch (create anchor, store proofs)
+        onCadenceReady := batch.BatchReadyCallback(processor.ProcessClosedBatch)
+
+        // Gas-aware scheduling: hold on-cadence batches while gas is above target
+        var gasWindow *batch.GasWindow
+        if cfg.GasWindowEnabled {
+            gasWindowCfg := &batch.GasWindowConfig{
+                TargetGasPrice: new(big.Int).Mul(big.NewInt(cfg.GasWindowTargetGwei), big.NewInt(1_000_000_000)),
+                MaxDelay:       cfg.GasWindowMaxDelay,
+                CheckInterval:  cfg.GasWindowCheckInterval,
+                Logger:         log.New(log.Writer(), "[GasWindow] ", log.LstdFlags),
+            }
+            gasWindow, err = batch.NewGasWindow(ethClient.GetGasPrice, onCadenceReady, gasWindowCfg)
+            if err != nil {
+                return nil, nil, fmt.Errorf("failed to create gas window: %w", err)
+            }
+            onCadenceReady = gasWindow.Callback
+            gasWindow.Start(context.Background())
+            log.Printf("✅ [Phase 5] Gas-aware anchoring enabled (target=%d gwei, max delay=%s)",
+                cfg.GasWindowTargetGwei, cfg.GasWindowMaxDelay)
+        }
+
         schedulerCfg := &batch.SchedulerConfig{
-            Interval:      15 * time.Minute, // ~15 min batches per whitepaper
+            Interval:      cfg.OnCadenceBatchInterval, // ON_CADENCE_BATCH_INTERVAL, default 15m per whitepaper
             CheckInterval: 1 * time.Minute,  // Check every minute
-            Callback: func(ctx context.Context, result *batch.ClosedBatchResult) error {
-                // Process the closed batch (create anchor, store proofs)
-                return processor.ProcessClosedBatch(ctx, result)
-            },
+            Callback:      onCadenceReady,
             GetAccumState: func() (int64, string) {
                 // Get current Accumulate state from lite client
                 // Uses the LiteClientProofGenerator to query consensus state
@@ -1058,6 +2165,11 @@ func startValidator(
                 return state.BlockHeight, state.BlockHash
             },
             Logger: log.New(log.Writer(), "[BatchScheduler] ", log.LstdFlags),
+
+            // Stagger batch closes so validators don't all anchor at the same moment
+            ValidatorID: cfg.ValidatorID,
+            PhaseSpread: cfg.BatchPhaseSpread, // BATCH_PHASE_SPREAD, default 2m
+            MaxJitter:   cfg.BatchCloseJitter, // BATCH_CLOSE_JITTER, default 30s
         }
 
         // Create batch scheduler
@@ -1068,15 +2180,38 @@ func startValidator(
         log.Println("✅ [Phase 5] Batch scheduler created")
 
         // Start the batch scheduler
-        if err := batchScheduler.Start(context.Background()); err != nil {
+        if err := batchScheduler.Start(ctx); err != nil {
             return nil, nil, fmt.Errorf("failed to start batch scheduler: %w", err)
         }
-        log.Println("🚀 [Phase 5] Batch scheduler started - processing ~15 min on-cadence batches")
+        log.Printf("🚀 [Phase 5] Batch scheduler started - processing %s on-cadence batches", cfg.OnCadenceBatchInterval)
+
+        // On shutdown, stop the close loop and drain the open batch straight to the
+        // processor (bypassing any gas window hold), bounded by SHUTDOWN_DRAIN_TIMEOUT
+        shutdown.Register(ShutdownFlushBatches, "on-cadence-batch", func(ctx context.Context) error {
+            if err := batchScheduler.Stop(); err != nil {
+                return err
+            }
+            drainCtx, cancel := context.WithTimeout(ctx, cfg.ShutdownDrainTimeout)
+            defer cancel()
+            report, err := collector.Drain(drainCtx, processor.ProcessClosedBatch, schedulerCfg.GetAccumState)
+            if report != nil && report.Flushed+report.Abandoned > 0 {
+                log.Printf("[Shutdown] On-cadence batch drain: %d transactions flushed, %d abandoned",
+                    report.Flushed, report.Abandoned)
+            }
+            return err
+        })
+        if gasWindow != nil {
+            shutdown.Register(ShutdownFlushBatches, "gas-window", func(ctx context.Context) error {
+                gasWindow.Stop()
+                gasWindow.Flush(ctx)
+                return nil
+            })
+        }
 
         // Create on-demand handler for immediate anchoring (~$0.25/proof)
         onDemandCfg := &batch.OnDemandConfig{
             MaxBatchSize: 5,
-            MaxWaitTime:  30 * time.Second,
+            MaxWaitTime:  cfg.OnDemandMaxWait, // ON_DEMAND_MAX_WAIT, default 30s
             Callback: func(ctx context.Context, result *batch.ClosedBatchResult) error {
                 return processor.ProcessClosedBatch(ctx, result)
             },
@@ -1088,12 +2223,64 @@ func startValidator(
             return nil, nil, fmt.Errorf("failed to create on-demand handler: %w", err)
         }
         log.Println("✅ [Phase 5] On-demand handler created for immediate anchoring")
+        shutdown.Register(ShutdownFlushBatches, "on-demand-batch", func(ctx context.Context) error {
+            _, err := onDemandHandler.FlushBatch(ctx)
+            return err
+        })
+        shutdown.Register(ShutdownFinishAnchoring, "batch-processor", processor.WaitIdle)
+
+        // Give on-demand batches that never anchor a terminal state: abandon them,
+        // record the outcome per request and refund the metered usage
+        var onDemandAbandoner *batch.OnDemandAbandoner
+        if cfg.OnDemandAbandonEnabled {
+            onDemandAbandoner, err = batch.NewOnDemandAbandoner(repos.Batches, &batch.OnDemandAbandonerConfig{
+                AbandonAfter:  cfg.OnDemandAbandonAfter,
+                CheckInterval: cfg.OnDemandAbandonCheckInterval,
+                ActiveBatch:   collector.GetOnDemandBatchInfo,
+                Logger:        log.New(log.Writer(), "[OnDemandAbandoner] ", log.LstdFlags),
+            })
+            if err != nil {
+                return nil, nil, fmt.Errorf("failed to create on-demand abandoner: %w", err)
+            }
+            if usageMeter != nil && cfg.OnDemandAbandonRefundUsage {
+                onDemandAbandoner.SetUsageRefunder(usageMeter)
+            }
+            onDemandAbandoner.Start(context.Background())
+            shutdown.Register(ShutdownStopTrackers, "on-demand-abandoner", func(ctx context.Context) error {
+                onDemandAbandoner.Stop()
+                return nil
+            })
+            log.Printf("✅ On-demand abandonment enabled (after %s, refunds: %v)",
+                cfg.OnDemandAbandonAfter, usageMeter != nil && cfg.OnDemandAbandonRefundUsage)
+        }
+
+        // Move batches whose anchors are final past the retention window to the archive
+        // tables; the confirmation tracker no longer follows final anchors
+        if cfg.ProofRetentionDays > 0 {
+            proofArchiver, err := batch.NewProofArchiver(repos.Archive, &batch.ProofArchiverConfig{
+                Retention:     time.Duration(cfg.ProofRetentionDays) * 24 * time.Hour,
+                CheckInterval: cfg.ProofArchiveInterval,
+                BatchLimit:    cfg.ProofArchiveBatchLimit,
+                Logger:        log.New(log.Writer(), "[ProofArchiver] ", log.LstdFlags),
+            })
+            if err != nil {
+                return nil, nil, fmt.Errorf("failed to create proof archiver: %w", err)
+            }
+            proofArchiver.Start(context.Background())
+            shutdown.Register(ShutdownStopTrackers, "proof-archiver", func(ctx context.Context) error {
+                proofArchiver.Stop()
+                return nil
+            })
+            log.Printf("✅ Proof archival enabled (retention: %d days, every %s)",
+                cfg.ProofRetentionDays, cfg.ProofArchiveInterval)
+        }
 
         // Create confirmation tracker for anchor finality monitoring
         confirmationCfg := &batch.ConfirmationTrackerConfig{
-            PollInterval:          30 * time.Second,
-            RequiredConfirmations: 12, // Standard Ethereum finality
-            Logger:                log.New(log.Writer(), "[ConfirmationTracker] ", log.LstdFlags),
+            PollInterval:           30 * time.Second,
+            AvailableConfirmations: cfg.AnchorAvailableConfirmations, // ANCHOR_AVAILABLE_CONFIRMATIONS, default 1
+            RequiredConfirmations:  cfg.AnchorFinalConfirmations,     // ANCHOR_FINAL_CONFIRMATIONS, default 12
+            Logger:                 log.New(log.Writer(), "[ConfirmationTracker] ", log.LstdFlags),
         }
 
         // Create Ethereum block provider using the Ethereum client
@@ -1115,15 +2302,29 @@ func startValidator(
             // Continue without confirmation tracking - it's not critical
         } else {
             // Wire Firestore sync service to confirmation tracker
-            if firestoreSyncService != nil {
-                confirmationTracker.SetFirestoreSyncService(firestoreSyncService)
-                log.Println("✅ [Firestore] Sync service wired to confirmation tracker")
-            }
+            confirmationTracker.SetFirestoreSyncService(firestoreSyncService)
+            // Follow replaced anchor transactions to whichever submission is mined
+            confirmationTracker.SetTransactionLocator(batch.TransactionLocatorFunc(func(ctx context.Context, txHash string) (*batch.MinedTransaction, error) {
+                receipt, err := ethClient.GetTransactionReceipt(ctx, txHash)
+                if err != nil || receipt == nil {
+                    return nil, err
+                }
+                return &batch.MinedTransaction{
+                    TxHash:      receipt.TxHash.Hex(),
+                    BlockNumber: receipt.BlockNumber.Int64(),
+                    BlockHash:   receipt.BlockHash.Hex(),
+                    GasUsed:     receipt.GasUsed,
+                    GasPrice:    receipt.EffectiveGasPrice,
+                }, nil
+            }))
             // Start the confirmation tracker
-            if err := confirmationTracker.Start(context.Background()); err != nil {
+            if err := confirmationTracker.Start(ctx); err != nil {
                 log.Printf("⚠️ [Phase 5] Failed to start confirmation tracker: %v", err)
             } else {
                 log.Println("✅ [Phase 5] Confirmation tracker started - monitoring anchor finality")
+                shutdown.Register(ShutdownStopTrackers, "confirmation-tracker", func(ctx context.Context) error {
+                    return confirmationTracker.Stop()
+                })
             }
         }
 
@@ -1132,13 +2333,32 @@ func startValidator(
         // Per Whitepaper Section 3.4.1 Component 4: Validator attestations
         // ==========================================================================
         var attestationService *attestation.Service
+        attestationPeerKeys, err := attestation.DecodePeerKeys(cfg.AttestationPeerKeys)
+        if err != nil {
+            return nil, nil, fmt.Errorf("invalid attestation peer keys: %w", err)
+        }
         attestationCfg := &attestation.Config{
             ValidatorID:   cfg.ValidatorID,
-            PrivateKey:    privateKey,
+            Signer:        validatorKey,
             PeerEndpoints: cfg.AttestationPeers,
             RequiredCount: cfg.AttestationRequiredCount,
             Timeout:       30 * time.Second,
-            Logger:        log.New(log.Writer(), "[Attestation] ", log.LstdFlags),
+            PeerHealth: attestation.PeerHealthConfig{
+                Interval:    cfg.AttestationPeerHealthInterval,
+                Timeout:     cfg.AttestationPeerHealthTimeout,
+                MaxFailures: cfg.AttestationPeerMaxFailures,
+            },
+            TLS: attestation.TLSConfig{
+                CertFile: cfg.AttestationTLSCert,
+                KeyFile:  cfg.AttestationTLSKey,
+                CABundle: cfg.AttestationCABundle,
+            },
+            PeerKeys: attestationPeerKeys,
+            Metrics:  batchMetrics,
+            BLSKeys:  blsKeyManager,
+            Logger:   log.New(log.Writer(), "[Attestation] ", log.LstdFlags),
+
+            ThresholdMode: cfg.AttestationThresholdMode,
         }
 
         attestationService, err = attestation.NewService(repos, attestationCfg)
@@ -1148,6 +2368,41 @@ func startValidator(
         } else {
             log.Printf("✅ [Phase 5] Attestation service created with %d peers", len(cfg.AttestationPeers))
 
+            // BLS attestations are aggregated against the registered on-chain validator set
+            if validatorSetSyncer != nil {
+                setAttestationValidators := func(vs *execution.ValidatorSet) {
+                    set, err := attestationValidatorSet(vs, cfg.AttestationThresholdNumerator, cfg.AttestationThresholdDenominator)
+                    if err != nil {
+                        log.Printf("⚠️ [Phase 5] Cannot aggregate BLS attestations: %v", err)
+                        return
+                    }
+                    attestationService.SetValidatorSet(set)
+                }
+                if synced := validatorSetSyncer.Current(); synced != nil {
+                    setAttestationValidators(synced)
+                }
+                validatorSetSyncer.OnChange(setAttestationValidators)
+                log.Printf("✅ [Phase 5] BLS attestation aggregation follows the on-chain validator set (threshold mode: %s, %d/%d of voting power)",
+                    cfg.AttestationThresholdMode, cfg.AttestationThresholdNumerator, cfg.AttestationThresholdDenominator)
+            } else {
+                log.Printf("⚠️ [Phase 5] No validator set sync - BLS attestations are collected but cannot be aggregated")
+                if cfg.AttestationThresholdMode == attestation.ThresholdModeVotingPower {
+                    log.Printf("⚠️ [Phase 5] Voting power threshold cannot be reached without a validator set")
+                }
+            }
+
+            // Skip unresponsive peers during collection instead of waiting out their timeout.
+            // Started even without peers so that peers added by a reload are checked too.
+            if err := attestationService.StartHealthChecks(context.Background()); err != nil {
+                log.Printf("⚠️ [Phase 5] Failed to start attestation peer health checks: %v", err)
+            } else {
+                log.Printf("✅ [Phase 5] Attestation peer health checks started (interval: %v)", cfg.AttestationPeerHealthInterval)
+                shutdown.Register(ShutdownStopTrackers, "attestation-peer-health", func(ctx context.Context) error {
+                    attestationService.StopHealthChecks()
+                    return nil
+                })
+            }
+
             // Wire attestation callback to batch processor
             // This triggers multi-validator attestation collection when a batch is anchored
             processor.SetOnAnchorCallback(func(ctx context.Context, batchID uuid.UUID, merkleRoot []byte, anchorTxHash string, txCount int, blockNumber int64) error {
@@ -1155,6 +2410,11 @@ func startValidator(
                 if err != nil {
                     return err
                 }
+                if status.ThresholdMode == attestation.ThresholdModeVotingPower {
+                    log.Printf("📜 Attestation status for batch %s: voting power %s/%s signed (sufficient: %v)",
+                        batchID, status.SignedVotingPower, status.TotalVotingPower, status.IsSufficient)
+                    return nil
+                }
                 log.Printf("📜 Attestation status for batch %s: %d/%d validators attested",
                     batchID, status.CollectedCount, status.RequiredCount)
                 return nil
@@ -1162,21 +2422,43 @@ func startValidator(
             log.Printf("✅ [Phase 5] Attestation callback wired to batch processor")
         }
 
+        // Anchor receipts: compact proof-of-anchoring signed with the validator's Ed25519 key
+        var receiptSigner *anchor_proof.AttestationSigner
+        if cfg.AnchorReceiptsEnabled {
+            switch database.AnchorFinality(cfg.AnchorReceiptFinality) {
+            case database.AnchorFinalityAvailable, database.AnchorFinalityFinal:
+            default:
+                return nil, nil, fmt.Errorf("invalid ANCHOR_RECEIPT_FINALITY %q: must be available or final", cfg.AnchorReceiptFinality)
+            }
+            receiptSigner, err = anchor_proof.NewAttestationSignerFromProvider(cfg.ValidatorID, validatorKey)
+            if err != nil {
+                return nil, nil, fmt.Errorf("failed to create anchor receipt signer: %w", err)
+            }
+            log.Printf("✅ [Phase 5] Anchor receipts enabled (min finality: %s)", cfg.AnchorReceiptFinality)
+        }
+
         // ==========================================================================
         // PHASE 4 Task 4.3: Event Watcher for Contract Event Monitoring
         // Per Implementation Plan: Monitor CertenAnchorV3 contract events
         // This provides visibility into on-chain anchor confirmations and proof executions
         // ==========================================================================
+        var verificationFailures *batch.VerificationFailureRecorder
         if cfg.CertenContractAddress != "" && cfg.EthereumURL != "" {
             eventWatcherConfig := &anchor.EventWatcherConfig{
-                ContractAddress: common.HexToAddress(cfg.CertenContractAddress),
-                EthereumURL:     cfg.EthereumURL,
-                ChainID:         cfg.EthChainID,
-                PollInterval:    30 * time.Second,
-                BlockLookback:   100,
-                EventBufferSize: 500,
-                RetryAttempts:   3,
-                RetryDelay:      5 * time.Second,
+                ContractAddress:  common.HexToAddress(cfg.CertenContractAddress),
+                EthereumURL:      cfg.EthereumURL,
+                ChainID:          cfg.EthChainID,
+                LogSource:        ethClient, // Follows the client's reconnects and failover
+                PollInterval:     30 * time.Second,
+                BlockLookback:    100,
+                MaxCatchUpBlocks: uint64(cfg.EventWatcherMaxCatchUpBlocks),
+                EventBufferSize:  500,
+                RetryAttempts:    3,
+                RetryDelay:       5 * time.Second,
+            }
+            // Resume from the last scanned block instead of the lookback after a restart
+            if cfg.EventWatcherCheckpointPath != "" {
+                eventWatcherConfig.Checkpoints = anchor.NewFileEventCheckpointStore(cfg.EventWatcherCheckpointPath)
             }
 
             eventWatcher, eventWatcherErr := anchor.NewEventWatcher(
@@ -1188,32 +2470,56 @@ func startValidator(
                 log.Printf("⚠️ [Phase 4] Failed to create event watcher: %v", eventWatcherErr)
             } else {
                 // Register handlers for contract events
-                eventWatcher.RegisterHandler(anchor.EventTypeAnchorCreated, func(event anchor.ContractEvent) error {
-                    e := event.(*anchor.AnchorCreatedEvent)
+                eventWatcher.OnAnchorCreated(func(e *anchor.AnchorCreatedEvent) error {
                     log.Printf("📡 [EventWatcher] AnchorCreated: bundleId=%x..., block=%d, validator=%s",
                         e.BundleID[:8], e.BlockNumber, e.Validator.Hex()[:10])
                     return nil
                 })
 
-                eventWatcher.RegisterHandler(anchor.EventTypeProofExecuted, func(event anchor.ContractEvent) error {
-                    e := event.(*anchor.ProofExecutedEvent)
+                eventWatcher.OnProofExecuted(func(e *anchor.ProofExecutedEvent) error {
                     log.Printf("📡 [EventWatcher] ProofExecuted: anchorId=%x..., merkle=%v, bls=%v, gov=%v",
                         e.AnchorID[:8], e.MerkleVerified, e.BLSVerified, e.GovernanceVerified)
                     return nil
                 })
 
-                eventWatcher.RegisterHandler(anchor.EventTypeProofVerificationFailed, func(event anchor.ContractEvent) error {
-                    e := event.(*anchor.ProofVerificationFailedEvent)
+                // Failed verifications are persisted, correlated to the local proof and batch,
+                // degrade health while recent and raise an alert when they repeat
+                verificationFailures, err = batch.NewVerificationFailureRecorder(repos.ProofFailures, &batch.VerificationFailureRecorderConfig{
+                    HealthWindow:   cfg.ProofFailureHealthWindow,
+                    AlertThreshold: cfg.ProofFailureAlertThreshold,
+                    AlertWindow:    cfg.ProofFailureAlertWindow,
+                    Logger:         log.New(log.Writer(), "[VerifyFailures] ", log.LstdFlags),
+                })
+                if err != nil {
+                    return nil, nil, fmt.Errorf("failed to create verification failure recorder: %w", err)
+                }
+                healthStatus.SetProofFailureStats(verificationFailures.Stats)
+                verificationFailures.SetAlertHandler(func(alert batch.VerificationFailureAlert) {
+                    log.Printf("🚨 [VerifyFailures] %d on-chain verification failures in %s (failed checks: %v, reasons: %q) - see GET /api/anchors/failures",
+                        alert.Failures, alert.Window, alert.FailedChecks, alert.Reasons)
+                })
+
+                eventWatcher.OnProofVerificationFailed(func(e *anchor.ProofVerificationFailedEvent) error {
                     log.Printf("⚠️ [EventWatcher] ProofVerificationFailed: anchorId=%x..., reason=%s",
                         e.AnchorID[:8], e.Reason)
-                    return nil
+                    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+                    defer cancel()
+                    return verificationFailures.HandleEvent(ctx, e)
                 })
 
+                // Re-registrations change this validator's voting power and the network total
+                if votingPowerTracker != nil {
+                    eventWatcher.RegisterHandler(anchor.EventTypeValidatorRegistered, votingPowerTracker.HandleEvent)
+                }
+
                 // Start the event watcher
                 if err := eventWatcher.Start(context.Background()); err != nil {
                     log.Printf("⚠️ [Phase 4] Failed to start event watcher: %v", err)
                 } else {
                     log.Printf("✅ [Phase 4] Event watcher started - monitoring contract %s", cfg.CertenContractAddress[:10])
+                    shutdown.Register(ShutdownStopTrackers, "event-watcher", func(ctx context.Context) error {
+                        return eventWatcher.Stop()
+                    })
                 }
             }
         } else {
@@ -1228,14 +2534,37 @@ func startValidator(
             OnDemandHandler:      onDemandHandler,
             ConfirmationTracker:  confirmationTracker,
             AttestationService:   attestationService,
+            InclusionRechecker:   inclusionRechecker,
+            UsageMeter:           usageMeter,
+            ReceiptSigner:        receiptSigner,
+            GasWindow:            gasWindow,
+            VerificationFailures: verificationFailures,
+            OnDemandAbandoner:    onDemandAbandoner,
+            VotingPower:          votingPowerTracker,
+            AnchorStateReconciler: anchorStateReconciler,
+            ProofCycleEvents:     execution.NewProofCycleEventBus(),
             Repos:                repos,
             FirestoreSyncService: firestoreSyncService,
         }
         // E.2 remediation: Update health status for batch system
         healthStatus.SetBatchSystem("active")
 
+        // Re-anchor batches whose anchor submission was interrupted by the last shutdown
+        if len(interruptedBatches) > 0 {
+            go func() {
+                for _, batchID := range interruptedBatches {
+                    result, err := processor.RetryFailedBatch(ctx, batchID)
+                    if err != nil {
+                        log.Printf("⚠️ Failed to re-anchor interrupted batch %s: %v", batchID, err)
+                        continue
+                    }
+                    log.Printf("✅ Retried interrupted batch %s (status %s)", batchID, result.Status)
+                }
+            }()
+        }
+
         // Log Firestore sync status
-        if firestoreSyncService != nil && firestoreSyncService.IsEnabled() {
+        if firestoreSyncService.IsEnabled() {
             log.Println("✅ [Firestore] Sync service wired to batch system - UI will receive real-time updates")
         } else {
             log.Println("⚠️ [Firestore] Sync service not enabled - web app will not receive real-time status updates")
@@ -1260,14 +2589,20 @@ func startValidator(
     accSignerURL := os.Getenv("ACCUMULATE_SIGNER_URL")
     writebackEnabled := os.Getenv("PROOF_CYCLE_WRITEBACK") == "true"
 
+    writebackFormat, err := execution.ParseWriteBackFormat(cfg.WriteBackEncoding, cfg.WriteBackFields, cfg.WriteBackRequiredFields)
+    if err != nil {
+        return nil, nil, fmt.Errorf("invalid write-back format: %w", err)
+    }
+
     if writebackEnabled && accWritebackPrincipal != "" && accSignerURL != "" {
         log.Printf("📝 [Phase 9] Configuring real Accumulate write-back:")
         log.Printf("   - Principal: %s", accWritebackPrincipal)
         log.Printf("   - Signer: %s", accSignerURL)
+        log.Printf("   - Format: %s (%d fields, 0 = all)", writebackFormat.Encoding, len(writebackFormat.Fields))
 
         // Check for optional separate write-back private key
         // This allows using a different key than the validator's key for signing write-back transactions
-        writebackPrivKey := privateKey
+        writebackSigner := validatorKey
         if writebackKeyHex := os.Getenv("ACCUMULATE_WRITEBACK_PRIV_KEY"); writebackKeyHex != "" {
             log.Printf("   - Using dedicated write-back private key from ACCUMULATE_WRITEBACK_PRIV_KEY")
             keyBytes, err := hex.DecodeString(strings.TrimSpace(writebackKeyHex))
@@ -1276,30 +2611,35 @@ func startValidator(
             } else if len(keyBytes) != ed25519.PrivateKeySize {
                 log.Printf("⚠️ [Phase 9] Invalid ACCUMULATE_WRITEBACK_PRIV_KEY size: expected %d, got %d (falling back to validator key)", ed25519.PrivateKeySize, len(keyBytes))
             } else {
-                writebackPrivKey = ed25519.PrivateKey(keyBytes)
+                writebackSigner, _ = keys.FromPrivateKey(ed25519.PrivateKey(keyBytes))
                 log.Printf("✅ [Phase 9] Loaded dedicated write-back private key")
             }
         }
 
         submitterCfg := &execution.AccumulateSubmitterConfig{
             Client:              liteClientAdapter,
-            PrivateKey:          writebackPrivKey,
+            Signer:              writebackSigner,
             AccountURL:          accWritebackPrincipal,
             SignerURL:           accSignerURL,
             KeyPageIndex:        1,
             KeyIndex:             0,
+            Format:              writebackFormat,
             ConfirmationTimeout: 2 * time.Minute,
             MaxRetries:          3,
             RetryDelay:          5 * time.Second,
+            Shadow:              cfg.ShadowMode,
             Logger:              log.New(log.Writer(), "[AccSubmitter] ", log.LstdFlags),
         }
 
-        var submitErr error
-        accSubmitter, submitErr = execution.NewAccumulateSubmitter(submitterCfg)
+        realSubmitter, submitErr := execution.NewAccumulateSubmitter(submitterCfg)
         if submitErr != nil {
             log.Printf("⚠️ [Phase 9] Failed to create Accumulate submitter: %v (using null submitter)", submitErr)
             accSubmitter = execution.NewNullAccumulateSubmitter(log.New(log.Writer(), "[NullSubmitter] ", log.LstdFlags))
         } else {
+            accSubmitter = realSubmitter
+            if batchComponents != nil {
+                batchComponents.WriteBackSubmitter = realSubmitter
+            }
             log.Printf("✅ [Phase 9] Real Accumulate submitter configured")
         }
     } else {
@@ -1318,14 +2658,27 @@ func startValidator(
         ThresholdDenominator:  3,
         AccumulatePrincipal:   accWritebackPrincipal,
         WriteBackEnabled:      writebackEnabled,
+        Shadow:                cfg.ShadowMode,
         BLSPrivateKey:         blsKeyManager.GetPrivateKeyBytes(),
+        Metrics:               batchMetrics,
     }
 
     // Get validator address from BLS public key
     validatorAddress := blsKeyManager.GetAddress()
 
-    // Create validator set (single validator for now, will load from config/contract later)
+    // Use the validator set synced from the contract, falling back to this validator alone
     validatorSet := execution.NewValidatorSetFromConfig(cfg.ValidatorID, validatorAddress)
+    validatorIndex := uint32(0)
+    if validatorSetSyncer != nil {
+        if synced := validatorSetSyncer.Current(); synced != nil {
+            validatorSet = synced
+            if self := synced.FindByID(cfg.ValidatorID); self != nil {
+                validatorIndex = self.Index
+            } else {
+                log.Printf("⚠️ [Phase 7-9] Validator %s is not in the on-chain validator set; its attestations will not count", cfg.ValidatorID)
+            }
+        }
+    }
 
     // Create Proof Cycle Orchestrator
     // Pass database repositories for proof artifact persistence (enables web app to track all 9 stages)
@@ -1336,7 +2689,7 @@ func startValidator(
     orchestrator, orchestratorErr := execution.NewProofCycleOrchestrator(
         cfg.ValidatorID,
         validatorAddress,
-        0, // validator index
+        validatorIndex,
         validatorSet,
         orchestratorConfig,
         accSubmitter,
@@ -1350,11 +2703,31 @@ func startValidator(
         // F.2 remediation: Update health status for proof cycle
         healthStatus.SetProofCycle("disabled")
     } else {
+        // Publish stage transitions for the proof progress stream
+        if batchComponents != nil {
+            orchestrator.SetEventBus(batchComponents.ProofCycleEvents)
+        }
+
+        // Weigh attestations against the current on-chain validator set
+        if validatorSetSyncer != nil {
+            validatorSetSyncer.OnChange(orchestrator.UpdateValidatorSet)
+        }
+
+        // Continue proof cycles interrupted by the last shutdown
+        if resumed, resumeErr := orchestrator.ResumePendingCycles(context.Background()); resumeErr != nil {
+            log.Printf("⚠️ [Phase 7-9] Failed to resume pending proof cycles: %v", resumeErr)
+        } else if resumed > 0 {
+            log.Printf("✅ [Phase 7-9] Resumed %d pending proof cycles", resumed)
+        }
+
         // ==========================================================================
         // UNIFIED MULTI-CHAIN ORCHESTRATOR (Feature Flag Controlled)
         // Per Unified Multi-Chain Architecture plan
         // ==========================================================================
-        if cfg.UseUnifiedOrchestrator {
+        if cfg.UseUnifiedOrchestrator && !hasRawKey {
+            // Its strategies and write-back builder sign with raw Ed25519 key bytes
+            log.Printf("⚠️ [Unified] Unified orchestrator needs a file-backed Ed25519 key (KEY_BACKEND=%s) - using legacy orchestrator", cfg.KeyBackend)
+        } else if cfg.UseUnifiedOrchestrator {
             log.Printf("🔄 [Unified] Initializing Unified Multi-Chain Orchestrator...")
 
             // Create strategy registry with all attestation and chain strategies
@@ -1377,6 +2750,21 @@ func startValidator(
                     log.Printf("   - Chained Proof Generator: disabled (no real proof builder)")
                 }
 
+                // Open the proof-cycle audit log; entries are signed with the validator key
+                var auditLog *execution.AuditLog
+                if cfg.AuditLogPath != "" {
+                    var auditErr error
+                    auditLog, auditErr = execution.OpenFileAuditLog(cfg.AuditLogPath, privateKey)
+                    if auditErr != nil {
+                        return nil, nil, fmt.Errorf("failed to open audit log: %w", auditErr)
+                    }
+                    shutdown.Register(ShutdownCloseClients, "audit-log", func(ctx context.Context) error {
+                        return auditLog.Close()
+                    })
+                    tip := auditLog.Tip()
+                    log.Printf("   - Audit Log: %s (sequence %d, tip %s)", cfg.AuditLogPath, tip.Sequence, tip.Hash)
+                }
+
                 // Create unified orchestrator configuration
                 unifiedConfig := &execution.UnifiedOrchestratorConfig{
                     ValidatorID:          cfg.ValidatorID,
@@ -1400,6 +2788,8 @@ func startValidator(
                     EnableWriteBack:      writebackEnabled,
                     ProofGenerator:       proofGenAdapter,
                     AccumulateQueryClient: liteClientAdapter, // For querying tx governance data (M-of-N threshold)
+                    MaxConcurrentCycles:  cfg.MaxConcurrentProofCycles,
+                    AuditLog:             auditLog,
                 }
 
                 unifiedOrchestrator, unifiedErr := execution.NewUnifiedOrchestrator(unifiedConfig)
@@ -1421,8 +2811,18 @@ func startValidator(
                         len(strategyRegistry.ListAttestationSchemes()),
                         len(strategyRegistry.ListChainIDs()))
                     log.Printf("   - Default Chain: %s", cfg.DefaultTargetChain)
+                    chainIDs := strategyRegistry.ListChainIDs()
+                    sort.Strings(chainIDs)
+                    for _, chainID := range chainIDs {
+                        identity.AddChain(server.IdentityChain{ChainID: chainID})
+                    }
                     log.Printf("   - Multi-Chain: %v", cfg.EnableMultiChain)
                     log.Printf("   - Unified Tables: %v", cfg.EnableUnifiedTables)
+                    log.Printf("   - Max Concurrent Cycles: %d (0 = unlimited)", cfg.MaxConcurrentProofCycles)
+                    healthStatus.SetProofCycleStats(unifiedOrchestrator.GetCycleStats)
+                    if auditLog != nil {
+                        healthStatus.SetAuditTip(auditLog.Tip)
+                    }
                     log.Printf("   - Fallback to Legacy: %v", cfg.FallbackToLegacy)
                     healthStatus.SetProofCycle("active")
 
@@ -1447,6 +2847,18 @@ func startValidator(
     // --- Intent discovery wiring ---
     log.Printf("🔍 Starting Certen Intent Discovery Service for validator...")
 
+    coldStartPolicy, err := intent.ParseColdStartPolicy(cfg.IntentColdStartPolicy)
+    if err != nil {
+        return nil, nil, fmt.Errorf("invalid INTENT_COLD_START_POLICY: %w", err)
+    }
+    if cfg.IntentColdStartLookback < 0 {
+        return nil, nil, fmt.Errorf("INTENT_COLD_START_LOOKBACK must not be negative")
+    }
+    priorityRules, err := intent.ParsePriorityRules(cfg.IntentPriorityRules)
+    if err != nil {
+        return nil, nil, fmt.Errorf("invalid INTENT_PRIORITY_RULES: %w", err)
+    }
+
     // Create IntentDiscovery configuration
     intentConfig := &intent.IntentDiscoveryConfig{
         BlockPollInterval:   5 * time.Second,
@@ -1454,6 +2866,21 @@ func startValidator(
         MaxConcurrentBlocks: 2000,  // Increased from 10 to handle high block rate
         IntentBatchSize:     100,   // Increased from 50 to process more intents per batch
         MinStartHeight:      0,
+        ColdStartPolicy:     coldStartPolicy,
+        ColdStartLookback:   uint64(cfg.IntentColdStartLookback),
+        ProofWorkPartitioning:  cfg.ProofWorkPartitioning,
+        ValidatorSet:           cfg.ValidatorSet,
+        ProofShareWait:         cfg.ProofShareWait,
+        ProofSharePollInterval: 2 * time.Second,
+        FinalityRetryDelay:     cfg.IntentFinalityRetryDelay,
+        MaxFinalityDeferrals:   cfg.IntentMaxFinalityDeferrals,
+        DedupMaxAge:            cfg.IntentDedupMaxAge,
+        DedupMaxEntries:        cfg.IntentDedupMaxEntries,
+        PriorityRules:          priorityRules,
+        SyntheticTxEnabled:      cfg.SyntheticTxEnabled,
+        SyntheticTxProofClass:   cfg.SyntheticTxProofClass,
+        SyntheticTxMaxPerIntent: cfg.SyntheticTxMaxPerIntent,
+        SyntheticTxFinalityWait: cfg.SyntheticTxFinalityWait,
     }
 
     // Get LedgerStore from ABCI application and wrap it for IntentDiscovery
@@ -1489,7 +2916,29 @@ func startValidator(
         log.Printf("   - G0/G1/G2 proofs generated before PostgreSQL persistence")
     }
 
+    // Proof-work partitioning: fetch owners' proofs from attestation peers, publish our own
+    // Shared proofs are signed with the validator key and checked against ATTESTATION_PEER_KEYS
+    if cfg.ProofWorkPartitioning {
+        sharedProofKeys, err := attestation.DecodePeerKeys(cfg.AttestationPeerKeys)
+        if err != nil {
+            return nil, nil, fmt.Errorf("invalid attestation peer keys: %w", err)
+        }
+        peerSource := intent.NewPeerSharedProofSource(
+            cfg.ValidatorID,
+            cfg.AttestationPeers,
+            sharedProofKeys,
+            5*time.Second,
+            log.New(log.Writer(), "[SharedProof] ", log.LstdFlags),
+        )
+        intentDiscovery.SetSharedProofExchange(peerSource, sharedProofCache, validatorKey)
+        log.Printf("✅ Proof-work partitioning wired to intent discovery (%d peers)", len(cfg.AttestationPeers))
+    }
+
     go intentDiscovery.StartMonitoring()
+    shutdown.Register(ShutdownStopIntake, "intent-discovery", func(ctx context.Context) error {
+        intentDiscovery.StopMonitoring()
+        return nil
+    })
 
     log.Printf("✅ CERTEN Validator initialized with real BFT consensus:")
     log.Printf("   - Validator ID: %s", cfg.ValidatorID)
@@ -1541,7 +2990,19 @@ func initializeStrategyRegistry(
         AnchorContract:    cfg.AnchorContractAddress,
         CertenContract:    cfg.CertenContractAddress,
         NetworkName:       cfg.NetworkName,
-        Logger:            log.New(log.Writer(), "[StrategyRegistry] ", log.LstdFlags),
+        Base: strategy.EVMNetworkSettings{
+            RPC:                   cfg.BaseRPC,
+            ChainID:               cfg.BaseChainID,
+            AnchorContract:        cfg.BaseAnchorContract,
+            RequiredConfirmations: cfg.BaseConfirmations,
+        },
+        Polygon: strategy.EVMNetworkSettings{
+            RPC:                   cfg.PolygonRPC,
+            ChainID:               cfg.PolygonChainID,
+            AnchorContract:        cfg.PolygonAnchorContract,
+            RequiredConfirmations: cfg.PolygonConfirmations,
+        },
+        Logger: log.New(log.Writer(), "[StrategyRegistry] ", log.LstdFlags),
     }
 
     // Initialize the registry with all strategies
@@ -1556,8 +3017,14 @@ func printHelp() {
     fmt.Println()
     fmt.Println("Options:")
     fmt.Println("  --validator-id=ID        Validator ID (default: validator-1)")
+    fmt.Println("  --config=PATH            YAML configuration file keyed by environment variable name;")
+    fmt.Println("                           environment variables and flags override its values")
     fmt.Println("  --help                   Show this help message")
     fmt.Println()
+    fmt.Println("Signals:")
+    fmt.Println("  SIGHUP                   Reload attestation peers (ATTESTATION_PEERS, ATTESTATION_REQUIRED_COUNT)")
+    fmt.Println("                           from the environment and --config file")
+    fmt.Println()
     fmt.Println("BFT Consensus Features:")
     fmt.Println("  ✅ Real distributed consensus")
     fmt.Println("  ✅ Byzantine fault tolerance")
@@ -81,6 +81,8 @@ type Transaction struct {
 	Signatures  []Signature            `json:"signatures"`
 	BlockHeight uint64                 `json:"block_height"`
 	Timestamp   time.Time              `json:"timestamp"`
+	Status      string                 `json:"status,omitempty"` // v3 message status (e.g. "delivered", "pending")
+	Produced    []string               `json:"produced,omitempty"` // IDs of synthetic transactions produced by this transaction
 }
 
 // Signature represents a transaction signature
@@ -217,34 +217,77 @@ func (l *LiteClientAdapter) SearchCertenTransactions(ctx context.Context, blockH
 	}
 
 	for _, partition := range partitions {
-		blocks, err := l.queryMinorBlocks(ctx, partition, blockHeight)
+		transactions, err := l.SearchPartitionCertenTransactions(ctx, partition, blockHeight)
 		if err != nil {
 			log.Printf("⚠️ [CERTEN-SEARCH] Failed to query %s block %d: %v", partition, blockHeight, err)
 			continue
 		}
+		allTransactions = append(allTransactions, transactions...)
+	}
+
+	log.Printf("✅ [CERTEN-SEARCH] DN + all BVNs at block %d yielded %d CERTEN_INTENT txs", blockHeight, len(allTransactions))
+	return allTransactions, nil
+}
+
+// SearchPartitionCertenTransactions searches one partition's minor block for CERTEN_INTENT transactions
+func (l *LiteClientAdapter) SearchPartitionCertenTransactions(ctx context.Context, partition string, blockHeight int64) ([]*CertenTransaction, error) {
+	blocks, err := l.queryMinorBlocks(ctx, partition, blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blocks) == 0 {
+		log.Printf("📊 [CERTEN-SEARCH] No block found at height %d on %s", blockHeight, partition)
+		return nil, nil
+	}
+
+	block := blocks[0]
+	log.Printf("📊 [CERTEN-SEARCH] %s block %d has %d entries", partition, blockHeight, len(block.Entries))
 
-		if len(blocks) == 0 {
-			log.Printf("📊 [CERTEN-SEARCH] No block found at height %d on %s", blockHeight, partition)
+	var transactions []*CertenTransaction
+	for _, entry := range block.Entries {
+		if !l.isCertenTransaction(entry) {
 			continue
 		}
+		certenTx := l.parseCertenTransaction(entry, block, partition)
+		if certenTx != nil {
+			transactions = append(transactions, certenTx)
+			log.Printf("🎯 [CERTEN-SEARCH] Found CERTEN transaction %s in %s block %d", certenTx.Hash, partition, blockHeight)
+		}
+	}
+	return transactions, nil
+}
 
-		block := blocks[0]
-		log.Printf("📊 [CERTEN-SEARCH] %s block %d has %d entries", partition, blockHeight, len(block.Entries))
+// ListPartitions returns the ledger URLs of the network's partitions (DN and every BVN)
+func (l *LiteClientAdapter) ListPartitions(ctx context.Context) ([]string, error) {
+	return l.getPartitions(ctx)
+}
 
-		for _, entry := range block.Entries {
-			if !l.isCertenTransaction(entry) {
-				continue
-			}
-			certenTx := l.parseCertenTransaction(entry, block, partition)
-			if certenTx != nil {
-				allTransactions = append(allTransactions, certenTx)
-				log.Printf("🎯 [CERTEN-SEARCH] Found CERTEN transaction %s in %s block %d", certenTx.Hash, partition, blockHeight)
-			}
-		}
+// GetPartitionHeight returns the latest minor block index of a partition, read from
+// the index of its system ledger account
+func (l *LiteClientAdapter) GetPartitionHeight(ctx context.Context, partition string) (uint64, error) {
+	result, err := l.queryV3API(ctx, "query", map[string]interface{}{
+		"scope": l.convertToLedgerScope(partition),
+		"query": map[string]interface{}{
+			"queryType": "default",
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("query %s ledger: %w", partition, err)
 	}
 
-	log.Printf("✅ [CERTEN-SEARCH] DN + all BVNs at block %d yielded %d CERTEN_INTENT txs", blockHeight, len(allTransactions))
-	return allTransactions, nil
+	// The V3 API returns {"recordType": "account", "account": {...}} at top level,
+	// or the same record wrapped in "record"
+	account, ok := result["account"].(map[string]interface{})
+	if !ok {
+		if record, ok := result["record"].(map[string]interface{}); ok {
+			account, _ = record["account"].(map[string]interface{})
+		}
+	}
+	if index, ok := account["index"].(float64); ok {
+		return uint64(index), nil
+	}
+	return 0, fmt.Errorf("no block index in %s ledger response", partition)
 }
 
 // CertenTransaction represents a discovered CERTEN intent transaction
@@ -743,6 +786,11 @@ func (l *LiteClientAdapter) GetTransaction(ctx context.Context, hash string) (*T
 					Hash: hash,
 				}
 
+				// Extract execution status (delivered, pending, remote, or an error code)
+				if status, ok := record["status"].(string); ok {
+					tx.Status = status
+				}
+
 				// Extract real transaction type
 				if txType, ok := record["type"].(string); ok {
 					tx.Type = txType
@@ -781,6 +829,19 @@ func (l *LiteClientAdapter) GetTransaction(ctx context.Context, hash string) (*T
 				// Extract signatures if available
 				tx.Signatures = []Signature{} // Real signatures would be extracted from transaction data
 
+				// Extract the synthetic transactions produced while executing this transaction
+				if produced, ok := record["produced"].(map[string]interface{}); ok {
+					if items, ok := produced["records"].([]interface{}); ok {
+						for _, item := range items {
+							if entry, ok := item.(map[string]interface{}); ok {
+								if txid, ok := entry["value"].(string); ok && txid != "" {
+									tx.Produced = append(tx.Produced, txid)
+								}
+							}
+						}
+					}
+				}
+
 				log.Printf("✅ [LITE-CLIENT] Retrieved real transaction: hash=%s, type=%s, height=%d",
 					hash, tx.Type, tx.BlockHeight)
 				return tx, nil
@@ -1635,8 +1696,15 @@ func (l *LiteClientAdapter) Close() error {
 
 // GetTransactionStatus queries the status of a transaction by hash or full transaction ID
 func (l *LiteClientAdapter) GetTransactionStatus(ctx context.Context, txHash string) (string, error) {
+	status, _, err := l.GetTransactionStatusDetail(ctx, txHash)
+	return status, err
+}
+
+// GetTransactionStatusDetail queries the status of a transaction by hash or full
+// transaction ID, along with the error the network reported for a failed transaction
+func (l *LiteClientAdapter) GetTransactionStatusDetail(ctx context.Context, txHash string) (string, string, error) {
 	if l.client == nil {
-		return "", fmt.Errorf("lite client not initialized")
+		return "", "", fmt.Errorf("lite client not initialized")
 	}
 
 	// Determine the scope for the query
@@ -1656,10 +1724,11 @@ func (l *LiteClientAdapter) GetTransactionStatus(ctx context.Context, txHash str
 		"scope": scope,
 	})
 	if err != nil {
-		return "", fmt.Errorf("query transaction status: %w", err)
+		return "", "", fmt.Errorf("query transaction status: %w", err)
 	}
 
 	log.Printf("🔍 [V3-STATUS] Query result: %+v", result)
+	chainError := transactionStatusError(result)
 
 	// Helper to extract status from a status object
 	extractStatus := func(status map[string]interface{}) string {
@@ -1702,18 +1771,18 @@ func (l *LiteClientAdapter) GetTransactionStatus(ctx context.Context, txHash str
 	// Format 1: Direct status in result
 	if status, ok := result["status"].(string); ok && status != "" {
 		log.Printf("🔍 [V3-STATUS] Found direct status: %s", status)
-		return status, nil
+		return status, chainError, nil
 	}
 
 	// Format 2: statusNo in result
 	if statusNo, ok := result["statusNo"].(float64); ok {
 		switch int(statusNo) {
 		case 201:
-			return "delivered", nil
+			return "delivered", chainError, nil
 		case 0:
-			return "pending", nil
+			return "pending", chainError, nil
 		default:
-			return "unknown", nil
+			return "unknown", chainError, nil
 		}
 	}
 
@@ -1722,15 +1791,15 @@ func (l *LiteClientAdapter) GetTransactionStatus(ctx context.Context, txHash str
 		if statusObj, ok := record["status"].(map[string]interface{}); ok {
 			if s := extractStatus(statusObj); s != "" {
 				log.Printf("🔍 [V3-STATUS] Found status in record.status: %s", s)
-				return s, nil
+				return s, chainError, nil
 			}
 		}
 		// Also check for direct status/statusNo in record
 		if status, ok := record["status"].(string); ok && status != "" {
-			return status, nil
+			return status, chainError, nil
 		}
 		if statusNo, ok := record["statusNo"].(float64); ok && int(statusNo) == 201 {
-			return "delivered", nil
+			return "delivered", chainError, nil
 		}
 	}
 
@@ -1738,13 +1807,48 @@ func (l *LiteClientAdapter) GetTransactionStatus(ctx context.Context, txHash str
 	if msg, ok := result["message"].(map[string]interface{}); ok {
 		if statusObj, ok := msg["status"].(map[string]interface{}); ok {
 			if s := extractStatus(statusObj); s != "" {
-				return s, nil
+				return s, chainError, nil
 			}
 		}
 	}
 
 	log.Printf("⚠️ [V3-STATUS] Could not extract status, returning pending")
-	return "pending", nil
+	return "pending", chainError, nil
+}
+
+// transactionStatusError returns the error message of a transaction status query
+// result, or "" if it reports none
+func transactionStatusError(result map[string]interface{}) string {
+	message := func(status map[string]interface{}) string {
+		switch e := status["error"].(type) {
+		case string:
+			return e
+		case map[string]interface{}:
+			if m, ok := e["message"].(string); ok {
+				return m
+			}
+		}
+		return ""
+	}
+
+	if m := message(result); m != "" {
+		return m
+	}
+	for _, key := range []string{"record", "message"} {
+		obj, ok := result[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if status, ok := obj["status"].(map[string]interface{}); ok {
+			if m := message(status); m != "" {
+				return m
+			}
+		}
+		if m := message(obj); m != "" {
+			return m
+		}
+	}
+	return ""
 }
 
 // SubmitWriteData submits a WriteData transaction to the Accumulate network
@@ -10,6 +10,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math/big"
@@ -26,6 +27,7 @@ import (
 
 // CertenAnchor contract ABI - canonical anchor format with three commitments
 // Phase 1: Extended with executeComprehensiveProof for full proof verification
+// verifyCertenProofDetailed is the view counterpart used to re-check stored proofs
 const certenAnchorABI = `[
 	{
 		"inputs": [
@@ -119,6 +121,93 @@ const certenAnchorABI = `[
 		"stateMutability": "nonpayable",
 		"type": "function"
 	},
+	{
+		"inputs": [
+			{"name": "anchorId", "type": "bytes32"},
+			{
+				"name": "proof",
+				"type": "tuple",
+				"components": [
+					{"name": "transactionHash", "type": "bytes32"},
+					{"name": "merkleRoot", "type": "bytes32"},
+					{"name": "proofHashes", "type": "bytes32[]"},
+					{"name": "leafHash", "type": "bytes32"},
+					{
+						"name": "governanceProof",
+						"type": "tuple",
+						"components": [
+							{"name": "keyBookURL", "type": "string"},
+							{"name": "keyBookRoot", "type": "bytes32"},
+							{"name": "keyPageProofs", "type": "bytes32[]"},
+							{"name": "authorityAddress", "type": "address"},
+							{"name": "authorityLevel", "type": "uint8"},
+							{"name": "nonce", "type": "uint256"},
+							{"name": "requiredSignatures", "type": "uint256"},
+							{"name": "providedSignatures", "type": "uint256"},
+							{"name": "thresholdMet", "type": "bool"}
+						]
+					},
+					{
+						"name": "blsProof",
+						"type": "tuple",
+						"components": [
+							{"name": "aggregateSignature", "type": "bytes"},
+							{"name": "validatorAddresses", "type": "address[]"},
+							{"name": "votingPowers", "type": "uint256[]"},
+							{"name": "totalVotingPower", "type": "uint256"},
+							{"name": "signedVotingPower", "type": "uint256"},
+							{"name": "thresholdMet", "type": "bool"},
+							{"name": "messageHash", "type": "bytes32"}
+						]
+					},
+					{
+						"name": "commitments",
+						"type": "tuple",
+						"components": [
+							{"name": "operationCommitment", "type": "bytes32"},
+							{"name": "crossChainCommitment", "type": "bytes32"},
+							{"name": "governanceRoot", "type": "bytes32"},
+							{"name": "sourceChain", "type": "string"},
+							{"name": "sourceBlockHeight", "type": "uint256"},
+							{"name": "sourceTxHash", "type": "bytes32"},
+							{"name": "targetChain", "type": "string"},
+							{"name": "targetAddress", "type": "address"}
+						]
+					},
+					{"name": "expirationTime", "type": "uint256"},
+					{"name": "metadata", "type": "bytes"}
+				]
+			}
+		],
+		"name": "verifyCertenProofDetailed",
+		"outputs": [{"name": "results", "type": "bool[6]"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [{"name": "commitmentHash", "type": "bytes32"}],
+		"name": "isCommitmentUsed",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "authority", "type": "address"},
+			{"name": "nonce", "type": "uint256"}
+		],
+		"name": "isNonceUsed",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [{"name": "anchorId", "type": "bytes32"}],
+		"name": "anchorExists",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
 	{
 		"inputs": [{"name": "anchorId", "type": "bytes32"}],
 		"name": "getAnchor",
@@ -135,6 +224,27 @@ const certenAnchorABI = `[
 		],
 		"stateMutability": "view",
 		"type": "function"
+	},
+	{
+		"inputs": [{"name": "anchorId", "type": "bytes32"}],
+		"name": "getVerificationStats",
+		"outputs": [
+			{"name": "totalVerifications", "type": "uint256"},
+			{"name": "successfulVerifications", "type": "uint256"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getGovernanceVerifierStatus",
+		"outputs": [
+			{"name": "verifierSet", "type": "bool"},
+			{"name": "verifierInitialized", "type": "bool"},
+			{"name": "minLevel", "type": "uint8"}
+		],
+		"stateMutability": "view",
+		"type": "function"
 	}
 ]`
 
@@ -149,6 +259,15 @@ type AnchorManager struct {
 	proofGenerator *proof.ProofGenerator // Shared proof generator from validator
 	ledgerStore    *ledger.LedgerStore   // Ledger store for anchor tracking
 	logger         *log.Logger           // Logger for anchor operations
+	govVerifier    *GovernanceVerifierMonitor // Tracks on-chain governance verifier availability
+	proofLimits    ProofSizeLimits            // Bounds executeComprehensiveProof calldata
+	merkleRootGuard bool                      // Reject empty, all-zero and zero-leaf Merkle roots
+	feeEscalation  *ethereum.FeeEscalationSchedule // Applied to urgent (on-demand) anchors; nil = disabled
+	stuckTxReplace *ethereum.FeeEscalationSchedule // Applied to all other anchors; nil = disabled
+	votingPower    *VotingPowerTracker             // Current on-chain voting power for BLS proof data; nil = assumed values
+	proofOverrides *ProofFieldOverrides            // DEBUG ONLY: replaces proof fields before submission; nil = none
+	anchorRetry    AnchorRetryPolicy               // Resubmission of batch anchors after transient failures
+	shadow         bool                            // Estimate and log transactions instead of sending them
 }
 
 // AnchorBatchConfig contains optional batch processing configuration
@@ -187,6 +306,9 @@ type AnchorData struct {
 	ValidatorID           string                 `json:"validator_id"`
 	Timestamp             time.Time              `json:"timestamp"`
 	BatchID               string    `json:"batch_id,omitempty"`
+
+	// FeeEscalation replaces the anchor transaction at rising gas prices while unconfirmed
+	FeeEscalation *ethereum.FeeEscalationSchedule `json:"-"`
 }
 
 // AnchorResult represents the result of an anchoring operation
@@ -201,6 +323,25 @@ type AnchorResult struct {
 	Timestamp       time.Time `json:"timestamp"`
 	ChainName       string    `json:"chain_name"`
 	ConfirmationTime time.Duration `json:"confirmation_time"`
+
+	// Fees paid (BaseFee/PriorityFee are nil on chains without EIP-1559)
+	GasPrice    *big.Int `json:"gas_price,omitempty"`
+	BaseFee     *big.Int `json:"base_fee,omitempty"`
+	PriorityFee *big.Int `json:"priority_fee,omitempty"`
+	FeeStrategy string   `json:"fee_strategy,omitempty"`
+	Attempts    int      `json:"attempts,omitempty"` // > 1 means the gas price was bumped
+
+	FeeEscalation []ethereum.FeeEscalationStep `json:"fee_escalation,omitempty"` // Submissions under a fee escalation schedule
+
+	Shadow bool `json:"shadow,omitempty"` // Simulated in shadow mode; nothing was sent
+}
+
+// weiString formats an optional wei amount, empty when unknown
+func weiString(v *big.Int) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
 }
 
 // Anchor represents an existing anchor in a target chain
@@ -264,11 +405,34 @@ func NewAnchorManager(liteClient *accumulate.LiteClientAdapter, cfg *config.Conf
 		logger = log.New(log.Writer(), "[AnchorManager] ", log.LstdFlags)
 	}
 
-	// Initialize the low-level Ethereum client
-	ethereumClient, err := ethereum.NewClient(cfg.EthereumURL, cfg.EthChainID)
+	// Initialize the low-level Ethereum client, failing over between the configured endpoints
+	ethereumClient, err := ethereum.NewClientWithFallbacks(cfg.EthereumEndpoints(), cfg.EthChainID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize ethereum client: %w", err)
 	}
+	reconnectPolicy := ethereum.DefaultReconnectPolicy()
+	reconnectPolicy.FailoverThreshold = cfg.EthFailoverThreshold
+	reconnectPolicy.PrimaryRetryInterval = cfg.EthPrimaryRetryInterval
+	if err := ethereumClient.SetReconnectPolicy(reconnectPolicy); err != nil {
+		return nil, fmt.Errorf("invalid ethereum failover policy: %w", err)
+	}
+
+	// Anchor transactions are EIP-1559 type-2 transactions unless legacy gas is configured
+	// (chains without a base fee fall back to legacy pricing per transaction)
+	if !cfg.EthUseLegacyGas {
+		policy := &ethereum.DynamicFeePolicy{BaseFeeMultiplier: cfg.EthBaseFeeMultiplier}
+		if cfg.EthMaxPriorityFeeWei > 0 {
+			policy.PriorityFee = big.NewInt(cfg.EthMaxPriorityFeeWei)
+		}
+		if err := ethereumClient.SetDynamicFees(policy); err != nil {
+			return nil, fmt.Errorf("invalid dynamic fee policy: %w", err)
+		}
+		tip := "suggested"
+		if policy.PriorityFee != nil {
+			tip = policy.PriorityFee.String() + " wei"
+		}
+		logger.Printf("⛽ Anchor transactions use EIP-1559 fees (base fee x%d + %s tip)", cfg.EthBaseFeeMultiplier, tip)
+	}
 
 	// Create default batch configuration
 	batchConfig := &AnchorBatchConfig{
@@ -287,6 +451,9 @@ func NewAnchorManager(liteClient *accumulate.LiteClientAdapter, cfg *config.Conf
 		proofGenerator: proofGen,
 		ledgerStore:    ledgerStore,
 		logger:         logger,
+		proofLimits:    DefaultProofSizeLimits(),
+		merkleRootGuard: true,
+		anchorRetry:    DefaultAnchorRetryPolicy(),
 		batchScheduler: &BatchScheduler{
 			config:         cfg,
 			batchConfig:    batchConfig,
@@ -472,9 +639,12 @@ func (am *AnchorManager) CreateAnchor(ctx context.Context, req *AnchorRequest) (
 		results[chainName] = result
 	}
 
-	// Mark anchor as produced in ledger store
+	// Mark anchor as produced in ledger store (shadow anchors were never produced)
 	if am.ledgerStore != nil {
 		for chainName, result := range results {
+			if result.Shadow {
+				continue
+			}
 			// Use NetworkName from config for target URL
 			networkName := am.config.NetworkName
 			if networkName == "" {
@@ -673,6 +843,7 @@ type AnchorInfo struct {
 type EthereumChain struct {
 	ethereumClient *ethereum.Client  // Use low-level client instead
 	config         *EthereumConfig
+	shadow         bool // Estimate and log transactions instead of sending them
 }
 
 type EthereumConfig struct {
@@ -706,14 +877,16 @@ func (ec *EthereumChain) GetChainID() string {
 	return fmt.Sprintf("ethereum-%d", ec.config.ChainID)
 }
 
-// CreateAnchor creates an anchor on Ethereum by calling the smart contract with retry logic
-func (ec *EthereumChain) CreateAnchor(ctx context.Context, anchor *AnchorData) (*AnchorResult, error) {
-	log.Printf("🔗 Creating canonical anchor on Ethereum contract: %s", ec.config.ContractAddress)
-
-	// Convert strings/bytes to [32]byte for contract parameters
-	var bundleId [32]byte
-	copy(bundleId[:], []byte(anchor.AnchorID))
+// BatchAnchorBundleID returns the deterministic on-chain anchor ID for a batch anchor:
+// the first 32 bytes of the anchor ID string (the batch ID), zero-padded
+func BatchAnchorBundleID(anchorID string) [32]byte {
+	var bundleID [32]byte
+	copy(bundleID[:], []byte(anchorID))
+	return bundleID
+}
 
+// createAnchorParams converts anchor data to the contract's createAnchor parameters
+func createAnchorParams(anchor *AnchorData) ([]interface{}, error) {
 	if len(anchor.OperationCommitment) != 32 {
 		return nil, fmt.Errorf("operation commitment must be 32 bytes, got %d", len(anchor.OperationCommitment))
 	}
@@ -731,32 +904,67 @@ func (ec *EthereumChain) CreateAnchor(ctx context.Context, anchor *AnchorData) (
 	copy(crossCommit[:], anchor.CrossChainCommitment)
 	copy(govRoot[:], anchor.GovernanceRoot)
 
+	return []interface{}{
+		BatchAnchorBundleID(anchor.AnchorID),
+		opCommit,
+		crossCommit,
+		govRoot,
+		big.NewInt(int64(anchor.AccumulateBlockHeight)),
+	}, nil
+}
+
+// CreateAnchor creates an anchor on Ethereum by calling the smart contract with retry logic
+func (ec *EthereumChain) CreateAnchor(ctx context.Context, anchor *AnchorData) (*AnchorResult, error) {
+	log.Printf("🔗 Creating canonical anchor on Ethereum contract: %s", ec.config.ContractAddress)
+
+	params, err := createAnchorParams(anchor)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse contract address
 	contractAddr := common.HexToAddress(ec.config.ContractAddress)
 	log.Printf("📋 Contract address: %s", contractAddr.Hex())
 
 	log.Printf("🔧 Transaction params:")
-	log.Printf("   - Bundle ID: %x", bundleId)
-	log.Printf("   - Operation Commitment: %x", opCommit)
-	log.Printf("   - CrossChain Commitment: %x", crossCommit)
-	log.Printf("   - Governance Root: %x", govRoot)
+	log.Printf("   - Bundle ID: %x", params[0])
+	log.Printf("   - Operation Commitment: %x", params[1])
+	log.Printf("   - CrossChain Commitment: %x", params[2])
+	log.Printf("   - Governance Root: %x", params[3])
 	log.Printf("   - Block Height: %d", anchor.AccumulateBlockHeight)
 
-	// Use the low-level ethereum client to send the contract transaction with retry
-	result, err := ec.ethereumClient.SendContractTransactionWithRetry(
-		ctx,
-		contractAddr,
-		certenAnchorABI,
-		ec.config.PrivateKey,
-		"createAnchor",
-		ec.config.GasLimit,
-		5, // maxRetries
-		bundleId,
-		opCommit,
-		crossCommit,
-		govRoot,
-		big.NewInt(int64(anchor.AccumulateBlockHeight)),
-	)
+	if ec.shadow {
+		return ec.simulateContractTransaction(ctx, anchor.AnchorID, "createAnchor", ec.config.GasLimit, true, params...)
+	}
+
+	// Use the low-level ethereum client to send the contract transaction, replacing it on
+	// the fee escalation schedule for urgent anchors and with retry otherwise
+	var result *ethereum.ContractCallResult
+	if anchor.FeeEscalation != nil {
+		log.Printf("⏫ Anchoring with fee escalation (every %s, +%d%%, max %d replacements)",
+			anchor.FeeEscalation.Interval, anchor.FeeEscalation.BumpPercent, anchor.FeeEscalation.MaxReplacements)
+		result, err = ec.ethereumClient.SendContractTransactionEscalating(
+			ctx,
+			contractAddr,
+			certenAnchorABI,
+			ec.config.PrivateKey,
+			"createAnchor",
+			ec.config.GasLimit,
+			anchor.FeeEscalation,
+			params...,
+		)
+	} else {
+		result, err = ec.ethereumClient.SendContractTransactionWithRetry(
+			ctx,
+			contractAddr,
+			certenAnchorABI,
+			ec.config.PrivateKey,
+			"createAnchor",
+			ec.config.GasLimit,
+			5, // maxRetries
+			params...,
+		)
+	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create anchor: %w", err)
@@ -774,6 +982,12 @@ func (ec *EthereumChain) CreateAnchor(ctx context.Context, anchor *AnchorData) (
 		Timestamp:        result.Timestamp,
 		ChainName:        "ethereum",
 		ConfirmationTime: 15 * time.Second,
+		GasPrice:         result.GasPrice,
+		BaseFee:          result.BaseFee,
+		PriorityFee:      result.PriorityFee,
+		FeeStrategy:      result.FeeStrategy,
+		Attempts:         result.Attempts,
+		FeeEscalation:    result.Escalation,
 	}
 
 	log.Printf("🎉 Successfully created anchor on Ethereum!")
@@ -783,8 +997,7 @@ func (ec *EthereumChain) CreateAnchor(ctx context.Context, anchor *AnchorData) (
 // GetAnchor retrieves an anchor from Ethereum smart contract
 func (ec *EthereumChain) GetAnchor(ctx context.Context, anchorID string) (*Anchor, error) {
 	// Convert anchorID to bytes32
-	var bundleId [32]byte
-	copy(bundleId[:], []byte(anchorID))
+	bundleId := BatchAnchorBundleID(anchorID)
 
 	// Parse contract address
 	contractAddr := common.HexToAddress(ec.config.ContractAddress)
@@ -876,12 +1089,30 @@ func (ec *EthereumChain) VerifyAnchor(ctx context.Context, anchorID string) (boo
 	return true, nil
 }
 
-// EstimateGas estimates gas cost for anchoring
+// EstimateGas estimates gas cost for anchoring with eth_estimateGas and the suggested
+// gas price, without sending the createAnchor transaction
 func (ec *EthereumChain) EstimateGas(ctx context.Context, anchor *AnchorData) (*GasEstimate, error) {
+	params, err := createAnchorParams(anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := ec.ethereumClient.EstimateContractTransaction(
+		ctx,
+		common.HexToAddress(ec.config.ContractAddress),
+		certenAnchorABI,
+		ec.config.PrivateKey,
+		"createAnchor",
+		params...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate createAnchor: %w", err)
+	}
+
 	return &GasEstimate{
-		GasLimit:    ec.config.GasLimit,
-		GasPrice:    ec.config.GasPrice,
-		TotalCost:   big.NewInt(0).Mul(big.NewInt(int64(ec.config.GasLimit)), ec.config.GasPrice),
+		GasLimit:    estimate.GasLimit,
+		GasPrice:    estimate.GasPrice,
+		TotalCost:   estimate.TotalCost,
 		ChainName:   "ethereum",
 		EstimatedAt: time.Now(),
 	}, nil
@@ -930,6 +1161,7 @@ type AnchorOnChainRequest struct {
 	AccumulateHash       string `json:"accumulate_hash"`
 	TargetChain          string `json:"target_chain"`
 	ValidatorID          string `json:"validator_id"`
+	Urgent               bool   `json:"urgent,omitempty"` // On-demand: anchor on the fee escalation schedule
 }
 
 // AnchorOnChainResult is the result from creating a batch anchor
@@ -943,30 +1175,41 @@ type AnchorOnChainResult struct {
 	TotalCostWei string    `json:"total_cost_wei"`
 	Timestamp    time.Time `json:"timestamp"`
 	Success      bool      `json:"success"`
+
+	// EIP-1559 fee breakdown and how the gas price was chosen
+	BaseFeeWei     string `json:"base_fee_wei,omitempty"`
+	PriorityFeeWei string `json:"priority_fee_wei,omitempty"`
+	FeeStrategy    string `json:"fee_strategy,omitempty"`
+	GasBumped      bool   `json:"gas_bumped"`
+
+	// Submissions made under the fee escalation schedule (urgent anchors only)
+	FeeEscalation []ethereum.FeeEscalationStep `json:"fee_escalation,omitempty"`
+
+	Shadow bool `json:"shadow,omitempty"` // Simulated in shadow mode; nothing was sent
 }
 
-// CreateBatchAnchorOnChain creates an anchor using the REAL Merkle root from a batch
-// This is the Phase 5 implementation that replaces placeholder hashes
-// It implements the batch.AnchorManagerInterface
-func (am *AnchorManager) CreateBatchAnchorOnChain(ctx context.Context, req *AnchorOnChainRequest) (*AnchorOnChainResult, error) {
-	am.logger.Printf("🔗 [Phase 5] Creating batch anchor with REAL Merkle root")
-	am.logger.Printf("   BatchID: %s", req.BatchID)
-	am.logger.Printf("   MerkleRoot: %x", req.MerkleRoot[:8])
-	am.logger.Printf("   TxCount: %d", req.TxCount)
-	am.logger.Printf("   AccumulateHeight: %d", req.AccumulateHeight)
+// batchAnchorData validates a batch anchor request and resolves its target chain and
+// the anchor data to submit there
+func (am *AnchorManager) batchAnchorData(req *AnchorOnChainRequest) (Chain, string, *AnchorData, error) {
+	// Refuse to spend gas anchoring a root that commits to nothing
+	if am.merkleRootGuard {
+		if err := CheckMerkleRoot(req.MerkleRoot, req.TxCount); err != nil {
+			return nil, "", nil, fmt.Errorf("refusing to anchor batch %s: %w", req.BatchID, err)
+		}
+	}
 
 	// Validate commitments are 32 bytes
 	if len(req.MerkleRoot) != 32 {
-		return nil, fmt.Errorf("merkle root must be 32 bytes, got %d", len(req.MerkleRoot))
+		return nil, "", nil, fmt.Errorf("merkle root must be 32 bytes, got %d", len(req.MerkleRoot))
 	}
 	if len(req.OperationCommitment) != 32 {
-		return nil, fmt.Errorf("operation commitment must be 32 bytes, got %d", len(req.OperationCommitment))
+		return nil, "", nil, fmt.Errorf("operation commitment must be 32 bytes, got %d", len(req.OperationCommitment))
 	}
 	if len(req.CrossChainCommitment) != 32 {
-		return nil, fmt.Errorf("cross-chain commitment must be 32 bytes, got %d", len(req.CrossChainCommitment))
+		return nil, "", nil, fmt.Errorf("cross-chain commitment must be 32 bytes, got %d", len(req.CrossChainCommitment))
 	}
 	if len(req.GovernanceRoot) != 32 {
-		return nil, fmt.Errorf("governance root must be 32 bytes, got %d", len(req.GovernanceRoot))
+		return nil, "", nil, fmt.Errorf("governance root must be 32 bytes, got %d", len(req.GovernanceRoot))
 	}
 
 	// Get the target chain (default to ethereum)
@@ -977,7 +1220,7 @@ func (am *AnchorManager) CreateBatchAnchorOnChain(ctx context.Context, req *Anch
 
 	chain, exists := am.chains[targetChain]
 	if !exists {
-		return nil, fmt.Errorf("chain %s not configured", targetChain)
+		return nil, "", nil, fmt.Errorf("chain %s not configured", targetChain)
 	}
 
 	// Create anchor data with REAL commitments
@@ -992,20 +1235,58 @@ func (am *AnchorManager) CreateBatchAnchorOnChain(ctx context.Context, req *Anch
 		Timestamp:             time.Now(),
 		BatchID:               req.BatchID,
 	}
+	// Urgent anchors use the on-demand escalation schedule; the rest (and urgent anchors
+	// without one) are replaced only when stuck
+	anchorData.FeeEscalation = am.stuckTxReplace
+	if req.Urgent && am.feeEscalation != nil {
+		anchorData.FeeEscalation = am.feeEscalation
+	}
+	return chain, targetChain, anchorData, nil
+}
+
+// EstimateBatchAnchorOnChain prices the createAnchor transaction CreateBatchAnchorOnChain
+// would send for the request, without sending it
+func (am *AnchorManager) EstimateBatchAnchorOnChain(ctx context.Context, req *AnchorOnChainRequest) (*GasEstimate, error) {
+	chain, targetChain, anchorData, err := am.batchAnchorData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := chain.EstimateGas(ctx, anchorData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate anchor on %s: %w", targetChain, err)
+	}
+	return estimate, nil
+}
+
+// CreateBatchAnchorOnChain creates an anchor using the REAL Merkle root from a batch
+// This is the Phase 5 implementation that replaces placeholder hashes
+// It implements the batch.AnchorManagerInterface
+func (am *AnchorManager) CreateBatchAnchorOnChain(ctx context.Context, req *AnchorOnChainRequest) (*AnchorOnChainResult, error) {
+	chain, targetChain, anchorData, err := am.batchAnchorData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	am.logger.Printf("🔗 [Phase 5] Creating batch anchor with REAL Merkle root")
+	am.logger.Printf("   BatchID: %s", req.BatchID)
+	am.logger.Printf("   MerkleRoot: %x", req.MerkleRoot[:8])
+	am.logger.Printf("   TxCount: %d", req.TxCount)
+	am.logger.Printf("   AccumulateHeight: %d", req.AccumulateHeight)
 
 	am.logger.Printf("📋 Using REAL commitments from batch (NOT placeholders):")
 	am.logger.Printf("   Operation (Merkle Root): %x", req.OperationCommitment[:8])
 	am.logger.Printf("   Cross-Chain: %x", req.CrossChainCommitment[:8])
 	am.logger.Printf("   Governance: %x", req.GovernanceRoot[:8])
 
-	// Create anchor on chain
-	result, err := chain.CreateAnchor(ctx, anchorData)
+	// Create anchor on chain, retrying transient RPC and mempool failures
+	result, err := am.createAnchorWithRetry(ctx, chain, anchorData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create anchor on %s: %w", targetChain, err)
 	}
 
-	// Mark anchor as produced in ledger store
-	if am.ledgerStore != nil {
+	// Mark anchor as produced in ledger store (shadow anchors were never produced)
+	if am.ledgerStore != nil && !result.Shadow {
 		targetURL := fmt.Sprintf("%s://mainnet", targetChain)
 		if err := am.ledgerStore.MarkAnchorProduced(
 			0, // Certen block height
@@ -1031,10 +1312,17 @@ func (am *AnchorManager) CreateBatchAnchorOnChain(ctx context.Context, req *Anch
 		BlockNumber:  int64(result.BlockNumber),
 		BlockHash:    result.BlockHash,
 		GasUsed:      int64(result.GasUsed),
-		GasPriceWei:  result.GasCost.String(),
+		GasPriceWei:  weiString(result.GasPrice),
 		TotalCostWei: result.GasCost.String(),
 		Timestamp:    result.Timestamp,
 		Success:      result.Success,
+
+		BaseFeeWei:     weiString(result.BaseFee),
+		PriorityFeeWei: weiString(result.PriorityFee),
+		FeeStrategy:    result.FeeStrategy,
+		GasBumped:      result.Attempts > 1,
+		FeeEscalation:  result.FeeEscalation,
+		Shadow:         result.Shadow,
 	}, nil
 }
 
@@ -1060,6 +1348,19 @@ type ExecuteComprehensiveProofResult struct {
 	Timestamp   time.Time `json:"timestamp"`
 	Success     bool      `json:"success"`
 	ProofValid  bool      `json:"proof_valid"`
+
+	// Set when governance proof data was omitted under the "skip" governance verifier policy
+	GovernanceSkipped    bool   `json:"governance_skipped,omitempty"`
+	GovernanceSkipReason string `json:"governance_skip_reason,omitempty"`
+
+	// Size of the ABI-encoded executeComprehensiveProof calldata
+	CalldataBytes int `json:"calldata_bytes"`
+
+	Shadow bool `json:"shadow,omitempty"` // Simulated in shadow mode; nothing was sent
+
+	// Set when the contract has already consumed the proof's commitment or nonce; nothing was sent
+	AlreadyAnchored       bool   `json:"already_anchored,omitempty"`
+	AlreadyAnchoredReason string `json:"already_anchored_reason,omitempty"`
 }
 
 // ExecuteComprehensiveProof submits a complete proof bundle to the CertenAnchorV3 contract
@@ -1090,6 +1391,10 @@ func (am *AnchorManager) ExecuteComprehensiveProof(ctx context.Context, req *Exe
 	if err := req.ProofBundle.Validate(); err != nil {
 		return nil, fmt.Errorf("proof bundle validation failed: %w", err)
 	}
+	if err := ValidateProofStruct(req.ProofBundle); err != nil {
+		am.logger.Printf("❌ [Phase 1] Comprehensive proof rejected: %v", err)
+		return nil, fmt.Errorf("proof struct validation failed: %w", err)
+	}
 
 	// Generate the anchor ID bytes32 (matching how createAnchor generated it)
 	anchorIDBytes32 := GenerateBundleIDBytes32(req.AnchorID, req.ProofBundle.Timestamp.Unix())
@@ -1106,6 +1411,23 @@ func (am *AnchorManager) ExecuteComprehensiveProof(ctx context.Context, req *Exe
 		return nil, fmt.Errorf("failed to convert proof bundle to contract format")
 	}
 
+	// Align governance proof data with the contract's verification capability
+	var govSkipReason string
+	if am.govVerifier != nil {
+		govSkipReason = am.govVerifier.ApplyPolicy(contractProof)
+	}
+	if err := am.applyProofOverrides(req.AnchorID, contractProof); err != nil {
+		return nil, err
+	}
+
+	// Bound the calldata (and so the gas) before submission
+	calldataBytes, err := am.proofLimits.Check(anchorIDBytes32, contractProof)
+	if err != nil {
+		am.logger.Printf("❌ [Phase 1] Comprehensive proof rejected: %v", err)
+		return nil, fmt.Errorf("proof size check failed: %w", err)
+	}
+	am.logger.Printf("   Calldata: %d bytes (%d proof hashes)", calldataBytes, len(contractProof.ProofHashes))
+
 	// Get the ethereum chain
 	chain, exists := am.chains["ethereum"]
 	if !exists {
@@ -1117,6 +1439,21 @@ func (am *AnchorManager) ExecuteComprehensiveProof(ctx context.Context, req *Exe
 		return nil, fmt.Errorf("invalid ethereum chain type")
 	}
 
+	// Skip proofs the contract would revert as replays instead of paying gas for the revert
+	if reason, err := ethChain.ConsumedProofReason(ctx, contractProof); err != nil {
+		am.logger.Printf("⚠️ [Phase 1] Replay check failed, submitting anyway: %v", err)
+	} else if reason != "" {
+		am.logger.Printf("⏭️ [Phase 1] Proof for anchor %s already anchored (%s) - not submitting", req.AnchorID, reason)
+		return &ExecuteComprehensiveProofResult{
+			Timestamp:             time.Now(),
+			GovernanceSkipped:     govSkipReason != "",
+			GovernanceSkipReason:  govSkipReason,
+			CalldataBytes:         calldataBytes,
+			AlreadyAnchored:       true,
+			AlreadyAnchoredReason: reason,
+		}, nil
+	}
+
 	// Execute the comprehensive proof on-chain
 	result, err := ethChain.ExecuteComprehensiveProof(ctx, anchorIDBytes32, contractProof)
 	if err != nil {
@@ -1134,10 +1471,16 @@ func (am *AnchorManager) ExecuteComprehensiveProof(ctx context.Context, req *Exe
 		BlockNumber: int64(result.BlockNumber),
 		BlockHash:   result.BlockHash,
 		GasUsed:     int64(result.GasUsed),
-		GasPriceWei: result.GasCost.String(),
+		GasPriceWei: weiString(result.GasPrice),
 		Timestamp:   result.Timestamp,
 		Success:     result.Success,
 		ProofValid:  result.Success,
+
+		GovernanceSkipped:    govSkipReason != "",
+		GovernanceSkipReason: govSkipReason,
+
+		CalldataBytes: calldataBytes,
+		Shadow:        result.Shadow,
 	}, nil
 }
 
@@ -1162,6 +1505,10 @@ func (ec *EthereumChain) ExecuteComprehensiveProof(ctx context.Context, anchorID
 	log.Printf("   - Gov Threshold Met: %v", proof.GovernanceProof.ThresholdMet)
 	log.Printf("   - Expiration: %v", proof.ExpirationTime)
 
+	if ec.shadow {
+		return ec.simulateContractTransaction(ctx, hex.EncodeToString(anchorID[:]), "executeComprehensiveProof", ec.config.GasLimit*5, false, anchorID, proof)
+	}
+
 	// Use the low-level ethereum client to send the contract transaction with retry
 	// The proof struct needs to be passed as a single tuple argument
 	result, err := ec.ethereumClient.SendContractTransactionWithRetry(
@@ -1194,9 +1541,112 @@ func (ec *EthereumChain) ExecuteComprehensiveProof(ctx context.Context, anchorID
 		Timestamp:        result.Timestamp,
 		ChainName:        "ethereum",
 		ConfirmationTime: 15 * time.Second,
+		GasPrice:         result.GasPrice,
+		BaseFee:          result.BaseFee,
+		PriorityFee:      result.PriorityFee,
+		FeeStrategy:      result.FeeStrategy,
+		Attempts:         result.Attempts,
+	}, nil
+}
+
+// GetGovernanceVerifierStatus reads the governance verifier status from the anchor contract
+func (ec *EthereumChain) GetGovernanceVerifierStatus(ctx context.Context) (*GovernanceVerifierStatus, error) {
+	contractAddr := common.HexToAddress(ec.config.ContractAddress)
+
+	result, err := ec.ethereumClient.CallContract(ctx, contractAddr, certenAnchorABI, "getGovernanceVerifierStatus")
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getGovernanceVerifierStatus: %w", err)
+	}
+	if len(result) < 3 {
+		return nil, fmt.Errorf("unexpected result length from getGovernanceVerifierStatus: %d", len(result))
+	}
+
+	return &GovernanceVerifierStatus{
+		VerifierSet:         result[0].(bool),
+		VerifierInitialized: result[1].(bool),
+		MinLevel:            result[2].(uint8),
 	}, nil
 }
 
+// StartGovernanceVerifierMonitor checks the contract's governance verifier at startup and
+// periodically thereafter, applying the policy to every comprehensive proof submission
+func (am *AnchorManager) StartGovernanceVerifierMonitor(ctx context.Context, policy GovernanceVerifierPolicy, checkInterval time.Duration) (*GovernanceVerifierMonitor, error) {
+	chain, exists := am.chains["ethereum"]
+	if !exists {
+		return nil, fmt.Errorf("ethereum chain not configured")
+	}
+	ethChain, ok := chain.(*EthereumChain)
+	if !ok {
+		return nil, fmt.Errorf("invalid ethereum chain type")
+	}
+
+	monitor := NewGovernanceVerifierMonitor(ethChain, policy, checkInterval,
+		log.New(log.Writer(), "[GovVerifier] ", log.LstdFlags))
+	am.govVerifier = monitor
+	if err := monitor.Start(ctx); err != nil {
+		return nil, err
+	}
+	return monitor, nil
+}
+
+// SetProofSizeLimits sets the limits enforced before executeComprehensiveProof is submitted
+func (am *AnchorManager) SetProofSizeLimits(limits ProofSizeLimits) {
+	am.proofLimits = limits
+}
+
+// SetMerkleRootGuard enables or disables rejection of empty, all-zero and zero-leaf Merkle roots
+func (am *AnchorManager) SetMerkleRootGuard(enabled bool) {
+	am.merkleRootGuard = enabled
+}
+
+// SetFeeEscalation sets the fee escalation schedule for urgent (on-demand) anchors; nil disables it
+func (am *AnchorManager) SetFeeEscalation(schedule *ethereum.FeeEscalationSchedule) {
+	am.feeEscalation = schedule
+}
+
+// SetStuckTxReplacement sets the schedule on which anchor transactions without an urgent
+// fee escalation schedule are replaced (same nonce, higher gas price) while unconfirmed;
+// nil disables it
+func (am *AnchorManager) SetStuckTxReplacement(schedule *ethereum.FeeEscalationSchedule) {
+	am.stuckTxReplace = schedule
+}
+
+// SetVotingPowerTracker sets the tracker whose on-chain voting power replaces the
+// assumed values in BLS proof data; nil keeps the assumed values
+func (am *AnchorManager) SetVotingPowerTracker(tracker *VotingPowerTracker) {
+	am.votingPower = tracker
+}
+
+// SetProofFieldOverrides enables debug overrides of submitted CertenProof fields.
+// Overrides are refused on Ethereum mainnet; nil disables them.
+func (am *AnchorManager) SetProofFieldOverrides(overrides *ProofFieldOverrides) error {
+	if overrides != nil && am.config != nil && am.config.EthChainID == ethereumMainnetChainID {
+		return fmt.Errorf("proof field overrides are refused on Ethereum mainnet (chain %d)", am.config.EthChainID)
+	}
+	am.proofOverrides = overrides
+	if overrides != nil {
+		am.logger.Printf("🚨 DEBUG PROOF FIELD OVERRIDES ENABLED - submitted proofs are deliberately altered: %s", overrides)
+	}
+	return nil
+}
+
+// applyProofOverrides applies the debug overrides, if any, to a contract proof
+func (am *AnchorManager) applyProofOverrides(anchorID string, proof *ContractCertenProof) error {
+	if am.proofOverrides == nil {
+		return nil
+	}
+	if err := am.proofOverrides.Apply(proof); err != nil {
+		return err
+	}
+	am.logger.Printf("🚨 DEBUG: proof for anchor %s submitted with overrides %s", anchorID, am.proofOverrides)
+	return nil
+}
+
+// GetGovernanceVerifierMonitor returns the governance verifier monitor, or nil if not started
+func (am *AnchorManager) GetGovernanceVerifierMonitor() *GovernanceVerifierMonitor {
+	return am.govVerifier
+}
+
 // =============================================================================
 // PHASE 1: Batch Adapter Bridge for ExecuteComprehensiveProof
 // Per ANCHOR_V3_IMPLEMENTATION_PLAN.md Task 1.3
@@ -1213,6 +1663,7 @@ type ExecuteComprehensiveProofOnChainRequest struct {
 	MerkleRoot           [32]byte   `json:"merkle_root"`
 	ProofHashes          [][32]byte `json:"proof_hashes"`
 	LeafHash             [32]byte   `json:"leaf_hash"`
+	LeafIndex            uint64     `json:"leaf_index"`
 	OperationCommitment  [32]byte   `json:"operation_commitment"`
 	CrossChainCommitment [32]byte   `json:"cross_chain_commitment"`
 	GovernanceRoot       [32]byte   `json:"governance_root"`
@@ -1228,17 +1679,66 @@ type ExecuteComprehensiveProofOnChainResult struct {
 	GasUsed     int64  `json:"gas_used"`
 	Success     bool   `json:"success"`
 	ProofValid  bool   `json:"proof_valid"`
+
+	CalldataBytes int  `json:"calldata_bytes"`
+	Shadow        bool `json:"shadow,omitempty"`
+
+	AlreadyAnchored       bool   `json:"already_anchored,omitempty"`
+	AlreadyAnchoredReason string `json:"already_anchored_reason,omitempty"`
 }
 
 // ExecuteComprehensiveProofOnChain implements the batch.AnchorManagerInterface
 // This bridges the batch processor format to the AnchorManager's ExecuteComprehensiveProof
 // Per CRITICAL-001: This is called after CreateBatchAnchorOnChain to submit proofs
 func (am *AnchorManager) ExecuteComprehensiveProofOnChain(ctx context.Context, req interface{}) (interface{}, error) {
-	// Type assert or convert the request
-	// We accept interface{} to avoid circular imports with batch package
-
 	am.logger.Printf("📋 [Phase 1] ExecuteComprehensiveProofOnChain called")
 
+	anchorID, proofBundle, err := onChainRequestToProofBundle(req)
+	if err != nil {
+		return nil, err
+	}
+	if am.votingPower != nil {
+		am.votingPower.ApplyToBLSProof(proofBundle.BLSProof)
+	}
+
+	am.logger.Printf("   AnchorID: %s", anchorID)
+	am.logger.Printf("   BatchID: %s", proofBundle.BatchID)
+	am.logger.Printf("   ValidatorID: %s", proofBundle.ValidatorID)
+	am.logger.Printf("   MerkleRoot: %x...", proofBundle.MerkleRoot[:8])
+
+	// Call the internal ExecuteComprehensiveProof method
+	internalReq := &ExecuteComprehensiveProofRequest{
+		AnchorID:    anchorID,
+		ProofBundle: proofBundle,
+	}
+
+	result, err := am.ExecuteComprehensiveProof(ctx, internalReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return in the expected format
+	return &ExecuteComprehensiveProofOnChainResult{
+		TxHash:      result.TxHash,
+		BlockNumber: result.BlockNumber,
+		BlockHash:   result.BlockHash,
+		GasUsed:     result.GasUsed,
+		Success:     result.Success,
+		ProofValid:  result.ProofValid,
+
+		CalldataBytes: result.CalldataBytes,
+		Shadow:        result.Shadow,
+
+		AlreadyAnchored:       result.AlreadyAnchored,
+		AlreadyAnchoredReason: result.AlreadyAnchoredReason,
+	}, nil
+}
+
+// onChainRequestToProofBundle builds the ProofBundle submitted for an on-chain request.
+// We accept interface{} to avoid circular imports with batch package; requests of other
+// struct types are decoded through their JSON form, which mirrors
+// ExecuteComprehensiveProofOnChainRequest.
+func onChainRequestToProofBundle(req interface{}) (string, *ProofBundle, error) {
 	// Handle the request based on its structure
 	// Since we can't import batch types, we'll use a map-based approach or direct struct
 	var anchorID string
@@ -1248,6 +1748,7 @@ func (am *AnchorManager) ExecuteComprehensiveProofOnChain(ctx context.Context, r
 	var merkleRoot [32]byte
 	var proofHashes [][32]byte
 	var leafHash [32]byte
+	var leafIndex uint64
 	var opCommitment [32]byte
 	var ccCommitment [32]byte
 	var govRoot [32]byte
@@ -1264,6 +1765,7 @@ func (am *AnchorManager) ExecuteComprehensiveProofOnChain(ctx context.Context, r
 		merkleRoot = r.MerkleRoot
 		proofHashes = r.ProofHashes
 		leafHash = r.LeafHash
+		leafIndex = r.LeafIndex
 		opCommitment = r.OperationCommitment
 		ccCommitment = r.CrossChainCommitment
 		govRoot = r.GovernanceRoot
@@ -1297,8 +1799,18 @@ func (am *AnchorManager) ExecuteComprehensiveProofOnChain(ctx context.Context, r
 		if v, ok := r["leaf_hash"].([32]byte); ok {
 			leafHash = v
 		}
+	case nil:
+		return "", nil, fmt.Errorf("request cannot be nil")
 	default:
-		return nil, fmt.Errorf("unsupported request type: %T", req)
+		data, err := json.Marshal(req)
+		if err != nil {
+			return "", nil, fmt.Errorf("unsupported request type: %T", req)
+		}
+		var mirrored ExecuteComprehensiveProofOnChainRequest
+		if err := json.Unmarshal(data, &mirrored); err != nil {
+			return "", nil, fmt.Errorf("unsupported request type: %T: %w", req, err)
+		}
+		return onChainRequestToProofBundle(&mirrored)
 	}
 
 	if anchorID == "" {
@@ -1308,11 +1820,6 @@ func (am *AnchorManager) ExecuteComprehensiveProofOnChain(ctx context.Context, r
 		timestamp = time.Now().Unix()
 	}
 
-	am.logger.Printf("   AnchorID: %s", anchorID)
-	am.logger.Printf("   BatchID: %s", batchID)
-	am.logger.Printf("   ValidatorID: %s", validatorID)
-	am.logger.Printf("   MerkleRoot: %x...", merkleRoot[:8])
-
 	// Build a ProofBundle from the request data
 	proofBundle := &ProofBundle{
 		BundleID:             anchorID,
@@ -1321,6 +1828,7 @@ func (am *AnchorManager) ExecuteComprehensiveProofOnChain(ctx context.Context, r
 		Timestamp:            time.Unix(timestamp, 0),
 		TransactionHash:      txHash,
 		LeafHash:             leafHash,
+		LeafIndex:            leafIndex,
 		MerkleRoot:           merkleRoot,
 		ProofHashes:          proofHashes,
 		OperationCommitment:  opCommitment,
@@ -1347,28 +1855,117 @@ func (am *AnchorManager) ExecuteComprehensiveProofOnChain(ctx context.Context, r
 		},
 	}
 
-	// Call the internal ExecuteComprehensiveProof method
-	internalReq := &ExecuteComprehensiveProofRequest{
-		AnchorID:    anchorID,
-		ProofBundle: proofBundle,
+	return anchorID, proofBundle, nil
+}
+
+// ProofVerificationChecks is the result of the contract's verifyCertenProofDetailed view,
+// one flag per check in contract order
+type ProofVerificationChecks struct {
+	MerkleVerified     bool `json:"merkle_verified"`
+	GovernanceVerified bool `json:"governance_verified"`
+	BLSVerified        bool `json:"bls_verified"`
+	CommitmentVerified bool `json:"commitment_verified"`
+	TimestampValid     bool `json:"timestamp_valid"`
+	NonceValid         bool `json:"nonce_valid"`
+}
+
+// Passed reports whether every check succeeded
+func (c *ProofVerificationChecks) Passed() bool {
+	return c != nil && c.MerkleVerified && c.GovernanceVerified && c.BLSVerified &&
+		c.CommitmentVerified && c.TimestampValid && c.NonceValid
+}
+
+// VerifyCertenProofDetailed calls the contract's verifyCertenProofDetailed view for a proof
+func (ec *EthereumChain) VerifyCertenProofDetailed(ctx context.Context, anchorID [32]byte, proof *ContractCertenProof) (*ProofVerificationChecks, error) {
+	if proof == nil {
+		return nil, fmt.Errorf("proof cannot be nil")
 	}
 
-	result, err := am.ExecuteComprehensiveProof(ctx, internalReq)
+	contractAddr := common.HexToAddress(ec.config.ContractAddress)
+
+	result, err := ec.ethereumClient.CallContract(ctx, contractAddr, certenAnchorABI, "verifyCertenProofDetailed", anchorID, proof)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to call verifyCertenProofDetailed: %w", err)
+	}
+	if len(result) < 1 {
+		return nil, fmt.Errorf("empty result from verifyCertenProofDetailed")
+	}
+	checks, ok := result[0].([6]bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type from verifyCertenProofDetailed: %T", result[0])
 	}
 
-	// Return in the expected format
-	return &ExecuteComprehensiveProofOnChainResult{
-		TxHash:      result.TxHash,
-		BlockNumber: result.BlockNumber,
-		BlockHash:   result.BlockHash,
-		GasUsed:     result.GasUsed,
-		Success:     result.Success,
-		ProofValid:  result.ProofValid,
+	return &ProofVerificationChecks{
+		MerkleVerified:     checks[0],
+		GovernanceVerified: checks[1],
+		BLSVerified:        checks[2],
+		CommitmentVerified: checks[3],
+		TimestampValid:     checks[4],
+		NonceValid:         checks[5],
 	}, nil
 }
 
+// VerifyComprehensiveProof checks a proof bundle against the contract without submitting it.
+// The contract proof is built exactly as ExecuteComprehensiveProof would submit it.
+func (am *AnchorManager) VerifyComprehensiveProof(ctx context.Context, req *ExecuteComprehensiveProofRequest) (*ProofVerificationChecks, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.AnchorID == "" {
+		return nil, fmt.Errorf("anchor_id is required")
+	}
+	if req.ProofBundle == nil {
+		return nil, fmt.Errorf("proof_bundle is required")
+	}
+
+	anchorIDBytes32 := GenerateBundleIDBytes32(req.AnchorID, req.ProofBundle.Timestamp.Unix())
+
+	contractProof := req.ProofBundle.ToContractProof()
+	if contractProof == nil {
+		return nil, fmt.Errorf("failed to convert proof bundle to contract format")
+	}
+	if am.govVerifier != nil && am.govVerifier.SkipsGovernance() {
+		contractProof.GovernanceProof = emptyContractGovernanceProof()
+	}
+	if err := am.applyProofOverrides(req.AnchorID, contractProof); err != nil {
+		return nil, err
+	}
+
+	chain, exists := am.chains["ethereum"]
+	if !exists {
+		return nil, fmt.Errorf("ethereum chain not configured")
+	}
+	ethChain, ok := chain.(*EthereumChain)
+	if !ok {
+		return nil, fmt.Errorf("invalid ethereum chain type")
+	}
+
+	return ethChain.VerifyCertenProofDetailed(ctx, anchorIDBytes32, contractProof)
+}
+
+// VerifyComprehensiveProofOnChain is the batch-facing counterpart of ExecuteComprehensiveProofOnChain:
+// it accepts the same request and returns *ProofVerificationChecks
+func (am *AnchorManager) VerifyComprehensiveProofOnChain(ctx context.Context, req interface{}) (interface{}, error) {
+	anchorID, proofBundle, err := onChainRequestToProofBundle(req)
+	if err != nil {
+		return nil, err
+	}
+	if am.votingPower != nil {
+		am.votingPower.ApplyToBLSProof(proofBundle.BLSProof)
+	}
+
+	checks, err := am.VerifyComprehensiveProof(ctx, &ExecuteComprehensiveProofRequest{
+		AnchorID:    anchorID,
+		ProofBundle: proofBundle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	am.logger.Printf("🔍 On-chain proof check for anchor %s: passed=%v (%+v)", anchorID, checks.Passed(), *checks)
+	return checks, nil
+}
+
 // =============================================================================
 // PHASE 5: Verification and Hardening
 // Per ANCHOR_V3_IMPLEMENTATION_PLAN.md Tasks 5.1, 5.2
@@ -1564,6 +2161,106 @@ func (ec *EthereumChain) GetStoredAnchor(ctx context.Context, bundleID [32]byte)
 	}, nil
 }
 
+// AnchorExists reports whether the contract holds an anchor for the given ID
+func (ec *EthereumChain) AnchorExists(ctx context.Context, bundleID [32]byte) (bool, error) {
+	contractAddr := common.HexToAddress(ec.config.ContractAddress)
+
+	result, err := ec.ethereumClient.CallContract(ctx, contractAddr, certenAnchorABI, "anchorExists", bundleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to call anchorExists: %w", err)
+	}
+	if len(result) < 1 {
+		return false, fmt.Errorf("unexpected result length from anchorExists: %d", len(result))
+	}
+	exists, ok := result[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected anchorExists result type: %T", result[0])
+	}
+	return exists, nil
+}
+
+// AnchorVerificationStats is the proof verification record the contract keeps per anchor
+type AnchorVerificationStats struct {
+	Total      uint64 // executeComprehensiveProof calls for the anchor
+	Successful uint64 // Calls whose proof verified
+}
+
+// GetVerificationStats retrieves an anchor's proof verification counts from the Ethereum contract
+func (ec *EthereumChain) GetVerificationStats(ctx context.Context, bundleID [32]byte) (*AnchorVerificationStats, error) {
+	contractAddr := common.HexToAddress(ec.config.ContractAddress)
+
+	result, err := ec.ethereumClient.CallContract(ctx, contractAddr, certenAnchorABI, "getVerificationStats", bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getVerificationStats: %w", err)
+	}
+	if len(result) < 2 {
+		return nil, fmt.Errorf("unexpected result length from getVerificationStats: %d", len(result))
+	}
+	total, ok1 := result[0].(*big.Int)
+	successful, ok2 := result[1].(*big.Int)
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("unexpected getVerificationStats result types: %T, %T", result[0], result[1])
+	}
+	return &AnchorVerificationStats{Total: total.Uint64(), Successful: successful.Uint64()}, nil
+}
+
+// GetBatchAnchorState reads the full on-chain state of a batch's deterministic anchor ID:
+// whether it exists, the stored anchor (including its valid flag) and its proof
+// verification counts. stored and stats are nil when the anchor does not exist.
+func (am *AnchorManager) GetBatchAnchorState(ctx context.Context, batchID string) (
+	exists bool, stored *StoredAnchorData, stats *AnchorVerificationStats, err error) {
+	chain, ok := am.chains["ethereum"]
+	if !ok {
+		return false, nil, nil, fmt.Errorf("ethereum chain not configured")
+	}
+	ethChain, ok := chain.(*EthereumChain)
+	if !ok {
+		return false, nil, nil, fmt.Errorf("invalid ethereum chain type")
+	}
+
+	bundleID := BatchAnchorBundleID(batchID)
+	exists, err = ethChain.AnchorExists(ctx, bundleID)
+	if err != nil || !exists {
+		return false, nil, nil, err
+	}
+	if stored, err = ethChain.GetStoredAnchor(ctx, bundleID); err != nil {
+		return true, nil, nil, err
+	}
+	if stats, err = ethChain.GetVerificationStats(ctx, bundleID); err != nil {
+		return true, stored, nil, err
+	}
+	return true, stored, stats, nil
+}
+
+// LookupBatchAnchorOnChain checks whether a batch was already anchored on Ethereum under its
+// deterministic anchor ID (see BatchAnchorBundleID). When it was, the stored operation
+// commitment (the batch Merkle root), Accumulate height and anchor timestamp are returned
+// so the caller can match them against the local batch before skipping re-submission.
+func (am *AnchorManager) LookupBatchAnchorOnChain(ctx context.Context, batchID string) (
+	exists bool, operationCommitment []byte, accumHeight int64, anchoredAt time.Time, err error) {
+	chain, ok := am.chains["ethereum"]
+	if !ok {
+		return false, nil, 0, time.Time{}, fmt.Errorf("ethereum chain not configured")
+	}
+	ethChain, ok := chain.(*EthereumChain)
+	if !ok {
+		return false, nil, 0, time.Time{}, fmt.Errorf("invalid ethereum chain type")
+	}
+
+	bundleID := BatchAnchorBundleID(batchID)
+	exists, err = ethChain.AnchorExists(ctx, bundleID)
+	if err != nil || !exists {
+		return false, nil, 0, time.Time{}, err
+	}
+
+	stored, err := ethChain.GetStoredAnchor(ctx, bundleID)
+	if err != nil {
+		return true, nil, 0, time.Time{}, err
+	}
+	return true, stored.OperationCommitment[:], int64(stored.AccumulateBlockHeight),
+		time.Unix(int64(stored.Timestamp), 0), nil
+}
+
 // =============================================================================
 // PHASE 5 Task 5.2: Bundle ID Collision Prevention
 // Addresses HIGH-004: BundleID Collision Risk
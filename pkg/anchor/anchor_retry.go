@@ -0,0 +1,179 @@
+// Copyright 2025 Certen Protocol
+//
+// Anchor Retry Policy - Resubmitting batch anchors after transient failures
+//
+// A createAnchor submission can fail because the Ethereum RPC is briefly unreachable,
+// the nonce raced another transaction, or the transaction was dropped from the mempool
+// while waiting to be mined. These failures are retried with exponential backoff up to
+// a maximum number of attempts. Failures that would recur on resubmission (a revert,
+// an invalid proof, insufficient funds) and unrecognized errors fail immediately.
+//
+// A failed wait can hide a transaction that was mined after all, so before each retry
+// the anchor's on-chain existence is checked and a duplicate createAnchor (which the
+// contract would revert) is never sent.
+
+package anchor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrAnchorAlreadyOnChain is returned when a retried batch anchor turns out to have been
+// created on-chain by an earlier attempt whose result was lost
+var ErrAnchorAlreadyOnChain = errors.New("anchor already exists on-chain")
+
+// AnchorRetryPolicy bounds retries of a failed createAnchor submission
+type AnchorRetryPolicy struct {
+	MaxAttempts    int           // Submissions including the first (<= 1 disables retries)
+	InitialBackoff time.Duration // Wait before the first retry, doubled for each further retry
+	MaxBackoff     time.Duration // Upper bound on the wait between retries
+}
+
+// DefaultAnchorRetryPolicy returns the default retry policy
+func DefaultAnchorRetryPolicy() AnchorRetryPolicy {
+	return AnchorRetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 5 * time.Second,
+		MaxBackoff:     time.Minute,
+	}
+}
+
+// Backoff returns the wait before the given retry (1 = first retry)
+func (p AnchorRetryPolicy) Backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < retry; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// Fatal error fragments take precedence: the same submission would fail again
+var fatalAnchorErrors = []string{
+	"execution reverted",
+	"revert",
+	"invalid proof",
+	"insufficient funds",
+	"gas required exceeds allowance",
+	"intrinsic gas too low",
+	"invalid sender",
+	"failed to parse",
+	"failed to pack",
+}
+
+// Transient error fragments from the RPC connection, nonce management and the mempool
+var retryableAnchorErrors = []string{
+	"nonce too low",
+	"replacement transaction underpriced",
+	"already known",
+	"transaction underpriced",
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"no such host",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+	"eof",
+	"too many requests",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+	"not found", // Transaction dropped from the mempool while waiting to be mined
+	"failed to wait for transaction",
+}
+
+// IsRetryableAnchorError reports whether a createAnchor failure is transient and the
+// anchor may be resubmitted. Unrecognized errors are not retried.
+func IsRetryableAnchorError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrAnchorAlreadyOnChain) {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range fatalAnchorErrors {
+		if strings.Contains(msg, fragment) {
+			return false
+		}
+	}
+	for _, fragment := range retryableAnchorErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// anchorExistenceChecker reports whether the contract already holds an anchor
+// Implemented by EthereumChain
+type anchorExistenceChecker interface {
+	AnchorExists(ctx context.Context, bundleID [32]byte) (bool, error)
+}
+
+// SetAnchorRetryPolicy sets the retry policy for batch anchor submissions
+func (am *AnchorManager) SetAnchorRetryPolicy(policy AnchorRetryPolicy) {
+	am.anchorRetry = policy
+}
+
+// createAnchorWithRetry submits a batch anchor, retrying transient failures under the
+// manager's retry policy. The returned error carries the attempt count and last error.
+func (am *AnchorManager) createAnchorWithRetry(ctx context.Context, chain Chain, anchorData *AnchorData) (*AnchorResult, error) {
+	policy := am.anchorRetry
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			backoff := policy.Backoff(attempt - 1)
+			am.logger.Printf("🔁 Retrying anchor for batch %s in %s (attempt %d/%d, last error: %v)",
+				anchorData.BatchID, backoff, attempt, maxAttempts, lastErr)
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("anchor retry for batch %s interrupted after %d attempts (last error: %v): %w",
+					anchorData.BatchID, attempt-1, lastErr, ctx.Err())
+			case <-time.After(backoff):
+			}
+
+			// An earlier attempt may have been mined after its wait failed
+			if checker, ok := chain.(anchorExistenceChecker); ok {
+				exists, err := checker.AnchorExists(ctx, BatchAnchorBundleID(anchorData.AnchorID))
+				if err == nil && exists {
+					return nil, fmt.Errorf("%w for batch %s after %d attempts (last error: %v)",
+						ErrAnchorAlreadyOnChain, anchorData.BatchID, attempt-1, lastErr)
+				}
+			}
+		}
+
+		result, err := chain.CreateAnchor(ctx, anchorData)
+		if err == nil {
+			if attempt > 1 {
+				am.logger.Printf("✅ Anchor for batch %s created on attempt %d/%d", anchorData.BatchID, attempt, maxAttempts)
+			}
+			return result, nil
+		}
+		lastErr = err
+
+		if !IsRetryableAnchorError(err) {
+			if attempt > 1 {
+				return nil, fmt.Errorf("non-retryable failure on attempt %d/%d: %w", attempt, maxAttempts, err)
+			}
+			return nil, err
+		}
+	}
+
+	if maxAttempts == 1 {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("giving up after %d attempts: %w", maxAttempts, lastErr)
+}
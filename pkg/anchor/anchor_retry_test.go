@@ -0,0 +1,188 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Anchor Retry Policy
+// Tests error classification, backoff growth and resubmission of batch anchors
+
+package anchor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/ethereum"
+)
+
+// retryTestChain fails CreateAnchor with the queued errors, then succeeds
+type retryTestChain struct {
+	errs     []error
+	calls    int
+	onChain  bool
+	existsCt int
+}
+
+func (c *retryTestChain) GetChainName() string { return "test" }
+func (c *retryTestChain) GetChainID() string   { return "1" }
+func (c *retryTestChain) CreateAnchor(ctx context.Context, anchor *AnchorData) (*AnchorResult, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return nil, c.errs[c.calls-1]
+	}
+	return &AnchorResult{AnchorID: anchor.AnchorID, Success: true}, nil
+}
+func (c *retryTestChain) GetAnchor(ctx context.Context, anchorID string) (*Anchor, error) {
+	return nil, nil
+}
+func (c *retryTestChain) VerifyAnchor(ctx context.Context, anchorID string) (bool, error) {
+	return false, nil
+}
+func (c *retryTestChain) EstimateGas(ctx context.Context, anchor *AnchorData) (*GasEstimate, error) {
+	return nil, nil
+}
+func (c *retryTestChain) GetLatestBlock(ctx context.Context) (*ChainBlock, error) {
+	return nil, nil
+}
+func (c *retryTestChain) AnchorExists(ctx context.Context, bundleID [32]byte) (bool, error) {
+	c.existsCt++
+	return c.onChain, nil
+}
+
+func newRetryTestManager(maxAttempts int) *AnchorManager {
+	return &AnchorManager{
+		logger: log.New(io.Discard, "", 0),
+		anchorRetry: AnchorRetryPolicy{
+			MaxAttempts:    maxAttempts,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+		},
+	}
+}
+
+func TestIsRetryableAnchorError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("failed to send transaction: nonce too low"), true},
+		{errors.New("replacement transaction underpriced"), true},
+		{errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), true},
+		{errors.New("failed to wait for transaction: not found"), true},
+		{errors.New("execution reverted: anchor already exists"), false},
+		{errors.New("invalid proof: merkle path mismatch"), false},
+		{errors.New("insufficient funds for gas * price + value"), false},
+		{errors.New("something unexpected"), false},
+		{context.Canceled, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableAnchorError(tt.err); got != tt.want {
+			t.Errorf("IsRetryableAnchorError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestAnchorRetryPolicy_Backoff(t *testing.T) {
+	p := AnchorRetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := p.Backoff(i + 1); got != w {
+			t.Errorf("Backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestCreateAnchorWithRetry_RecoversFromTransientError(t *testing.T) {
+	am := newRetryTestManager(3)
+	chain := &retryTestChain{errs: []error{errors.New("connection refused"), errors.New("nonce too low")}}
+
+	result, err := am.createAnchorWithRetry(context.Background(), chain, &AnchorData{AnchorID: "a", BatchID: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success || chain.calls != 3 {
+		t.Errorf("expected success on third attempt, got success=%v calls=%d", result.Success, chain.calls)
+	}
+}
+
+func TestCreateAnchorWithRetry_FatalErrorNotRetried(t *testing.T) {
+	am := newRetryTestManager(3)
+	chain := &retryTestChain{errs: []error{errors.New("execution reverted")}}
+
+	if _, err := am.createAnchorWithRetry(context.Background(), chain, &AnchorData{AnchorID: "a"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if chain.calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", chain.calls)
+	}
+}
+
+func TestCreateAnchorWithRetry_GivesUpWithLastError(t *testing.T) {
+	am := newRetryTestManager(2)
+	chain := &retryTestChain{errs: []error{errors.New("connection refused"), errors.New("connection reset by peer")}}
+
+	_, err := am.createAnchorWithRetry(context.Background(), chain, &AnchorData{AnchorID: "a"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if chain.calls != 2 || !strings.Contains(err.Error(), "after 2 attempts") || !strings.Contains(err.Error(), "connection reset by peer") {
+		t.Errorf("unexpected result: calls=%d err=%v", chain.calls, err)
+	}
+}
+
+func TestCreateAnchorWithRetry_StopsWhenAlreadyOnChain(t *testing.T) {
+	am := newRetryTestManager(3)
+	chain := &retryTestChain{errs: []error{errors.New("failed to wait for transaction: not found")}, onChain: true}
+
+	_, err := am.createAnchorWithRetry(context.Background(), chain, &AnchorData{AnchorID: "a"})
+	if !errors.Is(err, ErrAnchorAlreadyOnChain) {
+		t.Fatalf("expected ErrAnchorAlreadyOnChain, got %v", err)
+	}
+	if chain.calls != 1 || chain.existsCt != 1 {
+		t.Errorf("expected no resubmission, got calls=%d existence checks=%d", chain.calls, chain.existsCt)
+	}
+}
+
+func TestBatchAnchorData_ReplacementSchedule(t *testing.T) {
+	urgent := &ethereum.FeeEscalationSchedule{Interval: time.Second, BumpPercent: 25}
+	stuck := &ethereum.FeeEscalationSchedule{Interval: time.Minute, BumpPercent: 20}
+	am := newRetryTestManager(1)
+	am.chains = map[string]Chain{"ethereum": &retryTestChain{}}
+
+	root := make([]byte, 32)
+	root[0] = 1
+	req := &AnchorOnChainRequest{
+		BatchID:              "b",
+		MerkleRoot:           root,
+		OperationCommitment:  root,
+		CrossChainCommitment: root,
+		GovernanceRoot:       root,
+		TxCount:              1,
+	}
+
+	schedule := func(urgentReq bool) *ethereum.FeeEscalationSchedule {
+		req.Urgent = urgentReq
+		_, _, data, err := am.batchAnchorData(req)
+		if err != nil {
+			t.Fatalf("batchAnchorData failed: %v", err)
+		}
+		return data.FeeEscalation
+	}
+
+	if schedule(false) != nil || schedule(true) != nil {
+		t.Error("expected no replacement schedule by default")
+	}
+
+	am.SetStuckTxReplacement(stuck)
+	if schedule(false) != stuck || schedule(true) != stuck {
+		t.Error("expected stuck transaction replacement for all anchors")
+	}
+
+	am.SetFeeEscalation(urgent)
+	if schedule(false) != stuck || schedule(true) != urgent {
+		t.Error("expected urgent anchors to use the on-demand escalation schedule")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Certen Protocol
+//
+// Event Watcher Checkpoints - Resume contract event scanning after a restart
+//
+// The watcher records the last block whose events have all been handled, together
+// with the events already handled above it. On restart it resumes from that block
+// instead of a fixed lookback, so downtime does not lose events and short restarts
+// do not replay them.
+
+package anchor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultMaxCatchUpBlocks bounds how far behind the head a checkpoint may resume from
+// (about 17 hours of Ethereum blocks)
+const DefaultMaxCatchUpBlocks = 5000
+
+// EventCheckpoint is how far the watcher has scanned a contract
+type EventCheckpoint struct {
+	ContractAddress string            `json:"contract_address"`
+	BlockNumber     uint64            `json:"block_number"`   // Every event up to this block has been handled
+	HandledEvents   map[string]uint64 `json:"handled_events"` // Events handled above BlockNumber: event key -> block
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// EventCheckpointStore persists event watcher checkpoints
+type EventCheckpointStore interface {
+	// LoadCheckpoint returns the contract's checkpoint, or nil if there is none
+	LoadCheckpoint(ctx context.Context, contractAddress string) (*EventCheckpoint, error)
+	// SaveCheckpoint replaces the contract's checkpoint
+	SaveCheckpoint(ctx context.Context, checkpoint *EventCheckpoint) error
+}
+
+// FileEventCheckpointStore keeps checkpoints in a local JSON file, one per contract
+type FileEventCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileEventCheckpointStore creates a store backed by the file at path
+func NewFileEventCheckpointStore(path string) *FileEventCheckpointStore {
+	return &FileEventCheckpointStore{path: path}
+}
+
+// LoadCheckpoint implements EventCheckpointStore
+func (s *FileEventCheckpointStore) LoadCheckpoint(ctx context.Context, contractAddress string) (*EventCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return checkpoints[strings.ToLower(contractAddress)], nil
+}
+
+// SaveCheckpoint implements EventCheckpointStore
+func (s *FileEventCheckpointStore) SaveCheckpoint(ctx context.Context, checkpoint *EventCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[strings.ToLower(checkpoint.ContractAddress)] = checkpoint
+
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create event checkpoint directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write event checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write event checkpoint: %w", err)
+	}
+	return nil
+}
+
+// read returns the checkpoints in the file, keyed by lowercase contract address
+func (s *FileEventCheckpointStore) read() (map[string]*EventCheckpoint, error) {
+	checkpoints := make(map[string]*EventCheckpoint)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to parse event checkpoint %s: %w", s.path, err)
+	}
+	return checkpoints, nil
+}
+
+// eventKey identifies a contract event across rescans
+func eventKey(log types.Log) string {
+	return fmt.Sprintf("%s:%d", strings.ToLower(log.TxHash.Hex()), log.Index)
+}
+
+// resumeBlock returns the last processed block to resume from: the checkpoint when it
+// is within maxCatchUp blocks of the head, otherwise the head minus maxCatchUp.
+// skipped reports how many blocks beyond the bound will not be scanned.
+func resumeBlock(checkpoint, currentBlock, maxCatchUp uint64) (block, skipped uint64) {
+	if checkpoint >= currentBlock || currentBlock-checkpoint <= maxCatchUp {
+		return checkpoint, 0
+	}
+	block = currentBlock - maxCatchUp
+	return block, block - checkpoint
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Event Watcher Checkpoints
+// Tests resuming from a saved checkpoint, skipping handled events and the catch-up bound
+
+package anchor
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeLogSource serves ValidatorRegistered logs up to a movable head
+type fakeLogSource struct {
+	mu   sync.Mutex
+	head uint64
+	logs []types.Log
+}
+
+func (s *fakeLogSource) addLog(t *testing.T, block uint64, txByte byte) types.Log {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(CertenAnchorV3EventsABI))
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l := types.Log{
+		Topics:      []common.Hash{parsed.Events["ValidatorRegistered"].ID, common.BytesToHash([]byte{txByte})},
+		BlockNumber: block,
+		TxHash:      common.BytesToHash([]byte{0xee, txByte}),
+	}
+	s.logs = append(s.logs, l)
+	return l
+}
+
+func (s *fakeLogSource) setHead(head uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.head = head
+}
+
+func (s *fakeLogSource) BlockNumber(ctx context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.head, nil
+}
+
+func (s *fakeLogSource) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var logs []types.Log
+	for _, l := range s.logs {
+		if l.BlockNumber >= query.FromBlock.Uint64() && l.BlockNumber <= query.ToBlock.Uint64() {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+// runWatcher starts a watcher over source, waits until it has scanned to the head and
+// handled want events, then stops it. Returns the blocks of the handled events.
+func runWatcher(t *testing.T, source *fakeLogSource, store EventCheckpointStore, want int) []uint64 {
+	t.Helper()
+	w, err := NewEventWatcher(&EventWatcherConfig{
+		ContractAddress: common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		LogSource:       source,
+		PollInterval:    5 * time.Millisecond,
+		BlockLookback:   100,
+		Checkpoints:     store,
+		EventBufferSize: 10,
+		RetryAttempts:   1,
+	}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewEventWatcher: %v", err)
+	}
+
+	var mu sync.Mutex
+	var blocks []uint64
+	w.RegisterHandler(EventTypeUnknown, func(event ContractEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		blocks = append(blocks, event.GetBlockNumber())
+		return nil
+	})
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	head, _ := source.BlockNumber(context.Background())
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		handled := len(blocks)
+		mu.Unlock()
+		if handled >= want && w.GetLastProcessedBlock() >= head {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out with %d/%d events handled at block %d/%d", handled, want, w.GetLastProcessedBlock(), head)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond) // Let any duplicate through
+	if err := w.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return blocks
+}
+
+func TestEventWatcher_ResumesFromCheckpoint(t *testing.T) {
+	source := &fakeLogSource{head: 50}
+	source.addLog(t, 10, 1)
+	source.addLog(t, 20, 2)
+	source.addLog(t, 45, 3)
+	store := NewFileEventCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	if blocks := runWatcher(t, source, store, 3); len(blocks) != 3 {
+		t.Fatalf("first run handled %v, want 3 events", blocks)
+	}
+	checkpoint, err := store.LoadCheckpoint(context.Background(), "0x00000000000000000000000000000000000000AA")
+	if err != nil || checkpoint == nil || checkpoint.BlockNumber != 50 {
+		t.Fatalf("expected a checkpoint at block 50, got %+v (err %v)", checkpoint, err)
+	}
+
+	// After the restart only the new event is handled
+	source.addLog(t, 60, 4)
+	source.setHead(70)
+	if blocks := runWatcher(t, source, store, 1); len(blocks) != 1 || blocks[0] != 60 {
+		t.Errorf("second run handled events at blocks %v, want [60]", blocks)
+	}
+}
+
+func TestEventWatcher_SkipsHandledEvents(t *testing.T) {
+	source := &fakeLogSource{head: 50}
+	handledLog := source.addLog(t, 45, 1)
+	source.addLog(t, 48, 2)
+	store := NewFileEventCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	// Stopped after handling the block 45 event but before its block was checkpointed
+	if err := store.SaveCheckpoint(context.Background(), &EventCheckpoint{
+		ContractAddress: "0x00000000000000000000000000000000000000aa",
+		BlockNumber:     40,
+		HandledEvents:   map[string]uint64{eventKey(handledLog): 45},
+	}); err != nil {
+		t.Fatalf("SaveCheckpoint: %v", err)
+	}
+
+	if blocks := runWatcher(t, source, store, 1); len(blocks) != 1 || blocks[0] != 48 {
+		t.Errorf("handled events at blocks %v, want [48]", blocks)
+	}
+
+	// Handled events at or below the new checkpoint are no longer kept
+	checkpoint, _ := store.LoadCheckpoint(context.Background(), "0x00000000000000000000000000000000000000aa")
+	if checkpoint == nil || checkpoint.BlockNumber != 50 || len(checkpoint.HandledEvents) != 0 {
+		t.Errorf("unexpected checkpoint %+v", checkpoint)
+	}
+}
+
+func TestResumeBlock(t *testing.T) {
+	tests := []struct {
+		checkpoint, current, maxCatchUp uint64
+		wantBlock, wantSkipped          uint64
+	}{
+		{checkpoint: 900, current: 1000, maxCatchUp: 500, wantBlock: 900},
+		{checkpoint: 100, current: 1000, maxCatchUp: 500, wantBlock: 500, wantSkipped: 400},
+		{checkpoint: 1200, current: 1000, maxCatchUp: 500, wantBlock: 1200}, // Endpoint behind the checkpoint
+	}
+	for _, tt := range tests {
+		block, skipped := resumeBlock(tt.checkpoint, tt.current, tt.maxCatchUp)
+		if block != tt.wantBlock || skipped != tt.wantSkipped {
+			t.Errorf("resumeBlock(%d, %d, %d) = (%d, %d), want (%d, %d)",
+				tt.checkpoint, tt.current, tt.maxCatchUp, block, skipped, tt.wantBlock, tt.wantSkipped)
+		}
+	}
+}
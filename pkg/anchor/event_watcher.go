@@ -301,9 +301,17 @@ type EventWatcherConfig struct {
 	EthereumURL string
 	ChainID     int64
 
+	// LogSource, when set, is used instead of dialing EthereumURL, e.g. the validator's
+	// *ethereum.Client so the watcher follows its reconnects and endpoint failover
+	LogSource EventLogSource
+
 	// Polling configuration (for networks without WebSocket support)
 	PollInterval time.Duration
-	BlockLookback uint64 // How many blocks back to scan on start
+	BlockLookback uint64 // How many blocks back to scan on start without a checkpoint
+
+	// Checkpoints, when set, persist the last scanned block so a restart resumes from it
+	Checkpoints      EventCheckpointStore
+	MaxCatchUpBlocks uint64 // Furthest behind the head a checkpoint may resume from (0 = DefaultMaxCatchUpBlocks)
 
 	// Filter configuration
 	EnabledEvents []EventType // Which events to watch (empty = all)
@@ -317,19 +325,26 @@ type EventWatcherConfig struct {
 // DefaultEventWatcherConfig returns a default configuration
 func DefaultEventWatcherConfig() *EventWatcherConfig {
 	return &EventWatcherConfig{
-		PollInterval:    15 * time.Second,
-		BlockLookback:   100,
-		EventBufferSize: 1000,
-		RetryAttempts:   3,
-		RetryDelay:      2 * time.Second,
-		EnabledEvents:   []EventType{}, // All events
+		PollInterval:     15 * time.Second,
+		BlockLookback:    100,
+		MaxCatchUpBlocks: DefaultMaxCatchUpBlocks,
+		EventBufferSize:  1000,
+		RetryAttempts:    3,
+		RetryDelay:       2 * time.Second,
+		EnabledEvents:    []EventType{}, // All events
 	}
 }
 
+// EventLogSource is the part of an Ethereum client the watcher reads from
+type EventLogSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
 // EventWatcher monitors CertenAnchorV3 contract events
 type EventWatcher struct {
 	config *EventWatcherConfig
-	client *ethclient.Client
+	client EventLogSource
 	abi    abi.ABI
 
 	// Event channel
@@ -338,7 +353,10 @@ type EventWatcher struct {
 
 	// State management
 	lastProcessedBlock uint64
+	pending            map[ContractEvent]string // Emitted but not yet dispatched: event -> key
+	handled            map[string]uint64        // Dispatched above the checkpoint: key -> block
 	mu                 sync.RWMutex
+	checkpointMu       sync.Mutex // Serializes checkpoint saves
 
 	// Lifecycle management
 	ctx        context.Context
@@ -367,7 +385,7 @@ func NewEventWatcher(config *EventWatcherConfig, logger *log.Logger) (*EventWatc
 		return nil, fmt.Errorf("contract address is required")
 	}
 
-	if config.EthereumURL == "" {
+	if config.EthereumURL == "" && config.LogSource == nil {
 		return nil, fmt.Errorf("ethereum URL is required")
 	}
 
@@ -377,15 +395,22 @@ func NewEventWatcher(config *EventWatcherConfig, logger *log.Logger) (*EventWatc
 		return nil, fmt.Errorf("failed to parse events ABI: %w", err)
 	}
 
-	// Connect to Ethereum
-	client, err := ethclient.Dial(config.EthereumURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum: %w", err)
+	// Connect to Ethereum unless a client is shared
+	client := config.LogSource
+	if client == nil {
+		dialed, err := ethclient.Dial(config.EthereumURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Ethereum: %w", err)
+		}
+		client = dialed
 	}
 
 	if logger == nil {
 		logger = log.New(log.Writer(), "[EventWatcher] ", log.LstdFlags)
 	}
+	if config.MaxCatchUpBlocks == 0 {
+		config.MaxCatchUpBlocks = DefaultMaxCatchUpBlocks
+	}
 
 	return &EventWatcher{
 		config:   config,
@@ -393,6 +418,8 @@ func NewEventWatcher(config *EventWatcherConfig, logger *log.Logger) (*EventWatc
 		abi:      parsedABI,
 		events:   make(chan ContractEvent, config.EventBufferSize),
 		errors:   make(chan error, 100),
+		pending:  make(map[ContractEvent]string),
+		handled:  make(map[string]uint64),
 		handlers: make(map[EventType][]EventHandler),
 		logger:   logger,
 	}, nil
@@ -410,6 +437,43 @@ func (w *EventWatcher) RegisterHandler(eventType EventType, handler EventHandler
 	w.handlers[eventType] = append(w.handlers[eventType], handler)
 }
 
+// OnAnchorCreated registers a handler for AnchorCreated events
+func (w *EventWatcher) OnAnchorCreated(handler func(*AnchorCreatedEvent) error) {
+	w.RegisterHandler(EventTypeAnchorCreated, typedHandler(EventTypeAnchorCreated, handler))
+}
+
+// OnProofExecuted registers a handler for ProofExecuted events
+func (w *EventWatcher) OnProofExecuted(handler func(*ProofExecutedEvent) error) {
+	w.RegisterHandler(EventTypeProofExecuted, typedHandler(EventTypeProofExecuted, handler))
+}
+
+// OnProofVerificationFailed registers a handler for ProofVerificationFailed events
+func (w *EventWatcher) OnProofVerificationFailed(handler func(*ProofVerificationFailedEvent) error) {
+	w.RegisterHandler(EventTypeProofVerificationFailed, typedHandler(EventTypeProofVerificationFailed, handler))
+}
+
+// OnGovernanceExecuted registers a handler for GovernanceExecuted events
+func (w *EventWatcher) OnGovernanceExecuted(handler func(*GovernanceExecutedEvent) error) {
+	w.RegisterHandler(EventTypeGovernanceExecuted, typedHandler(EventTypeGovernanceExecuted, handler))
+}
+
+// OnValidatorRegistered registers a handler for ValidatorRegistered events
+func (w *EventWatcher) OnValidatorRegistered(handler func(*ValidatorRegisteredEvent) error) {
+	w.RegisterHandler(EventTypeValidatorRegistered, typedHandler(EventTypeValidatorRegistered, handler))
+}
+
+// typedHandler adapts a handler of one concrete event type to an EventHandler. An event
+// of any other type is reported as a handler error instead of panicking.
+func typedHandler[E ContractEvent](eventType EventType, handler func(E) error) EventHandler {
+	return func(event ContractEvent) error {
+		typed, ok := event.(E)
+		if !ok {
+			return fmt.Errorf("unexpected %T for %s handler", event, eventType)
+		}
+		return handler(typed)
+	}
+}
+
 // Events returns the event channel for receiving parsed events
 func (w *EventWatcher) Events() <-chan ContractEvent {
 	return w.events
@@ -469,6 +533,9 @@ func (w *EventWatcher) Stop() error {
 	// Wait for goroutines to finish
 	w.wg.Wait()
 
+	// Events still buffered were not handled; the checkpoint stays below them
+	w.saveCheckpoint()
+
 	// Close channels
 	close(w.events)
 	close(w.errors)
@@ -485,6 +552,29 @@ func (w *EventWatcher) initializeStartBlock() error {
 		return fmt.Errorf("failed to get current block: %w", err)
 	}
 
+	// Resume from the checkpoint when there is one
+	if w.config.Checkpoints != nil {
+		checkpoint, err := w.config.Checkpoints.LoadCheckpoint(w.ctx, w.config.ContractAddress.Hex())
+		if err != nil {
+			w.logger.Printf("Failed to load checkpoint, falling back to a %d block lookback: %v", w.config.BlockLookback, err)
+		} else if checkpoint != nil {
+			block, skipped := resumeBlock(checkpoint.BlockNumber, currentBlock, w.config.MaxCatchUpBlocks)
+			if skipped > 0 {
+				w.logger.Printf("⚠️ Checkpoint at block %d is %d blocks behind the head; skipping %d blocks (max catch-up %d)",
+					checkpoint.BlockNumber, currentBlock-checkpoint.BlockNumber, skipped, w.config.MaxCatchUpBlocks)
+			}
+			w.lastProcessedBlock = block
+			for key, eventBlock := range checkpoint.HandledEvents {
+				if eventBlock > block {
+					w.handled[key] = eventBlock
+				}
+			}
+			w.logger.Printf("Resuming from checkpoint at block %d (%d events already handled above it)",
+				block, len(w.handled))
+			return nil
+		}
+	}
+
 	// Calculate start block with lookback
 	if currentBlock > w.config.BlockLookback {
 		w.lastProcessedBlock = currentBlock - w.config.BlockLookback
@@ -519,7 +609,8 @@ func (w *EventWatcher) pollLoop() {
 	}
 }
 
-// pollEvents fetches and processes events from the contract
+// pollEvents fetches and processes events from the contract, scanning range by range
+// until it reaches the current block
 func (w *EventWatcher) pollEvents() error {
 	// Get current block number
 	currentBlock, err := w.client.BlockNumber(w.ctx)
@@ -527,21 +618,33 @@ func (w *EventWatcher) pollEvents() error {
 		return fmt.Errorf("failed to get current block: %w", err)
 	}
 
-	w.mu.RLock()
-	fromBlock := w.lastProcessedBlock + 1
-	w.mu.RUnlock()
+	for {
+		w.mu.RLock()
+		fromBlock := w.lastProcessedBlock + 1
+		w.mu.RUnlock()
 
-	if fromBlock > currentBlock {
-		return nil // No new blocks
-	}
+		if fromBlock > currentBlock {
+			return nil // Caught up
+		}
+
+		// Cap the range to prevent too large queries
+		// NOTE: Alchemy free tier limits eth_getLogs to 10 blocks per request
+		toBlock := currentBlock
+		maxBlockRange := uint64(9) // Alchemy free tier limit (10 blocks inclusive)
+		if toBlock-fromBlock > maxBlockRange {
+			toBlock = fromBlock + maxBlockRange
+		}
 
-	// Cap the range to prevent too large queries
-	// NOTE: Alchemy free tier limits eth_getLogs to 10 blocks per request
-	toBlock := currentBlock
-	maxBlockRange := uint64(9) // Alchemy free tier limit (10 blocks inclusive)
-	if toBlock-fromBlock > maxBlockRange {
-		toBlock = fromBlock + maxBlockRange
+		if err := w.scanRange(fromBlock, toBlock); err != nil {
+			return err
+		}
+		w.saveCheckpoint()
 	}
+}
+
+// scanRange fetches the events of a block range and emits the ones not yet handled
+func (w *EventWatcher) scanRange(fromBlock, toBlock uint64) error {
+	var err error
 
 	// Build filter query
 	query := ethereum.FilterQuery{
@@ -578,19 +681,35 @@ func (w *EventWatcher) pollEvents() error {
 		return fmt.Errorf("failed to filter logs after %d attempts: %w", w.config.RetryAttempts, err)
 	}
 
-	// Parse and emit events
+	// Parse and emit events, skipping those handled before a restart or a rescan
+	emitted := 0
 	for _, log := range logs {
+		key := eventKey(log)
+		if w.seen(key) {
+			continue
+		}
 		event, err := w.parseLog(log)
 		if err != nil {
 			w.logger.Printf("Failed to parse log: %v", err)
 			continue
 		}
-		if event != nil {
-			select {
-			case w.events <- event:
-			default:
-				w.logger.Printf("Event channel full, dropping event")
-			}
+		if event == nil {
+			continue
+		}
+
+		w.mu.Lock()
+		w.pending[event] = key
+		w.mu.Unlock()
+
+		// Wait for the dispatcher rather than drop: the checkpoint moves past this block
+		select {
+		case w.events <- event:
+			emitted++
+		case <-w.ctx.Done():
+			w.mu.Lock()
+			delete(w.pending, event)
+			w.mu.Unlock()
+			return w.ctx.Err()
 		}
 	}
 
@@ -599,13 +718,85 @@ func (w *EventWatcher) pollEvents() error {
 	w.lastProcessedBlock = toBlock
 	w.mu.Unlock()
 
-	if len(logs) > 0 {
-		w.logger.Printf("Processed %d events from blocks %d to %d", len(logs), fromBlock, toBlock)
+	if emitted > 0 {
+		w.logger.Printf("Processed %d events from blocks %d to %d", emitted, fromBlock, toBlock)
 	}
 
 	return nil
 }
 
+// seen reports whether an event is already handled or waiting to be dispatched
+func (w *EventWatcher) seen(key string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if _, ok := w.handled[key]; ok {
+		return true
+	}
+	for _, pendingKey := range w.pending {
+		if pendingKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+// markHandled records a dispatched event so a rescan of its block skips it
+func (w *EventWatcher) markHandled(event ContractEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if key, ok := w.pending[event]; ok {
+		delete(w.pending, event)
+		w.handled[key] = event.GetBlockNumber()
+	}
+}
+
+// checkpoint returns the current checkpoint: the last block with no undispatched
+// events, and the events handled above it. Handled events at or below the checkpoint
+// are no longer needed and are dropped.
+func (w *EventWatcher) checkpoint() *EventCheckpoint {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	block := w.lastProcessedBlock
+	for event := range w.pending {
+		if b := event.GetBlockNumber(); b > 0 && b-1 < block {
+			block = b - 1
+		}
+	}
+
+	handled := make(map[string]uint64)
+	for key, eventBlock := range w.handled {
+		if eventBlock > block {
+			handled[key] = eventBlock
+		} else {
+			delete(w.handled, key)
+		}
+	}
+
+	return &EventCheckpoint{
+		ContractAddress: w.config.ContractAddress.Hex(),
+		BlockNumber:     block,
+		HandledEvents:   handled,
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// saveCheckpoint persists the current checkpoint, if checkpointing is enabled
+func (w *EventWatcher) saveCheckpoint() {
+	if w.config.Checkpoints == nil {
+		return
+	}
+
+	w.checkpointMu.Lock()
+	defer w.checkpointMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := w.config.Checkpoints.SaveCheckpoint(ctx, w.checkpoint()); err != nil {
+		w.logger.Printf("Failed to save checkpoint: %v", err)
+	}
+}
+
 // getTopicForEventType returns the topic hash for an event type
 func (w *EventWatcher) getTopicForEventType(et EventType) common.Hash {
 	switch et {
@@ -901,6 +1092,8 @@ func (w *EventWatcher) dispatchLoop() {
 				return
 			}
 			w.dispatchEvent(event)
+			w.markHandled(event)
+			w.saveCheckpoint()
 		}
 	}
 }
@@ -942,7 +1135,7 @@ func (w *EventWatcher) GetLastProcessedBlock() uint64 {
 	return w.lastProcessedBlock
 }
 
-// SetLastProcessedBlock sets the last processed block (for resuming from a checkpoint)
+// SetLastProcessedBlock sets the last processed block (for resuming from a known block)
 func (w *EventWatcher) SetLastProcessedBlock(blockNumber uint64) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -0,0 +1,65 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Event Watcher
+// Tests typed event subscriptions
+
+package anchor
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEventWatcher_TypedSubscriptions(t *testing.T) {
+	w, err := NewEventWatcher(&EventWatcherConfig{
+		ContractAddress: common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		LogSource:       &fakeLogSource{},
+		EventBufferSize: 10,
+	}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewEventWatcher: %v", err)
+	}
+
+	var failed []*ProofVerificationFailedEvent
+	var created []*AnchorCreatedEvent
+	w.OnProofVerificationFailed(func(e *ProofVerificationFailedEvent) error {
+		failed = append(failed, e)
+		return nil
+	})
+	w.OnAnchorCreated(func(e *AnchorCreatedEvent) error {
+		created = append(created, e)
+		return nil
+	})
+
+	w.dispatchEvent(&ProofVerificationFailedEvent{Reason: "merkle root mismatch", BlockNumber: 7})
+	w.dispatchEvent(&AnchorCreatedEvent{BlockNumber: 8})
+	w.dispatchEvent(&ProofExecutedEvent{BlockNumber: 9}) // No typed handler registered
+
+	if len(failed) != 1 || failed[0].Reason != "merkle root mismatch" {
+		t.Errorf("ProofVerificationFailed handler got %+v", failed)
+	}
+	if len(created) != 1 || created[0].BlockNumber != 8 {
+		t.Errorf("AnchorCreated handler got %+v", created)
+	}
+}
+
+func TestTypedHandler_RejectsOtherEventTypes(t *testing.T) {
+	called := false
+	handler := typedHandler(EventTypeProofVerificationFailed, func(e *ProofVerificationFailedEvent) error {
+		called = true
+		return nil
+	})
+
+	if err := handler(&ProofExecutedEvent{}); err == nil {
+		t.Error("expected an error for a mismatched event type")
+	}
+	if called {
+		t.Error("handler must not be called with a mismatched event type")
+	}
+	if err := handler(&ProofVerificationFailedEvent{}); err != nil || !called {
+		t.Errorf("expected the handler to be called, got err %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2025 Certen Protocol
+//
+// Governance Verifier Monitor - Aligns governance proof submission with on-chain capability
+//
+// CertenAnchorV3 can only verify governance proof data once a governance verifier has been
+// set and initialized (getGovernanceVerifierStatus). The monitor checks this at startup and
+// periodically, and applies a policy when no verifier is configured:
+//   - "warn": submit governance proof data as usual but log a warning on every submission
+//   - "skip": submit an empty governance proof and record why it was skipped
+// An unknown status (the contract call failed) never triggers the policy.
+
+package anchor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GovernanceVerifierPolicy selects what happens when no governance verifier is configured on-chain
+type GovernanceVerifierPolicy string
+
+const (
+	GovernanceVerifierPolicyWarn GovernanceVerifierPolicy = "warn" // Submit governance data, warn loudly
+	GovernanceVerifierPolicySkip GovernanceVerifierPolicy = "skip" // Omit governance data, record the reason
+)
+
+// ParseGovernanceVerifierPolicy parses a policy name, defaulting to warn
+func ParseGovernanceVerifierPolicy(s string) (GovernanceVerifierPolicy, error) {
+	switch GovernanceVerifierPolicy(strings.ToLower(strings.TrimSpace(s))) {
+	case "", GovernanceVerifierPolicyWarn:
+		return GovernanceVerifierPolicyWarn, nil
+	case GovernanceVerifierPolicySkip:
+		return GovernanceVerifierPolicySkip, nil
+	default:
+		return "", fmt.Errorf("unknown governance verifier policy %q (expected \"warn\" or \"skip\")", s)
+	}
+}
+
+// GovernanceVerifierStatus is the result of getGovernanceVerifierStatus on the anchor contract
+type GovernanceVerifierStatus struct {
+	VerifierSet         bool  `json:"verifier_set"`
+	VerifierInitialized bool  `json:"verifier_initialized"`
+	MinLevel            uint8 `json:"min_level"`
+}
+
+// Configured reports whether the contract can verify governance proof data
+func (s *GovernanceVerifierStatus) Configured() bool {
+	return s != nil && s.VerifierSet && s.VerifierInitialized
+}
+
+// GovernanceVerifierStatusFetcher reads the governance verifier status from the anchor contract
+type GovernanceVerifierStatusFetcher interface {
+	GetGovernanceVerifierStatus(ctx context.Context) (*GovernanceVerifierStatus, error)
+}
+
+// GovernanceVerifierHealth summarizes the monitor state for health reporting
+type GovernanceVerifierHealth struct {
+	Status         string                    `json:"status"` // "configured", "not_configured", "unknown"
+	Policy         GovernanceVerifierPolicy  `json:"policy"`
+	Verifier       *GovernanceVerifierStatus `json:"verifier,omitempty"`
+	LastChecked    time.Time                 `json:"last_checked,omitempty"`
+	LastError      string                    `json:"last_error,omitempty"`
+	SkippedProofs  int64                     `json:"skipped_proofs"`
+	WarnedProofs   int64                     `json:"warned_proofs"`
+	LastSkipReason string                    `json:"last_skip_reason,omitempty"`
+}
+
+// GovernanceVerifierMonitor tracks whether the anchor contract has a governance verifier
+type GovernanceVerifierMonitor struct {
+	mu sync.RWMutex
+
+	fetcher       GovernanceVerifierStatusFetcher
+	policy        GovernanceVerifierPolicy
+	checkInterval time.Duration
+
+	status         *GovernanceVerifierStatus
+	lastChecked    time.Time
+	lastError      string
+	skippedProofs  int64
+	warnedProofs   int64
+	lastSkipReason string
+
+	onStatusChange func(health GovernanceVerifierHealth)
+
+	logger  *log.Logger
+	cancel  context.CancelFunc
+	running bool
+}
+
+// NewGovernanceVerifierMonitor creates a monitor; checkInterval defaults to 10 minutes
+func NewGovernanceVerifierMonitor(fetcher GovernanceVerifierStatusFetcher, policy GovernanceVerifierPolicy, checkInterval time.Duration, logger *log.Logger) *GovernanceVerifierMonitor {
+	if policy == "" {
+		policy = GovernanceVerifierPolicyWarn
+	}
+	if checkInterval <= 0 {
+		checkInterval = 10 * time.Minute
+	}
+	if logger == nil {
+		logger = log.New(log.Writer(), "[GovVerifier] ", log.LstdFlags)
+	}
+	return &GovernanceVerifierMonitor{
+		fetcher:       fetcher,
+		policy:        policy,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+}
+
+// SetOnStatusChange sets a callback invoked when the configured/not-configured state changes
+func (m *GovernanceVerifierMonitor) SetOnStatusChange(fn func(health GovernanceVerifierHealth)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onStatusChange = fn
+}
+
+// Check queries the contract once and updates the recorded status
+func (m *GovernanceVerifierMonitor) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	status, err := m.fetcher.GetGovernanceVerifierStatus(ctx)
+
+	m.mu.Lock()
+	before := m.statusLabelLocked()
+	m.lastChecked = time.Now()
+	if err != nil {
+		m.lastError = err.Error()
+	} else {
+		m.lastError = ""
+		m.status = status
+	}
+	after := m.statusLabelLocked()
+	onChange := m.onStatusChange
+	health := m.healthLocked()
+	m.mu.Unlock()
+
+	if err != nil {
+		m.logger.Printf("⚠️ Failed to read governance verifier status: %v", err)
+		return err
+	}
+
+	if before != after {
+		if status.Configured() {
+			m.logger.Printf("✅ Governance verifier configured on-chain (min level: G%d)", status.MinLevel)
+		} else {
+			m.logger.Printf("🚨 NO GOVERNANCE VERIFIER CONFIGURED ON-CHAIN (set=%v, initialized=%v) - policy: %s",
+				status.VerifierSet, status.VerifierInitialized, m.policy)
+		}
+		if onChange != nil {
+			onChange(health)
+		}
+	}
+	return nil
+}
+
+// Start runs an initial check and then re-checks periodically until Stop is called
+func (m *GovernanceVerifierMonitor) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return fmt.Errorf("governance verifier monitor already running")
+	}
+	ctx, m.cancel = context.WithCancel(ctx)
+	m.running = true
+	m.mu.Unlock()
+
+	m.logger.Printf("🏛️ Starting governance verifier monitor (interval: %v, policy: %s)", m.checkInterval, m.policy)
+	m.Check(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Check(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop halts periodic checks
+func (m *GovernanceVerifierMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.running {
+		return
+	}
+	m.cancel()
+	m.running = false
+}
+
+// ApplyPolicy adjusts a contract proof according to the policy when no governance verifier
+// is configured. Returns the reason governance data was skipped, or "" if it was kept.
+func (m *GovernanceVerifierMonitor) ApplyPolicy(proof *ContractCertenProof) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Unknown or configured: submit as usual
+	if m.status == nil || m.status.Configured() || proof == nil {
+		return ""
+	}
+
+	reason := fmt.Sprintf("no governance verifier configured on-chain (set=%v, initialized=%v)",
+		m.status.VerifierSet, m.status.VerifierInitialized)
+
+	if m.policy == GovernanceVerifierPolicySkip {
+		proof.GovernanceProof = emptyContractGovernanceProof()
+		m.skippedProofs++
+		m.lastSkipReason = reason
+		m.logger.Printf("⏭️ Governance proof data skipped: %s", reason)
+		return reason
+	}
+
+	m.warnedProofs++
+	m.logger.Printf("🚨 WARNING: submitting governance proof data the contract cannot verify - %s", reason)
+	return ""
+}
+
+// SkipsGovernance reports whether the policy currently replaces governance proof data,
+// without recording a skip. Used when rebuilding a proof exactly as it was submitted.
+func (m *GovernanceVerifierMonitor) SkipsGovernance() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.policy == GovernanceVerifierPolicySkip && m.status != nil && !m.status.Configured()
+}
+
+// Health returns the monitor state for health reporting
+func (m *GovernanceVerifierMonitor) Health() GovernanceVerifierHealth {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.healthLocked()
+}
+
+func (m *GovernanceVerifierMonitor) healthLocked() GovernanceVerifierHealth {
+	var verifier *GovernanceVerifierStatus
+	if m.status != nil {
+		s := *m.status
+		verifier = &s
+	}
+	return GovernanceVerifierHealth{
+		Status:         m.statusLabelLocked(),
+		Policy:         m.policy,
+		Verifier:       verifier,
+		LastChecked:    m.lastChecked,
+		LastError:      m.lastError,
+		SkippedProofs:  m.skippedProofs,
+		WarnedProofs:   m.warnedProofs,
+		LastSkipReason: m.lastSkipReason,
+	}
+}
+
+func (m *GovernanceVerifierMonitor) statusLabelLocked() string {
+	switch {
+	case m.status == nil:
+		return "unknown"
+	case m.status.Configured():
+		return "configured"
+	default:
+		return "not_configured"
+	}
+}
+
+// emptyContractGovernanceProof returns governance proof data carrying no authorization claims
+func emptyContractGovernanceProof() ContractGovernanceProofData {
+	return ContractGovernanceProofData{
+		KeyPageProofs:      [][32]byte{},
+		AuthorityAddress:   common.Address{},
+		Nonce:              big.NewInt(0),
+		RequiredSignatures: big.NewInt(0),
+		ProvidedSignatures: big.NewInt(0),
+	}
+}
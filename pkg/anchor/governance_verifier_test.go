@@ -0,0 +1,134 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Governance Verifier Monitor
+// Tests policy parsing, status tracking and skip/warn handling of governance proof data
+
+package anchor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// stubVerifierFetcher returns a fixed status or error
+type stubVerifierFetcher struct {
+	status *GovernanceVerifierStatus
+	err    error
+}
+
+func (s *stubVerifierFetcher) GetGovernanceVerifierStatus(ctx context.Context) (*GovernanceVerifierStatus, error) {
+	return s.status, s.err
+}
+
+func newTestContractProof() *ContractCertenProof {
+	return &ContractCertenProof{
+		GovernanceProof: ContractGovernanceProofData{
+			KeyBookURL:         "acc://example.acme/book",
+			AuthorityLevel:     1,
+			Nonce:              big.NewInt(1),
+			RequiredSignatures: big.NewInt(1),
+			ProvidedSignatures: big.NewInt(1),
+			ThresholdMet:       true,
+		},
+	}
+}
+
+func TestParseGovernanceVerifierPolicy(t *testing.T) {
+	tests := map[string]GovernanceVerifierPolicy{
+		"":      GovernanceVerifierPolicyWarn,
+		"warn":  GovernanceVerifierPolicyWarn,
+		" SKIP": GovernanceVerifierPolicySkip,
+	}
+	for input, expected := range tests {
+		got, err := ParseGovernanceVerifierPolicy(input)
+		if err != nil || got != expected {
+			t.Errorf("ParseGovernanceVerifierPolicy(%q) = %q, %v; expected %q", input, got, err, expected)
+		}
+	}
+	if _, err := ParseGovernanceVerifierPolicy("ignore"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestGovernanceVerifierMonitor_SkipPolicy(t *testing.T) {
+	fetcher := &stubVerifierFetcher{status: &GovernanceVerifierStatus{VerifierSet: true}}
+	m := NewGovernanceVerifierMonitor(fetcher, GovernanceVerifierPolicySkip, 0, nil)
+
+	var changes []string
+	m.SetOnStatusChange(func(h GovernanceVerifierHealth) { changes = append(changes, h.Status) })
+
+	if err := m.Check(context.Background()); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if h := m.Health(); h.Status != "not_configured" {
+		t.Fatalf("expected not_configured, got %s", h.Status)
+	}
+
+	proof := newTestContractProof()
+	reason := m.ApplyPolicy(proof)
+	if reason == "" {
+		t.Fatal("expected governance data to be skipped")
+	}
+	if proof.GovernanceProof.KeyBookURL != "" || proof.GovernanceProof.ThresholdMet {
+		t.Errorf("expected empty governance proof, got %+v", proof.GovernanceProof)
+	}
+	if proof.GovernanceProof.Nonce == nil || proof.GovernanceProof.RequiredSignatures == nil {
+		t.Error("empty governance proof must keep non-nil integers for ABI packing")
+	}
+
+	h := m.Health()
+	if h.SkippedProofs != 1 || h.LastSkipReason != reason {
+		t.Errorf("expected 1 skipped proof with recorded reason, got %+v", h)
+	}
+
+	// Verifier gets initialized on-chain - governance data is submitted again
+	fetcher.status = &GovernanceVerifierStatus{VerifierSet: true, VerifierInitialized: true, MinLevel: 1}
+	if err := m.Check(context.Background()); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	proof = newTestContractProof()
+	if reason := m.ApplyPolicy(proof); reason != "" || proof.GovernanceProof.KeyBookURL == "" {
+		t.Errorf("expected governance data to be kept once configured, reason %q", reason)
+	}
+
+	if len(changes) != 2 || changes[0] != "not_configured" || changes[1] != "configured" {
+		t.Errorf("expected status changes [not_configured configured], got %v", changes)
+	}
+}
+
+func TestGovernanceVerifierMonitor_WarnPolicy(t *testing.T) {
+	fetcher := &stubVerifierFetcher{status: &GovernanceVerifierStatus{}}
+	m := NewGovernanceVerifierMonitor(fetcher, GovernanceVerifierPolicyWarn, 0, nil)
+	m.Check(context.Background())
+
+	proof := newTestContractProof()
+	if reason := m.ApplyPolicy(proof); reason != "" {
+		t.Errorf("warn policy should not skip governance data, got reason %q", reason)
+	}
+	if proof.GovernanceProof.KeyBookURL == "" {
+		t.Error("warn policy should leave governance data intact")
+	}
+	if h := m.Health(); h.WarnedProofs != 1 || h.SkippedProofs != 0 {
+		t.Errorf("expected 1 warned proof, got %+v", h)
+	}
+}
+
+func TestGovernanceVerifierMonitor_UnknownStatusKeepsData(t *testing.T) {
+	fetcher := &stubVerifierFetcher{err: errors.New("rpc unavailable")}
+	m := NewGovernanceVerifierMonitor(fetcher, GovernanceVerifierPolicySkip, 0, nil)
+
+	if err := m.Check(context.Background()); err == nil {
+		t.Fatal("expected Check to return the fetch error")
+	}
+	h := m.Health()
+	if h.Status != "unknown" || h.LastError == "" {
+		t.Errorf("expected unknown status with recorded error, got %+v", h)
+	}
+
+	proof := newTestContractProof()
+	if reason := m.ApplyPolicy(proof); reason != "" {
+		t.Errorf("unknown status should not skip governance data, got reason %q", reason)
+	}
+}
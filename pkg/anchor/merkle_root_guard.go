@@ -0,0 +1,52 @@
+// Copyright 2025 Certen Protocol
+//
+// Merkle Root Guard - Refuses to anchor a root that commits to nothing
+//
+// A bug in batch Merkle construction could produce an empty or all-zero root, or
+// the hash of an empty input (what a tree with no leaves collapses to). Anchoring
+// such a root spends gas to record meaningless data on-chain, so the anchor manager
+// rejects it before any transaction is built.
+
+package anchor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrInvalidMerkleRoot is returned when a batch Merkle root commits to no data
+var ErrInvalidMerkleRoot = errors.New("invalid merkle root (batch merkle construction error)")
+
+// emptyLeavesRoots are the roots produced by hashing zero leaves with the hash
+// functions used for batch trees (SHA-256) and on-chain commitments (Keccak-256)
+var emptyLeavesRoots = [][]byte{
+	func() []byte { h := sha256.Sum256(nil); return h[:] }(),
+	crypto.Keccak256(nil),
+}
+
+// CheckMerkleRoot rejects a root that is empty, not 32 bytes, all-zero, or (for a
+// non-empty batch) equal to the known hash of zero leaves
+func CheckMerkleRoot(root []byte, txCount int) error {
+	if len(root) == 0 {
+		return fmt.Errorf("%w: root is empty", ErrInvalidMerkleRoot)
+	}
+	if len(root) != 32 {
+		return fmt.Errorf("%w: root must be 32 bytes, got %d", ErrInvalidMerkleRoot, len(root))
+	}
+	if bytes.Equal(root, make([]byte, 32)) {
+		return fmt.Errorf("%w: root is all zeros", ErrInvalidMerkleRoot)
+	}
+	if txCount > 0 {
+		for _, empty := range emptyLeavesRoots {
+			if bytes.Equal(root, empty) {
+				return fmt.Errorf("%w: root %x is the hash of zero leaves but the batch has %d transactions",
+					ErrInvalidMerkleRoot, root, txCount)
+			}
+		}
+	}
+	return nil
+}
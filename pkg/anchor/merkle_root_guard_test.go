@@ -0,0 +1,70 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Merkle Root Guard
+// Tests rejection of empty, all-zero and zero-leaf roots
+
+package anchor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestCheckMerkleRoot(t *testing.T) {
+	sha256Empty := sha256.Sum256(nil)
+	valid := sha256.Sum256([]byte("leaf"))
+
+	tests := []struct {
+		name    string
+		root    []byte
+		txCount int
+		wantErr bool
+	}{
+		{"valid root", valid[:], 3, false},
+		{"empty root", nil, 3, true},
+		{"short root", valid[:16], 3, true},
+		{"all-zero root", make([]byte, 32), 3, true},
+		{"sha256 of zero leaves", sha256Empty[:], 3, true},
+		{"keccak256 of zero leaves", crypto.Keccak256(nil), 1, true},
+		{"zero-leaf hash for empty batch", sha256Empty[:], 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMerkleRoot(tt.root, tt.txCount)
+			if tt.wantErr && !errors.Is(err, ErrInvalidMerkleRoot) {
+				t.Errorf("expected ErrInvalidMerkleRoot, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateBatchAnchorOnChain_RejectsInvalidMerkleRoot(t *testing.T) {
+	am := &AnchorManager{logger: log.New(io.Discard, "", 0), merkleRootGuard: true}
+	commitment := bytes.Repeat([]byte{1}, 32)
+	req := &AnchorOnChainRequest{
+		BatchID:              "batch-1",
+		MerkleRoot:           make([]byte, 32),
+		OperationCommitment:  commitment,
+		CrossChainCommitment: commitment,
+		GovernanceRoot:       commitment,
+		TxCount:              2,
+	}
+	if _, err := am.CreateBatchAnchorOnChain(context.Background(), req); !errors.Is(err, ErrInvalidMerkleRoot) {
+		t.Errorf("expected ErrInvalidMerkleRoot, got %v", err)
+	}
+
+	req.MerkleRoot = nil
+	if _, err := am.CreateBatchAnchorOnChain(context.Background(), req); !errors.Is(err, ErrInvalidMerkleRoot) {
+		t.Errorf("expected ErrInvalidMerkleRoot for empty root, got %v", err)
+	}
+}
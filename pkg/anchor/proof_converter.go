@@ -46,6 +46,7 @@ type ProofBundle struct {
 	// Merkle inclusion proof
 	MerkleRoot  [32]byte   `json:"merkle_root"`
 	ProofHashes [][32]byte `json:"proof_hashes"`
+	LeafIndex   uint64     `json:"leaf_index"` // Position of LeafHash in the batch tree
 
 	// Commitment data (cryptographically derived from proof data)
 	OperationCommitment  [32]byte `json:"operation_commitment"`
@@ -418,6 +419,17 @@ func (b *ProofBundle) SetMerkleProof(root [32]byte, leafHash [32]byte, path [][3
 	b.ProofHashes = path
 }
 
+// votingThresholdMet reports whether signedPower is at least 2/3 of totalPower
+func votingThresholdMet(signedPower, totalPower *big.Int) bool {
+	if totalPower == nil || totalPower.Sign() <= 0 || signedPower == nil {
+		return false
+	}
+	// 2/3 threshold: signedPower * 3 >= totalPower * 2
+	signedTimes3 := new(big.Int).Mul(signedPower, big.NewInt(3))
+	totalTimes2 := new(big.Int).Mul(totalPower, big.NewInt(2))
+	return signedTimes3.Cmp(totalTimes2) >= 0
+}
+
 // SetBLSProof sets the BLS aggregate signature proof
 func (b *ProofBundle) SetBLSProof(
 	signature []byte,
@@ -427,22 +439,13 @@ func (b *ProofBundle) SetBLSProof(
 	signedPower *big.Int,
 	messageHash [32]byte,
 ) {
-	// Calculate if threshold met (2/3+ required)
-	thresholdMet := false
-	if totalPower != nil && totalPower.Sign() > 0 && signedPower != nil {
-		// 2/3 threshold: signedPower * 3 >= totalPower * 2
-		signedTimes3 := new(big.Int).Mul(signedPower, big.NewInt(3))
-		totalTimes2 := new(big.Int).Mul(totalPower, big.NewInt(2))
-		thresholdMet = signedTimes3.Cmp(totalTimes2) >= 0
-	}
-
 	b.BLSProof = &BLSProofData{
 		AggregateSignature: signature,
 		ValidatorAddresses: validators,
 		VotingPowers:       powers,
 		TotalVotingPower:   totalPower,
 		SignedVotingPower:  signedPower,
-		ThresholdMet:       thresholdMet,
+		ThresholdMet:       votingThresholdMet(signedPower, totalPower),
 		MessageHash:        messageHash,
 	}
 }
@@ -0,0 +1,208 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Field Overrides - Debug-only tampering of submitted CertenProof fields
+//
+// Integration tests against a test or mock deployment of the verifier need proofs with
+// specific field values to drive the contract through its failure branches (for example
+// blsProof.thresholdMet=false). Overrides replace named CertenProof fields after the
+// proof is built and before it is verified or submitted.
+//
+// Overrides produce invalid proofs by design, so they are guarded twice:
+//   - compile time: they only exist in binaries built with -tags certen_proof_overrides;
+//     ParseProofFieldOverrides fails with ErrProofOverridesUnavailable otherwise
+//   - runtime: the anchor manager refuses them on Ethereum mainnet and logs every
+//     overridden submission
+//
+// Spec format: comma-separated field=value pairs using the Solidity field names, e.g.
+//   blsProof.thresholdMet=false,governanceProof.authorityLevel=0,expirationTime=1
+
+package anchor
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrProofOverridesUnavailable is returned when the binary was built without proof override support
+var ErrProofOverridesUnavailable = errors.New("proof field overrides are not compiled in (build with -tags certen_proof_overrides)")
+
+// ethereumMainnetChainID is the chain on which proof overrides are always refused
+const ethereumMainnetChainID = 1
+
+// proofFieldSetter parses a value and writes it into a contract proof
+type proofFieldSetter func(proof *ContractCertenProof, value string) error
+
+// proofFieldSetters lists the overridable fields by Solidity name
+var proofFieldSetters = map[string]proofFieldSetter{
+	"transactionHash": bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.TransactionHash }),
+	"merkleRoot":      bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.MerkleRoot }),
+	"leafHash":        bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.LeafHash }),
+	"expirationTime":  uintSetter(func(p *ContractCertenProof) **big.Int { return &p.ExpirationTime }),
+	"metadata":        bytesSetter(func(p *ContractCertenProof) *[]byte { return &p.Metadata }),
+
+	"blsProof.aggregateSignature": bytesSetter(func(p *ContractCertenProof) *[]byte { return &p.BlsProof.AggregateSignature }),
+	"blsProof.totalVotingPower":   uintSetter(func(p *ContractCertenProof) **big.Int { return &p.BlsProof.TotalVotingPower }),
+	"blsProof.signedVotingPower":  uintSetter(func(p *ContractCertenProof) **big.Int { return &p.BlsProof.SignedVotingPower }),
+	"blsProof.thresholdMet":       boolSetter(func(p *ContractCertenProof) *bool { return &p.BlsProof.ThresholdMet }),
+	"blsProof.messageHash":        bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.BlsProof.MessageHash }),
+
+	"governanceProof.keyBookRoot":        bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.GovernanceProof.KeyBookRoot }),
+	"governanceProof.nonce":              uintSetter(func(p *ContractCertenProof) **big.Int { return &p.GovernanceProof.Nonce }),
+	"governanceProof.requiredSignatures": uintSetter(func(p *ContractCertenProof) **big.Int { return &p.GovernanceProof.RequiredSignatures }),
+	"governanceProof.providedSignatures": uintSetter(func(p *ContractCertenProof) **big.Int { return &p.GovernanceProof.ProvidedSignatures }),
+	"governanceProof.thresholdMet":       boolSetter(func(p *ContractCertenProof) *bool { return &p.GovernanceProof.ThresholdMet }),
+	"governanceProof.authorityLevel": func(p *ContractCertenProof, value string) error {
+		level, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return fmt.Errorf("expected uint8: %w", err)
+		}
+		p.GovernanceProof.AuthorityLevel = uint8(level)
+		return nil
+	},
+
+	"commitments.operationCommitment":  bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.Commitments.OperationCommitment }),
+	"commitments.crossChainCommitment": bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.Commitments.CrossChainCommitment }),
+	"commitments.governanceRoot":       bytes32Setter(func(p *ContractCertenProof) *[32]byte { return &p.Commitments.GovernanceRoot }),
+	"commitments.sourceChain":          stringSetter(func(p *ContractCertenProof) *string { return &p.Commitments.SourceChain }),
+	"commitments.targetChain":          stringSetter(func(p *ContractCertenProof) *string { return &p.Commitments.TargetChain }),
+}
+
+func bytes32Setter(field func(*ContractCertenProof) *[32]byte) proofFieldSetter {
+	return func(p *ContractCertenProof, value string) error {
+		b, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil || len(b) != 32 {
+			return fmt.Errorf("expected 32-byte hex value")
+		}
+		copy(field(p)[:], b)
+		return nil
+	}
+}
+
+func bytesSetter(field func(*ContractCertenProof) *[]byte) proofFieldSetter {
+	return func(p *ContractCertenProof, value string) error {
+		b, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return fmt.Errorf("expected hex value: %w", err)
+		}
+		*field(p) = b
+		return nil
+	}
+}
+
+func uintSetter(field func(*ContractCertenProof) **big.Int) proofFieldSetter {
+	return func(p *ContractCertenProof, value string) error {
+		n, ok := new(big.Int).SetString(value, 10)
+		if !ok || n.Sign() < 0 {
+			return fmt.Errorf("expected unsigned decimal integer")
+		}
+		*field(p) = n
+		return nil
+	}
+}
+
+func boolSetter(field func(*ContractCertenProof) *bool) proofFieldSetter {
+	return func(p *ContractCertenProof, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("expected true or false")
+		}
+		*field(p) = b
+		return nil
+	}
+}
+
+func stringSetter(field func(*ContractCertenProof) *string) proofFieldSetter {
+	return func(p *ContractCertenProof, value string) error {
+		*field(p) = value
+		return nil
+	}
+}
+
+// proofFieldOverride is one parsed field=value pair
+type proofFieldOverride struct {
+	field string
+	value string
+	set   proofFieldSetter
+}
+
+// ProofFieldOverrides replaces CertenProof fields before submission (debug builds only)
+type ProofFieldOverrides struct {
+	overrides []proofFieldOverride
+}
+
+// ParseProofFieldOverrides parses an override spec. It fails with ErrProofOverridesUnavailable
+// unless the binary was built with -tags certen_proof_overrides.
+func ParseProofFieldOverrides(spec string) (*ProofFieldOverrides, error) {
+	if !proofOverridesCompiled {
+		return nil, ErrProofOverridesUnavailable
+	}
+	return parseProofFieldOverrides(spec)
+}
+
+func parseProofFieldOverrides(spec string) (*ProofFieldOverrides, error) {
+	o := &ProofFieldOverrides{}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid proof override %q (expected field=value)", pair)
+		}
+		field, value = strings.TrimSpace(field), strings.TrimSpace(value)
+		set, known := proofFieldSetters[field]
+		if !known {
+			return nil, fmt.Errorf("unknown proof override field %q (supported: %s)", field, strings.Join(ProofOverrideFields(), ", "))
+		}
+		// Reject malformed values up front rather than at submission
+		if err := set(&ContractCertenProof{}, value); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", field, err)
+		}
+		o.overrides = append(o.overrides, proofFieldOverride{field: field, value: value, set: set})
+	}
+	if len(o.overrides) == 0 {
+		return nil, fmt.Errorf("proof override spec is empty")
+	}
+	return o, nil
+}
+
+// ProofOverrideFields returns the names of the overridable fields, sorted
+func ProofOverrideFields() []string {
+	fields := make([]string, 0, len(proofFieldSetters))
+	for field := range proofFieldSetters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Apply writes the overridden values into the proof
+func (o *ProofFieldOverrides) Apply(proof *ContractCertenProof) error {
+	if o == nil || proof == nil {
+		return nil
+	}
+	for _, override := range o.overrides {
+		if err := override.set(proof, override.value); err != nil {
+			return fmt.Errorf("proof override %s: %w", override.field, err)
+		}
+	}
+	return nil
+}
+
+// String returns the overrides in spec form
+func (o *ProofFieldOverrides) String() string {
+	if o == nil {
+		return ""
+	}
+	pairs := make([]string, len(o.overrides))
+	for i, override := range o.overrides {
+		pairs[i] = override.field + "=" + override.value
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,9 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Field Overrides - Compiled in for integration testing builds
+
+//go:build certen_proof_overrides
+
+package anchor
+
+const proofOverridesCompiled = true
@@ -0,0 +1,69 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof Field Overrides
+// Tests spec parsing, field application, the build tag guard and the mainnet refusal
+
+package anchor
+
+import (
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/config"
+)
+
+func TestProofFieldOverrides_Apply(t *testing.T) {
+	root := strings.Repeat("ab", 32)
+	overrides, err := parseProofFieldOverrides("blsProof.thresholdMet=false, governanceProof.authorityLevel=0,expirationTime=1,merkleRoot=0x" + root)
+	if err != nil {
+		t.Fatalf("parseProofFieldOverrides failed: %v", err)
+	}
+
+	proof := newTestContractProof()
+	proof.BlsProof.ThresholdMet = true
+	if err := overrides.Apply(proof); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if proof.BlsProof.ThresholdMet || proof.GovernanceProof.AuthorityLevel != 0 ||
+		proof.ExpirationTime.Cmp(big.NewInt(1)) != 0 || proof.MerkleRoot[0] != 0xab {
+		t.Errorf("overrides not applied: %+v", proof)
+	}
+}
+
+func TestProofFieldOverrides_ParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"blsProof.thresholdMet",
+		"blsProof.unknown=1",
+		"blsProof.thresholdMet=maybe",
+		"merkleRoot=0x1234",
+		"governanceProof.authorityLevel=256",
+		"blsProof.signedVotingPower=-1",
+	} {
+		if _, err := parseProofFieldOverrides(spec); err == nil {
+			t.Errorf("spec %q: expected error", spec)
+		}
+	}
+}
+
+func TestProofFieldOverrides_Guards(t *testing.T) {
+	if !proofOverridesCompiled {
+		if _, err := ParseProofFieldOverrides("blsProof.thresholdMet=false"); !errors.Is(err, ErrProofOverridesUnavailable) {
+			t.Errorf("expected ErrProofOverridesUnavailable, got %v", err)
+		}
+	}
+
+	overrides, _ := parseProofFieldOverrides("blsProof.thresholdMet=false")
+	am := &AnchorManager{config: &config.Config{EthChainID: 1}, logger: log.New(io.Discard, "", 0)}
+	if err := am.SetProofFieldOverrides(overrides); err == nil || am.proofOverrides != nil {
+		t.Error("overrides must be refused on mainnet")
+	}
+	am.config.EthChainID = 11155111
+	if err := am.SetProofFieldOverrides(overrides); err != nil || am.proofOverrides == nil {
+		t.Errorf("overrides should be accepted on a test network: %v", err)
+	}
+}
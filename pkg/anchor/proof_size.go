@@ -0,0 +1,89 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Size Limits - Bounds the calldata submitted to executeComprehensiveProof
+//
+// The gas cost of executeComprehensiveProof is dominated by its ABI-encoded calldata
+// (16 gas per non-zero byte) plus the per-hash verification work on-chain, and both grow
+// with the Merkle path and the governance key page proofs. The proof is verified by the
+// contract as a single tuple and cannot be split across transactions, so an oversized
+// proof is rejected before submission instead of producing an unbounded gas bill or a
+// transaction that exceeds the block gas limit.
+
+package anchor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ErrProofTooLarge is returned when a proof exceeds the configured size limits
+var ErrProofTooLarge = errors.New("proof exceeds size limit")
+
+// ProofSizeLimits bounds the size of a comprehensive proof submission (0 = unlimited)
+type ProofSizeLimits struct {
+	MaxProofHashes   int // Maximum Merkle path length (proofHashes)
+	MaxCalldataBytes int // Maximum ABI-encoded calldata for executeComprehensiveProof
+}
+
+// DefaultProofSizeLimits returns default limits
+func DefaultProofSizeLimits() ProofSizeLimits {
+	return ProofSizeLimits{
+		MaxProofHashes:   64,        // Paths for batches of up to 2^64 leaves
+		MaxCalldataBytes: 64 * 1024, // ~1M gas of calldata, well under the 128KB tx pool limit
+	}
+}
+
+var (
+	parsedAnchorABI    abi.ABI
+	parsedAnchorABIErr error
+	parseAnchorABIOnce sync.Once
+)
+
+func anchorContractABI() (abi.ABI, error) {
+	parseAnchorABIOnce.Do(func() {
+		parsedAnchorABI, parsedAnchorABIErr = abi.JSON(strings.NewReader(certenAnchorABI))
+	})
+	return parsedAnchorABI, parsedAnchorABIErr
+}
+
+// ComprehensiveProofCalldataSize returns the size in bytes of the ABI-encoded
+// executeComprehensiveProof call, including the 4-byte method selector
+func ComprehensiveProofCalldataSize(anchorID [32]byte, proof *ContractCertenProof) (int, error) {
+	if proof == nil {
+		return 0, fmt.Errorf("proof cannot be nil")
+	}
+	parsed, err := anchorContractABI()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse anchor contract ABI: %w", err)
+	}
+	calldata, err := parsed.Pack("executeComprehensiveProof", anchorID, proof)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode executeComprehensiveProof calldata: %w", err)
+	}
+	return len(calldata), nil
+}
+
+// Check encodes the proof and returns its calldata size, or an error wrapping
+// ErrProofTooLarge if the proof exceeds either limit
+func (l ProofSizeLimits) Check(anchorID [32]byte, proof *ContractCertenProof) (int, error) {
+	if proof == nil {
+		return 0, fmt.Errorf("proof cannot be nil")
+	}
+	if l.MaxProofHashes > 0 && len(proof.ProofHashes) > l.MaxProofHashes {
+		return 0, fmt.Errorf("%w: %d proof hashes (max %d)", ErrProofTooLarge, len(proof.ProofHashes), l.MaxProofHashes)
+	}
+
+	size, err := ComprehensiveProofCalldataSize(anchorID, proof)
+	if err != nil {
+		return 0, err
+	}
+	if l.MaxCalldataBytes > 0 && size > l.MaxCalldataBytes {
+		return size, fmt.Errorf("%w: %d bytes of calldata (max %d, %d proof hashes, %d key page proofs)",
+			ErrProofTooLarge, size, l.MaxCalldataBytes, len(proof.ProofHashes), len(proof.GovernanceProof.KeyPageProofs))
+	}
+	return size, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof Size Limits
+// Tests calldata size computation and rejection of oversized proofs
+
+package anchor
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func newSizedContractProof(proofHashes, keyPageProofs int) *ContractCertenProof {
+	proof := newTestContractProof()
+	proof.ProofHashes = make([][32]byte, proofHashes)
+	proof.GovernanceProof.KeyPageProofs = make([][32]byte, keyPageProofs)
+	proof.BlsProof.TotalVotingPower = big.NewInt(100)
+	proof.BlsProof.SignedVotingPower = big.NewInt(67)
+	proof.Commitments.SourceBlockHeight = big.NewInt(1000)
+	proof.ExpirationTime = big.NewInt(0)
+	return proof
+}
+
+func TestComprehensiveProofCalldataSize(t *testing.T) {
+	var anchorID [32]byte
+
+	small, err := ComprehensiveProofCalldataSize(anchorID, newSizedContractProof(4, 0))
+	if err != nil {
+		t.Fatalf("ComprehensiveProofCalldataSize failed: %v", err)
+	}
+	large, err := ComprehensiveProofCalldataSize(anchorID, newSizedContractProof(14, 0))
+	if err != nil {
+		t.Fatalf("ComprehensiveProofCalldataSize failed: %v", err)
+	}
+	// Each additional proof hash adds one 32-byte word
+	if large-small != 10*32 {
+		t.Errorf("expected 320 more bytes for 10 more proof hashes, got %d (%d -> %d)", large-small, small, large)
+	}
+	if (small-4)%32 != 0 {
+		t.Errorf("expected selector plus whole words, got %d bytes", small)
+	}
+}
+
+func TestProofSizeLimits_Check(t *testing.T) {
+	var anchorID [32]byte
+	proof := newSizedContractProof(8, 4)
+
+	size, err := ProofSizeLimits{}.Check(anchorID, proof)
+	if err != nil || size == 0 {
+		t.Fatalf("expected unlimited check to pass with a size, got %d, %v", size, err)
+	}
+
+	if _, err := (ProofSizeLimits{MaxProofHashes: 7}).Check(anchorID, proof); !errors.Is(err, ErrProofTooLarge) {
+		t.Errorf("expected ErrProofTooLarge for too many proof hashes, got %v", err)
+	}
+
+	got, err := ProofSizeLimits{MaxCalldataBytes: size - 1}.Check(anchorID, proof)
+	if !errors.Is(err, ErrProofTooLarge) || got != size {
+		t.Errorf("expected ErrProofTooLarge with size %d for oversized calldata, got %d, %v", size, got, err)
+	}
+
+	if _, err := (ProofSizeLimits{MaxProofHashes: 8, MaxCalldataBytes: size}).Check(anchorID, proof); err != nil {
+		t.Errorf("expected proof at the limits to pass, got %v", err)
+	}
+}
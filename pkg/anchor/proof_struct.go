@@ -0,0 +1,126 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Struct Validation - Consistency checks on an assembled proof before submission
+//
+// executeComprehensiveProof reverts when the parts of a proof disagree with each other,
+// after the transaction has paid for the verification work. ValidateProofStruct
+// recomputes what can be derived from the proof itself and rejects it before submission:
+//   - the batch Merkle root, from the leaf hash, the proof path and the leaf index
+//   - the 2/3 voting power threshold, from the signed and total voting power
+//   - one voting power per BLS validator address, summing to the signed voting power
+//   - the BLS message hash, which is the batch Merkle root the validators signed
+//
+// Batch trees hash parents as SHA256(left || right) and pair an odd node with itself
+// (see pkg/merkle), so the side of each sibling is given by the bits of the leaf index.
+
+package anchor
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Errors returned by ValidateProofStruct, one per failed consistency check
+var (
+	ErrProofMerkleMismatch  = errors.New("proof path does not lead to the merkle root")
+	ErrProofVotingThreshold = errors.New("voting power threshold not met")
+	ErrProofLengthMismatch  = errors.New("proof array lengths do not match")
+	ErrProofMessageHash     = errors.New("bls message hash does not match the merkle root")
+)
+
+// ValidateProofStruct checks that the parts of an assembled proof bundle are consistent
+// with each other. The returned error wraps one of the ErrProof* errors and names the
+// values that disagree.
+func ValidateProofStruct(b *ProofBundle) error {
+	if b == nil {
+		return errors.New("proof bundle is nil")
+	}
+
+	root, err := InclusionRoot(b.LeafHash, b.ProofHashes, b.LeafIndex)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrProofMerkleMismatch, err)
+	}
+	if root != b.MerkleRoot {
+		return fmt.Errorf("%w: leaf %x at index %d with %d proof hashes gives root %x, proof has %x",
+			ErrProofMerkleMismatch, b.LeafHash[:8], b.LeafIndex, len(b.ProofHashes), root[:8], b.MerkleRoot[:8])
+	}
+
+	bls := b.BLSProof
+	if bls == nil {
+		return errors.New("bls_proof is required")
+	}
+	if len(bls.VotingPowers) != len(bls.ValidatorAddresses) {
+		return fmt.Errorf("%w: %d validator addresses but %d voting powers",
+			ErrProofLengthMismatch, len(bls.ValidatorAddresses), len(bls.VotingPowers))
+	}
+	if len(bls.VotingPowers) > 0 {
+		sum := new(big.Int)
+		for i, power := range bls.VotingPowers {
+			if power == nil || power.Sign() < 0 {
+				return fmt.Errorf("%w: voting power %d of validator %s is missing or negative",
+					ErrProofLengthMismatch, i, bls.ValidatorAddresses[i].Hex())
+			}
+			sum.Add(sum, power)
+		}
+		if bls.SignedVotingPower == nil || sum.Cmp(bls.SignedVotingPower) != 0 {
+			return fmt.Errorf("%w: voting powers sum to %s, signed voting power is %s",
+				ErrProofVotingThreshold, sum, bigString(bls.SignedVotingPower))
+		}
+	}
+
+	if bls.TotalVotingPower == nil || bls.TotalVotingPower.Sign() <= 0 {
+		return fmt.Errorf("%w: total voting power is %s", ErrProofVotingThreshold, bigString(bls.TotalVotingPower))
+	}
+	if bls.SignedVotingPower == nil || bls.SignedVotingPower.Cmp(bls.TotalVotingPower) > 0 {
+		return fmt.Errorf("%w: signed voting power %s exceeds total %s",
+			ErrProofVotingThreshold, bigString(bls.SignedVotingPower), bls.TotalVotingPower)
+	}
+	if !votingThresholdMet(bls.SignedVotingPower, bls.TotalVotingPower) {
+		return fmt.Errorf("%w: signed voting power %s is below 2/3 of %s",
+			ErrProofVotingThreshold, bls.SignedVotingPower, bls.TotalVotingPower)
+	}
+	if !bls.ThresholdMet {
+		return fmt.Errorf("%w: threshold_met is false but %s of %s voting power signed",
+			ErrProofVotingThreshold, bls.SignedVotingPower, bls.TotalVotingPower)
+	}
+
+	if bls.MessageHash != b.MerkleRoot {
+		return fmt.Errorf("%w: message hash %x, merkle root %x", ErrProofMessageHash, bls.MessageHash[:8], b.MerkleRoot[:8])
+	}
+
+	return nil
+}
+
+// InclusionRoot recomputes the batch Merkle root from a leaf, its proof path and its
+// index in the tree. A leaf with an empty path is its own root.
+func InclusionRoot(leaf [32]byte, path [][32]byte, leafIndex uint64) ([32]byte, error) {
+	if len(path) < 64 && leafIndex>>uint(len(path)) != 0 {
+		return [32]byte{}, fmt.Errorf("leaf index %d is outside a tree of depth %d", leafIndex, len(path))
+	}
+
+	node := leaf
+	index := leafIndex
+	var pair [64]byte
+	for _, sibling := range path {
+		if index&1 == 1 {
+			copy(pair[:32], sibling[:])
+			copy(pair[32:], node[:])
+		} else {
+			copy(pair[:32], node[:])
+			copy(pair[32:], sibling[:])
+		}
+		node = sha256.Sum256(pair[:])
+		index >>= 1
+	}
+	return node, nil
+}
+
+// bigString formats a possibly nil big.Int
+func bigString(v *big.Int) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return v.String()
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof Struct Validation
+// Tests Merkle root recomputation against pkg/merkle trees and each rejected inconsistency
+
+package anchor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/merkle"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// newConsistentBundle returns a bundle proving leaf index of a five-leaf batch tree
+func newConsistentBundle(t *testing.T, index int) *ProofBundle {
+	t.Helper()
+	leaves := make([][]byte, 5)
+	for i := range leaves {
+		h := sha256.Sum256([]byte(fmt.Sprintf("tx-%d", i)))
+		leaves[i] = h[:]
+	}
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		t.Fatalf("BuildTree failed: %v", err)
+	}
+	inclusion, err := tree.GenerateProof(index)
+	if err != nil {
+		t.Fatalf("GenerateProof failed: %v", err)
+	}
+
+	var root, leaf [32]byte
+	copy(root[:], tree.Root())
+	copy(leaf[:], leaves[index])
+	path := make([][32]byte, len(inclusion.Path))
+	for i, node := range inclusion.Path {
+		b, _ := hex.DecodeString(node.Hash)
+		copy(path[i][:], b)
+	}
+
+	b := NewProofBundle("batch-1", "validator-1")
+	b.SetMerkleProof(root, leaf, path)
+	b.LeafIndex = uint64(index)
+	b.SetBLSProof(
+		[]byte{1},
+		[]common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")},
+		[]*big.Int{big.NewInt(40), big.NewInt(30)},
+		big.NewInt(100),
+		big.NewInt(70),
+		root,
+	)
+	return b
+}
+
+func TestInclusionRoot_MatchesBatchTree(t *testing.T) {
+	for index := 0; index < 5; index++ {
+		if err := ValidateProofStruct(newConsistentBundle(t, index)); err != nil {
+			t.Errorf("leaf %d: expected a consistent proof, got %v", index, err)
+		}
+	}
+
+	var leaf [32]byte
+	leaf[0] = 1
+	if root, err := InclusionRoot(leaf, nil, 0); err != nil || root != leaf {
+		t.Errorf("expected a single leaf to be its own root, got %x (%v)", root, err)
+	}
+	if _, err := InclusionRoot(leaf, make([][32]byte, 2), 4); err == nil {
+		t.Error("expected an index outside the tree to be rejected")
+	}
+}
+
+func TestValidateProofStruct_Rejections(t *testing.T) {
+	cases := []struct {
+		name   string
+		tamper func(b *ProofBundle)
+		want   error
+	}{
+		{"tampered leaf", func(b *ProofBundle) { b.LeafHash[0] ^= 1 }, ErrProofMerkleMismatch},
+		{"tampered path", func(b *ProofBundle) { b.ProofHashes[1][0] ^= 1 }, ErrProofMerkleMismatch},
+		{"wrong leaf index", func(b *ProofBundle) { b.LeafIndex = 2 }, ErrProofMerkleMismatch},
+		{"truncated path", func(b *ProofBundle) { b.ProofHashes = b.ProofHashes[:2] }, ErrProofMerkleMismatch},
+		{"missing voting power", func(b *ProofBundle) {
+			b.BLSProof.VotingPowers = b.BLSProof.VotingPowers[:1]
+		}, ErrProofLengthMismatch},
+		{"voting powers do not sum to signed power", func(b *ProofBundle) {
+			b.BLSProof.VotingPowers[1] = big.NewInt(20)
+		}, ErrProofVotingThreshold},
+		{"below threshold", func(b *ProofBundle) {
+			b.BLSProof.VotingPowers[1] = big.NewInt(20)
+			b.BLSProof.SignedVotingPower = big.NewInt(60)
+		}, ErrProofVotingThreshold},
+		{"signed exceeds total", func(b *ProofBundle) { b.BLSProof.TotalVotingPower = big.NewInt(50) }, ErrProofVotingThreshold},
+		{"threshold flag not set", func(b *ProofBundle) { b.BLSProof.ThresholdMet = false }, ErrProofVotingThreshold},
+		{"message hash mismatch", func(b *ProofBundle) { b.BLSProof.MessageHash[0] ^= 1 }, ErrProofMessageHash},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newConsistentBundle(t, 3)
+			tc.tamper(b)
+			if err := ValidateProofStruct(b); !errors.Is(err, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestOnChainRequestBundle_PassesStructValidation(t *testing.T) {
+	b := newConsistentBundle(t, 4)
+	_, bundle, err := onChainRequestToProofBundle(&ExecuteComprehensiveProofOnChainRequest{
+		AnchorID:    "batch-1",
+		MerkleRoot:  b.MerkleRoot,
+		LeafHash:    b.LeafHash,
+		LeafIndex:   b.LeafIndex,
+		ProofHashes: b.ProofHashes,
+	})
+	if err != nil {
+		t.Fatalf("onChainRequestToProofBundle failed: %v", err)
+	}
+	if err := ValidateProofStruct(bundle); err != nil {
+		t.Errorf("expected the bundle built for the on-chain request to be consistent, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Certen Protocol
+//
+// Replay Guard - Detecting proofs the anchor contract has already consumed
+//
+// executeComprehensiveProof records each proof's commitment hash and its governance
+// (authority, nonce) pair, and reverts when either is submitted again. Reading both with
+// isCommitmentUsed and isNonceUsed before submission avoids paying gas for that revert
+// and reports the proof as already anchored instead. The contract still enforces the
+// check, so a missed detection only costs the reverted transaction.
+
+package anchor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ProofCommitmentHash returns the hash under which the contract records a proof's
+// commitments: keccak256(operationCommitment || crossChainCommitment || governanceRoot)
+func ProofCommitmentHash(proof *ContractCertenProof) [32]byte {
+	c := proof.Commitments
+	return crypto.Keccak256Hash(c.OperationCommitment[:], c.CrossChainCommitment[:], c.GovernanceRoot[:])
+}
+
+// IsCommitmentUsed reports whether the contract has already consumed a commitment hash
+func (ec *EthereumChain) IsCommitmentUsed(ctx context.Context, commitmentHash [32]byte) (bool, error) {
+	return ec.callBool(ctx, "isCommitmentUsed", commitmentHash)
+}
+
+// IsNonceUsed reports whether the contract has already consumed an authority's governance nonce
+func (ec *EthereumChain) IsNonceUsed(ctx context.Context, authority common.Address, nonce *big.Int) (bool, error) {
+	return ec.callBool(ctx, "isNonceUsed", authority, nonce)
+}
+
+// ConsumedProofReason returns why the contract would reject proof as a replay, or ""
+// when neither its commitment hash nor its governance nonce has been used. Proofs
+// without a governance authority (governance data omitted) have no nonce to check.
+func (ec *EthereumChain) ConsumedProofReason(ctx context.Context, proof *ContractCertenProof) (string, error) {
+	if proof == nil {
+		return "", fmt.Errorf("proof cannot be nil")
+	}
+
+	commitmentHash := ProofCommitmentHash(proof)
+	used, err := ec.IsCommitmentUsed(ctx, commitmentHash)
+	if err != nil {
+		return "", err
+	}
+	if used {
+		return fmt.Sprintf("commitment %x already used", commitmentHash), nil
+	}
+
+	gov := proof.GovernanceProof
+	if gov.AuthorityAddress == (common.Address{}) || gov.Nonce == nil {
+		return "", nil
+	}
+	used, err = ec.IsNonceUsed(ctx, gov.AuthorityAddress, gov.Nonce)
+	if err != nil {
+		return "", err
+	}
+	if used {
+		return fmt.Sprintf("nonce %s of authority %s already used", gov.Nonce, gov.AuthorityAddress.Hex()), nil
+	}
+	return "", nil
+}
+
+// callBool calls a view method of the anchor contract that returns a single bool
+func (ec *EthereumChain) callBool(ctx context.Context, method string, args ...interface{}) (bool, error) {
+	contractAddr := common.HexToAddress(ec.config.ContractAddress)
+
+	result, err := ec.ethereumClient.CallContract(ctx, contractAddr, certenAnchorABI, method, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	if len(result) < 1 {
+		return false, fmt.Errorf("unexpected result length from %s: %d", method, len(result))
+	}
+	value, ok := result[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected %s result type: %T", method, result[0])
+	}
+	return value, nil
+}
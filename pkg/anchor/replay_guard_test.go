@@ -0,0 +1,125 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Replay Guard
+// Tests detection of proofs whose commitment or governance nonce the contract has consumed
+
+package anchor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// usedStateRPC answers isCommitmentUsed and isNonceUsed eth_calls with fixed results
+type usedStateRPC struct {
+	commitmentUsed bool
+	nonceUsed      bool
+	nonceCalls     int
+}
+
+func (r *usedStateRPC) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var call struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&call); err != nil || call.Method != "eth_call" || len(call.Params) == 0 {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	var msg struct {
+		Data  string `json:"data"`
+		Input string `json:"input"`
+	}
+	_ = json.Unmarshal(call.Params[0], &msg)
+	data := msg.Input
+	if data == "" {
+		data = msg.Data
+	}
+
+	used := false
+	switch {
+	case strings.HasPrefix(data, "0x"+hex.EncodeToString(crypto.Keccak256([]byte("isCommitmentUsed(bytes32)"))[:4])):
+		used = r.commitmentUsed
+	case strings.HasPrefix(data, "0x"+hex.EncodeToString(crypto.Keccak256([]byte("isNonceUsed(address,uint256)"))[:4])):
+		r.nonceCalls++
+		used = r.nonceUsed
+	}
+	word := make([]byte, 32)
+	if used {
+		word[31] = 1
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(call.ID) + `,"result":"0x` + hex.EncodeToString(word) + `"}`))
+}
+
+func newReplayTestChain(t *testing.T, rpc *usedStateRPC) *EthereumChain {
+	t.Helper()
+	server := httptest.NewServer(rpc)
+	t.Cleanup(server.Close)
+
+	client, err := ethereum.NewClient(server.URL, 11155111)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return &EthereumChain{
+		config:         &EthereumConfig{ContractAddress: "0x0000000000000000000000000000000000000001"},
+		ethereumClient: client,
+	}
+}
+
+func replayTestProof(authority common.Address) *ContractCertenProof {
+	return &ContractCertenProof{
+		GovernanceProof: ContractGovernanceProofData{AuthorityAddress: authority, Nonce: big.NewInt(7)},
+		Commitments:     ContractCommitmentData{OperationCommitment: [32]byte{1}, CrossChainCommitment: [32]byte{2}, GovernanceRoot: [32]byte{3}},
+	}
+}
+
+func TestConsumedProofReason(t *testing.T) {
+	ctx := context.Background()
+	authority := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	t.Run("unused proof", func(t *testing.T) {
+		chain := newReplayTestChain(t, &usedStateRPC{})
+		reason, err := chain.ConsumedProofReason(ctx, replayTestProof(authority))
+		if err != nil || reason != "" {
+			t.Errorf("expected no replay, got %q, %v", reason, err)
+		}
+	})
+
+	t.Run("commitment used", func(t *testing.T) {
+		rpc := &usedStateRPC{commitmentUsed: true, nonceUsed: true}
+		chain := newReplayTestChain(t, rpc)
+		reason, err := chain.ConsumedProofReason(ctx, replayTestProof(authority))
+		if err != nil || !strings.Contains(reason, "commitment") || rpc.nonceCalls != 0 {
+			t.Errorf("expected a commitment replay without a nonce check, got %q, %v", reason, err)
+		}
+	})
+
+	t.Run("nonce used", func(t *testing.T) {
+		chain := newReplayTestChain(t, &usedStateRPC{nonceUsed: true})
+		reason, err := chain.ConsumedProofReason(ctx, replayTestProof(authority))
+		if err != nil || !strings.Contains(reason, "nonce 7") {
+			t.Errorf("expected a nonce replay, got %q, %v", reason, err)
+		}
+	})
+
+	t.Run("no governance authority", func(t *testing.T) {
+		rpc := &usedStateRPC{nonceUsed: true}
+		chain := newReplayTestChain(t, rpc)
+		reason, err := chain.ConsumedProofReason(ctx, replayTestProof(common.Address{}))
+		if err != nil || reason != "" || rpc.nonceCalls != 0 {
+			t.Errorf("expected the nonce check to be skipped, got %q, %v", reason, err)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Certen Protocol
+//
+// Shadow Mode - Exercising the anchor path without sending transactions
+//
+// In shadow mode the Ethereum chain builds, encodes and prices every contract
+// transaction it would send (createAnchor and executeComprehensiveProof) and logs the
+// payload, but never signs or broadcasts it. Results carry Shadow = true and a
+// placeholder transaction hash derived from the calldata, so they can be recorded
+// downstream and told apart from real anchors.
+//
+// createAnchor is estimated with eth_estimateGas and a failed estimate fails the
+// anchor, since the real transaction would revert. executeComprehensiveProof is
+// estimated too, but the estimate usually reverts because the shadow anchor it
+// proves against was never created; that failure is logged and the proof is still
+// reported with the configured gas limit.
+
+package anchor
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SetShadowMode enables or disables shadow mode on the configured Ethereum chains
+func (am *AnchorManager) SetShadowMode(enabled bool) {
+	am.shadow = enabled
+	for _, chain := range am.chains {
+		if ec, ok := chain.(*EthereumChain); ok {
+			ec.shadow = enabled
+		}
+	}
+	if enabled {
+		am.logger.Printf("🕶️ [SHADOW] Anchor transactions will be estimated and logged, not sent")
+	}
+}
+
+// ShadowMode reports whether anchor transactions are simulated instead of sent
+func (am *AnchorManager) ShadowMode() bool {
+	return am.shadow
+}
+
+// ShadowTxHash returns the placeholder transaction hash recorded for a simulated
+// transaction: keccak256 of the calldata and the simulation time, as a 0x-prefixed
+// 32-byte hex string like a real transaction hash
+func ShadowTxHash(calldata []byte, at time.Time) string {
+	var nonce [8]byte
+	binary.BigEndian.PutUint64(nonce[:], uint64(at.UnixNano()))
+	return crypto.Keccak256Hash([]byte("certen-shadow"), calldata, nonce[:]).Hex()
+}
+
+// simulateContractTransaction encodes and prices a contract call without sending it.
+// gasLimit is reported when the estimate fails and requireEstimate is false.
+func (ec *EthereumChain) simulateContractTransaction(
+	ctx context.Context,
+	anchorID string,
+	method string,
+	gasLimit uint64,
+	requireEstimate bool,
+	params ...interface{},
+) (*AnchorResult, error) {
+	parsed, err := anchorContractABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse anchor contract ABI: %w", err)
+	}
+	calldata, err := parsed.Pack(method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s calldata: %w", method, err)
+	}
+
+	now := time.Now()
+	result := &AnchorResult{
+		AnchorID:        anchorID,
+		TransactionHash: ShadowTxHash(calldata, now),
+		GasUsed:         gasLimit,
+		Success:         true,
+		Timestamp:       now,
+		ChainName:       "ethereum",
+		FeeStrategy:     "shadow",
+		Shadow:          true,
+	}
+
+	estimate, err := ec.ethereumClient.EstimateContractTransaction(
+		ctx,
+		common.HexToAddress(ec.config.ContractAddress),
+		certenAnchorABI,
+		ec.config.PrivateKey,
+		method,
+		params...,
+	)
+	if err != nil {
+		if requireEstimate {
+			return nil, fmt.Errorf("shadow %s would fail: gas estimate: %w", method, err)
+		}
+		log.Printf("🕶️ [SHADOW] %s gas estimate failed (using gas limit %d): %v", method, gasLimit, err)
+	} else {
+		result.GasUsed = estimate.GasLimit
+		result.GasPrice = estimate.GasPrice
+		result.GasCost = estimate.TotalCost
+	}
+
+	cost := "unknown"
+	if result.GasCost != nil {
+		cost = result.GasCost.String() + " wei"
+	}
+	log.Printf("🕶️ [SHADOW] Would send %s to %s: %d bytes of calldata, gas %d, cost %s",
+		method, ec.config.ContractAddress, len(calldata), result.GasUsed, cost)
+	log.Printf("🕶️ [SHADOW] Calldata: 0x%x", calldata)
+	log.Printf("🕶️ [SHADOW] Recorded as %s (not sent)", result.TransactionHash)
+	return result, nil
+}
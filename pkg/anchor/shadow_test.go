@@ -0,0 +1,148 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Shadow Mode
+// Tests that shadow anchors and proofs are estimated and recorded but never sent
+
+package anchor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/ethereum"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// revertingRPC answers every JSON-RPC call with an execution revert and records the methods called
+type revertingRPC struct {
+	mu      sync.Mutex
+	methods []string
+}
+
+func (r *revertingRPC) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var call struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&call); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.methods = append(r.methods, call.Method)
+	r.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(call.ID) + `,"error":{"code":3,"message":"execution reverted"}}`))
+}
+
+func (r *revertingRPC) called(method string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func newShadowTestChain(t *testing.T) (*EthereumChain, *revertingRPC) {
+	t.Helper()
+	rpc := &revertingRPC{}
+	server := httptest.NewServer(rpc)
+	t.Cleanup(server.Close)
+
+	client, err := ethereum.NewClient(server.URL, 11155111)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	chain, err := NewEthereumChain(&EthereumConfig{
+		ChainID:         11155111,
+		PrivateKey:      hex.EncodeToString(crypto.FromECDSA(key)),
+		ContractAddress: "0x0000000000000000000000000000000000000001",
+		GasLimit:        300000,
+	}, client)
+	if err != nil {
+		t.Fatalf("NewEthereumChain failed: %v", err)
+	}
+	return chain, rpc
+}
+
+func TestShadowTxHash(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	hash := ShadowTxHash([]byte{1, 2, 3}, at)
+	if len(hash) != 66 || !strings.HasPrefix(hash, "0x") {
+		t.Fatalf("expected a 0x-prefixed 32-byte hash, got %q", hash)
+	}
+	if ShadowTxHash([]byte{1, 2, 3}, at) != hash {
+		t.Error("expected the same hash for the same calldata and time")
+	}
+	if ShadowTxHash([]byte{1, 2, 3}, at.Add(time.Nanosecond)) == hash || ShadowTxHash([]byte{1, 2, 4}, at) == hash {
+		t.Error("expected a different hash for different calldata or time")
+	}
+}
+
+func TestSetShadowMode(t *testing.T) {
+	chain := &EthereumChain{}
+	am := &AnchorManager{chains: map[string]Chain{"ethereum": chain}, logger: log.New(io.Discard, "", 0)}
+
+	am.SetShadowMode(true)
+	if !am.ShadowMode() || !chain.shadow {
+		t.Fatal("expected shadow mode on the manager and its Ethereum chain")
+	}
+	am.SetShadowMode(false)
+	if am.ShadowMode() || chain.shadow {
+		t.Fatal("expected shadow mode to be disabled")
+	}
+}
+
+func TestShadowMode_NeverSends(t *testing.T) {
+	chain, rpc := newShadowTestChain(t)
+	chain.shadow = true
+	ctx := context.Background()
+
+	t.Run("createAnchor fails when its estimate reverts", func(t *testing.T) {
+		_, err := chain.CreateAnchor(ctx, &AnchorData{
+			AnchorID:              "batch-1",
+			AccumulateBlockHeight: 100,
+			OperationCommitment:   make([]byte, 32),
+			CrossChainCommitment:  make([]byte, 32),
+			GovernanceRoot:        make([]byte, 32),
+		})
+		if err == nil || !strings.Contains(err.Error(), "would fail") {
+			t.Fatalf("expected the reverted estimate to fail the shadow anchor, got %v", err)
+		}
+	})
+
+	t.Run("proof is recorded with the configured gas limit", func(t *testing.T) {
+		var anchorID [32]byte
+		result, err := chain.ExecuteComprehensiveProof(ctx, anchorID, newSizedContractProof(4, 0))
+		if err != nil {
+			t.Fatalf("ExecuteComprehensiveProof failed: %v", err)
+		}
+		if !result.Shadow || !result.Success || result.GasUsed != 300000*5 || len(result.TransactionHash) != 66 {
+			t.Errorf("unexpected shadow proof result: %+v", result)
+		}
+	})
+
+	if !rpc.called("eth_estimateGas") {
+		t.Error("expected gas to be estimated")
+	}
+	if rpc.called("eth_sendRawTransaction") || rpc.called("eth_sendTransaction") {
+		t.Error("shadow mode sent a transaction")
+	}
+}
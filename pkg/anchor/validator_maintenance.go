@@ -0,0 +1,387 @@
+// Copyright 2025 Certen Protocol
+//
+// Validator Maintenance - Deregistration from CertenAnchorV3 for planned maintenance
+//
+// A validator taken offline for an extended period still holds voting power on-chain
+// that it cannot exercise, which raises the effective BLS threshold for everyone else.
+// Maintenance mode removes the validator from the contract's validator set before it
+// shuts down and registers it again, with the same voting power and BLS key, on the
+// next start.
+//
+// Removing a validator is a privileged, owner-only contract call, so it is guarded:
+//   - the feature is opt-in and triggered explicitly by an administrator
+//   - the validator must be registered and the signing key must own the contract
+//   - the validator set must keep at least MinRemainingValidators validators
+//   - the registration is persisted before removeValidator is submitted, so a crash
+//     at any point still leads to re-registration on restart
+
+package anchor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/certen/independant-validator/pkg/ethereum"
+)
+
+// ErrMaintenanceNotPermitted is returned when a maintenance guard refuses deregistration
+var ErrMaintenanceNotPermitted = errors.New("validator maintenance not permitted")
+
+// validatorRegistryABI covers the CertenAnchorV3 validator set functions
+const validatorRegistryABI = `[
+	{"inputs": [{"name": "", "type": "address"}], "name": "validators", "outputs": [{"name": "registered", "type": "bool"}, {"name": "votingPower", "type": "uint256"}, {"name": "blsPublicKey", "type": "bytes"}, {"name": "registeredAt", "type": "uint256"}], "stateMutability": "view", "type": "function"},
+	{"inputs": [], "name": "getValidatorCount", "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view", "type": "function"},
+	{"inputs": [{"name": "", "type": "uint256"}], "name": "validatorList", "outputs": [{"name": "", "type": "address"}], "stateMutability": "view", "type": "function"},
+	{"inputs": [], "name": "totalVotingPower", "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view", "type": "function"},
+	{"inputs": [], "name": "owner", "outputs": [{"name": "", "type": "address"}], "stateMutability": "view", "type": "function"},
+	{"inputs": [{"name": "validator", "type": "address"}], "name": "removeValidator", "outputs": [], "stateMutability": "nonpayable", "type": "function"},
+	{"inputs": [{"name": "validator", "type": "address"}, {"name": "votingPower", "type": "uint256"}, {"name": "blsPublicKey", "type": "bytes"}], "name": "registerValidator", "outputs": [], "stateMutability": "nonpayable", "type": "function"}
+]`
+
+// ValidatorRegistration is a validator's entry in the contract's validator set
+type ValidatorRegistration struct {
+	Registered   bool
+	VotingPower  *big.Int
+	BLSPublicKey []byte
+}
+
+// ValidatorRegistry reads and changes the on-chain validator set
+type ValidatorRegistry interface {
+	GetValidatorRegistration(ctx context.Context, validator common.Address) (*ValidatorRegistration, error)
+	GetValidatorCount(ctx context.Context) (uint64, error)
+	GetOwner(ctx context.Context) (common.Address, error)
+	RemoveValidator(ctx context.Context, validator common.Address) (string, error)
+	RegisterValidator(ctx context.Context, validator common.Address, votingPower *big.Int, blsPublicKey []byte) (string, error)
+}
+
+// ContractValidatorRegistry is the ValidatorRegistry of a CertenAnchorV3 contract
+type ContractValidatorRegistry struct {
+	client     *ethereum.Client
+	contract   common.Address
+	privateKey string
+	gasLimit   uint64
+}
+
+// NewContractValidatorRegistry creates a registry for the contract at contractAddress,
+// sending transactions signed with privateKeyHex
+func NewContractValidatorRegistry(client *ethereum.Client, contractAddress, privateKeyHex string, gasLimit uint64) (*ContractValidatorRegistry, error) {
+	if client == nil {
+		return nil, fmt.Errorf("ethereum client cannot be nil")
+	}
+	if !common.IsHexAddress(contractAddress) {
+		return nil, fmt.Errorf("invalid contract address %q", contractAddress)
+	}
+	if gasLimit == 0 {
+		gasLimit = 300000
+	}
+	return &ContractValidatorRegistry{
+		client:     client,
+		contract:   common.HexToAddress(contractAddress),
+		privateKey: privateKeyHex,
+		gasLimit:   gasLimit,
+	}, nil
+}
+
+// GetValidatorRegistration reads validators(validator)
+func (r *ContractValidatorRegistry) GetValidatorRegistration(ctx context.Context, validator common.Address) (*ValidatorRegistration, error) {
+	result, err := r.client.CallContract(ctx, r.contract, validatorRegistryABI, "validators", validator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call validators: %w", err)
+	}
+	if len(result) < 3 {
+		return nil, fmt.Errorf("unexpected result length from validators: %d", len(result))
+	}
+	return &ValidatorRegistration{
+		Registered:   result[0].(bool),
+		VotingPower:  result[1].(*big.Int),
+		BLSPublicKey: result[2].([]byte),
+	}, nil
+}
+
+// GetValidatorCount reads getValidatorCount()
+func (r *ContractValidatorRegistry) GetValidatorCount(ctx context.Context) (uint64, error) {
+	result, err := r.client.CallContract(ctx, r.contract, validatorRegistryABI, "getValidatorCount")
+	if err != nil {
+		return 0, fmt.Errorf("failed to call getValidatorCount: %w", err)
+	}
+	if len(result) < 1 {
+		return 0, fmt.Errorf("empty result from getValidatorCount")
+	}
+	return result[0].(*big.Int).Uint64(), nil
+}
+
+// GetValidatorAt reads validatorList(index)
+func (r *ContractValidatorRegistry) GetValidatorAt(ctx context.Context, index uint64) (common.Address, error) {
+	result, err := r.client.CallContract(ctx, r.contract, validatorRegistryABI, "validatorList", new(big.Int).SetUint64(index))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to call validatorList(%d): %w", index, err)
+	}
+	if len(result) < 1 {
+		return common.Address{}, fmt.Errorf("empty result from validatorList(%d)", index)
+	}
+	return result[0].(common.Address), nil
+}
+
+// GetTotalVotingPower reads totalVotingPower()
+func (r *ContractValidatorRegistry) GetTotalVotingPower(ctx context.Context) (*big.Int, error) {
+	result, err := r.client.CallContract(ctx, r.contract, validatorRegistryABI, "totalVotingPower")
+	if err != nil {
+		return nil, fmt.Errorf("failed to call totalVotingPower: %w", err)
+	}
+	if len(result) < 1 {
+		return nil, fmt.Errorf("empty result from totalVotingPower")
+	}
+	return result[0].(*big.Int), nil
+}
+
+// GetOwner reads owner()
+func (r *ContractValidatorRegistry) GetOwner(ctx context.Context) (common.Address, error) {
+	result, err := r.client.CallContract(ctx, r.contract, validatorRegistryABI, "owner")
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to call owner: %w", err)
+	}
+	if len(result) < 1 {
+		return common.Address{}, fmt.Errorf("empty result from owner")
+	}
+	return result[0].(common.Address), nil
+}
+
+// RemoveValidator submits removeValidator(validator) and returns the transaction hash
+func (r *ContractValidatorRegistry) RemoveValidator(ctx context.Context, validator common.Address) (string, error) {
+	return r.send(ctx, "removeValidator", validator)
+}
+
+// RegisterValidator submits registerValidator(validator, votingPower, blsPublicKey)
+func (r *ContractValidatorRegistry) RegisterValidator(ctx context.Context, validator common.Address, votingPower *big.Int, blsPublicKey []byte) (string, error) {
+	return r.send(ctx, "registerValidator", validator, votingPower, blsPublicKey)
+}
+
+func (r *ContractValidatorRegistry) send(ctx context.Context, method string, params ...interface{}) (string, error) {
+	result, err := r.client.SendContractTransaction(ctx, r.contract, validatorRegistryABI, r.privateKey, method, r.gasLimit, params...)
+	if err != nil {
+		return "", fmt.Errorf("failed to submit %s: %w", method, err)
+	}
+	if !result.Success {
+		return result.TransactionHash, fmt.Errorf("%s transaction %s reverted", method, result.TransactionHash)
+	}
+	return result.TransactionHash, nil
+}
+
+// MaintenanceState records a deregistration awaiting re-registration
+type MaintenanceState struct {
+	ValidatorAddress string    `json:"validator_address"`
+	VotingPower      string    `json:"voting_power"`
+	BLSPublicKey     string    `json:"bls_public_key"` // Hex
+	Reason           string    `json:"reason,omitempty"`
+	RequestedAt      time.Time `json:"requested_at"`
+	DeregisterTxHash string    `json:"deregister_tx_hash,omitempty"` // Empty until removeValidator is mined
+}
+
+// MaintenanceConfig configures validator maintenance
+type MaintenanceConfig struct {
+	ValidatorAddress       common.Address // On-chain validator address
+	StatePath              string         // File holding the pending re-registration
+	MinRemainingValidators int            // Validators that must remain registered
+	Logger                 *log.Logger
+}
+
+// ValidatorMaintenance deregisters the validator for maintenance and re-registers it
+type ValidatorMaintenance struct {
+	mu       sync.Mutex
+	registry ValidatorRegistry
+	config   MaintenanceConfig
+	state    *MaintenanceState
+	logger   *log.Logger
+}
+
+// NewValidatorMaintenance creates a maintenance controller, loading any pending state
+func NewValidatorMaintenance(registry ValidatorRegistry, cfg MaintenanceConfig) (*ValidatorMaintenance, error) {
+	if registry == nil {
+		return nil, fmt.Errorf("validator registry cannot be nil")
+	}
+	if cfg.StatePath == "" {
+		return nil, fmt.Errorf("maintenance state path is required")
+	}
+	if cfg.ValidatorAddress == (common.Address{}) {
+		return nil, fmt.Errorf("validator address is required")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[Maintenance] ", log.LstdFlags)
+	}
+
+	m := &ValidatorMaintenance{registry: registry, config: cfg, logger: cfg.Logger}
+	data, err := os.ReadFile(cfg.StatePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read maintenance state: %w", err)
+	default:
+		var state MaintenanceState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to parse maintenance state %s: %w", cfg.StatePath, err)
+		}
+		m.state = &state
+	}
+	return m, nil
+}
+
+// State returns the pending maintenance state, or nil when not in maintenance
+func (m *ValidatorMaintenance) State() *MaintenanceState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == nil {
+		return nil
+	}
+	state := *m.state
+	return &state
+}
+
+// ValidatorAddress returns the on-chain validator address
+func (m *ValidatorMaintenance) ValidatorAddress() common.Address {
+	return m.config.ValidatorAddress
+}
+
+// Deregister removes the validator from the on-chain validator set after checking every
+// guard. The registration is persisted first so it can be restored on restart.
+func (m *ValidatorMaintenance) Deregister(ctx context.Context, reason string) (*MaintenanceState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != nil {
+		return nil, fmt.Errorf("%w: already deregistered for maintenance at %s",
+			ErrMaintenanceNotPermitted, m.state.RequestedAt.Format(time.RFC3339))
+	}
+
+	validator := m.config.ValidatorAddress
+	registration, err := m.registry.GetValidatorRegistration(ctx, validator)
+	if err != nil {
+		return nil, err
+	}
+	if !registration.Registered {
+		return nil, fmt.Errorf("%w: %s is not a registered validator", ErrMaintenanceNotPermitted, validator.Hex())
+	}
+	owner, err := m.registry.GetOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if owner != validator {
+		return nil, fmt.Errorf("%w: removeValidator is owner-only and the contract owner is %s; ask the governance process to deregister %s",
+			ErrMaintenanceNotPermitted, owner.Hex(), validator.Hex())
+	}
+	count, err := m.registry.GetValidatorCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if int64(count)-1 < int64(m.config.MinRemainingValidators) {
+		return nil, fmt.Errorf("%w: %d validators registered, at least %d must remain",
+			ErrMaintenanceNotPermitted, count, m.config.MinRemainingValidators)
+	}
+
+	state := &MaintenanceState{
+		ValidatorAddress: validator.Hex(),
+		VotingPower:      registration.VotingPower.String(),
+		BLSPublicKey:     hex.EncodeToString(registration.BLSPublicKey),
+		Reason:           reason,
+		RequestedAt:      time.Now().UTC(),
+	}
+	if err := m.saveState(state); err != nil {
+		return nil, err
+	}
+	m.state = state
+
+	m.logger.Printf("🛠️ Deregistering validator %s for maintenance (voting power %s): %s",
+		validator.Hex(), state.VotingPower, reason)
+	txHash, err := m.registry.RemoveValidator(ctx, validator)
+	if err != nil {
+		// Still registered: drop the pending state so a restart does not re-register
+		if still, checkErr := m.registry.GetValidatorRegistration(ctx, validator); checkErr == nil && still.Registered {
+			m.state = nil
+			os.Remove(m.config.StatePath)
+		}
+		return nil, fmt.Errorf("failed to deregister validator: %w", err)
+	}
+	state.DeregisterTxHash = txHash
+	if err := m.saveState(state); err != nil {
+		m.logger.Printf("⚠️ Failed to record deregistration tx %s: %v", txHash, err)
+	}
+	m.logger.Printf("✅ Validator %s deregistered (tx %s)", validator.Hex(), txHash)
+
+	result := *state
+	return &result, nil
+}
+
+// Reregister restores a registration removed for maintenance. It returns the restored
+// state, or nil when the validator was not in maintenance.
+func (m *ValidatorMaintenance) Reregister(ctx context.Context) (*MaintenanceState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state == nil {
+		return nil, nil
+	}
+	state := m.state
+	validator := common.HexToAddress(state.ValidatorAddress)
+	if validator != m.config.ValidatorAddress {
+		return nil, fmt.Errorf("maintenance state is for validator %s, not %s", validator.Hex(), m.config.ValidatorAddress.Hex())
+	}
+
+	registration, err := m.registry.GetValidatorRegistration(ctx, validator)
+	if err != nil {
+		return nil, err
+	}
+	if !registration.Registered {
+		votingPower, ok := new(big.Int).SetString(state.VotingPower, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid voting power %q in maintenance state", state.VotingPower)
+		}
+		blsPublicKey, err := hex.DecodeString(strings.TrimPrefix(state.BLSPublicKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid BLS public key in maintenance state: %w", err)
+		}
+		txHash, err := m.registry.RegisterValidator(ctx, validator, votingPower, blsPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to re-register validator: %w", err)
+		}
+		m.logger.Printf("✅ Validator %s re-registered after maintenance (voting power %s, tx %s)",
+			validator.Hex(), state.VotingPower, txHash)
+	} else {
+		m.logger.Printf("Validator %s is already registered; clearing maintenance state", validator.Hex())
+	}
+
+	if err := os.Remove(m.config.StatePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to clear maintenance state: %w", err)
+	}
+	m.state = nil
+	return state, nil
+}
+
+// saveState writes the state atomically
+func (m *ValidatorMaintenance) saveState(state *MaintenanceState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.config.StatePath), 0o700); err != nil {
+		return fmt.Errorf("failed to create maintenance state directory: %w", err)
+	}
+	tmp := m.config.StatePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write maintenance state: %w", err)
+	}
+	if err := os.Rename(tmp, m.config.StatePath); err != nil {
+		return fmt.Errorf("failed to write maintenance state: %w", err)
+	}
+	return nil
+}
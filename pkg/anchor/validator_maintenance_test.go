@@ -0,0 +1,185 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Validator Maintenance
+// Tests deregistration guards, state persistence and re-registration on restart
+
+package anchor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type fakeValidatorRegistry struct {
+	registrations map[common.Address]*ValidatorRegistration
+	owner         common.Address
+	removeErr     error
+	removed       int
+	registered    int
+}
+
+func (f *fakeValidatorRegistry) GetValidatorRegistration(ctx context.Context, validator common.Address) (*ValidatorRegistration, error) {
+	if reg, ok := f.registrations[validator]; ok {
+		return reg, nil
+	}
+	return &ValidatorRegistration{VotingPower: big.NewInt(0)}, nil
+}
+
+func (f *fakeValidatorRegistry) GetValidatorCount(ctx context.Context) (uint64, error) {
+	var count uint64
+	for _, reg := range f.registrations {
+		if reg.Registered {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeValidatorRegistry) GetOwner(ctx context.Context) (common.Address, error) {
+	return f.owner, nil
+}
+
+func (f *fakeValidatorRegistry) RemoveValidator(ctx context.Context, validator common.Address) (string, error) {
+	if f.removeErr != nil {
+		return "", f.removeErr
+	}
+	f.removed++
+	delete(f.registrations, validator)
+	return "0xremove", nil
+}
+
+func (f *fakeValidatorRegistry) RegisterValidator(ctx context.Context, validator common.Address, votingPower *big.Int, blsPublicKey []byte) (string, error) {
+	f.registered++
+	f.registrations[validator] = &ValidatorRegistration{Registered: true, VotingPower: votingPower, BLSPublicKey: blsPublicKey}
+	return "0xregister", nil
+}
+
+func newMaintenanceTestRegistry(validator common.Address, others int) *fakeValidatorRegistry {
+	registry := &fakeValidatorRegistry{
+		registrations: map[common.Address]*ValidatorRegistration{
+			validator: {Registered: true, VotingPower: big.NewInt(100), BLSPublicKey: []byte{0xb1, 0x5b}},
+		},
+		owner: validator,
+	}
+	for i := 0; i < others; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(1000 + i)))
+		registry.registrations[addr] = &ValidatorRegistration{Registered: true, VotingPower: big.NewInt(1)}
+	}
+	return registry
+}
+
+func newTestMaintenance(t *testing.T, registry ValidatorRegistry, validator common.Address, statePath string) *ValidatorMaintenance {
+	t.Helper()
+	m, err := NewValidatorMaintenance(registry, MaintenanceConfig{
+		ValidatorAddress:       validator,
+		StatePath:              statePath,
+		MinRemainingValidators: 2,
+		Logger:                 log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewValidatorMaintenance failed: %v", err)
+	}
+	return m
+}
+
+func TestValidatorMaintenance_DeregisterAndReregister(t *testing.T) {
+	validator := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	registry := newMaintenanceTestRegistry(validator, 2)
+	statePath := filepath.Join(t.TempDir(), "maintenance_state.json")
+	ctx := context.Background()
+
+	m := newTestMaintenance(t, registry, validator, statePath)
+	state, err := m.Deregister(ctx, "kernel upgrade")
+	if err != nil {
+		t.Fatalf("Deregister failed: %v", err)
+	}
+	if state.VotingPower != "100" || state.DeregisterTxHash != "0xremove" || registry.removed != 1 {
+		t.Errorf("unexpected deregistration state %+v", state)
+	}
+	if _, err := m.Deregister(ctx, "again"); !errors.Is(err, ErrMaintenanceNotPermitted) {
+		t.Errorf("expected second deregistration to be refused, got %v", err)
+	}
+
+	// A restart loads the pending state and restores the registration
+	restarted := newTestMaintenance(t, registry, validator, statePath)
+	if restarted.State() == nil {
+		t.Fatal("expected maintenance state to survive a restart")
+	}
+	restored, err := restarted.Reregister(ctx)
+	if err != nil || restored == nil {
+		t.Fatalf("Reregister failed: %v", err)
+	}
+	reg := registry.registrations[validator]
+	if reg == nil || !reg.Registered || reg.VotingPower.Int64() != 100 || string(reg.BLSPublicKey) != string([]byte{0xb1, 0x5b}) {
+		t.Errorf("registration not restored: %+v", reg)
+	}
+	if restarted.State() != nil {
+		t.Error("expected maintenance state to be cleared")
+	}
+	if _, err := os.Stat(statePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected state file to be removed, got %v", err)
+	}
+
+	// Nothing pending: Reregister is a no-op
+	if restored, err := restarted.Reregister(ctx); err != nil || restored != nil || registry.registered != 1 {
+		t.Errorf("expected no-op re-registration, got %+v, %v", restored, err)
+	}
+}
+
+func TestValidatorMaintenance_Guards(t *testing.T) {
+	validator := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	ctx := context.Background()
+
+	// Too few validators would remain
+	registry := newMaintenanceTestRegistry(validator, 1)
+	m := newTestMaintenance(t, registry, validator, filepath.Join(t.TempDir(), "state.json"))
+	if _, err := m.Deregister(ctx, "maintenance"); !errors.Is(err, ErrMaintenanceNotPermitted) {
+		t.Errorf("expected quorum guard, got %v", err)
+	}
+
+	// Validator is not the contract owner
+	registry = newMaintenanceTestRegistry(validator, 3)
+	registry.owner = common.HexToAddress("0x00000000000000000000000000000000000000ff")
+	m = newTestMaintenance(t, registry, validator, filepath.Join(t.TempDir(), "state.json"))
+	if _, err := m.Deregister(ctx, "maintenance"); !errors.Is(err, ErrMaintenanceNotPermitted) {
+		t.Errorf("expected owner guard, got %v", err)
+	}
+
+	// Validator is not registered
+	other := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	registry = newMaintenanceTestRegistry(validator, 3)
+	registry.owner = other
+	m = newTestMaintenance(t, registry, other, filepath.Join(t.TempDir(), "state.json"))
+	if _, err := m.Deregister(ctx, "maintenance"); !errors.Is(err, ErrMaintenanceNotPermitted) {
+		t.Errorf("expected registration guard, got %v", err)
+	}
+	if registry.removed != 0 {
+		t.Errorf("expected no removeValidator calls, got %d", registry.removed)
+	}
+}
+
+func TestValidatorMaintenance_FailedRemovalClearsState(t *testing.T) {
+	validator := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	registry := newMaintenanceTestRegistry(validator, 3)
+	registry.removeErr = errors.New("reverted")
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	m := newTestMaintenance(t, registry, validator, statePath)
+	if _, err := m.Deregister(context.Background(), "maintenance"); err == nil {
+		t.Fatal("expected removal failure")
+	}
+	if m.State() != nil {
+		t.Error("expected state to be cleared while still registered")
+	}
+	if _, err := os.Stat(statePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected no state file, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2025 Certen Protocol
+//
+// Voting Power Tracker - Keeps the validator's self-reported voting power current
+//
+// The contract owner can change a validator's voting power at any time by
+// registering it again. The BLS proof data submitted with every comprehensive proof
+// reports this validator's voting power and the network total, so a value assumed
+// at startup goes stale and the threshold data sent on-chain stops matching the
+// contract's validator set. The tracker reads both values from the contract at
+// startup and follows ValidatorRegistered events from then on:
+//   - "follow" (default) adopts the new voting power for subsequent proofs
+//   - "pin" keeps reporting the startup voting power and warns on every change
+
+package anchor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// VotingPowerPolicy selects how a change to this validator's on-chain voting power is handled
+type VotingPowerPolicy string
+
+const (
+	VotingPowerPolicyFollow VotingPowerPolicy = "follow" // Report the new voting power in subsequent proofs
+	VotingPowerPolicyPin    VotingPowerPolicy = "pin"    // Keep reporting the startup voting power, warn
+)
+
+// ParseVotingPowerPolicy parses a policy name, defaulting to follow
+func ParseVotingPowerPolicy(s string) (VotingPowerPolicy, error) {
+	switch VotingPowerPolicy(strings.ToLower(strings.TrimSpace(s))) {
+	case "", VotingPowerPolicyFollow:
+		return VotingPowerPolicyFollow, nil
+	case VotingPowerPolicyPin:
+		return VotingPowerPolicyPin, nil
+	default:
+		return "", fmt.Errorf("unknown voting power policy %q (expected \"follow\" or \"pin\")", s)
+	}
+}
+
+// VotingPowerSource reads voting power from the contract's validator set
+// Implemented by ContractValidatorRegistry
+type VotingPowerSource interface {
+	GetValidatorRegistration(ctx context.Context, validator common.Address) (*ValidatorRegistration, error)
+	GetTotalVotingPower(ctx context.Context) (*big.Int, error)
+}
+
+// VotingPowerChange records an observed change to this validator's voting power
+type VotingPowerChange struct {
+	OldPower    string    `json:"old_power"`
+	NewPower    string    `json:"new_power"`
+	BlockNumber uint64    `json:"block_number,omitempty"`
+	TxHash      string    `json:"tx_hash,omitempty"`
+	Applied     bool      `json:"applied"` // False when the policy pinned the previous value
+	ObservedAt  time.Time `json:"observed_at"`
+}
+
+// VotingPowerStatus reports the voting power used in proofs and its on-chain counterpart
+type VotingPowerStatus struct {
+	ValidatorAddress   string             `json:"validator_address"`
+	Policy             VotingPowerPolicy  `json:"policy"`
+	VotingPower        string             `json:"voting_power,omitempty"`          // Reported in BLS proof data
+	OnChainVotingPower string             `json:"on_chain_voting_power,omitempty"` // Last value seen on-chain
+	TotalVotingPower   string             `json:"total_voting_power,omitempty"`
+	Changes            int64              `json:"changes"` // Changes observed since startup
+	LastChange         *VotingPowerChange `json:"last_change,omitempty"`
+	LastError          string             `json:"last_error,omitempty"`
+}
+
+// VotingPowerTrackerConfig configures the voting power tracker
+type VotingPowerTrackerConfig struct {
+	ValidatorAddress common.Address    // On-chain validator address
+	Policy           VotingPowerPolicy // Handling of voting power changes
+	CallTimeout      time.Duration     // Timeout of contract reads made from event handlers
+	Logger           *log.Logger
+}
+
+// VotingPowerTracker caches this validator's voting power and the network total
+type VotingPowerTracker struct {
+	mu sync.Mutex
+
+	source      VotingPowerSource
+	validator   common.Address
+	policy      VotingPowerPolicy
+	callTimeout time.Duration
+	logger      *log.Logger
+
+	power      *big.Int // Reported in proofs; nil until first loaded
+	onChain    *big.Int
+	total      *big.Int
+	changes    int64
+	lastChange *VotingPowerChange
+	lastErr    string
+}
+
+// NewVotingPowerTracker creates a new voting power tracker
+func NewVotingPowerTracker(source VotingPowerSource, cfg *VotingPowerTrackerConfig) (*VotingPowerTracker, error) {
+	if source == nil {
+		return nil, fmt.Errorf("voting power source cannot be nil")
+	}
+	if cfg == nil || cfg.ValidatorAddress == (common.Address{}) {
+		return nil, fmt.Errorf("validator address is required")
+	}
+	policy, err := ParseVotingPowerPolicy(string(cfg.Policy))
+	if err != nil {
+		return nil, err
+	}
+	callTimeout := cfg.CallTimeout
+	if callTimeout <= 0 {
+		callTimeout = 15 * time.Second
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = log.New(log.Writer(), "[VotingPower] ", log.LstdFlags)
+	}
+
+	return &VotingPowerTracker{
+		source:      source,
+		validator:   cfg.ValidatorAddress,
+		policy:      policy,
+		callTimeout: callTimeout,
+		logger:      logger,
+	}, nil
+}
+
+// Refresh reads this validator's registration and the total voting power from the
+// contract. The first successful read sets the voting power reported in proofs;
+// later differences are handled as a change under the configured policy.
+func (t *VotingPowerTracker) Refresh(ctx context.Context) error {
+	reg, err := t.source.GetValidatorRegistration(ctx, t.validator)
+	if err != nil {
+		t.setError(err)
+		return err
+	}
+	if !reg.Registered {
+		err := fmt.Errorf("validator %s is not registered on-chain", t.validator.Hex())
+		t.setError(err)
+		return err
+	}
+	t.observe(reg.VotingPower, 0, "")
+	return t.refreshTotal(ctx)
+}
+
+// HandleEvent is an EventHandler for ValidatorRegistered events. A registration of
+// this validator is a voting power change; any registration changes the total.
+func (t *VotingPowerTracker) HandleEvent(event ContractEvent) error {
+	registered, ok := event.(*ValidatorRegisteredEvent)
+	if !ok {
+		return nil
+	}
+	if registered.Validator == t.validator && registered.VotingPower != nil {
+		t.observe(registered.VotingPower, registered.BlockNumber, registered.TxHash)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.callTimeout)
+	defer cancel()
+	return t.refreshTotal(ctx)
+}
+
+// observe records an on-chain voting power value and applies it according to policy
+func (t *VotingPowerTracker) observe(power *big.Int, blockNumber uint64, txHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.power == nil {
+		t.power = new(big.Int).Set(power)
+		t.onChain = new(big.Int).Set(power)
+		t.logger.Printf("Voting power of %s: %s (policy=%s)", t.validator.Hex(), power, t.policy)
+		return
+	}
+	if t.onChain.Cmp(power) == 0 {
+		return
+	}
+
+	change := &VotingPowerChange{
+		OldPower:    t.onChain.String(),
+		NewPower:    power.String(),
+		BlockNumber: blockNumber,
+		TxHash:      txHash,
+		Applied:     t.policy == VotingPowerPolicyFollow,
+		ObservedAt:  time.Now(),
+	}
+	t.onChain = new(big.Int).Set(power)
+	t.changes++
+	t.lastChange = change
+
+	if change.Applied {
+		t.power = new(big.Int).Set(power)
+		t.logger.Printf("⚠️ VOTING POWER CHANGED for %s: %s -> %s (block %d, tx %s); subsequent proofs report the new value",
+			t.validator.Hex(), change.OldPower, change.NewPower, blockNumber, txHash)
+		return
+	}
+	t.logger.Printf("⚠️ VOTING POWER CHANGED for %s: %s -> %s (block %d, tx %s); policy=pin, proofs keep reporting %s",
+		t.validator.Hex(), change.OldPower, change.NewPower, blockNumber, txHash, t.power)
+}
+
+func (t *VotingPowerTracker) refreshTotal(ctx context.Context) error {
+	total, err := t.source.GetTotalVotingPower(ctx)
+	if err != nil {
+		t.setError(err)
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.total != nil && t.total.Cmp(total) != 0 {
+		t.logger.Printf("Total voting power changed: %s -> %s", t.total, total)
+	}
+	t.total = new(big.Int).Set(total)
+	t.lastErr = ""
+	return nil
+}
+
+func (t *VotingPowerTracker) setError(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastErr = err.Error()
+}
+
+// VotingPower returns the voting power reported in proofs, or nil before the first read
+func (t *VotingPowerTracker) VotingPower() *big.Int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.power == nil {
+		return nil
+	}
+	return new(big.Int).Set(t.power)
+}
+
+// ApplyToBLSProof replaces the assumed voting power in BLS proof data with this
+// validator's current values and recomputes the threshold. It reports false and
+// leaves the proof untouched until the voting power has been read.
+func (t *VotingPowerTracker) ApplyToBLSProof(proof *BLSProofData) bool {
+	if proof == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.power == nil {
+		return false
+	}
+
+	proof.ValidatorAddresses = []common.Address{t.validator}
+	proof.VotingPowers = []*big.Int{new(big.Int).Set(t.power)}
+	proof.SignedVotingPower = new(big.Int).Set(t.power)
+	if t.total != nil {
+		proof.TotalVotingPower = new(big.Int).Set(t.total)
+	}
+	proof.ThresholdMet = votingThresholdMet(proof.SignedVotingPower, proof.TotalVotingPower)
+	return true
+}
+
+// Status returns the tracked voting power for health reporting
+func (t *VotingPowerTracker) Status() VotingPowerStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := VotingPowerStatus{
+		ValidatorAddress: t.validator.Hex(),
+		Policy:           t.policy,
+		Changes:          t.changes,
+		LastChange:       t.lastChange,
+		LastError:        t.lastErr,
+	}
+	if t.power != nil {
+		status.VotingPower = t.power.String()
+		status.OnChainVotingPower = t.onChain.String()
+	}
+	if t.total != nil {
+		status.TotalVotingPower = t.total.String()
+	}
+	return status
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Voting Power Tracker
+// Tests startup reads, ValidatorRegistered handling under each policy and BLS proof data updates
+
+package anchor
+
+import (
+	"context"
+	"io"
+	"log"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fakeVotingPowerSource serves a mutable validator set
+type fakeVotingPowerSource struct {
+	powers map[common.Address]int64
+}
+
+func (f *fakeVotingPowerSource) GetValidatorRegistration(ctx context.Context, validator common.Address) (*ValidatorRegistration, error) {
+	power, ok := f.powers[validator]
+	return &ValidatorRegistration{Registered: ok, VotingPower: big.NewInt(power)}, nil
+}
+
+func (f *fakeVotingPowerSource) GetTotalVotingPower(ctx context.Context) (*big.Int, error) {
+	var total int64
+	for _, power := range f.powers {
+		total += power
+	}
+	return big.NewInt(total), nil
+}
+
+func newTestVotingPowerTracker(t *testing.T, policy VotingPowerPolicy) (*VotingPowerTracker, *fakeVotingPowerSource, common.Address) {
+	t.Helper()
+	self := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	source := &fakeVotingPowerSource{powers: map[common.Address]int64{
+		self: 70,
+		common.HexToAddress("0x00000000000000000000000000000000000000b2"): 30,
+	}}
+	tracker, err := NewVotingPowerTracker(source, &VotingPowerTrackerConfig{
+		ValidatorAddress: self,
+		Policy:           policy,
+		Logger:           log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewVotingPowerTracker failed: %v", err)
+	}
+	if err := tracker.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	return tracker, source, self
+}
+
+func TestVotingPowerTracker_FollowsReregistration(t *testing.T) {
+	tracker, source, self := newTestVotingPowerTracker(t, VotingPowerPolicyFollow)
+
+	proof := &BLSProofData{TotalVotingPower: big.NewInt(100), SignedVotingPower: big.NewInt(67), ThresholdMet: true}
+	if !tracker.ApplyToBLSProof(proof) {
+		t.Fatal("expected proof to be updated")
+	}
+	if proof.SignedVotingPower.Int64() != 70 || proof.TotalVotingPower.Int64() != 100 || !proof.ThresholdMet ||
+		len(proof.ValidatorAddresses) != 1 || proof.ValidatorAddresses[0] != self {
+		t.Fatalf("unexpected proof data %+v", proof)
+	}
+
+	// The owner halves this validator's voting power
+	source.powers[self] = 35
+	if err := tracker.HandleEvent(&ValidatorRegisteredEvent{Validator: self, VotingPower: big.NewInt(35), BlockNumber: 42, TxHash: "0xre"}); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	if tracker.VotingPower().Int64() != 35 {
+		t.Errorf("voting power = %s, expected 35", tracker.VotingPower())
+	}
+
+	tracker.ApplyToBLSProof(proof)
+	if proof.SignedVotingPower.Int64() != 35 || proof.TotalVotingPower.Int64() != 65 || proof.ThresholdMet {
+		t.Errorf("unexpected proof data after change %+v", proof)
+	}
+
+	status := tracker.Status()
+	if status.Changes != 1 || status.LastChange == nil || !status.LastChange.Applied ||
+		status.LastChange.OldPower != "70" || status.LastChange.NewPower != "35" || status.LastChange.BlockNumber != 42 {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestVotingPowerTracker_PinAndOtherValidators(t *testing.T) {
+	tracker, source, self := newTestVotingPowerTracker(t, VotingPowerPolicyPin)
+
+	// Another validator joining only changes the total
+	other := common.HexToAddress("0x00000000000000000000000000000000000000c3")
+	source.powers[other] = 50
+	if err := tracker.HandleEvent(&ValidatorRegisteredEvent{Validator: other, VotingPower: big.NewInt(50)}); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	if status := tracker.Status(); status.Changes != 0 || status.TotalVotingPower != "150" {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	// A change to this validator is recorded but the startup value keeps being reported
+	source.powers[self] = 90
+	if err := tracker.HandleEvent(&ValidatorRegisteredEvent{Validator: self, VotingPower: big.NewInt(90)}); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	status := tracker.Status()
+	if status.VotingPower != "70" || status.OnChainVotingPower != "90" || status.LastChange == nil || status.LastChange.Applied {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestVotingPowerTracker_NotLoaded(t *testing.T) {
+	self := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	tracker, err := NewVotingPowerTracker(&fakeVotingPowerSource{powers: map[common.Address]int64{}}, &VotingPowerTrackerConfig{
+		ValidatorAddress: self,
+		Logger:           log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewVotingPowerTracker failed: %v", err)
+	}
+	if err := tracker.Refresh(context.Background()); err == nil {
+		t.Error("expected error for an unregistered validator")
+	}
+
+	proof := &BLSProofData{TotalVotingPower: big.NewInt(100), SignedVotingPower: big.NewInt(67), ThresholdMet: true}
+	if tracker.ApplyToBLSProof(proof) || proof.SignedVotingPower.Int64() != 67 {
+		t.Errorf("proof should be left untouched, got %+v", proof)
+	}
+
+	if _, err := ParseVotingPowerPolicy("ignore"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Certen Protocol
+//
+// Anchor Receipt - Compact, portable, signed proof-of-anchoring
+//
+// A receipt states that batch X with Merkle root R was anchored in transaction T at block B
+// of the target chain and reached the stated finality, signed by the issuing validator's
+// Ed25519 key. It is much smaller than a full proof bundle and can be verified offline with
+// nothing but the receipt itself (and, optionally, the validator's known public key).
+//
+// The signed message is SHA256 over the receipt's canonical payload: the receipt version
+// line followed by one "key=value" line per field, in the order of AnchorReceipt's fields.
+
+package anchor_proof
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnchorReceiptVersion identifies the receipt format and is the first line of the signed payload
+const AnchorReceiptVersion = "certen-anchor-receipt/v1"
+
+// AnchorReceipt is a validator-signed attestation that a batch root was anchored on-chain
+// All binary data is hex-encoded, all times are RFC3339 UTC
+type AnchorReceipt struct {
+	Version         string `json:"version"`
+	AnchorID        string `json:"anchor_id"`
+	BatchID         string `json:"batch_id"`
+	MerkleRoot      string `json:"merkle_root"`
+	TargetChain     string `json:"target_chain"`
+	ChainID         string `json:"chain_id,omitempty"`
+	ContractAddress string `json:"contract_address,omitempty"`
+	AnchorTxHash    string `json:"anchor_tx_hash"`
+	BlockNumber     int64  `json:"block_number"`
+	BlockHash       string `json:"block_hash,omitempty"`
+	AnchoredAt      string `json:"anchored_at,omitempty"`
+	Confirmations   int    `json:"confirmations"`
+	Finality        string `json:"finality"`
+	ValidatorID     string `json:"validator_id"`
+	ValidatorPubkey string `json:"validator_pubkey"`
+	IssuedAt        string `json:"issued_at"`
+
+	// Ed25519 signature over SHA256(SigningPayload())
+	Signature string `json:"signature"`
+}
+
+// SigningPayload returns the canonical bytes covered by the receipt signature
+func (r *AnchorReceipt) SigningPayload() []byte {
+	var b strings.Builder
+	b.WriteString(r.Version)
+	b.WriteByte('\n')
+	for _, field := range [][2]string{
+		{"anchor_id", r.AnchorID},
+		{"batch_id", r.BatchID},
+		{"merkle_root", r.MerkleRoot},
+		{"target_chain", r.TargetChain},
+		{"chain_id", r.ChainID},
+		{"contract_address", r.ContractAddress},
+		{"anchor_tx_hash", r.AnchorTxHash},
+		{"block_number", strconv.FormatInt(r.BlockNumber, 10)},
+		{"block_hash", r.BlockHash},
+		{"anchored_at", r.AnchoredAt},
+		{"confirmations", strconv.Itoa(r.Confirmations)},
+		{"finality", r.Finality},
+		{"validator_id", r.ValidatorID},
+		{"validator_pubkey", r.ValidatorPubkey},
+		{"issued_at", r.IssuedAt},
+	} {
+		b.WriteString(field[0])
+		b.WriteByte('=')
+		b.WriteString(field[1])
+		b.WriteByte('\n')
+	}
+	return []byte(b.String())
+}
+
+// SignAnchorReceipt fills in the validator identity, issue time and signature of a receipt
+func (s *AttestationSigner) SignAnchorReceipt(receipt *AnchorReceipt) error {
+	if receipt == nil {
+		return fmt.Errorf("receipt cannot be nil")
+	}
+	if receipt.AnchorTxHash == "" || receipt.MerkleRoot == "" {
+		return fmt.Errorf("receipt requires anchor tx hash and merkle root")
+	}
+
+	receipt.Version = AnchorReceiptVersion
+	receipt.ValidatorID = s.validatorID
+	receipt.ValidatorPubkey = hex.EncodeToString(s.publicKey)
+	receipt.IssuedAt = time.Now().UTC().Format(time.RFC3339)
+
+	digest := sha256.Sum256(receipt.SigningPayload())
+	signature, err := s.key.Sign(digest[:])
+	if err != nil {
+		return fmt.Errorf("failed to sign receipt: %w", err)
+	}
+	receipt.Signature = hex.EncodeToString(signature)
+	return nil
+}
+
+// VerifyAnchorReceipt checks a receipt's signature against the public key embedded in it
+// Callers that trust specific validators must also compare ValidatorPubkey to the key they expect
+func VerifyAnchorReceipt(receipt *AnchorReceipt) error {
+	if receipt == nil {
+		return fmt.Errorf("receipt cannot be nil")
+	}
+	if receipt.Version != AnchorReceiptVersion {
+		return fmt.Errorf("unsupported receipt version %q", receipt.Version)
+	}
+
+	pubkey, err := hex.DecodeString(receipt.ValidatorPubkey)
+	if err != nil || len(pubkey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid validator public key")
+	}
+	signature, err := hex.DecodeString(receipt.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signature encoding")
+	}
+
+	digest := sha256.Sum256(receipt.SigningPayload())
+	if !ed25519.Verify(pubkey, digest[:], signature) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
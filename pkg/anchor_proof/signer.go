@@ -2,6 +2,38 @@
 //
 // Validator Attestation Signer - Creates and verifies validator attestations
 // Validators sign attestations using Ed25519 to cryptographically endorse proofs
+//
+// Signed message layouts (the Ed25519 signature is over the 32-byte SHA-256 digest):
+//
+//	V1 (proof attestations):
+//	  "CERTEN_ATTESTATION_V1"  21 bytes ASCII
+//	  merkle root              32 bytes
+//	  anchor tx hash           UTF-8 string as sent, remaining bytes
+//
+//	V2 (batch attestations, MessageVersion 2):
+//	  "CERTEN_ATTESTATION_V2"  21 bytes ASCII
+//	  batch ID                 16 bytes, RFC 4122 binary form of the UUID
+//	  merkle root              32 bytes
+//	  nonce                    8 bytes, unsigned big-endian
+//	  anchor tx hash           UTF-8 string as sent, remaining bytes
+//
+// V2 binds an attestation to one batch and makes each one unique: the nonce increases
+// with every attestation a validator signs (it starts from the Unix time in nanoseconds,
+// so it keeps increasing across restarts), and verifiers reject nonces already seen for
+// the validator.
+//
+// BLS12-381 attestations (SignatureScheme "bls12-381") are aggregated for the anchor
+// contract, so every validator signs the same message and there is no nonce:
+//
+//	BatchResultHash (32 bytes) = SHA-256 of:
+//	  "CERTEN_BATCH_RESULT_V1" 22 bytes ASCII
+//	  batch ID                 16 bytes, RFC 4122 binary form of the UUID
+//	  merkle root              32 bytes
+//	  anchor tx hash           UTF-8 string as sent, remaining bytes
+//
+// signed with BLS domain separation: the BLS message is
+// SHA-256("CERTEN_RESULT_ATTESTATION_V1" || BatchResultHash). A BLS attestation is only
+// accepted from a validator whose Ed25519 attestation for the batch was accepted.
 
 package anchor_proof
 
@@ -9,19 +41,26 @@ import (
 	"bytes"
 	"crypto/ed25519"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/keys"
 )
 
 // AttestationSigner creates validator attestations
 type AttestationSigner struct {
 	validatorID string
-	privateKey  ed25519.PrivateKey
+	key         keys.KeyProvider
 	publicKey   ed25519.PublicKey
+
+	nonceMu   sync.Mutex
+	lastNonce uint64
 }
 
 // NewAttestationSigner creates a new signer with the given private key
@@ -29,11 +68,23 @@ func NewAttestationSigner(validatorID string, privateKey ed25519.PrivateKey) (*A
 	if len(privateKey) != ed25519.PrivateKeySize {
 		return nil, fmt.Errorf("invalid private key size: expected %d, got %d", ed25519.PrivateKeySize, len(privateKey))
 	}
+	key, err := keys.FromPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewAttestationSignerFromProvider(validatorID, key)
+}
 
+// NewAttestationSignerFromProvider creates a signer that signs through a key provider,
+// so the private key may stay in a KMS or HSM
+func NewAttestationSignerFromProvider(validatorID string, key keys.KeyProvider) (*AttestationSigner, error) {
+	if key == nil {
+		return nil, fmt.Errorf("key provider is required")
+	}
 	return &AttestationSigner{
 		validatorID: validatorID,
-		privateKey:  privateKey,
-		publicKey:   privateKey.Public().(ed25519.PublicKey),
+		key:         key,
+		publicKey:   key.PublicKey(),
 	}, nil
 }
 
@@ -81,7 +132,10 @@ func (s *AttestationSigner) SignProof(proof *CertenAnchorProof) (*ValidatorAttes
 	message := createAttestationMessage(merkleRoot, proof.AnchorReference.TxHash)
 
 	// Sign the message
-	signature := ed25519.Sign(s.privateKey, message)
+	signature, err := s.key.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attestation: %w", err)
+	}
 
 	return &ValidatorAttestation{
 		AttestationID:      uuid.New(),
@@ -117,7 +171,41 @@ func (s *AttestationSigner) SignMerkleRoot(merkleRoot []byte, anchorTxHash strin
 	}
 
 	message := createAttestationMessage(merkleRoot, anchorTxHash)
-	signature := ed25519.Sign(s.privateKey, message)
+	signature, err := s.key.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attestation: %w", err)
+	}
+
+	return &ValidatorAttestation{
+		AttestationID:      uuid.New(),
+		ValidatorID:        s.validatorID,
+		ValidatorPubkey:    s.publicKey,
+		AttestedMerkleRoot: merkleRoot,
+		AttestedAnchorTx:   anchorTxHash,
+		Signature:          signature,
+		AttestedAt:         time.Now(),
+	}, nil
+}
+
+// SignBatch creates a replay-protected (V2) attestation binding the batch ID, merkle
+// root and anchor tx with the signer's next nonce
+func (s *AttestationSigner) SignBatch(batchID uuid.UUID, merkleRoot []byte, anchorTxHash string) (*ValidatorAttestation, error) {
+	if batchID == uuid.Nil {
+		return nil, fmt.Errorf("batch ID is required")
+	}
+	if len(merkleRoot) != 32 {
+		return nil, fmt.Errorf("merkle root must be 32 bytes")
+	}
+	if anchorTxHash == "" {
+		return nil, fmt.Errorf("anchor tx hash is required")
+	}
+
+	nonce := s.nextNonce()
+	message := createBatchAttestationMessage(batchID, merkleRoot, nonce, anchorTxHash)
+	signature, err := s.key.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attestation: %w", err)
+	}
 
 	return &ValidatorAttestation{
 		AttestationID:      uuid.New(),
@@ -125,11 +213,29 @@ func (s *AttestationSigner) SignMerkleRoot(merkleRoot []byte, anchorTxHash strin
 		ValidatorPubkey:    s.publicKey,
 		AttestedMerkleRoot: merkleRoot,
 		AttestedAnchorTx:   anchorTxHash,
+		SignatureScheme:    SignatureSchemeEd25519,
+		MessageVersion:     AttestationMessageV2,
+		BatchID:            batchID,
+		Nonce:              nonce,
 		Signature:          signature,
 		AttestedAt:         time.Now(),
 	}, nil
 }
 
+// nextNonce returns a nonce greater than every nonce returned before, and than the
+// nonces of earlier runs as long as the clock does not go back
+func (s *AttestationSigner) nextNonce() uint64 {
+	s.nonceMu.Lock()
+	defer s.nonceMu.Unlock()
+
+	nonce := uint64(time.Now().UnixNano())
+	if nonce <= s.lastNonce {
+		nonce = s.lastNonce + 1
+	}
+	s.lastNonce = nonce
+	return nonce
+}
+
 // =============================================================================
 // Attestation Verification
 // =============================================================================
@@ -203,7 +309,7 @@ func (v *AttestationVerifier) VerifyAttestation(att *ValidatorAttestation) (*Att
 	}
 
 	// Recreate the message that was signed
-	message := createAttestationMessage(att.AttestedMerkleRoot, att.AttestedAnchorTx)
+	message := attestationMessage(att)
 
 	// Verify the signature
 	result.Valid = ed25519.Verify(att.ValidatorPubkey, message, att.Signature)
@@ -286,6 +392,59 @@ type BatchAttestationVerifyResult struct {
 // Helper Functions
 // =============================================================================
 
+// Attestation signature schemes (ValidatorAttestation.SignatureScheme)
+const (
+	SignatureSchemeEd25519  = "ed25519"
+	SignatureSchemeBLS12381 = "bls12-381"
+)
+
+// IsEd25519 reports whether the attestation is signed with Ed25519, the default scheme
+func (att *ValidatorAttestation) IsEd25519() bool {
+	return att.SignatureScheme == "" || att.SignatureScheme == SignatureSchemeEd25519
+}
+
+// BatchResultHash returns the message BLS attestations of a batch sign, shared by all
+// validators so that their signatures can be aggregated
+// Format: SHA256("CERTEN_BATCH_RESULT_V1" || batch_id || merkle_root || anchor_tx_hash)
+func BatchResultHash(batchID uuid.UUID, merkleRoot []byte, anchorTxHash string) [32]byte {
+	var buf bytes.Buffer
+	buf.WriteString("CERTEN_BATCH_RESULT_V1")
+	buf.Write(batchID[:])
+	buf.Write(merkleRoot)
+	buf.WriteString(anchorTxHash)
+	return sha256.Sum256(buf.Bytes())
+}
+
+// Attestation message versions
+const (
+	AttestationMessageV1 = 1 // Merkle root and anchor tx
+	AttestationMessageV2 = 2 // Batch ID, merkle root, nonce and anchor tx
+)
+
+// attestationMessage returns the message an attestation's signature covers
+func attestationMessage(att *ValidatorAttestation) []byte {
+	if att.MessageVersion == AttestationMessageV2 {
+		return createBatchAttestationMessage(att.BatchID, att.AttestedMerkleRoot, att.Nonce, att.AttestedAnchorTx)
+	}
+	return createAttestationMessage(att.AttestedMerkleRoot, att.AttestedAnchorTx)
+}
+
+// createBatchAttestationMessage creates the canonical V2 message to be signed
+// Format: SHA256("CERTEN_ATTESTATION_V2" || batch_id || merkle_root || nonce (uint64 BE) || anchor_tx_hash)
+func createBatchAttestationMessage(batchID uuid.UUID, merkleRoot []byte, nonce uint64, anchorTxHash string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("CERTEN_ATTESTATION_V2")
+	buf.Write(batchID[:])
+	buf.Write(merkleRoot)
+	var nonceBytes [8]byte
+	binary.BigEndian.PutUint64(nonceBytes[:], nonce)
+	buf.Write(nonceBytes[:])
+	buf.WriteString(anchorTxHash)
+
+	hash := sha256.Sum256(buf.Bytes())
+	return hash[:]
+}
+
 // createAttestationMessage creates the canonical message to be signed
 // Format: SHA256("CERTEN_ATTESTATION_V1" || merkle_root || anchor_tx_hash)
 func createAttestationMessage(merkleRoot []byte, anchorTxHash string) []byte {
@@ -302,10 +461,10 @@ func createAttestationMessage(merkleRoot []byte, anchorTxHash string) []byte {
 
 // ValidateAttestationSignature is a convenience function to verify a single attestation
 func ValidateAttestationSignature(att *ValidatorAttestation) bool {
-	if att == nil || len(att.ValidatorPubkey) != ed25519.PublicKeySize || len(att.Signature) != ed25519.SignatureSize {
+	if att == nil || !att.IsEd25519() || len(att.ValidatorPubkey) != ed25519.PublicKeySize || len(att.Signature) != ed25519.SignatureSize {
 		return false
 	}
-	message := createAttestationMessage(att.AttestedMerkleRoot, att.AttestedAnchorTx)
+	message := attestationMessage(att)
 	return ed25519.Verify(att.ValidatorPubkey, message, att.Signature)
 }
 
@@ -316,6 +475,7 @@ func ValidateAttestationSignature(att *ValidatorAttestation) bool {
 // AttestationBundle represents a collection of attestations for a proof
 type AttestationBundle struct {
 	ProofID       uuid.UUID              `json:"proof_id"`
+	BatchID       uuid.UUID              `json:"batch_id"` // Batch being attested (V2 attestations)
 	MerkleRoot    []byte                 `json:"merkle_root"`
 	AnchorTxHash  string                 `json:"anchor_tx_hash"`
 	Attestations  []ValidatorAttestation `json:"attestations"`
@@ -324,6 +484,9 @@ type AttestationBundle struct {
 	IsSufficient  bool                   `json:"is_sufficient"`
 	RequiredCount int                    `json:"required_count"`
 	CreatedAt     time.Time              `json:"created_at"`
+
+	// BLS12-381 attestations for the anchor contract, verified by the collector
+	BLSAttestations []ValidatorAttestation `json:"bls_attestations,omitempty"`
 }
 
 // NewAttestationBundle creates a new attestation bundle
@@ -374,6 +537,27 @@ func (b *AttestationBundle) AddAttestation(att *ValidatorAttestation) error {
 	return nil
 }
 
+// AddBLSAttestation adds a BLS attestation for this bundle's batch. The caller verifies
+// the BLS signature, which needs the validator's registered BLS key.
+func (b *AttestationBundle) AddBLSAttestation(att *ValidatorAttestation) error {
+	if att.SignatureScheme != SignatureSchemeBLS12381 {
+		return fmt.Errorf("attestation is not a BLS attestation")
+	}
+	if att.BatchID != b.BatchID || !bytes.Equal(att.AttestedMerkleRoot, b.MerkleRoot) || att.AttestedAnchorTx != b.AnchorTxHash {
+		return fmt.Errorf("BLS attestation does not match bundle")
+	}
+	for _, existing := range b.BLSAttestations {
+		if bytes.Equal(existing.ValidatorPubkey, att.ValidatorPubkey) {
+			return fmt.Errorf("duplicate BLS attestation from public key (validator %s)", att.ValidatorID)
+		}
+		if existing.ValidatorID == att.ValidatorID {
+			return fmt.Errorf("duplicate BLS attestation from validator %s", att.ValidatorID)
+		}
+	}
+	b.BLSAttestations = append(b.BLSAttestations, *att)
+	return nil
+}
+
 // ToJSON serializes the bundle to JSON
 func (b *AttestationBundle) ToJSON() ([]byte, error) {
 	return json.Marshal(b)
@@ -388,6 +572,16 @@ func (b *AttestationBundle) GetValidatorIDs() []string {
 	return ids
 }
 
+// HasAttestationFrom reports whether the bundle holds an attestation from a validator
+func (b *AttestationBundle) HasAttestationFrom(validatorID string) bool {
+	for _, att := range b.Attestations {
+		if att.ValidatorID == validatorID {
+			return true
+		}
+	}
+	return false
+}
+
 // MerkleRootHex returns the Merkle root as a hex string
 func (b *AttestationBundle) MerkleRootHex() string {
 	return hex.EncodeToString(b.MerkleRoot)
@@ -512,14 +512,23 @@ type ValidatorAttestation struct {
 
 	// Validator identity
 	ValidatorID     string `json:"validator_id"`
-	ValidatorPubkey []byte `json:"validator_pubkey"` // 32 bytes Ed25519
+	ValidatorPubkey []byte `json:"validator_pubkey"` // 32 bytes Ed25519, or the BLS public key
 
 	// What is being attested to
 	AttestedMerkleRoot []byte `json:"attested_merkle_root"` // 32 bytes
 	AttestedAnchorTx   string `json:"attested_anchor_tx"`
 
-	// The signature (over canonical proof representation)
-	Signature []byte `json:"signature"` // 64 bytes Ed25519
+	// Scheme of Signature and ValidatorPubkey: Ed25519 (default) authenticates the
+	// validator to its peers, BLS12-381 signatures are aggregated for the anchor contract
+	SignatureScheme string `json:"signature_scheme,omitempty"`
+
+	// Replay protection, signed in batch attestations (MessageVersion 2)
+	MessageVersion int       `json:"message_version,omitempty"` // 0 or 1: merkle root and anchor tx only
+	BatchID        uuid.UUID `json:"batch_id"`
+	Nonce          uint64    `json:"nonce,omitempty"` // Increases with every attestation of the validator
+
+	// The signature (over canonical proof representation, see attestationMessage)
+	Signature []byte `json:"signature"` // 64 bytes Ed25519, or the BLS signature
 
 	// Timestamp
 	AttestedAt time.Time `json:"attested_at"`
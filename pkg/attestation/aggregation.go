@@ -0,0 +1,327 @@
+// Copyright 2025 Certen Protocol
+//
+// Attestation Aggregation - Building the on-chain BLS proof from collected attestations
+//
+// The anchor contract accepts a single aggregate BLS signature together with the
+// addresses and voting powers of the validators that signed. Aggregation turns the
+// attestations collected from peers into that struct:
+//   - every signature is verified against the public key registered for its
+//     validator, never against the key carried in the attestation
+//   - attestations from unregistered validators, for another message, with an invalid
+//     signature or repeating a validator already counted are rejected
+//   - BLS signatures are aggregated and their validators' voting power is summed
+//   - Ed25519 signatures are verified and reported, but cannot be aggregated into the
+//     BLS signature, so they do not count toward the on-chain signed voting power
+//
+// The threshold is met when signedVotingPower/totalVotingPower reaches the configured
+// fraction (2/3 by default, as checked by the contract).
+//
+// During collection each validator returns an Ed25519 attestation, which authenticates
+// it to its peers, and - when it has a BLS key - a BLS attestation of the batch result
+// (anchor_proof.BatchResultHash), which counts toward the voting power threshold. The
+// batch's comprehensive proof is submitted with the anchor, before these attestations
+// exist, so they are not aggregated into it.
+
+package attestation
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/anchor_proof"
+	"github.com/certen/independant-validator/pkg/attestation/strategy"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+	"github.com/certen/independant-validator/pkg/execution/contracts"
+)
+
+var (
+	// ErrNoValidatorSet is returned when aggregating before a validator set is registered
+	ErrNoValidatorSet = errors.New("no validator set registered")
+
+	// ErrNoBLSSignatures is returned when no attestation carries a valid BLS signature
+	ErrNoBLSSignatures = errors.New("no valid BLS signatures to aggregate")
+)
+
+// RegisteredValidator is a validator whose attestations count toward the anchor threshold
+type RegisteredValidator struct {
+	ValidatorID      string
+	Address          common.Address    // On-chain validator address
+	VotingPower      *big.Int          // Voting power registered with the contract
+	BLSPublicKey     []byte            // Compressed BLS12-381 public key (optional)
+	Ed25519PublicKey ed25519.PublicKey // Ed25519 public key (optional)
+}
+
+// ValidatorSet is the registered validator set and the threshold it must reach
+type ValidatorSet struct {
+	validators  map[string]*registeredKeys
+	order       []string // Registration order, used for the proof's validator list
+	total       *big.Int
+	numerator   uint64
+	denominator uint64
+}
+
+// registeredKeys is a registered validator with its parsed BLS key
+type registeredKeys struct {
+	RegisteredValidator
+	blsKey *bls.PublicKey
+}
+
+// NewValidatorSet registers validators with a numerator/denominator voting power
+// threshold (0/0 = the contract's 2/3)
+func NewValidatorSet(validators []RegisteredValidator, numerator, denominator uint64) (*ValidatorSet, error) {
+	if numerator == 0 && denominator == 0 {
+		numerator, denominator = 2, 3
+	}
+	if denominator == 0 || numerator == 0 || numerator > denominator {
+		return nil, fmt.Errorf("invalid threshold %d/%d", numerator, denominator)
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("validator set is empty")
+	}
+
+	set := &ValidatorSet{
+		validators:  make(map[string]*registeredKeys, len(validators)),
+		total:       new(big.Int),
+		numerator:   numerator,
+		denominator: denominator,
+	}
+	for _, v := range validators {
+		if v.ValidatorID == "" {
+			return nil, fmt.Errorf("validator ID is required")
+		}
+		if _, exists := set.validators[v.ValidatorID]; exists {
+			return nil, fmt.Errorf("validator %s registered twice", v.ValidatorID)
+		}
+		if v.VotingPower == nil || v.VotingPower.Sign() <= 0 {
+			return nil, fmt.Errorf("validator %s: voting power must be positive", v.ValidatorID)
+		}
+		if len(v.BLSPublicKey) == 0 && len(v.Ed25519PublicKey) == 0 {
+			return nil, fmt.Errorf("validator %s: no public key", v.ValidatorID)
+		}
+		if len(v.Ed25519PublicKey) != 0 && len(v.Ed25519PublicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("validator %s: invalid Ed25519 public key size %d", v.ValidatorID, len(v.Ed25519PublicKey))
+		}
+
+		keys := &registeredKeys{RegisteredValidator: v}
+		keys.VotingPower = new(big.Int).Set(v.VotingPower)
+		if len(v.BLSPublicKey) != 0 {
+			pk, err := bls.PublicKeyFromBytes(v.BLSPublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("validator %s: invalid BLS public key: %w", v.ValidatorID, err)
+			}
+			keys.blsKey = pk
+		}
+
+		set.validators[v.ValidatorID] = keys
+		set.order = append(set.order, v.ValidatorID)
+		set.total.Add(set.total, keys.VotingPower)
+	}
+	return set, nil
+}
+
+// TotalVotingPower returns the voting power of the whole set
+func (vs *ValidatorSet) TotalVotingPower() *big.Int {
+	return new(big.Int).Set(vs.total)
+}
+
+// ThresholdMet reports whether signed voting power reaches the set's threshold
+func (vs *ValidatorSet) ThresholdMet(signed *big.Int) bool {
+	if signed == nil || vs.total.Sign() <= 0 {
+		return false
+	}
+	// signed/total >= numerator/denominator
+	lhs := new(big.Int).Mul(signed, new(big.Int).SetUint64(vs.denominator))
+	rhs := new(big.Int).Mul(vs.total, new(big.Int).SetUint64(vs.numerator))
+	return lhs.Cmp(rhs) >= 0
+}
+
+// RejectedAttestation is an attestation left out of the aggregate
+type RejectedAttestation struct {
+	ValidatorID string `json:"validator_id"`
+	Scheme      string `json:"scheme"`
+	Reason      string `json:"reason"`
+}
+
+// AggregationResult is the on-chain BLS proof and how each attestation was used
+type AggregationResult struct {
+	Proof          contracts.CertenAnchorV3BLSProofData
+	BLSSigners     []string              // Validators in the aggregate signature
+	Ed25519Signers []string              // Verified Ed25519 attestations (not on-chain)
+	Rejected       []RejectedAttestation // Attestations left out, with the reason
+}
+
+// SetValidatorSet registers the validator set attestations are aggregated against.
+// In voting power mode the collections in progress are re-evaluated against it.
+func (s *Service) SetValidatorSet(set *ValidatorSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validatorSet = set
+	for _, bundle := range s.bundles {
+		s.updateSufficiencyLocked(bundle)
+	}
+}
+
+// AggregateAttestations verifies collected attestations over messageHash against the
+// registered validator set and builds the BLS proof data for on-chain submission
+func (s *Service) AggregateAttestations(messageHash [32]byte, attestations []*strategy.Attestation) (*AggregationResult, error) {
+	s.mu.RLock()
+	set := s.validatorSet
+	s.mu.RUnlock()
+	if set == nil {
+		return nil, ErrNoValidatorSet
+	}
+
+	result, err := set.Aggregate(messageHash, attestations)
+	if err != nil {
+		return nil, err
+	}
+	for _, rejected := range result.Rejected {
+		s.logger.Printf("Rejected %s attestation from %s: %s", rejected.Scheme, rejected.ValidatorID, rejected.Reason)
+	}
+	s.logger.Printf("Aggregated %d BLS signatures: signed power %s of %s (threshold met: %v)",
+		len(result.BLSSigners), result.Proof.SignedVotingPower, result.Proof.TotalVotingPower, result.Proof.ThresholdMet)
+	return result, nil
+}
+
+// signBatchResult returns this validator's BLS attestation of a batch result, or nil
+// when no BLS key is configured
+func (s *Service) signBatchResult(batchID uuid.UUID, merkleRoot []byte, anchorTxHash string) (*anchor_proof.ValidatorAttestation, error) {
+	if s.blsKeys == nil || s.blsKeys.GetPublicKey() == nil {
+		return nil, nil
+	}
+	if batchID == uuid.Nil {
+		return nil, fmt.Errorf("batch ID is required")
+	}
+
+	hash := anchor_proof.BatchResultHash(batchID, merkleRoot, anchorTxHash)
+	sig, err := s.blsKeys.SignWithDomain(hash[:], bls.DomainResult)
+	if err != nil {
+		return nil, fmt.Errorf("sign batch result: %w", err)
+	}
+	return &anchor_proof.ValidatorAttestation{
+		AttestationID:      uuid.New(),
+		ValidatorID:        s.validatorID,
+		ValidatorPubkey:    s.blsKeys.GetPublicKeyBytes(),
+		AttestedMerkleRoot: merkleRoot,
+		AttestedAnchorTx:   anchorTxHash,
+		SignatureScheme:    anchor_proof.SignatureSchemeBLS12381,
+		BatchID:            batchID,
+		Signature:          sig.Bytes(),
+		AttestedAt:         time.Now(),
+	}, nil
+}
+
+// Aggregate verifies attestations over messageHash and aggregates the valid BLS signatures.
+// BLS attestations sign messageHash under DomainResult; the proof's MessageHash is the
+// resulting digest (bls.ComputeResultMessageHash), which the aggregate verifies against.
+func (vs *ValidatorSet) Aggregate(messageHash [32]byte, attestations []*strategy.Attestation) (*AggregationResult, error) {
+	result := &AggregationResult{}
+	reject := func(att *strategy.Attestation, reason string) {
+		result.Rejected = append(result.Rejected, RejectedAttestation{
+			ValidatorID: att.ValidatorID,
+			Scheme:      att.Scheme.String(),
+			Reason:      reason,
+		})
+	}
+
+	blsSignatures := make(map[string]*bls.Signature)
+	ed25519Seen := make(map[string]bool)
+	for _, att := range attestations {
+		if att == nil {
+			continue
+		}
+		validator, ok := vs.validators[att.ValidatorID]
+		if !ok {
+			reject(att, "validator not registered")
+			continue
+		}
+		if att.MessageHash != messageHash {
+			reject(att, "attestation is for a different message")
+			continue
+		}
+
+		switch att.Scheme {
+		case strategy.AttestationSchemeBLS12381:
+			if _, dup := blsSignatures[att.ValidatorID]; dup {
+				reject(att, "duplicate attestation")
+				continue
+			}
+			if validator.blsKey == nil {
+				reject(att, "no BLS public key registered")
+				continue
+			}
+			sig, err := bls.SignatureFromBytes(att.Signature)
+			if err != nil {
+				reject(att, fmt.Sprintf("invalid signature: %v", err))
+				continue
+			}
+			if !validator.blsKey.VerifyWithDomain(sig, messageHash[:], bls.DomainResult) {
+				reject(att, "signature verification failed")
+				continue
+			}
+			blsSignatures[att.ValidatorID] = sig
+
+		case strategy.AttestationSchemeEd25519:
+			if ed25519Seen[att.ValidatorID] {
+				reject(att, "duplicate attestation")
+				continue
+			}
+			if len(validator.Ed25519PublicKey) == 0 {
+				reject(att, "no Ed25519 public key registered")
+				continue
+			}
+			message := strategy.Ed25519DomainMessage(strategy.Ed25519DomainResult, messageHash[:])
+			if len(att.Signature) != ed25519.SignatureSize || !ed25519.Verify(validator.Ed25519PublicKey, message, att.Signature) {
+				reject(att, "signature verification failed")
+				continue
+			}
+			ed25519Seen[att.ValidatorID] = true
+
+		default:
+			reject(att, "unsupported scheme")
+		}
+	}
+
+	if len(blsSignatures) == 0 {
+		return nil, ErrNoBLSSignatures
+	}
+
+	// Validators are listed in registration order so the proof is deterministic
+	signatures := make([]*bls.Signature, 0, len(blsSignatures))
+	signed := new(big.Int)
+	for _, id := range vs.order {
+		validator := vs.validators[id]
+		if ed25519Seen[id] {
+			result.Ed25519Signers = append(result.Ed25519Signers, id)
+		}
+		sig, ok := blsSignatures[id]
+		if !ok {
+			continue
+		}
+		signatures = append(signatures, sig)
+		result.BLSSigners = append(result.BLSSigners, id)
+		result.Proof.ValidatorAddresses = append(result.Proof.ValidatorAddresses, validator.Address)
+		result.Proof.VotingPowers = append(result.Proof.VotingPowers, new(big.Int).Set(validator.VotingPower))
+		signed.Add(signed, validator.VotingPower)
+	}
+
+	aggregate, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate signatures: %w", err)
+	}
+
+	result.Proof.AggregateSignature = aggregate.Bytes()
+	result.Proof.TotalVotingPower = vs.TotalVotingPower()
+	result.Proof.SignedVotingPower = signed
+	result.Proof.ThresholdMet = vs.ThresholdMet(signed)
+	// The contract passes messageHash unchanged to the BLS verifier, so it carries the
+	// digest that was signed rather than the attested message
+	result.Proof.MessageHash = bls.ComputeResultMessageHash(messageHash)
+	return result, nil
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Attestation Aggregation
+// Tests verifying attestations against registered keys and building the on-chain BLS proof
+
+package attestation
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/anchor_proof"
+	"github.com/certen/independant-validator/pkg/attestation/strategy"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+)
+
+type testValidator struct {
+	id       string
+	blsKey   *bls.PrivateKey
+	edKey    ed25519.PrivateKey
+	register RegisteredValidator
+}
+
+func newTestValidators(t *testing.T, n int) []*testValidator {
+	t.Helper()
+	validators := make([]*testValidator, n)
+	for i := range validators {
+		sk, pk, err := bls.GenerateKeyPair()
+		if err != nil {
+			t.Fatalf("generate BLS key: %v", err)
+		}
+		edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("generate Ed25519 key: %v", err)
+		}
+		id := fmt.Sprintf("validator-%d", i+1)
+		validators[i] = &testValidator{
+			id:     id,
+			blsKey: sk,
+			edKey:  edKey,
+			register: RegisteredValidator{
+				ValidatorID:      id,
+				Address:          common.BigToAddress(big.NewInt(int64(i + 1))),
+				VotingPower:      big.NewInt(10),
+				BLSPublicKey:     pk.Bytes(),
+				Ed25519PublicKey: edPub,
+			},
+		}
+	}
+	return validators
+}
+
+func newTestValidatorSet(t *testing.T, validators []*testValidator, numerator, denominator uint64) *ValidatorSet {
+	t.Helper()
+	registered := make([]RegisteredValidator, len(validators))
+	for i, v := range validators {
+		registered[i] = v.register
+	}
+	set, err := NewValidatorSet(registered, numerator, denominator)
+	if err != nil {
+		t.Fatalf("NewValidatorSet failed: %v", err)
+	}
+	return set
+}
+
+func (v *testValidator) blsAttestation(hash [32]byte) *strategy.Attestation {
+	return &strategy.Attestation{
+		Scheme:      strategy.AttestationSchemeBLS12381,
+		ValidatorID: v.id,
+		Signature:   v.blsKey.SignWithDomain(hash[:], bls.DomainResult).Bytes(),
+		MessageHash: hash,
+	}
+}
+
+func (v *testValidator) ed25519Attestation(hash [32]byte) *strategy.Attestation {
+	message := strategy.Ed25519DomainMessage(strategy.Ed25519DomainResult, hash[:])
+	return &strategy.Attestation{
+		Scheme:      strategy.AttestationSchemeEd25519,
+		ValidatorID: v.id,
+		Signature:   ed25519.Sign(v.edKey, message),
+		MessageHash: hash,
+	}
+}
+
+func TestAggregate_ThreeOfFour(t *testing.T) {
+	validators := newTestValidators(t, 4)
+	set := newTestValidatorSet(t, validators, 3, 4)
+	hash := [32]byte{0x01}
+
+	// Three signers reach 3/4; a duplicate does not count twice
+	atts := []*strategy.Attestation{
+		validators[0].blsAttestation(hash),
+		validators[2].blsAttestation(hash),
+		validators[1].blsAttestation(hash),
+		validators[0].blsAttestation(hash),
+	}
+	result, err := set.Aggregate(hash, atts)
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	proof := result.Proof
+	if !proof.ThresholdMet || proof.SignedVotingPower.Int64() != 30 || proof.TotalVotingPower.Int64() != 40 {
+		t.Errorf("expected 30/40 with threshold met, got %s/%s met=%v", proof.SignedVotingPower, proof.TotalVotingPower, proof.ThresholdMet)
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0].Reason != "duplicate attestation" {
+		t.Errorf("expected the duplicate to be rejected, got %+v", result.Rejected)
+	}
+	if len(proof.ValidatorAddresses) != 3 || proof.ValidatorAddresses[1] != validators[1].register.Address {
+		t.Errorf("expected signers in registration order, got %v", proof.ValidatorAddresses)
+	}
+	if proof.MessageHash != bls.ComputeResultMessageHash(hash) {
+		t.Error("proof message hash is not the signed digest of the message")
+	}
+
+	// The aggregate signature verifies against the signers' aggregated public key
+	var keys []*bls.PublicKey
+	for _, v := range validators[:3] {
+		keys = append(keys, v.blsKey.PublicKey())
+	}
+	aggKey, err := bls.AggregatePublicKeys(keys)
+	if err != nil {
+		t.Fatalf("AggregatePublicKeys failed: %v", err)
+	}
+	sig, err := bls.SignatureFromBytes(proof.AggregateSignature)
+	if err != nil || !aggKey.VerifyWithDomain(sig, hash[:], bls.DomainResult) {
+		t.Errorf("aggregate signature does not verify (err %v)", err)
+	}
+
+	// Two signers fall short of 3/4
+	result, err = set.Aggregate(hash, atts[:2])
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if result.Proof.ThresholdMet || result.Proof.SignedVotingPower.Int64() != 20 {
+		t.Errorf("expected 20/40 below threshold, got %s met=%v", result.Proof.SignedVotingPower, result.Proof.ThresholdMet)
+	}
+}
+
+func TestAggregate_FourOfSeven(t *testing.T) {
+	validators := newTestValidators(t, 7)
+	set := newTestValidatorSet(t, validators, 4, 7)
+	hash := [32]byte{0x02}
+
+	forged := validators[4].blsAttestation(hash)
+	forged.ValidatorID = validators[5].id // Signed with another validator's key
+
+	outsider := newTestValidators(t, 1)[0]
+	outsider.id = "validator-x"
+
+	atts := []*strategy.Attestation{
+		validators[0].blsAttestation(hash),
+		validators[1].blsAttestation(hash),
+		validators[2].blsAttestation(hash),
+		forged,
+		outsider.blsAttestation(hash),
+		validators[6].blsAttestation([32]byte{0x03}),
+		validators[3].ed25519Attestation(hash),
+	}
+	result, err := set.Aggregate(hash, atts)
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if result.Proof.ThresholdMet || result.Proof.SignedVotingPower.Int64() != 30 {
+		t.Errorf("expected 30/70 below 4/7, got %s met=%v", result.Proof.SignedVotingPower, result.Proof.ThresholdMet)
+	}
+	if len(result.Rejected) != 3 {
+		t.Errorf("expected forged, unregistered and wrong-message attestations rejected, got %+v", result.Rejected)
+	}
+	if len(result.Ed25519Signers) != 1 || result.Ed25519Signers[0] != validators[3].id {
+		t.Errorf("expected verified Ed25519 signer, got %v", result.Ed25519Signers)
+	}
+
+	// A fourth BLS signer reaches 4/7
+	atts = append(atts, validators[3].blsAttestation(hash))
+	result, err = set.Aggregate(hash, atts)
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if !result.Proof.ThresholdMet || result.Proof.SignedVotingPower.Int64() != 40 || result.Proof.TotalVotingPower.Int64() != 70 {
+		t.Errorf("expected 40/70 with threshold met, got %s/%s met=%v",
+			result.Proof.SignedVotingPower, result.Proof.TotalVotingPower, result.Proof.ThresholdMet)
+	}
+	if len(result.Proof.VotingPowers) != 4 {
+		t.Errorf("expected 4 voting powers, got %d", len(result.Proof.VotingPowers))
+	}
+}
+
+func TestAggregate_VerifiesAgainstContractMessageHash(t *testing.T) {
+	validators := newTestValidators(t, 3)
+	batchID := uuid.New()
+	root := make([]byte, 32)
+	root[0] = 0x09
+
+	// Each validator signs the batch result the way the attestation service does
+	var atts []*strategy.Attestation
+	var keys []*bls.PublicKey
+	for _, v := range validators {
+		blsKeys := bls.NewKeyManager("")
+		if err := blsKeys.GenerateFromValidatorID(v.id, "test"); err != nil {
+			t.Fatalf("GenerateFromValidatorID: %v", err)
+		}
+		v.register.BLSPublicKey = blsKeys.GetPublicKeyBytes()
+		keys = append(keys, blsKeys.GetPublicKey())
+
+		svc, err := NewService(nil, &Config{ValidatorID: v.id, PrivateKey: v.edKey, BLSKeys: blsKeys, Logger: log.New(io.Discard, "", 0)})
+		if err != nil {
+			t.Fatalf("NewService: %v", err)
+		}
+		att, err := svc.signBatchResult(batchID, root, "0xanchor")
+		if err != nil {
+			t.Fatalf("signBatchResult: %v", err)
+		}
+		atts = append(atts, &strategy.Attestation{
+			Scheme:      strategy.AttestationSchemeBLS12381,
+			ValidatorID: att.ValidatorID,
+			Signature:   att.Signature,
+			MessageHash: anchor_proof.BatchResultHash(batchID, root, "0xanchor"),
+		})
+	}
+
+	hash := anchor_proof.BatchResultHash(batchID, root, "0xanchor")
+	result, err := newTestValidatorSet(t, validators, 0, 0).Aggregate(hash, atts)
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+
+	// The contract's BLS verifier checks the aggregate against the proof's messageHash as is
+	sig, err := bls.SignatureFromBytes(result.Proof.AggregateSignature)
+	if err != nil {
+		t.Fatalf("parse aggregate signature: %v", err)
+	}
+	if !bls.VerifyAggregateSignature(sig, keys, result.Proof.MessageHash[:]) {
+		t.Error("aggregate signature does not verify against the proof message hash")
+	}
+	if result.Proof.MessageHash != bls.ComputeResultMessageHash(hash) {
+		t.Error("proof message hash is not derived from the batch result hash")
+	}
+}
+
+func TestAggregateAttestations_Service(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	cfg := DefaultConfig()
+	cfg.ValidatorID = "validator-1"
+	cfg.PrivateKey = key
+	svc, err := NewService(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewService failed: %v", err)
+	}
+
+	hash := [32]byte{0x04}
+	if _, err := svc.AggregateAttestations(hash, nil); !errors.Is(err, ErrNoValidatorSet) {
+		t.Errorf("expected ErrNoValidatorSet, got %v", err)
+	}
+
+	validators := newTestValidators(t, 3)
+	svc.SetValidatorSet(newTestValidatorSet(t, validators, 0, 0))
+	if _, err := svc.AggregateAttestations(hash, []*strategy.Attestation{validators[0].ed25519Attestation(hash)}); !errors.Is(err, ErrNoBLSSignatures) {
+		t.Errorf("expected ErrNoBLSSignatures, got %v", err)
+	}
+
+	// Default threshold is the contract's 2/3
+	result, err := svc.AggregateAttestations(hash, []*strategy.Attestation{
+		validators[0].blsAttestation(hash),
+		validators[1].blsAttestation(hash),
+	})
+	if err != nil || !result.Proof.ThresholdMet {
+		t.Errorf("expected 2 of 3 to meet 2/3, got %+v (err %v)", result, err)
+	}
+
+	if _, err := NewValidatorSet([]RegisteredValidator{validators[0].register, validators[0].register}, 0, 0); err == nil {
+		t.Error("expected error for a validator registered twice")
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 Certen Protocol
+//
+// Peer Health - Skipping unresponsive attestation peers
+//
+// A peer that is down makes every attestation request wait for the full request
+// timeout. The health check pings every peer periodically:
+//   - a peer that fails MaxFailures consecutive checks is marked degraded and is not
+//     asked for attestations
+//   - a degraded peer that answers a check again is healthy and rejoins collection
+//   - the required attestation count is unchanged, so degraded peers still count
+//     toward the validator set the threshold was chosen for
+
+package attestation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// PeerState is the health of an attestation peer
+type PeerState string
+
+const (
+	PeerStateUnknown  PeerState = "unknown"  // Not checked yet; still asked for attestations
+	PeerStateHealthy  PeerState = "healthy"  // Answered the last check
+	PeerStateDegraded PeerState = "degraded" // Failed MaxFailures consecutive checks; skipped
+)
+
+// PeerHealthConfig configures periodic peer health checks
+type PeerHealthConfig struct {
+	Interval    time.Duration // Time between checks (0 = 15s)
+	Timeout     time.Duration // Timeout of a single check (0 = 5s)
+	MaxFailures int           // Consecutive failures before a peer is degraded (0 = 2)
+}
+
+// PeerHealth is the health of one attestation peer
+type PeerHealth struct {
+	Endpoint            string     `json:"endpoint"`
+	State               PeerState  `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastCheck           *time.Time `json:"last_check,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LatencyMs           int64      `json:"latency_ms,omitempty"`
+}
+
+// peerHealthChecker holds the health check configuration and per-peer state
+type peerHealthChecker struct {
+	config  PeerHealthConfig
+	peers   map[string]*PeerHealth
+	running bool
+	cancel  context.CancelFunc
+}
+
+// withDefaults fills unset fields
+func (c PeerHealthConfig) withDefaults() PeerHealthConfig {
+	if c.Interval <= 0 {
+		c.Interval = 15 * time.Second
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 5 * time.Second
+	}
+	if c.MaxFailures <= 0 {
+		c.MaxFailures = 2
+	}
+	return c
+}
+
+// peerHealthLocked returns the health entry for a peer, creating it; the caller holds s.mu
+func (s *Service) peerHealthLocked(endpoint string) *PeerHealth {
+	health, ok := s.health.peers[endpoint]
+	if !ok {
+		health = &PeerHealth{Endpoint: endpoint, State: PeerStateUnknown}
+		s.health.peers[endpoint] = health
+	}
+	return health
+}
+
+// activePeers returns the peers to ask for attestations and the number of degraded
+// peers skipped
+func (s *Service) activePeers() ([]string, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	active := make([]string, 0, len(s.peerEndpoints))
+	skipped := 0
+	for _, peer := range s.peerEndpoints {
+		if health, ok := s.health.peers[peer]; ok && health.State == PeerStateDegraded {
+			skipped++
+			continue
+		}
+		active = append(active, peer)
+	}
+	return active, skipped
+}
+
+// CheckPeers pings every peer once and updates its health
+func (s *Service) CheckPeers(ctx context.Context) {
+	s.mu.RLock()
+	peers := append([]string(nil), s.peerEndpoints...)
+	config := s.health.config.withDefaults()
+	s.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		wg.Add(1)
+		go func(peerURL string) {
+			defer wg.Done()
+			start := time.Now()
+			err := s.pingPeer(ctx, peerURL, config.Timeout)
+			s.recordPeerCheck(peerURL, start, time.Since(start), err, config.MaxFailures)
+		}(peer)
+	}
+	wg.Wait()
+}
+
+// pingPeer checks that a peer's attestation API answers
+func (s *Service) pingPeer(ctx context.Context, peerURL string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/api/attestations/peers", peerURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Validator-ID", s.validatorID)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("peer returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// recordPeerCheck applies a check result to the peer's health
+func (s *Service) recordPeerCheck(peerURL string, checkedAt time.Time, latency time.Duration, err error, maxFailures int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The peer may have been removed while it was being checked
+	if !containsPeer(s.peerEndpoints, peerURL) {
+		return
+	}
+	health := s.peerHealthLocked(peerURL)
+	health.LastCheck = &checkedAt
+	previous := health.State
+
+	if err != nil {
+		health.ConsecutiveFailures++
+		health.LastError = err.Error()
+		if health.ConsecutiveFailures >= maxFailures {
+			health.State = PeerStateDegraded
+		}
+		if health.State == PeerStateDegraded && previous != PeerStateDegraded {
+			s.logger.Printf("⚠️ Attestation peer %s degraded after %d failed checks: %v", peerURL, health.ConsecutiveFailures, err)
+		}
+		return
+	}
+
+	health.ConsecutiveFailures = 0
+	health.LastError = ""
+	health.LastSuccess = &checkedAt
+	health.LatencyMs = latency.Milliseconds()
+	health.State = PeerStateHealthy
+	if previous == PeerStateDegraded {
+		s.logger.Printf("✅ Attestation peer %s recovered", peerURL)
+	}
+}
+
+// containsPeer reports whether peers contains peer
+func containsPeer(peers []string, peer string) bool {
+	for _, p := range peers {
+		if p == peer {
+			return true
+		}
+	}
+	return false
+}
+
+// StartHealthChecks checks every peer now and then periodically until StopHealthChecks
+func (s *Service) StartHealthChecks(ctx context.Context) error {
+	s.mu.Lock()
+	if s.health.running {
+		s.mu.Unlock()
+		return fmt.Errorf("peer health checks already running")
+	}
+	ctx, s.health.cancel = context.WithCancel(ctx)
+	s.health.running = true
+	config := s.health.config.withDefaults()
+	s.mu.Unlock()
+
+	s.logger.Printf("🩺 Starting attestation peer health checks (interval: %v, max failures: %d)", config.Interval, config.MaxFailures)
+	s.CheckPeers(ctx)
+
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.CheckPeers(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// StopHealthChecks halts periodic peer health checks
+func (s *Service) StopHealthChecks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.health.running {
+		return
+	}
+	s.health.cancel()
+	s.health.running = false
+}
+
+// PeerHealth returns the health of every configured peer
+func (s *Service) PeerHealth() []PeerHealth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]PeerHealth, 0, len(s.peerEndpoints))
+	for _, peer := range s.peerEndpoints {
+		if health, ok := s.health.peers[peer]; ok {
+			result = append(result, *health)
+		} else {
+			result = append(result, PeerHealth{Endpoint: peer, State: PeerStateUnknown})
+		}
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Peer Health
+// Tests degrading unresponsive attestation peers, their automatic recovery and
+// reloading the peer list while a collection is in progress
+
+package attestation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPeerHealth_DegradeAndRecover(t *testing.T) {
+	var down atomic.Bool
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer flaky.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/attestations/peers" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer healthy.Close()
+
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	cfg := DefaultConfig()
+	cfg.ValidatorID = "validator-1"
+	cfg.PrivateKey = key
+	cfg.PeerEndpoints = []string{healthy.URL, flaky.URL}
+	cfg.PeerHealth = PeerHealthConfig{Timeout: time.Second, MaxFailures: 2}
+	svc, err := NewService(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewService failed: %v", err)
+	}
+	ctx := context.Background()
+
+	// Unchecked peers are asked for attestations
+	if peers, skipped := svc.activePeers(); len(peers) != 2 || skipped != 0 {
+		t.Errorf("expected 2 active peers before checks, got %v (%d skipped)", peers, skipped)
+	}
+
+	// One failure is tolerated, the second degrades the peer
+	down.Store(true)
+	svc.CheckPeers(ctx)
+	if health := svc.PeerHealth(); health[1].State == PeerStateDegraded || health[1].ConsecutiveFailures != 1 {
+		t.Errorf("expected one tolerated failure, got %+v", health[1])
+	}
+	svc.CheckPeers(ctx)
+	health := svc.PeerHealth()
+	if health[0].State != PeerStateHealthy || health[1].State != PeerStateDegraded || health[1].LastError == "" {
+		t.Errorf("expected healthy and degraded peers, got %+v", health)
+	}
+	if peers, skipped := svc.activePeers(); len(peers) != 1 || peers[0] != healthy.URL || skipped != 1 {
+		t.Errorf("expected the degraded peer to be skipped, got %v (%d skipped)", peers, skipped)
+	}
+
+	// A recovered peer rejoins
+	down.Store(false)
+	svc.CheckPeers(ctx)
+	if health := svc.PeerHealth(); health[1].State != PeerStateHealthy || health[1].ConsecutiveFailures != 0 {
+		t.Errorf("expected recovered peer, got %+v", health[1])
+	}
+	if peers, _ := svc.activePeers(); len(peers) != 2 {
+		t.Errorf("expected 2 active peers after recovery, got %v", peers)
+	}
+
+	// Removed peers drop their health
+	svc.UpdatePeers([]string{healthy.URL})
+	if health := svc.PeerHealth(); len(health) != 1 || len(svc.health.peers) != 1 {
+		t.Errorf("expected health for the remaining peer only, got %+v", health)
+	}
+}
+
+func TestReloadPeers_KeepsInFlightCollection(t *testing.T) {
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	oldPeer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer oldPeer.Close()
+
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	cfg := DefaultConfig()
+	cfg.ValidatorID = "validator-1"
+	cfg.PrivateKey = key
+	cfg.PeerEndpoints = []string{oldPeer.URL}
+	cfg.RequiredCount = 2
+	svc, err := NewService(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewService failed: %v", err)
+	}
+
+	type result struct {
+		status *AttestationStatus
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		status, err := svc.RequestAttestations(context.Background(), &AttestationRequest{
+			RequestID:    uuid.New(),
+			ProofID:      uuid.New(),
+			BatchID:      uuid.New(),
+			MerkleRoot:   make([]byte, 32),
+			AnchorTxHash: "0xabc",
+		})
+		done <- result{status, err}
+	}()
+	<-requested
+
+	// Swap in four new peers with a derived required count while the old peer is still answering
+	newPeers := []string{"http://validator-2:8080", "http://validator-3:8080", "http://validator-4:8080", "http://validator-5:8080"}
+	reload := svc.ReloadPeers(newPeers, 0)
+	if reload.RequiredCount != 3 || !reload.Derived {
+		t.Errorf("expected 2f+1 = 3 derived for 5 validators, got %+v", reload)
+	}
+	if len(reload.Added) != 4 || len(reload.Removed) != 1 || reload.Removed[0] != oldPeer.URL {
+		t.Errorf("unexpected peer changes %+v", reload)
+	}
+	close(release)
+
+	res := <-done
+	if res.err != nil {
+		t.Fatalf("RequestAttestations failed: %v", res.err)
+	}
+	if res.status.RequiredCount != 2 || res.status.CollectedCount != 1 {
+		t.Errorf("in-flight collection should keep its required count of 2, got %+v", res.status)
+	}
+	if settings := svc.Settings(); settings.RequiredCount != 3 || len(settings.PeerEndpoints) != 4 {
+		t.Errorf("later collections should use the reloaded settings, got %+v", settings)
+	}
+
+	// An explicit required count is kept as given
+	if reload := svc.ReloadPeers(newPeers[:1], 2); reload.RequiredCount != 2 || reload.Derived {
+		t.Errorf("expected the explicit required count, got %+v", reload)
+	}
+}
+
+func TestQuorumForValidators(t *testing.T) {
+	for n, want := range map[int]int{0: 1, 1: 1, 3: 1, 4: 3, 6: 3, 7: 5, 10: 7} {
+		if got := QuorumForValidators(n); got != want {
+			t.Errorf("QuorumForValidators(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
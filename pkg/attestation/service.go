@@ -17,17 +17,22 @@ import (
 	"context"
 	"crypto/ed25519"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/certen/independant-validator/pkg/anchor_proof"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
 	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/keys"
+	"github.com/certen/independant-validator/pkg/metrics"
 )
 
 // Service manages multi-validator attestation collection
@@ -38,17 +43,34 @@ type Service struct {
 	repos  *database.Repositories
 	signer *anchor_proof.AttestationSigner
 
+	// BLS key signing the batch result for the anchor contract (nil: Ed25519 only)
+	blsKeys *bls.KeyManager
+
 	// Configuration
 	validatorID   string
 	peerEndpoints []string // URLs of peer validators (e.g., "http://validator-2:8080")
 	requiredCount int      // Required attestations for consensus (typically 2f+1)
+	thresholdMode string   // ThresholdModeCount or ThresholdModeVotingPower
 	timeout       time.Duration
 
 	// Pending attestation bundles (proofID -> bundle)
 	bundles map[uuid.UUID]*anchor_proof.AttestationBundle
 
-	// HTTP client for peer communication
+	// Registered validators for BLS aggregation (nil until SetValidatorSet)
+	validatorSet *ValidatorSet
+
+	// Ed25519 keys of peers whose attestations are accepted, and rejection counts by reason
+	peerKeys map[string]ed25519.PublicKey
+	nonces   map[string]*nonceWindow // Validator ID -> recently seen attestation nonces
+	rejected map[string]uint64
+	metrics  *metrics.Registry
+
+	// Peer health (degraded peers are skipped during collection)
+	health peerHealthChecker
+
+	// HTTP client for peer communication (mTLS when tlsEnabled)
 	httpClient *http.Client
+	tlsEnabled bool
 
 	// Logging
 	logger *log.Logger
@@ -58,9 +80,16 @@ type Service struct {
 type Config struct {
 	ValidatorID     string
 	PrivateKey      ed25519.PrivateKey
+	Signer          keys.KeyProvider // Signs attestations in place of PrivateKey (KMS or HSM backed keys)
 	PeerEndpoints   []string
-	RequiredCount   int // Number of attestations required (e.g., 3 for 4 validators with f=1)
+	RequiredCount   int // Number of attestations required (e.g., 3 for 4 validators with f=1); 0 derives 2f+1
 	Timeout         time.Duration
+	PeerHealth      PeerHealthConfig // Periodic peer health checks (see StartHealthChecks)
+	TLS             TLSConfig        // mTLS between validators (plain HTTP when unset)
+	PeerKeys        map[string]ed25519.PublicKey // Validator ID -> Ed25519 key of accepted peers
+	Metrics         *metrics.Registry            // Rejected attestation counter (nil disables)
+	ThresholdMode   string                       // ThresholdModeCount (default) or ThresholdModeVotingPower
+	BLSKeys         *bls.KeyManager              // BLS attestations for the anchor contract (nil disables)
 	Logger          *log.Logger
 }
 
@@ -83,23 +112,74 @@ func NewService(repos *database.Repositories, cfg *Config) (*Service, error) {
 	}
 
 	// Create signer
-	signer, err := anchor_proof.NewAttestationSigner(cfg.ValidatorID, cfg.PrivateKey)
+	var signer *anchor_proof.AttestationSigner
+	var err error
+	if cfg.Signer != nil {
+		signer, err = anchor_proof.NewAttestationSignerFromProvider(cfg.ValidatorID, cfg.Signer)
+	} else {
+		signer, err = anchor_proof.NewAttestationSigner(cfg.ValidatorID, cfg.PrivateKey)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signer: %w", err)
 	}
 
+	httpClient := &http.Client{
+		Timeout: cfg.Timeout,
+	}
+	if cfg.TLS.Enabled() {
+		tlsConfig, err := cfg.TLS.ClientTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		for _, peer := range cfg.PeerEndpoints {
+			if !strings.HasPrefix(peer, "https://") {
+				cfg.Logger.Printf("⚠️ Attestation peer %s is not an https:// endpoint - requests to it are not authenticated", peer)
+			}
+		}
+	} else if len(cfg.PeerEndpoints) > 0 {
+		cfg.Logger.Printf("⚠️ No attestation TLS configured - peer requests use plain HTTP without authentication")
+	}
+	if len(cfg.PeerEndpoints) > 0 && len(cfg.PeerKeys) == 0 {
+		cfg.Logger.Printf("⚠️ No attestation peer keys configured - attestations from peers will be rejected")
+	}
+	peerKeys := make(map[string]ed25519.PublicKey, len(cfg.PeerKeys))
+	for id, key := range cfg.PeerKeys {
+		peerKeys[id] = key
+	}
+
+	thresholdMode, err := parseThresholdMode(cfg.ThresholdMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Zero derives 2f+1 from the configured validators, as ReloadPeers does
+	requiredCount := cfg.RequiredCount
+	if requiredCount <= 0 {
+		requiredCount = QuorumForValidators(len(cfg.PeerEndpoints) + 1)
+	}
+
 	return &Service{
 		repos:         repos,
 		signer:        signer,
+		blsKeys:       cfg.BLSKeys,
 		validatorID:   cfg.ValidatorID,
 		peerEndpoints: cfg.PeerEndpoints,
-		requiredCount: cfg.RequiredCount,
+		requiredCount: requiredCount,
+		thresholdMode: thresholdMode,
 		timeout:       cfg.Timeout,
 		bundles:       make(map[uuid.UUID]*anchor_proof.AttestationBundle),
-		httpClient: &http.Client{
-			Timeout: cfg.Timeout,
+		peerKeys:      peerKeys,
+		nonces:        make(map[string]*nonceWindow),
+		rejected:      make(map[string]uint64),
+		metrics:       cfg.Metrics,
+		health: peerHealthChecker{
+			config: cfg.PeerHealth,
+			peers:  make(map[string]*PeerHealth),
 		},
-		logger: cfg.Logger,
+		httpClient: httpClient,
+		tlsEnabled: cfg.TLS.Enabled(),
+		logger:     cfg.Logger,
 	}, nil
 }
 
@@ -136,6 +216,9 @@ type AttestationResponse struct {
 	Success     bool                            `json:"success"`
 	Error       string                          `json:"error,omitempty"`
 	Attestation *anchor_proof.ValidatorAttestation `json:"attestation,omitempty"`
+
+	// BLS attestation of the batch result, when the peer has a BLS key
+	BLSAttestation *anchor_proof.ValidatorAttestation `json:"bls_attestation,omitempty"`
 }
 
 // AttestationStatus tracks the collection status for a proof
@@ -148,6 +231,11 @@ type AttestationStatus struct {
 	IsSufficient   bool      `json:"is_sufficient"`
 	Validators     []string  `json:"validators"` // Validator IDs who have attested
 	StartedAt      time.Time `json:"started_at"`
+
+	// Voting power mode: power of the BLS signers and of the registered validator set
+	ThresholdMode     string `json:"threshold_mode"`
+	SignedVotingPower string `json:"signed_voting_power,omitempty"`
+	TotalVotingPower  string `json:"total_voting_power,omitempty"`
 }
 
 // =============================================================================
@@ -168,14 +256,20 @@ func (s *Service) RequestAttestations(ctx context.Context, req *AttestationReque
 			req.AnchorTxHash,
 			s.requiredCount,
 		)
+		bundle.BatchID = req.BatchID
 		s.bundles[req.ProofID] = bundle
 	}
 	s.mu.Unlock()
 
-	s.logger.Printf("Requesting attestations from %d peers for proof %s", len(s.peerEndpoints), req.ProofID)
+	peers, skipped := s.activePeers()
+	if skipped > 0 {
+		s.logger.Printf("Requesting attestations from %d peers for proof %s (%d degraded peers skipped)", len(peers), req.ProofID, skipped)
+	} else {
+		s.logger.Printf("Requesting attestations from %d peers for proof %s", len(peers), req.ProofID)
+	}
 
 	// First, add our own attestation
-	ownAttestation, err := s.signer.SignMerkleRoot(req.MerkleRoot, req.AnchorTxHash)
+	ownAttestation, err := s.signer.SignBatch(req.BatchID, req.MerkleRoot, req.AnchorTxHash)
 	if err != nil {
 		s.logger.Printf("Failed to create own attestation: %v", err)
 	} else {
@@ -185,6 +279,7 @@ func (s *Service) RequestAttestations(ctx context.Context, req *AttestationReque
 		} else {
 			s.logger.Printf("Added own attestation to bundle")
 		}
+		s.updateSufficiencyLocked(bundle)
 		s.mu.Unlock()
 
 		// Store own attestation in database
@@ -192,12 +287,22 @@ func (s *Service) RequestAttestations(ctx context.Context, req *AttestationReque
 			s.storeAttestation(ctx, req.ProofID, ownAttestation)
 		}
 	}
+	if ownBLS, err := s.signBatchResult(req.BatchID, req.MerkleRoot, req.AnchorTxHash); err != nil {
+		s.logger.Printf("Failed to create own BLS attestation: %v", err)
+	} else if ownBLS != nil {
+		s.mu.Lock()
+		if err := bundle.AddBLSAttestation(ownBLS); err != nil {
+			s.logger.Printf("Failed to add own BLS attestation: %v", err)
+		}
+		s.updateSufficiencyLocked(bundle)
+		s.mu.Unlock()
+	}
 
 	// Request attestations from peers in parallel
 	var wg sync.WaitGroup
-	responses := make(chan *AttestationResponse, len(s.peerEndpoints))
+	responses := make(chan *AttestationResponse, len(peers))
 
-	for _, peer := range s.peerEndpoints {
+	for _, peer := range peers {
 		wg.Add(1)
 		go func(peerURL string) {
 			defer wg.Done()
@@ -221,20 +326,23 @@ func (s *Service) RequestAttestations(ctx context.Context, req *AttestationReque
 		close(responses)
 	}()
 
-	// Collect responses
+	// Collect responses; each attestation is verified against its validator's registered key
 	for resp := range responses {
 		if resp.Success && resp.Attestation != nil {
-			s.mu.Lock()
-			if err := bundle.AddAttestation(resp.Attestation); err != nil {
-				s.logger.Printf("Failed to add attestation: %v", err)
-			} else {
-				s.logger.Printf("Added attestation from %s", resp.Attestation.ValidatorID)
-				// Store in database
-				if s.repos != nil {
-					s.storeAttestation(ctx, req.ProofID, resp.Attestation)
+			if err := s.OnAttestationReceived(ctx, req.ProofID, resp.Attestation); err != nil {
+				if !errors.Is(err, ErrAttestationRejected) {
+					s.logger.Printf("Failed to add attestation: %v", err)
+				}
+				continue
+			}
+			s.logger.Printf("Added attestation from %s", resp.Attestation.ValidatorID)
+
+			// Only accepted once the peer is authenticated by its Ed25519 attestation
+			if resp.BLSAttestation != nil {
+				if err := s.OnAttestationReceived(ctx, req.ProofID, resp.BLSAttestation); err != nil && !errors.Is(err, ErrAttestationRejected) {
+					s.logger.Printf("Failed to add BLS attestation: %v", err)
 				}
 			}
-			s.mu.Unlock()
 		}
 	}
 
@@ -242,16 +350,7 @@ func (s *Service) RequestAttestations(ctx context.Context, req *AttestationReque
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return &AttestationStatus{
-		ProofID:        req.ProofID,
-		MerkleRoot:     fmt.Sprintf("%x", req.MerkleRoot),
-		AnchorTxHash:   req.AnchorTxHash,
-		RequiredCount:  s.requiredCount,
-		CollectedCount: bundle.ValidCount,
-		IsSufficient:   bundle.IsSufficient,
-		Validators:     bundle.GetValidatorIDs(),
-		StartedAt:      bundle.CreatedAt,
-	}, nil
+	return s.statusLocked(req.ProofID, bundle), nil
 }
 
 // requestFromPeer sends an attestation request to a single peer
@@ -324,14 +423,22 @@ func (s *Service) HandleAttestationRequest(ctx context.Context, req *Attestation
 		}, nil
 	}
 
+	if req.BatchID == uuid.Nil {
+		return &AttestationResponse{
+			RequestID: req.RequestID,
+			Success:   false,
+			Error:     "batch ID is required",
+		}, nil
+	}
+
 	// TODO: Add additional validation:
 	// - Verify the anchor tx exists on-chain
 	// - Verify the merkle root matches our calculation
 	// - Verify we have seen the transactions in the batch
 	// For now, we trust the requesting validator (they are in our peer list)
 
-	// Create our attestation
-	attestation, err := s.signer.SignMerkleRoot(req.MerkleRoot, req.AnchorTxHash)
+	// Create our attestation, bound to the batch with a fresh nonce
+	attestation, err := s.signer.SignBatch(req.BatchID, req.MerkleRoot, req.AnchorTxHash)
 	if err != nil {
 		return &AttestationResponse{
 			RequestID: req.RequestID,
@@ -347,10 +454,17 @@ func (s *Service) HandleAttestationRequest(ctx context.Context, req *Attestation
 		s.storeAttestation(ctx, req.ProofID, attestation)
 	}
 
+	// The BLS attestation is optional: without it the peer still counts us as attested
+	blsAttestation, err := s.signBatchResult(req.BatchID, req.MerkleRoot, req.AnchorTxHash)
+	if err != nil {
+		s.logger.Printf("Failed to create BLS attestation for proof %s: %v", req.ProofID, err)
+	}
+
 	return &AttestationResponse{
-		RequestID:   req.RequestID,
-		Success:     true,
-		Attestation: attestation,
+		RequestID:      req.RequestID,
+		Success:        true,
+		Attestation:    attestation,
+		BLSAttestation: blsAttestation,
 	}, nil
 }
 
@@ -379,6 +493,35 @@ func (s *Service) storeAttestation(ctx context.Context, proofID uuid.UUID, att *
 // Status and Bundle Management
 // =============================================================================
 
+// ServiceSettings is the service's effective configuration
+type ServiceSettings struct {
+	ValidatorID   string   `json:"validator_id"`
+	PeerEndpoints []string `json:"peer_endpoints"`
+	RequiredCount int      `json:"required_count"`
+	ThresholdMode string   `json:"threshold_mode"`
+	Timeout       string   `json:"timeout"`
+	MutualTLS     bool     `json:"mutual_tls"`
+}
+
+// Settings returns the service's effective configuration
+func (s *Service) Settings() ServiceSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ServiceSettings{
+		ValidatorID:   s.validatorID,
+		PeerEndpoints: append([]string(nil), s.peerEndpoints...),
+		RequiredCount: s.requiredCount,
+		ThresholdMode: s.thresholdMode,
+		Timeout:       s.timeout.String(),
+		MutualTLS:     s.tlsEnabled,
+	}
+}
+
+// MutualTLS reports whether validators authenticate each other with certificates
+func (s *Service) MutualTLS() bool {
+	return s.tlsEnabled
+}
+
 // GetAttestationStatus returns the current status of attestation collection for a proof
 func (s *Service) GetAttestationStatus(proofID uuid.UUID) *AttestationStatus {
 	s.mu.RLock()
@@ -388,8 +531,12 @@ func (s *Service) GetAttestationStatus(proofID uuid.UUID) *AttestationStatus {
 	if !exists {
 		return nil
 	}
+	return s.statusLocked(proofID, bundle)
+}
 
-	return &AttestationStatus{
+// statusLocked returns the collection status of a bundle. Callers must hold s.mu.
+func (s *Service) statusLocked(proofID uuid.UUID, bundle *anchor_proof.AttestationBundle) *AttestationStatus {
+	status := &AttestationStatus{
 		ProofID:        proofID,
 		MerkleRoot:     fmt.Sprintf("%x", bundle.MerkleRoot),
 		AnchorTxHash:   bundle.AnchorTxHash,
@@ -398,7 +545,13 @@ func (s *Service) GetAttestationStatus(proofID uuid.UUID) *AttestationStatus {
 		IsSufficient:   bundle.IsSufficient,
 		Validators:     bundle.GetValidatorIDs(),
 		StartedAt:      bundle.CreatedAt,
+		ThresholdMode:  s.thresholdMode,
 	}
+	if s.thresholdMode == ThresholdModeVotingPower && s.validatorSet != nil {
+		status.SignedVotingPower = s.signedVotingPowerLocked(bundle).String()
+		status.TotalVotingPower = s.validatorSet.TotalVotingPower().String()
+	}
+	return status
 }
 
 // GetBundle returns the attestation bundle for a proof
@@ -437,10 +590,79 @@ func (s *Service) CleanupOldBundles(maxAge time.Duration) int {
 func (s *Service) UpdatePeers(peers []string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.peerEndpoints = peers
+	s.setPeersLocked(peers)
 	s.logger.Printf("Updated peer list: %v", peers)
 }
 
+// PeerReloadResult describes the peer list after ReloadPeers
+type PeerReloadResult struct {
+	Peers         []string `json:"peers"`
+	Added         []string `json:"added"`
+	Removed       []string `json:"removed"`
+	RequiredCount int      `json:"required_count"`
+	Derived       bool     `json:"required_count_derived"` // RequiredCount computed as 2f+1 from the peer count
+}
+
+// ReloadPeers replaces the peer list and the required attestation count together.
+// A requiredCount <= 0 derives 2f+1 from the new validator count (peers plus this
+// validator). Collections already in progress keep the peers and required count
+// they started with; only later collections use the new ones.
+func (s *Service) ReloadPeers(peers []string, requiredCount int) PeerReloadResult {
+	peers = append([]string(nil), peers...)
+	derived := requiredCount <= 0
+	if derived {
+		requiredCount = QuorumForValidators(len(peers) + 1)
+	}
+
+	s.mu.Lock()
+	result := PeerReloadResult{Peers: peers, RequiredCount: requiredCount, Derived: derived}
+	for _, peer := range peers {
+		if !containsPeer(s.peerEndpoints, peer) {
+			result.Added = append(result.Added, peer)
+		}
+	}
+	for _, peer := range s.peerEndpoints {
+		if !containsPeer(peers, peer) {
+			result.Removed = append(result.Removed, peer)
+		}
+	}
+	s.setPeersLocked(peers)
+	s.requiredCount = requiredCount
+	tlsEnabled := s.tlsEnabled
+	s.mu.Unlock()
+
+	if tlsEnabled {
+		for _, peer := range result.Added {
+			if !strings.HasPrefix(peer, "https://") {
+				s.logger.Printf("⚠️ Attestation peer %s is not an https:// endpoint - requests to it are not authenticated", peer)
+			}
+		}
+	}
+	s.logger.Printf("🔄 Reloaded peer list: %d peers (%d added, %d removed), %d attestations required",
+		len(peers), len(result.Added), len(result.Removed), requiredCount)
+	return result
+}
+
+// setPeersLocked replaces the peer list and drops the health of removed peers.
+// Callers must hold s.mu.
+func (s *Service) setPeersLocked(peers []string) {
+	s.peerEndpoints = peers
+	for endpoint := range s.health.peers {
+		if !containsPeer(peers, endpoint) {
+			delete(s.health.peers, endpoint)
+		}
+	}
+}
+
+// QuorumForValidators returns the 2f+1 attestations needed among n validators,
+// tolerating f = (n-1)/3 faulty ones
+func QuorumForValidators(n int) int {
+	if n <= 0 {
+		return 1
+	}
+	return 2*((n-1)/3) + 1
+}
+
 // GetPeers returns the current peer endpoints
 func (s *Service) GetPeers() []string {
 	s.mu.RLock()
@@ -393,8 +393,14 @@ func (s *Ed25519Strategy) GetThresholdConfig() *ThresholdConfig {
 
 // createDomainMessage creates a domain-separated message for signing
 func (s *Ed25519Strategy) createDomainMessage(messageHash []byte) []byte {
+	return Ed25519DomainMessage(s.config.Domain, messageHash)
+}
+
+// Ed25519DomainMessage returns the domain-separated message an Ed25519 attestation
+// over messageHash signs
+func Ed25519DomainMessage(domain string, messageHash []byte) []byte {
 	var buf bytes.Buffer
-	buf.WriteString(s.config.Domain)
+	buf.WriteString(domain)
 	buf.Write(messageHash)
 
 	hash := sha256.Sum256(buf.Bytes())
@@ -0,0 +1,67 @@
+// Copyright 2025 Certen Protocol
+//
+// Attestation Threshold - Deciding when a collection has enough attestations
+//
+// In count mode (the default) a collection is sufficient once RequiredCount validators
+// have attested. The anchor contract instead checks that the validators in the
+// aggregate BLS signature hold a fraction of the total voting power (the
+// numerator/denominator set with setThreshold), which a count only matches when voting
+// powers are uniform.
+//
+// In voting power mode a collection is sufficient once the validators whose BLS
+// attestations were accepted hold the registered validator set's threshold fraction of
+// its total voting power - the check the contract applies to the aggregate, so a proof
+// it would reject is never reported as sufficient. Until a validator set is registered
+// (SetValidatorSet) no collection is sufficient in this mode.
+
+package attestation
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/certen/independant-validator/pkg/anchor_proof"
+)
+
+// Collection threshold modes
+const (
+	ThresholdModeCount       = "count"        // RequiredCount attestations
+	ThresholdModeVotingPower = "voting_power" // The validator set's fraction of total voting power
+)
+
+// parseThresholdMode returns the threshold mode, defaulting to count mode
+func parseThresholdMode(mode string) (string, error) {
+	switch mode {
+	case "", ThresholdModeCount:
+		return ThresholdModeCount, nil
+	case ThresholdModeVotingPower:
+		return ThresholdModeVotingPower, nil
+	default:
+		return "", fmt.Errorf("unknown threshold mode %q (expected %s or %s)", mode, ThresholdModeCount, ThresholdModeVotingPower)
+	}
+}
+
+// signedVotingPowerLocked returns the registered voting power of the validators whose
+// BLS attestations are in bundle. Callers must hold s.mu.
+func (s *Service) signedVotingPowerLocked(bundle *anchor_proof.AttestationBundle) *big.Int {
+	signed := new(big.Int)
+	if s.validatorSet == nil {
+		return signed
+	}
+	for _, att := range bundle.BLSAttestations {
+		if v, ok := s.validatorSet.validators[att.ValidatorID]; ok {
+			signed.Add(signed, v.VotingPower)
+		}
+	}
+	return signed
+}
+
+// updateSufficiencyLocked recomputes whether bundle is sufficient in voting power mode.
+// In count mode the bundle tracks this itself as attestations are added. Callers must
+// hold s.mu.
+func (s *Service) updateSufficiencyLocked(bundle *anchor_proof.AttestationBundle) {
+	if s.thresholdMode != ThresholdModeVotingPower {
+		return
+	}
+	bundle.IsSufficient = s.validatorSet != nil && s.validatorSet.ThresholdMet(s.signedVotingPowerLocked(bundle))
+}
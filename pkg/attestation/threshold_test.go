@@ -0,0 +1,127 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Attestation Threshold
+// Tests voting power based collection completeness with non-uniform voting powers
+
+package attestation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"log"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/anchor_proof"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+)
+
+// attestFrom adds v's Ed25519 and BLS attestations to the collection of req
+func attestFrom(t *testing.T, svc *Service, v *testValidator, req *AttestationRequest) {
+	t.Helper()
+	signer, err := anchor_proof.NewAttestationSigner(v.id, v.edKey)
+	if err != nil {
+		t.Fatalf("NewAttestationSigner: %v", err)
+	}
+	att, err := signer.SignBatch(req.BatchID, req.MerkleRoot, req.AnchorTxHash)
+	if err != nil {
+		t.Fatalf("SignBatch: %v", err)
+	}
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, att); err != nil {
+		t.Fatalf("Ed25519 attestation from %s rejected: %v", v.id, err)
+	}
+	blsAtt := blsBatchAttestation(v.id, v.blsKey, v.register.BLSPublicKey, req.BatchID, req.MerkleRoot, req.AnchorTxHash)
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, blsAtt); err != nil {
+		t.Fatalf("BLS attestation from %s rejected: %v", v.id, err)
+	}
+}
+
+func TestVotingPowerThreshold(t *testing.T) {
+	validators := newTestValidators(t, 4)
+	self := validators[0]
+	validators[1].register.VotingPower = big.NewInt(50)
+	blsKeys := bls.NewKeyManager("")
+	if err := blsKeys.GenerateNewKey(); err != nil {
+		t.Fatalf("GenerateNewKey: %v", err)
+	}
+	self.register.BLSPublicKey = blsKeys.GetPublicKeyBytes()
+
+	svc, err := NewService(nil, &Config{
+		ValidatorID:   self.id,
+		PrivateKey:    self.edKey,
+		BLSKeys:       blsKeys,
+		RequiredCount: 3,
+		ThresholdMode: ThresholdModeVotingPower,
+		Timeout:       time.Second,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	root := make([]byte, 32)
+	req := &AttestationRequest{ProofID: uuid.New(), BatchID: uuid.New(), MerkleRoot: root, AnchorTxHash: "0xpower"}
+	if _, err := svc.RequestAttestations(context.Background(), req); err != nil {
+		t.Fatalf("RequestAttestations: %v", err)
+	}
+	if svc.GetAttestationStatus(req.ProofID).IsSufficient {
+		t.Error("sufficient without a registered validator set")
+	}
+
+	// Powers 10, 50, 10, 10: three validators reach the count but hold 30 of 80
+	svc.SetValidatorSet(newTestValidatorSet(t, validators, 0, 0))
+	attestFrom(t, svc, validators[2], req)
+	attestFrom(t, svc, validators[3], req)
+	status := svc.GetAttestationStatus(req.ProofID)
+	if status.CollectedCount < status.RequiredCount {
+		t.Fatalf("collected %d attestations, want at least %d", status.CollectedCount, status.RequiredCount)
+	}
+	if status.IsSufficient {
+		t.Errorf("sufficient with %s of %s voting power", status.SignedVotingPower, status.TotalVotingPower)
+	}
+
+	attestFrom(t, svc, validators[1], req)
+	status = svc.GetAttestationStatus(req.ProofID)
+	if !status.IsSufficient {
+		t.Errorf("not sufficient with %s of %s voting power", status.SignedVotingPower, status.TotalVotingPower)
+	}
+	if status.ThresholdMode != ThresholdModeVotingPower || status.SignedVotingPower != "80" || status.TotalVotingPower != "80" {
+		t.Errorf("status = %s %s/%s, want voting_power 80/80", status.ThresholdMode, status.SignedVotingPower, status.TotalVotingPower)
+	}
+
+	// A stricter threshold registered later re-evaluates the collection
+	set, err := NewValidatorSet([]RegisteredValidator{self.register, validators[1].register, validators[2].register, validators[3].register,
+		{ValidatorID: "validator-9", VotingPower: big.NewInt(100), BLSPublicKey: validators[3].register.BLSPublicKey}}, 2, 3)
+	if err != nil {
+		t.Fatalf("NewValidatorSet: %v", err)
+	}
+	svc.SetValidatorSet(set)
+	if svc.GetAttestationStatus(req.ProofID).IsSufficient {
+		t.Error("still sufficient after the validator set grew to 180 voting power")
+	}
+}
+
+func TestCountThreshold_Default(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	if _, err := NewService(nil, &Config{ValidatorID: "validator-1", PrivateKey: key, ThresholdMode: "majority", Logger: log.New(io.Discard, "", 0)}); err == nil {
+		t.Error("expected error for unknown threshold mode")
+	}
+
+	svc, err := NewService(nil, &Config{ValidatorID: "validator-1", PrivateKey: key, RequiredCount: 1, Logger: log.New(io.Discard, "", 0)})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	req := &AttestationRequest{ProofID: uuid.New(), BatchID: uuid.New(), MerkleRoot: make([]byte, 32), AnchorTxHash: "0xcount"}
+	status, err := svc.RequestAttestations(context.Background(), req)
+	if err != nil {
+		t.Fatalf("RequestAttestations: %v", err)
+	}
+	if status.ThresholdMode != ThresholdModeCount || !status.IsSufficient || status.SignedVotingPower != "" {
+		t.Errorf("status = %+v, want a sufficient count-mode collection", status)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Certen Protocol
+//
+// Attestation mTLS - Authenticating validators to each other
+//
+// Validators run by different operators exchange attestation requests over the
+// network. With a certificate, key and CA bundle configured:
+//   - requests to peers present this validator's certificate and only accept peers
+//     whose certificate chains to the CA bundle
+//   - the API server asks clients for a certificate and verifies any it is given
+//     against the CA bundle; attestation requests without a verified certificate are
+//     refused by the handlers, while public endpoints stay reachable without one
+//
+// Without TLS configuration attestation traffic falls back to plain HTTP.
+
+package attestation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig locates the PEM files used for attestation mTLS
+type TLSConfig struct {
+	CertFile string // This validator's certificate
+	KeyFile  string // This validator's private key
+	CABundle string // CA certificates peer certificates must chain to
+}
+
+// Enabled reports whether any TLS file is configured
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CABundle != ""
+}
+
+// load reads the key pair and CA pool; every file is required once any is set
+func (c TLSConfig) load() (tls.Certificate, *x509.CertPool, error) {
+	if c.CertFile == "" || c.KeyFile == "" || c.CABundle == "" {
+		return tls.Certificate{}, nil, fmt.Errorf("attestation TLS needs a certificate, key and CA bundle")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load attestation TLS key pair: %w", err)
+	}
+	pem, err := os.ReadFile(c.CABundle)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read attestation CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return tls.Certificate{}, nil, fmt.Errorf("attestation CA bundle %s contains no certificates", c.CABundle)
+	}
+	return cert, pool, nil
+}
+
+// ClientTLSConfig returns the TLS configuration for requests to peers
+func (c TLSConfig) ClientTLSConfig() (*tls.Config, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ServerTLSConfig returns the TLS configuration for the API server. Client certificates
+// are verified when presented; HasVerifiedPeer tells handlers whether one was.
+func (c TLSConfig) ServerTLSConfig() (*tls.Config, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// HasVerifiedPeer reports whether a TLS connection presented a client certificate
+// that chains to the CA bundle
+func HasVerifiedPeer(state *tls.ConnectionState) bool {
+	return state != nil && len(state.VerifiedChains) > 0
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Attestation mTLS
+// Tests loading certificates and verifying peers against the CA bundle
+
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCert issues a certificate signed by parent (self-signed when parent is nil)
+// and writes it and its key as PEM files into dir
+func writeTestCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, name+".crt"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, name+".key"), "EC PRIVATE KEY", keyDER)
+	return cert, key
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTLSConfig_MutualAuthentication(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeTestCert(t, dir, "ca", nil, nil)
+	writeTestCert(t, dir, "server", ca, caKey)
+	writeTestCert(t, dir, "client", ca, caKey)
+	writeTestCert(t, dir, "rogue", nil, nil) // Self-signed, not issued by the CA
+
+	tlsFiles := func(name, caName string) TLSConfig {
+		return TLSConfig{
+			CertFile: filepath.Join(dir, name+".crt"),
+			KeyFile:  filepath.Join(dir, name+".key"),
+			CABundle: filepath.Join(dir, caName+".crt"),
+		}
+	}
+
+	serverTLS, err := tlsFiles("server", "ca").ServerTLSConfig()
+	if err != nil {
+		t.Fatalf("ServerTLSConfig failed: %v", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if HasVerifiedPeer(r.TLS) {
+			io.WriteString(w, "verified")
+		} else {
+			io.WriteString(w, "anonymous")
+		}
+	}))
+	server.TLS = serverTLS
+	server.StartTLS()
+	defer server.Close()
+
+	get := func(config TLSConfig) (string, error) {
+		clientTLS, err := config.ClientTLSConfig()
+		if err != nil {
+			return "", err
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	// A validator with a CA-issued certificate is verified
+	if body, err := get(tlsFiles("client", "ca")); err != nil || body != "verified" {
+		t.Errorf("expected verified peer, got %q (err %v)", body, err)
+	}
+
+	// A certificate from another CA is never verified (the client withholds it, or the
+	// handshake fails)
+	if body, err := get(tlsFiles("rogue", "ca")); err == nil && body != "anonymous" {
+		t.Errorf("expected unverified peer for a certificate outside the CA bundle, got %q", body)
+	}
+
+	// The client refuses a server that does not chain to its CA bundle
+	if _, err := get(tlsFiles("client", "rogue")); err == nil {
+		t.Error("expected the client to reject the server certificate")
+	}
+
+	// Incomplete configuration is an error, not a silent fallback
+	partial := tlsFiles("client", "ca")
+	partial.CABundle = ""
+	if !partial.Enabled() {
+		t.Error("expected partial configuration to count as enabled")
+	}
+	if _, err := partial.ClientTLSConfig(); err == nil {
+		t.Error("expected error for missing CA bundle")
+	}
+	if (TLSConfig{}).Enabled() {
+		t.Error("expected empty configuration to be disabled")
+	}
+}
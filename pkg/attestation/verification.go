@@ -0,0 +1,274 @@
+// Copyright 2025 Certen Protocol
+//
+// Attestation Verification - Checking peer attestations against registered validator keys
+//
+// An attestation returned by a peer carries its own public key, and the bundle only
+// checks the signature against that key, so any peer could attest under another
+// validator's ID. Before a peer attestation is counted or stored, OnAttestationReceived
+// checks that:
+//   - the validator ID is known: a configured peer key, a validator of the registered
+//     validator set, or this validator itself
+//   - the attestation carries the public key registered for that validator
+//   - the signature is valid for the registered key
+//   - it is a batch (V2) attestation, whose signature covers the batch ID and a nonce
+//   - it attests to the batch, merkle root and anchor transaction being collected
+//   - its nonce was not seen before for the validator, so a captured attestation
+//     cannot be replayed
+//
+// BLS attestations (SignatureScheme bls12-381) sign the batch result for the anchor
+// contract and carry no nonce, so they are only accepted from a validator whose
+// Ed25519 attestation for the same collection was accepted, and must carry the BLS key
+// registered for it in the validator set (or this validator's own key).
+//
+// Nonces are tracked per validator in a window of the most recent
+// attestationNonceWindow nonces: a nonce in the window is a replay, and a nonce
+// below the window is too old to tell and rejected as well. The signed byte layout
+// is documented in anchor_proof (signer.go).
+//
+// Rejected attestations are logged, counted per reason (RejectedAttestationCounts) and
+// recorded in the certen_attestations_rejected_total metric for security monitoring.
+
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/anchor_proof"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+)
+
+// ErrAttestationRejected is returned for peer attestations that fail verification
+var ErrAttestationRejected = errors.New("attestation rejected")
+
+// Reasons a peer attestation is rejected
+const (
+	RejectReasonUnknownValidator = "unknown_validator"
+	RejectReasonKeyMismatch      = "key_mismatch"
+	RejectReasonInvalidSignature = "invalid_signature"
+	RejectReasonContentMismatch  = "content_mismatch"
+	RejectReasonUnboundMessage   = "unbound_message" // Not a V2 attestation: no batch ID or nonce signed
+	RejectReasonReplayedNonce    = "replayed_nonce"
+	RejectReasonUnauthenticated  = "unauthenticated" // BLS attestation without an accepted Ed25519 attestation
+	RejectReasonUnknownScheme    = "unknown_scheme"
+)
+
+// attestationNonceWindow is the number of recent nonces remembered per validator
+const attestationNonceWindow = 4096
+
+// nonceWindow holds the recent nonces of one validator
+type nonceWindow struct {
+	seen  map[uint64]struct{}
+	floor uint64 // Nonces at or below the floor have left the window
+}
+
+// accept records nonce, returning false if it was seen or has left the window
+func (w *nonceWindow) accept(nonce uint64) bool {
+	if nonce <= w.floor {
+		return false
+	}
+	if _, seen := w.seen[nonce]; seen {
+		return false
+	}
+	w.seen[nonce] = struct{}{}
+	if len(w.seen) > attestationNonceWindow {
+		oldest := nonce
+		for n := range w.seen {
+			if n < oldest {
+				oldest = n
+			}
+		}
+		delete(w.seen, oldest)
+		w.floor = oldest
+	}
+	return true
+}
+
+// DecodePeerKeys decodes validator ID -> hex Ed25519 public key pairs
+func DecodePeerKeys(keys map[string]string) (map[string]ed25519.PublicKey, error) {
+	decoded := make(map[string]ed25519.PublicKey, len(keys))
+	for id, keyHex := range keys {
+		key, err := hex.DecodeString(strings.TrimPrefix(keyHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: invalid public key hex: %w", id, err)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("validator %s: invalid public key size: expected %d, got %d", id, ed25519.PublicKeySize, len(key))
+		}
+		decoded[id] = key
+	}
+	return decoded, nil
+}
+
+// SetPeerKeys replaces the Ed25519 public keys of the peers whose attestations are accepted
+func (s *Service) SetPeerKeys(keys map[string]ed25519.PublicKey) {
+	copied := make(map[string]ed25519.PublicKey, len(keys))
+	for id, key := range keys {
+		copied[id] = key
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.peerKeys = copied
+}
+
+// registeredKeyLocked returns the Ed25519 public key registered for a validator.
+// Callers must hold s.mu.
+func (s *Service) registeredKeyLocked(validatorID string) (ed25519.PublicKey, bool) {
+	if validatorID == s.validatorID {
+		return s.signer.GetPublicKey(), true
+	}
+	if key, ok := s.peerKeys[validatorID]; ok {
+		return key, true
+	}
+	if s.validatorSet != nil {
+		if v, ok := s.validatorSet.validators[validatorID]; ok && len(v.Ed25519PublicKey) != 0 {
+			return v.Ed25519PublicKey, true
+		}
+	}
+	return nil, false
+}
+
+// OnAttestationReceived verifies an attestation received from a peer against the key
+// registered for its validator and adds it to the proof's bundle. Attestations failing
+// verification are rejected with ErrAttestationRejected.
+func (s *Service) OnAttestationReceived(ctx context.Context, proofID uuid.UUID, att *anchor_proof.ValidatorAttestation) error {
+	if att == nil {
+		return fmt.Errorf("attestation cannot be nil")
+	}
+
+	s.mu.Lock()
+	bundle, exists := s.bundles[proofID]
+	if !exists {
+		s.mu.Unlock()
+		return fmt.Errorf("no attestation collection for proof %s", proofID)
+	}
+	verify := s.verifyAttestationLocked
+	if !att.IsEd25519() {
+		verify = s.verifyBLSAttestationLocked
+	}
+	if reason := verify(bundle, att); reason != "" {
+		s.rejected[reason]++
+		s.mu.Unlock()
+		s.metrics.RecordAttestationRejected(reason)
+		s.logger.Printf("🚫 Rejected attestation from %s for proof %s: %s", att.ValidatorID, proofID, reason)
+		return fmt.Errorf("%w: %s", ErrAttestationRejected, reason)
+	}
+	if !att.IsEd25519() {
+		// BLS attestations are kept in the bundle for aggregation only
+		err := bundle.AddBLSAttestation(att)
+		s.updateSufficiencyLocked(bundle)
+		s.mu.Unlock()
+		return err
+	}
+	err := bundle.AddAttestation(att)
+	s.updateSufficiencyLocked(bundle)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if s.repos != nil {
+		s.storeAttestation(ctx, proofID, att)
+	}
+	return nil
+}
+
+// verifyAttestationLocked returns why an attestation must be rejected, or "" when it
+// is valid for bundle. Callers must hold s.mu.
+func (s *Service) verifyAttestationLocked(bundle *anchor_proof.AttestationBundle, att *anchor_proof.ValidatorAttestation) string {
+	key, known := s.registeredKeyLocked(att.ValidatorID)
+	if !known {
+		return RejectReasonUnknownValidator
+	}
+	if !bytes.Equal(att.ValidatorPubkey, key) {
+		return RejectReasonKeyMismatch
+	}
+	if !anchor_proof.ValidateAttestationSignature(att) {
+		return RejectReasonInvalidSignature
+	}
+	if att.MessageVersion != anchor_proof.AttestationMessageV2 {
+		return RejectReasonUnboundMessage
+	}
+	if att.BatchID != bundle.BatchID ||
+		!bytes.Equal(att.AttestedMerkleRoot, bundle.MerkleRoot) ||
+		att.AttestedAnchorTx != bundle.AnchorTxHash {
+		return RejectReasonContentMismatch
+	}
+
+	// Recorded last, so attestations rejected above do not use up the nonce
+	window, ok := s.nonces[att.ValidatorID]
+	if !ok {
+		window = &nonceWindow{seen: make(map[uint64]struct{})}
+		s.nonces[att.ValidatorID] = window
+	}
+	if !window.accept(att.Nonce) {
+		return RejectReasonReplayedNonce
+	}
+	return ""
+}
+
+// registeredBLSKeyLocked returns the BLS public key registered for a validator.
+// Callers must hold s.mu.
+func (s *Service) registeredBLSKeyLocked(validatorID string) (*bls.PublicKey, bool) {
+	if validatorID == s.validatorID && s.blsKeys != nil && s.blsKeys.GetPublicKey() != nil {
+		return s.blsKeys.GetPublicKey(), true
+	}
+	if s.validatorSet != nil {
+		if v, ok := s.validatorSet.validators[validatorID]; ok && v.blsKey != nil {
+			return v.blsKey, true
+		}
+	}
+	return nil, false
+}
+
+// verifyBLSAttestationLocked returns why a BLS attestation must be rejected, or "" when
+// it is valid for bundle. Callers must hold s.mu.
+func (s *Service) verifyBLSAttestationLocked(bundle *anchor_proof.AttestationBundle, att *anchor_proof.ValidatorAttestation) string {
+	if att.SignatureScheme != anchor_proof.SignatureSchemeBLS12381 {
+		return RejectReasonUnknownScheme
+	}
+	if !bundle.HasAttestationFrom(att.ValidatorID) {
+		return RejectReasonUnauthenticated
+	}
+	key, known := s.registeredBLSKeyLocked(att.ValidatorID)
+	if !known {
+		return RejectReasonUnknownValidator
+	}
+	if !bytes.Equal(att.ValidatorPubkey, key.Bytes()) {
+		return RejectReasonKeyMismatch
+	}
+	sig, err := bls.SignatureFromBytes(att.Signature)
+	if err != nil {
+		return RejectReasonInvalidSignature
+	}
+	hash := anchor_proof.BatchResultHash(att.BatchID, att.AttestedMerkleRoot, att.AttestedAnchorTx)
+	if !key.VerifyWithDomain(sig, hash[:], bls.DomainResult) {
+		return RejectReasonInvalidSignature
+	}
+	if att.BatchID != bundle.BatchID ||
+		!bytes.Equal(att.AttestedMerkleRoot, bundle.MerkleRoot) ||
+		att.AttestedAnchorTx != bundle.AnchorTxHash {
+		return RejectReasonContentMismatch
+	}
+	return ""
+}
+
+// RejectedAttestationCounts returns the number of rejected peer attestations by reason
+func (s *Service) RejectedAttestationCounts() map[string]uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]uint64, len(s.rejected))
+	for reason, n := range s.rejected {
+		counts[reason] = n
+	}
+	return counts
+}
@@ -0,0 +1,322 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Attestation Verification
+// Tests rejecting peer attestations from unknown validators, with substituted keys,
+// invalid signatures, for another batch or with a replayed nonce, counting the
+// rejections by reason, and collecting and aggregating BLS attestations alongside them
+
+package attestation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/anchor_proof"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+)
+
+func newTestSigner(t *testing.T, validatorID string) (*anchor_proof.AttestationSigner, ed25519.PublicKey) {
+	t.Helper()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate Ed25519 key: %v", err)
+	}
+	signer, err := anchor_proof.NewAttestationSigner(validatorID, key)
+	if err != nil {
+		t.Fatalf("NewAttestationSigner: %v", err)
+	}
+	return signer, pub
+}
+
+func TestOnAttestationReceived(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	peer, peerKey := newTestSigner(t, "validator-2")
+	impostor, _ := newTestSigner(t, "validator-2")
+	stranger, strangerKey := newTestSigner(t, "validator-9")
+
+	svc, err := NewService(nil, &Config{
+		ValidatorID: "validator-1",
+		PrivateKey:  key,
+		PeerKeys:    map[string]ed25519.PublicKey{"validator-2": peerKey},
+		Timeout:     time.Second,
+		Logger:      log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	root := make([]byte, 32)
+	root[0] = 0x01
+	req := &AttestationRequest{ProofID: uuid.New(), BatchID: uuid.New(), MerkleRoot: root, AnchorTxHash: "0xabc"}
+	if _, err := svc.RequestAttestations(context.Background(), req); err != nil {
+		t.Fatalf("RequestAttestations: %v", err)
+	}
+
+	sign := func(signer *anchor_proof.AttestationSigner, merkleRoot []byte) *anchor_proof.ValidatorAttestation {
+		att, err := signer.SignBatch(req.BatchID, merkleRoot, "0xabc")
+		if err != nil {
+			t.Fatalf("SignBatch: %v", err)
+		}
+		return att
+	}
+
+	// An attestation under the peer's ID signed with another key, whether it carries
+	// that key or the registered one
+	substituted := sign(impostor, root)
+	forged := sign(impostor, root)
+	forged.ValidatorPubkey = peerKey
+
+	otherRoot := make([]byte, 32)
+	otherRoot[0] = 0x02
+
+	// A valid signature over another batch, and one without batch ID and nonce
+	otherBatch, err := peer.SignBatch(uuid.New(), root, "0xabc")
+	if err != nil {
+		t.Fatalf("SignBatch: %v", err)
+	}
+	unbound, err := peer.SignMerkleRoot(root, "0xabc")
+	if err != nil {
+		t.Fatalf("SignMerkleRoot: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		att    *anchor_proof.ValidatorAttestation
+		reason string
+	}{
+		{"unknown validator", sign(stranger, root), RejectReasonUnknownValidator},
+		{"substituted key", substituted, RejectReasonKeyMismatch},
+		{"forged signature", forged, RejectReasonInvalidSignature},
+		{"other merkle root", sign(peer, otherRoot), RejectReasonContentMismatch},
+		{"other batch", otherBatch, RejectReasonContentMismatch},
+		{"unbound message", unbound, RejectReasonUnboundMessage},
+	}
+	for _, tc := range cases {
+		err := svc.OnAttestationReceived(context.Background(), req.ProofID, tc.att)
+		if !errors.Is(err, ErrAttestationRejected) {
+			t.Errorf("%s: expected ErrAttestationRejected, got %v", tc.name, err)
+		}
+	}
+
+	valid := sign(peer, root)
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, valid); err != nil {
+		t.Fatalf("valid peer attestation rejected: %v", err)
+	}
+	if status := svc.GetAttestationStatus(req.ProofID); status.CollectedCount != 2 {
+		t.Errorf("collected %d attestations, want own and peer (2)", status.CollectedCount)
+	}
+
+	// The same attestation replayed into a later collection of the same batch
+	replayProof := uuid.New()
+	replayReq := &AttestationRequest{ProofID: replayProof, BatchID: req.BatchID, MerkleRoot: root, AnchorTxHash: "0xabc"}
+	if _, err := svc.RequestAttestations(context.Background(), replayReq); err != nil {
+		t.Fatalf("RequestAttestations: %v", err)
+	}
+	if err := svc.OnAttestationReceived(context.Background(), replayProof, valid); !errors.Is(err, ErrAttestationRejected) {
+		t.Errorf("replayed attestation: expected ErrAttestationRejected, got %v", err)
+	}
+	if err := svc.OnAttestationReceived(context.Background(), replayProof, sign(peer, root)); err != nil {
+		t.Errorf("fresh attestation rejected: %v", err)
+	}
+
+	counts := svc.RejectedAttestationCounts()
+	want := map[string]uint64{
+		RejectReasonUnknownValidator: 1,
+		RejectReasonKeyMismatch:      1,
+		RejectReasonInvalidSignature: 1,
+		RejectReasonContentMismatch:  2,
+		RejectReasonUnboundMessage:   1,
+		RejectReasonReplayedNonce:    1,
+	}
+	for reason, n := range want {
+		if counts[reason] != n {
+			t.Errorf("rejections for %s = %d, want %d", reason, counts[reason], n)
+		}
+	}
+
+	// Keys added later, e.g. by a peer reload, are accepted
+	svc.SetPeerKeys(map[string]ed25519.PublicKey{"validator-2": peerKey, "validator-9": strangerKey})
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, sign(stranger, root)); err != nil {
+		t.Errorf("attestation from newly registered validator rejected: %v", err)
+	}
+}
+
+func TestOnAttestationReceived_ValidatorSetKeys(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	svc, err := NewService(nil, &Config{
+		ValidatorID: "validator-0",
+		PrivateKey:  key,
+		Timeout:     time.Second,
+		Logger:      log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	validators := newTestValidators(t, 1)
+	svc.SetValidatorSet(newTestValidatorSet(t, validators, 0, 0))
+
+	root := make([]byte, 32)
+	req := &AttestationRequest{ProofID: uuid.New(), BatchID: uuid.New(), MerkleRoot: root, AnchorTxHash: "0xdef"}
+	if _, err := svc.RequestAttestations(context.Background(), req); err != nil {
+		t.Fatalf("RequestAttestations: %v", err)
+	}
+
+	signer, err := anchor_proof.NewAttestationSigner(validators[0].id, validators[0].edKey)
+	if err != nil {
+		t.Fatalf("NewAttestationSigner: %v", err)
+	}
+	att, err := signer.SignBatch(req.BatchID, root, "0xdef")
+	if err != nil {
+		t.Fatalf("SignBatch: %v", err)
+	}
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, att); err != nil {
+		t.Errorf("attestation from registered validator rejected: %v", err)
+	}
+}
+
+func TestNonceWindow(t *testing.T) {
+	w := &nonceWindow{seen: make(map[uint64]struct{})}
+
+	// Out-of-order nonces are accepted once each
+	for _, nonce := range []uint64{10, 12, 11} {
+		if !w.accept(nonce) {
+			t.Errorf("nonce %d rejected", nonce)
+		}
+	}
+	if w.accept(11) {
+		t.Error("replayed nonce accepted")
+	}
+
+	// Nonces that left the window cannot be told apart from replays
+	for nonce := uint64(100); nonce < 100+attestationNonceWindow; nonce++ {
+		w.accept(nonce)
+	}
+	if len(w.seen) != attestationNonceWindow {
+		t.Errorf("window holds %d nonces, want %d", len(w.seen), attestationNonceWindow)
+	}
+	if w.accept(11) {
+		t.Error("nonce below the window accepted")
+	}
+	if !w.accept(13) {
+		t.Error("unseen nonce within the window rejected")
+	}
+}
+
+// blsBatchAttestation signs a batch result with key under validatorID, carrying pubkey
+func blsBatchAttestation(validatorID string, key *bls.PrivateKey, pubkey []byte, batchID uuid.UUID, root []byte, anchorTx string) *anchor_proof.ValidatorAttestation {
+	hash := anchor_proof.BatchResultHash(batchID, root, anchorTx)
+	return &anchor_proof.ValidatorAttestation{
+		AttestationID:      uuid.New(),
+		ValidatorID:        validatorID,
+		ValidatorPubkey:    pubkey,
+		AttestedMerkleRoot: root,
+		AttestedAnchorTx:   anchorTx,
+		SignatureScheme:    anchor_proof.SignatureSchemeBLS12381,
+		BatchID:            batchID,
+		Signature:          key.SignWithDomain(hash[:], bls.DomainResult).Bytes(),
+		AttestedAt:         time.Now(),
+	}
+}
+
+func TestBLSAttestations(t *testing.T) {
+	validators := newTestValidators(t, 3)
+	self, peer, other := validators[0], validators[1], validators[2]
+	blsKeys := bls.NewKeyManager("")
+	if err := blsKeys.GenerateNewKey(); err != nil {
+		t.Fatalf("GenerateNewKey: %v", err)
+	}
+	self.register.BLSPublicKey = blsKeys.GetPublicKeyBytes()
+
+	svc, err := NewService(nil, &Config{
+		ValidatorID: self.id,
+		PrivateKey:  self.edKey,
+		BLSKeys:     blsKeys,
+		Timeout:     time.Second,
+		Logger:      log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	svc.SetValidatorSet(newTestValidatorSet(t, validators, 0, 0))
+
+	root := make([]byte, 32)
+	root[0] = 0x07
+	req := &AttestationRequest{ProofID: uuid.New(), BatchID: uuid.New(), MerkleRoot: root, AnchorTxHash: "0xbls"}
+	if _, err := svc.RequestAttestations(context.Background(), req); err != nil {
+		t.Fatalf("RequestAttestations: %v", err)
+	}
+
+	peerPub := peer.register.BLSPublicKey
+	otherPub := other.register.BLSPublicKey
+	// The peer has not authenticated with its Ed25519 attestation yet
+	early := blsBatchAttestation(peer.id, peer.blsKey, peerPub, req.BatchID, root, "0xbls")
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, early); !errors.Is(err, ErrAttestationRejected) {
+		t.Errorf("unauthenticated BLS attestation: expected ErrAttestationRejected, got %v", err)
+	}
+
+	signer, err := anchor_proof.NewAttestationSigner(peer.id, peer.edKey)
+	if err != nil {
+		t.Fatalf("NewAttestationSigner: %v", err)
+	}
+	edAtt, err := signer.SignBatch(req.BatchID, root, "0xbls")
+	if err != nil {
+		t.Fatalf("SignBatch: %v", err)
+	}
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, edAtt); err != nil {
+		t.Fatalf("Ed25519 attestation rejected: %v", err)
+	}
+
+	otherRoot := make([]byte, 32)
+	unknownScheme := blsBatchAttestation(peer.id, peer.blsKey, peerPub, req.BatchID, root, "0xbls")
+	unknownScheme.SignatureScheme = "secp256k1"
+	cases := []struct {
+		name string
+		att  *anchor_proof.ValidatorAttestation
+	}{
+		{"substituted key", blsBatchAttestation(peer.id, other.blsKey, otherPub, req.BatchID, root, "0xbls")},
+		{"forged signature", blsBatchAttestation(peer.id, other.blsKey, peerPub, req.BatchID, root, "0xbls")},
+		{"other merkle root", blsBatchAttestation(peer.id, peer.blsKey, peerPub, req.BatchID, otherRoot, "0xbls")},
+		{"unknown scheme", unknownScheme},
+	}
+	for _, tc := range cases {
+		if err := svc.OnAttestationReceived(context.Background(), req.ProofID, tc.att); !errors.Is(err, ErrAttestationRejected) {
+			t.Errorf("%s: expected ErrAttestationRejected, got %v", tc.name, err)
+		}
+	}
+	counts := svc.RejectedAttestationCounts()
+	for _, reason := range []string{RejectReasonUnauthenticated, RejectReasonKeyMismatch, RejectReasonInvalidSignature, RejectReasonContentMismatch, RejectReasonUnknownScheme} {
+		if counts[reason] != 1 {
+			t.Errorf("rejections for %s = %d, want 1", reason, counts[reason])
+		}
+	}
+
+	if err := svc.OnAttestationReceived(context.Background(), req.ProofID, blsBatchAttestation(peer.id, peer.blsKey, peerPub, req.BatchID, root, "0xbls")); err != nil {
+		t.Fatalf("BLS attestation rejected: %v", err)
+	}
+	if status := svc.GetAttestationStatus(req.ProofID); status.CollectedCount != 2 {
+		t.Errorf("collected %d Ed25519 attestations, want 2 (BLS attestations are not counted)", status.CollectedCount)
+	}
+
+	// Our own and the peer's BLS attestations are kept with the collection
+	bundle := svc.bundles[req.ProofID]
+	if len(bundle.BLSAttestations) != 2 || bundle.BLSAttestations[0].ValidatorID != self.id || bundle.BLSAttestations[1].ValidatorID != peer.id {
+		t.Errorf("BLS attestations = %+v, want from %s and %s", bundle.BLSAttestations, self.id, peer.id)
+	}
+
+	// Responding to a peer returns both attestations
+	resp, err := svc.HandleAttestationRequest(context.Background(), &AttestationRequest{ProofID: uuid.New(), BatchID: uuid.New(), MerkleRoot: root, AnchorTxHash: "0xbls"})
+	if err != nil || !resp.Success {
+		t.Fatalf("HandleAttestationRequest: %v %+v", err, resp)
+	}
+	if !resp.Attestation.IsEd25519() || resp.BLSAttestation == nil || resp.BLSAttestation.SignatureScheme != anchor_proof.SignatureSchemeBLS12381 {
+		t.Errorf("response attestations: %+v, BLS %+v", resp.Attestation, resp.BLSAttestation)
+	}
+}
@@ -39,6 +39,28 @@ type AnchorManagerInterface interface {
 	ExecuteComprehensiveProofOnChain(ctx context.Context, req interface{}) (interface{}, error)
 }
 
+// ProofVerifierInterface is optionally implemented by an AnchorManagerInterface that can
+// check proofs against the contract's verifyCertenProofDetailed view without submitting them
+type ProofVerifierInterface interface {
+	// VerifyComprehensiveProofOnChain takes an *ExecuteProofOnChainRequest and returns a value
+	// whose JSON form matches OnChainProofVerification
+	VerifyComprehensiveProofOnChain(ctx context.Context, req interface{}) (interface{}, error)
+}
+
+// AnchorEstimatorInterface is optionally implemented by an AnchorManagerInterface that can
+// price a createAnchor transaction without submitting it
+type AnchorEstimatorInterface interface {
+	EstimateBatchAnchorOnChain(ctx context.Context, req *AnchorOnChainRequest) (*AnchorCostEstimate, error)
+}
+
+// AnchorCostEstimate is the expected cost of anchoring a batch, estimated without submitting
+type AnchorCostEstimate struct {
+	TargetChain  string `json:"target_chain"`
+	GasLimit     uint64 `json:"gas_limit"`
+	GasPriceWei  string `json:"gas_price_wei"`
+	TotalCostWei string `json:"total_cost_wei"`
+}
+
 // ExecuteProofOnChainRequest is the request for comprehensive proof execution
 // This is the on-chain format that bridges batch processor to anchor manager
 type ExecuteProofOnChainRequest struct {
@@ -49,6 +71,7 @@ type ExecuteProofOnChainRequest struct {
 	MerkleRoot           [32]byte `json:"merkle_root"`
 	ProofHashes          [][32]byte `json:"proof_hashes"`
 	LeafHash             [32]byte `json:"leaf_hash"`
+	LeafIndex            uint64   `json:"leaf_index"`
 	OperationCommitment  [32]byte `json:"operation_commitment"`
 	CrossChainCommitment [32]byte `json:"cross_chain_commitment"`
 	GovernanceRoot       [32]byte `json:"governance_root"`
@@ -64,6 +87,13 @@ type ExecuteProofOnChainResult struct {
 	GasUsed     int64  `json:"gas_used"`
 	Success     bool   `json:"success"`
 	ProofValid  bool   `json:"proof_valid"`
+
+	CalldataBytes int  `json:"calldata_bytes"`   // Size of the submitted executeComprehensiveProof calldata
+	Shadow        bool `json:"shadow,omitempty"` // Simulated in shadow mode; nothing was sent
+
+	// Set when the contract has already consumed the proof's commitment or nonce; nothing was sent
+	AlreadyAnchored       bool   `json:"already_anchored,omitempty"`
+	AlreadyAnchoredReason string `json:"already_anchored_reason,omitempty"`
 }
 
 // AnchorOnChainRequest is the request to create an anchor on-chain
@@ -80,6 +110,7 @@ type AnchorOnChainRequest struct {
 	AccumulateHash       string `json:"accumulate_hash"`
 	TargetChain          string `json:"target_chain"`
 	ValidatorID          string `json:"validator_id"`
+	Urgent               bool   `json:"urgent,omitempty"` // On-demand: anchor on the fee escalation schedule
 
 	// ========== Phase 2: Additional Proof Binding Data ==========
 
@@ -104,6 +135,28 @@ type AnchorOnChainResult struct {
 	TotalCostWei string    `json:"total_cost_wei"`
 	Timestamp    time.Time `json:"timestamp"`
 	Success      bool      `json:"success"`
+	Fees         AnchorFees `json:"fees"`
+	Shadow       bool      `json:"shadow,omitempty"` // Simulated in shadow mode; nothing was sent
+}
+
+// AnchorFees is the EIP-1559 fee breakdown of an anchor transaction and how its gas
+// price was chosen; fee amounts are empty on chains without a base fee
+type AnchorFees struct {
+	BaseFeeWei     string `json:"base_fee_wei,omitempty"`
+	PriorityFeeWei string `json:"priority_fee_wei,omitempty"`
+	FeeStrategy    string `json:"fee_strategy,omitempty"`
+	GasBumped      bool   `json:"gas_bumped"` // The transaction was resubmitted at a higher gas price
+
+	// Submissions made under the fee escalation schedule (urgent anchors only)
+	Escalation []FeeEscalationStep `json:"escalation,omitempty"`
+}
+
+// FeeEscalationStep is one submission of an anchor transaction under the fee escalation schedule
+type FeeEscalationStep struct {
+	Attempt     int       `json:"attempt"` // 1 = initial submission
+	TxHash      string    `json:"tx_hash"`
+	GasPriceWei string    `json:"gas_price_wei"`
+	SubmittedAt time.Time `json:"submitted_at"`
 }
 
 // AnchorAdapter implements AnchorCreator interface for batch.Processor
@@ -203,6 +256,40 @@ func (a *AnchorAdapter) CreateBatchAnchor(ctx context.Context, req *BatchAnchorR
 	a.logger.Printf("Creating batch anchor for batch %s (merkle_root=%s, txs=%d)",
 		req.BatchID, hex.EncodeToString(req.MerkleRoot)[:16]+"...", req.TxCount)
 
+	onChainReq, err := a.buildOnChainRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the actual anchor manager to write to chain
+	result, err := a.anchorManager.CreateBatchAnchorOnChain(ctx, onChainReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create anchor on chain: %w", err)
+	}
+
+	a.logger.Printf("Batch anchor created: tx=%s, block=%d, gas=%d, proof_data=%v",
+		result.TxHash[:16]+"...", result.BlockNumber, result.GasUsed, onChainReq.ProofDataIncluded)
+
+	return &BatchAnchorResult{
+		AnchorID:     uuid.New(),
+		BatchID:      req.BatchID,
+		TargetChain:  req.TargetChain,
+		TxHash:       result.TxHash,
+		BlockNumber:  result.BlockNumber,
+		BlockHash:    result.BlockHash,
+		GasUsed:      result.GasUsed,
+		GasPriceWei:  result.GasPriceWei,
+		TotalCostWei: result.TotalCostWei,
+		Success:      result.Success,
+		Timestamp:    result.Timestamp,
+		Fees:         result.Fees,
+		Shadow:       result.Shadow,
+	}, nil
+}
+
+// buildOnChainRequest derives the batch commitments and converts a batch anchor request
+// to the on-chain request
+func (a *AnchorAdapter) buildOnChainRequest(req *BatchAnchorRequest) (*AnchorOnChainRequest, error) {
 	// Phase 2/3: Derive commitments from REAL proof data when available
 	// Per HIGH-002: CrossChainCommitment = real BPT root from Accumulate
 	// Per HIGH-003: GovernanceRoot = Merkle root of all governance proof hashes
@@ -242,7 +329,7 @@ func (a *AnchorAdapter) CreateBatchAnchor(ctx context.Context, req *BatchAnchorR
 
 	// Convert batch request to on-chain request
 	// Per whitepaper: OperationCommitment = batch merkle root
-	onChainReq := &AnchorOnChainRequest{
+	return &AnchorOnChainRequest{
 		BatchID:              req.BatchID.String(), // Convert UUID to string
 		MerkleRoot:           req.MerkleRoot,
 		OperationCommitment:  req.MerkleRoot, // Operation commitment IS the merkle root
@@ -253,34 +340,28 @@ func (a *AnchorAdapter) CreateBatchAnchor(ctx context.Context, req *BatchAnchorR
 		AccumulateHash:       req.AccumulateHash,
 		TargetChain:          req.TargetChain,
 		ValidatorID:          req.ValidatorID,
+		Urgent:               req.Urgent,
 		// Phase 2 additions
 		NetworkRootHash:      req.NetworkRootHash,
 		GovernanceProofCount: govProofCount,
 		ProofDataIncluded:    proofDataIncluded,
+	}, nil
+}
+
+// EstimateBatchAnchor prices the anchor CreateBatchAnchor would create for the request,
+// deriving the same commitments but without submitting anything on-chain
+func (a *AnchorAdapter) EstimateBatchAnchor(ctx context.Context, req *BatchAnchorRequest) (*AnchorCostEstimate, error) {
+	estimator, ok := a.anchorManager.(AnchorEstimatorInterface)
+	if !ok {
+		return nil, fmt.Errorf("anchor cost estimation not supported by anchor manager")
 	}
 
-	// Call the actual anchor manager to write to chain
-	result, err := a.anchorManager.CreateBatchAnchorOnChain(ctx, onChainReq)
+	onChainReq, err := a.buildOnChainRequest(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create anchor on chain: %w", err)
+		return nil, err
 	}
 
-	a.logger.Printf("Batch anchor created: tx=%s, block=%d, gas=%d, proof_data=%v",
-		result.TxHash[:16]+"...", result.BlockNumber, result.GasUsed, proofDataIncluded)
-
-	return &BatchAnchorResult{
-		AnchorID:     uuid.New(),
-		BatchID:      req.BatchID,
-		TargetChain:  req.TargetChain,
-		TxHash:       result.TxHash,
-		BlockNumber:  result.BlockNumber,
-		BlockHash:    result.BlockHash,
-		GasUsed:      result.GasUsed,
-		GasPriceWei:  result.GasPriceWei,
-		TotalCostWei: result.TotalCostWei,
-		Success:      result.Success,
-		Timestamp:    result.Timestamp,
-	}, nil
+	return estimator.EstimateBatchAnchorOnChain(ctx, onChainReq)
 }
 
 // ExecuteComprehensiveProof implements AnchorCreator interface
@@ -311,6 +392,7 @@ func (a *AnchorAdapter) ExecuteComprehensiveProof(ctx context.Context, req *Exec
 		MerkleRoot:           req.MerkleRoot,
 		ProofHashes:          req.ProofHashes,
 		LeafHash:             req.LeafHash,
+		LeafIndex:            req.LeafIndex,
 		OperationCommitment:  req.OperationCommitment,
 		CrossChainCommitment: req.CrossChainCommitment,
 		GovernanceRoot:       req.GovernanceRoot,
@@ -348,7 +430,16 @@ func (a *AnchorAdapter) ExecuteComprehensiveProof(ctx context.Context, req *Exec
 				result.ProofValid = v
 			}
 		} else {
-			return nil, fmt.Errorf("unexpected result type from ExecuteComprehensiveProofOnChain: %T", resultInterface)
+			// The anchor package returns its own mirror of ExecuteProofOnChainResult;
+			// decode it through the shared JSON form
+			data, jsonErr := json.Marshal(resultInterface)
+			if resultInterface == nil || jsonErr != nil {
+				return nil, fmt.Errorf("unexpected result type from ExecuteComprehensiveProofOnChain: %T", resultInterface)
+			}
+			result = &ExecuteProofOnChainResult{}
+			if err := json.Unmarshal(data, result); err != nil {
+				return nil, fmt.Errorf("unexpected result type from ExecuteComprehensiveProofOnChain: %T: %w", resultInterface, err)
+			}
 		}
 	}
 
@@ -357,8 +448,12 @@ func (a *AnchorAdapter) ExecuteComprehensiveProof(ctx context.Context, req *Exec
 		txHashDisplay = txHashDisplay[:16] + "..."
 	}
 
-	a.logger.Printf("✅ [Phase 1] Comprehensive proof executed: tx=%s, block=%d, valid=%v",
-		txHashDisplay, result.BlockNumber, result.ProofValid)
+	if result.AlreadyAnchored {
+		a.logger.Printf("⏭️ [Phase 1] Comprehensive proof already anchored: %s", result.AlreadyAnchoredReason)
+	} else {
+		a.logger.Printf("✅ [Phase 1] Comprehensive proof executed: tx=%s, block=%d, valid=%v",
+			txHashDisplay, result.BlockNumber, result.ProofValid)
+	}
 
 	return &ExecuteProofResult{
 		TxHash:      result.TxHash,
@@ -367,9 +462,60 @@ func (a *AnchorAdapter) ExecuteComprehensiveProof(ctx context.Context, req *Exec
 		GasUsed:     result.GasUsed,
 		Success:     result.Success,
 		ProofValid:  result.ProofValid,
+
+		CalldataBytes: result.CalldataBytes,
+		Shadow:        result.Shadow,
+
+		AlreadyAnchored:       result.AlreadyAnchored,
+		AlreadyAnchoredReason: result.AlreadyAnchoredReason,
 	}, nil
 }
 
+// VerifyComprehensiveProof checks a proof request against the contract without submitting it.
+// Implements OnChainProofVerifier.
+func (a *AnchorAdapter) VerifyComprehensiveProof(ctx context.Context, req *ExecuteProofRequest) (*OnChainProofVerification, error) {
+	if req == nil {
+		return nil, fmt.Errorf("execute proof request is required")
+	}
+	verifier, ok := a.anchorManager.(ProofVerifierInterface)
+	if !ok {
+		return nil, fmt.Errorf("anchor manager does not support on-chain proof verification")
+	}
+
+	resultInterface, err := verifier.VerifyComprehensiveProofOnChain(ctx, &ExecuteProofOnChainRequest{
+		AnchorID:             req.AnchorID,
+		BatchID:              req.BatchID,
+		ValidatorID:          req.ValidatorID,
+		TransactionHash:      req.TransactionHash,
+		MerkleRoot:           req.MerkleRoot,
+		ProofHashes:          req.ProofHashes,
+		LeafHash:             req.LeafHash,
+		LeafIndex:            req.LeafIndex,
+		OperationCommitment:  req.OperationCommitment,
+		CrossChainCommitment: req.CrossChainCommitment,
+		GovernanceRoot:       req.GovernanceRoot,
+		BLSSignature:         req.BLSSignature,
+		Timestamp:            req.Timestamp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify comprehensive proof on chain: %w", err)
+	}
+
+	if result, ok := resultInterface.(*OnChainProofVerification); ok {
+		return result, nil
+	}
+	// The anchor package returns its own mirror type; convert through its JSON form
+	data, err := json.Marshal(resultInterface)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected result type from VerifyComprehensiveProofOnChain: %T", resultInterface)
+	}
+	var result OnChainProofVerification
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("unexpected result type from VerifyComprehensiveProofOnChain: %T: %w", resultInterface, err)
+	}
+	return &result, nil
+}
+
 // =============================================================================
 // Phase 2/3: Real Cryptographic Commitment Derivation (HIGH-002, HIGH-003, CRITICAL-003)
 // =============================================================================
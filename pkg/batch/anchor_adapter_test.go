@@ -10,10 +10,12 @@ package batch
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -423,6 +425,91 @@ func TestAnchorOnChainRequest_Serialization(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Fee Escalation Tests
+// ============================================================================
+
+func TestAnchorManagerWrapper_PassesUrgent(t *testing.T) {
+	var gotUrgent bool
+	wrapper := NewAnchorManagerWrapper(func(ctx context.Context, batchID string, merkleRoot, opCommit, crossCommit, govRoot []byte,
+		txCount int, accumHeight int64, accumHash, targetChain, validatorID string, urgent bool) (
+		string, int64, string, int64, string, string, AnchorFees, bool, bool, error) {
+		gotUrgent = urgent
+		return "0xabc", 1, "0xblock", 21000, "1", "21000", AnchorFees{}, true, false, nil
+	})
+
+	if _, err := wrapper.CreateBatchAnchorOnChain(context.Background(), &AnchorOnChainRequest{BatchID: uuid.New().String(), Urgent: true}); err != nil {
+		t.Fatalf("CreateBatchAnchorOnChain failed: %v", err)
+	}
+	if !gotUrgent {
+		t.Error("urgent flag was not passed to the anchor manager")
+	}
+}
+
+// ============================================================================
+// Anchor Cost Estimation Tests
+// ============================================================================
+
+func TestAnchorAdapter_EstimateBatchAnchor(t *testing.T) {
+	created := false
+	wrapper := NewAnchorManagerWrapper(func(ctx context.Context, batchID string, merkleRoot, opCommit, crossCommit, govRoot []byte,
+		txCount int, accumHeight int64, accumHash, targetChain, validatorID string, urgent bool) (
+		string, int64, string, int64, string, string, AnchorFees, bool, bool, error) {
+		created = true
+		return "0xabc", 1, "0xblock", 21000, "1", "21000", AnchorFees{}, true, false, nil
+	})
+	adapter := NewAnchorAdapter(wrapper, nil)
+	req := &BatchAnchorRequest{BatchID: uuid.New(), MerkleRoot: sha256Sum("root"), TxCount: 1, Urgent: true}
+
+	if _, err := adapter.EstimateBatchAnchor(context.Background(), req); err == nil {
+		t.Error("expected error when estimation is not configured")
+	}
+
+	var got *AnchorOnChainRequest
+	wrapper.SetEstimateFunc(func(ctx context.Context, r *AnchorOnChainRequest) (*AnchorCostEstimate, error) {
+		got = r
+		return &AnchorCostEstimate{TargetChain: "ethereum", GasLimit: 90000, GasPriceWei: "5000000000", TotalCostWei: "450000000000000"}, nil
+	})
+	estimate, err := adapter.EstimateBatchAnchor(context.Background(), req)
+	if err != nil {
+		t.Fatalf("EstimateBatchAnchor failed: %v", err)
+	}
+	if estimate.GasLimit != 90000 || created {
+		t.Errorf("unexpected estimate %+v (anchor created: %v)", estimate, created)
+	}
+	if got == nil || !bytes.Equal(got.OperationCommitment, req.MerkleRoot) || len(got.CrossChainCommitment) != 32 ||
+		len(got.GovernanceRoot) != 32 || !got.Urgent {
+		t.Errorf("estimate request does not match the anchor request: %+v", got)
+	}
+}
+
+func TestFeeEscalationSteps_MarksMinedSubmission(t *testing.T) {
+	anchorID := uuid.New()
+	submitted := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	result := &BatchAnchorResult{
+		TxHash: "0xBBBB",
+		Fees: AnchorFees{Escalation: []FeeEscalationStep{
+			{Attempt: 1, TxHash: "0xaaaa", GasPriceWei: "10000000000", SubmittedAt: submitted},
+			{Attempt: 2, TxHash: "0xbbbb", GasPriceWei: "12500000000", SubmittedAt: submitted.Add(45 * time.Second)},
+		}},
+	}
+
+	steps := feeEscalationSteps(anchorID, result)
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(steps))
+	}
+	if steps[0].Mined || !steps[1].Mined {
+		t.Errorf("expected only the second submission mined, got %v and %v", steps[0].Mined, steps[1].Mined)
+	}
+	if steps[1].AnchorID != anchorID || steps[1].GasPriceWei != "12500000000" {
+		t.Errorf("unexpected step %+v", steps[1])
+	}
+
+	if steps := feeEscalationSteps(anchorID, &BatchAnchorResult{TxHash: "0xbbbb"}); len(steps) != 0 {
+		t.Errorf("expected no steps without escalation, got %d", len(steps))
+	}
+}
+
 // ============================================================================
 // Legacy Fallback Function Tests
 // ============================================================================
@@ -11,7 +11,9 @@ package batch
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"time"
 )
 
 // AnchorManagerWrapper wraps an anchor.AnchorManager to implement AnchorManagerInterface
@@ -20,14 +22,27 @@ type AnchorManagerWrapper struct {
 	// createFunc is the function that creates anchors on-chain
 	// We use a function reference instead of importing anchor package to avoid circular imports
 	createFunc func(ctx context.Context, batchID string, merkleRoot, opCommit, crossCommit, govRoot []byte,
-		txCount int, accumHeight int64, accumHash, targetChain, validatorID string) (
+		txCount int, accumHeight int64, accumHash, targetChain, validatorID string, urgent bool) (
 		txHash string, blockNumber int64, blockHash string, gasUsed int64,
-		gasPriceWei, totalCostWei string, success bool, err error)
+		gasPriceWei, totalCostWei string, fees AnchorFees, success, shadow bool, err error)
 
 	// executeProofFunc is the function that executes comprehensive proofs on-chain
 	// Per CRITICAL-001: This MUST be called after CreateBatchAnchorOnChain
 	executeProofFunc func(ctx context.Context, req interface{}) (interface{}, error)
 
+	// verifyProofFunc checks a proof against the contract's verifyCertenProofDetailed view
+	verifyProofFunc func(ctx context.Context, req interface{}) (interface{}, error)
+
+	// lookupAnchorFunc checks whether a batch is already anchored under its deterministic anchor ID
+	lookupAnchorFunc func(ctx context.Context, batchID string) (
+		exists bool, operationCommitment []byte, accumHeight int64, anchoredAt time.Time, err error)
+
+	// anchorStateFunc reads the full on-chain state of a batch's anchor for reconciliation
+	anchorStateFunc func(ctx context.Context, batchID string) (*OnChainAnchorState, error)
+
+	// estimateFunc prices a createAnchor transaction without submitting it
+	estimateFunc func(ctx context.Context, req *AnchorOnChainRequest) (*AnchorCostEstimate, error)
+
 	// logger for logging proof execution
 	logger *log.Logger
 }
@@ -37,9 +52,9 @@ type AnchorManagerWrapper struct {
 // Note: This constructor creates a wrapper without ExecuteComprehensiveProof support
 // Use NewAnchorManagerWrapperFull for complete Phase 1 CRITICAL-001 compliance
 func NewAnchorManagerWrapper(createFunc func(ctx context.Context, batchID string, merkleRoot, opCommit, crossCommit, govRoot []byte,
-	txCount int, accumHeight int64, accumHash, targetChain, validatorID string) (
+	txCount int, accumHeight int64, accumHash, targetChain, validatorID string, urgent bool) (
 	txHash string, blockNumber int64, blockHash string, gasUsed int64,
-	gasPriceWei, totalCostWei string, success bool, err error)) *AnchorManagerWrapper {
+	gasPriceWei, totalCostWei string, fees AnchorFees, success, shadow bool, err error)) *AnchorManagerWrapper {
 	return &AnchorManagerWrapper{
 		createFunc: createFunc,
 		logger:     log.New(log.Writer(), "[AnchorWrapper] ", log.LstdFlags),
@@ -50,9 +65,9 @@ func NewAnchorManagerWrapper(createFunc func(ctx context.Context, batchID string
 // Per CRITICAL-001: ExecuteComprehensiveProof MUST be called after CreateBatchAnchorOnChain
 func NewAnchorManagerWrapperFull(
 	createFunc func(ctx context.Context, batchID string, merkleRoot, opCommit, crossCommit, govRoot []byte,
-		txCount int, accumHeight int64, accumHash, targetChain, validatorID string) (
+		txCount int, accumHeight int64, accumHash, targetChain, validatorID string, urgent bool) (
 		txHash string, blockNumber int64, blockHash string, gasUsed int64,
-		gasPriceWei, totalCostWei string, success bool, err error),
+		gasPriceWei, totalCostWei string, fees AnchorFees, success, shadow bool, err error),
 	executeProofFunc func(ctx context.Context, req interface{}) (interface{}, error),
 	logger *log.Logger,
 ) *AnchorManagerWrapper {
@@ -71,9 +86,33 @@ func (w *AnchorManagerWrapper) SetExecuteProofFunc(f func(ctx context.Context, r
 	w.executeProofFunc = f
 }
 
+// SetVerifyProofFunc sets the on-chain proof verification function (for late binding)
+func (w *AnchorManagerWrapper) SetVerifyProofFunc(f func(ctx context.Context, req interface{}) (interface{}, error)) {
+	w.verifyProofFunc = f
+}
+
+// SetLookupAnchorFunc sets the on-chain anchor lookup function (for late binding)
+// The function should call anchor.AnchorManager.LookupBatchAnchorOnChain internally
+func (w *AnchorManagerWrapper) SetLookupAnchorFunc(f func(ctx context.Context, batchID string) (
+	exists bool, operationCommitment []byte, accumHeight int64, anchoredAt time.Time, err error)) {
+	w.lookupAnchorFunc = f
+}
+
+// SetAnchorStateFunc sets the on-chain anchor state function (for late binding)
+// The function should call anchor.AnchorManager.GetBatchAnchorState internally
+func (w *AnchorManagerWrapper) SetAnchorStateFunc(f func(ctx context.Context, batchID string) (*OnChainAnchorState, error)) {
+	w.anchorStateFunc = f
+}
+
+// SetEstimateFunc sets the anchor cost estimation function (for late binding)
+// The function should call anchor.AnchorManager.EstimateBatchAnchorOnChain internally
+func (w *AnchorManagerWrapper) SetEstimateFunc(f func(ctx context.Context, req *AnchorOnChainRequest) (*AnchorCostEstimate, error)) {
+	w.estimateFunc = f
+}
+
 // CreateBatchAnchorOnChain implements AnchorManagerInterface
 func (w *AnchorManagerWrapper) CreateBatchAnchorOnChain(ctx context.Context, req *AnchorOnChainRequest) (*AnchorOnChainResult, error) {
-	txHash, blockNumber, blockHash, gasUsed, gasPriceWei, totalCostWei, success, err := w.createFunc(
+	txHash, blockNumber, blockHash, gasUsed, gasPriceWei, totalCostWei, fees, success, shadow, err := w.createFunc(
 		ctx,
 		req.BatchID,
 		req.MerkleRoot,
@@ -85,6 +124,7 @@ func (w *AnchorManagerWrapper) CreateBatchAnchorOnChain(ctx context.Context, req
 		req.AccumulateHash,
 		req.TargetChain,
 		req.ValidatorID,
+		req.Urgent,
 	)
 	if err != nil {
 		return nil, err
@@ -98,6 +138,8 @@ func (w *AnchorManagerWrapper) CreateBatchAnchorOnChain(ctx context.Context, req
 		GasPriceWei:  gasPriceWei,
 		TotalCostWei: totalCostWei,
 		Success:      success,
+		Fees:         fees,
+		Shadow:       shadow,
 	}, nil
 }
 
@@ -116,3 +158,44 @@ func (w *AnchorManagerWrapper) ExecuteComprehensiveProofOnChain(ctx context.Cont
 
 	return w.executeProofFunc(ctx, req)
 }
+
+// VerifyComprehensiveProofOnChain implements ProofVerifierInterface
+func (w *AnchorManagerWrapper) VerifyComprehensiveProofOnChain(ctx context.Context, req interface{}) (interface{}, error) {
+	if w.verifyProofFunc == nil {
+		return nil, fmt.Errorf("on-chain proof verification not configured")
+	}
+	return w.verifyProofFunc(ctx, req)
+}
+
+// LookupBatchAnchorOnChain implements OnChainAnchorLookup
+func (w *AnchorManagerWrapper) LookupBatchAnchorOnChain(ctx context.Context, batchID string) (*OnChainAnchorStatus, error) {
+	if w.lookupAnchorFunc == nil {
+		return nil, fmt.Errorf("on-chain anchor lookup not configured")
+	}
+	exists, opCommit, accumHeight, anchoredAt, err := w.lookupAnchorFunc(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return &OnChainAnchorStatus{
+		Exists:           exists,
+		MerkleRoot:       opCommit,
+		AccumulateHeight: accumHeight,
+		AnchoredAt:       anchoredAt,
+	}, nil
+}
+
+// GetBatchAnchorStateOnChain implements OnChainAnchorStateLookup
+func (w *AnchorManagerWrapper) GetBatchAnchorStateOnChain(ctx context.Context, batchID string) (*OnChainAnchorState, error) {
+	if w.anchorStateFunc == nil {
+		return nil, fmt.Errorf("on-chain anchor state lookup not configured")
+	}
+	return w.anchorStateFunc(ctx, batchID)
+}
+
+// EstimateBatchAnchorOnChain implements AnchorEstimatorInterface
+func (w *AnchorManagerWrapper) EstimateBatchAnchorOnChain(ctx context.Context, req *AnchorOnChainRequest) (*AnchorCostEstimate, error) {
+	if w.estimateFunc == nil {
+		return nil, fmt.Errorf("anchor cost estimation not configured")
+	}
+	return w.estimateFunc(ctx, req)
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 Certen Protocol
+//
+// Anchor Reconciler - Detects batches that were anchored on-chain but not recorded locally
+//
+// The processor creates the on-chain anchor before it stores the anchor record and marks
+// the batch anchored. A crash in between leaves a batch that is closed (or failed, if the
+// anchor call errored after the transaction landed) even though its anchor exists on-chain;
+// re-anchoring it reverts with "Anchor already exists" and wastes gas.
+//
+// On startup the reconciler checks every batch in such an ambiguous state against the
+// contract using the deterministic anchor ID (the batch ID). Batches whose on-chain anchor
+// carries the local Merkle root are marked anchored so they are never re-submitted.
+// Batches still anchoring without an on-chain anchor were interrupted before their anchor
+// transaction landed; they are marked failed and reported so they can be retried.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// ReconciledAnchorNote is stored as the batch status message of reconciled batches
+const ReconciledAnchorNote = "reconciled: anchor found on-chain after restart"
+
+// InterruptedAnchorNote is stored as the error message of batches whose anchor submission
+// was interrupted before the anchor landed on-chain
+const InterruptedAnchorNote = "interrupted: anchor submission did not complete before restart"
+
+// ambiguousBatchStatuses are the statuses a batch can be left in when the process stops
+// between anchor submission and recording the anchor
+var ambiguousBatchStatuses = []database.BatchStatus{
+	database.BatchStatusClosed,
+	database.BatchStatusAnchoring,
+	database.BatchStatusFailed,
+}
+
+// OnChainAnchorStatus is the on-chain state of a batch's deterministic anchor ID
+type OnChainAnchorStatus struct {
+	Exists           bool
+	MerkleRoot       []byte // Operation commitment stored on-chain (= batch Merkle root)
+	AccumulateHeight int64
+	AnchoredAt       time.Time
+}
+
+// OnChainAnchorLookup looks up a batch's anchor on the target chain
+// Implemented by AnchorManagerWrapper
+type OnChainAnchorLookup interface {
+	LookupBatchAnchorOnChain(ctx context.Context, batchID string) (*OnChainAnchorStatus, error)
+}
+
+// AnchorReconcileStore is the batch storage the reconciler reads and updates
+// Implemented by database.BatchRepository
+type AnchorReconcileStore interface {
+	BatchTransitionStore
+	GetBatchesByStatus(ctx context.Context, statuses []database.BatchStatus, since time.Time) ([]*database.AnchorBatch, error)
+}
+
+// AnchorReconcileReport summarizes a reconciliation run
+type AnchorReconcileReport struct {
+	Checked        int         `json:"checked"`
+	MarkedAnchored []uuid.UUID `json:"marked_anchored,omitempty"`
+	NotAnchored    int         `json:"not_anchored"`            // No on-chain anchor - left for re-submission
+	Interrupted    []uuid.UUID `json:"interrupted,omitempty"`   // Were anchoring without an on-chain anchor - marked failed for retry
+	RootMismatch   []uuid.UUID `json:"root_mismatch,omitempty"` // On-chain anchor with a different Merkle root
+	Errors         int         `json:"errors"`
+}
+
+// AnchorReconcilerConfig holds configuration for the anchor reconciler
+type AnchorReconcilerConfig struct {
+	MaxAge time.Duration // Only batches updated within MaxAge are checked (0 = all)
+	Logger *log.Logger
+}
+
+// DefaultAnchorReconcilerConfig returns default configuration
+func DefaultAnchorReconcilerConfig() *AnchorReconcilerConfig {
+	return &AnchorReconcilerConfig{
+		MaxAge: 7 * 24 * time.Hour,
+		Logger: log.New(log.Writer(), "[AnchorReconciler] ", log.LstdFlags),
+	}
+}
+
+// AnchorReconciler marks batches anchored when their anchor already exists on-chain
+type AnchorReconciler struct {
+	store  AnchorReconcileStore
+	lookup OnChainAnchorLookup
+	maxAge time.Duration
+	now    func() time.Time
+	logger *log.Logger
+}
+
+// NewAnchorReconciler creates a new anchor reconciler
+func NewAnchorReconciler(store AnchorReconcileStore, lookup OnChainAnchorLookup, cfg *AnchorReconcilerConfig) (*AnchorReconciler, error) {
+	if store == nil {
+		return nil, fmt.Errorf("anchor reconcile store cannot be nil")
+	}
+	if lookup == nil {
+		return nil, fmt.Errorf("on-chain anchor lookup cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultAnchorReconcilerConfig()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[AnchorReconciler] ", log.LstdFlags)
+	}
+
+	return &AnchorReconciler{
+		store:  store,
+		lookup: lookup,
+		maxAge: cfg.MaxAge,
+		now:    time.Now,
+		logger: cfg.Logger,
+	}, nil
+}
+
+// Reconcile checks all ambiguous batches on-chain and marks those already anchored
+// Lookup failures are counted and logged; the batch is left untouched
+func (r *AnchorReconciler) Reconcile(ctx context.Context) (*AnchorReconcileReport, error) {
+	var since time.Time
+	if r.maxAge > 0 {
+		since = r.now().Add(-r.maxAge)
+	}
+
+	batches, err := r.store.GetBatchesByStatus(ctx, ambiguousBatchStatuses, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batches for reconciliation: %w", err)
+	}
+
+	report := &AnchorReconcileReport{}
+	for _, batch := range batches {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		// Batches without a Merkle root never reached anchor submission
+		if len(batch.MerkleRoot) == 0 {
+			continue
+		}
+		report.Checked++
+
+		status, err := r.lookup.LookupBatchAnchorOnChain(ctx, batch.BatchID.String())
+		if err != nil {
+			report.Errors++
+			r.logger.Printf("⚠️ Failed to look up on-chain anchor for batch %s: %v", batch.BatchID, err)
+			continue
+		}
+		if status == nil || !status.Exists {
+			report.NotAnchored++
+			if batch.Status == database.BatchStatusAnchoring {
+				if err := transitionBatch(ctx, r.store, batch.BatchID, database.BatchStatusFailed, InterruptedAnchorNote); err != nil {
+					report.Errors++
+					r.logger.Printf("⚠️ Failed to mark interrupted batch %s failed: %v", batch.BatchID, err)
+					continue
+				}
+				report.Interrupted = append(report.Interrupted, batch.BatchID)
+				r.logger.Printf("🔁 Batch %s was interrupted while anchoring and has no on-chain anchor - marked failed for retry",
+					batch.BatchID)
+			}
+			continue
+		}
+
+		if !bytes.Equal(status.MerkleRoot, batch.MerkleRoot) {
+			report.RootMismatch = append(report.RootMismatch, batch.BatchID)
+			r.logger.Printf("❌ Batch %s has an on-chain anchor with a different Merkle root (local=%x, on-chain=%x) - leaving status %s",
+				batch.BatchID, batch.MerkleRoot, status.MerkleRoot, batch.Status)
+			continue
+		}
+
+		if err := transitionBatch(ctx, r.store, batch.BatchID, database.BatchStatusAnchored, ReconciledAnchorNote); err != nil {
+			report.Errors++
+			r.logger.Printf("⚠️ Failed to mark batch %s anchored: %v", batch.BatchID, err)
+			continue
+		}
+		report.MarkedAnchored = append(report.MarkedAnchored, batch.BatchID)
+		r.logger.Printf("✅ Batch %s already anchored on-chain (was %s, height=%d, at=%s) - skipping re-submission",
+			batch.BatchID, batch.Status, status.AccumulateHeight, status.AnchoredAt.UTC().Format(time.RFC3339))
+	}
+
+	r.logger.Printf("Reconciliation complete: checked=%d, marked_anchored=%d, not_anchored=%d, interrupted=%d, root_mismatch=%d, errors=%d",
+		report.Checked, len(report.MarkedAnchored), report.NotAnchored, len(report.Interrupted), len(report.RootMismatch), report.Errors)
+	return report, nil
+}
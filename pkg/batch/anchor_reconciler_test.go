@@ -0,0 +1,130 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Anchor Reconciler
+// Tests detection of batches already anchored on-chain, interrupted anchor submissions
+// and the statuses they are left in
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// stubReconcileStore returns fixed batches and records guarded status updates
+type stubReconcileStore struct {
+	batches []*database.AnchorBatch
+	since   time.Time
+	updated map[uuid.UUID]database.BatchStatus
+}
+
+func (s *stubReconcileStore) GetBatchesByStatus(ctx context.Context, statuses []database.BatchStatus, since time.Time) ([]*database.AnchorBatch, error) {
+	s.since = since
+	return s.batches, nil
+}
+
+func (s *stubReconcileStore) TransitionBatchStatus(ctx context.Context, batchID uuid.UUID, fromStatuses []database.BatchStatus, status database.BatchStatus, errorMsg string) (bool, error) {
+	for _, batch := range s.batches {
+		if batch.BatchID != batchID {
+			continue
+		}
+		for _, from := range fromStatuses {
+			if batch.Status == from {
+				if s.updated == nil {
+					s.updated = make(map[uuid.UUID]database.BatchStatus)
+				}
+				s.updated[batchID] = status
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// stubAnchorLookup answers lookups from a map keyed by batch ID
+type stubAnchorLookup struct {
+	anchors map[string]*OnChainAnchorStatus
+	errs    map[string]error
+}
+
+func (l *stubAnchorLookup) LookupBatchAnchorOnChain(ctx context.Context, batchID string) (*OnChainAnchorStatus, error) {
+	if err := l.errs[batchID]; err != nil {
+		return nil, err
+	}
+	if status, ok := l.anchors[batchID]; ok {
+		return status, nil
+	}
+	return &OnChainAnchorStatus{}, nil
+}
+
+func TestAnchorReconciler_Reconcile(t *testing.T) {
+	root := sha256Sum("root")
+	anchored := &database.AnchorBatch{BatchID: uuid.New(), MerkleRoot: root, Status: database.BatchStatusClosed}
+	failed := &database.AnchorBatch{BatchID: uuid.New(), MerkleRoot: root, Status: database.BatchStatusFailed}
+	missing := &database.AnchorBatch{BatchID: uuid.New(), MerkleRoot: root, Status: database.BatchStatusClosed}
+	mismatch := &database.AnchorBatch{BatchID: uuid.New(), MerkleRoot: root, Status: database.BatchStatusClosed}
+	lookupErr := &database.AnchorBatch{BatchID: uuid.New(), MerkleRoot: root, Status: database.BatchStatusAnchoring}
+	noRoot := &database.AnchorBatch{BatchID: uuid.New(), Status: database.BatchStatusClosed}
+	interrupted := &database.AnchorBatch{BatchID: uuid.New(), MerkleRoot: root, Status: database.BatchStatusAnchoring}
+
+	store := &stubReconcileStore{batches: []*database.AnchorBatch{anchored, failed, missing, mismatch, lookupErr, noRoot, interrupted}}
+	lookup := &stubAnchorLookup{
+		anchors: map[string]*OnChainAnchorStatus{
+			anchored.BatchID.String(): {Exists: true, MerkleRoot: root},
+			failed.BatchID.String():   {Exists: true, MerkleRoot: root},
+			mismatch.BatchID.String(): {Exists: true, MerkleRoot: sha256Sum("other")},
+		},
+		errs: map[string]error{lookupErr.BatchID.String(): errors.New("rpc unavailable")},
+	}
+
+	r, err := NewAnchorReconciler(store, lookup, nil)
+	if err != nil {
+		t.Fatalf("NewAnchorReconciler failed: %v", err)
+	}
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	report, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if report.Checked != 6 || report.NotAnchored != 2 || report.Errors != 1 {
+		t.Errorf("unexpected report counts: %+v", report)
+	}
+	if len(report.MarkedAnchored) != 2 || len(report.RootMismatch) != 1 || report.RootMismatch[0] != mismatch.BatchID {
+		t.Errorf("expected 2 anchored and 1 mismatch, got %+v", report)
+	}
+	if len(report.Interrupted) != 1 || report.Interrupted[0] != interrupted.BatchID {
+		t.Errorf("expected the anchoring batch without an on-chain anchor to be interrupted, got %+v", report)
+	}
+	if len(store.updated) != 3 ||
+		store.updated[anchored.BatchID] != database.BatchStatusAnchored ||
+		store.updated[failed.BatchID] != database.BatchStatusAnchored ||
+		store.updated[interrupted.BatchID] != database.BatchStatusFailed {
+		t.Errorf("expected matching batches anchored and the interrupted one failed, got %v", store.updated)
+	}
+	if !store.since.Equal(now.Add(-DefaultAnchorReconcilerConfig().MaxAge)) {
+		t.Errorf("expected lookback of MaxAge, got since=%s", store.since)
+	}
+}
+
+func TestAnchorReconciler_NoMaxAge(t *testing.T) {
+	store := &stubReconcileStore{}
+	r, _ := NewAnchorReconciler(store, &stubAnchorLookup{}, &AnchorReconcilerConfig{})
+	if _, err := r.Reconcile(context.Background()); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if !store.since.IsZero() {
+		t.Errorf("expected no lookback limit, got since=%s", store.since)
+	}
+
+	if _, err := NewAnchorReconciler(nil, &stubAnchorLookup{}, nil); err == nil {
+		t.Error("expected error for nil store")
+	}
+}
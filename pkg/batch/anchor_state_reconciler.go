@@ -0,0 +1,387 @@
+// Copyright 2025 Certen Protocol
+//
+// Anchor State Reconciler - Periodic audit of local anchor records against the chain
+//
+// Local anchor records can drift from the anchor contract: a reorg drops an anchor that
+// was recorded as mined, an operator calls invalidateAnchor, or a proof execution that
+// was recorded locally never reached the contract. Every check interval the reconciler
+// samples mined anchor records at random and compares each with the contract's
+// anchorExists, getAnchor and getVerificationStats views.
+//
+// Each disagreement is flagged as a discrepancy (one per anchor and kind) rather than
+// corrected: the local record keeps what this validator submitted, the discrepancy
+// records what the chain says. Anchors with open discrepancies are re-checked on every
+// sweep, and a discrepancy is resolved once the chain agrees with the record again.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// maxRecheckedDiscrepancies bounds how many open discrepancies are re-checked per sweep
+const maxRecheckedDiscrepancies = 500
+
+// OnChainAnchorState is the full on-chain record of a batch's deterministic anchor ID
+type OnChainAnchorState struct {
+	Exists                  bool
+	Valid                   bool   // Cleared by invalidateAnchor
+	OperationCommitment     []byte // = batch Merkle root
+	AccumulateHeight        int64
+	Verifications           uint64 // executeComprehensiveProof calls (getVerificationStats)
+	SuccessfulVerifications uint64
+}
+
+// OnChainAnchorStateLookup reads a batch anchor's on-chain state
+// Implemented by AnchorManagerWrapper
+type OnChainAnchorStateLookup interface {
+	GetBatchAnchorStateOnChain(ctx context.Context, batchID string) (*OnChainAnchorState, error)
+}
+
+// AnchorStateStore is the anchor storage the reconciler samples and flags
+// Implemented by database.AnchorRepository
+type AnchorStateStore interface {
+	SampleAnchorsForReconciliation(ctx context.Context, chain database.TargetChain, createdBefore time.Time, limit int) ([]*database.AnchorRecord, error)
+	GetAnchor(ctx context.Context, anchorID uuid.UUID) (*database.AnchorRecord, error)
+	RecordAnchorDiscrepancy(ctx context.Context, d *database.AnchorStateDiscrepancy) error
+	ResolveAnchorDiscrepancies(ctx context.Context, anchorID uuid.UUID, stillOpen []database.AnchorDiscrepancyKind) (int64, error)
+	ListAnchorDiscrepancies(ctx context.Context, includeResolved bool, limit, offset int) ([]database.AnchorStateDiscrepancy, error)
+	CountOpenAnchorDiscrepancies(ctx context.Context) (map[database.AnchorDiscrepancyKind]int64, error)
+}
+
+// AnchorStateReconcileReport summarizes a reconciliation sweep
+type AnchorStateReconcileReport struct {
+	Checked       int                                    `json:"checked"`
+	Rechecked     int                                    `json:"rechecked"` // Anchors re-checked for open discrepancies
+	Consistent    int                                    `json:"consistent"`
+	Discrepancies map[database.AnchorDiscrepancyKind]int `json:"discrepancies,omitempty"` // Detected this sweep, by kind
+	Resolved      int64                                  `json:"resolved"`
+	Errors        int                                    `json:"errors"`
+}
+
+// AnchorStateReconcilerStatus reports the reconciler's policy, open discrepancies and last sweep
+type AnchorStateReconcilerStatus struct {
+	SampleSize        int                                      `json:"sample_size"`
+	CheckInterval     string                                   `json:"check_interval"`
+	MinAge            string                                   `json:"min_age"`
+	OpenDiscrepancies int64                                    `json:"open_discrepancies"`
+	OpenByKind        map[database.AnchorDiscrepancyKind]int64 `json:"open_by_kind,omitempty"`
+	TotalChecked      int64                                    `json:"total_checked"` // Anchors checked since startup
+	LastSweepAt       *time.Time                               `json:"last_sweep_at,omitempty"`
+	LastSweep         *AnchorStateReconcileReport              `json:"last_sweep,omitempty"`
+}
+
+// AnchorStateReconcilerConfig holds configuration for the anchor state reconciler
+type AnchorStateReconcilerConfig struct {
+	SampleSize    int           // Anchors sampled per sweep
+	CheckInterval time.Duration // How often a sweep runs
+	MinAge        time.Duration // Anchors younger than this are not sampled (left to the confirmation tracker)
+	Logger        *log.Logger
+}
+
+// DefaultAnchorStateReconcilerConfig returns default configuration
+func DefaultAnchorStateReconcilerConfig() *AnchorStateReconcilerConfig {
+	return &AnchorStateReconcilerConfig{
+		SampleSize:    25,
+		CheckInterval: 30 * time.Minute,
+		MinAge:        time.Hour,
+		Logger:        log.New(log.Writer(), "[AnchorStateReconciler] ", log.LstdFlags),
+	}
+}
+
+// AnchorStateReconciler flags local anchor records that disagree with on-chain state
+type AnchorStateReconciler struct {
+	mu sync.Mutex
+
+	store         AnchorStateStore
+	lookup        OnChainAnchorStateLookup
+	sampleSize    int
+	checkInterval time.Duration
+	minAge        time.Duration
+	now           func() time.Time
+	logger        *log.Logger
+
+	openByKind   map[database.AnchorDiscrepancyKind]int64
+	totalChecked int64
+	lastSweepAt  time.Time
+	lastSweep    *AnchorStateReconcileReport
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewAnchorStateReconciler creates a new anchor state reconciler
+func NewAnchorStateReconciler(store AnchorStateStore, lookup OnChainAnchorStateLookup, cfg *AnchorStateReconcilerConfig) (*AnchorStateReconciler, error) {
+	if store == nil {
+		return nil, fmt.Errorf("anchor state store cannot be nil")
+	}
+	if lookup == nil {
+		return nil, fmt.Errorf("on-chain anchor state lookup cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultAnchorStateReconcilerConfig()
+	}
+	if cfg.SampleSize <= 0 {
+		return nil, fmt.Errorf("sample size must be positive")
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = DefaultAnchorStateReconcilerConfig().CheckInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[AnchorStateReconciler] ", log.LstdFlags)
+	}
+
+	return &AnchorStateReconciler{
+		store:         store,
+		lookup:        lookup,
+		sampleSize:    cfg.SampleSize,
+		checkInterval: cfg.CheckInterval,
+		minAge:        cfg.MinAge,
+		now:           time.Now,
+		logger:        cfg.Logger,
+	}, nil
+}
+
+// Start starts the periodic sweep loop
+func (r *AnchorStateReconciler) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopCh != nil {
+		return
+	}
+	r.stopCh = make(chan struct{})
+	r.doneCh = make(chan struct{})
+
+	go r.run(ctx, r.stopCh, r.doneCh)
+	r.logger.Printf("Started (sample=%d, check=%s, min age=%s)", r.sampleSize, r.checkInterval, r.minAge)
+}
+
+// Stop stops the sweep loop
+func (r *AnchorStateReconciler) Stop() {
+	r.mu.Lock()
+	stopCh, doneCh := r.stopCh, r.doneCh
+	r.stopCh, r.doneCh = nil, nil
+	r.mu.Unlock()
+
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	<-doneCh
+}
+
+func (r *AnchorStateReconciler) run(ctx context.Context, stopCh, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if _, err := r.Sweep(ctx); err != nil {
+				r.logger.Printf("⚠️ Anchor state reconciliation failed: %v", err)
+			}
+		}
+	}
+}
+
+// Sweep re-checks anchors with open discrepancies, then checks a random sample of mined
+// anchors. Lookup and storage failures on individual anchors are counted and logged.
+func (r *AnchorStateReconciler) Sweep(ctx context.Context) (*AnchorStateReconcileReport, error) {
+	report := &AnchorStateReconcileReport{Discrepancies: make(map[database.AnchorDiscrepancyKind]int)}
+	checked := make(map[uuid.UUID]bool)
+
+	open, err := r.store.ListAnchorDiscrepancies(ctx, false, maxRecheckedDiscrepancies, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open anchor discrepancies: %w", err)
+	}
+	for _, d := range open {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if checked[d.AnchorID] {
+			continue
+		}
+		checked[d.AnchorID] = true
+
+		record, err := r.store.GetAnchor(ctx, d.AnchorID)
+		if err != nil {
+			report.Errors++
+			r.logger.Printf("⚠️ Failed to load anchor %s for re-check: %v", d.AnchorID, err)
+			continue
+		}
+		report.Rechecked++
+		r.check(ctx, record, report)
+	}
+
+	sample, err := r.store.SampleAnchorsForReconciliation(ctx, database.TargetChainEthereum, r.now().Add(-r.minAge), r.sampleSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample anchors: %w", err)
+	}
+	for _, record := range sample {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if checked[record.AnchorID] {
+			continue
+		}
+		checked[record.AnchorID] = true
+		report.Checked++
+		r.check(ctx, record, report)
+	}
+
+	openByKind, err := r.store.CountOpenAnchorDiscrepancies(ctx)
+	if err != nil {
+		report.Errors++
+		r.logger.Printf("⚠️ Failed to count open anchor discrepancies: %v", err)
+	}
+
+	r.mu.Lock()
+	if openByKind != nil {
+		r.openByKind = openByKind
+	}
+	r.totalChecked += int64(report.Checked + report.Rechecked)
+	r.lastSweepAt = r.now()
+	r.lastSweep = report
+	r.mu.Unlock()
+
+	if len(report.Discrepancies) > 0 || report.Resolved > 0 || report.Errors > 0 {
+		r.logger.Printf("Sweep complete: checked=%d, rechecked=%d, consistent=%d, discrepancies=%v, resolved=%d, errors=%d",
+			report.Checked, report.Rechecked, report.Consistent, report.Discrepancies, report.Resolved, report.Errors)
+	}
+	return report, nil
+}
+
+// check compares one anchor record with the chain, flags each discrepancy found and
+// resolves the anchor's discrepancies that no longer hold
+func (r *AnchorStateReconciler) check(ctx context.Context, record *database.AnchorRecord, report *AnchorStateReconcileReport) {
+	state, err := r.lookup.GetBatchAnchorStateOnChain(ctx, record.BatchID.String())
+	if err != nil {
+		report.Errors++
+		r.logger.Printf("⚠️ Failed to read on-chain state of anchor %s (batch %s): %v", record.AnchorID, record.BatchID, err)
+		return
+	}
+
+	found := compareAnchorState(record, state)
+	kinds := make([]database.AnchorDiscrepancyKind, 0, len(found))
+	for _, d := range found {
+		if err := r.store.RecordAnchorDiscrepancy(ctx, d); err != nil {
+			report.Errors++
+			r.logger.Printf("⚠️ Failed to record %s discrepancy for anchor %s: %v", d.Kind, record.AnchorID, err)
+			return
+		}
+		kinds = append(kinds, d.Kind)
+		report.Discrepancies[d.Kind]++
+		r.logger.Printf("❌ Anchor %s (batch %s): %s - %s", record.AnchorID, record.BatchID, d.Kind, d.Detail)
+	}
+	if len(found) == 0 {
+		report.Consistent++
+	}
+
+	resolved, err := r.store.ResolveAnchorDiscrepancies(ctx, record.AnchorID, kinds)
+	if err != nil {
+		report.Errors++
+		r.logger.Printf("⚠️ Failed to resolve discrepancies for anchor %s: %v", record.AnchorID, err)
+		return
+	}
+	if resolved > 0 {
+		report.Resolved += resolved
+		r.logger.Printf("✅ Anchor %s (batch %s): %d discrepancies resolved", record.AnchorID, record.BatchID, resolved)
+	}
+}
+
+// compareAnchorState returns the discrepancies between a local anchor record and its on-chain state
+func compareAnchorState(record *database.AnchorRecord, state *OnChainAnchorState) []*database.AnchorStateDiscrepancy {
+	var found []*database.AnchorStateDiscrepancy
+	flag := func(kind database.AnchorDiscrepancyKind, format string, args ...interface{}) {
+		found = append(found, &database.AnchorStateDiscrepancy{
+			AnchorID: record.AnchorID,
+			Kind:     kind,
+			BatchID:  record.BatchID,
+			Detail:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	if state == nil || !state.Exists {
+		flag(database.AnchorDiscrepancyMissing, "anchor recorded in tx %s (block %d) does not exist on-chain",
+			record.AnchorTxHash, record.AnchorBlockNumber)
+		return found
+	}
+	if !state.Valid {
+		flag(database.AnchorDiscrepancyInvalidated, "anchor was invalidated on-chain")
+	}
+
+	localCommitment := record.OperationCommitment
+	if len(localCommitment) == 0 {
+		localCommitment = record.MerkleRoot
+	}
+	if len(localCommitment) > 0 && !bytes.Equal(localCommitment, state.OperationCommitment) {
+		flag(database.AnchorDiscrepancyCommitmentMismatch, "operation commitment local=%x on-chain=%x",
+			localCommitment, state.OperationCommitment)
+	}
+	if record.AccumHeight.Valid && record.AccumHeight.Int64 != state.AccumulateHeight {
+		flag(database.AnchorDiscrepancyHeightMismatch, "accumulate height local=%d on-chain=%d",
+			record.AccumHeight.Int64, state.AccumulateHeight)
+	}
+	if record.ProofGasUsed.Valid && state.Verifications == 0 {
+		flag(database.AnchorDiscrepancyProofNotRecorded, "proof execution used %d gas but the contract records no verification",
+			record.ProofGasUsed.Int64)
+	}
+	return found
+}
+
+// ListDiscrepancies returns discrepancies, most recently detected first
+func (r *AnchorStateReconciler) ListDiscrepancies(ctx context.Context, includeResolved bool, limit, offset int) ([]database.AnchorStateDiscrepancy, error) {
+	return r.store.ListAnchorDiscrepancies(ctx, includeResolved, limit, offset)
+}
+
+// OpenDiscrepancies returns the number of unresolved discrepancies as of the last sweep
+func (r *AnchorStateReconciler) OpenDiscrepancies() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var total int64
+	for _, count := range r.openByKind {
+		total += count
+	}
+	return total
+}
+
+// Status returns the reconciliation policy, open discrepancies and the result of the last sweep
+func (r *AnchorStateReconciler) Status() AnchorStateReconcilerStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := AnchorStateReconcilerStatus{
+		SampleSize:    r.sampleSize,
+		CheckInterval: r.checkInterval.String(),
+		MinAge:        r.minAge.String(),
+		TotalChecked:  r.totalChecked,
+		LastSweep:     r.lastSweep,
+	}
+	if len(r.openByKind) > 0 {
+		status.OpenByKind = make(map[database.AnchorDiscrepancyKind]int64, len(r.openByKind))
+		for kind, count := range r.openByKind {
+			status.OpenByKind[kind] = count
+			status.OpenDiscrepancies += count
+		}
+	}
+	if !r.lastSweepAt.IsZero() {
+		at := r.lastSweepAt
+		status.LastSweepAt = &at
+	}
+	return status
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Anchor State Reconciler
+// Tests discrepancy detection, re-checks of open discrepancies and their resolution
+
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// memoryAnchorStateStore keeps anchor records and discrepancies in memory
+type memoryAnchorStateStore struct {
+	anchors       map[uuid.UUID]*database.AnchorRecord
+	sample        []*database.AnchorRecord
+	discrepancies map[uuid.UUID]map[database.AnchorDiscrepancyKind]bool // true = open
+}
+
+func newMemoryAnchorStateStore(records ...*database.AnchorRecord) *memoryAnchorStateStore {
+	s := &memoryAnchorStateStore{
+		anchors:       make(map[uuid.UUID]*database.AnchorRecord),
+		sample:        records,
+		discrepancies: make(map[uuid.UUID]map[database.AnchorDiscrepancyKind]bool),
+	}
+	for _, record := range records {
+		s.anchors[record.AnchorID] = record
+	}
+	return s
+}
+
+func (s *memoryAnchorStateStore) SampleAnchorsForReconciliation(ctx context.Context, chain database.TargetChain, createdBefore time.Time, limit int) ([]*database.AnchorRecord, error) {
+	return s.sample, nil
+}
+
+func (s *memoryAnchorStateStore) GetAnchor(ctx context.Context, anchorID uuid.UUID) (*database.AnchorRecord, error) {
+	if record, ok := s.anchors[anchorID]; ok {
+		return record, nil
+	}
+	return nil, database.ErrAnchorNotFound
+}
+
+func (s *memoryAnchorStateStore) RecordAnchorDiscrepancy(ctx context.Context, d *database.AnchorStateDiscrepancy) error {
+	if s.discrepancies[d.AnchorID] == nil {
+		s.discrepancies[d.AnchorID] = make(map[database.AnchorDiscrepancyKind]bool)
+	}
+	s.discrepancies[d.AnchorID][d.Kind] = true
+	return nil
+}
+
+func (s *memoryAnchorStateStore) ResolveAnchorDiscrepancies(ctx context.Context, anchorID uuid.UUID, stillOpen []database.AnchorDiscrepancyKind) (int64, error) {
+	keep := make(map[database.AnchorDiscrepancyKind]bool)
+	for _, kind := range stillOpen {
+		keep[kind] = true
+	}
+	var resolved int64
+	for kind, open := range s.discrepancies[anchorID] {
+		if open && !keep[kind] {
+			s.discrepancies[anchorID][kind] = false
+			resolved++
+		}
+	}
+	return resolved, nil
+}
+
+func (s *memoryAnchorStateStore) ListAnchorDiscrepancies(ctx context.Context, includeResolved bool, limit, offset int) ([]database.AnchorStateDiscrepancy, error) {
+	var list []database.AnchorStateDiscrepancy
+	for anchorID, kinds := range s.discrepancies {
+		for kind, open := range kinds {
+			if open || includeResolved {
+				d := database.AnchorStateDiscrepancy{AnchorID: anchorID, Kind: kind}
+				if !open {
+					d.ResolvedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				}
+				list = append(list, d)
+			}
+		}
+	}
+	return list, nil
+}
+
+func (s *memoryAnchorStateStore) CountOpenAnchorDiscrepancies(ctx context.Context) (map[database.AnchorDiscrepancyKind]int64, error) {
+	counts := make(map[database.AnchorDiscrepancyKind]int64)
+	for _, kinds := range s.discrepancies {
+		for kind, open := range kinds {
+			if open {
+				counts[kind]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// stubAnchorStateLookup answers state lookups from a map keyed by batch ID
+type stubAnchorStateLookup struct {
+	states map[string]*OnChainAnchorState
+	errs   map[string]error
+}
+
+func (l *stubAnchorStateLookup) GetBatchAnchorStateOnChain(ctx context.Context, batchID string) (*OnChainAnchorState, error) {
+	if err := l.errs[batchID]; err != nil {
+		return nil, err
+	}
+	if state, ok := l.states[batchID]; ok {
+		return state, nil
+	}
+	return &OnChainAnchorState{}, nil
+}
+
+func newTestAnchorRecord(root []byte) *database.AnchorRecord {
+	return &database.AnchorRecord{
+		AnchorID:            uuid.New(),
+		BatchID:             uuid.New(),
+		TargetChain:         database.TargetChainEthereum,
+		MerkleRoot:          root,
+		OperationCommitment: root,
+		AccumHeight:         sql.NullInt64{Int64: 100, Valid: true},
+		ProofGasUsed:        sql.NullInt64{Int64: 250000, Valid: true},
+	}
+}
+
+func TestAnchorStateReconciler_Sweep(t *testing.T) {
+	root := sha256Sum("root")
+	consistent := newTestAnchorRecord(root)
+	missing := newTestAnchorRecord(root)
+	invalidated := newTestAnchorRecord(root)
+	mismatched := newTestAnchorRecord(root)
+	unreachable := newTestAnchorRecord(root)
+
+	store := newMemoryAnchorStateStore(consistent, missing, invalidated, mismatched, unreachable)
+	lookup := &stubAnchorStateLookup{
+		states: map[string]*OnChainAnchorState{
+			consistent.BatchID.String():  {Exists: true, Valid: true, OperationCommitment: root, AccumulateHeight: 100, Verifications: 1},
+			invalidated.BatchID.String(): {Exists: true, Valid: false, OperationCommitment: root, AccumulateHeight: 100, Verifications: 1},
+			mismatched.BatchID.String():  {Exists: true, Valid: true, OperationCommitment: sha256Sum("other"), AccumulateHeight: 99},
+		},
+		errs: map[string]error{unreachable.BatchID.String(): errors.New("rpc unavailable")},
+	}
+
+	r, err := NewAnchorStateReconciler(store, lookup, &AnchorStateReconcilerConfig{SampleSize: 10, Logger: log.New(io.Discard, "", 0)})
+	if err != nil {
+		t.Fatalf("NewAnchorStateReconciler failed: %v", err)
+	}
+
+	report, err := r.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if report.Checked != 5 || report.Consistent != 1 || report.Errors != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	for kind, expected := range map[database.AnchorDiscrepancyKind]int{
+		database.AnchorDiscrepancyMissing:            1,
+		database.AnchorDiscrepancyInvalidated:        1,
+		database.AnchorDiscrepancyCommitmentMismatch: 1,
+		database.AnchorDiscrepancyHeightMismatch:     1,
+		database.AnchorDiscrepancyProofNotRecorded:   1,
+	} {
+		if report.Discrepancies[kind] != expected {
+			t.Errorf("%s: %d discrepancies, expected %d", kind, report.Discrepancies[kind], expected)
+		}
+	}
+	if status := r.Status(); status.OpenDiscrepancies != 5 || r.OpenDiscrepancies() != 5 || status.LastSweepAt == nil {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	// The anchor is re-instated on-chain; later sweeps sample other anchors only
+	lookup.states[invalidated.BatchID.String()].Valid = true
+	store.sample = []*database.AnchorRecord{consistent}
+
+	report, err = r.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if report.Rechecked != 3 || report.Checked != 1 || report.Resolved != 1 {
+		t.Errorf("unexpected report after re-instatement %+v", report)
+	}
+	if store.discrepancies[invalidated.AnchorID][database.AnchorDiscrepancyInvalidated] {
+		t.Error("invalidation discrepancy should be resolved")
+	}
+	if r.OpenDiscrepancies() != 4 {
+		t.Errorf("open discrepancies = %d, expected 4", r.OpenDiscrepancies())
+	}
+}
+
+func TestCompareAnchorState_FallsBackToMerkleRoot(t *testing.T) {
+	root := sha256Sum("root")
+	record := newTestAnchorRecord(root)
+	record.OperationCommitment = nil
+	record.AccumHeight = sql.NullInt64{}
+	record.ProofGasUsed = sql.NullInt64{}
+
+	if found := compareAnchorState(record, &OnChainAnchorState{Exists: true, Valid: true, OperationCommitment: root}); len(found) != 0 {
+		t.Errorf("expected no discrepancies, got %+v", found[0])
+	}
+	if found := compareAnchorState(record, nil); len(found) != 1 || found[0].Kind != database.AnchorDiscrepancyMissing {
+		t.Errorf("expected a missing discrepancy, got %v", found)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Certen Protocol
+//
+// Batch ID - Content-derived batch identifiers
+//
+// Batch IDs are random by default, so validators batching the same transactions
+// name their batches differently and attestations for them cannot be matched by ID.
+// With deterministic IDs (DETERMINISTIC_BATCH_ID) a batch is renamed when it closes
+// to a name-based UUID over its batch type, the Accumulate block height it closed at
+// and its transaction hashes in sorted order, so the same content always gets the
+// same ID regardless of arrival order.
+
+package batch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// BatchIDNamespace is the UUID namespace of deterministic batch IDs
+var BatchIDNamespace = uuid.MustParse("6f2c8a3e-4b1d-5e7f-9a0c-3d5e7f9b1c2a")
+
+// DeriveBatchID returns the deterministic ID of a batch of the given type holding
+// txHashes, closed at the Accumulate block height accumHeight. It is a version 5
+// (name-based) UUID hashed with SHA-256 instead of SHA-1.
+func DeriveBatchID(batchType database.BatchType, txHashes [][]byte, accumHeight int64) uuid.UUID {
+	sorted := make([][]byte, len(txHashes))
+	copy(sorted, txHashes)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+
+	var name bytes.Buffer
+	name.WriteString(string(batchType))
+	name.WriteByte(0)
+	binary.Write(&name, binary.BigEndian, accumHeight)
+	for _, hash := range sorted {
+		binary.Write(&name, binary.BigEndian, uint32(len(hash)))
+		name.Write(hash)
+	}
+	return uuid.NewHash(sha256.New(), BatchIDNamespace, name.Bytes(), 5)
+}
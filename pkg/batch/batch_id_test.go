@@ -0,0 +1,44 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Batch ID
+// Tests that derived batch IDs depend on content only, not transaction order
+
+package batch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestDeriveBatchID(t *testing.T) {
+	hashes := make([][]byte, 3)
+	for i := range hashes {
+		sum := sha256.Sum256([]byte{byte(i)})
+		hashes[i] = sum[:]
+	}
+	reordered := [][]byte{hashes[2], hashes[0], hashes[1]}
+
+	id := DeriveBatchID(database.BatchTypeOnCadence, hashes, 1000)
+	if id.Version() != 5 {
+		t.Errorf("expected a version 5 UUID, got version %d", id.Version())
+	}
+	if again := DeriveBatchID(database.BatchTypeOnCadence, reordered, 1000); again != id {
+		t.Errorf("transaction order changed the ID: %s vs %s", again, id)
+	}
+	if !bytes.Equal(reordered[0], hashes[2]) {
+		t.Error("DeriveBatchID reordered the caller's slice")
+	}
+
+	for name, other := range map[string][16]byte{
+		"height":       DeriveBatchID(database.BatchTypeOnCadence, hashes, 1001),
+		"batch type":   DeriveBatchID(database.BatchTypeOnDemand, hashes, 1000),
+		"transactions": DeriveBatchID(database.BatchTypeOnCadence, hashes[:2], 1000),
+	} {
+		if other == id {
+			t.Errorf("changing the %s did not change the ID", name)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Certen Protocol
+//
+// Batch Retry - Re-anchoring a failed batch on operator request
+//
+// A batch whose anchor submission failed (e.g. a transient Ethereum RPC outage) stays
+// failed until an operator retries it. A retry rebuilds the batch's Merkle root and
+// inclusion proofs from its persisted transactions, moves it back to closed and runs
+// the regular anchor path. Only failed batches are retried:
+//   - anchored, waiting-for-confirmation and confirmed batches are already anchored
+//   - a failed batch that nevertheless has an anchor record is treated as anchored
+//   - every other state is still in progress (or abandoned) and is not retryable
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// BatchRetryResult is the outcome of retrying a failed batch
+type BatchRetryResult struct {
+	BatchID       uuid.UUID            `json:"batch_id"`
+	MerkleRootHex string               `json:"merkle_root"`
+	TxCount       int                  `json:"tx_count"`
+	Status        database.BatchStatus `json:"status"` // Status after the retry
+}
+
+// checkBatchRetryable returns ErrBatchAlreadyAnchored or ErrBatchNotRetryable unless
+// the batch is failed
+func checkBatchRetryable(status database.BatchStatus) error {
+	switch status {
+	case database.BatchStatusFailed:
+		return nil
+	case database.BatchStatusAnchored, database.BatchStatusWaitingConfirms, database.BatchStatusConfirmed:
+		return fmt.Errorf("%w (status %s)", ErrBatchAlreadyAnchored, status)
+	default:
+		return fmt.Errorf("%w (status %s)", ErrBatchNotRetryable, status)
+	}
+}
+
+// RetryFailedBatch re-anchors a failed batch from its persisted transactions. The
+// returned result is non-nil whenever the anchor path ran, including when it failed
+// again and the batch was marked failed.
+func (p *Processor) RetryFailedBatch(ctx context.Context, batchID uuid.UUID) (*BatchRetryResult, error) {
+	batch, err := p.repos.Batches.GetBatch(ctx, batchID)
+	if err != nil {
+		if errors.Is(err, database.ErrBatchNotFound) {
+			return nil, ErrBatchNotFound
+		}
+		return nil, err
+	}
+	if err := checkBatchRetryable(batch.Status); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.repos.Anchors.GetAnchorByBatchID(ctx, batchID); err == nil {
+		return nil, fmt.Errorf("%w (anchor record exists)", ErrBatchAlreadyAnchored)
+	} else if !errors.Is(err, database.ErrAnchorNotFound) {
+		return nil, err
+	}
+
+	txs, err := p.repos.Batches.GetTransactionsInBatch(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrBatchNotRetryable, ErrBatchEmpty)
+	}
+
+	result, err := p.rebuildClosedBatch(batch, txs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reopening is conditional on the batch still being failed, so concurrent retries
+	// cannot both reach the anchor path
+	reopened, err := p.repos.Batches.ReopenFailedBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if !reopened {
+		return nil, fmt.Errorf("%w (batch changed state during retry)", ErrBatchNotRetryable)
+	}
+
+	p.logger.Printf("🔁 Retrying failed batch %s (txs=%d, root=%s)", batchID, result.TxCount, result.MerkleRootHex)
+	processErr := p.ProcessClosedBatch(ctx, result)
+
+	retry := &BatchRetryResult{
+		BatchID:       batchID,
+		MerkleRootHex: result.MerkleRootHex,
+		TxCount:       result.TxCount,
+		Status:        database.BatchStatusFailed,
+	}
+	if updated, err := p.repos.Batches.GetBatch(ctx, batchID); err == nil {
+		retry.Status = updated.Status
+	}
+	return retry, processErr
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Batch Retry
+// Tests retryable batch states and rebuilding a batch from its persisted transactions
+
+package batch
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestCheckBatchRetryable(t *testing.T) {
+	tests := []struct {
+		status database.BatchStatus
+		want   error
+	}{
+		{database.BatchStatusFailed, nil},
+		{database.BatchStatusAnchored, ErrBatchAlreadyAnchored},
+		{database.BatchStatusWaitingConfirms, ErrBatchAlreadyAnchored},
+		{database.BatchStatusConfirmed, ErrBatchAlreadyAnchored},
+		{database.BatchStatusPending, ErrBatchNotRetryable},
+		{database.BatchStatusClosed, ErrBatchNotRetryable},
+		{database.BatchStatusAnchoring, ErrBatchNotRetryable},
+		{database.BatchStatusAbandoned, ErrBatchNotRetryable},
+	}
+	for _, tt := range tests {
+		if err := checkBatchRetryable(tt.status); !errors.Is(err, tt.want) || (tt.want == nil) != (err == nil) {
+			t.Errorf("%s: got %v, want %v", tt.status, err, tt.want)
+		}
+	}
+}
+
+func TestRebuildClosedBatch(t *testing.T) {
+	p := &Processor{leafEncoding: LeafEncodingRawTxHash, logger: log.Default()}
+
+	var txs []*database.BatchTransaction
+	for _, name := range []string{"tx0", "tx1", "tx2"} {
+		hash := sha256.Sum256([]byte(name))
+		txs = append(txs, &database.BatchTransaction{
+			AccumTxHash: name,
+			AccountURL:  "acc://alice.acme/tokens",
+			TxHash:      hash[:],
+			GovLevel:    sql.NullString{String: "G1", Valid: true},
+			Priority:    database.PriorityHigh,
+		})
+	}
+	batch := &database.AnchorBatch{BatchID: uuid.New(), BatchType: database.BatchTypeOnCadence}
+
+	result, err := p.rebuildClosedBatch(batch, txs)
+	if err != nil {
+		t.Fatalf("rebuildClosedBatch failed: %v", err)
+	}
+	if result.TxCount != 3 || len(result.Proofs) != 3 || len(result.MerkleRoot) != 32 {
+		t.Fatalf("unexpected rebuilt batch: txs=%d proofs=%d root=%x", result.TxCount, len(result.Proofs), result.MerkleRoot)
+	}
+	if td := result.Transactions[1]; td.AccumTxHash != "tx1" || td.GovLevel != "G1" || td.Priority != database.PriorityHigh {
+		t.Errorf("unexpected restored transaction %+v", td)
+	}
+
+	// The rebuilt root must match the stored root
+	batch.MerkleRoot = result.MerkleRoot
+	if _, err := p.rebuildClosedBatch(batch, txs); err != nil {
+		t.Errorf("expected matching stored root to rebuild, got %v", err)
+	}
+	batch.MerkleRoot = make([]byte, 32)
+	if _, err := p.rebuildClosedBatch(batch, txs); err == nil {
+		t.Error("expected error for a stored root mismatch")
+	}
+}
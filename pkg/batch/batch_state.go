@@ -0,0 +1,102 @@
+// Copyright 2025 Certen Protocol
+//
+// Batch State Machine - Guarded batch status transitions
+//
+// A batch moves pending → closed → anchoring → anchored → confirmed. The processor
+// persists anchoring before it submits the anchor transaction, so after a crash a batch
+// still in anchoring was interrupted mid-submission: the anchor reconciler marks it
+// anchored if its anchor landed on-chain, or failed so it is anchored again.
+//
+// Every status change names the status it moves to and only applies when the batch is
+// in a status that may precede it. Moving a batch to the status it already has is
+// allowed, so replaying an update after a crash is harmless.
+//
+//   pending   → closed, abandoned
+//   closed    → anchoring, anchored (reconciled), failed, abandoned
+//   anchoring → anchored, failed
+//   anchored  → confirmed
+//   failed    → closed (retry), anchored (reconciled), abandoned
+//   confirmed and abandoned are terminal
+
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// batchTransitions lists the statuses each batch status may move to
+var batchTransitions = map[database.BatchStatus][]database.BatchStatus{
+	database.BatchStatusPending: {database.BatchStatusClosed, database.BatchStatusAbandoned},
+	database.BatchStatusClosed: {
+		database.BatchStatusAnchoring, database.BatchStatusAnchored,
+		database.BatchStatusFailed, database.BatchStatusAbandoned,
+	},
+	database.BatchStatusAnchoring: {database.BatchStatusAnchored, database.BatchStatusFailed},
+	database.BatchStatusAnchored:  {database.BatchStatusConfirmed},
+	database.BatchStatusFailed: {
+		database.BatchStatusClosed, database.BatchStatusAnchored, database.BatchStatusAbandoned,
+	},
+	database.BatchStatusConfirmed: nil,
+	database.BatchStatusAbandoned: nil,
+}
+
+// BatchTransitionStore persists guarded batch status changes
+// Implemented by database.BatchRepository
+type BatchTransitionStore interface {
+	TransitionBatchStatus(ctx context.Context, batchID uuid.UUID, fromStatuses []database.BatchStatus, status database.BatchStatus, errorMsg string) (bool, error)
+}
+
+// CanTransitionBatch reports whether a batch may move from one status to another
+func CanTransitionBatch(from, to database.BatchStatus) bool {
+	if from == to {
+		_, known := batchTransitions[from]
+		return known
+	}
+	for _, next := range batchTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextBatchStatuses returns the statuses a batch in the given status may move to
+func NextBatchStatuses(status database.BatchStatus) []database.BatchStatus {
+	return append([]database.BatchStatus(nil), batchTransitions[status]...)
+}
+
+// IsTerminalBatchStatus reports whether a batch in the given status never changes again
+func IsTerminalBatchStatus(status database.BatchStatus) bool {
+	next, known := batchTransitions[status]
+	return known && len(next) == 0
+}
+
+// batchStatusPredecessors returns the statuses a batch may be in to move to status,
+// including status itself
+func batchStatusPredecessors(status database.BatchStatus) []database.BatchStatus {
+	var from []database.BatchStatus
+	for candidate := range batchTransitions {
+		if CanTransitionBatch(candidate, status) {
+			from = append(from, candidate)
+		}
+	}
+	return from
+}
+
+// transitionBatch moves a batch to status, returning ErrIllegalBatchTransition when the
+// batch is in a status that may not move there (or does not exist)
+func transitionBatch(ctx context.Context, store BatchTransitionStore, batchID uuid.UUID, status database.BatchStatus, errorMsg string) error {
+	moved, err := store.TransitionBatchStatus(ctx, batchID, batchStatusPredecessors(status), status, errorMsg)
+	if err != nil {
+		return err
+	}
+	if !moved {
+		return fmt.Errorf("%w: batch %s cannot move to %s", ErrIllegalBatchTransition, batchID, status)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Batch State Machine
+// Tests legal and illegal status transitions and guarded status updates
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestCanTransitionBatch(t *testing.T) {
+	tests := []struct {
+		from, to database.BatchStatus
+		want     bool
+	}{
+		{database.BatchStatusPending, database.BatchStatusClosed, true},
+		{database.BatchStatusClosed, database.BatchStatusAnchoring, true},
+		{database.BatchStatusAnchoring, database.BatchStatusAnchored, true},
+		{database.BatchStatusAnchoring, database.BatchStatusFailed, true},
+		{database.BatchStatusAnchored, database.BatchStatusConfirmed, true},
+		{database.BatchStatusFailed, database.BatchStatusClosed, true},
+		{database.BatchStatusFailed, database.BatchStatusAnchored, true},
+		{database.BatchStatusAnchored, database.BatchStatusAnchored, true}, // Replayed update
+		{database.BatchStatusPending, database.BatchStatusAnchoring, false},
+		{database.BatchStatusAnchoring, database.BatchStatusClosed, false},
+		{database.BatchStatusAnchored, database.BatchStatusFailed, false},
+		{database.BatchStatusConfirmed, database.BatchStatusAnchored, false},
+		{database.BatchStatusAbandoned, database.BatchStatusClosed, false},
+		{database.BatchStatusWaitingConfirms, database.BatchStatusWaitingConfirms, false}, // Display-only status
+	}
+	for _, tt := range tests {
+		if got := CanTransitionBatch(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionBatch(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	if !IsTerminalBatchStatus(database.BatchStatusConfirmed) || !IsTerminalBatchStatus(database.BatchStatusAbandoned) {
+		t.Error("expected confirmed and abandoned to be terminal")
+	}
+	if IsTerminalBatchStatus(database.BatchStatusAnchoring) || IsTerminalBatchStatus("bogus") {
+		t.Error("expected anchoring and unknown statuses not to be terminal")
+	}
+}
+
+// statusStore holds batch statuses and applies guarded transitions like the repository
+type statusStore map[uuid.UUID]database.BatchStatus
+
+func (s statusStore) TransitionBatchStatus(ctx context.Context, batchID uuid.UUID, fromStatuses []database.BatchStatus, status database.BatchStatus, errorMsg string) (bool, error) {
+	current, ok := s[batchID]
+	if !ok {
+		return false, nil
+	}
+	for _, from := range fromStatuses {
+		if current == from {
+			s[batchID] = status
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestTransitionBatch(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	store := statusStore{id: database.BatchStatusClosed}
+
+	for _, status := range []database.BatchStatus{
+		database.BatchStatusAnchoring,
+		database.BatchStatusAnchored,
+		database.BatchStatusAnchored, // Idempotent
+	} {
+		if err := transitionBatch(ctx, store, id, status, ""); err != nil {
+			t.Fatalf("transition to %s: %v", status, err)
+		}
+	}
+	if store[id] != database.BatchStatusAnchored {
+		t.Fatalf("expected anchored, got %s", store[id])
+	}
+
+	// An anchored batch can never be marked failed again
+	if err := transitionBatch(ctx, store, id, database.BatchStatusFailed, "late error"); !errors.Is(err, ErrIllegalBatchTransition) {
+		t.Errorf("expected ErrIllegalBatchTransition, got %v", err)
+	}
+	if store[id] != database.BatchStatusAnchored {
+		t.Errorf("illegal transition changed status to %s", store[id])
+	}
+
+	if err := transitionBatch(ctx, store, uuid.New(), database.BatchStatusClosed, ""); !errors.Is(err, ErrIllegalBatchTransition) {
+		t.Errorf("expected ErrIllegalBatchTransition for an unknown batch, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@
 // - Maintains an open batch for on-cadence transactions
 // - Adds transactions with proper Merkle tree indexing
 // - Tracks batch state (pending, closed)
+// - Rejects transactions that are already in a batch (see tx_dedup.go)
 // - Integrates with PostgreSQL via database repositories
 
 package batch
@@ -16,9 +17,11 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,6 +29,7 @@ import (
 	"github.com/certen/independant-validator/pkg/database"
 	"github.com/certen/independant-validator/pkg/firestore"
 	"github.com/certen/independant-validator/pkg/merkle"
+	"github.com/certen/independant-validator/pkg/metrics"
 )
 
 // TransactionData represents a transaction to be added to a batch
@@ -52,6 +56,15 @@ type TransactionData struct {
 	// Per Unified Multi-Chain Architecture: Transactions specify their target chain
 	TargetChain string // Target chain ID (e.g., "ethereum", "sepolia", "solana-devnet")
 
+	// Effective intent priority: orders quota-queued transactions and anchoring of
+	// closed batches, and an urgent on-demand transaction closes its batch at once
+	Priority database.RequestPriority // Empty = normal
+
+	// Synthetic transaction support: synthetic transactions produced while executing
+	// a user transaction are proven and anchored linked to that transaction
+	Origin       database.TransactionOrigin // Empty = user
+	OriginTxHash string                     // Producing user transaction hash (synthetic only)
+
 	// Intent Metadata (for Transaction Center integration)
 	FromChain       string     // Source chain (e.g., 'accumulate')
 	ToChain         string     // Destination chain (e.g., 'ethereum')
@@ -75,45 +88,66 @@ type Collector struct {
 	onDemandBatch  *activeBatch
 
 	// Configuration
-	validatorID    string
-	maxBatchSize   int           // Max transactions per batch
-	batchTimeout   time.Duration // Max time a batch can stay open (~15 min)
-	maxOnDemand    int           // Max transactions in on-demand batch before immediate anchor
+	validatorID          string
+	maxBatchSize         int           // Max transactions per batch
+	batchTimeout         time.Duration // Max time a batch can stay open (~15 min)
+	maxOnDemand          int           // Max transactions in on-demand batch before immediate anchor
+	leafEncoding         LeafEncoding  // Merkle leaf layout expected by the anchoring verifier
+	deterministicBatchID bool          // Rename closing batches to DeriveBatchID
+
+	// Rejects transactions already in a batch (see tx_dedup.go)
+	dedup *txDeduplicator
 
 	// Logging
 	logger *log.Logger
 
-	// Firestore sync for real-time UI updates
-	firestoreSyncService *firestore.SyncService
+	// Firestore sync for real-time UI updates (never nil; NullSyncService when disabled)
+	firestoreSyncService firestore.Syncer
+
+	// Per-account proof metering and quotas (optional)
+	usageMeter *UsageMeter
+
+	// Prometheus metrics (nil = disabled)
+	metrics *metrics.Registry
+
+	// Fails adds fast while the database is unreachable (nil = always try the database).
+	// Read before taking mu so callers do not queue behind a blocked database call.
+	dbBreaker atomic.Pointer[database.CircuitBreaker]
 }
 
 // activeBatch represents a batch being built
 type activeBatch struct {
-	batchID     uuid.UUID
-	batchType   database.BatchType
-	startTime   time.Time
-	leaves      [][]byte                    // Transaction hashes for Merkle tree
-	txData      []*TransactionData          // Original transaction data
-	merkleTree  *merkle.Tree                // Built when batch is closed
+	batchID    uuid.UUID
+	batchType  database.BatchType
+	startTime  time.Time
+	leaves     [][]byte           // Encoded Merkle leaves (see LeafEncoding)
+	txData     []*TransactionData // Original transaction data
+	merkleTree *merkle.Tree       // Grows with leaves; proofs are taken when the batch closes
 }
 
 // CollectorConfig holds collector configuration
 type CollectorConfig struct {
-	ValidatorID    string
-	MaxBatchSize   int
-	BatchTimeout   time.Duration
-	MaxOnDemand    int
-	Logger         *log.Logger
+	ValidatorID          string
+	MaxBatchSize         int
+	BatchTimeout         time.Duration
+	MaxOnDemand          int
+	LeafEncoding         LeafEncoding  // Empty = DefaultLeafEncoding
+	DeterministicBatchID bool          // Derive batch IDs from content when batches close (default random)
+	DedupCacheSize       int           // Recently batched transactions remembered (0 = DefaultTxDedupCacheSize)
+	DedupCacheTTL        time.Duration // How long they are remembered (0 = DefaultTxDedupCacheTTL)
+	Logger               *log.Logger
+	Metrics              *metrics.Registry // Optional - nil disables metrics
 }
 
 // DefaultCollectorConfig returns default configuration
 func DefaultCollectorConfig() *CollectorConfig {
 	return &CollectorConfig{
-		ValidatorID:    "validator-default",
-		MaxBatchSize:   1000,                  // Max 1000 txs per batch
-		BatchTimeout:   15 * time.Minute,      // ~15 min batches per whitepaper
-		MaxOnDemand:    5,                     // Small on-demand batches
-		Logger:         log.New(log.Writer(), "[BatchCollector] ", log.LstdFlags),
+		ValidatorID:  "validator-default",
+		MaxBatchSize: 1000,             // Max 1000 txs per batch
+		BatchTimeout: 15 * time.Minute, // ~15 min batches per whitepaper
+		MaxOnDemand:  5,                // Small on-demand batches
+		LeafEncoding: DefaultLeafEncoding,
+		Logger:       log.New(log.Writer(), "[BatchCollector] ", log.LstdFlags),
 	}
 }
 
@@ -128,30 +162,82 @@ func NewCollector(repos *database.Repositories, cfg *CollectorConfig) (*Collecto
 	if cfg.Logger == nil {
 		cfg.Logger = log.New(log.Writer(), "[BatchCollector] ", log.LstdFlags)
 	}
+	leafEncoding, err := ParseLeafEncoding(string(cfg.LeafEncoding))
+	if err != nil {
+		return nil, err
+	}
+	var dedupStore TxDedupStore
+	if repos.Batches != nil {
+		dedupStore = repos.Batches
+	}
 
 	return &Collector{
-		repos:          repos,
-		validatorID:    cfg.ValidatorID,
-		maxBatchSize:   cfg.MaxBatchSize,
-		batchTimeout:   cfg.BatchTimeout,
-		maxOnDemand:    cfg.MaxOnDemand,
-		logger:         cfg.Logger,
+		repos:                repos,
+		validatorID:          cfg.ValidatorID,
+		maxBatchSize:         cfg.MaxBatchSize,
+		batchTimeout:         cfg.BatchTimeout,
+		maxOnDemand:          cfg.MaxOnDemand,
+		leafEncoding:         leafEncoding,
+		deterministicBatchID: cfg.DeterministicBatchID,
+		dedup:                newTxDeduplicator(dedupStore, cfg.DedupCacheSize, cfg.DedupCacheTTL),
+		firestoreSyncService: firestore.NullSyncService{},
+		logger:               cfg.Logger,
+		metrics:              cfg.Metrics,
 	}, nil
 }
 
 // SetFirestoreSyncService sets the Firestore sync service for real-time UI updates
-func (c *Collector) SetFirestoreSyncService(svc *firestore.SyncService) {
+// (nil = firestore.NullSyncService)
+func (c *Collector) SetFirestoreSyncService(svc firestore.Syncer) {
+	if svc == nil {
+		svc = firestore.NullSyncService{}
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.firestoreSyncService = svc
 }
 
+// SetUsageMeter enables per-account proof metering and quota enforcement
+func (c *Collector) SetUsageMeter(m *UsageMeter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usageMeter = m
+}
+
+// SetDatabaseBreaker rejects transactions with database.ErrDatabaseUnavailable while
+// breaker is open
+func (c *Collector) SetDatabaseBreaker(breaker *database.CircuitBreaker) {
+	c.dbBreaker.Store(breaker)
+}
+
+// databaseAvailable returns database.ErrDatabaseUnavailable while the breaker is open
+func (c *Collector) databaseAvailable() error {
+	if breaker := c.dbBreaker.Load(); breaker != nil {
+		return breaker.Allow()
+	}
+	return nil
+}
+
 // AddOnCadenceTransaction adds a transaction to the current on-cadence batch
 // This is the default path for ~$0.05/proof amortized cost
 func (c *Collector) AddOnCadenceTransaction(ctx context.Context, tx *TransactionData) (*BatchTransactionResult, error) {
+	if err := c.databaseAvailable(); err != nil {
+		return nil, err
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	// A re-delivered transaction must not be proven and anchored twice
+	if err := c.dedup.check(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	// Enforce the account's proof quota
+	if queued, err := c.admitTransaction(ctx, tx, database.BatchTypeOnCadence); queued != nil || err != nil {
+		return queued, err
+	}
+
 	// Ensure we have an open on-cadence batch
 	if c.onCadenceBatch == nil {
 		if err := c.createBatch(ctx, database.BatchTypeOnCadence); err != nil {
@@ -160,15 +246,35 @@ func (c *Collector) AddOnCadenceTransaction(ctx context.Context, tx *Transaction
 	}
 
 	// Add transaction to batch
-	return c.addToBatch(ctx, c.onCadenceBatch, tx)
+	result, err := c.addToBatch(ctx, c.onCadenceBatch, tx)
+	if err != nil {
+		return nil, err
+	}
+	c.recordUsage(ctx, tx, database.BatchTypeOnCadence)
+
+	return result, nil
 }
 
 // AddOnDemandTransaction adds a transaction to an on-demand batch
 // This is for immediate anchoring at ~$0.25/proof
 func (c *Collector) AddOnDemandTransaction(ctx context.Context, tx *TransactionData) (*BatchTransactionResult, error) {
+	if err := c.databaseAvailable(); err != nil {
+		return nil, err
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	// A re-delivered transaction must not be proven and anchored twice
+	if err := c.dedup.check(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	// Enforce the account's proof quota
+	if queued, err := c.admitTransaction(ctx, tx, database.BatchTypeOnDemand); queued != nil || err != nil {
+		return queued, err
+	}
+
 	// Ensure we have an open on-demand batch
 	if c.onDemandBatch == nil {
 		if err := c.createBatch(ctx, database.BatchTypeOnDemand); err != nil {
@@ -181,15 +287,107 @@ func (c *Collector) AddOnDemandTransaction(ctx context.Context, tx *TransactionD
 	if err != nil {
 		return nil, err
 	}
+	c.recordUsage(ctx, tx, database.BatchTypeOnDemand)
 
 	// Check if on-demand batch should be immediately closed
-	if len(c.onDemandBatch.leaves) >= c.maxOnDemand {
+	if len(c.onDemandBatch.leaves) >= c.maxOnDemand || tx.Priority == database.PriorityUrgent {
 		result.BatchReady = true
 	}
 
 	return result, nil
 }
 
+// admitTransaction applies the usage meter's quota to a transaction. It returns a queued
+// result when the quota action held the transaction back, or ErrQuotaExceeded.
+// Caller must hold c.mu
+func (c *Collector) admitTransaction(ctx context.Context, tx *TransactionData, batchType database.BatchType) (*BatchTransactionResult, error) {
+	if c.usageMeter == nil {
+		return nil, nil
+	}
+	queuedUntil, err := c.usageMeter.admit(ctx, tx, batchType)
+	if err != nil {
+		return nil, err
+	}
+	if queuedUntil.IsZero() {
+		return nil, nil
+	}
+	return &BatchTransactionResult{
+		BatchType:   batchType,
+		Queued:      true,
+		QueuedUntil: &queuedUntil,
+	}, nil
+}
+
+// recordUsage counts an added transaction against its account
+// Caller must hold c.mu
+func (c *Collector) recordUsage(ctx context.Context, tx *TransactionData, batchType database.BatchType) {
+	if c.usageMeter == nil {
+		return
+	}
+	if err := c.usageMeter.Record(ctx, tx, batchType); err != nil {
+		c.logger.Printf("⚠️ Failed to record proof usage for %s: %v", tx.AccountURL, err)
+	}
+}
+
+// ReleaseQueuedTransactions adds transactions held back by account quotas to their batch
+// once the account is under quota again. Returns the number of released transactions.
+func (c *Collector) ReleaseQueuedTransactions(ctx context.Context) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.usageMeter == nil {
+		return 0
+	}
+
+	return c.usageMeter.releaseQueued(ctx, func(q *queuedProofRequest) error {
+		if c.openBatch(q.batchType) == nil {
+			if err := c.createBatch(ctx, q.batchType); err != nil {
+				return err
+			}
+		}
+		// A copy delivered while this one was queued may have been batched since
+		if err := c.dedup.check(ctx, q.tx); err != nil {
+			if errors.Is(err, ErrTransactionAlreadyBatched) {
+				c.logger.Printf("Dropping queued duplicate: %v", err)
+				return nil
+			}
+			return err
+		}
+		_, err := c.addToBatch(ctx, c.openBatch(q.batchType), q.tx)
+		return err
+	})
+}
+
+// renameToDerivedID renames a closing batch to the ID derived from its content.
+// If the rename fails the batch keeps its random ID.
+// Caller must hold c.mu
+func (c *Collector) renameToDerivedID(ctx context.Context, batch *activeBatch, accumHeight int64) {
+	txHashes := make([][]byte, len(batch.txData))
+	for i, tx := range batch.txData {
+		txHashes[i] = tx.TxHash
+	}
+	derived := DeriveBatchID(batch.batchType, txHashes, accumHeight)
+	if derived == batch.batchID {
+		return
+	}
+	if err := c.repos.Batches.RenameBatch(ctx, batch.batchID, derived); err != nil {
+		c.logger.Printf("⚠️ Keeping random ID for batch %s, rename to derived ID %s failed: %v", batch.batchID, derived, err)
+		return
+	}
+	c.logger.Printf("Renamed batch %s to derived ID %s (height %d, %d txs)", batch.batchID, derived, accumHeight, len(txHashes))
+	c.dedup.rename(batch.batchID, derived)
+	batch.batchID = derived
+}
+
+// openBatch returns the open batch of the given type, or nil
+// Caller must hold c.mu
+func (c *Collector) openBatch(batchType database.BatchType) *activeBatch {
+	if batchType == database.BatchTypeOnDemand {
+		return c.onDemandBatch
+	}
+	return c.onCadenceBatch
+}
+
 // createBatch creates a new batch in the database
 func (c *Collector) createBatch(ctx context.Context, batchType database.BatchType) error {
 	input := &database.NewAnchorBatch{
@@ -203,15 +401,17 @@ func (c *Collector) createBatch(ctx context.Context, batchType database.BatchTyp
 	}
 
 	active := &activeBatch{
-		batchID:   batch.BatchID,
-		batchType: batchType,
-		startTime: time.Now(),
-		leaves:    make([][]byte, 0),
-		txData:    make([]*TransactionData, 0),
+		batchID:    batch.BatchID,
+		batchType:  batchType,
+		startTime:  time.Now(),
+		leaves:     make([][]byte, 0),
+		txData:     make([]*TransactionData, 0),
+		merkleTree: merkle.NewTree(),
 	}
 
 	if batchType == database.BatchTypeOnCadence {
 		c.onCadenceBatch = active
+		c.metrics.SetOnCadenceBatchOpenedAt(active.startTime)
 	} else {
 		c.onDemandBatch = active
 	}
@@ -230,10 +430,17 @@ func (c *Collector) addToBatch(ctx context.Context, batch *activeBatch, tx *Tran
 	// Get tree index (position in Merkle tree)
 	treeIndex := len(batch.leaves)
 
-	// Add to in-memory batch
-	leafCopy := make([]byte, 32)
-	copy(leafCopy, tx.TxHash)
-	batch.leaves = append(batch.leaves, leafCopy)
+	leaf, err := c.leafEncoding.EncodeLeaf(tx.TxHash, tx.AccountURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode merkle leaf: %w", err)
+	}
+
+	// Add to the tree and in-memory batch before storing, so the stored row always
+	// matches a leaf of the tree the batch is anchored with
+	if _, err := batch.merkleTree.AddLeaf(leaf); err != nil {
+		return nil, fmt.Errorf("failed to add merkle leaf: %w", err)
+	}
+	batch.leaves = append(batch.leaves, leaf)
 	batch.txData = append(batch.txData, tx)
 
 	// Build merkle path placeholder (will be filled when batch is closed)
@@ -253,6 +460,11 @@ func (c *Collector) addToBatch(ctx context.Context, batch *activeBatch, tx *Tran
 		GovLevel:     database.GovernanceLevel(tx.GovLevel),
 		IntentType:   tx.IntentType,
 		IntentData:   tx.IntentData,
+		Priority:     tx.Priority,
+		Origin:       tx.Origin,
+	}
+	if tx.OriginTxHash != "" {
+		dbTx.OriginTxHash = &tx.OriginTxHash
 	}
 
 	// Pass intent tracking fields if present (for Firestore linking)
@@ -291,11 +503,15 @@ func (c *Collector) addToBatch(ctx context.Context, batch *activeBatch, tx *Tran
 
 	storedTx, err := c.repos.Batches.AddTransaction(ctx, dbTx)
 	if err != nil {
-		// Rollback in-memory state
+		// Rollback the tree and in-memory state together
+		if rollbackErr := batch.merkleTree.RemoveLastLeaf(); rollbackErr != nil {
+			c.logger.Printf("⚠️ Failed to roll back merkle leaf for batch %s: %v", batch.batchID, rollbackErr)
+		}
 		batch.leaves = batch.leaves[:len(batch.leaves)-1]
 		batch.txData = batch.txData[:len(batch.txData)-1]
 		return nil, fmt.Errorf("failed to store transaction: %w", err)
 	}
+	c.dedup.remember(tx, batch.batchID)
 
 	// Serialize empty path for result
 	merklePathJSON, _ := json.Marshal(emptyPath)
@@ -315,7 +531,7 @@ func (c *Collector) addToBatch(ctx context.Context, batch *activeBatch, tx *Tran
 
 	// Trigger Firestore sync for intent discovery (Stage 3)
 	// This fires when we discover an intent from Accumulate
-	if c.firestoreSyncService != nil && c.firestoreSyncService.IsEnabled() {
+	if c.firestoreSyncService.IsEnabled() {
 		go c.triggerIntentDiscoveredFirestoreEvent(tx, batch.batchType)
 	}
 
@@ -331,6 +547,10 @@ type BatchTransactionResult struct {
 	BatchType     database.BatchType `json:"batch_type"`
 	BatchSize     int                `json:"batch_size"`
 	BatchReady    bool               `json:"batch_ready"` // True if batch should be closed/anchored
+
+	// Set when the account is over its proof quota and the transaction was queued
+	Queued      bool       `json:"queued,omitempty"`
+	QueuedUntil *time.Time `json:"queued_until,omitempty"` // When the quota window resets
 }
 
 // ClosedBatchResult is returned when a batch is closed
@@ -386,6 +606,8 @@ func (c *Collector) CloseOnCadenceBatch(ctx context.Context, accumHeight int64,
 	}
 
 	c.onCadenceBatch = nil
+	c.metrics.SetOnCadenceBatchOpenedAt(time.Time{})
+	c.metrics.RecordBatchClosed(string(database.BatchTypeOnCadence))
 	return result, nil
 }
 
@@ -404,6 +626,7 @@ func (c *Collector) CloseOnDemandBatch(ctx context.Context, accumHeight int64, a
 	}
 
 	c.onDemandBatch = nil
+	c.metrics.RecordBatchClosed(string(database.BatchTypeOnDemand))
 	return result, nil
 }
 
@@ -428,12 +651,15 @@ func (c *Collector) closeBatch(ctx context.Context, batch *activeBatch, accumHei
 		}, nil
 	}
 
-	// Build Merkle tree
-	tree, err := merkle.BuildTree(batch.leaves)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build merkle tree: %w", err)
+	if c.deterministicBatchID {
+		c.renameToDerivedID(ctx, batch, accumHeight)
+	}
+
+	// The tree was built as transactions were added
+	tree := batch.merkleTree
+	if tree == nil || tree.LeafCount() != len(batch.leaves) {
+		return nil, fmt.Errorf("merkle tree out of step with batch %s: %d leaves in batch", batch.batchID, len(batch.leaves))
 	}
-	batch.merkleTree = tree
 
 	merkleRoot := tree.Root()
 	endTime := time.Now()
@@ -475,7 +701,7 @@ func (c *Collector) closeBatch(ctx context.Context, batch *activeBatch, accumHei
 	}
 
 	// Close batch in database
-	err = c.repos.Batches.CloseBatch(ctx, batch.batchID, merkleRoot, accumHeight, accumHash)
+	err := c.repos.Batches.CloseBatch(ctx, batch.batchID, merkleRoot, accumHeight, accumHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to close batch in database: %w", err)
 	}
@@ -485,7 +711,7 @@ func (c *Collector) closeBatch(ctx context.Context, batch *activeBatch, accumHei
 		len(batch.leaves), time.Since(batch.startTime))
 
 	// Trigger Firestore sync for batch closed event (Stage 5)
-	if c.firestoreSyncService != nil && c.firestoreSyncService.IsEnabled() {
+	if c.firestoreSyncService.IsEnabled() {
 		go c.triggerBatchClosedFirestoreEvent(batch, tree.RootHex())
 	}
 
@@ -595,6 +821,30 @@ func (c *Collector) extractBPTFromChainedProof(proofJSON json.RawMessage) (bptRo
 	return bptRoot, networkRoot
 }
 
+// CollectorSettings is the collector's effective configuration
+type CollectorSettings struct {
+	MaxBatchSize         int          `json:"max_batch_size"`
+	BatchTimeout         string       `json:"batch_timeout"`
+	MaxOnDemand          int          `json:"max_on_demand"`
+	LeafEncoding         LeafEncoding `json:"leaf_encoding"`
+	DeterministicBatchID bool         `json:"deterministic_batch_id"`
+	DedupCacheSize       int          `json:"dedup_cache_size"`
+	DedupCacheTTL        string       `json:"dedup_cache_ttl"`
+}
+
+// Settings returns the collector's effective configuration
+func (c *Collector) Settings() CollectorSettings {
+	return CollectorSettings{
+		MaxBatchSize:         c.maxBatchSize,
+		BatchTimeout:         c.batchTimeout.String(),
+		MaxOnDemand:          c.maxOnDemand,
+		LeafEncoding:         c.leafEncoding,
+		DeterministicBatchID: c.deterministicBatchID,
+		DedupCacheSize:       c.dedup.capacity,
+		DedupCacheTTL:        c.dedup.ttl.String(),
+	}
+}
+
 // GetOnCadenceBatchInfo returns info about the current on-cadence batch
 func (c *Collector) GetOnCadenceBatchInfo() *BatchInfo {
 	c.mu.RLock()
@@ -680,10 +930,6 @@ func (c *Collector) HasPendingOnDemandBatch() bool {
 // triggerBatchClosedFirestoreEvent sends batch closed events to Firestore for each transaction
 // This enables real-time UI updates for Stage 5 (Batch Consensus)
 func (c *Collector) triggerBatchClosedFirestoreEvent(batch *activeBatch, merkleRootHex string) {
-	if c.firestoreSyncService == nil {
-		return
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -693,7 +939,7 @@ func (c *Collector) triggerBatchClosedFirestoreEvent(batch *activeBatch, merkleR
 		batchTxs = append(batchTxs, firestore.BatchTransaction{
 			AccumTxHash: tx.AccumTxHash,
 			Position:    i,
-			LeafHash:    hex.EncodeToString(tx.TxHash),
+			LeafHash:    hex.EncodeToString(batch.leaves[i]),
 		})
 	}
 
@@ -719,10 +965,6 @@ func (c *Collector) triggerBatchClosedFirestoreEvent(batch *activeBatch, merkleR
 // triggerIntentDiscoveredFirestoreEvent sends intent discovered event to Firestore
 // This enables real-time UI updates for Stage 3 (Intent Discovery)
 func (c *Collector) triggerIntentDiscoveredFirestoreEvent(tx *TransactionData, batchType database.BatchType) {
-	if c.firestoreSyncService == nil {
-		return
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
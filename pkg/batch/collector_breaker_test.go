@@ -0,0 +1,111 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Collector database circuit breaker
+// Tests that transactions fail fast while the database is unreachable and are
+// accepted again once it recovers
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// fakePinger reports the database as reachable or not
+type fakePinger struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *fakePinger) set(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// waitForBreakerState polls until the breaker reaches state
+func waitForBreakerState(t *testing.T, breaker *database.CircuitBreaker, state database.BreakerState) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for breaker.State() != state {
+		if time.Now().After(deadline) {
+			t.Fatalf("breaker did not reach state %s, still %s", state, breaker.State())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCollector_DatabaseBreaker(t *testing.T) {
+	pinger := &fakePinger{}
+	breaker, err := database.NewCircuitBreaker(pinger, &database.CircuitBreakerConfig{
+		FailureThreshold: 2,
+		CheckInterval:    5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewCircuitBreaker: %v", err)
+	}
+
+	var mu sync.Mutex
+	var states []database.BreakerState
+	breaker.SetStateHandler(func(state database.BreakerState) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, state)
+	})
+
+	collector, err := NewCollector(&database.Repositories{}, nil)
+	if err != nil {
+		t.Fatalf("NewCollector: %v", err)
+	}
+	collector.SetDatabaseBreaker(breaker)
+
+	breaker.Start()
+	defer breaker.Stop()
+
+	pinger.set(errors.New("connection refused"))
+	waitForBreakerState(t, breaker, database.BreakerDisconnected)
+
+	tx := &TransactionData{AccumTxHash: "abc123", AccountURL: "acc://test.acme"}
+	if _, err := collector.AddOnCadenceTransaction(context.Background(), tx); !errors.Is(err, database.ErrDatabaseUnavailable) {
+		t.Errorf("on-cadence add: expected ErrDatabaseUnavailable, got %v", err)
+	}
+	if _, err := collector.AddOnDemandTransaction(context.Background(), tx); !errors.Is(err, database.ErrDatabaseUnavailable) {
+		t.Errorf("on-demand add: expected ErrDatabaseUnavailable, got %v", err)
+	}
+
+	pinger.set(nil)
+	waitForBreakerState(t, breaker, database.BreakerConnected)
+	if err := collector.databaseAvailable(); err != nil {
+		t.Errorf("expected the database to be available after recovery, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []database.BreakerState{database.BreakerDisconnected, database.BreakerConnected}
+	if len(states) != len(want) || states[0] != want[0] || states[1] != want[1] {
+		t.Errorf("expected state changes %v, got %v", want, states)
+	}
+}
+
+func TestNewCircuitBreaker_RejectsInvalidConfig(t *testing.T) {
+	if _, err := database.NewCircuitBreaker(nil, nil); err == nil {
+		t.Error("expected an error without a pinger")
+	}
+	if _, err := database.NewCircuitBreaker(&fakePinger{}, &database.CircuitBreakerConfig{CheckInterval: time.Second}); err == nil {
+		t.Error("expected an error for a zero failure threshold")
+	}
+	if _, err := database.NewCircuitBreaker(&fakePinger{}, &database.CircuitBreakerConfig{FailureThreshold: 1}); err == nil {
+		t.Error("expected an error for a zero check interval")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Certen Protocol
+//
+// Collector Drain - Flushing the open on-cadence batch on shutdown
+//
+// On shutdown the open on-cadence batch would otherwise wait for the next start to be
+// closed. Drain force-closes it and hands it to the processor, waiting until the anchor
+// transaction has been submitted or the drain deadline passes. Transactions of a batch
+// that could not be closed or handed off in time are reported as abandoned; they remain
+// stored with their batch, which is left open or closed but unanchored.
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DrainReport summarizes a shutdown drain of the on-cadence batch
+type DrainReport struct {
+	BatchID   uuid.UUID     `json:"batch_id,omitempty"`
+	Flushed   int           `json:"flushed"`   // Transactions whose anchor transaction was submitted
+	Abandoned int           `json:"abandoned"` // Transactions whose batch was not closed or not handed off in time
+	Duration  time.Duration `json:"duration"`
+}
+
+// Drain force-closes the open on-cadence batch and hands it off for anchoring, waiting
+// until the handoff returns or ctx is done. accumState supplies the Accumulate height and
+// hash the batch is closed at and may be nil. With no open batch the report is empty.
+func (c *Collector) Drain(ctx context.Context, handoff BatchReadyCallback, accumState func() (int64, string)) (*DrainReport, error) {
+	start := time.Now()
+	report := &DrainReport{}
+
+	info := c.GetOnCadenceBatchInfo()
+	if info == nil || info.TxCount == 0 {
+		return report, nil
+	}
+	report.BatchID = info.BatchID
+
+	var height int64
+	var hash string
+	if accumState != nil {
+		height, hash = accumState()
+	}
+
+	result, err := c.CloseOnCadenceBatch(ctx, height, hash)
+	if err != nil {
+		report.Abandoned = info.TxCount
+		report.Duration = time.Since(start)
+		c.logger.Printf("⚠️ Drain of on-cadence batch %s failed: %d transactions flushed, %d abandoned", info.BatchID, report.Flushed, report.Abandoned)
+		return report, fmt.Errorf("failed to close on-cadence batch: %w", err)
+	}
+	if result == nil {
+		// Closed by the scheduler between the check and the close
+		report.Duration = time.Since(start)
+		return report, nil
+	}
+
+	err = handOffDrainedBatch(ctx, result, handoff, report)
+	report.Duration = time.Since(start)
+	if err != nil {
+		c.logger.Printf("⚠️ Drain of on-cadence batch %s incomplete after %s: %d transactions flushed, %d abandoned (%v)",
+			result.BatchID, report.Duration.Round(time.Millisecond), report.Flushed, report.Abandoned, err)
+		return report, err
+	}
+	c.logger.Printf("✅ Drained on-cadence batch %s in %s: %d transactions flushed, %d abandoned",
+		result.BatchID, report.Duration.Round(time.Millisecond), report.Flushed, report.Abandoned)
+	return report, nil
+}
+
+// handOffDrainedBatch runs the handoff for a closed batch and records its transactions as
+// flushed once it returns successfully, or as abandoned if it fails or ctx is done first
+func handOffDrainedBatch(ctx context.Context, result *ClosedBatchResult, handoff BatchReadyCallback, report *DrainReport) error {
+	report.BatchID = result.BatchID
+	if handoff == nil {
+		report.Abandoned = result.TxCount
+		return fmt.Errorf("no handoff configured for drained batch")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- handoff(ctx, result)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			report.Abandoned = result.TxCount
+			return fmt.Errorf("anchor submission failed: %w", err)
+		}
+		report.Flushed = result.TxCount
+		return nil
+	case <-ctx.Done():
+		report.Abandoned = result.TxCount
+		return fmt.Errorf("anchor submission not confirmed before the drain deadline: %w", ctx.Err())
+	}
+}
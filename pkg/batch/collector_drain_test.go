@@ -0,0 +1,55 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Collector Drain
+// Tests flushed and abandoned counts for a completed, failed and timed-out handoff
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestHandOffDrainedBatch(t *testing.T) {
+	result := &ClosedBatchResult{BatchID: uuid.New(), TxCount: 7}
+
+	report := &DrainReport{}
+	err := handOffDrainedBatch(context.Background(), result, func(ctx context.Context, r *ClosedBatchResult) error {
+		return nil
+	}, report)
+	if err != nil || report.Flushed != 7 || report.Abandoned != 0 || report.BatchID != result.BatchID {
+		t.Errorf("completed handoff: err=%v report=%+v", err, report)
+	}
+
+	report = &DrainReport{}
+	err = handOffDrainedBatch(context.Background(), result, func(ctx context.Context, r *ClosedBatchResult) error {
+		return errors.New("rpc unavailable")
+	}, report)
+	if err == nil || report.Flushed != 0 || report.Abandoned != 7 {
+		t.Errorf("failed handoff: err=%v report=%+v", err, report)
+	}
+
+	// The handoff ignores the deadline; the drain must not wait for it
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+
+	report = &DrainReport{}
+	err = handOffDrainedBatch(ctx, result, func(ctx context.Context, r *ClosedBatchResult) error {
+		<-release
+		return nil
+	}, report)
+	if !errors.Is(err, context.DeadlineExceeded) || report.Flushed != 0 || report.Abandoned != 7 {
+		t.Errorf("timed-out handoff: err=%v report=%+v", err, report)
+	}
+
+	report = &DrainReport{}
+	if err := handOffDrainedBatch(context.Background(), result, nil, report); err == nil || report.Abandoned != 7 {
+		t.Errorf("missing handoff: err=%v report=%+v", err, report)
+	}
+}
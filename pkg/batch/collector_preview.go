@@ -0,0 +1,91 @@
+// Copyright 2025 Certen Protocol
+//
+// Collector Preview - Dry-run view of the batch an on-demand transaction would close
+//
+// An on-demand anchor estimate needs the batch the transaction would be anchored in:
+// the open on-demand batch plus the new transaction. The preview builds that batch's
+// Merkle tree, inclusion proofs and aggregated proof data exactly as closeBatch does,
+// but from copies: it takes no batch slot, stores nothing and leaves the open batch
+// and usage meter untouched.
+
+package batch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/merkle"
+)
+
+// PreviewOnDemandBatch returns the batch that would be closed if tx were added to the open
+// on-demand batch now. The result shares no state with the collector and may be mutated.
+func (c *Collector) PreviewOnDemandBatch(tx *TransactionData, accumHeight int64, accumHash string) (*ClosedBatchResult, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is required")
+	}
+	if len(tx.TxHash) != 32 {
+		return nil, fmt.Errorf("transaction hash must be 32 bytes, got %d", len(tx.TxHash))
+	}
+	leaf, err := c.leafEncoding.EncodeLeaf(tx.TxHash, tx.AccountURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode merkle leaf: %w", err)
+	}
+
+	c.mu.RLock()
+	batchID := uuid.New() // The transaction would open a new batch
+	startTime := time.Now()
+	var leaves [][]byte
+	var txData []*TransactionData
+	if c.onDemandBatch != nil {
+		batchID = c.onDemandBatch.batchID
+		startTime = c.onDemandBatch.startTime
+		leaves = append(leaves, c.onDemandBatch.leaves...)
+		for _, pending := range c.onDemandBatch.txData {
+			copied := *pending
+			txData = append(txData, &copied)
+		}
+	}
+	c.mu.RUnlock()
+
+	copied := *tx
+	leaves = append(leaves, leaf)
+	txData = append(txData, &copied)
+
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build merkle tree: %w", err)
+	}
+
+	proofs := make([]*merkle.InclusionProof, len(leaves))
+	for i := range leaves {
+		proof, err := tree.GenerateProof(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate proof for leaf %d: %w", i, err)
+		}
+		proofs[i] = proof
+	}
+
+	bptRoot, networkRoot, govProofHashes := c.extractProofData(txData)
+	endTime := time.Now()
+
+	return &ClosedBatchResult{
+		BatchID:               batchID,
+		BatchType:             database.BatchTypeOnDemand,
+		MerkleRoot:            tree.Root(),
+		MerkleRootHex:         tree.RootHex(),
+		TxCount:               len(leaves),
+		StartTime:             startTime,
+		EndTime:               endTime,
+		Duration:              endTime.Sub(startTime),
+		AccumulateHeight:      accumHeight,
+		AccumulateHash:        accumHash,
+		Proofs:                proofs,
+		Transactions:          txData,
+		AggregatedBPTRoot:     bptRoot,
+		AggregatedNetworkRoot: networkRoot,
+		GovernanceProofHashes: govProofHashes,
+	}, nil
+}
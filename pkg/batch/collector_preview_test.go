@@ -0,0 +1,78 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Collector Preview
+// Tests that the previewed on-demand batch includes the open batch and leaves it unchanged
+
+package batch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/merkle"
+)
+
+func previewTestTx(name string) *TransactionData {
+	hash := sha256.Sum256([]byte(name))
+	return &TransactionData{AccumTxHash: name, AccountURL: "acc://" + name + ".acme", TxHash: hash[:]}
+}
+
+func TestPreviewOnDemandBatch(t *testing.T) {
+	c := &Collector{leafEncoding: DefaultLeafEncoding, logger: log.New(io.Discard, "", 0)}
+
+	// No open batch: a single-transaction batch
+	preview, err := c.PreviewOnDemandBatch(previewTestTx("first"), 10, "abc")
+	if err != nil {
+		t.Fatalf("PreviewOnDemandBatch failed: %v", err)
+	}
+	if preview.TxCount != 1 || preview.BatchType != database.BatchTypeOnDemand || preview.AccumulateHeight != 10 {
+		t.Errorf("unexpected preview: %+v", preview)
+	}
+
+	// Open batch with one transaction: the preview extends it
+	pending := previewTestTx("pending")
+	leaf, _ := DefaultLeafEncoding.EncodeLeaf(pending.TxHash, pending.AccountURL)
+	open := &activeBatch{
+		batchID:   uuid.New(),
+		batchType: database.BatchTypeOnDemand,
+		startTime: time.Now(),
+		leaves:    [][]byte{leaf},
+		txData:    []*TransactionData{pending},
+	}
+	c.onDemandBatch = open
+
+	tx := previewTestTx("new")
+	preview, err = c.PreviewOnDemandBatch(tx, 11, "def")
+	if err != nil {
+		t.Fatalf("PreviewOnDemandBatch failed: %v", err)
+	}
+	if preview.BatchID != open.batchID || preview.TxCount != 2 || len(preview.Proofs) != 2 {
+		t.Errorf("expected preview of open batch with 2 transactions, got %+v", preview)
+	}
+
+	newLeaf, _ := DefaultLeafEncoding.EncodeLeaf(tx.TxHash, tx.AccountURL)
+	tree, _ := merkle.BuildTree([][]byte{leaf, newLeaf})
+	if !bytes.Equal(preview.MerkleRoot, tree.Root()) {
+		t.Errorf("preview root %x, want %x", preview.MerkleRoot, tree.Root())
+	}
+
+	// The open batch is untouched and shares no transaction data with the preview
+	if len(open.leaves) != 1 || len(open.txData) != 1 {
+		t.Errorf("open batch mutated: %d leaves, %d txs", len(open.leaves), len(open.txData))
+	}
+	preview.Transactions[0].GovProof = []byte(`{}`)
+	if pending.GovProof != nil {
+		t.Error("preview shares transaction data with the open batch")
+	}
+
+	if _, err := c.PreviewOnDemandBatch(&TransactionData{TxHash: []byte{1}}, 0, ""); err == nil {
+		t.Error("expected error for short transaction hash")
+	}
+}
@@ -7,7 +7,10 @@
 // - Periodically polls for unconfirmed anchors
 // - Queries the Ethereum node for block confirmations
 // - Updates anchor and proof status when confirmed
+// - Marks anchors as "available" once mined with the minimum confirmations
 // - Marks anchors as finalized after reaching required confirmations
+// - Follows replaced anchor transactions to whichever submission is mined
+// - Invalidates anchors whose block was reorged out and restarts their confirmations
 
 package batch
 
@@ -15,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,6 +37,29 @@ type BlockInfoProvider interface {
 	GetBlockTimestamp(ctx context.Context, blockNumber int64) (time.Time, error)
 }
 
+// TransactionLocator finds the block a transaction was mined in
+type TransactionLocator interface {
+	// LocateTransaction returns the mined transaction, or nil if it is not mined
+	LocateTransaction(ctx context.Context, txHash string) (*MinedTransaction, error)
+}
+
+// TransactionLocatorFunc adapts a function to a TransactionLocator
+type TransactionLocatorFunc func(ctx context.Context, txHash string) (*MinedTransaction, error)
+
+// LocateTransaction implements TransactionLocator
+func (f TransactionLocatorFunc) LocateTransaction(ctx context.Context, txHash string) (*MinedTransaction, error) {
+	return f(ctx, txHash)
+}
+
+// MinedTransaction is where and at what price a transaction was mined
+type MinedTransaction struct {
+	TxHash      string
+	BlockNumber int64
+	BlockHash   string
+	GasUsed     uint64
+	GasPrice    *big.Int // Effective gas price (nil if unknown)
+}
+
 // ConfirmationTracker monitors anchor confirmations
 type ConfirmationTracker struct {
 	mu sync.RWMutex
@@ -39,16 +67,19 @@ type ConfirmationTracker struct {
 	// Dependencies
 	repos                *database.Repositories
 	blockProvider        BlockInfoProvider
-	firestoreSyncService *firestore.SyncService // Real-time UI sync
+	firestoreSyncService firestore.Syncer   // Real-time UI sync (never nil)
+	txLocator            TransactionLocator // Follows replaced anchor transactions; nil = disabled
 
 	// Configuration
-	pollInterval          time.Duration
-	requiredConfirmations int
+	pollInterval           time.Duration
+	availableConfirmations int
+	requiredConfirmations  int
 
 	// State
 	running bool
 	stopCh  chan struct{}
 	doneCh  chan struct{}
+	check   func(ctx context.Context) // One polling pass; checkUnconfirmedAnchors outside tests
 
 	// Logging
 	logger *log.Logger
@@ -56,17 +87,19 @@ type ConfirmationTracker struct {
 
 // ConfirmationTrackerConfig holds tracker configuration
 type ConfirmationTrackerConfig struct {
-	PollInterval          time.Duration
-	RequiredConfirmations int // Number of confirmations for finality (default: 12 for Ethereum)
-	Logger                *log.Logger
+	PollInterval           time.Duration
+	AvailableConfirmations int // Number of confirmations for "available" status (default: 1)
+	RequiredConfirmations  int // Number of confirmations for finality (default: 12 for Ethereum)
+	Logger                 *log.Logger
 }
 
 // DefaultConfirmationTrackerConfig returns default configuration
 func DefaultConfirmationTrackerConfig() *ConfirmationTrackerConfig {
 	return &ConfirmationTrackerConfig{
-		PollInterval:          30 * time.Second,
-		RequiredConfirmations: 12, // Standard Ethereum finality
-		Logger:                log.New(log.Writer(), "[ConfirmationTracker] ", log.LstdFlags),
+		PollInterval:           30 * time.Second,
+		AvailableConfirmations: database.DefaultAvailableConfirmations,
+		RequiredConfirmations:  12, // Standard Ethereum finality
+		Logger:                 log.New(log.Writer(), "[ConfirmationTracker] ", log.LstdFlags),
 	}
 }
 
@@ -85,17 +118,29 @@ func NewConfirmationTracker(
 	if cfg.Logger == nil {
 		cfg.Logger = log.New(log.Writer(), "[ConfirmationTracker] ", log.LstdFlags)
 	}
+	if cfg.AvailableConfirmations <= 0 {
+		cfg.AvailableConfirmations = database.DefaultAvailableConfirmations
+	}
+	if cfg.AvailableConfirmations > cfg.RequiredConfirmations {
+		return nil, fmt.Errorf("available confirmations (%d) cannot exceed required confirmations (%d)",
+			cfg.AvailableConfirmations, cfg.RequiredConfirmations)
+	}
 
-	return &ConfirmationTracker{
-		repos:                 repos,
-		blockProvider:         blockProvider,
-		pollInterval:          cfg.PollInterval,
-		requiredConfirmations: cfg.RequiredConfirmations,
-		logger:                cfg.Logger,
-	}, nil
+	t := &ConfirmationTracker{
+		repos:                  repos,
+		blockProvider:          blockProvider,
+		pollInterval:           cfg.PollInterval,
+		availableConfirmations: cfg.AvailableConfirmations,
+		requiredConfirmations:  cfg.RequiredConfirmations,
+		firestoreSyncService:   firestore.NullSyncService{},
+		logger:                 cfg.Logger,
+	}
+	t.check = t.checkUnconfirmedAnchors
+	return t, nil
 }
 
-// Start begins the confirmation tracking loop
+// Start begins the confirmation tracking loop. The loop stops when ctx is cancelled or
+// Stop is called.
 func (t *ConfirmationTracker) Start(ctx context.Context) error {
 	t.mu.Lock()
 	if t.running {
@@ -108,18 +153,20 @@ func (t *ConfirmationTracker) Start(ctx context.Context) error {
 	t.running = true
 	t.mu.Unlock()
 
-	go t.run(ctx)
+	go t.run(ctx, t.stopCh, t.doneCh)
 
-	t.logger.Printf("Started (polling every %s, %d confirmations for finality)",
-		t.pollInterval, t.requiredConfirmations)
+	t.logger.Printf("Started (polling every %s, %d confirmations for availability, %d for finality)",
+		t.pollInterval, t.availableConfirmations, t.requiredConfirmations)
 	return nil
 }
 
-// Stop stops the confirmation tracker
+// Stop stops the confirmation tracker and waits for the tracking loop to exit
 func (t *ConfirmationTracker) Stop() error {
 	t.mu.Lock()
 	if !t.running {
 		t.mu.Unlock()
+		// The loop may still be returning after its context was cancelled
+		t.Wait()
 		return nil
 	}
 
@@ -127,40 +174,67 @@ func (t *ConfirmationTracker) Stop() error {
 	t.running = false
 	t.mu.Unlock()
 
-	<-t.doneCh
+	t.Wait()
 
 	t.logger.Println("Stopped")
 	return nil
 }
 
+// Wait blocks until the tracking loop has exited. Returns immediately if the tracker
+// was never started.
+func (t *ConfirmationTracker) Wait() {
+	t.mu.RLock()
+	doneCh := t.doneCh
+	t.mu.RUnlock()
+	if doneCh != nil {
+		<-doneCh
+	}
+}
+
 // SetFirestoreSyncService sets the Firestore sync service for real-time UI updates
-func (t *ConfirmationTracker) SetFirestoreSyncService(svc *firestore.SyncService) {
+// (nil = firestore.NullSyncService)
+func (t *ConfirmationTracker) SetFirestoreSyncService(svc firestore.Syncer) {
+	if svc == nil {
+		svc = firestore.NullSyncService{}
+	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.firestoreSyncService = svc
-	if svc != nil && svc.IsEnabled() {
+	if svc.IsEnabled() {
 		t.logger.Println("Firestore sync service connected - will sync confirmation updates")
 	}
 }
 
+// SetTransactionLocator enables following anchors whose transaction was replaced (fee
+// escalation or stuck transaction replacement) to whichever submission is mined
+func (t *ConfirmationTracker) SetTransactionLocator(locator TransactionLocator) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.txLocator = locator
+}
+
 // run is the main tracking loop
-func (t *ConfirmationTracker) run(ctx context.Context) {
-	defer close(t.doneCh)
+func (t *ConfirmationTracker) run(ctx context.Context, stopCh <-chan struct{}, doneCh chan struct{}) {
+	defer close(doneCh)
 
 	ticker := time.NewTicker(t.pollInterval)
 	defer ticker.Stop()
 
 	// Initial check
-	t.checkUnconfirmedAnchors(ctx)
+	t.check(ctx)
 
 	for {
 		select {
 		case <-ctx.Done():
+			t.mu.Lock()
+			t.running = false
+			t.mu.Unlock()
+			t.logger.Println("Context cancelled - stopped")
 			return
-		case <-t.stopCh:
+		case <-stopCh:
 			return
 		case <-ticker.C:
-			t.checkUnconfirmedAnchors(ctx)
+			t.check(ctx)
 		}
 	}
 }
@@ -197,6 +271,17 @@ func (t *ConfirmationTracker) checkUnconfirmedAnchors(ctx context.Context) {
 
 // processAnchor processes a single anchor for confirmation updates
 func (t *ConfirmationTracker) processAnchor(ctx context.Context, anchor *database.AnchorRecord, latestBlock int64) {
+	if !t.followReplacements(ctx, anchor) {
+		return
+	}
+
+	// Anchors invalidated by a reorg wait until their transaction is mined again
+	if anchor.Finality == database.AnchorFinalityReorged {
+		if restored, _ := t.relocateReorgedAnchor(ctx, anchor); !restored {
+			return
+		}
+	}
+
 	// Calculate confirmations
 	var confirmations int
 	if latestBlock > 0 && anchor.AnchorBlockNumber > 0 {
@@ -221,6 +306,15 @@ func (t *ConfirmationTracker) processAnchor(ctx context.Context, anchor *databas
 		}
 	}
 
+	// A different hash at the anchor's height means its block was reorged out
+	if blockReorged(anchor.AnchorBlockHash.String, blockHash) {
+		t.invalidateReorgedAnchor(ctx, anchor, blockHash)
+		return
+	}
+	if blockHash == "" {
+		blockHash = anchor.AnchorBlockHash.String // Keep the recorded hash when the lookup fails
+	}
+
 	// Update confirmations in database
 	err := t.repos.Anchors.UpdateConfirmations(ctx, anchor.AnchorID, confirmations, blockHash, blockTimestamp)
 	if err != nil {
@@ -229,12 +323,25 @@ func (t *ConfirmationTracker) processAnchor(ctx context.Context, anchor *databas
 	}
 
 	// Sync confirmation update to Firestore (Stage 7)
-	if t.firestoreSyncService != nil && t.firestoreSyncService.IsEnabled() {
+	if t.firestoreSyncService.IsEnabled() {
 		go t.triggerConfirmationFirestoreEvent(ctx, anchor, confirmations, latestBlock)
 	}
 
+	// Check if anchor has become available (mined with the minimum confirmations)
+	availableConfirms, requiredConfirms := t.anchorThresholds(anchor)
+	finality := database.ComputeAnchorFinality(confirmations, availableConfirms, requiredConfirms)
+	if finality == database.AnchorFinalityAvailable && anchor.Finality != database.AnchorFinalityAvailable {
+		t.logger.Printf("Anchor %s is available (%d/%d confirmations)", anchor.AnchorID, confirmations, requiredConfirms)
+
+		if err := t.repos.Anchors.MarkAnchorAvailable(ctx, anchor.AnchorID); err != nil {
+			t.logger.Printf("Failed to mark anchor %s as available: %v", anchor.AnchorID, err)
+		}
+		t.updateProofConfirmations(ctx, anchor, confirmations, blockHash, finality)
+		return
+	}
+
 	// Check if anchor has reached finality
-	if confirmations >= t.requiredConfirmations {
+	if finality == database.AnchorFinalityFinal {
 		t.logger.Printf("Anchor %s reached finality (%d confirmations)", anchor.AnchorID, confirmations)
 
 		// Mark anchor as final
@@ -243,18 +350,10 @@ func (t *ConfirmationTracker) processAnchor(ctx context.Context, anchor *databas
 		}
 
 		// Update all proofs associated with this anchor
-		proofs, err := t.repos.Proofs.GetProofsByAnchorID(ctx, anchor.AnchorID)
-		if err != nil {
-			t.logger.Printf("Failed to get proofs for anchor %s: %v", anchor.AnchorID, err)
+		if !t.updateProofConfirmations(ctx, anchor, confirmations, blockHash, finality) {
 			return
 		}
 
-		for _, proof := range proofs {
-			if err := t.repos.Proofs.UpdateAnchorConfirmations(ctx, proof.ProofID, confirmations, blockHash); err != nil {
-				t.logger.Printf("Failed to update proof %s confirmations: %v", proof.ProofID, err)
-			}
-		}
-
 		// Mark external chain results as finalized (Gap 5 fix)
 		if t.repos.ProofArtifacts != nil {
 			if ecCount, err := t.repos.ProofArtifacts.MarkExternalChainResultsFinalizedByAnchor(ctx, anchor.AnchorID); err != nil {
@@ -273,6 +372,206 @@ func (t *ConfirmationTracker) processAnchor(ctx context.Context, anchor *databas
 	}
 }
 
+// anchorThresholds returns the confirmation thresholds recorded on the anchor when it was
+// created, falling back to the tracker's configuration for anchors that predate them
+func (t *ConfirmationTracker) anchorThresholds(anchor *database.AnchorRecord) (availableConfirms, requiredConfirms int) {
+	availableConfirms, requiredConfirms = anchor.AvailableConfirms, anchor.RequiredConfirms
+	if availableConfirms <= 0 {
+		availableConfirms = t.availableConfirmations
+	}
+	if requiredConfirms <= 0 {
+		requiredConfirms = t.requiredConfirmations
+	}
+	return availableConfirms, requiredConfirms
+}
+
+// followReplacements points the anchor at whichever submission of its transaction is mined.
+// Replaced anchor transactions have several submissions sharing a nonce, and a reorg can
+// drop the recorded one in favour of another. Returns false when none is mined.
+func (t *ConfirmationTracker) followReplacements(ctx context.Context, anchor *database.AnchorRecord) bool {
+	t.mu.RLock()
+	locator := t.txLocator
+	t.mu.RUnlock()
+	if locator == nil {
+		return true
+	}
+
+	steps, err := t.repos.Anchors.GetFeeEscalationSteps(ctx, anchor.AnchorID)
+	if err != nil {
+		t.logger.Printf("Failed to get submissions of anchor %s: %v", anchor.AnchorID, err)
+		return true
+	}
+	if len(steps) < 2 {
+		return true // Never replaced
+	}
+
+	mined, err := locateMinedSubmission(ctx, locator, anchor.AnchorTxHash, steps)
+	if err != nil {
+		t.logger.Printf("Failed to locate submissions of anchor %s: %v", anchor.AnchorID, err)
+		return true
+	}
+	if mined == nil {
+		t.logger.Printf("No submission of anchor %s is mined (reorged out?), waiting for one of %d", anchor.AnchorID, len(steps))
+		if err := t.repos.Anchors.UpdateConfirmations(ctx, anchor.AnchorID, 0, "", time.Time{}); err != nil {
+			t.logger.Printf("Failed to reset confirmations for anchor %s: %v", anchor.AnchorID, err)
+		}
+		return false
+	}
+	if strings.EqualFold(mined.TxHash, anchor.AnchorTxHash) && mined.BlockNumber == anchor.AnchorBlockNumber {
+		return true
+	}
+
+	sub := &database.MinedAnchorSubmission{
+		TxHash:      mined.TxHash,
+		BlockNumber: mined.BlockNumber,
+		BlockHash:   mined.BlockHash,
+		GasUsed:     int64(mined.GasUsed),
+	}
+	if mined.GasPrice != nil {
+		sub.GasPriceWei = mined.GasPrice.String()
+		sub.TotalCostWei = new(big.Int).Mul(mined.GasPrice, new(big.Int).SetUint64(mined.GasUsed)).String()
+	}
+	if err := t.repos.Anchors.SwitchMinedSubmission(ctx, anchor.AnchorID, sub); err != nil {
+		t.logger.Printf("Failed to switch anchor %s to mined submission %s: %v", anchor.AnchorID, mined.TxHash, err)
+		return true
+	}
+	t.logger.Printf("Anchor %s now tracks submission %s in block %d (was %s in block %d)",
+		anchor.AnchorID, mined.TxHash, mined.BlockNumber, anchor.AnchorTxHash, anchor.AnchorBlockNumber)
+	anchor.AnchorTxHash = mined.TxHash
+	anchor.AnchorBlockNumber = mined.BlockNumber
+	return true
+}
+
+// blockReorged reports whether the canonical hash at an anchor's height differs from
+// the hash recorded for it. Unknown hashes on either side are not a reorg.
+func blockReorged(recorded, current string) bool {
+	if recorded == "" || current == "" {
+		return false
+	}
+	return !strings.EqualFold(strings.TrimPrefix(recorded, "0x"), strings.TrimPrefix(current, "0x"))
+}
+
+// invalidateReorgedAnchor marks an anchor whose block was reorged out, resets the
+// confirmations of the anchor and its proofs, and looks for its transaction again
+func (t *ConfirmationTracker) invalidateReorgedAnchor(ctx context.Context, anchor *database.AnchorRecord, currentHash string) {
+	reorgedHash := anchor.AnchorBlockHash.String
+	t.logger.Printf("Anchor %s block %d was reorged out (hash %s, now %s), invalidating %s confirmations",
+		anchor.AnchorID, anchor.AnchorBlockNumber, reorgedHash, currentHash, anchor.Finality)
+
+	if err := t.repos.Anchors.MarkAnchorReorged(ctx, anchor.AnchorID); err != nil {
+		t.logger.Printf("Failed to mark anchor %s as reorged: %v", anchor.AnchorID, err)
+		return
+	}
+	anchor.Finality = database.AnchorFinalityReorged
+	anchor.Confirmations = 0
+	anchor.AnchorBlockHash.String, anchor.AnchorBlockHash.Valid = "", false
+	t.updateProofConfirmations(ctx, anchor, 0, "", database.AnchorFinalityReorged)
+
+	_, dropped := t.relocateReorgedAnchor(ctx, anchor)
+
+	if t.firestoreSyncService.IsEnabled() {
+		event := &firestore.AnchorReorgedEvent{
+			BatchID:            anchor.BatchID.String(),
+			AnchorTxHash:       anchor.AnchorTxHash,
+			BlockNumber:        anchor.AnchorBlockNumber,
+			ReorgedBlockHash:   reorgedHash,
+			CurrentBlockHash:   currentHash,
+			TransactionDropped: dropped,
+		}
+		go t.triggerReorgFirestoreEvent(ctx, anchor, event)
+	}
+}
+
+// relocateReorgedAnchor looks for a reorged anchor's transaction on the canonical chain
+// and, once it is mined again, restarts confirmation counting from its new block.
+// Until then the anchor stays flagged as reorged; dropped reports that the transaction
+// is not mined anywhere and needs to be re-anchored.
+func (t *ConfirmationTracker) relocateReorgedAnchor(ctx context.Context, anchor *database.AnchorRecord) (restored, dropped bool) {
+	t.mu.RLock()
+	locator := t.txLocator
+	t.mu.RUnlock()
+	if locator == nil {
+		t.logger.Printf("Anchor %s is reorged and cannot be re-located without a transaction locator", anchor.AnchorID)
+		return false, false
+	}
+
+	mined, err := locator.LocateTransaction(ctx, anchor.AnchorTxHash)
+	if err != nil {
+		t.logger.Printf("Failed to locate transaction of reorged anchor %s: %v", anchor.AnchorID, err)
+		return false, false
+	}
+	if mined == nil {
+		t.logger.Printf("Anchor %s transaction %s is no longer mined, flagged as reorged until it is re-anchored",
+			anchor.AnchorID, anchor.AnchorTxHash)
+		return false, true
+	}
+
+	if err := t.repos.Anchors.RestoreReorgedAnchor(ctx, anchor.AnchorID, mined.BlockNumber, mined.BlockHash); err != nil {
+		t.logger.Printf("Failed to restore reorged anchor %s: %v", anchor.AnchorID, err)
+		return false, false
+	}
+	t.logger.Printf("Anchor %s transaction %s mined again in block %d (was block %d)",
+		anchor.AnchorID, anchor.AnchorTxHash, mined.BlockNumber, anchor.AnchorBlockNumber)
+	anchor.Finality = database.AnchorFinalityPending
+	anchor.AnchorBlockNumber = mined.BlockNumber
+	anchor.AnchorBlockHash.String, anchor.AnchorBlockHash.Valid = mined.BlockHash, mined.BlockHash != ""
+	return true, false
+}
+
+// locateMinedSubmission returns the mined submission among an anchor's submissions,
+// checking the recorded one first and then the others from the latest
+func locateMinedSubmission(ctx context.Context, locator TransactionLocator, recorded string, steps []database.AnchorFeeEscalationStep) (*MinedTransaction, error) {
+	hashes := []string{recorded}
+	for i := len(steps) - 1; i >= 0; i-- {
+		if !strings.EqualFold(steps[i].TxHash, recorded) {
+			hashes = append(hashes, steps[i].TxHash)
+		}
+	}
+	for _, hash := range hashes {
+		mined, err := locator.LocateTransaction(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if mined != nil {
+			return mined, nil
+		}
+	}
+	return nil, nil
+}
+
+// updateProofConfirmations propagates an anchor's confirmations and finality to its proofs.
+// Returns false if the proofs could not be loaded.
+func (t *ConfirmationTracker) updateProofConfirmations(ctx context.Context, anchor *database.AnchorRecord, confirmations int, blockHash string, finality database.AnchorFinality) bool {
+	proofs, err := t.repos.Proofs.GetProofsByAnchorID(ctx, anchor.AnchorID)
+	if err != nil {
+		t.logger.Printf("Failed to get proofs for anchor %s: %v", anchor.AnchorID, err)
+		return false
+	}
+
+	for _, proof := range proofs {
+		if err := t.repos.Proofs.UpdateAnchorConfirmations(ctx, proof.ProofID, confirmations, blockHash, finality); err != nil {
+			t.logger.Printf("Failed to update proof %s confirmations: %v", proof.ProofID, err)
+		}
+	}
+	return true
+}
+
+// ConfirmationTrackerSettings is the tracker's effective configuration
+type ConfirmationTrackerSettings struct {
+	PollInterval           string `json:"poll_interval"`
+	AvailableConfirmations int    `json:"available_confirmations"`
+	RequiredConfirmations  int    `json:"required_confirmations"`
+}
+
+// Settings returns the tracker's effective configuration
+func (t *ConfirmationTracker) Settings() ConfirmationTrackerSettings {
+	return ConfirmationTrackerSettings{
+		PollInterval:           t.pollInterval.String(),
+		AvailableConfirmations: t.availableConfirmations,
+		RequiredConfirmations:  t.requiredConfirmations,
+	}
+}
+
 // ForceCheck manually triggers a confirmation check
 func (t *ConfirmationTracker) ForceCheck(ctx context.Context) {
 	t.checkUnconfirmedAnchors(ctx)
@@ -290,6 +589,16 @@ func (t *ConfirmationTracker) GetStats(ctx context.Context) (*ConfirmationStats,
 		return nil, err
 	}
 
+	availableAnchors, err := t.repos.Anchors.CountAvailableAnchors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reorgedAnchors, err := t.repos.Anchors.CountReorgedAnchors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	unconfirmedAnchors, err := t.repos.Anchors.GetUnconfirmedAnchors(ctx)
 	if err != nil {
 		return nil, err
@@ -300,29 +609,31 @@ func (t *ConfirmationTracker) GetStats(ctx context.Context) (*ConfirmationStats,
 	t.mu.RUnlock()
 
 	return &ConfirmationStats{
-		TotalAnchors:          totalAnchors,
-		FinalizedAnchors:      finalAnchors,
-		PendingAnchors:        int64(len(unconfirmedAnchors)),
-		RequiredConfirmations: t.requiredConfirmations,
-		TrackerRunning:        running,
+		TotalAnchors:           totalAnchors,
+		FinalizedAnchors:       finalAnchors,
+		AvailableAnchors:       availableAnchors,
+		ReorgedAnchors:         reorgedAnchors,
+		PendingAnchors:         int64(len(unconfirmedAnchors)),
+		AvailableConfirmations: t.availableConfirmations,
+		RequiredConfirmations:  t.requiredConfirmations,
+		TrackerRunning:         running,
 	}, nil
 }
 
 // ConfirmationStats holds confirmation tracker statistics
 type ConfirmationStats struct {
-	TotalAnchors          int64 `json:"total_anchors"`
-	FinalizedAnchors      int64 `json:"finalized_anchors"`
-	PendingAnchors        int64 `json:"pending_anchors"`
-	RequiredConfirmations int   `json:"required_confirmations"`
-	TrackerRunning        bool  `json:"tracker_running"`
+	TotalAnchors           int64 `json:"total_anchors"`
+	FinalizedAnchors       int64 `json:"finalized_anchors"`
+	AvailableAnchors       int64 `json:"available_anchors"` // Mined but not yet final (subset of pending)
+	ReorgedAnchors         int64 `json:"reorged_anchors"`   // Block reorged out, waiting to be mined again (subset of pending)
+	PendingAnchors         int64 `json:"pending_anchors"`
+	AvailableConfirmations int   `json:"available_confirmations"`
+	RequiredConfirmations  int   `json:"required_confirmations"`
+	TrackerRunning         bool  `json:"tracker_running"`
 }
 
 // triggerConfirmationFirestoreEvent sends confirmation update to Firestore (Stage 7)
 func (t *ConfirmationTracker) triggerConfirmationFirestoreEvent(ctx context.Context, anchor *database.AnchorRecord, confirmations int, latestBlock int64) {
-	if t.firestoreSyncService == nil {
-		return
-	}
-
 	// Get transaction hashes for this anchor's batch
 	txHashes, err := t.repos.Batches.GetTransactionHashesByBatchID(ctx, anchor.BatchID)
 	if err != nil {
@@ -334,12 +645,13 @@ func (t *ConfirmationTracker) triggerConfirmationFirestoreEvent(ctx context.Cont
 		return
 	}
 
+	_, requiredConfirms := t.anchorThresholds(anchor)
 	event := &firestore.ConfirmationUpdateEvent{
 		BatchID:               anchor.BatchID.String(),
 		AnchorTxHash:          anchor.AnchorTxHash,
 		CurrentConfirmations:  confirmations,
-		RequiredConfirmations: t.requiredConfirmations,
-		IsConfirmed:           confirmations >= t.requiredConfirmations,
+		RequiredConfirmations: requiredConfirms,
+		IsConfirmed:           confirmations >= requiredConfirms,
 		BlockNumber:           latestBlock,
 		TransactionHashes:     txHashes,
 	}
@@ -349,6 +661,23 @@ func (t *ConfirmationTracker) triggerConfirmationFirestoreEvent(ctx context.Cont
 	}
 }
 
+// triggerReorgFirestoreEvent sends an anchor reorg to Firestore
+func (t *ConfirmationTracker) triggerReorgFirestoreEvent(ctx context.Context, anchor *database.AnchorRecord, event *firestore.AnchorReorgedEvent) {
+	txHashes, err := t.repos.Batches.GetTransactionHashesByBatchID(ctx, anchor.BatchID)
+	if err != nil {
+		t.logger.Printf("Warning: failed to get tx hashes for batch %s: %v", anchor.BatchID, err)
+		return
+	}
+	if len(txHashes) == 0 {
+		return
+	}
+	event.TransactionHashes = txHashes
+
+	if err := t.firestoreSyncService.OnAnchorReorged(ctx, event); err != nil {
+		t.logger.Printf("Warning: failed to sync anchor reorg to Firestore: %v", err)
+	}
+}
+
 // BatchAwareStatus provides batch-type-aware status for health checks
 type BatchAwareStatus struct {
 	TrackerStatus         string `json:"tracker_status"`
@@ -0,0 +1,187 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Confirmation Tracker
+// Tests locating the mined submission of a replaced anchor transaction, detecting
+// reorged anchor blocks, per-anchor finality thresholds and stopping the tracking
+// loop on context cancellation
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestLocateMinedSubmission(t *testing.T) {
+	steps := []database.AnchorFeeEscalationStep{
+		{Attempt: 1, TxHash: "0xaa"},
+		{Attempt: 2, TxHash: "0xbb"},
+		{Attempt: 3, TxHash: "0xcc"},
+	}
+
+	var queried []string
+	locator := func(mined map[string]int64) TransactionLocator {
+		queried = nil
+		return TransactionLocatorFunc(func(ctx context.Context, txHash string) (*MinedTransaction, error) {
+			txHash = strings.ToLower(txHash) // Hash lookups are case-insensitive
+			queried = append(queried, txHash)
+			if block, ok := mined[txHash]; ok {
+				return &MinedTransaction{TxHash: txHash, BlockNumber: block}, nil
+			}
+			return nil, nil
+		})
+	}
+
+	// Recorded submission still mined: only it is queried
+	mined, err := locateMinedSubmission(context.Background(), locator(map[string]int64{"0xcc": 100}), "0xCC", steps)
+	if err != nil || mined == nil || mined.TxHash != "0xcc" || len(queried) != 1 {
+		t.Errorf("expected recorded submission, got %+v (queried %v, err %v)", mined, queried, err)
+	}
+
+	// Recorded submission reorged out, an earlier one mined instead
+	mined, err = locateMinedSubmission(context.Background(), locator(map[string]int64{"0xaa": 101}), "0xcc", steps)
+	if err != nil || mined == nil || mined.TxHash != "0xaa" || mined.BlockNumber != 101 {
+		t.Errorf("expected earlier submission, got %+v (err %v)", mined, err)
+	}
+	if strings.Join(queried, ",") != "0xcc,0xbb,0xaa" {
+		t.Errorf("expected recorded then latest-first lookups, got %v", queried)
+	}
+
+	// Nothing mined
+	if mined, err := locateMinedSubmission(context.Background(), locator(nil), "0xcc", steps); err != nil || mined != nil {
+		t.Errorf("expected no mined submission, got %+v (err %v)", mined, err)
+	}
+
+	// Lookup errors are returned
+	failing := TransactionLocatorFunc(func(ctx context.Context, txHash string) (*MinedTransaction, error) {
+		return nil, errors.New("node unavailable")
+	})
+	if _, err := locateMinedSubmission(context.Background(), failing, "0xcc", steps); err == nil {
+		t.Error("expected lookup error")
+	}
+}
+
+func TestBlockReorged(t *testing.T) {
+	tests := []struct {
+		recorded, current string
+		want              bool
+	}{
+		{"0xabc123", "0xabc123", false},
+		{"0xABC123", "abc123", false}, // Case and 0x prefix are ignored
+		{"0xabc123", "0xdef456", true},
+		{"", "0xdef456", false}, // Nothing recorded yet
+		{"0xabc123", "", false}, // Lookup failed
+	}
+	for _, tt := range tests {
+		if got := blockReorged(tt.recorded, tt.current); got != tt.want {
+			t.Errorf("blockReorged(%q, %q) = %v, want %v", tt.recorded, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestComputeAnchorFinality(t *testing.T) {
+	tests := []struct {
+		confirmations, available, required int
+		want                               database.AnchorFinality
+	}{
+		{0, 1, 12, database.AnchorFinalityPending},
+		{1, 1, 12, database.AnchorFinalityAvailable},
+		{11, 1, 12, database.AnchorFinalityAvailable},
+		{12, 1, 12, database.AnchorFinalityFinal},
+		{20, 1, 12, database.AnchorFinalityFinal},
+		{2, 3, 12, database.AnchorFinalityPending},
+		{5, 0, 12, database.AnchorFinalityPending}, // Availability disabled
+		{1, 1, 1, database.AnchorFinalityFinal},    // Final takes precedence
+	}
+	for _, tt := range tests {
+		if got := database.ComputeAnchorFinality(tt.confirmations, tt.available, tt.required); got != tt.want {
+			t.Errorf("ComputeAnchorFinality(%d, %d, %d) = %s, want %s",
+				tt.confirmations, tt.available, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestAnchorFinalityTransitions(t *testing.T) {
+	tracker, err := NewConfirmationTracker(&database.Repositories{}, nil, &ConfirmationTrackerConfig{
+		AvailableConfirmations: 1,
+		RequiredConfirmations:  12,
+		Logger:                 log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewConfirmationTracker: %v", err)
+	}
+
+	// The anchor's own thresholds win over the tracker's configuration
+	anchor := &database.AnchorRecord{AvailableConfirms: 2, RequiredConfirms: 4}
+	want := []database.AnchorFinality{
+		database.AnchorFinalityPending,   // 0
+		database.AnchorFinalityPending,   // 1
+		database.AnchorFinalityAvailable, // 2
+		database.AnchorFinalityAvailable, // 3
+		database.AnchorFinalityFinal,     // 4
+	}
+	for confirmations, w := range want {
+		available, required := tracker.anchorThresholds(anchor)
+		if got := database.ComputeAnchorFinality(confirmations, available, required); got != w {
+			t.Errorf("%d confirmations: got %s, want %s", confirmations, got, w)
+		}
+	}
+
+	// Anchors without recorded thresholds use the tracker's
+	available, required := tracker.anchorThresholds(&database.AnchorRecord{})
+	if available != 1 || required != 12 {
+		t.Errorf("expected tracker thresholds 1/12, got %d/%d", available, required)
+	}
+}
+
+func TestConfirmationTrackerStopsOnContextCancel(t *testing.T) {
+	tracker, err := NewConfirmationTracker(&database.Repositories{}, nil, &ConfirmationTrackerConfig{
+		PollInterval:          time.Millisecond,
+		RequiredConfirmations: 12,
+		Logger:                log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewConfirmationTracker: %v", err)
+	}
+	var checks atomic.Int64
+	tracker.check = func(ctx context.Context) { checks.Add(1) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := tracker.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for checks.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	waited := make(chan struct{})
+	go func() {
+		tracker.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("tracking loop still running after context cancellation")
+	}
+
+	// No polling pass runs once the loop has exited
+	after := checks.Load()
+	time.Sleep(10 * time.Millisecond)
+	if checks.Load() != after {
+		t.Errorf("expected no checks after cancellation, got %d more", checks.Load()-after)
+	}
+	if err := tracker.Stop(); err != nil {
+		t.Errorf("Stop after cancellation: %v", err)
+	}
+}
@@ -220,9 +220,9 @@ func (cc *ConsensusCoordinator) Start(ctx context.Context) error {
 		}
 
 		// Register event handlers
-		cc.eventWatcher.RegisterHandler(anchor.EventTypeAnchorCreated, cc.handleAnchorCreatedEvent)
-		cc.eventWatcher.RegisterHandler(anchor.EventTypeProofExecuted, cc.handleProofExecutedEvent)
-		cc.eventWatcher.RegisterHandler(anchor.EventTypeProofVerificationFailed, cc.handleProofVerificationFailedEvent)
+		cc.eventWatcher.OnAnchorCreated(cc.handleAnchorCreatedEvent)
+		cc.eventWatcher.OnProofExecuted(cc.handleProofExecutedEvent)
+		cc.eventWatcher.OnProofVerificationFailed(cc.handleProofVerificationFailedEvent)
 	}
 
 	// Start cleanup goroutine
@@ -495,12 +495,7 @@ func (cc *ConsensusCoordinator) updateEntryState(batchID uuid.UUID, state Consen
 // =============================================================================
 
 // handleAnchorCreatedEvent handles AnchorCreated events from the contract
-func (cc *ConsensusCoordinator) handleAnchorCreatedEvent(event anchor.ContractEvent) error {
-	anchorEvent, ok := event.(*anchor.AnchorCreatedEvent)
-	if !ok {
-		return fmt.Errorf("invalid event type for AnchorCreated handler")
-	}
-
+func (cc *ConsensusCoordinator) handleAnchorCreatedEvent(anchorEvent *anchor.AnchorCreatedEvent) error {
 	cc.logger.Printf("Observed AnchorCreated on-chain: bundleId=%s, validator=%s, block=%d",
 		hex.EncodeToString(anchorEvent.BundleID[:])[:16],
 		anchorEvent.Validator.Hex()[:10],
@@ -512,12 +507,7 @@ func (cc *ConsensusCoordinator) handleAnchorCreatedEvent(event anchor.ContractEv
 }
 
 // handleProofExecutedEvent handles ProofExecuted events from the contract
-func (cc *ConsensusCoordinator) handleProofExecutedEvent(event anchor.ContractEvent) error {
-	proofEvent, ok := event.(*anchor.ProofExecutedEvent)
-	if !ok {
-		return fmt.Errorf("invalid event type for ProofExecuted handler")
-	}
-
+func (cc *ConsensusCoordinator) handleProofExecutedEvent(proofEvent *anchor.ProofExecutedEvent) error {
 	cc.logger.Printf("Observed ProofExecuted on-chain: anchorId=%s, merkle=%v, bls=%v, gov=%v",
 		hex.EncodeToString(proofEvent.AnchorID[:])[:16],
 		proofEvent.MerkleVerified,
@@ -528,12 +518,7 @@ func (cc *ConsensusCoordinator) handleProofExecutedEvent(event anchor.ContractEv
 }
 
 // handleProofVerificationFailedEvent handles ProofVerificationFailed events
-func (cc *ConsensusCoordinator) handleProofVerificationFailedEvent(event anchor.ContractEvent) error {
-	failEvent, ok := event.(*anchor.ProofVerificationFailedEvent)
-	if !ok {
-		return fmt.Errorf("invalid event type for ProofVerificationFailed handler")
-	}
-
+func (cc *ConsensusCoordinator) handleProofVerificationFailedEvent(failEvent *anchor.ProofVerificationFailedEvent) error {
 	cc.logger.Printf("ALERT: ProofVerificationFailed on-chain: anchorId=%s, reason=%s",
 		hex.EncodeToString(failEvent.AnchorID[:])[:16],
 		failEvent.Reason)
@@ -15,4 +15,16 @@ var (
 	ErrBatchEmpty       = errors.New("batch is empty")
 	ErrInvalidTxHash    = errors.New("transaction hash must be 32 bytes")
 	ErrSchedulerRunning = errors.New("scheduler is already running")
+	ErrQuotaExceeded    = errors.New("account proof quota exceeded")
+
+	// ErrTransactionAlreadyBatched means the transaction is already queued or anchored;
+	// a re-delivered transaction can be treated as handled
+	ErrTransactionAlreadyBatched = errors.New("transaction already queued or anchored")
+
+	ErrBatchNotRetryable    = errors.New("batch is not in a retryable state")
+	ErrBatchAlreadyAnchored = errors.New("batch is already anchored")
+
+	// ErrIllegalBatchTransition means the batch's current status may not move to the
+	// requested one (see batchTransitions)
+	ErrIllegalBatchTransition = errors.New("illegal batch status transition")
 )
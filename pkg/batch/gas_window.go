@@ -0,0 +1,304 @@
+// Copyright 2025 Certen Protocol
+//
+// Gas Window - Defers on-cadence anchoring until gas is below a target
+//
+// On-cadence batches are the cheap tier and are already expected to wait, so when gas-aware
+// scheduling is enabled a batch that closes while gas is above the target is held instead of
+// anchored. Held batches are released (oldest first) once gas drops to the target, or when a
+// batch has been held for the configured maximum delay. On-demand batches always anchor
+// immediately. If the gas price cannot be read, batches anchor immediately (fail open).
+//
+// Held batches live in memory; a batch held when the process stops stays closed in the
+// database. Flush releases everything and is called during shutdown.
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// GasWindowDecision is what the gas window does with a newly closed on-cadence batch
+type GasWindowDecision string
+
+const (
+	GasWindowAnchorNow GasWindowDecision = "anchor_now" // Gas at or below target (or unknown)
+	GasWindowHold      GasWindowDecision = "hold"       // Gas above target - batches are held
+)
+
+// GasPriceFunc returns the current gas price in wei
+type GasPriceFunc func(ctx context.Context) (*big.Int, error)
+
+// GasWindowConfig holds configuration for gas-aware anchoring
+type GasWindowConfig struct {
+	TargetGasPrice *big.Int      // Anchor on-cadence batches when gas is at or below this (wei)
+	MaxDelay       time.Duration // Anchor a held batch after this long regardless of gas
+	CheckInterval  time.Duration // How often held batches are re-evaluated
+	Logger         *log.Logger
+}
+
+// DefaultGasWindowConfig returns default configuration
+func DefaultGasWindowConfig() *GasWindowConfig {
+	return &GasWindowConfig{
+		TargetGasPrice: big.NewInt(20_000_000_000), // 20 gwei
+		MaxDelay:       6 * time.Hour,
+		CheckInterval:  time.Minute,
+		Logger:         log.New(log.Writer(), "[GasWindow] ", log.LstdFlags),
+	}
+}
+
+// GasWindowStatus reports the current gas decision and held batches
+type GasWindowStatus struct {
+	Decision       GasWindowDecision `json:"decision"`
+	GasPriceWei    string            `json:"gas_price_wei,omitempty"` // Last observed gas price
+	TargetPriceWei string            `json:"target_price_wei"`
+	MaxDelay       string            `json:"max_delay"`
+	HeldBatches    int               `json:"held_batches"`
+	OldestHeldAt   *time.Time        `json:"oldest_held_at,omitempty"`
+	LastCheckedAt  *time.Time        `json:"last_checked_at,omitempty"`
+}
+
+type heldBatch struct {
+	result *ClosedBatchResult
+	heldAt time.Time
+}
+
+// GasWindow wraps the batch-ready callback and holds on-cadence batches while gas is high
+type GasWindow struct {
+	mu sync.Mutex
+
+	gasPrice GasPriceFunc
+	next     BatchReadyCallback
+
+	target        *big.Int
+	maxDelay      time.Duration
+	checkInterval time.Duration
+
+	held        []*heldBatch
+	lastPrice   *big.Int
+	lastChecked time.Time
+	decision    GasWindowDecision
+
+	now    func() time.Time
+	stopCh chan struct{}
+	doneCh chan struct{}
+	logger *log.Logger
+}
+
+// NewGasWindow creates a gas window that forwards batches to next
+func NewGasWindow(gasPrice GasPriceFunc, next BatchReadyCallback, cfg *GasWindowConfig) (*GasWindow, error) {
+	if gasPrice == nil {
+		return nil, fmt.Errorf("gas price source cannot be nil")
+	}
+	if next == nil {
+		return nil, fmt.Errorf("batch callback cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultGasWindowConfig()
+	}
+	if cfg.TargetGasPrice == nil || cfg.TargetGasPrice.Sign() <= 0 {
+		return nil, fmt.Errorf("target gas price must be positive")
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = time.Minute
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[GasWindow] ", log.LstdFlags)
+	}
+
+	return &GasWindow{
+		gasPrice:      gasPrice,
+		next:          next,
+		target:        new(big.Int).Set(cfg.TargetGasPrice),
+		maxDelay:      cfg.MaxDelay,
+		checkInterval: cfg.CheckInterval,
+		decision:      GasWindowAnchorNow,
+		now:           time.Now,
+		logger:        cfg.Logger,
+	}, nil
+}
+
+// Callback is the BatchReadyCallback to hand to the scheduler and on-demand handler
+func (g *GasWindow) Callback(ctx context.Context, result *ClosedBatchResult) error {
+	if result == nil || result.BatchType == database.BatchTypeOnDemand {
+		return g.next(ctx, result)
+	}
+
+	if g.checkGas(ctx) == GasWindowAnchorNow {
+		g.mu.Lock()
+		waiting := len(g.held)
+		g.mu.Unlock()
+		// Keep FIFO order: a new batch never overtakes held ones
+		if waiting == 0 {
+			return g.next(ctx, result)
+		}
+	}
+
+	g.mu.Lock()
+	g.held = append(g.held, &heldBatch{result: result, heldAt: g.now()})
+	count := len(g.held)
+	g.mu.Unlock()
+
+	g.logger.Printf("⏸️ Holding on-cadence batch %s until gas <= %s wei (held=%d, max delay %s)",
+		result.BatchID, g.target, count, g.maxDelay)
+
+	// Release right away if gas already dropped
+	if g.Decision() == GasWindowAnchorNow {
+		g.release(ctx, false)
+	}
+	return nil
+}
+
+// Start begins periodically re-evaluating held batches
+func (g *GasWindow) Start(ctx context.Context) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopCh != nil {
+		return
+	}
+	g.stopCh = make(chan struct{})
+	g.doneCh = make(chan struct{})
+
+	go g.run(ctx, g.stopCh, g.doneCh)
+	g.logger.Printf("Started (target=%s wei, max delay=%s, check=%s)", g.target, g.maxDelay, g.checkInterval)
+}
+
+// Stop stops the re-evaluation loop; held batches stay held
+func (g *GasWindow) Stop() {
+	g.mu.Lock()
+	stopCh, doneCh := g.stopCh, g.doneCh
+	g.stopCh, g.doneCh = nil, nil
+	g.mu.Unlock()
+
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	<-doneCh
+}
+
+// Flush anchors every held batch now regardless of gas
+func (g *GasWindow) Flush(ctx context.Context) int {
+	return g.release(ctx, true)
+}
+
+// Decision returns the decision made at the last gas check
+func (g *GasWindow) Decision() GasWindowDecision {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.decision
+}
+
+// Status returns the current decision and held batch count
+func (g *GasWindow) Status() *GasWindowStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	status := &GasWindowStatus{
+		Decision:       g.decision,
+		TargetPriceWei: g.target.String(),
+		MaxDelay:       g.maxDelay.String(),
+		HeldBatches:    len(g.held),
+	}
+	if g.lastPrice != nil {
+		status.GasPriceWei = g.lastPrice.String()
+	}
+	if len(g.held) > 0 {
+		oldest := g.held[0].heldAt
+		status.OldestHeldAt = &oldest
+	}
+	if !g.lastChecked.IsZero() {
+		checked := g.lastChecked
+		status.LastCheckedAt = &checked
+	}
+	return status
+}
+
+func (g *GasWindow) run(ctx context.Context, stopCh, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	ticker := time.NewTicker(g.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			g.mu.Lock()
+			waiting := len(g.held)
+			g.mu.Unlock()
+			if waiting > 0 {
+				g.checkGas(ctx)
+				g.release(ctx, false)
+			}
+		}
+	}
+}
+
+// checkGas reads the gas price and records the resulting decision
+func (g *GasWindow) checkGas(ctx context.Context) GasWindowDecision {
+	price, err := g.gasPrice(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.lastChecked = g.now()
+	if err != nil {
+		g.logger.Printf("⚠️ Failed to read gas price, anchoring without deferral: %v", err)
+		g.decision = GasWindowAnchorNow
+		return g.decision
+	}
+
+	g.lastPrice = price
+	if price.Cmp(g.target) <= 0 {
+		g.decision = GasWindowAnchorNow
+	} else {
+		g.decision = GasWindowHold
+	}
+	return g.decision
+}
+
+// release forwards held batches that may be anchored now: all of them when gas is at or
+// below target (or force is set), otherwise only those held for longer than maxDelay
+func (g *GasWindow) release(ctx context.Context, force bool) int {
+	g.mu.Lock()
+	anchorAll := force || g.decision == GasWindowAnchorNow
+	now := g.now()
+
+	var ready []*heldBatch
+	remaining := g.held[:0]
+	for _, h := range g.held {
+		if anchorAll || (g.maxDelay > 0 && now.Sub(h.heldAt) >= g.maxDelay) {
+			ready = append(ready, h)
+		} else {
+			remaining = append(remaining, h)
+		}
+	}
+	g.held = remaining
+	g.mu.Unlock()
+
+	for _, h := range ready {
+		reason := "gas below target"
+		if force {
+			reason = "flush"
+		} else if !anchorAll {
+			reason = "max delay reached"
+		}
+		g.logger.Printf("▶️ Releasing on-cadence batch %s after %s (%s)",
+			h.result.BatchID, now.Sub(h.heldAt).Round(time.Second), reason)
+
+		if err := g.next(ctx, h.result); err != nil {
+			g.logger.Printf("❌ Anchoring released batch %s failed: %v", h.result.BatchID, err)
+		}
+	}
+	return len(ready)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Gas Window
+// Tests holding on-cadence batches during high gas, release on gas drop or max delay,
+// and that on-demand batches are never held
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+type gasWindowHarness struct {
+	window   *GasWindow
+	price    int64
+	priceErr error
+	now      time.Time
+	anchored []uuid.UUID
+}
+
+func newGasWindowHarness(t *testing.T) *gasWindowHarness {
+	t.Helper()
+	h := &gasWindowHarness{price: 50, now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+	w, err := NewGasWindow(
+		func(ctx context.Context) (*big.Int, error) {
+			if h.priceErr != nil {
+				return nil, h.priceErr
+			}
+			return big.NewInt(h.price), nil
+		},
+		func(ctx context.Context, result *ClosedBatchResult) error {
+			h.anchored = append(h.anchored, result.BatchID)
+			return nil
+		},
+		&GasWindowConfig{TargetGasPrice: big.NewInt(20), MaxDelay: time.Hour, CheckInterval: time.Minute},
+	)
+	if err != nil {
+		t.Fatalf("NewGasWindow failed: %v", err)
+	}
+	w.now = func() time.Time { return h.now }
+	h.window = w
+	return h
+}
+
+func closedBatch(batchType database.BatchType) *ClosedBatchResult {
+	return &ClosedBatchResult{BatchID: uuid.New(), BatchType: batchType}
+}
+
+func TestGasWindow_HoldAndReleaseOnGasDrop(t *testing.T) {
+	h := newGasWindowHarness(t)
+	ctx := context.Background()
+
+	first, second := closedBatch(database.BatchTypeOnCadence), closedBatch(database.BatchTypeOnCadence)
+	h.window.Callback(ctx, first)
+	h.window.Callback(ctx, second)
+
+	if len(h.anchored) != 0 {
+		t.Fatalf("expected on-cadence batches to be held during high gas, anchored %v", h.anchored)
+	}
+	status := h.window.Status()
+	if status.Decision != GasWindowHold || status.HeldBatches != 2 || status.GasPriceWei != "50" {
+		t.Errorf("unexpected status while holding: %+v", status)
+	}
+
+	// On-demand batches are never held
+	onDemand := closedBatch(database.BatchTypeOnDemand)
+	h.window.Callback(ctx, onDemand)
+	if len(h.anchored) != 1 || h.anchored[0] != onDemand.BatchID {
+		t.Fatalf("expected on-demand batch to anchor immediately, anchored %v", h.anchored)
+	}
+
+	// Gas drops - held batches are released oldest first
+	h.price = 15
+	h.window.checkGas(ctx)
+	if n := h.window.release(ctx, false); n != 2 {
+		t.Fatalf("expected 2 batches released, got %d", n)
+	}
+	if h.anchored[1] != first.BatchID || h.anchored[2] != second.BatchID {
+		t.Errorf("expected held batches released in order, got %v", h.anchored)
+	}
+	if status := h.window.Status(); status.Decision != GasWindowAnchorNow || status.HeldBatches != 0 {
+		t.Errorf("unexpected status after release: %+v", status)
+	}
+
+	// Low gas - new batches anchor immediately
+	third := closedBatch(database.BatchTypeOnCadence)
+	h.window.Callback(ctx, third)
+	if len(h.anchored) != 4 || h.anchored[3] != third.BatchID {
+		t.Errorf("expected immediate anchoring at low gas, anchored %v", h.anchored)
+	}
+}
+
+func TestGasWindow_MaxDelayAndFlush(t *testing.T) {
+	h := newGasWindowHarness(t)
+	ctx := context.Background()
+
+	old := closedBatch(database.BatchTypeOnCadence)
+	h.window.Callback(ctx, old)
+	h.now = h.now.Add(30 * time.Minute)
+	recent := closedBatch(database.BatchTypeOnCadence)
+	h.window.Callback(ctx, recent)
+
+	// Gas stays high: only the batch held for MaxDelay is released
+	h.now = h.now.Add(31 * time.Minute)
+	h.window.checkGas(ctx)
+	if n := h.window.release(ctx, false); n != 1 || h.anchored[0] != old.BatchID {
+		t.Fatalf("expected only the batch past max delay to be released, got %d: %v", n, h.anchored)
+	}
+
+	if n := h.window.Flush(ctx); n != 1 || h.anchored[1] != recent.BatchID {
+		t.Errorf("expected flush to release the remaining batch, got %d: %v", n, h.anchored)
+	}
+}
+
+func TestGasWindow_FailOpen(t *testing.T) {
+	h := newGasWindowHarness(t)
+	h.priceErr = errors.New("rpc unavailable")
+
+	h.window.Callback(context.Background(), closedBatch(database.BatchTypeOnCadence))
+	if len(h.anchored) != 1 {
+		t.Errorf("expected batch to anchor when gas price is unavailable, anchored %v", h.anchored)
+	}
+
+	if _, err := NewGasWindow(nil, h.window.next, nil); err == nil {
+		t.Error("expected error for nil gas price source")
+	}
+	if _, err := NewGasWindow(h.window.gasPrice, h.window.next, &GasWindowConfig{}); err == nil {
+		t.Error("expected error for missing target gas price")
+	}
+}
@@ -0,0 +1,631 @@
+// Copyright 2025 Certen Protocol
+//
+// Inclusion Rechecker - Batch inclusion re-check for stored proofs that fail on-chain verification
+//
+// A stored proof is checked with the contract's verifyCertenProofDetailed view. When the
+// check fails and auto-rebuild is enabled, only the batch Merkle inclusion proof is rebuilt
+// from the batch's current transaction leaves and compared with the stored one:
+//   - the rebuilt proof differs and passes: it replaces the stored inclusion proof, and an
+//     "onchain_regenerated" verification record flags the correction
+//   - the rebuilt proof is identical or still fails: the persistent failure is recorded
+// The operation, cross-chain and governance commitments come from the anchor record, since
+// they are immutable on-chain. Lite client and governance proofs are not regenerated here: a
+// proof failing the governance or commitment checks stays a persistent failure. Rebuilds are
+// rate limited per proof and per hour.
+//
+// CheckProof is the read-only variant for auditors: it returns the contract's breakdown
+// without recording or regenerating, and serves failing results from a short-lived cache so
+// repeated requests for a bad proof do not each cost an RPC call.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/merkle"
+	"github.com/google/uuid"
+)
+
+// Verification record types written by the rechecker
+const (
+	VerificationTypeOnChain            = "onchain"
+	VerificationTypeOnChainRegenerated = "onchain_regenerated"
+)
+
+// Errors returned by InclusionRechecker.VerifyProof
+var (
+	ErrProofNotFound   = errors.New("proof not found")
+	ErrProofNotBatched = errors.New("proof is not part of a batch")
+)
+
+// OnChainProofVerification is the result of the contract's verifyCertenProofDetailed view
+// JSON tags mirror anchor.ProofVerificationChecks
+type OnChainProofVerification struct {
+	MerkleVerified     bool `json:"merkle_verified"`
+	GovernanceVerified bool `json:"governance_verified"`
+	BLSVerified        bool `json:"bls_verified"`
+	CommitmentVerified bool `json:"commitment_verified"`
+	TimestampValid     bool `json:"timestamp_valid"`
+	NonceValid         bool `json:"nonce_valid"`
+}
+
+// Passed reports whether every check succeeded
+func (v *OnChainProofVerification) Passed() bool {
+	return v != nil && len(v.FailedChecks()) == 0
+}
+
+// FailedChecks lists the names of the checks that did not pass
+func (v *OnChainProofVerification) FailedChecks() []string {
+	if v == nil {
+		return []string{"unknown"}
+	}
+	var failed []string
+	for _, c := range []struct {
+		name string
+		ok   bool
+	}{
+		{"merkle", v.MerkleVerified},
+		{"governance", v.GovernanceVerified},
+		{"bls", v.BLSVerified},
+		{"commitment", v.CommitmentVerified},
+		{"timestamp", v.TimestampValid},
+		{"nonce", v.NonceValid},
+	} {
+		if !c.ok {
+			failed = append(failed, c.name)
+		}
+	}
+	return failed
+}
+
+// OnChainProofVerifier checks a proof request against the contract without submitting it
+// Implemented by AnchorAdapter
+type OnChainProofVerifier interface {
+	VerifyComprehensiveProof(ctx context.Context, req *ExecuteProofRequest) (*OnChainProofVerification, error)
+}
+
+// ProofRegenerationStore is the storage the rechecker reads proofs from and writes corrections to
+type ProofRegenerationStore interface {
+	GetProofByID(ctx context.Context, proofID uuid.UUID) (*database.ProofArtifact, error)
+	GetAnchorByBatchID(ctx context.Context, batchID uuid.UUID) (*database.AnchorRecord, error)
+	GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error)
+	ReplaceProofInclusion(ctx context.Context, proofID uuid.UUID, txID int64, inclusion *merkle.InclusionProof) error
+	RecordVerification(ctx context.Context, proofID uuid.UUID, verificationType string, passed bool, errorMsg, verifierID string, duration time.Duration) error
+}
+
+// repositoryRegenerationStore implements ProofRegenerationStore on the database repositories
+type repositoryRegenerationStore struct {
+	repos *database.Repositories
+}
+
+// NewProofRegenerationStore creates a ProofRegenerationStore backed by the database repositories
+func NewProofRegenerationStore(repos *database.Repositories) ProofRegenerationStore {
+	return &repositoryRegenerationStore{repos: repos}
+}
+
+func (s *repositoryRegenerationStore) GetProofByID(ctx context.Context, proofID uuid.UUID) (*database.ProofArtifact, error) {
+	return s.repos.ProofArtifacts.GetProofByID(ctx, proofID)
+}
+
+func (s *repositoryRegenerationStore) GetAnchorByBatchID(ctx context.Context, batchID uuid.UUID) (*database.AnchorRecord, error) {
+	return s.repos.Anchors.GetAnchorByBatchID(ctx, batchID)
+}
+
+func (s *repositoryRegenerationStore) GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error) {
+	return s.repos.Batches.GetTransactionsInBatch(ctx, batchID)
+}
+
+func (s *repositoryRegenerationStore) ReplaceProofInclusion(ctx context.Context, proofID uuid.UUID, txID int64, inclusion *merkle.InclusionProof) error {
+	root, err := hex.DecodeString(inclusion.MerkleRoot)
+	if err != nil {
+		return fmt.Errorf("invalid merkle root: %w", err)
+	}
+	leaf, err := hex.DecodeString(inclusion.LeafHash)
+	if err != nil {
+		return fmt.Errorf("invalid leaf hash: %w", err)
+	}
+	path, err := inclusion.PathToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal merkle path: %w", err)
+	}
+
+	if err := s.repos.Batches.UpdateMerklePath(ctx, txID, path); err != nil {
+		return err
+	}
+	return s.repos.ProofArtifacts.UpdateProofMerkleInclusion(ctx, proofID, root, leaf, inclusion.LeafIndex)
+}
+
+func (s *repositoryRegenerationStore) RecordVerification(ctx context.Context, proofID uuid.UUID, verificationType string, passed bool, errorMsg, verifierID string, duration time.Duration) error {
+	var errPtr *string
+	if errorMsg != "" {
+		errPtr = &errorMsg
+	}
+	durationMS := int(duration.Milliseconds())
+	_, err := s.repos.ProofArtifacts.CreateVerificationRecord(ctx, proofID, verificationType, passed, errPtr, &verifierID, &durationMS)
+	return err
+}
+
+// RegenerationOutcome is the result of an on-chain proof check
+type RegenerationOutcome string
+
+const (
+	RegenerationOutcomeVerified          RegenerationOutcome = "verified"           // Stored proof passed
+	RegenerationOutcomeFailed            RegenerationOutcome = "failed"             // Stored proof failed, regeneration disabled
+	RegenerationOutcomeRateLimited       RegenerationOutcome = "rate_limited"       // Stored proof failed, regeneration deferred
+	RegenerationOutcomeCorrected         RegenerationOutcome = "corrected"          // Regenerated proof passed and replaced the stored one
+	RegenerationOutcomePersistentFailure RegenerationOutcome = "persistent_failure" // Regenerated proof is identical or still fails
+)
+
+// ProofRegenerationReport describes an on-chain proof check and any regeneration attempt
+type ProofRegenerationReport struct {
+	ProofID     uuid.UUID                 `json:"proof_id"`
+	Outcome     RegenerationOutcome       `json:"outcome"`
+	Stored      *OnChainProofVerification `json:"stored"`
+	Regenerated *OnChainProofVerification `json:"regenerated,omitempty"`
+	Differences []string                  `json:"differences,omitempty"` // Fields that changed on regeneration
+	Message     string                    `json:"message,omitempty"`
+	CheckedAt   time.Time                 `json:"checked_at"`
+}
+
+// InclusionRecheckerConfig holds configuration for the inclusion rechecker
+type InclusionRecheckerConfig struct {
+	AutoRegenerate  bool          // Rebuild the inclusion proof of proofs that fail on-chain verification
+	ProofCooldown   time.Duration // Minimum time between rebuilds of the same proof
+	MaxPerHour      int           // Maximum rebuilds across all proofs per hour
+	ValidatorID     string
+	LeafEncoding    LeafEncoding  // Must match the collector's (empty = DefaultLeafEncoding)
+	FailureCacheTTL time.Duration // How long a failing CheckProof result is served from cache (0 disables)
+	Logger          *log.Logger
+}
+
+// DefaultInclusionRecheckerConfig returns default configuration (rebuilds disabled)
+func DefaultInclusionRecheckerConfig() *InclusionRecheckerConfig {
+	return &InclusionRecheckerConfig{
+		AutoRegenerate:  false,
+		ProofCooldown:   time.Hour,
+		MaxPerHour:      10,
+		FailureCacheTTL: 30 * time.Second,
+		Logger:          log.New(log.Writer(), "[InclusionRecheck] ", log.LstdFlags),
+	}
+}
+
+// InclusionRechecker verifies stored proofs on-chain and re-checks the batch inclusion of those that fail
+type InclusionRechecker struct {
+	mu sync.Mutex
+
+	store    ProofRegenerationStore
+	verifier OnChainProofVerifier
+
+	autoRegenerate bool
+	proofCooldown  time.Duration
+	maxPerHour     int
+	validatorID    string
+	leafEncoding   LeafEncoding
+
+	// Rate limiting state
+	lastAttempt map[uuid.UUID]time.Time
+	windowStart time.Time
+	windowCount int
+	now         func() time.Time
+
+	// Failing CheckProof results, served until they are failureCacheTTL old
+	failureCacheTTL time.Duration
+	failedChecks    map[uuid.UUID]*ProofCheckResult
+
+	logger *log.Logger
+}
+
+// ProofCheckResult is the contract's verification breakdown for a stored proof
+type ProofCheckResult struct {
+	ProofID   uuid.UUID                 `json:"proof_id"`
+	Passed    bool                      `json:"passed"`
+	Checks    *OnChainProofVerification `json:"checks"`
+	Failed    []string                  `json:"failed_checks,omitempty"`
+	CheckedAt time.Time                 `json:"checked_at"`
+	Cached    bool                      `json:"cached"`
+}
+
+// NewInclusionRechecker creates a new inclusion rechecker
+func NewInclusionRechecker(store ProofRegenerationStore, verifier OnChainProofVerifier, cfg *InclusionRecheckerConfig) (*InclusionRechecker, error) {
+	if store == nil {
+		return nil, fmt.Errorf("proof regeneration store cannot be nil")
+	}
+	if verifier == nil {
+		return nil, fmt.Errorf("on-chain proof verifier cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultInclusionRecheckerConfig()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[InclusionRecheck] ", log.LstdFlags)
+	}
+	leafEncoding, err := ParseLeafEncoding(string(cfg.LeafEncoding))
+	if err != nil {
+		return nil, err
+	}
+
+	return &InclusionRechecker{
+		store:           store,
+		verifier:        verifier,
+		autoRegenerate:  cfg.AutoRegenerate,
+		proofCooldown:   cfg.ProofCooldown,
+		maxPerHour:      cfg.MaxPerHour,
+		validatorID:     cfg.ValidatorID,
+		leafEncoding:    leafEncoding,
+		lastAttempt:     make(map[uuid.UUID]time.Time),
+		now:             time.Now,
+		failureCacheTTL: cfg.FailureCacheTTL,
+		failedChecks:    make(map[uuid.UUID]*ProofCheckResult),
+		logger:          cfg.Logger,
+	}, nil
+}
+
+// AutoRegenerateEnabled reports whether failing proofs have their inclusion proof rebuilt
+func (r *InclusionRechecker) AutoRegenerateEnabled() bool {
+	return r.autoRegenerate
+}
+
+// CheckProof checks a stored proof with the contract's verifyCertenProofDetailed view and
+// returns the per-check breakdown. Nothing is recorded or regenerated.
+func (r *InclusionRechecker) CheckProof(ctx context.Context, proofID uuid.UUID) (*ProofCheckResult, error) {
+	now := r.now()
+	r.mu.Lock()
+	if cached, ok := r.failedChecks[proofID]; ok && now.Sub(cached.CheckedAt) < r.failureCacheTTL {
+		r.mu.Unlock()
+		result := *cached
+		result.Cached = true
+		return &result, nil
+	}
+	r.mu.Unlock()
+
+	_, _, _, stored, err := r.loadStoredProof(ctx, proofID)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := r.verifier.VerifyComprehensiveProof(ctx, stored)
+	if err != nil {
+		return nil, fmt.Errorf("on-chain verification failed: %w", err)
+	}
+
+	result := &ProofCheckResult{
+		ProofID:   proofID,
+		Passed:    checks.Passed(),
+		Checks:    checks,
+		CheckedAt: now.UTC(),
+	}
+	if result.Passed {
+		return result, nil
+	}
+	result.Failed = checks.FailedChecks()
+
+	if r.failureCacheTTL > 0 {
+		r.mu.Lock()
+		for id, cached := range r.failedChecks {
+			if now.Sub(cached.CheckedAt) >= r.failureCacheTTL {
+				delete(r.failedChecks, id)
+			}
+		}
+		r.failedChecks[proofID] = result
+		r.mu.Unlock()
+	}
+	return result, nil
+}
+
+// VerifyProof checks a stored proof on-chain and, if it fails and auto-rebuild is enabled,
+// rebuilds its batch inclusion proof and replaces it when the rebuilt proof passes
+func (r *InclusionRechecker) VerifyProof(ctx context.Context, proofID uuid.UUID) (*ProofRegenerationReport, error) {
+	artifact, txs, tx, stored, err := r.loadStoredProof(ctx, proofID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := r.now()
+	storedResult, err := r.verifier.VerifyComprehensiveProof(ctx, stored)
+	if err != nil {
+		return nil, fmt.Errorf("on-chain verification failed: %w", err)
+	}
+
+	report := &ProofRegenerationReport{
+		ProofID:   proofID,
+		Stored:    storedResult,
+		CheckedAt: start.UTC(),
+	}
+
+	if storedResult.Passed() {
+		report.Outcome = RegenerationOutcomeVerified
+		r.record(ctx, proofID, VerificationTypeOnChain, true, "", start)
+		return report, nil
+	}
+
+	failure := fmt.Sprintf("failed checks: %s", strings.Join(storedResult.FailedChecks(), ", "))
+	r.record(ctx, proofID, VerificationTypeOnChain, false, failure, start)
+
+	if !r.autoRegenerate {
+		report.Outcome = RegenerationOutcomeFailed
+		report.Message = failure
+		return report, nil
+	}
+	if reason := r.allowRegeneration(proofID); reason != "" {
+		report.Outcome = RegenerationOutcomeRateLimited
+		report.Message = fmt.Sprintf("%s; regeneration deferred: %s", failure, reason)
+		return report, nil
+	}
+
+	r.logger.Printf("🔄 Proof %s failed on-chain verification (%s) - re-checking inclusion in batch %s",
+		proofID, failure, artifact.BatchID)
+
+	regenerated, inclusion, err := rebuildInclusionRequest(stored, txs, tx, r.leafEncoding)
+	if err != nil {
+		report.Outcome = RegenerationOutcomePersistentFailure
+		report.Message = fmt.Sprintf("%s; regeneration failed: %v", failure, err)
+		r.record(ctx, proofID, VerificationTypeOnChainRegenerated, false, report.Message, start)
+		return report, nil
+	}
+
+	report.Differences = compareProofRequests(stored, regenerated)
+	if artifact.LeafIndex != nil && *artifact.LeafIndex != inclusion.LeafIndex {
+		report.Differences = append(report.Differences, "leaf_index")
+	}
+	if len(report.Differences) == 0 {
+		report.Outcome = RegenerationOutcomePersistentFailure
+		report.Message = fmt.Sprintf("%s; regenerated proof is identical to the stored proof", failure)
+		r.record(ctx, proofID, VerificationTypeOnChainRegenerated, false, report.Message, start)
+		r.logger.Printf("❌ Proof %s: %s", proofID, report.Message)
+		return report, nil
+	}
+
+	regeneratedResult, err := r.verifier.VerifyComprehensiveProof(ctx, regenerated)
+	if err != nil {
+		return nil, fmt.Errorf("on-chain verification of regenerated proof failed: %w", err)
+	}
+	report.Regenerated = regeneratedResult
+
+	if !regeneratedResult.Passed() {
+		report.Outcome = RegenerationOutcomePersistentFailure
+		report.Message = fmt.Sprintf("%s; regenerated proof also failed checks: %s",
+			failure, strings.Join(regeneratedResult.FailedChecks(), ", "))
+		r.record(ctx, proofID, VerificationTypeOnChainRegenerated, false, report.Message, start)
+		r.logger.Printf("❌ Proof %s: %s", proofID, report.Message)
+		return report, nil
+	}
+
+	if err := r.store.ReplaceProofInclusion(ctx, proofID, tx.ID, inclusion); err != nil {
+		return nil, fmt.Errorf("failed to replace regenerated proof: %w", err)
+	}
+
+	report.Outcome = RegenerationOutcomeCorrected
+	report.Message = fmt.Sprintf("stored proof replaced (changed: %s)", strings.Join(report.Differences, ", "))
+	r.record(ctx, proofID, VerificationTypeOnChainRegenerated, true, report.Message, start)
+	r.logger.Printf("✅ Proof %s corrected by inclusion rebuild (changed: %s)", proofID, strings.Join(report.Differences, ", "))
+
+	return report, nil
+}
+
+// loadStoredProof loads a stored proof, its batch transactions and the transaction it was
+// generated for, and rebuilds the proof request that was submitted on-chain
+func (r *InclusionRechecker) loadStoredProof(ctx context.Context, proofID uuid.UUID) (*database.ProofArtifact, []*database.BatchTransaction, *database.BatchTransaction, *ExecuteProofRequest, error) {
+	artifact, err := r.store.GetProofByID(ctx, proofID)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to get proof: %w", err)
+	}
+	if artifact == nil {
+		return nil, nil, nil, nil, fmt.Errorf("%w: %s", ErrProofNotFound, proofID)
+	}
+	if artifact.BatchID == nil {
+		return nil, nil, nil, nil, fmt.Errorf("%w: %s", ErrProofNotBatched, proofID)
+	}
+
+	anchor, err := r.store.GetAnchorByBatchID(ctx, *artifact.BatchID)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to get anchor for batch %s: %w", artifact.BatchID, err)
+	}
+	txs, err := r.store.GetTransactionsInBatch(ctx, *artifact.BatchID)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to get batch transactions: %w", err)
+	}
+	tx := findProofTransaction(artifact, txs)
+	if tx == nil {
+		return nil, nil, nil, nil, fmt.Errorf("transaction for proof %s not found in batch %s", proofID, artifact.BatchID)
+	}
+
+	stored, err := storedProofRequest(artifact, anchor, tx, r.validatorID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return artifact, txs, tx, stored, nil
+}
+
+// allowRegeneration applies the per-proof cooldown and hourly limit, returning the reason
+// a regeneration is not allowed, or "" after reserving a slot
+func (r *InclusionRechecker) allowRegeneration(proofID uuid.UUID) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if last, ok := r.lastAttempt[proofID]; ok && r.proofCooldown > 0 && now.Sub(last) < r.proofCooldown {
+		return fmt.Sprintf("proof regenerated %s ago (cooldown %s)", now.Sub(last).Round(time.Second), r.proofCooldown)
+	}
+	if now.Sub(r.windowStart) >= time.Hour {
+		r.windowStart = now
+		r.windowCount = 0
+	}
+	if r.maxPerHour > 0 && r.windowCount >= r.maxPerHour {
+		return fmt.Sprintf("hourly regeneration limit of %d reached", r.maxPerHour)
+	}
+
+	r.windowCount++
+	r.lastAttempt[proofID] = now
+
+	// Drop cooldown entries that have expired
+	for id, t := range r.lastAttempt {
+		if now.Sub(t) >= r.proofCooldown {
+			delete(r.lastAttempt, id)
+		}
+	}
+	return ""
+}
+
+// record writes a verification record, logging rather than failing on error
+func (r *InclusionRechecker) record(ctx context.Context, proofID uuid.UUID, verificationType string, passed bool, errorMsg string, start time.Time) {
+	if err := r.store.RecordVerification(ctx, proofID, verificationType, passed, errorMsg, r.validatorID, r.now().Sub(start)); err != nil {
+		r.logger.Printf("⚠️ Failed to record %s verification for proof %s: %v", verificationType, proofID, err)
+	}
+}
+
+// findProofTransaction finds the batch transaction a proof was generated for
+func findProofTransaction(artifact *database.ProofArtifact, txs []*database.BatchTransaction) *database.BatchTransaction {
+	for _, tx := range txs {
+		if artifact.AccumTxHash != "" && tx.AccumTxHash == artifact.AccumTxHash {
+			return tx
+		}
+	}
+	for _, tx := range txs {
+		if len(artifact.LeafHash) > 0 && bytes.Equal(tx.TxHash, artifact.LeafHash) {
+			return tx
+		}
+	}
+	return nil
+}
+
+// storedProofRequest rebuilds the proof request for a stored proof. The anchor ID and
+// timestamp follow the batch processor: the batch ID, and the time the anchor was recorded.
+func storedProofRequest(artifact *database.ProofArtifact, anchor *database.AnchorRecord, tx *database.BatchTransaction, validatorID string) (*ExecuteProofRequest, error) {
+	if anchor == nil {
+		return nil, fmt.Errorf("no anchor recorded for batch %s", artifact.BatchID)
+	}
+
+	req := &ExecuteProofRequest{
+		AnchorID:    artifact.BatchID.String(),
+		BatchID:     artifact.BatchID.String(),
+		ValidatorID: validatorID,
+		Timestamp:   anchor.CreatedAt.Unix(),
+	}
+	if anchor.AnchorTimestamp.Valid {
+		req.Timestamp = anchor.AnchorTimestamp.Time.Unix()
+	}
+
+	merkleRoot := artifact.MerkleRoot
+	if len(merkleRoot) == 0 {
+		merkleRoot = anchor.MerkleRoot
+	}
+	leafHash := artifact.LeafHash
+	if len(leafHash) == 0 {
+		leafHash = tx.TxHash
+	}
+	copy(req.MerkleRoot[:], merkleRoot)
+	copy(req.LeafHash[:], leafHash)
+	copy(req.TransactionHash[:], tx.TxHash)
+	if artifact.LeafIndex != nil && *artifact.LeafIndex >= 0 {
+		req.LeafIndex = uint64(*artifact.LeafIndex)
+	} else if tx.TreeIndex >= 0 {
+		req.LeafIndex = uint64(tx.TreeIndex)
+	}
+
+	copy(req.OperationCommitment[:], anchor.OperationCommitment)
+	if len(anchor.OperationCommitment) == 0 {
+		req.OperationCommitment = req.MerkleRoot
+	}
+	copy(req.CrossChainCommitment[:], anchor.CrossChainCommitment)
+	copy(req.GovernanceRoot[:], anchor.GovernanceRoot)
+
+	if len(tx.MerklePath) > 0 {
+		var path []database.MerklePathNode
+		if err := json.Unmarshal(tx.MerklePath, &path); err != nil {
+			return nil, fmt.Errorf("invalid stored merkle path: %w", err)
+		}
+		for _, node := range path {
+			hashBytes, err := hex.DecodeString(node.Hash)
+			if err != nil || len(hashBytes) != 32 {
+				return nil, fmt.Errorf("invalid stored merkle path node %q", node.Hash)
+			}
+			var h [32]byte
+			copy(h[:], hashBytes)
+			req.ProofHashes = append(req.ProofHashes, h)
+		}
+	}
+
+	return req, nil
+}
+
+// rebuildInclusionRequest rebuilds the Merkle inclusion proof for tx from the batch's current
+// transaction leaves, keeping the stored request's commitments
+func rebuildInclusionRequest(stored *ExecuteProofRequest, txs []*database.BatchTransaction, tx *database.BatchTransaction, encoding LeafEncoding) (*ExecuteProofRequest, *merkle.InclusionProof, error) {
+	ordered := make([]*database.BatchTransaction, len(txs))
+	copy(ordered, txs)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].TreeIndex < ordered[j].TreeIndex })
+
+	leaves := make([][]byte, len(ordered))
+	leafIndex := -1
+	for i, t := range ordered {
+		leaf, err := encoding.EncodeLeaf(t.TxHash, t.AccountURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("transaction %s: %w", t.AccumTxHash, err)
+		}
+		leaves[i] = leaf
+		if t == tx {
+			leafIndex = i
+		}
+	}
+	if leafIndex < 0 {
+		return nil, nil, fmt.Errorf("transaction %s not in batch", tx.AccumTxHash)
+	}
+
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build merkle tree: %w", err)
+	}
+	inclusion, err := tree.GenerateProof(leafIndex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate inclusion proof: %w", err)
+	}
+
+	regenerated := *stored
+	regenerated.ProofHashes = nil
+	copy(regenerated.MerkleRoot[:], tree.Root())
+	copy(regenerated.LeafHash[:], leaves[leafIndex])
+	regenerated.LeafIndex = uint64(leafIndex)
+	for _, node := range inclusion.Path {
+		hashBytes, err := hex.DecodeString(node.Hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid proof node: %w", err)
+		}
+		var h [32]byte
+		copy(h[:], hashBytes)
+		regenerated.ProofHashes = append(regenerated.ProofHashes, h)
+	}
+
+	return &regenerated, inclusion, nil
+}
+
+// compareProofRequests lists the Merkle inclusion fields that differ between two requests
+func compareProofRequests(a, b *ExecuteProofRequest) []string {
+	var diffs []string
+	if a.MerkleRoot != b.MerkleRoot {
+		diffs = append(diffs, "merkle_root")
+	}
+	if a.LeafHash != b.LeafHash {
+		diffs = append(diffs, "leaf_hash")
+	}
+	if len(a.ProofHashes) != len(b.ProofHashes) {
+		diffs = append(diffs, "proof_path")
+	} else {
+		for i := range a.ProofHashes {
+			if a.ProofHashes[i] != b.ProofHashes[i] {
+				diffs = append(diffs, "proof_path")
+				break
+			}
+		}
+	}
+	return diffs
+}
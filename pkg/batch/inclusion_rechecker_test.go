@@ -0,0 +1,291 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Inclusion Rechecker
+// Tests on-chain verification outcomes, rebuilding stale batch inclusion proofs and rate limiting
+
+package batch
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/merkle"
+	"github.com/google/uuid"
+)
+
+type verificationCall struct {
+	verificationType string
+	passed           bool
+}
+
+// stubRegenerationStore holds a single batch with one proof
+type stubRegenerationStore struct {
+	artifact *database.ProofArtifact
+	anchor   *database.AnchorRecord
+	txs      []*database.BatchTransaction
+
+	replaced *merkle.InclusionProof
+	records  []verificationCall
+}
+
+func (s *stubRegenerationStore) GetProofByID(ctx context.Context, proofID uuid.UUID) (*database.ProofArtifact, error) {
+	if s.artifact == nil || s.artifact.ProofID != proofID {
+		return nil, nil
+	}
+	return s.artifact, nil
+}
+
+func (s *stubRegenerationStore) GetAnchorByBatchID(ctx context.Context, batchID uuid.UUID) (*database.AnchorRecord, error) {
+	return s.anchor, nil
+}
+
+func (s *stubRegenerationStore) GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error) {
+	return s.txs, nil
+}
+
+func (s *stubRegenerationStore) ReplaceProofInclusion(ctx context.Context, proofID uuid.UUID, txID int64, inclusion *merkle.InclusionProof) error {
+	s.replaced = inclusion
+	return nil
+}
+
+func (s *stubRegenerationStore) RecordVerification(ctx context.Context, proofID uuid.UUID, verificationType string, passed bool, errorMsg, verifierID string, duration time.Duration) error {
+	s.records = append(s.records, verificationCall{verificationType, passed})
+	return nil
+}
+
+// stubVerifier passes a request only when its Merkle root matches validRoot
+type stubVerifier struct {
+	validRoot [32]byte
+	calls     int
+}
+
+func (v *stubVerifier) VerifyComprehensiveProof(ctx context.Context, req *ExecuteProofRequest) (*OnChainProofVerification, error) {
+	v.calls++
+	ok := req.MerkleRoot == v.validRoot
+	return &OnChainProofVerification{
+		MerkleVerified:     ok,
+		GovernanceVerified: true,
+		BLSVerified:        true,
+		CommitmentVerified: true,
+		TimestampValid:     true,
+		NonceValid:         true,
+	}, nil
+}
+
+// newRegenerationFixture builds a 3-transaction batch whose stored proof for the second
+// transaction has the given Merkle root and path
+func newRegenerationFixture(t *testing.T, storedRoot []byte, storedPath []merkle.ProofNode) (*stubRegenerationStore, [32]byte) {
+	t.Helper()
+	batchID := uuid.New()
+
+	leaves := [][]byte{sha256Sum("tx-0"), sha256Sum("tx-1"), sha256Sum("tx-2")}
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		t.Fatalf("BuildTree failed: %v", err)
+	}
+	var currentRoot [32]byte
+	copy(currentRoot[:], tree.Root())
+
+	if storedRoot == nil {
+		storedRoot = tree.Root()
+	}
+	if storedPath == nil {
+		proof, _ := tree.GenerateProof(1)
+		storedPath = proof.Path
+	}
+	pathJSON, _ := json.Marshal(storedPath)
+
+	var txs []*database.BatchTransaction
+	for i, leaf := range leaves {
+		txs = append(txs, &database.BatchTransaction{
+			ID:          int64(i + 1),
+			BatchID:     batchID,
+			AccumTxHash: []string{"acc-0", "acc-1", "acc-2"}[i],
+			TreeIndex:   i,
+			TxHash:      leaf,
+		})
+	}
+	txs[1].MerklePath = pathJSON
+
+	leafIndex := 1
+	return &stubRegenerationStore{
+		artifact: &database.ProofArtifact{
+			ProofID:     uuid.New(),
+			AccumTxHash: "acc-1",
+			BatchID:     &batchID,
+			MerkleRoot:  storedRoot,
+			LeafHash:    leaves[1],
+			LeafIndex:   &leafIndex,
+		},
+		anchor: &database.AnchorRecord{BatchID: batchID, MerkleRoot: tree.Root(), CreatedAt: time.Now()},
+		txs:    txs,
+	}, currentRoot
+}
+
+func newTestRegenerator(t *testing.T, store ProofRegenerationStore, verifier OnChainProofVerifier, auto bool) *InclusionRechecker {
+	t.Helper()
+	cfg := DefaultInclusionRecheckerConfig()
+	cfg.AutoRegenerate = auto
+	r, err := NewInclusionRechecker(store, verifier, cfg)
+	if err != nil {
+		t.Fatalf("NewInclusionRechecker failed: %v", err)
+	}
+	return r
+}
+
+func TestInclusionRechecker_StoredProofPasses(t *testing.T) {
+	store, root := newRegenerationFixture(t, nil, nil)
+	verifier := &stubVerifier{validRoot: root}
+	r := newTestRegenerator(t, store, verifier, true)
+
+	report, err := r.VerifyProof(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("VerifyProof failed: %v", err)
+	}
+	if report.Outcome != RegenerationOutcomeVerified || verifier.calls != 1 {
+		t.Errorf("expected verified after one call, got %s after %d calls", report.Outcome, verifier.calls)
+	}
+	if len(store.records) != 1 || store.records[0] != (verificationCall{VerificationTypeOnChain, true}) {
+		t.Errorf("expected one passing onchain record, got %+v", store.records)
+	}
+}
+
+func TestInclusionRechecker_CorrectsStaleProof(t *testing.T) {
+	stale := sha256Sum("stale-root")
+	store, root := newRegenerationFixture(t, stale, []merkle.ProofNode{})
+	verifier := &stubVerifier{validRoot: root}
+	r := newTestRegenerator(t, store, verifier, true)
+
+	report, err := r.VerifyProof(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("VerifyProof failed: %v", err)
+	}
+	if report.Outcome != RegenerationOutcomeCorrected {
+		t.Fatalf("expected corrected, got %s (%s)", report.Outcome, report.Message)
+	}
+	if store.replaced == nil || store.replaced.LeafIndex != 1 || store.replaced.MerkleRoot != hex.EncodeToString(root[:]) {
+		t.Errorf("expected regenerated inclusion proof to be stored, got %+v", store.replaced)
+	}
+	if len(report.Differences) == 0 || report.Differences[0] != "merkle_root" {
+		t.Errorf("expected merkle_root difference, got %v", report.Differences)
+	}
+
+	last := store.records[len(store.records)-1]
+	if last != (verificationCall{VerificationTypeOnChainRegenerated, true}) {
+		t.Errorf("expected correction to be flagged with a passing onchain_regenerated record, got %+v", last)
+	}
+}
+
+func TestInclusionRechecker_PersistentFailure(t *testing.T) {
+	store, _ := newRegenerationFixture(t, nil, nil)
+	verifier := &stubVerifier{} // No root passes
+	r := newTestRegenerator(t, store, verifier, true)
+
+	report, err := r.VerifyProof(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("VerifyProof failed: %v", err)
+	}
+	if report.Outcome != RegenerationOutcomePersistentFailure || store.replaced != nil {
+		t.Errorf("expected persistent failure without replacement, got %s", report.Outcome)
+	}
+	if verifier.calls != 1 {
+		t.Errorf("identical regenerated proof should not be re-verified, got %d calls", verifier.calls)
+	}
+}
+
+func TestInclusionRechecker_DisabledAndRateLimited(t *testing.T) {
+	store, root := newRegenerationFixture(t, sha256Sum("stale-root"), nil)
+	verifier := &stubVerifier{validRoot: root}
+
+	disabled := newTestRegenerator(t, store, verifier, false)
+	report, err := disabled.VerifyProof(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("VerifyProof failed: %v", err)
+	}
+	if report.Outcome != RegenerationOutcomeFailed || store.replaced != nil {
+		t.Errorf("expected failure without regeneration when disabled, got %s", report.Outcome)
+	}
+
+	// Cooldown blocks a second regeneration of the same proof
+	r := newTestRegenerator(t, store, &stubVerifier{}, true)
+	if report, _ := r.VerifyProof(context.Background(), store.artifact.ProofID); report.Outcome != RegenerationOutcomePersistentFailure {
+		t.Fatalf("expected first attempt to regenerate, got %s", report.Outcome)
+	}
+	if report, _ := r.VerifyProof(context.Background(), store.artifact.ProofID); report.Outcome != RegenerationOutcomeRateLimited {
+		t.Errorf("expected second attempt within cooldown to be rate limited, got %s", report.Outcome)
+	}
+
+	// Hourly limit applies across proofs and resets after an hour
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	r.proofCooldown, r.maxPerHour = 0, 1
+	r.windowStart, r.windowCount = now, 1
+	if reason := r.allowRegeneration(uuid.New()); reason == "" {
+		t.Error("expected hourly limit to block regeneration")
+	}
+	now = now.Add(time.Hour)
+	if reason := r.allowRegeneration(uuid.New()); reason != "" {
+		t.Errorf("expected regeneration to be allowed in a new window, got %q", reason)
+	}
+}
+
+func TestInclusionRechecker_CheckProofCachesFailures(t *testing.T) {
+	store, root := newRegenerationFixture(t, nil, nil)
+	passing := &stubVerifier{validRoot: root}
+	r := newTestRegenerator(t, store, passing, true)
+
+	result, err := r.CheckProof(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("CheckProof failed: %v", err)
+	}
+	if !result.Passed || result.Cached || len(result.Failed) != 0 {
+		t.Errorf("expected an uncached pass, got %+v", result)
+	}
+	if _, err := r.CheckProof(context.Background(), store.artifact.ProofID); err != nil || passing.calls != 2 {
+		t.Errorf("expected passing results not to be cached, got %d calls (%v)", passing.calls, err)
+	}
+	if len(store.records) != 0 || store.replaced != nil {
+		t.Errorf("expected CheckProof to record and replace nothing, got %+v", store.records)
+	}
+
+	failing := &stubVerifier{}
+	r = newTestRegenerator(t, store, failing, true)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	first, _ := r.CheckProof(context.Background(), store.artifact.ProofID)
+	second, _ := r.CheckProof(context.Background(), store.artifact.ProofID)
+	if first.Passed || len(first.Failed) != 1 || first.Failed[0] != "merkle" {
+		t.Errorf("expected only the merkle check to fail, got %+v", first)
+	}
+	if !second.Cached || failing.calls != 1 {
+		t.Errorf("expected the failure to be served from cache, got cached=%v after %d calls", second.Cached, failing.calls)
+	}
+
+	now = now.Add(DefaultInclusionRecheckerConfig().FailureCacheTTL)
+	if third, _ := r.CheckProof(context.Background(), store.artifact.ProofID); third.Cached || failing.calls != 2 {
+		t.Errorf("expected an expired failure to be re-checked, got cached=%v after %d calls", third.Cached, failing.calls)
+	}
+}
+
+func TestInclusionRechecker_Errors(t *testing.T) {
+	store, root := newRegenerationFixture(t, nil, nil)
+	r := newTestRegenerator(t, store, &stubVerifier{validRoot: root}, true)
+
+	if _, err := r.VerifyProof(context.Background(), uuid.New()); !errors.Is(err, ErrProofNotFound) {
+		t.Errorf("expected ErrProofNotFound, got %v", err)
+	}
+
+	store.artifact.BatchID = nil
+	if _, err := r.VerifyProof(context.Background(), store.artifact.ProofID); !errors.Is(err, ErrProofNotBatched) {
+		t.Errorf("expected ErrProofNotBatched, got %v", err)
+	}
+
+	if _, err := NewInclusionRechecker(nil, &stubVerifier{}, nil); err == nil {
+		t.Error("expected error for nil store")
+	}
+}
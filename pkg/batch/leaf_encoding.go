@@ -0,0 +1,129 @@
+// Copyright 2025 Certen Protocol
+//
+// Leaf Encoding - Merkle leaf preimage layouts for batch trees
+//
+// The batch Merkle root is only useful if the on-chain verifier reconstructs it from the
+// same leaves, so the leaf layout must match what the deployed verifier hashes. Modes:
+//
+//   raw_tx_hash           leaf = txHash (default; CertenAnchorV3)
+//   sha256_tx_hash        leaf = SHA-256(txHash), for verifiers that hash the leaf first
+//   keccak256_tx_hash     leaf = keccak256(txHash), i.e. keccak256(abi.encodePacked(bytes32))
+//   sha256_tx_account     leaf = SHA-256(txHash || accountURL), binding the leaf to its account
+//   keccak256_tx_account  leaf = keccak256(abi.encodePacked(bytes32 txHash, string accountURL))
+//
+// The mode is chosen per anchoring target: an override for the contract address wins,
+// then one for the chain ID, then the default. The collector, processor and proof
+// regenerator must all use the same mode, or rebuilt roots will not match anchored ones.
+
+package batch
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LeafEncoding is a Merkle leaf preimage layout
+type LeafEncoding string
+
+const (
+	LeafEncodingRawTxHash          LeafEncoding = "raw_tx_hash"
+	LeafEncodingSHA256TxHash       LeafEncoding = "sha256_tx_hash"
+	LeafEncodingKeccak256TxHash    LeafEncoding = "keccak256_tx_hash"
+	LeafEncodingSHA256TxAccount    LeafEncoding = "sha256_tx_account"
+	LeafEncodingKeccak256TxAccount LeafEncoding = "keccak256_tx_account"
+)
+
+// DefaultLeafEncoding is the layout CertenAnchorV3 verifies
+const DefaultLeafEncoding = LeafEncodingRawTxHash
+
+// ParseLeafEncoding parses a leaf encoding mode; empty means the default
+func ParseLeafEncoding(s string) (LeafEncoding, error) {
+	encoding := LeafEncoding(strings.ToLower(strings.TrimSpace(s)))
+	switch encoding {
+	case "":
+		return DefaultLeafEncoding, nil
+	case LeafEncodingRawTxHash, LeafEncodingSHA256TxHash, LeafEncodingKeccak256TxHash,
+		LeafEncodingSHA256TxAccount, LeafEncodingKeccak256TxAccount:
+		return encoding, nil
+	default:
+		return "", fmt.Errorf("unknown leaf encoding %q", s)
+	}
+}
+
+// EncodeLeaf builds the Merkle leaf for a transaction
+func (e LeafEncoding) EncodeLeaf(txHash []byte, accountURL string) ([]byte, error) {
+	if len(txHash) != 32 {
+		return nil, ErrInvalidTxHash
+	}
+	switch e {
+	case "", LeafEncodingRawTxHash:
+		leaf := make([]byte, 32)
+		copy(leaf, txHash)
+		return leaf, nil
+	case LeafEncodingSHA256TxHash:
+		sum := sha256.Sum256(txHash)
+		return sum[:], nil
+	case LeafEncodingKeccak256TxHash:
+		return crypto.Keccak256(txHash), nil
+	case LeafEncodingSHA256TxAccount:
+		h := sha256.New()
+		h.Write(txHash)
+		h.Write([]byte(accountURL))
+		return h.Sum(nil), nil
+	case LeafEncodingKeccak256TxAccount:
+		return crypto.Keccak256(txHash, []byte(accountURL)), nil
+	default:
+		return nil, fmt.Errorf("unknown leaf encoding %q", string(e))
+	}
+}
+
+// LeafEncodingSelector picks the leaf encoding for an anchoring target
+type LeafEncodingSelector struct {
+	Default   LeafEncoding
+	Overrides map[string]LeafEncoding // Keyed by lower-case contract address or chain ID
+}
+
+// ParseLeafEncodingSelector parses the default mode and "<contract-or-chain-id>=<mode>" overrides
+func ParseLeafEncodingSelector(defaultMode string, overrides []string) (*LeafEncodingSelector, error) {
+	def, err := ParseLeafEncoding(defaultMode)
+	if err != nil {
+		return nil, err
+	}
+	selector := &LeafEncodingSelector{Default: def, Overrides: make(map[string]LeafEncoding)}
+	for _, override := range overrides {
+		target, mode, ok := strings.Cut(override, "=")
+		target = strings.ToLower(strings.TrimSpace(target))
+		if !ok || target == "" {
+			return nil, fmt.Errorf("invalid leaf encoding override %q: expected <contract-or-chain-id>=<mode>", override)
+		}
+		encoding, err := ParseLeafEncoding(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leaf encoding override %q: %w", override, err)
+		}
+		if _, dup := selector.Overrides[target]; dup {
+			return nil, fmt.Errorf("duplicate leaf encoding override for %s", target)
+		}
+		selector.Overrides[target] = encoding
+	}
+	return selector, nil
+}
+
+// For returns the leaf encoding for a target contract on a chain
+func (s *LeafEncodingSelector) For(chainID, contractAddress string) LeafEncoding {
+	if s == nil {
+		return DefaultLeafEncoding
+	}
+	if encoding, ok := s.Overrides[strings.ToLower(contractAddress)]; ok && contractAddress != "" {
+		return encoding
+	}
+	if encoding, ok := s.Overrides[strings.ToLower(chainID)]; ok && chainID != "" {
+		return encoding
+	}
+	if s.Default == "" {
+		return DefaultLeafEncoding
+	}
+	return s.Default
+}
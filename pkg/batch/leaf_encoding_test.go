@@ -0,0 +1,99 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Leaf Encoding
+// Tests leaf preimage layouts, mode parsing and per-target selection
+
+package batch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestLeafEncoding_EncodeLeaf(t *testing.T) {
+	txHash := sha256.Sum256([]byte("tx"))
+	account := "acc://alice.acme/tokens"
+	withAccount := append(append([]byte{}, txHash[:]...), account...)
+	sha := sha256.Sum256(txHash[:])
+	shaAccount := sha256.Sum256(withAccount)
+
+	tests := []struct {
+		encoding LeafEncoding
+		expected []byte
+	}{
+		{LeafEncodingRawTxHash, txHash[:]},
+		{"", txHash[:]},
+		{LeafEncodingSHA256TxHash, sha[:]},
+		{LeafEncodingKeccak256TxHash, crypto.Keccak256(txHash[:])},
+		{LeafEncodingSHA256TxAccount, shaAccount[:]},
+		{LeafEncodingKeccak256TxAccount, crypto.Keccak256(withAccount)},
+	}
+	for _, tt := range tests {
+		leaf, err := tt.encoding.EncodeLeaf(txHash[:], account)
+		if err != nil {
+			t.Fatalf("%s: EncodeLeaf failed: %v", tt.encoding, err)
+		}
+		if !bytes.Equal(leaf, tt.expected) {
+			t.Errorf("%s: expected %x, got %x", tt.encoding, tt.expected, leaf)
+		}
+	}
+
+	// The raw leaf is a copy, not an alias of the tx hash
+	leaf, _ := LeafEncodingRawTxHash.EncodeLeaf(txHash[:], account)
+	leaf[0] ^= 0xff
+	if leaf[0] == txHash[0] {
+		t.Error("raw leaf should not alias the transaction hash")
+	}
+
+	if _, err := LeafEncodingSHA256TxHash.EncodeLeaf(txHash[:16], account); !errors.Is(err, ErrInvalidTxHash) {
+		t.Errorf("expected ErrInvalidTxHash for a short hash, got %v", err)
+	}
+	if _, err := LeafEncoding("bogus").EncodeLeaf(txHash[:], account); err == nil {
+		t.Error("expected error for unknown encoding")
+	}
+}
+
+func TestParseLeafEncodingSelector(t *testing.T) {
+	contract := "0xAbCdEf0000000000000000000000000000000001"
+	selector, err := ParseLeafEncodingSelector("sha256_tx_hash", []string{
+		"11155111=keccak256_tx_hash",
+		contract + "=KECCAK256_TX_ACCOUNT",
+	})
+	if err != nil {
+		t.Fatalf("ParseLeafEncodingSelector failed: %v", err)
+	}
+
+	if got := selector.For("11155111", contract); got != LeafEncodingKeccak256TxAccount {
+		t.Errorf("contract override should win, got %s", got)
+	}
+	if got := selector.For("11155111", "0x0000000000000000000000000000000000000002"); got != LeafEncodingKeccak256TxHash {
+		t.Errorf("chain override should apply, got %s", got)
+	}
+	if got := selector.For("1", ""); got != LeafEncodingSHA256TxHash {
+		t.Errorf("expected default, got %s", got)
+	}
+	var nilSelector *LeafEncodingSelector
+	if got := nilSelector.For("1", ""); got != DefaultLeafEncoding {
+		t.Errorf("nil selector should use the default, got %s", got)
+	}
+
+	invalid := []struct {
+		def       string
+		overrides []string
+	}{
+		{"sha1", nil},
+		{"", []string{"11155111"}},
+		{"", []string{"=raw_tx_hash"}},
+		{"", []string{"1=unknown"}},
+		{"", []string{"1=raw_tx_hash", "1=sha256_tx_hash"}},
+	}
+	for _, tt := range invalid {
+		if _, err := ParseLeafEncodingSelector(tt.def, tt.overrides); err == nil {
+			t.Errorf("expected error for default=%q overrides=%v", tt.def, tt.overrides)
+		}
+	}
+}
@@ -110,6 +110,11 @@ func (h *OnDemandHandler) ProcessTransaction(ctx context.Context, tx *Transactio
 		AnchorTriggered:   false,
 	}
 
+	// Over-quota transaction held back by the usage meter - nothing to anchor yet
+	if txResult.Queued {
+		return result, nil
+	}
+
 	// Check if we should trigger anchoring
 	shouldAnchor := false
 	reason := ""
@@ -159,6 +164,18 @@ func (h *OnDemandHandler) ProcessTransaction(ctx context.Context, tx *Transactio
 	return result, nil
 }
 
+// PreviewTransaction returns the batch tx would be anchored in, without adding it or
+// triggering anchoring (see Collector.PreviewOnDemandBatch)
+func (h *OnDemandHandler) PreviewTransaction(tx *TransactionData) (*ClosedBatchResult, error) {
+	// Don't hold the handler lock: ProcessTransaction holds it while anchoring
+	h.mu.Lock()
+	getAccumState := h.getAccumState
+	h.mu.Unlock()
+
+	height, hash := getAccumState()
+	return h.collector.PreviewOnDemandBatch(tx, height, hash)
+}
+
 // FlushBatch forces immediate anchoring of any pending on-demand transactions
 func (h *OnDemandHandler) FlushBatch(ctx context.Context) (*ClosedBatchResult, error) {
 	h.mu.Lock()
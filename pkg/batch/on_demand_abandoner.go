@@ -0,0 +1,316 @@
+// Copyright 2025 Certen Protocol
+//
+// On-Demand Abandoner - Terminal state for on-demand batches that never anchor
+//
+// An on-demand batch normally anchors seconds after it closes. When it does not (its
+// transaction's proof generation failed and was dead-lettered, anchoring failed, or the
+// batch was never closed) the batch record lingers without a terminal state. Once a
+// batch has been idle for the abandonment period the abandoner:
+//   - marks it abandoned, a terminal status: abandoned batches are never anchored
+//   - records an outcome per transaction so the customer can be notified
+//   - refunds the on-demand usage metered for each transaction, when metering is enabled
+//
+// Batches in the anchoring state are left alone (their anchor may still land), as is the
+// collector's open on-demand batch.
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// abandonableBatchStatuses are the statuses of on-demand batches that never anchored
+var abandonableBatchStatuses = []database.BatchStatus{
+	database.BatchStatusPending,
+	database.BatchStatusClosed,
+	database.BatchStatusFailed,
+}
+
+// OnDemandAbandonStore is the batch storage the abandoner reads and updates
+// Implemented by database.BatchRepository
+type OnDemandAbandonStore interface {
+	GetStaleOnDemandBatches(ctx context.Context, statuses []database.BatchStatus, before time.Time) ([]*database.AnchorBatch, error)
+	GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error)
+	AbandonOnDemandBatch(ctx context.Context, batchID uuid.UUID, fromStatuses []database.BatchStatus, reason string, requests []database.AbandonedOnDemandRequest) (bool, error)
+	MarkAbandonedUsageRefunded(ctx context.Context, id int64) error
+	ListAbandonedOnDemandRequests(ctx context.Context, accountURL string, limit, offset int) ([]database.AbandonedOnDemandRequest, error)
+}
+
+// UsageRefunder removes metered usage of requests that produced no proof
+// Implemented by UsageMeter
+type UsageRefunder interface {
+	Refund(ctx context.Context, accountURL string, countedAt time.Time, batchType database.BatchType) error
+}
+
+// OnDemandAbandonReport summarizes an abandonment sweep
+type OnDemandAbandonReport struct {
+	Checked   int         `json:"checked"`
+	Abandoned []uuid.UUID `json:"abandoned,omitempty"`
+	Requests  int         `json:"requests"` // Transactions in abandoned batches
+	Refunded  int         `json:"refunded"` // Requests whose metered usage was refunded
+	Errors    int         `json:"errors"`
+}
+
+// OnDemandAbandonerStatus reports the abandoner's policy and last sweep
+type OnDemandAbandonerStatus struct {
+	AbandonAfter   string                 `json:"abandon_after"`
+	CheckInterval  string                 `json:"check_interval"`
+	RefundsEnabled bool                   `json:"refunds_enabled"`
+	TotalAbandoned int64                  `json:"total_abandoned"` // Batches abandoned since startup
+	LastSweepAt    *time.Time             `json:"last_sweep_at,omitempty"`
+	LastSweep      *OnDemandAbandonReport `json:"last_sweep,omitempty"`
+}
+
+// OnDemandAbandonerConfig holds configuration for the on-demand abandoner
+type OnDemandAbandonerConfig struct {
+	AbandonAfter  time.Duration     // Idle time after which an unanchored on-demand batch is abandoned
+	CheckInterval time.Duration     // How often stale batches are swept
+	ActiveBatch   func() *BatchInfo // The collector's open on-demand batch (optional)
+	Logger        *log.Logger
+}
+
+// DefaultOnDemandAbandonerConfig returns default configuration
+func DefaultOnDemandAbandonerConfig() *OnDemandAbandonerConfig {
+	return &OnDemandAbandonerConfig{
+		AbandonAfter:  6 * time.Hour,
+		CheckInterval: 10 * time.Minute,
+		Logger:        log.New(log.Writer(), "[OnDemandAbandoner] ", log.LstdFlags),
+	}
+}
+
+// OnDemandAbandoner moves on-demand batches that never anchored to the abandoned state
+type OnDemandAbandoner struct {
+	mu sync.Mutex
+
+	store         OnDemandAbandonStore
+	refunder      UsageRefunder
+	abandonAfter  time.Duration
+	checkInterval time.Duration
+	activeBatch   func() *BatchInfo
+	now           func() time.Time
+	logger        *log.Logger
+
+	totalAbandoned int64
+	lastSweepAt    time.Time
+	lastSweep      *OnDemandAbandonReport
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewOnDemandAbandoner creates a new on-demand abandoner
+func NewOnDemandAbandoner(store OnDemandAbandonStore, cfg *OnDemandAbandonerConfig) (*OnDemandAbandoner, error) {
+	if store == nil {
+		return nil, fmt.Errorf("on-demand abandon store cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultOnDemandAbandonerConfig()
+	}
+	if cfg.AbandonAfter <= 0 {
+		return nil, fmt.Errorf("abandonment period must be positive")
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = DefaultOnDemandAbandonerConfig().CheckInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[OnDemandAbandoner] ", log.LstdFlags)
+	}
+
+	return &OnDemandAbandoner{
+		store:         store,
+		abandonAfter:  cfg.AbandonAfter,
+		checkInterval: cfg.CheckInterval,
+		activeBatch:   cfg.ActiveBatch,
+		now:           time.Now,
+		logger:        cfg.Logger,
+	}, nil
+}
+
+// SetUsageRefunder enables refunds of the usage metered for abandoned requests
+func (a *OnDemandAbandoner) SetUsageRefunder(refunder UsageRefunder) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.refunder = refunder
+}
+
+// Start starts the periodic sweep loop
+func (a *OnDemandAbandoner) Start(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopCh != nil {
+		return
+	}
+	a.stopCh = make(chan struct{})
+	a.doneCh = make(chan struct{})
+
+	go a.run(ctx, a.stopCh, a.doneCh)
+	a.logger.Printf("Started (abandon after=%s, check=%s)", a.abandonAfter, a.checkInterval)
+}
+
+// Stop stops the sweep loop
+func (a *OnDemandAbandoner) Stop() {
+	a.mu.Lock()
+	stopCh, doneCh := a.stopCh, a.doneCh
+	a.stopCh, a.doneCh = nil, nil
+	a.mu.Unlock()
+
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	<-doneCh
+}
+
+func (a *OnDemandAbandoner) run(ctx context.Context, stopCh, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	ticker := time.NewTicker(a.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if _, err := a.Sweep(ctx); err != nil {
+				a.logger.Printf("⚠️ Abandonment sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+// Sweep abandons every on-demand batch that has not anchored within the abandonment period
+// Failures on individual batches are counted and logged; the batch is retried next sweep
+func (a *OnDemandAbandoner) Sweep(ctx context.Context) (*OnDemandAbandonReport, error) {
+	a.mu.Lock()
+	refunder := a.refunder
+	a.mu.Unlock()
+
+	batches, err := a.store.GetStaleOnDemandBatches(ctx, abandonableBatchStatuses, a.now().Add(-a.abandonAfter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale on-demand batches: %w", err)
+	}
+
+	var active uuid.UUID
+	if a.activeBatch != nil {
+		if info := a.activeBatch(); info != nil {
+			active = info.BatchID
+		}
+	}
+
+	report := &OnDemandAbandonReport{}
+	for _, batch := range batches {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if batch.BatchID == active {
+			continue
+		}
+		report.Checked++
+
+		requests, err := a.abandon(ctx, batch)
+		if err != nil {
+			report.Errors++
+			a.logger.Printf("⚠️ Failed to abandon on-demand batch %s: %v", batch.BatchID, err)
+			continue
+		}
+		if requests == nil {
+			continue // Anchored or abandoned meanwhile
+		}
+		report.Abandoned = append(report.Abandoned, batch.BatchID)
+		report.Requests += len(requests)
+
+		for _, req := range requests {
+			if refunder == nil || req.ID == 0 {
+				continue
+			}
+			if err := refunder.Refund(ctx, req.AccountURL, req.RequestedAt, database.BatchTypeOnDemand); err != nil {
+				report.Errors++
+				a.logger.Printf("⚠️ Failed to refund usage of %s for %s: %v", req.AccumTxHash, req.AccountURL, err)
+				continue
+			}
+			if err := a.store.MarkAbandonedUsageRefunded(ctx, req.ID); err != nil {
+				a.logger.Printf("⚠️ Failed to record refund of %s: %v", req.AccumTxHash, err)
+			}
+			report.Refunded++
+		}
+		a.logger.Printf("🗑️ Abandoned on-demand batch %s (was %s, %d requests)", batch.BatchID, batch.Status, len(requests))
+	}
+
+	a.mu.Lock()
+	a.totalAbandoned += int64(len(report.Abandoned))
+	a.lastSweepAt = a.now()
+	a.lastSweep = report
+	a.mu.Unlock()
+
+	if len(report.Abandoned) > 0 || report.Errors > 0 {
+		a.logger.Printf("Sweep complete: checked=%d, abandoned=%d, requests=%d, refunded=%d, errors=%d",
+			report.Checked, len(report.Abandoned), report.Requests, report.Refunded, report.Errors)
+	}
+	return report, nil
+}
+
+// abandon marks one batch abandoned with an outcome per transaction. It returns nil
+// requests without error when the batch left the abandonable statuses meanwhile.
+func (a *OnDemandAbandoner) abandon(ctx context.Context, batch *database.AnchorBatch) ([]database.AbandonedOnDemandRequest, error) {
+	txs, err := a.store.GetTransactionsInBatch(ctx, batch.BatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := fmt.Sprintf("on-demand batch not anchored within %s (status %s)", a.abandonAfter, batch.Status)
+	if batch.ErrorMessage.Valid && batch.ErrorMessage.String != "" {
+		reason += ": " + batch.ErrorMessage.String
+	}
+
+	requests := make([]database.AbandonedOnDemandRequest, 0, len(txs))
+	for _, tx := range txs {
+		requests = append(requests, database.AbandonedOnDemandRequest{
+			AccumTxHash: tx.AccumTxHash,
+			AccountURL:  NormalizeAccountURL(tx.AccountURL),
+			RequestedAt: tx.CreatedAt,
+		})
+	}
+
+	abandoned, err := a.store.AbandonOnDemandBatch(ctx, batch.BatchID, abandonableBatchStatuses, reason, requests)
+	if err != nil || !abandoned {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ListAbandoned returns abandoned request outcomes, newest first, optionally for one account
+func (a *OnDemandAbandoner) ListAbandoned(ctx context.Context, accountURL string, limit, offset int) ([]database.AbandonedOnDemandRequest, error) {
+	if accountURL != "" {
+		accountURL = NormalizeAccountURL(accountURL)
+	}
+	return a.store.ListAbandonedOnDemandRequests(ctx, accountURL, limit, offset)
+}
+
+// Status returns the abandonment policy and the result of the last sweep
+func (a *OnDemandAbandoner) Status() OnDemandAbandonerStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	status := OnDemandAbandonerStatus{
+		AbandonAfter:   a.abandonAfter.String(),
+		CheckInterval:  a.checkInterval.String(),
+		RefundsEnabled: a.refunder != nil,
+		TotalAbandoned: a.totalAbandoned,
+		LastSweep:      a.lastSweep,
+	}
+	if !a.lastSweepAt.IsZero() {
+		at := a.lastSweepAt
+		status.LastSweepAt = &at
+	}
+	return status
+}
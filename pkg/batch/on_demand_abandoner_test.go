@@ -0,0 +1,143 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for On-Demand Abandoner
+// Tests stale batch selection, per-request outcomes, usage refunds and the open batch exemption
+
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// memoryAbandonStore keeps on-demand batches and their transactions in memory
+type memoryAbandonStore struct {
+	batches   []*database.AnchorBatch
+	txs       map[uuid.UUID][]*database.BatchTransaction
+	abandoned []database.AbandonedOnDemandRequest
+}
+
+func (s *memoryAbandonStore) GetStaleOnDemandBatches(ctx context.Context, statuses []database.BatchStatus, before time.Time) ([]*database.AnchorBatch, error) {
+	var stale []*database.AnchorBatch
+	for _, b := range s.batches {
+		for _, status := range statuses {
+			if b.Status == status && b.UpdatedAt.Before(before) {
+				stale = append(stale, b)
+			}
+		}
+	}
+	return stale, nil
+}
+
+func (s *memoryAbandonStore) GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error) {
+	return s.txs[batchID], nil
+}
+
+func (s *memoryAbandonStore) AbandonOnDemandBatch(ctx context.Context, batchID uuid.UUID, fromStatuses []database.BatchStatus, reason string, requests []database.AbandonedOnDemandRequest) (bool, error) {
+	for _, b := range s.batches {
+		if b.BatchID != batchID {
+			continue
+		}
+		for i := range requests {
+			requests[i].ID = int64(len(s.abandoned) + 1)
+			requests[i].BatchID = batchID
+			requests[i].PreviousStatus = b.Status
+			requests[i].Reason = reason
+			s.abandoned = append(s.abandoned, requests[i])
+		}
+		b.Status = database.BatchStatusAbandoned
+		return true, nil
+	}
+	return false, database.ErrBatchNotFound
+}
+
+func (s *memoryAbandonStore) MarkAbandonedUsageRefunded(ctx context.Context, id int64) error {
+	s.abandoned[id-1].UsageRefunded = true
+	return nil
+}
+
+func (s *memoryAbandonStore) ListAbandonedOnDemandRequests(ctx context.Context, accountURL string, limit, offset int) ([]database.AbandonedOnDemandRequest, error) {
+	var out []database.AbandonedOnDemandRequest
+	for _, r := range s.abandoned {
+		if accountURL == "" || r.AccountURL == accountURL {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func TestOnDemandAbandoner_Sweep(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stale := now.Add(-7 * time.Hour)
+	failed := &database.AnchorBatch{
+		BatchID: uuid.New(), BatchType: database.BatchTypeOnDemand, Status: database.BatchStatusFailed,
+		ErrorMessage: sql.NullString{String: "proof generation dead-lettered", Valid: true}, UpdatedAt: stale,
+	}
+	empty := &database.AnchorBatch{BatchID: uuid.New(), BatchType: database.BatchTypeOnDemand, Status: database.BatchStatusClosed, UpdatedAt: stale}
+	open := &database.AnchorBatch{BatchID: uuid.New(), BatchType: database.BatchTypeOnDemand, Status: database.BatchStatusPending, UpdatedAt: stale}
+	recent := &database.AnchorBatch{BatchID: uuid.New(), BatchType: database.BatchTypeOnDemand, Status: database.BatchStatusFailed, UpdatedAt: now.Add(-time.Hour)}
+
+	store := &memoryAbandonStore{
+		batches: []*database.AnchorBatch{failed, empty, open, recent},
+		txs: map[uuid.UUID][]*database.BatchTransaction{
+			failed.BatchID: {{AccumTxHash: "tx1", AccountURL: "ACC://Alice.acme/", CreatedAt: stale}},
+		},
+	}
+	abandoner, err := NewOnDemandAbandoner(store, &OnDemandAbandonerConfig{
+		AbandonAfter: 6 * time.Hour,
+		ActiveBatch:  func() *BatchInfo { return &BatchInfo{BatchID: open.BatchID} },
+		Logger:       log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewOnDemandAbandoner failed: %v", err)
+	}
+	abandoner.now = func() time.Time { return now }
+
+	usage := newMemoryUsageStore()
+	meter, _ := newTestUsageMeter(t, usage, QuotaActionReject, UsageWindow{Size: 24 * time.Hour, Limit: 10})
+	if err := meter.Record(context.Background(), &TransactionData{AccountURL: "acc://alice.acme"}, database.BatchTypeOnDemand); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	abandoner.SetUsageRefunder(meter)
+
+	report, err := abandoner.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if report.Checked != 2 || len(report.Abandoned) != 2 || report.Requests != 1 || report.Refunded != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if failed.Status != database.BatchStatusAbandoned || empty.Status != database.BatchStatusAbandoned {
+		t.Errorf("stale batches should be abandoned, got %s and %s", failed.Status, empty.Status)
+	}
+	if open.Status != database.BatchStatusPending || recent.Status != database.BatchStatusFailed {
+		t.Errorf("open and recent batches should be untouched, got %s and %s", open.Status, recent.Status)
+	}
+
+	outcomes, err := abandoner.ListAbandoned(context.Background(), "acc://alice.acme", 10, 0)
+	if err != nil {
+		t.Fatalf("ListAbandoned failed: %v", err)
+	}
+	if len(outcomes) != 1 || !outcomes[0].UsageRefunded || outcomes[0].PreviousStatus != database.BatchStatusFailed {
+		t.Fatalf("unexpected outcomes %+v", outcomes)
+	}
+	if want := "on-demand batch not anchored within 6h0m0s (status failed): proof generation dead-lettered"; outcomes[0].Reason != want {
+		t.Errorf("reason = %q, expected %q", outcomes[0].Reason, want)
+	}
+
+	day := now.Truncate(24 * time.Hour)
+	if m, _ := usage.GetAccountUsage(context.Background(), "acc://alice.acme", 24*time.Hour, day); m.ProofCount != 0 || m.OnDemandCount != 0 {
+		t.Errorf("usage should be refunded, got %d proofs (%d on-demand)", m.ProofCount, m.OnDemandCount)
+	}
+
+	if status := abandoner.Status(); status.TotalAbandoned != 2 || !status.RefundsEnabled || status.LastSweepAt == nil {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
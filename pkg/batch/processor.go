@@ -12,21 +12,26 @@
 package batch
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math/big"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/ethereum"
 	"github.com/certen/independant-validator/pkg/firestore"
 	"github.com/certen/independant-validator/pkg/merkle"
+	"github.com/certen/independant-validator/pkg/metrics"
 	"github.com/certen/independant-validator/pkg/proof"
 )
 
@@ -42,6 +47,12 @@ type AnchorCreator interface {
 	ExecuteComprehensiveProof(ctx context.Context, req *ExecuteProofRequest) (*ExecuteProofResult, error)
 }
 
+// AnchorEstimator is optionally implemented by an AnchorCreator that can price an anchor
+// without creating it (AnchorAdapter implements it)
+type AnchorEstimator interface {
+	EstimateBatchAnchor(ctx context.Context, req *BatchAnchorRequest) (*AnchorCostEstimate, error)
+}
+
 // ExecuteProofRequest is the request to execute a comprehensive proof
 // This bridges batch processor data to the anchor manager's proof execution
 type ExecuteProofRequest struct {
@@ -52,6 +63,7 @@ type ExecuteProofRequest struct {
 	MerkleRoot           [32]byte  `json:"merkle_root"`             // Batch Merkle root
 	ProofHashes          [][32]byte `json:"proof_hashes"`           // Merkle proof path
 	LeafHash             [32]byte  `json:"leaf_hash"`               // Leaf being proven
+	LeafIndex            uint64    `json:"leaf_index"`              // Leaf position, orders the proof path
 	OperationCommitment  [32]byte  `json:"operation_commitment"`    // = MerkleRoot
 	CrossChainCommitment [32]byte  `json:"cross_chain_commitment"`  // BPT root from Accumulate
 	GovernanceRoot       [32]byte  `json:"governance_root"`         // Root of governance proofs
@@ -67,6 +79,13 @@ type ExecuteProofResult struct {
 	GasUsed     int64  `json:"gas_used"`
 	Success     bool   `json:"success"`
 	ProofValid  bool   `json:"proof_valid"`
+
+	CalldataBytes int  `json:"calldata_bytes"`   // Size of the submitted executeComprehensiveProof calldata
+	Shadow        bool `json:"shadow,omitempty"` // Simulated in shadow mode; nothing was sent
+
+	// Set when the contract has already consumed the proof's commitment or nonce; nothing was sent
+	AlreadyAnchored       bool   `json:"already_anchored,omitempty"`
+	AlreadyAnchoredReason string `json:"already_anchored_reason,omitempty"`
 }
 
 // BatchAnchorRequest is the request to anchor a batch
@@ -79,6 +98,7 @@ type BatchAnchorRequest struct {
 	AccumulateHash   string    `json:"accumulate_hash"`
 	TargetChain      string    `json:"target_chain"` // "ethereum", "bitcoin"
 	ValidatorID      string    `json:"validator_id"`
+	Urgent           bool      `json:"urgent,omitempty"` // On-demand: anchor on the fee escalation schedule
 
 	// ========== Phase 2 Additions: Real Proof Data ==========
 	// These fields provide cryptographic binding per CERTEN whitepaper
@@ -118,6 +138,8 @@ type BatchAnchorResult struct {
 	TotalCostWei    string    `json:"total_cost_wei"`
 	Success         bool      `json:"success"`
 	Timestamp       time.Time `json:"timestamp"`
+	Fees            AnchorFees `json:"fees"`
+	Shadow          bool      `json:"shadow,omitempty"` // Simulated in shadow mode; nothing was sent
 }
 
 // OnAnchorCallback is called when a batch is successfully anchored
@@ -142,6 +164,7 @@ type Processor struct {
 	chainID        string
 	networkName    string
 	contractAddr   string
+	leafEncoding   LeafEncoding // Merkle leaf layout expected by the target contract
 
 	// Governance proof configuration
 	defaultGovLevel proof.GovernanceLevel // Default governance level for batch proofs
@@ -151,6 +174,10 @@ type Processor struct {
 	// and ensure all validators agree on the same merkleRoot
 	validatorSet []string // List of all validators in consensus (sorted)
 
+	// Anchor finality thresholds recorded on new anchors
+	availableConfirmations int
+	requiredConfirmations  int
+
 	// Processing state
 	processing   map[uuid.UUID]bool // Batches currently being processed
 
@@ -160,8 +187,14 @@ type Processor struct {
 	// Logging
 	logger *log.Logger
 
-	// Firestore sync for real-time UI updates
-	firestoreSyncService *firestore.SyncService
+	// Firestore sync for real-time UI updates (never nil; NullSyncService when disabled)
+	firestoreSyncService firestore.Syncer
+
+	// Prometheus metrics (nil = disabled)
+	metrics *metrics.Registry
+
+	// Prices anchor transactions in USD when they are recorded (nil = wei only)
+	feeOracle ethereum.FeeOracle
 }
 
 // ProcessorConfig holds processor configuration
@@ -177,10 +210,22 @@ type ProcessorConfig struct {
 	GovernanceLevel    proof.GovernanceLevel // Default governance level (G0, G1, G2)
 	V3Endpoint         string                // Accumulate V3 API endpoint
 	ValidatorKey       []byte                // Ed25519 private key for signing governance proofs
+	GovernanceBatchDedup bool                // Share authority snapshots across a batch's G1/G2 proofs
 
 	// CONSENSUS FIX: Validator set for executor selection
 	// This list must be the SAME on all validators to ensure consistent election
 	ValidatorSet       []string              // List of validator IDs (e.g., ["validator-1", "validator-2", ...])
+
+	// Anchor finality thresholds (0 = chain defaults)
+	AvailableConfirmations int // Confirmations before an anchor is "available"
+	RequiredConfirmations  int // Confirmations before an anchor is "final"
+
+	// Merkle leaf layout for the target contract (empty = DefaultLeafEncoding)
+	// Must match the collector's, see LeafEncodingSelector
+	LeafEncoding LeafEncoding
+
+	// Prometheus metrics (nil = disabled)
+	Metrics *metrics.Registry
 }
 
 // DefaultProcessorConfig returns default configuration
@@ -210,6 +255,11 @@ func NewProcessor(repos *database.Repositories, anchorCreator AnchorCreator, cfg
 		cfg.Logger = log.New(log.Writer(), "[BatchProcessor] ", log.LstdFlags)
 	}
 
+	leafEncoding, err := ParseLeafEncoding(string(cfg.LeafEncoding))
+	if err != nil {
+		return nil, err
+	}
+
 	// CONSENSUS FIX: Sort and store validator set for deterministic executor selection
 	validatorSet := cfg.ValidatorSet
 	if len(validatorSet) == 0 {
@@ -227,10 +277,15 @@ func NewProcessor(repos *database.Repositories, anchorCreator AnchorCreator, cfg
 		chainID:         cfg.ChainID,
 		networkName:     cfg.NetworkName,
 		contractAddr:    cfg.ContractAddress,
+		leafEncoding:    leafEncoding,
 		processing:      make(map[uuid.UUID]bool),
 		logger:          cfg.Logger,
 		defaultGovLevel: cfg.GovernanceLevel,
 		validatorSet:    validatorSet, // CONSENSUS FIX: Store sorted validator set
+		availableConfirmations: cfg.AvailableConfirmations,
+		requiredConfirmations:  cfg.RequiredConfirmations,
+		metrics:                cfg.Metrics,
+		firestoreSyncService:   firestore.NullSyncService{},
 	}
 
 	// Phase 2: Initialize governance proof generator if V3 endpoint is configured
@@ -244,6 +299,7 @@ func NewProcessor(repos *database.Repositories, anchorCreator AnchorCreator, cfg
 			V3Endpoint:   cfg.V3Endpoint,
 			ValidatorKey: validatorKey,
 			ValidatorID:  cfg.ValidatorID,
+			BatchDedup:   cfg.GovernanceBatchDedup,
 			Logger:       log.New(log.Writer(), "[GovProof] ", log.LstdFlags),
 		}
 
@@ -343,11 +399,58 @@ func (p *Processor) SetDefaultGovernanceLevel(level proof.GovernanceLevel) {
 }
 
 // SetFirestoreSyncService sets the Firestore sync service for real-time UI updates
-func (p *Processor) SetFirestoreSyncService(svc *firestore.SyncService) {
+// (nil = firestore.NullSyncService)
+func (p *Processor) SetFirestoreSyncService(svc firestore.Syncer) {
+	if svc == nil {
+		svc = firestore.NullSyncService{}
+	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.firestoreSyncService = svc
-	p.logger.Printf("✅ Firestore sync service configured for batch processor")
+	if svc.IsEnabled() {
+		p.logger.Printf("✅ Firestore sync service configured for batch processor")
+	}
+}
+
+// SetFeeOracle prices each recorded anchor transaction in USD at the ETH/USD price of
+// the time it was anchored
+func (p *Processor) SetFeeOracle(oracle ethereum.FeeOracle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.feeOracle = oracle
+	p.logger.Printf("✅ Fee oracle configured for anchor cost accounting")
+}
+
+// ProcessorSettings is the processor's effective configuration
+type ProcessorSettings struct {
+	TargetChain            string       `json:"target_chain"`
+	ChainID                string       `json:"chain_id"`
+	NetworkName            string       `json:"network_name"`
+	ContractAddress        string       `json:"contract_address"`
+	LeafEncoding           LeafEncoding `json:"leaf_encoding"`
+	GovernanceLevel        string       `json:"governance_level"`
+	GovernanceGenerator    bool         `json:"governance_generator"`
+	ValidatorSet           []string     `json:"validator_set"`
+	AvailableConfirmations int          `json:"available_confirmations"`
+	RequiredConfirmations  int          `json:"required_confirmations"`
+}
+
+// Settings returns the processor's effective configuration
+func (p *Processor) Settings() ProcessorSettings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return ProcessorSettings{
+		TargetChain:            p.targetChain,
+		ChainID:                p.chainID,
+		NetworkName:            p.networkName,
+		ContractAddress:        p.contractAddr,
+		LeafEncoding:           p.leafEncoding,
+		GovernanceLevel:        string(p.defaultGovLevel),
+		GovernanceGenerator:    p.govGenerator != nil,
+		ValidatorSet:           append([]string(nil), p.validatorSet...),
+		AvailableConfirmations: p.availableConfirmations,
+		RequiredConfirmations:  p.requiredConfirmations,
+	}
 }
 
 // HasGovernanceGenerator returns true if governance generator is configured
@@ -357,6 +460,28 @@ func (p *Processor) HasGovernanceGenerator() bool {
 	return p.govGenerator != nil
 }
 
+// WaitIdle blocks until no batch is being processed or ctx is done
+// Used during shutdown so in-flight anchor submissions are not cut off
+func (p *Processor) WaitIdle(ctx context.Context) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		p.mu.Lock()
+		inFlight := len(p.processing)
+		p.mu.Unlock()
+		if inFlight == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d batches still processing: %w", inFlight, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // ProcessClosedBatch processes a closed batch and creates an anchor
 // This is called by the scheduler or on-demand handler when a batch is ready
 func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchResult) error {
@@ -417,41 +542,35 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 
 	// Step 1: Create anchor on external chain (ONLY if elected executor)
 	var anchorResult *BatchAnchorResult
+	var proofResult *ExecuteProofResult
 	if p.anchorCreator != nil && isElected {
 		p.logger.Printf("%s 🚀 [CONSENSUS] Validator %s is ELECTED - proceeding with anchor creation for batch %s (price_tier=%s)",
 			batchTypePrefix, p.validatorID, result.BatchID, priceTier)
 
-		// Phase 2: Extract proof data from ClosedBatchResult per HIGH-002, HIGH-003
-		txProofs, govProofs, govLevels := p.extractProofDataFromResult(result)
-
-		req := &BatchAnchorRequest{
-			BatchID:          result.BatchID,
-			MerkleRoot:       result.MerkleRoot,
-			TxCount:          result.TxCount,
-			AccumulateHeight: result.AccumulateHeight,
-			AccumulateHash:   result.AccumulateHash,
-			TargetChain:      p.targetChain,
-			ValidatorID:      p.validatorID,
-			// Phase 2 additions: Real proof data
-			BPTRoot:           result.AggregatedBPTRoot,
-			NetworkRootHash:   result.AggregatedNetworkRoot,
-			TransactionProofs: txProofs,
-			GovernanceProofs:  govProofs,
-			GovernanceLevels:  govLevels,
+		// Persist anchoring before submitting, so a crash mid-submission leaves a batch
+		// the anchor reconciler can find instead of one that looks merely closed
+		if err := transitionBatch(ctx, p.repos.Batches, result.BatchID, database.BatchStatusAnchoring, ""); err != nil {
+			return fmt.Errorf("failed to mark batch anchoring: %w", err)
 		}
 
 		var err error
-		anchorResult, err = p.anchorCreator.CreateBatchAnchor(ctx, req)
+		anchorResult, err = p.anchorCreator.CreateBatchAnchor(ctx, p.buildBatchAnchorRequest(result))
 		if err != nil {
+			p.metrics.RecordAnchorTxFailure(string(result.BatchType), "create_anchor")
 			// Mark batch as failed
-			if updateErr := p.repos.Batches.UpdateBatchStatus(ctx, result.BatchID, database.BatchStatusFailed, err.Error()); updateErr != nil {
+			if updateErr := transitionBatch(ctx, p.repos.Batches, result.BatchID, database.BatchStatusFailed, err.Error()); updateErr != nil {
 				p.logger.Printf("Failed to update batch status: %v", updateErr)
 			}
 			return fmt.Errorf("failed to create anchor: %w", err)
 		}
 
-		p.logger.Printf("%s ✅ [CONSENSUS] Anchor created by elected executor on %s: tx=%s, block=%d",
-			batchTypePrefix, anchorResult.TargetChain, anchorResult.TxHash[:16]+"...", anchorResult.BlockNumber)
+		if anchorResult.Shadow {
+			p.logger.Printf("%s 🕶️ [SHADOW] Anchor simulated on %s (not sent): tx=%s, gas=%d, cost=%s wei",
+				batchTypePrefix, anchorResult.TargetChain, anchorResult.TxHash[:16]+"...", anchorResult.GasUsed, anchorResult.TotalCostWei)
+		} else {
+			p.logger.Printf("%s ✅ [CONSENSUS] Anchor created by elected executor on %s: tx=%s, block=%d",
+				batchTypePrefix, anchorResult.TargetChain, anchorResult.TxHash[:16]+"...", anchorResult.BlockNumber)
+		}
 		// =====================================================================
 		// PHASE 1: Execute Comprehensive Proof (CRITICAL-001 Fix)
 		// Per ANCHOR_V3_IMPLEMENTATION_PLAN.md: MUST call executeComprehensiveProof
@@ -468,15 +587,26 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 		} else {
 			p.logger.Printf("%s 📋 [Phase 1] Executing comprehensive proof on-chain...", batchTypePrefix)
 
-			proofResult, proofErr := p.anchorCreator.ExecuteComprehensiveProof(ctx, proofReq)
+			var proofErr error
+			proofResult, proofErr = p.anchorCreator.ExecuteComprehensiveProof(ctx, proofReq)
 			if proofErr != nil {
+				p.metrics.RecordAnchorTxFailure(string(result.BatchType), "execute_proof")
 				p.logger.Printf("%s ⚠️ [Phase 1] Comprehensive proof execution failed: %v", batchTypePrefix, proofErr)
 				// Continue - anchor was created, but proof execution failed
 				// In production, this should trigger retry logic
+			} else if proofResult != nil && proofResult.AlreadyAnchored {
+				// Nothing was sent: the contract would have reverted the replay
+				p.logger.Printf("%s ⏭️ [Phase 1] Comprehensive proof already anchored, not submitted: %s",
+					batchTypePrefix, proofResult.AlreadyAnchoredReason)
 			} else if proofResult != nil {
-				p.logger.Printf("%s ✅ [Phase 1] Comprehensive proof executed successfully!", batchTypePrefix)
+				p.metrics.RecordProofExecuted(string(result.BatchType))
+				if proofResult.Shadow {
+					p.logger.Printf("%s 🕶️ [SHADOW] Comprehensive proof assembled and simulated (not sent)", batchTypePrefix)
+				} else {
+					p.logger.Printf("%s ✅ [Phase 1] Comprehensive proof executed successfully!", batchTypePrefix)
+				}
 				p.logger.Printf("%s    Proof TxHash: %s", batchTypePrefix, proofResult.TxHash[:16]+"...")
-				p.logger.Printf("%s    Block: %d, GasUsed: %d", batchTypePrefix, proofResult.BlockNumber, proofResult.GasUsed)
+				p.logger.Printf("%s    Block: %d, GasUsed: %d, Calldata: %d bytes", batchTypePrefix, proofResult.BlockNumber, proofResult.GasUsed, proofResult.CalldataBytes)
 				p.logger.Printf("%s    ProofValid: %v, Success: %v", batchTypePrefix, proofResult.ProofValid, proofResult.Success)
 			}
 		}
@@ -486,7 +616,7 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 			p.validatorID, result.BatchID)
 		// Update batch status to indicate it was processed but not anchored by this validator
 		// The elected executor will create the anchor
-		if err := p.repos.Batches.UpdateBatchStatus(ctx, result.BatchID, database.BatchStatusClosed, "awaiting_elected_anchor"); err != nil {
+		if err := transitionBatch(ctx, p.repos.Batches, result.BatchID, database.BatchStatusClosed, "awaiting_elected_anchor"); err != nil {
 			p.logger.Printf("Warning: failed to update batch status: %v", err)
 		}
 		return nil // Exit early - elected executor will handle anchor creation
@@ -509,6 +639,20 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 			GasUsed:         anchorResult.GasUsed,
 			GasPriceWei:     anchorResult.GasPriceWei,
 			TotalCostWei:    anchorResult.TotalCostWei,
+			BaseFeeWei:      anchorResult.Fees.BaseFeeWei,
+			PriorityFeeWei:  anchorResult.Fees.PriorityFeeWei,
+			FeeStrategy:     anchorResult.Fees.FeeStrategy,
+			GasBumped:       anchorResult.Fees.GasBumped,
+			Shadow:          anchorResult.Shadow,
+			AvailableConfirmations: p.availableConfirmations,
+			RequiredConfirmations:  p.requiredConfirmations,
+		}
+		if proofResult != nil {
+			anchorRecord.ProofGasUsed = proofResult.GasUsed
+			anchorRecord.ProofCalldataBytes = proofResult.CalldataBytes
+		}
+		if !anchorResult.Shadow {
+			anchorRecord.TotalCostUSD = p.anchorCostUSD(ctx, anchorResult.TotalCostWei)
 		}
 
 		anchor, err := p.repos.Anchors.CreateAnchor(ctx, anchorRecord)
@@ -517,6 +661,7 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 			// Continue - anchor was created on-chain
 		} else {
 			anchorID = anchor.AnchorID
+			p.recordFeeEscalation(ctx, anchorID, anchorResult)
 		}
 	}
 
@@ -533,10 +678,17 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 	if anchorResult == nil {
 		status = database.BatchStatusClosed // No anchor creator, just closed
 	}
-	if err := p.repos.Batches.UpdateBatchStatus(ctx, result.BatchID, status, ""); err != nil {
+	if err := transitionBatch(ctx, p.repos.Batches, result.BatchID, status, ""); err != nil {
 		p.logger.Printf("Failed to update batch status: %v", err)
 	}
 
+	// Shadow anchors were never sent: peers have nothing to attest and the UI nothing to show
+	if anchorResult != nil && anchorResult.Shadow {
+		p.logger.Printf("%s 🕶️ [SHADOW] Batch %s recorded with shadow anchor %s (attestation and sync skipped)",
+			batchTypePrefix, result.BatchID, anchorResult.TxHash)
+		return nil
+	}
+
 	// PHASE 5: Trigger attestation collection callback
 	// Per Whitepaper Section 3.4.1 Component 4: Multi-validator attestations
 	if p.onAnchorCallback != nil && anchorResult != nil {
@@ -550,7 +702,7 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 	}
 
 	// Trigger Firestore sync for anchor submitted event (Stage 6)
-	if p.firestoreSyncService != nil && p.firestoreSyncService.IsEnabled() && anchorResult != nil {
+	if p.firestoreSyncService.IsEnabled() && anchorResult != nil {
 		go p.triggerAnchorSubmittedFirestoreEvent(result, anchorResult)
 	}
 
@@ -558,6 +710,57 @@ func (p *Processor) ProcessClosedBatch(ctx context.Context, result *ClosedBatchR
 	return nil
 }
 
+// anchorCostUSD converts an anchor transaction's cost to USD at the fee oracle's current
+// ETH/USD price; nil without an oracle, a quote or a cost
+func (p *Processor) anchorCostUSD(ctx context.Context, totalCostWei string) *float64 {
+	if p.feeOracle == nil {
+		return nil
+	}
+	costWei, ok := new(big.Int).SetString(totalCostWei, 10)
+	if !ok {
+		return nil
+	}
+	quoteCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	quote, err := p.feeOracle.Quote(quoteCtx)
+	if err != nil {
+		p.logger.Printf("Fee oracle unavailable, anchor cost recorded in wei only: %v", err)
+		return nil
+	}
+	costUSD := quote.WeiToUSD(costWei)
+	return &costUSD
+}
+
+// recordFeeEscalation stores the submissions of an anchor sent on the fee escalation schedule
+func (p *Processor) recordFeeEscalation(ctx context.Context, anchorID uuid.UUID, anchorResult *BatchAnchorResult) {
+	steps := feeEscalationSteps(anchorID, anchorResult)
+	if len(steps) == 0 {
+		return
+	}
+	if err := p.repos.Anchors.RecordFeeEscalation(ctx, steps); err != nil {
+		p.logger.Printf("Failed to store fee escalation for anchor %s: %v", anchorID, err)
+		return
+	}
+	p.logger.Printf("⛽ Anchor %s confirmed after %d fee escalation step(s): gas_price=%s wei, total_cost=%s wei",
+		anchorID, len(steps), anchorResult.GasPriceWei, anchorResult.TotalCostWei)
+}
+
+// feeEscalationSteps converts an anchor's escalation submissions to records, marking the mined one
+func feeEscalationSteps(anchorID uuid.UUID, anchorResult *BatchAnchorResult) []database.AnchorFeeEscalationStep {
+	steps := make([]database.AnchorFeeEscalationStep, 0, len(anchorResult.Fees.Escalation))
+	for _, s := range anchorResult.Fees.Escalation {
+		steps = append(steps, database.AnchorFeeEscalationStep{
+			AnchorID:    anchorID,
+			Attempt:     s.Attempt,
+			TxHash:      s.TxHash,
+			GasPriceWei: s.GasPriceWei,
+			SubmittedAt: s.SubmittedAt,
+			Mined:       strings.EqualFold(s.TxHash, anchorResult.TxHash),
+		})
+	}
+	return steps
+}
+
 // createProofs creates Certen Anchor Proofs for each transaction in the batch
 func (p *Processor) createProofs(ctx context.Context, result *ClosedBatchResult, anchorID uuid.UUID, anchorResult *BatchAnchorResult) error {
 	// Get transactions from database
@@ -664,46 +867,17 @@ func (p *Processor) ProcessPendingBatches(ctx context.Context) error {
 			continue
 		}
 
-		// Rebuild merkle tree
-		leaves := make([][]byte, len(txs))
-		for i, tx := range txs {
-			leaves[i] = tx.TxHash
-		}
-
-		if len(leaves) == 0 {
+		if len(txs) == 0 {
 			p.logger.Printf("Skipping empty batch %s", batch.BatchID)
 			continue
 		}
 
-		tree, err := merkle.BuildTree(leaves)
+		result, err := p.rebuildClosedBatch(batch, txs)
 		if err != nil {
-			p.logger.Printf("Failed to rebuild merkle tree for batch %s: %v", batch.BatchID, err)
+			p.logger.Printf("❌ Failed to rebuild batch %s: %v", batch.BatchID, err)
 			continue
 		}
 
-		// Generate proofs
-		proofs := make([]*merkle.InclusionProof, len(leaves))
-		for i := range leaves {
-			proof, err := tree.GenerateProof(i)
-			if err != nil {
-				p.logger.Printf("Failed to generate proof for leaf %d: %v", i, err)
-				continue
-			}
-			proofs[i] = proof
-		}
-
-		// Create closed batch result
-		result := &ClosedBatchResult{
-			BatchID:       batch.BatchID,
-			BatchType:     batch.BatchType,
-			MerkleRoot:    batch.MerkleRoot,
-			MerkleRootHex: hex.EncodeToString(batch.MerkleRoot),
-			TxCount:       len(txs),
-			StartTime:     batch.StartTime,
-			EndTime:       time.Now(),
-			Proofs:        proofs,
-		}
-
 		if err := p.ProcessClosedBatch(ctx, result); err != nil {
 			p.logger.Printf("Failed to process batch %s: %v", batch.BatchID, err)
 			continue
@@ -713,6 +887,69 @@ func (p *Processor) ProcessPendingBatches(ctx context.Context) error {
 	return nil
 }
 
+// rebuildClosedBatch rebuilds a stored batch's Merkle tree and inclusion proofs from its
+// persisted transactions. The rebuilt root must match the stored root.
+func (p *Processor) rebuildClosedBatch(batch *database.AnchorBatch, txs []*database.BatchTransaction) (*ClosedBatchResult, error) {
+	leaves, err := p.encodeLeaves(txs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode merkle leaves: %w", err)
+	}
+
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild merkle tree: %w", err)
+	}
+	if len(batch.MerkleRoot) > 0 && !bytes.Equal(tree.Root(), batch.MerkleRoot) {
+		return nil, fmt.Errorf("rebuilt merkle root %s does not match stored root %s (leaf encoding %s changed?)",
+			tree.RootHex(), hex.EncodeToString(batch.MerkleRoot), p.leafEncoding)
+	}
+
+	proofs := make([]*merkle.InclusionProof, len(leaves))
+	for i := range leaves {
+		proof, err := tree.GenerateProof(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate proof for leaf %d: %w", i, err)
+		}
+		proofs[i] = proof
+	}
+
+	transactions := make([]*TransactionData, len(txs))
+	for i, tx := range txs {
+		transactions[i] = transactionDataFromRecord(tx)
+	}
+
+	return &ClosedBatchResult{
+		BatchID:       batch.BatchID,
+		BatchType:     batch.BatchType,
+		MerkleRoot:    tree.Root(),
+		MerkleRootHex: tree.RootHex(),
+		TxCount:       len(txs),
+		StartTime:     batch.StartTime,
+		EndTime:       time.Now(),
+		Proofs:        proofs,
+		Transactions:  transactions,
+	}, nil
+}
+
+// transactionDataFromRecord restores the collector's view of a persisted batch transaction
+func transactionDataFromRecord(tx *database.BatchTransaction) *TransactionData {
+	return &TransactionData{
+		AccumTxHash:  tx.AccumTxHash,
+		AccountURL:   tx.AccountURL,
+		TxHash:       tx.TxHash,
+		ChainedProof: tx.ChainedProof,
+		GovProof:     tx.GovProof,
+		GovLevel:     tx.GovLevel.String,
+		IntentType:   tx.IntentType.String,
+		IntentData:   tx.IntentData,
+		UserID:       tx.UserID.String,
+		IntentID:     tx.IntentID.String,
+		Priority:     tx.Priority,
+		Origin:       tx.Origin,
+		OriginTxHash: tx.OriginTxHash.String,
+	}
+}
+
 // SetAnchorCreator sets the anchor creator (for late binding)
 func (p *Processor) SetAnchorCreator(creator AnchorCreator) {
 	p.mu.Lock()
@@ -720,6 +957,19 @@ func (p *Processor) SetAnchorCreator(creator AnchorCreator) {
 	p.anchorCreator = creator
 }
 
+// encodeLeaves builds the Merkle leaves for a batch's transactions in tree order
+func (p *Processor) encodeLeaves(txs []*database.BatchTransaction) ([][]byte, error) {
+	leaves := make([][]byte, len(txs))
+	for i, tx := range txs {
+		leaf, err := p.leafEncoding.EncodeLeaf(tx.TxHash, tx.AccountURL)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", tx.AccumTxHash, err)
+		}
+		leaves[i] = leaf
+	}
+	return leaves, nil
+}
+
 // UpdateChainConfig updates the target chain configuration
 func (p *Processor) UpdateChainConfig(chain, chainID, network, contract string) {
 	p.mu.Lock()
@@ -748,14 +998,24 @@ func (p *Processor) buildProofArtifact(
 		"accum_tx_hash":     tx.AccumTxHash,
 		"account_url":       tx.AccountURL,
 		"merkle_root":       hex.EncodeToString(result.MerkleRoot),
+		"leaf_encoding":     string(p.leafEncoding),
 		"anchor_chain":      p.targetChain,
 		"anchor_tx_hash":    anchorResult.TxHash,
 		"anchor_block":      anchorResult.BlockNumber,
 		"validator_id":      p.validatorID,
 		"proof_version":     database.CurrentProofVersion,
+		"priority":          string(tx.Priority),
 		"created_at":        time.Now().Format(time.RFC3339),
 	}
 
+	// Link synthetic transactions to the user transaction that produced them
+	if tx.Origin != "" {
+		artifact["tx_origin"] = string(tx.Origin)
+	}
+	if tx.OriginTxHash.Valid {
+		artifact["origin_tx_hash"] = tx.OriginTxHash.String
+	}
+
 	// Add chained proof if present
 	if len(tx.ChainedProof) > 0 {
 		artifact["chained_proof"] = json.RawMessage(tx.ChainedProof)
@@ -795,6 +1055,12 @@ func (p *Processor) buildProofArtifact(
 		proofClass = database.ProofClassOnDemand
 	}
 
+	// The proven leaf is the encoded leaf, which equals the tx hash only for raw_tx_hash
+	leafHash := tx.TxHash
+	if leaf, err := hex.DecodeString(inclusionProof.LeafHash); err == nil && len(leaf) == 32 {
+		leafHash = leaf
+	}
+
 	// Build the new proof artifact input
 	batchID := result.BatchID
 	leafIndex := inclusionProof.LeafIndex
@@ -809,7 +1075,7 @@ func (p *Processor) buildProofArtifact(
 		AccountURL:   tx.AccountURL,
 		BatchID:      &batchID,
 		MerkleRoot:   result.MerkleRoot,
-		LeafHash:     tx.TxHash,
+		LeafHash:     leafHash,
 		LeafIndex:    &leafIndex,
 		GovLevel:     govLevelPtr,
 		ProofClass:   proofClass,
@@ -868,6 +1134,52 @@ func (p *Processor) extractProofDataFromResult(result *ClosedBatchResult) (
 	return txProofs, govProofs, govLevels
 }
 
+// buildBatchAnchorRequest builds the anchor request for a closed batch
+func (p *Processor) buildBatchAnchorRequest(result *ClosedBatchResult) *BatchAnchorRequest {
+	// Phase 2: Extract proof data from ClosedBatchResult per HIGH-002, HIGH-003
+	txProofs, govProofs, govLevels := p.extractProofDataFromResult(result)
+
+	return &BatchAnchorRequest{
+		BatchID:          result.BatchID,
+		MerkleRoot:       result.MerkleRoot,
+		TxCount:          result.TxCount,
+		AccumulateHeight: result.AccumulateHeight,
+		AccumulateHash:   result.AccumulateHash,
+		TargetChain:      p.targetChain,
+		ValidatorID:      p.validatorID,
+		Urgent:           result.BatchType == database.BatchTypeOnDemand,
+		// Phase 2 additions: Real proof data
+		BPTRoot:           result.AggregatedBPTRoot,
+		NetworkRootHash:   result.AggregatedNetworkRoot,
+		TransactionProofs: txProofs,
+		GovernanceProofs:  govProofs,
+		GovernanceLevels:  govLevels,
+	}
+}
+
+// EstimateAnchor prices the anchor ProcessClosedBatch would create for a batch, running the
+// same governance proof generation and commitment derivation but submitting nothing.
+// The result is typically a preview (see Collector.PreviewOnDemandBatch) and is enriched
+// in place with governance proofs.
+func (p *Processor) EstimateAnchor(ctx context.Context, result *ClosedBatchResult) (*AnchorCostEstimate, error) {
+	if result == nil {
+		return nil, fmt.Errorf("closed batch result is required")
+	}
+	estimator, ok := p.anchorCreator.(AnchorEstimator)
+	if !ok {
+		return nil, fmt.Errorf("anchor cost estimation not supported by anchor creator")
+	}
+
+	if p.govGenerator != nil && len(result.Transactions) > 0 {
+		if err := p.enrichBatchWithGovernanceProofs(ctx, result); err != nil {
+			// Non-fatal, as when anchoring
+			p.logger.Printf("⚠️ Governance proof generation for estimate failed (non-fatal): %v", err)
+		}
+	}
+
+	return estimator.EstimateBatchAnchor(ctx, p.buildBatchAnchorRequest(result))
+}
+
 // =============================================================================
 // Phase 1: Comprehensive Proof Execution Integration (CRITICAL-001)
 // Per ANCHOR_V3_IMPLEMENTATION_PLAN.md Task 1.3
@@ -945,6 +1257,7 @@ func (p *Processor) buildProofRequestFromBatch(
 	// Get representative transaction hash (first non-empty tx hash)
 	var transactionHash [32]byte
 	var leafHash [32]byte
+	var leafIndex uint64
 	proofHashes := make([][32]byte, 0)
 
 	if len(result.Proofs) > 0 && result.Proofs[0] != nil {
@@ -955,6 +1268,9 @@ func (p *Processor) buildProofRequestFromBatch(
 		if leafHashBytes, err := hex.DecodeString(firstProof.LeafHash); err == nil && len(leafHashBytes) == 32 {
 			copy(leafHash[:], leafHashBytes)
 		}
+		if firstProof.LeafIndex > 0 {
+			leafIndex = uint64(firstProof.LeafIndex)
+		}
 
 		// Convert proof path to [32]byte array
 		for _, node := range firstProof.Path {
@@ -969,7 +1285,11 @@ func (p *Processor) buildProofRequestFromBatch(
 	// If we have transactions, get the first tx hash as representative
 	if len(result.Transactions) > 0 && len(result.Transactions[0].TxHash) == 32 {
 		copy(transactionHash[:], result.Transactions[0].TxHash)
-		copy(leafHash[:], result.Transactions[0].TxHash)
+		if leafHash == [32]byte{} {
+			if leaf, err := p.leafEncoding.EncodeLeaf(result.Transactions[0].TxHash, result.Transactions[0].AccountURL); err == nil {
+				copy(leafHash[:], leaf)
+			}
+		}
 	} else if leafHash == [32]byte{} {
 		// Fallback: use merkle root as representative
 		transactionHash = merkleRoot
@@ -984,6 +1304,7 @@ func (p *Processor) buildProofRequestFromBatch(
 		MerkleRoot:           merkleRoot,
 		ProofHashes:          proofHashes,
 		LeafHash:             leafHash,
+		LeafIndex:            leafIndex,
 		OperationCommitment:  operationCommitment,
 		CrossChainCommitment: crossChainCommitment,
 		GovernanceRoot:       governanceRoot,
@@ -1086,6 +1407,10 @@ func (p *Processor) buildGovernanceProofs(ctx context.Context, result *ClosedBat
 
 	p.logger.Printf("✅ [Phase 2] Generated %d/%d governance proofs in %dms",
 		govResult.SuccessCount, len(result.Transactions), govResult.GenerationTimeMs)
+	if batchProof.Dedup != nil {
+		p.logger.Printf("   Authority work shared: %d authorities, %d snapshots reused",
+			batchProof.Dedup.Authorities, batchProof.Dedup.SnapshotReuses)
+	}
 	p.logger.Printf("   Governance Root: %x...", govResult.GovernanceRoot[:8])
 
 	return govResult, nil
@@ -1148,10 +1473,6 @@ func (p *Processor) enrichBatchWithGovernanceProofs(ctx context.Context, result
 // triggerAnchorSubmittedFirestoreEvent sends anchor submitted events to Firestore for each transaction
 // This enables real-time UI updates for Stage 6 (Ethereum Anchoring)
 func (p *Processor) triggerAnchorSubmittedFirestoreEvent(result *ClosedBatchResult, anchorResult *BatchAnchorResult) {
-	if p.firestoreSyncService == nil {
-		return
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
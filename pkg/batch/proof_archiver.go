@@ -0,0 +1,186 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Archiver - Moves batches and proofs past the retention window out of the hot tables
+//
+// Nothing else prunes anchored batches, so without archival the proof tables grow
+// without bound. On every sweep the archiver archives batches whose anchors all reached
+// finality more than the retention period ago (see database.ArchiveRepository):
+//   - their proofs are stored as archive documents and still served by proof ID
+//   - their batch transactions and proof rows are deleted
+//
+// Batches with an anchor that is not final are left alone: the confirmation tracker
+// still follows them and may yet see a reorg.
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProofArchiveStore is the storage the archiver reads and updates
+// Implemented by database.ArchiveRepository
+type ProofArchiveStore interface {
+	GetArchivableBatches(ctx context.Context, finalBefore time.Time, limit int) ([]uuid.UUID, error)
+	ArchiveBatch(ctx context.Context, batchID uuid.UUID, finalBefore time.Time) (int, bool, error)
+}
+
+// ProofArchiveReport summarizes an archival sweep
+type ProofArchiveReport struct {
+	Archived []uuid.UUID `json:"archived,omitempty"`
+	Proofs   int         `json:"proofs"` // Proofs in archived batches
+	Errors   int         `json:"errors"`
+}
+
+// ProofArchiverConfig holds configuration for the proof archiver
+type ProofArchiverConfig struct {
+	Retention     time.Duration // Time after anchor finality that batches stay in the hot tables
+	CheckInterval time.Duration // How often archivable batches are swept
+	BatchLimit    int           // Batches archived per sweep
+	Logger        *log.Logger
+}
+
+// DefaultProofArchiverConfig returns default configuration
+func DefaultProofArchiverConfig() *ProofArchiverConfig {
+	return &ProofArchiverConfig{
+		Retention:     90 * 24 * time.Hour,
+		CheckInterval: time.Hour,
+		BatchLimit:    100,
+		Logger:        log.New(log.Writer(), "[ProofArchiver] ", log.LstdFlags),
+	}
+}
+
+// ProofArchiver periodically archives batches past the retention window
+type ProofArchiver struct {
+	mu sync.Mutex
+
+	store         ProofArchiveStore
+	retention     time.Duration
+	checkInterval time.Duration
+	batchLimit    int
+	now           func() time.Time
+	logger        *log.Logger
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewProofArchiver creates a new proof archiver
+func NewProofArchiver(store ProofArchiveStore, cfg *ProofArchiverConfig) (*ProofArchiver, error) {
+	if store == nil {
+		return nil, fmt.Errorf("proof archive store cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultProofArchiverConfig()
+	}
+	if cfg.Retention <= 0 {
+		return nil, fmt.Errorf("retention period must be positive")
+	}
+	defaults := DefaultProofArchiverConfig()
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaults.CheckInterval
+	}
+	if cfg.BatchLimit <= 0 {
+		cfg.BatchLimit = defaults.BatchLimit
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = defaults.Logger
+	}
+
+	return &ProofArchiver{
+		store:         store,
+		retention:     cfg.Retention,
+		checkInterval: cfg.CheckInterval,
+		batchLimit:    cfg.BatchLimit,
+		now:           time.Now,
+		logger:        cfg.Logger,
+	}, nil
+}
+
+// Start starts the periodic sweep loop
+func (a *ProofArchiver) Start(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopCh != nil {
+		return
+	}
+	a.stopCh = make(chan struct{})
+	a.doneCh = make(chan struct{})
+
+	go a.run(ctx, a.stopCh, a.doneCh)
+	a.logger.Printf("Started (retention=%s, check=%s, limit=%d)", a.retention, a.checkInterval, a.batchLimit)
+}
+
+// Stop stops the sweep loop
+func (a *ProofArchiver) Stop() {
+	a.mu.Lock()
+	stopCh, doneCh := a.stopCh, a.doneCh
+	a.stopCh, a.doneCh = nil, nil
+	a.mu.Unlock()
+
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	<-doneCh
+}
+
+func (a *ProofArchiver) run(ctx context.Context, stopCh, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	ticker := time.NewTicker(a.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if _, err := a.Sweep(ctx); err != nil {
+				a.logger.Printf("⚠️ Archival sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+// Sweep archives up to the batch limit of batches whose anchors reached finality before
+// the retention window. Failures on individual batches are counted and logged; the
+// batch is retried next sweep.
+func (a *ProofArchiver) Sweep(ctx context.Context) (*ProofArchiveReport, error) {
+	cutoff := a.now().Add(-a.retention)
+	batchIDs, err := a.store.GetArchivableBatches(ctx, cutoff, a.batchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archivable batches: %w", err)
+	}
+
+	report := &ProofArchiveReport{}
+	for _, batchID := range batchIDs {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		proofs, archived, err := a.store.ArchiveBatch(ctx, batchID, cutoff)
+		if err != nil {
+			report.Errors++
+			a.logger.Printf("⚠️ Failed to archive batch %s: %v", batchID, err)
+			continue
+		}
+		if !archived {
+			continue // Archived or reorged meanwhile
+		}
+		report.Archived = append(report.Archived, batchID)
+		report.Proofs += proofs
+	}
+
+	if len(report.Archived) > 0 || report.Errors > 0 {
+		a.logger.Printf("Sweep complete: archived=%d batches, proofs=%d, errors=%d",
+			len(report.Archived), report.Proofs, report.Errors)
+	}
+	return report, nil
+}
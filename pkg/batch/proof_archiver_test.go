@@ -0,0 +1,137 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof Archiver
+// Tests retention cutoff, batch limit and per-batch failure handling
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryArchiveStore keeps archivable batches in memory
+type memoryArchiveStore struct {
+	finalAt  map[uuid.UUID]time.Time
+	proofs   map[uuid.UUID]int
+	failing  map[uuid.UUID]bool
+	archived map[uuid.UUID]bool
+	limits   []int
+}
+
+func (s *memoryArchiveStore) GetArchivableBatches(ctx context.Context, finalBefore time.Time, limit int) ([]uuid.UUID, error) {
+	s.limits = append(s.limits, limit)
+	var ids []uuid.UUID
+	for id, at := range s.finalAt {
+		if !s.archived[id] && at.Before(finalBefore) && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *memoryArchiveStore) ArchiveBatch(ctx context.Context, batchID uuid.UUID, finalBefore time.Time) (int, bool, error) {
+	if s.failing[batchID] {
+		return 0, false, errors.New("archive failed")
+	}
+	if s.archived[batchID] || !s.finalAt[batchID].Before(finalBefore) {
+		return 0, false, nil
+	}
+	s.archived[batchID] = true
+	return s.proofs[batchID], true, nil
+}
+
+func newTestProofArchiver(t *testing.T, store ProofArchiveStore, now time.Time, limit int) *ProofArchiver {
+	t.Helper()
+	archiver, err := NewProofArchiver(store, &ProofArchiverConfig{
+		Retention:  30 * 24 * time.Hour,
+		BatchLimit: limit,
+		Logger:     log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewProofArchiver: %v", err)
+	}
+	archiver.now = func() time.Time { return now }
+	return archiver
+}
+
+func TestProofArchiver_Sweep(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	old, recent, failing := uuid.New(), uuid.New(), uuid.New()
+	store := &memoryArchiveStore{
+		finalAt: map[uuid.UUID]time.Time{
+			old:     now.Add(-31 * 24 * time.Hour),
+			recent:  now.Add(-29 * 24 * time.Hour),
+			failing: now.Add(-40 * 24 * time.Hour),
+		},
+		proofs:   map[uuid.UUID]int{old: 4},
+		failing:  map[uuid.UUID]bool{failing: true},
+		archived: map[uuid.UUID]bool{},
+	}
+	archiver := newTestProofArchiver(t, store, now, 10)
+
+	report, err := archiver.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if len(report.Archived) != 1 || report.Archived[0] != old {
+		t.Errorf("archived = %v, want [%s]", report.Archived, old)
+	}
+	if report.Proofs != 4 {
+		t.Errorf("proofs = %d, want 4", report.Proofs)
+	}
+	if report.Errors != 1 {
+		t.Errorf("errors = %d, want 1", report.Errors)
+	}
+	if store.archived[recent] {
+		t.Error("batch within the retention window was archived")
+	}
+
+	// A second sweep retries the failed batch only
+	report, err = archiver.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("second Sweep: %v", err)
+	}
+	if len(report.Archived) != 0 || report.Errors != 1 {
+		t.Errorf("second sweep archived=%d errors=%d, want 0 and 1", len(report.Archived), report.Errors)
+	}
+}
+
+func TestProofArchiver_BatchLimit(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &memoryArchiveStore{
+		finalAt:  map[uuid.UUID]time.Time{},
+		archived: map[uuid.UUID]bool{},
+	}
+	for i := 0; i < 5; i++ {
+		store.finalAt[uuid.New()] = now.Add(-60 * 24 * time.Hour)
+	}
+	archiver := newTestProofArchiver(t, store, now, 2)
+
+	report, err := archiver.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if len(report.Archived) != 2 {
+		t.Errorf("archived %d batches, want 2", len(report.Archived))
+	}
+	if len(store.limits) != 1 || store.limits[0] != 2 {
+		t.Errorf("store limits = %v, want [2]", store.limits)
+	}
+}
+
+func TestNewProofArchiver_RequiresRetention(t *testing.T) {
+	store := &memoryArchiveStore{}
+	if _, err := NewProofArchiver(store, &ProofArchiverConfig{}); err == nil {
+		t.Error("expected error for zero retention")
+	}
+	if _, err := NewProofArchiver(nil, DefaultProofArchiverConfig()); err == nil {
+		t.Error("expected error for nil store")
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Chain Verifier - Re-checks every link of a stored proof chain in one pass
+//
+// The chain runs from the Accumulate transaction to the external anchor:
+//   - L1: transaction -> BVN root chain anchor (receipt)
+//   - L2: BVN root chain anchor -> DN root chain anchor (receipt)
+//   - L3: DN root chain anchor -> DN self anchor (receipt)
+//   - L4: consensus finality recorded for the DN block
+//   - anchor: batch Merkle inclusion of the transaction under the anchored root
+//
+// Receipts are recomputed with the lite client's receipt verifier, and each layer must
+// start where the previous one ended. The anchor link is checked with the batch Merkle
+// verification used to build inclusion proofs. Consensus app-hash binds need live
+// CometBFT endpoints and are not re-queried; L4 checks the finality data recorded at
+// proof generation.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	chained_proof "github.com/certen/independant-validator/accumulate-lite-client-2/liteclient/proof/working-proof_do_not_edit"
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/merkle"
+	"github.com/google/uuid"
+)
+
+// Links of a proof chain, in order
+const (
+	ChainLinkL1     = "L1"
+	ChainLinkL2     = "L2"
+	ChainLinkL3     = "L3"
+	ChainLinkL4     = "L4"
+	ChainLinkAnchor = "anchor"
+)
+
+// ChainLinkStatus is the outcome of checking one link
+type ChainLinkStatus string
+
+const (
+	ChainLinkPassed  ChainLinkStatus = "passed"
+	ChainLinkFailed  ChainLinkStatus = "failed"
+	ChainLinkMissing ChainLinkStatus = "missing" // No data recorded for the link
+)
+
+// ProofChainStore is the storage the chain verifier reads proofs, layers and anchors from
+type ProofChainStore interface {
+	GetProofByID(ctx context.Context, proofID uuid.UUID) (*database.ProofArtifact, error)
+	GetChainedProofLayers(ctx context.Context, proofID uuid.UUID) ([]database.ChainedProofLayer, error)
+	GetAnchorByBatchID(ctx context.Context, batchID uuid.UUID) (*database.AnchorRecord, error)
+	GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error)
+}
+
+// repositoryChainStore implements ProofChainStore on the database repositories
+type repositoryChainStore struct {
+	repos *database.Repositories
+}
+
+// NewProofChainStore creates a ProofChainStore backed by the database repositories
+func NewProofChainStore(repos *database.Repositories) ProofChainStore {
+	return &repositoryChainStore{repos: repos}
+}
+
+func (s *repositoryChainStore) GetProofByID(ctx context.Context, proofID uuid.UUID) (*database.ProofArtifact, error) {
+	return s.repos.ProofArtifacts.GetProofByID(ctx, proofID)
+}
+
+func (s *repositoryChainStore) GetChainedProofLayers(ctx context.Context, proofID uuid.UUID) ([]database.ChainedProofLayer, error) {
+	return s.repos.ProofArtifacts.GetChainedProofLayers(ctx, proofID)
+}
+
+func (s *repositoryChainStore) GetAnchorByBatchID(ctx context.Context, batchID uuid.UUID) (*database.AnchorRecord, error) {
+	return s.repos.Anchors.GetAnchorByBatchID(ctx, batchID)
+}
+
+func (s *repositoryChainStore) GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error) {
+	return s.repos.Batches.GetTransactionsInBatch(ctx, batchID)
+}
+
+// ChainLinkResult is the outcome of checking one link of a proof chain
+type ChainLinkResult struct {
+	Link        string          `json:"link"`
+	Description string          `json:"description"`
+	Status      ChainLinkStatus `json:"status"`
+	Source      string          `json:"source,omitempty"` // Hash the link starts from
+	Target      string          `json:"target,omitempty"` // Hash the link proves into
+	Error       string          `json:"error,omitempty"`
+}
+
+// ProofChainReport is the per-link result of a full chain verification
+type ProofChainReport struct {
+	ProofID     uuid.UUID         `json:"proof_id"`
+	AccumTxHash string            `json:"accum_tx_hash"`
+	Valid       bool              `json:"valid"` // Every link passed
+	Links       []ChainLinkResult `json:"links"`
+	CheckedAt   time.Time         `json:"checked_at"`
+}
+
+// ProofChainVerifier re-checks the L1 -> L2 -> L3 -> L4 -> anchor chain of stored proofs
+type ProofChainVerifier struct {
+	store ProofChainStore
+	now   func() time.Time
+}
+
+// NewProofChainVerifier creates a new proof chain verifier
+func NewProofChainVerifier(store ProofChainStore) (*ProofChainVerifier, error) {
+	if store == nil {
+		return nil, fmt.Errorf("proof chain store cannot be nil")
+	}
+	return &ProofChainVerifier{store: store, now: time.Now}, nil
+}
+
+// VerifyChain checks every link of a stored proof's chain. Links that fail or have no
+// recorded data are reported individually; an error is returned only when the proof
+// or its records cannot be read.
+func (v *ProofChainVerifier) VerifyChain(ctx context.Context, proofID uuid.UUID) (*ProofChainReport, error) {
+	artifact, err := v.store.GetProofByID(ctx, proofID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proof: %w", err)
+	}
+	if artifact == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProofNotFound, proofID)
+	}
+
+	layers, err := v.store.GetChainedProofLayers(ctx, proofID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chained proof layers: %w", err)
+	}
+	byNumber := make(map[int]*database.ChainedProofLayer, len(layers))
+	for i := range layers {
+		byNumber[layers[i].LayerNumber] = &layers[i]
+	}
+
+	report := &ProofChainReport{
+		ProofID:     proofID,
+		AccumTxHash: artifact.AccumTxHash,
+		CheckedAt:   v.now().UTC(),
+	}
+
+	// L1 starts at the transaction hash; each later layer starts where the previous ended
+	l1 := verifyReceiptLink(ChainLinkL1, "Transaction to BVN", byNumber[1], txHashHex(artifact.AccumTxHash))
+	l2 := verifyReceiptLink(ChainLinkL2, "BVN to DN", byNumber[2], l1.Target)
+	l3 := verifyReceiptLink(ChainLinkL3, "DN to DN self anchor", byNumber[3], l2.Target)
+	report.Links = append(report.Links, l1, l2, l3, verifyFinalityLink(byNumber[3]))
+
+	anchorLink, err := v.verifyAnchorLink(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	report.Links = append(report.Links, anchorLink)
+
+	report.Valid = true
+	for _, link := range report.Links {
+		if link.Status != ChainLinkPassed {
+			report.Valid = false
+		}
+	}
+	return report, nil
+}
+
+// verifyReceiptLink recomputes a layer's receipt from its source through its entries to its
+// target and checks the layer starts at expectedSource (skipped when empty)
+func verifyReceiptLink(link, description string, layer *database.ChainedProofLayer, expectedSource string) ChainLinkResult {
+	result := ChainLinkResult{Link: link, Description: description}
+	if layer == nil {
+		result.Status = ChainLinkMissing
+		result.Error = fmt.Sprintf("no %s layer recorded", link)
+		return result
+	}
+	result.Source = hex.EncodeToString(layer.SourceHash)
+	result.Target = hex.EncodeToString(layer.TargetHash)
+
+	fail := func(format string, args ...interface{}) ChainLinkResult {
+		result.Status = ChainLinkFailed
+		result.Error = fmt.Sprintf(format, args...)
+		return result
+	}
+
+	if len(layer.SourceHash) == 0 || len(layer.TargetHash) == 0 {
+		return fail("layer has no receipt recorded")
+	}
+	receipt := chained_proof.Receipt{
+		Start:   result.Source,
+		Anchor:  result.Target,
+		Entries: make([]chained_proof.ReceiptStep, 0, len(layer.ReceiptEntries)),
+	}
+	for _, entry := range layer.ReceiptEntries {
+		receipt.Entries = append(receipt.Entries, chained_proof.ReceiptStep{
+			Hash:  strings.ToLower(strings.TrimPrefix(entry.Hash, "0x")),
+			Right: entry.Position == string(merkle.Right),
+		})
+	}
+	if err := chained_proof.NewReceiptVerifier(false).ValidateIntegrity(receipt); err != nil {
+		return fail("receipt invalid: %v", err)
+	}
+
+	if expectedSource != "" && result.Source != expectedSource {
+		return fail("receipt starts at %s, expected %s", result.Source, expectedSource)
+	}
+	if len(layer.ReceiptAnchor) > 0 && !bytes.Equal(layer.ReceiptAnchor, layer.TargetHash) {
+		return fail("recorded receipt anchor %x does not match receipt target", layer.ReceiptAnchor)
+	}
+
+	result.Status = ChainLinkPassed
+	return result
+}
+
+// txHashHex normalizes an Accumulate transaction hash to lowercase hex, or returns ""
+// when it is not a 32-byte hash
+func txHashHex(accumTxHash string) string {
+	h := strings.ToLower(strings.TrimPrefix(accumTxHash, "0x"))
+	if b, err := hex.DecodeString(h); err != nil || len(b) != 32 {
+		return ""
+	}
+	return h
+}
+
+// verifyFinalityLink checks the consensus finality recorded with the L3 layer
+func verifyFinalityLink(l3 *database.ChainedProofLayer) ChainLinkResult {
+	result := ChainLinkResult{Link: ChainLinkL4, Description: "DN consensus finality"}
+	if l3 == nil {
+		result.Status = ChainLinkMissing
+		result.Error = "no L3 layer recorded"
+		return result
+	}
+	result.Source = hex.EncodeToString(l3.TargetHash)
+
+	switch {
+	case l3.DNBlockHeight == nil || *l3.DNBlockHeight <= 0:
+		result.Status = ChainLinkFailed
+		result.Error = "no DN consensus height recorded"
+	case l3.ConsensusTimestamp == nil || l3.ConsensusTimestamp.IsZero():
+		result.Status = ChainLinkFailed
+		result.Error = "no consensus timestamp recorded"
+	default:
+		result.Status = ChainLinkPassed
+		result.Target = fmt.Sprintf("dn block %d", *l3.DNBlockHeight)
+	}
+	return result
+}
+
+// verifyAnchorLink checks the transaction's Merkle inclusion under the root anchored for its batch
+func (v *ProofChainVerifier) verifyAnchorLink(ctx context.Context, artifact *database.ProofArtifact) (ChainLinkResult, error) {
+	result := ChainLinkResult{Link: ChainLinkAnchor, Description: "Batch Merkle root to external anchor"}
+	if artifact.BatchID == nil {
+		result.Status = ChainLinkMissing
+		result.Error = "proof has not been batched"
+		return result, nil
+	}
+
+	anchor, err := v.store.GetAnchorByBatchID(ctx, *artifact.BatchID)
+	if err != nil {
+		return result, fmt.Errorf("failed to get anchor for batch %s: %w", artifact.BatchID, err)
+	}
+	if anchor == nil || anchor.AnchorTxHash == "" {
+		result.Status = ChainLinkMissing
+		result.Error = fmt.Sprintf("batch %s has not been anchored", artifact.BatchID)
+		return result, nil
+	}
+	result.Target = anchor.AnchorTxHash
+
+	txs, err := v.store.GetTransactionsInBatch(ctx, *artifact.BatchID)
+	if err != nil {
+		return result, fmt.Errorf("failed to get batch transactions: %w", err)
+	}
+
+	fail := func(format string, args ...interface{}) (ChainLinkResult, error) {
+		result.Status = ChainLinkFailed
+		result.Error = fmt.Sprintf(format, args...)
+		return result, nil
+	}
+
+	tx := findProofTransaction(artifact, txs)
+	if tx == nil {
+		return fail("transaction not found in batch %s", artifact.BatchID)
+	}
+	leafHash := artifact.LeafHash
+	if len(leafHash) == 0 {
+		leafHash = tx.TxHash
+	}
+	result.Source = hex.EncodeToString(leafHash)
+
+	if len(artifact.MerkleRoot) > 0 && !bytes.Equal(artifact.MerkleRoot, anchor.MerkleRoot) {
+		return fail("proof merkle root %x does not match anchored root %x", artifact.MerkleRoot, anchor.MerkleRoot)
+	}
+
+	inclusion := &merkle.InclusionProof{}
+	if len(tx.MerklePath) > 0 {
+		if err := json.Unmarshal(tx.MerklePath, &inclusion.Path); err != nil {
+			return fail("invalid stored merkle path: %v", err)
+		}
+	}
+	ok, err := merkle.VerifyProof(leafHash, inclusion, anchor.MerkleRoot)
+	if err != nil {
+		return fail("merkle inclusion invalid: %v", err)
+	}
+	if !ok {
+		return fail("merkle path does not reach anchored root %x", anchor.MerkleRoot)
+	}
+
+	result.Status = ChainLinkPassed
+	return result, nil
+}
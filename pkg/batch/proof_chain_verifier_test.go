@@ -0,0 +1,174 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof Chain Verifier
+// Tests receipt recomputation, layer linkage, finality data and the anchor inclusion link
+
+package batch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/merkle"
+	"github.com/google/uuid"
+)
+
+// stubChainStore holds a single proof with its layers and batch
+type stubChainStore struct {
+	artifact *database.ProofArtifact
+	layers   []database.ChainedProofLayer
+	anchor   *database.AnchorRecord
+	txs      []*database.BatchTransaction
+}
+
+func (s *stubChainStore) GetProofByID(ctx context.Context, proofID uuid.UUID) (*database.ProofArtifact, error) {
+	if s.artifact == nil || s.artifact.ProofID != proofID {
+		return nil, nil
+	}
+	return s.artifact, nil
+}
+
+func (s *stubChainStore) GetChainedProofLayers(ctx context.Context, proofID uuid.UUID) ([]database.ChainedProofLayer, error) {
+	return s.layers, nil
+}
+
+func (s *stubChainStore) GetAnchorByBatchID(ctx context.Context, batchID uuid.UUID) (*database.AnchorRecord, error) {
+	return s.anchor, nil
+}
+
+func (s *stubChainStore) GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*database.BatchTransaction, error) {
+	return s.txs, nil
+}
+
+// receiptLayer builds a layer whose single-step receipt proves source into a new target
+func receiptLayer(number int, source []byte, sibling string) database.ChainedProofLayer {
+	siblingHash := sha256Sum(sibling)
+	target := sha256.Sum256(append(append([]byte{}, source...), siblingHash...))
+	return database.ChainedProofLayer{
+		LayerNumber:    number,
+		SourceHash:     source,
+		TargetHash:     target[:],
+		ReceiptEntries: []database.MerklePathNode{{Hash: hex.EncodeToString(siblingHash), Position: "right"}},
+	}
+}
+
+// newChainFixture builds a proof whose L1-L3 layers chain from its transaction hash and
+// whose transaction is the second leaf of an anchored 3-transaction batch
+func newChainFixture(t *testing.T) *stubChainStore {
+	t.Helper()
+	batchID := uuid.New()
+	txHash := sha256Sum("tx-1")
+
+	l1 := receiptLayer(1, txHash, "bvn-sibling")
+	l2 := receiptLayer(2, l1.TargetHash, "dn-sibling")
+	l3 := receiptLayer(3, l2.TargetHash, "dn-self-sibling")
+	height := int64(1200)
+	finalized := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	l3.DNBlockHeight = &height
+	l3.ConsensusTimestamp = &finalized
+
+	leaves := [][]byte{sha256Sum("tx-0"), txHash, sha256Sum("tx-2")}
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		t.Fatalf("BuildTree failed: %v", err)
+	}
+	inclusion, _ := tree.GenerateProof(1)
+	pathJSON, _ := json.Marshal(inclusion.Path)
+
+	return &stubChainStore{
+		artifact: &database.ProofArtifact{
+			ProofID:     uuid.New(),
+			AccumTxHash: hex.EncodeToString(txHash),
+			BatchID:     &batchID,
+			MerkleRoot:  tree.Root(),
+			LeafHash:    txHash,
+		},
+		layers: []database.ChainedProofLayer{l1, l2, l3},
+		anchor: &database.AnchorRecord{BatchID: batchID, AnchorTxHash: "0xanchor", MerkleRoot: tree.Root()},
+		txs: []*database.BatchTransaction{
+			{ID: 1, AccumTxHash: "acc-0", TreeIndex: 0, TxHash: leaves[0]},
+			{ID: 2, AccumTxHash: hex.EncodeToString(txHash), TreeIndex: 1, TxHash: txHash, MerklePath: pathJSON},
+			{ID: 3, AccumTxHash: "acc-2", TreeIndex: 2, TxHash: leaves[2]},
+		},
+	}
+}
+
+func chainLinkStatuses(report *ProofChainReport) map[string]ChainLinkStatus {
+	statuses := make(map[string]ChainLinkStatus)
+	for _, link := range report.Links {
+		statuses[link.Link] = link.Status
+	}
+	return statuses
+}
+
+func TestProofChainVerifier_ValidChain(t *testing.T) {
+	store := newChainFixture(t)
+	verifier, err := NewProofChainVerifier(store)
+	if err != nil {
+		t.Fatalf("NewProofChainVerifier failed: %v", err)
+	}
+
+	report, err := verifier.VerifyChain(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("VerifyChain failed: %v", err)
+	}
+	if !report.Valid || len(report.Links) != 5 {
+		t.Fatalf("expected 5 passing links, got %+v", report.Links)
+	}
+	for _, link := range report.Links {
+		if link.Status != ChainLinkPassed {
+			t.Errorf("link %s: %s (%s)", link.Link, link.Status, link.Error)
+		}
+	}
+}
+
+func TestProofChainVerifier_BrokenLinks(t *testing.T) {
+	store := newChainFixture(t)
+	// L2 proves a different BVN anchor than L1 produced, L3 has a forged receipt step
+	// and the batch path is tampered
+	store.layers[1] = receiptLayer(2, sha256Sum("other-anchor"), "dn-sibling")
+	store.layers[2].ReceiptEntries[0].Hash = hex.EncodeToString(sha256Sum("forged"))
+	store.txs[1].MerklePath, _ = json.Marshal([]merkle.ProofNode{{Hash: hex.EncodeToString(sha256Sum("x")), Position: merkle.Right}})
+
+	verifier, _ := NewProofChainVerifier(store)
+	report, err := verifier.VerifyChain(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("VerifyChain failed: %v", err)
+	}
+	statuses := chainLinkStatuses(report)
+	if report.Valid || statuses[ChainLinkL1] != ChainLinkPassed || statuses[ChainLinkL2] != ChainLinkFailed ||
+		statuses[ChainLinkL3] != ChainLinkFailed || statuses[ChainLinkL4] != ChainLinkPassed || statuses[ChainLinkAnchor] != ChainLinkFailed {
+		t.Errorf("unexpected link statuses %v", statuses)
+	}
+}
+
+func TestProofChainVerifier_MissingLinks(t *testing.T) {
+	store := newChainFixture(t)
+	store.layers = store.layers[:1]
+	store.artifact.BatchID = nil
+
+	verifier, _ := NewProofChainVerifier(store)
+	report, err := verifier.VerifyChain(context.Background(), store.artifact.ProofID)
+	if err != nil {
+		t.Fatalf("VerifyChain failed: %v", err)
+	}
+	statuses := chainLinkStatuses(report)
+	if report.Valid || statuses[ChainLinkL1] != ChainLinkPassed {
+		t.Errorf("unexpected link statuses %v", statuses)
+	}
+	for _, link := range []string{ChainLinkL2, ChainLinkL3, ChainLinkL4, ChainLinkAnchor} {
+		if statuses[link] != ChainLinkMissing {
+			t.Errorf("link %s: expected missing, got %s", link, statuses[link])
+		}
+	}
+
+	if _, err := verifier.VerifyChain(context.Background(), uuid.New()); !errors.Is(err, ErrProofNotFound) {
+		t.Errorf("expected ErrProofNotFound, got %v", err)
+	}
+}
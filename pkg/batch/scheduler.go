@@ -7,12 +7,21 @@
 // - Runs a background timer for on-cadence batches
 // - Triggers batch closing when timer fires or batch is full
 // - Coordinates with the batch processor for anchoring
+//
+// Close-time staggering: validators see the same transactions at about the same time, so
+// identical timers make them all close batches and anchor simultaneously. Each batch closes
+// at start + interval - phaseOffset - jitter, where phaseOffset is derived from the validator
+// ID (stable across restarts) and jitter is drawn per batch. Both shorten the window, so
+// batches never stay open longer than the configured interval.
 
 package batch
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"log"
+	"math/rand"
 	"sync"
 	"time"
 )
@@ -40,6 +49,9 @@ type Scheduler struct {
 	// Configuration
 	interval time.Duration // Batch interval (~15 min)
 	checkInterval time.Duration // How often to check (1 min)
+	phaseOffset time.Duration // Deterministic per-validator offset subtracted from the interval
+	maxJitter   time.Duration // Upper bound of the random per-batch offset
+	jitterFn    func(max time.Duration) time.Duration
 
 	// State
 	state     SchedulerState
@@ -61,6 +73,11 @@ type SchedulerConfig struct {
 	Callback      BatchReadyCallback // Called when batch is ready
 	GetAccumState func() (int64, string) // Gets current Accumulate state
 	Logger        *log.Logger
+
+	// Close-time staggering (zero disables)
+	ValidatorID string        // Seeds the per-validator phase offset
+	PhaseSpread time.Duration // Phase offsets are spread over [0, PhaseSpread)
+	MaxJitter   time.Duration // Random per-batch offset in [0, MaxJitter)
 }
 
 // DefaultSchedulerConfig returns default configuration
@@ -93,36 +110,43 @@ func NewScheduler(collector *Collector, cfg *SchedulerConfig) (*Scheduler, error
 		callback:      cfg.Callback,
 		interval:      cfg.Interval,
 		checkInterval: cfg.CheckInterval,
+		phaseOffset:   ValidatorPhaseOffset(cfg.ValidatorID, cfg.PhaseSpread),
+		maxJitter:     cfg.MaxJitter,
+		jitterFn:      randomJitter,
 		state:         SchedulerStateStopped,
 		getAccumState: cfg.GetAccumState,
 		logger:        cfg.Logger,
 	}, nil
 }
 
-// Start begins the scheduler
+// Start begins the scheduler. The close loop stops when ctx is cancelled or Stop is
+// called, so no batch is closed after shutdown has begun.
 func (s *Scheduler) Start(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.state == SchedulerStateRunning {
-		return nil // Already running
+	if s.state != SchedulerStateStopped {
+		return nil // Already running or paused
 	}
 
 	s.stopCh = make(chan struct{})
 	s.doneCh = make(chan struct{})
 	s.state = SchedulerStateRunning
 
-	go s.run(ctx)
+	go s.run(ctx, s.stopCh, s.doneCh)
 
-	s.logger.Printf("[ON-CADENCE] Scheduler started (interval=%s, check=%s)", s.interval, s.checkInterval)
+	s.logger.Printf("[ON-CADENCE] Scheduler started (interval=%s, check=%s, phase_offset=%s, max_jitter=%s)",
+		s.interval, s.checkInterval, s.phaseOffset, s.maxJitter)
 	return nil
 }
 
-// Stop stops the scheduler
+// Stop stops the scheduler and waits for the close loop to exit
 func (s *Scheduler) Stop() error {
 	s.mu.Lock()
-	if s.state != SchedulerStateRunning {
+	if s.state == SchedulerStateStopped {
 		s.mu.Unlock()
+		// The loop may still be returning after its context was cancelled
+		s.Wait()
 		return nil
 	}
 
@@ -131,12 +155,23 @@ func (s *Scheduler) Stop() error {
 	s.mu.Unlock()
 
 	// Wait for run loop to finish
-	<-s.doneCh
+	s.Wait()
 
 	s.logger.Println("[ON-CADENCE] Scheduler stopped")
 	return nil
 }
 
+// Wait blocks until the close loop has exited. Returns immediately if the scheduler
+// was never started.
+func (s *Scheduler) Wait() {
+	s.mu.RLock()
+	doneCh := s.doneCh
+	s.mu.RUnlock()
+	if doneCh != nil {
+		<-doneCh
+	}
+}
+
 // Pause temporarily pauses the scheduler
 func (s *Scheduler) Pause() {
 	s.mu.Lock()
@@ -164,90 +199,108 @@ func (s *Scheduler) State() SchedulerState {
 	return s.state
 }
 
-// run is the main scheduler loop
-func (s *Scheduler) run(ctx context.Context) {
-	defer close(s.doneCh)
+// run is the main scheduler loop. It is handed its channels so a restart after
+// cancellation cannot swap them out from under a loop that is still returning.
+func (s *Scheduler) run(ctx context.Context, stopCh <-chan struct{}, doneCh chan struct{}) {
+	defer close(doneCh)
 
 	ticker := time.NewTicker(s.checkInterval)
 	defer ticker.Stop()
 
-	// Track when the current batch was opened
-	var batchStartTime time.Time
+	// Track when the current batch was opened and when it is due to close
+	var batchStartTime, closeAt time.Time
 	hasBatch := false
 
+	// Fires at closeAt so staggered close times are not rounded to the check interval
+	closeTimer := time.NewTimer(time.Hour)
+	closeTimer.Stop()
+	defer closeTimer.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
+			s.mu.Lock()
+			s.state = SchedulerStateStopped
+			s.mu.Unlock()
 			s.logger.Println("[ON-CADENCE] Scheduler context cancelled")
 			return
 
-		case <-s.stopCh:
+		case <-stopCh:
 			return
 
 		case <-ticker.C:
-			s.mu.RLock()
-			state := s.state
-			s.mu.RUnlock()
+		case <-closeTimer.C:
+		}
 
-			if state != SchedulerStateRunning {
-				continue
-			}
+		s.mu.RLock()
+		state := s.state
+		s.mu.RUnlock()
 
-			// Check if we have a pending batch
-			info := s.collector.GetOnCadenceBatchInfo()
-			if info == nil {
-				hasBatch = false
-				continue
-			}
+		if state != SchedulerStateRunning {
+			continue
+		}
 
-			if !hasBatch {
-				batchStartTime = info.StartTime
-				hasBatch = true
-				expectedCompletion := info.StartTime.Add(s.interval)
-				s.logger.Printf("[ON-CADENCE] Tracking batch %s (started %s ago, expected completion: %s)",
-					info.BatchID, time.Since(info.StartTime).Round(time.Second),
-					expectedCompletion.Format("15:04:05"))
-			}
+		// Release transactions held back by account quotas whose window has rolled over
+		if released := s.collector.ReleaseQueuedTransactions(ctx); released > 0 {
+			s.logger.Printf("[ON-CADENCE] Released %d quota-queued transactions", released)
+		}
 
-			// Check if batch should be closed
-			shouldClose := false
-			reason := ""
+		// Check if we have a pending batch
+		info := s.collector.GetOnCadenceBatchInfo()
+		if info == nil {
+			hasBatch = false
+			continue
+		}
 
-			// Check timeout
-			if time.Since(batchStartTime) >= s.interval {
-				shouldClose = true
-				reason = "timeout"
-			}
+		if !hasBatch || !info.StartTime.Equal(batchStartTime) {
+			batchStartTime = info.StartTime
+			hasBatch = true
+			closeAt = s.batchCloseTime(info.StartTime)
+			closeTimer.Stop()
+			closeTimer.Reset(time.Until(closeAt))
+			s.logger.Printf("[ON-CADENCE] Tracking batch %s (started %s ago, expected completion: %s)",
+				info.BatchID, time.Since(info.StartTime).Round(time.Second),
+				closeAt.Format("15:04:05"))
+		}
 
-			// Check if collector says batch is ready
-			if s.collector.ShouldCloseOnCadenceBatch() {
-				shouldClose = true
-				if reason == "" {
-					reason = "size limit"
-				}
+		// Check if batch should be closed
+		shouldClose := false
+		reason := ""
+
+		// Check timeout (staggered close time)
+		if !time.Now().Before(closeAt) {
+			shouldClose = true
+			reason = "timeout"
+		}
+
+		// Check if collector says batch is ready
+		if s.collector.ShouldCloseOnCadenceBatch() {
+			shouldClose = true
+			if reason == "" {
+				reason = "size limit"
 			}
+		}
 
-			if shouldClose && info.TxCount > 0 {
-				s.logger.Printf("[ON-CADENCE] Closing batch %s (reason=%s, txs=%d, age=%s, price_tier=$0.05/proof)",
-					info.BatchID, reason, info.TxCount, time.Since(batchStartTime).Round(time.Second))
+		if shouldClose && info.TxCount > 0 {
+			s.logger.Printf("[ON-CADENCE] Closing batch %s (reason=%s, txs=%d, age=%s, price_tier=$0.05/proof)",
+				info.BatchID, reason, info.TxCount, time.Since(batchStartTime).Round(time.Second))
 
-				// Get current Accumulate state
-				height, hash := s.getAccumState()
+			// Get current Accumulate state
+			height, hash := s.getAccumState()
 
-				// Close the batch
-				result, err := s.collector.CloseOnCadenceBatch(ctx, height, hash)
-				if err != nil {
-					s.logger.Printf("[ON-CADENCE] Failed to close batch: %v", err)
-					continue
-				}
+			// Close the batch
+			result, err := s.collector.CloseOnCadenceBatch(ctx, height, hash)
+			if err != nil {
+				s.logger.Printf("[ON-CADENCE] Failed to close batch: %v", err)
+				continue
+			}
 
-				hasBatch = false
+			hasBatch = false
 
-				// Call the callback if set
-				if s.callback != nil && result != nil {
-					if err := s.callback(ctx, result); err != nil {
-						s.logger.Printf("[ON-CADENCE] Batch callback failed: %v", err)
-					}
+			// Call the callback if set
+			if s.callback != nil && result != nil {
+				if err := s.callback(ctx, result); err != nil {
+					s.logger.Printf("[ON-CADENCE] Batch callback failed: %v", err)
 				}
 			}
 		}
@@ -307,3 +360,45 @@ func (s *Scheduler) SetInterval(d time.Duration) {
 	s.interval = d
 	s.logger.Printf("[ON-CADENCE] Batch interval updated to %s", d)
 }
+
+// batchCloseTime returns when a batch started at start is due to close
+func (s *Scheduler) batchCloseTime(start time.Time) time.Time {
+	s.mu.RLock()
+	interval, offset, maxJitter, jitterFn := s.interval, s.phaseOffset, s.maxJitter, s.jitterFn
+	s.mu.RUnlock()
+
+	window := interval - offset
+	if maxJitter > 0 && jitterFn != nil {
+		window -= jitterFn(maxJitter)
+	}
+	// Never close sooner than a tenth of the interval, however large the offsets
+	if minWindow := interval / 10; window < minWindow {
+		window = minWindow
+	}
+	return start.Add(window)
+}
+
+// GetPhaseOffset returns this validator's deterministic batch-close phase offset
+func (s *Scheduler) GetPhaseOffset() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.phaseOffset
+}
+
+// ValidatorPhaseOffset derives a stable offset in [0, spread) from a validator ID,
+// so each validator closes batches at a different point without coordination
+func ValidatorPhaseOffset(validatorID string, spread time.Duration) time.Duration {
+	if spread <= 0 || validatorID == "" {
+		return 0
+	}
+	sum := sha256.Sum256([]byte(validatorID))
+	return time.Duration(binary.BigEndian.Uint64(sum[:8]) % uint64(spread))
+}
+
+// randomJitter returns a random duration in [0, max)
+func randomJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
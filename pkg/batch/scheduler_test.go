@@ -0,0 +1,135 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Batch Scheduler
+// Tests per-validator phase offsets, staggered batch close times and shutdown on
+// context cancellation
+
+package batch
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestValidatorPhaseOffset(t *testing.T) {
+	spread := 2 * time.Minute
+
+	a := ValidatorPhaseOffset("validator-1", spread)
+	if a != ValidatorPhaseOffset("validator-1", spread) {
+		t.Error("phase offset must be deterministic for the same validator")
+	}
+
+	seen := make(map[time.Duration]bool)
+	for _, id := range []string{"validator-1", "validator-2", "validator-3", "validator-4"} {
+		offset := ValidatorPhaseOffset(id, spread)
+		if offset < 0 || offset >= spread {
+			t.Errorf("offset %s for %s outside [0, %s)", offset, id, spread)
+		}
+		seen[offset] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected different validators to get different offsets")
+	}
+
+	if ValidatorPhaseOffset("validator-1", 0) != 0 || ValidatorPhaseOffset("", spread) != 0 {
+		t.Error("expected zero offset when staggering is disabled or the validator ID is empty")
+	}
+}
+
+func TestBatchCloseTime(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := &Scheduler{
+		interval:    15 * time.Minute,
+		phaseOffset: 40 * time.Second,
+		maxJitter:   30 * time.Second,
+		jitterFn:    func(max time.Duration) time.Duration { return max / 2 },
+	}
+
+	expected := start.Add(15*time.Minute - 40*time.Second - 15*time.Second)
+	if got := s.batchCloseTime(start); !got.Equal(expected) {
+		t.Errorf("batchCloseTime() = %s, expected %s", got, expected)
+	}
+
+	// Without staggering the batch closes at the full interval
+	s.phaseOffset, s.maxJitter = 0, 0
+	if got := s.batchCloseTime(start); !got.Equal(start.Add(15 * time.Minute)) {
+		t.Errorf("batchCloseTime() without staggering = %s, expected %s", got, start.Add(15*time.Minute))
+	}
+
+	// Offsets larger than the interval are floored at a tenth of it
+	s.phaseOffset = time.Hour
+	if got := s.batchCloseTime(start); !got.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("batchCloseTime() with oversized offset = %s, expected %s", got, start.Add(90*time.Second))
+	}
+}
+
+func TestRandomJitterBounds(t *testing.T) {
+	if randomJitter(0) != 0 {
+		t.Error("expected zero jitter when disabled")
+	}
+	for i := 0; i < 100; i++ {
+		if j := randomJitter(time.Second); j < 0 || j >= time.Second {
+			t.Fatalf("jitter %s outside [0, 1s)", j)
+		}
+	}
+}
+
+func TestSchedulerStopsOnContextCancel(t *testing.T) {
+	collector, err := NewCollector(&database.Repositories{}, nil)
+	if err != nil {
+		t.Fatalf("NewCollector: %v", err)
+	}
+	s, err := NewScheduler(collector, &SchedulerConfig{
+		Interval:      time.Hour,
+		CheckInterval: time.Millisecond,
+		Logger:        log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewScheduler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := s.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond) // Let the loop tick a few times
+	cancel()
+
+	waited := make(chan struct{})
+	go func() {
+		s.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler loop still running after context cancellation")
+	}
+	if s.State() != SchedulerStateStopped {
+		t.Errorf("expected state %s after cancellation, got %s", SchedulerStateStopped, s.State())
+	}
+
+	// Stop after cancellation returns without blocking, and the scheduler can be restarted
+	if err := s.Stop(); err != nil {
+		t.Fatalf("Stop after cancellation: %v", err)
+	}
+	if err := s.Start(context.Background()); err != nil || s.State() != SchedulerStateRunning {
+		t.Fatalf("expected restart to run, got state %s (err %v)", s.State(), err)
+	}
+	if err := s.Stop(); err != nil || s.State() != SchedulerStateStopped {
+		t.Errorf("expected Stop to stop the restarted loop, got state %s (err %v)", s.State(), err)
+	}
+}
+
+func TestSchedulerWaitWithoutStart(t *testing.T) {
+	s := &Scheduler{state: SchedulerStateStopped}
+	s.Wait() // Must not block
+	if err := s.Stop(); err != nil {
+		t.Errorf("Stop on a never started scheduler: %v", err)
+	}
+}
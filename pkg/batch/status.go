@@ -11,6 +11,7 @@
 package batch
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/certen/independant-validator/pkg/database"
@@ -34,7 +35,7 @@ const (
 	// Default batch interval for on-cadence batches
 	DefaultBatchInterval = 15 * time.Minute
 
-	// Grace period buffer before flagging on-cadence batch as potentially stalled
+	// Default grace period buffer before flagging on-cadence batch as potentially stalled
 	OnCadenceGracePeriod = 5 * time.Minute
 )
 
@@ -66,6 +67,9 @@ func GetStatusMessage(batchType database.BatchType, status database.BatchStatus)
 	case database.BatchStatusFailed:
 		return "Anchoring failed. This batch may be retried or requires investigation."
 
+	case database.BatchStatusAbandoned:
+		return "On-demand batch was not anchored in time and has been abandoned. Metered usage was refunded."
+
 	case database.BatchStatusWaitingForBatch:
 		return "Transaction added to on-cadence batch. Waiting for batch window to close (~15 minutes)."
 
@@ -159,10 +163,14 @@ func GetBatchStatusInfo(
 // IsBatchStalled checks if a batch appears to be stalled beyond expected delays
 // For on-cadence: stalled if pending > interval + grace period
 // For on-demand: stalled if pending > 2 minutes
-func IsBatchStalled(batchType database.BatchType, status database.BatchStatus, age time.Duration, batchInterval time.Duration) bool {
+// A non-positive interval or grace period falls back to the default
+func IsBatchStalled(batchType database.BatchType, status database.BatchStatus, age time.Duration, batchInterval, gracePeriod time.Duration) bool {
 	if batchInterval <= 0 {
 		batchInterval = DefaultBatchInterval
 	}
+	if gracePeriod <= 0 {
+		gracePeriod = OnCadenceGracePeriod
+	}
 
 	if status == database.BatchStatusFailed {
 		return true
@@ -171,7 +179,7 @@ func IsBatchStalled(batchType database.BatchType, status database.BatchStatus, a
 	if status == database.BatchStatusPending {
 		if batchType == database.BatchTypeOnCadence {
 			// On-cadence: stalled if exceeds interval + grace period
-			return age > (batchInterval + OnCadenceGracePeriod)
+			return age > (batchInterval + gracePeriod)
 		}
 		// On-demand: stalled if exceeds 2 minutes
 		return age > 2*time.Minute
@@ -197,10 +205,12 @@ type BatchHealthStatus struct {
 }
 
 // GetBatchSystemHealth returns the overall health status of the batch system
+// An on-cadence batch is reported stalled once its age exceeds batchInterval + gracePeriod
 func GetBatchSystemHealth(
 	onCadenceInfo *BatchInfo,
 	onDemandInfo *BatchInfo,
 	batchInterval time.Duration,
+	gracePeriod time.Duration,
 ) *BatchHealthStatus {
 	health := &BatchHealthStatus{
 		OverallStatus:        "healthy",
@@ -221,7 +231,7 @@ func GetBatchSystemHealth(
 		health.OnCadencePending = true
 		health.OnCadenceStatus = "pending"
 
-		if IsBatchStalled(database.BatchTypeOnCadence, database.BatchStatusPending, onCadenceInfo.Age, batchInterval) {
+		if IsBatchStalled(database.BatchTypeOnCadence, database.BatchStatusPending, onCadenceInfo.Age, batchInterval, gracePeriod) {
 			health.OnCadenceStatus = "stalled"
 			health.OnCadenceDelayNormal = false
 			health.OverallStatus = "degraded"
@@ -239,7 +249,7 @@ func GetBatchSystemHealth(
 		health.OnDemandPending = true
 		health.OnDemandStatus = "pending"
 
-		if IsBatchStalled(database.BatchTypeOnDemand, database.BatchStatusPending, onDemandInfo.Age, batchInterval) {
+		if IsBatchStalled(database.BatchTypeOnDemand, database.BatchStatusPending, onDemandInfo.Age, batchInterval, gracePeriod) {
 			health.OnDemandStatus = "stalled"
 			health.OverallStatus = "degraded"
 		}
@@ -249,8 +259,8 @@ func GetBatchSystemHealth(
 	if health.OnCadencePending && health.OnCadenceDelayNormal {
 		remaining := batchInterval - onCadenceInfo.Age
 		if remaining > 0 {
-			health.StatusMessage = "On-cadence batch collecting transactions. This is normal operation. " +
-				"Delays up to 15 minutes are expected for cost-efficient batching."
+			health.StatusMessage = fmt.Sprintf("On-cadence batch collecting transactions. This is normal operation. "+
+				"Delays up to %s are expected for cost-efficient batching.", batchInterval)
 		} else {
 			health.StatusMessage = "On-cadence batch closing. Anchor transaction will be submitted shortly."
 		}
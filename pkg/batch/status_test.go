@@ -0,0 +1,45 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Batch Status Helpers
+// Tests stall detection with configured on-cadence intervals and grace periods
+
+package batch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestIsBatchStalled_ConfiguredInterval(t *testing.T) {
+	cases := []struct {
+		age      time.Duration
+		interval time.Duration
+		grace    time.Duration
+		stalled  bool
+	}{
+		{age: 6 * time.Minute, interval: 5 * time.Minute, grace: 2 * time.Minute, stalled: false},
+		{age: 8 * time.Minute, interval: 5 * time.Minute, grace: 2 * time.Minute, stalled: true},
+		{age: 34 * time.Minute, interval: 30 * time.Minute, grace: 5 * time.Minute, stalled: false},
+		{age: 19 * time.Minute, interval: 0, grace: 0, stalled: false}, // Defaults: 15m + 5m
+		{age: 21 * time.Minute, interval: 0, grace: 0, stalled: true},
+	}
+	for _, tc := range cases {
+		got := IsBatchStalled(database.BatchTypeOnCadence, database.BatchStatusPending, tc.age, tc.interval, tc.grace)
+		if got != tc.stalled {
+			t.Errorf("age=%s interval=%s grace=%s: stalled=%v, expected %v", tc.age, tc.interval, tc.grace, got, tc.stalled)
+		}
+	}
+}
+
+func TestGetBatchSystemHealth_GracePeriod(t *testing.T) {
+	onCadence := &BatchInfo{BatchType: database.BatchTypeOnCadence, TxCount: 3, Age: 7 * time.Minute}
+
+	if health := GetBatchSystemHealth(onCadence, nil, 5*time.Minute, 5*time.Minute); health.OnCadenceStatus != "closing" || health.OverallStatus != "healthy" {
+		t.Errorf("within grace: %+v", health)
+	}
+	if health := GetBatchSystemHealth(onCadence, nil, 5*time.Minute, time.Minute); health.OnCadenceStatus != "stalled" || health.OverallStatus != "degraded" {
+		t.Errorf("beyond grace: %+v", health)
+	}
+}
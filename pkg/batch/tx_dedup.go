@@ -0,0 +1,173 @@
+// Copyright 2025 Certen Protocol
+//
+// Transaction Dedup - Rejects transactions that are already batched
+//
+// Intent discovery re-delivers Accumulate transactions when its cursor rewinds after a
+// restart. Without a guard the same transaction would land in a second batch and be
+// proven and anchored twice. Before a transaction is added the collector checks:
+//   - a bounded in-memory cache of recently batched transactions, keyed on the
+//     canonical (lower-case, unprefixed) Accumulate transaction hash
+//   - the database, for transactions batched before a restart or evicted from the cache
+//
+// A transaction in any batch that is not abandoned is rejected with
+// ErrTransactionAlreadyBatched, which callers treat as already handled. Cache entries
+// expire well before on-demand batches are abandoned, so abandoned transactions can be
+// submitted again.
+
+package batch
+
+import (
+	"container/list"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// Transaction dedup cache defaults
+const (
+	DefaultTxDedupCacheSize = 10000
+	DefaultTxDedupCacheTTL  = time.Hour
+)
+
+// TxDedupStore finds the batch already holding a transaction
+// Implemented by database.BatchRepository
+type TxDedupStore interface {
+	GetTransactionBatchStatus(ctx context.Context, accumTxHash string) (uuid.UUID, database.BatchStatus, error)
+}
+
+// txDedupEntry is a recently batched transaction
+type txDedupEntry struct {
+	hash      string
+	batchID   uuid.UUID
+	expiresAt time.Time
+}
+
+// txDeduplicator is a bounded LRU of batched transactions backed by the database
+type txDeduplicator struct {
+	mu       sync.Mutex
+	store    TxDedupStore // Nil checks the cache only
+	capacity int
+	ttl      time.Duration
+	order    *list.List // Front is most recently batched
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+// newTxDeduplicator creates a deduplicator caching up to capacity transactions for ttl.
+// Zero values use DefaultTxDedupCacheSize and DefaultTxDedupCacheTTL.
+func newTxDeduplicator(store TxDedupStore, capacity int, ttl time.Duration) *txDeduplicator {
+	if capacity <= 0 {
+		capacity = DefaultTxDedupCacheSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultTxDedupCacheTTL
+	}
+	return &txDeduplicator{
+		store:    store,
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// CanonicalTxHash returns the dedup key of a transaction: its Accumulate hash in lower
+// case without a 0x prefix, or the hex transaction hash when it has none
+func CanonicalTxHash(tx *TransactionData) string {
+	hash := strings.ToLower(strings.TrimSpace(tx.AccumTxHash))
+	hash = strings.TrimPrefix(hash, "0x")
+	if hash == "" {
+		return hex.EncodeToString(tx.TxHash)
+	}
+	return hash
+}
+
+// check returns ErrTransactionAlreadyBatched if the transaction is in a batch
+func (d *txDeduplicator) check(ctx context.Context, tx *TransactionData) error {
+	hash := CanonicalTxHash(tx)
+	if batchID, ok := d.cached(hash); ok {
+		return fmt.Errorf("%w: %s is in batch %s", ErrTransactionAlreadyBatched, tx.AccumTxHash, batchID)
+	}
+	if d.store == nil || tx.AccumTxHash == "" {
+		return nil
+	}
+
+	batchID, status, err := d.store.GetTransactionBatchStatus(ctx, tx.AccumTxHash)
+	if errors.Is(err, database.ErrTransactionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate transaction: %w", err)
+	}
+	d.remember(tx, batchID)
+	return fmt.Errorf("%w: %s is in %s batch %s", ErrTransactionAlreadyBatched, tx.AccumTxHash, status, batchID)
+}
+
+// cached returns the batch of a cached, unexpired transaction
+func (d *txDeduplicator) cached(hash string) (uuid.UUID, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	elem, ok := d.entries[hash]
+	if !ok {
+		return uuid.Nil, false
+	}
+	entry := elem.Value.(*txDedupEntry)
+	if d.now().After(entry.expiresAt) {
+		d.order.Remove(elem)
+		delete(d.entries, hash)
+		return uuid.Nil, false
+	}
+	return entry.batchID, true
+}
+
+// remember caches a transaction as batched in batchID, evicting the oldest entry
+// when the cache is full
+func (d *txDeduplicator) remember(tx *TransactionData, batchID uuid.UUID) {
+	hash := CanonicalTxHash(tx)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expiresAt := d.now().Add(d.ttl)
+	if elem, ok := d.entries[hash]; ok {
+		entry := elem.Value.(*txDedupEntry)
+		entry.batchID, entry.expiresAt = batchID, expiresAt
+		d.order.MoveToFront(elem)
+		return
+	}
+	d.entries[hash] = d.order.PushFront(&txDedupEntry{hash: hash, batchID: batchID, expiresAt: expiresAt})
+	for d.order.Len() > d.capacity {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(*txDedupEntry).hash)
+	}
+}
+
+// rename moves cached transactions of a batch to its new ID
+func (d *txDeduplicator) rename(batchID, newBatchID uuid.UUID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, elem := range d.entries {
+		if entry := elem.Value.(*txDedupEntry); entry.batchID == batchID {
+			entry.batchID = newBatchID
+		}
+	}
+}
+
+// size returns the number of cached transactions
+func (d *txDeduplicator) size() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.order.Len()
+}
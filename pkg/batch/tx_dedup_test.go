@@ -0,0 +1,116 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Transaction Dedup
+// Tests rejecting cached and stored transactions, cache bounds and expiry
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// fakeTxDedupStore serves the batch of stored transactions
+type fakeTxDedupStore struct {
+	batches map[string]uuid.UUID
+	err     error
+	lookups int
+}
+
+func (s *fakeTxDedupStore) GetTransactionBatchStatus(ctx context.Context, accumTxHash string) (uuid.UUID, database.BatchStatus, error) {
+	s.lookups++
+	if s.err != nil {
+		return uuid.Nil, "", s.err
+	}
+	batchID, ok := s.batches[accumTxHash]
+	if !ok {
+		return uuid.Nil, "", database.ErrTransactionNotFound
+	}
+	return batchID, database.BatchStatusAnchored, nil
+}
+
+func dedupTx(hash string) *TransactionData {
+	return &TransactionData{AccumTxHash: hash, TxHash: make([]byte, 32)}
+}
+
+func TestTxDeduplicator_Check(t *testing.T) {
+	stored := uuid.New()
+	store := &fakeTxDedupStore{batches: map[string]uuid.UUID{"bb": stored}}
+	d := newTxDeduplicator(store, 0, 0)
+	ctx := context.Background()
+
+	// Unknown transactions pass; once batched, the same hash in any form is rejected
+	if err := d.check(ctx, dedupTx("AA")); err != nil {
+		t.Fatalf("new transaction rejected: %v", err)
+	}
+	open := uuid.New()
+	d.remember(dedupTx("AA"), open)
+	lookups := store.lookups
+	if err := d.check(ctx, dedupTx("0xaa")); !errors.Is(err, ErrTransactionAlreadyBatched) {
+		t.Errorf("expected the cached transaction to be rejected, got %v", err)
+	}
+	if store.lookups != lookups {
+		t.Error("a cached transaction should not query the database")
+	}
+
+	// Transactions batched before a restart are found in the database and cached
+	if err := d.check(ctx, dedupTx("bb")); !errors.Is(err, ErrTransactionAlreadyBatched) {
+		t.Errorf("expected the stored transaction to be rejected, got %v", err)
+	}
+	if batchID, ok := d.cached("bb"); !ok || batchID != stored {
+		t.Errorf("stored transaction not cached: %s, %v", batchID, ok)
+	}
+
+	// A renamed batch keeps its transactions
+	renamed := uuid.New()
+	d.rename(open, renamed)
+	if batchID, _ := d.cached("aa"); batchID != renamed {
+		t.Errorf("expected batch %s after rename, got %s", renamed, batchID)
+	}
+
+	// Database errors are not treated as duplicates
+	store.err = errors.New("connection refused")
+	if err := d.check(ctx, dedupTx("cc")); err == nil || errors.Is(err, ErrTransactionAlreadyBatched) {
+		t.Errorf("expected a lookup error, got %v", err)
+	}
+}
+
+func TestTxDeduplicator_BoundsAndExpiry(t *testing.T) {
+	now := time.Now()
+	d := newTxDeduplicator(nil, 2, time.Minute)
+	d.now = func() time.Time { return now }
+
+	for _, hash := range []string{"01", "02", "03"} {
+		d.remember(dedupTx(hash), uuid.New())
+	}
+	if d.size() != 2 {
+		t.Errorf("expected the cache to hold 2 entries, got %d", d.size())
+	}
+	if _, ok := d.cached("01"); ok {
+		t.Error("the oldest entry should have been evicted")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := d.check(context.Background(), dedupTx("03")); err != nil {
+		t.Errorf("expired entry should no longer reject, got %v", err)
+	}
+	if d.size() != 1 {
+		t.Errorf("expired entry not dropped, size %d", d.size())
+	}
+}
+
+func TestCanonicalTxHash(t *testing.T) {
+	if got := CanonicalTxHash(dedupTx(" 0xABcd ")); got != "abcd" {
+		t.Errorf("CanonicalTxHash = %q, want abcd", got)
+	}
+	tx := &TransactionData{TxHash: []byte{0xde, 0xad}}
+	if got := CanonicalTxHash(tx); got != "dead" {
+		t.Errorf("CanonicalTxHash without an Accumulate hash = %q, want dead", got)
+	}
+}
@@ -0,0 +1,421 @@
+// Copyright 2025 Certen Protocol
+//
+// Usage Meter - Per-account proof metering and optional quotas
+//
+// Every proof request admitted by the collector is counted against its account in each
+// configured metering window (fixed windows aligned to the window size in UTC, e.g. hourly
+// and daily). Counts are persisted so they survive restarts and feed usage-based billing
+// against the on-cadence (~$0.05) and on-demand (~$0.25) pricing tiers.
+//
+// A window can carry a quota. Requests from an account that reached a quota are either
+// rejected with ErrQuotaExceeded or queued in memory and released into their batch once
+// the window rolls over. Queued requests are not persisted.
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// QuotaAction is what happens to proof requests from an account over its quota
+type QuotaAction string
+
+const (
+	QuotaActionReject QuotaAction = "reject" // Fail the request with ErrQuotaExceeded
+	QuotaActionQueue  QuotaAction = "queue"  // Hold the request until the quota window rolls over
+)
+
+// ParseQuotaAction parses a quota action name; empty selects reject
+func ParseQuotaAction(s string) (QuotaAction, error) {
+	switch QuotaAction(strings.ToLower(strings.TrimSpace(s))) {
+	case "", QuotaActionReject:
+		return QuotaActionReject, nil
+	case QuotaActionQueue:
+		return QuotaActionQueue, nil
+	default:
+		return "", fmt.Errorf("unknown quota action %q (expected reject or queue)", s)
+	}
+}
+
+// UsageWindow is a metering window with an optional proof quota
+type UsageWindow struct {
+	Size  time.Duration
+	Limit int64 // Maximum proofs per account per window (0 = metered only)
+}
+
+// ParseUsageWindows parses a comma-separated window list such as "1h:100,24h:1000,720h",
+// where each entry is a window size with an optional ":limit"
+func ParseUsageWindows(spec string) ([]UsageWindow, error) {
+	var windows []UsageWindow
+	seen := make(map[time.Duration]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		sizeStr, limitStr, hasLimit := strings.Cut(entry, ":")
+		size, err := time.ParseDuration(strings.TrimSpace(sizeStr))
+		if err != nil || size < time.Second {
+			return nil, fmt.Errorf("invalid usage window %q: size must be a duration of at least 1s", entry)
+		}
+		if seen[size] {
+			return nil, fmt.Errorf("duplicate usage window %s", size)
+		}
+		seen[size] = true
+
+		w := UsageWindow{Size: size}
+		if hasLimit {
+			w.Limit, err = strconv.ParseInt(strings.TrimSpace(limitStr), 10, 64)
+			if err != nil || w.Limit < 0 {
+				return nil, fmt.Errorf("invalid usage window %q: limit must be a non-negative integer", entry)
+			}
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// NormalizeAccountURL returns the canonical form under which an account is metered:
+// lower-case, with the acc:// scheme and no trailing slash. It also repairs "acc:/"
+// produced by HTTP path cleaning.
+func NormalizeAccountURL(accountURL string) string {
+	u := strings.ToLower(strings.TrimSpace(accountURL))
+	u = strings.TrimSuffix(u, "/")
+	if u == "" {
+		return ""
+	}
+	u = strings.TrimPrefix(u, "acc:")
+	return "acc://" + strings.TrimLeft(u, "/")
+}
+
+// UsageMeterStore persists usage meters
+// Implemented by database.UsageRepository
+type UsageMeterStore interface {
+	IncrementAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time, onDemand bool) (*database.AccountUsageMeter, error)
+	GetAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time) (*database.AccountUsageMeter, error)
+	RefundAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time, onDemand bool) error
+}
+
+// WindowUsage is an account's usage in the current instance of one window
+type WindowUsage struct {
+	Window        string    `json:"window"`
+	WindowStart   time.Time `json:"window_start"`
+	WindowEnd     time.Time `json:"window_end"`
+	ProofCount    int64     `json:"proof_count"`
+	OnDemandCount int64     `json:"on_demand_count"`
+	Limit         int64     `json:"limit,omitempty"`
+	Remaining     *int64    `json:"remaining,omitempty"` // Set only for windows with a quota
+}
+
+// AccountUsage is an account's usage across all metering windows
+type AccountUsage struct {
+	AccountURL  string        `json:"account_url"`
+	Windows     []WindowUsage `json:"windows"`
+	OverQuota   bool          `json:"over_quota"`
+	QuotaAction QuotaAction   `json:"quota_action,omitempty"`
+	Queued      int           `json:"queued"`
+	CheckedAt   time.Time     `json:"checked_at"`
+}
+
+// UsageMeterConfig holds configuration for the usage meter
+type UsageMeterConfig struct {
+	Windows   []UsageWindow
+	Action    QuotaAction
+	MaxQueued int // Maximum queued requests across all accounts (queue action only)
+	Logger    *log.Logger
+}
+
+// DefaultUsageMeterConfig returns default configuration (hourly, daily and 30-day meters, no quotas)
+func DefaultUsageMeterConfig() *UsageMeterConfig {
+	return &UsageMeterConfig{
+		Windows: []UsageWindow{
+			{Size: time.Hour},
+			{Size: 24 * time.Hour},
+			{Size: 30 * 24 * time.Hour},
+		},
+		Action:    QuotaActionReject,
+		MaxQueued: 1000,
+		Logger:    log.New(log.Writer(), "[UsageMeter] ", log.LstdFlags),
+	}
+}
+
+// queuedProofRequest is a request held back by the queue quota action
+type queuedProofRequest struct {
+	tx        *TransactionData
+	batchType database.BatchType
+	queuedAt  time.Time
+}
+
+// UsageMeter counts proofs per account and enforces quotas
+type UsageMeter struct {
+	mu sync.Mutex
+
+	store     UsageMeterStore
+	windows   []UsageWindow
+	action    QuotaAction
+	maxQueued int
+	queue     []*queuedProofRequest
+
+	now    func() time.Time
+	logger *log.Logger
+}
+
+// NewUsageMeter creates a new usage meter
+func NewUsageMeter(store UsageMeterStore, cfg *UsageMeterConfig) (*UsageMeter, error) {
+	if store == nil {
+		return nil, fmt.Errorf("usage meter store cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultUsageMeterConfig()
+	}
+	if len(cfg.Windows) == 0 {
+		return nil, fmt.Errorf("at least one usage window is required")
+	}
+	if cfg.Action == "" {
+		cfg.Action = QuotaActionReject
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[UsageMeter] ", log.LstdFlags)
+	}
+
+	return &UsageMeter{
+		store:     store,
+		windows:   cfg.Windows,
+		action:    cfg.Action,
+		maxQueued: cfg.MaxQueued,
+		now:       time.Now,
+		logger:    cfg.Logger,
+	}, nil
+}
+
+// QuotasEnabled reports whether any window carries a quota
+func (m *UsageMeter) QuotasEnabled() bool {
+	for _, w := range m.windows {
+		if w.Limit > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// windowStart returns the start of the current instance of a window
+func (m *UsageMeter) windowStart(size time.Duration) time.Time {
+	return m.now().UTC().Truncate(size)
+}
+
+// quotaViolation describes the first window whose quota an account has reached
+type quotaViolation struct {
+	window  UsageWindow
+	count   int64
+	resetAt time.Time
+}
+
+func (v *quotaViolation) String() string {
+	return fmt.Sprintf("%d/%d proofs in %s window (resets %s)",
+		v.count, v.window.Limit, v.window.Size, v.resetAt.Format(time.RFC3339))
+}
+
+// checkQuota returns the violated window, or nil when the account is under all quotas
+// Caller must hold m.mu
+func (m *UsageMeter) checkQuota(ctx context.Context, accountURL string) (*quotaViolation, error) {
+	for _, w := range m.windows {
+		if w.Limit <= 0 {
+			continue
+		}
+		start := m.windowStart(w.Size)
+		meter, err := m.store.GetAccountUsage(ctx, accountURL, w.Size, start)
+		if err != nil {
+			return nil, err
+		}
+		if meter.ProofCount >= w.Limit {
+			return &quotaViolation{window: w, count: meter.ProofCount, resetAt: start.Add(w.Size)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// record counts one proof for the account in every window
+// Caller must hold m.mu
+func (m *UsageMeter) record(ctx context.Context, accountURL string, batchType database.BatchType) error {
+	onDemand := batchType == database.BatchTypeOnDemand
+	for _, w := range m.windows {
+		if _, err := m.store.IncrementAccountUsage(ctx, accountURL, w.Size, m.windowStart(w.Size), onDemand); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// admit checks a request against the account's quotas. It returns a non-zero time when
+// the request was queued (the time its quota window resets) and ErrQuotaExceeded when it
+// was rejected.
+func (m *UsageMeter) admit(ctx context.Context, tx *TransactionData, batchType database.BatchType) (time.Time, error) {
+	accountURL := NormalizeAccountURL(tx.AccountURL)
+	if accountURL == "" || !m.QuotasEnabled() {
+		return time.Time{}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	violation, err := m.checkQuota(ctx, accountURL)
+	if err != nil {
+		// Metering storage problems must not block proof generation
+		m.logger.Printf("⚠️ Quota check failed for %s (admitting request): %v", accountURL, err)
+		return time.Time{}, nil
+	}
+	if violation == nil {
+		return time.Time{}, nil
+	}
+
+	if m.action == QuotaActionQueue {
+		if m.maxQueued > 0 && len(m.queue) >= m.maxQueued {
+			return time.Time{}, fmt.Errorf("%w for %s: %s (quota queue full)", ErrQuotaExceeded, accountURL, violation)
+		}
+		m.queue = append(m.queue, &queuedProofRequest{tx: tx, batchType: batchType, queuedAt: m.now()})
+		m.logger.Printf("⏸️ Queued %s request for %s: %s", batchType, accountURL, violation)
+		return violation.resetAt, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w for %s: %s", ErrQuotaExceeded, accountURL, violation)
+}
+
+// Record counts an admitted proof request against its account
+func (m *UsageMeter) Record(ctx context.Context, tx *TransactionData, batchType database.BatchType) error {
+	accountURL := NormalizeAccountURL(tx.AccountURL)
+	if accountURL == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.record(ctx, accountURL, batchType)
+}
+
+// Refund removes a proof request counted at countedAt from its account's meters, e.g.
+// when the request was abandoned without producing a proof
+func (m *UsageMeter) Refund(ctx context.Context, accountURL string, countedAt time.Time, batchType database.BatchType) error {
+	accountURL = NormalizeAccountURL(accountURL)
+	if accountURL == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	onDemand := batchType == database.BatchTypeOnDemand
+	for _, w := range m.windows {
+		if err := m.store.RefundAccountUsage(ctx, accountURL, w.Size, countedAt.UTC().Truncate(w.Size), onDemand); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseQueued passes queued requests whose account is back under quota to add, highest
+// priority first and in queue order within a priority, and counts the ones added. Requests that stay over quota or fail to be
+// added remain queued. Returns the number of released requests.
+func (m *UsageMeter) releaseQueued(ctx context.Context, add func(*queuedProofRequest) error) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.queue) == 0 {
+		return 0
+	}
+	sort.SliceStable(m.queue, func(i, j int) bool {
+		return m.queue[i].tx.Priority.Rank() > m.queue[j].tx.Priority.Rank()
+	})
+
+	released := 0
+	remaining := m.queue[:0]
+	for _, q := range m.queue {
+		accountURL := NormalizeAccountURL(q.tx.AccountURL)
+		violation, err := m.checkQuota(ctx, accountURL)
+		if err != nil || violation != nil {
+			remaining = append(remaining, q)
+			continue
+		}
+		if err := add(q); err != nil {
+			m.logger.Printf("⚠️ Failed to release queued request %s for %s: %v", q.tx.AccumTxHash, accountURL, err)
+			remaining = append(remaining, q)
+			continue
+		}
+		if err := m.record(ctx, accountURL, q.batchType); err != nil {
+			m.logger.Printf("⚠️ Failed to record usage for %s: %v", accountURL, err)
+		}
+		released++
+	}
+	for i := len(remaining); i < len(m.queue); i++ {
+		m.queue[i] = nil
+	}
+	m.queue = remaining
+
+	return released
+}
+
+// GetUsage returns the account's usage in the current instance of every window
+func (m *UsageMeter) GetUsage(ctx context.Context, accountURL string) (*AccountUsage, error) {
+	accountURL = NormalizeAccountURL(accountURL)
+	if accountURL == "" {
+		return nil, fmt.Errorf("account URL is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	usage := &AccountUsage{
+		AccountURL: accountURL,
+		CheckedAt:  m.now().UTC(),
+	}
+	if m.QuotasEnabled() {
+		usage.QuotaAction = m.action
+	}
+
+	for _, w := range m.windows {
+		start := m.windowStart(w.Size)
+		meter, err := m.store.GetAccountUsage(ctx, accountURL, w.Size, start)
+		if err != nil {
+			return nil, err
+		}
+		wu := WindowUsage{
+			Window:        w.Size.String(),
+			WindowStart:   start,
+			WindowEnd:     start.Add(w.Size),
+			ProofCount:    meter.ProofCount,
+			OnDemandCount: meter.OnDemandCount,
+			Limit:         w.Limit,
+		}
+		if w.Limit > 0 {
+			remaining := w.Limit - meter.ProofCount
+			if remaining <= 0 {
+				remaining = 0
+				usage.OverQuota = true
+			}
+			wu.Remaining = &remaining
+		}
+		usage.Windows = append(usage.Windows, wu)
+	}
+
+	for _, q := range m.queue {
+		if NormalizeAccountURL(q.tx.AccountURL) == accountURL {
+			usage.Queued++
+		}
+	}
+
+	return usage, nil
+}
+
+// QueuedCount returns the number of requests held back by quotas
+func (m *UsageMeter) QueuedCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.queue)
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Usage Meter
+// Tests window parsing, per-account counting, quota rejection and queue release
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+type usageKey struct {
+	account string
+	size    time.Duration
+	start   time.Time
+}
+
+// memoryUsageStore keeps meters in a map
+type memoryUsageStore struct {
+	meters map[usageKey]*database.AccountUsageMeter
+}
+
+func newMemoryUsageStore() *memoryUsageStore {
+	return &memoryUsageStore{meters: make(map[usageKey]*database.AccountUsageMeter)}
+}
+
+func (s *memoryUsageStore) IncrementAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time, onDemand bool) (*database.AccountUsageMeter, error) {
+	key := usageKey{accountURL, windowSize, windowStart}
+	m, ok := s.meters[key]
+	if !ok {
+		m = &database.AccountUsageMeter{AccountURL: accountURL, WindowSize: windowSize, WindowStart: windowStart}
+		s.meters[key] = m
+	}
+	m.ProofCount++
+	if onDemand {
+		m.OnDemandCount++
+	}
+	return m, nil
+}
+
+func (s *memoryUsageStore) GetAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time) (*database.AccountUsageMeter, error) {
+	if m, ok := s.meters[usageKey{accountURL, windowSize, windowStart}]; ok {
+		return m, nil
+	}
+	return &database.AccountUsageMeter{AccountURL: accountURL, WindowSize: windowSize, WindowStart: windowStart}, nil
+}
+
+func (s *memoryUsageStore) RefundAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time, onDemand bool) error {
+	if m, ok := s.meters[usageKey{accountURL, windowSize, windowStart}]; ok && m.ProofCount > 0 {
+		m.ProofCount--
+		if onDemand && m.OnDemandCount > 0 {
+			m.OnDemandCount--
+		}
+	}
+	return nil
+}
+
+func newTestUsageMeter(t *testing.T, store UsageMeterStore, action QuotaAction, windows ...UsageWindow) (*UsageMeter, *time.Time) {
+	t.Helper()
+	m, err := NewUsageMeter(store, &UsageMeterConfig{Windows: windows, Action: action, MaxQueued: 2})
+	if err != nil {
+		t.Fatalf("NewUsageMeter failed: %v", err)
+	}
+	now := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestParseUsageWindows(t *testing.T) {
+	windows, err := ParseUsageWindows("1h:100, 24h ,720h:0")
+	if err != nil {
+		t.Fatalf("ParseUsageWindows failed: %v", err)
+	}
+	expected := []UsageWindow{{time.Hour, 100}, {24 * time.Hour, 0}, {720 * time.Hour, 0}}
+	if len(windows) != len(expected) {
+		t.Fatalf("expected %d windows, got %v", len(expected), windows)
+	}
+	for i := range expected {
+		if windows[i] != expected[i] {
+			t.Errorf("window %d = %+v, expected %+v", i, windows[i], expected[i])
+		}
+	}
+
+	for _, bad := range []string{"hourly", "1h:-1", "1h:x", "1h,60m", "10ms"} {
+		if _, err := ParseUsageWindows(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+
+	if action, err := ParseQuotaAction(" Queue"); err != nil || action != QuotaActionQueue {
+		t.Errorf("ParseQuotaAction(queue) = %q, %v", action, err)
+	}
+	if _, err := ParseQuotaAction("drop"); err == nil {
+		t.Error("expected error for unknown quota action")
+	}
+}
+
+func TestNormalizeAccountURL(t *testing.T) {
+	for input, expected := range map[string]string{
+		"acc://Example.acme/tokens/": "acc://example.acme/tokens",
+		"acc:/example.acme":          "acc://example.acme",
+		"example.acme":               "acc://example.acme",
+		"  ":                         "",
+	} {
+		if got := NormalizeAccountURL(input); got != expected {
+			t.Errorf("NormalizeAccountURL(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestUsageMeter_RejectOverQuota(t *testing.T) {
+	store := newMemoryUsageStore()
+	m, now := newTestUsageMeter(t, store, QuotaActionReject,
+		UsageWindow{Size: time.Hour, Limit: 2}, UsageWindow{Size: 24 * time.Hour})
+	ctx := context.Background()
+	tx := &TransactionData{AccountURL: "acc://example.acme"}
+
+	for i := 0; i < 2; i++ {
+		if until, err := m.admit(ctx, tx, database.BatchTypeOnDemand); err != nil || !until.IsZero() {
+			t.Fatalf("request %d should be admitted, got %v", i, err)
+		}
+		if err := m.Record(ctx, tx, database.BatchTypeOnDemand); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	if _, err := m.admit(ctx, tx, database.BatchTypeOnCadence); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+	}
+	if _, err := m.admit(ctx, &TransactionData{AccountURL: "acc://other.acme"}, database.BatchTypeOnCadence); err != nil {
+		t.Errorf("quota must be per account, got %v", err)
+	}
+
+	usage, err := m.GetUsage(ctx, "ACC://example.acme/")
+	if err != nil {
+		t.Fatalf("GetUsage failed: %v", err)
+	}
+	hourly, daily := usage.Windows[0], usage.Windows[1]
+	if hourly.ProofCount != 2 || hourly.OnDemandCount != 2 || *hourly.Remaining != 0 || !usage.OverQuota {
+		t.Errorf("unexpected hourly usage: %+v (over quota %v)", hourly, usage.OverQuota)
+	}
+	if !hourly.WindowStart.Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected hourly window aligned to the hour, got %s", hourly.WindowStart)
+	}
+	if daily.Remaining != nil || daily.ProofCount != 2 {
+		t.Errorf("expected metered-only daily window with 2 proofs, got %+v", daily)
+	}
+
+	// Next hour - the hourly quota resets, the daily meter keeps counting
+	*now = now.Add(time.Hour)
+	if _, err := m.admit(ctx, tx, database.BatchTypeOnCadence); err != nil {
+		t.Errorf("expected admission after window rollover, got %v", err)
+	}
+}
+
+func TestUsageMeter_QueueAndRelease(t *testing.T) {
+	store := newMemoryUsageStore()
+	m, now := newTestUsageMeter(t, store, QuotaActionQueue, UsageWindow{Size: time.Hour, Limit: 1})
+	ctx := context.Background()
+	tx := &TransactionData{AccountURL: "acc://example.acme", AccumTxHash: "tx-1"}
+
+	m.Record(ctx, tx, database.BatchTypeOnCadence)
+
+	until, err := m.admit(ctx, &TransactionData{AccountURL: "acc://example.acme", AccumTxHash: "tx-2"}, database.BatchTypeOnDemand)
+	if err != nil || !until.Equal(time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected request queued until the window resets, got %s, %v", until, err)
+	}
+	m.admit(ctx, &TransactionData{AccountURL: "acc://example.acme", AccumTxHash: "tx-3"}, database.BatchTypeOnCadence)
+	if _, err := m.admit(ctx, tx, database.BatchTypeOnCadence); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected rejection once the queue is full, got %v", err)
+	}
+
+	var added []string
+	add := func(q *queuedProofRequest) error {
+		added = append(added, q.tx.AccumTxHash)
+		return nil
+	}
+
+	if n := m.releaseQueued(ctx, add); n != 0 {
+		t.Errorf("nothing should be released within the window, released %d", n)
+	}
+
+	// Next window admits one request; the other stays queued
+	*now = now.Add(time.Hour)
+	if n := m.releaseQueued(ctx, add); n != 1 || len(added) != 1 || added[0] != "tx-2" {
+		t.Errorf("expected tx-2 to be released first, released %d: %v", n, added)
+	}
+	if m.QueuedCount() != 1 {
+		t.Errorf("expected 1 request still queued, got %d", m.QueuedCount())
+	}
+	usage, _ := m.GetUsage(ctx, "acc://example.acme")
+	if usage.Queued != 1 || usage.Windows[0].OnDemandCount != 1 {
+		t.Errorf("expected released on-demand proof to be counted and 1 queued, got %+v", usage)
+	}
+}
+
+func TestUsageMeter_ReleaseByPriority(t *testing.T) {
+	store := newMemoryUsageStore()
+	m, now := newTestUsageMeter(t, store, QuotaActionQueue, UsageWindow{Size: time.Hour, Limit: 1})
+	ctx := context.Background()
+
+	m.Record(ctx, &TransactionData{AccountURL: "acc://example.acme", AccumTxHash: "tx-1"}, database.BatchTypeOnCadence)
+	m.admit(ctx, &TransactionData{AccountURL: "acc://example.acme", AccumTxHash: "tx-low", Priority: database.PriorityLow}, database.BatchTypeOnCadence)
+	m.admit(ctx, &TransactionData{AccountURL: "acc://example.acme", AccumTxHash: "tx-urgent", Priority: database.PriorityUrgent}, database.BatchTypeOnCadence)
+
+	var added []string
+	*now = now.Add(time.Hour)
+	m.releaseQueued(ctx, func(q *queuedProofRequest) error {
+		added = append(added, q.tx.AccumTxHash)
+		return nil
+	})
+	if len(added) != 1 || added[0] != "tx-urgent" {
+		t.Errorf("expected the urgent request to be released first, got %v", added)
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright 2025 Certen Protocol
+//
+// Verification Failure Recorder - Persists ProofVerificationFailed contract events
+//
+// Each event is stored once and correlated to local state:
+//   - the batch, decoded from the anchor ID (batch anchors carry the first 32 characters
+//     of the batch ID, see anchor.BatchAnchorBundleID)
+//   - the proof artifact of the batch transaction whose hash the event names
+//
+// A correlated proof is marked onchain_verification_failed with the event's reason.
+// Failures seen within the health window degrade the validator's health status, and
+// AlertThreshold failures within AlertWindow raise an alert through the alert handler.
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// VerificationFailureStore persists failure events and correlates them to proofs
+// Implemented by database.VerificationFailureRepository
+type VerificationFailureStore interface {
+	RecordVerificationFailure(ctx context.Context, f *database.ProofVerificationFailure) (bool, error)
+	FindBatchByIDPrefix(ctx context.Context, prefix string) (uuid.UUID, error)
+	FindProofByBatchTransaction(ctx context.Context, batchID uuid.UUID, txHash []byte) (uuid.UUID, error)
+	MarkProofOnChainVerificationFailed(ctx context.Context, proofID uuid.UUID, reason string) error
+	ListVerificationFailures(ctx context.Context, limit, offset int) ([]database.ProofVerificationFailure, error)
+}
+
+// VerificationFailureReport is a stored failure event as served by the API
+type VerificationFailureReport struct {
+	ID              int64      `json:"id"`
+	EventTxHash     string     `json:"event_tx_hash"`
+	LogIndex        int        `json:"log_index"`
+	BlockNumber     int64      `json:"block_number"`
+	AnchorID        string     `json:"anchor_id"`
+	TransactionHash string     `json:"transaction_hash"`
+	FailedChecks    []string   `json:"failed_checks"`
+	Reason          string     `json:"reason"`
+	EventTimestamp  *time.Time `json:"event_timestamp,omitempty"`
+	BatchID         *uuid.UUID `json:"batch_id,omitempty"`
+	ProofID         *uuid.UUID `json:"proof_id,omitempty"`
+	RecordedAt      time.Time  `json:"recorded_at"`
+}
+
+// VerificationFailureStats summarizes the failure events seen since startup
+type VerificationFailureStats struct {
+	Total         int64      `json:"total"`
+	Uncorrelated  int64      `json:"uncorrelated"` // Events that matched no local proof
+	Recent        int        `json:"recent"`       // Events within the health window
+	HealthWindow  string     `json:"health_window"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	AlertsRaised  int64      `json:"alerts_raised"`
+}
+
+// VerificationFailureAlert is raised when failures reach the alert threshold within
+// the alert window
+type VerificationFailureAlert struct {
+	Failures     int            `json:"failures"` // Failures within the window
+	Window       string         `json:"window"`
+	FailedChecks map[string]int `json:"failed_checks"` // Failures per failed check: merkle, bls, governance, commitment
+	Reasons      []string       `json:"reasons"`       // Distinct reasons, oldest first
+	RaisedAt     time.Time      `json:"raised_at"`
+}
+
+// alertSample is a failure counted towards the alert threshold
+type alertSample struct {
+	at     time.Time
+	checks []string
+	reason string
+}
+
+// VerificationFailureRecorderConfig holds configuration for the verification failure recorder
+type VerificationFailureRecorderConfig struct {
+	HealthWindow   time.Duration // Failures within this window degrade health
+	AlertThreshold int           // Failures within AlertWindow that raise an alert (0 = no alerts)
+	AlertWindow    time.Duration // Window for AlertThreshold; at most one alert is raised per window
+	Logger         *log.Logger
+}
+
+// DefaultVerificationFailureRecorderConfig returns default configuration
+func DefaultVerificationFailureRecorderConfig() *VerificationFailureRecorderConfig {
+	return &VerificationFailureRecorderConfig{
+		HealthWindow:   time.Hour,
+		AlertThreshold: 3,
+		AlertWindow:    15 * time.Minute,
+		Logger:         log.New(log.Writer(), "[VerifyFailures] ", log.LstdFlags),
+	}
+}
+
+// VerificationFailureRecorder persists and correlates ProofVerificationFailed events
+type VerificationFailureRecorder struct {
+	mu sync.Mutex
+
+	store          VerificationFailureStore
+	healthWindow   time.Duration
+	alertThreshold int
+	alertWindow    time.Duration
+	onAlert        func(VerificationFailureAlert)
+	logger         *log.Logger
+	now            func() time.Time
+
+	total        int64
+	uncorrelated int64
+	recent       []time.Time   // Failure times within the health window, oldest first
+	alertRecent  []alertSample // Failures within the alert window, oldest first
+	lastAlertAt  time.Time
+	alertsRaised int64
+}
+
+// NewVerificationFailureRecorder creates a new verification failure recorder
+func NewVerificationFailureRecorder(store VerificationFailureStore, cfg *VerificationFailureRecorderConfig) (*VerificationFailureRecorder, error) {
+	if store == nil {
+		return nil, errors.New("verification failure store cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultVerificationFailureRecorderConfig()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.New(log.Writer(), "[VerifyFailures] ", log.LstdFlags)
+	}
+	if cfg.HealthWindow <= 0 {
+		cfg.HealthWindow = DefaultVerificationFailureRecorderConfig().HealthWindow
+	}
+	if cfg.AlertThreshold < 0 {
+		return nil, errors.New("alert threshold cannot be negative")
+	}
+	if cfg.AlertThreshold > 0 && cfg.AlertWindow <= 0 {
+		return nil, errors.New("alert window must be positive when alerts are enabled")
+	}
+
+	return &VerificationFailureRecorder{
+		store:          store,
+		healthWindow:   cfg.HealthWindow,
+		alertThreshold: cfg.AlertThreshold,
+		alertWindow:    cfg.AlertWindow,
+		logger:         cfg.Logger,
+		now:            time.Now,
+	}, nil
+}
+
+// SetAlertHandler sets the function called when failures reach the alert threshold
+func (r *VerificationFailureRecorder) SetAlertHandler(handler func(VerificationFailureAlert)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onAlert = handler
+}
+
+// HandleEvent persists a ProofVerificationFailed event, correlates it and marks the
+// affected proof. Events already stored (e.g. replayed after a restart) are ignored.
+func (r *VerificationFailureRecorder) HandleEvent(ctx context.Context, e *anchor.ProofVerificationFailedEvent) error {
+	failure := &database.ProofVerificationFailure{
+		EventTxHash:        e.TxHash,
+		LogIndex:           int(e.LogIndex),
+		BlockNumber:        int64(e.BlockNumber),
+		AnchorID:           append([]byte(nil), e.AnchorID[:]...),
+		TransactionHash:    append([]byte(nil), e.TransactionHash[:]...),
+		MerkleVerified:     e.MerkleVerified,
+		BLSVerified:        e.BLSVerified,
+		GovernanceVerified: e.GovernanceVerified,
+		CommitmentVerified: e.CommitmentVerified,
+		Reason:             e.Reason,
+		EventTimestamp:     eventTime(e.Timestamp),
+	}
+	failure.BatchID, failure.ProofID = r.correlate(ctx, e)
+
+	inserted, err := r.store.RecordVerificationFailure(ctx, failure)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		return nil
+	}
+
+	if failure.ProofID != nil {
+		if err := r.store.MarkProofOnChainVerificationFailed(ctx, *failure.ProofID, e.Reason); err != nil {
+			r.logger.Printf("⚠️ Failed to mark proof %s: %v", failure.ProofID, err)
+		}
+		r.logger.Printf("❌ Proof %s (batch %s) failed on-chain verification: %s (failed: %v)",
+			failure.ProofID, failure.BatchID, e.Reason, failedEventChecks(failure))
+	} else {
+		r.logger.Printf("❌ Uncorrelated on-chain verification failure: anchorId=%x tx=%x reason=%s",
+			e.AnchorID[:8], e.TransactionHash[:8], e.Reason)
+	}
+
+	r.mu.Lock()
+	r.total++
+	if failure.ProofID == nil {
+		r.uncorrelated++
+	}
+	r.recent = append(r.pruneRecent(), r.now())
+	alert, handler := r.checkAlert(failedEventChecks(failure), e.Reason)
+	r.mu.Unlock()
+
+	if alert != nil && handler != nil {
+		handler(*alert)
+	}
+	return nil
+}
+
+// checkAlert counts a failure towards the alert threshold and returns the alert to
+// raise, if any, with the handler to raise it with; the caller holds mu
+func (r *VerificationFailureRecorder) checkAlert(checks []string, reason string) (*VerificationFailureAlert, func(VerificationFailureAlert)) {
+	if r.alertThreshold <= 0 {
+		return nil, nil
+	}
+	now := r.now()
+	cutoff := now.Add(-r.alertWindow)
+	i := 0
+	for i < len(r.alertRecent) && r.alertRecent[i].at.Before(cutoff) {
+		i++
+	}
+	r.alertRecent = append(r.alertRecent[i:], alertSample{at: now, checks: checks, reason: reason})
+
+	if len(r.alertRecent) < r.alertThreshold {
+		return nil, nil
+	}
+	if !r.lastAlertAt.IsZero() && now.Sub(r.lastAlertAt) < r.alertWindow {
+		return nil, nil // Already alerted for this window
+	}
+	r.lastAlertAt = now
+	r.alertsRaised++
+
+	alert := &VerificationFailureAlert{
+		Failures:     len(r.alertRecent),
+		Window:       r.alertWindow.String(),
+		FailedChecks: make(map[string]int),
+		Reasons:      []string{},
+		RaisedAt:     now,
+	}
+	seen := make(map[string]bool)
+	for _, sample := range r.alertRecent {
+		for _, check := range sample.checks {
+			alert.FailedChecks[check]++
+		}
+		if !seen[sample.reason] {
+			seen[sample.reason] = true
+			alert.Reasons = append(alert.Reasons, sample.reason)
+		}
+	}
+	return alert, r.onAlert
+}
+
+// correlate resolves the local batch and proof an event refers to
+func (r *VerificationFailureRecorder) correlate(ctx context.Context, e *anchor.ProofVerificationFailedEvent) (*uuid.UUID, *uuid.UUID) {
+	prefix, ok := batchIDPrefixFromAnchorID(e.AnchorID)
+	if !ok {
+		return nil, nil
+	}
+	batchID, err := r.store.FindBatchByIDPrefix(ctx, prefix)
+	if err != nil {
+		if !errors.Is(err, database.ErrBatchNotFound) {
+			r.logger.Printf("⚠️ Failed to resolve batch for anchorId %x: %v", e.AnchorID[:8], err)
+		}
+		return nil, nil
+	}
+	proofID, err := r.store.FindProofByBatchTransaction(ctx, batchID, e.TransactionHash[:])
+	if err != nil {
+		if !errors.Is(err, database.ErrProofNotFound) {
+			r.logger.Printf("⚠️ Failed to resolve proof in batch %s: %v", batchID, err)
+		}
+		return &batchID, nil
+	}
+	return &batchID, &proofID
+}
+
+// ListFailures returns stored failure events, newest first
+func (r *VerificationFailureRecorder) ListFailures(ctx context.Context, limit, offset int) ([]VerificationFailureReport, error) {
+	failures, err := r.store.ListVerificationFailures(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]VerificationFailureReport, 0, len(failures))
+	for i := range failures {
+		f := &failures[i]
+		reports = append(reports, VerificationFailureReport{
+			ID:              f.ID,
+			EventTxHash:     f.EventTxHash,
+			LogIndex:        f.LogIndex,
+			BlockNumber:     f.BlockNumber,
+			AnchorID:        "0x" + hex.EncodeToString(f.AnchorID),
+			TransactionHash: "0x" + hex.EncodeToString(f.TransactionHash),
+			FailedChecks:    failedEventChecks(f),
+			Reason:          f.Reason,
+			EventTimestamp:  f.EventTimestamp,
+			BatchID:         f.BatchID,
+			ProofID:         f.ProofID,
+			RecordedAt:      f.CreatedAt,
+		})
+	}
+	return reports, nil
+}
+
+// Stats returns the failure counters since startup
+func (r *VerificationFailureRecorder) Stats() VerificationFailureStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recent = r.pruneRecent()
+
+	stats := VerificationFailureStats{
+		Total:        r.total,
+		Uncorrelated: r.uncorrelated,
+		Recent:       len(r.recent),
+		HealthWindow: r.healthWindow.String(),
+		AlertsRaised: r.alertsRaised,
+	}
+	if n := len(r.recent); n > 0 {
+		last := r.recent[n-1]
+		stats.LastFailureAt = &last
+	}
+	return stats
+}
+
+// pruneRecent drops failure times older than the health window; the caller holds mu
+func (r *VerificationFailureRecorder) pruneRecent() []time.Time {
+	cutoff := r.now().Add(-r.healthWindow)
+	i := 0
+	for i < len(r.recent) && r.recent[i].Before(cutoff) {
+		i++
+	}
+	return r.recent[i:]
+}
+
+// batchIDPrefixFromAnchorID decodes the batch ID prefix carried by a batch anchor ID.
+// Anchor IDs that are not a zero-padded batch ID prefix (e.g. hashed bundle IDs) do not decode.
+func batchIDPrefixFromAnchorID(anchorID [32]byte) (string, bool) {
+	prefix := string(bytes.TrimRight(anchorID[:], "\x00"))
+	if len(prefix) != len(anchorID) {
+		return "", false
+	}
+	// A canonical batch ID is 36 characters; complete the prefix to validate it
+	if _, err := uuid.Parse(prefix + "0000"); err != nil {
+		return "", false
+	}
+	return prefix, true
+}
+
+// CountFailedChecks returns how many of the reports failed each check
+// (merkle, bls, governance, commitment)
+func CountFailedChecks(reports []VerificationFailureReport) map[string]int {
+	counts := map[string]int{"merkle": 0, "bls": 0, "governance": 0, "commitment": 0}
+	for _, report := range reports {
+		for _, check := range report.FailedChecks {
+			counts[check]++
+		}
+	}
+	return counts
+}
+
+// failedEventChecks lists the names of the checks an event reported as failed
+func failedEventChecks(f *database.ProofVerificationFailure) []string {
+	failed := []string{}
+	for _, c := range []struct {
+		name string
+		ok   bool
+	}{
+		{"merkle", f.MerkleVerified},
+		{"bls", f.BLSVerified},
+		{"governance", f.GovernanceVerified},
+		{"commitment", f.CommitmentVerified},
+	} {
+		if !c.ok {
+			failed = append(failed, c.name)
+		}
+	}
+	return failed
+}
+
+// eventTime converts an event's Unix timestamp, if present
+func eventTime(ts *big.Int) *time.Time {
+	if ts == nil || ts.Sign() <= 0 || !ts.IsInt64() {
+		return nil
+	}
+	t := time.Unix(ts.Int64(), 0).UTC()
+	return &t
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Verification Failure Recorder
+// Tests anchor ID decoding, event correlation, deduplication, health counters and alerting
+
+package batch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/google/uuid"
+)
+
+// memoryFailureStore keeps failure events in memory with one batch and one proof
+type memoryFailureStore struct {
+	batchID  uuid.UUID
+	txHash   []byte
+	proofID  uuid.UUID
+	failures []database.ProofVerificationFailure
+	marked   map[uuid.UUID]string
+}
+
+func (s *memoryFailureStore) RecordVerificationFailure(ctx context.Context, f *database.ProofVerificationFailure) (bool, error) {
+	for _, existing := range s.failures {
+		if existing.EventTxHash == f.EventTxHash && existing.LogIndex == f.LogIndex {
+			return false, nil
+		}
+	}
+	f.ID = int64(len(s.failures) + 1)
+	s.failures = append(s.failures, *f)
+	return true, nil
+}
+
+func (s *memoryFailureStore) FindBatchByIDPrefix(ctx context.Context, prefix string) (uuid.UUID, error) {
+	if strings.HasPrefix(s.batchID.String(), prefix) {
+		return s.batchID, nil
+	}
+	return uuid.Nil, database.ErrBatchNotFound
+}
+
+func (s *memoryFailureStore) FindProofByBatchTransaction(ctx context.Context, batchID uuid.UUID, txHash []byte) (uuid.UUID, error) {
+	if batchID == s.batchID && bytes.Equal(txHash, s.txHash) {
+		return s.proofID, nil
+	}
+	return uuid.Nil, database.ErrProofNotFound
+}
+
+func (s *memoryFailureStore) MarkProofOnChainVerificationFailed(ctx context.Context, proofID uuid.UUID, reason string) error {
+	s.marked[proofID] = reason
+	return nil
+}
+
+func (s *memoryFailureStore) ListVerificationFailures(ctx context.Context, limit, offset int) ([]database.ProofVerificationFailure, error) {
+	return s.failures, nil
+}
+
+func TestBatchIDPrefixFromAnchorID(t *testing.T) {
+	batchID := uuid.New()
+	prefix, ok := batchIDPrefixFromAnchorID(anchor.BatchAnchorBundleID(batchID.String()))
+	if !ok || !strings.HasPrefix(batchID.String(), prefix) || len(prefix) != 32 {
+		t.Errorf("expected a 32-character prefix of %s, got %q (%v)", batchID, prefix, ok)
+	}
+
+	var hashed [32]byte
+	copy(hashed[:], bytes.Repeat([]byte{0xab}, 32))
+	if _, ok := batchIDPrefixFromAnchorID(hashed); ok {
+		t.Error("hashed bundle ID should not decode")
+	}
+	if _, ok := batchIDPrefixFromAnchorID(anchor.BatchAnchorBundleID("short-id")); ok {
+		t.Error("short anchor ID should not decode")
+	}
+}
+
+func TestVerificationFailureRecorder_HandleEvent(t *testing.T) {
+	store := &memoryFailureStore{
+		batchID: uuid.New(),
+		txHash:  bytes.Repeat([]byte{0x11}, 32),
+		proofID: uuid.New(),
+		marked:  make(map[uuid.UUID]string),
+	}
+	recorder, err := NewVerificationFailureRecorder(store, &VerificationFailureRecorderConfig{
+		HealthWindow: time.Hour,
+		Logger:       log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewVerificationFailureRecorder failed: %v", err)
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now }
+
+	event := &anchor.ProofVerificationFailedEvent{
+		AnchorID:       anchor.BatchAnchorBundleID(store.batchID.String()),
+		MerkleVerified: false,
+		BLSVerified:    true,
+		Reason:         "merkle proof invalid",
+		Timestamp:      big.NewInt(now.Unix()),
+		TxHash:         "0xevent1",
+		LogIndex:       3,
+	}
+	copy(event.TransactionHash[:], store.txHash)
+
+	ctx := context.Background()
+	if err := recorder.HandleEvent(ctx, event); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	// A replayed event is stored and counted once
+	if err := recorder.HandleEvent(ctx, event); err != nil {
+		t.Fatalf("HandleEvent replay failed: %v", err)
+	}
+
+	if len(store.failures) != 1 {
+		t.Fatalf("expected 1 stored failure, got %d", len(store.failures))
+	}
+	f := store.failures[0]
+	if f.BatchID == nil || *f.BatchID != store.batchID || f.ProofID == nil || *f.ProofID != store.proofID {
+		t.Errorf("event not correlated: batch=%v proof=%v", f.BatchID, f.ProofID)
+	}
+	if store.marked[store.proofID] != "merkle proof invalid" {
+		t.Errorf("proof not marked with the reason, got %q", store.marked[store.proofID])
+	}
+
+	// An event for an unknown transaction keeps the batch but no proof
+	unknown := *event
+	unknown.LogIndex = 4
+	unknown.TransactionHash = [32]byte{0x22}
+	if err := recorder.HandleEvent(ctx, &unknown); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	if f := store.failures[1]; f.BatchID == nil || f.ProofID != nil {
+		t.Errorf("expected batch-only correlation, got batch=%v proof=%v", f.BatchID, f.ProofID)
+	}
+
+	stats := recorder.Stats()
+	if stats.Total != 2 || stats.Uncorrelated != 1 || stats.Recent != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	now = now.Add(2 * time.Hour)
+	if stats := recorder.Stats(); stats.Recent != 0 || stats.Total != 2 {
+		t.Errorf("failures outside the health window should not be recent, got %+v", stats)
+	}
+
+	reports, err := recorder.ListFailures(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("ListFailures failed: %v", err)
+	}
+	if len(reports) != 2 || strings.Join(reports[0].FailedChecks, ",") != "merkle,governance,commitment" {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+	if reports[0].EventTimestamp == nil || !reports[0].EventTimestamp.Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected event timestamp %v", reports[0].EventTimestamp)
+	}
+}
+
+func TestVerificationFailureRecorder_Alerts(t *testing.T) {
+	store := &memoryFailureStore{marked: make(map[uuid.UUID]string)}
+	recorder, err := NewVerificationFailureRecorder(store, &VerificationFailureRecorderConfig{
+		HealthWindow:   time.Hour,
+		AlertThreshold: 3,
+		AlertWindow:    15 * time.Minute,
+		Logger:         log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewVerificationFailureRecorder failed: %v", err)
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now }
+
+	var alerts []VerificationFailureAlert
+	recorder.SetAlertHandler(func(a VerificationFailureAlert) { alerts = append(alerts, a) })
+
+	ctx := context.Background()
+	fail := func(logIndex uint, merkle, bls bool, reason string) {
+		t.Helper()
+		if err := recorder.HandleEvent(ctx, &anchor.ProofVerificationFailedEvent{
+			MerkleVerified:     merkle,
+			BLSVerified:        bls,
+			GovernanceVerified: true,
+			CommitmentVerified: true,
+			Reason:             reason,
+			TxHash:             "0xalert",
+			LogIndex:           logIndex,
+		}); err != nil {
+			t.Fatalf("HandleEvent failed: %v", err)
+		}
+		now = now.Add(time.Minute)
+	}
+
+	fail(1, false, true, "merkle proof invalid")
+	fail(2, false, true, "merkle proof invalid")
+	if len(alerts) != 0 {
+		t.Fatalf("alert raised below the threshold: %+v", alerts)
+	}
+	fail(3, true, false, "bls signature invalid")
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert at the threshold, got %d", len(alerts))
+	}
+	a := alerts[0]
+	if a.Failures != 3 || a.FailedChecks["merkle"] != 2 || a.FailedChecks["bls"] != 1 || a.FailedChecks["governance"] != 0 {
+		t.Errorf("unexpected alert %+v", a)
+	}
+	if strings.Join(a.Reasons, ",") != "merkle proof invalid,bls signature invalid" {
+		t.Errorf("unexpected alert reasons %v", a.Reasons)
+	}
+
+	// No second alert within the same window
+	fail(4, false, true, "merkle proof invalid")
+	if len(alerts) != 1 {
+		t.Errorf("expected no repeat alert within the window, got %d alerts", len(alerts))
+	}
+
+	// Once the earlier failures age out, a new burst alerts again
+	now = now.Add(30 * time.Minute)
+	fail(5, false, true, "merkle proof invalid")
+	fail(6, false, true, "merkle proof invalid")
+	fail(7, false, true, "merkle proof invalid")
+	if len(alerts) != 2 || alerts[1].Failures != 3 {
+		t.Errorf("expected a second alert for the new burst, got %+v", alerts)
+	}
+	if stats := recorder.Stats(); stats.AlertsRaised != 2 {
+		t.Errorf("expected 2 alerts in stats, got %+v", stats)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Certen Protocol
+//
+// EVM Network Profiles - Base and Polygon PoS anchor strategies
+//
+// Base and Polygon run the same CertenAnchorV3 contract as Ethereum, so they reuse
+// EVMStrategy with their own contract address, chain ID and RPC endpoint. What differs
+// is how long a receipt must age before it is final: Base inherits Ethereum's
+// finality through its L1 batches within a few blocks, while Polygon PoS has seen
+// reorgs dozens of blocks deep and needs a much longer confirmation window.
+
+package strategy
+
+import (
+	"fmt"
+	"time"
+)
+
+// Chain IDs of the supported Base and Polygon networks
+const (
+	BaseMainnetChainID    int64 = 8453
+	BaseSepoliaChainID    int64 = 84532
+	PolygonMainnetChainID int64 = 137
+	PolygonAmoyChainID    int64 = 80002
+)
+
+// evmNetworkProfile holds the defaults of a known EVM network
+type evmNetworkProfile struct {
+	family          string // "base" or "polygon"
+	networkName     string
+	confirmations   int           // Blocks a receipt must age before it is final
+	pollingInterval time.Duration // About one block time
+}
+
+var evmNetworkProfiles = map[int64]evmNetworkProfile{
+	BaseMainnetChainID:    {family: "base", networkName: "base", confirmations: 10, pollingInterval: 2 * time.Second},
+	BaseSepoliaChainID:    {family: "base", networkName: "base-sepolia", confirmations: 5, pollingInterval: 2 * time.Second},
+	PolygonMainnetChainID: {family: "polygon", networkName: "polygon", confirmations: 128, pollingInterval: 2 * time.Second},
+	PolygonAmoyChainID:    {family: "polygon", networkName: "polygon-amoy", confirmations: 64, pollingInterval: 2 * time.Second},
+}
+
+// NewBaseStrategy creates an EVM strategy for Base. chainID selects Base mainnet
+// (0 or 8453) or Base Sepolia (84532); confirmations of 0 uses the network default.
+func NewBaseStrategy(rpcURL, privateKeyHex, contractAddress, validatorID string, chainID int64, confirmations int) (*EVMStrategy, error) {
+	if chainID == 0 {
+		chainID = BaseMainnetChainID
+	}
+	return newEVMNetworkStrategy("base", rpcURL, privateKeyHex, contractAddress, validatorID, chainID, confirmations)
+}
+
+// NewPolygonStrategy creates an EVM strategy for Polygon PoS. chainID selects Polygon
+// mainnet (0 or 137) or Amoy (80002); confirmations of 0 uses the network default.
+func NewPolygonStrategy(rpcURL, privateKeyHex, contractAddress, validatorID string, chainID int64, confirmations int) (*EVMStrategy, error) {
+	if chainID == 0 {
+		chainID = PolygonMainnetChainID
+	}
+	return newEVMNetworkStrategy("polygon", rpcURL, privateKeyHex, contractAddress, validatorID, chainID, confirmations)
+}
+
+// newEVMNetworkStrategy connects a strategy for a known network and checks the RPC
+// endpoint serves that network
+func newEVMNetworkStrategy(family, rpcURL, privateKeyHex, contractAddress, validatorID string, chainID int64, confirmations int) (*EVMStrategy, error) {
+	config, err := evmNetworkStrategyConfig(family, rpcURL, privateKeyHex, contractAddress, validatorID, chainID, confirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy, err := NewEVMStrategy(config)
+	if err != nil {
+		return nil, err
+	}
+	if strategy.ChainID() != config.ChainConfig.ChainID {
+		return nil, fmt.Errorf("%s RPC endpoint serves chain %s, expected %s",
+			config.ChainConfig.NetworkName, strategy.ChainID(), config.ChainConfig.ChainID)
+	}
+	return strategy, nil
+}
+
+// evmNetworkStrategyConfig builds the strategy configuration of a known network
+func evmNetworkStrategyConfig(family, rpcURL, privateKeyHex, contractAddress, validatorID string, chainID int64, confirmations int) (*EVMStrategyConfig, error) {
+	profile, ok := evmNetworkProfiles[chainID]
+	if !ok || profile.family != family {
+		return nil, fmt.Errorf("chain ID %d is not a %s network", chainID, family)
+	}
+	if contractAddress == "" {
+		return nil, fmt.Errorf("%s anchor contract address is required", profile.networkName)
+	}
+	if confirmations < 0 {
+		return nil, fmt.Errorf("%s confirmations cannot be negative", profile.networkName)
+	}
+	if confirmations == 0 {
+		confirmations = profile.confirmations
+	}
+
+	config := DefaultEVMStrategyConfig()
+	config.ChainConfig = &ChainConfig{
+		Platform:              ChainPlatformEVM,
+		ChainID:               fmt.Sprintf("%d", chainID),
+		NetworkName:           profile.networkName,
+		RPC:                   rpcURL,
+		ContractAddress:       contractAddress,
+		RequiredConfirmations: confirmations,
+		Enabled:               true,
+	}
+	config.PrivateKeyHex = privateKeyHex
+	config.AnchorContractAddress = contractAddress
+	config.PollingInterval = profile.pollingInterval
+	config.ValidatorID = validatorID
+	return config, nil
+}
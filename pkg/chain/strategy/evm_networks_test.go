@@ -0,0 +1,75 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for EVM Network Profiles
+// Tests per-network chain IDs, names and confirmation counts for Base and Polygon
+
+package strategy
+
+import (
+	"testing"
+	"time"
+)
+
+const testAnchorContract = "0x1234567890123456789012345678901234567890"
+
+func TestEVMNetworkStrategyConfig_Defaults(t *testing.T) {
+	tests := []struct {
+		family        string
+		chainID       int64
+		wantName      string
+		wantConfirmed int
+	}{
+		{"base", BaseMainnetChainID, "base", 10},
+		{"base", BaseSepoliaChainID, "base-sepolia", 5},
+		{"polygon", PolygonMainnetChainID, "polygon", 128},
+		{"polygon", PolygonAmoyChainID, "polygon-amoy", 64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantName, func(t *testing.T) {
+			config, err := evmNetworkStrategyConfig(tt.family, "http://rpc", "", testAnchorContract, "validator-1", tt.chainID, 0)
+			if err != nil {
+				t.Fatalf("evmNetworkStrategyConfig failed: %v", err)
+			}
+			chainConfig := config.ChainConfig
+			if chainConfig.NetworkName != tt.wantName || chainConfig.RequiredConfirmations != tt.wantConfirmed {
+				t.Errorf("expected %s with %d confirmations, got %s with %d",
+					tt.wantName, tt.wantConfirmed, chainConfig.NetworkName, chainConfig.RequiredConfirmations)
+			}
+			if chainConfig.ContractAddress != testAnchorContract || config.AnchorContractAddress != testAnchorContract {
+				t.Errorf("expected the per-network anchor contract, got %s", chainConfig.ContractAddress)
+			}
+			if config.PollingInterval != 2*time.Second {
+				t.Errorf("expected a 2s polling interval, got %s", config.PollingInterval)
+			}
+		})
+	}
+}
+
+func TestEVMNetworkStrategyConfig_Overrides(t *testing.T) {
+	config, err := evmNetworkStrategyConfig("polygon", "http://rpc", "", testAnchorContract, "validator-1", PolygonMainnetChainID, 256)
+	if err != nil {
+		t.Fatalf("evmNetworkStrategyConfig failed: %v", err)
+	}
+	if config.ChainConfig.RequiredConfirmations != 256 || config.ChainConfig.ChainID != "137" {
+		t.Errorf("expected chain 137 with 256 confirmations, got %s with %d",
+			config.ChainConfig.ChainID, config.ChainConfig.RequiredConfirmations)
+	}
+
+	invalid := []struct {
+		name          string
+		family        string
+		chainID       int64
+		contract      string
+		confirmations int
+	}{
+		{"chain ID of another family", "base", PolygonMainnetChainID, testAnchorContract, 0},
+		{"unknown chain ID", "polygon", 1, testAnchorContract, 0},
+		{"missing contract", "base", BaseMainnetChainID, "", 0},
+		{"negative confirmations", "base", BaseMainnetChainID, testAnchorContract, -1},
+	}
+	for _, tt := range invalid {
+		if _, err := evmNetworkStrategyConfig(tt.family, "http://rpc", "", tt.contract, "validator-1", tt.chainID, tt.confirmations); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
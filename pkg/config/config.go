@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strconv"
@@ -18,13 +20,40 @@ type Config struct {
 	AccumulateCometBVN1 string // CometBFT endpoint for BVN1
 	AccumulateCometBVN2 string // CometBFT endpoint for BVN2
 	AccumulateCometBVN3 string // CometBFT endpoint for BVN3 (Kermit network)
-	EthereumURL        string
+	EthereumURL        string   // Primary RPC endpoint: the first entry of ETHEREUM_URL
+	EthereumURLs       []string // ETHEREUM_URL entries: the primary, then fallbacks in order
 	EthChainID         int64
 
+	// Endpoint failover between the ETHEREUM_URL entries
+	EthFailoverThreshold    int           // Consecutive endpoint errors before switching to the next endpoint
+	EthPrimaryRetryInterval time.Duration // Time on a fallback before trying the primary again
+
+	// Contract event watcher checkpointing
+	EventWatcherCheckpointPath   string // File holding the last scanned block (empty = scan BlockLookback blocks on start)
+	EventWatcherMaxCatchUpBlocks int64  // Furthest behind the head a checkpoint may resume from
+
+	// Anchor transaction pricing: EIP-1559 type-2 transactions unless EthUseLegacyGas is
+	// set or the chain has no base fee
+	EthUseLegacyGas      bool  // Price anchors with a legacy gas price
+	EthMaxPriorityFeeWei int64 // maxPriorityFeePerGas (0 = eth_maxPriorityFeePerGas suggestion)
+	EthBaseFeeMultiplier int   // maxFeePerGas headroom: latest base fee x multiplier + tip
+
+	// Additional EVM anchor networks, enabled by setting their RPC URL. Anchors are
+	// signed with EthPrivateKey; chain ID and confirmations of 0 use network defaults
+	BaseRPC               string
+	BaseChainID           int64 // 8453 (mainnet) or 84532 (Base Sepolia)
+	BaseAnchorContract    string
+	BaseConfirmations     int
+	PolygonRPC            string
+	PolygonChainID        int64 // 137 (mainnet) or 80002 (Amoy)
+	PolygonAnchorContract string
+	PolygonConfirmations  int
+
 	// Server Configuration
 	ListenAddr   string
 	MetricsAddr  string
 	HealthAddr   string
+	MetricsEnabled bool // Serve Prometheus batch/proof metrics on /metrics
 
 	// Database Configuration (URL-based, legacy)
 	DatabaseURL         string
@@ -41,9 +70,16 @@ type Config struct {
 	DBPassword       string
 	DBName           string
 	DBSSLMode        string
-	DBMaxOpenConns   int
-	DBMaxIdleConns   int
-	DBConnMaxLifetime time.Duration
+	DBMaxOpenConns   int           // Pool size (defaults to DATABASE_MAX_CONNS)
+	DBMaxIdleConns   int           // Idle connections kept open (defaults to DATABASE_MIN_CONNS)
+	DBConnMaxLifetime time.Duration // Connections are recycled after this long (defaults to DATABASE_MAX_LIFETIME)
+
+	// Database Circuit Breaker
+	// Fails batch operations fast while PostgreSQL is unreachable instead of waiting on the pool
+	DBBreakerEnabled       bool          // Ping the database and open the breaker on repeated failures
+	DBBreakerFailures      int           // Consecutive failed pings that open the breaker
+	DBBreakerCheckInterval time.Duration // Time between pings (also the Retry-After of rejected requests)
+	DBBreakerPingTimeout   time.Duration // Timeout of each ping
 
 	// Blockchain Configuration
 	EthPrivateKey     string
@@ -53,10 +89,21 @@ type Config struct {
 	Ed25519KeyPath string // Path to Ed25519 private key file
 	DataDir        string // Base directory for data files
 
+	// Key Backend Configuration
+	// Where the validator's Ed25519 signing key lives: a local file, or Google Cloud KMS
+	// where the key never leaves the KMS/HSM
+	KeyBackend            string        // file (default) or gcp-kms
+	GCPKMSKeyName         string        // Crypto key version resource name (EC_SIGN_ED25519)
+	GCPKMSCredentialsFile string        // Service account JSON ("" = application default credentials)
+	GCPKMSSignTimeout     time.Duration // Timeout of each KMS signing request
+
 	// Contract Addresses
 	AnchorContractAddress     string
 	AccountAbstractionAddress string
 	CertenContractAddress     string
+	BLSZKVerifierAddress      string // Optional: checked at startup when set
+	GovernanceVerifierAddress string // Optional: checked at startup when set
+	ValidateContractAddresses bool   // Fail startup unless configured contract addresses have deployed code
 
 	// Service Configuration
 	ValidatorID   string
@@ -72,13 +119,215 @@ type Config struct {
 	NetworkName string // Network name for anchoring (e.g., "mainnet", "sepolia", "devnet")
 
 	// Governance Proof Configuration
-	GovProofCLIPath string // Path to govproof CLI binary (optional - enables real G0/G1/G2 proofs)
+	GovProofCLIPath string // Path to govproof CLI binary (optional - proofs are generated in-process when unset)
 	GovProofWorkDir string // Working directory for governance proof artifacts
+	GovernanceBatchDedup bool // Build G1 authority snapshots once per (key page, version) per batch
+	GovProofCacheSize    int           // Completed governance proofs kept in the LRU result cache (0 disables)
+	GovProofCacheTTL     time.Duration // How long a cached governance proof is reused
 
 	// Multi-Validator Attestation Configuration
 	// Per Whitepaper Section 3.4.1 Component 4: Validator attestations
-	AttestationPeers         []string // URLs of peer validators for attestation collection
-	AttestationRequiredCount int      // Number of attestations required (2f+1)
+	AttestationPeers              []string      // URLs of peer validators for attestation collection
+	AttestationRequiredCount      int           // Number of attestations required (2f+1)
+	AttestationPeerHealthInterval time.Duration // Time between attestation peer health checks
+	AttestationPeerHealthTimeout  time.Duration // Timeout of a single peer health check
+	AttestationPeerMaxFailures    int           // Consecutive failed checks before a peer is skipped
+	AttestationTLSCert            string        // PEM certificate presented to peers (mTLS)
+	AttestationTLSKey             string        // PEM private key for AttestationTLSCert
+	AttestationCABundle           string        // PEM CA bundle peer certificates must chain to
+
+	// Peer attestations are only accepted from these validators, signed with their key
+	AttestationPeerKeys map[string]string // Validator ID -> hex Ed25519 public key
+
+	// Collection threshold: "count" (AttestationRequiredCount) or "voting_power", a
+	// fraction of the on-chain validator set's voting power as set with setThreshold
+	AttestationThresholdMode        string
+	AttestationThresholdNumerator   int
+	AttestationThresholdDenominator int
+
+	// Proof-Work Partitioning Configuration
+	// Splits intent proof generation across validators (intent hash modulo validator count)
+	ValidatorSet          []string      // IDs of all validators - MUST be identical on every validator
+	ProofWorkPartitioning bool          // Enable proof-work partitioning
+	ProofShareWait        time.Duration // How long non-owners wait for a shared proof before generating locally
+
+	// Anchor Finality Configuration
+	// "available" = anchor tx mined with N confirmations, "final" = full required confirmations
+	AnchorAvailableConfirmations int // Confirmations before anchors/proofs are "available"
+	AnchorFinalConfirmations     int // Confirmations before anchors/proofs are "final"
+
+	// Intent Finality Deferral Configuration
+	// Intents whose Accumulate transaction is not yet final are retried, then dead-lettered
+	IntentFinalityRetryDelay   time.Duration // Delay before retrying a deferred intent
+	IntentMaxFinalityDeferrals int           // Deferrals before an intent is dead-lettered
+
+	// Intent Dedup Retention Configuration
+	// Processed intents are remembered (and persisted) to skip rediscovered duplicates
+	IntentDedupMaxAge     time.Duration // Retention by age (0 = no age bound); at least the deferral horizon
+	IntentDedupMaxEntries int           // Retention by size (0 = no size bound)
+
+	// Intent Discovery Cold Start Configuration
+	// Where a validator with no persisted last processed block starts scanning
+	IntentColdStartPolicy   string // "genesis", "head" or "lookback"
+	IntentColdStartLookback int64  // Blocks behind head for the lookback policy
+
+	// Intent Priority Configuration
+	// Rules "<type|class|tag|account>:<value>=<priority>" for intents without a priority hint
+	IntentPriorityRules []string
+
+	// Synthetic Transaction Configuration
+	// Synthetic transactions produced by an intent's transaction are proven and anchored linked to it
+	SyntheticTxEnabled      bool          // Discover, prove and anchor synthetic transactions
+	SyntheticTxProofClass   string        // "on_cadence", "on_demand" or "" to inherit the intent's class
+	SyntheticTxMaxPerIntent int           // Synthetic transactions anchored per intent (0 = unbounded)
+	SyntheticTxFinalityWait time.Duration // How long to wait for a pending synthetic transaction to be delivered
+
+	// Governance Verifier Policy Configuration
+	// Behavior when the anchor contract has no governance verifier set and initialized
+	GovernanceVerifierPolicy        string        // "warn" (submit governance data, warn) or "skip" (omit it)
+	GovernanceVerifierCheckInterval time.Duration // How often to re-read getGovernanceVerifierStatus
+
+	// Comprehensive Proof Size Limits Configuration
+	// Oversized proofs are rejected before executeComprehensiveProof is submitted (0 = unlimited)
+	AnchorMaxProofHashes   int // Maximum Merkle path length (proofHashes)
+	AnchorMaxCalldataBytes int // Maximum ABI-encoded calldata size in bytes
+
+	// Merkle Root Guard Configuration
+	// Refuse to anchor empty, all-zero or zero-leaf Merkle roots (batch construction errors)
+	AnchorMerkleRootGuard bool
+
+	// Shadow Mode Configuration
+	// Run the full pipeline - gas estimation, proof assembly, write-back signing - but log and
+	// record what would be submitted instead of sending any Ethereum or Accumulate transaction
+	ShadowMode bool
+
+	// Anchor Retry Configuration
+	// Resubmits batch anchors after transient RPC, nonce and mempool failures
+	AnchorRetryMaxAttempts    int           // Submissions including the first (1 = no retries)
+	AnchorRetryInitialBackoff time.Duration // Wait before the first retry, doubled per retry
+	AnchorRetryMaxBackoff     time.Duration // Upper bound on the wait between retries
+
+	// Batch Interval Configuration
+	// Cadence per proof class; the whitepaper default is ~15 minute on-cadence batches
+	OnCadenceBatchInterval time.Duration // How long an on-cadence batch collects before closing (at least 1m)
+	OnDemandMaxWait        time.Duration // Longest an on-demand batch waits for more transactions
+	BatchStallGracePeriod  time.Duration // Age beyond the interval after which an on-cadence batch is reported stalled
+
+	// Batch Close Staggering Configuration
+	// Desynchronizes on-cadence batch closes (and anchoring) across validators
+	BatchPhaseSpread time.Duration // Per-validator phase offsets are spread over [0, spread)
+	BatchCloseJitter time.Duration // Random per-batch offset in [0, jitter)
+
+	// Gas Window Configuration
+	// Holds on-cadence batches while gas is above target; on-demand always anchors immediately
+	GasWindowEnabled       bool          // Defer on-cadence anchoring during high gas
+	GasWindowTargetGwei    int64         // Anchor held batches once gas is at or below this
+	GasWindowMaxDelay      time.Duration // Anchor a held batch after this long regardless of gas
+	GasWindowCheckInterval time.Duration // How often held batches are re-evaluated
+
+	// Fee Oracle Configuration
+	// Live gas price and ETH/USD price for USD figures in the cost endpoints; the static
+	// price tiers are served when the oracle is disabled or unreachable
+	FeeOracleEnabled          bool          // Price cost endpoints from live gas and ETH/USD prices
+	FeeOracleSource           string        // ETH/USD feed: "chainlink" or "http"
+	FeeOracleChainlinkAddress string        // Chainlink ETH/USD aggregator contract (source=chainlink)
+	FeeOracleHTTPURL          string        // JSON endpoint serving the ETH/USD price (source=http)
+	FeeOracleHTTPPricePath    string        // Dot-separated path to the price in the JSON response
+	FeeOracleCacheTTL         time.Duration // How long a quote is reused before the feed is queried again
+	FeeOracleBatchGas         int64         // Gas per anchored batch (createAnchor + executeComprehensiveProof)
+	FeeOracleOnCadenceProofs  int           // Typical proofs per on-cadence batch, sharing its gas
+
+	// On-Demand Fee Escalation Configuration
+	// Unconfirmed on-demand anchors are replaced (same nonce) at rising gas prices
+	OnDemandFeeEscalationEnabled     bool          // Anchor on-demand batches on the escalation schedule
+	OnDemandFeeEscalationInterval    time.Duration // Time to wait for confirmation before each replacement
+	OnDemandFeeEscalationBumpPercent int           // Gas price increase per replacement (at least 10)
+	OnDemandFeeEscalationMaxSteps    int           // Replacements after the initial submission
+	OnDemandFeeEscalationMaxGwei     int64         // Gas price ceiling
+
+	// Stuck Anchor Transaction Replacement Configuration
+	// Anchors without an on-demand escalation schedule are replaced (same nonce) at a
+	// higher gas price when unconfirmed after the timeout
+	AnchorStuckTxReplaceEnabled bool          // Replace stuck anchor transactions
+	AnchorStuckTxTimeout        time.Duration // Time without confirmation before each replacement
+	AnchorStuckTxBumpPercent    int           // Gas price increase per replacement (at least 10)
+	AnchorStuckTxMaxBumps       int           // Replacements after the initial submission
+	AnchorStuckTxMaxGwei        int64         // Gas price ceiling
+
+	// On-Demand Abandonment Configuration
+	// On-demand batches that never anchor are moved to the terminal abandoned state
+	OnDemandAbandonEnabled       bool          // Sweep for on-demand batches that never anchored
+	OnDemandAbandonAfter         time.Duration // Idle time after which an unanchored batch is abandoned
+	OnDemandAbandonCheckInterval time.Duration // How often stale batches are swept
+	OnDemandAbandonRefundUsage   bool          // Refund metered usage of abandoned requests
+
+	// Proof Retention Configuration
+	// Batches and proofs whose anchors were final longer than the retention window are
+	// moved to archive tables; archived proofs are still served by ID
+	ProofRetentionDays     int           // Days after anchor finality before archival (0 keeps everything)
+	ProofArchiveInterval   time.Duration // How often archivable batches are swept
+	ProofArchiveBatchLimit int           // Batches archived per sweep
+
+	// Proof Regeneration Configuration
+	// Rebuilds stored proofs that fail the contract's verifyCertenProofDetailed check
+	ProofAutoRegenerate       bool          // Rebuild the batch inclusion proof of proofs that fail on-chain verification
+	ProofRegenerateCooldown   time.Duration // Minimum time between regenerations of the same proof
+	ProofRegenerateMaxPerHour int           // Maximum regenerations across all proofs per hour
+
+	// Proof Verification Failure Configuration
+	// ProofVerificationFailed contract events are persisted and correlated to local proofs
+	ProofFailureHealthWindow   time.Duration // Failures within this window degrade health
+	ProofFailureAlertThreshold int           // Failures within ProofFailureAlertWindow that raise an alert (0 = no alerts)
+	ProofFailureAlertWindow    time.Duration // Window for ProofFailureAlertThreshold
+
+	// Anchor Reconciliation Configuration
+	// On startup, marks batches anchored when their anchor already exists on-chain
+	AnchorReconcileOnStartup bool          // Check ambiguous batches against the contract on startup
+	AnchorReconcileMaxAge    time.Duration // Only batches updated within this window are checked (0 = all)
+
+	// Anchor State Reconciliation Configuration
+	// Periodically compares sampled anchor records with the contract and flags discrepancies
+	AnchorStateReconcileEnabled    bool          // Run the background anchor state reconciliation
+	AnchorStateReconcileInterval   time.Duration // How often anchors are sampled and checked
+	AnchorStateReconcileSampleSize int           // Anchors sampled per check
+	AnchorStateReconcileMinAge     time.Duration // Anchors younger than this are not sampled
+
+	// Proof Usage Metering Configuration
+	// Per-account proof counts for billing, with optional quotas
+	ProofMeteringEnabled bool   // Count proofs per account (persisted in account_usage_meters)
+	ProofQuotaWindows    string // Metering windows with optional quotas, e.g. "1h:100,24h:1000,720h"
+	ProofQuotaAction     string // "reject" or "queue" requests from accounts over quota
+	ProofQuotaMaxQueued  int    // Maximum queued over-quota requests across all accounts
+
+	// Anchor Receipt Configuration
+	// Signed proof-of-anchoring receipts served at /api/anchors/:id/receipt
+	AnchorReceiptsEnabled bool   // Serve receipts signed with the validator's Ed25519 key
+	AnchorReceiptFinality string // Minimum anchor finality for a receipt: "available" or "final"
+
+	// Shutdown Configuration
+	// Each shutdown stage is abandoned once its timeout elapses
+	ShutdownStageTimeout  time.Duration // Default timeout for every shutdown stage
+	ShutdownFlushTimeout  time.Duration // Timeout for closing and handing off open batches
+	ShutdownAnchorTimeout time.Duration // Timeout for in-flight anchor submissions to finish
+	ShutdownDrainTimeout  time.Duration // Timeout for draining the open on-cadence batch to an anchor submission
+
+	// Consensus Peer Health Configuration
+	// Health is degraded while fewer CometBFT peers than the minimum are connected
+	HealthMinPeers             int           // Minimum connected consensus peers (0 = not part of health)
+	HealthPeerCheckInterval    time.Duration // How often the peer count is sampled
+	HealthConsensusStallWindow time.Duration // No commit within this window with pending work reports consensus stalled
+
+	// State Sync Configuration
+	// Validators snapshot the ValidatorApp state so a new or lagging node can restore it
+	// from a peer instead of replaying every block
+	StateSyncSnapshotDir        string        // Snapshot directory (one subdirectory per validator)
+	StateSyncSnapshotInterval   int           // Take a snapshot every this many blocks (0 disables)
+	StateSyncSnapshotKeepRecent int           // Snapshots kept on disk
+	StateSyncEnable             bool          // Restore a node without local state from a peer snapshot
+	StateSyncRPCServers         []string      // CometBFT RPC endpoints for light client verification (at least 2)
+	StateSyncTrustHeight        int64         // Trusted block height for the light client
+	StateSyncTrustHash          string        // Hex hash of the block at the trusted height
+	StateSyncTrustPeriod        time.Duration // How long the trusted block stays trusted
 
 	// Security Configuration
 	JWTSecret   string
@@ -101,6 +350,71 @@ type Config struct {
 	EnableUnifiedTables    bool   // Write to unified PostgreSQL tables
 	FallbackToLegacy       bool   // Fall back to legacy if unified fails
 	DefaultTargetChain     string // Default target chain (e.g., "ethereum", "sepolia")
+
+	// Proof Cycle Concurrency
+	// Excess cycles queue; on-demand cycles are admitted before on-cadence ones
+	MaxConcurrentProofCycles int // Proof cycles running at once (0 = unlimited)
+
+	// Proof Cycle Audit Log
+	// Append-only, hash-chained record of proof-cycle decisions for compliance
+	AuditLogPath string // JSON-lines audit log file (empty = disabled)
+
+	// Proof Result Write-Back Format
+	// Structure of the result written to the Accumulate results account
+	WriteBackEncoding       string   // "key_value" (one key=value entry per field) or "json"
+	WriteBackFields         []string // Fields to write, "field" or "field:key" (empty = all)
+	WriteBackRequiredFields []string // Fields that must be non-empty before submission
+
+	// Batch Merkle Leaf Encoding
+	// Leaf preimage layout must match the deployed verifier (see batch.LeafEncoding)
+	BatchLeafEncoding          string   // Default mode, e.g. "raw_tx_hash"
+	BatchLeafEncodingOverrides []string // "<contract-or-chain-id>=<mode>" per anchoring target
+
+	// Batch IDs
+	// Deterministic IDs let validators batching the same transactions agree on the ID
+	DeterministicBatchID bool // Derive batch IDs from tx hashes and block height on close (default random)
+
+	// Batch Transaction Dedup
+	// Re-delivered transactions already in a batch are rejected (cache + database check)
+	BatchDedupCacheSize int           // Recently batched transactions remembered in memory
+	BatchDedupCacheTTL  time.Duration // How long they are remembered
+
+	// On-Demand Rate Limiting
+	// Token bucket per account URL and/or client IP on POST /api/anchors/on-demand
+	OnDemandRateLimit   int      // Sustained requests per minute per key (0 = unlimited)
+	OnDemandRateBurst   int      // Requests allowed in a burst per key
+	OnDemandRateLimitBy []string // Keys to limit by: "account", "ip"
+
+	// Validator Identity
+	IdentityEndpointEnabled bool // Serve GET /api/v1/identity
+
+	// Admin API
+	AdminAPIToken string // Bearer token for /api/admin/* diagnostics (empty = disabled)
+
+	// Validator Maintenance
+	// Admin-triggered deregistration from the on-chain validator set before a planned
+	// shutdown, with re-registration on restart. Opt-in; requires an admin token.
+	ValidatorMaintenanceEnabled      bool   // Register the /api/admin/maintenance endpoint
+	ValidatorMaintenanceAdminToken   string // Bearer token for the admin endpoint
+	ValidatorMaintenanceStatePath    string // File holding the pending re-registration
+	ValidatorMaintenanceMinRemaining int    // Validators that must remain registered
+
+	// Validator Voting Power
+	// Reads this validator's voting power from the contract and follows ValidatorRegistered
+	// events, so BLS proof data reports the current on-chain value
+	VotingPowerTrackingEnabled bool   // Replace the assumed voting power in BLS proof data
+	VotingPowerChangePolicy    string // "follow" (adopt a changed voting power) or "pin" (keep the startup value, warn)
+
+	// Validator Set Sync
+	// Builds the validator set used for attestation thresholds and consensus quorum from
+	// the contract's registered validators instead of the configured count
+	ValidatorSetSyncEnabled  bool          // Read the validator set from CertenAnchorV3
+	ValidatorSetSyncInterval time.Duration // Time between validator set refreshes
+	ValidatorSetIDs          []string      // "<validator-id>=<address>" naming on-chain validators
+
+	// Debug Proof Field Overrides (integration testing against mock verifiers only)
+	// Requires a binary built with -tags certen_proof_overrides; refused on Ethereum mainnet
+	DebugProofOverrides string // Comma-separated field=value pairs, e.g. "blsProof.thresholdMet=false"
 }
 
 // Load reads configuration from environment variables
@@ -125,13 +439,31 @@ func Load() (*Config, error) {
 		AccumulateCometBVN1: getEnv("ACCUMULATE_COMET_BVN1", ""), // BVN1 CometBFT endpoint
 		AccumulateCometBVN2: getEnv("ACCUMULATE_COMET_BVN2", ""), // BVN2 CometBFT endpoint
 		AccumulateCometBVN3: getEnv("ACCUMULATE_COMET_BVN3", ""), // BVN3 CometBFT endpoint (Kermit)
-		EthereumURL:        getEnv("ETHEREUM_URL", ""),
+		EthereumURLs:       parseList(getEnv("ETHEREUM_URL", "")),
 		EthChainID:         getEnvInt64("ETH_CHAIN_ID", 11155111),
+		EthFailoverThreshold:    getEnvInt("ETH_FAILOVER_THRESHOLD", 3),
+		EthPrimaryRetryInterval: getEnvDuration("ETH_PRIMARY_RETRY_INTERVAL", 5*time.Minute),
+		EventWatcherCheckpointPath:   getEnv("EVENT_WATCHER_CHECKPOINT_PATH", "data/event_watcher_checkpoint.json"),
+		EventWatcherMaxCatchUpBlocks: getEnvInt64("EVENT_WATCHER_MAX_CATCHUP_BLOCKS", 5000),
+		EthUseLegacyGas:      getEnvBool("ETH_USE_LEGACY_GAS", false),
+		EthMaxPriorityFeeWei: getEnvInt64("ETH_MAX_PRIORITY_FEE_WEI", 1500000000), // 1.5 gwei
+		EthBaseFeeMultiplier: getEnvInt("ETH_BASE_FEE_MULTIPLIER", 2),
+
+		// Additional EVM anchor networks - disabled unless their RPC URL is set
+		BaseRPC:               getEnv("BASE_RPC_URL", ""),
+		BaseChainID:           getEnvInt64("BASE_CHAIN_ID", 0),
+		BaseAnchorContract:    getEnv("BASE_ANCHOR_CONTRACT", ""),
+		BaseConfirmations:     getEnvInt("BASE_CONFIRMATIONS", 0),
+		PolygonRPC:            getEnv("POLYGON_RPC_URL", ""),
+		PolygonChainID:        getEnvInt64("POLYGON_CHAIN_ID", 0),
+		PolygonAnchorContract: getEnv("POLYGON_ANCHOR_CONTRACT", ""),
+		PolygonConfirmations:  getEnvInt("POLYGON_CONFIRMATIONS", 0),
 
 		// Server Configuration - safe defaults
 		ListenAddr:  getEnv("API_HOST", "0.0.0.0") + ":" + getEnv("API_PORT", "8080"),
 		MetricsAddr: getEnv("API_HOST", "0.0.0.0") + ":" + getEnv("METRICS_PORT", "9090"),
 		HealthAddr:  getEnv("API_HOST", "0.0.0.0") + ":" + getEnv("HEALTH_CHECK_PORT", "8081"),
+		MetricsEnabled: getEnvBool("METRICS_ENABLED", false),
 
 		// Database Configuration - REQUIRED, no default for security
 		DatabaseURL:         getEnv("DATABASE_URL", ""),
@@ -148,9 +480,15 @@ func Load() (*Config, error) {
 		DBPassword:        getEnv("DB_PASSWORD", ""),
 		DBName:            getEnv("DB_NAME", "certen_validator"),
 		DBSSLMode:         getEnv("DB_SSL_MODE", "require"),
-		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
-		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
-		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", time.Hour),
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", getEnvInt("DATABASE_MAX_CONNS", 25)),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", getEnvInt("DATABASE_MIN_CONNS", 5)),
+		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", time.Duration(getEnvInt("DATABASE_MAX_LIFETIME", 3600))*time.Second),
+
+		// Database Circuit Breaker
+		DBBreakerEnabled:       getEnvBool("DB_BREAKER_ENABLED", true),
+		DBBreakerFailures:      getEnvInt("DB_BREAKER_FAILURES", 3),
+		DBBreakerCheckInterval: getEnvDuration("DB_BREAKER_CHECK_INTERVAL", 10*time.Second),
+		DBBreakerPingTimeout:   getEnvDuration("DB_BREAKER_PING_TIMEOUT", 2*time.Second),
 
 		// Blockchain Configuration - REQUIRED for production
 		EthPrivateKey:     getEnv("ETH_PRIVATE_KEY", ""),
@@ -160,10 +498,19 @@ func Load() (*Config, error) {
 		Ed25519KeyPath: getEnv("ED25519_KEY_PATH", ""),         // Optional: Custom path to Ed25519 key file
 		DataDir:        getEnv("DATA_DIR", "./data"),           // Base directory for data files
 
+		// Key Backend Configuration (file-backed by default)
+		KeyBackend:            getEnv("KEY_BACKEND", "file"),
+		GCPKMSKeyName:         getEnv("GCP_KMS_KEY_NAME", ""),
+		GCPKMSCredentialsFile: getEnv("GCP_KMS_CREDENTIALS_FILE", ""),
+		GCPKMSSignTimeout:     getEnvDuration("GCP_KMS_SIGN_TIMEOUT", 10*time.Second),
+
 		// Contract Addresses
 		AnchorContractAddress:     getEnv("ANCHOR_CONTRACT_ADDRESS", ""),
 		AccountAbstractionAddress: getEnv("ACCOUNT_ABSTRACTION_ADDRESS", ""),
 		CertenContractAddress:     getEnv("CERTEN_CONTRACT_ADDRESS", ""),
+		BLSZKVerifierAddress:      getEnv("BLS_ZK_VERIFIER_ADDRESS", ""),
+		GovernanceVerifierAddress: getEnv("GOVERNANCE_VERIFIER_ADDRESS", ""),
+		ValidateContractAddresses: getEnvBool("VALIDATE_CONTRACT_ADDRESSES", true),
 
 		// Service Configuration
 		ValidatorID:   getEnv("VALIDATOR_ID", "validator-default"),
@@ -178,13 +525,178 @@ func Load() (*Config, error) {
 		// Network Identification
 		NetworkName: getEnv("NETWORK_NAME", "devnet"),
 
-		// Governance Proof Configuration (optional - enables real G0/G1/G2 proofs)
+		// Governance Proof Configuration (CLI optional - in-process G0/G1/G2 proofs by default)
 		GovProofCLIPath: getEnv("GOV_PROOF_CLI_PATH", ""), // Path to compiled govproof binary
 		GovProofWorkDir: getEnv("GOV_PROOF_WORK_DIR", "/tmp/gov_proofs"),
+		GovernanceBatchDedup: getEnvBool("GOVERNANCE_BATCH_DEDUP", true),
+		GovProofCacheSize:    getEnvInt("GOV_PROOF_CACHE_SIZE", 1024),
+		GovProofCacheTTL:     getEnvDuration("GOV_PROOF_CACHE_TTL", 10*time.Minute),
 
 		// Multi-Validator Attestation Configuration
-		AttestationPeers:         parseAttestationPeers(getEnv("ATTESTATION_PEERS", "")),
-		AttestationRequiredCount: getEnvInt("ATTESTATION_REQUIRED_COUNT", 3), // 2f+1 for f=1
+		AttestationPeers:              parseAttestationPeers(getEnv("ATTESTATION_PEERS", "")),
+		AttestationRequiredCount:      getEnvInt("ATTESTATION_REQUIRED_COUNT", 3), // 2f+1 for f=1
+		AttestationPeerKeys:           parseAttestationPeerKeys(getEnv("ATTESTATION_PEER_KEYS", "")),
+		AttestationPeerHealthInterval: getEnvDuration("ATTESTATION_PEER_HEALTH_INTERVAL", 15*time.Second),
+		AttestationPeerHealthTimeout:  getEnvDuration("ATTESTATION_PEER_HEALTH_TIMEOUT", 5*time.Second),
+		AttestationPeerMaxFailures:    getEnvInt("ATTESTATION_PEER_MAX_FAILURES", 2),
+		AttestationTLSCert:            getEnv("ATTESTATION_TLS_CERT", ""),
+		AttestationTLSKey:             getEnv("ATTESTATION_TLS_KEY", ""),
+		AttestationCABundle:           getEnv("ATTESTATION_CA_BUNDLE", ""),
+
+		// Collection threshold (count or voting_power), default 2/3 as in the contract
+		AttestationThresholdMode:        getEnv("ATTESTATION_THRESHOLD_MODE", "count"),
+		AttestationThresholdNumerator:   getEnvInt("ATTESTATION_THRESHOLD_NUMERATOR", 2),
+		AttestationThresholdDenominator: getEnvInt("ATTESTATION_THRESHOLD_DENOMINATOR", 3),
+
+		// Proof-Work Partitioning Configuration (disabled by default)
+		ValidatorSet:          parseList(getEnv("VALIDATOR_SET", "")),
+		ProofWorkPartitioning: getEnvBool("PROOF_WORK_PARTITIONING", false),
+		ProofShareWait:        getEnvDuration("PROOF_SHARE_WAIT", 20*time.Second),
+
+		// Anchor Finality Configuration
+		AnchorAvailableConfirmations: getEnvInt("ANCHOR_AVAILABLE_CONFIRMATIONS", 1),
+		AnchorFinalConfirmations:     getEnvInt("ANCHOR_FINAL_CONFIRMATIONS", 12), // Standard Ethereum finality
+
+		// Intent Finality Deferral Configuration
+		IntentFinalityRetryDelay:   getEnvDuration("INTENT_FINALITY_RETRY_DELAY", 30*time.Second),
+		IntentMaxFinalityDeferrals: getEnvInt("INTENT_MAX_FINALITY_DEFERRALS", 10),
+
+		// Intent Dedup Retention Configuration
+		IntentDedupMaxAge:     getEnvDuration("INTENT_DEDUP_MAX_AGE", 24*time.Hour),
+		IntentDedupMaxEntries: getEnvInt("INTENT_DEDUP_MAX_ENTRIES", 100000),
+
+		// Intent Discovery Cold Start Configuration
+		IntentColdStartPolicy:   getEnv("INTENT_COLD_START_POLICY", "lookback"),
+		IntentColdStartLookback: getEnvInt64("INTENT_COLD_START_LOOKBACK", 5),
+
+		// Intent Priority Configuration
+		IntentPriorityRules: parseList(getEnv("INTENT_PRIORITY_RULES", "")),
+
+		// Synthetic Transaction Configuration (disabled by default)
+		SyntheticTxEnabled:      getEnvBool("SYNTHETIC_TX_ENABLED", false),
+		SyntheticTxProofClass:   getEnv("SYNTHETIC_TX_PROOF_CLASS", ""),
+		SyntheticTxMaxPerIntent: getEnvInt("SYNTHETIC_TX_MAX_PER_INTENT", 16),
+		SyntheticTxFinalityWait: getEnvDuration("SYNTHETIC_TX_FINALITY_WAIT", 30*time.Second),
+
+		// Governance Verifier Policy Configuration
+		GovernanceVerifierPolicy:        getEnv("GOVERNANCE_VERIFIER_POLICY", "warn"),
+		GovernanceVerifierCheckInterval: getEnvDuration("GOVERNANCE_VERIFIER_CHECK_INTERVAL", 10*time.Minute),
+
+		// Comprehensive Proof Size Limits Configuration
+		AnchorMaxProofHashes:   getEnvInt("ANCHOR_MAX_PROOF_HASHES", 64),
+		AnchorMaxCalldataBytes: getEnvInt("ANCHOR_MAX_CALLDATA_BYTES", 64*1024),
+
+		// Merkle Root Guard Configuration
+		AnchorMerkleRootGuard: getEnvBool("ANCHOR_MERKLE_ROOT_GUARD", true),
+
+		// Shadow Mode Configuration (disabled by default)
+		ShadowMode: getEnvBool("SHADOW_MODE", false),
+
+		// Anchor Retry Configuration
+		AnchorRetryMaxAttempts:    getEnvInt("ANCHOR_RETRY_MAX_ATTEMPTS", 3),
+		AnchorRetryInitialBackoff: getEnvDuration("ANCHOR_RETRY_INITIAL_BACKOFF", 5*time.Second),
+		AnchorRetryMaxBackoff:     getEnvDuration("ANCHOR_RETRY_MAX_BACKOFF", time.Minute),
+
+		// Batch Interval Configuration
+		OnCadenceBatchInterval: getEnvDuration("ON_CADENCE_BATCH_INTERVAL", 15*time.Minute),
+		OnDemandMaxWait:        getEnvDuration("ON_DEMAND_MAX_WAIT", 30*time.Second),
+		BatchStallGracePeriod:  getEnvDuration("BATCH_STALL_GRACE_PERIOD", 5*time.Minute),
+
+		// Batch Close Staggering Configuration (set both to 0 to close exactly on the interval)
+		BatchPhaseSpread: getEnvDuration("BATCH_PHASE_SPREAD", 2*time.Minute),
+		BatchCloseJitter: getEnvDuration("BATCH_CLOSE_JITTER", 30*time.Second),
+
+		// Gas Window Configuration (disabled by default)
+		GasWindowEnabled:       getEnvBool("GAS_WINDOW_ENABLED", false),
+		GasWindowTargetGwei:    getEnvInt64("GAS_WINDOW_TARGET_GWEI", 20),
+		GasWindowMaxDelay:      getEnvDuration("GAS_WINDOW_MAX_DELAY", 6*time.Hour),
+		GasWindowCheckInterval: getEnvDuration("GAS_WINDOW_CHECK_INTERVAL", time.Minute),
+
+		// Fee Oracle Configuration (disabled by default - static price tiers)
+		FeeOracleEnabled:          getEnvBool("FEE_ORACLE_ENABLED", false),
+		FeeOracleSource:           getEnv("FEE_ORACLE_SOURCE", "chainlink"),
+		FeeOracleChainlinkAddress: getEnv("FEE_ORACLE_CHAINLINK_ADDRESS", ""),
+		FeeOracleHTTPURL:          getEnv("FEE_ORACLE_HTTP_URL", ""),
+		FeeOracleHTTPPricePath:    getEnv("FEE_ORACLE_HTTP_PRICE_PATH", "ethereum.usd"),
+		FeeOracleCacheTTL:         getEnvDuration("FEE_ORACLE_CACHE_TTL", 5*time.Minute),
+		FeeOracleBatchGas:         getEnvInt64("FEE_ORACLE_BATCH_GAS", 400000),
+		FeeOracleOnCadenceProofs:  getEnvInt("FEE_ORACLE_ON_CADENCE_PROOFS", 50),
+
+		// On-Demand Fee Escalation Configuration (disabled by default)
+		OnDemandFeeEscalationEnabled:     getEnvBool("ON_DEMAND_FEE_ESCALATION_ENABLED", false),
+		OnDemandFeeEscalationInterval:    getEnvDuration("ON_DEMAND_FEE_ESCALATION_INTERVAL", 45*time.Second),
+		OnDemandFeeEscalationBumpPercent: getEnvInt("ON_DEMAND_FEE_ESCALATION_BUMP_PERCENT", 25),
+		OnDemandFeeEscalationMaxSteps:    getEnvInt("ON_DEMAND_FEE_ESCALATION_MAX_STEPS", 5),
+		OnDemandFeeEscalationMaxGwei:     getEnvInt64("ON_DEMAND_FEE_ESCALATION_MAX_GWEI", 200),
+
+		// Stuck Anchor Transaction Replacement Configuration (disabled by default)
+		AnchorStuckTxReplaceEnabled: getEnvBool("ANCHOR_STUCK_TX_REPLACE_ENABLED", false),
+		AnchorStuckTxTimeout:        getEnvDuration("ANCHOR_STUCK_TX_TIMEOUT", 5*time.Minute),
+		AnchorStuckTxBumpPercent:    getEnvInt("ANCHOR_STUCK_TX_BUMP_PERCENT", 20),
+		AnchorStuckTxMaxBumps:       getEnvInt("ANCHOR_STUCK_TX_MAX_BUMPS", 3),
+		AnchorStuckTxMaxGwei:        getEnvInt64("ANCHOR_STUCK_TX_MAX_GWEI", 200),
+
+		// On-Demand Abandonment Configuration
+		OnDemandAbandonEnabled:       getEnvBool("ON_DEMAND_ABANDON_ENABLED", true),
+		OnDemandAbandonAfter:         getEnvDuration("ON_DEMAND_ABANDON_AFTER", 6*time.Hour),
+		OnDemandAbandonCheckInterval: getEnvDuration("ON_DEMAND_ABANDON_CHECK_INTERVAL", 10*time.Minute),
+		OnDemandAbandonRefundUsage:   getEnvBool("ON_DEMAND_ABANDON_REFUND_USAGE", true),
+
+		// Proof Retention Configuration (disabled by default)
+		ProofRetentionDays:     getEnvInt("PROOF_RETENTION_DAYS", 0),
+		ProofArchiveInterval:   getEnvDuration("PROOF_ARCHIVE_INTERVAL", time.Hour),
+		ProofArchiveBatchLimit: getEnvInt("PROOF_ARCHIVE_BATCH_LIMIT", 100),
+
+		// Proof Regeneration Configuration (disabled by default)
+		ProofAutoRegenerate:       getEnvBool("PROOF_AUTO_REGENERATE", false),
+		ProofRegenerateCooldown:   getEnvDuration("PROOF_REGENERATE_COOLDOWN", time.Hour),
+		ProofRegenerateMaxPerHour: getEnvInt("PROOF_REGENERATE_MAX_PER_HOUR", 10),
+
+		// Proof Verification Failure Configuration
+		ProofFailureHealthWindow:   getEnvDuration("PROOF_FAILURE_HEALTH_WINDOW", time.Hour),
+		ProofFailureAlertThreshold: getEnvInt("PROOF_FAILURE_ALERT_THRESHOLD", 3),
+		ProofFailureAlertWindow:    getEnvDuration("PROOF_FAILURE_ALERT_WINDOW", 15*time.Minute),
+
+		// Anchor Reconciliation Configuration
+		AnchorReconcileOnStartup: getEnvBool("ANCHOR_RECONCILE_ON_STARTUP", true),
+		AnchorReconcileMaxAge:    getEnvDuration("ANCHOR_RECONCILE_MAX_AGE", 7*24*time.Hour),
+
+		// Anchor State Reconciliation Configuration
+		AnchorStateReconcileEnabled:    getEnvBool("ANCHOR_STATE_RECONCILE_ENABLED", true),
+		AnchorStateReconcileInterval:   getEnvDuration("ANCHOR_STATE_RECONCILE_INTERVAL", 30*time.Minute),
+		AnchorStateReconcileSampleSize: getEnvInt("ANCHOR_STATE_RECONCILE_SAMPLE_SIZE", 25),
+		AnchorStateReconcileMinAge:     getEnvDuration("ANCHOR_STATE_RECONCILE_MIN_AGE", time.Hour),
+
+		// Proof Usage Metering Configuration (metered only - no quotas - by default)
+		ProofMeteringEnabled: getEnvBool("PROOF_METERING_ENABLED", true),
+		ProofQuotaWindows:    getEnv("PROOF_QUOTA_WINDOWS", "1h,24h,720h"),
+		ProofQuotaAction:     getEnv("PROOF_QUOTA_ACTION", "reject"),
+		ProofQuotaMaxQueued:  getEnvInt("PROOF_QUOTA_MAX_QUEUED", 1000),
+
+		// Anchor Receipt Configuration (disabled by default)
+		AnchorReceiptsEnabled: getEnvBool("ANCHOR_RECEIPTS_ENABLED", false),
+		AnchorReceiptFinality: getEnv("ANCHOR_RECEIPT_FINALITY", "final"),
+
+		// Shutdown Configuration
+		ShutdownStageTimeout:  getEnvDuration("SHUTDOWN_STAGE_TIMEOUT", 15*time.Second),
+		ShutdownFlushTimeout:  getEnvDuration("SHUTDOWN_FLUSH_TIMEOUT", time.Minute),
+		ShutdownAnchorTimeout: getEnvDuration("SHUTDOWN_ANCHOR_TIMEOUT", 2*time.Minute),
+		ShutdownDrainTimeout:  getEnvDuration("SHUTDOWN_DRAIN_TIMEOUT", 45*time.Second),
+
+		// Consensus Peer Health Configuration
+		HealthMinPeers:             getEnvInt("HEALTH_MIN_PEERS", 0),
+		HealthPeerCheckInterval:    getEnvDuration("HEALTH_PEER_CHECK_INTERVAL", 10*time.Second),
+		HealthConsensusStallWindow: getEnvDuration("HEALTH_CONSENSUS_STALL_WINDOW", 2*time.Minute),
+
+		// State Sync Configuration
+		StateSyncSnapshotDir:        getEnv("STATE_SYNC_SNAPSHOT_DIR", "/app/data/validator-snapshots"),
+		StateSyncSnapshotInterval:   getEnvInt("STATE_SYNC_SNAPSHOT_INTERVAL", 1000),
+		StateSyncSnapshotKeepRecent: getEnvInt("STATE_SYNC_SNAPSHOT_KEEP_RECENT", 2),
+		StateSyncEnable:             getEnvBool("STATE_SYNC_ENABLE", false),
+		StateSyncRPCServers:         parseList(getEnv("STATE_SYNC_RPC_SERVERS", "")),
+		StateSyncTrustHeight:        getEnvInt64("STATE_SYNC_TRUST_HEIGHT", 0),
+		StateSyncTrustHash:          getEnv("STATE_SYNC_TRUST_HASH", ""),
+		StateSyncTrustPeriod:        getEnvDuration("STATE_SYNC_TRUST_PERIOD", 168*time.Hour),
 
 		// Security Configuration - REQUIRED, no weak defaults
 		JWTSecret:   getEnv("JWT_SECRET", ""),
@@ -207,6 +719,62 @@ func Load() (*Config, error) {
 		EnableUnifiedTables:    getEnvBool("FF_UNIFIED_TABLES", true),
 		FallbackToLegacy:       getEnvBool("FF_FALLBACK_LEGACY", true),
 		DefaultTargetChain:     getEnv("DEFAULT_TARGET_CHAIN", "sepolia"),
+
+		// Proof Cycle Concurrency
+		MaxConcurrentProofCycles: getEnvInt("MAX_CONCURRENT_PROOF_CYCLES", 8),
+
+		// Proof Cycle Audit Log
+		AuditLogPath: getEnv("AUDIT_LOG_PATH", ""),
+
+		// Proof Result Write-Back Format
+		WriteBackEncoding:       getEnv("WRITE_BACK_ENCODING", "key_value"),
+		WriteBackFields:         parseList(getEnv("WRITE_BACK_FIELDS", "")),
+		WriteBackRequiredFields: parseList(getEnv("WRITE_BACK_REQUIRED_FIELDS", "")),
+
+		// Batch Merkle Leaf Encoding
+		BatchLeafEncoding:          getEnv("BATCH_LEAF_ENCODING", "raw_tx_hash"),
+		BatchLeafEncodingOverrides: parseList(getEnv("BATCH_LEAF_ENCODING_OVERRIDES", "")),
+
+		// Batch IDs
+		DeterministicBatchID: getEnvBool("DETERMINISTIC_BATCH_ID", false),
+
+		// Batch Transaction Dedup
+		BatchDedupCacheSize: getEnvInt("BATCH_DEDUP_CACHE_SIZE", 10000),
+		BatchDedupCacheTTL:  getEnvDuration("BATCH_DEDUP_CACHE_TTL", time.Hour),
+
+		// On-Demand Rate Limiting
+		OnDemandRateLimit:   getEnvInt("ON_DEMAND_RATE_LIMIT", 10),
+		OnDemandRateBurst:   getEnvInt("ON_DEMAND_RATE_BURST", 20),
+		OnDemandRateLimitBy: parseList(getEnv("ON_DEMAND_RATE_LIMIT_BY", "account")),
+
+		// Validator Identity
+		IdentityEndpointEnabled: getEnvBool("IDENTITY_ENDPOINT_ENABLED", true),
+
+		// Admin API (disabled unless a token is set)
+		AdminAPIToken: getEnv("ADMIN_API_TOKEN", ""),
+
+		// Validator Maintenance (disabled by default)
+		ValidatorMaintenanceEnabled:      getEnvBool("VALIDATOR_MAINTENANCE_ENABLED", false),
+		ValidatorMaintenanceAdminToken:   getEnv("VALIDATOR_MAINTENANCE_ADMIN_TOKEN", ""),
+		ValidatorMaintenanceStatePath:    getEnv("VALIDATOR_MAINTENANCE_STATE_PATH", "data/maintenance_state.json"),
+		ValidatorMaintenanceMinRemaining: getEnvInt("VALIDATOR_MAINTENANCE_MIN_REMAINING", 3),
+
+		// Validator Voting Power
+		VotingPowerTrackingEnabled: getEnvBool("VOTING_POWER_TRACKING_ENABLED", true),
+		VotingPowerChangePolicy:    getEnv("VOTING_POWER_CHANGE_POLICY", "follow"),
+
+		// Validator Set Sync
+		ValidatorSetSyncEnabled:  getEnvBool("VALIDATOR_SET_SYNC_ENABLED", true),
+		ValidatorSetSyncInterval: getEnvDuration("VALIDATOR_SET_SYNC_INTERVAL", 5*time.Minute),
+		ValidatorSetIDs:          parseList(getEnv("VALIDATOR_SET_IDS", "")),
+
+		// Debug Proof Field Overrides (disabled unless set)
+		DebugProofOverrides: getEnv("DEBUG_PROOF_OVERRIDES", ""),
+	}
+
+	// ETHEREUM_URL may list fallback endpoints after the primary
+	if len(cfg.EthereumURLs) > 0 {
+		cfg.EthereumURL = cfg.EthereumURLs[0]
 	}
 
 	return cfg, nil
@@ -221,6 +789,15 @@ func (c *Config) Validate() error {
 	if c.EthereumURL == "" {
 		errors = append(errors, "ETHEREUM_URL is required but not set")
 	}
+	if c.EthFailoverThreshold < 1 {
+		errors = append(errors, "ETH_FAILOVER_THRESHOLD must be at least 1")
+	}
+	if c.EthPrimaryRetryInterval <= 0 {
+		errors = append(errors, "ETH_PRIMARY_RETRY_INTERVAL must be positive")
+	}
+	if c.EventWatcherMaxCatchUpBlocks < 1 {
+		errors = append(errors, "EVENT_WATCHER_MAX_CATCHUP_BLOCKS must be at least 1")
+	}
 	if c.AccumulateURL == "" {
 		errors = append(errors, "ACCUMULATE_URL is required but not set")
 	}
@@ -230,6 +807,32 @@ func (c *Config) Validate() error {
 		errors = append(errors, "ETH_PRIVATE_KEY is required but not set")
 	}
 
+	if !c.EthUseLegacyGas {
+		if c.EthMaxPriorityFeeWei < 0 {
+			errors = append(errors, "ETH_MAX_PRIORITY_FEE_WEI cannot be negative")
+		}
+		if c.EthBaseFeeMultiplier < 1 {
+			errors = append(errors, "ETH_BASE_FEE_MULTIPLIER must be at least 1")
+		}
+	}
+
+	if c.BaseRPC != "" && c.BaseAnchorContract == "" {
+		errors = append(errors, "BASE_ANCHOR_CONTRACT is required when BASE_RPC_URL is set")
+	}
+	if c.PolygonRPC != "" && c.PolygonAnchorContract == "" {
+		errors = append(errors, "POLYGON_ANCHOR_CONTRACT is required when POLYGON_RPC_URL is set")
+	}
+	if c.BaseConfirmations < 0 || c.PolygonConfirmations < 0 {
+		errors = append(errors, "BASE_CONFIRMATIONS and POLYGON_CONFIRMATIONS cannot be negative")
+	}
+
+	if c.GovProofCacheSize < 0 {
+		errors = append(errors, "GOV_PROOF_CACHE_SIZE cannot be negative")
+	}
+	if c.GovProofCacheSize > 0 && c.GovProofCacheTTL <= 0 {
+		errors = append(errors, "GOV_PROOF_CACHE_TTL must be positive when GOV_PROOF_CACHE_SIZE is set")
+	}
+
 	// Required contract addresses (at least one must be set for production)
 	if c.CertenContractAddress == "" && c.AnchorContractAddress == "" {
 		errors = append(errors, "CERTEN_CONTRACT_ADDRESS or ANCHOR_CONTRACT_ADDRESS is required")
@@ -247,6 +850,26 @@ func (c *Config) Validate() error {
 			errors = append(errors, "DATABASE_URL appears to contain default/weak credentials - use secure credentials")
 		}
 	}
+	if c.DBMaxOpenConns < 1 {
+		errors = append(errors, "DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if c.DBMaxIdleConns < 0 || c.DBMaxIdleConns > c.DBMaxOpenConns {
+		errors = append(errors, "DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+	if c.DBConnMaxLifetime < 0 {
+		errors = append(errors, "DB_CONN_MAX_LIFETIME cannot be negative")
+	}
+	if c.DBBreakerEnabled {
+		if c.DBBreakerFailures < 1 {
+			errors = append(errors, "DB_BREAKER_FAILURES must be at least 1")
+		}
+		if c.DBBreakerCheckInterval <= 0 {
+			errors = append(errors, "DB_BREAKER_CHECK_INTERVAL must be positive")
+		}
+		if c.DBBreakerPingTimeout <= 0 || c.DBBreakerPingTimeout > c.DBBreakerCheckInterval {
+			errors = append(errors, "DB_BREAKER_PING_TIMEOUT must be positive and at most DB_BREAKER_CHECK_INTERVAL")
+		}
+	}
 
 	// JWT secret validation
 	if c.JWTSecret == "" {
@@ -267,6 +890,255 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Admin API tokens guard operator endpoints
+	if c.AdminAPIToken != "" && len(c.AdminAPIToken) < 32 {
+		errors = append(errors, "ADMIN_API_TOKEN must be at least 32 characters")
+	}
+
+	// Validator maintenance deregisters on-chain, so the admin endpoint needs a strong token
+	if c.ValidatorMaintenanceEnabled {
+		if len(c.ValidatorMaintenanceAdminToken) < 32 {
+			errors = append(errors, "VALIDATOR_MAINTENANCE_ADMIN_TOKEN must be at least 32 characters when VALIDATOR_MAINTENANCE_ENABLED is true")
+		}
+		if c.ValidatorMaintenanceMinRemaining < 1 {
+			errors = append(errors, "VALIDATOR_MAINTENANCE_MIN_REMAINING must be at least 1")
+		}
+	}
+
+	if c.ValidatorSetSyncEnabled && c.ValidatorSetSyncInterval <= 0 {
+		errors = append(errors, "VALIDATOR_SET_SYNC_INTERVAL must be positive when VALIDATOR_SET_SYNC_ENABLED is true")
+	}
+
+	if c.ProofFailureAlertThreshold < 0 {
+		errors = append(errors, "PROOF_FAILURE_ALERT_THRESHOLD cannot be negative")
+	}
+	if c.ProofFailureAlertThreshold > 0 && c.ProofFailureAlertWindow <= 0 {
+		errors = append(errors, "PROOF_FAILURE_ALERT_WINDOW must be positive when PROOF_FAILURE_ALERT_THRESHOLD is set")
+	}
+
+	if c.AnchorRetryMaxAttempts < 1 {
+		errors = append(errors, "ANCHOR_RETRY_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.AnchorRetryInitialBackoff <= 0 {
+		errors = append(errors, "ANCHOR_RETRY_INITIAL_BACKOFF must be positive")
+	} else if c.AnchorRetryMaxBackoff < c.AnchorRetryInitialBackoff {
+		errors = append(errors, "ANCHOR_RETRY_MAX_BACKOFF cannot be less than ANCHOR_RETRY_INITIAL_BACKOFF")
+	}
+
+	if c.OnCadenceBatchInterval < time.Minute {
+		errors = append(errors, "ON_CADENCE_BATCH_INTERVAL must be at least 1m")
+	}
+	if c.OnDemandMaxWait <= 0 {
+		errors = append(errors, "ON_DEMAND_MAX_WAIT must be positive")
+	}
+	if c.BatchStallGracePeriod <= 0 {
+		errors = append(errors, "BATCH_STALL_GRACE_PERIOD must be positive")
+	}
+	if c.HealthConsensusStallWindow <= 0 {
+		errors = append(errors, "HEALTH_CONSENSUS_STALL_WINDOW must be positive")
+	}
+
+	// Replacement transactions must outbid the previous submission by at least 10%
+	if c.OnDemandFeeEscalationEnabled {
+		if c.OnDemandFeeEscalationInterval <= 0 {
+			errors = append(errors, "ON_DEMAND_FEE_ESCALATION_INTERVAL must be positive")
+		}
+		if c.OnDemandFeeEscalationBumpPercent < 10 {
+			errors = append(errors, "ON_DEMAND_FEE_ESCALATION_BUMP_PERCENT must be at least 10")
+		}
+		if c.OnDemandFeeEscalationMaxSteps < 0 {
+			errors = append(errors, "ON_DEMAND_FEE_ESCALATION_MAX_STEPS cannot be negative")
+		}
+		if c.OnDemandFeeEscalationMaxGwei < 1 {
+			errors = append(errors, "ON_DEMAND_FEE_ESCALATION_MAX_GWEI must be at least 1")
+		}
+	}
+	if c.AnchorStuckTxReplaceEnabled {
+		if c.AnchorStuckTxTimeout <= 0 {
+			errors = append(errors, "ANCHOR_STUCK_TX_TIMEOUT must be positive")
+		}
+		if c.AnchorStuckTxBumpPercent < 10 {
+			errors = append(errors, "ANCHOR_STUCK_TX_BUMP_PERCENT must be at least 10")
+		}
+		if c.AnchorStuckTxMaxBumps < 1 {
+			errors = append(errors, "ANCHOR_STUCK_TX_MAX_BUMPS must be at least 1")
+		}
+		if c.AnchorStuckTxMaxGwei < 1 {
+			errors = append(errors, "ANCHOR_STUCK_TX_MAX_GWEI must be at least 1")
+		}
+	}
+
+	if c.FeeOracleEnabled {
+		switch c.FeeOracleSource {
+		case "chainlink":
+			if c.FeeOracleChainlinkAddress == "" {
+				errors = append(errors, "FEE_ORACLE_CHAINLINK_ADDRESS is required when FEE_ORACLE_SOURCE is chainlink")
+			}
+		case "http":
+			if c.FeeOracleHTTPURL == "" || c.FeeOracleHTTPPricePath == "" {
+				errors = append(errors, "FEE_ORACLE_HTTP_URL and FEE_ORACLE_HTTP_PRICE_PATH are required when FEE_ORACLE_SOURCE is http")
+			}
+		default:
+			errors = append(errors, fmt.Sprintf("FEE_ORACLE_SOURCE must be chainlink or http, got %q", c.FeeOracleSource))
+		}
+		if c.FeeOracleCacheTTL < 0 {
+			errors = append(errors, "FEE_ORACLE_CACHE_TTL cannot be negative")
+		}
+		if c.FeeOracleBatchGas <= 0 || c.FeeOracleOnCadenceProofs <= 0 {
+			errors = append(errors, "FEE_ORACLE_BATCH_GAS and FEE_ORACLE_ON_CADENCE_PROOFS must be positive")
+		}
+	}
+
+	// The dedup set must be bounded and outlive every finality deferral retry
+	if c.IntentDedupMaxAge < 0 || c.IntentDedupMaxEntries < 0 {
+		errors = append(errors, "INTENT_DEDUP_MAX_AGE and INTENT_DEDUP_MAX_ENTRIES cannot be negative")
+	} else if c.IntentDedupMaxAge == 0 && c.IntentDedupMaxEntries == 0 {
+		errors = append(errors, "at least one of INTENT_DEDUP_MAX_AGE and INTENT_DEDUP_MAX_ENTRIES must be set")
+	}
+	if horizon := c.IntentFinalityRetryDelay * time.Duration(c.IntentMaxFinalityDeferrals+1); c.IntentDedupMaxAge > 0 && c.IntentDedupMaxAge < horizon {
+		errors = append(errors, fmt.Sprintf("INTENT_DEDUP_MAX_AGE must be at least the finality deferral horizon (%s)", horizon))
+	}
+
+	if c.SyntheticTxEnabled {
+		switch c.SyntheticTxProofClass {
+		case "", "on_cadence", "on_demand":
+		default:
+			errors = append(errors, fmt.Sprintf("SYNTHETIC_TX_PROOF_CLASS must be on_cadence, on_demand or empty, got %q", c.SyntheticTxProofClass))
+		}
+		if c.SyntheticTxMaxPerIntent < 0 {
+			errors = append(errors, "SYNTHETIC_TX_MAX_PER_INTENT cannot be negative")
+		}
+		if c.SyntheticTxFinalityWait < 0 {
+			errors = append(errors, "SYNTHETIC_TX_FINALITY_WAIT cannot be negative")
+		}
+	}
+
+	switch c.KeyBackend {
+	case "file":
+	case "gcp-kms":
+		if !strings.Contains(c.GCPKMSKeyName, "/cryptoKeyVersions/") {
+			errors = append(errors, "GCP_KMS_KEY_NAME must be a crypto key version resource name when KEY_BACKEND=gcp-kms")
+		}
+		if c.GCPKMSSignTimeout <= 0 {
+			errors = append(errors, "GCP_KMS_SIGN_TIMEOUT must be positive")
+		}
+		// The audit log still signs with raw key bytes
+		if c.AuditLogPath != "" {
+			errors = append(errors, "AUDIT_LOG_PATH is not supported with KEY_BACKEND=gcp-kms")
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("KEY_BACKEND must be file or gcp-kms, got %q", c.KeyBackend))
+	}
+
+	// mTLS needs all three files; a partial setup would silently fall back to plain HTTP
+	if c.AttestationTLSCert != "" || c.AttestationTLSKey != "" || c.AttestationCABundle != "" {
+		if c.AttestationTLSCert == "" || c.AttestationTLSKey == "" || c.AttestationCABundle == "" {
+			errors = append(errors, "ATTESTATION_TLS_CERT, ATTESTATION_TLS_KEY and ATTESTATION_CA_BUNDLE must be set together")
+		}
+	}
+
+	for id, key := range c.AttestationPeerKeys {
+		if id == "" {
+			errors = append(errors, "ATTESTATION_PEER_KEYS entries must be validatorID=hexPublicKey")
+			continue
+		}
+		if raw, err := hex.DecodeString(strings.TrimPrefix(key, "0x")); err != nil || len(raw) != ed25519.PublicKeySize {
+			errors = append(errors, fmt.Sprintf("ATTESTATION_PEER_KEYS: %s must have a hex-encoded %d byte Ed25519 public key", id, ed25519.PublicKeySize))
+		}
+	}
+
+	switch c.AttestationThresholdMode {
+	case "count":
+	case "voting_power":
+		if !c.ValidatorSetSyncEnabled {
+			errors = append(errors, "ATTESTATION_THRESHOLD_MODE=voting_power requires VALIDATOR_SET_SYNC_ENABLED=true")
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("ATTESTATION_THRESHOLD_MODE must be count or voting_power, got %q", c.AttestationThresholdMode))
+	}
+	if c.AttestationThresholdNumerator <= 0 || c.AttestationThresholdNumerator > c.AttestationThresholdDenominator {
+		errors = append(errors, "ATTESTATION_THRESHOLD_NUMERATOR must be positive and at most ATTESTATION_THRESHOLD_DENOMINATOR")
+	}
+
+	if c.OnDemandAbandonEnabled && c.OnDemandAbandonAfter <= 0 {
+		errors = append(errors, "ON_DEMAND_ABANDON_AFTER must be positive when ON_DEMAND_ABANDON_ENABLED is true")
+	}
+
+	if c.ProofRetentionDays < 0 {
+		errors = append(errors, "PROOF_RETENTION_DAYS cannot be negative")
+	} else if c.ProofRetentionDays > 0 {
+		if c.ProofArchiveInterval <= 0 {
+			errors = append(errors, "PROOF_ARCHIVE_INTERVAL must be positive when PROOF_RETENTION_DAYS is set")
+		}
+		if c.ProofArchiveBatchLimit <= 0 {
+			errors = append(errors, "PROOF_ARCHIVE_BATCH_LIMIT must be positive when PROOF_RETENTION_DAYS is set")
+		}
+	}
+
+	if c.BatchDedupCacheSize <= 0 {
+		errors = append(errors, "BATCH_DEDUP_CACHE_SIZE must be positive")
+	}
+	if c.BatchDedupCacheTTL <= 0 {
+		errors = append(errors, "BATCH_DEDUP_CACHE_TTL must be positive")
+	} else if c.OnDemandAbandonEnabled && c.BatchDedupCacheTTL >= c.OnDemandAbandonAfter {
+		// Otherwise transactions of abandoned batches could not be resubmitted until evicted
+		errors = append(errors, "BATCH_DEDUP_CACHE_TTL must be shorter than ON_DEMAND_ABANDON_AFTER")
+	}
+
+	if c.OnDemandRateLimit < 0 {
+		errors = append(errors, "ON_DEMAND_RATE_LIMIT cannot be negative")
+	} else if c.OnDemandRateLimit > 0 {
+		if c.OnDemandRateBurst < 1 {
+			errors = append(errors, "ON_DEMAND_RATE_BURST must be at least 1 when ON_DEMAND_RATE_LIMIT is set")
+		}
+		for _, by := range c.OnDemandRateLimitBy {
+			if by != "account" && by != "ip" {
+				errors = append(errors, fmt.Sprintf("ON_DEMAND_RATE_LIMIT_BY has unknown key %q (want account or ip)", by))
+			}
+		}
+		if len(c.OnDemandRateLimitBy) == 0 {
+			errors = append(errors, "ON_DEMAND_RATE_LIMIT_BY must name at least one of account, ip")
+		}
+	}
+
+	if c.AnchorStateReconcileEnabled {
+		if c.AnchorStateReconcileInterval <= 0 {
+			errors = append(errors, "ANCHOR_STATE_RECONCILE_INTERVAL must be positive when ANCHOR_STATE_RECONCILE_ENABLED is true")
+		}
+		if c.AnchorStateReconcileSampleSize <= 0 {
+			errors = append(errors, "ANCHOR_STATE_RECONCILE_SAMPLE_SIZE must be positive when ANCHOR_STATE_RECONCILE_ENABLED is true")
+		}
+		if c.AnchorStateReconcileMinAge < 0 {
+			errors = append(errors, "ANCHOR_STATE_RECONCILE_MIN_AGE must not be negative")
+		}
+	}
+
+	if c.StateSyncSnapshotInterval < 0 {
+		errors = append(errors, "STATE_SYNC_SNAPSHOT_INTERVAL cannot be negative")
+	} else if c.StateSyncSnapshotInterval > 0 && c.StateSyncSnapshotKeepRecent < 1 {
+		errors = append(errors, "STATE_SYNC_SNAPSHOT_KEEP_RECENT must be at least 1 when STATE_SYNC_SNAPSHOT_INTERVAL is set")
+	}
+	if c.StateSyncEnable {
+		if len(c.StateSyncRPCServers) < 2 {
+			errors = append(errors, "STATE_SYNC_RPC_SERVERS must list at least 2 endpoints when STATE_SYNC_ENABLE is true")
+		}
+		if c.StateSyncTrustHeight <= 0 {
+			errors = append(errors, "STATE_SYNC_TRUST_HEIGHT must be positive when STATE_SYNC_ENABLE is true")
+		}
+		if b, err := hex.DecodeString(c.StateSyncTrustHash); err != nil || len(b) != 32 {
+			errors = append(errors, "STATE_SYNC_TRUST_HASH must be a 32-byte hex block hash when STATE_SYNC_ENABLE is true")
+		}
+		if c.StateSyncTrustPeriod <= 0 {
+			errors = append(errors, "STATE_SYNC_TRUST_PERIOD must be positive when STATE_SYNC_ENABLE is true")
+		}
+	}
+
+	if c.ShutdownDrainTimeout <= 0 {
+		errors = append(errors, "SHUTDOWN_DRAIN_TIMEOUT must be positive")
+	} else if c.ShutdownFlushTimeout > 0 && c.ShutdownDrainTimeout > c.ShutdownFlushTimeout {
+		errors = append(errors, "SHUTDOWN_DRAIN_TIMEOUT cannot exceed SHUTDOWN_FLUSH_TIMEOUT")
+	}
+
 	// TLS should be enabled in production
 	if !c.TLSEnabled {
 		// This is a warning, not an error, but log it
@@ -298,6 +1170,15 @@ func (c *Config) ValidateForDevelopment() error {
 	return nil
 }
 
+// EthereumEndpoints returns the Ethereum RPC endpoints, primary first. A config built
+// without EthereumURLs falls back to EthereumURL alone.
+func (c *Config) EthereumEndpoints() []string {
+	if len(c.EthereumURLs) > 0 {
+		return c.EthereumURLs
+	}
+	return []string{c.EthereumURL}
+}
+
 // Helper functions for environment variable parsing
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -346,15 +1227,36 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 // parseAttestationPeers parses comma-separated peer URLs for attestation collection
 // Example: "http://validator-2:8080,http://validator-3:8080,http://validator-4:8080"
 func parseAttestationPeers(value string) []string {
+	return parseList(value)
+}
+
+// parseAttestationPeerKeys parses comma-separated validatorID=hexPublicKey pairs
+// Example: "validator-2=3b6a27bc...,validator-3=8a88e3dd..."
+// Entries without "=" are kept with an empty key so that Validate reports them.
+func parseAttestationPeerKeys(value string) map[string]string {
+	items := parseList(value)
+	if len(items) == 0 {
+		return nil
+	}
+	keys := make(map[string]string, len(items))
+	for _, item := range items {
+		id, key, _ := strings.Cut(item, "=")
+		keys[strings.TrimSpace(id)] = strings.TrimSpace(key)
+	}
+	return keys
+}
+
+// parseList parses a comma-separated list, trimming whitespace and dropping empty items
+func parseList(value string) []string {
 	if value == "" {
 		return nil
 	}
-	peers := strings.Split(value, ",")
-	result := make([]string, 0, len(peers))
-	for _, peer := range peers {
-		peer = strings.TrimSpace(peer)
-		if peer != "" {
-			result = append(result, peer)
+	items := strings.Split(value, ",")
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			result = append(result, item)
 		}
 	}
 	return result
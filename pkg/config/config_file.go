@@ -0,0 +1,154 @@
+// Copyright 2025 Certen Protocol
+//
+// Configuration File
+// Loads settings from a YAML file in addition to environment variables.
+//
+// File keys are the environment variable names. Nested mappings join their keys with
+// "_", so a file can group related settings:
+//
+//	ethereum:
+//	  url: https://sepolia.example/v2/KEY
+//	eth_chain_id: 11155111
+//	attestation_peers:
+//	  - http://validator-2:8080
+//	  - http://validator-3:8080
+//
+// sets ETHEREUM_URL, ETH_CHAIN_ID and ATTESTATION_PEERS (lists are comma-joined).
+// Precedence, lowest first: built-in defaults < file < environment < CLI flags.
+// File values are applied to the process environment for variables that are unset or
+// empty, so components reading the environment directly see them too. Loading the
+// file again (e.g. on SIGHUP) replaces the values it set earlier, and unsets those
+// removed from the file, without touching variables set in the environment.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadWithFile loads configuration from the file at path overlaid by environment
+// variables. An empty path loads from the environment only, like Load.
+func LoadWithFile(path string) (*Config, error) {
+	if path != "" {
+		values, err := ReadConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyFileValues(values); err != nil {
+			return nil, err
+		}
+	}
+	return Load()
+}
+
+// ReadConfigFile reads a YAML (or JSON) configuration file into environment variable values
+func ReadConfigFile(path string) (map[string]string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, fmt.Errorf("unsupported config file %s: expected .yaml, .yml or .json", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if len(root.Content) == 0 {
+		return values, nil // Empty file
+	}
+	if err := flattenConfigNode(root.Content[0], "", values); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// flattenConfigNode collects the scalar values under node keyed by their joined,
+// upper-cased key path
+func flattenConfigNode(node *yaml.Node, prefix string, values map[string]string) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := strings.ToUpper(strings.TrimSpace(node.Content[i].Value))
+			if key == "" {
+				return fmt.Errorf("empty key at line %d", node.Content[i].Line)
+			}
+			if prefix != "" {
+				key = prefix + "_" + key
+			}
+			if err := flattenConfigNode(node.Content[i+1], key, values); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("%s: list items must be plain values (line %d)", prefix, item.Line)
+			}
+			items = append(items, item.Value)
+		}
+		values[prefix] = strings.Join(items, ",")
+	case yaml.ScalarNode:
+		if prefix == "" {
+			return fmt.Errorf("expected a mapping of settings, got a single value")
+		}
+		if node.Tag == "!!null" {
+			return nil // Left unset
+		}
+		values[prefix] = node.Value
+	case yaml.AliasNode:
+		return flattenConfigNode(node.Alias, prefix, values)
+	default:
+		return fmt.Errorf("%s: unsupported value at line %d", prefix, node.Line)
+	}
+	return nil
+}
+
+// appliedFileValues holds the environment variables last set from a config file
+var (
+	appliedFileMu     sync.Mutex
+	appliedFileValues = map[string]string{}
+)
+
+// applyFileValues sets the environment variables that are unset or empty, so that
+// the environment overrides the file. Variables still holding a value set by an
+// earlier load are updated, or unset if the file no longer has them.
+func applyFileValues(values map[string]string) error {
+	appliedFileMu.Lock()
+	defer appliedFileMu.Unlock()
+
+	fromFile := func(key string) bool {
+		previous, ok := appliedFileValues[key]
+		return ok && os.Getenv(key) == previous
+	}
+	for key := range appliedFileValues {
+		if _, ok := values[key]; !ok && fromFile(key) {
+			os.Unsetenv(key)
+		}
+	}
+
+	applied := make(map[string]string, len(values))
+	for key, value := range values {
+		if os.Getenv(key) != "" && !fromFile(key) {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("apply config file value %s: %w", key, err)
+		}
+		applied[key] = value
+	}
+	appliedFileValues = applied
+	return nil
+}
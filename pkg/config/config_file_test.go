@@ -0,0 +1,185 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Configuration File
+// Tests flattening, precedence over defaults and under the environment, reloading a
+// changed file, and that a file and the equivalent environment variables load the
+// same configuration
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeConfigFile writes content to a config file named name in a temp directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return path
+}
+
+// unsetEnv clears keys for the test and restores them afterwards
+func unsetEnv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		t.Setenv(key, "")
+	}
+}
+
+func TestReadConfigFile_Flattens(t *testing.T) {
+	path := writeConfigFile(t, "validator.yaml", `
+ethereum:
+  url: https://rpc.example/v2/key
+eth_chain_id: 11155111
+ATTESTATION_PEERS:
+  - http://validator-2:8080
+  - http://validator-3:8080
+accumulate:
+  comet:
+    dn: http://127.0.0.1:26657
+firebase_project_id: ~
+`)
+	values, err := ReadConfigFile(path)
+	if err != nil {
+		t.Fatalf("ReadConfigFile: %v", err)
+	}
+	want := map[string]string{
+		"ETHEREUM_URL":        "https://rpc.example/v2/key",
+		"ETH_CHAIN_ID":        "11155111",
+		"ATTESTATION_PEERS":   "http://validator-2:8080,http://validator-3:8080",
+		"ACCUMULATE_COMET_DN": "http://127.0.0.1:26657",
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("ReadConfigFile = %v, want %v", values, want)
+	}
+}
+
+func TestReadConfigFile_Errors(t *testing.T) {
+	if _, err := ReadConfigFile(writeConfigFile(t, "validator.ini", "a=b")); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+	if _, err := ReadConfigFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if _, err := ReadConfigFile(writeConfigFile(t, "scalar.yaml", "just-a-value")); err == nil {
+		t.Error("expected an error for a file that is not a mapping")
+	}
+	if _, err := ReadConfigFile(writeConfigFile(t, "nested.yaml", "peers:\n  - {url: x}\n")); err == nil {
+		t.Error("expected an error for a list of mappings")
+	}
+}
+
+func TestLoadWithFile_Precedence(t *testing.T) {
+	unsetEnv(t, "ETH_CHAIN_ID", "ANCHOR_FINAL_CONFIRMATIONS", "EVENT_WATCHER_MAX_CATCHUP_BLOCKS", "VALIDATOR_ID")
+	path := writeConfigFile(t, "validator.yml", `
+eth_chain_id: 1
+anchor_final_confirmations: 20
+validator_id: validator-from-file
+`)
+	t.Setenv("ANCHOR_FINAL_CONFIRMATIONS", "32") // The environment overrides the file
+
+	cfg, err := LoadWithFile(path)
+	if err != nil {
+		t.Fatalf("LoadWithFile: %v", err)
+	}
+	if cfg.EthChainID != 1 || cfg.ValidatorID != "validator-from-file" {
+		t.Errorf("file values not applied: chain %d, validator %q", cfg.EthChainID, cfg.ValidatorID)
+	}
+	if cfg.AnchorFinalConfirmations != 32 {
+		t.Errorf("environment should override the file, got %d confirmations", cfg.AnchorFinalConfirmations)
+	}
+	if cfg.EventWatcherMaxCatchUpBlocks != 5000 {
+		t.Errorf("default not kept for a setting in neither, got %d", cfg.EventWatcherMaxCatchUpBlocks)
+	}
+}
+
+func TestLoadWithFile_Reload(t *testing.T) {
+	unsetEnv(t, "ATTESTATION_PEERS", "ATTESTATION_REQUIRED_COUNT", "VALIDATOR_ID")
+	t.Setenv("VALIDATOR_ID", "validator-from-env")
+	path := writeConfigFile(t, "validator.yaml", `
+attestation_peers: [http://validator-2:8080]
+attestation_required_count: 2
+validator_id: validator-from-file
+`)
+	if _, err := LoadWithFile(path); err != nil {
+		t.Fatalf("LoadWithFile: %v", err)
+	}
+
+	// The edited file replaces its earlier values and drops removed ones
+	if err := os.WriteFile(path, []byte(`
+attestation_peers: [http://validator-2:8080, http://validator-3:8080]
+validator_id: validator-from-file
+`), 0o600); err != nil {
+		t.Fatalf("rewrite config file: %v", err)
+	}
+	cfg, err := LoadWithFile(path)
+	if err != nil {
+		t.Fatalf("LoadWithFile: %v", err)
+	}
+	if len(cfg.AttestationPeers) != 2 {
+		t.Errorf("reloaded peers not applied: %v", cfg.AttestationPeers)
+	}
+	if cfg.AttestationRequiredCount != 3 {
+		t.Errorf("setting removed from the file should fall back to the default, got %d", cfg.AttestationRequiredCount)
+	}
+	if cfg.ValidatorID != "validator-from-env" {
+		t.Errorf("environment should still override the file, got %q", cfg.ValidatorID)
+	}
+}
+
+func TestLoadWithFile_RoundTrip(t *testing.T) {
+	settings := map[string]string{
+		"ETHEREUM_URL":                  "https://primary.example,https://fallback.example",
+		"ETH_CHAIN_ID":                  "11155111",
+		"ATTESTATION_PEERS":             "http://validator-2:8080,http://validator-3:8080",
+		"ATTESTATION_REQUIRED_COUNT":    "5",
+		"PROOF_FAILURE_ALERT_WINDOW":    "30m",
+		"PROOF_CYCLE_WRITEBACK":         "true",
+		"VALIDATOR_SET_SYNC_ENABLED":    "false",
+		"CERTEN_CONTRACT_ADDRESS":       "0xEb17eBd351D2e040a0cB3026a3D04BEc182d8b98",
+		"ANCHOR_FINAL_CONFIRMATIONS":    "24",
+		"ETH_PRIMARY_RETRY_INTERVAL":    "2m",
+		"EVENT_WATCHER_CHECKPOINT_PATH": "",
+	}
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+
+	// From the environment
+	for key, value := range settings {
+		t.Setenv(key, value)
+	}
+	fromEnv, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// From a file holding the same settings
+	unsetEnv(t, keys...)
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		t.Fatalf("marshal settings: %v", err)
+	}
+	fromFile, err := LoadWithFile(writeConfigFile(t, "validator.yaml", string(data)))
+	if err != nil {
+		t.Fatalf("LoadWithFile: %v", err)
+	}
+
+	if !reflect.DeepEqual(fromEnv, fromFile) {
+		t.Errorf("file and environment loaded different configurations:\nenv:  %+v\nfile: %+v", fromEnv, fromFile)
+	}
+	if fromFile.ProofFailureAlertWindow != 30*time.Minute || len(fromFile.EthereumURLs) != 2 {
+		t.Errorf("unexpected values loaded from the file: window %s, urls %v",
+			fromFile.ProofFailureAlertWindow, fromFile.EthereumURLs)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Certen Protocol
+//
+// Configuration Redaction
+// Renders the effective configuration for diagnostics with secrets removed: passwords,
+// private keys, secrets and tokens are replaced, and URLs are reduced to scheme and host
+// because RPC and database URLs routinely carry credentials or API keys.
+
+package config
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces a secret that is set
+const RedactedValue = "[REDACTED]"
+
+// sensitiveFieldMarkers mark fields whose values are secrets
+var sensitiveFieldMarkers = []string{"Password", "PrivateKey", "Secret", "Token"}
+
+// Redacted returns the configuration keyed by field name with secrets redacted
+func (c *Config) Redacted() map[string]interface{} {
+	out := make(map[string]interface{})
+	v := reflect.ValueOf(*c)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		out[field.Name] = redactField(field.Name, v.Field(i).Interface())
+	}
+	return out
+}
+
+func redactField(name string, value interface{}) interface{} {
+	for _, marker := range sensitiveFieldMarkers {
+		if strings.Contains(name, marker) {
+			if reflect.ValueOf(value).IsZero() {
+				return ""
+			}
+			return RedactedValue
+		}
+	}
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String()
+	case string:
+		if strings.HasSuffix(name, "URL") {
+			return redactURL(v)
+		}
+	case []string:
+		if strings.HasSuffix(name, "URLs") {
+			redacted := make([]string, len(v))
+			for i, raw := range v {
+				redacted[i] = redactURL(raw)
+			}
+			return redacted
+		}
+	}
+	return value
+}
+
+// redactURL keeps only the scheme and host of a URL
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return RedactedValue
+	}
+	redacted := u.Scheme + "://" + u.Host
+	if u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		redacted += "/" + RedactedValue
+	}
+	return redacted
+}
@@ -44,6 +44,11 @@ type ValidatorApp struct {
 
 	// Validator count for quorum calculation
 	validatorCount int
+
+	// State sync snapshots (see state_sync.go)
+	snapshots        *SnapshotStore
+	snapshotInterval uint64
+	restore          *snapshotRestore
 }
 
 // NewValidatorApp creates a new ABCI application for validator consensus.
@@ -89,7 +94,10 @@ func (app *ValidatorApp) SetRepositories(repos *database.Repositories) {
 }
 
 // SetValidatorCount sets the total number of validators for quorum calculation
+// Safe to call while blocks are being committed, e.g. when the validator set is resynced
 func (app *ValidatorApp) SetValidatorCount(count int) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
 	app.validatorCount = count
 }
 
@@ -102,6 +110,8 @@ func (app *ValidatorApp) Info(ctx context.Context, req *abcitypes.RequestInfo) (
 	// Log startup info for debugging state recovery issues
 	app.logger.Printf("📋 Info() called - App height: %d, AppHash: %x",
 		app.latestHeight, app.lastCommitHash[:min(8, len(app.lastCommitHash))])
+	app.logger.Printf("📋 CometBFT %s (block protocol %d, p2p protocol %d, ABCI %s), app version %d",
+		req.Version, req.BlockVersion, req.P2PVersion, req.AbciVersion, ValidatorAppVersion)
 
 	// Check for potential state inconsistency with ledger
 	if app.ledgerStore != nil {
@@ -122,7 +132,7 @@ func (app *ValidatorApp) Info(ctx context.Context, req *abcitypes.RequestInfo) (
 	return &abcitypes.ResponseInfo{
 		Data:             "Certen Validator Consensus Application",
 		Version:          "1.0.0",
-		AppVersion:       1,
+		AppVersion:       ValidatorAppVersion,
 		LastBlockHeight:  app.latestHeight,
 		LastBlockAppHash: app.lastCommitHash,
 	}, nil
@@ -346,6 +356,9 @@ func (app *ValidatorApp) Commit(ctx context.Context, req *abcitypes.RequestCommi
 		app.persistConsensusData(ctx)
 	}
 
+	// Periodic state sync snapshot of the committed state
+	app.maybeSnapshot()
+
 	blockCount := len(app.validatorBlocks)
 	app.logger.Printf("📦 Committed validator block %d with %d ValidatorBlocks (hash: %x)",
 		app.latestHeight, blockCount, appHash[:8])
@@ -567,25 +580,7 @@ func (app *ValidatorApp) VerifyVoteExtension(ctx context.Context, req *abcitypes
 	return &abcitypes.ResponseVerifyVoteExtension{Status: abcitypes.ResponseVerifyVoteExtension_ACCEPT}, nil
 }
 
-// ListSnapshots returns available snapshots
-func (app *ValidatorApp) ListSnapshots(ctx context.Context, req *abcitypes.RequestListSnapshots) (*abcitypes.ResponseListSnapshots, error) {
-	return &abcitypes.ResponseListSnapshots{}, nil
-}
-
-// OfferSnapshot handles snapshot offers
-func (app *ValidatorApp) OfferSnapshot(ctx context.Context, req *abcitypes.RequestOfferSnapshot) (*abcitypes.ResponseOfferSnapshot, error) {
-	return &abcitypes.ResponseOfferSnapshot{Result: abcitypes.ResponseOfferSnapshot_ABORT}, nil
-}
-
-// LoadSnapshotChunk loads snapshot chunks
-func (app *ValidatorApp) LoadSnapshotChunk(ctx context.Context, req *abcitypes.RequestLoadSnapshotChunk) (*abcitypes.ResponseLoadSnapshotChunk, error) {
-	return &abcitypes.ResponseLoadSnapshotChunk{}, nil
-}
-
-// ApplySnapshotChunk applies snapshot chunks
-func (app *ValidatorApp) ApplySnapshotChunk(ctx context.Context, req *abcitypes.RequestApplySnapshotChunk) (*abcitypes.ResponseApplySnapshotChunk, error) {
-	return &abcitypes.ResponseApplySnapshotChunk{Result: abcitypes.ResponseApplySnapshotChunk_ABORT}, nil
-}
+// ListSnapshots, OfferSnapshot, LoadSnapshotChunk and ApplySnapshotChunk are in state_sync.go
 
 // ==============================================
 // State Recovery & Graceful Shutdown Methods
@@ -18,6 +18,7 @@ import (
 
 	abcitypes "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
+	cstypes "github.com/cometbft/cometbft/consensus/types"
 	dbm "github.com/cometbft/cometbft-db"
 	cmtlog "github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/node"
@@ -36,6 +37,7 @@ import (
 
 	"github.com/certen/independant-validator/pkg/crypto/bls"
 	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/keys"
 	"github.com/certen/independant-validator/pkg/kvdb"
 	"github.com/certen/independant-validator/pkg/ledger"
 	"github.com/certen/independant-validator/pkg/proof"
@@ -132,6 +134,8 @@ type BFTConsensusEngine interface {
 	// GetLedgerStoreProvider returns the ABCI app if it provides ledger store access
 	// This works for both CertenApplication and ValidatorApp
 	GetLedgerStoreProvider() LedgerStoreProvider
+	// GetConsensusMetrics reports the current height, round, step, peers and last commit
+	GetConsensusMetrics(ctx context.Context) (*ConsensusMetrics, error)
 }
 
 // BFTExecutionResult = "what CometBFT told us" for the VB tx.
@@ -226,7 +230,7 @@ type ProofGenerator interface {
 	GenerateProof(ctx context.Context, req *proof.ProofRequest) (*proof.CertenProof, error)
 }
 
-// GovernanceProofGenerator generates G0/G1/G2 governance proofs
+// GovernanceProofGenerator generates G0/G1/G2/G3 governance proofs
 // Per CERTEN spec v3-governance-kpsw-exec-4.0, these proofs are generated
 // AFTER L1-L4 lite client proof completes (dependency chain)
 type GovernanceProofGenerator interface {
@@ -242,6 +246,10 @@ type GovernanceProofGenerator interface {
 	// Uses G1 artifacts + verifies payload and effects (post-execution only)
 	GenerateG2(ctx context.Context, req *proof.GovernanceRequest) (*proof.GovernanceProof, error)
 
+	// GenerateG3 generates G3 proof (Outcome Binding + Cross-Chain Anchor Binding)
+	// Uses G2 artifacts + binds the target-chain anchor transaction (post-anchoring only)
+	GenerateG3(ctx context.Context, req *proof.GovernanceRequest) (*proof.GovernanceProof, error)
+
 	// GenerateAtLevel generates governance proof at specified level
 	GenerateAtLevel(ctx context.Context, level proof.GovernanceLevel, req *proof.GovernanceRequest) (*proof.GovernanceProof, error)
 }
@@ -258,11 +266,16 @@ type BFTValidator struct {
 	logger                 Logger
 	validatorID            string
 	chainID                string // CometBFT chain ID (e.g., "certen-validator")
-	privateKey             ed25519.PrivateKey
+	signer                 keys.KeyProvider
 	executionQueue         chan *ExecutionTask
 	ctx                    context.Context
 	cancel                 context.CancelFunc
 
+	// Executor selection: every validator derives the same executor per block height.
+	// executorSet is guarded by mu and replaced by SetExecutorSet.
+	executorSeed []byte
+	executorSet  []BFTValidatorInfo
+
 	// BFT coordination fields
 	mu                     sync.RWMutex
 
@@ -303,7 +316,7 @@ func NewBFTValidator(
 	params *ConsensusParams,
 	validatorID string,
 	chainID string, // CometBFT chain ID (e.g., "certen-validator")
-	privateKey ed25519.PrivateKey,
+	signer keys.KeyProvider,
 	anchorManager AnchorManager,
 	proofGenerator ProofGenerator,
 	governanceProofGen GovernanceProofGenerator, // G0/G1/G2 proof generator (runs AFTER L1-L4)
@@ -318,6 +331,11 @@ func NewBFTValidator(
 		chainID = "certen-validator"
 	}
 
+	var executorSeed []byte
+	if params != nil {
+		executorSeed = params.ExecutorSelectionSeed
+	}
+
 	validator := &BFTValidator{
 		engine:                engine,
 		anchorManager:         anchorManager,
@@ -328,10 +346,12 @@ func NewBFTValidator(
 		logger:                logger,
 		validatorID:           validatorID,
 		chainID:               chainID,
-		privateKey:            privateKey,
+		signer:                signer,
 		executionQueue:        make(chan *ExecutionTask, 100),
 		ctx:                   ctx,
 		cancel:                cancel,
+		executorSeed:          executorSeed,
+		executorSet:           genesisValidatorSet(), // until SetExecutorSet provides the on-chain set
 		// anchorResultChannels removed - HTTP orchestration violates audit boundary
 	}
 
@@ -360,6 +380,21 @@ func (bv *BFTValidator) GetConsensusEngine() BFTConsensusEngine {
 	return bv.engine
 }
 
+// GetConsensusMetrics reports consensus progress from the consensus engine, with Status
+// evaluated against stallWindow
+func (bv *BFTValidator) GetConsensusMetrics(ctx context.Context, stallWindow time.Duration) (*ConsensusMetrics, error) {
+	engine := bv.GetConsensusEngine()
+	if engine == nil {
+		return nil, fmt.Errorf("consensus engine not configured")
+	}
+	metrics, err := engine.GetConsensusMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics.EvaluateProgress(stallWindow, time.Now())
+	return metrics, nil
+}
+
 // GetValidatorID returns the ID of this validator
 func (bv *BFTValidator) GetValidatorID() string {
 	return bv.validatorID
@@ -375,6 +410,27 @@ func (bv *BFTValidator) SetProofCycleOrchestrator(orchestrator ProofCycleOrchest
 	}
 }
 
+// SetExecutorSet replaces the validator set executors are selected from, with each
+// validator's voting power. It is normally the validator set registered on-chain; each
+// validator refreshes it independently, so around a change peers may briefly disagree
+// on the executor of a height.
+func (bv *BFTValidator) SetExecutorSet(set []BFTValidatorInfo) {
+	executorSet := append([]BFTValidatorInfo(nil), set...)
+
+	bv.mu.Lock()
+	bv.executorSet = executorSet
+	bv.mu.Unlock()
+
+	bv.logger.Printf("🎯 [BFT-DETERMINISTIC] Executor set updated: %d validators", len(executorSet))
+}
+
+// getExecutorSet returns the validator set executors are selected from
+func (bv *BFTValidator) getExecutorSet() []BFTValidatorInfo {
+	bv.mu.RLock()
+	defer bv.mu.RUnlock()
+	return bv.executorSet
+}
+
 // GetProofCycleOrchestrator returns the proof cycle orchestrator
 func (bv *BFTValidator) GetProofCycleOrchestrator() ProofCycleOrchestratorInterface {
 	bv.mu.RLock()
@@ -412,7 +468,7 @@ func (bv *BFTValidator) ExecuteWithBFTConsensus(
 		intent.ID, roundID, blockHeight)
 
 	// Step 1: Phase 3 - Deterministic executor selection (no ExecutionConsensus)
-	selectedExecutorID := bv.selectExecutorDeterministically(roundID, intent.ID)
+	selectedExecutorID := bv.selectExecutor(roundID, blockHeight)
 
 	// Broadcast executor selection to CometBFT for consensus agreement
 	if err := bv.broadcastExecutorSelection(roundID, selectedExecutorID); err != nil {
@@ -524,7 +580,7 @@ func (bv *BFTValidator) executeTask(task *ExecutionTask) {
 		bv.logger.Printf("🎯 [BFT-EXEC] No ballot in ABCI state for round %s, selecting executor via CometBFT", task.RoundID)
 
 		// Use a simple deterministic executor selection for now (could be enhanced with real voting)
-		selectedExecutor := bv.selectExecutorDeterministically(task.RoundID, task.Intent.ID)
+		selectedExecutor := bv.selectExecutor(task.RoundID, task.BlockHeight)
 		if err := bv.broadcastExecutorSelection(task.RoundID, selectedExecutor); err != nil {
 			bv.logger.Printf("❌ [BFT-EXEC] Failed to broadcast executor selection: %v", err)
 			return
@@ -546,6 +602,13 @@ func (bv *BFTValidator) executeTask(task *ExecutionTask) {
 		return
 	}
 
+	// Executor selections are ordinary transactions; only the rightful executor may execute.
+	// This is a local check against this node's executor set, outside ABCI state.
+	if err := bv.VerifyExecutor(task.BlockHeight, ballot.FinalExecutorID); err != nil {
+		bv.logger.Printf("❌ [BFT-EXEC] Rejecting executor selection for round %s: %v", task.RoundID, err)
+		return
+	}
+
 	// Check if this validator is the elected executor
 	if ballot.FinalExecutorID != bv.validatorID {
 		bv.logger.Printf("👁️ [BFT-EXEC] Validator %s participating in consensus (executor: %s) - NOT executing",
@@ -703,16 +766,19 @@ func (bv *BFTValidator) executeCanonicalBFTWorkflow(
 		// CRITICAL: Generate initial validator signature for pre-execution
 		// For on_demand intents, we sign the operation commitment as the proposing validator
 		// Other validators will add their signatures during BFT consensus
-		if len(validatorSignatures) == 0 && bv.privateKey != nil {
+		if len(validatorSignatures) == 0 && bv.signer != nil {
 			opID, err := certenIntent.OperationID()
 			if err == nil {
 				// Sign the operation commitment with our validator's ed25519 key
 				message := []byte(opID)
-				signature := ed25519.Sign(bv.privateKey, message)
-				signatureHex := hex.EncodeToString(signature)
-				validatorSignatures = []string{signatureHex}
-				bv.logger.Printf("🔑 [VALIDATOR-SIG] Generated initial validator signature for intent %s (opID: %s...)",
-					certenIntent.IntentID, opID[:16])
+				if signature, err := bv.signer.Sign(message); err == nil {
+					signatureHex := hex.EncodeToString(signature)
+					validatorSignatures = []string{signatureHex}
+					bv.logger.Printf("🔑 [VALIDATOR-SIG] Generated initial validator signature for intent %s (opID: %s...)",
+						certenIntent.IntentID, opID[:16])
+				} else {
+					bv.logger.Printf("⚠️ [VALIDATOR-SIG] Failed to sign operationID: %v", err)
+				}
 			} else {
 				bv.logger.Printf("⚠️ [VALIDATOR-SIG] Failed to compute operationID for signing: %v", err)
 			}
@@ -725,8 +791,10 @@ func (bv *BFTValidator) executeCanonicalBFTWorkflow(
 			if blsKeyManager != nil {
 				opID, err := certenIntent.OperationID()
 				if err == nil {
-					// Sign the operation ID with BLS key using governance domain
-					blsSig, err := blsKeyManager.SignWithDomain([]byte(opID), bls.DomainAttestation)
+					// Sign the anchor message hash for the operation; the same hash is
+					// submitted as blsProof.messageHash to the anchor contract
+					messageHash := bls.ComputeAnchorMessageHash(opID)
+					blsSig, err := blsKeyManager.Sign(messageHash[:])
 					if err == nil {
 						blsSignature = blsSig.Hex()
 						bv.logger.Printf("🔐 [BLS-SIG] Generated BLS signature for governance proof (intent %s)", certenIntent.IntentID)
@@ -982,8 +1050,8 @@ func (bv *BFTValidator) executeCanonicalBFTWorkflow(
 	// This prevents multiple validators from creating duplicate transactions
 	// =======================================================================
 
-	// Deterministically select executor based on round ID
-	selectedExecutorID := bv.selectExecutorForRound(roundID)
+	// Deterministically select executor based on the block height
+	selectedExecutorID := bv.selectExecutor(roundID, blockHeight)
 
 	if selectedExecutorID != bv.validatorID {
 		bv.logger.Printf("👁️ [CANONICAL-BFT] Validator %s is NOT elected executor (executor: %s) - skipping external submission",
@@ -1805,6 +1873,10 @@ func (app *CertenApplication) processExecutionResult(txData map[string]interface
 	if roundID, ok := txData["round_id"].(string); ok {
 		if intentID, ok := txData["intent_id"].(string); ok {
 			if executorID, ok := txData["executor_id"].(string); ok {
+				// The executor is not checked here: the executor set is synced from the
+				// contract by each node on its own schedule, so checking it in ABCI would
+				// make nodes disagree on app state. executeTask checks it before executing.
+
 				success := false
 				if s, ok := txData["success"].(bool); ok {
 					success = s
@@ -1935,7 +2007,8 @@ func generateDeterministicValidatorPublicKey(validatorID string) cmted25519.PubK
 }
 
 // NewUnifiedCometBFTEngine creates a unified CometBFT engine for dev testing (use NewProductionEngine for production)
-func NewUnifiedCometBFTEngine(validatorID string) (*RealCometBFTEngine, error) {
+// stateSync enables periodic ValidatorApp snapshots and restoring a fresh node from a peer snapshot
+func NewUnifiedCometBFTEngine(validatorID string, stateSync StateSyncConfig) (*RealCometBFTEngine, error) {
 	logger := log.New(os.Stdout, fmt.Sprintf("[CometBFT-%s] ", validatorID), log.LstdFlags|log.Lmicroseconds)
 
 	// All validators use the same internal container ports - Docker handles external mapping
@@ -2006,6 +2079,19 @@ func NewUnifiedCometBFTEngine(validatorID string) (*RealCometBFTEngine, error) {
 	cfg.P2P.AllowDuplicateIP = true              // Allow duplicate IPs in Docker network
 	cfg.P2P.PersistentPeersMaxDialPeriod = 60 * time.Second // Keep trying persistent peers
 
+	// State sync: a node without local state restores the latest verified peer snapshot
+	// instead of replaying every block
+	if stateSync.Enable {
+		cfg.StateSync.Enable = true
+		cfg.StateSync.RPCServers = stateSync.RPCServers
+		cfg.StateSync.TrustHeight = stateSync.TrustHeight
+		cfg.StateSync.TrustHash = stateSync.TrustHash
+		if stateSync.TrustPeriod > 0 {
+			cfg.StateSync.TrustPeriod = stateSync.TrustPeriod
+		}
+		logger.Printf("🔄 State sync enabled: trust height %d, RPC servers %v", stateSync.TrustHeight, stateSync.RPCServers)
+	}
+
 	// Generate deterministic node key (always overwrite existing)
 	nodeKeyFile := filepath.Join(homeDir, "config", "node_key.json")
 	os.MkdirAll(filepath.Dir(nodeKeyFile), 0755)
@@ -2056,9 +2142,7 @@ func NewUnifiedCometBFTEngine(validatorID string) (*RealCometBFTEngine, error) {
 
 	// Create the unified validator set for 7 BFT validators
 	validatorMap := make(map[string]*SimpleBFTValidator)
-	validatorIDs := []string{"validator-1", "validator-2", "validator-3", "validator-4", "validator-5", "validator-6", "validator-7"}
-
-	for _, vID := range validatorIDs {
+	for _, vID := range genesisValidatorIDs {
 		validatorPubKey := generateDeterministicValidatorPublicKey(vID)
 		bftValidator := &SimpleBFTValidator{
 			ID:          vID,
@@ -2082,6 +2166,15 @@ func NewUnifiedCometBFTEngine(validatorID string) (*RealCometBFTEngine, error) {
 	app := NewValidatorApp(ledgerStore, chainID)
 	logger.Printf("✅ [VALIDATOR-CHAIN] Created ValidatorApp for VB consensus: chain=%s", chainID)
 
+	if stateSync.SnapshotInterval > 0 && stateSync.SnapshotDir != "" {
+		snapshots, err := NewSnapshotStore(filepath.Join(stateSync.SnapshotDir, validatorID), stateSync.SnapshotKeepRecent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot store: %w", err)
+		}
+		app.SetSnapshotStore(snapshots, stateSync.SnapshotInterval)
+		logger.Printf("📸 [STATE-SYNC] Snapshots every %d blocks, keeping %d", stateSync.SnapshotInterval, stateSync.SnapshotKeepRecent)
+	}
+
 	// CRITICAL: Recover state from ledger before CometBFT calls Info()
 	// This ensures the app reports the correct height/appHash so CometBFT can sync properly
 	if err := app.RecoverState(); err != nil {
@@ -2146,6 +2239,64 @@ func (e *RealCometBFTEngine) Stop() error {
 	return nil
 }
 
+// GetStatus reports block progress and connected peers of the in-process node
+// Implements StatusFetcher for the consensus health monitor
+func (e *RealCometBFTEngine) GetStatus(ctx context.Context) (*ConsensusStatus, error) {
+	e.mu.RLock()
+	started := e.started
+	e.mu.RUnlock()
+	if !started {
+		return nil, fmt.Errorf("cometbft node not started")
+	}
+
+	status := &ConsensusStatus{
+		LatestBlockHeight: e.node.BlockStore().Height(),
+		CatchingUp:        e.node.ConsensusReactor().WaitSync(),
+		NumPeers:          e.node.Switch().Peers().Size(),
+	}
+	if meta := e.node.BlockStore().LoadBlockMeta(status.LatestBlockHeight); meta != nil {
+		status.LatestBlockTime = meta.Header.Time
+	}
+	return status, nil
+}
+
+// GetConsensusMetrics reports the round state of the in-process node. Height, round and
+// step come from the consensus_state RPC, which encodes them as "height/round/step".
+func (e *RealCometBFTEngine) GetConsensusMetrics(ctx context.Context) (*ConsensusMetrics, error) {
+	e.mu.RLock()
+	started := e.started
+	e.mu.RUnlock()
+	if !started {
+		return nil, fmt.Errorf("cometbft node not started")
+	}
+
+	res, err := e.rpcClient.ConsensusState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query consensus state: %w", err)
+	}
+	var roundState struct {
+		HeightRoundStep string `json:"height/round/step"`
+	}
+	if err := json.Unmarshal(res.RoundState, &roundState); err != nil {
+		return nil, fmt.Errorf("decode consensus state: %w", err)
+	}
+	var step uint8
+	metrics := &ConsensusMetrics{}
+	if _, err := fmt.Sscanf(roundState.HeightRoundStep, "%d/%d/%d", &metrics.Height, &metrics.Round, &step); err != nil {
+		return nil, fmt.Errorf("parse height/round/step %q: %w", roundState.HeightRoundStep, err)
+	}
+	metrics.Step = cstypes.RoundStepType(step).String()
+
+	metrics.ActivePeers = e.node.Switch().Peers().Size()
+	metrics.CatchingUp = e.node.ConsensusReactor().WaitSync()
+	metrics.PendingTxs = e.node.Mempool().Size()
+	metrics.LastCommitHeight = e.node.BlockStore().Height()
+	if meta := e.node.BlockStore().LoadBlockMeta(metrics.LastCommitHeight); meta != nil {
+		metrics.LastCommitTime = meta.Header.Time
+	}
+	return metrics, nil
+}
+
 // BroadcastValidatorBlockCommit encodes the canonical ValidatorBlock as JSON,
 // submits via BroadcastTxSync, then polls for confirmed inclusion in a block.
 // This ensures cryptographic proof integrity by returning only after consensus commits.
@@ -2386,10 +2537,9 @@ func (engine *RealCometBFTEngine) writeDeterministicGenesisIfNeeded(cfg *config.
 
 // createGenesisDocument creates the genesis document with all validators
 func (engine *RealCometBFTEngine) createGenesisDocument() (*cmttypes.GenesisDoc, error) {
-	allValidatorIDs := []string{"validator-1", "validator-2", "validator-3", "validator-4", "validator-5", "validator-6", "validator-7"}
-	validators := make([]cmttypes.GenesisValidator, 0, len(allValidatorIDs))
+	validators := make([]cmttypes.GenesisValidator, 0, len(genesisValidatorIDs))
 
-	for _, validatorID := range allValidatorIDs {
+	for _, validatorID := range genesisValidatorIDs {
 		validatorPubKey := generateDeterministicValidatorPublicKey(validatorID)
 		genesisValidator := cmttypes.GenesisValidator{
 			Address: validatorPubKey.Address(),
@@ -2627,7 +2777,7 @@ type IntentRecord struct {
 
 
 // broadcastExecutionResult broadcasts execution results to CometBFT for app state tracking
-func (bv *BFTValidator) broadcastExecutionResult(roundID, intentID string, success bool, executorID string) error {
+func (bv *BFTValidator) broadcastExecutionResult(roundID, intentID string, blockHeight uint64, success bool, executorID string) error {
 	if bv.engine == nil {
 		return fmt.Errorf("consensus engine not initialized")
 	}
@@ -2636,13 +2786,14 @@ func (bv *BFTValidator) broadcastExecutionResult(roundID, intentID string, succe
 
 	// Create transaction for execution result
 	txData := map[string]interface{}{
-		"type":        "execution_result",
-		"round_id":    roundID,
-		"intent_id":   intentID,
-		"success":     success,
-		"executor_id": executorID,
-		"timestamp":   time.Now().Unix(),
-		"validator":   bv.validatorID,
+		"type":         "execution_result",
+		"round_id":     roundID,
+		"intent_id":    intentID,
+		"block_height": blockHeight,
+		"success":      success,
+		"executor_id":  executorID,
+		"timestamp":    time.Now().Unix(),
+		"validator":    bv.validatorID,
 	}
 
 	// Serialize and broadcast
@@ -2670,29 +2821,21 @@ func (bv *BFTValidator) getABCIExecutionState(roundID string) (*ExecutionRecord,
 	return bv.engine.GetABCIApp().GetExecutionState(roundID)
 }
 
-// selectExecutorForRound selects an executor for a canonical BFT round
-// This is a simplified wrapper for the canonical workflow that uses roundID only
-// (roundID already contains intentID:blockHeight:timestamp for uniqueness)
-func (bv *BFTValidator) selectExecutorForRound(roundID string) string {
-	// Use roundID as both roundID and intentID since it already contains the intent
-	return bv.selectExecutorDeterministically(roundID, roundID)
+// selectExecutor selects the executor for the block height of a round (see SelectExecutor).
+// It returns "" when no validator is eligible, so no validator executes.
+func (bv *BFTValidator) selectExecutor(roundID string, height uint64) string {
+	selected, err := SelectExecutor(bv.executorSeed, bv.getExecutorSet(), height)
+	if err != nil {
+		bv.logger.Printf("❌ [BFT-DETERMINISTIC] No executor for round %s at height %d: %v", roundID, height, err)
+		return ""
+	}
+	bv.logger.Printf("🎯 [BFT-DETERMINISTIC] Selected executor %s for round %s (height %d)", selected, roundID, height)
+	return selected
 }
 
-// selectExecutorDeterministically selects an executor using a deterministic algorithm
-func (bv *BFTValidator) selectExecutorDeterministically(roundID, intentID string) string {
-	// Simple deterministic selection based on hash
-	hash := sha256.New()
-	hash.Write([]byte(roundID + intentID))
-	hashBytes := hash.Sum(nil)
-
-	// Convert to number and mod by available validators
-	// For now, just use a simple list of known validators
-	validators := []string{"validator-1", "validator-2", "validator-3", "validator-4", "validator-5", "validator-6", "validator-7"}
-	index := int(hashBytes[0]) % len(validators)
-
-	selected := validators[index]
-	bv.logger.Printf("🎯 [BFT-DETERMINISTIC] Selected executor %s for round %s (index %d)", selected, roundID, index)
-	return selected
+// VerifyExecutor checks that executorID was the rightful executor for the block at height
+func (bv *BFTValidator) VerifyExecutor(height uint64, executorID string) error {
+	return VerifyExecutorSelection(bv.executorSeed, bv.getExecutorSet(), height, executorID)
 }
 
 // broadcastExecutorSelection broadcasts executor selection to CometBFT for ABCI processing
@@ -2798,14 +2941,20 @@ func (bv *BFTValidator) createAnchorFromProof(
 func (bv *BFTValidator) signValidatorBlock(vb *ValidatorBlock) (string, error) {
 	// Phase 3: Direct Ed25519 signing without ExecutionConsensus
 	blockData := fmt.Sprintf("%s:%s:%d:%s", vb.ValidatorID, vb.BundleID, vb.BlockHeight, vb.OperationCommitment)
-	signature := ed25519.Sign(bv.privateKey, []byte(blockData))
+	signature, err := bv.signer.Sign([]byte(blockData))
+	if err != nil {
+		return "", fmt.Errorf("sign validator block: %w", err)
+	}
 	return fmt.Sprintf("0x%x", signature), nil
 }
 
 func (bv *BFTValidator) signAnchorResult(resp *AnchorResponse) (string, error) {
 	// Phase 3: Direct Ed25519 signing without ExecutionConsensus
 	anchorData := fmt.Sprintf("%s:%s:%t", resp.AnchorID, resp.Message, resp.Success)
-	signature := ed25519.Sign(bv.privateKey, []byte(anchorData))
+	signature, err := bv.signer.Sign([]byte(anchorData))
+	if err != nil {
+		return "", fmt.Errorf("sign anchor result: %w", err)
+	}
 	return fmt.Sprintf("0x%x", signature), nil
 }
 
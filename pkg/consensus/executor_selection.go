@@ -0,0 +1,108 @@
+// Copyright 2025 Certen Protocol
+//
+// Executor Selection - Deterministic choice of the validator that executes a block
+//
+// Only one validator submits to external chains for a given Accumulate block. Every
+// validator derives that executor from the same inputs - the network-wide selection seed,
+// the validator set and the block height - so any of them can recompute the choice and
+// reject execution results reported by anyone else.
+//
+// Selection is weighted by voting power: the seed and height are hashed, reduced modulo the
+// total voting power of the eligible validators, and mapped onto their cumulative powers in
+// ValidatorID order. The input order of the validator set does not affect the result.
+
+package consensus
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// executorSelectionDomain separates executor selection digests from other seed hashes
+const executorSelectionDomain = "certen-executor-selection-v1"
+
+var (
+	// ErrNoEligibleExecutor indicates the validator set has no active validator with voting power
+	ErrNoEligibleExecutor = errors.New("no eligible executor in validator set")
+	// ErrIllegitimateExecutor indicates a validator executed a block it was not selected for
+	ErrIllegitimateExecutor = errors.New("validator was not the selected executor")
+)
+
+// genesisValidatorIDs are the validators of the deterministic genesis document
+var genesisValidatorIDs = []string{"validator-1", "validator-2", "validator-3", "validator-4", "validator-5", "validator-6", "validator-7"}
+
+// genesisValidatorSet returns the genesis validators with equal voting power
+func genesisValidatorSet() []BFTValidatorInfo {
+	set := make([]BFTValidatorInfo, len(genesisValidatorIDs))
+	for i, id := range genesisValidatorIDs {
+		set[i] = BFTValidatorInfo{ValidatorID: id, VotingPower: 1, IsActive: true}
+	}
+	return set
+}
+
+// SelectExecutor returns the ValidatorID of the executor for the block at height.
+// Inactive validators and validators without voting power are never selected.
+func SelectExecutor(seed []byte, validatorSet []BFTValidatorInfo, height uint64) (string, error) {
+	candidates := make([]BFTValidatorInfo, 0, len(validatorSet))
+	seen := make(map[string]bool, len(validatorSet))
+	var total int64
+	for _, v := range validatorSet {
+		if !v.IsActive || v.VotingPower <= 0 {
+			continue
+		}
+		if v.ValidatorID == "" {
+			return "", fmt.Errorf("validator with voting power %d has no ID", v.VotingPower)
+		}
+		if seen[v.ValidatorID] {
+			return "", fmt.Errorf("validator %s appears more than once in the validator set", v.ValidatorID)
+		}
+		if total > math.MaxInt64-v.VotingPower {
+			return "", fmt.Errorf("total voting power overflows at validator %s", v.ValidatorID)
+		}
+		seen[v.ValidatorID] = true
+		total += v.VotingPower
+		candidates = append(candidates, v)
+	}
+	if len(candidates) == 0 {
+		return "", ErrNoEligibleExecutor
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ValidatorID < candidates[j].ValidatorID
+	})
+
+	h := sha256.New()
+	h.Write([]byte(executorSelectionDomain))
+	h.Write(seed)
+	var heightBytes [8]byte
+	binary.BigEndian.PutUint64(heightBytes[:], height)
+	h.Write(heightBytes[:])
+	target := new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), big.NewInt(total)).Int64()
+
+	var cumulative int64
+	for _, v := range candidates {
+		cumulative += v.VotingPower
+		if target < cumulative {
+			return v.ValidatorID, nil
+		}
+	}
+	return candidates[len(candidates)-1].ValidatorID, nil
+}
+
+// VerifyExecutorSelection checks that executorID is the executor SelectExecutor chooses for
+// the block at height. The returned error wraps ErrIllegitimateExecutor on a mismatch.
+func VerifyExecutorSelection(seed []byte, validatorSet []BFTValidatorInfo, height uint64, executorID string) error {
+	selected, err := SelectExecutor(seed, validatorSet, height)
+	if err != nil {
+		return fmt.Errorf("select executor for height %d: %w", height, err)
+	}
+	if executorID != selected {
+		return fmt.Errorf("%w: %q executed height %d, selected executor is %s",
+			ErrIllegitimateExecutor, executorID, height, selected)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Executor Selection
+// Tests determinism, fairness across heights, eligibility and verification of executors,
+// selection from the validator set given to SetExecutorSet and that ABCI execution
+// results do not depend on it
+
+package consensus
+
+import (
+	"errors"
+	"io"
+	"log"
+	"math"
+	"testing"
+)
+
+var testSeed = []byte("certen-validator")
+
+func TestSelectExecutor_DeterministicAndOrderIndependent(t *testing.T) {
+	set := genesisValidatorSet()
+	reversed := make([]BFTValidatorInfo, len(set))
+	for i, v := range set {
+		reversed[len(set)-1-i] = v
+	}
+
+	otherSeedDiffers := false
+	for height := uint64(0); height < 200; height++ {
+		a, err := SelectExecutor(testSeed, set, height)
+		if err != nil {
+			t.Fatalf("height %d: SelectExecutor failed: %v", height, err)
+		}
+		b, _ := SelectExecutor(testSeed, set, height)
+		c, _ := SelectExecutor(testSeed, reversed, height)
+		if a != b || a != c {
+			t.Fatalf("height %d: selections differ: %s, %s, reversed set %s", height, a, b, c)
+		}
+		if d, _ := SelectExecutor([]byte("other-chain"), set, height); d != a {
+			otherSeedDiffers = true
+		}
+	}
+	if !otherSeedDiffers {
+		t.Error("expected a different seed to change some selections")
+	}
+}
+
+func TestSelectExecutor_FairAcrossHeights(t *testing.T) {
+	const heights = 70000
+	set := genesisValidatorSet()
+	counts := make(map[string]int)
+	for height := uint64(1); height <= heights; height++ {
+		id, err := SelectExecutor(testSeed, set, height)
+		if err != nil {
+			t.Fatalf("height %d: SelectExecutor failed: %v", height, err)
+		}
+		counts[id]++
+	}
+
+	expected := float64(heights) / float64(len(set))
+	for _, v := range set {
+		if got := float64(counts[v.ValidatorID]); math.Abs(got-expected) > 0.05*expected {
+			t.Errorf("%s selected %v times, expected %v within 5%%", v.ValidatorID, got, expected)
+		}
+	}
+}
+
+func TestSelectExecutor_WeightedByVotingPower(t *testing.T) {
+	const heights = 60000
+	set := []BFTValidatorInfo{
+		{ValidatorID: "validator-1", VotingPower: 1, IsActive: true},
+		{ValidatorID: "validator-2", VotingPower: 2, IsActive: true},
+		{ValidatorID: "validator-3", VotingPower: 3, IsActive: true},
+		{ValidatorID: "validator-4", VotingPower: 9, IsActive: false},
+		{ValidatorID: "validator-5", VotingPower: 0, IsActive: true},
+	}
+	counts := make(map[string]int)
+	for height := uint64(1); height <= heights; height++ {
+		id, _ := SelectExecutor(testSeed, set, height)
+		counts[id]++
+	}
+
+	if counts["validator-4"] != 0 || counts["validator-5"] != 0 {
+		t.Errorf("inactive or powerless validators selected: %v", counts)
+	}
+	for _, v := range set[:3] {
+		expected := float64(heights) * float64(v.VotingPower) / 6
+		if got := float64(counts[v.ValidatorID]); math.Abs(got-expected) > 0.05*expected {
+			t.Errorf("%s selected %v times, expected %v within 5%%", v.ValidatorID, got, expected)
+		}
+	}
+}
+
+func TestSelectExecutor_InvalidSets(t *testing.T) {
+	if _, err := SelectExecutor(testSeed, nil, 1); !errors.Is(err, ErrNoEligibleExecutor) {
+		t.Errorf("expected ErrNoEligibleExecutor for an empty set, got %v", err)
+	}
+	inactive := []BFTValidatorInfo{{ValidatorID: "validator-1", VotingPower: 1}}
+	if _, err := SelectExecutor(testSeed, inactive, 1); !errors.Is(err, ErrNoEligibleExecutor) {
+		t.Errorf("expected ErrNoEligibleExecutor without active validators, got %v", err)
+	}
+	duplicate := []BFTValidatorInfo{
+		{ValidatorID: "validator-1", VotingPower: 1, IsActive: true},
+		{ValidatorID: "validator-1", VotingPower: 1, IsActive: true},
+	}
+	if _, err := SelectExecutor(testSeed, duplicate, 1); err == nil {
+		t.Error("expected a duplicate validator to be rejected")
+	}
+}
+
+func TestVerifyExecutorSelection(t *testing.T) {
+	set := genesisValidatorSet()
+	for height := uint64(1); height <= 20; height++ {
+		selected, err := SelectExecutor(testSeed, set, height)
+		if err != nil {
+			t.Fatalf("SelectExecutor failed: %v", err)
+		}
+		if err := VerifyExecutorSelection(testSeed, set, height, selected); err != nil {
+			t.Errorf("height %d: expected %s to verify, got %v", height, selected, err)
+		}
+		for _, v := range set {
+			if v.ValidatorID == selected {
+				continue
+			}
+			if err := VerifyExecutorSelection(testSeed, set, height, v.ValidatorID); !errors.Is(err, ErrIllegitimateExecutor) {
+				t.Errorf("height %d: expected %s to be rejected, got %v", height, v.ValidatorID, err)
+			}
+		}
+	}
+}
+
+func TestBFTValidator_SetExecutorSet(t *testing.T) {
+	bv := &BFTValidator{
+		logger:       log.New(io.Discard, "", 0),
+		executorSeed: testSeed,
+		executorSet:  genesisValidatorSet(),
+	}
+
+	// Only validator-9 holds voting power in the synced set, so it executes every height
+	synced := []BFTValidatorInfo{
+		{ValidatorID: "validator-1", VotingPower: 0, IsActive: true},
+		{ValidatorID: "validator-9", VotingPower: 5, IsActive: true},
+	}
+	bv.SetExecutorSet(synced)
+	synced[1].VotingPower = 0 // the validator keeps its own copy
+
+	for height := uint64(1); height <= 20; height++ {
+		if selected := bv.selectExecutor("round", height); selected != "validator-9" {
+			t.Fatalf("height %d: selected %q, want validator-9 from the synced set", height, selected)
+		}
+		if err := bv.VerifyExecutor(height, "validator-9"); err != nil {
+			t.Errorf("height %d: VerifyExecutor: %v", height, err)
+		}
+		if err := bv.VerifyExecutor(height, "validator-1"); !errors.Is(err, ErrIllegitimateExecutor) {
+			t.Errorf("height %d: expected validator-1 to be rejected, got %v", height, err)
+		}
+	}
+}
+
+func TestProcessExecutionResult_IndependentOfLocalExecutorSet(t *testing.T) {
+	// A node whose synced executor set has no eligible validator must still record
+	// the same execution results as its peers
+	bv := &BFTValidator{logger: log.New(io.Discard, "", 0), executorSeed: testSeed}
+	bv.SetExecutorSet(nil)
+	app := &CertenApplication{
+		logger:         log.New(io.Discard, "", 0),
+		executionState: make(map[string]*ExecutionRecord),
+		validator:      bv,
+	}
+
+	app.processExecutionResult(map[string]interface{}{
+		"round_id":     "round-1",
+		"intent_id":    "intent-1",
+		"executor_id":  "validator-3",
+		"block_height": float64(7),
+		"success":      true,
+	})
+
+	record, ok := app.executionState["round-1"]
+	if !ok || record.ExecutorID != "validator-3" || !record.Success {
+		t.Fatalf("execution result not recorded: %+v", record)
+	}
+}
@@ -47,6 +47,7 @@ type ConsensusHealthMonitor struct {
 	onStallDetected    func(height int64, stallDuration time.Duration)
 	onRecovery         func(height int64)
 	onPeerCountLow     func(count int)
+	onPeerCountChange  func(count int)
 
 	// CometBFT status fetcher (injected)
 	statusFetcher      StatusFetcher
@@ -74,6 +75,36 @@ type ConsensusStatus struct {
 	VotingPower       int64
 }
 
+// ConsensusMetrics reports the progress of the local CometBFT consensus state machine
+type ConsensusMetrics struct {
+	Height           int64     `json:"height"` // Height being decided
+	Round            int32     `json:"round"`
+	Step             string    `json:"step"`
+	ActivePeers      int       `json:"active_peers"`
+	LastCommitHeight int64     `json:"last_commit_height"`
+	LastCommitTime   time.Time `json:"last_commit_time"` // Header time of the latest committed block
+	CatchingUp       bool      `json:"catching_up"`
+	PendingTxs       int       `json:"pending_txs"` // Transactions waiting in the mempool
+	Status           string    `json:"status"`      // "progressing", "idle", "catching_up", "stalled"
+}
+
+// EvaluateProgress sets Status from the time since the last commit. Validators only create
+// blocks for transactions, so a height that stops advancing is idle unless there is work to
+// decide: consensus is stalled when no block was committed within stallWindow while peers
+// are connected and the mempool holds transactions or the height needed another round.
+func (m *ConsensusMetrics) EvaluateProgress(stallWindow time.Duration, now time.Time) {
+	switch {
+	case m.CatchingUp:
+		m.Status = "catching_up"
+	case !m.LastCommitTime.IsZero() && now.Sub(m.LastCommitTime) <= stallWindow:
+		m.Status = "progressing"
+	case m.ActivePeers > 0 && (m.PendingTxs > 0 || m.Round > 0):
+		m.Status = "stalled"
+	default:
+		m.Status = "idle"
+	}
+}
+
 // HealthMonitorConfig configures the health monitor
 type HealthMonitorConfig struct {
 	StallThreshold  time.Duration // Default: 2 minutes
@@ -126,6 +157,21 @@ func (m *ConsensusHealthMonitor) SetOnPeerCountLow(fn func(count int)) {
 	m.onPeerCountLow = fn
 }
 
+// SetOnPeerCountChange sets callback for any change in the connected peer count
+// It is also called after the first successful check
+func (m *ConsensusHealthMonitor) SetOnPeerCountChange(fn func(count int)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPeerCountChange = fn
+}
+
+// MinPeers returns the configured minimum peer count
+func (m *ConsensusHealthMonitor) MinPeers() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.minPeers
+}
+
 // Start begins the health monitoring loop
 func (m *ConsensusHealthMonitor) Start() error {
 	m.mu.Lock()
@@ -176,7 +222,11 @@ func (m *ConsensusHealthMonitor) Check() error {
 	defer m.mu.Unlock()
 
 	now := time.Now()
+	firstCheck := m.lastCheckTime.IsZero()
 	m.lastCheckTime = now
+	if (firstCheck || status.NumPeers != m.connectedPeers) && m.onPeerCountChange != nil {
+		go m.onPeerCountChange(status.NumPeers)
+	}
 	m.connectedPeers = status.NumPeers
 
 	// Check for stall
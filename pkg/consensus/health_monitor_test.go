@@ -0,0 +1,181 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Consensus Health Monitor
+// Tests peer count change notifications, the degraded/recovered peer health transitions
+// and consensus progress evaluation
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubStatusFetcher returns a fixed status that tests update between checks
+type stubStatusFetcher struct {
+	mu     sync.Mutex
+	status ConsensusStatus
+}
+
+func (f *stubStatusFetcher) GetStatus(ctx context.Context) (*ConsensusStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status := f.status
+	return &status, nil
+}
+
+func (f *stubStatusFetcher) set(height int64, peers int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status.LatestBlockHeight = height
+	f.status.NumPeers = peers
+}
+
+func newTestHealthMonitor(fetcher StatusFetcher, minPeers int) *ConsensusHealthMonitor {
+	m := NewConsensusHealthMonitor(HealthMonitorConfig{
+		StallThreshold: time.Hour,
+		MinPeers:       minPeers,
+		CheckInterval:  time.Hour,
+	}, fetcher)
+	m.logger = log.New(io.Discard, "", 0)
+	return m
+}
+
+func TestHealthMonitor_PeerCountChange(t *testing.T) {
+	fetcher := &stubStatusFetcher{}
+	m := newTestHealthMonitor(fetcher, 2)
+
+	changes := make(chan int, 8)
+	m.SetOnPeerCountChange(func(count int) { changes <- count })
+
+	expect := func(want int) {
+		t.Helper()
+		select {
+		case got := <-changes:
+			if got != want {
+				t.Errorf("peer count change %d, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no peer count change, want %d", want)
+		}
+	}
+
+	// The first check reports the count even though it matches the zero value
+	fetcher.set(1, 0)
+	m.Check()
+	expect(0)
+
+	fetcher.set(2, 3)
+	m.Check()
+	expect(3)
+
+	// Unchanged count: no notification
+	fetcher.set(3, 3)
+	m.Check()
+	select {
+	case got := <-changes:
+		t.Errorf("unexpected peer count change %d", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	fetcher.set(4, 1)
+	m.Check()
+	expect(1)
+}
+
+func TestHealthMonitor_PeerDegradeAndRecover(t *testing.T) {
+	fetcher := &stubStatusFetcher{}
+	m := newTestHealthMonitor(fetcher, 2)
+
+	low := make(chan int, 8)
+	m.SetOnPeerCountLow(func(count int) { low <- count })
+
+	fetcher.set(1, 3)
+	if err := m.Check(); err != nil {
+		t.Fatalf("healthy check failed: %v", err)
+	}
+	if status := m.GetHealthStatus().Status; status != "healthy" {
+		t.Errorf("expected healthy, got %s", status)
+	}
+
+	// Peers drop below the minimum
+	fetcher.set(2, 1)
+	if err := m.Check(); !errors.Is(err, ErrInsufficientPeers) {
+		t.Errorf("expected ErrInsufficientPeers, got %v", err)
+	}
+	report := m.GetHealthStatus()
+	if report.Status != "degraded" || report.ConnectedPeers != 1 || report.MinPeers != 2 {
+		t.Errorf("expected degraded with 1/2 peers, got %s with %d/%d", report.Status, report.ConnectedPeers, report.MinPeers)
+	}
+	select {
+	case count := <-low:
+		if count != 1 {
+			t.Errorf("low peer callback with %d, want 1", count)
+		}
+	case <-time.After(time.Second):
+		t.Error("low peer callback not called")
+	}
+
+	// Peers reconnect
+	fetcher.set(3, 2)
+	if err := m.Check(); err != nil {
+		t.Errorf("recovered check failed: %v", err)
+	}
+	if status := m.GetHealthStatus().Status; status != "healthy" {
+		t.Errorf("expected healthy after recovery, got %s", status)
+	}
+}
+
+func TestHealthMonitor_MinPeersConcurrentWithCheck(t *testing.T) {
+	fetcher := &stubStatusFetcher{}
+	m := newTestHealthMonitor(fetcher, 2)
+
+	// MinPeers is read from the peer count callback while Check runs; run under -race
+	var wg sync.WaitGroup
+	m.SetOnPeerCountChange(func(count int) {
+		defer wg.Done()
+		if m.MinPeers() != 2 {
+			t.Errorf("expected min peers 2, got %d", m.MinPeers())
+		}
+	})
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		fetcher.set(int64(i), i)
+		m.Check()
+	}
+	wg.Wait()
+}
+
+func TestConsensusMetrics_EvaluateProgress(t *testing.T) {
+	now := time.Now()
+	window := time.Minute
+
+	tests := []struct {
+		name    string
+		metrics ConsensusMetrics
+		want    string
+	}{
+		{"catching up wins over a recent commit", ConsensusMetrics{CatchingUp: true, LastCommitTime: now}, "catching_up"},
+		{"commit within the window", ConsensusMetrics{LastCommitTime: now.Add(-30 * time.Second), ActivePeers: 3, PendingTxs: 5}, "progressing"},
+		{"commit exactly at the window", ConsensusMetrics{LastCommitTime: now.Add(-window)}, "progressing"},
+		{"old commit with pending transactions", ConsensusMetrics{LastCommitTime: now.Add(-2 * window), ActivePeers: 3, PendingTxs: 5}, "stalled"},
+		{"old commit in a later round", ConsensusMetrics{LastCommitTime: now.Add(-2 * window), ActivePeers: 3, Round: 2}, "stalled"},
+		{"no commit yet with pending transactions", ConsensusMetrics{ActivePeers: 1, PendingTxs: 1}, "stalled"},
+		{"old commit and nothing to decide", ConsensusMetrics{LastCommitTime: now.Add(-2 * window), ActivePeers: 3}, "idle"},
+		{"pending transactions without peers", ConsensusMetrics{LastCommitTime: now.Add(-2 * window), PendingTxs: 5}, "idle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.metrics
+			m.EvaluateProgress(window, now)
+			if m.Status != tt.want {
+				t.Errorf("Status = %q, want %q", m.Status, tt.want)
+			}
+		})
+	}
+}
@@ -480,6 +480,22 @@ func ComputeMessageHash(domain string, data ...[]byte) [32]byte {
 	return result
 }
 
+// ComputeAnchorMessageHash computes the BLS proof messageHash submitted to the anchor contract
+// for an operation. The contract does not derive this hash: it forwards blsProof.messageHash
+// unchanged to the BLS ZK verifier, which checks the aggregate signature against it. It must
+// therefore be exactly the digest validators sign, H(DomainAttestation || operationID), so
+// that SignWithDomain(operationID, DomainAttestation) verifies against it with Verify.
+func ComputeAnchorMessageHash(operationID string) [32]byte {
+	return ComputeMessageHash(DomainAttestation, []byte(operationID))
+}
+
+// ComputeResultMessageHash computes the BLS proof messageHash submitted to the anchor contract
+// for a result attestation. Results are signed with SignWithDomain(resultHash, DomainResult),
+// so the digest the verifier checks the aggregate signature against is H(DomainResult || resultHash).
+func ComputeResultMessageHash(resultHash [32]byte) [32]byte {
+	return ComputeMessageHash(DomainResult, resultHash[:])
+}
+
 // GenerateRandomBytes generates cryptographically secure random bytes
 func GenerateRandomBytes(n int) ([]byte, error) {
 	b := make([]byte, n)
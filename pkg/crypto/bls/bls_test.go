@@ -331,6 +331,50 @@ func TestComputeMessageHash(t *testing.T) {
 	}
 }
 
+func TestComputeAnchorMessageHash(t *testing.T) {
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	opID := "0x9f2c4a7d1e3b5c6a8d0f2e4b6c8a0d2f4e6b8c0a2d4f6e8b0c2a4d6f8e0b2c4a"
+	messageHash := ComputeAnchorMessageHash(opID)
+
+	// A validator's domain-separated signature over the operation ID must verify
+	// directly against the message hash submitted to the contract
+	sig := sk.SignWithDomain([]byte(opID), DomainAttestation)
+	if !pk.Verify(sig, messageHash[:]) {
+		t.Error("Domain signature over operation ID did not verify against anchor message hash")
+	}
+	if !pk.VerifyWithDomain(sk.Sign(messageHash[:]), []byte(opID), DomainAttestation) {
+		t.Error("Signature over anchor message hash did not verify as a domain signature")
+	}
+
+	other := ComputeAnchorMessageHash(opID[:len(opID)-1] + "b")
+	if other == messageHash || pk.Verify(sig, other[:]) {
+		t.Error("Different operation IDs must produce different message hashes")
+	}
+}
+
+func TestComputeResultMessageHash(t *testing.T) {
+	sk, pk, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	resultHash := [32]byte{0x5a}
+	messageHash := ComputeResultMessageHash(resultHash)
+
+	// A result attestation must verify directly against the message hash submitted to the contract
+	sig := sk.SignWithDomain(resultHash[:], DomainResult)
+	if !pk.Verify(sig, messageHash[:]) {
+		t.Error("Domain signature over result hash did not verify against result message hash")
+	}
+	if anchor := ComputeAnchorMessageHash(string(resultHash[:])); anchor == messageHash {
+		t.Error("Result and anchor message hashes must use different domains")
+	}
+}
+
 func TestDerivedPublicKeyConsistency(t *testing.T) {
 	sk, pk1, err := GenerateKeyPair()
 	if err != nil {
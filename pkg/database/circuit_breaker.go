@@ -0,0 +1,220 @@
+// Copyright 2025 Certen Protocol
+//
+// Database Circuit Breaker
+//
+// When PostgreSQL becomes unreachable every batch operation blocks until its connection
+// attempt times out, and callers pile up behind the collector lock. The breaker pings
+// the database on an interval:
+//   - after FailureThreshold consecutive failed pings it opens, and Allow fails fast
+//     with ErrDatabaseUnavailable instead of waiting on the pool
+//   - while open it keeps pinging, and closes again on the first successful ping
+//
+// State changes are reported to a handler, e.g. to update the health status.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrDatabaseUnavailable is returned while the circuit breaker is open
+var ErrDatabaseUnavailable = errors.New("database unavailable")
+
+// BreakerState is the state of the database circuit breaker, as reported by the health endpoint
+type BreakerState string
+
+// Breaker states
+const (
+	BreakerConnected    BreakerState = "connected"    // Closed: requests go to the database
+	BreakerDisconnected BreakerState = "disconnected" // Open: requests fail fast
+)
+
+// Pinger checks that the database is reachable
+// Implemented by Client
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// CircuitBreakerConfig configures the database circuit breaker
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failed pings that open the breaker
+	CheckInterval    time.Duration // Time between pings
+	PingTimeout      time.Duration // Timeout of each ping
+	Logger           *log.Logger
+}
+
+// DefaultCircuitBreakerConfig returns the default breaker configuration
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureThreshold: 3,
+		CheckInterval:    10 * time.Second,
+		PingTimeout:      2 * time.Second,
+		Logger:           log.New(log.Writer(), "[DBBreaker] ", log.LstdFlags),
+	}
+}
+
+// CircuitBreaker fails database work fast while the database is unreachable
+type CircuitBreaker struct {
+	pinger Pinger
+	cfg    CircuitBreakerConfig
+
+	mu            sync.RWMutex
+	state         BreakerState
+	failures      int
+	lastErr       error
+	onStateChange func(BreakerState)
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewCircuitBreaker creates a closed breaker for pinger; nil config uses the defaults
+func NewCircuitBreaker(pinger Pinger, cfg *CircuitBreakerConfig) (*CircuitBreaker, error) {
+	if pinger == nil {
+		return nil, fmt.Errorf("pinger cannot be nil")
+	}
+	if cfg == nil {
+		cfg = DefaultCircuitBreakerConfig()
+	}
+	if cfg.FailureThreshold < 1 {
+		return nil, fmt.Errorf("failure threshold must be at least 1, got %d", cfg.FailureThreshold)
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("check interval must be positive, got %s", cfg.CheckInterval)
+	}
+	resolved := *cfg
+	if resolved.PingTimeout <= 0 || resolved.PingTimeout > resolved.CheckInterval {
+		resolved.PingTimeout = resolved.CheckInterval
+	}
+	if resolved.Logger == nil {
+		resolved.Logger = log.New(log.Writer(), "[DBBreaker] ", log.LstdFlags)
+	}
+
+	return &CircuitBreaker{
+		pinger: pinger,
+		cfg:    resolved,
+		state:  BreakerConnected,
+	}, nil
+}
+
+// SetStateHandler registers a function called on every state change
+func (b *CircuitBreaker) SetStateHandler(handler func(BreakerState)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onStateChange = handler
+}
+
+// State returns the current breaker state
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.state
+}
+
+// Allow returns ErrDatabaseUnavailable, wrapping the last ping error, while the breaker is open
+func (b *CircuitBreaker) Allow() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.state == BreakerDisconnected {
+		return fmt.Errorf("%w: %v", ErrDatabaseUnavailable, b.lastErr)
+	}
+	return nil
+}
+
+// RetryAfter is how long callers rejected by an open breaker should wait before retrying
+func (b *CircuitBreaker) RetryAfter() time.Duration {
+	return b.cfg.CheckInterval
+}
+
+// Start pings the database every CheckInterval until Stop is called
+func (b *CircuitBreaker) Start() {
+	b.mu.Lock()
+	if b.stopCh != nil {
+		b.mu.Unlock()
+		return
+	}
+	b.stopCh = make(chan struct{})
+	b.doneCh = make(chan struct{})
+	b.mu.Unlock()
+
+	go b.run()
+}
+
+// Stop ends the ping loop and waits for it to exit
+func (b *CircuitBreaker) Stop() {
+	b.mu.Lock()
+	stopCh, doneCh := b.stopCh, b.doneCh
+	b.stopCh = nil
+	b.mu.Unlock()
+
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	<-doneCh
+}
+
+func (b *CircuitBreaker) run() {
+	b.mu.RLock()
+	stopCh, doneCh := b.stopCh, b.doneCh
+	b.mu.RUnlock()
+	defer close(doneCh)
+
+	ticker := time.NewTicker(b.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			b.check(context.Background())
+		}
+	}
+}
+
+// check pings the database once and updates the breaker state
+func (b *CircuitBreaker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, b.cfg.PingTimeout)
+	defer cancel()
+	b.record(b.pinger.Ping(ctx))
+}
+
+// record counts a ping result, opening the breaker after FailureThreshold consecutive
+// failures and closing it on the first success
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	previous := b.state
+	if err != nil {
+		b.failures++
+		b.lastErr = err
+		if b.failures >= b.cfg.FailureThreshold {
+			b.state = BreakerDisconnected
+		}
+	} else {
+		b.failures = 0
+		b.lastErr = nil
+		b.state = BreakerConnected
+	}
+	state := b.state
+	handler := b.onStateChange
+	b.mu.Unlock()
+
+	if state == previous {
+		return
+	}
+	if state == BreakerDisconnected {
+		b.cfg.Logger.Printf("❌ Database unreachable after %d failed pings, failing batch operations fast: %v",
+			b.cfg.FailureThreshold, err)
+	} else {
+		b.cfg.Logger.Printf("✅ Database reachable again, resuming batch operations")
+	}
+	if handler != nil {
+		handler(state)
+	}
+}
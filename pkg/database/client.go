@@ -67,10 +67,10 @@ func NewClient(cfg *config.Config, opts ...ClientOption) (*Client, error) {
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(cfg.DatabaseMaxConns)
-	db.SetMaxIdleConns(cfg.DatabaseMinConns)
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
 	db.SetConnMaxIdleTime(time.Duration(cfg.DatabaseMaxIdleTime) * time.Second)
-	db.SetConnMaxLifetime(time.Duration(cfg.DatabaseMaxLifetime) * time.Second)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
 
 	client.db = db
 
@@ -83,8 +83,8 @@ func NewClient(cfg *config.Config, opts ...ClientOption) (*Client, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	client.logger.Printf("Connected to database (max_conns=%d, min_conns=%d)",
-		cfg.DatabaseMaxConns, cfg.DatabaseMinConns)
+	client.logger.Printf("Connected to database (max_open_conns=%d, max_idle_conns=%d, conn_max_lifetime=%s)",
+		cfg.DBMaxOpenConns, cfg.DBMaxIdleConns, cfg.DBConnMaxLifetime)
 
 	return client, nil
 }
@@ -0,0 +1,60 @@
+-- Migration: 007_anchor_finality_levels.sql
+-- Description: Distinguish "available" from "final" anchors and proofs
+-- Created: 2026-02-03
+--
+-- An anchor becomes "available" once mined with a minimum number of confirmations
+-- (default 1) and "final" once it reaches its required confirmations (default 12).
+-- Clients can read proofs at the available level and accept the reorg risk, or
+-- wait for final.
+
+-- ============================================================================
+-- ANCHOR_RECORDS FINALITY LEVEL
+-- ============================================================================
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS available_confirmations INT NOT NULL DEFAULT 1;
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS available_at TIMESTAMPTZ;
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS finality VARCHAR(16) NOT NULL DEFAULT 'pending';
+
+ALTER TABLE anchor_records DROP CONSTRAINT IF EXISTS valid_anchor_finality;
+ALTER TABLE anchor_records
+ADD CONSTRAINT valid_anchor_finality CHECK (finality IN ('pending', 'available', 'final'));
+
+-- Backfill existing anchors from their confirmation state
+UPDATE anchor_records
+SET finality = 'final', available_at = COALESCE(confirmed_at, updated_at)
+WHERE is_final = true AND finality <> 'final';
+
+UPDATE anchor_records
+SET finality = 'available', available_at = updated_at
+WHERE is_final = false AND confirmations >= available_confirmations AND finality = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_anchor_records_finality ON anchor_records(finality);
+
+-- ============================================================================
+-- CERTEN_ANCHOR_PROOFS FINALITY LEVEL
+-- ============================================================================
+
+ALTER TABLE certen_anchor_proofs
+ADD COLUMN IF NOT EXISTS anchor_finality VARCHAR(16) NOT NULL DEFAULT 'pending';
+
+ALTER TABLE certen_anchor_proofs DROP CONSTRAINT IF EXISTS valid_proof_anchor_finality;
+ALTER TABLE certen_anchor_proofs
+ADD CONSTRAINT valid_proof_anchor_finality CHECK (anchor_finality IN ('pending', 'available', 'final'));
+
+UPDATE certen_anchor_proofs p
+SET anchor_finality = a.finality
+FROM anchor_records a
+WHERE p.anchor_id = a.anchor_id AND p.anchor_finality <> a.finality;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('007_anchor_finality_levels', 'Add available/final finality levels to anchors and proofs', NOW())
+ON CONFLICT (version) DO NOTHING;
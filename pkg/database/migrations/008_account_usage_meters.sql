@@ -0,0 +1,30 @@
+-- Migration: 008_account_usage_meters.sql
+-- Description: Per-account proof generation meters for billing and quotas
+-- Created: 2026-02-10
+--
+-- One row per (account, window size, window start). Windows are fixed-size and
+-- aligned to the window size in UTC (e.g. 1h windows start on the hour). Counts
+-- are split by pricing tier: on-demand proofs are also included in proof_count.
+
+CREATE TABLE IF NOT EXISTS account_usage_meters (
+    account_url     VARCHAR(512) NOT NULL,
+    window_seconds  BIGINT NOT NULL,
+    window_start    TIMESTAMPTZ NOT NULL,
+    proof_count     BIGINT NOT NULL DEFAULT 0,
+    on_demand_count BIGINT NOT NULL DEFAULT 0,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (account_url, window_seconds, window_start),
+    CONSTRAINT valid_usage_window CHECK (window_seconds > 0),
+    CONSTRAINT valid_usage_counts CHECK (proof_count >= 0 AND on_demand_count >= 0 AND on_demand_count <= proof_count)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_usage_meters_window ON account_usage_meters(window_seconds, window_start);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('008_account_usage_meters', 'Add per-account proof usage meters', NOW())
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,26 @@
+-- Migration: 009_anchor_proof_cost.sql
+-- Description: Record executeComprehensiveProof gas and calldata size per anchor
+-- Created: 2026-02-17
+--
+-- The anchor's gas_used covers the createAnchor transaction. The comprehensive proof
+-- is a second transaction whose cost scales with its ABI-encoded calldata (Merkle path
+-- and governance key page proofs); both are stored so proof size can be correlated
+-- with gas cost. NULL when the proof was not executed.
+
+-- ============================================================================
+-- ANCHOR_RECORDS PROOF COST
+-- ============================================================================
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS proof_gas_used BIGINT;
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS proof_calldata_bytes INT;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('009_anchor_proof_cost', 'Add proof gas and calldata size to anchor records', NOW())
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,35 @@
+-- Migration: 010_anchor_gas_history.sql
+-- Description: Record the fee breakdown and fee strategy of each anchor transaction
+-- Created: 2026-02-18
+--
+-- gas_price_wei holds the gas price paid by the anchor transaction. On EIP-1559 chains
+-- the block base fee and the priority fee (gas price above the base fee) are stored
+-- alongside it, with the strategy used to choose the price and whether the transaction
+-- had to be resubmitted at a higher price. Together they form the gas price history
+-- served by GET /api/v1/reports/gas-history.
+
+-- ============================================================================
+-- ANCHOR_RECORDS FEE BREAKDOWN
+-- ============================================================================
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS base_fee_wei VARCHAR(50);
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS priority_fee_wei VARCHAR(50);
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS fee_strategy VARCHAR(32);
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS gas_bumped BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_anchors_created ON anchor_records(created_at);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('010_anchor_gas_history', 'Add fee breakdown and fee strategy to anchor records', NOW())
+ON CONFLICT (version) DO NOTHING;
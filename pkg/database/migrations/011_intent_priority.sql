@@ -0,0 +1,26 @@
+-- Migration: 011_intent_priority.sql
+-- Description: Record the effective priority of each batched intent
+-- Created: 2026-02-19
+--
+-- Intents carry an optional priority hint (from their intent data or a configured
+-- rule). The effective priority is stored with the batch transaction, exposed in its
+-- proof artifact, and used to anchor closed batches holding higher-priority
+-- transactions first.
+
+-- ============================================================================
+-- BATCH_TRANSACTIONS PRIORITY
+-- ============================================================================
+
+ALTER TABLE batch_transactions
+ADD COLUMN IF NOT EXISTS priority VARCHAR(20) NOT NULL DEFAULT 'normal'
+    CHECK (priority IN ('low', 'normal', 'high', 'urgent'));
+
+CREATE INDEX IF NOT EXISTS idx_batch_tx_batch_priority ON batch_transactions(batch_id, priority);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('011_intent_priority', 'Add effective intent priority to batch transactions', NOW())
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,52 @@
+-- Migration: 012_proof_verification_failures.sql
+-- Description: Persist ProofVerificationFailed contract events
+-- Created: 2026-02-20
+--
+-- Each ProofVerificationFailed event emitted by the CertenAnchorV3 contract is stored
+-- once, keyed by the log that emitted it. The event is correlated to the local batch
+-- (decoded from the on-chain anchor ID) and to the proof artifact of the transaction it
+-- names; correlated proofs are marked 'onchain_verification_failed'. The events are
+-- served by GET /api/v1/proofs/verification-failures.
+
+-- ============================================================================
+-- PROOF_VERIFICATION_FAILURES
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS proof_verification_failures (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Event location
+    event_tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    block_number BIGINT NOT NULL,
+
+    -- Event payload
+    anchor_id BYTEA NOT NULL,
+    transaction_hash BYTEA NOT NULL,
+    merkle_verified BOOLEAN NOT NULL,
+    bls_verified BOOLEAN NOT NULL,
+    governance_verified BOOLEAN NOT NULL,
+    commitment_verified BOOLEAN NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    event_timestamp TIMESTAMPTZ,
+
+    -- Local correlation (NULL when the event could not be matched)
+    batch_id UUID REFERENCES anchor_batches(id),
+    proof_id UUID REFERENCES proof_artifacts(proof_id),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (event_tx_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pvf_created ON proof_verification_failures(created_at);
+CREATE INDEX IF NOT EXISTS idx_pvf_batch ON proof_verification_failures(batch_id);
+CREATE INDEX IF NOT EXISTS idx_pvf_proof ON proof_verification_failures(proof_id);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('012_proof_verification_failures', 'Add proof verification failure events', NOW())
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,30 @@
+-- Migration: 013_anchor_fee_escalation.sql
+-- Description: Record fee escalation steps of urgent anchor transactions
+-- Created: 2026-02-21
+--
+-- On-demand anchors may be sent on a fee escalation schedule: the transaction starts
+-- at the suggested gas price and, while unconfirmed, is replaced (same nonce) at
+-- progressively higher prices up to a ceiling. Each submission is stored here; the
+-- mined submission is flagged and its cost is the anchor's total_cost_wei.
+
+-- ============================================================================
+-- ANCHOR_FEE_ESCALATIONS
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS anchor_fee_escalations (
+    anchor_id UUID NOT NULL REFERENCES anchor_records(anchor_id),
+    attempt INT NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    gas_price_wei VARCHAR(50) NOT NULL,
+    submitted_at TIMESTAMPTZ NOT NULL,
+    mined BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (anchor_id, attempt)
+);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('013_anchor_fee_escalation', 'Add fee escalation steps of anchor transactions', NOW())
+ON CONFLICT (version) DO NOTHING;
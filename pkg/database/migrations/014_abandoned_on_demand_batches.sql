@@ -0,0 +1,51 @@
+-- Migration: 014_abandoned_on_demand_batches.sql
+-- Description: Terminal 'abandoned' state for on-demand batches that never anchored
+-- Created: 2026-02-22
+--
+-- An on-demand batch that is still pending, closed or failed after the configured
+-- abandonment period (e.g. its only transaction's proof generation failed) is marked
+-- 'abandoned'. One outcome row is recorded per transaction in the batch, noting whether
+-- the customer's metered on-demand usage was refunded, so the customer can be notified.
+-- The outcomes are served by GET /api/batches/abandoned.
+
+-- ============================================================================
+-- ANCHOR_BATCHES: allow the abandoned status
+-- ============================================================================
+
+ALTER TABLE anchor_batches DROP CONSTRAINT IF EXISTS valid_batch_status;
+ALTER TABLE anchor_batches ADD CONSTRAINT valid_batch_status
+    CHECK (status IN ('pending', 'closed', 'anchoring', 'anchored', 'confirmed', 'failed', 'abandoned'));
+
+-- ============================================================================
+-- ABANDONED_ON_DEMAND_REQUESTS
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS abandoned_on_demand_requests (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id UUID NOT NULL REFERENCES anchor_batches(id) ON DELETE CASCADE,
+    accumulate_tx_hash VARCHAR(128) NOT NULL,
+    account_url VARCHAR(512) NOT NULL,
+
+    -- Batch state when it was abandoned
+    previous_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+
+    -- When the request was metered, and whether that usage was refunded
+    requested_at TIMESTAMPTZ NOT NULL,
+    usage_refunded BOOLEAN NOT NULL DEFAULT FALSE,
+
+    abandoned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (batch_id, accumulate_tx_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_abandoned_od_account ON abandoned_on_demand_requests(account_url, abandoned_at DESC);
+CREATE INDEX IF NOT EXISTS idx_abandoned_od_abandoned ON abandoned_on_demand_requests(abandoned_at DESC);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('014_abandoned_on_demand_batches', 'Add abandoned on-demand batch outcomes', NOW())
+ON CONFLICT (version) DO NOTHING;
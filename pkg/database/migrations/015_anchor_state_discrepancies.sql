@@ -0,0 +1,40 @@
+-- Migration: 015_anchor_state_discrepancies.sql
+-- Description: Discrepancies between local anchor records and on-chain anchor state
+-- Created: 2026-02-24
+--
+-- The anchor state reconciler periodically samples anchor records and compares them
+-- with the anchor contract (anchorExists, getAnchor, getVerificationStats). Each
+-- disagreement is recorded once per anchor and kind; it is re-detected while it
+-- persists and resolved when the chain agrees with the local record again.
+-- Open discrepancies are served by GET /api/anchors/reconciliation.
+
+-- ============================================================================
+-- ANCHOR_STATE_DISCREPANCIES
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS anchor_state_discrepancies (
+    anchor_id UUID NOT NULL REFERENCES anchor_records(anchor_id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    batch_id UUID NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+
+    first_detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+
+    PRIMARY KEY (anchor_id, kind),
+    CONSTRAINT valid_discrepancy_kind CHECK (kind IN (
+        'missing_on_chain', 'invalidated_on_chain', 'commitment_mismatch',
+        'height_mismatch', 'proof_not_recorded'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_anchor_discrepancies_open ON anchor_state_discrepancies(last_detected_at DESC)
+    WHERE resolved_at IS NULL;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('015_anchor_state_discrepancies', 'Add anchor state reconciliation discrepancies', NOW())
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,30 @@
+-- Migration: 016_synthetic_transactions.sql
+-- Description: Distinguish synthetic from user transactions in batches
+-- Created: 2026-03-04
+--
+-- Accumulate produces synthetic transactions (token deposits, synthetic account
+-- creation, ...) while executing user transactions. When enabled, the validator
+-- proves and anchors them as well; each row records its origin and, for synthetic
+-- transactions, the hash of the user transaction that produced it.
+
+-- ============================================================================
+-- BATCH_TRANSACTIONS ORIGIN
+-- ============================================================================
+
+ALTER TABLE batch_transactions
+ADD COLUMN IF NOT EXISTS tx_origin VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (tx_origin IN ('user', 'synthetic'));
+
+ALTER TABLE batch_transactions
+ADD COLUMN IF NOT EXISTS origin_tx_hash VARCHAR(128);
+
+CREATE INDEX IF NOT EXISTS idx_batch_tx_origin_tx_hash ON batch_transactions(origin_tx_hash)
+    WHERE origin_tx_hash IS NOT NULL;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('016_synthetic_transactions', 'Add transaction origin to batch transactions', NOW())
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,41 @@
+-- Migration: 017_proof_cycle_states.sql
+-- Description: In-flight proof cycle state for resuming cycles after a restart
+-- Created: 2026-03-11
+--
+-- The proof cycle orchestrator records each cycle's stage (observing, attesting,
+-- writing_back) and the artifacts produced so far. On startup, cycles that have not
+-- completed or failed are reloaded and continued from their stage. The synthetic
+-- write-back transaction is stored before it is submitted and again with its receipt
+-- after submission, so a resumed cycle never submits a write-back twice.
+
+-- ============================================================================
+-- PROOF_CYCLE_STATES
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS proof_cycle_states (
+    cycle_id VARCHAR(256) PRIMARY KEY,
+    intent_id VARCHAR(256) NOT NULL,
+    stage VARCHAR(16) NOT NULL,
+    execution_tx_hash VARCHAR(66) NOT NULL DEFAULT '', -- Single-transaction cycles only
+    cycle_data JSONB NOT NULL,                         -- ProofCycleCompletion
+    write_back_tx JSONB,                               -- SyntheticTransaction, once built
+    error_message TEXT NOT NULL DEFAULT '',
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ,
+
+    CONSTRAINT valid_proof_cycle_stage CHECK (stage IN (
+        'observing', 'attesting', 'writing_back', 'completed', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_proof_cycle_states_pending ON proof_cycle_states(created_at)
+    WHERE finished_at IS NULL;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('017_proof_cycle_states', 'Add in-flight proof cycle state', NOW())
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,39 @@
+-- Migration: 018_anchor_reorgs.sql
+-- Description: Mark anchors and proofs whose anchor block was reorged out
+-- Created: 2026-03-13
+--
+-- The confirmation tracker records the hash of the block an anchor was mined in.
+-- When the canonical hash at that height changes, the anchor is marked "reorged"
+-- and its confirmations restart from zero. It returns to "pending" once its
+-- transaction is found in a canonical block again; if the transaction is no longer
+-- mined at all, it stays "reorged" for an operator to re-anchor.
+
+-- ============================================================================
+-- ANCHOR_RECORDS REORG STATE
+-- ============================================================================
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS reorg_count INT NOT NULL DEFAULT 0;
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS reorged_at TIMESTAMPTZ;
+
+ALTER TABLE anchor_records DROP CONSTRAINT IF EXISTS valid_anchor_finality;
+ALTER TABLE anchor_records
+ADD CONSTRAINT valid_anchor_finality CHECK (finality IN ('pending', 'available', 'final', 'reorged'));
+
+-- ============================================================================
+-- CERTEN_ANCHOR_PROOFS REORG STATE
+-- ============================================================================
+
+ALTER TABLE certen_anchor_proofs DROP CONSTRAINT IF EXISTS valid_proof_anchor_finality;
+ALTER TABLE certen_anchor_proofs
+ADD CONSTRAINT valid_proof_anchor_finality CHECK (anchor_finality IN ('pending', 'available', 'final', 'reorged'));
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('018_anchor_reorgs', 'Add reorged finality state to anchors and proofs', NOW())
+ON CONFLICT (version) DO NOTHING;
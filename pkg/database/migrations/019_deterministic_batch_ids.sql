@@ -0,0 +1,26 @@
+-- Migration: 019_deterministic_batch_ids.sql
+-- Description: Allow a pending batch to take its content-derived ID when it closes
+-- Created: 2026-03-14
+--
+-- With DETERMINISTIC_BATCH_ID the collector opens a batch under a random ID and,
+-- once its transactions are known, renames it to an ID derived from the sorted
+-- transaction hashes and the Accumulate block height, so that validators batching
+-- the same transactions agree on the batch ID. Only batch_transactions reference a
+-- batch while it is pending, so its foreign key follows the rename.
+
+-- ============================================================================
+-- BATCH_TRANSACTIONS FOREIGN KEY
+-- ============================================================================
+
+ALTER TABLE batch_transactions DROP CONSTRAINT IF EXISTS batch_transactions_batch_id_fkey;
+ALTER TABLE batch_transactions
+ADD CONSTRAINT batch_transactions_batch_id_fkey
+    FOREIGN KEY (batch_id) REFERENCES anchor_batches(id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('019_deterministic_batch_ids', 'Cascade batch ID renames to batch transactions', NOW())
+ON CONFLICT (version) DO NOTHING;
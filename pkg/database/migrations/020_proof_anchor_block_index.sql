@@ -0,0 +1,23 @@
+-- Migration: 020_proof_anchor_block_index.sql
+-- Description: Index proofs by anchoring chain and block for block range lookups
+-- Created: 2026-03-16
+--
+-- Operators reconcile proofs against on-chain AnchorCreated events by block range
+-- (GET /api/v1/proofs/blocks). Proofs that are not anchored yet have no block and
+-- are left out of the index.
+
+-- ============================================================================
+-- PROOF_ARTIFACTS ANCHOR BLOCK INDEX
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_proof_artifacts_anchor_block
+    ON proof_artifacts(anchor_chain, anchor_block_number)
+    WHERE anchor_block_number IS NOT NULL;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('020_proof_anchor_block_index', 'Index proofs by anchor chain and block', NOW())
+ON CONFLICT (version) DO NOTHING;
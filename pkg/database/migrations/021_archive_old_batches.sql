@@ -0,0 +1,59 @@
+-- Migration: 021_archive_old_batches.sql
+-- Description: Archive tables for batches and proofs past the retention window
+-- Created: 2026-03-18
+--
+-- With PROOF_RETENTION_DAYS set, batches whose anchors all reached finality before
+-- the retention window are archived: each proof artifact is stored with its detail
+-- records (chained layers, governance levels, attestations, anchor reference and
+-- verifications) as one JSON document in archived_proofs, the batch transactions and
+-- certen anchor proofs are stored as JSON in archived_batches, and the hot rows are
+-- deleted. The anchor_batches and anchor_records rows are kept (marked archived) so
+-- anchors can still be reconciled against the chain. Archived proofs remain available
+-- from GET /api/v1/proofs/:id.
+
+-- ============================================================================
+-- ANCHOR_BATCHES ARCHIVAL MARKER
+-- ============================================================================
+
+ALTER TABLE anchor_batches
+ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_batches_unarchived ON anchor_batches(created_at)
+    WHERE archived_at IS NULL AND status IN ('anchored', 'confirmed');
+
+-- ============================================================================
+-- ARCHIVED_BATCHES
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS archived_batches (
+    batch_id UUID PRIMARY KEY REFERENCES anchor_batches(id),
+    transactions JSONB NOT NULL DEFAULT '[]',   -- batch_transactions rows
+    anchor_proofs JSONB NOT NULL DEFAULT '[]',  -- certen_anchor_proofs rows
+    proof_count INT NOT NULL DEFAULT 0,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ============================================================================
+-- ARCHIVED_PROOFS
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS archived_proofs (
+    proof_id UUID PRIMARY KEY,
+    batch_id UUID NOT NULL REFERENCES archived_batches(batch_id),
+    accum_tx_hash VARCHAR(128) NOT NULL,
+    account_url VARCHAR(512) NOT NULL,
+    proof JSONB NOT NULL,                       -- The proof artifact with its details
+    created_at TIMESTAMPTZ NOT NULL,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_proofs_batch ON archived_proofs(batch_id);
+CREATE INDEX IF NOT EXISTS idx_archived_proofs_tx_hash ON archived_proofs(accum_tx_hash);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('021_archive_old_batches', 'Add archive tables for batches and proofs past retention', NOW())
+ON CONFLICT (version) DO NOTHING;
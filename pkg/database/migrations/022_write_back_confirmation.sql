@@ -0,0 +1,37 @@
+-- Migration: 022_write_back_confirmation.sql
+-- Description: Record the Accumulate write-back txid and unconfirmed write-backs
+-- Created: 2026-03-19
+--
+-- After submitting a write-back the orchestrator polls Accumulate until the
+-- transaction is delivered, fails, or the confirmation timeout passes. A cycle whose
+-- write-back was not confirmed in time finishes in the write_back_unconfirmed stage
+-- rather than failed, since the transaction may still be delivered. The Accumulate
+-- transaction ID is stored in its own column so operators can look it up.
+
+-- ============================================================================
+-- PROOF_CYCLE_STATES
+-- ============================================================================
+
+ALTER TABLE proof_cycle_states
+ALTER COLUMN stage TYPE VARCHAR(32);
+
+ALTER TABLE proof_cycle_states
+ADD COLUMN IF NOT EXISTS write_back_tx_id VARCHAR(256) NOT NULL DEFAULT '';
+
+ALTER TABLE proof_cycle_states
+DROP CONSTRAINT IF EXISTS valid_proof_cycle_stage;
+
+ALTER TABLE proof_cycle_states
+ADD CONSTRAINT valid_proof_cycle_stage CHECK (stage IN (
+    'observing', 'attesting', 'writing_back', 'completed', 'failed', 'write_back_unconfirmed'));
+
+CREATE INDEX IF NOT EXISTS idx_proof_cycle_states_write_back_tx_id ON proof_cycle_states(write_back_tx_id)
+    WHERE write_back_tx_id <> '';
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('022_write_back_confirmation', 'Record write-back txid and unconfirmed write-backs', NOW())
+ON CONFLICT (version) DO NOTHING;
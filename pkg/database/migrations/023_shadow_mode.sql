@@ -0,0 +1,33 @@
+-- Migration: 023_shadow_mode.sql
+-- Description: Flag anchors and proof cycles recorded in shadow mode
+-- Created: 2026-03-24
+--
+-- In shadow mode the validator runs the full pipeline - gas estimation, proof
+-- assembly, write-back signing - but never sends a transaction. Results are still
+-- recorded so a shadow run can be inspected, and flagged so they are never
+-- mistaken for real anchors or tracked for confirmations.
+
+-- ============================================================================
+-- ANCHOR_RECORDS
+-- ============================================================================
+
+ALTER TABLE anchor_records
+ADD COLUMN IF NOT EXISTS shadow BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_anchor_records_shadow ON anchor_records(created_at)
+    WHERE shadow = TRUE;
+
+-- ============================================================================
+-- PROOF_CYCLE_STATES
+-- ============================================================================
+
+ALTER TABLE proof_cycle_states
+ADD COLUMN IF NOT EXISTS shadow BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('023_shadow_mode', 'Flag anchors and proof cycles recorded in shadow mode', NOW())
+ON CONFLICT (version) DO NOTHING;
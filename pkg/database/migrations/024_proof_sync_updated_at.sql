@@ -0,0 +1,39 @@
+-- Migration: 024_proof_sync_updated_at.sql
+-- Description: Track when proof artifacts last changed for the audit sync cursor
+-- Created: 2026-03-26
+--
+-- The proof sync endpoint resumes from a (timestamp, proof_id) cursor. Keyed on
+-- created_at, a proof that was streamed while pending and anchored or verified
+-- later was never sent again. updated_at moves forward on every update, so the
+-- sync cursor follows it and re-sends a proof each time it changes; auditing
+-- nodes upsert by proof_id.
+
+-- ============================================================================
+-- PROOF_ARTIFACTS
+-- ============================================================================
+
+ALTER TABLE proof_artifacts
+ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+-- Existing rows: the latest lifecycle timestamp recorded so far
+UPDATE proof_artifacts
+SET updated_at = GREATEST(created_at, COALESCE(anchored_at, created_at), COALESCE(verified_at, created_at))
+WHERE updated_at IS NULL;
+
+ALTER TABLE proof_artifacts ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE proof_artifacts ALTER COLUMN updated_at SET NOT NULL;
+
+DROP TRIGGER IF EXISTS update_proof_artifacts_updated_at ON proof_artifacts;
+CREATE TRIGGER update_proof_artifacts_updated_at
+    BEFORE UPDATE ON proof_artifacts
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX IF NOT EXISTS idx_proof_artifacts_sync ON proof_artifacts(updated_at, proof_id);
+
+-- ============================================================================
+-- RECORD MIGRATION
+-- ============================================================================
+
+INSERT INTO schema_migrations (version, description, applied_at)
+VALUES ('024_proof_sync_updated_at', 'Track when proof artifacts last changed for the audit sync cursor', NOW())
+ON CONFLICT (version) DO NOTHING;
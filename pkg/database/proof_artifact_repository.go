@@ -195,6 +195,90 @@ func (r *ProofArtifactRepository) GetProofsByAccount(ctx context.Context, accoun
 	return summaries, nil
 }
 
+// QueryProofsByAccount retrieves one page of an account's proofs, newest first, with
+// the total number of proofs matching the filters. Pages continue from a cursor
+// rather than an offset so deep pages stay cheap on busy accounts.
+func (r *ProofArtifactRepository) QueryProofsByAccount(ctx context.Context, q *AccountProofQuery) (*ProofSummaryPage, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	conditions := []string{"pa.account_url = $1"}
+	args := []interface{}{q.AccountURL}
+	if q.Status != nil {
+		args = append(args, *q.Status)
+		conditions = append(conditions, fmt.Sprintf("pa.status = $%d", len(args)))
+	}
+	if q.CreatedAfter != nil {
+		args = append(args, *q.CreatedAfter)
+		conditions = append(conditions, fmt.Sprintf("pa.created_at >= $%d", len(args)))
+	}
+	if q.CreatedBefore != nil {
+		args = append(args, *q.CreatedBefore)
+		conditions = append(conditions, fmt.Sprintf("pa.created_at <= $%d", len(args)))
+	}
+	whereClause := strings.Join(conditions, " AND ")
+
+	page := &ProofSummaryPage{}
+	countQuery := "SELECT COUNT(*) FROM proof_artifacts pa WHERE " + whereClause
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("failed to count proofs by account: %w", err)
+	}
+
+	offset := q.Offset
+	if q.After != nil {
+		args = append(args, q.After.CreatedAt, q.After.ProofID)
+		whereClause += fmt.Sprintf(" AND (pa.created_at, pa.proof_id) < ($%d, $%d)", len(args)-1, len(args))
+		offset = 0
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	args = append(args, limit+1, offset)
+	query := fmt.Sprintf(`
+		SELECT pa.proof_id, pa.proof_type, pa.accum_tx_hash, pa.account_url,
+			   pa.gov_level, pa.status, pa.created_at, pa.anchored_at,
+			   COALESCE((SELECT COUNT(*) FROM validator_attestations va WHERE va.proof_id = pa.proof_id), 0) as attestation_count
+		FROM proof_artifacts pa
+		WHERE %s
+		ORDER BY pa.created_at DESC, pa.proof_id DESC
+		LIMIT $%d OFFSET $%d`, whereClause, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query proofs by account: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s ProofSummary
+		if err := rows.Scan(
+			&s.ProofID, &s.ProofType, &s.AccumTxHash, &s.AccountURL,
+			&s.GovLevel, &s.Status, &s.CreatedAt, &s.AnchoredAt,
+			&s.AttestationCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan proof summary: %w", err)
+		}
+		page.Proofs = append(page.Proofs, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate proofs by account: %w", err)
+	}
+
+	if len(page.Proofs) > limit {
+		page.Proofs = page.Proofs[:limit]
+		last := page.Proofs[limit-1]
+		page.Next = &ProofCursor{CreatedAt: last.CreatedAt, ProofID: last.ProofID}
+	}
+	return page, nil
+}
+
 // GetProofsByBatch retrieves all proofs in a batch
 func (r *ProofArtifactRepository) GetProofsByBatch(ctx context.Context, batchID uuid.UUID) ([]ProofArtifact, error) {
 	query := `
@@ -267,6 +351,55 @@ func (r *ProofArtifactRepository) GetProofsByAnchorTx(ctx context.Context, ancho
 	return proofs, nil
 }
 
+// GetProofsByBlockRange retrieves proofs anchored on chain in blocks fromBlock to toBlock
+// (inclusive), ordered by block and anchor transaction (paginated)
+func (r *ProofArtifactRepository) GetProofsByBlockRange(ctx context.Context, chain string, fromBlock, toBlock int64, limit, offset int) ([]ProofArtifact, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT pa.proof_id, pa.proof_type, pa.proof_version, pa.accum_tx_hash, pa.account_url,
+			   pa.batch_id, pa.batch_position, pa.anchor_id, pa.anchor_tx_hash, pa.anchor_block_number, pa.anchor_chain,
+			   pa.merkle_root, pa.leaf_hash, pa.leaf_index, pa.gov_level, pa.proof_class, pa.validator_id,
+			   pa.status, pa.verification_status, pa.created_at, pa.anchored_at, pa.verified_at,
+			   pa.artifact_json, pa.artifact_hash
+		FROM proof_artifacts pa
+		WHERE pa.anchor_chain = $1
+		  AND pa.anchor_block_number BETWEEN $2 AND $3
+		ORDER BY pa.anchor_block_number, pa.anchor_tx_hash, pa.batch_position, pa.proof_id
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.QueryContext(ctx, query, chain, fromBlock, toBlock, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query proofs by block range: %w", err)
+	}
+	defer rows.Close()
+
+	var proofs []ProofArtifact
+	for rows.Next() {
+		var p ProofArtifact
+		if err := rows.Scan(
+			&p.ProofID, &p.ProofType, &p.ProofVersion, &p.AccumTxHash, &p.AccountURL,
+			&p.BatchID, &p.BatchPosition, &p.AnchorID, &p.AnchorTxHash, &p.AnchorBlockNumber, &p.AnchorChain,
+			&p.MerkleRoot, &p.LeafHash, &p.LeafIndex, &p.GovLevel, &p.ProofClass, &p.ValidatorID,
+			&p.Status, &p.VerificationStatus, &p.CreatedAt, &p.AnchoredAt, &p.VerifiedAt,
+			&p.ArtifactJSON, &p.ArtifactHash,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan proof: %w", err)
+		}
+		proofs = append(proofs, p)
+	}
+
+	return proofs, rows.Err()
+}
+
 // QueryProofs executes a filtered query on proofs
 func (r *ProofArtifactRepository) QueryProofs(ctx context.Context, filter *ProofArtifactFilter) ([]ProofSummary, error) {
 	if filter == nil {
@@ -490,6 +623,27 @@ func (r *ProofArtifactRepository) UpdateProofGovLevel(ctx context.Context, proof
 	return nil
 }
 
+// UpdateProofMerkleInclusion replaces the Merkle inclusion data of a proof
+// Used when a proof is regenerated after failing on-chain verification
+func (r *ProofArtifactRepository) UpdateProofMerkleInclusion(ctx context.Context, proofID uuid.UUID, merkleRoot, leafHash []byte, leafIndex int) error {
+	query := `
+		UPDATE proof_artifacts
+		SET merkle_root = $2, leaf_hash = $3, leaf_index = $4
+		WHERE proof_id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, proofID, merkleRoot, leafHash, leafIndex)
+	if err != nil {
+		return fmt.Errorf("failed to update proof merkle inclusion: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("proof not found: %s", proofID)
+	}
+
+	return nil
+}
+
 // UpdateProofFinalState updates the final state of a proof after cycle completes
 // This is a comprehensive update that sets anchor info, status, gov_level, and verification in one call
 func (r *ProofArtifactRepository) UpdateProofFinalState(ctx context.Context, proofID uuid.UUID, anchorTxHash string, anchorBlockNumber int64, anchorChain string, govLevel GovernanceLevel, verified bool) error {
@@ -753,6 +907,9 @@ func (r *ProofArtifactRepository) CreateChainedProofLayer(ctx context.Context, i
 	layer.DNBlockHeight = input.DNBlockHeight
 	layer.ConsensusTimestamp = input.ConsensusTimestamp
 	layer.LayerJSON = input.LayerJSON
+	layer.SourceHash = input.SourceHash
+	layer.TargetHash = input.TargetHash
+	layer.ReceiptEntries = input.ReceiptEntries
 
 	err := r.db.QueryRowContext(ctx, query,
 		input.ProofID, input.LayerNumber, input.LayerName,
@@ -776,7 +933,8 @@ func (r *ProofArtifactRepository) GetChainedProofLayers(ctx context.Context, pro
 			   bvn_partition, receipt_anchor,
 			   bvn_root, dn_root, anchor_sequence, bvn_partition_id,
 			   dn_block_hash, dn_block_height, consensus_timestamp,
-			   layer_json, verified, verified_at, created_at
+			   layer_json, source_hash, target_hash, receipt_entries,
+			   verified, verified_at, created_at
 		FROM chained_proof_layers
 		WHERE proof_id = $1
 		ORDER BY layer_number`
@@ -790,15 +948,22 @@ func (r *ProofArtifactRepository) GetChainedProofLayers(ctx context.Context, pro
 	var layers []ChainedProofLayer
 	for rows.Next() {
 		var l ChainedProofLayer
+		var receiptEntries []byte
 		if err := rows.Scan(
 			&l.LayerID, &l.ProofID, &l.LayerNumber, &l.LayerName,
 			&l.BVNPartition, &l.ReceiptAnchor,
 			&l.BVNRoot, &l.DNRoot, &l.AnchorSequence, &l.BVNPartitionID,
 			&l.DNBlockHash, &l.DNBlockHeight, &l.ConsensusTimestamp,
-			&l.LayerJSON, &l.Verified, &l.VerifiedAt, &l.CreatedAt,
+			&l.LayerJSON, &l.SourceHash, &l.TargetHash, &receiptEntries,
+			&l.Verified, &l.VerifiedAt, &l.CreatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan chained proof layer: %w", err)
 		}
+		if len(receiptEntries) > 0 {
+			if err := json.Unmarshal(receiptEntries, &l.ReceiptEntries); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal receipt_entries: %w", err)
+			}
+		}
 		layers = append(layers, l)
 	}
 
@@ -1210,45 +1375,105 @@ func (r *ProofArtifactRepository) GetAnchorReference(ctx context.Context, proofI
 // SYNC OPERATIONS (For Auditing Nodes)
 // ============================================================================
 
-// GetProofsModifiedSince retrieves proofs modified since a timestamp
-func (r *ProofArtifactRepository) GetProofsModifiedSince(ctx context.Context, since time.Time, limit int) ([]ProofArtifact, error) {
+// StreamProofsSince calls fn for up to limit proofs that follow the after cursor in
+// (updated_at, proof_id) order, reading rows as they arrive instead of buffering the
+// result set. fn also receives the proof's own cursor to resume after it. A proof that
+// changes after it was streamed (anchored, verified) is streamed again. A nil cursor
+// starts from the first proof. Reports whether more proofs follow the last one passed
+// to fn; an error from fn stops the stream and is returned.
+func (r *ProofArtifactRepository) StreamProofsSince(ctx context.Context, after *ProofSyncCursor, limit int, fn func(*ProofArtifact, ProofSyncCursor) error) (bool, error) {
 	if limit <= 0 {
 		limit = 1000
 	}
+	cursor := ProofSyncCursor{}
+	if after != nil {
+		cursor = *after
+	}
 
+	// One extra row tells whether another page follows
 	query := `
 		SELECT proof_id, proof_type, proof_version, accum_tx_hash, account_url,
 			   batch_id, batch_position, anchor_id, anchor_tx_hash, anchor_block_number, anchor_chain,
 			   merkle_root, leaf_hash, leaf_index, gov_level, proof_class, validator_id,
 			   status, verification_status, created_at, anchored_at, verified_at,
-			   artifact_json, artifact_hash
+			   artifact_json, artifact_hash, updated_at
 		FROM proof_artifacts
-		WHERE created_at > $1 OR anchored_at > $1
-		ORDER BY COALESCE(anchored_at, created_at)
-		LIMIT $2`
+		WHERE (updated_at, proof_id) > ($1, $2)
+		ORDER BY updated_at, proof_id
+		LIMIT $3`
 
-	rows, err := r.db.QueryContext(ctx, query, since, limit)
+	rows, err := r.db.QueryContext(ctx, query, cursor.UpdatedAt, cursor.ProofID, limit+1)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query proofs modified since: %w", err)
+		return false, fmt.Errorf("failed to query proofs since cursor: %w", err)
 	}
 	defer rows.Close()
 
-	var proofs []ProofArtifact
+	streamed := 0
 	for rows.Next() {
+		if streamed == limit {
+			return true, nil
+		}
 		var p ProofArtifact
+		var updatedAt time.Time
 		if err := rows.Scan(
 			&p.ProofID, &p.ProofType, &p.ProofVersion, &p.AccumTxHash, &p.AccountURL,
 			&p.BatchID, &p.BatchPosition, &p.AnchorID, &p.AnchorTxHash, &p.AnchorBlockNumber, &p.AnchorChain,
 			&p.MerkleRoot, &p.LeafHash, &p.LeafIndex, &p.GovLevel, &p.ProofClass, &p.ValidatorID,
 			&p.Status, &p.VerificationStatus, &p.CreatedAt, &p.AnchoredAt, &p.VerifiedAt,
-			&p.ArtifactJSON, &p.ArtifactHash,
+			&p.ArtifactJSON, &p.ArtifactHash, &updatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan proof: %w", err)
+			return false, fmt.Errorf("failed to scan proof: %w", err)
 		}
-		proofs = append(proofs, p)
+		if err := fn(&p, ProofSyncCursor{UpdatedAt: updatedAt, ProofID: p.ProofID}); err != nil {
+			return false, err
+		}
+		streamed++
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("failed to iterate proofs: %w", err)
 	}
 
-	return proofs, nil
+	return false, nil
+}
+
+// GetProofMerkleInclusion retrieves the batch Merkle inclusion and anchor of a proof
+// (nil if the proof does not exist)
+func (r *ProofArtifactRepository) GetProofMerkleInclusion(ctx context.Context, proofID uuid.UUID) (*ProofMerkleInclusion, error) {
+	query := `
+		SELECT pa.proof_id, pa.accum_tx_hash, pa.account_url, pa.batch_id,
+			   COALESCE(pa.leaf_hash, bt.transaction_hash),
+			   COALESCE(pa.leaf_index, bt.tree_index),
+			   COALESCE(pa.merkle_root, ab.merkle_root),
+			   COALESCE(pa.merkle_path, bt.merkle_path),
+			   COALESCE(pa.anchor_tx_hash, ab.anchor_tx_hash),
+			   COALESCE(pa.anchor_block_number, ab.anchor_block_num),
+			   COALESCE(pa.anchor_chain, ab.target_chain)
+		FROM proof_artifacts pa
+		LEFT JOIN batch_transactions bt ON bt.batch_id = pa.batch_id AND bt.accumulate_tx_hash = pa.accum_tx_hash
+		LEFT JOIN anchor_batches ab ON ab.id = pa.batch_id
+		WHERE pa.proof_id = $1`
+
+	var inclusion ProofMerkleInclusion
+	var merklePathJSON []byte
+	err := r.db.QueryRowContext(ctx, query, proofID).Scan(
+		&inclusion.ProofID, &inclusion.AccumTxHash, &inclusion.AccountURL, &inclusion.BatchID,
+		&inclusion.LeafHash, &inclusion.LeafIndex, &inclusion.MerkleRoot, &merklePathJSON,
+		&inclusion.AnchorTxHash, &inclusion.AnchorBlockNumber, &inclusion.AnchorChain,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proof merkle inclusion: %w", err)
+	}
+
+	if len(merklePathJSON) > 0 {
+		if err := json.Unmarshal(merklePathJSON, &inclusion.MerklePath); err != nil {
+			return nil, fmt.Errorf("failed to decode merkle path: %w", err)
+		}
+	}
+
+	return &inclusion, nil
 }
 
 // GetBatchProofStats retrieves statistics for a batch
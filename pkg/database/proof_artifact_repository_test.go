@@ -209,6 +209,91 @@ func TestGetProofsByAccount(t *testing.T) {
 	}
 }
 
+func TestQueryProofsByAccount(t *testing.T) {
+	if testDB == nil {
+		t.Skip("Test database not configured")
+	}
+
+	repo := NewProofArtifactRepository(testDB)
+	ctx := context.Background()
+
+	accountURL := "acc://test-" + uuid.New().String()[:8] + ".acme/tokens"
+
+	var createdIDs []uuid.UUID
+	for i := 0; i < 5; i++ {
+		input := &NewProofArtifact{
+			ProofType:    ProofTypeCertenAnchor,
+			AccumTxHash:  "test_tx_" + uuid.New().String()[:8],
+			AccountURL:   accountURL,
+			ProofClass:   ProofClassOnCadence,
+			ValidatorID:  "test-validator-1",
+			ArtifactJSON: json.RawMessage(`{"index": ` + string(rune('0'+i)) + `}`),
+		}
+		proof, err := repo.CreateProofArtifact(ctx, input)
+		if err != nil {
+			t.Fatalf("Failed to create proof %d: %v", i, err)
+		}
+		createdIDs = append(createdIDs, proof.ProofID)
+	}
+	defer func() {
+		for _, id := range createdIDs {
+			_, _ = testDB.ExecContext(ctx, "DELETE FROM proof_artifacts WHERE proof_id = $1", id)
+		}
+	}()
+	if err := repo.UpdateProofStatus(ctx, createdIDs[0], ProofStatusFailed); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+
+	// Walk every page with the cursor
+	seen := make(map[uuid.UUID]bool)
+	query := &AccountProofQuery{AccountURL: accountURL, Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("Cursor pagination did not terminate")
+		}
+		page, err := repo.QueryProofsByAccount(ctx, query)
+		if err != nil {
+			t.Fatalf("Failed to query page %d: %v", pages, err)
+		}
+		if page.Total != 5 {
+			t.Errorf("Expected total 5, got %d", page.Total)
+		}
+		for _, p := range page.Proofs {
+			if seen[p.ProofID] {
+				t.Errorf("Proof %s returned on two pages", p.ProofID)
+			}
+			seen[p.ProofID] = true
+		}
+		if page.Next == nil {
+			break
+		}
+		query.After = page.Next
+	}
+	if len(seen) != 5 {
+		t.Errorf("Expected 5 proofs across pages, got %d", len(seen))
+	}
+
+	// Status filter
+	failed := ProofStatusFailed
+	page, err := repo.QueryProofsByAccount(ctx, &AccountProofQuery{AccountURL: accountURL, Status: &failed})
+	if err != nil {
+		t.Fatalf("Failed to query by status: %v", err)
+	}
+	if page.Total != 1 || len(page.Proofs) != 1 || page.Next != nil {
+		t.Errorf("Expected 1 failed proof on a single page, got total %d, %d proofs", page.Total, len(page.Proofs))
+	}
+
+	// Time range that excludes every proof
+	future := time.Now().Add(time.Hour)
+	page, err = repo.QueryProofsByAccount(ctx, &AccountProofQuery{AccountURL: accountURL, CreatedAfter: &future})
+	if err != nil {
+		t.Fatalf("Failed to query by time range: %v", err)
+	}
+	if page.Total != 0 || len(page.Proofs) != 0 {
+		t.Errorf("Expected no proofs after %s, got %d", future, page.Total)
+	}
+}
+
 func TestUpdateProofAnchored(t *testing.T) {
 	if testDB == nil {
 		t.Skip("Test database not configured")
@@ -259,6 +344,136 @@ func TestUpdateProofAnchored(t *testing.T) {
 	}
 }
 
+func TestStreamProofsSince(t *testing.T) {
+	if testDB == nil {
+		t.Skip("Test database not configured")
+	}
+
+	repo := NewProofArtifactRepository(testDB)
+	ctx := context.Background()
+
+	var proofIDs []uuid.UUID
+	for i := 0; i < 3; i++ {
+		proof, err := repo.CreateProofArtifact(ctx, &NewProofArtifact{
+			ProofType:    ProofTypeCertenAnchor,
+			AccumTxHash:  "test_tx_" + uuid.New().String()[:8],
+			AccountURL:   "acc://test.acme/tokens",
+			ProofClass:   ProofClassOnCadence,
+			ValidatorID:  "test-validator-1",
+			ArtifactJSON: json.RawMessage(`{"test": true}`),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create proof: %v", err)
+		}
+		proofIDs = append(proofIDs, proof.ProofID)
+	}
+	defer func() {
+		for _, id := range proofIDs {
+			_, _ = testDB.ExecContext(ctx, "DELETE FROM proof_artifacts WHERE proof_id = $1", id)
+		}
+	}()
+
+	// Start just before the first test proof
+	var firstUpdated time.Time
+	if err := testDB.QueryRowContext(ctx, "SELECT updated_at FROM proof_artifacts WHERE proof_id = $1", proofIDs[0]).Scan(&firstUpdated); err != nil {
+		t.Fatalf("Failed to read updated_at: %v", err)
+	}
+	after := &ProofSyncCursor{UpdatedAt: firstUpdated.Add(-time.Microsecond)}
+
+	var streamed []uuid.UUID
+	collect := func(p *ProofArtifact, cursor ProofSyncCursor) error {
+		if cursor.ProofID != p.ProofID {
+			t.Errorf("Cursor proof ID %s does not match proof %s", cursor.ProofID, p.ProofID)
+		}
+		streamed = append(streamed, p.ProofID)
+		after = &cursor
+		return nil
+	}
+
+	// limit + 1 rows exist: the page is full and more follow
+	hasMore, err := repo.StreamProofsSince(ctx, after, 2, collect)
+	if err != nil {
+		t.Fatalf("Failed to stream proofs: %v", err)
+	}
+	if !hasMore || len(streamed) != 2 || streamed[0] != proofIDs[0] || streamed[1] != proofIDs[1] {
+		t.Fatalf("Expected the first two proofs with more to follow, got %v (has more %v)", streamed, hasMore)
+	}
+
+	// Resuming from the cursor continues with the remaining proof
+	streamed = nil
+	hasMore, err = repo.StreamProofsSince(ctx, after, 2, collect)
+	if err != nil {
+		t.Fatalf("Failed to resume stream: %v", err)
+	}
+	if hasMore || len(streamed) != 1 || streamed[0] != proofIDs[2] {
+		t.Fatalf("Expected only the third proof, got %v (has more %v)", streamed, hasMore)
+	}
+
+	// A proof anchored after it was streamed is streamed again
+	if err := repo.UpdateProofAnchored(ctx, proofIDs[0], uuid.New(), "0x"+uuid.New().String()[:32], 12345678, "ethereum"); err != nil {
+		t.Fatalf("Failed to update proof as anchored: %v", err)
+	}
+	streamed = nil
+	if _, err := repo.StreamProofsSince(ctx, after, 10, collect); err != nil {
+		t.Fatalf("Failed to stream updated proofs: %v", err)
+	}
+	if len(streamed) != 1 || streamed[0] != proofIDs[0] {
+		t.Errorf("Expected the anchored proof to be streamed again, got %v", streamed)
+	}
+}
+
+func TestGetProofsByBlockRange(t *testing.T) {
+	if testDB == nil {
+		t.Skip("Test database not configured")
+	}
+
+	repo := NewProofArtifactRepository(testDB)
+	ctx := context.Background()
+
+	// A chain name unique to this run keeps other proofs out of the range
+	chain := "test-chain-" + uuid.New().String()[:8]
+	for _, block := range []int64{100, 150, 200, 250} {
+		proof, err := repo.CreateProofArtifact(ctx, &NewProofArtifact{
+			ProofType:    ProofTypeCertenAnchor,
+			AccumTxHash:  "test_tx_" + uuid.New().String()[:8],
+			AccountURL:   "acc://test.acme/tokens",
+			ProofClass:   ProofClassOnCadence,
+			ValidatorID:  "test-validator-1",
+			ArtifactJSON: json.RawMessage(`{"test": true}`),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create proof: %v", err)
+		}
+		defer func() {
+			_, _ = testDB.ExecContext(ctx, "DELETE FROM proof_artifacts WHERE proof_id = $1", proof.ProofID)
+		}()
+		if err := repo.UpdateProofAnchoredSimple(ctx, proof.ProofID, "0x"+uuid.New().String()[:32], block, chain); err != nil {
+			t.Fatalf("Failed to anchor proof: %v", err)
+		}
+	}
+
+	proofs, err := repo.GetProofsByBlockRange(ctx, chain, 150, 250, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to query by block range: %v", err)
+	}
+	if len(proofs) != 3 {
+		t.Fatalf("Expected 3 proofs in blocks 150-250, got %d", len(proofs))
+	}
+	for i, want := range []int64{150, 200, 250} {
+		if proofs[i].AnchorBlockNumber == nil || *proofs[i].AnchorBlockNumber != want {
+			t.Errorf("Proof %d: expected block %d, got %v", i, want, proofs[i].AnchorBlockNumber)
+		}
+	}
+
+	page, err := repo.GetProofsByBlockRange(ctx, chain, 0, 1000, 2, 2)
+	if err != nil {
+		t.Fatalf("Failed to query second page: %v", err)
+	}
+	if len(page) != 2 || *page[0].AnchorBlockNumber != 200 {
+		t.Errorf("Expected the second page to start at block 200, got %d proofs", len(page))
+	}
+}
+
 // ============================================================================
 // ChainedProofLayer Tests
 // ============================================================================
@@ -6,7 +6,11 @@
 package database
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -42,6 +46,16 @@ const (
 	ProofStatusFailed   ProofStatus = "failed"
 )
 
+// IsValid reports whether s is a known proof status
+func (s ProofStatus) IsValid() bool {
+	switch s {
+	case ProofStatusPending, ProofStatusBatched, ProofStatusAnchored,
+		ProofStatusAttested, ProofStatusVerified, ProofStatusFailed:
+		return true
+	}
+	return false
+}
+
 // VerificationStatus tracks verification state
 type VerificationStatus string
 
@@ -49,6 +63,9 @@ const (
 	VerificationStatusPending  VerificationStatus = "pending"
 	VerificationStatusVerified VerificationStatus = "verified"
 	VerificationStatusFailed   VerificationStatus = "failed"
+
+	// VerificationStatusOnChainFailed marks a proof named by a ProofVerificationFailed contract event
+	VerificationStatusOnChainFailed VerificationStatus = "onchain_verification_failed"
 )
 
 // ============================================================================
@@ -163,6 +180,11 @@ type ChainedProofLayer struct {
 	// Full Layer Artifact
 	LayerJSON json.RawMessage `json:"layer_json" db:"layer_json"`
 
+	// Receipt Path (source -> target)
+	SourceHash     []byte           `json:"source_hash,omitempty" db:"source_hash"`
+	TargetHash     []byte           `json:"target_hash,omitempty" db:"target_hash"`
+	ReceiptEntries []MerklePathNode `json:"receipt_entries,omitempty" db:"receipt_entries"`
+
 	// Verification
 	Verified   bool       `json:"verified" db:"verified"`
 	VerifiedAt *time.Time `json:"verified_at,omitempty" db:"verified_at"`
@@ -503,6 +525,93 @@ type ProofArtifactFilter struct {
 	GovernanceLevel   *string  `json:"governance_level,omitempty"`
 }
 
+// AccountProofQuery selects one page of an account's proofs, newest first
+type AccountProofQuery struct {
+	AccountURL    string
+	Status        *ProofStatus
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	// Pagination: After continues from a previous page's cursor; Offset is only
+	// used without it
+	Limit  int
+	Offset int
+	After  *ProofCursor
+}
+
+// ProofCursor is the position of a proof in (created_at, proof_id) order
+type ProofCursor struct {
+	CreatedAt time.Time
+	ProofID   uuid.UUID
+}
+
+// String encodes the cursor as an opaque URL-safe token
+func (c ProofCursor) String() string {
+	return encodeProofCursor(c.CreatedAt, c.ProofID)
+}
+
+// ParseProofCursor decodes a token produced by ProofCursor.String
+func ParseProofCursor(token string) (*ProofCursor, error) {
+	at, proofID, err := decodeProofCursor(token)
+	if err != nil {
+		return nil, err
+	}
+	return &ProofCursor{CreatedAt: at, ProofID: proofID}, nil
+}
+
+// ProofSyncCursor is the position of a proof in (updated_at, proof_id) order. A
+// proof moves forward in this order whenever it is updated, e.g. when anchored.
+type ProofSyncCursor struct {
+	UpdatedAt time.Time
+	ProofID   uuid.UUID
+}
+
+// String encodes the cursor as an opaque URL-safe token
+func (c ProofSyncCursor) String() string {
+	return encodeProofCursor(c.UpdatedAt, c.ProofID)
+}
+
+// ParseProofSyncCursor decodes a token produced by ProofSyncCursor.String
+func ParseProofSyncCursor(token string) (*ProofSyncCursor, error) {
+	at, proofID, err := decodeProofCursor(token)
+	if err != nil {
+		return nil, err
+	}
+	return &ProofSyncCursor{UpdatedAt: at, ProofID: proofID}, nil
+}
+
+func encodeProofCursor(at time.Time, proofID uuid.UUID) string {
+	raw := strconv.FormatInt(at.UnixNano(), 10) + ":" + proofID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeProofCursor(token string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor format")
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor timestamp: %w", err)
+	}
+	proofID, err := uuid.Parse(id)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor proof ID: %w", err)
+	}
+	return time.Unix(0, unixNano).UTC(), proofID, nil
+}
+
+// ProofSummaryPage is one page of proof summaries
+type ProofSummaryPage struct {
+	Proofs []ProofSummary
+	Total  int          // Proofs matching the filters across all pages
+	Next   *ProofCursor // Cursor of the following page; nil on the last page
+}
+
 // ============================================================================
 // API Response Types
 // ============================================================================
@@ -515,6 +624,24 @@ type ProofArtifactWithDetails struct {
 	Attestations     []ProofAttestation        `json:"attestations,omitempty"`
 	AnchorReference  *AnchorReferenceRecord    `json:"anchor_reference,omitempty"`
 	Verifications    []ProofVerificationRecord `json:"verifications,omitempty"`
+	ArchivedAt       *time.Time                `json:"archived_at,omitempty"` // Set when served from the archive
+}
+
+// ProofMerkleInclusion is a proof's inclusion in its batch Merkle tree together with
+// the anchor that committed the batch root. Fields missing from the proof artifact are
+// filled in from its batch transaction and batch.
+type ProofMerkleInclusion struct {
+	ProofID           uuid.UUID        `json:"proof_id"`
+	AccumTxHash       string           `json:"accum_tx_hash"`
+	AccountURL        string           `json:"account_url"`
+	BatchID           *uuid.UUID       `json:"batch_id,omitempty"`
+	LeafHash          []byte           `json:"leaf_hash,omitempty"`
+	LeafIndex         *int             `json:"leaf_index,omitempty"`
+	MerkleRoot        []byte           `json:"merkle_root,omitempty"`
+	MerklePath        []MerklePathNode `json:"merkle_path,omitempty"`
+	AnchorTxHash      *string          `json:"anchor_tx_hash,omitempty"`
+	AnchorBlockNumber *int64           `json:"anchor_block_number,omitempty"`
+	AnchorChain       *string          `json:"anchor_chain,omitempty"`
 }
 
 // ProofSummary is a lightweight proof listing
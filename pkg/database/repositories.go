@@ -15,6 +15,10 @@ type Repositories struct {
 	Requests       *RequestRepository
 	Consensus      *ConsensusRepository // Consensus entries and batch attestations
 	Unified        *UnifiedRepository   // Multi-chain unified attestations and chain execution results
+	Usage          *UsageRepository     // Per-account proof usage meters
+	ProofFailures  *VerificationFailureRepository // ProofVerificationFailed contract events
+	ProofCycles    *ProofCycleRepository          // In-flight proof cycle state
+	Archive        *ArchiveRepository             // Batches and proofs past the retention window
 }
 
 // NewRepositories creates all repositories with the given client
@@ -28,5 +32,9 @@ func NewRepositories(client *Client) *Repositories {
 		Requests:       NewRequestRepository(client),
 		Consensus:      NewConsensusRepository(client),
 		Unified:        NewUnifiedRepository(client.DB()),       // Multi-chain unified tables
+		Usage:          NewUsageRepository(client),
+		ProofFailures:  NewVerificationFailureRepository(client),
+		ProofCycles:    NewProofCycleRepository(client),
+		Archive:        NewArchiveRepository(client),
 	}
 }
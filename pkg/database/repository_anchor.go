@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // AnchorRepository handles external chain anchor record operations
@@ -29,6 +30,18 @@ func NewAnchorRepository(client *Client) *AnchorRepository {
 
 // CreateAnchor creates a new anchor record after successful external chain submission
 func (r *AnchorRepository) CreateAnchor(ctx context.Context, input *NewAnchorRecord) (*AnchorRecord, error) {
+	requiredConfirms := input.RequiredConfirmations
+	if requiredConfirms <= 0 {
+		requiredConfirms = getRequiredConfirmations(input.TargetChain)
+	}
+	availableConfirms := input.AvailableConfirmations
+	if availableConfirms <= 0 {
+		availableConfirms = DefaultAvailableConfirmations
+	}
+	if availableConfirms > requiredConfirms {
+		availableConfirms = requiredConfirms
+	}
+
 	anchor := &AnchorRecord{
 		AnchorID:             uuid.New(),
 		BatchID:              input.BatchID,
@@ -45,7 +58,9 @@ func (r *AnchorRepository) CreateAnchor(ctx context.Context, input *NewAnchorRec
 		CrossChainCommitment: input.CrossChainCommitment,
 		GovernanceRoot:       input.GovernanceRoot,
 		Confirmations:        0,
-		RequiredConfirms:     getRequiredConfirmations(input.TargetChain),
+		RequiredConfirms:     requiredConfirms,
+		AvailableConfirms:    availableConfirms,
+		Finality:             AnchorFinalityPending,
 		IsFinal:              false,
 		GasUsed:              sql.NullInt64{Int64: input.GasUsed, Valid: input.GasUsed > 0},
 		GasPriceWei:          sql.NullString{String: input.GasPriceWei, Valid: input.GasPriceWei != ""},
@@ -53,6 +68,16 @@ func (r *AnchorRepository) CreateAnchor(ctx context.Context, input *NewAnchorRec
 		ValidatorID:          input.ValidatorID,
 		CreatedAt:            time.Now(),
 		UpdatedAt:            time.Now(),
+		ProofGasUsed:         sql.NullInt64{Int64: input.ProofGasUsed, Valid: input.ProofGasUsed > 0},
+		ProofCalldataBytes:   sql.NullInt64{Int64: int64(input.ProofCalldataBytes), Valid: input.ProofCalldataBytes > 0},
+		BaseFeeWei:           sql.NullString{String: input.BaseFeeWei, Valid: input.BaseFeeWei != ""},
+		PriorityFeeWei:       sql.NullString{String: input.PriorityFeeWei, Valid: input.PriorityFeeWei != ""},
+		FeeStrategy:          sql.NullString{String: input.FeeStrategy, Valid: input.FeeStrategy != ""},
+		GasBumped:            input.GasBumped,
+		Shadow:               input.Shadow,
+	}
+	if input.TotalCostUSD != nil {
+		anchor.TotalCostUSD = sql.NullFloat64{Float64: *input.TotalCostUSD, Valid: true}
 	}
 
 	query := `
@@ -61,8 +86,10 @@ func (r *AnchorRepository) CreateAnchor(ctx context.Context, input *NewAnchorRec
 			contract_address, anchor_tx_hash, anchor_block_number, anchor_block_hash,
 			merkle_root, accumulate_height, operation_commitment, cross_chain_commitment,
 			governance_root, confirmations, required_confirmations, is_final,
-			gas_used, gas_price_wei, total_cost_wei, validator_id, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+			gas_used, gas_price_wei, total_cost_wei, validator_id, created_at, updated_at,
+			available_confirmations, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow, total_cost_usd
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33)
 		RETURNING anchor_id, created_at, updated_at`
 
 	err := r.client.QueryRowContext(ctx, query,
@@ -72,6 +99,9 @@ func (r *AnchorRepository) CreateAnchor(ctx context.Context, input *NewAnchorRec
 		anchor.GovernanceRoot, anchor.Confirmations, anchor.RequiredConfirms, anchor.IsFinal,
 		anchor.GasUsed, anchor.GasPriceWei, anchor.TotalCostWei, anchor.ValidatorID,
 		anchor.CreatedAt, anchor.UpdatedAt,
+		anchor.AvailableConfirms, anchor.Finality, anchor.ProofGasUsed, anchor.ProofCalldataBytes,
+		anchor.BaseFeeWei, anchor.PriorityFeeWei, anchor.FeeStrategy, anchor.GasBumped, anchor.Shadow,
+		anchor.TotalCostUSD,
 	).Scan(&anchor.AnchorID, &anchor.CreatedAt, &anchor.UpdatedAt)
 
 	if err != nil {
@@ -101,7 +131,9 @@ func (r *AnchorRepository) GetAnchor(ctx context.Context, anchorID uuid.UUID) (*
 			anchor_timestamp, merkle_root, accumulate_height, operation_commitment,
 			cross_chain_commitment, governance_root, confirmations, required_confirmations,
 			confirmed_at, is_final, gas_used, gas_price_wei, total_cost_wei, total_cost_usd,
-			validator_id, created_at, updated_at
+			validator_id, created_at, updated_at,
+			available_confirmations, available_at, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow
 		FROM anchor_records
 		WHERE anchor_id = $1`
 
@@ -113,6 +145,8 @@ func (r *AnchorRepository) GetAnchor(ctx context.Context, anchorID uuid.UUID) (*
 		&anchor.CrossChainCommitment, &anchor.GovernanceRoot, &anchor.Confirmations, &anchor.RequiredConfirms,
 		&anchor.ConfirmedAt, &anchor.IsFinal, &anchor.GasUsed, &anchor.GasPriceWei, &anchor.TotalCostWei,
 		&anchor.TotalCostUSD, &anchor.ValidatorID, &anchor.CreatedAt, &anchor.UpdatedAt,
+		&anchor.AvailableConfirms, &anchor.AvailableAt, &anchor.Finality, &anchor.ProofGasUsed, &anchor.ProofCalldataBytes,
+		&anchor.BaseFeeWei, &anchor.PriorityFeeWei, &anchor.FeeStrategy, &anchor.GasBumped, &anchor.Shadow,
 	)
 
 	if err == sql.ErrNoRows {
@@ -134,7 +168,9 @@ func (r *AnchorRepository) GetAnchorByTxHash(ctx context.Context, txHash string)
 			anchor_timestamp, merkle_root, accumulate_height, operation_commitment,
 			cross_chain_commitment, governance_root, confirmations, required_confirmations,
 			confirmed_at, is_final, gas_used, gas_price_wei, total_cost_wei, total_cost_usd,
-			validator_id, created_at, updated_at
+			validator_id, created_at, updated_at,
+			available_confirmations, available_at, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow
 		FROM anchor_records
 		WHERE anchor_tx_hash = $1`
 
@@ -146,6 +182,8 @@ func (r *AnchorRepository) GetAnchorByTxHash(ctx context.Context, txHash string)
 		&anchor.CrossChainCommitment, &anchor.GovernanceRoot, &anchor.Confirmations, &anchor.RequiredConfirms,
 		&anchor.ConfirmedAt, &anchor.IsFinal, &anchor.GasUsed, &anchor.GasPriceWei, &anchor.TotalCostWei,
 		&anchor.TotalCostUSD, &anchor.ValidatorID, &anchor.CreatedAt, &anchor.UpdatedAt,
+		&anchor.AvailableConfirms, &anchor.AvailableAt, &anchor.Finality, &anchor.ProofGasUsed, &anchor.ProofCalldataBytes,
+		&anchor.BaseFeeWei, &anchor.PriorityFeeWei, &anchor.FeeStrategy, &anchor.GasBumped, &anchor.Shadow,
 	)
 
 	if err == sql.ErrNoRows {
@@ -167,7 +205,9 @@ func (r *AnchorRepository) GetAnchorByBatchID(ctx context.Context, batchID uuid.
 			anchor_timestamp, merkle_root, accumulate_height, operation_commitment,
 			cross_chain_commitment, governance_root, confirmations, required_confirmations,
 			confirmed_at, is_final, gas_used, gas_price_wei, total_cost_wei, total_cost_usd,
-			validator_id, created_at, updated_at
+			validator_id, created_at, updated_at,
+			available_confirmations, available_at, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow
 		FROM anchor_records
 		WHERE batch_id = $1`
 
@@ -179,6 +219,8 @@ func (r *AnchorRepository) GetAnchorByBatchID(ctx context.Context, batchID uuid.
 		&anchor.CrossChainCommitment, &anchor.GovernanceRoot, &anchor.Confirmations, &anchor.RequiredConfirms,
 		&anchor.ConfirmedAt, &anchor.IsFinal, &anchor.GasUsed, &anchor.GasPriceWei, &anchor.TotalCostWei,
 		&anchor.TotalCostUSD, &anchor.ValidatorID, &anchor.CreatedAt, &anchor.UpdatedAt,
+		&anchor.AvailableConfirms, &anchor.AvailableAt, &anchor.Finality, &anchor.ProofGasUsed, &anchor.ProofCalldataBytes,
+		&anchor.BaseFeeWei, &anchor.PriorityFeeWei, &anchor.FeeStrategy, &anchor.GasBumped, &anchor.Shadow,
 	)
 
 	if err == sql.ErrNoRows {
@@ -200,9 +242,11 @@ func (r *AnchorRepository) GetUnconfirmedAnchors(ctx context.Context) ([]*Anchor
 			anchor_timestamp, merkle_root, accumulate_height, operation_commitment,
 			cross_chain_commitment, governance_root, confirmations, required_confirmations,
 			confirmed_at, is_final, gas_used, gas_price_wei, total_cost_wei, total_cost_usd,
-			validator_id, created_at, updated_at
+			validator_id, created_at, updated_at,
+			available_confirmations, available_at, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow
 		FROM anchor_records
-		WHERE is_final = false
+		WHERE is_final = false AND shadow = false
 		ORDER BY created_at ASC`
 
 	rows, err := r.client.QueryContext(ctx, query)
@@ -221,6 +265,8 @@ func (r *AnchorRepository) GetUnconfirmedAnchors(ctx context.Context) ([]*Anchor
 			&anchor.CrossChainCommitment, &anchor.GovernanceRoot, &anchor.Confirmations, &anchor.RequiredConfirms,
 			&anchor.ConfirmedAt, &anchor.IsFinal, &anchor.GasUsed, &anchor.GasPriceWei, &anchor.TotalCostWei,
 			&anchor.TotalCostUSD, &anchor.ValidatorID, &anchor.CreatedAt, &anchor.UpdatedAt,
+			&anchor.AvailableConfirms, &anchor.AvailableAt, &anchor.Finality, &anchor.ProofGasUsed, &anchor.ProofCalldataBytes,
+			&anchor.BaseFeeWei, &anchor.PriorityFeeWei, &anchor.FeeStrategy, &anchor.GasBumped, &anchor.Shadow,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan anchor: %w", err)
@@ -253,11 +299,31 @@ func (r *AnchorRepository) UpdateConfirmations(ctx context.Context, anchorID uui
 	return nil
 }
 
+// MarkAnchorAvailable marks an anchor as mined with the minimum confirmations for use.
+// Anchors that are already available or final are left unchanged.
+func (r *AnchorRepository) MarkAnchorAvailable(ctx context.Context, anchorID uuid.UUID) error {
+	query := `
+		UPDATE anchor_records
+		SET finality = 'available',
+			available_at = $2,
+			updated_at = $3
+		WHERE anchor_id = $1 AND finality = 'pending'`
+
+	_, err := r.client.ExecContext(ctx, query, anchorID, time.Now(), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark anchor available: %w", err)
+	}
+
+	return nil
+}
+
 // MarkAnchorFinal marks an anchor as having sufficient confirmations
 func (r *AnchorRepository) MarkAnchorFinal(ctx context.Context, anchorID uuid.UUID) error {
 	query := `
 		UPDATE anchor_records
 		SET is_final = true,
+			finality = 'final',
+			available_at = COALESCE(available_at, $2),
 			confirmed_at = $2,
 			updated_at = $3
 		WHERE anchor_id = $1`
@@ -275,6 +341,56 @@ func (r *AnchorRepository) MarkAnchorFinal(ctx context.Context, anchorID uuid.UU
 	return nil
 }
 
+// MarkAnchorReorged invalidates an anchor whose block was reorged out: its finality
+// becomes "reorged", confirmations restart from zero and the block hash is cleared
+func (r *AnchorRepository) MarkAnchorReorged(ctx context.Context, anchorID uuid.UUID) error {
+	query := `
+		UPDATE anchor_records
+		SET finality = 'reorged',
+			is_final = false,
+			confirmations = 0,
+			anchor_block_hash = NULL,
+			available_at = NULL,
+			confirmed_at = NULL,
+			reorg_count = reorg_count + 1,
+			reorged_at = $2,
+			updated_at = $2
+		WHERE anchor_id = $1`
+
+	result, err := r.client.ExecContext(ctx, query, anchorID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark anchor reorged: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("anchor not found")
+	}
+
+	return nil
+}
+
+// RestoreReorgedAnchor points a reorged anchor at the canonical block its transaction
+// was mined in again. Its finality returns to "pending" and confirmations restart.
+func (r *AnchorRepository) RestoreReorgedAnchor(ctx context.Context, anchorID uuid.UUID, blockNumber int64, blockHash string) error {
+	query := `
+		UPDATE anchor_records
+		SET finality = 'pending',
+			anchor_block_number = $2,
+			anchor_block_hash = $3,
+			confirmations = 0,
+			updated_at = $4
+		WHERE anchor_id = $1 AND finality = 'reorged'`
+
+	_, err := r.client.ExecContext(ctx, query, anchorID, blockNumber,
+		sql.NullString{String: blockHash, Valid: blockHash != ""}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to restore reorged anchor: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateAnchorCostUSD updates the USD cost for an anchor (after price lookup)
 func (r *AnchorRepository) UpdateAnchorCostUSD(ctx context.Context, anchorID uuid.UUID, costUSD float64) error {
 	query := `
@@ -298,7 +414,9 @@ func (r *AnchorRepository) GetAnchorsByChain(ctx context.Context, chain TargetCh
 			anchor_timestamp, merkle_root, accumulate_height, operation_commitment,
 			cross_chain_commitment, governance_root, confirmations, required_confirmations,
 			confirmed_at, is_final, gas_used, gas_price_wei, total_cost_wei, total_cost_usd,
-			validator_id, created_at, updated_at
+			validator_id, created_at, updated_at,
+			available_confirmations, available_at, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow
 		FROM anchor_records
 		WHERE target_chain = $1
 		ORDER BY created_at DESC
@@ -320,6 +438,8 @@ func (r *AnchorRepository) GetAnchorsByChain(ctx context.Context, chain TargetCh
 			&anchor.CrossChainCommitment, &anchor.GovernanceRoot, &anchor.Confirmations, &anchor.RequiredConfirms,
 			&anchor.ConfirmedAt, &anchor.IsFinal, &anchor.GasUsed, &anchor.GasPriceWei, &anchor.TotalCostWei,
 			&anchor.TotalCostUSD, &anchor.ValidatorID, &anchor.CreatedAt, &anchor.UpdatedAt,
+			&anchor.AvailableConfirms, &anchor.AvailableAt, &anchor.Finality, &anchor.ProofGasUsed, &anchor.ProofCalldataBytes,
+			&anchor.BaseFeeWei, &anchor.PriorityFeeWei, &anchor.FeeStrategy, &anchor.GasBumped, &anchor.Shadow,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan anchor: %w", err)
@@ -338,7 +458,9 @@ func (r *AnchorRepository) GetRecentAnchors(ctx context.Context, limit int) ([]*
 			anchor_timestamp, merkle_root, accumulate_height, operation_commitment,
 			cross_chain_commitment, governance_root, confirmations, required_confirmations,
 			confirmed_at, is_final, gas_used, gas_price_wei, total_cost_wei, total_cost_usd,
-			validator_id, created_at, updated_at
+			validator_id, created_at, updated_at,
+			available_confirmations, available_at, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow
 		FROM anchor_records
 		ORDER BY created_at DESC
 		LIMIT $1`
@@ -359,6 +481,8 @@ func (r *AnchorRepository) GetRecentAnchors(ctx context.Context, limit int) ([]*
 			&anchor.CrossChainCommitment, &anchor.GovernanceRoot, &anchor.Confirmations, &anchor.RequiredConfirms,
 			&anchor.ConfirmedAt, &anchor.IsFinal, &anchor.GasUsed, &anchor.GasPriceWei, &anchor.TotalCostWei,
 			&anchor.TotalCostUSD, &anchor.ValidatorID, &anchor.CreatedAt, &anchor.UpdatedAt,
+			&anchor.AvailableConfirms, &anchor.AvailableAt, &anchor.Finality, &anchor.ProofGasUsed, &anchor.ProofCalldataBytes,
+			&anchor.BaseFeeWei, &anchor.PriorityFeeWei, &anchor.FeeStrategy, &anchor.GasBumped, &anchor.Shadow,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan anchor: %w", err)
@@ -369,6 +493,188 @@ func (r *AnchorRepository) GetRecentAnchors(ctx context.Context, limit int) ([]*
 	return anchors, rows.Err()
 }
 
+// GetGasHistory returns the gas paid by anchors created since the given time, oldest first
+func (r *AnchorRepository) GetGasHistory(ctx context.Context, since time.Time, limit int) ([]*GasHistoryPoint, error) {
+	query := `
+		SELECT anchor_id, target_chain, anchor_tx_hash, anchor_block_number, created_at,
+			COALESCE(gas_used, 0), COALESCE(gas_price_wei, ''), COALESCE(base_fee_wei, ''),
+			COALESCE(priority_fee_wei, ''), COALESCE(total_cost_wei, ''), COALESCE(fee_strategy, ''),
+			gas_bumped,
+			(SELECT COUNT(*) FROM anchor_fee_escalations e WHERE e.anchor_id = anchor_records.anchor_id)
+		FROM anchor_records
+		WHERE created_at >= $1
+		ORDER BY created_at ASC
+		LIMIT $2`
+
+	rows, err := r.client.QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gas history: %w", err)
+	}
+	defer rows.Close()
+
+	var points []*GasHistoryPoint
+	for rows.Next() {
+		point := &GasHistoryPoint{}
+		if err := rows.Scan(
+			&point.AnchorID, &point.TargetChain, &point.AnchorTxHash, &point.BlockNumber, &point.AnchoredAt,
+			&point.GasUsed, &point.GasPriceWei, &point.BaseFeeWei,
+			&point.PriorityFeeWei, &point.TotalCostWei, &point.FeeStrategy,
+			&point.GasBumped, &point.FeeEscalations,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan gas history: %w", err)
+		}
+		points = append(points, point)
+	}
+
+	return points, rows.Err()
+}
+
+// GetCostHistory sums the gas and cost of the anchor transactions sent in [from, to),
+// per UTC day and validator or per validator. Shadow anchors were never sent and are
+// left out.
+func (r *AnchorRepository) GetCostHistory(ctx context.Context, from, to time.Time, groupBy CostGrouping) ([]*AnchorCostRollup, error) {
+	period := "date_trunc('day', created_at AT TIME ZONE 'UTC')"
+	if groupBy == CostGroupByValidator {
+		period = "NULL::TIMESTAMP"
+	} else if groupBy != CostGroupByDay {
+		return nil, fmt.Errorf("unknown cost grouping %q", groupBy)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS period, COALESCE(validator_id, '') AS validator, COUNT(*),
+			COALESCE(SUM(gas_used), 0), COALESCE(SUM(NULLIF(total_cost_wei, '')::NUMERIC), 0)::TEXT,
+			COALESCE(SUM(total_cost_usd), 0), COUNT(total_cost_usd)
+		FROM anchor_records
+		WHERE created_at >= $1 AND created_at < $2 AND NOT shadow
+		GROUP BY period, validator
+		ORDER BY period, validator`, period)
+
+	rows, err := r.client.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cost history: %w", err)
+	}
+	defer rows.Close()
+
+	var rollups []*AnchorCostRollup
+	for rows.Next() {
+		rollup := &AnchorCostRollup{}
+		var day sql.NullTime
+		if err := rows.Scan(
+			&day, &rollup.ValidatorID, &rollup.AnchorCount,
+			&rollup.GasUsed, &rollup.TotalCostWei,
+			&rollup.TotalCostUSD, &rollup.PricedAnchors,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan cost history: %w", err)
+		}
+		if day.Valid {
+			d := day.Time.UTC()
+			rollup.Day = &d
+		}
+		rollups = append(rollups, rollup)
+	}
+
+	return rollups, rows.Err()
+}
+
+// RecordFeeEscalation stores the submissions of an anchor transaction sent on a fee escalation schedule
+func (r *AnchorRepository) RecordFeeEscalation(ctx context.Context, steps []AnchorFeeEscalationStep) error {
+	tx, err := r.client.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO anchor_fee_escalations (anchor_id, attempt, tx_hash, gas_price_wei, submitted_at, mined)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (anchor_id, attempt) DO NOTHING`
+
+	for _, step := range steps {
+		if _, err := tx.Tx().ExecContext(ctx, query,
+			step.AnchorID, step.Attempt, step.TxHash, step.GasPriceWei, step.SubmittedAt, step.Mined,
+		); err != nil {
+			return fmt.Errorf("failed to record fee escalation step %d: %w", step.Attempt, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit fee escalation: %w", err)
+	}
+	return nil
+}
+
+// GetFeeEscalationSteps returns the submissions of an anchor transaction in attempt order
+func (r *AnchorRepository) GetFeeEscalationSteps(ctx context.Context, anchorID uuid.UUID) ([]AnchorFeeEscalationStep, error) {
+	query := `
+		SELECT anchor_id, attempt, tx_hash, gas_price_wei, submitted_at, mined
+		FROM anchor_fee_escalations
+		WHERE anchor_id = $1
+		ORDER BY attempt ASC`
+
+	rows, err := r.client.QueryContext(ctx, query, anchorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fee escalation steps: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []AnchorFeeEscalationStep
+	for rows.Next() {
+		var step AnchorFeeEscalationStep
+		if err := rows.Scan(&step.AnchorID, &step.Attempt, &step.TxHash, &step.GasPriceWei, &step.SubmittedAt, &step.Mined); err != nil {
+			return nil, fmt.Errorf("failed to scan fee escalation step: %w", err)
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, rows.Err()
+}
+
+// SwitchMinedSubmission points an anchor at the submission of its transaction that is
+// actually mined, e.g. after a reorg dropped the recorded one. Confirmations restart
+// from the new block; the mined flag moves to the new submission.
+func (r *AnchorRepository) SwitchMinedSubmission(ctx context.Context, anchorID uuid.UUID, sub *MinedAnchorSubmission) error {
+	tx, err := r.client.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Tx().ExecContext(ctx, `
+		UPDATE anchor_records
+		SET anchor_tx_hash = $2,
+			anchor_block_number = $3,
+			anchor_block_hash = $4,
+			gas_used = $5,
+			gas_price_wei = $6,
+			total_cost_wei = $7,
+			confirmations = 0,
+			updated_at = $8
+		WHERE anchor_id = $1`,
+		anchorID, sub.TxHash, sub.BlockNumber,
+		sql.NullString{String: sub.BlockHash, Valid: sub.BlockHash != ""},
+		sub.GasUsed,
+		sql.NullString{String: sub.GasPriceWei, Valid: sub.GasPriceWei != ""},
+		sql.NullString{String: sub.TotalCostWei, Valid: sub.TotalCostWei != ""},
+		time.Now(),
+	); err != nil {
+		return fmt.Errorf("failed to update anchor transaction: %w", err)
+	}
+
+	if _, err := tx.Tx().ExecContext(ctx, `
+		UPDATE anchor_fee_escalations
+		SET mined = (LOWER(tx_hash) = LOWER($2))
+		WHERE anchor_id = $1`,
+		anchorID, sub.TxHash,
+	); err != nil {
+		return fmt.Errorf("failed to update mined submission: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit mined submission: %w", err)
+	}
+	return nil
+}
+
 // CountAnchors returns the total number of anchors
 func (r *AnchorRepository) CountAnchors(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM anchor_records`
@@ -382,6 +688,32 @@ func (r *AnchorRepository) CountAnchors(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+// CountAvailableAnchors returns the number of anchors that are available but not yet final
+func (r *AnchorRepository) CountAvailableAnchors(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM anchor_records WHERE finality = 'available'`
+
+	var count int64
+	err := r.client.QueryRowContext(ctx, query).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count available anchors: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountReorgedAnchors returns the number of anchors waiting to be mined again after a reorg
+func (r *AnchorRepository) CountReorgedAnchors(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM anchor_records WHERE finality = 'reorged'`
+
+	var count int64
+	err := r.client.QueryRowContext(ctx, query).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reorged anchors: %w", err)
+	}
+
+	return count, nil
+}
+
 // CountFinalAnchors returns the number of finalized anchors
 func (r *AnchorRepository) CountFinalAnchors(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM anchor_records WHERE is_final = true`
@@ -394,3 +726,145 @@ func (r *AnchorRepository) CountFinalAnchors(ctx context.Context) (int64, error)
 
 	return count, nil
 }
+
+// SampleAnchorsForReconciliation returns up to limit randomly chosen anchors on a chain that
+// were mined (available or final) and created before the given time
+func (r *AnchorRepository) SampleAnchorsForReconciliation(ctx context.Context, chain TargetChain, createdBefore time.Time, limit int) ([]*AnchorRecord, error) {
+	query := `
+		SELECT anchor_id, batch_id, target_chain, chain_id, network_name,
+			contract_address, anchor_tx_hash, anchor_block_number, anchor_block_hash,
+			anchor_timestamp, merkle_root, accumulate_height, operation_commitment,
+			cross_chain_commitment, governance_root, confirmations, required_confirmations,
+			confirmed_at, is_final, gas_used, gas_price_wei, total_cost_wei, total_cost_usd,
+			validator_id, created_at, updated_at,
+			available_confirmations, available_at, finality, proof_gas_used, proof_calldata_bytes,
+			base_fee_wei, priority_fee_wei, fee_strategy, gas_bumped, shadow
+		FROM anchor_records
+		WHERE target_chain = $1 AND finality IN ('available', 'final') AND created_at < $2 AND shadow = false
+		ORDER BY random()
+		LIMIT $3`
+
+	rows, err := r.client.QueryContext(ctx, query, chain, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample anchors: %w", err)
+	}
+	defer rows.Close()
+
+	var anchors []*AnchorRecord
+	for rows.Next() {
+		anchor := &AnchorRecord{}
+		err := rows.Scan(
+			&anchor.AnchorID, &anchor.BatchID, &anchor.TargetChain, &anchor.ChainID, &anchor.NetworkName,
+			&anchor.ContractAddress, &anchor.AnchorTxHash, &anchor.AnchorBlockNumber, &anchor.AnchorBlockHash,
+			&anchor.AnchorTimestamp, &anchor.MerkleRoot, &anchor.AccumHeight, &anchor.OperationCommitment,
+			&anchor.CrossChainCommitment, &anchor.GovernanceRoot, &anchor.Confirmations, &anchor.RequiredConfirms,
+			&anchor.ConfirmedAt, &anchor.IsFinal, &anchor.GasUsed, &anchor.GasPriceWei, &anchor.TotalCostWei,
+			&anchor.TotalCostUSD, &anchor.ValidatorID, &anchor.CreatedAt, &anchor.UpdatedAt,
+			&anchor.AvailableConfirms, &anchor.AvailableAt, &anchor.Finality, &anchor.ProofGasUsed, &anchor.ProofCalldataBytes,
+			&anchor.BaseFeeWei, &anchor.PriorityFeeWei, &anchor.FeeStrategy, &anchor.GasBumped, &anchor.Shadow,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan anchor: %w", err)
+		}
+		anchors = append(anchors, anchor)
+	}
+
+	return anchors, rows.Err()
+}
+
+// RecordAnchorDiscrepancy records a discrepancy, or refreshes it (and reopens it if it was
+// resolved) when the anchor already has one of the same kind
+func (r *AnchorRepository) RecordAnchorDiscrepancy(ctx context.Context, d *AnchorStateDiscrepancy) error {
+	query := `
+		INSERT INTO anchor_state_discrepancies (anchor_id, kind, batch_id, detail)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (anchor_id, kind) DO UPDATE SET
+			detail = EXCLUDED.detail,
+			last_detected_at = NOW(),
+			first_detected_at = CASE WHEN anchor_state_discrepancies.resolved_at IS NULL
+				THEN anchor_state_discrepancies.first_detected_at ELSE NOW() END,
+			resolved_at = NULL`
+
+	_, err := r.client.ExecContext(ctx, query, d.AnchorID, d.Kind, d.BatchID, d.Detail)
+	if err != nil {
+		return fmt.Errorf("failed to record anchor discrepancy: %w", err)
+	}
+	return nil
+}
+
+// ResolveAnchorDiscrepancies resolves the anchor's open discrepancies whose kind is not in
+// stillOpen and returns how many were resolved
+func (r *AnchorRepository) ResolveAnchorDiscrepancies(ctx context.Context, anchorID uuid.UUID, stillOpen []AnchorDiscrepancyKind) (int64, error) {
+	kinds := make([]string, len(stillOpen))
+	for i, kind := range stillOpen {
+		kinds[i] = string(kind)
+	}
+
+	query := `
+		UPDATE anchor_state_discrepancies
+		SET resolved_at = NOW()
+		WHERE anchor_id = $1 AND resolved_at IS NULL AND NOT (kind = ANY($2))`
+
+	result, err := r.client.ExecContext(ctx, query, anchorID, pq.Array(kinds))
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve anchor discrepancies: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ListAnchorDiscrepancies returns discrepancies, most recently detected first; resolved
+// ones are included only when includeResolved is set
+func (r *AnchorRepository) ListAnchorDiscrepancies(ctx context.Context, includeResolved bool, limit, offset int) ([]AnchorStateDiscrepancy, error) {
+	query := `
+		SELECT anchor_id, kind, batch_id, detail, first_detected_at, last_detected_at, resolved_at
+		FROM anchor_state_discrepancies
+		WHERE $1 OR resolved_at IS NULL
+		ORDER BY last_detected_at DESC, anchor_id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.client.QueryContext(ctx, query, includeResolved, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anchor discrepancies: %w", err)
+	}
+	defer rows.Close()
+
+	var discrepancies []AnchorStateDiscrepancy
+	for rows.Next() {
+		var d AnchorStateDiscrepancy
+		if err := rows.Scan(
+			&d.AnchorID, &d.Kind, &d.BatchID, &d.Detail, &d.FirstDetectedAt, &d.LastDetectedAt, &d.ResolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan anchor discrepancy: %w", err)
+		}
+		discrepancies = append(discrepancies, d)
+	}
+
+	return discrepancies, rows.Err()
+}
+
+// CountOpenAnchorDiscrepancies returns the number of unresolved discrepancies by kind
+func (r *AnchorRepository) CountOpenAnchorDiscrepancies(ctx context.Context) (map[AnchorDiscrepancyKind]int64, error) {
+	query := `
+		SELECT kind, COUNT(*)
+		FROM anchor_state_discrepancies
+		WHERE resolved_at IS NULL
+		GROUP BY kind`
+
+	rows, err := r.client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count anchor discrepancies: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[AnchorDiscrepancyKind]int64)
+	for rows.Next() {
+		var kind AnchorDiscrepancyKind
+		var count int64
+		if err := rows.Scan(&kind, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan anchor discrepancy count: %w", err)
+		}
+		counts[kind] = count
+	}
+
+	return counts, rows.Err()
+}
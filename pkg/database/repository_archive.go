@@ -0,0 +1,241 @@
+// Copyright 2025 Certen Protocol
+//
+// Archive Repository - Moves batches and proofs past the retention window to archive tables
+//
+// A batch is archivable once every anchor of the batch reached finality before the
+// retention cutoff: the confirmation tracker only follows anchors that are not final,
+// so archived batches can no longer be reorged or reconfirmed. Batches with open anchor
+// state discrepancies are kept until the discrepancy is resolved.
+//
+// Archiving a batch, in one transaction:
+//   - stores each proof artifact with its details as JSON in archived_proofs
+//   - stores the batch transactions and certen anchor proofs as JSON in archived_batches
+//   - deletes the hot rows, clearing references from result and request tables
+//   - marks the batch archived; the batch and its anchor records are kept
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// archivableBatchCondition selects batches of anchor_batches b whose anchors all reached
+// finality before $1
+const archivableBatchCondition = `
+	b.status IN ('anchored', 'confirmed')
+	AND b.archived_at IS NULL
+	AND EXISTS (SELECT 1 FROM anchor_records ar WHERE ar.batch_id = b.id AND ar.is_final)
+	AND NOT EXISTS (
+		SELECT 1 FROM anchor_records ar
+		WHERE ar.batch_id = b.id AND ar.status <> 'failed'
+		  AND (NOT ar.is_final OR ar.confirmed_at IS NULL OR ar.confirmed_at >= $1))
+	AND NOT EXISTS (
+		SELECT 1 FROM anchor_state_discrepancies d
+		WHERE d.batch_id = b.id AND d.resolved_at IS NULL)`
+
+// ArchiveRepository handles archival of old batches and proofs
+type ArchiveRepository struct {
+	client *Client
+	proofs *ProofArtifactRepository
+}
+
+// NewArchiveRepository creates a new archive repository
+func NewArchiveRepository(client *Client) *ArchiveRepository {
+	return &ArchiveRepository{
+		client: client,
+		proofs: NewProofArtifactRepository(client.DB()),
+	}
+}
+
+// GetArchivableBatches returns up to limit batches, oldest first, whose anchors all
+// reached finality before the given time
+func (r *ArchiveRepository) GetArchivableBatches(ctx context.Context, finalBefore time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT b.id FROM anchor_batches b
+		WHERE ` + archivableBatchCondition + `
+		ORDER BY b.created_at
+		LIMIT $2`
+
+	rows, err := r.client.QueryContext(ctx, query, finalBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archivable batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batchIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan batch ID: %w", err)
+		}
+		batchIDs = append(batchIDs, id)
+	}
+	return batchIDs, rows.Err()
+}
+
+// ArchiveBatch archives a batch and its proofs and returns the number of proofs
+// archived. It returns false without error when the batch is no longer archivable
+// (e.g. it was archived meanwhile or one of its anchors was reorged).
+func (r *ArchiveRepository) ArchiveBatch(ctx context.Context, batchID uuid.UUID, finalBefore time.Time) (int, bool, error) {
+	// Proof details are read through the proof artifact repository before the
+	// transaction; anchored proofs of a final batch no longer change
+	proofs, err := r.proofSnapshots(ctx, batchID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	tx, err := r.client.BeginTx(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	var locked uuid.UUID
+	err = tx.Tx().QueryRowContext(ctx, `
+		SELECT b.id FROM anchor_batches b
+		WHERE b.id = $2 AND `+archivableBatchCondition+`
+		FOR UPDATE OF b`, finalBefore, batchID,
+	).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to lock batch: %w", err)
+	}
+
+	_, err = tx.Tx().ExecContext(ctx, `
+		INSERT INTO archived_batches (batch_id, transactions, anchor_proofs, proof_count)
+		SELECT $1,
+			COALESCE((SELECT jsonb_agg(to_jsonb(bt) ORDER BY bt.tree_index) FROM batch_transactions bt WHERE bt.batch_id = $1), '[]'),
+			COALESCE((SELECT jsonb_agg(to_jsonb(cap)) FROM certen_anchor_proofs cap WHERE cap.batch_id = $1), '[]'),
+			$2`,
+		batchID, len(proofs))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to archive batch: %w", err)
+	}
+
+	proofIDs := make([]string, 0, len(proofs))
+	for _, proof := range proofs {
+		doc, err := json.Marshal(proof)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to encode proof %s: %w", proof.ProofID, err)
+		}
+		_, err = tx.Tx().ExecContext(ctx, `
+			INSERT INTO archived_proofs (proof_id, batch_id, accum_tx_hash, account_url, proof, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			proof.ProofID, batchID, proof.AccumTxHash, proof.AccountURL, doc, proof.CreatedAt)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to archive proof %s: %w", proof.ProofID, err)
+		}
+		proofIDs = append(proofIDs, proof.ProofID.String())
+	}
+
+	ids := pq.Array(proofIDs)
+	statements := []struct {
+		name  string
+		query string
+	}{
+		// Details stored in the archived proof document
+		{"chained proof layers", `DELETE FROM chained_proof_layers WHERE proof_id = ANY($1::uuid[])`},
+		{"governance proof levels", `DELETE FROM governance_proof_levels WHERE proof_id = ANY($1::uuid[])`},
+		{"verification history", `DELETE FROM verification_history WHERE proof_id = ANY($1::uuid[])`},
+		{"anchor references", `DELETE FROM anchor_references WHERE proof_id = ANY($1::uuid[])`},
+		// Records that outlive the proof keep their data without the reference
+		{"proof requests", `UPDATE proof_requests SET proof_id = NULL WHERE proof_id = ANY($1::uuid[])`},
+		{"external chain results", `UPDATE external_chain_results SET proof_id = NULL WHERE proof_id = ANY($1::uuid[])`},
+		{"unified attestations", `UPDATE unified_attestations SET proof_id = NULL WHERE proof_id = ANY($1::uuid[])`},
+		{"aggregated attestations", `UPDATE aggregated_attestations SET proof_id = NULL WHERE proof_id = ANY($1::uuid[])`},
+		{"chain execution results", `UPDATE chain_execution_results SET proof_id = NULL WHERE proof_id = ANY($1::uuid[])`},
+		{"verification failures", `UPDATE proof_verification_failures SET proof_id = NULL WHERE proof_id = ANY($1::uuid[])`},
+		// Validator attestations and proof bundles cascade
+		{"proof artifacts", `DELETE FROM proof_artifacts WHERE proof_id = ANY($1::uuid[])`},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Tx().ExecContext(ctx, stmt.query, ids); err != nil {
+			return 0, false, fmt.Errorf("failed to archive %s: %w", stmt.name, err)
+		}
+	}
+
+	if _, err := tx.Tx().ExecContext(ctx, `DELETE FROM batch_transactions WHERE batch_id = $1`, batchID); err != nil {
+		return 0, false, fmt.Errorf("failed to archive batch transactions: %w", err)
+	}
+	if _, err := tx.Tx().ExecContext(ctx, `DELETE FROM certen_anchor_proofs WHERE batch_id = $1`, batchID); err != nil {
+		return 0, false, fmt.Errorf("failed to archive certen anchor proofs: %w", err)
+	}
+
+	_, err = tx.Tx().ExecContext(ctx,
+		`UPDATE anchor_batches SET archived_at = $2, updated_at = $2 WHERE id = $1`,
+		batchID, time.Now())
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to mark batch archived: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit batch archival: %w", err)
+	}
+	return len(proofs), true, nil
+}
+
+// proofSnapshots returns the proof artifacts of a batch with their details
+func (r *ArchiveRepository) proofSnapshots(ctx context.Context, batchID uuid.UUID) ([]*ProofArtifactWithDetails, error) {
+	rows, err := r.client.QueryContext(ctx,
+		`SELECT proof_id FROM proof_artifacts WHERE batch_id = $1 ORDER BY batch_position`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch proofs: %w", err)
+	}
+	var proofIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan proof ID: %w", err)
+		}
+		proofIDs = append(proofIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query batch proofs: %w", err)
+	}
+
+	proofs := make([]*ProofArtifactWithDetails, 0, len(proofIDs))
+	for _, id := range proofIDs {
+		proof, err := r.proofs.GetProofWithDetails(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proof %s: %w", id, err)
+		}
+		if proof != nil {
+			proofs = append(proofs, proof)
+		}
+	}
+	return proofs, nil
+}
+
+// GetArchivedProof returns an archived proof with its details, or ErrProofNotFound
+// when the proof is not archived
+func (r *ArchiveRepository) GetArchivedProof(ctx context.Context, proofID uuid.UUID) (*ProofArtifactWithDetails, error) {
+	var doc []byte
+	var archivedAt time.Time
+	err := r.client.QueryRowContext(ctx,
+		`SELECT proof, archived_at FROM archived_proofs WHERE proof_id = $1`, proofID,
+	).Scan(&doc, &archivedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrProofNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived proof: %w", err)
+	}
+
+	var proof ProofArtifactWithDetails
+	if err := json.Unmarshal(doc, &proof); err != nil {
+		return nil, fmt.Errorf("failed to decode archived proof %s: %w", proofID, err)
+	}
+	proof.ArchivedAt = &archivedAt
+	return &proof, nil
+}
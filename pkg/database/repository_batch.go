@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // BatchRepository handles anchor batch operations
@@ -151,7 +152,8 @@ func (r *BatchRepository) GetPendingBatch(ctx context.Context, validatorID strin
 	return batch, nil
 }
 
-// GetBatchesReadyForAnchoring returns batches that are closed and ready to be anchored
+// GetBatchesReadyForAnchoring returns batches that are closed and ready to be anchored,
+// batches holding higher-priority transactions first, then oldest first
 func (r *BatchRepository) GetBatchesReadyForAnchoring(ctx context.Context) ([]*AnchorBatch, error) {
 	query := `
 		SELECT id, batch_type, merkle_root, transaction_count,
@@ -160,7 +162,12 @@ func (r *BatchRepository) GetBatchesReadyForAnchoring(ctx context.Context) ([]*A
 			created_at, updated_at
 		FROM anchor_batches
 		WHERE status = 'closed'
-		ORDER BY created_at ASC`
+		ORDER BY (
+			SELECT COALESCE(MAX(CASE bt.priority
+				WHEN 'urgent' THEN 3 WHEN 'high' THEN 2 WHEN 'low' THEN 0 ELSE 1 END), 1)
+			FROM batch_transactions bt
+			WHERE bt.batch_id = anchor_batches.id
+		) DESC, created_at ASC`
 
 	rows, err := r.client.QueryContext(ctx, query)
 	if err != nil {
@@ -186,6 +193,67 @@ func (r *BatchRepository) GetBatchesReadyForAnchoring(ctx context.Context) ([]*A
 	return batches, rows.Err()
 }
 
+// GetBatchesByStatus returns batches in any of the given statuses updated at or after since,
+// oldest first
+func (r *BatchRepository) GetBatchesByStatus(ctx context.Context, statuses []BatchStatus, since time.Time) ([]*AnchorBatch, error) {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	query := `
+		SELECT id, batch_type, merkle_root, transaction_count,
+			batch_start_time, batch_end_time, accumulate_block_height,
+			accumulate_block_hash, validator_id, status, error_message,
+			created_at, updated_at
+		FROM anchor_batches
+		WHERE status = ANY($1) AND updated_at >= $2
+		ORDER BY created_at ASC`
+
+	rows, err := r.client.QueryContext(ctx, query, pq.Array(names), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batches by status: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*AnchorBatch
+	for rows.Next() {
+		batch := &AnchorBatch{}
+		err := rows.Scan(
+			&batch.BatchID, &batch.BatchType, &batch.MerkleRoot, &batch.TxCount,
+			&batch.StartTime, &batch.EndTime, &batch.AccumHeight,
+			&batch.AccumHash, &batch.ValidatorID, &batch.Status, &batch.ErrorMessage,
+			&batch.CreatedAt, &batch.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, rows.Err()
+}
+
+// RenameBatch changes the ID of a pending batch; its transactions follow the new ID
+func (r *BatchRepository) RenameBatch(ctx context.Context, batchID, newBatchID uuid.UUID) error {
+	query := `
+		UPDATE anchor_batches
+		SET id = $2, updated_at = $3
+		WHERE id = $1 AND status = 'pending'`
+
+	result, err := r.client.ExecContext(ctx, query, batchID, newBatchID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to rename batch: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("batch not found or not in pending status")
+	}
+
+	return nil
+}
+
 // CloseBatch closes a batch with the computed merkle root
 func (r *BatchRepository) CloseBatch(ctx context.Context, batchID uuid.UUID, merkleRoot []byte, accumHeight int64, accumHash string) error {
 	query := `
@@ -212,31 +280,48 @@ func (r *BatchRepository) CloseBatch(ctx context.Context, batchID uuid.UUID, mer
 	return nil
 }
 
-// UpdateBatchStatus updates the batch status
-func (r *BatchRepository) UpdateBatchStatus(ctx context.Context, batchID uuid.UUID, status BatchStatus, errorMsg string) error {
-	var query string
-	var args []interface{}
-
-	if errorMsg != "" {
-		query = `
-			UPDATE anchor_batches
-			SET status = $2, error_message = $3, updated_at = $4
-			WHERE id = $1`
-		args = []interface{}{batchID, status, errorMsg, time.Now()}
-	} else {
-		query = `
-			UPDATE anchor_batches
-			SET status = $2, updated_at = $3
-			WHERE id = $1`
-		args = []interface{}{batchID, status, time.Now()}
-	}
-
-	_, err := r.client.ExecContext(ctx, query, args...)
+// TransitionBatchStatus moves a batch to status when its current status is one of
+// fromStatuses, replacing its error message unless errorMsg is empty. It returns false
+// without error when the batch is in another status (or does not exist), so callers can
+// reject illegal transitions and lost races.
+func (r *BatchRepository) TransitionBatchStatus(ctx context.Context, batchID uuid.UUID, fromStatuses []BatchStatus, status BatchStatus, errorMsg string) (bool, error) {
+	names := make([]string, len(fromStatuses))
+	for i, s := range fromStatuses {
+		names[i] = string(s)
+	}
+
+	query := `
+		UPDATE anchor_batches
+		SET status = $2,
+			error_message = CASE WHEN $3 = '' THEN error_message ELSE $3 END,
+			updated_at = $4
+		WHERE id = $1 AND status = ANY($5)`
+
+	result, err := r.client.ExecContext(ctx, query, batchID, status, errorMsg, time.Now(), pq.Array(names))
 	if err != nil {
-		return fmt.Errorf("failed to update batch status: %w", err)
+		return false, fmt.Errorf("failed to update batch status: %w", err)
 	}
 
-	return nil
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ReopenFailedBatch moves a failed batch back to closed so it can be anchored again,
+// clearing its error. It returns false without error when the batch is not failed
+// (e.g. a concurrent retry already reopened it).
+func (r *BatchRepository) ReopenFailedBatch(ctx context.Context, batchID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE anchor_batches
+		SET status = 'closed', error_message = NULL, updated_at = $2
+		WHERE id = $1 AND status = 'failed'`
+
+	result, err := r.client.ExecContext(ctx, query, batchID, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to reopen batch: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
 }
 
 // IncrementTxCount increments the transaction count for a batch
@@ -340,6 +425,18 @@ func (r *BatchRepository) AddTransaction(ctx context.Context, input *NewBatchTra
 	if input.CreatedAtClient != nil {
 		createdAtClient = sql.NullTime{Time: *input.CreatedAtClient, Valid: true}
 	}
+	priority := input.Priority
+	if priority == "" {
+		priority = PriorityNormal
+	}
+	origin := input.Origin
+	if origin == "" {
+		origin = TransactionOriginUser
+	}
+	var originTxHash sql.NullString
+	if input.OriginTxHash != nil {
+		originTxHash = sql.NullString{String: *input.OriginTxHash, Valid: true}
+	}
 
 	tx := &BatchTransaction{
 		BatchID:         input.BatchID,
@@ -355,6 +452,7 @@ func (r *BatchRepository) AddTransaction(ctx context.Context, input *NewBatchTra
 		GovValid:        input.GovProof != nil,
 		IntentType:      sql.NullString{String: input.IntentType, Valid: input.IntentType != ""},
 		IntentData:      input.IntentData,
+		Priority:        priority,
 		CreatedAt:       time.Now(),
 		UserID:          userID,
 		IntentID:        intentID,
@@ -366,6 +464,8 @@ func (r *BatchRepository) AddTransaction(ctx context.Context, input *NewBatchTra
 		TokenSymbol:     tokenSymbol,
 		AdiURL:          adiURL,
 		CreatedAtClient: createdAtClient,
+		Origin:          origin,
+		OriginTxHash:    originTxHash,
 	}
 
 	query := `
@@ -375,8 +475,8 @@ func (r *BatchRepository) AddTransaction(ctx context.Context, input *NewBatchTra
 			governance_proof, governance_level, governance_valid,
 			intent_type, intent_data, user_id, intent_id,
 			from_chain, to_chain, from_address, to_address, amount, token_symbol, adi_url, created_at_client,
-			created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
+			priority, created_at, tx_origin, origin_tx_hash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)
 		RETURNING id, created_at`
 
 	err = r.client.QueryRowContext(ctx, query,
@@ -385,7 +485,7 @@ func (r *BatchRepository) AddTransaction(ctx context.Context, input *NewBatchTra
 		tx.GovProof, tx.GovLevel, tx.GovValid,
 		tx.IntentType, tx.IntentData, tx.UserID, tx.IntentID,
 		tx.FromChain, tx.ToChain, tx.FromAddress, tx.ToAddress, tx.Amount, tx.TokenSymbol, tx.AdiURL, tx.CreatedAtClient,
-		tx.CreatedAt,
+		tx.Priority, tx.CreatedAt, tx.Origin, tx.OriginTxHash,
 	).Scan(&tx.ID, &tx.CreatedAt)
 
 	if err != nil {
@@ -406,7 +506,8 @@ func (r *BatchRepository) GetTransaction(ctx context.Context, txID int64) (*Batc
 		SELECT id, batch_id, accumulate_tx_hash, account_url, tree_index,
 			merkle_path, transaction_hash, chained_proof, chained_proof_valid,
 			governance_proof, governance_level, governance_valid,
-			intent_type, intent_data, created_at
+			intent_type, intent_data, priority, created_at,
+			tx_origin, origin_tx_hash
 		FROM batch_transactions
 		WHERE id = $1`
 
@@ -415,7 +516,8 @@ func (r *BatchRepository) GetTransaction(ctx context.Context, txID int64) (*Batc
 		&tx.ID, &tx.BatchID, &tx.AccumTxHash, &tx.AccountURL, &tx.TreeIndex,
 		&tx.MerklePath, &tx.TxHash, &tx.ChainedProof, &tx.ChainedValid,
 		&tx.GovProof, &tx.GovLevel, &tx.GovValid,
-		&tx.IntentType, &tx.IntentData, &tx.CreatedAt,
+		&tx.IntentType, &tx.IntentData, &tx.Priority, &tx.CreatedAt,
+		&tx.Origin, &tx.OriginTxHash,
 	)
 
 	if err == sql.ErrNoRows {
@@ -435,7 +537,8 @@ func (r *BatchRepository) GetTransactionByAccumHash(ctx context.Context, accumTx
 		SELECT id, batch_id, accumulate_tx_hash, account_url, tree_index,
 			merkle_path, transaction_hash, chained_proof, chained_proof_valid,
 			governance_proof, governance_level, governance_valid,
-			intent_type, intent_data, created_at
+			intent_type, intent_data, priority, created_at,
+			tx_origin, origin_tx_hash
 		FROM batch_transactions
 		WHERE accumulate_tx_hash = $1
 		ORDER BY created_at DESC
@@ -446,7 +549,8 @@ func (r *BatchRepository) GetTransactionByAccumHash(ctx context.Context, accumTx
 		&tx.ID, &tx.BatchID, &tx.AccumTxHash, &tx.AccountURL, &tx.TreeIndex,
 		&tx.MerklePath, &tx.TxHash, &tx.ChainedProof, &tx.ChainedValid,
 		&tx.GovProof, &tx.GovLevel, &tx.GovValid,
-		&tx.IntentType, &tx.IntentData, &tx.CreatedAt,
+		&tx.IntentType, &tx.IntentData, &tx.Priority, &tx.CreatedAt,
+		&tx.Origin, &tx.OriginTxHash,
 	)
 
 	if err == sql.ErrNoRows {
@@ -460,13 +564,39 @@ func (r *BatchRepository) GetTransactionByAccumHash(ctx context.Context, accumTx
 	return tx, nil
 }
 
+// GetTransactionBatchStatus returns the batch holding an Accumulate transaction and
+// that batch's status. Abandoned batches are ignored, since their transactions may be
+// submitted again. Returns ErrTransactionNotFound if no other batch holds it.
+func (r *BatchRepository) GetTransactionBatchStatus(ctx context.Context, accumTxHash string) (uuid.UUID, BatchStatus, error) {
+	query := `
+		SELECT bt.batch_id, ab.status
+		FROM batch_transactions bt
+		JOIN anchor_batches ab ON ab.id = bt.batch_id
+		WHERE bt.accumulate_tx_hash = $1 AND ab.status <> $2
+		ORDER BY bt.created_at DESC
+		LIMIT 1`
+
+	var batchID uuid.UUID
+	var status BatchStatus
+	err := r.client.QueryRowContext(ctx, query, accumTxHash, BatchStatusAbandoned).Scan(&batchID, &status)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, "", ErrTransactionNotFound
+	}
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("failed to get transaction batch: %w", err)
+	}
+
+	return batchID, status, nil
+}
+
 // GetTransactionsInBatch retrieves all transactions in a batch
 func (r *BatchRepository) GetTransactionsInBatch(ctx context.Context, batchID uuid.UUID) ([]*BatchTransaction, error) {
 	query := `
 		SELECT id, batch_id, accumulate_tx_hash, account_url, tree_index,
 			merkle_path, transaction_hash, chained_proof, chained_proof_valid,
 			governance_proof, governance_level, governance_valid,
-			intent_type, intent_data, created_at
+			intent_type, intent_data, priority, created_at,
+			tx_origin, origin_tx_hash
 		FROM batch_transactions
 		WHERE batch_id = $1
 		ORDER BY tree_index ASC`
@@ -484,7 +614,8 @@ func (r *BatchRepository) GetTransactionsInBatch(ctx context.Context, batchID uu
 			&tx.ID, &tx.BatchID, &tx.AccumTxHash, &tx.AccountURL, &tx.TreeIndex,
 			&tx.MerklePath, &tx.TxHash, &tx.ChainedProof, &tx.ChainedValid,
 			&tx.GovProof, &tx.GovLevel, &tx.GovValid,
-			&tx.IntentType, &tx.IntentData, &tx.CreatedAt,
+			&tx.IntentType, &tx.IntentData, &tx.Priority, &tx.CreatedAt,
+			&tx.Origin, &tx.OriginTxHash,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
@@ -622,3 +753,156 @@ func (r *BatchRepository) GetTransactionHashesByBatchID(ctx context.Context, bat
 
 	return hashes, nil
 }
+
+// ============================================================================
+// ABANDONED ON-DEMAND BATCH OPERATIONS
+// ============================================================================
+
+// GetStaleOnDemandBatches returns on-demand batches in any of the given statuses that were
+// last updated before the given time, oldest first
+func (r *BatchRepository) GetStaleOnDemandBatches(ctx context.Context, statuses []BatchStatus, before time.Time) ([]*AnchorBatch, error) {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	query := `
+		SELECT id, batch_type, merkle_root, transaction_count,
+			batch_start_time, batch_end_time, accumulate_block_height,
+			accumulate_block_hash, validator_id, status, error_message,
+			created_at, updated_at
+		FROM anchor_batches
+		WHERE batch_type = 'on_demand' AND status = ANY($1) AND updated_at < $2
+		ORDER BY created_at ASC`
+
+	rows, err := r.client.QueryContext(ctx, query, pq.Array(names), before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale on-demand batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*AnchorBatch
+	for rows.Next() {
+		batch := &AnchorBatch{}
+		err := rows.Scan(
+			&batch.BatchID, &batch.BatchType, &batch.MerkleRoot, &batch.TxCount,
+			&batch.StartTime, &batch.EndTime, &batch.AccumHeight,
+			&batch.AccumHash, &batch.ValidatorID, &batch.Status, &batch.ErrorMessage,
+			&batch.CreatedAt, &batch.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, rows.Err()
+}
+
+// AbandonOnDemandBatch marks a batch abandoned and records the outcome of each of its
+// transactions, setting their IDs and abandonment time. It returns false without error
+// when the batch is no longer in one of the given statuses (e.g. it anchored meanwhile).
+func (r *BatchRepository) AbandonOnDemandBatch(ctx context.Context, batchID uuid.UUID, fromStatuses []BatchStatus, reason string, requests []AbandonedOnDemandRequest) (bool, error) {
+	tx, err := r.client.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var status BatchStatus
+	err = tx.Tx().QueryRowContext(ctx,
+		`SELECT status FROM anchor_batches WHERE id = $1 FOR UPDATE`, batchID,
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		return false, ErrBatchNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock batch: %w", err)
+	}
+	abandonable := false
+	for _, s := range fromStatuses {
+		if status == s {
+			abandonable = true
+			break
+		}
+	}
+	if !abandonable {
+		return false, nil
+	}
+
+	_, err = tx.Tx().ExecContext(ctx, `
+		UPDATE anchor_batches
+		SET status = $2, error_message = $3, updated_at = $4
+		WHERE id = $1`,
+		batchID, BatchStatusAbandoned, reason, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to mark batch abandoned: %w", err)
+	}
+
+	query := `
+		INSERT INTO abandoned_on_demand_requests (
+			batch_id, accumulate_tx_hash, account_url, previous_status, reason, requested_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (batch_id, accumulate_tx_hash) DO NOTHING
+		RETURNING id, abandoned_at`
+
+	for i := range requests {
+		req := &requests[i]
+		req.BatchID = batchID
+		req.PreviousStatus = status
+		req.Reason = reason
+		err := tx.Tx().QueryRowContext(ctx, query,
+			batchID, req.AccumTxHash, req.AccountURL, status, reason, req.RequestedAt,
+		).Scan(&req.ID, &req.AbandonedAt)
+		if err != nil && err != sql.ErrNoRows {
+			return false, fmt.Errorf("failed to record abandoned request %s: %w", req.AccumTxHash, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit batch abandonment: %w", err)
+	}
+	return true, nil
+}
+
+// MarkAbandonedUsageRefunded records that an abandoned request's metered usage was refunded
+func (r *BatchRepository) MarkAbandonedUsageRefunded(ctx context.Context, id int64) error {
+	_, err := r.client.ExecContext(ctx,
+		`UPDATE abandoned_on_demand_requests SET usage_refunded = TRUE WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark usage refunded: %w", err)
+	}
+	return nil
+}
+
+// ListAbandonedOnDemandRequests returns abandoned request outcomes, newest first,
+// optionally restricted to one account ("" = all accounts)
+func (r *BatchRepository) ListAbandonedOnDemandRequests(ctx context.Context, accountURL string, limit, offset int) ([]AbandonedOnDemandRequest, error) {
+	query := `
+		SELECT id, batch_id, accumulate_tx_hash, account_url, previous_status, reason,
+			requested_at, usage_refunded, abandoned_at
+		FROM abandoned_on_demand_requests
+		WHERE $1 = '' OR account_url = $1
+		ORDER BY abandoned_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.client.QueryContext(ctx, query, accountURL, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list abandoned requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []AbandonedOnDemandRequest
+	for rows.Next() {
+		var req AbandonedOnDemandRequest
+		if err := rows.Scan(
+			&req.ID, &req.BatchID, &req.AccumTxHash, &req.AccountURL, &req.PreviousStatus, &req.Reason,
+			&req.RequestedAt, &req.UsageRefunded, &req.AbandonedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan abandoned request: %w", err)
+		}
+		requests = append(requests, req)
+	}
+
+	return requests, rows.Err()
+}
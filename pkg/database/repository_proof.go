@@ -58,6 +58,7 @@ func (r *ProofRepository) CreateProof(ctx context.Context, input *NewCertenAncho
 		AnchorBlockNumber: input.AnchorBlockNumber,
 		AnchorBlockHash:   sql.NullString{String: input.AnchorBlockHash, Valid: input.AnchorBlockHash != ""},
 		AnchorConfirms:    0,
+		AnchorFinality:    AnchorFinalityPending,
 		AccumStateProof:   input.AccumStateProof,
 		AccumBlockHeight:  sql.NullInt64{Int64: input.AccumBlockHeight, Valid: input.AccumBlockHeight > 0},
 		AccumBVN:          sql.NullString{String: input.AccumBVN, Valid: input.AccumBVN != ""},
@@ -103,7 +104,7 @@ func (r *ProofRepository) GetProof(ctx context.Context, proofID uuid.UUID) (*Cer
 	query := `
 		SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 			account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 			accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 			governance_proof, governance_level, governance_valid,
 			verified, verification_time, verification_details,
@@ -115,7 +116,7 @@ func (r *ProofRepository) GetProof(ctx context.Context, proofID uuid.UUID) (*Cer
 	err := r.client.QueryRowContext(ctx, query, proofID).Scan(
 		&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 		&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-		&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+		&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 		&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 		&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 		&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -138,7 +139,7 @@ func (r *ProofRepository) GetProofByAccumTxHash(ctx context.Context, accumTxHash
 	query := `
 		SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 			account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 			accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 			governance_proof, governance_level, governance_valid,
 			verified, verification_time, verification_details,
@@ -152,7 +153,7 @@ func (r *ProofRepository) GetProofByAccumTxHash(ctx context.Context, accumTxHash
 	err := r.client.QueryRowContext(ctx, query, accumTxHash).Scan(
 		&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 		&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-		&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+		&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 		&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 		&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 		&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -175,7 +176,7 @@ func (r *ProofRepository) GetProofsByBatchID(ctx context.Context, batchID uuid.U
 	query := `
 		SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 			account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 			accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 			governance_proof, governance_level, governance_valid,
 			verified, verification_time, verification_details,
@@ -196,7 +197,7 @@ func (r *ProofRepository) GetProofsByBatchID(ctx context.Context, batchID uuid.U
 		err := rows.Scan(
 			&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 			&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 			&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 			&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 			&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -216,7 +217,7 @@ func (r *ProofRepository) GetProofsByAnchorID(ctx context.Context, anchorID uuid
 	query := `
 		SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 			account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 			accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 			governance_proof, governance_level, governance_valid,
 			verified, verification_time, verification_details,
@@ -237,7 +238,7 @@ func (r *ProofRepository) GetProofsByAnchorID(ctx context.Context, anchorID uuid
 		err := rows.Scan(
 			&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 			&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 			&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 			&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 			&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -257,7 +258,7 @@ func (r *ProofRepository) GetProofsByAccountURL(ctx context.Context, accountURL
 	query := `
 		SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 			account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 			accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 			governance_proof, governance_level, governance_valid,
 			verified, verification_time, verification_details,
@@ -279,7 +280,7 @@ func (r *ProofRepository) GetProofsByAccountURL(ctx context.Context, accountURL
 		err := rows.Scan(
 			&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 			&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 			&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 			&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 			&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -317,18 +318,20 @@ func (r *ProofRepository) UpdateVerification(ctx context.Context, proofID uuid.U
 	return nil
 }
 
-// UpdateAnchorConfirmations updates the anchor confirmations for a proof
-func (r *ProofRepository) UpdateAnchorConfirmations(ctx context.Context, proofID uuid.UUID, confirmations int, blockHash string) error {
+// UpdateAnchorConfirmations updates the anchor confirmations and finality level for a proof
+func (r *ProofRepository) UpdateAnchorConfirmations(ctx context.Context, proofID uuid.UUID, confirmations int, blockHash string, finality AnchorFinality) error {
 	query := `
 		UPDATE certen_anchor_proofs
 		SET anchor_confirmations = $2,
 			anchor_block_hash = $3,
-			updated_at = $4
+			anchor_finality = $4,
+			updated_at = $5
 		WHERE proof_id = $1`
 
 	_, err := r.client.ExecContext(ctx, query,
 		proofID, confirmations,
 		sql.NullString{String: blockHash, Valid: blockHash != ""},
+		finality,
 		time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to update anchor confirmations: %w", err)
@@ -376,7 +379,7 @@ func (r *ProofRepository) GetUnverifiedProofs(ctx context.Context, limit int) ([
 	query := `
 		SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 			account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 			accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 			governance_proof, governance_level, governance_valid,
 			verified, verification_time, verification_details,
@@ -398,7 +401,7 @@ func (r *ProofRepository) GetUnverifiedProofs(ctx context.Context, limit int) ([
 		err := rows.Scan(
 			&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 			&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 			&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 			&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 			&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -422,7 +425,7 @@ func (r *ProofRepository) GetVerifiedProofs(ctx context.Context, govLevel Govern
 		query = `
 			SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 				account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-				anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+				anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 				accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 				governance_proof, governance_level, governance_valid,
 				verified, verification_time, verification_details,
@@ -436,7 +439,7 @@ func (r *ProofRepository) GetVerifiedProofs(ctx context.Context, govLevel Govern
 		query = `
 			SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 				account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-				anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+				anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 				accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 				governance_proof, governance_level, governance_valid,
 				verified, verification_time, verification_details,
@@ -460,7 +463,7 @@ func (r *ProofRepository) GetVerifiedProofs(ctx context.Context, govLevel Govern
 		err := rows.Scan(
 			&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 			&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 			&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 			&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 			&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -506,7 +509,7 @@ func (r *ProofRepository) GetRecentProofs(ctx context.Context, limit int) ([]*Ce
 	query := `
 		SELECT proof_id, batch_id, anchor_id, transaction_id, accumulate_tx_hash,
 			account_url, merkle_root, merkle_inclusion_proof, anchor_chain,
-			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations,
+			anchor_tx_hash, anchor_block_number, anchor_block_hash, anchor_confirmations, anchor_finality,
 			accumulate_state_proof, accumulate_block_height, accumulate_bvn,
 			governance_proof, governance_level, governance_valid,
 			verified, verification_time, verification_details,
@@ -527,7 +530,7 @@ func (r *ProofRepository) GetRecentProofs(ctx context.Context, limit int) ([]*Ce
 		err := rows.Scan(
 			&proof.ProofID, &proof.BatchID, &proof.AnchorID, &proof.TransactionID, &proof.AccumTxHash,
 			&proof.AccountURL, &proof.MerkleRoot, &proof.MerkleInclusion, &proof.AnchorChain,
-			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms,
+			&proof.AnchorTxHash, &proof.AnchorBlockNumber, &proof.AnchorBlockHash, &proof.AnchorConfirms, &proof.AnchorFinality,
 			&proof.AccumStateProof, &proof.AccumBlockHeight, &proof.AccumBVN,
 			&proof.GovProof, &proof.GovLevel, &proof.GovValid,
 			&proof.Verified, &proof.VerificationTime, &proof.VerifyDetails,
@@ -0,0 +1,102 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Cycle Repository - In-flight proof cycle state
+// Persists each proof cycle's stage and artifacts so cycles interrupted by a restart
+// can be resumed
+
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// ProofCycleRepository handles proof cycle state operations
+type ProofCycleRepository struct {
+	client *Client
+}
+
+// NewProofCycleRepository creates a new proof cycle repository
+func NewProofCycleRepository(client *Client) *ProofCycleRepository {
+	return &ProofCycleRepository{client: client}
+}
+
+// SaveProofCycleState inserts or updates an in-flight cycle. A stored write-back
+// transaction and its Accumulate txid are kept when the update carries none, and a
+// finished cycle is not reopened.
+func (r *ProofCycleRepository) SaveProofCycleState(ctx context.Context, state *ProofCycleState) error {
+	query := `
+		INSERT INTO proof_cycle_states (
+			cycle_id, intent_id, stage, execution_tx_hash, cycle_data, write_back_tx, write_back_tx_id, shadow
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (cycle_id) DO UPDATE SET
+			stage = EXCLUDED.stage,
+			cycle_data = EXCLUDED.cycle_data,
+			write_back_tx = COALESCE(EXCLUDED.write_back_tx, proof_cycle_states.write_back_tx),
+			write_back_tx_id = COALESCE(NULLIF(EXCLUDED.write_back_tx_id, ''), proof_cycle_states.write_back_tx_id),
+			updated_at = NOW()
+		WHERE proof_cycle_states.finished_at IS NULL`
+
+	var writeBackTx interface{}
+	if len(state.WriteBackTx) > 0 {
+		writeBackTx = []byte(state.WriteBackTx)
+	}
+	_, err := r.client.ExecContext(ctx, query,
+		state.CycleID, state.IntentID, state.Stage, state.ExecutionTxHash,
+		[]byte(state.CycleData), writeBackTx, state.WriteBackTxID, state.Shadow,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save proof cycle state: %w", err)
+	}
+	return nil
+}
+
+// FinishProofCycle marks a cycle completed, failed or write-back unconfirmed
+func (r *ProofCycleRepository) FinishProofCycle(ctx context.Context, cycleID, stage, errorMessage string) error {
+	query := `
+		UPDATE proof_cycle_states
+		SET stage = $2, error_message = $3, updated_at = NOW(), finished_at = NOW()
+		WHERE cycle_id = $1 AND finished_at IS NULL`
+
+	if _, err := r.client.ExecContext(ctx, query, cycleID, stage, errorMessage); err != nil {
+		return fmt.Errorf("failed to finish proof cycle: %w", err)
+	}
+	return nil
+}
+
+// ListPendingProofCycles returns the cycles that have neither completed nor failed, oldest first
+func (r *ProofCycleRepository) ListPendingProofCycles(ctx context.Context) ([]*ProofCycleState, error) {
+	query := `
+		SELECT cycle_id, intent_id, stage, execution_tx_hash, cycle_data,
+			write_back_tx, write_back_tx_id, shadow, error_message, created_at, updated_at
+		FROM proof_cycle_states
+		WHERE finished_at IS NULL
+		ORDER BY created_at`
+
+	rows, err := r.client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending proof cycles: %w", err)
+	}
+	defer rows.Close()
+
+	var states []*ProofCycleState
+	for rows.Next() {
+		state := &ProofCycleState{}
+		var cycleData, writeBackTx []byte
+		if err := rows.Scan(
+			&state.CycleID, &state.IntentID, &state.Stage, &state.ExecutionTxHash, &cycleData,
+			&writeBackTx, &state.WriteBackTxID, &state.Shadow, &state.ErrorMessage, &state.CreatedAt, &state.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan proof cycle state: %w", err)
+		}
+		state.CycleData = cycleData
+		if len(writeBackTx) > 0 {
+			state.WriteBackTx = writeBackTx
+		}
+		states = append(states, state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list pending proof cycles: %w", err)
+	}
+	return states, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Certen Protocol
+//
+// Usage Repository - Per-account proof usage meters
+// Persists proof counts per account and metering window for billing and quotas
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UsageRepository handles account usage meter operations
+type UsageRepository struct {
+	client *Client
+}
+
+// NewUsageRepository creates a new usage repository
+func NewUsageRepository(client *Client) *UsageRepository {
+	return &UsageRepository{client: client}
+}
+
+// IncrementAccountUsage adds one proof to the account's meter for the given window and
+// returns the updated meter. onDemand also counts the proof in the on-demand tier.
+func (r *UsageRepository) IncrementAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time, onDemand bool) (*AccountUsageMeter, error) {
+	var onDemandInc int64
+	if onDemand {
+		onDemandInc = 1
+	}
+
+	query := `
+		INSERT INTO account_usage_meters (account_url, window_seconds, window_start, proof_count, on_demand_count, updated_at)
+		VALUES ($1, $2, $3, 1, $4, $5)
+		ON CONFLICT (account_url, window_seconds, window_start) DO UPDATE
+		SET proof_count = account_usage_meters.proof_count + 1,
+			on_demand_count = account_usage_meters.on_demand_count + EXCLUDED.on_demand_count,
+			updated_at = EXCLUDED.updated_at
+		RETURNING proof_count, on_demand_count, updated_at`
+
+	meter := &AccountUsageMeter{
+		AccountURL:  accountURL,
+		WindowSize:  windowSize,
+		WindowStart: windowStart,
+	}
+	err := r.client.QueryRowContext(ctx, query,
+		accountURL, int64(windowSize/time.Second), windowStart, onDemandInc, time.Now(),
+	).Scan(&meter.ProofCount, &meter.OnDemandCount, &meter.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment account usage: %w", err)
+	}
+
+	return meter, nil
+}
+
+// RefundAccountUsage removes one proof from the account's meter for the given window.
+// onDemand also removes it from the on-demand tier. Counts never drop below zero.
+func (r *UsageRepository) RefundAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time, onDemand bool) error {
+	var onDemandDec int64
+	if onDemand {
+		onDemandDec = 1
+	}
+
+	query := `
+		UPDATE account_usage_meters
+		SET proof_count = GREATEST(proof_count - 1, 0),
+			on_demand_count = GREATEST(LEAST(on_demand_count - $4, proof_count - 1), 0),
+			updated_at = $5
+		WHERE account_url = $1 AND window_seconds = $2 AND window_start = $3`
+
+	_, err := r.client.ExecContext(ctx, query,
+		accountURL, int64(windowSize/time.Second), windowStart, onDemandDec, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to refund account usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetAccountUsage returns the account's meter for the given window
+// A window without proofs returns a zero meter
+func (r *UsageRepository) GetAccountUsage(ctx context.Context, accountURL string, windowSize time.Duration, windowStart time.Time) (*AccountUsageMeter, error) {
+	query := `
+		SELECT proof_count, on_demand_count, updated_at
+		FROM account_usage_meters
+		WHERE account_url = $1 AND window_seconds = $2 AND window_start = $3`
+
+	meter := &AccountUsageMeter{
+		AccountURL:  accountURL,
+		WindowSize:  windowSize,
+		WindowStart: windowStart,
+	}
+	err := r.client.QueryRowContext(ctx, query, accountURL, int64(windowSize/time.Second), windowStart).
+		Scan(&meter.ProofCount, &meter.OnDemandCount, &meter.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return meter, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account usage: %w", err)
+	}
+
+	return meter, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Certen Protocol
+//
+// Verification Failure Repository - ProofVerificationFailed contract events
+// Persists failure events, correlates them to local batches and proofs, and marks the
+// affected proof artifacts
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VerificationTypeOnChainEvent is the verification history type of a ProofVerificationFailed event
+const VerificationTypeOnChainEvent = "onchain_event"
+
+// VerificationFailureRepository handles proof verification failure operations
+type VerificationFailureRepository struct {
+	client *Client
+}
+
+// NewVerificationFailureRepository creates a new verification failure repository
+func NewVerificationFailureRepository(client *Client) *VerificationFailureRepository {
+	return &VerificationFailureRepository{client: client}
+}
+
+// RecordVerificationFailure stores a failure event and sets its ID and creation time.
+// It returns false without error when the event (same tx hash and log index) was
+// already stored.
+func (r *VerificationFailureRepository) RecordVerificationFailure(ctx context.Context, f *ProofVerificationFailure) (bool, error) {
+	query := `
+		INSERT INTO proof_verification_failures (
+			event_tx_hash, log_index, block_number, anchor_id, transaction_hash,
+			merkle_verified, bls_verified, governance_verified, commitment_verified,
+			reason, event_timestamp, batch_id, proof_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (event_tx_hash, log_index) DO NOTHING
+		RETURNING id, created_at`
+
+	err := r.client.QueryRowContext(ctx, query,
+		f.EventTxHash, f.LogIndex, f.BlockNumber, f.AnchorID, f.TransactionHash,
+		f.MerkleVerified, f.BLSVerified, f.GovernanceVerified, f.CommitmentVerified,
+		f.Reason, f.EventTimestamp, f.BatchID, f.ProofID,
+	).Scan(&f.ID, &f.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record verification failure: %w", err)
+	}
+
+	return true, nil
+}
+
+// FindBatchByIDPrefix returns the batch whose ID (in canonical string form) starts with prefix
+// On-chain anchor IDs carry the first 32 characters of the batch ID
+func (r *VerificationFailureRepository) FindBatchByIDPrefix(ctx context.Context, prefix string) (uuid.UUID, error) {
+	query := `
+		SELECT id FROM anchor_batches
+		WHERE id::text LIKE $1 || '%'
+		LIMIT 2`
+
+	rows, err := r.client.QueryContext(ctx, query, prefix)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find batch by id prefix: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to scan batch id: %w", err)
+		}
+		matches = append(matches, id)
+	}
+	if err := rows.Err(); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find batch by id prefix: %w", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return uuid.Nil, ErrBatchNotFound
+	case 1:
+		return matches[0], nil
+	default:
+		return uuid.Nil, fmt.Errorf("batch id prefix %s is ambiguous", prefix)
+	}
+}
+
+// FindProofByBatchTransaction returns the proof artifact of the batch transaction with the given hash
+func (r *VerificationFailureRepository) FindProofByBatchTransaction(ctx context.Context, batchID uuid.UUID, txHash []byte) (uuid.UUID, error) {
+	query := `
+		SELECT pa.proof_id
+		FROM batch_transactions bt
+		JOIN proof_artifacts pa
+			ON pa.batch_id = bt.batch_id AND pa.accum_tx_hash = bt.accumulate_tx_hash
+		WHERE bt.batch_id = $1 AND bt.transaction_hash = $2
+		ORDER BY pa.created_at DESC
+		LIMIT 1`
+
+	var proofID uuid.UUID
+	err := r.client.QueryRowContext(ctx, query, batchID, txHash).Scan(&proofID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, ErrProofNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find proof by batch transaction: %w", err)
+	}
+
+	return proofID, nil
+}
+
+// MarkProofOnChainVerificationFailed sets the proof's verification status to
+// onchain_verification_failed and records the reason in its verification history
+func (r *VerificationFailureRepository) MarkProofOnChainVerificationFailed(ctx context.Context, proofID uuid.UUID, reason string) error {
+	tx, err := r.client.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Tx().ExecContext(ctx, `
+		UPDATE proof_artifacts
+		SET verification_status = $2, verified_at = NOW()
+		WHERE proof_id = $1`,
+		proofID, VerificationStatusOnChainFailed)
+	if err != nil {
+		return fmt.Errorf("failed to mark proof verification failed: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrProofNotFound
+	}
+
+	_, err = tx.Tx().ExecContext(ctx, `
+		INSERT INTO verification_history (proof_id, verification_type, passed, error_message, verification_method)
+		VALUES ($1, $2, FALSE, $3, 'contract_event')`,
+		proofID, VerificationTypeOnChainEvent, reason)
+	if err != nil {
+		return fmt.Errorf("failed to record verification history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit proof verification failure: %w", err)
+	}
+	return nil
+}
+
+// ListVerificationFailures returns failure events, newest first
+func (r *VerificationFailureRepository) ListVerificationFailures(ctx context.Context, limit, offset int) ([]ProofVerificationFailure, error) {
+	query := `
+		SELECT id, event_tx_hash, log_index, block_number, anchor_id, transaction_hash,
+			merkle_verified, bls_verified, governance_verified, commitment_verified,
+			reason, event_timestamp, batch_id, proof_id, created_at
+		FROM proof_verification_failures
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.client.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verification failures: %w", err)
+	}
+	defer rows.Close()
+
+	var failures []ProofVerificationFailure
+	for rows.Next() {
+		var f ProofVerificationFailure
+		if err := rows.Scan(
+			&f.ID, &f.EventTxHash, &f.LogIndex, &f.BlockNumber, &f.AnchorID, &f.TransactionHash,
+			&f.MerkleVerified, &f.BLSVerified, &f.GovernanceVerified, &f.CommitmentVerified,
+			&f.Reason, &f.EventTimestamp, &f.BatchID, &f.ProofID, &f.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan verification failure: %w", err)
+		}
+		failures = append(failures, f)
+	}
+
+	return failures, rows.Err()
+}
+
+// CountVerificationFailuresSince returns the number of failure events stored since the given time
+func (r *VerificationFailureRepository) CountVerificationFailuresSince(ctx context.Context, since time.Time) (int64, error) {
+	var count int64
+	err := r.client.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM proof_verification_failures WHERE created_at >= $1`, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count verification failures: %w", err)
+	}
+	return count, nil
+}
@@ -13,6 +13,8 @@ package database
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -44,6 +46,7 @@ const (
 	BatchStatusWaitingConfirms  BatchStatus = "waiting_for_confirmations" // Waiting for blockchain finality confirmations
 	BatchStatusConfirmed        BatchStatus = "confirmed"              // Anchor has sufficient confirmations
 	BatchStatusFailed           BatchStatus = "failed"                 // Anchoring failed
+	BatchStatusAbandoned        BatchStatus = "abandoned"              // On-demand batch that never anchored (terminal)
 )
 
 // AnchorBatch represents a batch of transactions anchored together
@@ -91,8 +94,13 @@ type BatchTransaction struct {
 	GovValid        bool            `db:"governance_valid" json:"governance_valid"`
 	IntentType      sql.NullString  `db:"intent_type" json:"intent_type,omitempty"`
 	IntentData      json.RawMessage `db:"intent_data" json:"intent_data,omitempty"`
+	Priority        RequestPriority `db:"priority" json:"priority"` // Effective intent priority
 	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
 
+	// Transaction origin: synthetic transactions link back to the user transaction that produced them
+	Origin       TransactionOrigin `db:"tx_origin" json:"tx_origin"`
+	OriginTxHash sql.NullString    `db:"origin_tx_hash" json:"origin_tx_hash,omitempty"`
+
 	// Intent Tracking (for Firestore linking)
 	UserID   sql.NullString `db:"user_id" json:"user_id,omitempty"`
 	IntentID sql.NullString `db:"intent_id" json:"intent_id,omitempty"`
@@ -159,6 +167,111 @@ type AnchorRecord struct {
 	ValidatorID          string        `db:"validator_id" json:"validator_id"`
 	CreatedAt            time.Time     `db:"created_at" json:"created_at"`
 	UpdatedAt            time.Time     `db:"updated_at" json:"updated_at"`
+
+	// Two-level finality: "available" once mined with AvailableConfirms, "final" at RequiredConfirms
+	AvailableConfirms int            `db:"available_confirmations" json:"available_confirmations"`
+	AvailableAt       sql.NullTime   `db:"available_at" json:"available_at,omitempty"`
+	Finality          AnchorFinality `db:"finality" json:"finality"`
+
+	// Cost of the executeComprehensiveProof transaction, for correlating proof size with gas
+	ProofGasUsed       sql.NullInt64 `db:"proof_gas_used" json:"proof_gas_used,omitempty"`
+	ProofCalldataBytes sql.NullInt64 `db:"proof_calldata_bytes" json:"proof_calldata_bytes,omitempty"`
+
+	// EIP-1559 fee breakdown of the anchor transaction and how its gas price was chosen
+	BaseFeeWei     sql.NullString `db:"base_fee_wei" json:"base_fee_wei,omitempty"`
+	PriorityFeeWei sql.NullString `db:"priority_fee_wei" json:"priority_fee_wei,omitempty"`
+	FeeStrategy    sql.NullString `db:"fee_strategy" json:"fee_strategy,omitempty"`
+	GasBumped      bool           `db:"gas_bumped" json:"gas_bumped"`
+
+	// Recorded in shadow mode: the transaction was estimated but never sent
+	Shadow bool `db:"shadow" json:"shadow"`
+}
+
+// GasHistoryPoint is the gas price paid by one anchor transaction
+type GasHistoryPoint struct {
+	AnchorID       uuid.UUID   `json:"anchor_id"`
+	TargetChain    TargetChain `json:"target_chain"`
+	AnchorTxHash   string      `json:"anchor_tx_hash"`
+	BlockNumber    int64       `json:"block_number"`
+	AnchoredAt     time.Time   `json:"anchored_at"`
+	GasUsed        int64       `json:"gas_used"`
+	GasPriceWei    string      `json:"gas_price_wei,omitempty"`
+	BaseFeeWei     string      `json:"base_fee_wei,omitempty"`
+	PriorityFeeWei string      `json:"priority_fee_wei,omitempty"`
+	TotalCostWei   string      `json:"total_cost_wei,omitempty"`
+	FeeStrategy    string      `json:"fee_strategy,omitempty"`
+	GasBumped      bool        `json:"gas_bumped"`
+	FeeEscalations int         `json:"fee_escalations,omitempty"` // Submissions under a fee escalation schedule
+}
+
+// CostGrouping selects how anchor spend is rolled up
+type CostGrouping string
+
+const (
+	CostGroupByDay       CostGrouping = "day"       // One row per day and validator
+	CostGroupByValidator CostGrouping = "validator" // One row per validator over the whole range
+)
+
+// IsValid reports whether the grouping is known
+func (g CostGrouping) IsValid() bool {
+	return g == CostGroupByDay || g == CostGroupByValidator
+}
+
+// AnchorCostRollup is the spend on sent anchor transactions of one validator in one period
+type AnchorCostRollup struct {
+	Day          *time.Time `json:"day,omitempty"` // UTC day; nil when grouped by validator
+	ValidatorID  string     `json:"validator_id"`
+	AnchorCount  int        `json:"anchor_count"`
+	GasUsed      int64      `json:"gas_used"`
+	TotalCostWei string     `json:"total_cost_wei"`
+	TotalCostUSD float64    `json:"total_cost_usd"`
+	// Anchors with a USD figure; TotalCostUSD leaves out the others
+	PricedAnchors int `json:"priced_anchors"`
+}
+
+// AnchorFeeEscalationStep is one submission of an anchor transaction under a fee escalation schedule
+// Maps to: anchor_fee_escalations table
+type AnchorFeeEscalationStep struct {
+	AnchorID    uuid.UUID `db:"anchor_id" json:"anchor_id"`
+	Attempt     int       `db:"attempt" json:"attempt"` // 1 = initial submission
+	TxHash      string    `db:"tx_hash" json:"tx_hash"`
+	GasPriceWei string    `db:"gas_price_wei" json:"gas_price_wei"`
+	SubmittedAt time.Time `db:"submitted_at" json:"submitted_at"`
+	Mined       bool      `db:"mined" json:"mined"` // The submission that confirmed
+}
+
+// MinedAnchorSubmission is the mined submission of an anchor transaction that was replaced
+type MinedAnchorSubmission struct {
+	TxHash       string
+	BlockNumber  int64
+	BlockHash    string
+	GasUsed      int64
+	GasPriceWei  string
+	TotalCostWei string
+}
+
+// AnchorFinality is the settlement level of an anchor on its target chain
+type AnchorFinality string
+
+const (
+	AnchorFinalityPending   AnchorFinality = "pending"   // Not mined or below the availability threshold
+	AnchorFinalityAvailable AnchorFinality = "available" // Mined with the minimum confirmations - usable at the client's risk
+	AnchorFinalityFinal     AnchorFinality = "final"     // Reached the required confirmations
+	AnchorFinalityReorged   AnchorFinality = "reorged"   // Anchor block was reorged out; waiting for the transaction to be mined again
+)
+
+// DefaultAvailableConfirmations is the default confirmation count for an anchor to be "available"
+const DefaultAvailableConfirmations = 1
+
+// ComputeAnchorFinality derives the finality level from a confirmation count and thresholds
+func ComputeAnchorFinality(confirmations, availableConfirms, requiredConfirms int) AnchorFinality {
+	if confirmations >= requiredConfirms {
+		return AnchorFinalityFinal
+	}
+	if availableConfirms > 0 && confirmations >= availableConfirms {
+		return AnchorFinalityAvailable
+	}
+	return AnchorFinalityPending
 }
 
 // ============================================================================
@@ -201,6 +314,7 @@ type CertenAnchorProof struct {
 	AnchorBlockNumber int64           `db:"anchor_block_number" json:"anchor_block_number"`
 	AnchorBlockHash   sql.NullString  `db:"anchor_block_hash" json:"anchor_block_hash,omitempty"`
 	AnchorConfirms    int             `db:"anchor_confirmations" json:"anchor_confirmations"`
+	AnchorFinality    AnchorFinality  `db:"anchor_finality" json:"anchor_finality"` // pending, available, final, or reorged
 
 	// Component 3: State Proof (ChainedProof L1-L3)
 	AccumStateProof  json.RawMessage `db:"accumulate_state_proof" json:"accumulate_state_proof,omitempty"`
@@ -276,6 +390,41 @@ const (
 	PriorityUrgent RequestPriority = "urgent"
 )
 
+// TransactionOrigin distinguishes user-signed transactions from synthetic
+// transactions produced by the network while executing them
+type TransactionOrigin string
+
+const (
+	TransactionOriginUser      TransactionOrigin = "user"
+	TransactionOriginSynthetic TransactionOrigin = "synthetic"
+)
+
+// ParseRequestPriority parses a priority name; empty selects normal
+func ParseRequestPriority(s string) (RequestPriority, error) {
+	switch p := RequestPriority(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return PriorityNormal, nil
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown priority %q (expected low, normal, high or urgent)", s)
+	}
+}
+
+// Rank orders priorities from low (0) to urgent (3); unknown values rank as normal
+func (p RequestPriority) Rank() int {
+	switch p {
+	case PriorityLow:
+		return 0
+	case PriorityHigh:
+		return 2
+	case PriorityUrgent:
+		return 3
+	default:
+		return 1
+	}
+}
+
 // RequestStatus represents the status of a proof request
 type RequestStatus string
 
@@ -306,6 +455,114 @@ type ProofRequest struct {
 	RetryCount   int             `db:"retry_count" json:"retry_count"`
 }
 
+// ============================================================================
+// ACCOUNT USAGE TYPES
+// ============================================================================
+
+// AccountUsageMeter is the proof count of one account in one metering window
+// Maps to: account_usage_meters table
+type AccountUsageMeter struct {
+	AccountURL    string        `db:"account_url" json:"account_url"`
+	WindowSize    time.Duration `db:"window_seconds" json:"window_size"`
+	WindowStart   time.Time     `db:"window_start" json:"window_start"`
+	ProofCount    int64         `db:"proof_count" json:"proof_count"`
+	OnDemandCount int64         `db:"on_demand_count" json:"on_demand_count"` // Included in ProofCount
+	UpdatedAt     time.Time     `db:"updated_at" json:"updated_at"`
+}
+
+// ============================================================================
+// PROOF VERIFICATION FAILURE TYPES
+// ============================================================================
+
+// ProofVerificationFailure is a ProofVerificationFailed contract event
+// Maps to: proof_verification_failures table
+type ProofVerificationFailure struct {
+	ID                 int64      `db:"id" json:"id"`
+	EventTxHash        string     `db:"event_tx_hash" json:"event_tx_hash"`
+	LogIndex           int        `db:"log_index" json:"log_index"`
+	BlockNumber        int64      `db:"block_number" json:"block_number"`
+	AnchorID           []byte     `db:"anchor_id" json:"anchor_id"`               // 32 bytes
+	TransactionHash    []byte     `db:"transaction_hash" json:"transaction_hash"` // 32 bytes
+	MerkleVerified     bool       `db:"merkle_verified" json:"merkle_verified"`
+	BLSVerified        bool       `db:"bls_verified" json:"bls_verified"`
+	GovernanceVerified bool       `db:"governance_verified" json:"governance_verified"`
+	CommitmentVerified bool       `db:"commitment_verified" json:"commitment_verified"`
+	Reason             string     `db:"reason" json:"reason"`
+	EventTimestamp     *time.Time `db:"event_timestamp" json:"event_timestamp,omitempty"`
+	BatchID            *uuid.UUID `db:"batch_id" json:"batch_id,omitempty"` // Correlated local batch
+	ProofID            *uuid.UUID `db:"proof_id" json:"proof_id,omitempty"` // Correlated proof artifact
+	CreatedAt          time.Time  `db:"created_at" json:"created_at"`
+}
+
+// AbandonedOnDemandRequest is the outcome of a transaction in an abandoned on-demand batch
+// Maps to: abandoned_on_demand_requests table
+type AbandonedOnDemandRequest struct {
+	ID             int64       `db:"id" json:"id"`
+	BatchID        uuid.UUID   `db:"batch_id" json:"batch_id"`
+	AccumTxHash    string      `db:"accumulate_tx_hash" json:"accumulate_tx_hash"`
+	AccountURL     string      `db:"account_url" json:"account_url"`
+	PreviousStatus BatchStatus `db:"previous_status" json:"previous_status"` // Batch status when abandoned
+	Reason         string      `db:"reason" json:"reason"`
+	RequestedAt    time.Time   `db:"requested_at" json:"requested_at"`     // When the request was metered
+	UsageRefunded  bool        `db:"usage_refunded" json:"usage_refunded"` // Metered on-demand usage was refunded
+	AbandonedAt    time.Time   `db:"abandoned_at" json:"abandoned_at"`
+}
+
+// ============================================================================
+// PROOF CYCLE STATE TYPES
+// ============================================================================
+
+// Proof cycle stages; observing, attesting and writing_back are in flight
+const (
+	ProofCycleStageObserving   = "observing"
+	ProofCycleStageAttesting   = "attesting"
+	ProofCycleStageWritingBack = "writing_back"
+	ProofCycleStageCompleted   = "completed"
+	ProofCycleStageFailed      = "failed"
+
+	// Write-back submitted but not confirmed before the timeout; look up its Accumulate txid
+	ProofCycleStageWriteBackUnconfirmed = "write_back_unconfirmed"
+)
+
+// ProofCycleState is the persisted state of a proof cycle
+// Maps to: proof_cycle_states table
+type ProofCycleState struct {
+	CycleID         string          `db:"cycle_id" json:"cycle_id"`
+	IntentID        string          `db:"intent_id" json:"intent_id"`
+	Stage           string          `db:"stage" json:"stage"`
+	ExecutionTxHash string          `db:"execution_tx_hash" json:"execution_tx_hash,omitempty"` // Single-transaction cycles only
+	CycleData       json.RawMessage `db:"cycle_data" json:"cycle_data"`
+	WriteBackTx     json.RawMessage `db:"write_back_tx" json:"write_back_tx,omitempty"`       // Nil until the write-back is built
+	WriteBackTxID   string          `db:"write_back_tx_id" json:"write_back_tx_id,omitempty"` // Accumulate txid once submitted
+	Shadow          bool            `db:"shadow" json:"shadow"`                               // Run in shadow mode; nothing was submitted
+	ErrorMessage    string          `db:"error_message" json:"error_message,omitempty"`
+	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time       `db:"updated_at" json:"updated_at"`
+}
+
+// AnchorDiscrepancyKind classifies a disagreement between a local anchor record and the chain
+type AnchorDiscrepancyKind string
+
+const (
+	AnchorDiscrepancyMissing            AnchorDiscrepancyKind = "missing_on_chain"     // anchorExists is false
+	AnchorDiscrepancyInvalidated        AnchorDiscrepancyKind = "invalidated_on_chain" // getAnchor reports valid = false
+	AnchorDiscrepancyCommitmentMismatch AnchorDiscrepancyKind = "commitment_mismatch"  // Stored operation commitment differs
+	AnchorDiscrepancyHeightMismatch     AnchorDiscrepancyKind = "height_mismatch"      // Stored Accumulate height differs
+	AnchorDiscrepancyProofNotRecorded   AnchorDiscrepancyKind = "proof_not_recorded"   // Proof submitted locally, no verification on-chain
+)
+
+// AnchorStateDiscrepancy is a disagreement between a local anchor record and on-chain state
+// Maps to: anchor_state_discrepancies table
+type AnchorStateDiscrepancy struct {
+	AnchorID        uuid.UUID             `db:"anchor_id" json:"anchor_id"`
+	Kind            AnchorDiscrepancyKind `db:"kind" json:"kind"`
+	BatchID         uuid.UUID             `db:"batch_id" json:"batch_id"`
+	Detail          string                `db:"detail" json:"detail"`
+	FirstDetectedAt time.Time             `db:"first_detected_at" json:"first_detected_at"`
+	LastDetectedAt  time.Time             `db:"last_detected_at" json:"last_detected_at"`
+	ResolvedAt      sql.NullTime          `db:"resolved_at" json:"resolved_at,omitempty"` // Set once the chain agrees again
+}
+
 // ============================================================================
 // HELPER TYPES FOR INSERT/UPDATE OPERATIONS
 // ============================================================================
@@ -329,6 +586,11 @@ type NewBatchTransaction struct {
 	GovLevel     GovernanceLevel // Optional
 	IntentType   string          // Optional
 	IntentData   json.RawMessage // Optional
+	Priority     RequestPriority // Optional - defaults to normal
+
+	// Transaction origin (for synthetic transaction linking)
+	Origin       TransactionOrigin // Optional - defaults to user
+	OriginTxHash *string           // Optional - producing user transaction (synthetic only)
 
 	// Intent Tracking (for Firestore linking)
 	UserID   *string // Optional - user who submitted the intent
@@ -364,6 +626,27 @@ type NewAnchorRecord struct {
 	GasUsed              int64
 	GasPriceWei          string
 	TotalCostWei         string
+
+	// Finality thresholds (0 = defaults for the target chain)
+	AvailableConfirmations int
+	RequiredConfirmations  int
+
+	// executeComprehensiveProof cost (0 = proof not executed)
+	ProofGasUsed       int64
+	ProofCalldataBytes int
+
+	// Anchor transaction fee breakdown (empty = unknown)
+	BaseFeeWei     string
+	PriorityFeeWei string
+	FeeStrategy    string
+	GasBumped      bool
+
+	// Shadow mode: the anchor transaction was estimated but never sent
+	Shadow bool
+
+	// USD cost of the anchor transaction at the fee oracle's ETH/USD price when it
+	// was anchored (nil = no oracle price)
+	TotalCostUSD *float64
 }
 
 // NewCertenAnchorProof is used to create a new proof
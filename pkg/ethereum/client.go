@@ -3,9 +3,12 @@ package ethereum
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -17,30 +20,70 @@ import (
 	ethereum "github.com/ethereum/go-ethereum"
 )
 
-// Client represents an Ethereum client
+// Client represents an Ethereum client. Calls that fail with a connection error
+// re-dial the endpoint (see ReconnectPolicy) and are retried on the new connection;
+// with fallback endpoints configured, repeated errors rotate to the next endpoint.
 type Client struct {
-	client  *ethclient.Client
+	client  *ethclient.Client // Replaced on reconnect; read through rpc()
 	chainID *big.Int
-	url     string
+	urls    []string // Primary endpoint first, then fallbacks
+	active  int      // Index in urls of the endpoint in use
+
+	dynamicFees *DynamicFeePolicy // nil = legacy gas pricing
+
+	mu              sync.RWMutex
+	reconnectMu     sync.Mutex // Serializes reconnects and endpoint switches
+	state           ConnectionState
+	reconnectPolicy ReconnectPolicy
+	onStateChange   func(ConnectionState)
+	failures        int       // Consecutive endpoint errors on the active endpoint
+	leftPrimaryAt   time.Time // Last switch away from (or failed return to) the primary
 }
 
 // NewClient creates a new Ethereum client
 func NewClient(url string, chainID int64) (*Client, error) {
-	client, err := ethclient.Dial(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum: %w", err)
+	return NewClientWithFallbacks([]string{url}, chainID)
+}
+
+// NewClientWithFallbacks creates a client for a primary endpoint followed by fallback
+// endpoints, connected to the first endpoint that dials
+func NewClientWithFallbacks(urls []string, chainID int64) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("failed to connect to Ethereum: no endpoint configured")
 	}
 
-	return &Client{
-		client:  client,
-		chainID: big.NewInt(chainID),
-		url:     url,
-	}, nil
+	var lastErr error
+	for i, url := range urls {
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		c := &Client{
+			client:          client,
+			chainID:         big.NewInt(chainID),
+			urls:            urls,
+			active:          i,
+			state:           ConnectionConnected,
+			reconnectPolicy: DefaultReconnectPolicy(),
+		}
+		if i > 0 {
+			c.leftPrimaryAt = time.Now()
+			log.Printf("⚠️ [ethereum] Primary endpoint %s unavailable, using fallback %s", endpointLabel(urls[0]), endpointLabel(url))
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("failed to connect to Ethereum: %w", lastErr)
 }
 
 // GetBalance gets the ETH balance of an address
 func (c *Client) GetBalance(ctx context.Context, address common.Address) (*big.Int, error) {
-	balance, err := c.client.BalanceAt(ctx, address, nil)
+	var balance *big.Int
+	err := c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+		balance, err = client.BalanceAt(ctx, address, nil)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
@@ -49,7 +92,11 @@ func (c *Client) GetBalance(ctx context.Context, address common.Address) (*big.I
 
 // GetNonce gets the nonce for an address
 func (c *Client) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
-	nonce, err := c.client.PendingNonceAt(ctx, address)
+	var nonce uint64
+	err := c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+		nonce, err = client.PendingNonceAt(ctx, address)
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get nonce: %w", err)
 	}
@@ -58,7 +105,11 @@ func (c *Client) GetNonce(ctx context.Context, address common.Address) (uint64,
 
 // GetGasPrice gets the current gas price
 func (c *Client) GetGasPrice(ctx context.Context) (*big.Int, error) {
-	gasPrice, err := c.client.SuggestGasPrice(ctx)
+	var gasPrice *big.Int
+	err := c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+		gasPrice, err = client.SuggestGasPrice(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
 	}
@@ -122,7 +173,11 @@ func PrivateKeyToHex(privateKey *ecdsa.PrivateKey) string {
 
 // EstimateGas estimates gas for a transaction
 func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
-	gasLimit, err := c.client.EstimateGas(ctx, msg)
+	var gasLimit uint64
+	err := c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+		gasLimit, err = client.EstimateGas(ctx, msg)
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to estimate gas: %w", err)
 	}
@@ -131,7 +186,7 @@ func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64,
 
 // WaitForTransaction waits for a transaction to be mined
 func (c *Client) WaitForTransaction(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
-	receipt, err := bind.WaitMined(ctx, c.client, tx)
+	receipt, err := bind.WaitMined(ctx, reconnectingBackend{c}, tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait for transaction: %w", err)
 	}
@@ -143,20 +198,34 @@ func (c *Client) GetChainID() *big.Int {
 	return c.chainID
 }
 
-// GetClient returns the underlying ethclient
+// GetClient returns the current underlying ethclient; it is replaced on reconnect
 func (c *Client) GetClient() *ethclient.Client {
-	return c.client
+	return c.rpc()
+}
+
+// Close closes the underlying RPC connection
+func (c *Client) Close() {
+	c.rpc().Close()
 }
 
 // Health checks if the Ethereum client is healthy
 func (c *Client) Health(ctx context.Context) error {
-	_, err := c.client.BlockNumber(ctx)
+	err := c.withReconnect(ctx, func(client *ethclient.Client) error {
+		_, err := client.BlockNumber(ctx)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("ethereum health check failed: %w", err)
 	}
 	return nil
 }
 
+// Fee strategies used to price contract transactions
+const (
+	FeeStrategySuggested  = "legacy_suggested"  // eth_gasPrice with a 5 gwei floor
+	FeeStrategyEscalating = "legacy_escalating" // As suggested, raised 20% per resubmission
+)
+
 // ContractCallResult represents the result of a contract call
 type ContractCallResult struct {
 	TransactionHash string    `json:"transaction_hash"`
@@ -167,6 +236,47 @@ type ContractCallResult struct {
 	Success         bool      `json:"success"`
 	Timestamp       time.Time `json:"timestamp"`
 	ReturnData      []byte    `json:"return_data,omitempty"`
+
+	// Fees paid: GasPrice is the effective price per gas and the priority fee is the
+	// part above the block base fee. BaseFee and PriorityFee are nil on chains without
+	// EIP-1559; MaxFeePerGas and MaxPriorityFeePerGas are set for type-2 transactions.
+	GasPrice             *big.Int `json:"gas_price"`
+	BaseFee              *big.Int `json:"base_fee,omitempty"`
+	PriorityFee          *big.Int `json:"priority_fee,omitempty"`
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+	FeeStrategy          string   `json:"fee_strategy"`
+	Attempts             int      `json:"attempts"` // Submissions made; > 1 means the gas price was bumped
+
+	// Submissions made under a fee escalation schedule (FeeStrategyScheduled only)
+	Escalation []FeeEscalationStep `json:"escalation,omitempty"`
+}
+
+// setFees records the fees paid by a mined transaction sent with the given fees
+func (c *Client) setFees(ctx context.Context, result *ContractCallResult, fees txFees, receipt *types.Receipt) {
+	result.MaxFeePerGas = fees.GasFeeCap
+	result.MaxPriorityFeePerGas = fees.GasTipCap
+
+	var baseFee *big.Int
+	if header, err := c.rpc().HeaderByNumber(ctx, receipt.BlockNumber); err == nil {
+		baseFee = header.BaseFee
+	}
+
+	// Prefer the price reported in the receipt; otherwise derive it from the fees sent
+	paid := receipt.EffectiveGasPrice
+	if paid == nil || paid.Sign() == 0 {
+		paid = fees.maxPrice()
+		if fees.dynamic() && baseFee != nil {
+			paid = txFees{GasFeeCap: fees.GasFeeCap, GasTipCap: fees.GasTipCap, BaseFee: baseFee}.expectedPrice()
+		}
+	}
+	result.GasPrice = paid
+	result.GasCost = new(big.Int).Mul(paid, new(big.Int).SetUint64(receipt.GasUsed))
+
+	if baseFee != nil {
+		result.BaseFee = baseFee
+		result.PriorityFee = new(big.Int).Sub(paid, baseFee)
+	}
 }
 
 // CallContract makes a read-only contract call
@@ -184,10 +294,14 @@ func (c *Client) CallContract(ctx context.Context, contractAddr common.Address,
 	}
 
 	// Make the contract call
-	result, err := c.client.CallContract(ctx, ethereum.CallMsg{
-		To:   &contractAddr,
-		Data: callData,
-	}, nil)
+	var result []byte
+	err = c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+		result, err = client.CallContract(ctx, ethereum.CallMsg{
+			To:   &contractAddr,
+			Data: callData,
+		}, nil)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("contract call failed: %w", err)
 	}
@@ -201,6 +315,57 @@ func (c *Client) CallContract(ctx context.Context, contractAddr common.Address,
 	return outputs, nil
 }
 
+// ContractCallEstimate is the expected cost of a contract transaction that was not sent
+type ContractCallEstimate struct {
+	GasLimit     uint64   `json:"gas_limit"`                 // eth_estimateGas for the call from the signer
+	GasPrice     *big.Int `json:"gas_price"`                 // As SendContractTransaction would pay at the current base fee
+	TotalCost    *big.Int `json:"total_cost"`                // GasLimit * GasPrice
+	MaxFeePerGas *big.Int `json:"max_fee_per_gas,omitempty"` // Fee cap of a type-2 transaction
+	FeeStrategy  string   `json:"fee_strategy"`
+}
+
+// EstimateContractTransaction prices a contract transaction exactly as SendContractTransaction
+// would send it, without signing or sending it
+func (c *Client) EstimateContractTransaction(ctx context.Context, contractAddr common.Address, abiString string, privateKeyHex string, methodName string, params ...interface{}) (*ContractCallEstimate, error) {
+	contractABI, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	callData, err := contractABI.Pack(methodName, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack method call: %w", err)
+	}
+
+	fromAddress, err := GetPublicAddress(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := c.EstimateGas(ctx, ethereum.CallMsg{
+		From: fromAddress,
+		To:   &contractAddr,
+		Data: callData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := c.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := fees.expectedPrice()
+
+	return &ContractCallEstimate{
+		GasLimit:     gasLimit,
+		GasPrice:     gasPrice,
+		TotalCost:    new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit)),
+		MaxFeePerGas: fees.GasFeeCap,
+		FeeStrategy:  fees.strategy(FeeStrategySuggested, FeeStrategyDynamic),
+	}, nil
+}
+
 // SendContractTransaction sends a transaction to a contract
 func (c *Client) SendContractTransaction(ctx context.Context, contractAddr common.Address, abiString string, privateKeyHex string, methodName string, gasLimit uint64, params ...interface{}) (*ContractCallResult, error) {
 	// Parse the contract ABI
@@ -226,40 +391,25 @@ func (c *Client) SendContractTransaction(ctx context.Context, contractAddr commo
 	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
 
 	// Get nonce
-	nonce, err := c.client.PendingNonceAt(ctx, fromAddress)
+	nonce, err := c.GetNonce(ctx, fromAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, err
 	}
 
-	// Get gas price with minimum floor
-	gasPrice, err := c.client.SuggestGasPrice(ctx)
+	// Get gas price (legacy, minimum floor) or EIP-1559 fees
+	fees, err := c.suggestFees(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
-	}
-	// Enforce minimum 5 Gwei to ensure transactions get included
-	minGasPrice := big.NewInt(5 * 1e9)
-	if gasPrice.Cmp(minGasPrice) < 0 {
-		gasPrice = minGasPrice
+		return nil, err
 	}
 
-	// Create transaction
-	tx := types.NewTransaction(
-		nonce,
-		contractAddr,
-		big.NewInt(0), // value
-		gasLimit,
-		gasPrice,
-		callData,
-	)
-
-	// Sign transaction
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(c.chainID), privateKey)
+	// Create and sign transaction
+	signedTx, err := c.signContractTx(privateKey, nonce, contractAddr, gasLimit, fees, callData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, err
 	}
 
 	// Send transaction
-	err = c.client.SendTransaction(ctx, signedTx)
+	err = c.sendTransaction(ctx, signedTx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send transaction: %w", err)
 	}
@@ -275,10 +425,12 @@ func (c *Client) SendContractTransaction(ctx context.Context, contractAddr commo
 		BlockNumber:     receipt.BlockNumber.Uint64(),
 		BlockHash:       receipt.BlockHash.Hex(),
 		GasUsed:         receipt.GasUsed,
-		GasCost:         new(big.Int).Mul(gasPrice, big.NewInt(int64(receipt.GasUsed))),
 		Success:         receipt.Status == types.ReceiptStatusSuccessful,
 		Timestamp:       time.Now(),
+		FeeStrategy:     fees.strategy(FeeStrategySuggested, FeeStrategyDynamic),
+		Attempts:        1,
 	}
+	c.setFees(ctx, result, fees, receipt)
 
 	return result, nil
 }
@@ -310,49 +462,30 @@ func (c *Client) SendContractTransactionWithRetry(ctx context.Context, contractA
 	// Retry loop with gas price escalation
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		// Get fresh nonce and gas price for each attempt
-		nonce, err := c.client.PendingNonceAt(ctx, fromAddress)
+		nonce, err := c.GetNonce(ctx, fromAddress)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get nonce: %w", err)
+			return nil, err
 		}
 
-		// Get base gas price and escalate on retries
-		baseGasPrice, err := c.client.SuggestGasPrice(ctx)
+		// Get base fees and escalate on retries
+		fees, err := c.suggestFees(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get gas price: %w", err)
-		}
-
-		// Enforce minimum 5 Gwei to ensure transactions get included
-		minGasPrice := big.NewInt(5 * 1e9)
-		if baseGasPrice.Cmp(minGasPrice) < 0 {
-			baseGasPrice = minGasPrice
+			return nil, err
 		}
 
-		// Escalate gas price by 20% for each retry
-		gasPrice := new(big.Int).Set(baseGasPrice)
+		// Escalate gas price (or fee cap and tip) by 20% for each retry
 		if attempt > 0 {
-			multiplier := big.NewInt(int64(100 + (20 * attempt))) // 120%, 140%, etc.
-			gasPrice = gasPrice.Mul(gasPrice, multiplier)
-			gasPrice = gasPrice.Div(gasPrice, big.NewInt(100))
+			fees = fees.scaled(20 * attempt) // 120%, 140%, etc.
 		}
 
-		// Create transaction
-		tx := types.NewTransaction(
-			nonce,
-			contractAddr,
-			big.NewInt(0), // value
-			gasLimit,
-			gasPrice,
-			callData,
-		)
-
-		// Sign transaction
-		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(c.chainID), privateKey)
+		// Create and sign transaction
+		signedTx, err := c.signContractTx(privateKey, nonce, contractAddr, gasLimit, fees, callData)
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %w", err)
+			return nil, err
 		}
 
 		// Send transaction
-		err = c.client.SendTransaction(ctx, signedTx)
+		err = c.sendTransaction(ctx, signedTx)
 		if err != nil {
 			errStr := err.Error()
 			// Check if this is a retryable error
@@ -378,10 +511,12 @@ func (c *Client) SendContractTransactionWithRetry(ctx context.Context, contractA
 			BlockNumber:     receipt.BlockNumber.Uint64(),
 			BlockHash:       receipt.BlockHash.Hex(),
 			GasUsed:         receipt.GasUsed,
-			GasCost:         new(big.Int).Mul(gasPrice, big.NewInt(int64(receipt.GasUsed))),
 			Success:         receipt.Status == types.ReceiptStatusSuccessful,
 			Timestamp:       time.Now(),
+			FeeStrategy:     fees.strategy(FeeStrategyEscalating, FeeStrategyDynamicEscalating),
+			Attempts:        attempt + 1,
 		}
+		c.setFees(ctx, result, fees, receipt)
 
 		return result, nil
 	}
@@ -391,7 +526,11 @@ func (c *Client) SendContractTransactionWithRetry(ctx context.Context, contractA
 
 // GetBlock gets a block by number
 func (c *Client) GetBlock(ctx context.Context, blockNumber *big.Int) (*types.Block, error) {
-	block, err := c.client.BlockByNumber(ctx, blockNumber)
+	var block *types.Block
+	err := c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+		block, err = client.BlockByNumber(ctx, blockNumber)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block: %w", err)
 	}
@@ -421,4 +560,50 @@ func (c *Client) GetBlockInfo(ctx context.Context, blockNumber int64) (hash stri
 		return "", time.Time{}, err
 	}
 	return block.Hash().Hex(), time.Unix(int64(block.Time()), 0), nil
-}
\ No newline at end of file
+}
+
+// BlockNumber returns the latest block number without fetching the block
+func (c *Client) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = c.withReconnect(ctx, func(client *ethclient.Client) error {
+		number, err = client.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+// FilterLogs returns the logs matching query
+// Used by the event watcher when it shares this client
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = c.withReconnect(ctx, func(client *ethclient.Client) error {
+		logs, err = client.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// GetTransactionReceipt returns the receipt of a mined transaction, or nil if it is not mined
+// Used by confirmation tracker for following replacement submissions of anchor transactions
+func (c *Client) GetTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
+	receipt, err := reconnectingBackend{c}.TransactionReceipt(ctx, common.HexToHash(txHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt for %s: %w", txHash, err)
+	}
+	return receipt, nil
+}
+
+// sendTransaction submits a signed transaction through the reconnect. A resubmission
+// after reconnecting that the node already has counts as sent.
+func (c *Client) sendTransaction(ctx context.Context, tx *types.Transaction) error {
+	attempts := 0
+	return c.withReconnect(ctx, func(client *ethclient.Client) error {
+		attempts++
+		err := client.SendTransaction(ctx, tx)
+		if err != nil && attempts > 1 && strings.Contains(err.Error(), "already known") {
+			return nil
+		}
+		return err
+	})
+}
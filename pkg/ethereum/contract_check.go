@@ -0,0 +1,47 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInvalidContractAddress is returned when a configured contract address is unusable
+var ErrInvalidContractAddress = errors.New("invalid contract address")
+
+// CodeFetcher reads deployed contract code (implemented by ethclient.Client)
+type CodeFetcher interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// CheckContractAddress verifies that address is a well-formed, non-zero address with
+// deployed code. An empty, zero or externally owned address would otherwise only surface
+// later as failed or silently reverted anchoring transactions.
+func CheckContractAddress(ctx context.Context, fetcher CodeFetcher, address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: not set", ErrInvalidContractAddress)
+	}
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("%w: %q is not a hex address", ErrInvalidContractAddress, address)
+	}
+	addr := common.HexToAddress(address)
+	if addr == (common.Address{}) {
+		return fmt.Errorf("%w: zero address", ErrInvalidContractAddress)
+	}
+	code, err := fetcher.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code at %s: %w", addr.Hex(), err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%w: no contract code at %s (wrong network or not a contract)", ErrInvalidContractAddress, addr.Hex())
+	}
+	return nil
+}
+
+// CheckContractAddress verifies that address has deployed code on this client's network
+func (c *Client) CheckContractAddress(ctx context.Context, address string) error {
+	return CheckContractAddress(ctx, reconnectingBackend{c}, address)
+}
@@ -0,0 +1,72 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// stubCodeFetcher serves contract code from a map
+type stubCodeFetcher struct {
+	code  map[common.Address][]byte
+	err   error
+	calls int
+}
+
+func (f *stubCodeFetcher) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.code[account], nil
+}
+
+func TestCheckContractAddress(t *testing.T) {
+	contract := common.HexToAddress("0xEb17eBd351D2e040a0cB3026a3D04BEc182d8b98")
+	eoa := common.HexToAddress("0x8B18BE5EE7B4e1f33BAd6f5f0f31588F64F63A4e")
+	fetcher := &stubCodeFetcher{code: map[common.Address][]byte{contract: {0x60, 0x80, 0x60, 0x40}}}
+
+	tests := []struct {
+		name      string
+		address   string
+		wantErr   bool
+		wantFetch bool // Whether the address is well-formed enough to query
+	}{
+		{"deployed contract", contract.Hex(), false, true},
+		{"lower case contract", "0xeb17ebd351d2e040a0cb3026a3d04bec182d8b98", false, true},
+		{"empty", "", true, false},
+		{"zero address", "0x0000000000000000000000000000000000000000", true, false},
+		{"malformed", "0x1234", true, false},
+		{"not hex", "0xZZ17eBd351D2e040a0cB3026a3D04BEc182d8b98", true, false},
+		{"no code", eoa.Hex(), true, true},
+	}
+	for _, tt := range tests {
+		fetcher.calls = 0
+		err := CheckContractAddress(context.Background(), fetcher, tt.address)
+		if tt.wantErr && !errors.Is(err, ErrInvalidContractAddress) {
+			t.Errorf("%s: expected ErrInvalidContractAddress, got %v", tt.name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if (fetcher.calls > 0) != tt.wantFetch {
+			t.Errorf("%s: fetched code %d times, want fetch %v", tt.name, fetcher.calls, tt.wantFetch)
+		}
+	}
+}
+
+func TestCheckContractAddress_FetchError(t *testing.T) {
+	rpcErr := errors.New("connection refused")
+	fetcher := &stubCodeFetcher{err: rpcErr}
+
+	err := CheckContractAddress(context.Background(), fetcher, "0xEb17eBd351D2e040a0cB3026a3D04BEc182d8b98")
+	if !errors.Is(err, rpcErr) {
+		t.Errorf("expected the RPC error, got %v", err)
+	}
+	if errors.Is(err, ErrInvalidContractAddress) {
+		t.Error("an RPC failure must not be reported as an invalid address")
+	}
+}
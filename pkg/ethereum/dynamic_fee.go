@@ -0,0 +1,207 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Fee strategies for EIP-1559 dynamic fee (type-2) transactions
+const (
+	FeeStrategyDynamic           = "eip1559"            // Base fee x multiplier + tip
+	FeeStrategyDynamicEscalating = "eip1559_escalating" // As dynamic, fee cap and tip raised 20% per resubmission
+	FeeStrategyDynamicScheduled  = "eip1559_scheduled"  // As dynamic, replaced on a FeeEscalationSchedule
+)
+
+// DynamicFeePolicy prices contract transactions as EIP-1559 type-2 transactions
+type DynamicFeePolicy struct {
+	PriorityFee       *big.Int // maxPriorityFeePerGas (nil = eth_maxPriorityFeePerGas suggestion)
+	BaseFeeMultiplier int      // maxFeePerGas = latest base fee x multiplier + tip (0 = 2)
+}
+
+// Validate checks that the policy can price a transaction
+func (p *DynamicFeePolicy) Validate() error {
+	if p.PriorityFee != nil && p.PriorityFee.Sign() < 0 {
+		return errors.New("priority fee cannot be negative")
+	}
+	if p.BaseFeeMultiplier < 0 {
+		return errors.New("base fee multiplier cannot be negative")
+	}
+	return nil
+}
+
+// fees returns the type-2 pricing for a block with the given base fee
+func (p *DynamicFeePolicy) fees(baseFee, tip *big.Int) txFees {
+	multiplier := p.BaseFeeMultiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(int64(multiplier)))
+	feeCap.Add(feeCap, tip)
+	return txFees{
+		GasFeeCap: feeCap,
+		GasTipCap: new(big.Int).Set(tip),
+		BaseFee:   baseFee,
+	}
+}
+
+// SetDynamicFees makes contract transactions EIP-1559 type-2 transactions priced by
+// policy. Chains without a base fee keep legacy pricing; nil restores legacy pricing.
+func (c *Client) SetDynamicFees(policy *DynamicFeePolicy) error {
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+	}
+	c.dynamicFees = policy
+	return nil
+}
+
+// txFees prices one contract transaction: GasPrice for a legacy transaction, or
+// GasFeeCap and GasTipCap for a dynamic fee transaction
+type txFees struct {
+	GasPrice  *big.Int // Legacy gas price (nil for dynamic fee transactions)
+	GasFeeCap *big.Int // maxFeePerGas
+	GasTipCap *big.Int // maxPriorityFeePerGas
+	BaseFee   *big.Int // Base fee the dynamic fees were derived from
+}
+
+// dynamic reports whether the fees are for a type-2 transaction
+func (f txFees) dynamic() bool {
+	return f.GasFeeCap != nil
+}
+
+// strategy returns the fee strategy name for the legacy or dynamic variant
+func (f txFees) strategy(legacy, dynamic string) string {
+	if f.dynamic() {
+		return dynamic
+	}
+	return legacy
+}
+
+// maxPrice is the most the transaction can pay per gas
+func (f txFees) maxPrice() *big.Int {
+	if f.dynamic() {
+		return f.GasFeeCap
+	}
+	return f.GasPrice
+}
+
+// expectedPrice is the price per gas expected to be paid if mined at the current base fee
+func (f txFees) expectedPrice() *big.Int {
+	if !f.dynamic() {
+		return f.GasPrice
+	}
+	expected := new(big.Int).Add(f.BaseFee, f.GasTipCap)
+	if expected.Cmp(f.GasFeeCap) > 0 {
+		return new(big.Int).Set(f.GasFeeCap)
+	}
+	return expected
+}
+
+// scaled returns the fees raised by percent
+func (f txFees) scaled(percent int) txFees {
+	scale := func(v *big.Int) *big.Int {
+		if v == nil {
+			return nil
+		}
+		scaled := new(big.Int).Mul(v, big.NewInt(int64(100+percent)))
+		return scaled.Div(scaled, big.NewInt(100))
+	}
+	return txFees{
+		GasPrice:  scale(f.GasPrice),
+		GasFeeCap: scale(f.GasFeeCap),
+		GasTipCap: scale(f.GasTipCap),
+		BaseFee:   f.BaseFee,
+	}
+}
+
+// capped returns the fees limited to ceiling per gas (nil = no ceiling)
+func (f txFees) capped(ceiling *big.Int) txFees {
+	if ceiling == nil {
+		return f
+	}
+	limit := func(v *big.Int) *big.Int {
+		if v != nil && v.Cmp(ceiling) > 0 {
+			return new(big.Int).Set(ceiling)
+		}
+		return v
+	}
+	return txFees{
+		GasPrice:  limit(f.GasPrice),
+		GasFeeCap: limit(f.GasFeeCap),
+		GasTipCap: limit(f.GasTipCap),
+		BaseFee:   f.BaseFee,
+	}
+}
+
+// suggestFees prices a new contract transaction: dynamic fees from the latest base fee
+// when a DynamicFeePolicy is set and the chain supports EIP-1559, otherwise the
+// suggested legacy gas price with a 5 Gwei floor
+func (c *Client) suggestFees(ctx context.Context) (txFees, error) {
+	if c.dynamicFees != nil {
+		var header *types.Header
+		err := c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+			header, err = client.HeaderByNumber(ctx, nil)
+			return err
+		})
+		if err != nil {
+			return txFees{}, fmt.Errorf("failed to get latest block header: %w", err)
+		}
+		if header.BaseFee != nil {
+			tip := c.dynamicFees.PriorityFee
+			if tip == nil {
+				err = c.withReconnect(ctx, func(client *ethclient.Client) (err error) {
+					tip, err = client.SuggestGasTipCap(ctx)
+					return err
+				})
+				if err != nil {
+					return txFees{}, fmt.Errorf("failed to get priority fee: %w", err)
+				}
+			}
+			return c.dynamicFees.fees(header.BaseFee, tip), nil
+		}
+		// No base fee: the chain does not support EIP-1559
+	}
+
+	gasPrice, err := c.GetGasPrice(ctx)
+	if err != nil {
+		return txFees{}, err
+	}
+	// Enforce minimum 5 Gwei to ensure transactions get included
+	if minGasPrice := big.NewInt(5 * 1e9); gasPrice.Cmp(minGasPrice) < 0 {
+		gasPrice = minGasPrice
+	}
+	return txFees{GasPrice: gasPrice}, nil
+}
+
+// signContractTx builds and signs a legacy or dynamic fee transaction calling contractAddr
+func (c *Client) signContractTx(privateKey *ecdsa.PrivateKey, nonce uint64, contractAddr common.Address, gasLimit uint64, fees txFees, callData []byte) (*types.Transaction, error) {
+	var tx *types.Transaction
+	if fees.dynamic() {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   c.chainID,
+			Nonce:     nonce,
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       gasLimit,
+			To:        &contractAddr,
+			Value:     big.NewInt(0),
+			Data:      callData,
+		})
+	} else {
+		tx = types.NewTransaction(nonce, contractAddr, big.NewInt(0), gasLimit, fees.GasPrice, callData)
+	}
+
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(c.chainID), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return signedTx, nil
+}
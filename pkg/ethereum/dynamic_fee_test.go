@@ -0,0 +1,112 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9))
+}
+
+func TestDynamicFeePolicy_Fees(t *testing.T) {
+	policy := &DynamicFeePolicy{}
+	fees := policy.fees(gwei(30), gwei(2))
+
+	if !fees.dynamic() {
+		t.Fatal("expected dynamic fees")
+	}
+	if fees.GasFeeCap.Cmp(gwei(62)) != 0 || fees.GasTipCap.Cmp(gwei(2)) != 0 {
+		t.Errorf("fee cap %s tip %s, want 62 gwei and 2 gwei", fees.GasFeeCap, fees.GasTipCap)
+	}
+	if fees.expectedPrice().Cmp(gwei(32)) != 0 {
+		t.Errorf("expected price %s, want 32 gwei", fees.expectedPrice())
+	}
+	if fees.strategy(FeeStrategySuggested, FeeStrategyDynamic) != FeeStrategyDynamic {
+		t.Error("expected dynamic fee strategy")
+	}
+
+	policy.BaseFeeMultiplier = 3
+	if fees := policy.fees(gwei(30), gwei(2)); fees.GasFeeCap.Cmp(gwei(92)) != 0 {
+		t.Errorf("fee cap %s, want 92 gwei", fees.GasFeeCap)
+	}
+
+	if err := (&DynamicFeePolicy{PriorityFee: big.NewInt(-1)}).Validate(); err == nil {
+		t.Error("expected error for negative priority fee")
+	}
+}
+
+func TestTxFees_ScaledAndCapped(t *testing.T) {
+	fees := txFees{GasFeeCap: gwei(100), GasTipCap: gwei(10), BaseFee: gwei(45)}
+
+	scaled := fees.scaled(20)
+	if scaled.GasFeeCap.Cmp(gwei(120)) != 0 || scaled.GasTipCap.Cmp(gwei(12)) != 0 || scaled.GasPrice != nil {
+		t.Errorf("unexpected scaled fees: %+v", scaled)
+	}
+
+	capped := scaled.capped(gwei(110))
+	if capped.GasFeeCap.Cmp(gwei(110)) != 0 || capped.GasTipCap.Cmp(gwei(12)) != 0 {
+		t.Errorf("unexpected capped fees: %+v", capped)
+	}
+
+	legacy := txFees{GasPrice: gwei(10)}.scaled(40)
+	if legacy.dynamic() || legacy.GasPrice.Cmp(gwei(14)) != 0 {
+		t.Errorf("unexpected scaled legacy fees: %+v", legacy)
+	}
+}
+
+func TestFeeEscalationSchedule_NextFees(t *testing.T) {
+	schedule := &FeeEscalationSchedule{Interval: 1, BumpPercent: 25, MaxGasPrice: gwei(120)}
+
+	next, ok := schedule.nextFees(txFees{GasFeeCap: gwei(80), GasTipCap: gwei(4)})
+	if !ok || next.GasFeeCap.Cmp(gwei(100)) != 0 || next.GasTipCap.Cmp(gwei(5)) != 0 {
+		t.Errorf("unexpected replacement: ok=%v %+v", ok, next)
+	}
+
+	// The ceiling leaves the fee cap less than the 10% replacement bump
+	if _, ok := schedule.nextFees(txFees{GasFeeCap: gwei(115), GasTipCap: gwei(4)}); ok {
+		t.Error("expected no replacement at the ceiling")
+	}
+
+	next, ok = schedule.nextFees(txFees{GasPrice: gwei(40)})
+	if !ok || next.dynamic() || next.GasPrice.Cmp(gwei(50)) != 0 {
+		t.Errorf("unexpected legacy replacement: ok=%v %+v", ok, next)
+	}
+}
+
+func TestSignContractTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{chainID: big.NewInt(11155111)}
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	dynamicTx, err := c.signContractTx(key, 7, to, 300000, txFees{GasFeeCap: gwei(60), GasTipCap: gwei(2)}, []byte{1})
+	if err != nil {
+		t.Fatalf("signContractTx failed: %v", err)
+	}
+	if dynamicTx.Type() != types.DynamicFeeTxType || dynamicTx.GasFeeCap().Cmp(gwei(60)) != 0 || dynamicTx.GasTipCap().Cmp(gwei(2)) != 0 {
+		t.Errorf("unexpected dynamic fee transaction: type %d", dynamicTx.Type())
+	}
+
+	legacyTx, err := c.signContractTx(key, 7, to, 300000, txFees{GasPrice: gwei(20)}, []byte{1})
+	if err != nil {
+		t.Fatalf("signContractTx failed: %v", err)
+	}
+	if legacyTx.Type() != types.LegacyTxType || legacyTx.GasPrice().Cmp(gwei(20)) != 0 {
+		t.Errorf("unexpected legacy transaction: type %d", legacyTx.Type())
+	}
+
+	signer := types.LatestSignerForChainID(c.chainID)
+	for _, tx := range []*types.Transaction{dynamicTx, legacyTx} {
+		from, err := types.Sender(signer, tx)
+		if err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Errorf("type %d transaction sender %s, %v", tx.Type(), from.Hex(), err)
+		}
+	}
+}
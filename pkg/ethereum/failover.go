@@ -0,0 +1,96 @@
+package ethereum
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+)
+
+// primaryCheckTimeout bounds the dial that tries to return to the primary endpoint
+const primaryCheckTimeout = 5 * time.Second
+
+// ActiveEndpoint returns the scheme and host of the endpoint in use
+func (c *Client) ActiveEndpoint() string {
+	return endpointLabel(c.activeURL())
+}
+
+// activeURL returns the endpoint in use
+func (c *Client) activeURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.urls[c.active]
+}
+
+// recordFailure counts an endpoint error and rotates to the next endpoint once the
+// active one has failed FailoverThreshold times in a row. The caller holds reconnectMu.
+func (c *Client) recordFailure(policy ReconnectPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures++
+	if c.failures < policy.FailoverThreshold || len(c.urls) < 2 {
+		return
+	}
+
+	from := c.active
+	c.active = (c.active + 1) % len(c.urls)
+	c.failures = 0
+	if c.active != 0 {
+		c.leftPrimaryAt = time.Now()
+	}
+	log.Printf("⚠️ [ethereum] Endpoint %s failed %d times in a row, switching to %s",
+		endpointLabel(c.urls[from]), policy.FailoverThreshold, endpointLabel(c.urls[c.active]))
+}
+
+// maybeReturnToPrimary switches back to the primary endpoint once the client has been
+// on a fallback for PrimaryRetryInterval and the primary answers again. It never waits
+// for a reconnect in progress.
+func (c *Client) maybeReturnToPrimary(ctx context.Context) {
+	if !c.primaryRetryDue() || !c.reconnectMu.TryLock() {
+		return
+	}
+	defer c.reconnectMu.Unlock()
+	if !c.primaryRetryDue() {
+		return // Another call already switched
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, primaryCheckTimeout)
+	defer cancel()
+	client, err := c.dialAndCheck(checkCtx, c.urls[0])
+
+	c.mu.Lock()
+	if err != nil {
+		c.leftPrimaryAt = time.Now() // Try again after another interval
+		c.mu.Unlock()
+		log.Printf("⚠️ [ethereum] Primary endpoint %s still unavailable, staying on %s: %v",
+			endpointLabel(c.urls[0]), endpointLabel(c.urls[c.active]), err)
+		return
+	}
+	old, from := c.client, c.urls[c.active]
+	c.client = client
+	c.active = 0
+	c.failures = 0
+	c.mu.Unlock()
+	old.Close()
+
+	c.setState(ConnectionConnected)
+	log.Printf("✅ [ethereum] Primary endpoint %s is back, switched from %s", endpointLabel(c.urls[0]), endpointLabel(from))
+}
+
+// primaryRetryDue reports whether the client is on a fallback long enough to try the primary
+func (c *Client) primaryRetryDue() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.active != 0 && time.Since(c.leftPrimaryAt) >= c.reconnectPolicy.PrimaryRetryInterval
+}
+
+// endpointLabel reduces an endpoint URL to scheme and host for logs; RPC URLs
+// routinely carry API keys in the path or query
+func endpointLabel(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "[endpoint]"
+	}
+	return u.Scheme + "://" + u.Host
+}
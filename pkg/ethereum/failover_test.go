@@ -0,0 +1,68 @@
+package ethereum
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_FailsOverAndReturnsToPrimary(t *testing.T) {
+	primary, primaryDrops := flakyEndpoint(t, 1000)
+	fallback, _ := flakyEndpoint(t, 0)
+
+	client, err := NewClientWithFallbacks([]string{primary.URL, fallback.URL}, 1)
+	if err != nil {
+		t.Fatalf("NewClientWithFallbacks: %v", err)
+	}
+	defer client.Close()
+
+	policy := testReconnectPolicy(5)
+	policy.FailoverThreshold = 2
+	policy.PrimaryRetryInterval = 20 * time.Millisecond
+	if err := client.SetReconnectPolicy(policy); err != nil {
+		t.Fatalf("SetReconnectPolicy: %v", err)
+	}
+
+	// The failed call and one failed re-dial reach the threshold; the fallback answers
+	if _, err := client.GetGasPrice(context.Background()); err != nil {
+		t.Fatalf("expected the call to succeed on the fallback, got %v", err)
+	}
+	if client.ActiveEndpoint() != fallback.URL {
+		t.Fatalf("active endpoint %s, want the fallback %s", client.ActiveEndpoint(), fallback.URL)
+	}
+
+	// The primary is not retried before the interval, nor while it is still down
+	time.Sleep(policy.PrimaryRetryInterval)
+	if _, err := client.GetGasPrice(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.ActiveEndpoint() != fallback.URL {
+		t.Errorf("expected to stay on the fallback while the primary is down")
+	}
+
+	primaryDrops.Store(0)
+	time.Sleep(policy.PrimaryRetryInterval)
+	if _, err := client.GetGasPrice(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.ActiveEndpoint() != primary.URL {
+		t.Errorf("active endpoint %s, want the primary %s", client.ActiveEndpoint(), primary.URL)
+	}
+}
+
+func TestNewClientWithFallbacks_NoEndpoint(t *testing.T) {
+	if _, err := NewClientWithFallbacks(nil, 1); err == nil {
+		t.Error("expected an error without endpoints")
+	}
+}
+
+func TestEndpointLabel(t *testing.T) {
+	label := endpointLabel("https://eth-sepolia.g.alchemy.com/v2/SECRETKEY?x=1")
+	if label != "https://eth-sepolia.g.alchemy.com" || strings.Contains(label, "SECRET") {
+		t.Errorf("endpointLabel leaked the path: %s", label)
+	}
+	if endpointLabel("not a url") != "[endpoint]" {
+		t.Errorf("expected a placeholder for an unparsable URL")
+	}
+}
@@ -0,0 +1,220 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// FeeStrategyScheduled prices a transaction as suggested and replaces it at rising gas
+// prices while it stays unconfirmed, following a FeeEscalationSchedule (see also
+// FeeStrategyDynamicScheduled for type-2 transactions)
+const FeeStrategyScheduled = "legacy_scheduled"
+
+// minReplacementBumpPercent is the smallest gas price increase nodes accept for a
+// replacement transaction
+const minReplacementBumpPercent = 10
+
+// receiptPollInterval is how often submitted transactions are checked for a receipt
+const receiptPollInterval = 2 * time.Second
+
+// FeeEscalationSchedule replaces an unconfirmed transaction at progressively higher gas prices
+type FeeEscalationSchedule struct {
+	Interval        time.Duration // Time to wait for confirmation before each replacement
+	BumpPercent     int           // Gas price increase per replacement (at least 10)
+	MaxReplacements int           // Replacements after the initial submission
+	MaxGasPrice     *big.Int      // Gas price (type-2: fee cap) ceiling (nil = none)
+}
+
+// Validate checks that the schedule can be followed
+func (s *FeeEscalationSchedule) Validate() error {
+	if s.Interval <= 0 {
+		return errors.New("fee escalation interval must be positive")
+	}
+	if s.BumpPercent < minReplacementBumpPercent {
+		return fmt.Errorf("fee escalation bump must be at least %d%%", minReplacementBumpPercent)
+	}
+	if s.MaxReplacements < 0 {
+		return errors.New("fee escalation max replacements cannot be negative")
+	}
+	if s.MaxGasPrice != nil && s.MaxGasPrice.Sign() <= 0 {
+		return errors.New("fee escalation gas price ceiling must be positive")
+	}
+	return nil
+}
+
+// nextPrice returns the replacement gas price after current, or false when the
+// ceiling leaves no room for a replacement nodes would accept
+func (s *FeeEscalationSchedule) nextPrice(current *big.Int) (*big.Int, bool) {
+	next := new(big.Int).Mul(current, big.NewInt(int64(100+s.BumpPercent)))
+	next.Div(next, big.NewInt(100))
+	if s.MaxGasPrice != nil && next.Cmp(s.MaxGasPrice) > 0 {
+		next.Set(s.MaxGasPrice)
+	}
+	minimum := new(big.Int).Mul(current, big.NewInt(100+minReplacementBumpPercent))
+	minimum.Div(minimum, big.NewInt(100))
+	return next, next.Cmp(minimum) >= 0
+}
+
+// nextFees returns the replacement fees after current. Dynamic fee replacements must
+// raise both the fee cap and the tip by the minimum bump.
+func (s *FeeEscalationSchedule) nextFees(current txFees) (txFees, bool) {
+	if !current.dynamic() {
+		price, ok := s.nextPrice(current.GasPrice)
+		return txFees{GasPrice: price}, ok
+	}
+	feeCap, feeCapOK := s.nextPrice(current.GasFeeCap)
+	tip, tipOK := s.nextPrice(current.GasTipCap)
+	return txFees{GasFeeCap: feeCap, GasTipCap: tip, BaseFee: current.BaseFee}, feeCapOK && tipOK
+}
+
+// FeeEscalationStep is one submission of an escalated transaction
+type FeeEscalationStep struct {
+	Attempt     int       `json:"attempt"` // 1 = initial submission
+	TxHash      string    `json:"tx_hash"`
+	GasPrice    *big.Int  `json:"gas_price"`                          // Type-2: maxFeePerGas
+	GasTipCap   *big.Int  `json:"max_priority_fee_per_gas,omitempty"` // Type-2 only
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// SendContractTransactionEscalating sends a contract transaction and, while it stays
+// unconfirmed, replaces it (same nonce) at higher gas prices on the given schedule.
+// Once the schedule is exhausted or the ceiling is reached it waits for whichever
+// submission is mined, until ctx is done. Every submission is recorded in the result.
+func (c *Client) SendContractTransactionEscalating(ctx context.Context, contractAddr common.Address, abiString string, privateKeyHex string, methodName string, gasLimit uint64, schedule *FeeEscalationSchedule, params ...interface{}) (*ContractCallResult, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	callData, err := contractABI.Pack(methodName, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack method call: %w", err)
+	}
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	fromAddress := crypto.PubkeyToAddress(*privateKey.Public().(*ecdsa.PublicKey))
+
+	nonce, err := c.GetNonce(ctx, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start at the suggested price (legacy with the usual 5 Gwei floor, or EIP-1559
+	// fees), within the ceiling
+	fees, err := c.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fees = fees.capped(schedule.MaxGasPrice)
+
+	var steps []FeeEscalationStep
+	sent := make(map[common.Hash]txFees)
+	escalating, submit := true, true
+
+	for {
+		if submit {
+			signedTx, err := c.signContractTx(privateKey, nonce, contractAddr, gasLimit, fees, callData)
+			if err != nil {
+				return nil, err
+			}
+			if err := c.sendTransaction(ctx, signedTx); err != nil {
+				if len(steps) == 0 {
+					return nil, fmt.Errorf("failed to send transaction: %w", err)
+				}
+				// The replacement was rejected (e.g. an earlier submission was just
+				// mined): stop escalating and wait for the submissions already made
+				escalating = false
+			} else {
+				steps = append(steps, FeeEscalationStep{
+					Attempt:     len(steps) + 1,
+					TxHash:      signedTx.Hash().Hex(),
+					GasPrice:    new(big.Int).Set(fees.maxPrice()),
+					GasTipCap:   fees.GasTipCap,
+					SubmittedAt: time.Now(),
+				})
+				sent[signedTx.Hash()] = fees
+			}
+		}
+
+		wait := schedule.Interval
+		if !escalating {
+			wait = 0 // Until ctx is done
+		}
+		receipt, err := c.waitForAnyReceipt(ctx, sent, wait)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction receipt after %d submissions: %w", len(steps), err)
+		}
+		if receipt != nil {
+			paid := sent[receipt.TxHash]
+			result := &ContractCallResult{
+				TransactionHash: receipt.TxHash.Hex(),
+				BlockNumber:     receipt.BlockNumber.Uint64(),
+				BlockHash:       receipt.BlockHash.Hex(),
+				GasUsed:         receipt.GasUsed,
+				Success:         receipt.Status == types.ReceiptStatusSuccessful,
+				Timestamp:       time.Now(),
+				FeeStrategy:     paid.strategy(FeeStrategyScheduled, FeeStrategyDynamicScheduled),
+				Attempts:        len(steps),
+				Escalation:      steps,
+			}
+			c.setFees(ctx, result, paid, receipt)
+			return result, nil
+		}
+
+		// Unconfirmed within the interval: replace at the next price if the schedule allows
+		next, ok := schedule.nextFees(fees)
+		if !ok || len(steps) > schedule.MaxReplacements {
+			escalating, submit = false, false
+			continue
+		}
+		fees = next
+	}
+}
+
+// waitForAnyReceipt polls for a receipt of any of the given transactions. It returns
+// nil without error when wait elapses first; wait 0 polls until ctx is done.
+func (c *Client) waitForAnyReceipt(ctx context.Context, txs map[common.Hash]txFees, wait time.Duration) (*types.Receipt, error) {
+	var deadline <-chan time.Time
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for hash := range txs {
+			receipt, err := reconnectingBackend{c}.TransactionReceipt(ctx, hash)
+			if err == nil {
+				return receipt, nil
+			}
+			if !errors.Is(err, ethereum.NotFound) {
+				return nil, err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline:
+			return nil, nil
+		case <-ticker.C:
+		}
+	}
+}
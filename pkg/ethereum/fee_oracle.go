@@ -0,0 +1,252 @@
+// Copyright 2025 Certen Protocol
+//
+// Fee Oracle - Live gas price and ETH/USD price for anchor cost figures
+//
+// A FeeOracle quotes the current gas price and ETH/USD price so that cost endpoints can
+// turn estimated gas into wei and USD. The default oracle reads the gas price from the
+// RPC node and ETH/USD from a PriceFeed: a Chainlink aggregator contract or an HTTP JSON
+// endpoint. Quotes are cached for a TTL so the feed is not queried on every request;
+// when a refresh fails the error is returned and callers fall back to static pricing.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FeeQuote is a gas price and ETH/USD price observed together
+type FeeQuote struct {
+	GasPrice *big.Int  `json:"gas_price_wei"`
+	EthUSD   float64   `json:"eth_usd"`
+	Source   string    `json:"source"`
+	QuotedAt time.Time `json:"quoted_at"`
+}
+
+// CostWei returns the cost of gas at the quoted gas price
+func (q *FeeQuote) CostWei(gas uint64) *big.Int {
+	return new(big.Int).Mul(q.GasPrice, new(big.Int).SetUint64(gas))
+}
+
+// WeiToUSD converts a wei amount to USD at the quoted ETH/USD price
+func (q *FeeQuote) WeiToUSD(wei *big.Int) float64 {
+	eth, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18)).Float64()
+	return eth * q.EthUSD
+}
+
+// FeeOracle quotes the live prices needed to convert anchor gas to USD
+type FeeOracle interface {
+	Quote(ctx context.Context) (*FeeQuote, error)
+}
+
+// PriceFeed returns the current ETH/USD price
+type PriceFeed interface {
+	EthUSD(ctx context.Context) (float64, error)
+	Name() string
+}
+
+// CachedFeeOracle is the default FeeOracle: gas price from the node, ETH/USD from a feed
+type CachedFeeOracle struct {
+	mu sync.Mutex
+
+	gasPrice func(ctx context.Context) (*big.Int, error)
+	feed     PriceFeed
+	ttl      time.Duration
+
+	cached *FeeQuote
+}
+
+// NewCachedFeeOracle creates a fee oracle caching quotes for ttl (non-positive = no caching)
+func NewCachedFeeOracle(gasPrice func(ctx context.Context) (*big.Int, error), feed PriceFeed, ttl time.Duration) (*CachedFeeOracle, error) {
+	if gasPrice == nil {
+		return nil, fmt.Errorf("gas price source is required")
+	}
+	if feed == nil {
+		return nil, fmt.Errorf("price feed is required")
+	}
+	return &CachedFeeOracle{gasPrice: gasPrice, feed: feed, ttl: ttl}, nil
+}
+
+// Quote returns the cached quote while it is fresh, otherwise queries the node and feed
+func (o *CachedFeeOracle) Quote(ctx context.Context) (*FeeQuote, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.cached != nil && time.Since(o.cached.QuotedAt) < o.ttl {
+		return o.cached, nil
+	}
+
+	gasPrice, err := o.gasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ethUSD, err := o.feed.EthUSD(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s price feed: %w", o.feed.Name(), err)
+	}
+	if ethUSD <= 0 {
+		return nil, fmt.Errorf("%s price feed returned non-positive ETH/USD price %v", o.feed.Name(), ethUSD)
+	}
+
+	o.cached = &FeeQuote{
+		GasPrice: gasPrice,
+		EthUSD:   ethUSD,
+		Source:   o.feed.Name(),
+		QuotedAt: time.Now(),
+	}
+	return o.cached, nil
+}
+
+// chainlinkAggregatorABI is the subset of AggregatorV3Interface read by ChainlinkPriceFeed
+const chainlinkAggregatorABI = `[
+	{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"latestRoundData","outputs":[
+		{"internalType":"uint80","name":"roundId","type":"uint80"},
+		{"internalType":"int256","name":"answer","type":"int256"},
+		{"internalType":"uint256","name":"startedAt","type":"uint256"},
+		{"internalType":"uint256","name":"updatedAt","type":"uint256"},
+		{"internalType":"uint80","name":"answeredInRound","type":"uint80"}
+	],"stateMutability":"view","type":"function"}
+]`
+
+// ChainlinkPriceFeed reads ETH/USD from a Chainlink aggregator contract
+type ChainlinkPriceFeed struct {
+	client     *Client
+	aggregator common.Address
+}
+
+// NewChainlinkPriceFeed creates a price feed reading the aggregator at address
+func NewChainlinkPriceFeed(client *Client, address string) (*ChainlinkPriceFeed, error) {
+	if client == nil {
+		return nil, fmt.Errorf("ethereum client is required")
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: %q is not a hex address", ErrInvalidContractAddress, address)
+	}
+	return &ChainlinkPriceFeed{client: client, aggregator: common.HexToAddress(address)}, nil
+}
+
+// Name implements PriceFeed
+func (f *ChainlinkPriceFeed) Name() string {
+	return "chainlink"
+}
+
+// EthUSD implements PriceFeed
+func (f *ChainlinkPriceFeed) EthUSD(ctx context.Context) (float64, error) {
+	decimalsOut, err := f.client.CallContract(ctx, f.aggregator, chainlinkAggregatorABI, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	roundOut, err := f.client.CallContract(ctx, f.aggregator, chainlinkAggregatorABI, "latestRoundData")
+	if err != nil {
+		return 0, err
+	}
+	if len(decimalsOut) != 1 || len(roundOut) != 5 {
+		return 0, fmt.Errorf("unexpected aggregator response")
+	}
+	decimals, ok := decimalsOut[0].(uint8)
+	if !ok {
+		return 0, fmt.Errorf("unexpected decimals type %T", decimalsOut[0])
+	}
+	answer, ok := roundOut[1].(*big.Int)
+	if !ok {
+		return 0, fmt.Errorf("unexpected answer type %T", roundOut[1])
+	}
+	return scaleAnswer(answer, decimals), nil
+}
+
+// scaleAnswer converts a fixed-point aggregator answer to a float
+func scaleAnswer(answer *big.Int, decimals uint8) float64 {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(answer), scale).Float64()
+	return price
+}
+
+// HTTPPriceFeed reads ETH/USD from a JSON HTTP endpoint
+type HTTPPriceFeed struct {
+	url    string
+	path   []string
+	client *http.Client
+}
+
+// NewHTTPPriceFeed creates a price feed reading the number (or numeric string) at the
+// dot-separated pricePath of the JSON document served at url, e.g. "ethereum.usd" for
+// CoinGecko's simple price endpoint or "data.amount" for Coinbase's spot price
+func NewHTTPPriceFeed(url, pricePath string, timeout time.Duration) (*HTTPPriceFeed, error) {
+	if url == "" {
+		return nil, fmt.Errorf("price feed URL is required")
+	}
+	if pricePath == "" {
+		return nil, fmt.Errorf("price path is required")
+	}
+	return &HTTPPriceFeed{
+		url:    url,
+		path:   strings.Split(pricePath, "."),
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name implements PriceFeed
+func (f *HTTPPriceFeed) Name() string {
+	return "http"
+}
+
+// EthUSD implements PriceFeed
+func (f *HTTPPriceFeed) EthUSD(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var doc interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return 0, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return priceAtPath(doc, f.path)
+}
+
+// priceAtPath walks a decoded JSON document and returns the price found at path
+func priceAtPath(doc interface{}, path []string) (float64, error) {
+	value := doc
+	for _, key := range path {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("no object at %q", key)
+		}
+		if value, ok = obj[key]; !ok {
+			return 0, fmt.Errorf("missing field %q", key)
+		}
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		price, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid price %q", v)
+		}
+		return price, nil
+	default:
+		return 0, fmt.Errorf("price at %s is %T, not a number", strings.Join(path, "."), value)
+	}
+}
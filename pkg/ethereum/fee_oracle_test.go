@@ -0,0 +1,149 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Fee Oracle
+// Tests quote caching, price feed parsing and wei/USD conversion
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakePriceFeed struct {
+	price float64
+	err   error
+	calls int
+}
+
+func (f *fakePriceFeed) Name() string { return "fake" }
+func (f *fakePriceFeed) EthUSD(ctx context.Context) (float64, error) {
+	f.calls++
+	return f.price, f.err
+}
+
+func fixedGasPrice(gwei int64) func(ctx context.Context) (*big.Int, error) {
+	return func(ctx context.Context) (*big.Int, error) {
+		return new(big.Int).Mul(big.NewInt(gwei), big.NewInt(1e9)), nil
+	}
+}
+
+func TestFeeQuote_Conversion(t *testing.T) {
+	q := &FeeQuote{GasPrice: big.NewInt(20e9), EthUSD: 2000}
+
+	wei := q.CostWei(500000) // 0.01 ETH
+	if wei.String() != "10000000000000000" {
+		t.Errorf("CostWei = %s, want 10000000000000000", wei)
+	}
+	if usd := q.WeiToUSD(wei); usd < 19.999 || usd > 20.001 {
+		t.Errorf("WeiToUSD = %v, want 20", usd)
+	}
+}
+
+func TestCachedFeeOracle_CachesWithinTTL(t *testing.T) {
+	feed := &fakePriceFeed{price: 3000}
+	oracle, err := NewCachedFeeOracle(fixedGasPrice(10), feed, time.Hour)
+	if err != nil {
+		t.Fatalf("NewCachedFeeOracle failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		quote, err := oracle.Quote(context.Background())
+		if err != nil {
+			t.Fatalf("Quote failed: %v", err)
+		}
+		if quote.EthUSD != 3000 || quote.Source != "fake" {
+			t.Errorf("unexpected quote: %+v", quote)
+		}
+	}
+	if feed.calls != 1 {
+		t.Errorf("expected 1 feed call within TTL, got %d", feed.calls)
+	}
+
+	// Expire the cached quote
+	oracle.cached.QuotedAt = time.Now().Add(-2 * time.Hour)
+	if _, err := oracle.Quote(context.Background()); err != nil {
+		t.Fatalf("Quote failed: %v", err)
+	}
+	if feed.calls != 2 {
+		t.Errorf("expected refresh after TTL, got %d feed calls", feed.calls)
+	}
+}
+
+func TestCachedFeeOracle_Errors(t *testing.T) {
+	if _, err := NewCachedFeeOracle(nil, &fakePriceFeed{}, 0); err == nil {
+		t.Error("expected error without gas price source")
+	}
+
+	feed := &fakePriceFeed{err: errors.New("feed down")}
+	oracle, _ := NewCachedFeeOracle(fixedGasPrice(10), feed, 0)
+	if _, err := oracle.Quote(context.Background()); err == nil {
+		t.Error("expected feed error")
+	}
+
+	feed.err, feed.price = nil, 0
+	if _, err := oracle.Quote(context.Background()); err == nil {
+		t.Error("expected error for non-positive price")
+	}
+}
+
+func TestScaleAnswer(t *testing.T) {
+	// Chainlink ETH/USD uses 8 decimals
+	if got := scaleAnswer(big.NewInt(345612345678), 8); got < 3456.1234 || got > 3456.1235 {
+		t.Errorf("scaleAnswer = %v, want 3456.12345678", got)
+	}
+}
+
+func TestPriceAtPath(t *testing.T) {
+	doc := map[string]interface{}{
+		"ethereum": map[string]interface{}{"usd": 2500.5},
+		"data":     map[string]interface{}{"amount": "2499.75"},
+	}
+	tests := []struct {
+		path    []string
+		want    float64
+		wantErr bool
+	}{
+		{[]string{"ethereum", "usd"}, 2500.5, false},
+		{[]string{"data", "amount"}, 2499.75, false},
+		{[]string{"ethereum", "eur"}, 0, true},
+		{[]string{"ethereum"}, 0, true},
+		{[]string{"ethereum", "usd", "x"}, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := priceAtPath(doc, tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("priceAtPath(%v) = %v, %v", tt.path, got, err)
+		}
+	}
+}
+
+func TestHTTPPriceFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"ethereum":{"usd":2750.25}}`)
+	}))
+	defer srv.Close()
+
+	feed, err := NewHTTPPriceFeed(srv.URL, "ethereum.usd", time.Second)
+	if err != nil {
+		t.Fatalf("NewHTTPPriceFeed failed: %v", err)
+	}
+	if price, err := feed.EthUSD(context.Background()); err != nil || price != 2750.25 {
+		t.Errorf("EthUSD = %v, %v; want 2750.25", price, err)
+	}
+
+	down, _ := NewHTTPPriceFeed(srv.URL+"/down", "ethereum.usd", time.Second)
+	if _, err := down.EthUSD(context.Background()); err == nil {
+		t.Error("expected error for non-200 response")
+	}
+}
@@ -0,0 +1,272 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ConnectionState is the state of the client's connection to the Ethereum endpoint
+type ConnectionState string
+
+// Connection states, as reported by the health endpoint
+const (
+	ConnectionConnected    ConnectionState = "connected"
+	ConnectionReconnecting ConnectionState = "reconnecting"
+	ConnectionDisconnected ConnectionState = "disconnected" // Reconnecting gave up; the next call tries again
+)
+
+// ReconnectPolicy controls how the client re-dials the endpoint after a connection
+// error, and when it fails over between endpoints
+type ReconnectPolicy struct {
+	InitialDelay time.Duration // Wait before the second dial; doubled after each failure
+	MaxDelay     time.Duration // Upper bound for the wait between dials
+	MaxAttempts  int           // Dials per reconnect before the call fails
+
+	FailoverThreshold    int           // Consecutive endpoint errors before rotating to the next endpoint
+	PrimaryRetryInterval time.Duration // Time on a fallback before trying the primary again
+}
+
+// DefaultReconnectPolicy retries for roughly a minute before failing the call
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialDelay:         500 * time.Millisecond,
+		MaxDelay:             30 * time.Second,
+		MaxAttempts:          8,
+		FailoverThreshold:    3,
+		PrimaryRetryInterval: 5 * time.Minute,
+	}
+}
+
+// Validate checks that the policy makes progress
+func (p ReconnectPolicy) Validate() error {
+	if p.InitialDelay <= 0 {
+		return fmt.Errorf("reconnect initial delay must be positive")
+	}
+	if p.MaxDelay < p.InitialDelay {
+		return fmt.Errorf("reconnect max delay must be at least the initial delay")
+	}
+	if p.MaxAttempts < 1 {
+		return fmt.Errorf("reconnect max attempts must be at least 1")
+	}
+	if p.FailoverThreshold < 1 {
+		return fmt.Errorf("failover threshold must be at least 1")
+	}
+	if p.PrimaryRetryInterval <= 0 {
+		return fmt.Errorf("primary retry interval must be positive")
+	}
+	return nil
+}
+
+// SetReconnectPolicy replaces the backoff used when re-dialing the endpoint
+func (c *Client) SetReconnectPolicy(policy ReconnectPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reconnectPolicy = policy
+	return nil
+}
+
+// SetConnectionStateHandler registers a function called on every connection state
+// change, e.g. to update the health status
+func (c *Client) SetConnectionStateHandler(handler func(ConnectionState)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onStateChange = handler
+}
+
+// ConnectionState returns the current connection state
+func (c *Client) ConnectionState() ConnectionState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// rpc returns the current connection
+func (c *Client) rpc() *ethclient.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.client
+}
+
+// setState records a connection state change and notifies the handler
+func (c *Client) setState(state ConnectionState) {
+	c.mu.Lock()
+	if c.state == state {
+		c.mu.Unlock()
+		return
+	}
+	c.state = state
+	handler := c.onStateChange
+	c.mu.Unlock()
+
+	if handler != nil {
+		handler(state)
+	}
+}
+
+// withReconnect runs op on the current connection. On an endpoint error it re-dials
+// (failing over when the endpoint keeps erroring) and runs op once more.
+func (c *Client) withReconnect(ctx context.Context, op func(*ethclient.Client) error) error {
+	c.maybeReturnToPrimary(ctx)
+
+	client := c.rpc()
+	err := op(client)
+	if err == nil {
+		c.recordSuccess()
+		return nil
+	}
+	if !isEndpointError(err) {
+		return err
+	}
+
+	if rerr := c.reconnect(ctx, client); rerr != nil {
+		return fmt.Errorf("%w (reconnect failed: %v)", err, rerr)
+	}
+	if err = op(c.rpc()); err == nil {
+		c.recordSuccess()
+	}
+	return err
+}
+
+// recordSuccess marks the active endpoint as working
+func (c *Client) recordSuccess() {
+	c.mu.Lock()
+	c.failures = 0
+	c.mu.Unlock()
+	c.setState(ConnectionConnected)
+}
+
+// reconnect replaces the failed connection, dialing with exponential backoff. Callers
+// that fail on the same connection share one reconnect.
+func (c *Client) reconnect(ctx context.Context, failed *ethclient.Client) error {
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+
+	if c.rpc() != failed {
+		return nil // Another call already reconnected
+	}
+
+	c.mu.RLock()
+	policy := c.reconnectPolicy
+	c.mu.RUnlock()
+
+	c.setState(ConnectionReconnecting)
+	c.recordFailure(policy)
+	log.Printf("⚠️ [ethereum] Connection to %s lost, reconnecting", endpointLabel(c.activeURL()))
+
+	delay := policy.InitialDelay
+	for attempt := 1; ; attempt++ {
+		url := c.activeURL()
+		client, err := c.dialAndCheck(ctx, url)
+		if err == nil {
+			c.mu.Lock()
+			c.client = client
+			c.mu.Unlock()
+			failed.Close()
+
+			c.setState(ConnectionConnected)
+			log.Printf("✅ [ethereum] Reconnected to %s after %d attempt(s)", endpointLabel(url), attempt)
+			return nil
+		}
+		c.recordFailure(policy)
+
+		if attempt >= policy.MaxAttempts {
+			c.setState(ConnectionDisconnected)
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			c.setState(ConnectionDisconnected)
+			return ctx.Err()
+		}
+		if delay *= 2; delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+}
+
+// dialAndCheck dials the endpoint and confirms it answers. HTTP dials never fail on
+// their own, so the block number query is what tells a live endpoint from a dead one.
+func (c *Client) dialAndCheck(ctx context.Context, url string) (*ethclient.Client, error) {
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.BlockNumber(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// isConnectionError reports whether err means the endpoint could not be reached, as
+// opposed to the endpoint rejecting the request. Cancellation is never a connection error.
+func isConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, rpc.ErrClientQuit) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"connection refused", "connection reset", "broken pipe", "no such host", "network is unreachable"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return strings.HasSuffix(msg, "eof") // Transport errors that lost the wrapped io.EOF
+}
+
+// isEndpointError reports whether err is the endpoint's fault: it could not be reached,
+// is rate limiting or failed with a server error
+func isEndpointError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+	return isConnectionError(err)
+}
+
+// reconnectingBackend lets bind.WaitMined poll for receipts through the reconnect
+type reconnectingBackend struct {
+	c *Client
+}
+
+func (b reconnectingBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (receipt *types.Receipt, err error) {
+	err = b.c.withReconnect(ctx, func(client *ethclient.Client) error {
+		receipt, err = client.TransactionReceipt(ctx, hash)
+		return err
+	})
+	return receipt, err
+}
+
+func (b reconnectingBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = b.c.withReconnect(ctx, func(client *ethclient.Client) error {
+		code, err = client.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
@@ -0,0 +1,173 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// flakyEndpoint answers eth_blockNumber and eth_gasPrice, dropping the connection
+// for the next `drops` requests
+func flakyEndpoint(t *testing.T, drops int32) (*httptest.Server, *atomic.Int32) {
+	var remaining atomic.Int32
+	remaining.Store(drops)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+
+		if remaining.Add(-1) >= 0 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x10"}`, req.ID)
+	}))
+	t.Cleanup(server.Close)
+	return server, &remaining
+}
+
+func testReconnectPolicy(attempts int) ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialDelay:         time.Millisecond,
+		MaxDelay:             4 * time.Millisecond,
+		MaxAttempts:          attempts,
+		FailoverThreshold:    100,
+		PrimaryRetryInterval: time.Hour,
+	}
+}
+
+func TestClient_ReconnectsOnConnectionError(t *testing.T) {
+	// The call, the first re-dial check and the second re-dial check are dropped
+	server, _ := flakyEndpoint(t, 3)
+	client, err := NewClient(server.URL, 1)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	if err := client.SetReconnectPolicy(testReconnectPolicy(5)); err != nil {
+		t.Fatalf("SetReconnectPolicy: %v", err)
+	}
+
+	var mu sync.Mutex
+	var states []ConnectionState
+	client.SetConnectionStateHandler(func(state ConnectionState) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, state)
+	})
+
+	before := client.GetClient()
+	price, err := client.GetGasPrice(context.Background())
+	if err != nil {
+		t.Fatalf("expected the call to succeed after reconnecting, got %v", err)
+	}
+	if price.Int64() != 16 {
+		t.Errorf("gas price %s, want 16", price)
+	}
+	if client.GetClient() == before {
+		t.Error("expected the connection to be replaced")
+	}
+	if client.ConnectionState() != ConnectionConnected {
+		t.Errorf("state %s, want connected", client.ConnectionState())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(states) != 2 || states[0] != ConnectionReconnecting || states[1] != ConnectionConnected {
+		t.Errorf("state changes %v, want [reconnecting connected]", states)
+	}
+}
+
+func TestClient_ReconnectGivesUp(t *testing.T) {
+	server, remaining := flakyEndpoint(t, 100)
+	client, err := NewClient(server.URL, 1)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	if err := client.SetReconnectPolicy(testReconnectPolicy(2)); err != nil {
+		t.Fatalf("SetReconnectPolicy: %v", err)
+	}
+
+	if _, err := client.GetLatestBlockNumber(context.Background()); err == nil {
+		t.Fatal("expected an error while the endpoint is down")
+	}
+	if client.ConnectionState() != ConnectionDisconnected {
+		t.Errorf("state %s, want disconnected", client.ConnectionState())
+	}
+
+	// Once the endpoint is back the next call reconnects
+	remaining.Store(0)
+	if _, err := client.GetGasPrice(context.Background()); err != nil {
+		t.Fatalf("expected the call to succeed once the endpoint is back, got %v", err)
+	}
+	if client.ConnectionState() != ConnectionConnected {
+		t.Errorf("state %s, want connected", client.ConnectionState())
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{io.EOF, true},
+		{fmt.Errorf("post: %w", io.ErrUnexpectedEOF), true},
+		{rpc.ErrClientQuit, true},
+		{errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), true},
+		{errors.New(`Post "http://node:8545": EOF`), true},
+		{context.Canceled, false},
+		{fmt.Errorf("call: %w", context.DeadlineExceeded), false},
+		{errors.New("execution reverted: proof already anchored"), false},
+		{errors.New("nonce too low"), false},
+	}
+	for _, tt := range tests {
+		if got := isConnectionError(tt.err); got != tt.want {
+			t.Errorf("isConnectionError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+
+	// Rate limiting and server errors count against the endpoint; client errors do not
+	for status, want := range map[int]bool{429: true, 502: true, 400: false, 401: false} {
+		if got := isEndpointError(rpc.HTTPError{StatusCode: status}); got != want {
+			t.Errorf("isEndpointError(HTTP %d) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestReconnectPolicy_Validate(t *testing.T) {
+	if err := DefaultReconnectPolicy().Validate(); err != nil {
+		t.Errorf("default policy: %v", err)
+	}
+	invalid := []ReconnectPolicy{
+		{InitialDelay: 0, MaxDelay: time.Second, MaxAttempts: 1},
+		{InitialDelay: time.Second, MaxDelay: time.Millisecond, MaxAttempts: 1},
+		{InitialDelay: time.Second, MaxDelay: time.Second, MaxAttempts: 0},
+		{InitialDelay: time.Second, MaxDelay: time.Second, MaxAttempts: 1, FailoverThreshold: 0, PrimaryRetryInterval: time.Minute},
+		{InitialDelay: time.Second, MaxDelay: time.Second, MaxAttempts: 1, FailoverThreshold: 1, PrimaryRetryInterval: 0},
+	}
+	for _, policy := range invalid {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", policy)
+		}
+	}
+}
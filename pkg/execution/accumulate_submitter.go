@@ -12,6 +12,7 @@ package execution
 import (
 	"context"
 	"crypto/ed25519"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -20,6 +21,7 @@ import (
 	"time"
 
 	"github.com/certen/independant-validator/pkg/accumulate"
+	"github.com/certen/independant-validator/pkg/keys"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/types/messaging"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
 	"gitlab.com/accumulatenetwork/accumulate/protocol"
@@ -37,8 +39,8 @@ type AccumulateSubmitterImpl struct {
 	client *accumulate.LiteClientAdapter
 
 	// Signing credentials
-	signingKey ed25519.PrivateKey
-	publicKey  ed25519.PublicKey
+	signer    keys.KeyProvider
+	publicKey ed25519.PublicKey
 
 	// Account configuration
 	accountURL   string // Principal account for write-back (e.g., "acc://certen.acme/proof-results")
@@ -46,15 +48,22 @@ type AccumulateSubmitterImpl struct {
 	keyPageIndex uint64 // Key page index (signer version)
 	keyIndex     uint64 // Key index within the page
 
+	// Structure and encoding of the written result
+	format *WriteBackFormat
+
 	// Nonce and credit management
 	nonceTracker  *NonceTracker
 	creditChecker *CreditChecker
 
 	// Configuration
 	confirmationTimeout time.Duration
+	pollInterval        time.Duration
 	maxRetries          int
 	retryDelay          time.Duration
 
+	// Shadow mode: sign and log write-backs without submitting them
+	shadow bool
+
 	// Logging
 	logger *log.Logger
 }
@@ -67,17 +76,27 @@ type AccumulateSubmitterConfig struct {
 	// Signing credentials - must be valid Ed25519 key
 	PrivateKey ed25519.PrivateKey
 
+	// Signer signs in place of PrivateKey, so a KMS or HSM backed key never leaves it
+	Signer keys.KeyProvider
+
 	// Account configuration
 	AccountURL   string // Data account for write-back
 	SignerURL    string // Key page URL
 	KeyPageIndex uint64
 	KeyIndex     uint64
 
+	// Format of the written result (nil = comprehensive key=value format)
+	Format *WriteBackFormat
+
 	// Timing configuration
 	ConfirmationTimeout time.Duration
+	PollInterval        time.Duration // Interval between confirmation status queries
 	MaxRetries          int
 	RetryDelay          time.Duration
 
+	// Shadow mode: build and sign write-backs and log the submission, but never send it
+	Shadow bool
+
 	// Logger
 	Logger *log.Logger
 }
@@ -88,8 +107,15 @@ func NewAccumulateSubmitter(cfg *AccumulateSubmitterConfig) (*AccumulateSubmitte
 		return nil, fmt.Errorf("accumulate client is required")
 	}
 
-	if len(cfg.PrivateKey) != ed25519.PrivateKeySize {
-		return nil, fmt.Errorf("invalid Ed25519 private key: expected %d bytes, got %d", ed25519.PrivateKeySize, len(cfg.PrivateKey))
+	signer := cfg.Signer
+	if signer == nil {
+		if len(cfg.PrivateKey) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 private key: expected %d bytes, got %d", ed25519.PrivateKeySize, len(cfg.PrivateKey))
+		}
+		var err error
+		if signer, err = keys.FromPrivateKey(cfg.PrivateKey); err != nil {
+			return nil, err
+		}
 	}
 
 	if cfg.AccountURL == "" {
@@ -100,8 +126,13 @@ func NewAccumulateSubmitter(cfg *AccumulateSubmitterConfig) (*AccumulateSubmitte
 		return nil, fmt.Errorf("signer URL is required")
 	}
 
-	// Extract public key from private key
-	publicKey := cfg.PrivateKey.Public().(ed25519.PublicKey)
+	format := cfg.Format
+	if format == nil {
+		format = DefaultWriteBackFormat()
+	}
+	if err := format.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid write-back format: %w", err)
+	}
 
 	// Set defaults
 	confirmationTimeout := cfg.ConfirmationTimeout
@@ -109,6 +140,11 @@ func NewAccumulateSubmitter(cfg *AccumulateSubmitterConfig) (*AccumulateSubmitte
 		confirmationTimeout = 2 * time.Minute
 	}
 
+	pollInterval := cfg.PollInterval
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Second
+	}
+
 	maxRetries := cfg.MaxRetries
 	if maxRetries == 0 {
 		maxRetries = 3
@@ -132,17 +168,20 @@ func NewAccumulateSubmitter(cfg *AccumulateSubmitterConfig) (*AccumulateSubmitte
 
 	submitter := &AccumulateSubmitterImpl{
 		client:              cfg.Client,
-		signingKey:          cfg.PrivateKey,
-		publicKey:           publicKey,
+		signer:              signer,
+		publicKey:           signer.PublicKey(),
 		accountURL:          cfg.AccountURL,
 		signerURL:           cfg.SignerURL,
 		keyPageIndex:        cfg.KeyPageIndex,
 		keyIndex:            cfg.KeyIndex,
+		format:              format,
 		nonceTracker:        nonceTracker,
 		creditChecker:       creditChecker,
 		confirmationTimeout: confirmationTimeout,
+		pollInterval:        pollInterval,
 		maxRetries:          maxRetries,
 		retryDelay:          retryDelay,
+		shadow:              cfg.Shadow,
 		logger:              logger,
 	}
 
@@ -186,6 +225,11 @@ func (s *AccumulateSubmitterImpl) SubmitTransaction(ctx context.Context, tx *Syn
 		Signatures:  []protocol.Signature{sig},
 	}
 
+	// In shadow mode the signed envelope is logged and its txid returned, never submitted
+	if s.shadow {
+		return s.simulateEnvelope(accTx, envelope)
+	}
+
 	// Step 5: Submit to Accumulate network via JSON-RPC
 	txHash, err := s.submitEnvelope(ctx, envelope)
 	if err != nil {
@@ -204,8 +248,11 @@ func (s *AccumulateSubmitterImpl) createAccumulateTransaction(tx *SyntheticTrans
 		return nil, fmt.Errorf("invalid account URL: %w", err)
 	}
 
-	// Convert CertenDataEntry to Accumulate DoubleHashDataEntry format
-	dataEntries := tx.Body.DataEntry.ToDoubleHashFormat()
+	// Encode the CertenDataEntry in the configured write-back format
+	dataEntries, err := s.format.Encode(&tx.Body.DataEntry)
+	if err != nil {
+		return nil, fmt.Errorf("encode write-back payload: %w", err)
+	}
 
 	// Create the WriteData body with DoubleHashDataEntry
 	writeDataBody := &protocol.WriteData{
@@ -274,9 +321,15 @@ func (s *AccumulateSubmitterImpl) createAndSignSignature(ctx context.Context, tx
 	// Get the transaction hash (computed using Accumulate's binary encoding)
 	txHash := tx.GetHash()
 
-	// Sign using Accumulate's protocol signing function
-	// SignED25519 computes: sign(SHA256(sigMetadataHash + txHash))
-	protocol.SignED25519(sig, s.signingKey, nil, txHash)
+	// Sign like Accumulate's protocol.SignED25519: sign(SHA256(sigMetadataHash + txHash)),
+	// through the key provider so the private key may stay in a KMS or HSM
+	signingData := append(sig.Metadata().Hash(), txHash...)
+	signingHash := sha256.Sum256(signingData)
+	signature, err := s.signer.Sign(signingHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	sig.Signature = signature
 
 	// Set the transaction hash in the signature
 	sig.TransactionHash = *(*[32]byte)(txHash)
@@ -287,6 +340,39 @@ func (s *AccumulateSubmitterImpl) createAndSignSignature(ctx context.Context, tx
 
 // submitEnvelope submits the envelope to Accumulate via JSON-RPC
 func (s *AccumulateSubmitterImpl) submitEnvelope(ctx context.Context, envelope *messaging.Envelope) (string, error) {
+	submission, err := buildSubmission(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	submissionJSON, _ := json.MarshalIndent(submission, "", "  ")
+	s.logger.Printf("🔍 [V3-SUBMIT] Submitting to Accumulate:\n%s", string(submissionJSON))
+
+	// Submit using the client's SubmitDirect method
+	txHash, err := s.client.SubmitDirect(ctx, submission)
+	if err != nil {
+		return "", fmt.Errorf("failed to submit to network: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// simulateEnvelope logs the submission a signed envelope would make and returns the
+// transaction's txid without sending it
+func (s *AccumulateSubmitterImpl) simulateEnvelope(tx *protocol.Transaction, envelope *messaging.Envelope) (string, error) {
+	submission, err := buildSubmission(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	txID := tx.ID().String()
+	submissionJSON, _ := json.MarshalIndent(submission, "", "  ")
+	s.logger.Printf("🕶️ [SHADOW] Would submit to Accumulate (txid %s, not sent):\n%s", txID, string(submissionJSON))
+	return txID, nil
+}
+
+// buildSubmission converts an envelope to the submission format of the Accumulate V3 API
+func buildSubmission(envelope *messaging.Envelope) (map[string]interface{}, error) {
 	// Build the submission in the exact format expected by Accumulate V3 API
 	// Format: { "transaction": [...], "signatures": [...] }
 	// This matches the JS SDK's client.submit() format
@@ -296,11 +382,11 @@ func (s *AccumulateSubmitterImpl) submitEnvelope(ctx context.Context, envelope *
 	for _, tx := range envelope.Transaction {
 		txJSON, err := json.Marshal(tx)
 		if err != nil {
-			return "", fmt.Errorf("failed to marshal transaction: %w", err)
+			return nil, fmt.Errorf("failed to marshal transaction: %w", err)
 		}
 		var txMap map[string]interface{}
 		if err := json.Unmarshal(txJSON, &txMap); err != nil {
-			return "", fmt.Errorf("failed to unmarshal transaction: %w", err)
+			return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
 		}
 		txArray = append(txArray, txMap)
 	}
@@ -310,31 +396,20 @@ func (s *AccumulateSubmitterImpl) submitEnvelope(ctx context.Context, envelope *
 	for _, sig := range envelope.Signatures {
 		sigJSON, err := json.Marshal(sig)
 		if err != nil {
-			return "", fmt.Errorf("failed to marshal signature: %w", err)
+			return nil, fmt.Errorf("failed to marshal signature: %w", err)
 		}
 		var sigMap map[string]interface{}
 		if err := json.Unmarshal(sigJSON, &sigMap); err != nil {
-			return "", fmt.Errorf("failed to unmarshal signature: %w", err)
+			return nil, fmt.Errorf("failed to unmarshal signature: %w", err)
 		}
 		sigArray = append(sigArray, sigMap)
 	}
 
 	// Build the submission request directly (not wrapped in "envelope")
-	submission := map[string]interface{}{
+	return map[string]interface{}{
 		"transaction": txArray,
 		"signatures":  sigArray,
-	}
-
-	submissionJSON, _ := json.MarshalIndent(submission, "", "  ")
-	s.logger.Printf("🔍 [V3-SUBMIT] Submitting to Accumulate:\n%s", string(submissionJSON))
-
-	// Submit using the client's SubmitDirect method
-	txHash, err := s.client.SubmitDirect(ctx, submission)
-	if err != nil {
-		return "", fmt.Errorf("failed to submit to network: %w", err)
-	}
-
-	return txHash, nil
+	}, nil
 }
 
 // GetTransactionStatus queries the status of a submitted transaction
@@ -360,6 +435,30 @@ func (s *AccumulateSubmitterImpl) GetTransactionStatus(ctx context.Context, txHa
 	}
 }
 
+// WaitForConfirmation polls the status of a submitted transaction until it is
+// delivered or failed, or the confirmation timeout passes
+func (s *AccumulateSubmitterImpl) WaitForConfirmation(ctx context.Context, txID string) *WriteBackConfirmation {
+	if s.shadow {
+		// Nothing was submitted; treat the simulated write-back as delivered
+		return &WriteBackConfirmation{TxID: txID, Outcome: WriteBackConfirmed, Status: "shadow"}
+	}
+
+	s.logger.Printf("⏳ Waiting up to %s for confirmation of %s", s.confirmationTimeout, txID)
+
+	result := pollWriteBackConfirmation(ctx, txID, s.client.GetTransactionStatusDetail,
+		s.confirmationTimeout, s.pollInterval, s.maxRetries)
+
+	switch result.Outcome {
+	case WriteBackConfirmed:
+		s.logger.Printf("✅ Transaction confirmed: %s", txID)
+	case WriteBackFailed:
+		s.logger.Printf("❌ Transaction failed: %s (status %s): %s", txID, result.Status, result.ChainError)
+	default:
+		s.logger.Printf("⚠️ Transaction unconfirmed after %d polls: %s", result.Polls, txID)
+	}
+	return result
+}
+
 // =============================================================================
 // DATA ENTRY FORMAT CONVERSION
 // =============================================================================
@@ -495,12 +594,21 @@ func (s *AccumulateSubmitterImpl) GetAccountURL() string {
 
 // GetPublicKey returns the public key used for signing
 func (s *AccumulateSubmitterImpl) GetPublicKey() ed25519.PublicKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.publicKey
 }
 
 // GetPublicKeyHex returns the hex-encoded public key
 func (s *AccumulateSubmitterImpl) GetPublicKeyHex() string {
-	return hex.EncodeToString(s.publicKey)
+	return hex.EncodeToString(s.GetPublicKey())
+}
+
+// GetKeyPageIndex returns the key page index used when the key page version cannot be queried
+func (s *AccumulateSubmitterImpl) GetKeyPageIndex() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keyPageIndex
 }
 
 // =============================================================================
@@ -532,3 +640,8 @@ func (s *NullAccumulateSubmitter) GetTransactionStatus(ctx context.Context, txHa
 	s.logger.Printf("⚠️ [NULL] Would check status for: %s", txHash)
 	return "confirmed", nil
 }
+
+// WaitForConfirmation always reports confirmed for null submitter
+func (s *NullAccumulateSubmitter) WaitForConfirmation(ctx context.Context, txID string) *WriteBackConfirmation {
+	return &WriteBackConfirmation{TxID: txID, Outcome: WriteBackConfirmed, Status: "confirmed", Polls: 1}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the BLS anchor message hash
+// Runs the CertenAnchorV3 bytecode in an in-memory EVM and checks that the messageHash
+// reaching the BLS verifier through the contract's verifyBLSSignature view is the one
+// computed by bls.ComputeAnchorMessageHash
+
+package execution
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+	"github.com/certen/independant-validator/pkg/execution/contracts"
+)
+
+// expectingBLSVerifier returns creation bytecode for a stand-in BLS ZK verifier:
+// isInitialized() returns true and verifyBLSSignature(bytes,bytes32) returns whether
+// the messageHash it receives equals expected
+func expectingBLSVerifier(expected [32]byte) []byte {
+	runtimeCode := []byte{
+		0x60, 0x00, 0x35, 0x60, 0xe0, 0x1c, // selector := calldataload(0) >> 224
+		0x63, 0x5e, 0x16, 0xef, 0x7d, 0x14, // selector == isInitialized()
+		0x60, 0x3c, 0x57, // jump to return true
+		0x60, 0x24, 0x35, // messageHash := calldataload(0x24)
+		0x7f, // push32 expected
+	}
+	runtimeCode = append(runtimeCode, expected[:]...)
+	runtimeCode = append(runtimeCode,
+		0x14, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3, // return messageHash == expected
+		0x5b, 0x60, 0x01, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3, // return true
+	)
+	initCode := []byte{0x60, byte(len(runtimeCode)), 0x80, 0x60, 0x0b, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3}
+	return append(initCode, runtimeCode...)
+}
+
+type anchorEVM struct {
+	t       *testing.T
+	cfg     *runtime.Config
+	abi     *abi.ABI
+	address common.Address
+}
+
+func deployAnchorContract(t *testing.T) *anchorEVM {
+	t.Helper()
+	parsed, err := contracts.CertenAnchorV3MetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse anchor ABI: %v", err)
+	}
+	cfg := &runtime.Config{
+		Origin:      common.HexToAddress("0x00000000000000000000000000000000000c3e70"),
+		GasLimit:    30_000_000,
+		BlockNumber: big.NewInt(1),
+		Time:        1_750_000_000,
+	}
+	_, address, _, err := runtime.Create(common.FromHex(contracts.CertenAnchorV3MetaData.Bin), cfg)
+	if err != nil {
+		t.Fatalf("failed to deploy anchor contract: %v", err)
+	}
+	return &anchorEVM{t: t, cfg: cfg, abi: parsed, address: address}
+}
+
+func (e *anchorEVM) call(method string, args ...interface{}) []interface{} {
+	e.t.Helper()
+	input, err := e.abi.Pack(method, args...)
+	if err != nil {
+		e.t.Fatalf("failed to pack %s: %v", method, err)
+	}
+	output, _, err := runtime.Call(e.address, input, e.cfg)
+	if err != nil {
+		e.t.Fatalf("%s failed: %v", method, err)
+	}
+	values, err := e.abi.Unpack(method, output)
+	if err != nil {
+		e.t.Fatalf("failed to unpack %s: %v", method, err)
+	}
+	return values
+}
+
+func TestComputeAnchorMessageHash_MatchesContract(t *testing.T) {
+	opID := "0x" + common.Bytes2Hex(crypto.Keccak256([]byte("operation")))
+	messageHash := bls.ComputeAnchorMessageHash(opID)
+
+	evm := deployAnchorContract(t)
+	_, verifier, _, err := runtime.Create(expectingBLSVerifier(messageHash), evm.cfg)
+	if err != nil {
+		t.Fatalf("failed to deploy verifier: %v", err)
+	}
+	evm.call("setBLSZKVerifier", verifier)
+	evm.call("setBLSZKVerificationEnabled", true)
+
+	signature := bytes.Repeat([]byte{0x01}, 48)
+	if ok := evm.call("verifyBLSSignature", signature, messageHash)[0].(bool); !ok {
+		t.Fatal("contract did not forward ComputeAnchorMessageHash to the BLS verifier")
+	}
+
+	// The previous derivation (Keccak256 of the intent ID) is not what validators sign
+	legacy := crypto.Keccak256Hash([]byte("intent-1"))
+	if ok := evm.call("verifyBLSSignature", signature, [32]byte(legacy))[0].(bool); ok {
+		t.Error("expected a different message hash to be rejected")
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Certen Protocol
+//
+// Audit Log - Append-only, hash-chained record of proof-cycle decisions
+//
+// The database records proof-cycle state, but rows can be updated or deleted by anyone
+// with access to it, including the validator operator. The audit log gives compliance
+// teams tamper-evidence for the validator's own decisions: stage transitions, who
+// attested, what was anchored and the write-back result.
+//
+// Every entry commits to the hash of the entry before it, so removing, reordering or
+// editing an entry breaks the chain from that point on. Entries may also carry an
+// Ed25519 signature over their hash, making each entry verifiable on its own. The chain
+// tip (sequence and hash) can be published so that an auditor holding an earlier tip
+// can later prove the log was only ever appended to.
+//
+// Entries are written to an AuditSink. FileAuditSink appends JSON lines to a local file;
+// an external WORM store (e.g. object-locked bucket) can be used by implementing AuditSink.
+
+package execution
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Proof-cycle audit events
+const (
+	AuditEventCycleStarted   = "cycle_started"
+	AuditEventPhaseCompleted = "phase_completed"
+	AuditEventObserved       = "observed"
+	AuditEventAttested       = "attested"
+	AuditEventWriteBack      = "write_back"
+	AuditEventCycleCompleted = "cycle_completed"
+	AuditEventCycleFailed    = "cycle_failed"
+)
+
+// ErrAuditChainBroken is returned when an audit entry does not match the chain
+var ErrAuditChainBroken = errors.New("audit chain broken")
+
+// AuditEntry is one link of the audit chain
+type AuditEntry struct {
+	Sequence  uint64          `json:"sequence"`
+	Timestamp time.Time       `json:"timestamp"`
+	CycleID   string          `json:"cycle_id"`
+	Event     string          `json:"event"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	PrevHash  string          `json:"prev_hash"`            // Hex hash of the previous entry, zero for the first
+	Hash      string          `json:"hash"`                 // Hex SHA-256 over the fields above
+	PublicKey string          `json:"public_key,omitempty"` // Hex Ed25519 key of the signer
+	Signature string          `json:"signature,omitempty"`  // Hex Ed25519 signature over Hash
+}
+
+// AuditTip identifies the latest entry of the audit chain
+type AuditTip struct {
+	Sequence uint64 `json:"sequence"` // Number of entries written
+	Hash     string `json:"hash"`     // Hash of the latest entry, zero when empty
+}
+
+// zeroAuditHash is the PrevHash of the first entry
+var zeroAuditHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+// computeHash returns the hash the entry commits to
+// Variable-length fields are length-prefixed so no two entries share a preimage
+func (e *AuditEntry) computeHash() string {
+	h := sha256.New()
+	var buf [8]byte
+	writeUint := func(v uint64) {
+		binary.BigEndian.PutUint64(buf[:], v)
+		h.Write(buf[:])
+	}
+	writeBytes := func(b []byte) {
+		writeUint(uint64(len(b)))
+		h.Write(b)
+	}
+	writeUint(e.Sequence)
+	writeUint(uint64(e.Timestamp.UnixNano()))
+	writeBytes([]byte(e.CycleID))
+	writeBytes([]byte(e.Event))
+	writeBytes(e.Details)
+	writeBytes([]byte(e.PrevHash))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyAuditEntry checks that the entry follows prevHash, that its hash is correct
+// and, when signed, that the signature is valid
+func VerifyAuditEntry(entry *AuditEntry, prevHash string) error {
+	if entry.PrevHash != prevHash {
+		return fmt.Errorf("%w: entry %d prev_hash %s, expected %s", ErrAuditChainBroken, entry.Sequence, entry.PrevHash, prevHash)
+	}
+	if entry.computeHash() != entry.Hash {
+		return fmt.Errorf("%w: entry %d hash mismatch", ErrAuditChainBroken, entry.Sequence)
+	}
+	if entry.Signature == "" {
+		return nil
+	}
+	pub, err := hex.DecodeString(entry.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return fmt.Errorf("entry %d: invalid public key", entry.Sequence)
+	}
+	sig, err := hex.DecodeString(entry.Signature)
+	if err != nil {
+		return fmt.Errorf("entry %d: invalid signature encoding", entry.Sequence)
+	}
+	hash, _ := hex.DecodeString(entry.Hash)
+	if !ed25519.Verify(pub, hash, sig) {
+		return fmt.Errorf("%w: entry %d signature invalid", ErrAuditChainBroken, entry.Sequence)
+	}
+	return nil
+}
+
+// VerifyAuditChain verifies a sequence of entries from the start of the chain
+func VerifyAuditChain(entries []*AuditEntry) (AuditTip, error) {
+	tip := AuditTip{Hash: zeroAuditHash}
+	for _, entry := range entries {
+		if entry.Sequence != tip.Sequence+1 {
+			return tip, fmt.Errorf("%w: entry sequence %d, expected %d", ErrAuditChainBroken, entry.Sequence, tip.Sequence+1)
+		}
+		if err := VerifyAuditEntry(entry, tip.Hash); err != nil {
+			return tip, err
+		}
+		tip = AuditTip{Sequence: entry.Sequence, Hash: entry.Hash}
+	}
+	return tip, nil
+}
+
+// AuditSink stores audit entries; implementations must never modify written entries
+type AuditSink interface {
+	Append(entry *AuditEntry) error
+	Close() error
+}
+
+// AuditLog appends hash-chained proof-cycle events to a sink
+type AuditLog struct {
+	mu     sync.Mutex
+	sink   AuditSink
+	signer ed25519.PrivateKey
+	tip    AuditTip
+}
+
+// NewAuditLog creates an audit log continuing from tip; signer may be nil
+func NewAuditLog(sink AuditSink, tip AuditTip, signer ed25519.PrivateKey) *AuditLog {
+	if tip.Hash == "" {
+		tip.Hash = zeroAuditHash
+	}
+	return &AuditLog{sink: sink, signer: signer, tip: tip}
+}
+
+// OpenFileAuditLog opens (or creates) a file-backed audit log, verifying the existing
+// chain before appending to it
+func OpenFileAuditLog(path string, signer ed25519.PrivateKey) (*AuditLog, error) {
+	entries, err := ReadAuditFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	tip, err := VerifyAuditChain(entries)
+	if err != nil {
+		return nil, fmt.Errorf("verify audit log %s: %w", path, err)
+	}
+	sink, err := NewFileAuditSink(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuditLog(sink, tip, signer), nil
+}
+
+// Record appends an event to the chain; details are JSON-encoded
+func (l *AuditLog) Record(cycleID, event string, details interface{}) (*AuditEntry, error) {
+	var raw json.RawMessage
+	if details != nil {
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			return nil, fmt.Errorf("encode audit details: %w", err)
+		}
+		raw = encoded
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := &AuditEntry{
+		Sequence:  l.tip.Sequence + 1,
+		Timestamp: time.Now().UTC(),
+		CycleID:   cycleID,
+		Event:     event,
+		Details:   raw,
+		PrevHash:  l.tip.Hash,
+	}
+	entry.Hash = entry.computeHash()
+	if l.signer != nil {
+		entry.PublicKey = hex.EncodeToString(l.signer.Public().(ed25519.PublicKey))
+		entry.Signature = l.sign(entry.Hash)
+	}
+
+	if err := l.sink.Append(entry); err != nil {
+		return nil, fmt.Errorf("append audit entry: %w", err)
+	}
+	l.tip = AuditTip{Sequence: entry.Sequence, Hash: entry.Hash}
+	return entry, nil
+}
+
+// sign returns the hex Ed25519 signature over a hex entry hash
+func (l *AuditLog) sign(hash string) string {
+	raw, _ := hex.DecodeString(hash)
+	return hex.EncodeToString(ed25519.Sign(l.signer, raw))
+}
+
+// Tip returns the latest entry of the chain, for publication
+func (l *AuditLog) Tip() AuditTip {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tip
+}
+
+// Close closes the underlying sink
+func (l *AuditLog) Close() error {
+	return l.sink.Close()
+}
+
+// FileAuditSink appends entries as JSON lines to a local file opened append-only
+type FileAuditSink struct {
+	file *os.File
+}
+
+// NewFileAuditSink opens path for appending, creating it if needed
+func NewFileAuditSink(path string) (*FileAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	return &FileAuditSink{file: file}, nil
+}
+
+// Append writes the entry and syncs it to disk
+func (s *FileAuditSink) Append(entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Close closes the file
+func (s *FileAuditSink) Close() error {
+	return s.file.Close()
+}
+
+// ReadAuditFile reads all entries of a file-backed audit log
+func ReadAuditFile(path string) ([]*AuditEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []*AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("decode audit entry %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Audit Log
+// Tests hash chaining, signatures, reopening a file-backed log and tamper detection
+
+package execution
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog_ChainAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+
+	auditLog, err := OpenFileAuditLog(path, key)
+	if err != nil {
+		t.Fatalf("OpenFileAuditLog failed: %v", err)
+	}
+	if _, err := auditLog.Record("cycle-1", AuditEventCycleStarted, map[string]interface{}{"tx_hashes": []string{"0xabc"}}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	second, err := auditLog.Record("cycle-1", AuditEventAttested, map[string]interface{}{"validators": []string{"validator-1"}})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	tip := auditLog.Tip()
+	if tip.Sequence != 2 || tip.Hash != second.Hash {
+		t.Fatalf("unexpected tip %+v", tip)
+	}
+	auditLog.Close()
+
+	// Reopening continues the chain from the persisted tip
+	auditLog, err = OpenFileAuditLog(path, key)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	if auditLog.Tip() != tip {
+		t.Fatalf("expected reopened tip %+v, got %+v", tip, auditLog.Tip())
+	}
+	third, err := auditLog.Record("cycle-1", AuditEventCycleCompleted, nil)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	auditLog.Close()
+	if third.PrevHash != tip.Hash {
+		t.Errorf("expected entry 3 to commit to %s, got %s", tip.Hash, third.PrevHash)
+	}
+
+	entries, err := ReadAuditFile(path)
+	if err != nil {
+		t.Fatalf("ReadAuditFile failed: %v", err)
+	}
+	finalTip, err := VerifyAuditChain(entries)
+	if err != nil {
+		t.Fatalf("VerifyAuditChain failed: %v", err)
+	}
+	if finalTip.Sequence != 3 || finalTip.Hash != third.Hash {
+		t.Errorf("unexpected final tip %+v", finalTip)
+	}
+	for _, entry := range entries {
+		if entry.Signature == "" {
+			t.Errorf("expected entry %d to be signed", entry.Sequence)
+		}
+	}
+}
+
+func TestAuditLog_TamperDetection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+
+	auditLog, err := OpenFileAuditLog(path, key)
+	if err != nil {
+		t.Fatalf("OpenFileAuditLog failed: %v", err)
+	}
+	auditLog.Record("cycle-1", AuditEventWriteBack, map[string]interface{}{"tx_hash": "receipt-1"})
+	auditLog.Record("cycle-1", AuditEventCycleCompleted, nil)
+	auditLog.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), "receipt-1", "receipt-2", 1)
+	if err := os.WriteFile(path, []byte(tampered), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenFileAuditLog(path, key); !errors.Is(err, ErrAuditChainBroken) {
+		t.Errorf("expected ErrAuditChainBroken for an edited entry, got %v", err)
+	}
+
+	// Dropping the first entry breaks the chain as well
+	lines := strings.SplitN(string(data), "\n", 2)
+	if err := os.WriteFile(path, []byte(lines[1]), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadAuditFile(path)
+	if err != nil {
+		t.Fatalf("ReadAuditFile failed: %v", err)
+	}
+	if _, err := VerifyAuditChain(entries); !errors.Is(err, ErrAuditChainBroken) {
+		t.Errorf("expected ErrAuditChainBroken for a removed entry, got %v", err)
+	}
+}
+
+func TestVerifyAuditEntry_Signature(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	sink := &memoryAuditSink{}
+	auditLog := NewAuditLog(sink, AuditTip{}, key)
+
+	entry, err := auditLog.Record("cycle-1", AuditEventCycleStarted, nil)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := VerifyAuditEntry(entry, zeroAuditHash); err != nil {
+		t.Fatalf("expected entry to verify on its own, got %v", err)
+	}
+
+	// A signature by a different key over the same hash is rejected
+	forged := *entry
+	forged.Signature = NewAuditLog(&memoryAuditSink{}, AuditTip{}, otherKey).sign(entry.Hash)
+	if err := VerifyAuditEntry(&forged, zeroAuditHash); !errors.Is(err, ErrAuditChainBroken) {
+		t.Errorf("expected ErrAuditChainBroken for a foreign signature, got %v", err)
+	}
+}
+
+type memoryAuditSink struct {
+	entries []*AuditEntry
+}
+
+func (s *memoryAuditSink) Append(entry *AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *memoryAuditSink) Close() error { return nil }
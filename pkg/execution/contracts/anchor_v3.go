@@ -14,6 +14,7 @@ package contracts
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -175,6 +176,54 @@ func (w *CertenAnchorV3Wrapper) GetValidatorInfo(opts *bind.CallOpts, validator
 	}, nil
 }
 
+// GetValidatorRegistration retrieves a validator's full registration, including its BLS key
+func (w *CertenAnchorV3Wrapper) GetValidatorRegistration(opts *bind.CallOpts, validator common.Address) (*ValidatorInfoV3, error) {
+	result, err := w.CertenAnchorV3Caller.Validators(opts, validator)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorInfoV3{
+		Registered:   result.Registered,
+		VotingPower:  result.VotingPower,
+		BLSPublicKey: result.BlsPublicKey,
+		RegisteredAt: result.RegisteredAt,
+	}, nil
+}
+
+// RegisterValidatorIfNeeded submits registerValidator(validator, votingPower, blsPublicKey)
+// unless the validator is already registered. Returns the validator's registration
+// before the call and a nil transaction when nothing was submitted.
+// REQUIRES: opts.From is the contract owner
+func (w *CertenAnchorV3Wrapper) RegisterValidatorIfNeeded(
+	opts *bind.TransactOpts,
+	validator common.Address,
+	votingPower *big.Int,
+	blsPublicKey []byte,
+) (*ValidatorInfoV3, *types.Transaction, error) {
+	callOpts := &bind.CallOpts{Context: opts.Context, From: opts.From}
+	existing, err := w.GetValidatorRegistration(callOpts, validator)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read registration: %w", err)
+	}
+	if existing.Registered {
+		return existing, nil, nil
+	}
+
+	owner, err := w.CertenAnchorV3Caller.Owner(callOpts)
+	if err != nil {
+		return existing, nil, fmt.Errorf("read owner: %w", err)
+	}
+	if owner != opts.From {
+		return existing, nil, fmt.Errorf("registerValidator is owner-only: signer %s is not the contract owner %s", opts.From.Hex(), owner.Hex())
+	}
+
+	tx, err := w.CertenAnchorV3Transactor.RegisterValidator(opts, validator, votingPower, blsPublicKey)
+	if err != nil {
+		return existing, nil, err
+	}
+	return existing, tx, nil
+}
+
 // VerifyProofDetailed returns detailed verification results as VerificationResultV3
 func (w *CertenAnchorV3Wrapper) VerifyProofDetailed(
 	opts *bind.CallOpts,
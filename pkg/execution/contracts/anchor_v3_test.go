@@ -0,0 +1,224 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for CertenAnchorV3 wrapper
+// Tests RegisterValidatorIfNeeded against the contract bytecode running in an in-memory EVM
+
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/holiman/uint256"
+)
+
+// evmBackend is a bind.ContractBackend that runs transactions and calls directly
+// against an in-memory EVM state. Every transaction is mined immediately.
+type evmBackend struct {
+	chainID  *big.Int
+	state    *state.StateDB
+	receipts map[common.Hash]*types.Receipt
+}
+
+var _ bind.ContractBackend = (*evmBackend)(nil)
+
+func newEVMBackend(t *testing.T, funded ...common.Address) *evmBackend {
+	t.Helper()
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	for _, addr := range funded {
+		statedb.AddBalance(addr, uint256.NewInt(math.MaxUint64), 0)
+	}
+	return &evmBackend{chainID: big.NewInt(1337), state: statedb, receipts: make(map[common.Hash]*types.Receipt)}
+}
+
+func (b *evmBackend) config(from common.Address, statedb *state.StateDB) *runtime.Config {
+	return &runtime.Config{Origin: from, GasLimit: 30_000_000, State: statedb}
+}
+
+func (b *evmBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return b.state.GetCode(contract), nil
+}
+
+func (b *evmBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if call.To == nil {
+		return nil, errors.New("call without a target")
+	}
+	ret, _, err := runtime.Call(*call.To, call.Data, b.config(call.From, b.state.Copy()))
+	return ret, err
+}
+
+func (b *evmBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(0)}, nil
+}
+
+func (b *evmBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return b.state.GetCode(account), nil
+}
+
+func (b *evmBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return b.state.GetNonce(account), nil
+}
+
+func (b *evmBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (b *evmBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (b *evmBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 30_000_000, nil
+}
+
+func (b *evmBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(b.chainID), tx)
+	if err != nil {
+		return err
+	}
+	if tx.Nonce() != b.state.GetNonce(from) {
+		return errors.New("nonce mismatch")
+	}
+
+	receipt := &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful}
+	if tx.To() == nil {
+		// Create bumps the sender nonce itself
+		_, receipt.ContractAddress, _, err = runtime.Create(tx.Data(), b.config(from, b.state))
+	} else {
+		b.state.SetNonce(from, tx.Nonce()+1, 0)
+		_, _, err = runtime.Call(*tx.To(), tx.Data(), b.config(from, b.state))
+	}
+	if err != nil {
+		receipt.Status = types.ReceiptStatusFailed
+	}
+	b.receipts[tx.Hash()] = receipt
+	return nil
+}
+
+func (b *evmBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (b *evmBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+func (b *evmBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error { <-quit; return nil }), nil
+}
+
+// deployAnchorV3 deploys CertenAnchorV3 owned by ownerKey on a fresh backend
+func deployAnchorV3(t *testing.T, ownerKey *ecdsa.PrivateKey, funded ...common.Address) (*evmBackend, *CertenAnchorV3Wrapper) {
+	t.Helper()
+	backend := newEVMBackend(t, append(funded, crypto.PubkeyToAddress(ownerKey.PublicKey))...)
+
+	address, tx, _, err := DeployCertenAnchorV3(transactOpts(t, backend, ownerKey), backend)
+	if err != nil {
+		t.Fatalf("deploy: %v", err)
+	}
+	if receipt, _ := backend.TransactionReceipt(context.Background(), tx.Hash()); receipt.Status != types.ReceiptStatusSuccessful || receipt.ContractAddress != address {
+		t.Fatalf("deploy failed: %+v", receipt)
+	}
+
+	contract, err := NewCertenAnchorV3Wrapper(address, backend)
+	if err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	return backend, contract
+}
+
+func transactOpts(t *testing.T, backend *evmBackend, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	t.Helper()
+	opts, err := bind.NewKeyedTransactorWithChainID(key, backend.chainID)
+	if err != nil {
+		t.Fatalf("transactor: %v", err)
+	}
+	opts.Context = context.Background()
+	return opts
+}
+
+func TestRegisterValidatorIfNeeded(t *testing.T) {
+	ownerKey, _ := crypto.GenerateKey()
+	validatorKey, _ := crypto.GenerateKey()
+	validator := crypto.PubkeyToAddress(validatorKey.PublicKey)
+	blsPublicKey := bytes.Repeat([]byte{0x42}, 48)
+
+	backend, contract := deployAnchorV3(t, ownerKey)
+	opts := transactOpts(t, backend, ownerKey)
+
+	// Not yet registered: the registration is submitted
+	existing, tx, err := contract.RegisterValidatorIfNeeded(opts, validator, big.NewInt(10), blsPublicKey)
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if existing.Registered {
+		t.Fatal("validator reported registered before the first call")
+	}
+	if tx == nil {
+		t.Fatal("expected a registerValidator transaction")
+	}
+
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("registerValidator reverted")
+	}
+
+	info, err := contract.GetValidatorRegistration(nil, validator)
+	if err != nil {
+		t.Fatalf("read registration: %v", err)
+	}
+	if !info.Registered || info.VotingPower.Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("registration = %+v, want registered with voting power 10", info)
+	}
+
+	// Already registered: no transaction, existing registration returned
+	existing, tx, err = contract.RegisterValidatorIfNeeded(opts, validator, big.NewInt(99), blsPublicKey)
+	if err != nil {
+		t.Fatalf("second register: %v", err)
+	}
+	if tx != nil {
+		t.Fatal("expected no transaction for an already registered validator")
+	}
+	if !existing.Registered || existing.VotingPower.Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("existing = %+v, want the original registration", existing)
+	}
+}
+
+func TestRegisterValidatorIfNeeded_NotOwner(t *testing.T) {
+	ownerKey, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+	other := crypto.PubkeyToAddress(otherKey.PublicKey)
+
+	backend, contract := deployAnchorV3(t, ownerKey, other)
+
+	_, tx, err := contract.RegisterValidatorIfNeeded(transactOpts(t, backend, otherKey), other, big.NewInt(1), bytes.Repeat([]byte{0x42}, 48))
+	if err == nil {
+		t.Fatal("expected an owner-only error")
+	}
+	if tx != nil {
+		t.Fatal("expected no transaction from a non-owner")
+	}
+}
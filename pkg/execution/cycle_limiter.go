@@ -0,0 +1,123 @@
+// Copyright 2025 Certen Protocol
+//
+// Cycle Limiter - Bounds the number of proof cycles running concurrently
+//
+// Every proof cycle observes the target chain, collects attestations and writes back to
+// Accumulate, all of which hit RPC endpoints and CPU. When many batches close together
+// (e.g. after a pause) the orchestrator would otherwise start all of their cycles at once.
+// The limiter admits up to a fixed number of cycles; excess cycles wait in FIFO queues,
+// and on-demand cycles are always admitted ahead of queued on-cadence cycles.
+
+package execution
+
+import (
+	"context"
+	"sync"
+)
+
+// CycleLimiterStats reports proof cycle concurrency
+type CycleLimiterStats struct {
+	MaxConcurrent  int `json:"max_concurrent"` // 0 = unlimited
+	Active         int `json:"active"`
+	QueuedOnDemand int `json:"queued_on_demand"`
+	QueuedCadence  int `json:"queued_on_cadence"`
+}
+
+// CycleLimiter admits at most maxConcurrent proof cycles at a time
+type CycleLimiter struct {
+	mu             sync.Mutex
+	maxConcurrent  int
+	active         int
+	onDemandQueue  []chan struct{}
+	onCadenceQueue []chan struct{}
+}
+
+// NewCycleLimiter creates a limiter; maxConcurrent <= 0 disables the limit
+func NewCycleLimiter(maxConcurrent int) *CycleLimiter {
+	if maxConcurrent < 0 {
+		maxConcurrent = 0
+	}
+	return &CycleLimiter{maxConcurrent: maxConcurrent}
+}
+
+// Acquire blocks until a cycle slot is available or ctx is done
+// On-demand cycles are admitted before any queued on-cadence cycle
+func (l *CycleLimiter) Acquire(ctx context.Context, onDemand bool) error {
+	l.mu.Lock()
+	if l.maxConcurrent == 0 || l.active < l.maxConcurrent && l.queuedLocked() == 0 {
+		l.active++
+		l.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	if onDemand {
+		l.onDemandQueue = append(l.onDemandQueue, ready)
+	} else {
+		l.onCadenceQueue = append(l.onCadenceQueue, ready)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if removeWaiter(&l.onDemandQueue, ready) || removeWaiter(&l.onCadenceQueue, ready) {
+			return ctx.Err()
+		}
+		// The slot was handed over while ctx was being cancelled - pass it on
+		l.releaseLocked()
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot taken by Acquire, handing it to the next queued cycle
+func (l *CycleLimiter) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.releaseLocked()
+}
+
+// Stats returns the current active and queued cycle counts
+func (l *CycleLimiter) Stats() CycleLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return CycleLimiterStats{
+		MaxConcurrent:  l.maxConcurrent,
+		Active:         l.active,
+		QueuedOnDemand: len(l.onDemandQueue),
+		QueuedCadence:  len(l.onCadenceQueue),
+	}
+}
+
+func (l *CycleLimiter) releaseLocked() {
+	var next chan struct{}
+	switch {
+	case len(l.onDemandQueue) > 0:
+		next, l.onDemandQueue = l.onDemandQueue[0], l.onDemandQueue[1:]
+	case len(l.onCadenceQueue) > 0:
+		next, l.onCadenceQueue = l.onCadenceQueue[0], l.onCadenceQueue[1:]
+	default:
+		l.active--
+		return
+	}
+	// The slot moves to the waiter; the active count is unchanged
+	close(next)
+}
+
+func (l *CycleLimiter) queuedLocked() int {
+	return len(l.onDemandQueue) + len(l.onCadenceQueue)
+}
+
+// removeWaiter removes ready from queue and reports whether it was still queued
+func removeWaiter(queue *[]chan struct{}, ready chan struct{}) bool {
+	for i, w := range *queue {
+		if w == ready {
+			*queue = append((*queue)[:i], (*queue)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
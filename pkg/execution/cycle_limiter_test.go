@@ -0,0 +1,96 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Cycle Limiter
+// Tests the concurrency bound, on-demand priority and queue cancellation
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitQueued polls until the limiter reports the given queued counts
+func waitQueued(t *testing.T, l *CycleLimiter, onDemand, cadence int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		stats := l.Stats()
+		if stats.QueuedOnDemand == onDemand && stats.QueuedCadence == cadence {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %d on-demand and %d on-cadence queued, got %+v", onDemand, cadence, l.Stats())
+}
+
+func TestCycleLimiter_OnDemandPriority(t *testing.T) {
+	l := NewCycleLimiter(1)
+	ctx := context.Background()
+
+	if err := l.Acquire(ctx, false); err != nil {
+		t.Fatalf("first Acquire failed: %v", err)
+	}
+
+	admitted := make(chan string, 2)
+	go func() {
+		l.Acquire(ctx, false)
+		admitted <- "on_cadence"
+	}()
+	waitQueued(t, l, 0, 1)
+	go func() {
+		l.Acquire(ctx, true)
+		admitted <- "on_demand"
+	}()
+	waitQueued(t, l, 1, 1)
+
+	if stats := l.Stats(); stats.Active != 1 || stats.MaxConcurrent != 1 {
+		t.Errorf("expected 1 active of max 1, got %+v", stats)
+	}
+
+	l.Release()
+	if first := <-admitted; first != "on_demand" {
+		t.Errorf("expected the on-demand cycle to be admitted first, got %s", first)
+	}
+	l.Release()
+	if second := <-admitted; second != "on_cadence" {
+		t.Errorf("expected the on-cadence cycle second, got %s", second)
+	}
+	l.Release()
+
+	if stats := l.Stats(); stats.Active != 0 {
+		t.Errorf("expected no active cycles after release, got %+v", stats)
+	}
+}
+
+func TestCycleLimiter_CancelWhileQueued(t *testing.T) {
+	l := NewCycleLimiter(1)
+	l.Acquire(context.Background(), true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- l.Acquire(ctx, false) }()
+	waitQueued(t, l, 0, 1)
+
+	cancel()
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if stats := l.Stats(); stats.QueuedCadence != 0 || stats.Active != 1 {
+		t.Errorf("expected cancelled waiter to leave the queue, got %+v", stats)
+	}
+}
+
+func TestCycleLimiter_Unlimited(t *testing.T) {
+	l := NewCycleLimiter(0)
+	for i := 0; i < 50; i++ {
+		if err := l.Acquire(context.Background(), false); err != nil {
+			t.Fatalf("Acquire %d failed: %v", i, err)
+		}
+	}
+	if stats := l.Stats(); stats.Active != 50 || stats.QueuedCadence != 0 {
+		t.Errorf("expected 50 active and none queued, got %+v", stats)
+	}
+}
@@ -23,4 +23,7 @@ var (
 
 	// ErrInsufficientResultData is returned when contract response is too short
 	ErrInsufficientResultData = errors.New("insufficient result data from contract")
+
+	// ErrUnsupportedTargetChain is returned when an intent targets a chain with no registered strategy
+	ErrUnsupportedTargetChain = errors.New("no strategy registered for target chain")
 )
@@ -458,7 +458,10 @@ func (ecm *EthereumContractManager) SubmitCertenProofToAnchor(
 	ecm.auth.GasLimit = estimatedGas
 
 	// Build comprehensive proof from CERTEN proof data
-	comprehensiveProof := ecm.buildComprehensiveProof(certenIntent, certenProof, anchorResult)
+	comprehensiveProof, err := ecm.buildComprehensiveProof(certenIntent, certenProof, anchorResult)
+	if err != nil {
+		return "", fmt.Errorf("build comprehensive proof: %w", err)
+	}
 
 	fmt.Printf("📡 [ETH-VERIFY] Submitting proof to CertenAnchorV3 via executeComprehensiveProof...\n")
 	fmt.Printf("   Contract: %s\n", ecm.anchorV3.GetAddress().Hex())
@@ -588,7 +591,10 @@ func (ecm *EthereumContractManager) ExecuteUnifiedAnchorWorkflowFull(
 	bundleID := ecm.generateAnchorID(certenIntent, certenProof)
 
 	// Build commitments from proof data
-	comprehensiveProof := ecm.buildComprehensiveProof(certenIntent, certenProof, anchorResult)
+	comprehensiveProof, err := ecm.buildComprehensiveProof(certenIntent, certenProof, anchorResult)
+	if err != nil {
+		return "", "", "", fmt.Errorf("build comprehensive proof: %w", err)
+	}
 
 	createTxHash, err = ecm.CreateAnchorOnChain(
 		ctx,
@@ -641,7 +647,10 @@ func (ecm *EthereumContractManager) ExecuteUnifiedAnchorWorkflow(
 	bundleID := ecm.generateAnchorID(certenIntent, certenProof)
 
 	// Build commitments from proof data
-	comprehensiveProof := ecm.buildComprehensiveProof(certenIntent, certenProof, anchorResult)
+	comprehensiveProof, err := ecm.buildComprehensiveProof(certenIntent, certenProof, anchorResult)
+	if err != nil {
+		return "", "", fmt.Errorf("build comprehensive proof: %w", err)
+	}
 
 	createTxHash, err = ecm.CreateAnchorOnChain(
 		ctx,
@@ -674,7 +683,7 @@ func (ecm *EthereumContractManager) buildComprehensiveProof(
 	certenIntent *intent.CertenIntent,
 	certenProof *proof.CertenProof,
 	anchorResult *anchor.AnchorResponse,
-) contracts.ComprehensiveCertenProof {
+) (contracts.ComprehensiveCertenProof, error) {
 
 	// Parse transaction hash
 	var txHash [32]byte
@@ -759,9 +768,14 @@ func (ecm *EthereumContractManager) buildComprehensiveProof(
 		}
 	}
 
-	// Compute message hash for BLS verification
-	var messageHash [32]byte
-	copy(messageHash[:], crypto.Keccak256([]byte(certenIntent.IntentID)))
+	// Compute message hash for BLS verification - the contract forwards it unchanged to the
+	// BLS verifier, so it must be the digest the validators signed for this operation.
+	// Without it the contract can only reject the proof, so nothing is submitted.
+	opID, err := certenIntent.OperationID()
+	if err != nil {
+		return contracts.ComprehensiveCertenProof{}, fmt.Errorf("compute operation ID for BLS message hash: %w", err)
+	}
+	messageHash := bls.ComputeAnchorMessageHash(opID)
 
 	// Generate ZK proof from BLS signature if prover is available
 	zkProofBytes := ecm.generateBLSZKProof(blsSignatureBytes, messageHash, signedVotingPower, totalVotingPower)
@@ -837,7 +851,7 @@ func (ecm *EthereumContractManager) buildComprehensiveProof(
 		Commitments:     commitments,
 		ExpirationTime:  big.NewInt(time.Now().Add(24 * time.Hour).Unix()),
 		Metadata:        metadata,
-	}
+	}, nil
 }
 
 // generateBLSZKProof generates a Groth16 ZK proof from a BLS signature
@@ -0,0 +1,46 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Ethereum Contract Manager
+// Tests the BLS message hash of comprehensive proofs
+
+package execution
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+	"github.com/certen/independant-validator/pkg/intent"
+	"github.com/certen/independant-validator/pkg/proof"
+)
+
+func TestBuildComprehensiveProof_MessageHash(t *testing.T) {
+	ecm := &EthereumContractManager{auth: &bind.TransactOpts{}, config: &CertenContractConfig{}}
+	certenProof := &proof.CertenProof{TransactionHash: "0x01"}
+	anchorResult := &anchor.AnchorResponse{AnchorID: "anchor-1"}
+
+	valid := &intent.CertenIntent{
+		IntentID:       "intent-1",
+		IntentData:     []byte(`{"kind":"transfer"}`),
+		CrossChainData: []byte(`{}`),
+		GovernanceData: []byte(`{}`),
+		ReplayData:     []byte(`{}`),
+	}
+	built, err := ecm.buildComprehensiveProof(valid, certenProof, anchorResult)
+	if err != nil {
+		t.Fatalf("buildComprehensiveProof: %v", err)
+	}
+	opID, _ := valid.OperationID()
+	if want := bls.ComputeAnchorMessageHash(opID); built.BLSProof.MessageHash != want {
+		t.Errorf("message hash = %x, want ComputeAnchorMessageHash(opID) %x", built.BLSProof.MessageHash, want)
+	}
+
+	// Without an operation ID the proof could only be rejected on-chain
+	invalid := *valid
+	invalid.IntentData = []byte(`{`)
+	if _, err := ecm.buildComprehensiveProof(&invalid, certenProof, anchorResult); err == nil {
+		t.Fatal("expected an error when the operation ID cannot be computed")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Certen Protocol
+//
+// Write-Back Key Rotation - Switching the Accumulate write-back signing key at runtime
+//
+// RotateSigningKey replaces the key the submitter signs write-backs with, without a
+// restart:
+//   - the new key must sign a probe message that verifies against its public key
+//   - the rotation waits for an in-flight submission to finish with the old key
+//   - optionally an UpdateKey transaction signed with the old key replaces the old key
+//     on the key page first; the submitter switches only once it is confirmed
+//
+// The rotation is not persisted: ACCUMULATE_WRITEBACK_PRIV_KEY must name the new key
+// before the next restart.
+
+package execution
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"gitlab.com/accumulatenetwork/accumulate/pkg/types/messaging"
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+
+	"github.com/certen/independant-validator/pkg/keys"
+)
+
+// KeyRotation is the outcome of a write-back signing key rotation
+type KeyRotation struct {
+	OldPublicKey  string    `json:"old_public_key"`
+	NewPublicKey  string    `json:"new_public_key"`
+	KeyPageIndex  uint64    `json:"key_page_index"`
+	KeyUpdateTxID string    `json:"key_update_tx_id,omitempty"` // UpdateKey transaction, when the key page was updated
+	RotatedAt     time.Time `json:"rotated_at"`
+}
+
+// ValidateSigningKey checks that key signs a random probe message with a signature that
+// verifies against its public key
+func ValidateSigningKey(key keys.KeyProvider) error {
+	if key == nil {
+		return fmt.Errorf("signing key is required")
+	}
+	publicKey := key.PublicKey()
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid Ed25519 public key: expected %d bytes, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+
+	probe := make([]byte, 32)
+	if _, err := rand.Read(probe); err != nil {
+		return fmt.Errorf("generate probe message: %w", err)
+	}
+	signature, err := key.Sign(probe)
+	if err != nil {
+		return fmt.Errorf("sign probe message: %w", err)
+	}
+	if !ed25519.Verify(publicKey, probe, signature) {
+		return fmt.Errorf("probe signature does not verify against the key's public key")
+	}
+	return nil
+}
+
+// RotateSigningKey switches write-back signing to newKey. keyPageIndex replaces the
+// configured key page index used when the key page version cannot be queried (0 keeps
+// it). With updateKeyPage, the old key first signs an UpdateKey transaction replacing
+// itself with newKey on the signer key page, and the rotation fails unless it confirms.
+func (s *AccumulateSubmitterImpl) RotateSigningKey(ctx context.Context, newKey keys.KeyProvider, keyPageIndex uint64, updateKeyPage bool) (*KeyRotation, error) {
+	if err := ValidateSigningKey(newKey); err != nil {
+		return nil, fmt.Errorf("new signing key rejected: %w", err)
+	}
+	newPublicKey := newKey.PublicKey()
+
+	// Submissions hold the lock while they sign and submit, so one in flight finishes
+	// with the old key before the switch
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if newPublicKey.Equal(s.publicKey) {
+		return nil, fmt.Errorf("new signing key is the current signing key")
+	}
+
+	rotation := &KeyRotation{
+		OldPublicKey: hex.EncodeToString(s.publicKey),
+		NewPublicKey: hex.EncodeToString(newPublicKey),
+	}
+
+	if updateKeyPage {
+		txID, err := s.submitKeyUpdate(ctx, newPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("update key page %s: %w", s.signerURL, err)
+		}
+		rotation.KeyUpdateTxID = txID
+	}
+
+	s.signer = newKey
+	s.publicKey = newPublicKey
+	if keyPageIndex != 0 {
+		s.keyPageIndex = keyPageIndex
+	}
+	rotation.KeyPageIndex = s.keyPageIndex
+	rotation.RotatedAt = time.Now().UTC()
+
+	s.logger.Printf("🔑 Rotated write-back signing key: %s... -> %s... (key page index %d)",
+		rotation.OldPublicKey[:16], rotation.NewPublicKey[:16], rotation.KeyPageIndex)
+	return rotation, nil
+}
+
+// submitKeyUpdate submits an UpdateKey transaction signed with the current key that
+// replaces it with newPublicKey on the signer key page, and waits for it to confirm.
+// Must be called with s.mu held.
+func (s *AccumulateSubmitterImpl) submitKeyUpdate(ctx context.Context, newPublicKey ed25519.PublicKey) (string, error) {
+	keyPage, err := url.Parse(s.signerURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid signer URL: %w", err)
+	}
+	newKeyHash := sha256.Sum256(newPublicKey)
+	tx := &protocol.Transaction{
+		Header: protocol.TransactionHeader{
+			Principal: keyPage,
+		},
+		Body: &protocol.UpdateKey{NewKeyHash: newKeyHash[:]},
+	}
+
+	sig, err := s.createAndSignSignature(ctx, tx, uint64(time.Now().UnixMicro()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create signature: %w", err)
+	}
+	envelope := &messaging.Envelope{
+		Transaction: []*protocol.Transaction{tx},
+		Signatures:  []protocol.Signature{sig},
+	}
+
+	var txID string
+	if s.shadow {
+		txID, err = s.simulateEnvelope(tx, envelope)
+	} else {
+		txID, err = s.submitEnvelope(ctx, envelope)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to submit key update: %w", err)
+	}
+	s.logger.Printf("📤 Submitted key update %s on %s", txID, s.signerURL)
+
+	// An unconfirmed update may still be delivered, so the key page state is unknown
+	if err := s.WaitForConfirmation(ctx, txID).Err(); err != nil {
+		return txID, fmt.Errorf("%w (check the key page before retrying)", err)
+	}
+	return txID, nil
+}
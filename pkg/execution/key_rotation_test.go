@@ -0,0 +1,87 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Write-Back Key Rotation
+// Tests key validation before the switch and signing with the rotated key
+
+package execution
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/keys"
+)
+
+// mismatchedKey signs with one key but reports the public key of another
+type mismatchedKey struct {
+	signer    *keys.FileKeyProvider
+	publicKey ed25519.PublicKey
+}
+
+func (k *mismatchedKey) PublicKey() ed25519.PublicKey        { return k.publicKey }
+func (k *mismatchedKey) Sign(message []byte) ([]byte, error) { return k.signer.Sign(message) }
+
+func newTestKey(t *testing.T) *keys.FileKeyProvider {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := keys.FromPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestRotateSigningKey(t *testing.T) {
+	oldKey := newTestKey(t)
+	s := &AccumulateSubmitterImpl{
+		signer:       oldKey,
+		publicKey:    oldKey.PublicKey(),
+		keyPageIndex: 1,
+		logger:       log.New(io.Discard, "", 0),
+	}
+	ctx := context.Background()
+
+	t.Run("rejects key with mismatched public key", func(t *testing.T) {
+		bad := &mismatchedKey{signer: newTestKey(t), publicKey: newTestKey(t).PublicKey()}
+		if _, err := s.RotateSigningKey(ctx, bad, 2, false); err == nil || !strings.Contains(err.Error(), "does not verify") {
+			t.Errorf("expected a verification error, got %v", err)
+		}
+		if !s.GetPublicKey().Equal(oldKey.PublicKey()) {
+			t.Error("rejected key must not replace the signing key")
+		}
+	})
+
+	t.Run("rejects current key", func(t *testing.T) {
+		if _, err := s.RotateSigningKey(ctx, oldKey, 0, false); err == nil {
+			t.Error("expected an error rotating to the current key")
+		}
+	})
+
+	t.Run("switches key and key page index", func(t *testing.T) {
+		newKey := newTestKey(t)
+		rotation, err := s.RotateSigningKey(ctx, newKey, 2, false)
+		if err != nil {
+			t.Fatalf("rotate: %v", err)
+		}
+		if rotation.OldPublicKey != hex.EncodeToString(oldKey.PublicKey()) || rotation.NewPublicKey != s.GetPublicKeyHex() {
+			t.Errorf("unexpected rotation %+v", rotation)
+		}
+		if s.GetKeyPageIndex() != 2 || rotation.KeyPageIndex != 2 || rotation.KeyUpdateTxID != "" {
+			t.Errorf("expected key page index 2 without a key update, got %+v", rotation)
+		}
+
+		signature, err := s.signer.Sign([]byte("write-back"))
+		if err != nil || !ed25519.Verify(newKey.PublicKey(), []byte("write-back"), signature) {
+			t.Errorf("expected write-backs to be signed with the new key: %v", err)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Cycle Events - In-process pub/sub of proof cycle stage transitions
+//
+// The orchestrator publishes an event every time a cycle enters a stage, completes or
+// fails. API handlers subscribe to push progress to clients instead of having them
+// poll the proof endpoint. Publishing never blocks the orchestrator: a subscriber that
+// falls behind its buffer misses events rather than stalling proof cycles.
+
+package execution
+
+import (
+	"sync"
+	"time"
+)
+
+// Terminal proof cycle stages published after the last in-flight stage
+const (
+	ProofCycleStageCompleted = "completed"
+	ProofCycleStageFailed    = "failed"
+
+	// The write-back was submitted but not confirmed in time; it may still be delivered
+	ProofCycleStageWriteBackUnconfirmed = "write_back_unconfirmed"
+)
+
+// proofCycleEventBuffer is the number of events a subscriber may fall behind by
+const proofCycleEventBuffer = 16
+
+// ProofCycleEvent is a proof cycle stage transition
+type ProofCycleEvent struct {
+	CycleID      string    `json:"cycle_id"`
+	IntentID     string    `json:"intent_id"`
+	IntentTxHash string    `json:"intent_tx_hash,omitempty"`
+	Stage        string    `json:"stage"`
+	Phase        int       `json:"phase,omitempty"` // 7-9 for in-flight stages
+	Terminal     bool      `json:"terminal"`
+	Error        string    `json:"error,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// ProofCycleEventBus fans proof cycle events out to subscribers and remembers the
+// latest event of every in-flight cycle
+type ProofCycleEventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan ProofCycleEvent
+	nextID      int
+	latest      map[string]ProofCycleEvent
+}
+
+// NewProofCycleEventBus creates an event bus with no subscribers
+func NewProofCycleEventBus() *ProofCycleEventBus {
+	return &ProofCycleEventBus{
+		subscribers: make(map[int]chan ProofCycleEvent),
+		latest:      make(map[string]ProofCycleEvent),
+	}
+}
+
+// Publish delivers an event to every subscriber without blocking
+func (b *ProofCycleEventBus) Publish(event ProofCycleEvent) {
+	if b == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if event.Terminal {
+		delete(b.latest, event.CycleID)
+	} else {
+		b.latest[event.CycleID] = event
+	}
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of events published from now on, the latest event of
+// every in-flight cycle, and a function that ends the subscription
+func (b *ProofCycleEventBus) Subscribe() (<-chan ProofCycleEvent, []ProofCycleEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan ProofCycleEvent, proofCycleEventBuffer)
+	b.subscribers[id] = ch
+
+	current := make([]ProofCycleEvent, 0, len(b.latest))
+	for _, event := range b.latest {
+		current = append(current, event)
+	}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, id)
+			b.mu.Unlock()
+		})
+	}
+	return ch, current, unsubscribe
+}
+
+// SubscriberCount returns the number of active subscriptions
+func (b *ProofCycleEventBus) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// proofCyclePhase maps an in-flight stage to its proof cycle phase
+func proofCyclePhase(stage string) int {
+	switch stage {
+	case ProofCycleStageObserving:
+		return 7
+	case ProofCycleStageAttesting:
+		return 8
+	case ProofCycleStageWritingBack:
+		return 9
+	}
+	return 0
+}
+
+// SetEventBus publishes the orchestrator's stage transitions to bus (nil disables it)
+func (o *ProofCycleOrchestrator) SetEventBus(bus *ProofCycleEventBus) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = bus
+}
+
+// publishCycleEvent publishes a stage transition for a cycle; err is set for failures
+func (o *ProofCycleOrchestrator) publishCycleEvent(cycleID string, cycle *ProofCycleCompletion, stage string, err error) {
+	o.mu.RLock()
+	bus := o.events
+	event := ProofCycleEvent{
+		CycleID:  cycleID,
+		Stage:    stage,
+		Phase:    proofCyclePhase(stage),
+		Terminal: stage == ProofCycleStageCompleted || stage == ProofCycleStageFailed || stage == ProofCycleStageWriteBackUnconfirmed,
+	}
+	if cycle != nil {
+		event.IntentID = cycle.IntentID
+		event.IntentTxHash = cycle.IntentTxHash
+	}
+	o.mu.RUnlock()
+	if bus == nil {
+		return
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	bus.Publish(event)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof Cycle Events
+// Tests fan-out of stage transitions, the snapshot of in-flight cycles and the
+// proof cycle stage gauges
+
+package execution
+
+import (
+	"errors"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/metrics"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestProofCycleEventBus_PublishSubscribe(t *testing.T) {
+	bus := NewProofCycleEventBus()
+	o := &ProofCycleOrchestrator{
+		config:       &ProofCycleConfig{},
+		activeCycles: map[string]*ProofCycleCompletion{"c1": {IntentID: "intent-1", IntentTxHash: "0xabc"}},
+		cycleStages:  make(map[string]string),
+		cycleStore:   nullCycleStateStore{},
+		events:       bus,
+		logger:       log.New(io.Discard, "", 0),
+	}
+
+	o.setCycleStage("c1", ProofCycleStageObserving)
+
+	// A late subscriber sees the current stage of in-flight cycles
+	events, current, unsubscribe := bus.Subscribe()
+	if len(current) != 1 || current[0].Stage != ProofCycleStageObserving || current[0].Phase != 7 || current[0].IntentID != "intent-1" {
+		t.Fatalf("expected the observing stage in the snapshot, got %+v", current)
+	}
+
+	o.setCycleStage("c1", ProofCycleStageAttesting)
+	o.setCycleStage("c1", ProofCycleStageAttesting) // Unchanged stage is not republished
+	o.handleCycleFailed("c1", errors.New("verification failed"))
+
+	if event := <-events; event.Stage != ProofCycleStageAttesting || event.Phase != 8 || event.Terminal {
+		t.Errorf("expected attesting transition, got %+v", event)
+	}
+	if event := <-events; event.Stage != ProofCycleStageFailed || !event.Terminal || event.Error == "" || event.IntentTxHash != "0xabc" {
+		t.Errorf("expected terminal failure, got %+v", event)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	default:
+	}
+
+	// Finished cycles leave the snapshot
+	if _, current, unsubscribeLate := bus.Subscribe(); len(current) != 0 {
+		t.Errorf("expected no in-flight cycles, got %+v", current)
+	} else {
+		unsubscribeLate()
+	}
+
+	// A subscriber that stops reading does not block publishing
+	for i := 0; i < proofCycleEventBuffer*2; i++ {
+		bus.Publish(ProofCycleEvent{CycleID: "c2", Stage: ProofCycleStageObserving})
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if bus.SubscriberCount() != 0 {
+		t.Errorf("expected no subscribers, got %d", bus.SubscriberCount())
+	}
+}
+
+func TestSetCycleStage_StageGauges(t *testing.T) {
+	registry := metrics.NewRegistry()
+	o := &ProofCycleOrchestrator{
+		config:       &ProofCycleConfig{Metrics: registry},
+		activeCycles: map[string]*ProofCycleCompletion{"c1": {IntentID: "intent-1"}, "c2": {IntentID: "intent-2"}},
+		cycleStages:  make(map[string]string),
+		cycleStore:   nullCycleStateStore{},
+		events:       NewProofCycleEventBus(),
+		logger:       log.New(io.Discard, "", 0),
+	}
+
+	stages := []string{ProofCycleStageObserving, ProofCycleStageAttesting, ProofCycleStageWritingBack}
+	gauges := func() map[string]float64 {
+		families, err := registry.Gatherer().Gather()
+		if err != nil {
+			t.Fatalf("Gather failed: %v", err)
+		}
+		values := make(map[string]float64)
+		for _, family := range families {
+			if family.GetName() != "certen_proof_cycle_stage" {
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				values[stageLabel(m)] = m.GetGauge().GetValue()
+			}
+		}
+		return values
+	}
+	expect := func(step string, want map[string]float64) {
+		t.Helper()
+		got := gauges()
+		for _, stage := range stages {
+			if got[stage] < 0 {
+				t.Errorf("%s: stage %s went negative (%v)", step, stage, got[stage])
+			}
+			if got[stage] != want[stage] {
+				t.Errorf("%s: stage %s = %v, want %v", step, stage, got[stage], want[stage])
+			}
+		}
+	}
+
+	o.setCycleStage("c1", ProofCycleStageObserving)
+	o.setCycleStage("c2", ProofCycleStageObserving)
+	expect("both observing", map[string]float64{ProofCycleStageObserving: 2})
+
+	o.setCycleStage("c1", ProofCycleStageAttesting)
+	o.setCycleStage("c1", ProofCycleStageAttesting)
+	o.setCycleStage("c1", ProofCycleStageWritingBack)
+	expect("c1 writing back", map[string]float64{ProofCycleStageObserving: 1, ProofCycleStageWritingBack: 1})
+
+	// c2 fails; a late phase 8 for it must not count it again, and removing it twice is harmless
+	o.handleCycleFailed("c2", errors.New("observation timed out"))
+	o.setCycleStage("c2", ProofCycleStageAttesting)
+	o.setCycleStage("c2", "")
+	expect("c2 failed", map[string]float64{ProofCycleStageWritingBack: 1})
+
+	// c1 completes
+	o.mu.Lock()
+	delete(o.activeCycles, "c1")
+	o.mu.Unlock()
+	o.setCycleStage("c1", "")
+	o.setCycleStage("c1", "")
+	expect("all finished", map[string]float64{})
+}
+
+func stageLabel(m *dto.Metric) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == "stage" {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
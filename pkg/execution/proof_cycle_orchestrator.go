@@ -26,6 +26,7 @@ import (
 
 	"github.com/certen/independant-validator/pkg/crypto/bls"
 	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/metrics"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 )
@@ -60,6 +61,7 @@ type ProofCycleOrchestrator struct {
 
 	// Active proof cycles
 	activeCycles map[string]*ProofCycleCompletion
+	cycleStages  map[string]string // Current stage of each active cycle (for metrics)
 
 	// Callbacks
 	onCycleComplete func(*ProofCycleCompletion)
@@ -68,6 +70,13 @@ type ProofCycleOrchestrator struct {
 	// Database repositories for persistence
 	repos *database.Repositories
 
+	// In-flight cycle state, so cycles survive a restart (never nil; nullCycleStateStore
+	// when not persisted)
+	cycleStore ProofCycleStateStore
+
+	// Stage transitions for live progress subscribers (nil = not published)
+	events *ProofCycleEventBus
+
 	// Logging
 	logger Logger
 }
@@ -90,10 +99,24 @@ type ProofCycleConfig struct {
 	AccumulatePrincipal string
 	WriteBackEnabled    bool
 
+	// Shadow mode: the submitter signs and logs write-backs without submitting them,
+	// and persisted cycle states are flagged as shadow
+	Shadow bool
+
 	// BLS signing key
 	BLSPrivateKey []byte
+
+	// Prometheus metrics (nil = disabled)
+	Metrics *metrics.Registry
 }
 
+// Proof cycle stages reported by the certen_proof_cycle_stage metric
+const (
+	ProofCycleStageObserving   = "observing"    // Phase 7: observing the external chain
+	ProofCycleStageAttesting   = "attesting"    // Phase 8: verifying and collecting attestations
+	ProofCycleStageWritingBack = "writing_back" // Phase 9: writing the result back to Accumulate
+)
+
 // NewProofCycleOrchestrator creates a new proof cycle orchestrator
 func NewProofCycleOrchestrator(
 	validatorID string,
@@ -161,13 +184,19 @@ func NewProofCycleOrchestrator(
 		txBuilder:        txBuilder,
 		config:           config,
 		activeCycles:     make(map[string]*ProofCycleCompletion),
+		cycleStages:      make(map[string]string),
 		repos:            repos,
+		cycleStore:       nullCycleStateStore{},
 		logger:           logger,
 	}
+	if repos != nil && repos.ProofCycles != nil {
+		orchestrator.cycleStore = repos.ProofCycles
+	}
 
 	// Set up callbacks
 	collector.SetThresholdCallback(orchestrator.onAttestationThreshold)
 	writeBack.SetCallbacks(orchestrator.onWriteBackConfirmed, orchestrator.onWriteBackFailed)
+	writeBack.SetUnconfirmedCallback(orchestrator.onWriteBackUnconfirmed)
 
 	return orchestrator, nil
 }
@@ -225,6 +254,8 @@ func (o *ProofCycleOrchestrator) StartProofCycle(
 	}
 	o.activeCycles[cycleID] = cycle
 	o.mu.Unlock()
+	o.setCycleStage(cycleID, ProofCycleStageObserving)
+	o.saveCycleState(cycleID, ProofCycleStageObserving, executionTxHash, nil)
 
 	o.logger.Printf("🔄 [PROOF-CYCLE] Starting proof cycle: %s", cycleID)
 
@@ -323,6 +354,8 @@ func (o *ProofCycleOrchestrator) StartProofCycleWithAllTxs(
 	}
 	o.activeCycles[cycleID] = cycle
 	o.mu.Unlock()
+	o.setCycleStage(cycleID, ProofCycleStageObserving)
+	o.saveCycleState(cycleID, ProofCycleStageObserving, common.Hash{}, nil)
 
 	o.logger.Printf("🔄 [PROOF-CYCLE] Starting enhanced proof cycle: %s", cycleID)
 	o.logger.Printf("   📋 Tracking all 3 anchor workflow transactions:")
@@ -548,6 +581,8 @@ func (o *ProofCycleOrchestrator) executePhase8(
 	commitment *ExecutionCommitment,
 ) {
 	o.logger.Printf("🔐 [PHASE-8] Verifying result and creating attestation")
+	o.setCycleStage(cycleID, ProofCycleStageAttesting)
+	o.saveCycleState(cycleID, ProofCycleStageAttesting, common.Hash{}, nil)
 
 	// Verify and create attestation
 	attestation, err := o.verifier.VerifyAndAttest(result, commitment)
@@ -626,6 +661,8 @@ func (o *ProofCycleOrchestrator) executePhase9(
 	o.mu.Lock()
 	cycle.Attestation = agg
 	o.mu.Unlock()
+	o.setCycleStage(cycleID, ProofCycleStageWritingBack)
+	o.saveCycleState(cycleID, ProofCycleStageWritingBack, common.Hash{}, nil)
 
 	if !o.config.WriteBackEnabled {
 		o.logger.Printf("⚠️ [PHASE-9] Write-back disabled, completing cycle without Accumulate submission")
@@ -639,12 +676,27 @@ func (o *ProofCycleOrchestrator) executePhase9(
 	// Build ComprehensiveProofContext from cycle data for full audit support
 	proofCtx := o.buildComprehensiveProofContext(cycle, result, agg)
 
+	tx, err := o.writeBack.BuildResult(bundle, proofCtx)
+	if err != nil {
+		o.handleCycleFailed(cycleID, fmt.Errorf("phase 9 write-back failed: %w", err))
+		return
+	}
+
+	// Persist the transaction before submitting it and again with its receipt, so a
+	// restart never submits a second write-back for this cycle
+	o.saveCycleState(cycleID, ProofCycleStageWritingBack, common.Hash{}, tx)
+
 	// Submit to Accumulate with context
-	if err := o.writeBack.WriteResultWithContext(ctx, bundle, proofCtx); err != nil {
+	if err := o.writeBack.SubmitResult(ctx, tx); err != nil {
 		o.handleCycleFailed(cycleID, fmt.Errorf("phase 9 write-back failed: %w", err))
 		return
 	}
+	o.saveCycleState(cycleID, ProofCycleStageWritingBack, common.Hash{}, tx)
 
+	if o.config.Shadow {
+		o.logger.Printf("🕶️ [SHADOW] [PHASE-9] Write-back built and signed with comprehensive proof context (not submitted)")
+		return
+	}
 	o.logger.Printf("✅ [PHASE-9] Write-back submitted with comprehensive proof context, awaiting confirmation")
 }
 
@@ -882,6 +934,39 @@ func (o *ProofCycleOrchestrator) onWriteBackFailed(tx *SyntheticTransaction, err
 	}
 }
 
+// onWriteBackUnconfirmed handles a write-back that was submitted but not confirmed in
+// time. The transaction may still be delivered, so the cycle ends in its own stage
+// rather than as failed, with the Accumulate txid recorded for operator lookup.
+func (o *ProofCycleOrchestrator) onWriteBackUnconfirmed(tx *SyntheticTransaction, err error) {
+	o.logger.Printf("⚠️ [PHASE-9] Write-back unconfirmed: %v", err)
+
+	o.mu.Lock()
+	var cycleID string
+	var cycle *ProofCycleCompletion
+	for id, c := range o.activeCycles {
+		if c.BundleID == tx.OriginBundleID {
+			cycleID = id
+			cycle = c
+			break
+		}
+	}
+	o.mu.Unlock()
+	if cycle == nil {
+		return
+	}
+
+	// Record the transaction with its confirmation result before finishing the cycle
+	o.saveCycleState(cycleID, ProofCycleStageWritingBack, common.Hash{}, tx)
+
+	o.mu.Lock()
+	cycle.WriteBackTx = tx
+	delete(o.activeCycles, cycleID)
+	o.mu.Unlock()
+	o.setCycleStage(cycleID, "")
+	o.finishCycleState(cycleID, database.ProofCycleStageWriteBackUnconfirmed, err.Error())
+	o.publishCycleEvent(cycleID, cycle, ProofCycleStageWriteBackUnconfirmed, err)
+}
+
 // completeCycle marks a proof cycle as complete
 func (o *ProofCycleOrchestrator) completeCycle(
 	cycleID string,
@@ -894,6 +979,8 @@ func (o *ProofCycleOrchestrator) completeCycle(
 	cycle.Finalize()
 	delete(o.activeCycles, cycleID)
 	o.mu.Unlock()
+	o.setCycleStage(cycleID, "")
+	o.finishCycleState(cycleID, database.ProofCycleStageCompleted, "")
 
 	o.logger.Printf("🏆 [PROOF-CYCLE] Complete proof cycle finished!")
 	o.logger.Printf("   Cycle ID: %s", cycleID)
@@ -907,6 +994,7 @@ func (o *ProofCycleOrchestrator) completeCycle(
 	} else {
 		o.logger.Printf("✅ [PROOF-CYCLE] Proof artifact persisted to database")
 	}
+	o.publishCycleEvent(cycleID, cycle, ProofCycleStageCompleted, nil)
 
 	if o.onCycleComplete != nil {
 		go o.onCycleComplete(cycle)
@@ -1057,6 +1145,9 @@ func (o *ProofCycleOrchestrator) persistProofArtifact(cycle *ProofCycleCompletio
 		if cycle.WriteBackTx.TxHash != ([32]byte{}) {
 			writebackData["tx_hash"] = hex.EncodeToString(cycle.WriteBackTx.TxHash[:])
 		}
+		if cycle.WriteBackTx.TxReceipt != "" {
+			writebackData["accumulate_tx_id"] = cycle.WriteBackTx.TxReceipt
+		}
 		if !cycle.WriteBackTx.ConfirmedAt.IsZero() {
 			writebackData["confirmed_at"] = cycle.WriteBackTx.ConfirmedAt.Format(time.RFC3339)
 		}
@@ -1493,14 +1584,43 @@ func (o *ProofCycleOrchestrator) handleCycleFailed(cycleID string, err error) {
 	o.logger.Printf("❌ [PROOF-CYCLE] Cycle failed: %s - %v", cycleID, err)
 
 	o.mu.Lock()
+	cycle := o.activeCycles[cycleID]
 	delete(o.activeCycles, cycleID)
 	o.mu.Unlock()
+	o.setCycleStage(cycleID, "")
+	o.finishCycleState(cycleID, database.ProofCycleStageFailed, err.Error())
+	o.publishCycleEvent(cycleID, cycle, ProofCycleStageFailed, err)
 
 	if o.onCycleFailed != nil {
 		go o.onCycleFailed(cycleID, err)
 	}
 }
 
+// setCycleStage records the stage of an active cycle; an empty stage removes it.
+// Each cycle is counted in at most one stage gauge: a stage is only added while the
+// cycle is active, and only the recorded stage is decremented, so a late phase of a
+// cycle that already failed cannot leave a gauge behind or drive one negative.
+func (o *ProofCycleOrchestrator) setCycleStage(cycleID, stage string) {
+	o.mu.Lock()
+	previous := o.cycleStages[cycleID]
+	cycle := o.activeCycles[cycleID]
+	if stage != "" && cycle == nil {
+		o.mu.Unlock()
+		return
+	}
+	if stage == "" {
+		delete(o.cycleStages, cycleID)
+	} else {
+		o.cycleStages[cycleID] = stage
+	}
+	o.mu.Unlock()
+
+	o.config.Metrics.MoveProofCycleStage(previous, stage)
+	if stage != "" && stage != previous {
+		o.publishCycleEvent(cycleID, cycle, stage, nil)
+	}
+}
+
 // =============================================================================
 // CALLBACK SETTERS
 // =============================================================================
@@ -1516,6 +1636,11 @@ func (o *ProofCycleOrchestrator) SetCycleCallbacks(
 	o.onCycleFailed = onFailed
 }
 
+// UpdateValidatorSet replaces the validator set Phase 8 attestations are weighed against
+func (o *ProofCycleOrchestrator) UpdateValidatorSet(validatorSet *ValidatorSet) {
+	o.collector.UpdateValidatorSet(validatorSet)
+}
+
 // =============================================================================
 // STATUS METHODS
 // =============================================================================
@@ -1725,12 +1850,14 @@ func NewMultiValidatorSet(validators []struct {
 	return vs
 }
 
-// LoadValidatorSetFromContract loads the validator set from an on-chain contract
-// This will be implemented when the validator registry contract is deployed
-func LoadValidatorSetFromContract(ctx context.Context, contractAddress common.Address) (*ValidatorSet, error) {
-	// TODO: Implement contract call to load validators
-	// For now, return an error indicating the contract is not available
-	return nil, fmt.Errorf("validator registry contract not yet deployed at %s", contractAddress.Hex())
+// LoadValidatorSetFromContract loads the validator set registered in the contract once
+// Use a ValidatorSetSyncer to keep it current
+func LoadValidatorSetFromContract(ctx context.Context, source ValidatorSetSource, validatorIDs map[common.Address]string) (*ValidatorSet, error) {
+	syncer, err := NewValidatorSetSyncer(source, &ValidatorSetSyncerConfig{ValidatorIDs: validatorIDs})
+	if err != nil {
+		return nil, err
+	}
+	return syncer.read(ctx)
 }
 
 // =============================================================================
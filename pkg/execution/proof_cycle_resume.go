@@ -0,0 +1,210 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof Cycle Resume - Continuing proof cycles interrupted by a restart
+//
+// Active cycles only live in memory, so a restart between the Ethereum confirmation
+// wait and the Accumulate write-back would drop the write-back. The orchestrator saves
+// each cycle's stage and artifacts as it advances, and ResumePendingCycles picks up
+// every unfinished cycle on startup:
+//   - observing: the external chain transactions are observed again
+//   - attesting: the observed result is verified and attested again
+//   - writing_back: the write-back is built and submitted unless one was already
+//     persisted; a write-back with a receipt is only watched for confirmation, and one
+//     persisted without a receipt may have reached Accumulate, so the cycle is failed
+//     for operator review instead of submitting a duplicate
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProofCycleStateStore persists in-flight proof cycles
+type ProofCycleStateStore interface {
+	SaveProofCycleState(ctx context.Context, state *database.ProofCycleState) error
+	FinishProofCycle(ctx context.Context, cycleID, stage, errorMessage string) error
+	ListPendingProofCycles(ctx context.Context) ([]*database.ProofCycleState, error)
+}
+
+// nullCycleStateStore is the ProofCycleStateStore of an orchestrator without a
+// database: nothing is persisted and nothing is resumed
+type nullCycleStateStore struct{}
+
+func (nullCycleStateStore) SaveProofCycleState(ctx context.Context, state *database.ProofCycleState) error {
+	return nil
+}
+
+func (nullCycleStateStore) FinishProofCycle(ctx context.Context, cycleID, stage, errorMessage string) error {
+	return nil
+}
+
+func (nullCycleStateStore) ListPendingProofCycles(ctx context.Context) ([]*database.ProofCycleState, error) {
+	return nil, nil
+}
+
+// SetCycleStateStore replaces the store of in-flight cycle state (nil disables it)
+func (o *ProofCycleOrchestrator) SetCycleStateStore(store ProofCycleStateStore) {
+	if store == nil {
+		store = nullCycleStateStore{}
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cycleStore = store
+}
+
+// saveCycleState persists an active cycle at the given stage. executionTxHash is only
+// set for single-transaction cycles and writeBackTx once the write-back is built.
+// Failures are logged; the cycle continues in memory.
+func (o *ProofCycleOrchestrator) saveCycleState(
+	cycleID string,
+	stage string,
+	executionTxHash common.Hash,
+	writeBackTx *SyntheticTransaction,
+) {
+	o.mu.RLock()
+	store := o.cycleStore
+	cycle, ok := o.activeCycles[cycleID]
+	if !ok {
+		o.mu.RUnlock()
+		return
+	}
+	state := &database.ProofCycleState{
+		CycleID:  cycleID,
+		IntentID: cycle.IntentID,
+		Stage:    stage,
+		Shadow:   o.config.Shadow,
+	}
+	var err error
+	state.CycleData, err = json.Marshal(cycle)
+	if err == nil && writeBackTx != nil {
+		state.WriteBackTx, state.WriteBackTxID, err = o.writeBack.encodeTransaction(writeBackTx)
+	}
+	o.mu.RUnlock()
+	if err != nil {
+		o.logger.Printf("⚠️ [PROOF-CYCLE] Failed to encode cycle state %s: %v", cycleID, err)
+		return
+	}
+	if executionTxHash != (common.Hash{}) {
+		state.ExecutionTxHash = executionTxHash.Hex()
+	}
+
+	if err := store.SaveProofCycleState(context.Background(), state); err != nil {
+		o.logger.Printf("⚠️ [PROOF-CYCLE] Failed to persist cycle state %s: %v", cycleID, err)
+	}
+}
+
+// finishCycleState marks a persisted cycle completed or failed
+func (o *ProofCycleOrchestrator) finishCycleState(cycleID, stage, errorMessage string) {
+	o.mu.RLock()
+	store := o.cycleStore
+	o.mu.RUnlock()
+	if err := store.FinishProofCycle(context.Background(), cycleID, stage, errorMessage); err != nil {
+		o.logger.Printf("⚠️ [PROOF-CYCLE] Failed to finish cycle state %s: %v", cycleID, err)
+	}
+}
+
+// ResumePendingCycles reloads the cycles that were in flight when the validator
+// stopped and continues each from its persisted stage. It returns the number of
+// cycles resumed; cycles whose state cannot be decoded are failed.
+func (o *ProofCycleOrchestrator) ResumePendingCycles(ctx context.Context) (int, error) {
+	o.mu.RLock()
+	store := o.cycleStore
+	o.mu.RUnlock()
+
+	states, err := store.ListPendingProofCycles(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("load pending proof cycles: %w", err)
+	}
+
+	resumed := 0
+	for _, state := range states {
+		if err := o.resumeCycle(ctx, state); err != nil {
+			o.logger.Printf("❌ [PROOF-CYCLE] Cannot resume cycle %s: %v", state.CycleID, err)
+			o.finishCycleState(state.CycleID, database.ProofCycleStageFailed, err.Error())
+			continue
+		}
+		resumed++
+	}
+	return resumed, nil
+}
+
+// resumeCycle re-registers a persisted cycle and continues it from its stage
+func (o *ProofCycleOrchestrator) resumeCycle(ctx context.Context, state *database.ProofCycleState) error {
+	var cycle ProofCycleCompletion
+	if err := json.Unmarshal(state.CycleData, &cycle); err != nil {
+		return fmt.Errorf("decode cycle data: %w", err)
+	}
+	var writeBackTx *SyntheticTransaction
+	if len(state.WriteBackTx) > 0 {
+		writeBackTx = &SyntheticTransaction{}
+		if err := json.Unmarshal(state.WriteBackTx, writeBackTx); err != nil {
+			return fmt.Errorf("decode write-back transaction: %w", err)
+		}
+	}
+
+	// Check the stage has what it needs before registering the cycle
+	switch state.Stage {
+	case ProofCycleStageObserving:
+		if cycle.GovernanceTxHash == (common.Hash{}) && state.ExecutionTxHash == "" {
+			return fmt.Errorf("no transaction to observe")
+		}
+	case ProofCycleStageAttesting, ProofCycleStageWritingBack:
+		if cycle.ExecutionResult == nil {
+			return fmt.Errorf("no observed result for stage %s", state.Stage)
+		}
+		if state.Stage == ProofCycleStageWritingBack && writeBackTx == nil && cycle.Attestation == nil {
+			return fmt.Errorf("no aggregated attestation to write back")
+		}
+	default:
+		return fmt.Errorf("unknown stage %q", state.Stage)
+	}
+
+	o.mu.Lock()
+	if _, exists := o.activeCycles[state.CycleID]; exists {
+		o.mu.Unlock()
+		return nil
+	}
+	o.activeCycles[state.CycleID] = &cycle
+	o.mu.Unlock()
+	o.setCycleStage(state.CycleID, state.Stage)
+
+	o.logger.Printf("🔁 [PROOF-CYCLE] Resuming cycle %s at stage %s", state.CycleID, state.Stage)
+
+	switch state.Stage {
+	case ProofCycleStageObserving:
+		if cycle.GovernanceTxHash != (common.Hash{}) {
+			txHashes := &AnchorWorkflowTxHashes{
+				CreateTxHash:     cycle.CreateTxHash,
+				VerifyTxHash:     cycle.VerifyTxHash,
+				GovernanceTxHash: cycle.GovernanceTxHash,
+				PrimaryTxHash:    cycle.GovernanceTxHash,
+			}
+			go o.executePhase7Enhanced(ctx, state.CycleID, &cycle, txHashes, cycle.Commitment)
+		} else {
+			go o.executePhase7(ctx, state.CycleID, &cycle, common.HexToHash(state.ExecutionTxHash), cycle.Commitment)
+		}
+
+	case ProofCycleStageAttesting:
+		go o.executePhase8(ctx, state.CycleID, &cycle, cycle.ExecutionResult, cycle.Commitment)
+
+	case ProofCycleStageWritingBack:
+		switch {
+		case writeBackTx == nil:
+			// Never persisted, so never submitted
+			go o.executePhase9(ctx, state.CycleID, &cycle, cycle.ExecutionResult, cycle.Attestation)
+		case writeBackTx.TxReceipt != "":
+			o.logger.Printf("   Write-back %s already submitted, awaiting confirmation", writeBackTx.TxReceipt)
+			o.writeBack.ResumeConfirmation(ctx, writeBackTx)
+		default:
+			// The submission may or may not have reached Accumulate
+			go o.handleCycleFailed(state.CycleID,
+				fmt.Errorf("write-back %s was interrupted during submission; outcome unknown, not resubmitting", writeBackTx.ToHex()))
+		}
+	}
+	return nil
+}
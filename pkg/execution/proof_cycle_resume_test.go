@@ -0,0 +1,223 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof Cycle Resume
+// Tests resuming persisted cycles after a restart without duplicating write-backs
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// memoryCycleStore is an in-memory ProofCycleStateStore with the repository's semantics
+type memoryCycleStore struct {
+	mu     sync.Mutex
+	states map[string]*database.ProofCycleState
+}
+
+func newMemoryCycleStore() *memoryCycleStore {
+	return &memoryCycleStore{states: make(map[string]*database.ProofCycleState)}
+}
+
+func (s *memoryCycleStore) SaveProofCycleState(ctx context.Context, state *database.ProofCycleState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.states[state.CycleID]
+	if !ok {
+		saved := *state
+		s.states[state.CycleID] = &saved
+		return nil
+	}
+	if existing.Stage == database.ProofCycleStageCompleted || existing.Stage == database.ProofCycleStageFailed {
+		return nil
+	}
+	existing.Stage = state.Stage
+	existing.CycleData = state.CycleData
+	if state.WriteBackTx != nil {
+		existing.WriteBackTx = state.WriteBackTx
+	}
+	if state.WriteBackTxID != "" {
+		existing.WriteBackTxID = state.WriteBackTxID
+	}
+	return nil
+}
+
+func (s *memoryCycleStore) FinishProofCycle(ctx context.Context, cycleID, stage, errorMessage string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state, ok := s.states[cycleID]; ok {
+		state.Stage = stage
+		state.ErrorMessage = errorMessage
+	}
+	return nil
+}
+
+func (s *memoryCycleStore) ListPendingProofCycles(ctx context.Context) ([]*database.ProofCycleState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []*database.ProofCycleState
+	for _, state := range s.states {
+		if state.Stage != database.ProofCycleStageCompleted && state.Stage != database.ProofCycleStageFailed {
+			saved := *state
+			pending = append(pending, &saved)
+		}
+	}
+	return pending, nil
+}
+
+func (s *memoryCycleStore) get(cycleID string) database.ProofCycleState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.states[cycleID]
+}
+
+// countingSubmitter records Accumulate submissions; submitted transactions stay pending
+type countingSubmitter struct {
+	mu      sync.Mutex
+	submits int
+}
+
+func (s *countingSubmitter) SubmitTransaction(ctx context.Context, tx *SyntheticTransaction) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.submits++
+	return "receipt-1", nil
+}
+
+func (s *countingSubmitter) GetTransactionStatus(ctx context.Context, txHash string) (string, error) {
+	return "pending", nil
+}
+
+func (s *countingSubmitter) WaitForConfirmation(ctx context.Context, txID string) *WriteBackConfirmation {
+	<-ctx.Done()
+	return &WriteBackConfirmation{TxID: txID, Outcome: WriteBackPending}
+}
+
+func (s *countingSubmitter) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.submits
+}
+
+// newResumeTestOrchestrator builds an orchestrator with write-back only, standing in
+// for a validator process
+func newResumeTestOrchestrator(store ProofCycleStateStore, submitter AccumulateSubmitter) *ProofCycleOrchestrator {
+	builder := NewSyntheticTxBuilder("acc://certen.acme/results", "validator-1", make([]byte, 32))
+	o := &ProofCycleOrchestrator{
+		validatorID:  "validator-1",
+		writeBack:    NewResultWriteBack(builder, submitter),
+		txBuilder:    builder,
+		config:       &ProofCycleConfig{WriteBackEnabled: true},
+		activeCycles: make(map[string]*ProofCycleCompletion),
+		cycleStages:  make(map[string]string),
+		cycleStore:   store,
+		logger:       log.New(io.Discard, "", 0),
+	}
+	o.writeBack.SetCallbacks(o.onWriteBackConfirmed, o.onWriteBackFailed)
+	o.writeBack.SetUnconfirmedCallback(o.onWriteBackUnconfirmed)
+	return o
+}
+
+func TestProofCycleResume_WriteBackNotDuplicated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newMemoryCycleStore()
+	submitter := &countingSubmitter{}
+	first := newResumeTestOrchestrator(store, submitter)
+
+	txHash := common.HexToHash("0xabc1")
+	result := &ExternalChainResult{
+		Chain:       "sepolia",
+		ChainID:     11155111,
+		TxHash:      txHash,
+		BlockNumber: big.NewInt(100),
+		Status:      1,
+		ResultHash:  [32]byte{1},
+	}
+	agg := &AggregatedAttestation{
+		ResultHash:        result.ResultHash,
+		BlockNumber:       big.NewInt(100),
+		ValidatorCount:    1,
+		TotalVotingPower:  big.NewInt(1),
+		SignedVotingPower: big.NewInt(1),
+		ThresholdMet:      true,
+		Finalized:         true,
+	}
+	cycleID := "intent-0000000000000001:" + txHash.Hex()
+	cycle := &ProofCycleCompletion{
+		IntentID:        "intent-0000000000000001",
+		BundleID:        [32]byte{2},
+		ExecutionResult: result,
+	}
+	first.activeCycles[cycleID] = cycle
+	first.saveCycleState(cycleID, ProofCycleStageObserving, txHash, nil)
+	if state := store.get(cycleID); state.Stage != ProofCycleStageObserving || state.ExecutionTxHash != txHash.Hex() {
+		t.Fatalf("expected observing state with execution tx, got %+v", state)
+	}
+
+	// The write-back is submitted and persisted with its receipt
+	first.executePhase9(ctx, cycleID, cycle, result, agg)
+	if submitter.count() != 1 {
+		t.Fatalf("expected 1 submission, got %d", submitter.count())
+	}
+	submittedState := store.get(cycleID)
+	if submittedState.Stage != ProofCycleStageWritingBack || len(submittedState.WriteBackTx) == 0 {
+		t.Fatalf("expected persisted write-back, got %+v", submittedState)
+	}
+
+	// After a restart the submitted write-back is watched, not resubmitted
+	second := newResumeTestOrchestrator(store, submitter)
+	resumed, err := second.ResumePendingCycles(ctx)
+	if err != nil || resumed != 1 {
+		t.Fatalf("expected 1 resumed cycle, got %d (err %v)", resumed, err)
+	}
+	if submitter.count() != 1 {
+		t.Errorf("expected no resubmission, got %d submissions", submitter.count())
+	}
+	if second.GetActiveCycleCount() != 1 || second.writeBack.GetSubmittedCount() != 1 {
+		t.Errorf("expected the cycle to await confirmation, got %d active, %d submitted",
+			second.GetActiveCycleCount(), second.writeBack.GetSubmittedCount())
+	}
+	if resumed, _ := second.ResumePendingCycles(ctx); resumed != 1 || second.writeBack.GetSubmittedCount() != 1 {
+		t.Errorf("expected resuming an active cycle to be a no-op")
+	}
+
+	// A write-back persisted without a receipt may have landed, so the cycle fails
+	// instead of submitting again
+	tx, err := first.writeBack.BuildResult(NewAttestationBundle(cycle.BundleID, result, agg), nil)
+	if err != nil {
+		t.Fatalf("BuildResult failed: %v", err)
+	}
+	interrupted := submittedState
+	if interrupted.WriteBackTx, err = json.Marshal(tx); err != nil {
+		t.Fatal(err)
+	}
+	unknown := newMemoryCycleStore()
+	unknown.states[cycleID] = &interrupted
+
+	third := newResumeTestOrchestrator(unknown, submitter)
+	if _, err := third.ResumePendingCycles(ctx); err != nil {
+		t.Fatalf("ResumePendingCycles failed: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for unknown.get(cycleID).Stage != database.ProofCycleStageFailed && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if state := unknown.get(cycleID); state.Stage != database.ProofCycleStageFailed || state.ErrorMessage == "" {
+		t.Errorf("expected the interrupted write-back to fail the cycle, got %+v", state)
+	}
+	if submitter.count() != 1 {
+		t.Errorf("expected no resubmission, got %d submissions", submitter.count())
+	}
+}
@@ -200,6 +200,26 @@ type ValidatorSet struct {
 	ValidatorCount   int
 }
 
+// FindByID returns the validator with the given ID, or nil
+func (vs *ValidatorSet) FindByID(id string) *ValidatorInfo {
+	for i := range vs.Validators {
+		if vs.Validators[i].ID == id {
+			return &vs.Validators[i]
+		}
+	}
+	return nil
+}
+
+// FindByAddress returns the validator registered at addr, or nil
+func (vs *ValidatorSet) FindByAddress(addr common.Address) *ValidatorInfo {
+	for i := range vs.Validators {
+		if vs.Validators[i].Address == addr {
+			return &vs.Validators[i]
+		}
+	}
+	return nil
+}
+
 // ValidatorInfo contains information about a single validator
 type ValidatorInfo struct {
 	ID           string
@@ -417,6 +437,23 @@ func (c *AttestationCollector) GetSnapshot() *ValidatorSetSnapshot {
 	return c.snapshot
 }
 
+// UpdateValidatorSet replaces the validator set attestations are weighed against,
+// e.g. after the on-chain set changed. Results still collecting attestations are
+// re-evaluated against the new set when their next attestation arrives.
+func (c *AttestationCollector) UpdateValidatorSet(validatorSet *ValidatorSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validatorSet = validatorSet
+	c.snapshot = NewValidatorSetSnapshot(validatorSet, 0)
+	for _, agg := range c.aggregated {
+		if !agg.Finalized {
+			agg.TotalVotingPower = validatorSet.TotalVotingPower
+			agg.SnapshotID = c.snapshot.SnapshotID
+			agg.ValidatorRoot = c.snapshot.ValidatorRoot
+		}
+	}
+}
+
 // SetThresholdCallback sets the callback for when threshold is met
 func (c *AttestationCollector) SetThresholdCallback(callback func(*AggregatedAttestation)) {
 	c.mu.Lock()
@@ -430,7 +467,7 @@ func (c *AttestationCollector) AddAttestation(attestation *ResultAttestation) er
 	defer c.mu.Unlock()
 
 	// Verify the attestation is from a known validator
-	validator := c.findValidator(attestation.ValidatorID)
+	validator := c.findValidator(attestation.ValidatorID, attestation.ValidatorAddress)
 	if validator == nil {
 		return fmt.Errorf("unknown validator: %s", attestation.ValidatorID)
 	}
@@ -538,7 +575,7 @@ func (c *AttestationCollector) tryAggregate(resultHash [32]byte) (*AggregatedAtt
 	})
 
 	for _, att := range sortedAttestations {
-		validator := c.findValidator(att.ValidatorID)
+		validator := c.findValidator(att.ValidatorID, att.ValidatorAddress)
 		if validator == nil {
 			continue
 		}
@@ -581,14 +618,13 @@ func (c *AttestationCollector) tryAggregate(resultHash [32]byte) (*AggregatedAtt
 	return agg, false
 }
 
-// findValidator finds a validator by ID
-func (c *AttestationCollector) findValidator(id string) *ValidatorInfo {
-	for i := range c.validatorSet.Validators {
-		if c.validatorSet.Validators[i].ID == id {
-			return &c.validatorSet.Validators[i]
-		}
+// findValidator finds a validator by ID, falling back to its address for validators
+// synced from the contract without a known ID
+func (c *AttestationCollector) findValidator(id string, address common.Address) *ValidatorInfo {
+	if validator := c.validatorSet.FindByID(id); validator != nil || address == (common.Address{}) {
+		return validator
 	}
-	return nil
+	return c.validatorSet.FindByAddress(address)
 }
 
 // GetAggregated returns the aggregated attestation for a result
@@ -60,8 +60,11 @@ type SyntheticTransaction struct {
 	ConfirmedAt time.Time `json:"confirmed_at,omitempty"`
 
 	// Status
-	Status    string `json:"status"` // pending, submitted, confirmed, failed
-	TxReceipt string `json:"tx_receipt,omitempty"`
+	Status    string `json:"status"`               // pending, submitted, confirmed, failed, unconfirmed
+	TxReceipt string `json:"tx_receipt,omitempty"` // Accumulate transaction ID once submitted
+
+	// Outcome of the confirmation wait, once finished
+	Confirmation *WriteBackConfirmation `json:"confirmation,omitempty"`
 }
 
 // SyntheticTxBody contains the body of the synthetic transaction
@@ -641,19 +644,25 @@ type ResultWriteBack struct {
 	submitted map[[32]byte]*SyntheticTransaction
 
 	// Configuration
-	retryInterval   time.Duration
-	maxRetries      int
-	confirmTimeout  time.Duration
+	retryInterval time.Duration
+	maxRetries    int
 
 	// Callbacks
 	onConfirmed func(*SyntheticTransaction)
 	onFailed    func(*SyntheticTransaction, error)
+
+	// Called when the confirmation wait ends without a final status (nil = onFailed)
+	onUnconfirmed func(*SyntheticTransaction, error)
 }
 
 // AccumulateSubmitter interface for submitting transactions to Accumulate
 type AccumulateSubmitter interface {
 	SubmitTransaction(ctx context.Context, tx *SyntheticTransaction) (string, error)
 	GetTransactionStatus(ctx context.Context, txHash string) (string, error)
+
+	// WaitForConfirmation polls a submitted transaction until its outcome is known
+	// or the confirmation timeout passes
+	WaitForConfirmation(ctx context.Context, txID string) *WriteBackConfirmation
 }
 
 // NewResultWriteBack creates a new result write-back service
@@ -662,13 +671,12 @@ func NewResultWriteBack(
 	accClient AccumulateSubmitter,
 ) *ResultWriteBack {
 	return &ResultWriteBack{
-		builder:        builder,
-		accClient:      accClient,
-		pending:        make(map[[32]byte]*SyntheticTransaction),
-		submitted:      make(map[[32]byte]*SyntheticTransaction),
-		retryInterval:  5 * time.Second,
-		maxRetries:     3,
-		confirmTimeout: 2 * time.Minute,
+		builder:       builder,
+		accClient:     accClient,
+		pending:       make(map[[32]byte]*SyntheticTransaction),
+		submitted:     make(map[[32]byte]*SyntheticTransaction),
+		retryInterval: 5 * time.Second,
+		maxRetries:    3,
 	}
 }
 
@@ -683,6 +691,14 @@ func (w *ResultWriteBack) SetCallbacks(
 	w.onFailed = onFailed
 }
 
+// SetUnconfirmedCallback sets the callback for write-backs whose confirmation wait
+// ended without a final status; such transactions may still be delivered
+func (w *ResultWriteBack) SetUnconfirmedCallback(onUnconfirmed func(*SyntheticTransaction, error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onUnconfirmed = onUnconfirmed
+}
+
 // WriteResult creates and submits a synthetic transaction for a proof result
 // This is the basic method - for comprehensive proof data, use WriteResultWithContext
 func (w *ResultWriteBack) WriteResult(ctx context.Context, bundle *AttestationBundle) error {
@@ -692,17 +708,31 @@ func (w *ResultWriteBack) WriteResult(ctx context.Context, bundle *AttestationBu
 // WriteResultWithContext creates and submits a synthetic transaction with comprehensive proof context
 // The proofCtx parameter contains all additional data needed for full audit support (intent refs, commitment, etc.)
 func (w *ResultWriteBack) WriteResultWithContext(ctx context.Context, bundle *AttestationBundle, proofCtx *ComprehensiveProofContext) error {
+	tx, err := w.BuildResult(bundle, proofCtx)
+	if err != nil {
+		return err
+	}
+	return w.SubmitResult(ctx, tx)
+}
+
+// BuildResult creates and signs the synthetic transaction for a proof result without
+// submitting it, so callers can persist it first
+func (w *ResultWriteBack) BuildResult(bundle *AttestationBundle, proofCtx *ComprehensiveProofContext) (*SyntheticTransaction, error) {
 	// Build synthetic transaction with context
 	tx, err := w.builder.BuildFromBundleWithContext(bundle, proofCtx)
 	if err != nil {
-		return fmt.Errorf("build synthetic tx: %w", err)
+		return nil, fmt.Errorf("build synthetic tx: %w", err)
 	}
 
 	// Add our signature
 	if err := w.builder.AddSignature(tx); err != nil {
-		return fmt.Errorf("add signature: %w", err)
+		return nil, fmt.Errorf("add signature: %w", err)
 	}
+	return tx, nil
+}
 
+// SubmitResult submits a transaction from BuildResult and watches for its confirmation
+func (w *ResultWriteBack) SubmitResult(ctx context.Context, tx *SyntheticTransaction) error {
 	// Store as pending
 	w.mu.Lock()
 	w.pending[tx.TxID] = tx
@@ -712,6 +742,16 @@ func (w *ResultWriteBack) WriteResultWithContext(ctx context.Context, bundle *At
 	return w.submitWithRetry(ctx, tx)
 }
 
+// ResumeConfirmation watches a transaction submitted before a restart without
+// submitting it again
+func (w *ResultWriteBack) ResumeConfirmation(ctx context.Context, tx *SyntheticTransaction) {
+	w.mu.Lock()
+	w.submitted[tx.TxID] = tx
+	w.mu.Unlock()
+
+	go w.watchConfirmation(ctx, tx)
+}
+
 // submitWithRetry submits a transaction with retries
 func (w *ResultWriteBack) submitWithRetry(ctx context.Context, tx *SyntheticTransaction) error {
 	var lastErr error
@@ -760,62 +800,58 @@ func (w *ResultWriteBack) submitWithRetry(ctx context.Context, tx *SyntheticTran
 	return fmt.Errorf("submit failed after %d attempts: %w", w.maxRetries, lastErr)
 }
 
-// watchConfirmation watches for transaction confirmation
+// watchConfirmation waits for the submitter to classify the transaction's outcome
+// and reports it through the callbacks
 func (w *ResultWriteBack) watchConfirmation(ctx context.Context, tx *SyntheticTransaction) {
-	timeout := time.After(w.confirmTimeout)
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-
-		case <-timeout:
-			w.mu.Lock()
-			tx.Status = "timeout"
-			delete(w.submitted, tx.TxID)
-			w.mu.Unlock()
-
-			if w.onFailed != nil {
-				w.onFailed(tx, errors.New("confirmation timeout"))
-			}
-			return
-
-		case <-ticker.C:
-			status, err := w.accClient.GetTransactionStatus(ctx, tx.TxReceipt)
-			if err != nil {
-				continue
-			}
-
-			if status == "confirmed" || status == "delivered" {
-				w.mu.Lock()
-				tx.Status = "confirmed"
-				tx.ConfirmedAt = time.Now()
-				delete(w.submitted, tx.TxID)
-				w.mu.Unlock()
-
-				if w.onConfirmed != nil {
-					w.onConfirmed(tx)
-				}
-				return
-			}
+	result := w.accClient.WaitForConfirmation(ctx, tx.TxReceipt)
+	if ctx.Err() != nil && result.Outcome == WriteBackPending {
+		// Shutting down; the persisted cycle resumes the wait on restart
+		return
+	}
 
-			if status == "failed" || status == "rejected" {
-				w.mu.Lock()
-				tx.Status = "failed"
-				delete(w.submitted, tx.TxID)
-				w.mu.Unlock()
+	w.mu.Lock()
+	tx.Confirmation = result
+	switch result.Outcome {
+	case WriteBackConfirmed:
+		tx.Status = "confirmed"
+		tx.ConfirmedAt = time.Now()
+	case WriteBackFailed:
+		tx.Status = "failed"
+	default:
+		tx.Status = "unconfirmed"
+	}
+	delete(w.submitted, tx.TxID)
+	onConfirmed, onFailed, onUnconfirmed := w.onConfirmed, w.onFailed, w.onUnconfirmed
+	w.mu.Unlock()
 
-				if w.onFailed != nil {
-					w.onFailed(tx, fmt.Errorf("transaction %s", status))
-				}
-				return
-			}
+	switch result.Outcome {
+	case WriteBackConfirmed:
+		if onConfirmed != nil {
+			onConfirmed(tx)
+		}
+	case WriteBackFailed:
+		if onFailed != nil {
+			onFailed(tx, result.Err())
+		}
+	default:
+		if onUnconfirmed == nil {
+			onUnconfirmed = onFailed
+		}
+		if onUnconfirmed != nil {
+			onUnconfirmed(tx, result.Err())
 		}
 	}
 }
 
+// encodeTransaction encodes a transaction and returns its Accumulate txid under the
+// lock guarding its status, so it can be persisted while its confirmation is watched
+func (w *ResultWriteBack) encodeTransaction(tx *SyntheticTransaction) ([]byte, string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	data, err := json.Marshal(tx)
+	return data, tx.TxReceipt, err
+}
+
 // GetPendingCount returns the number of pending transactions
 func (w *ResultWriteBack) GetPendingCount() int {
 	w.mu.RLock()
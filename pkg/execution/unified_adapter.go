@@ -66,7 +66,7 @@ func (a *UnifiedOrchestratorAdapter) StartProofCycle(
 			BundleID:    bundleID,
 			TxHashes:    []string{executionTxHash.Hex()},
 			ProofClass:  "on_demand",
-			TargetChain: a.unified.config.DefaultChainID,
+			TargetChain: a.unified.TargetChainFor(commitment),
 		}
 
 		// Start cycle asynchronously
@@ -159,7 +159,7 @@ func (a *UnifiedOrchestratorAdapter) StartProofCycleWithAllTxs(
 			BundleID:            bundleID,
 			TxHashes:            txHashStrs,
 			ProofClass:          "on_demand",
-			TargetChain:         a.unified.config.DefaultChainID,
+			TargetChain:         a.unified.TargetChainFor(commitment),
 			UserID:              userIDPtr,
 			OperationCommitment: operationCommitment,
 			// Merkle inclusion proof data
@@ -170,7 +170,7 @@ func (a *UnifiedOrchestratorAdapter) StartProofCycleWithAllTxs(
 		}
 
 		fmt.Printf("[UnifiedAdapter] Starting unified proof cycle for intent %s with target chain %s\n",
-			intentID, a.unified.config.DefaultChainID)
+			intentID, req.TargetChain)
 
 		// Start cycle asynchronously
 		go func() {
@@ -289,7 +289,7 @@ func (a *UnifiedOrchestratorAdapter) StartProofCycleWithAccumulateRef(
 			BundleID:             bundleID,
 			TxHashes:             txHashStrs,
 			ProofClass:           "on_demand",
-			TargetChain:          a.unified.config.DefaultChainID,
+			TargetChain:          a.unified.TargetChainFor(commitment),
 			UserID:               userIDPtr,
 			AccumulateAccountURL: accumulateAccountURL,
 			AccumulateTxHash:     accumulateTxHash,
@@ -22,6 +22,7 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -90,6 +91,13 @@ type UnifiedOrchestratorConfig struct {
 	OnCycleFailed   func(*UnifiedProofCycleResult, error)
 	OnPhaseComplete func(cycleID string, phase int)
 
+	// MaxConcurrentCycles bounds proof cycles running at once (0 = unlimited)
+	// Excess cycles queue, with on-demand cycles admitted before on-cadence ones
+	MaxConcurrentCycles int
+
+	// AuditLog records proof-cycle decisions to an append-only hash chain (nil = disabled)
+	AuditLog *AuditLog
+
 	// Feature flags
 	EnableMultiChain       bool
 	EnableUnifiedTables    bool
@@ -261,6 +269,7 @@ type UnifiedProofCycleResult struct {
 	// Phase 9 results
 	WriteBackTxHash string `json:"write_back_tx_hash,omitempty"`
 	WriteBackSuccess bool   `json:"write_back_success"`
+	WriteBackStatus  string `json:"write_back_status,omitempty"` // confirmed, pending or failed
 
 	// Timing
 	StartedAt   time.Time  `json:"started_at"`
@@ -286,6 +295,9 @@ type UnifiedOrchestrator struct {
 	// Active cycles
 	activeCycles map[string]*activeCycle
 
+	// Bounds concurrently running cycles
+	limiter *CycleLimiter
+
 	// HTTP client for peer attestation collection
 	httpClient *http.Client
 
@@ -324,6 +336,7 @@ func NewUnifiedOrchestrator(config *UnifiedOrchestratorConfig) (*UnifiedOrchestr
 	orch := &UnifiedOrchestrator{
 		config:       config,
 		activeCycles: make(map[string]*activeCycle),
+		limiter:      NewCycleLimiter(config.MaxConcurrentCycles),
 		stopCh:       make(chan struct{}),
 		httpClient: &http.Client{
 			Timeout: config.AttestationTimeout,
@@ -358,6 +371,12 @@ func (o *UnifiedOrchestrator) StartProofCycle(ctx context.Context, req *UnifiedP
 		req.CycleID = uuid.New().String()
 	}
 
+	// Wait for a cycle slot; on-demand cycles are admitted first
+	if err := o.limiter.Acquire(ctx, req.ProofClass == "on_demand"); err != nil {
+		return &UnifiedProofCycleResult{CycleID: req.CycleID, Error: fmt.Sprintf("waiting for cycle slot: %v", err)}, err
+	}
+	defer o.limiter.Release()
+
 	// Create result
 	result := &UnifiedProofCycleResult{
 		CycleID:   req.CycleID,
@@ -365,12 +384,7 @@ func (o *UnifiedOrchestrator) StartProofCycle(ctx context.Context, req *UnifiedP
 	}
 
 	// Get strategies for target chain
-	targetChain := req.TargetChain
-	if targetChain == "" {
-		targetChain = o.config.DefaultChainID
-	}
-
-	chainStrategy, attestStrategy, err := o.config.Registry.GetStrategiesForChain(targetChain)
+	chainStrategy, attestStrategy, err := o.selectChain(req.TargetChain)
 	if err != nil {
 		result.Error = fmt.Sprintf("get strategies: %v", err)
 		return result, err
@@ -395,6 +409,14 @@ func (o *UnifiedOrchestrator) StartProofCycle(ctx context.Context, req *UnifiedP
 	o.activeCycles[req.CycleID] = cycle
 	o.mu.Unlock()
 
+	o.audit(req.CycleID, AuditEventCycleStarted, map[string]interface{}{
+		"proof_class":  req.ProofClass,
+		"intent_id":    req.IntentID,
+		"target_chain": result.ChainID,
+		"tx_hashes":    req.TxHashes,
+		"merkle_root":  hex.EncodeToString(req.MerkleRoot[:]),
+	})
+
 	defer func() {
 		o.mu.Lock()
 		delete(o.activeCycles, req.CycleID)
@@ -405,29 +427,35 @@ func (o *UnifiedOrchestrator) StartProofCycle(ctx context.Context, req *UnifiedP
 	if err := o.executePhase7(cycleCtx, cycle, chainStrategy); err != nil {
 		result.Error = fmt.Sprintf("phase 7 failed: %v", err)
 		result.FailPhase = 7
+		o.audit(req.CycleID, AuditEventCycleFailed, map[string]interface{}{"phase": 7, "error": result.Error})
 		if o.config.OnCycleFailed != nil {
 			o.config.OnCycleFailed(result, err)
 		}
 		return result, err
 	}
+	o.audit(req.CycleID, AuditEventPhaseCompleted, map[string]interface{}{"phase": 7})
 
 	if err := o.executePhase8(cycleCtx, cycle, attestStrategy); err != nil {
 		result.Error = fmt.Sprintf("phase 8 failed: %v", err)
 		result.FailPhase = 8
+		o.audit(req.CycleID, AuditEventCycleFailed, map[string]interface{}{"phase": 8, "error": result.Error})
 		if o.config.OnCycleFailed != nil {
 			o.config.OnCycleFailed(result, err)
 		}
 		return result, err
 	}
+	o.audit(req.CycleID, AuditEventPhaseCompleted, map[string]interface{}{"phase": 8})
 
 	if err := o.executePhase9(cycleCtx, cycle); err != nil {
 		result.Error = fmt.Sprintf("phase 9 failed: %v", err)
 		result.FailPhase = 9
+		o.audit(req.CycleID, AuditEventCycleFailed, map[string]interface{}{"phase": 9, "error": result.Error})
 		if o.config.OnCycleFailed != nil {
 			o.config.OnCycleFailed(result, err)
 		}
 		return result, err
 	}
+	o.audit(req.CycleID, AuditEventPhaseCompleted, map[string]interface{}{"phase": 9})
 
 	// Generate and persist proof bundle (after all phases complete)
 	if o.config.EnableUnifiedTables && o.config.Repos != nil {
@@ -442,6 +470,11 @@ func (o *UnifiedOrchestrator) StartProofCycle(ctx context.Context, req *UnifiedP
 	result.CompletedAt = &now
 	result.Success = true
 
+	o.audit(req.CycleID, AuditEventCycleCompleted, map[string]interface{}{
+		"threshold_met":      result.ThresholdMet,
+		"write_back_tx_hash": result.WriteBackTxHash,
+	})
+
 	if o.config.OnCycleComplete != nil {
 		o.config.OnCycleComplete(result)
 	}
@@ -449,6 +482,17 @@ func (o *UnifiedOrchestrator) StartProofCycle(ctx context.Context, req *UnifiedP
 	return result, nil
 }
 
+// audit records a proof-cycle event when an audit log is configured
+// A failed append is logged but does not fail the cycle
+func (o *UnifiedOrchestrator) audit(cycleID, event string, details map[string]interface{}) {
+	if o.config.AuditLog == nil {
+		return
+	}
+	if _, err := o.config.AuditLog.Record(cycleID, event, details); err != nil {
+		fmt.Printf("Warning: failed to record audit event %s for cycle %s: %v\n", event, cycleID, err)
+	}
+}
+
 // validateRequest validates a proof cycle request
 func (o *UnifiedOrchestrator) validateRequest(req *UnifiedProofCycleRequest) error {
 	if len(req.TxHashes) == 0 {
@@ -462,6 +506,97 @@ func (o *UnifiedOrchestrator) validateRequest(req *UnifiedProofCycleRequest) err
 	return nil
 }
 
+// =============================================================================
+// TARGET CHAIN SELECTION
+// =============================================================================
+
+// selectChain returns the strategies for the requested target chain. An empty target,
+// or any target while multi-chain routing is disabled, uses the default chain; a
+// target without a registered strategy is an ErrUnsupportedTargetChain error.
+func (o *UnifiedOrchestrator) selectChain(targetChain string) (chain.ChainExecutionStrategy, attestation.AttestationStrategy, error) {
+	registry := o.config.Registry
+	if targetChain == "" || !o.config.EnableMultiChain {
+		targetChain = o.config.DefaultChainID
+		if targetChain == "" {
+			targetChain = registry.GetDefaultChainID()
+		}
+	}
+	if targetChain == "" {
+		return nil, nil, fmt.Errorf("no target chain requested and no default chain configured")
+	}
+	if !registry.HasChainStrategy(targetChain) {
+		return nil, nil, fmt.Errorf("%w: %s (registered: %s)", ErrUnsupportedTargetChain, targetChain, strings.Join(sortedChainIDs(registry), ", "))
+	}
+	return registry.GetStrategiesForChain(targetChain)
+}
+
+// TargetChainFor returns the registered chain an intent's commitment targets, trying
+// the most specific identifier first: numeric chain ID, network name, then chain name.
+// When none is registered the most specific identifier is returned so the cycle fails
+// with ErrUnsupportedTargetChain; without any identifier it returns "" (default chain).
+func (o *UnifiedOrchestrator) TargetChainFor(commitment interface{}) string {
+	candidates := targetChainCandidates(commitment)
+	for _, candidate := range candidates {
+		if o.config.Registry.HasChainStrategy(candidate) {
+			return candidate
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return ""
+}
+
+// targetChainCandidates extracts target chain identifiers from commitment data
+func targetChainCandidates(commitment interface{}) []string {
+	var candidates []string
+	add := func(id string) {
+		if id != "" && id != "0" {
+			candidates = append(candidates, id)
+		}
+	}
+
+	switch c := commitment.(type) {
+	case map[string]interface{}:
+		switch id := c["chainID"].(type) {
+		case uint64:
+			add(fmt.Sprintf("%d", id))
+		case int64:
+			add(fmt.Sprintf("%d", id))
+		case int:
+			add(fmt.Sprintf("%d", id))
+		case float64:
+			add(fmt.Sprintf("%.0f", id))
+		case json.Number:
+			add(id.String())
+		case string:
+			add(id)
+		}
+		if network, ok := c["network"].(string); ok {
+			add(network)
+		}
+		if targetChain, ok := c["targetChain"].(string); ok {
+			add(targetChain)
+		}
+	case *ExecutionCommitment:
+		if c != nil {
+			add(c.TargetChain)
+		}
+	case *ExecutionCommitmentData:
+		if c != nil {
+			add(c.TargetChain)
+		}
+	}
+	return candidates
+}
+
+// sortedChainIDs lists the registered chain IDs in order, for error messages
+func sortedChainIDs(registry *strategy.Registry) []string {
+	ids := registry.ListChainIDs()
+	sort.Strings(ids)
+	return ids
+}
+
 // =============================================================================
 // PHASE 7: EXTERNAL CHAIN OBSERVATION
 // =============================================================================
@@ -511,6 +646,16 @@ func (o *UnifiedOrchestrator) executePhase7(ctx context.Context, cycle *activeCy
 	result.ObservationResults = observationResults
 	result.ChainExecutionIDs = chainExecutionIDs
 
+	anchored := make([]map[string]interface{}, 0, len(observationResults))
+	for _, obs := range observationResults {
+		anchored = append(anchored, map[string]interface{}{
+			"tx_hash":      obs.TxHash,
+			"block_number": obs.BlockNumber,
+			"block_hash":   obs.BlockHash,
+		})
+	}
+	o.audit(cycle.CycleID, AuditEventObserved, map[string]interface{}{"transactions": anchored})
+
 	return nil
 }
 
@@ -726,6 +871,18 @@ func (o *UnifiedOrchestrator) executePhase8(ctx context.Context, cycle *activeCy
 	result.AggregatedAttestation = aggAttestation
 	result.ThresholdMet = aggAttestation.ThresholdMet
 
+	validatorIDs := make([]string, 0, len(attestations))
+	for _, att := range attestations {
+		validatorIDs = append(validatorIDs, att.ValidatorID)
+	}
+	o.audit(cycle.CycleID, AuditEventAttested, map[string]interface{}{
+		"validators":       validatorIDs,
+		"scheme":           result.Scheme,
+		"achieved_weight":  aggAttestation.AchievedWeight,
+		"threshold_weight": aggAttestation.ThresholdWeight,
+		"threshold_met":    aggAttestation.ThresholdMet,
+	})
+
 	return nil
 }
 
@@ -1013,6 +1170,7 @@ func (o *UnifiedOrchestrator) executePhase9(ctx context.Context, cycle *activeCy
 	if !o.config.EnableWriteBack || o.txBuilder == nil || o.config.AccumulateClient == nil {
 		fmt.Printf("Write-back skipped (not configured): cycle=%s\n", cycle.CycleID)
 		cycle.Result.WriteBackSuccess = true
+		o.audit(cycle.CycleID, AuditEventWriteBack, map[string]interface{}{"skipped": true})
 		return nil
 	}
 
@@ -1043,14 +1201,36 @@ func (o *UnifiedOrchestrator) executePhase9(ctx context.Context, cycle *activeCy
 	// Submit transaction to Accumulate
 	receipt, err := o.config.AccumulateClient.SubmitTransaction(writeBackCtx, tx)
 	if err != nil {
+		o.audit(cycle.CycleID, AuditEventWriteBack, map[string]interface{}{"success": false, "error": err.Error()})
 		return fmt.Errorf("submit to accumulate: %w", err)
 	}
-
 	cycle.Result.WriteBackTxHash = receipt
-	cycle.Result.WriteBackSuccess = true
-
 	fmt.Printf("Write-back submitted: cycle=%s, receipt=%s\n", cycle.CycleID, receipt)
 
+	// Wait for Accumulate to deliver or fail the transaction
+	confirmation := o.config.AccumulateClient.WaitForConfirmation(writeBackCtx, receipt)
+	cycle.Result.WriteBackStatus = string(confirmation.Outcome)
+	cycle.Result.WriteBackSuccess = confirmation.Outcome == WriteBackConfirmed
+	auditData := map[string]interface{}{
+		"success":   cycle.Result.WriteBackSuccess,
+		"tx_hash":   receipt,
+		"principal": o.config.ResultsPrincipal,
+		"outcome":   confirmation.Outcome,
+	}
+	if err := confirmation.Err(); err != nil {
+		auditData["error"] = err.Error()
+	}
+	o.audit(cycle.CycleID, AuditEventWriteBack, auditData)
+
+	switch confirmation.Outcome {
+	case WriteBackFailed:
+		return confirmation.Err()
+	case WriteBackPending:
+		// May still be delivered; the receipt identifies it for follow-up
+		fmt.Printf("Write-back unconfirmed: cycle=%s, receipt=%s: %v\n", cycle.CycleID, receipt, confirmation.Err())
+	default:
+		fmt.Printf("Write-back confirmed: cycle=%s, receipt=%s\n", cycle.CycleID, receipt)
+	}
 	return nil
 }
 
@@ -1164,6 +1344,11 @@ func (o *UnifiedOrchestrator) GetActiveCycles() []string {
 	return cycles
 }
 
+// GetCycleStats returns the active and queued cycle counts
+func (o *UnifiedOrchestrator) GetCycleStats() CycleLimiterStats {
+	return o.limiter.Stats()
+}
+
 // GetCycleStatus returns the status of a specific cycle
 func (o *UnifiedOrchestrator) GetCycleStatus(cycleID string) (*UnifiedProofCycleResult, bool) {
 	o.mu.RLock()
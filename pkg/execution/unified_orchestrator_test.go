@@ -0,0 +1,99 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Unified Orchestrator
+// Tests routing proof cycles to the chain strategy their intent targets
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	attestation "github.com/certen/independant-validator/pkg/attestation/strategy"
+	chain "github.com/certen/independant-validator/pkg/chain/strategy"
+	"github.com/certen/independant-validator/pkg/strategy"
+)
+
+// newRoutingTestOrchestrator registers Solana devnet (the default) and NEAR testnet
+func newRoutingTestOrchestrator(t *testing.T) *UnifiedOrchestrator {
+	t.Helper()
+	registry := strategy.NewRegistry()
+
+	attest, err := attestation.NewEd25519StrategyWithNewKey("validator-1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterAttestationStrategy(attest); err != nil {
+		t.Fatal(err)
+	}
+	solana, _ := chain.NewSolanaDevnetStrategy("", "", "validator-1")
+	if err := registry.RegisterChainStrategy("solana-devnet", solana.Config(), solana); err != nil {
+		t.Fatal(err)
+	}
+	near, _ := chain.NewNEARTestnetStrategy("", "", "", "validator-1")
+	if err := registry.RegisterChainStrategy("near-testnet", near.Config(), near); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultUnifiedOrchestratorConfig()
+	config.ValidatorID = "validator-1"
+	config.Registry = registry
+	o, err := NewUnifiedOrchestrator(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestUnifiedOrchestrator_SelectChain(t *testing.T) {
+	o := newRoutingTestOrchestrator(t)
+
+	tests := []struct {
+		name       string
+		commitment interface{}
+		wantChain  string
+	}{
+		{"no commitment uses the default", nil, "solana-devnet"},
+		{"chain name from commitment map", map[string]interface{}{"targetChain": "near-testnet"}, "near-testnet"},
+		{"unregistered chain ID falls through to network", map[string]interface{}{"chainID": uint64(103), "network": "solana-devnet", "targetChain": "solana"}, "solana-devnet"},
+		{"typed commitment", &ExecutionCommitment{TargetChain: "near-testnet"}, "near-testnet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainStrategy, attestStrategy, err := o.selectChain(o.TargetChainFor(tt.commitment))
+			if err != nil {
+				t.Fatalf("selectChain failed: %v", err)
+			}
+			if chainStrategy.ChainID() != tt.wantChain {
+				t.Errorf("expected %s, got %s", tt.wantChain, chainStrategy.ChainID())
+			}
+			if attestStrategy.Scheme() != attestation.AttestationSchemeEd25519 {
+				t.Errorf("expected Ed25519 attestation, got %s", attestStrategy.Scheme())
+			}
+		})
+	}
+
+	// A chain without a strategy is an error, not a silent fallback to the default
+	target := o.TargetChainFor(map[string]interface{}{"chainID": uint64(8453), "targetChain": "base"})
+	if target != "8453" {
+		t.Errorf("expected the chain ID as the unresolved target, got %q", target)
+	}
+	if _, _, err := o.selectChain(target); !errors.Is(err, ErrUnsupportedTargetChain) {
+		t.Errorf("expected ErrUnsupportedTargetChain, got %v", err)
+	}
+	result, err := o.StartProofCycle(context.Background(), &UnifiedProofCycleRequest{
+		ProofClass:  "on_demand",
+		TxHashes:    []string{"0x01"},
+		TargetChain: target,
+	})
+	if !errors.Is(err, ErrUnsupportedTargetChain) || result == nil || result.Error == "" {
+		t.Errorf("expected the cycle to fail with ErrUnsupportedTargetChain, got %v (%+v)", err, result)
+	}
+
+	// With multi-chain routing disabled every intent goes to the default chain
+	o.config.EnableMultiChain = false
+	if chainStrategy, _, err := o.selectChain("near-testnet"); err != nil || chainStrategy.ChainID() != "solana-devnet" {
+		t.Errorf("expected the default chain with multi-chain disabled, got %v (err %v)", chainStrategy, err)
+	}
+}
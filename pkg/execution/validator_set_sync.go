@@ -0,0 +1,274 @@
+// Copyright 2025 Certen Protocol
+//
+// Validator Set Sync - Live validator set from the CertenAnchorV3 contract
+//
+// The attestation collector weighs signatures by the voting power of each validator
+// and the consensus app sizes its quorum by the validator count. Both must follow the
+// validator set registered on-chain rather than a startup assumption, so the syncer
+// reads getValidatorCount, validatorList and validators(addr) from the contract and
+// refreshes the set periodically, notifying listeners whenever it changes.
+
+package execution
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+)
+
+// ValidatorSetSource reads the validator set registered in the contract
+// Implemented by anchor.ContractValidatorRegistry
+type ValidatorSetSource interface {
+	GetValidatorCount(ctx context.Context) (uint64, error)
+	GetValidatorAt(ctx context.Context, index uint64) (common.Address, error)
+	GetValidatorRegistration(ctx context.Context, validator common.Address) (*anchor.ValidatorRegistration, error)
+}
+
+// ValidatorSetSyncerConfig configures the validator set syncer
+type ValidatorSetSyncerConfig struct {
+	RefreshInterval time.Duration             // Time between contract reads
+	ValidatorIDs    map[common.Address]string // Validator IDs by on-chain address; others are identified by address
+	Logger          Logger
+}
+
+// ValidatorSetSyncer keeps a ValidatorSet in step with the contract's validator set
+type ValidatorSetSyncer struct {
+	mu sync.Mutex
+
+	source          ValidatorSetSource
+	refreshInterval time.Duration
+	validatorIDs    map[common.Address]string
+	logger          Logger
+
+	current    *ValidatorSet
+	lastSynced time.Time
+	lastErr    string
+	onChange   []func(*ValidatorSet)
+
+	running bool
+	cancel  context.CancelFunc
+}
+
+// ValidatorSetSyncStatus reports the synced validator set
+type ValidatorSetSyncStatus struct {
+	ValidatorCount   int       `json:"validator_count"`
+	TotalVotingPower string    `json:"total_voting_power,omitempty"`
+	LastSynced       time.Time `json:"last_synced,omitempty"`
+	LastError        string    `json:"last_error,omitempty"`
+}
+
+// NewValidatorSetSyncer creates a syncer reading from source
+func NewValidatorSetSyncer(source ValidatorSetSource, cfg *ValidatorSetSyncerConfig) (*ValidatorSetSyncer, error) {
+	if source == nil {
+		return nil, fmt.Errorf("validator set source cannot be nil")
+	}
+	if cfg == nil {
+		cfg = &ValidatorSetSyncerConfig{}
+	}
+	interval := cfg.RefreshInterval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = log.New(log.Writer(), "[ValidatorSetSync] ", log.LstdFlags)
+	}
+
+	ids := make(map[common.Address]string, len(cfg.ValidatorIDs))
+	for addr, id := range cfg.ValidatorIDs {
+		ids[addr] = id
+	}
+	return &ValidatorSetSyncer{
+		source:          source,
+		refreshInterval: interval,
+		validatorIDs:    ids,
+		logger:          logger,
+	}, nil
+}
+
+// ParseValidatorIDs parses "<validator-id>=<address>" entries naming on-chain validators
+func ParseValidatorIDs(entries []string) (map[common.Address]string, error) {
+	ids := make(map[common.Address]string, len(entries))
+	for _, entry := range entries {
+		id, addr, ok := strings.Cut(entry, "=")
+		id, addr = strings.TrimSpace(id), strings.TrimSpace(addr)
+		if !ok || id == "" || !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid validator entry %q (expected <validator-id>=<address>)", entry)
+		}
+		ids[common.HexToAddress(addr)] = id
+	}
+	return ids, nil
+}
+
+// OnChange registers a function called with the new set whenever a sync changes it
+func (s *ValidatorSetSyncer) OnChange(fn func(*ValidatorSet)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = append(s.onChange, fn)
+}
+
+// Current returns the last synced validator set, or nil before the first sync
+func (s *ValidatorSetSyncer) Current() *ValidatorSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+// Status returns the synced validator count and the outcome of the last sync
+func (s *ValidatorSetSyncer) Status() ValidatorSetSyncStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := ValidatorSetSyncStatus{LastSynced: s.lastSynced, LastError: s.lastErr}
+	if s.current != nil {
+		status.ValidatorCount = s.current.ValidatorCount
+		status.TotalVotingPower = s.current.TotalVotingPower.String()
+	}
+	return status
+}
+
+// Sync reads the validator set from the contract. Listeners are notified when it
+// differs from the previous set; a failed read keeps the previous set.
+func (s *ValidatorSetSyncer) Sync(ctx context.Context) (*ValidatorSet, error) {
+	set, err := s.read(ctx)
+
+	s.mu.Lock()
+	if err != nil {
+		s.lastErr = err.Error()
+		s.mu.Unlock()
+		return nil, err
+	}
+	changed := !sameValidatorSet(s.current, set)
+	s.current = set
+	s.lastSynced = time.Now()
+	s.lastErr = ""
+	listeners := append([]func(*ValidatorSet){}, s.onChange...)
+	s.mu.Unlock()
+
+	if changed {
+		s.logger.Printf("👥 Validator set synced: %d validators, total voting power %s",
+			set.ValidatorCount, set.TotalVotingPower)
+		for _, fn := range listeners {
+			fn(set)
+		}
+	}
+	return set, nil
+}
+
+// read builds the registered validators in contract order
+func (s *ValidatorSetSyncer) read(ctx context.Context) (*ValidatorSet, error) {
+	count, err := s.source.GetValidatorCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &ValidatorSet{
+		Validators:       make([]ValidatorInfo, 0, count),
+		TotalVotingPower: big.NewInt(0),
+	}
+	for i := uint64(0); i < count; i++ {
+		addr, err := s.source.GetValidatorAt(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		reg, err := s.source.GetValidatorRegistration(ctx, addr)
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", addr.Hex(), err)
+		}
+		if !reg.Registered {
+			continue // Removed validators may linger in the list
+		}
+
+		id := s.validatorIDs[addr]
+		if id == "" {
+			id = addr.Hex()
+		}
+		power := new(big.Int)
+		if reg.VotingPower != nil {
+			power.Set(reg.VotingPower)
+		}
+		set.Validators = append(set.Validators, ValidatorInfo{
+			ID:           id,
+			Address:      addr,
+			Index:        uint32(len(set.Validators)),
+			VotingPower:  power,
+			BLSPublicKey: reg.BLSPublicKey,
+			Active:       true,
+		})
+		set.TotalVotingPower.Add(set.TotalVotingPower, power)
+	}
+	if len(set.Validators) == 0 {
+		return nil, fmt.Errorf("contract has no registered validators")
+	}
+	set.ValidatorCount = len(set.Validators)
+	return set, nil
+}
+
+// Start syncs once and then refreshes periodically until Stop is called.
+// Returns the error of the initial sync, after which refreshing continues regardless.
+func (s *ValidatorSetSyncer) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return fmt.Errorf("validator set syncer already running")
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.running = true
+	s.mu.Unlock()
+
+	_, err := s.Sync(ctx)
+
+	go func() {
+		ticker := time.NewTicker(s.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.Sync(ctx); err != nil {
+					s.logger.Printf("⚠️ Validator set sync failed, keeping the previous set: %v", err)
+				}
+			}
+		}
+	}()
+	return err
+}
+
+// Stop halts periodic refreshing
+func (s *ValidatorSetSyncer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		return
+	}
+	s.cancel()
+	s.running = false
+}
+
+// sameValidatorSet reports whether two sets list the same validators with the same
+// voting powers and BLS keys, in the same order
+func sameValidatorSet(a, b *ValidatorSet) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.Validators) != len(b.Validators) {
+		return false
+	}
+	for i := range a.Validators {
+		va, vb := a.Validators[i], b.Validators[i]
+		if va.ID != vb.ID || va.Address != vb.Address || va.VotingPower.Cmp(vb.VotingPower) != 0 ||
+			!bytes.Equal(va.BLSPublicKey, vb.BLSPublicKey) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Validator Set Sync
+// Tests building the set from the contract, change notification and collector updates
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+)
+
+// fakeValidatorSetSource serves a contract validator list
+type fakeValidatorSetSource struct {
+	list []common.Address
+	regs map[common.Address]*anchor.ValidatorRegistration
+	err  error
+}
+
+func (s *fakeValidatorSetSource) GetValidatorCount(ctx context.Context) (uint64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	return uint64(len(s.list)), nil
+}
+
+func (s *fakeValidatorSetSource) GetValidatorAt(ctx context.Context, index uint64) (common.Address, error) {
+	return s.list[index], nil
+}
+
+func (s *fakeValidatorSetSource) GetValidatorRegistration(ctx context.Context, validator common.Address) (*anchor.ValidatorRegistration, error) {
+	if reg := s.regs[validator]; reg != nil {
+		return reg, nil
+	}
+	return &anchor.ValidatorRegistration{VotingPower: big.NewInt(0)}, nil
+}
+
+func (s *fakeValidatorSetSource) register(addr common.Address, power int64) {
+	s.list = append(s.list, addr)
+	s.regs[addr] = &anchor.ValidatorRegistration{Registered: true, VotingPower: big.NewInt(power), BLSPublicKey: addr.Bytes()}
+}
+
+func TestValidatorSetSyncer_Sync(t *testing.T) {
+	self := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	peer := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	removed := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	source := &fakeValidatorSetSource{regs: make(map[common.Address]*anchor.ValidatorRegistration)}
+	source.register(self, 100)
+	source.list = append(source.list, removed) // Listed but no longer registered
+	source.register(peer, 50)
+
+	syncer, err := NewValidatorSetSyncer(source, &ValidatorSetSyncerConfig{
+		ValidatorIDs: map[common.Address]string{self: "validator-1"},
+		Logger:       log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("NewValidatorSetSyncer: %v", err)
+	}
+	var changes []*ValidatorSet
+	syncer.OnChange(func(vs *ValidatorSet) { changes = append(changes, vs) })
+
+	set, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if set.ValidatorCount != 2 || set.TotalVotingPower.Int64() != 150 {
+		t.Fatalf("unexpected set: %d validators, total %s", set.ValidatorCount, set.TotalVotingPower)
+	}
+	if v := set.FindByID("validator-1"); v == nil || v.Index != 0 || v.VotingPower.Int64() != 100 {
+		t.Errorf("unexpected entry for this validator: %+v", v)
+	}
+	if v := set.FindByAddress(peer); v == nil || v.ID != peer.Hex() || v.Index != 1 || len(v.BLSPublicKey) == 0 {
+		t.Errorf("unexpected entry for the peer: %+v", v)
+	}
+
+	// An unchanged set does not notify again; a voting power change does
+	if _, err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	source.regs[peer].VotingPower = big.NewInt(200)
+	if _, err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(changes) != 2 || changes[1].TotalVotingPower.Int64() != 300 {
+		t.Errorf("expected 2 change notifications, got %d", len(changes))
+	}
+
+	// A failed read keeps the previous set
+	source.err = errors.New("rpc unavailable")
+	if _, err := syncer.Sync(context.Background()); err == nil {
+		t.Error("expected the sync to fail")
+	}
+	if current := syncer.Current(); current == nil || current.TotalVotingPower.Int64() != 300 {
+		t.Errorf("previous set not kept after a failed sync")
+	}
+	if status := syncer.Status(); status.LastError == "" || status.ValidatorCount != 2 {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestParseValidatorIDs(t *testing.T) {
+	ids, err := ParseValidatorIDs([]string{"validator-2=0x0000000000000000000000000000000000000002"})
+	if err != nil || ids[common.HexToAddress("0x02")] != "validator-2" {
+		t.Errorf("unexpected result %v (err %v)", ids, err)
+	}
+	for _, entry := range []string{"validator-2", "=0x0000000000000000000000000000000000000002", "validator-2=not-an-address"} {
+		if _, err := ParseValidatorIDs([]string{entry}); err == nil {
+			t.Errorf("expected an error for %q", entry)
+		}
+	}
+}
+
+func TestAttestationCollector_UpdateValidatorSet(t *testing.T) {
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	collector := NewAttestationCollector(NewValidatorSetFromConfig("validator-1", [20]byte{1}), 2, 3)
+	if collector.findValidator("", addr) != nil {
+		t.Fatal("validator should not be known before the update")
+	}
+
+	synced := &ValidatorSet{
+		Validators: []ValidatorInfo{
+			{ID: "validator-1", Address: common.Address{1}, Index: 0, VotingPower: big.NewInt(100), Active: true},
+			{ID: addr.Hex(), Address: addr, Index: 1, VotingPower: big.NewInt(100), Active: true},
+		},
+		TotalVotingPower: big.NewInt(200),
+		ValidatorCount:   2,
+	}
+	collector.UpdateValidatorSet(synced)
+
+	// Validators synced without a known ID are matched by address
+	if v := collector.findValidator("validator-2", addr); v == nil || v.Index != 1 {
+		t.Errorf("expected the synced validator to be found by address, got %+v", v)
+	}
+	if snapshot := collector.GetSnapshot(); snapshot.TotalWeight.Int64() != 200 {
+		t.Errorf("snapshot not rebuilt for the new set, total weight %s", snapshot.TotalWeight)
+	}
+}
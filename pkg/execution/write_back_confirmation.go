@@ -0,0 +1,144 @@
+// Copyright 2025 Certen Protocol
+//
+// Write-Back Confirmation - Polling a submitted write-back until its outcome is known
+//
+// Submitting a WriteData transaction only means Accumulate accepted the envelope. The
+// submitter then polls the transaction status until it is delivered, fails, or the
+// confirmation timeout passes, and classifies the outcome:
+//   - confirmed: the transaction was delivered
+//   - failed: the network rejected or failed the transaction; the chain error says why
+//   - pending: no final status before the timeout; the transaction may still be
+//     delivered, so the cycle must not be treated as failed
+//
+// "Not found" answers are expected while a freshly submitted transaction propagates
+// and are polled again like a pending status. Other query errors are retried up to
+// the submitter's retry limit in a row before the outcome is reported as pending.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WriteBackOutcome is the classified outcome of a write-back transaction
+type WriteBackOutcome string
+
+const (
+	WriteBackConfirmed WriteBackOutcome = "confirmed"
+	WriteBackPending   WriteBackOutcome = "pending"
+	WriteBackFailed    WriteBackOutcome = "failed"
+)
+
+// WriteBackConfirmation is the result of waiting for a write-back transaction
+type WriteBackConfirmation struct {
+	TxID       string           `json:"tx_id"` // Accumulate transaction ID, for operator lookup
+	Outcome    WriteBackOutcome `json:"outcome"`
+	Status     string           `json:"status,omitempty"`      // Last status reported by the network
+	ChainError string           `json:"chain_error,omitempty"` // Why the network failed the transaction
+	QueryError string           `json:"query_error,omitempty"` // Last status query error
+	Polls      int              `json:"polls"`
+}
+
+// Err returns an error describing a failed or unconfirmed write-back, or nil once confirmed
+func (c *WriteBackConfirmation) Err() error {
+	switch c.Outcome {
+	case WriteBackConfirmed:
+		return nil
+	case WriteBackFailed:
+		reason := c.ChainError
+		if reason == "" {
+			reason = "status " + c.Status
+		}
+		return fmt.Errorf("write-back %s failed on Accumulate: %s", c.TxID, reason)
+	default:
+		if c.QueryError != "" {
+			return fmt.Errorf("write-back %s unconfirmed after %d polls: %s", c.TxID, c.Polls, c.QueryError)
+		}
+		return fmt.Errorf("write-back %s unconfirmed after %d polls (last status %q)", c.TxID, c.Polls, c.Status)
+	}
+}
+
+// ClassifyWriteBackStatus maps an Accumulate transaction status to a write-back outcome.
+// Statuses that are not final are pending; any other status is a failure.
+func ClassifyWriteBackStatus(status string) WriteBackOutcome {
+	switch strings.ToLower(status) {
+	case "delivered", "confirmed":
+		return WriteBackConfirmed
+	case "", "pending", "remote", "unknown", "ok", "notready", "not-ready", "notfound", "not-found":
+		return WriteBackPending
+	default:
+		return WriteBackFailed
+	}
+}
+
+// isNotYetDelivered reports whether a status query error only means the transaction
+// has not reached the queried node yet
+func isNotYetDelivered(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "notfound")
+}
+
+// transactionStatusFunc returns the status of a transaction and the error the network
+// reported for it
+type transactionStatusFunc func(ctx context.Context, txID string) (status, chainError string, err error)
+
+// pollWriteBackConfirmation polls a transaction's status every interval until it is
+// confirmed or failed, timeout passes, or ctx is done. maxQueryErrors consecutive query
+// errors other than "not found" end the wait with a pending outcome.
+func pollWriteBackConfirmation(
+	ctx context.Context,
+	txID string,
+	query transactionStatusFunc,
+	timeout time.Duration,
+	interval time.Duration,
+	maxQueryErrors int,
+) *WriteBackConfirmation {
+	result := &WriteBackConfirmation{TxID: txID, Outcome: WriteBackPending}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	queryErrors := 0
+	for {
+		status, chainError, err := query(ctx, txID)
+		result.Polls++
+		switch {
+		case err == nil:
+			queryErrors = 0
+			result.Status = status
+			result.QueryError = ""
+			result.Outcome = ClassifyWriteBackStatus(status)
+			if result.Outcome != WriteBackPending {
+				if result.Outcome == WriteBackFailed {
+					result.ChainError = chainError
+				}
+				return result
+			}
+		case isNotYetDelivered(err):
+			queryErrors = 0
+			result.QueryError = err.Error()
+		default:
+			queryErrors++
+			result.QueryError = err.Error()
+			if queryErrors >= maxQueryErrors {
+				return result
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if result.QueryError == "" {
+				result.QueryError = ctx.Err().Error()
+			}
+			return result
+		case <-deadline.C:
+			return result
+		case <-ticker.C:
+		}
+	}
+}
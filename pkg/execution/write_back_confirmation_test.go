@@ -0,0 +1,168 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Write-Back Confirmation
+// Tests outcome classification, retries of "not yet delivered" answers, unconfirmed cycles
+// and shadow write-backs
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// statusAnswer is one scripted answer to a transaction status query
+type statusAnswer struct {
+	status     string
+	chainError string
+	err        error
+}
+
+// scriptedStatus answers status queries in order, repeating the last answer
+func scriptedStatus(answers ...statusAnswer) (transactionStatusFunc, *int) {
+	calls := 0
+	return func(ctx context.Context, txID string) (string, string, error) {
+		a := answers[len(answers)-1]
+		if calls < len(answers) {
+			a = answers[calls]
+		}
+		calls++
+		return a.status, a.chainError, a.err
+	}, &calls
+}
+
+func TestClassifyWriteBackStatus(t *testing.T) {
+	cases := map[string]WriteBackOutcome{
+		"delivered":           WriteBackConfirmed,
+		"confirmed":           WriteBackConfirmed,
+		"pending":             WriteBackPending,
+		"remote":              WriteBackPending,
+		"unknown":             WriteBackPending,
+		"":                    WriteBackPending,
+		"failed":              WriteBackFailed,
+		"rejected":            WriteBackFailed,
+		"insufficientCredits": WriteBackFailed,
+	}
+	for status, want := range cases {
+		if got := ClassifyWriteBackStatus(status); got != want {
+			t.Errorf("ClassifyWriteBackStatus(%q) = %s, want %s", status, got, want)
+		}
+	}
+}
+
+func TestPollWriteBackConfirmation(t *testing.T) {
+	ctx := context.Background()
+	notFound := errors.New("query transaction status: API error: message not found (-33404)")
+
+	t.Run("not yet delivered then confirmed", func(t *testing.T) {
+		query, calls := scriptedStatus(
+			statusAnswer{err: notFound},
+			statusAnswer{status: "pending"},
+			statusAnswer{status: "delivered"},
+		)
+		result := pollWriteBackConfirmation(ctx, "acc://tx@unknown", query, time.Second, time.Millisecond, 2)
+		if result.Outcome != WriteBackConfirmed || *calls != 3 || result.Err() != nil {
+			t.Errorf("expected confirmed after 3 polls, got %+v after %d", result, *calls)
+		}
+	})
+
+	t.Run("failed with chain error", func(t *testing.T) {
+		query, _ := scriptedStatus(statusAnswer{status: "insufficientCredits", chainError: "insufficient credits: have 0, need 10"})
+		result := pollWriteBackConfirmation(ctx, "acc://tx@unknown", query, time.Second, time.Millisecond, 2)
+		if result.Outcome != WriteBackFailed || result.ChainError == "" {
+			t.Fatalf("expected failure with chain error, got %+v", result)
+		}
+		if err := result.Err(); err == nil || !strings.Contains(err.Error(), "insufficient credits") || !strings.Contains(err.Error(), "acc://tx@unknown") {
+			t.Errorf("expected error with txid and chain error, got %v", err)
+		}
+	})
+
+	t.Run("repeated query errors", func(t *testing.T) {
+		query, calls := scriptedStatus(statusAnswer{err: errors.New("connection refused")})
+		result := pollWriteBackConfirmation(ctx, "acc://tx@unknown", query, time.Second, time.Millisecond, 3)
+		if result.Outcome != WriteBackPending || *calls != 3 || result.QueryError == "" {
+			t.Errorf("expected pending after 3 failed queries, got %+v after %d", result, *calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		query, _ := scriptedStatus(statusAnswer{status: "pending"})
+		result := pollWriteBackConfirmation(ctx, "acc://tx@unknown", query, 20*time.Millisecond, time.Millisecond, 3)
+		if result.Outcome != WriteBackPending || result.Status != "pending" || result.Err() == nil {
+			t.Errorf("expected pending outcome at timeout, got %+v", result)
+		}
+	})
+}
+
+// outcomeSubmitter reports a fixed confirmation outcome for every submission
+type outcomeSubmitter struct {
+	countingSubmitter
+	outcome WriteBackOutcome
+}
+
+func (s *outcomeSubmitter) WaitForConfirmation(ctx context.Context, txID string) *WriteBackConfirmation {
+	return &WriteBackConfirmation{TxID: txID, Outcome: s.outcome, Status: "pending", Polls: 24}
+}
+
+func TestWriteBackUnconfirmed_FinishesCycleInOwnStage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newMemoryCycleStore()
+	o := newResumeTestOrchestrator(store, &outcomeSubmitter{outcome: WriteBackPending})
+
+	txHash := common.HexToHash("0xabc2")
+	result := &ExternalChainResult{
+		Chain:       "sepolia",
+		ChainID:     11155111,
+		TxHash:      txHash,
+		BlockNumber: big.NewInt(100),
+		Status:      1,
+		ResultHash:  [32]byte{3},
+	}
+	agg := &AggregatedAttestation{
+		ResultHash:        result.ResultHash,
+		BlockNumber:       big.NewInt(100),
+		ValidatorCount:    1,
+		TotalVotingPower:  big.NewInt(1),
+		SignedVotingPower: big.NewInt(1),
+		ThresholdMet:      true,
+		Finalized:         true,
+	}
+	cycleID := "intent-0000000000000002:" + txHash.Hex()
+	cycle := &ProofCycleCompletion{IntentID: "intent-0000000000000002", BundleID: [32]byte{4}, ExecutionResult: result}
+	o.activeCycles[cycleID] = cycle
+
+	o.executePhase9(ctx, cycleID, cycle, result, agg)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for store.get(cycleID).Stage == database.ProofCycleStageWritingBack && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	state := store.get(cycleID)
+	if state.Stage != database.ProofCycleStageWriteBackUnconfirmed {
+		t.Fatalf("expected stage %s, got %+v", database.ProofCycleStageWriteBackUnconfirmed, state)
+	}
+	if state.WriteBackTxID != "receipt-1" || !strings.Contains(state.ErrorMessage, "receipt-1") {
+		t.Errorf("expected the Accumulate txid to be recorded, got %+v", state)
+	}
+	if o.GetActiveCycleCount() != 0 {
+		t.Errorf("expected the cycle to leave the active set, got %d active", o.GetActiveCycleCount())
+	}
+}
+
+func TestShadowSubmitter_ConfirmsWithoutPolling(t *testing.T) {
+	// A shadow submitter has no client: polling would panic
+	s := &AccumulateSubmitterImpl{shadow: true}
+	result := s.WaitForConfirmation(context.Background(), "acc://tx@unknown")
+	if result.Outcome != WriteBackConfirmed || result.Status != "shadow" || result.Err() != nil {
+		t.Errorf("expected a confirmed shadow write-back, got %+v", result)
+	}
+}
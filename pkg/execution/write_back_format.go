@@ -0,0 +1,208 @@
+// Copyright 2025 Certen Protocol
+//
+// Write-Back Format - Configurable structure of proof results written to Accumulate
+//
+// By default the submitter writes the comprehensive key=value layout produced by
+// CertenDataEntry.ToDoubleHashFormat. Applications consuming the results account may
+// expect a different schema, so the format can select:
+//   - the encoding: one "key=value" data entry per field, or a single JSON object
+//   - which CertenDataEntry fields are written, in order
+//   - the key each field is written under (field:key renames a field)
+//   - fields that must be non-empty for the payload to be submitted
+//
+// Fields are named by their CertenDataEntry JSON tags (e.g. tx_hash, result_hash).
+// The encoded payload is validated before submission.
+
+package execution
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// WriteBackEncoding selects how result fields are laid out in the data entry
+type WriteBackEncoding string
+
+const (
+	WriteBackKeyValue WriteBackEncoding = "key_value" // One "key=value" data entry per field
+	WriteBackJSON     WriteBackEncoding = "json"      // One data entry holding a JSON object
+)
+
+// WriteBackField maps a CertenDataEntry field to the key written to Accumulate
+type WriteBackField struct {
+	Field string // CertenDataEntry JSON tag
+	Key   string // Key in the written payload
+}
+
+// WriteBackFormat describes the payload written to the results account
+type WriteBackFormat struct {
+	Encoding WriteBackEncoding
+	Fields   []WriteBackField // Empty = all fields (key_value: the full comprehensive layout)
+	Required []string         // Fields that must be non-empty
+}
+
+// DefaultWriteBackFormat returns the comprehensive key=value format
+func DefaultWriteBackFormat() *WriteBackFormat {
+	return &WriteBackFormat{Encoding: WriteBackKeyValue}
+}
+
+// dataEntryFields lists the CertenDataEntry JSON tags in declaration order
+var dataEntryFields = func() []string {
+	t := reflect.TypeOf(CertenDataEntry{})
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		fields = append(fields, strings.Split(t.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return fields
+}()
+
+func isDataEntryField(name string) bool {
+	for _, field := range dataEntryFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWriteBackFormat builds a format from an encoding name, a comma-separated field
+// list ("field" or "field:key") and a comma-separated list of required fields
+func ParseWriteBackFormat(encoding string, fields, required []string) (*WriteBackFormat, error) {
+	format := &WriteBackFormat{Encoding: WriteBackEncoding(strings.ToLower(strings.TrimSpace(encoding))), Required: required}
+	if format.Encoding == "" {
+		format.Encoding = WriteBackKeyValue
+	}
+	for _, item := range fields {
+		field, key, found := strings.Cut(item, ":")
+		field, key = strings.TrimSpace(field), strings.TrimSpace(key)
+		if !found {
+			key = field
+		}
+		format.Fields = append(format.Fields, WriteBackField{Field: field, Key: key})
+	}
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+	return format, nil
+}
+
+// Validate checks the encoding and that every field exists and keys are unique
+func (f *WriteBackFormat) Validate() error {
+	switch f.Encoding {
+	case WriteBackKeyValue, WriteBackJSON:
+	default:
+		return fmt.Errorf("unknown write-back encoding %q (expected key_value or json)", f.Encoding)
+	}
+	keys := make(map[string]bool, len(f.Fields))
+	for _, field := range f.Fields {
+		if !isDataEntryField(field.Field) {
+			return fmt.Errorf("unknown write-back field %q", field.Field)
+		}
+		if field.Key == "" {
+			return fmt.Errorf("write-back field %q has an empty key", field.Field)
+		}
+		if f.Encoding == WriteBackKeyValue && strings.Contains(field.Key, "=") {
+			return fmt.Errorf("write-back key %q must not contain '='", field.Key)
+		}
+		if keys[field.Key] {
+			return fmt.Errorf("duplicate write-back key %q", field.Key)
+		}
+		keys[field.Key] = true
+	}
+	for _, name := range f.Required {
+		if !isDataEntryField(name) {
+			return fmt.Errorf("unknown required write-back field %q", name)
+		}
+	}
+	return nil
+}
+
+// Encode converts the result into the data entry payload and validates it
+func (f *WriteBackFormat) Encode(e *CertenDataEntry) ([][]byte, error) {
+	values, err := dataEntryValues(e)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range f.Required {
+		if v, ok := values[name].(string); ok && v == "" {
+			return nil, fmt.Errorf("required write-back field %q is empty", name)
+		}
+	}
+
+	var payload [][]byte
+	switch {
+	case f.Encoding == WriteBackKeyValue && len(f.Fields) == 0:
+		payload = e.ToDoubleHashFormat()
+	case f.Encoding == WriteBackKeyValue:
+		payload = make([][]byte, 0, len(f.Fields))
+		for _, field := range f.Fields {
+			payload = append(payload, []byte(fmt.Sprintf("%s=%v", field.Key, values[field.Field])))
+		}
+	case len(f.Fields) == 0:
+		encoded, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		payload = [][]byte{encoded}
+	default:
+		// Build the object by hand to keep the configured key order
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, field := range f.Fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(field.Key)
+			value, err := json.Marshal(values[field.Field])
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		payload = [][]byte{buf.Bytes()}
+	}
+
+	if err := ValidateWriteBackPayload(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// ValidateWriteBackPayload checks that the payload fits a single WriteData entry
+func ValidateWriteBackPayload(payload [][]byte) error {
+	if len(payload) == 0 {
+		return fmt.Errorf("write-back payload is empty")
+	}
+	for i, data := range payload {
+		if len(data) == 0 {
+			return fmt.Errorf("write-back data entry %d is empty", i)
+		}
+	}
+	if _, err := protocol.CheckDataEntrySize(&protocol.DoubleHashDataEntry{Data: payload}); err != nil {
+		return fmt.Errorf("invalid write-back payload: %w", err)
+	}
+	return nil
+}
+
+// dataEntryValues returns the result fields keyed by JSON tag
+func dataEntryValues(e *CertenDataEntry) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	values := make(map[string]interface{}, len(dataEntryFields))
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
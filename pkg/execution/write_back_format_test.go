@@ -0,0 +1,122 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Write-Back Format
+// Tests format parsing, field selection and renaming, encodings and payload validation
+
+package execution
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func newWriteBackTestEntry() *CertenDataEntry {
+	return &CertenDataEntry{
+		EntryType:   "certen:proof_result:v2",
+		Version:     "2.0",
+		TxHash:      "0xabc",
+		BlockNumber: 1234,
+		Success:     true,
+		ResultHash:  "0xresult",
+	}
+}
+
+func TestParseWriteBackFormat(t *testing.T) {
+	format, err := ParseWriteBackFormat("", nil, nil)
+	if err != nil || format.Encoding != WriteBackKeyValue {
+		t.Fatalf("expected default key_value format, got %+v, %v", format, err)
+	}
+
+	format, err = ParseWriteBackFormat("JSON", []string{"tx_hash:txHash", "block_number"}, []string{"result_hash"})
+	if err != nil {
+		t.Fatalf("ParseWriteBackFormat failed: %v", err)
+	}
+	expected := []WriteBackField{{Field: "tx_hash", Key: "txHash"}, {Field: "block_number", Key: "block_number"}}
+	if format.Encoding != WriteBackJSON || len(format.Fields) != 2 || format.Fields[0] != expected[0] || format.Fields[1] != expected[1] {
+		t.Errorf("unexpected format %+v", format)
+	}
+
+	invalid := []struct {
+		encoding string
+		fields   []string
+		required []string
+	}{
+		{"xml", nil, nil},
+		{"json", []string{"not_a_field"}, nil},
+		{"json", []string{"tx_hash:hash", "block_hash:hash"}, nil},
+		{"key_value", []string{"tx_hash:a=b"}, nil},
+		{"key_value", nil, []string{"not_a_field"}},
+	}
+	for _, tt := range invalid {
+		if _, err := ParseWriteBackFormat(tt.encoding, tt.fields, tt.required); err == nil {
+			t.Errorf("expected error for encoding=%q fields=%v required=%v", tt.encoding, tt.fields, tt.required)
+		}
+	}
+}
+
+func TestWriteBackFormat_Encode(t *testing.T) {
+	entry := newWriteBackTestEntry()
+
+	// The default format is the comprehensive layout
+	payload, err := DefaultWriteBackFormat().Encode(entry)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if len(payload) != len(entry.ToDoubleHashFormat()) {
+		t.Errorf("expected the comprehensive layout, got %d entries", len(payload))
+	}
+
+	format, _ := ParseWriteBackFormat("key_value", []string{"tx_hash:hash", "block_number", "success"}, nil)
+	payload, err = format.Encode(entry)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	expected := [][]byte{[]byte("hash=0xabc"), []byte("block_number=1234"), []byte("success=true")}
+	if len(payload) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(payload))
+	}
+	for i := range expected {
+		if !bytes.Equal(payload[i], expected[i]) {
+			t.Errorf("entry %d: expected %q, got %q", i, expected[i], payload[i])
+		}
+	}
+
+	format, _ = ParseWriteBackFormat("json", []string{"tx_hash:txHash", "block_number:block"}, nil)
+	payload, err = format.Encode(entry)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if len(payload) != 1 || string(payload[0]) != `{"txHash":"0xabc","block":1234}` {
+		t.Errorf("unexpected JSON payload %q", payload)
+	}
+
+	format, _ = ParseWriteBackFormat("json", nil, nil)
+	payload, err = format.Encode(entry)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	var decoded CertenDataEntry
+	if err := json.Unmarshal(payload[0], &decoded); err != nil || decoded != *entry {
+		t.Errorf("expected full JSON entry to round-trip, got %v", err)
+	}
+}
+
+func TestWriteBackFormat_Validation(t *testing.T) {
+	entry := newWriteBackTestEntry()
+
+	format, _ := ParseWriteBackFormat("key_value", nil, []string{"tx_hash", "block_hash"})
+	if _, err := format.Encode(entry); err == nil || !strings.Contains(err.Error(), "block_hash") {
+		t.Errorf("expected error for empty required field, got %v", err)
+	}
+
+	entry.GovernanceProofRef = strings.Repeat("a", 25_000)
+	if _, err := DefaultWriteBackFormat().Encode(entry); err == nil {
+		t.Error("expected error for payload over the WriteData size limit")
+	}
+
+	if err := ValidateWriteBackPayload([][]byte{[]byte("a=b"), {}}); err == nil {
+		t.Error("expected error for empty data entry")
+	}
+}
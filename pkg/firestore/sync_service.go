@@ -497,6 +497,83 @@ type ConfirmationUpdateEvent struct {
 	TransactionHashes     []string
 }
 
+// OnAnchorReorged is called when the block an anchor was mined in is reorged out.
+// Confirmations restart from zero; the intent stays at confirmation tracking.
+func (s *SyncService) OnAnchorReorged(ctx context.Context, data *AnchorReorgedEvent) error {
+	if !s.IsEnabled() {
+		return nil
+	}
+
+	for _, accumTxHash := range data.TransactionHashes {
+		userID, intentID, err := s.resolveIntent(ctx, accumTxHash)
+		if err != nil || userID == "" || intentID == "" {
+			continue
+		}
+
+		snapshot := &StatusSnapshot{
+			Stage:       StageConfirmationTracking,
+			StageName:   StageNames[StageConfirmationTracking],
+			Status:      StatusInProgress,
+			Timestamp:   time.Now(),
+			Source:      "validator",
+			ValidatorID: s.validatorID,
+			Data: map[string]interface{}{
+				"anchorTxHash":         data.AnchorTxHash,
+				"reorged":              true,
+				"reorgedBlockNumber":   data.BlockNumber,
+				"reorgedBlockHash":     data.ReorgedBlockHash,
+				"currentBlockHash":     data.CurrentBlockHash,
+				"transactionDropped":   data.TransactionDropped,
+				"currentConfirmations": 0,
+			},
+		}
+
+		if prev, err := s.client.GetLatestStatusSnapshot(ctx, userID, intentID); err == nil && prev != nil {
+			snapshot.PreviousSnapshotID = prev.SnapshotID
+		}
+		snapshot.SnapshotHash = s.computeSnapshotHash(snapshot)
+
+		if err := s.client.CreateStatusSnapshot(ctx, userID, intentID, snapshot); err != nil {
+			s.logger.Printf("Warning: failed to create anchor reorg snapshot: %v", err)
+			continue
+		}
+
+		// Reset the intent's confirmation count
+		stage := int(StageConfirmationTracking)
+		now := time.Now()
+		confirmations := 0
+		if err := s.client.UpdateTransactionIntent(ctx, userID, intentID, &TransactionIntentUpdate{
+			CurrentStage:          &stage,
+			LastUpdated:           &now,
+			EthereumConfirmations: &confirmations,
+		}); err != nil {
+			s.logger.Printf("Warning: failed to reset intent confirmations: %v", err)
+		}
+
+		if err := s.createAuditEntry(ctx, userID, intentID, accumTxHash, "reorged",
+			fmt.Sprintf("Anchor block %d was reorged out; confirmations reset", data.BlockNumber),
+			map[string]interface{}{
+				"anchorTxHash":       data.AnchorTxHash,
+				"transactionDropped": data.TransactionDropped,
+			}); err != nil {
+			s.logger.Printf("Warning: failed to create audit entry: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// AnchorReorgedEvent contains data for an anchor whose block was reorged out
+type AnchorReorgedEvent struct {
+	BatchID            string
+	AnchorTxHash       string
+	BlockNumber        int64  // Height the anchor was mined at
+	ReorgedBlockHash   string // Hash recorded for that height
+	CurrentBlockHash   string // Canonical hash at that height now
+	TransactionDropped bool   // The anchor transaction is no longer mined anywhere
+	TransactionHashes  []string
+}
+
 // ========================================================================================
 // Stage 8: BLS Attestation
 // ========================================================================================
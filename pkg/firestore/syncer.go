@@ -0,0 +1,78 @@
+// Copyright 2025 Certen Protocol
+//
+// Syncer - Proof cycle event sync interface
+// Components always receive a Syncer: the Firestore SyncService when sync is enabled,
+// NullSyncService otherwise, so no caller needs a nil check
+
+package firestore
+
+import "context"
+
+// Syncer syncs proof cycle events for real-time UI updates
+type Syncer interface {
+	IsEnabled() bool
+	RegisterIntent(accumTxHash, userID, intentID string)
+
+	OnIntentDiscovered(ctx context.Context, data *IntentDiscoveredEvent) error
+	OnProofGenerated(ctx context.Context, data *ProofGeneratedEvent) error
+	OnBatchClosed(ctx context.Context, data *BatchClosedEvent) error
+	OnAnchorSubmitted(ctx context.Context, data *AnchorSubmittedEvent) error
+	OnConfirmationUpdate(ctx context.Context, data *ConfirmationUpdateEvent) error
+	OnAnchorReorged(ctx context.Context, data *AnchorReorgedEvent) error
+	OnBLSAttestation(ctx context.Context, data *BLSAttestationEvent) error
+	OnWriteBack(ctx context.Context, data *WriteBackEvent) error
+}
+
+var (
+	_ Syncer = (*SyncService)(nil)
+	_ Syncer = NullSyncService{}
+)
+
+// NullSyncService is a Syncer that is never enabled and drops every event
+type NullSyncService struct{}
+
+// IsEnabled always returns false
+func (NullSyncService) IsEnabled() bool { return false }
+
+// RegisterIntent does nothing
+func (NullSyncService) RegisterIntent(accumTxHash, userID, intentID string) {}
+
+// OnIntentDiscovered drops the event
+func (NullSyncService) OnIntentDiscovered(ctx context.Context, data *IntentDiscoveredEvent) error {
+	return nil
+}
+
+// OnProofGenerated drops the event
+func (NullSyncService) OnProofGenerated(ctx context.Context, data *ProofGeneratedEvent) error {
+	return nil
+}
+
+// OnBatchClosed drops the event
+func (NullSyncService) OnBatchClosed(ctx context.Context, data *BatchClosedEvent) error {
+	return nil
+}
+
+// OnAnchorSubmitted drops the event
+func (NullSyncService) OnAnchorSubmitted(ctx context.Context, data *AnchorSubmittedEvent) error {
+	return nil
+}
+
+// OnConfirmationUpdate drops the event
+func (NullSyncService) OnConfirmationUpdate(ctx context.Context, data *ConfirmationUpdateEvent) error {
+	return nil
+}
+
+// OnAnchorReorged drops the event
+func (NullSyncService) OnAnchorReorged(ctx context.Context, data *AnchorReorgedEvent) error {
+	return nil
+}
+
+// OnBLSAttestation drops the event
+func (NullSyncService) OnBLSAttestation(ctx context.Context, data *BLSAttestationEvent) error {
+	return nil
+}
+
+// OnWriteBack drops the event
+func (NullSyncService) OnWriteBack(ctx context.Context, data *WriteBackEvent) error {
+	return nil
+}
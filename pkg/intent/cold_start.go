@@ -0,0 +1,67 @@
+// Copyright 2025 Certen Protocol
+//
+// Cold-Start Policy - Where intent discovery begins without a persisted checkpoint
+//
+// A validator that has processed blocks before resumes from its persisted last block.
+// A brand-new validator has no checkpoint, and scanning from genesis on a long-lived
+// network is impractical, so the cold-start policy selects the first height instead:
+//   - genesis:  scan from MinStartHeight (the full history)
+//   - head:     start at the current head, only new blocks are scanned
+//   - lookback: start a fixed number of blocks behind the current head
+//
+// The chosen height is never below MinStartHeight.
+
+package intent
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColdStartPolicy selects the starting height when no checkpoint is persisted
+type ColdStartPolicy string
+
+const (
+	ColdStartGenesis  ColdStartPolicy = "genesis"  // Scan from MinStartHeight
+	ColdStartHead     ColdStartPolicy = "head"     // Start at the current head
+	ColdStartLookback ColdStartPolicy = "lookback" // Start ColdStartLookback blocks behind head
+)
+
+// DefaultColdStartLookback is the lookback used when none is configured
+const DefaultColdStartLookback = 5
+
+// ParseColdStartPolicy parses a cold-start policy name; empty selects lookback
+func ParseColdStartPolicy(s string) (ColdStartPolicy, error) {
+	switch policy := ColdStartPolicy(strings.ToLower(strings.TrimSpace(s))); policy {
+	case "":
+		return ColdStartLookback, nil
+	case ColdStartGenesis, ColdStartHead, ColdStartLookback:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown cold-start policy %q (expected genesis, head or lookback)", s)
+	}
+}
+
+// NeedsHead reports whether the policy needs the current head height
+func (p ColdStartPolicy) NeedsHead() bool {
+	return p != ColdStartGenesis
+}
+
+// ColdStartHeight returns the starting height for the policy given the current head
+func ColdStartHeight(policy ColdStartPolicy, lookback, minStart, head uint64) uint64 {
+	var start uint64
+	switch policy {
+	case ColdStartGenesis:
+		start = minStart
+	case ColdStartHead:
+		start = head
+	default:
+		if lookback < head {
+			start = head - lookback
+		}
+	}
+	if start < minStart {
+		start = minStart
+	}
+	return start
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Cold-Start Policy
+// Tests policy parsing and the starting height chosen for each policy
+
+package intent
+
+import "testing"
+
+func TestParseColdStartPolicy(t *testing.T) {
+	tests := map[string]ColdStartPolicy{
+		"":         ColdStartLookback,
+		"genesis":  ColdStartGenesis,
+		" HEAD ":   ColdStartHead,
+		"Lookback": ColdStartLookback,
+	}
+	for input, expected := range tests {
+		got, err := ParseColdStartPolicy(input)
+		if err != nil || got != expected {
+			t.Errorf("ParseColdStartPolicy(%q) = %q, %v; expected %q", input, got, err, expected)
+		}
+	}
+	if _, err := ParseColdStartPolicy("latest"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestColdStartHeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ColdStartPolicy
+		lookback uint64
+		minStart uint64
+		head     uint64
+		expected uint64
+	}{
+		{"genesis", ColdStartGenesis, 1000, 0, 5_000_000, 0},
+		{"genesis respects min start", ColdStartGenesis, 1000, 946000, 5_000_000, 946000},
+		{"head", ColdStartHead, 1000, 0, 5_000_000, 5_000_000},
+		{"lookback", ColdStartLookback, 1000, 0, 5_000_000, 4_999_000},
+		{"lookback past genesis", ColdStartLookback, 1000, 0, 400, 0},
+		{"lookback respects min start", ColdStartLookback, 100_000, 4_950_000, 5_000_000, 4_950_000},
+	}
+	for _, tt := range tests {
+		if got := ColdStartHeight(tt.policy, tt.lookback, tt.minStart, tt.head); got != tt.expected {
+			t.Errorf("%s: ColdStartHeight = %d, expected %d", tt.name, got, tt.expected)
+		}
+	}
+}
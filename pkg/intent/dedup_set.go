@@ -0,0 +1,265 @@
+// Copyright 2025 Certen Protocol
+//
+// Intent Dedup Set - Bounded, persistent retention of intent processing status
+//
+// The intent status map is what stops an intent seen in a re-scanned block from being
+// processed twice. Kept forever it grows without bound; kept only in memory it is lost
+// on restart and recently processed intents are processed again. Retention is bounded:
+//   - by age: completed, failed and dead-lettered intents are forgotten after DedupMaxAge
+//   - by size: beyond DedupMaxEntries the oldest of those intents are forgotten first
+//   - in-progress and deferred intents are never evicted
+//
+// The age bound is never shorter than the finality deferral horizon (every retry delay
+// plus the final attempt), so an intent is still remembered for as long as it could be
+// rediscovered while its processing is retried.
+//
+// Completed and dead-lettered intents are persisted through the ledger store and
+// restored on start.
+
+package intent
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultIntentDedupMaxAge     = 24 * time.Hour
+	DefaultIntentDedupMaxEntries = 100000
+)
+
+// IntentDedupStore persists the dedup set across restarts
+// Implemented by ledger.LedgerStore
+type IntentDedupStore interface {
+	SaveIntentDedupSet(data []byte) error
+	LoadIntentDedupSet() ([]byte, error)
+}
+
+// IntentDedupEntry is a persisted dedup set entry
+type IntentDedupEntry struct {
+	IntentID   string    `json:"intent_id"`
+	Status     string    `json:"status"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// IntentDedupStats reports the size and retention of the dedup set
+type IntentDedupStats struct {
+	Size             int     `json:"size"`
+	OldestAgeSeconds float64 `json:"oldest_age_seconds"`
+	MaxAge           string  `json:"max_age"`     // "0s" = no age bound
+	MaxEntries       int     `json:"max_entries"` // 0 = no size bound
+	Evicted          int64   `json:"evicted"`     // Entries evicted since startup
+	Persistent       bool    `json:"persistent"`
+}
+
+// IntentDedupRetryHorizon returns how long an intent can keep being retried under
+// finality deferral: every retry delay plus the final attempt
+func IntentDedupRetryHorizon(retryDelay time.Duration, maxDeferrals int) time.Duration {
+	if maxDeferrals < 0 {
+		maxDeferrals = 0
+	}
+	return retryDelay * time.Duration(maxDeferrals+1)
+}
+
+// dedupRetention returns the effective age and size bounds (0 = unbounded)
+func (id *IntentDiscovery) dedupRetention() (time.Duration, int) {
+	maxAge := id.config.DedupMaxAge
+	if maxAge > 0 {
+		if horizon := IntentDedupRetryHorizon(id.config.FinalityRetryDelay, id.config.MaxFinalityDeferrals); maxAge < horizon {
+			maxAge = horizon
+		}
+	}
+	return maxAge, id.config.DedupMaxEntries
+}
+
+// dedupEvictable reports whether an entry with this status may be forgotten
+func dedupEvictable(status IntentStatus) bool {
+	return status == IntentStatusCompleted || status == IntentStatusFailed || status == IntentStatusDeadLettered
+}
+
+// dedupPersisted reports whether an entry with this status survives a restart
+func dedupPersisted(status IntentStatus) bool {
+	return status == IntentStatusCompleted || status == IntentStatusDeadLettered
+}
+
+func parseIntentStatus(s string) (IntentStatus, bool) {
+	for _, status := range []IntentStatus{IntentStatusCompleted, IntentStatusDeadLettered} {
+		if status.String() == s {
+			return status, true
+		}
+	}
+	return IntentStatusPending, false
+}
+
+// setIntentStatusLocked records an intent's status and when it was set. Caller holds id.mu.
+func (id *IntentDiscovery) setIntentStatusLocked(intentID string, status IntentStatus) {
+	id.intentStatus[intentID] = status
+	id.intentRecordedAt[intentID] = time.Now()
+	if dedupPersisted(status) {
+		id.dedupDirty = true
+	}
+}
+
+// pruneDedupSet evicts entries beyond the age and size bounds and returns how many were evicted
+func (id *IntentDiscovery) pruneDedupSet(now time.Time) int {
+	maxAge, maxEntries := id.dedupRetention()
+
+	id.mu.Lock()
+	defer id.mu.Unlock()
+
+	evicted := 0
+	evict := func(intentID string) {
+		if dedupPersisted(id.intentStatus[intentID]) {
+			id.dedupDirty = true
+		}
+		delete(id.intentStatus, intentID)
+		delete(id.intentRecordedAt, intentID)
+		evicted++
+	}
+
+	if maxAge > 0 {
+		cutoff := now.Add(-maxAge)
+		for intentID, status := range id.intentStatus {
+			if dedupEvictable(status) && id.intentRecordedAt[intentID].Before(cutoff) {
+				evict(intentID)
+			}
+		}
+	}
+
+	if maxEntries > 0 && len(id.intentStatus) > maxEntries {
+		var candidates []string
+		for intentID, status := range id.intentStatus {
+			if dedupEvictable(status) {
+				candidates = append(candidates, intentID)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			return id.intentRecordedAt[candidates[i]].Before(id.intentRecordedAt[candidates[j]])
+		})
+		for _, intentID := range candidates {
+			if len(id.intentStatus) <= maxEntries {
+				break
+			}
+			evict(intentID)
+		}
+	}
+
+	id.dedupEvicted += int64(evicted)
+	return evicted
+}
+
+// dedupStore returns the ledger store when it can persist the dedup set
+func (id *IntentDiscovery) dedupStore() IntentDedupStore {
+	store, _ := id.ledgerStore.(IntentDedupStore)
+	return store
+}
+
+// loadDedupSet restores persisted entries that are still within the age bound
+func (id *IntentDiscovery) loadDedupSet() {
+	store := id.dedupStore()
+	if store == nil {
+		return
+	}
+	data, err := store.LoadIntentDedupSet()
+	if err != nil {
+		id.logger.Printf("⚠️ Failed to load intent dedup set: %v", err)
+		return
+	}
+	if len(data) == 0 {
+		return
+	}
+	var entries []IntentDedupEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		id.logger.Printf("⚠️ Failed to decode intent dedup set: %v", err)
+		return
+	}
+
+	id.mu.Lock()
+	restored := 0
+	for _, entry := range entries {
+		status, ok := parseIntentStatus(entry.Status)
+		if !ok {
+			continue
+		}
+		if _, exists := id.intentStatus[entry.IntentID]; exists {
+			continue // Seen again since the snapshot
+		}
+		id.intentStatus[entry.IntentID] = status
+		id.intentRecordedAt[entry.IntentID] = entry.RecordedAt
+		restored++
+	}
+	id.mu.Unlock()
+
+	evicted := id.pruneDedupSet(time.Now())
+	id.logger.Printf("📊 Restored %d intents into the dedup set (%d expired)", restored-evicted, evicted)
+}
+
+// persistDedupSet saves completed and dead-lettered entries when they changed since the last save
+func (id *IntentDiscovery) persistDedupSet() {
+	store := id.dedupStore()
+	if store == nil {
+		return
+	}
+
+	id.mu.Lock()
+	if !id.dedupDirty {
+		id.mu.Unlock()
+		return
+	}
+	entries := make([]IntentDedupEntry, 0, len(id.intentStatus))
+	for intentID, status := range id.intentStatus {
+		if dedupPersisted(status) {
+			entries = append(entries, IntentDedupEntry{
+				IntentID:   intentID,
+				Status:     status.String(),
+				RecordedAt: id.intentRecordedAt[intentID],
+			})
+		}
+	}
+	id.dedupDirty = false
+	id.mu.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err == nil {
+		err = store.SaveIntentDedupSet(data)
+	}
+	if err != nil {
+		id.mu.Lock()
+		id.dedupDirty = true
+		id.mu.Unlock()
+		id.logger.Printf("⚠️ Failed to persist intent dedup set: %v", err)
+	}
+}
+
+// DedupStats returns the dedup set's size, oldest entry age and retention bounds
+func (id *IntentDiscovery) DedupStats() IntentDedupStats {
+	maxAge, maxEntries := id.dedupRetention()
+
+	id.mu.RLock()
+	defer id.mu.RUnlock()
+
+	stats := IntentDedupStats{
+		Size:       len(id.intentStatus),
+		MaxAge:     maxAge.String(),
+		MaxEntries: maxEntries,
+		Evicted:    id.dedupEvicted,
+		Persistent: id.dedupStore() != nil,
+	}
+	stats.OldestAgeSeconds = id.dedupOldestAgeLocked().Seconds()
+	return stats
+}
+
+// dedupOldestAgeLocked returns the age of the oldest entry, 0 when empty. Caller holds id.mu.
+func (id *IntentDiscovery) dedupOldestAgeLocked() time.Duration {
+	var oldest time.Time
+	for _, recordedAt := range id.intentRecordedAt {
+		if oldest.IsZero() || recordedAt.Before(oldest) {
+			oldest = recordedAt
+		}
+	}
+	if oldest.IsZero() {
+		return 0
+	}
+	return time.Since(oldest)
+}
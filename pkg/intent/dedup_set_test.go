@@ -0,0 +1,110 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Intent Dedup Set
+// Tests age and size eviction, the deferral horizon floor and persistence across restarts
+
+package intent
+
+import (
+	"testing"
+	"time"
+)
+
+// memoryLedgerStore persists the last block and dedup set in memory
+type memoryLedgerStore struct {
+	lastBlock uint64
+	dedup     []byte
+}
+
+func (m *memoryLedgerStore) SaveIntentLastBlock(height uint64) error {
+	m.lastBlock = height
+	return nil
+}
+func (m *memoryLedgerStore) LoadIntentLastBlock() (uint64, error) { return m.lastBlock, nil }
+func (m *memoryLedgerStore) SaveIntentDedupSet(data []byte) error { m.dedup = data; return nil }
+func (m *memoryLedgerStore) LoadIntentDedupSet() ([]byte, error)  { return m.dedup, nil }
+
+func newDedupTestDiscovery(store LedgerStoreInterface, maxAge time.Duration, maxEntries int) *IntentDiscovery {
+	cfg := DefaultIntentDiscoveryConfig()
+	cfg.FinalityRetryDelay = time.Minute
+	cfg.MaxFinalityDeferrals = 2
+	cfg.DedupMaxAge = maxAge
+	cfg.DedupMaxEntries = maxEntries
+	return NewIntentDiscovery(nil, "", cfg, store, nil, "validator-1")
+}
+
+func TestDedupSet_AgeAndSizeEviction(t *testing.T) {
+	id := newDedupTestDiscovery(nil, time.Hour, 3)
+	now := time.Now()
+
+	for _, intentID := range []string{"old", "a", "b", "c", "active"} {
+		id.markInProgress(intentID)
+	}
+	id.markCompleted("old")
+	id.markCompleted("a")
+	id.markFailed("b")
+	id.markCompleted("c")
+	id.intentRecordedAt["old"] = now.Add(-2 * time.Hour)
+	id.intentRecordedAt["a"] = now.Add(-3 * time.Minute)
+	id.intentRecordedAt["b"] = now.Add(-2 * time.Minute)
+	id.intentRecordedAt["active"] = now.Add(-3 * time.Hour) // In progress: never evicted
+
+	if evicted := id.pruneDedupSet(now); evicted != 2 {
+		t.Errorf("evicted %d, expected 2 (one by age, one by size)", evicted)
+	}
+	for intentID, expected := range map[string]IntentStatus{"old": IntentStatusPending, "a": IntentStatusPending,
+		"b": IntentStatusFailed, "c": IntentStatusCompleted, "active": IntentStatusInProgress} {
+		if status := id.getIntentStatus(intentID); status != expected {
+			t.Errorf("%s: status %s, expected %s", intentID, status, expected)
+		}
+	}
+
+	stats := id.DedupStats()
+	if stats.Size != 3 || stats.Evicted != 2 || stats.OldestAgeSeconds < (3*time.Hour).Seconds()-1 || stats.Persistent {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestDedupSet_RetentionCoversDeferralHorizon(t *testing.T) {
+	id := newDedupTestDiscovery(nil, time.Minute, 0)
+	if maxAge, _ := id.dedupRetention(); maxAge != 3*time.Minute {
+		t.Errorf("max age %v, expected the 3m deferral horizon", maxAge)
+	}
+
+	id.markInProgress("retried")
+	id.markFailed("retried")
+	id.intentRecordedAt["retried"] = time.Now().Add(-2 * time.Minute)
+	if evicted := id.pruneDedupSet(time.Now()); evicted != 0 {
+		t.Errorf("intent within the deferral horizon was evicted")
+	}
+}
+
+func TestDedupSet_PersistsAcrossRestart(t *testing.T) {
+	store := &memoryLedgerStore{}
+	id := newDedupTestDiscovery(store, time.Hour, 0)
+	for _, intentID := range []string{"done", "expired", "failed", "running"} {
+		id.markInProgress(intentID)
+	}
+	id.markCompleted("done")
+	id.markCompleted("expired")
+	id.markFailed("failed")
+	id.intentRecordedAt["expired"] = time.Now().Add(-2 * time.Hour)
+	id.persistDedupSet()
+	if store.dedup == nil {
+		t.Fatal("dedup set was not persisted")
+	}
+
+	restarted := newDedupTestDiscovery(store, time.Hour, 0)
+	restarted.loadDedupSet()
+	if restarted.markInProgress("done") {
+		t.Error("completed intent was processed again after restart")
+	}
+	for _, intentID := range []string{"expired", "failed", "running"} {
+		if !restarted.markInProgress(intentID) {
+			t.Errorf("%s should be processable after restart", intentID)
+		}
+	}
+	if stats := restarted.DedupStats(); !stats.Persistent || stats.Evicted != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -14,8 +14,10 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +26,7 @@ import (
 	"github.com/certen/independant-validator/pkg/batch"
 	"github.com/certen/independant-validator/pkg/commitment"
 	"github.com/certen/independant-validator/pkg/consensus"
+	"github.com/certen/independant-validator/pkg/database"
 	"github.com/certen/independant-validator/pkg/proof"
 )
 
@@ -49,6 +52,34 @@ type IntentDiscoveryConfig struct {
 	MaxConcurrentBlocks int           `json:"max_concurrent_blocks"`
 	IntentBatchSize     int           `json:"intent_batch_size"`
 	MinStartHeight      uint64        `json:"min_start_height"`  // Minimum starting height fallback
+
+	// Cold start: where scanning begins when no last processed block is persisted
+	ColdStartPolicy   ColdStartPolicy `json:"cold_start_policy"`   // genesis, head or lookback
+	ColdStartLookback uint64          `json:"cold_start_lookback"` // Blocks behind head for the lookback policy
+
+	// Proof-work partitioning: each intent's proofs are generated by one owner validator
+	// (intent hash modulo validator count) and shared with the others
+	ProofWorkPartitioning  bool          `json:"proof_work_partitioning"`
+	ValidatorSet           []string      `json:"validator_set"`             // MUST match on all validators
+	ProofShareWait         time.Duration `json:"proof_share_wait"`          // Wait per fallback window before generating locally
+	ProofSharePollInterval time.Duration `json:"proof_share_poll_interval"` // Peer polling interval while waiting
+
+	// Finality deferral: intents whose transaction is not yet final are retried, then dead-lettered
+	FinalityRetryDelay   time.Duration `json:"finality_retry_delay"`   // Delay before retrying a deferred intent
+	MaxFinalityDeferrals int           `json:"max_finality_deferrals"` // Deferrals before an intent is dead-lettered
+
+	// Dedup retention: processed intents are remembered this long, at most this many (0 = unbounded)
+	DedupMaxAge     time.Duration `json:"dedup_max_age"`     // Raised to the finality deferral horizon if shorter
+	DedupMaxEntries int           `json:"dedup_max_entries"` // Oldest processed intents are evicted first
+
+	// Priority rules for intents without a priority hint (first match wins)
+	PriorityRules []PriorityRule `json:"priority_rules"`
+
+	// Synthetic transactions produced by an intent's transaction are proven and anchored linked to it
+	SyntheticTxEnabled      bool          `json:"synthetic_tx_enabled"`
+	SyntheticTxProofClass   string        `json:"synthetic_tx_proof_class"`    // on_cadence, on_demand or empty to inherit the intent's
+	SyntheticTxMaxPerIntent int           `json:"synthetic_tx_max_per_intent"` // 0 = unbounded
+	SyntheticTxFinalityWait time.Duration `json:"synthetic_tx_finality_wait"`  // Wait for pending synthetic transactions to be delivered
 }
 
 // IntentStatus represents the processing state of an intent
@@ -60,6 +91,8 @@ const (
 	IntentStatusInProgress                     // Currently being processed
 	IntentStatusCompleted                      // Successfully processed
 	IntentStatusFailed                         // Processing failed, can be retried
+	IntentStatusDeferred                       // Transaction not yet final, retry scheduled
+	IntentStatusDeadLettered                   // Transaction will never finalize or deferrals exhausted
 )
 
 func (s IntentStatus) String() string {
@@ -72,6 +105,10 @@ func (s IntentStatus) String() string {
 		return "completed"
 	case IntentStatusFailed:
 		return "failed"
+	case IntentStatusDeferred:
+		return "deferred"
+	case IntentStatusDeadLettered:
+		return "dead_lettered"
 	default:
 		return "unknown"
 	}
@@ -94,8 +131,26 @@ type IntentDiscovery struct {
 	batchingEnabled      bool                           // Toggle for batch system routing
 	governanceProofGen   proof.GovernanceProofGenerator // For G0/G1/G2 proof generation
 
+	// Proof-work partitioning across the validator set (nil = every validator generates)
+	workPartitioner     *ProofWorkPartitioner
+	sharedProofSource   SharedProofSource // Where non-owners fetch the owner's proof
+	sharedProofCache    *SharedProofCache // Where the owner publishes proofs for peers
+	sharedProofSigner   SharedProofSigner // Signs published proofs (nil = nothing is published)
+	sharedProofsUsed    int64             // Intents that reused a peer's proof
+	fallbackGenerations int64             // Non-owner intents generated locally after the share wait
+
+	// Finality deferral (nil checker = process intents as soon as they are discovered)
+	finalityChecker TransactionFinalityChecker
+	deferredIntents map[string]*DeferredIntent
+	deadLetters     []*DeadLetteredIntent
+
+	// Synthetic transaction anchoring (nil source = synthetic transactions are not discovered)
+	syntheticSource SyntheticTransactionSource
+	syntheticStats  SyntheticTxStats
+
 	// Block monitoring state
 	lastProcessedBlock  uint64
+	partitionCursors   map[string]uint64 // Per-partition last processed block (PartitionBlockSource clients)
 	isMonitoring       bool
 	stopCh             chan struct{}
 	blockProcessCh     chan *BlockProcessJob
@@ -103,6 +158,9 @@ type IntentDiscovery struct {
 
 	// Intent tracking - E.4 remediation: Two-phase status tracking
 	intentStatus       map[string]IntentStatus // Tracks status of each intent
+	intentRecordedAt   map[string]time.Time    // When each status was set (dedup retention)
+	dedupDirty         bool                    // Persisted entries changed since the last save
+	dedupEvicted       int64                   // Entries evicted by retention
 	intentCount        int64                   // Total intents discovered
 }
 
@@ -114,9 +172,10 @@ type LedgerStoreInterface interface {
 
 // BlockProcessJob represents a block processing job
 type BlockProcessJob struct {
-	PartitionURL string
-	BlockHeight  uint64
-	BlockData    *accumulate.Block
+	PartitionURL    string
+	BlockHeight     uint64
+	BlockData       *accumulate.Block
+	SinglePartition bool // Search only PartitionURL (per-partition cursors) instead of every partition
 }
 
 // CertenIntent uses the canonical type from protocol package
@@ -130,6 +189,16 @@ func DefaultIntentDiscoveryConfig() *IntentDiscoveryConfig {
 		MaxConcurrentBlocks: MAX_CONCURRENT_BLOCKS,
 		IntentBatchSize:     INTENT_BATCH_SIZE,
 		MinStartHeight:      946000,  // Current testnet baseline
+		ColdStartPolicy:     ColdStartLookback,
+		ColdStartLookback:   DefaultColdStartLookback,
+		ProofShareWait:         20 * time.Second,
+		ProofSharePollInterval: 2 * time.Second,
+		FinalityRetryDelay:     30 * time.Second,
+		MaxFinalityDeferrals:   10,
+		DedupMaxAge:            DefaultIntentDedupMaxAge,
+		DedupMaxEntries:        DefaultIntentDedupMaxEntries,
+		SyntheticTxMaxPerIntent: DefaultSyntheticTxMaxPerIntent,
+		SyntheticTxFinalityWait: DefaultSyntheticTxFinalityWait,
 	}
 }
 
@@ -146,7 +215,7 @@ func NewIntentDiscovery(
 		config = DefaultIntentDiscoveryConfig()
 	}
 
-	return &IntentDiscovery{
+	id := &IntentDiscovery{
 		client:           client,
 		accumulateURL:    accumulateURL,
 		config:           config,
@@ -155,8 +224,26 @@ func NewIntentDiscovery(
 		proofGenerator:   proofGen,
 		validatorID:      validatorID,
 		intentStatus:     make(map[string]IntentStatus), // E.4 remediation: Two-phase status tracking
+		intentRecordedAt: make(map[string]time.Time),
+		deferredIntents:  make(map[string]*DeferredIntent),
 		lastProcessedBlock: 0,
 	}
+
+	if client != nil {
+		id.finalityChecker = NewClientFinalityChecker(client)
+		id.syntheticSource = client
+	}
+
+	if config.ProofWorkPartitioning {
+		partitioner, err := NewProofWorkPartitioner(validatorID, config.ValidatorSet)
+		if err != nil {
+			id.logger.Printf("⚠️ Proof-work partitioning disabled: %v", err)
+		} else {
+			id.workPartitioner = partitioner
+		}
+	}
+
+	return id
 }
 
 // NewIntentDiscoveryLegacy creates a new intent discovery service with legacy signature for backward compatibility
@@ -204,6 +291,19 @@ func (id *IntentDiscovery) SetGovernanceProofGenerator(gen proof.GovernanceProof
 	}
 }
 
+// SetSharedProofExchange configures how proofs are exchanged under proof-work partitioning.
+// source is queried by non-owners; cache receives proofs this validator generates as owner,
+// signed with signer so peers can check they came from the owner.
+func (id *IntentDiscovery) SetSharedProofExchange(source SharedProofSource, cache *SharedProofCache, signer SharedProofSigner) {
+	id.sharedProofSource = source
+	id.sharedProofCache = cache
+	id.sharedProofSigner = signer
+	if id.workPartitioner != nil {
+		id.logger.Printf("🤝 Proof-work partitioning enabled across %d validators (share wait: %v)",
+			len(id.workPartitioner.ValidatorSet()), id.config.ProofShareWait)
+	}
+}
+
 // StartMonitoring begins monitoring Accumulate blockchain for Certen intents
 // This method supports restart - each call creates fresh channels and workers
 func (id *IntentDiscovery) StartMonitoring() {
@@ -226,6 +326,9 @@ func (id *IntentDiscovery) StartMonitoring() {
 
 	id.mu.Unlock()
 
+	// Intents processed before a restart must not be processed again
+	id.loadDedupSet()
+
 	id.logger.Printf("🔍 Starting Certen Intent Discovery Service...")
 	id.logger.Printf("📡 Monitoring Accumulate network: %s", id.accumulateURL)
 	id.logger.Printf("🎯 Looking for transactions with memo: %s", CERTEN_INTENT_MEMO)
@@ -235,6 +338,11 @@ func (id *IntentDiscovery) StartMonitoring() {
 	id.logger.Printf("   - Max Concurrent Blocks: %d", id.config.MaxConcurrentBlocks)
 	id.logger.Printf("   - Intent Batch Size: %d", id.config.IntentBatchSize)
 	id.logger.Printf("   - Min Start Height: %d", id.config.MinStartHeight)
+	id.logger.Printf("   - Cold Start: %s (lookback %d blocks)", id.config.ColdStartPolicy, id.config.ColdStartLookback)
+	id.logger.Printf("   - Finality Deferral: retry every %v, dead-letter after %d deferrals",
+		id.config.FinalityRetryDelay, id.config.MaxFinalityDeferrals)
+	dedupMaxAge, dedupMaxEntries := id.dedupRetention()
+	id.logger.Printf("   - Dedup Retention: %v, max %d entries", dedupMaxAge, dedupMaxEntries)
 
 	// Start block processor workers
 	for i := 0; i < 3; i++ {
@@ -246,21 +354,26 @@ func (id *IntentDiscovery) StartMonitoring() {
 	// Start main monitoring loop
 	go id.monitoringLoop()
 
+	// Retry intents deferred while their transaction was not yet final
+	go id.deferralLoop(id.stopCh)
+
 	id.logger.Printf("✅ Intent discovery service started successfully with 3 workers")
 }
 
 // StopMonitoring stops the intent discovery service
 func (id *IntentDiscovery) StopMonitoring() {
 	id.mu.Lock()
-	defer id.mu.Unlock()
-
 	if !id.isMonitoring {
+		id.mu.Unlock()
 		return
 	}
 
 	id.logger.Printf("🛑 Stopping intent discovery service...")
 	close(id.stopCh)
 	id.isMonitoring = false
+	id.mu.Unlock()
+
+	id.persistDedupSet()
 	id.logger.Printf("✅ Intent discovery service stopped")
 }
 
@@ -300,20 +413,31 @@ func (id *IntentDiscovery) monitoringLoop() {
 			if err := id.checkForNewBlocks(ctx); err != nil {
 				id.logger.Printf("⚠️ Error checking blocks: %v", err)
 			} else {
-				id.logger.Printf("✅ Block check completed at height: %d", id.lastProcessedBlock)
-				// Persist the updated height
-				if id.ledgerStore != nil {
+				if id.partitionSource() != nil {
+					id.logger.Printf("✅ Block check completed at partition heights: %v", id.PartitionCursors())
+					id.persistPartitionCursors()
+				} else if id.ledgerStore != nil {
+					id.logger.Printf("✅ Block check completed at height: %d", id.lastProcessedBlock)
+					// Persist the updated height
 					if err := id.ledgerStore.SaveIntentLastBlock(id.lastProcessedBlock); err != nil {
 						id.logger.Printf("⚠️ Failed to persist last processed block height: %v", err)
 					}
 				}
 			}
+			if evicted := id.pruneDedupSet(time.Now()); evicted > 0 {
+				id.logger.Printf("🧹 Evicted %d intents from the dedup set", evicted)
+			}
+			id.persistDedupSet()
 		}
 	}
 }
 
 // initializeStartingHeight determines the starting block height using persistence
 func (id *IntentDiscovery) initializeStartingHeight(ctx context.Context) error {
+	if source := id.partitionSource(); source != nil {
+		return id.initializePartitionCursors(ctx, source)
+	}
+
 	var startHeight uint64
 
 	// Try to load persisted height first
@@ -327,22 +451,32 @@ func (id *IntentDiscovery) initializeStartingHeight(ctx context.Context) error {
 		}
 	}
 
-	// If no persisted height, determine from latest block
+	// If no persisted height, apply the cold-start policy
 	if startHeight == 0 {
-		latestBlock, err := id.client.GetLatestBlock(ctx)
-		if err != nil {
-			id.logger.Printf("❌ Failed to get latest block: %v", err)
-			startHeight = id.config.MinStartHeight
-			id.logger.Printf("📊 Using configured minimum starting height: %d", startHeight)
-		} else {
-			startHeight = latestBlock.Height - 5 // Start 5 blocks back to catch any missed
-			id.logger.Printf("📊 Starting from latest block - 5: %d (latest: %d)", startHeight, latestBlock.Height)
+		policy := id.config.ColdStartPolicy
+		if policy == "" {
+			policy = ColdStartLookback
+		}
 
-			// Ensure we're not starting too far in the past
-			if startHeight < id.config.MinStartHeight {
-				startHeight = id.config.MinStartHeight
-				id.logger.Printf("📊 Adjusted to minimum starting height: %d", startHeight)
+		var head uint64
+		if policy.NeedsHead() {
+			latestBlock, err := id.client.GetLatestBlock(ctx)
+			if err != nil {
+				// Retried by the caller; falling back to MinStartHeight would rescan history
+				return fmt.Errorf("cold start (%s) needs the latest block: %w", policy, err)
 			}
+			head = latestBlock.Height
+		}
+
+		startHeight = ColdStartHeight(policy, id.config.ColdStartLookback, id.config.MinStartHeight, head)
+		switch policy {
+		case ColdStartGenesis:
+			id.logger.Printf("📊 Cold start (genesis): scanning from height %d", startHeight)
+		case ColdStartHead:
+			id.logger.Printf("📊 Cold start (head): starting at height %d, only new blocks are scanned", startHeight)
+		default:
+			id.logger.Printf("📊 Cold start (lookback %d): starting at height %d (head %d, min %d)",
+				id.config.ColdStartLookback, startHeight, head, id.config.MinStartHeight)
 		}
 
 		// Persist the initial height
@@ -359,6 +493,11 @@ func (id *IntentDiscovery) initializeStartingHeight(ctx context.Context) error {
 
 // checkForNewBlocks checks for new blocks and queues them for processing
 func (id *IntentDiscovery) checkForNewBlocks(ctx context.Context) error {
+	// Partitions advance independently: poll each against its own cursor
+	if source := id.partitionSource(); source != nil {
+		return id.checkPartitionBlocks(ctx, source)
+	}
+
 	// Use DN (Directory Network) as reference for latest block height
 	latestBlock, err := id.client.GetLatestBlock(ctx)
 	if err != nil {
@@ -458,7 +597,13 @@ func (id *IntentDiscovery) processBlock(job *BlockProcessJob, workerID string) e
 
 	// Use the new comprehensive v3 API search across all partitions
 	id.logger.Printf("🔍 Worker %s calling SearchCertenTransactions for block %d...", workerID, job.BlockHeight)
-	certenTransactions, err := id.client.SearchCertenTransactions(ctx, int64(job.BlockHeight))
+	var certenTransactions []*accumulate.CertenTransaction
+	var err error
+	if source := id.partitionSource(); job.SinglePartition && source != nil {
+		certenTransactions, err = source.SearchPartitionCertenTransactions(ctx, job.PartitionURL, int64(job.BlockHeight))
+	} else {
+		certenTransactions, err = id.client.SearchCertenTransactions(ctx, int64(job.BlockHeight))
+	}
 	if err != nil {
 		id.logger.Printf("❌ Worker %s failed to search for CERTEN transactions: %v", workerID, err)
 		return err
@@ -474,6 +619,13 @@ func (id *IntentDiscovery) processBlock(job *BlockProcessJob, workerID string) e
 		id.logger.Printf("📊 Worker %s verified: Block %d processed across all BVN and DN partitions", workerID, job.BlockHeight)
 	}
 
+	type discoveredIntent struct {
+		intent   *CertenIntent
+		certenTx *accumulate.CertenTransaction
+		priority database.RequestPriority
+	}
+	var discovered []discoveredIntent
+
 	for _, certenTx := range certenTransactions {
 		// Filter to transactions in this specific block
 		if certenTx.BlockHeight != int64(job.BlockHeight) {  // Fixed: compare int64 to uint64
@@ -489,6 +641,20 @@ func (id *IntentDiscovery) processBlock(job *BlockProcessJob, workerID string) e
 			id.logger.Printf("⚠️ Failed to convert CERTEN transaction to intent: %v", err)
 			continue
 		}
+		discovered = append(discovered, discoveredIntent{
+			intent:   intent,
+			certenTx: certenTx,
+			priority: ResolvePriority(intent, id.config.PriorityRules),
+		})
+	}
+
+	// Route higher-priority intents to the batch system first
+	sort.SliceStable(discovered, func(i, j int) bool {
+		return discovered[i].priority.Rank() > discovered[j].priority.Rank()
+	})
+
+	for _, d := range discovered {
+		intent, certenTx := d.intent, d.certenTx
 
 		// E.4 remediation: Two-phase marking to handle processing failures
 		// Phase 1: Mark as in_progress - prevents concurrent processing
@@ -503,19 +669,11 @@ func (id *IntentDiscovery) processBlock(job *BlockProcessJob, workerID string) e
 		id.logger.Printf("   Transaction: %s", intent.TransactionHash)
 		id.logger.Printf("   Partition: %s", certenTx.Partition)
 		id.logger.Printf("   Block Height: %d", job.BlockHeight)
+		id.logger.Printf("   Priority: %s", d.priority)
 		id.logger.Printf("   Intent Data: %+v", certenTx.IntentData)
 
-		// Process the intent through consensus
-		if err := id.processIntent(intent, job.BlockHeight); err != nil {
-			id.logger.Printf("❌ Failed to process intent %s: %v", intent.IntentID, err)
-			// E.4 remediation: Phase 2 (failure) - Mark as failed, allowing future retry
-			id.markFailed(intent.IntentID)
-			id.logger.Printf("   Intent %s marked as 'failed' - can be retried on next discovery", intent.IntentID)
-		} else {
+		if id.executeIntent(intent, job.BlockHeight) {
 			foundIntents++
-			// E.4 remediation: Phase 2 (success) - Mark as completed
-			id.markCompleted(intent.IntentID)
-			id.logger.Printf("✅ Intent %s processed successfully and marked complete", intent.IntentID)
 		}
 	}
 
@@ -529,6 +687,32 @@ func (id *IntentDiscovery) processBlock(job *BlockProcessJob, workerID string) e
 	return nil
 }
 
+// executeIntent runs an in-progress intent through the finality check and processing,
+// and records the outcome. Returns true if the intent was processed successfully.
+func (id *IntentDiscovery) executeIntent(intent *CertenIntent, blockHeight uint64) bool {
+	// Transactions that are not yet final are deferred rather than failed
+	if !id.admitIntent(intent, blockHeight) {
+		return false
+	}
+
+	// Process the intent through consensus
+	if err := id.processIntent(intent, blockHeight); err != nil {
+		id.logger.Printf("❌ Failed to process intent %s: %v", intent.IntentID, err)
+		if id.deferIfNotFinal(intent, blockHeight, err) {
+			return false
+		}
+		// E.4 remediation: Phase 2 (failure) - Mark as failed, allowing future retry
+		id.markFailed(intent.IntentID)
+		id.logger.Printf("   Intent %s marked as 'failed' - can be retried on next discovery", intent.IntentID)
+		return false
+	}
+
+	// E.4 remediation: Phase 2 (success) - Mark as completed
+	id.markCompleted(intent.IntentID)
+	id.logger.Printf("✅ Intent %s processed successfully and marked complete", intent.IntentID)
+	return true
+}
+
 // convertCertenTransactionToIntent converts a CertenTransaction from v3 API to canonical CertenIntent format
 func (id *IntentDiscovery) convertCertenTransactionToIntent(certenTx *accumulate.CertenTransaction) (*CertenIntent, error) {
 	// Debug: Log the incoming CertenTransaction data
@@ -711,6 +895,8 @@ func (id *IntentDiscovery) convertIntentToTransactionData(intent *CertenIntent,
 		IntentID:     intent.IntentID, // From intent_data.intent_id
 		// Multi-Chain Support: Target chain for anchoring
 		TargetChain:  targetChain,
+		Priority:     ResolvePriority(intent, id.config.PriorityRules),
+		Origin:       database.TransactionOriginUser,
 	}
 
 	// Extract Transaction Center metadata from CrossChainData
@@ -830,7 +1016,185 @@ func (id *IntentDiscovery) processIntent(intent *CertenIntent, blockHeight uint6
 	}
 	id.logger.Printf("📋 Intent %s has proofClass: %s", intent.IntentID, proofClass)
 
-	// 2️⃣ Generate a REAL L1-L3 chained proof via lite client's ProofBuilder
+	// 2️⃣ Resolve L1-L3 and G0/G1/G2 proofs (generated locally or shared by the owning validator)
+	certenProof, govProof, err := id.resolveIntentProofs(intent, accountURL, proofClass)
+	if err != nil {
+		return err
+	}
+
+	// 3️⃣ PHASE 5: Route to batch system for PostgreSQL persistence and CertenAnchorProof assembly
+	if id.batchingEnabled {
+		if err := id.routeIntentToBatchSystem(intent, certenProof, govProof, proofClass, blockHeight); errors.Is(err, batch.ErrTransactionAlreadyBatched) {
+			// Re-delivered after a cursor rewind: already queued or anchored, nothing to redo
+			id.logger.Printf("ℹ️ Intent %s already in the batch system, skipping: %v", intent.IntentID, err)
+		} else if err != nil {
+			id.logger.Printf("⚠️ Batch system routing failed for intent %s: %v", intent.IntentID, err)
+			// Continue with BFT consensus even if batch routing fails
+		} else {
+			id.logger.Printf("✅ Intent %s routed to batch system for PostgreSQL persistence", intent.IntentID)
+
+			// Synthetic transactions are delivered after their origin; anchor them in the background
+			if id.config.SyntheticTxEnabled {
+				go id.processSyntheticTransactions(intent, proofClass)
+			}
+		}
+	} else {
+		id.logger.Printf("⚠️ Batch system not enabled - intent %s will not be persisted to PostgreSQL", intent.IntentID)
+	}
+
+	// 4️⃣ Execute via canonical BFT API – ValidatorBlock creation
+	if id.bftConsensus != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), id.config.BFTTimeout)
+		defer cancel()
+
+		err = id.bftConsensus.ExecuteCanonicalIntentWithBFTConsensus(
+			ctx,
+			(*consensus.CertenIntent)(intent), // alias, but cast for clarity
+			certenProof,
+			blockHeight,
+		)
+		if err != nil {
+			id.logger.Printf("❌ Canonical BFT consensus execution failed for intent %s: %v", intent.IntentID, err)
+			return err
+		}
+
+		id.logger.Printf("✅ Canonical BFT consensus execution completed for intent: %s", intent.IntentID)
+	} else {
+		id.logger.Printf("⚠️ No BFT consensus configured - skipping ValidatorBlock creation for %s", intent.IntentID)
+	}
+
+	id.mu.Lock()
+	id.intentCount++
+	id.mu.Unlock()
+
+	return nil
+}
+
+// resolveIntentProofs returns the proofs for an intent, honoring proof-work partitioning.
+// Without partitioning (or as the owner) proofs are generated locally; otherwise the
+// owner's shared proof is awaited and local generation is the fallback.
+func (id *IntentDiscovery) resolveIntentProofs(intent *CertenIntent, accountURL, proofClass string) (*proof.CertenProof, *proof.GovernanceProof, error) {
+	if id.workPartitioner == nil {
+		return id.generateIntentProofs(intent, accountURL, proofClass)
+	}
+
+	rank := id.workPartitioner.Rank(intent.IntentID)
+	owner := id.workPartitioner.Owner(intent.IntentID)
+
+	if rank > 0 && id.sharedProofSource != nil {
+		// Backup (rank 1) waits one share window; everyone else waits two
+		waitWindows := rank
+		if waitWindows > 2 {
+			waitWindows = 2
+		}
+		id.logger.Printf("🤝 [PROOF-PARTITION] Intent %s owned by %s (our rank %d) - awaiting shared proof",
+			intent.IntentID, owner, rank)
+
+		shareWait := id.config.ProofShareWait
+		if shareWait <= 0 {
+			shareWait = 20 * time.Second
+		}
+		req := SharedProofRequest{IntentID: intent.IntentID, TransactionHash: intent.TransactionHash, Owner: owner}
+		shared := id.awaitSharedProof(req, time.Duration(waitWindows)*shareWait)
+		if shared != nil {
+			if err := id.checkSharedChainedProof(shared); err != nil {
+				id.logger.Printf("🚫 [PROOF-PARTITION] Shared proof for intent %s from %s failed verification: %v",
+					intent.IntentID, owner, err)
+				shared = nil
+			}
+		}
+		if shared != nil {
+			id.mu.Lock()
+			id.sharedProofsUsed++
+			id.mu.Unlock()
+			id.logger.Printf("✅ [PROOF-PARTITION] Using shared proof for intent %s generated by %s",
+				intent.IntentID, shared.GeneratedBy)
+			return shared.CertenProof, shared.GovernanceProof, nil
+		}
+
+		id.mu.Lock()
+		id.fallbackGenerations++
+		id.mu.Unlock()
+		id.logger.Printf("⚠️ [PROOF-PARTITION] No shared proof for intent %s from owner %s - generating locally (fallback)",
+			intent.IntentID, owner)
+	} else if rank > 0 {
+		id.logger.Printf("⚠️ [PROOF-PARTITION] No shared proof source configured - generating intent %s locally", intent.IntentID)
+	} else {
+		id.logger.Printf("🎯 [PROOF-PARTITION] Validator %s owns proof generation for intent %s", id.validatorID, intent.IntentID)
+	}
+
+	certenProof, govProof, err := id.generateIntentProofs(intent, accountURL, proofClass)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Publish so peers waiting on this intent can skip regeneration
+	if id.sharedProofCache != nil && id.sharedProofSigner != nil && certenProof != nil {
+		shared := &SharedProof{
+			IntentID:        intent.IntentID,
+			TransactionHash: intent.TransactionHash,
+			GeneratedBy:     id.validatorID,
+			GeneratedAt:     time.Now(),
+			CertenProof:     certenProof,
+			GovernanceProof: govProof,
+		}
+		if err := shared.Sign(id.sharedProofSigner); err != nil {
+			id.logger.Printf("⚠️ [PROOF-PARTITION] Not sharing proof for intent %s: %v", intent.IntentID, err)
+		} else {
+			id.sharedProofCache.Put(shared)
+		}
+	}
+
+	return certenProof, govProof, nil
+}
+
+// checkSharedChainedProof structurally verifies the lite client proof inside a shared
+// proof, as the owner did when generating it
+func (id *IntentDiscovery) checkSharedChainedProof(shared *SharedProof) error {
+	if shared.CertenProof.LiteClientProof == nil || shared.CertenProof.LiteClientProof.CompleteProof == nil {
+		return nil
+	}
+	if id.proofGenerator == nil {
+		return fmt.Errorf("no lite client to verify the chained proof")
+	}
+	return id.proofGenerator.VerifyProof(shared.CertenProof.LiteClientProof.CompleteProof)
+}
+
+// awaitSharedProof polls the shared proof source until a verified proof appears, the
+// wait elapses, or discovery is stopped
+func (id *IntentDiscovery) awaitSharedProof(req SharedProofRequest, wait time.Duration) *SharedProof {
+	pollInterval := id.config.ProofSharePollInterval
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), pollInterval)
+		shared, err := id.sharedProofSource.FetchSharedProof(ctx, req)
+		cancel()
+		if err != nil {
+			id.logger.Printf("⚠️ [PROOF-PARTITION] Shared proof lookup failed for %s: %v", req.IntentID, err)
+		} else if shared != nil && shared.CertenProof != nil {
+			return shared
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return nil
+		}
+
+		select {
+		case <-id.stopCh:
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// generateIntentProofs generates the L1-L3 chained proof and the G0/G1/G2 governance proof
+// for an intent on this validator
+func (id *IntentDiscovery) generateIntentProofs(intent *CertenIntent, accountURL, proofClass string) (*proof.CertenProof, *proof.GovernanceProof, error) {
+	// Generate a REAL L1-L3 chained proof via lite client's ProofBuilder
 	var certenProof *proof.CertenProof
 
 	if id.proofGenerator != nil {
@@ -881,7 +1245,7 @@ func (id *IntentDiscovery) processIntent(intent *CertenIntent, blockHeight uint6
 				// For on_demand intents, proof failure is a hard error
 				if proofClass == "on_demand" {
 					id.logger.Printf("❌ on_demand intent %s REQUIRES proof - cannot proceed without CertenProof", intent.IntentID)
-					return fmt.Errorf("on_demand intent %s requires proof but proof generation failed: %w", intent.IntentID, err)
+					return nil, nil, fmt.Errorf("on_demand intent %s requires proof but proof generation failed: %w", intent.IntentID, err)
 				} else {
 					id.logger.Printf("⚠️ Proceeding without proof for %s intent %s (proof failure allowed for cadence intents)", proofClass, intent.IntentID)
 				}
@@ -898,7 +1262,7 @@ func (id *IntentDiscovery) processIntent(intent *CertenIntent, blockHeight uint6
 				certenProof = adapter.ToCertenProof()
 				if certenProof == nil {
 					if proofClass == "on_demand" {
-						return fmt.Errorf("on_demand intent %s: adapter returned nil CertenProof", intent.IntentID)
+						return nil, nil, fmt.Errorf("on_demand intent %s: adapter returned nil CertenProof", intent.IntentID)
 					}
 					id.logger.Printf("⚠️ Adapter returned nil CertenProof for %s intent %s", proofClass, intent.IntentID)
 				} else {
@@ -910,13 +1274,13 @@ func (id *IntentDiscovery) processIntent(intent *CertenIntent, blockHeight uint6
 		// For on_demand intents, missing proof generator is a hard error
 		if proofClass == "on_demand" {
 			id.logger.Printf("❌ on_demand intent %s REQUIRES ProofGenerator but none configured", intent.IntentID)
-			return fmt.Errorf("on_demand intent %s requires ProofGenerator but none configured", intent.IntentID)
+			return nil, nil, fmt.Errorf("on_demand intent %s requires ProofGenerator but none configured", intent.IntentID)
 		} else {
 			id.logger.Printf("⚠️ No proofGenerator configured for %s intent %s", proofClass, intent.IntentID)
 		}
 	}
 
-	// 2.5️⃣ Generate G0/G1/G2 governance proof BEFORE routing to batch system
+	// Generate G0/G1/G2 governance proof BEFORE routing to batch system
 	// This ensures the generated proof (not input config) is persisted to PostgreSQL
 	var govProof *proof.GovernanceProof
 	if id.governanceProofGen != nil && certenProof != nil {
@@ -977,44 +1341,7 @@ func (id *IntentDiscovery) processIntent(intent *CertenIntent, blockHeight uint6
 		id.logger.Printf("⚠️ [GOV-PROOF] Governance proof generator not configured - using fallback")
 	}
 
-	// 3️⃣ PHASE 5: Route to batch system for PostgreSQL persistence and CertenAnchorProof assembly
-	if id.batchingEnabled {
-		if err := id.routeIntentToBatchSystem(intent, certenProof, govProof, proofClass, blockHeight); err != nil {
-			id.logger.Printf("⚠️ Batch system routing failed for intent %s: %v", intent.IntentID, err)
-			// Continue with BFT consensus even if batch routing fails
-		} else {
-			id.logger.Printf("✅ Intent %s routed to batch system for PostgreSQL persistence", intent.IntentID)
-		}
-	} else {
-		id.logger.Printf("⚠️ Batch system not enabled - intent %s will not be persisted to PostgreSQL", intent.IntentID)
-	}
-
-	// 4️⃣ Execute via canonical BFT API – ValidatorBlock creation
-	if id.bftConsensus != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), id.config.BFTTimeout)
-		defer cancel()
-
-		err = id.bftConsensus.ExecuteCanonicalIntentWithBFTConsensus(
-			ctx,
-			(*consensus.CertenIntent)(intent), // alias, but cast for clarity
-			certenProof,
-			blockHeight,
-		)
-		if err != nil {
-			id.logger.Printf("❌ Canonical BFT consensus execution failed for intent %s: %v", intent.IntentID, err)
-			return err
-		}
-
-		id.logger.Printf("✅ Canonical BFT consensus execution completed for intent: %s", intent.IntentID)
-	} else {
-		id.logger.Printf("⚠️ No BFT consensus configured - skipping ValidatorBlock creation for %s", intent.IntentID)
-	}
-
-	id.mu.Lock()
-	id.intentCount++
-	id.mu.Unlock()
-
-	return nil
+	return certenProof, govProof, nil
 }
 
 // routeIntentToBatchSystem routes an intent to the appropriate batch handler based on proofClass
@@ -1131,13 +1458,14 @@ func (id *IntentDiscovery) markInProgress(intentID string) bool {
 	status, exists := id.intentStatus[intentID]
 	if exists {
 		// Only allow processing if not already in_progress or completed
-		// Failed intents CAN be retried
-		if status == IntentStatusInProgress || status == IntentStatusCompleted {
-			return false // Already being processed or completed
+		// Failed intents CAN be retried; deferred intents are retried by the deferral loop
+		if status == IntentStatusInProgress || status == IntentStatusCompleted ||
+			status == IntentStatusDeferred || status == IntentStatusDeadLettered {
+			return false // Already being processed, completed, deferred or dead-lettered
 		}
 	}
 
-	id.intentStatus[intentID] = IntentStatusInProgress
+	id.setIntentStatusLocked(intentID, IntentStatusInProgress)
 	id.intentCount++
 	return true // Newly marked as in_progress
 }
@@ -1147,7 +1475,7 @@ func (id *IntentDiscovery) markInProgress(intentID string) bool {
 func (id *IntentDiscovery) markCompleted(intentID string) {
 	id.mu.Lock()
 	defer id.mu.Unlock()
-	id.intentStatus[intentID] = IntentStatusCompleted
+	id.setIntentStatusLocked(intentID, IntentStatusCompleted)
 }
 
 // markFailed marks an intent as failed (can be retried later)
@@ -1155,7 +1483,7 @@ func (id *IntentDiscovery) markCompleted(intentID string) {
 func (id *IntentDiscovery) markFailed(intentID string) {
 	id.mu.Lock()
 	defer id.mu.Unlock()
-	id.intentStatus[intentID] = IntentStatusFailed
+	id.setIntentStatusLocked(intentID, IntentStatusFailed)
 }
 
 // getIntentStatus returns the current status of an intent
@@ -1190,7 +1518,7 @@ func (id *IntentDiscovery) GetMetrics() map[string]interface{} {
 	defer id.mu.RUnlock()
 
 	// E.4 remediation: Count intents by status
-	var inProgress, completed, failed int
+	var inProgress, completed, failed, deferred, deadLettered int
 	for _, status := range id.intentStatus {
 		switch status {
 		case IntentStatusInProgress:
@@ -1199,18 +1527,32 @@ func (id *IntentDiscovery) GetMetrics() map[string]interface{} {
 			completed++
 		case IntentStatusFailed:
 			failed++
+		case IntentStatusDeferred:
+			deferred++
+		case IntentStatusDeadLettered:
+			deadLettered++
 		}
 	}
 
 	return map[string]interface{}{
 		"is_monitoring":        id.isMonitoring,
 		"last_processed_block": id.lastProcessedBlock,
+		"partition_cursors":    id.partitionCursorsLocked(),
 		"intents_discovered":   id.intentCount,
 		"intents_total":        len(id.intentStatus),
 		"intents_in_progress":  inProgress,
 		"intents_completed":    completed,
 		"intents_failed":       failed,
+		"intents_deferred":     deferred,
+		"intents_dead_lettered": deadLettered,
 		"accumulate_url":       id.accumulateURL,
+		"proof_partitioning":   id.workPartitioner != nil,
+		"shared_proofs_used":   id.sharedProofsUsed,
+		"fallback_generations": id.fallbackGenerations,
+		"dedup_set_size":       len(id.intentStatus),
+		"dedup_oldest_age_seconds": id.dedupOldestAgeLocked().Seconds(),
+		"synthetic_tx_enabled":     id.config.SyntheticTxEnabled,
+		"synthetic_tx_stats":       id.syntheticStats,
 	}
 }
 
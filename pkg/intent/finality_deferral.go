@@ -0,0 +1,282 @@
+// Copyright 2025 Certen Protocol
+//
+// Finality Deferral - Handling of intents whose Accumulate transaction is not yet final
+//
+// Discovery can outpace finality: an intent may be seen before its transaction is
+// delivered, and G0 (inclusion and finality) cannot complete until it is. Instead of
+// failing such intents permanently:
+//   - "not yet final" (pending/remote) intents are DEFERRED and retried after a delay
+//   - after MaxFinalityDeferrals retries the intent is DEAD-LETTERED
+//   - "will never finalize" (rejected/expired/invalid) intents are dead-lettered at once
+//   - an unknown status (lookup failed, status missing) does not block processing
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/accumulate"
+)
+
+// maxDeadLetters bounds the in-memory dead-letter list
+const maxDeadLetters = 1000
+
+// TxFinality classifies an Accumulate transaction's progress toward finality
+type TxFinality int
+
+const (
+	TxFinalityUnknown    TxFinality = iota // Status could not be determined - process as usual
+	TxFinalityFinal                        // Delivered or ok - proofs can be generated
+	TxFinalityPending                      // Not yet final - defer and retry
+	TxFinalityNeverFinal                   // Rejected, expired or invalid - dead-letter
+)
+
+func (f TxFinality) String() string {
+	switch f {
+	case TxFinalityFinal:
+		return "final"
+	case TxFinalityPending:
+		return "pending"
+	case TxFinalityNeverFinal:
+		return "never_final"
+	default:
+		return "unknown"
+	}
+}
+
+// ClassifyTxStatus maps an Accumulate v3 message status to a finality class
+func ClassifyTxStatus(status string) TxFinality {
+	switch status {
+	case "delivered", "ok": // "ok" is reported for messages that executed successfully
+		return TxFinalityFinal
+	case "pending", "remote", "notReady":
+		return TxFinalityPending
+	case "rejected", "expired", "badRequest", "unauthenticated", "unauthorized",
+		"insufficientCredits", "insufficientBalance", "notAllowed", "conflict",
+		"badSignerVersion", "badTimestamp", "badUrlLength", "wrongType":
+		return TxFinalityNeverFinal
+	default:
+		return TxFinalityUnknown
+	}
+}
+
+// TransactionFinalityChecker reports whether an Accumulate transaction is final.
+// It returns the finality class and the raw status it was derived from.
+type TransactionFinalityChecker interface {
+	CheckTransactionFinality(ctx context.Context, txHash string) (TxFinality, string, error)
+}
+
+// ClientFinalityChecker checks finality through the Accumulate client
+type ClientFinalityChecker struct {
+	client accumulate.Client
+}
+
+// NewClientFinalityChecker creates a finality checker backed by the Accumulate client
+func NewClientFinalityChecker(client accumulate.Client) *ClientFinalityChecker {
+	return &ClientFinalityChecker{client: client}
+}
+
+// CheckTransactionFinality implements TransactionFinalityChecker
+func (c *ClientFinalityChecker) CheckTransactionFinality(ctx context.Context, txHash string) (TxFinality, string, error) {
+	tx, err := c.client.GetTransaction(ctx, txHash)
+	if err != nil {
+		return TxFinalityUnknown, "", err
+	}
+	return ClassifyTxStatus(tx.Status), tx.Status, nil
+}
+
+// DeferredIntent is an intent waiting for its transaction to become final
+type DeferredIntent struct {
+	Intent          *CertenIntent
+	BlockHeight     uint64
+	Deferrals       int
+	FirstDeferredAt time.Time
+	NextAttempt     time.Time
+	Reason          string
+}
+
+// DeadLetteredIntent is an intent that will not be processed
+type DeadLetteredIntent struct {
+	IntentID        string    `json:"intent_id"`
+	TransactionHash string    `json:"transaction_hash"`
+	BlockHeight     uint64    `json:"block_height"`
+	Deferrals       int       `json:"deferrals"`
+	Reason          string    `json:"reason"`
+	DeadLetteredAt  time.Time `json:"dead_lettered_at"`
+}
+
+// SetFinalityChecker configures the finality check run before each intent is processed.
+// A nil checker disables deferral and intents are processed as soon as they are discovered.
+func (id *IntentDiscovery) SetFinalityChecker(checker TransactionFinalityChecker) {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+	id.finalityChecker = checker
+}
+
+// checkIntentFinality looks up the finality of an intent's transaction
+func (id *IntentDiscovery) checkIntentFinality(intent *CertenIntent) (TxFinality, string) {
+	id.mu.RLock()
+	checker := id.finalityChecker
+	id.mu.RUnlock()
+
+	if checker == nil || intent.TransactionHash == "" {
+		return TxFinalityUnknown, ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	finality, status, err := checker.CheckTransactionFinality(ctx, intent.TransactionHash)
+	if err != nil {
+		id.logger.Printf("⚠️ [FINALITY] Could not determine finality of %s for intent %s: %v",
+			intent.TransactionHash, intent.IntentID, err)
+		return TxFinalityUnknown, ""
+	}
+	return finality, status
+}
+
+// admitIntent decides whether an intent can be processed now.
+// Returns false if the intent was deferred or dead-lettered instead.
+func (id *IntentDiscovery) admitIntent(intent *CertenIntent, blockHeight uint64) bool {
+	finality, status := id.checkIntentFinality(intent)
+	return !id.handleNonFinal(intent, blockHeight, finality, status)
+}
+
+// deferIfNotFinal re-checks finality after a processing failure, so transient failures
+// caused by a not-yet-final transaction are deferred rather than failed.
+// Returns true if the intent was deferred or dead-lettered.
+func (id *IntentDiscovery) deferIfNotFinal(intent *CertenIntent, blockHeight uint64, procErr error) bool {
+	finality, status := id.checkIntentFinality(intent)
+	if finality == TxFinalityPending {
+		id.logger.Printf("⏳ [FINALITY] Intent %s failed while its transaction is %s: %v", intent.IntentID, status, procErr)
+	}
+	return id.handleNonFinal(intent, blockHeight, finality, status)
+}
+
+// handleNonFinal defers pending intents and dead-letters intents that will never finalize.
+// Returns true if the intent was handled here and must not be processed now.
+func (id *IntentDiscovery) handleNonFinal(intent *CertenIntent, blockHeight uint64, finality TxFinality, status string) bool {
+	switch finality {
+	case TxFinalityPending:
+		id.deferIntent(intent, blockHeight, fmt.Sprintf("transaction %s not yet final (status: %s)", intent.TransactionHash, status))
+		return true
+	case TxFinalityNeverFinal:
+		id.deadLetterIntent(intent, blockHeight, 0, fmt.Sprintf("transaction %s will never finalize (status: %s)", intent.TransactionHash, status))
+		return true
+	default:
+		return false
+	}
+}
+
+// deferIntent schedules an intent for retry, or dead-letters it once the deferral limit is reached
+func (id *IntentDiscovery) deferIntent(intent *CertenIntent, blockHeight uint64, reason string) {
+	retryDelay := id.config.FinalityRetryDelay
+	if retryDelay <= 0 {
+		retryDelay = 30 * time.Second
+	}
+	maxDeferrals := id.config.MaxFinalityDeferrals
+	if maxDeferrals <= 0 {
+		maxDeferrals = 10
+	}
+
+	id.mu.Lock()
+	entry, exists := id.deferredIntents[intent.IntentID]
+	if !exists {
+		entry = &DeferredIntent{
+			Intent:          intent,
+			BlockHeight:     blockHeight,
+			FirstDeferredAt: time.Now(),
+		}
+	}
+	if entry.Deferrals >= maxDeferrals {
+		delete(id.deferredIntents, intent.IntentID)
+		id.mu.Unlock()
+		id.deadLetterIntent(intent, blockHeight, entry.Deferrals,
+			fmt.Sprintf("%s after %d deferrals over %v", reason, entry.Deferrals, time.Since(entry.FirstDeferredAt).Round(time.Second)))
+		return
+	}
+	entry.Deferrals++
+	entry.NextAttempt = time.Now().Add(retryDelay)
+	entry.Reason = reason
+	id.deferredIntents[intent.IntentID] = entry
+	id.setIntentStatusLocked(intent.IntentID, IntentStatusDeferred)
+	id.mu.Unlock()
+
+	id.logger.Printf("⏳ [FINALITY] Intent %s deferred (%d/%d), retry in %v: %s",
+		intent.IntentID, entry.Deferrals, maxDeferrals, retryDelay, reason)
+}
+
+// deadLetterIntent records an intent that will not be processed
+func (id *IntentDiscovery) deadLetterIntent(intent *CertenIntent, blockHeight uint64, deferrals int, reason string) {
+	id.mu.Lock()
+	delete(id.deferredIntents, intent.IntentID)
+	id.setIntentStatusLocked(intent.IntentID, IntentStatusDeadLettered)
+	if len(id.deadLetters) >= maxDeadLetters {
+		id.deadLetters = id.deadLetters[1:]
+	}
+	id.deadLetters = append(id.deadLetters, &DeadLetteredIntent{
+		IntentID:        intent.IntentID,
+		TransactionHash: intent.TransactionHash,
+		BlockHeight:     blockHeight,
+		Deferrals:       deferrals,
+		Reason:          reason,
+		DeadLetteredAt:  time.Now(),
+	})
+	id.mu.Unlock()
+
+	id.logger.Printf("☠️ [FINALITY] Intent %s dead-lettered: %s", intent.IntentID, reason)
+}
+
+// dueDeferredIntents removes and returns deferred intents whose retry time has passed
+func (id *IntentDiscovery) dueDeferredIntents(now time.Time) []*DeferredIntent {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+
+	var due []*DeferredIntent
+	for intentID, entry := range id.deferredIntents {
+		if now.Before(entry.NextAttempt) || id.intentStatus[intentID] == IntentStatusInProgress {
+			continue
+		}
+		due = append(due, entry)
+		// Keep the entry so the deferral count carries over if it is deferred again
+		id.setIntentStatusLocked(intentID, IntentStatusInProgress)
+	}
+	return due
+}
+
+// deferralLoop retries deferred intents once their delay has elapsed
+func (id *IntentDiscovery) deferralLoop(stopCh chan struct{}) {
+	ticker := time.NewTicker(id.config.BlockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			for _, entry := range id.dueDeferredIntents(time.Now()) {
+				id.logger.Printf("🔁 [FINALITY] Retrying deferred intent %s (deferral %d)", entry.Intent.IntentID, entry.Deferrals)
+				id.executeIntent(entry.Intent, entry.BlockHeight)
+
+				// Deferred again keeps its entry; completed or failed intents are done retrying
+				id.mu.Lock()
+				if id.intentStatus[entry.Intent.IntentID] != IntentStatusDeferred {
+					delete(id.deferredIntents, entry.Intent.IntentID)
+				}
+				id.mu.Unlock()
+			}
+		}
+	}
+}
+
+// GetDeadLetteredIntents returns the intents that were dead-lettered, oldest first
+func (id *IntentDiscovery) GetDeadLetteredIntents() []*DeadLetteredIntent {
+	id.mu.RLock()
+	defer id.mu.RUnlock()
+
+	out := make([]*DeadLetteredIntent, len(id.deadLetters))
+	copy(out, id.deadLetters)
+	return out
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Finality Deferral
+// Tests status classification, deferral with a bounded retry count, and dead-lettering
+
+package intent
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// stubFinalityChecker returns a fixed status for every transaction
+type stubFinalityChecker struct {
+	status string
+}
+
+func (s *stubFinalityChecker) CheckTransactionFinality(ctx context.Context, txHash string) (TxFinality, string, error) {
+	return ClassifyTxStatus(s.status), s.status, nil
+}
+
+func newTestDiscovery(status string, maxDeferrals int) *IntentDiscovery {
+	cfg := DefaultIntentDiscoveryConfig()
+	cfg.FinalityRetryDelay = time.Minute
+	cfg.MaxFinalityDeferrals = maxDeferrals
+	id := NewIntentDiscovery(nil, "", cfg, nil, nil, "validator-1")
+	id.SetFinalityChecker(&stubFinalityChecker{status: status})
+	return id
+}
+
+func TestClassifyTxStatus(t *testing.T) {
+	tests := map[string]TxFinality{
+		"delivered":    TxFinalityFinal,
+		"ok":           TxFinalityFinal,
+		"pending":      TxFinalityPending,
+		"remote":       TxFinalityPending,
+		"rejected":     TxFinalityNeverFinal,
+		"expired":      TxFinalityNeverFinal,
+		"unauthorized": TxFinalityNeverFinal,
+		"":             TxFinalityUnknown,
+		"somethingNew": TxFinalityUnknown,
+	}
+	for status, expected := range tests {
+		if got := ClassifyTxStatus(status); got != expected {
+			t.Errorf("ClassifyTxStatus(%q) = %s, expected %s", status, got, expected)
+		}
+	}
+}
+
+func TestAdmitIntent_FinalAndUnknownProceed(t *testing.T) {
+	for _, status := range []string{"delivered", "ok", ""} {
+		id := newTestDiscovery(status, 3)
+		intent := &CertenIntent{IntentID: "intent-1", TransactionHash: "abc"}
+		if !id.admitIntent(intent, 100) {
+			t.Errorf("status %q: expected intent to be admitted", status)
+		}
+	}
+}
+
+func TestAdmitIntent_PendingDefersThenDeadLetters(t *testing.T) {
+	id := newTestDiscovery("pending", 3)
+	intent := &CertenIntent{IntentID: "intent-1", TransactionHash: "abc"}
+
+	for i := 1; i <= 3; i++ {
+		if id.admitIntent(intent, 100) {
+			t.Fatalf("attempt %d: pending intent should not be admitted", i)
+		}
+		if status := id.getIntentStatus(intent.IntentID); status != IntentStatusDeferred {
+			t.Fatalf("attempt %d: expected status deferred, got %s", i, status)
+		}
+		if got := id.deferredIntents[intent.IntentID].Deferrals; got != i {
+			t.Fatalf("attempt %d: expected %d deferrals, got %d", i, i, got)
+		}
+	}
+
+	// Deferral limit reached - next attempt dead-letters
+	if id.admitIntent(intent, 100) {
+		t.Fatal("pending intent should not be admitted after deferrals are exhausted")
+	}
+	if status := id.getIntentStatus(intent.IntentID); status != IntentStatusDeadLettered {
+		t.Fatalf("expected status dead_lettered, got %s", status)
+	}
+	deadLetters := id.GetDeadLetteredIntents()
+	if len(deadLetters) != 1 || deadLetters[0].Deferrals != 3 {
+		t.Fatalf("expected one dead letter with 3 deferrals, got %+v", deadLetters)
+	}
+	if _, ok := id.deferredIntents[intent.IntentID]; ok {
+		t.Error("dead-lettered intent should be removed from the deferral queue")
+	}
+
+	// Dead-lettered intents are not picked up again by discovery
+	if id.markInProgress(intent.IntentID) {
+		t.Error("dead-lettered intent should not be re-admitted by discovery")
+	}
+}
+
+func TestAdmitIntent_NeverFinalDeadLettersImmediately(t *testing.T) {
+	id := newTestDiscovery("rejected", 3)
+	intent := &CertenIntent{IntentID: "intent-1", TransactionHash: "abc"}
+
+	if id.admitIntent(intent, 100) {
+		t.Fatal("rejected intent should not be admitted")
+	}
+	if status := id.getIntentStatus(intent.IntentID); status != IntentStatusDeadLettered {
+		t.Fatalf("expected status dead_lettered, got %s", status)
+	}
+	if deadLetters := id.GetDeadLetteredIntents(); len(deadLetters) != 1 || deadLetters[0].Deferrals != 0 {
+		t.Fatalf("expected one dead letter with no deferrals, got %+v", deadLetters)
+	}
+}
+
+func TestDueDeferredIntents(t *testing.T) {
+	id := newTestDiscovery("pending", 3)
+	intent := &CertenIntent{IntentID: "intent-1", TransactionHash: "abc"}
+	id.admitIntent(intent, 100)
+
+	if due := id.dueDeferredIntents(time.Now()); len(due) != 0 {
+		t.Fatalf("expected no due intents before the retry delay, got %d", len(due))
+	}
+
+	due := id.dueDeferredIntents(time.Now().Add(2 * time.Minute))
+	if len(due) != 1 {
+		t.Fatalf("expected 1 due intent after the retry delay, got %d", len(due))
+	}
+	if status := id.getIntentStatus(intent.IntentID); status != IntentStatusInProgress {
+		t.Errorf("expected due intent to be in_progress, got %s", status)
+	}
+
+	// An in-progress retry is not handed out twice
+	if due := id.dueDeferredIntents(time.Now().Add(2 * time.Minute)); len(due) != 0 {
+		t.Errorf("expected in-progress intent to be skipped, got %d", len(due))
+	}
+}
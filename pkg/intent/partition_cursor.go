@@ -0,0 +1,216 @@
+// Copyright 2025 Certen Protocol
+//
+// Partition Cursors - Per-partition resume points for intent discovery
+//
+// Each Accumulate partition (the DN and every BVN) produces minor blocks at its own
+// pace, so their heights are unrelated. A single last-processed height applied to
+// every partition makes a restart reprocess the partitions that are behind it and
+// skip the ones that are ahead. When the client can list partitions and search them
+// one at a time, discovery keeps a cursor per partition instead:
+//   - each partition is polled against its own head and resumes from its own cursor
+//   - a partition seen for the first time is placed by the cold-start policy
+//   - a cursor only advances past blocks that were queued, so a full queue delays
+//     blocks to the next tick instead of dropping them
+//
+// Cursors are persisted through the ledger store. A store that still holds the single
+// legacy height migrates it to a cursor for every partition on first load.
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/certen/independant-validator/pkg/accumulate"
+)
+
+// PartitionBlockSource lists partitions and searches them block by block
+// Implemented by accumulate.LiteClientAdapter
+type PartitionBlockSource interface {
+	ListPartitions(ctx context.Context) ([]string, error)
+	GetPartitionHeight(ctx context.Context, partition string) (uint64, error)
+	SearchPartitionCertenTransactions(ctx context.Context, partition string, blockHeight int64) ([]*accumulate.CertenTransaction, error)
+}
+
+// IntentCursorStore persists per-partition cursors across restarts
+// Implemented by ledger.LedgerStore
+type IntentCursorStore interface {
+	SaveIntentPartitionLastBlocks(heights map[string]uint64) error
+	LoadIntentPartitionLastBlocks(partitions []string) (map[string]uint64, error)
+}
+
+// partitionSource returns the client when it can be searched partition by partition
+func (id *IntentDiscovery) partitionSource() PartitionBlockSource {
+	source, _ := id.client.(PartitionBlockSource)
+	return source
+}
+
+// cursorStore returns the ledger store when it can persist partition cursors
+func (id *IntentDiscovery) cursorStore() IntentCursorStore {
+	store, _ := id.ledgerStore.(IntentCursorStore)
+	return store
+}
+
+// isDirectoryPartition reports whether a partition ledger URL is the directory network
+func isDirectoryPartition(partition string) bool {
+	return strings.HasPrefix(strings.ToLower(partition), "acc://dn.")
+}
+
+// partitionColdStartHeight applies the cold-start policy to one partition. MinStartHeight
+// is a directory network height, so it only bounds the DN.
+func (id *IntentDiscovery) partitionColdStartHeight(partition string, head uint64) uint64 {
+	policy := id.config.ColdStartPolicy
+	if policy == "" {
+		policy = ColdStartLookback
+	}
+	var minStart uint64
+	if isDirectoryPartition(partition) {
+		minStart = id.config.MinStartHeight
+	}
+	return ColdStartHeight(policy, id.config.ColdStartLookback, minStart, head)
+}
+
+// initializePartitionCursors restores each partition's cursor, migrating the legacy single
+// height when necessary, and cold-starts partitions without one
+func (id *IntentDiscovery) initializePartitionCursors(ctx context.Context, source PartitionBlockSource) error {
+	partitions, err := source.ListPartitions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	persisted := map[string]uint64{}
+	if store := id.cursorStore(); store != nil {
+		if persisted, err = store.LoadIntentPartitionLastBlocks(partitions); err != nil {
+			id.logger.Printf("⚠️ Failed to load persisted partition cursors: %v", err)
+			persisted = map[string]uint64{}
+		}
+	}
+
+	cursors := make(map[string]uint64, len(partitions))
+	for _, partition := range partitions {
+		if height := persisted[partition]; height > 0 {
+			cursors[partition] = height
+			id.logger.Printf("📊 Partition %s: resuming after block %d", partition, height)
+			continue
+		}
+		var head uint64
+		if id.config.ColdStartPolicy.NeedsHead() {
+			if head, err = source.GetPartitionHeight(ctx, partition); err != nil {
+				// Retried by the caller; a guessed height would rescan or skip history
+				return fmt.Errorf("cold start of %s needs its latest block: %w", partition, err)
+			}
+		}
+		cursors[partition] = id.partitionColdStartHeight(partition, head)
+		id.logger.Printf("📊 Partition %s: cold start at block %d (head %d)", partition, cursors[partition], head)
+	}
+
+	id.mu.Lock()
+	id.partitionCursors = cursors
+	id.mu.Unlock()
+	id.persistPartitionCursors()
+	return nil
+}
+
+// checkPartitionBlocks queues each partition's new blocks and advances its cursor past
+// the blocks that were queued
+func (id *IntentDiscovery) checkPartitionBlocks(ctx context.Context, source PartitionBlockSource) error {
+	partitions, err := source.ListPartitions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	var failed int
+	for _, partition := range partitions {
+		head, err := source.GetPartitionHeight(ctx, partition)
+		if err != nil {
+			id.logger.Printf("⚠️ Failed to get latest block of %s: %v", partition, err)
+			failed++
+			continue
+		}
+
+		id.mu.Lock()
+		if id.partitionCursors == nil {
+			id.partitionCursors = make(map[string]uint64)
+		}
+		cursor, known := id.partitionCursors[partition]
+		if !known {
+			cursor = id.partitionColdStartHeight(partition, head)
+			id.partitionCursors[partition] = cursor
+			id.logger.Printf("📊 New partition %s: cold start at block %d (head %d)", partition, cursor, head)
+		}
+		id.mu.Unlock()
+
+		from := cursor + 1
+		if head < cursor {
+			// Partition reset (network switch) - resume from its current head
+			id.logger.Printf("🔄 Partition %s head %d < last processed %d, resetting to current head", partition, head, cursor)
+			from = head
+		}
+
+		queued := cursor
+		for height := from; height <= head; height++ {
+			select {
+			case id.blockProcessCh <- &BlockProcessJob{
+				PartitionURL:    partition,
+				BlockHeight:     height,
+				BlockData:       &accumulate.Block{Height: height},
+				SinglePartition: true,
+			}:
+				queued = height
+			case <-id.stopCh:
+				return nil
+			default:
+				id.logger.Printf("⚠️ Block processing queue full, %s blocks %d-%d wait for the next tick", partition, height, head)
+			}
+			if queued != height {
+				break
+			}
+		}
+		if head < cursor && queued == cursor {
+			queued = head
+		}
+
+		if queued != cursor {
+			id.mu.Lock()
+			id.partitionCursors[partition] = queued
+			id.mu.Unlock()
+			if queued > cursor {
+				id.logger.Printf("🔍 Queued %s blocks %d to %d", partition, from, queued)
+			}
+		}
+	}
+
+	if failed == len(partitions) {
+		return fmt.Errorf("failed to get the latest block of every partition")
+	}
+	return nil
+}
+
+// persistPartitionCursors saves every partition cursor
+func (id *IntentDiscovery) persistPartitionCursors() {
+	store := id.cursorStore()
+	if store == nil {
+		return
+	}
+	if err := store.SaveIntentPartitionLastBlocks(id.PartitionCursors()); err != nil {
+		id.logger.Printf("⚠️ Failed to persist partition cursors: %v", err)
+	}
+}
+
+// PartitionCursors returns the last processed block height of each partition
+// (empty when discovery tracks a single height)
+func (id *IntentDiscovery) PartitionCursors() map[string]uint64 {
+	id.mu.RLock()
+	defer id.mu.RUnlock()
+	return id.partitionCursorsLocked()
+}
+
+// partitionCursorsLocked copies the partition cursors; the caller holds id.mu
+func (id *IntentDiscovery) partitionCursorsLocked() map[string]uint64 {
+	cursors := make(map[string]uint64, len(id.partitionCursors))
+	for partition, height := range id.partitionCursors {
+		cursors[partition] = height
+	}
+	return cursors
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Partition Cursors
+// Tests independent per-partition resume, cold start of new partitions and queue backpressure
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/accumulate"
+)
+
+const (
+	testDN   = "acc://dn.acme/ledger"
+	testBVN0 = "acc://BVN0.acme/ledger"
+	testBVN1 = "acc://BVN1.acme/ledger"
+)
+
+// stubPartitionSource reports fixed partition heads
+type stubPartitionSource struct {
+	heads map[string]uint64
+	order []string
+}
+
+func (s *stubPartitionSource) ListPartitions(ctx context.Context) ([]string, error) {
+	return s.order, nil
+}
+func (s *stubPartitionSource) GetPartitionHeight(ctx context.Context, partition string) (uint64, error) {
+	return s.heads[partition], nil
+}
+func (s *stubPartitionSource) SearchPartitionCertenTransactions(ctx context.Context, partition string, blockHeight int64) ([]*accumulate.CertenTransaction, error) {
+	return nil, nil
+}
+
+// memoryCursorStore persists partition cursors in memory
+type memoryCursorStore struct {
+	memoryLedgerStore
+	cursors map[string]uint64
+}
+
+func (m *memoryCursorStore) SaveIntentPartitionLastBlocks(heights map[string]uint64) error {
+	m.cursors = heights
+	return nil
+}
+func (m *memoryCursorStore) LoadIntentPartitionLastBlocks(partitions []string) (map[string]uint64, error) {
+	return m.cursors, nil
+}
+
+func newCursorTestDiscovery(store LedgerStoreInterface, queueSize int) *IntentDiscovery {
+	cfg := DefaultIntentDiscoveryConfig()
+	cfg.MinStartHeight = 100
+	cfg.ColdStartPolicy = ColdStartLookback
+	cfg.ColdStartLookback = 5
+	id := NewIntentDiscovery(nil, "", cfg, store, nil, "validator-1")
+	id.stopCh = make(chan struct{})
+	id.blockProcessCh = make(chan *BlockProcessJob, queueSize)
+	return id
+}
+
+func drainJobs(t *testing.T, id *IntentDiscovery) map[string][]uint64 {
+	t.Helper()
+	jobs := make(map[string][]uint64)
+	for {
+		select {
+		case job := <-id.blockProcessCh:
+			if !job.SinglePartition {
+				t.Errorf("block %d queued for every partition", job.BlockHeight)
+			}
+			jobs[job.PartitionURL] = append(jobs[job.PartitionURL], job.BlockHeight)
+		default:
+			return jobs
+		}
+	}
+}
+
+func TestPartitionCursors_ResumeIndependently(t *testing.T) {
+	store := &memoryCursorStore{cursors: map[string]uint64{testDN: 1000, testBVN0: 40}}
+	source := &stubPartitionSource{
+		order: []string{testDN, testBVN0, testBVN1},
+		heads: map[string]uint64{testDN: 1002, testBVN0: 43, testBVN1: 20},
+	}
+	id := newCursorTestDiscovery(store, 100)
+
+	if err := id.initializePartitionCursors(context.Background(), source); err != nil {
+		t.Fatalf("initializePartitionCursors failed: %v", err)
+	}
+	// BVN1 has no cursor: lookback from its own head, not bounded by the DN's MinStartHeight
+	if cursors := id.PartitionCursors(); cursors[testDN] != 1000 || cursors[testBVN0] != 40 || cursors[testBVN1] != 15 {
+		t.Fatalf("unexpected restored cursors %v", cursors)
+	}
+
+	if err := id.checkPartitionBlocks(context.Background(), source); err != nil {
+		t.Fatalf("checkPartitionBlocks failed: %v", err)
+	}
+	jobs := drainJobs(t, id)
+	if len(jobs[testDN]) != 2 || jobs[testDN][0] != 1001 || len(jobs[testBVN0]) != 3 || jobs[testBVN0][0] != 41 || len(jobs[testBVN1]) != 5 {
+		t.Errorf("unexpected queued blocks %v", jobs)
+	}
+
+	id.persistPartitionCursors()
+	if store.cursors[testDN] != 1002 || store.cursors[testBVN0] != 43 || store.cursors[testBVN1] != 20 {
+		t.Errorf("unexpected persisted cursors %v", store.cursors)
+	}
+}
+
+func TestPartitionCursors_FullQueueDefersBlocks(t *testing.T) {
+	source := &stubPartitionSource{order: []string{testBVN0}, heads: map[string]uint64{testBVN0: 50}}
+	id := newCursorTestDiscovery(nil, 3)
+	id.partitionCursors = map[string]uint64{testBVN0: 40}
+
+	if err := id.checkPartitionBlocks(context.Background(), source); err != nil {
+		t.Fatalf("checkPartitionBlocks failed: %v", err)
+	}
+	if cursor := id.PartitionCursors()[testBVN0]; cursor != 43 {
+		t.Errorf("cursor %d, expected 43 (only queued blocks are passed)", cursor)
+	}
+
+	drainJobs(t, id)
+	if err := id.checkPartitionBlocks(context.Background(), source); err != nil {
+		t.Fatalf("checkPartitionBlocks failed: %v", err)
+	}
+	if jobs := drainJobs(t, id); len(jobs[testBVN0]) != 3 || jobs[testBVN0][0] != 44 {
+		t.Errorf("expected the deferred blocks to resume at 44, got %v", jobs)
+	}
+}
+
+func TestPartitionCursors_ResetWhenHeadBehindCursor(t *testing.T) {
+	source := &stubPartitionSource{order: []string{testBVN0}, heads: map[string]uint64{testBVN0: 10}}
+	id := newCursorTestDiscovery(nil, 10)
+	id.partitionCursors = map[string]uint64{testBVN0: 500}
+
+	if err := id.checkPartitionBlocks(context.Background(), source); err != nil {
+		t.Fatalf("checkPartitionBlocks failed: %v", err)
+	}
+	if cursor := id.PartitionCursors()[testBVN0]; cursor != 10 {
+		t.Errorf("cursor %d, expected reset to head 10", cursor)
+	}
+	if jobs := drainJobs(t, id); len(jobs[testBVN0]) != 1 || jobs[testBVN0][0] != 10 {
+		t.Errorf("expected the head block to be queued, got %v", jobs)
+	}
+}
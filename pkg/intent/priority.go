@@ -0,0 +1,106 @@
+// Copyright 2025 Certen Protocol
+//
+// Intent Priority - Effective priority of a discovered intent
+//
+// The on-demand/on-cadence proof class picks the anchoring path; the priority orders
+// intents within it. An intent's priority is, in order of precedence:
+//   - the "priority" hint in its intent data (low, normal, high, urgent)
+//   - the first configured rule matching the intent
+//   - normal
+//
+// Rules have the form "<match>=<priority>", where match is one of:
+//   - type:<intentType>      the intent data's intentType
+//   - class:<intent_class>   the intent data's intent_class
+//   - tag:<tag>              one of the intent data's tags
+//   - account:<prefix>       the account URL or organization ADI prefix
+//
+// Higher-priority intents in a block are routed to the batch system first, are
+// released first from the quota queue, and their batches are anchored first.
+
+package intent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/certen/independant-validator/pkg/consensus"
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// PriorityRule assigns a priority to intents matching a field
+type PriorityRule struct {
+	Field    string // type, class, tag or account
+	Value    string
+	Priority database.RequestPriority
+}
+
+// ParsePriorityRules parses "<field>:<value>=<priority>" rules
+func ParsePriorityRules(rules []string) ([]PriorityRule, error) {
+	parsed := make([]PriorityRule, 0, len(rules))
+	for _, item := range rules {
+		match, priorityName, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("priority rule %q: expected <field>:<value>=<priority>", item)
+		}
+		field, value, found := strings.Cut(strings.TrimSpace(match), ":")
+		field, value = strings.ToLower(strings.TrimSpace(field)), strings.TrimSpace(value)
+		if !found || value == "" {
+			return nil, fmt.Errorf("priority rule %q: expected <field>:<value>=<priority>", item)
+		}
+		switch field {
+		case "type", "class", "tag", "account":
+		default:
+			return nil, fmt.Errorf("priority rule %q: unknown field %q (expected type, class, tag or account)", item, field)
+		}
+		priority, err := database.ParseRequestPriority(priorityName)
+		if err != nil || strings.TrimSpace(priorityName) == "" {
+			return nil, fmt.Errorf("priority rule %q: unknown priority %q", item, priorityName)
+		}
+		parsed = append(parsed, PriorityRule{Field: field, Value: value, Priority: priority})
+	}
+	return parsed, nil
+}
+
+// matches reports whether the rule applies to the intent
+func (r PriorityRule) matches(intent *CertenIntent, data *consensus.IntentData) bool {
+	switch r.Field {
+	case "type":
+		return data != nil && strings.EqualFold(data.IntentType, r.Value)
+	case "class":
+		return data != nil && strings.EqualFold(data.IntentClass, r.Value)
+	case "tag":
+		if data != nil {
+			for _, tag := range data.Tags {
+				if strings.EqualFold(tag, r.Value) {
+					return true
+				}
+			}
+		}
+		return false
+	case "account":
+		prefix := strings.ToLower(r.Value)
+		return strings.HasPrefix(strings.ToLower(intent.AccountURL), prefix) ||
+			strings.HasPrefix(strings.ToLower(intent.OrganizationADI), prefix)
+	}
+	return false
+}
+
+// ResolvePriority returns the intent's effective priority: its own valid hint, else the
+// first matching rule, else normal
+func ResolvePriority(intent *CertenIntent, rules []PriorityRule) database.RequestPriority {
+	data, err := intent.ParseIntentData()
+	if err != nil {
+		data = nil // Rules on account prefixes still apply
+	}
+	if data != nil && strings.TrimSpace(data.Priority) != "" {
+		if priority, err := database.ParseRequestPriority(data.Priority); err == nil {
+			return priority
+		}
+	}
+	for _, rule := range rules {
+		if rule.matches(intent, data) {
+			return rule.Priority
+		}
+	}
+	return database.PriorityNormal
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Intent Priority
+// Tests rule parsing and resolution of an intent's effective priority
+
+package intent
+
+import (
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestParsePriorityRules(t *testing.T) {
+	rules, err := ParsePriorityRules([]string{"type:treasury_transfer=urgent", " account:acc://ops.acme = high "})
+	if err != nil {
+		t.Fatalf("ParsePriorityRules failed: %v", err)
+	}
+	if len(rules) != 2 || rules[0] != (PriorityRule{Field: "type", Value: "treasury_transfer", Priority: database.PriorityUrgent}) ||
+		rules[1] != (PriorityRule{Field: "account", Value: "acc://ops.acme", Priority: database.PriorityHigh}) {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+
+	for _, rule := range []string{"type:x", "type=high", "type:=high", "color:red=high", "type:x=soon", "type:x="} {
+		if _, err := ParsePriorityRules([]string{rule}); err == nil {
+			t.Errorf("expected error for rule %q", rule)
+		}
+	}
+}
+
+func TestResolvePriority(t *testing.T) {
+	rules, _ := ParsePriorityRules([]string{"tag:payroll=high", "account:acc://ops.acme=low"})
+
+	tests := []struct {
+		name       string
+		intentData string
+		accountURL string
+		want       database.RequestPriority
+	}{
+		{"hint wins over rules", `{"priority":"urgent","tags":["payroll"]}`, "acc://ops.acme/data", database.PriorityUrgent},
+		{"tag rule", `{"tags":["Payroll"]}`, "acc://other.acme/data", database.PriorityHigh},
+		{"account rule", `{}`, "acc://ops.acme/data", database.PriorityLow},
+		{"invalid hint falls back to rules", `{"priority":"asap"}`, "acc://ops.acme/data", database.PriorityLow},
+		{"unparseable intent data", `not json`, "acc://ops.acme/data", database.PriorityLow},
+		{"default", `{}`, "acc://other.acme/data", database.PriorityNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intent := &CertenIntent{IntentData: []byte(tt.intentData), AccountURL: tt.accountURL}
+			if got := ResolvePriority(intent, rules); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
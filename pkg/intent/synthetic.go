@@ -0,0 +1,351 @@
+// Copyright 2025 Certen Protocol
+//
+// Synthetic Transactions - Proving and anchoring transactions produced by an intent
+//
+// Executing a user transaction makes Accumulate produce synthetic transactions (token
+// deposits, synthetic account creation, ...) that are delivered to their principal's
+// partition afterwards. When enabled, once an intent has been routed to the batch
+// system its transaction's produced synthetic transactions are:
+//   - discovered from the origin transaction's "produced" records
+//   - awaited until delivered (bounded by SyntheticTxFinalityWait)
+//   - proven with an L1-L3 chained proof and a G0 (inclusion and finality) proof;
+//     G1/G2 do not apply as synthetic transactions are signed by the network, not
+//     by a key page
+//   - anchored in the intent's proof class (or SyntheticTxProofClass) with their
+//     batch transaction and proof artifact linked to the origin transaction
+//
+// Failures never affect the origin intent; they are logged and counted.
+
+package intent
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/accumulate"
+	"github.com/certen/independant-validator/pkg/batch"
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/proof"
+)
+
+const (
+	DefaultSyntheticTxMaxPerIntent = 16
+	DefaultSyntheticTxFinalityWait = 30 * time.Second
+)
+
+// SyntheticTransactionSource looks up Accumulate transactions and the synthetic
+// transactions they produced
+// Implemented by accumulate.Client
+type SyntheticTransactionSource interface {
+	GetTransaction(ctx context.Context, hash string) (*accumulate.Transaction, error)
+}
+
+// SyntheticTransaction is a synthetic transaction produced by an intent's transaction
+type SyntheticTransaction struct {
+	TxID         string // acc://<hash>@<principal>
+	Hash         string // 64-char hex transaction hash
+	Principal    string // Account the synthetic transaction is delivered to
+	Type         string // Accumulate transaction type (e.g. syntheticDepositTokens)
+	OriginTxHash string // Hash of the user transaction that produced it
+}
+
+// SyntheticTxStats counts synthetic transaction handling since startup
+type SyntheticTxStats struct {
+	Discovered int64 `json:"discovered"`
+	Anchored   int64 `json:"anchored"` // Routed to the batch system
+	Skipped    int64 `json:"skipped"`  // Never delivered, still pending after the wait, or over the per-intent limit
+	Failed     int64 `json:"failed"`   // Proof generation or batch routing failed
+}
+
+// SetSyntheticTransactionSource configures where synthetic transactions are looked up.
+// A nil source disables synthetic transaction discovery.
+func (id *IntentDiscovery) SetSyntheticTransactionSource(source SyntheticTransactionSource) {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+	id.syntheticSource = source
+}
+
+// SyntheticStats returns synthetic transaction counters
+func (id *IntentDiscovery) SyntheticStats() SyntheticTxStats {
+	id.mu.RLock()
+	defer id.mu.RUnlock()
+	return id.syntheticStats
+}
+
+// ParseSyntheticTxID splits an Accumulate transaction ID (acc://<hash>@<principal>)
+// into its hash and principal account URL
+func ParseSyntheticTxID(txid string) (hash, principal string, err error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(txid), "acc://")
+	at := strings.Index(rest, "@")
+	if at <= 0 || at == len(rest)-1 {
+		return "", "", fmt.Errorf("invalid transaction ID %q (expected acc://<hash>@<principal>)", txid)
+	}
+	hash = strings.ToLower(rest[:at])
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != 32 {
+		return "", "", fmt.Errorf("invalid transaction ID %q: hash is not 32 bytes of hex", txid)
+	}
+	return hash, "acc://" + rest[at+1:], nil
+}
+
+// syntheticProofClass returns the proof class synthetic transactions of an intent are anchored in
+func (id *IntentDiscovery) syntheticProofClass(intentProofClass string) string {
+	if id.config.SyntheticTxProofClass != "" {
+		return id.config.SyntheticTxProofClass
+	}
+	return intentProofClass
+}
+
+// recordSynthetic updates the synthetic transaction counters
+func (id *IntentDiscovery) recordSynthetic(update func(stats *SyntheticTxStats)) {
+	id.mu.Lock()
+	update(&id.syntheticStats)
+	id.mu.Unlock()
+}
+
+// processSyntheticTransactions proves and anchors the synthetic transactions produced
+// by an intent's transaction
+func (id *IntentDiscovery) processSyntheticTransactions(intent *CertenIntent, intentProofClass string) {
+	ctx, cancel := context.WithTimeout(context.Background(), id.config.SyntheticTxFinalityWait+2*id.config.BFTTimeout)
+	defer cancel()
+
+	synthetics, err := id.discoverSyntheticTransactions(ctx, intent)
+	if err != nil {
+		id.logger.Printf("⚠️ [SYNTHETIC] Discovery failed for intent %s: %v", intent.IntentID, err)
+		return
+	}
+	if len(synthetics) == 0 {
+		return
+	}
+
+	proofClass := id.syntheticProofClass(intentProofClass)
+	for _, synth := range synthetics {
+		if err := id.anchorSyntheticTransaction(ctx, intent, synth, proofClass); err != nil {
+			id.recordSynthetic(func(s *SyntheticTxStats) { s.Failed++ })
+			id.logger.Printf("⚠️ [SYNTHETIC] %s (from intent %s) not anchored: %v", synth.TxID, intent.IntentID, err)
+			continue
+		}
+		id.recordSynthetic(func(s *SyntheticTxStats) { s.Anchored++ })
+		id.logger.Printf("✅ [SYNTHETIC] %s routed as %s, linked to origin %s", synth.TxID, proofClass, synth.OriginTxHash)
+	}
+}
+
+// discoverSyntheticTransactions returns the delivered synthetic transactions produced by
+// an intent's transaction, waiting up to SyntheticTxFinalityWait for pending ones
+func (id *IntentDiscovery) discoverSyntheticTransactions(ctx context.Context, intent *CertenIntent) ([]*SyntheticTransaction, error) {
+	id.mu.RLock()
+	source := id.syntheticSource
+	id.mu.RUnlock()
+	if source == nil {
+		return nil, fmt.Errorf("no synthetic transaction source configured")
+	}
+	if intent.TransactionHash == "" {
+		return nil, fmt.Errorf("intent has no transaction hash")
+	}
+
+	origin, err := source.GetTransaction(ctx, intent.TransactionHash)
+	if err != nil {
+		return nil, fmt.Errorf("look up origin transaction: %w", err)
+	}
+
+	produced := origin.Produced
+	if limit := id.config.SyntheticTxMaxPerIntent; limit > 0 && len(produced) > limit {
+		over := int64(len(produced) - limit)
+		id.recordSynthetic(func(s *SyntheticTxStats) { s.Skipped += over })
+		id.logger.Printf("⚠️ [SYNTHETIC] Intent %s produced %d synthetic transactions - anchoring the first %d",
+			intent.IntentID, len(produced), limit)
+		produced = produced[:limit]
+	}
+
+	var synthetics []*SyntheticTransaction
+	for _, txid := range produced {
+		hash, principal, err := ParseSyntheticTxID(txid)
+		if err != nil {
+			id.logger.Printf("⚠️ [SYNTHETIC] Skipping produced record of intent %s: %v", intent.IntentID, err)
+			continue
+		}
+		id.recordSynthetic(func(s *SyntheticTxStats) { s.Discovered++ })
+
+		tx, finality := id.awaitSyntheticDelivery(ctx, source, hash)
+		if finality != TxFinalityFinal && finality != TxFinalityUnknown {
+			id.recordSynthetic(func(s *SyntheticTxStats) { s.Skipped++ })
+			id.logger.Printf("⚠️ [SYNTHETIC] Skipping %s: %s", txid, finality)
+			continue
+		}
+
+		synth := &SyntheticTransaction{
+			TxID:         txid,
+			Hash:         hash,
+			Principal:    principal,
+			OriginTxHash: intent.TransactionHash,
+		}
+		if tx != nil {
+			synth.Type = tx.Type
+		}
+		synthetics = append(synthetics, synth)
+	}
+	return synthetics, nil
+}
+
+// awaitSyntheticDelivery polls a synthetic transaction until it is no longer pending,
+// the finality wait elapses, or discovery is stopped
+func (id *IntentDiscovery) awaitSyntheticDelivery(ctx context.Context, source SyntheticTransactionSource, hash string) (*accumulate.Transaction, TxFinality) {
+	pollInterval := id.config.ProofSharePollInterval
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+
+	deadline := time.Now().Add(id.config.SyntheticTxFinalityWait)
+	for {
+		tx, err := source.GetTransaction(ctx, hash)
+		finality := TxFinalityUnknown
+		if err == nil {
+			finality = ClassifyTxStatus(tx.Status)
+		}
+		if finality != TxFinalityPending || time.Now().Add(pollInterval).After(deadline) {
+			return tx, finality
+		}
+
+		select {
+		case <-ctx.Done():
+			return tx, finality
+		case <-id.stopCh:
+			return tx, finality
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// anchorSyntheticTransaction proves a synthetic transaction and routes it to the batch system
+func (id *IntentDiscovery) anchorSyntheticTransaction(ctx context.Context, intent *CertenIntent, synth *SyntheticTransaction, proofClass string) error {
+	certenProof, govProof, err := id.generateSyntheticProofs(ctx, synth)
+	if err != nil {
+		return err
+	}
+
+	txData, err := id.convertSyntheticToTransactionData(intent, synth, certenProof, govProof)
+	if err != nil {
+		return fmt.Errorf("convert to transaction data: %w", err)
+	}
+
+	if proofClass == "on_demand" {
+		if id.onDemandHandler == nil {
+			return fmt.Errorf("on_demand synthetic transaction but OnDemandHandler not configured")
+		}
+		if _, err := id.onDemandHandler.ProcessTransaction(ctx, txData); err != nil {
+			return fmt.Errorf("on_demand handler failed: %w", err)
+		}
+		return nil
+	}
+
+	if id.batchCollector == nil {
+		return fmt.Errorf("on_cadence synthetic transaction but BatchCollector not configured")
+	}
+	if _, err := id.batchCollector.AddOnCadenceTransaction(ctx, txData); err != nil {
+		return fmt.Errorf("batch collector failed: %w", err)
+	}
+	return nil
+}
+
+// generateSyntheticProofs generates the L1-L3 chained proof and G0 proof for a synthetic
+// transaction. Unlike user intents there is no basic proof fallback: a synthetic
+// transaction is only anchored with a proof of its inclusion.
+func (id *IntentDiscovery) generateSyntheticProofs(ctx context.Context, synth *SyntheticTransaction) (*proof.CertenProof, *proof.GovernanceProof, error) {
+	if id.proofGenerator == nil || !id.proofGenerator.HasRealProofBuilder() {
+		return nil, nil, fmt.Errorf("L1-L3 proof builder not configured")
+	}
+
+	proofCtx, cancel := context.WithTimeout(ctx, id.config.BFTTimeout)
+	defer cancel()
+
+	// The partition is derived from the principal's routing number
+	chainedProof, err := id.proofGenerator.GenerateChainedProof(proofCtx, synth.Principal, synth.Hash, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("L1-L3 chained proof: %w", err)
+	}
+
+	req := &proof.ProofRequest{
+		RequestID:       fmt.Sprintf("synthetic_%s", synth.Hash),
+		ProofType:       "chained_l1_l2_l3",
+		TransactionHash: synth.Hash,
+		AccountURL:      synth.Principal,
+	}
+	certenProof := proof.NewCertenProofAdapter(proof.ChainedProofToCompleteProof(chainedProof), req, id.validatorID).ToCertenProof()
+	if certenProof == nil {
+		return nil, nil, fmt.Errorf("adapter returned nil CertenProof")
+	}
+
+	var govProof *proof.GovernanceProof
+	if id.governanceProofGen != nil {
+		g0, err := id.governanceProofGen.GenerateG0(proofCtx, &proof.GovernanceRequest{
+			AccountURL:      synth.Principal,
+			TransactionHash: synth.Hash,
+			Chain:           "main",
+		})
+		if err != nil {
+			id.logger.Printf("⚠️ [SYNTHETIC] G0 proof generation failed for %s: %v", synth.TxID, err)
+		} else {
+			govProof = g0
+		}
+	}
+
+	return certenProof, govProof, nil
+}
+
+// convertSyntheticToTransactionData builds the batch transaction for a synthetic
+// transaction, linked to its origin and inheriting the intent's tracking fields
+func (id *IntentDiscovery) convertSyntheticToTransactionData(intent *CertenIntent, synth *SyntheticTransaction, certenProof *proof.CertenProof, govProof *proof.GovernanceProof) (*batch.TransactionData, error) {
+	// Accumulate transaction hashes are already 32-byte SHA-256 digests
+	txHash, err := hex.DecodeString(synth.Hash)
+	if err != nil || len(txHash) != 32 {
+		return nil, fmt.Errorf("invalid synthetic transaction hash %q", synth.Hash)
+	}
+
+	intentData, err := json.Marshal(map[string]string{
+		"txid":             synth.TxID,
+		"type":             synth.Type,
+		"origin_tx_hash":   synth.OriginTxHash,
+		"origin_intent_id": intent.IntentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	targetChain, _, err := intent.GetTargetChain()
+	if err != nil {
+		targetChain = "sepolia"
+	}
+
+	txData := &batch.TransactionData{
+		AccumTxHash:  synth.Hash,
+		AccountURL:   synth.Principal,
+		TxHash:       txHash,
+		IntentType:   "synthetic_transaction",
+		IntentData:   intentData,
+		UserID:       intent.UserID,
+		IntentID:     intent.IntentID,
+		TargetChain:  targetChain,
+		Priority:     ResolvePriority(intent, id.config.PriorityRules),
+		Origin:       database.TransactionOriginSynthetic,
+		OriginTxHash: synth.OriginTxHash,
+		FromChain:    "accumulate",
+		AdiURL:       intent.OrganizationADI,
+	}
+
+	if certenProof != nil && certenProof.LiteClientProof != nil {
+		if chainedBytes, err := json.Marshal(certenProof.LiteClientProof); err == nil {
+			txData.ChainedProof = chainedBytes
+		}
+	}
+	if govProof != nil {
+		if govProofBytes, err := json.Marshal(govProof); err == nil {
+			txData.GovProof = govProofBytes
+			txData.GovLevel = string(govProof.Level)
+		}
+	}
+
+	return txData, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Synthetic Transactions
+// Tests transaction ID parsing, discovery with delivery filtering and the per-intent limit,
+// and linking of the batch transaction to its origin
+
+package intent
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/accumulate"
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+// stubSyntheticSource answers transaction lookups from a map keyed by hash
+type stubSyntheticSource struct {
+	txs map[string]*accumulate.Transaction
+}
+
+func (s *stubSyntheticSource) GetTransaction(ctx context.Context, hash string) (*accumulate.Transaction, error) {
+	if tx, ok := s.txs[hash]; ok {
+		return tx, nil
+	}
+	return &accumulate.Transaction{Hash: hash}, nil
+}
+
+func testSyntheticHash(c string) string {
+	return strings.Repeat(c, 64)
+}
+
+func TestParseSyntheticTxID(t *testing.T) {
+	hash, principal, err := ParseSyntheticTxID("acc://" + strings.ToUpper(testSyntheticHash("a")) + "@alice.acme/tokens")
+	if err != nil {
+		t.Fatalf("ParseSyntheticTxID failed: %v", err)
+	}
+	if hash != testSyntheticHash("a") || principal != "acc://alice.acme/tokens" {
+		t.Errorf("got hash=%s principal=%s", hash, principal)
+	}
+
+	for _, txid := range []string{"", "acc://alice.acme", "acc://@alice.acme", "acc://" + testSyntheticHash("a") + "@", "acc://abcd@alice.acme"} {
+		if _, _, err := ParseSyntheticTxID(txid); err == nil {
+			t.Errorf("%q: expected error", txid)
+		}
+	}
+}
+
+func TestDiscoverSyntheticTransactions(t *testing.T) {
+	cfg := DefaultIntentDiscoveryConfig()
+	cfg.SyntheticTxEnabled = true
+	cfg.SyntheticTxMaxPerIntent = 3
+	cfg.SyntheticTxFinalityWait = 0
+	id := NewIntentDiscovery(nil, "", cfg, nil, nil, "validator-1")
+
+	delivered, rejected, pending := testSyntheticHash("1"), testSyntheticHash("2"), testSyntheticHash("3")
+	id.SetSyntheticTransactionSource(&stubSyntheticSource{txs: map[string]*accumulate.Transaction{
+		"origin": {Hash: "origin", Status: "delivered", Produced: []string{
+			"acc://" + delivered + "@bob.acme/tokens",
+			"not-a-txid",
+			"acc://" + rejected + "@carol.acme/tokens",
+			"acc://" + pending + "@dave.acme/tokens",
+			"acc://" + testSyntheticHash("4") + "@erin.acme/tokens", // Over the limit
+		}},
+		delivered: {Hash: delivered, Status: "delivered", Type: "syntheticDepositTokens"},
+		rejected:  {Hash: rejected, Status: "rejected"},
+		pending:   {Hash: pending, Status: "pending"},
+	}})
+
+	synthetics, err := id.discoverSyntheticTransactions(context.Background(), &CertenIntent{IntentID: "intent-1", TransactionHash: "origin"})
+	if err != nil {
+		t.Fatalf("discoverSyntheticTransactions failed: %v", err)
+	}
+	if len(synthetics) != 1 {
+		t.Fatalf("got %d synthetic transactions, expected 1", len(synthetics))
+	}
+	synth := synthetics[0]
+	if synth.Hash != delivered || synth.Principal != "acc://bob.acme/tokens" || synth.Type != "syntheticDepositTokens" || synth.OriginTxHash != "origin" {
+		t.Errorf("unexpected synthetic transaction %+v", synth)
+	}
+	if stats := id.SyntheticStats(); stats.Discovered != 2 || stats.Skipped != 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestConvertSyntheticToTransactionData_LinksOrigin(t *testing.T) {
+	id := NewIntentDiscovery(nil, "", nil, nil, nil, "validator-1")
+	intent := &CertenIntent{IntentID: "intent-1", TransactionHash: "origin", UserID: "user-1", OrganizationADI: "acc://alice.acme"}
+	synth := &SyntheticTransaction{
+		TxID:         "acc://" + testSyntheticHash("b") + "@bob.acme/tokens",
+		Hash:         testSyntheticHash("b"),
+		Principal:    "acc://bob.acme/tokens",
+		OriginTxHash: "origin",
+	}
+
+	txData, err := id.convertSyntheticToTransactionData(intent, synth, nil, nil)
+	if err != nil {
+		t.Fatalf("convertSyntheticToTransactionData failed: %v", err)
+	}
+	if txData.Origin != database.TransactionOriginSynthetic || txData.OriginTxHash != "origin" ||
+		txData.AccumTxHash != synth.Hash || txData.AccountURL != synth.Principal || len(txData.TxHash) != 32 ||
+		txData.IntentID != "intent-1" || txData.UserID != "user-1" {
+		t.Errorf("unexpected transaction data %+v", txData)
+	}
+
+	var data map[string]string
+	if err := json.Unmarshal(txData.IntentData, &data); err != nil || data["origin_intent_id"] != "intent-1" || data["txid"] != synth.TxID {
+		t.Errorf("unexpected intent data %s", txData.IntentData)
+	}
+
+	synth.Hash = "not-hex"
+	if _, err := id.convertSyntheticToTransactionData(intent, synth, nil, nil); err == nil {
+		t.Error("expected error for an invalid hash")
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright 2025 Certen Protocol
+//
+// Proof-Work Partitioning - Deterministic split of proof generation across validators
+//
+// Every validator discovers the same intents independently. Without coordination each
+// of them generates the same L1-L3 chained proof and G0/G1/G2 governance proofs, which
+// multiplies Accumulate RPC load by the size of the validator set.
+//
+// When partitioning is enabled:
+//   - The intent hash modulo the validator count selects one OWNER validator
+//   - The owner generates the proofs and publishes them to its shared-proof cache
+//   - Other validators fetch the shared proof from peers instead of regenerating it,
+//     accepting it only when it is for the intent's transaction and carries the owner's
+//     Ed25519 signature
+//   - If the owner's proof does not appear within the share wait, the next validator
+//     in the deterministic order (the backup) generates it, and after a second wait
+//     every remaining validator falls back to local generation
+
+package intent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/proof"
+)
+
+// SharedProof is a proof set generated by one validator and shared with its peers
+type SharedProof struct {
+	IntentID        string                 `json:"intent_id"`
+	TransactionHash string                 `json:"transaction_hash"`
+	GeneratedBy     string                 `json:"generated_by"`
+	GeneratedAt     time.Time              `json:"generated_at"`
+	CertenProof     *proof.CertenProof     `json:"certen_proof,omitempty"`
+	GovernanceProof *proof.GovernanceProof `json:"governance_proof,omitempty"`
+
+	// Ed25519 signature of GeneratedBy over the other fields (see Sign)
+	Signature []byte `json:"signature,omitempty"`
+
+	// Wire encoding the proof was decoded from, which the signature is checked against
+	encoded []byte
+}
+
+// sharedProofDomain separates shared proof signatures from other validator signatures
+const sharedProofDomain = "certen-shared-proof-v1:"
+
+// SharedProofSigner signs shared proofs with the validator's Ed25519 key
+// (satisfied by keys.KeyProvider)
+type SharedProofSigner interface {
+	Sign(message []byte) ([]byte, error)
+}
+
+// sharedProofSigningPayload returns the bytes a shared proof signature covers: the JSON
+// fields of the encoded proof other than the signature, re-encoded with sorted keys. The
+// field values are kept verbatim, so the signer and a peer decoding the wire encoding
+// derive the same bytes.
+func sharedProofSigningPayload(encoded []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode shared proof: %w", err)
+	}
+	delete(fields, "signature")
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode shared proof payload: %w", err)
+	}
+	return append([]byte(sharedProofDomain), payload...), nil
+}
+
+// Sign signs the shared proof as its generating validator
+func (s *SharedProof) Sign(signer SharedProofSigner) error {
+	s.Signature = nil
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode shared proof: %w", err)
+	}
+	payload, err := sharedProofSigningPayload(encoded)
+	if err != nil {
+		return err
+	}
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		return fmt.Errorf("failed to sign shared proof: %w", err)
+	}
+	s.Signature = signature
+	return nil
+}
+
+// SharedProofRequest identifies the shared proof a validator is waiting for: the intent,
+// its transaction, and the partition owner that must have generated and signed it
+type SharedProofRequest struct {
+	IntentID        string
+	TransactionHash string
+	Owner           string
+}
+
+// VerifySharedProof checks that a shared proof answers the request and is signed by the
+// owner's key. A proof from any other validator, for another transaction, or without a
+// valid signature is rejected.
+func VerifySharedProof(shared *SharedProof, req SharedProofRequest, ownerKey ed25519.PublicKey) error {
+	if shared == nil {
+		return fmt.Errorf("no shared proof")
+	}
+	if shared.IntentID != req.IntentID {
+		return fmt.Errorf("proof is for intent %s, expected %s", shared.IntentID, req.IntentID)
+	}
+	if shared.GeneratedBy != req.Owner {
+		return fmt.Errorf("proof was generated by %s, not the owner %s", shared.GeneratedBy, req.Owner)
+	}
+	if shared.TransactionHash != req.TransactionHash {
+		return fmt.Errorf("proof is for transaction %s, expected %s", shared.TransactionHash, req.TransactionHash)
+	}
+	if shared.CertenProof != nil && shared.CertenProof.TransactionHash != "" &&
+		shared.CertenProof.TransactionHash != req.TransactionHash {
+		return fmt.Errorf("chained proof is for transaction %s, expected %s", shared.CertenProof.TransactionHash, req.TransactionHash)
+	}
+	if len(ownerKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("no public key for owner %s", req.Owner)
+	}
+	if len(shared.Signature) == 0 {
+		return fmt.Errorf("proof is not signed")
+	}
+
+	encoded := shared.encoded
+	if encoded == nil {
+		var err error
+		if encoded, err = json.Marshal(shared); err != nil {
+			return fmt.Errorf("failed to encode shared proof: %w", err)
+		}
+	}
+	payload, err := sharedProofSigningPayload(encoded)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(ownerKey, payload, shared.Signature) {
+		return fmt.Errorf("invalid signature from %s", req.Owner)
+	}
+	return nil
+}
+
+// SharedProofSource looks up a proof set that another validator already generated.
+// Implementations return (nil, nil) when no shared proof passing VerifySharedProof is
+// available yet.
+type SharedProofSource interface {
+	FetchSharedProof(ctx context.Context, req SharedProofRequest) (*SharedProof, error)
+}
+
+// ProofWorkPartitioner deterministically assigns proof-generation work to validators
+type ProofWorkPartitioner struct {
+	validatorID  string
+	validatorSet []string // sorted for determinism across validators
+}
+
+// NewProofWorkPartitioner creates a partitioner for the given validator set.
+// The set MUST be identical on all validators for the assignment to agree.
+func NewProofWorkPartitioner(validatorID string, validatorSet []string) (*ProofWorkPartitioner, error) {
+	if validatorID == "" {
+		return nil, fmt.Errorf("validator ID is required")
+	}
+	if len(validatorSet) == 0 {
+		return nil, fmt.Errorf("validator set cannot be empty")
+	}
+
+	sorted := make([]string, len(validatorSet))
+	copy(sorted, validatorSet)
+	sort.Strings(sorted)
+
+	found := false
+	for _, v := range sorted {
+		if v == validatorID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("validator %s is not in the validator set %v", validatorID, sorted)
+	}
+
+	return &ProofWorkPartitioner{
+		validatorID:  validatorID,
+		validatorSet: sorted,
+	}, nil
+}
+
+// ownerIndex returns the index of the owning validator for an intent
+func (p *ProofWorkPartitioner) ownerIndex(intentID string) int {
+	hash := sha256.Sum256([]byte(intentID))
+	index := uint32(hash[0])<<24 | uint32(hash[1])<<16 | uint32(hash[2])<<8 | uint32(hash[3])
+	return int(index % uint32(len(p.validatorSet)))
+}
+
+// Owner returns the validator primarily responsible for generating the intent's proofs
+func (p *ProofWorkPartitioner) Owner(intentID string) string {
+	return p.validatorSet[p.ownerIndex(intentID)]
+}
+
+// Rank returns this validator's position in the intent's fallback order.
+// Rank 0 is the owner, rank 1 is the backup, and so on around the sorted set.
+func (p *ProofWorkPartitioner) Rank(intentID string) int {
+	owner := p.ownerIndex(intentID)
+	for i, v := range p.validatorSet {
+		if v == p.validatorID {
+			return (i - owner + len(p.validatorSet)) % len(p.validatorSet)
+		}
+	}
+	return len(p.validatorSet)
+}
+
+// IsOwner reports whether this validator owns the intent's proof generation
+func (p *ProofWorkPartitioner) IsOwner(intentID string) bool {
+	return p.Rank(intentID) == 0
+}
+
+// ValidatorSet returns the sorted validator set used for assignment
+func (p *ProofWorkPartitioner) ValidatorSet() []string {
+	out := make([]string, len(p.validatorSet))
+	copy(out, p.validatorSet)
+	return out
+}
+
+// =============================================================================
+// Shared Proof Cache (served to peers)
+// =============================================================================
+
+// SharedProofCache holds proofs this validator generated as owner so peers can fetch them
+type SharedProofCache struct {
+	mu      sync.RWMutex
+	entries map[string]*SharedProof
+	ttl     time.Duration
+}
+
+// NewSharedProofCache creates a cache that retains shared proofs for the given TTL
+func NewSharedProofCache(ttl time.Duration) *SharedProofCache {
+	if ttl <= 0 {
+		ttl = 30 * time.Minute
+	}
+	return &SharedProofCache{
+		entries: make(map[string]*SharedProof),
+		ttl:     ttl,
+	}
+}
+
+// Put stores a shared proof and evicts expired entries
+func (c *SharedProofCache) Put(shared *SharedProof) {
+	if shared == nil || shared.IntentID == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for id, entry := range c.entries {
+		if now.Sub(entry.GeneratedAt) > c.ttl {
+			delete(c.entries, id)
+		}
+	}
+	c.entries[shared.IntentID] = shared
+}
+
+// Get returns the shared proof for an intent if present and not expired
+func (c *SharedProofCache) Get(intentID string) (*SharedProof, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[intentID]
+	if !ok || time.Since(entry.GeneratedAt) > c.ttl {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Len returns the number of cached shared proofs
+func (c *SharedProofCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// =============================================================================
+// Peer Shared Proof Source
+// =============================================================================
+
+// PeerSharedProofSource fetches shared proofs from peer validators over HTTP. The
+// endpoint is unauthenticated, so every response is checked against the owner's key.
+type PeerSharedProofSource struct {
+	peerEndpoints []string // e.g. "http://validator-2:8080"
+	peerKeys      map[string]ed25519.PublicKey
+	validatorID   string
+	httpClient    *http.Client
+	logger        *log.Logger
+}
+
+// NewPeerSharedProofSource creates a source that queries the given peer endpoints and
+// accepts proofs signed with peerKeys (validator ID -> Ed25519 public key)
+func NewPeerSharedProofSource(validatorID string, peerEndpoints []string, peerKeys map[string]ed25519.PublicKey, timeout time.Duration, logger *log.Logger) *PeerSharedProofSource {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	if logger == nil {
+		logger = log.New(log.Writer(), "[SharedProof] ", log.LstdFlags)
+	}
+	return &PeerSharedProofSource{
+		peerEndpoints: peerEndpoints,
+		peerKeys:      peerKeys,
+		validatorID:   validatorID,
+		httpClient:    &http.Client{Timeout: timeout},
+		logger:        logger,
+	}
+}
+
+// FetchSharedProof asks each peer for the intent's shared proof and returns the first one
+// that passes VerifySharedProof
+func (s *PeerSharedProofSource) FetchSharedProof(ctx context.Context, req SharedProofRequest) (*SharedProof, error) {
+	var lastErr error
+	for _, peer := range s.peerEndpoints {
+		shared, err := s.fetchFromPeer(ctx, peer, req.IntentID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if shared == nil {
+			continue
+		}
+		if err := VerifySharedProof(shared, req, s.peerKeys[req.Owner]); err != nil {
+			s.logger.Printf("🚫 Rejected shared proof for %s from %s: %v", req.IntentID, peer, err)
+			lastErr = err
+			continue
+		}
+		return shared, nil
+	}
+
+	// A miss on every reachable peer is not an error - the owner may not be done yet
+	if lastErr != nil && len(s.peerEndpoints) > 0 {
+		s.logger.Printf("⚠️ Shared proof lookup for %s had peer errors: %v", req.IntentID, lastErr)
+	}
+	return nil, nil
+}
+
+// fetchFromPeer queries a single peer; returns (nil, nil) on 404
+func (s *PeerSharedProofSource) fetchFromPeer(ctx context.Context, peerURL, intentID string) (*SharedProof, error) {
+	reqURL := fmt.Sprintf("%s/api/intents/shared-proofs/%s", peerURL, url.PathEscape(intentID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("X-Validator-ID", s.validatorID)
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", peerURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", peerURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer %s returned status %d: %s", peerURL, resp.StatusCode, string(body))
+	}
+
+	var shared SharedProof
+	if err := json.Unmarshal(body, &shared); err != nil {
+		return nil, fmt.Errorf("failed to parse shared proof from %s: %w", peerURL, err)
+	}
+	shared.encoded = body
+	return &shared, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Proof-Work Partitioning
+// Tests deterministic owner selection, fallback ranking, the shared proof cache, and
+// verification of shared proofs fetched from peers
+
+package intent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/proof"
+)
+
+var testValidatorSet = []string{"validator-3", "validator-1", "validator-2", "validator-4"}
+
+func TestProofWorkPartitioner_OwnerIsDeterministic(t *testing.T) {
+	p1, err := NewProofWorkPartitioner("validator-1", testValidatorSet)
+	if err != nil {
+		t.Fatalf("NewProofWorkPartitioner failed: %v", err)
+	}
+	// Different validator and different input order must agree on the owner
+	reordered := []string{"validator-4", "validator-2", "validator-1", "validator-3"}
+	p2, err := NewProofWorkPartitioner("validator-2", reordered)
+	if err != nil {
+		t.Fatalf("NewProofWorkPartitioner failed: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		intentID := fmt.Sprintf("intent-%d", i)
+		if p1.Owner(intentID) != p2.Owner(intentID) {
+			t.Fatalf("owner mismatch for %s: %s vs %s", intentID, p1.Owner(intentID), p2.Owner(intentID))
+		}
+	}
+}
+
+func TestProofWorkPartitioner_ExactlyOneOwnerAndUniqueRanks(t *testing.T) {
+	partitioners := make([]*ProofWorkPartitioner, 0, len(testValidatorSet))
+	for _, v := range testValidatorSet {
+		p, err := NewProofWorkPartitioner(v, testValidatorSet)
+		if err != nil {
+			t.Fatalf("NewProofWorkPartitioner(%s) failed: %v", v, err)
+		}
+		partitioners = append(partitioners, p)
+	}
+
+	for i := 0; i < 50; i++ {
+		intentID := fmt.Sprintf("intent-%d", i)
+		owners := 0
+		ranks := make(map[int]bool)
+		for _, p := range partitioners {
+			if p.IsOwner(intentID) {
+				owners++
+			}
+			ranks[p.Rank(intentID)] = true
+		}
+		if owners != 1 {
+			t.Errorf("intent %s has %d owners, expected 1", intentID, owners)
+		}
+		if len(ranks) != len(testValidatorSet) {
+			t.Errorf("intent %s has %d distinct ranks, expected %d", intentID, len(ranks), len(testValidatorSet))
+		}
+	}
+}
+
+func TestProofWorkPartitioner_SpreadsWork(t *testing.T) {
+	p, err := NewProofWorkPartitioner("validator-1", testValidatorSet)
+	if err != nil {
+		t.Fatalf("NewProofWorkPartitioner failed: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		counts[p.Owner(fmt.Sprintf("0x%064x", i))]++
+	}
+	for _, v := range testValidatorSet {
+		if counts[v] == 0 {
+			t.Errorf("validator %s was never selected as owner", v)
+		}
+	}
+}
+
+func TestNewProofWorkPartitioner_Errors(t *testing.T) {
+	if _, err := NewProofWorkPartitioner("", testValidatorSet); err == nil {
+		t.Error("expected error for empty validator ID")
+	}
+	if _, err := NewProofWorkPartitioner("validator-1", nil); err == nil {
+		t.Error("expected error for empty validator set")
+	}
+	if _, err := NewProofWorkPartitioner("validator-9", testValidatorSet); err == nil {
+		t.Error("expected error for validator outside the set")
+	}
+}
+
+func TestSharedProofCache_PutGetExpiry(t *testing.T) {
+	cache := NewSharedProofCache(time.Minute)
+
+	cache.Put(&SharedProof{IntentID: "intent-1", GeneratedBy: "validator-1", GeneratedAt: time.Now()})
+	if _, ok := cache.Get("intent-1"); !ok {
+		t.Error("expected cached shared proof")
+	}
+	if _, ok := cache.Get("intent-2"); ok {
+		t.Error("unexpected shared proof for unknown intent")
+	}
+
+	cache.Put(&SharedProof{IntentID: "intent-old", GeneratedAt: time.Now().Add(-2 * time.Minute)})
+	if _, ok := cache.Get("intent-old"); ok {
+		t.Error("expired shared proof should not be returned")
+	}
+
+	// Entries without an intent ID are ignored
+	cache.Put(&SharedProof{})
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 cached entries, got %d", cache.Len())
+	}
+}
+
+// ed25519Signer signs with a raw Ed25519 key
+type ed25519Signer ed25519.PrivateKey
+
+func (k ed25519Signer) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(ed25519.PrivateKey(k), message), nil
+}
+
+func signedTestProof(t *testing.T, key ed25519.PrivateKey) (*SharedProof, SharedProofRequest) {
+	t.Helper()
+	shared := &SharedProof{
+		IntentID:        "intent-1",
+		TransactionHash: "0xabc",
+		GeneratedBy:     "validator-2",
+		GeneratedAt:     time.Now(),
+		CertenProof: &proof.CertenProof{
+			ProofID:         "proof-1",
+			TransactionHash: "0xabc",
+			VerificationStatus: &proof.VerificationStatusData{
+				OverallValid:    true,
+				ComponentStatus: map[string]bool{"lite_client": true, "bpt": true},
+			},
+		},
+	}
+	if err := shared.Sign(ed25519Signer(key)); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	return shared, SharedProofRequest{IntentID: "intent-1", TransactionHash: "0xabc", Owner: "validator-2"}
+}
+
+func TestVerifySharedProof(t *testing.T) {
+	pub, key, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	shared, req := signedTestProof(t, key)
+	if err := VerifySharedProof(shared, req, pub); err != nil {
+		t.Fatalf("signed proof from the owner rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey
+	}{
+		{"other intent", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			r.IntentID = "intent-2"
+			return pub
+		}},
+		{"not the owner", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			r.Owner = "validator-3"
+			return pub
+		}},
+		{"other transaction", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			r.TransactionHash = "0xdef"
+			return pub
+		}},
+		{"chained proof for other transaction", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			s.CertenProof.TransactionHash = "0xdef"
+			return pub
+		}},
+		{"wrong key", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			return otherPub
+		}},
+		{"no key", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			return nil
+		}},
+		{"unsigned", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			s.Signature = nil
+			return pub
+		}},
+		{"tampered proof", func(s *SharedProof, r *SharedProofRequest) ed25519.PublicKey {
+			s.CertenProof.ProofID = "proof-forged"
+			return pub
+		}},
+	}
+	for _, tt := range tests {
+		s, r := signedTestProof(t, key)
+		if err := VerifySharedProof(s, r, tt.mutate(s, &r)); err == nil {
+			t.Errorf("%s: expected rejection", tt.name)
+		}
+	}
+}
+
+func TestPeerSharedProofSource_VerifiesPeerResponses(t *testing.T) {
+	ownerPub, ownerKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, forgerKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	genuine, req := signedTestProof(t, ownerKey)
+	forged, _ := signedTestProof(t, forgerKey)
+
+	serve := func(shared *SharedProof) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(shared)
+		}))
+	}
+	forger := serve(forged)
+	defer forger.Close()
+	owner := serve(genuine)
+	defer owner.Close()
+
+	keys := map[string]ed25519.PublicKey{"validator-2": ownerPub}
+
+	// The forged response from the first peer is skipped in favour of the owner's
+	source := NewPeerSharedProofSource("validator-1", []string{forger.URL, owner.URL}, keys, time.Second, nil)
+	shared, err := source.FetchSharedProof(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FetchSharedProof failed: %v", err)
+	}
+	if shared == nil || shared.CertenProof.ProofID != "proof-1" {
+		t.Fatal("expected the owner's proof")
+	}
+
+	// Only forged responses: nothing is accepted
+	source = NewPeerSharedProofSource("validator-1", []string{forger.URL}, keys, time.Second, nil)
+	if shared, _ := source.FetchSharedProof(context.Background(), req); shared != nil {
+		t.Error("forged shared proof was accepted")
+	}
+}
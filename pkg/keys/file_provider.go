@@ -0,0 +1,104 @@
+// Copyright 2025 Certen Protocol
+//
+// File Key Provider - Ed25519 key held in a hex file on disk
+//
+// Never derive keys from the validator ID: a missing key file is filled with a new
+// random key, written with owner-only permissions.
+
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileKeyProvider signs with an Ed25519 private key held in memory
+type FileKeyProvider struct {
+	path string // Empty for keys not loaded from a file
+	key  ed25519.PrivateKey
+}
+
+var _ KeyProvider = (*FileKeyProvider)(nil)
+
+// FromPrivateKey creates a provider for a private key already in memory
+func FromPrivateKey(key ed25519.PrivateKey) (*FileKeyProvider, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid ed25519 key size: expected %d, got %d", ed25519.PrivateKeySize, len(key))
+	}
+	return &FileKeyProvider{key: key}, nil
+}
+
+// LoadOrGenerateFileKey loads the hex-encoded key at path, or generates and saves a new
+// random key there if the file does not exist
+func LoadOrGenerateFileKey(path string) (*FileKeyProvider, error) {
+	// Ensure directory exists
+	keyDir := filepath.Dir(path)
+	if err := os.MkdirAll(keyDir, 0700); err != nil {
+		return nil, fmt.Errorf("create key directory %s: %w", keyDir, err)
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// Generate new secure random key
+		log.Printf("🔑 Generating new Ed25519 key...")
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate ed25519 key: %w", err)
+		}
+
+		// Save to file with restrictive permissions (owner read/write only)
+		if err := os.WriteFile(path, []byte(hex.EncodeToString(key)), 0600); err != nil {
+			return nil, fmt.Errorf("save ed25519 key to %s: %w", path, err)
+		}
+		log.Printf("✅ Generated and saved new Ed25519 key: %s", path)
+		return &FileKeyProvider{path: path, key: key}, nil
+	}
+
+	// Load existing key
+	log.Printf("🔑 Loading existing Ed25519 key from %s...", path)
+	p, err := LoadFileKey(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("✅ Loaded existing Ed25519 key from %s", path)
+	return p, nil
+}
+
+// LoadFileKey loads the hex-encoded key at path; unlike LoadOrGenerateFileKey a missing
+// file is an error
+func LoadFileKey(path string) (*FileKeyProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ed25519 key from %s: %w", path, err)
+	}
+	keyBytes, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decode ed25519 key from %s: %w", path, err)
+	}
+	p, err := FromPrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("load ed25519 key from %s: %w", path, err)
+	}
+	p.path = path
+	return p, nil
+}
+
+// PublicKey returns the Ed25519 public key
+func (p *FileKeyProvider) PublicKey() ed25519.PublicKey {
+	return p.key.Public().(ed25519.PublicKey)
+}
+
+// Sign signs message with the private key
+func (p *FileKeyProvider) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(p.key, message), nil
+}
+
+// Path returns the key file, empty for keys not loaded from a file
+func (p *FileKeyProvider) Path() string {
+	return p.path
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Certen Protocol
+//
+// GCP KMS Key Provider - Ed25519 signing in Google Cloud KMS
+//
+// The key version must use the EC_SIGN_ED25519 algorithm, which signs the raw message
+// (pure EdDSA). Requests and responses carry CRC32C checksums as Cloud KMS recommends,
+// and every returned signature is verified against the public key before use, so a
+// corrupted or misrouted response is never attached to a transaction.
+
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"hash/crc32"
+	"time"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+)
+
+// gcpKMSEd25519Algorithm is the Cloud KMS algorithm of Ed25519 signing keys
+const gcpKMSEd25519Algorithm = "EC_SIGN_ED25519"
+
+// GCPKMSKeyProvider signs with an Ed25519 key version held in Google Cloud KMS
+type GCPKMSKeyProvider struct {
+	versions    *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService
+	keyName     string
+	publicKey   ed25519.PublicKey
+	signTimeout time.Duration
+}
+
+var _ KeyProvider = (*GCPKMSKeyProvider)(nil)
+
+// NewGCPKMSKeyProvider connects to Cloud KMS and reads the public key of keyName, a
+// crypto key version resource name. credentialsFile is a service account JSON file;
+// empty uses application default credentials.
+func NewGCPKMSKeyProvider(ctx context.Context, keyName, credentialsFile string, signTimeout time.Duration) (*GCPKMSKeyProvider, error) {
+	var opts []option.ClientOption
+	if credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+	return newGCPKMSKeyProvider(ctx, keyName, signTimeout, opts...)
+}
+
+func newGCPKMSKeyProvider(ctx context.Context, keyName string, signTimeout time.Duration, opts ...option.ClientOption) (*GCPKMSKeyProvider, error) {
+	if keyName == "" {
+		return nil, fmt.Errorf("GCP KMS key provider requires a crypto key version name")
+	}
+	if signTimeout <= 0 {
+		signTimeout = DefaultSignTimeout
+	}
+
+	service, err := cloudkms.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create Cloud KMS client: %w", err)
+	}
+	p := &GCPKMSKeyProvider{
+		versions:    cloudkms.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(service),
+		keyName:     keyName,
+		signTimeout: signTimeout,
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, signTimeout)
+	defer cancel()
+	pub, err := p.versions.GetPublicKey(keyName).Context(fetchCtx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("get public key of %s: %w", keyName, err)
+	}
+	if pub.Algorithm != gcpKMSEd25519Algorithm {
+		return nil, fmt.Errorf("key %s uses %s, expected %s", keyName, pub.Algorithm, gcpKMSEd25519Algorithm)
+	}
+	if pub.PemCrc32c != 0 && int64(crc32c([]byte(pub.Pem))) != pub.PemCrc32c {
+		return nil, fmt.Errorf("public key of %s failed its CRC32C check", keyName)
+	}
+	if p.publicKey, err = parseEd25519PublicKeyPEM(pub.Pem); err != nil {
+		return nil, fmt.Errorf("public key of %s: %w", keyName, err)
+	}
+	return p, nil
+}
+
+// PublicKey returns the Ed25519 public key of the KMS key version
+func (p *GCPKMSKeyProvider) PublicKey() ed25519.PublicKey {
+	return p.publicKey
+}
+
+// Sign asks Cloud KMS to sign message and verifies the returned signature
+func (p *GCPKMSKeyProvider) Sign(message []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.signTimeout)
+	defer cancel()
+
+	resp, err := p.versions.AsymmetricSign(p.keyName, &cloudkms.AsymmetricSignRequest{
+		Data:       base64.StdEncoding.EncodeToString(message),
+		DataCrc32c: int64(crc32c(message)),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("KMS sign with %s: %w", p.keyName, err)
+	}
+	if !resp.VerifiedDataCrc32c {
+		return nil, fmt.Errorf("KMS sign with %s: request corrupted in transit", p.keyName)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("KMS sign with %s: decode signature: %w", p.keyName, err)
+	}
+	if int64(crc32c(signature)) != resp.SignatureCrc32c {
+		return nil, fmt.Errorf("KMS sign with %s: response corrupted in transit", p.keyName)
+	}
+	if !ed25519.Verify(p.publicKey, message, signature) {
+		return nil, fmt.Errorf("KMS sign with %s: signature does not verify against the key's public key", p.keyName)
+	}
+	return signature, nil
+}
+
+// KeyName returns the crypto key version resource name
+func (p *GCPKMSKeyProvider) KeyName() string {
+	return p.keyName
+}
+
+// parseEd25519PublicKeyPEM decodes a PEM-encoded PKIX Ed25519 public key
+func parseEd25519PublicKeyPEM(data string) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ed25519 public key, got %T", key)
+	}
+	return pub, nil
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+func crc32c(data []byte) uint32 {
+	return crc32.Checksum(data, crc32cTable)
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Certen Protocol
+//
+// Key Provider - Ed25519 signing without holding raw private key bytes
+//
+// The BFT validator, the attestation service and the Accumulate submitter sign with the
+// validator's Ed25519 key through a KeyProvider instead of holding the private key:
+//   - file: the key is a hex file on disk (0600), generated on first start (default)
+//   - gcp-kms: the key is an EC_SIGN_ED25519 key version in Google Cloud KMS (software
+//     or HSM protection level); every message is sent to KMS to be signed and the
+//     private key never leaves it
+//
+// BLS keys are not covered: no cloud KMS supports BLS12-381, so the BLS key stays file-backed.
+
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+)
+
+// KeyProvider signs messages with an Ed25519 key it need not expose
+type KeyProvider interface {
+	// PublicKey returns the Ed25519 public key
+	PublicKey() ed25519.PublicKey
+
+	// Sign returns the Ed25519 signature of message (pure Ed25519, not prehashed)
+	Sign(message []byte) ([]byte, error)
+}
+
+// Key provider backends
+const (
+	BackendFile   = "file"
+	BackendGCPKMS = "gcp-kms"
+)
+
+// DefaultSignTimeout bounds a single remote signing request
+const DefaultSignTimeout = 10 * time.Second
+
+// Config selects and configures a key provider
+type Config struct {
+	Backend string // BackendFile (default) or BackendGCPKMS
+
+	// File backend
+	FilePath string // Hex-encoded key, generated if missing
+
+	// GCP KMS backend
+	KMSKeyName         string        // projects/P/locations/L/keyRings/R/cryptoKeys/K/cryptoKeyVersions/V
+	KMSCredentialsFile string        // Service account JSON ("" = application default credentials)
+	SignTimeout        time.Duration // Per signing request (0 = DefaultSignTimeout)
+}
+
+// New creates the key provider selected by cfg
+func New(ctx context.Context, cfg Config) (KeyProvider, error) {
+	switch cfg.Backend {
+	case "", BackendFile:
+		if cfg.FilePath == "" {
+			return nil, fmt.Errorf("file key provider requires a key path")
+		}
+		return LoadOrGenerateFileKey(cfg.FilePath)
+	case BackendGCPKMS:
+		return NewGCPKMSKeyProvider(ctx, cfg.KMSKeyName, cfg.KMSCredentialsFile, cfg.SignTimeout)
+	default:
+		return nil, fmt.Errorf("unknown key backend %q: must be %s or %s", cfg.Backend, BackendFile, BackendGCPKMS)
+	}
+}
+
+// PrivateKey returns the raw private key held by a file-backed provider. ok is false for
+// providers whose key cannot leave the KMS or HSM.
+func PrivateKey(p KeyProvider) (ed25519.PrivateKey, bool) {
+	if fp, ok := p.(*FileKeyProvider); ok {
+		return fp.key, true
+	}
+	return nil, false
+}
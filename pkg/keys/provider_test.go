@@ -0,0 +1,169 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Key Providers
+// Tests the file-backed provider, backend selection and the Cloud KMS provider against a fake KMS
+
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+)
+
+func TestLoadOrGenerateFileKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "validator.key")
+
+	generated, err := LoadOrGenerateFileKey(path)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("key file not written: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+
+	loaded, err := LoadOrGenerateFileKey(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !loaded.PublicKey().Equal(generated.PublicKey()) {
+		t.Error("reloaded key differs from the generated key")
+	}
+
+	msg := []byte("certen")
+	sig, err := loaded.Sign(msg)
+	if err != nil || !ed25519.Verify(generated.PublicKey(), msg, sig) {
+		t.Errorf("signature does not verify: %v", err)
+	}
+	if key, ok := PrivateKey(loaded); !ok || len(key) != ed25519.PrivateKeySize {
+		t.Error("expected the file provider to expose its private key")
+	}
+}
+
+func TestLoadOrGenerateFileKey_RejectsBadKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "validator.key")
+	if err := os.WriteFile(path, []byte("abcd"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOrGenerateFileKey(path); err == nil || !strings.Contains(err.Error(), "key size") {
+		t.Errorf("expected a key size error, got %v", err)
+	}
+}
+
+func TestNew_UnknownBackend(t *testing.T) {
+	if _, err := New(context.Background(), Config{Backend: "aws-kms"}); err == nil {
+		t.Error("expected an error for an unknown backend")
+	}
+}
+
+// fakeKMS serves GetPublicKey and AsymmetricSign for one Ed25519 key version
+type fakeKMS struct {
+	key       ed25519.PrivateKey
+	algorithm string
+	corrupt   bool // Return a signature that does not verify
+	signs     int
+}
+
+func (f *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/publicKey"):
+		der, _ := x509.MarshalPKIXPublicKey(f.key.Public())
+		pemData := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		json.NewEncoder(w).Encode(&cloudkms.PublicKey{
+			Algorithm: f.algorithm,
+			Pem:       pemData,
+			PemCrc32c: int64(crc32c([]byte(pemData))),
+		})
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":asymmetricSign"):
+		f.signs++
+		var req cloudkms.AsymmetricSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := base64.StdEncoding.DecodeString(req.Data)
+		sig := ed25519.Sign(f.key, data)
+		if f.corrupt {
+			sig[0] ^= 0xff
+		}
+		json.NewEncoder(w).Encode(&cloudkms.AsymmetricSignResponse{
+			Signature:          base64.StdEncoding.EncodeToString(sig),
+			SignatureCrc32c:    int64(crc32c(sig)),
+			VerifiedDataCrc32c: int64(crc32c(data)) == req.DataCrc32c,
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+const testKeyName = "projects/p/locations/global/keyRings/r/cryptoKeys/validator/cryptoKeyVersions/1"
+
+func newTestKMSProvider(t *testing.T, kms *fakeKMS) (*GCPKMSKeyProvider, error) {
+	t.Helper()
+	srv := httptest.NewServer(kms)
+	t.Cleanup(srv.Close)
+	return newGCPKMSKeyProvider(context.Background(), testKeyName, time.Second,
+		option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication())
+}
+
+func TestGCPKMSKeyProvider_Sign(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	kms := &fakeKMS{key: key, algorithm: gcpKMSEd25519Algorithm}
+
+	p, err := newTestKMSProvider(t, kms)
+	if err != nil {
+		t.Fatalf("create provider: %v", err)
+	}
+	if !p.PublicKey().Equal(key.Public()) {
+		t.Fatal("provider public key differs from the KMS key")
+	}
+	if _, ok := PrivateKey(p); ok {
+		t.Error("a KMS provider must not expose a private key")
+	}
+
+	msg := []byte("certen attestation")
+	sig, err := p.Sign(msg)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if !ed25519.Verify(key.Public().(ed25519.PublicKey), msg, sig) || kms.signs != 1 {
+		t.Errorf("expected one verifying KMS signature, got %d signs", kms.signs)
+	}
+}
+
+func TestGCPKMSKeyProvider_RejectsWrongAlgorithm(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	_, err := newTestKMSProvider(t, &fakeKMS{key: key, algorithm: "EC_SIGN_P256_SHA256"})
+	if err == nil || !strings.Contains(err.Error(), gcpKMSEd25519Algorithm) {
+		t.Errorf("expected an algorithm error, got %v", err)
+	}
+}
+
+func TestGCPKMSKeyProvider_RejectsBadSignature(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	p, err := newTestKMSProvider(t, &fakeKMS{key: key, algorithm: gcpKMSEd25519Algorithm, corrupt: true})
+	if err != nil {
+		t.Fatalf("create provider: %v", err)
+	}
+	if _, err := p.Sign([]byte("certen")); err == nil || !strings.Contains(err.Error(), "does not verify") {
+		t.Errorf("expected a verification error, got %v", err)
+	}
+}
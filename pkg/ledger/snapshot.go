@@ -0,0 +1,287 @@
+// Copyright 2025 Certen Protocol
+//
+// Ledger Snapshots - Verifiable exports of the system and anchor ledgers
+//
+// A snapshot lists the ledger entries together with a Merkle root over them, so a
+// consumer can archive it and later prove it has not been altered:
+//   - leaf  = SHA256(key || 0x00 || data), where data is the entry's JSON as served
+//   - root  = pkg/merkle tree over the leaves (odd nodes are paired with themselves)
+//   - previousRoot = root over the first previousSize leaves; for the append-only system
+//     ledger this lets the holder of an earlier snapshot confirm it is a prefix of this one
+//
+// previousRoot is not a compact (RFC 6962 style) consistency proof: it is recomputed from
+// the entries carried in the snapshot itself, so it is only offered for snapshots that
+// start at height 1, where the first previousSize entries are the earlier snapshot.
+
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/merkle"
+)
+
+// MaxSnapshotEntries bounds the number of entries in a single snapshot
+const MaxSnapshotEntries = 10000
+
+// SnapshotAlgorithm identifies the leaf and tree hashing used by snapshots
+const SnapshotAlgorithm = "sha256(key||0x00||data)/sha256-merkle-dup-odd"
+
+// ErrSnapshotTooLarge is returned when a snapshot range exceeds MaxSnapshotEntries
+var ErrSnapshotTooLarge = errors.New("snapshot range too large")
+
+// ErrPreviousSizeRange is returned when previous_size is requested for a snapshot that
+// does not start at height 1
+var ErrPreviousSizeRange = errors.New("previous size requires a snapshot starting at height 1")
+
+// SnapshotEntry is a single ledger entry in a snapshot
+type SnapshotEntry struct {
+	Key      string          `json:"key"`      // e.g. "block:42", "meta", "target:acc://dn.acme"
+	Data     json.RawMessage `json:"data"`     // Entry JSON
+	LeafHash string          `json:"leafHash"` // Hex SHA256(key || 0x00 || data)
+}
+
+// SnapshotConsistencyProof carries what a consumer needs to check a snapshot's integrity
+type SnapshotConsistencyProof struct {
+	Algorithm    string `json:"algorithm"`
+	TreeSize     int    `json:"treeSize"`
+	PreviousSize int    `json:"previousSize,omitempty"` // Size of an earlier snapshot being checked against
+	PreviousRoot string `json:"previousRoot,omitempty"` // Root over the first PreviousSize entries (not a compact proof)
+	StateHash    string `json:"stateHash"`              // SHA256 of the ledger state JSON at snapshot time
+}
+
+// LedgerSnapshot is a verifiable export of a ledger
+type LedgerSnapshot struct {
+	Type             string                   `json:"type"` // "systemLedgerSnapshot" or "anchorLedgerSnapshot"
+	ChainID          string                   `json:"chainId"`
+	Height           uint64                   `json:"height"` // System: latest block height; anchor: last sequence number
+	FromHeight       uint64                   `json:"fromHeight,omitempty"`
+	ToHeight         uint64                   `json:"toHeight,omitempty"`
+	State            json.RawMessage          `json:"state"` // Ledger state as returned by the query endpoint
+	Entries          []SnapshotEntry          `json:"entries"`
+	RootHash         string                   `json:"rootHash"`
+	ConsistencyProof SnapshotConsistencyProof `json:"consistencyProof"`
+	CreatedAt        time.Time                `json:"createdAt"`
+}
+
+// snapshotLeafHash computes the leaf hash for a snapshot entry
+func snapshotLeafHash(key string, data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(key))
+	h.Write([]byte{0x00})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// newSnapshotEntry marshals a value into a snapshot entry
+func newSnapshotEntry(key string, v interface{}) (SnapshotEntry, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return SnapshotEntry{}, fmt.Errorf("failed to marshal snapshot entry %s: %w", key, err)
+	}
+	return SnapshotEntry{
+		Key:      key,
+		Data:     data,
+		LeafHash: hex.EncodeToString(snapshotLeafHash(key, data)),
+	}, nil
+}
+
+// snapshotRoot computes the Merkle root over entry leaf hashes
+func snapshotRoot(entries []SnapshotEntry) (string, error) {
+	if len(entries) == 0 {
+		return "", merkle.ErrEmptyTree
+	}
+	leaves := make([][]byte, len(entries))
+	for i, e := range entries {
+		leaves[i] = snapshotLeafHash(e.Key, e.Data)
+	}
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		return "", err
+	}
+	return tree.RootHex(), nil
+}
+
+// finalizeSnapshot fills in the root, state and consistency proof of a snapshot
+func finalizeSnapshot(snap *LedgerSnapshot, state interface{}, previousSize int) error {
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ledger state: %w", err)
+	}
+	stateHash := sha256.Sum256(stateJSON)
+
+	root, err := snapshotRoot(snap.Entries)
+	if err != nil {
+		return fmt.Errorf("failed to compute snapshot root: %w", err)
+	}
+
+	snap.State = stateJSON
+	snap.RootHash = root
+	snap.ConsistencyProof = SnapshotConsistencyProof{
+		Algorithm: SnapshotAlgorithm,
+		TreeSize:  len(snap.Entries),
+		StateHash: hex.EncodeToString(stateHash[:]),
+	}
+
+	if previousSize > 0 {
+		if previousSize > len(snap.Entries) {
+			return fmt.Errorf("previous size %d exceeds snapshot size %d", previousSize, len(snap.Entries))
+		}
+		prevRoot, err := snapshotRoot(snap.Entries[:previousSize])
+		if err != nil {
+			return fmt.Errorf("failed to compute previous root: %w", err)
+		}
+		snap.ConsistencyProof.PreviousSize = previousSize
+		snap.ConsistencyProof.PreviousRoot = prevRoot
+	}
+
+	snap.CreatedAt = time.Now().UTC()
+	return nil
+}
+
+// GetSystemLedgerSnapshot exports system ledger blocks in [fromHeight, toHeight].
+// toHeight 0 means the latest height; fromHeight 0 means height 1. previousSize, if set,
+// adds the root over the first previousSize entries for comparison with an earlier snapshot;
+// it is only accepted when the snapshot starts at height 1.
+func (s *LedgerStore) GetSystemLedgerSnapshot(chainID string, fromHeight, toHeight uint64, previousSize int) (*LedgerSnapshot, error) {
+	state, err := s.GetSystemLedgerLatest(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	if toHeight == 0 || toHeight > state.Data.Index {
+		toHeight = state.Data.Index
+	}
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("invalid snapshot range: from %d > to %d", fromHeight, toHeight)
+	}
+	if previousSize > 0 && fromHeight != 1 {
+		return nil, fmt.Errorf("%w: from height is %d", ErrPreviousSizeRange, fromHeight)
+	}
+	if toHeight-fromHeight+1 > MaxSnapshotEntries {
+		return nil, fmt.Errorf("%w: %d blocks requested, maximum is %d", ErrSnapshotTooLarge, toHeight-fromHeight+1, MaxSnapshotEntries)
+	}
+
+	snap := &LedgerSnapshot{
+		Type:       "systemLedgerSnapshot",
+		ChainID:    chainID,
+		Height:     state.Data.Index,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+
+	for height := fromHeight; height <= toHeight; height++ {
+		b, err := s.kv.Get(systemBlockKey(height))
+		if err != nil || len(b) == 0 {
+			continue // Heights without a committed block are not part of the ledger
+		}
+		var blockMeta SystemLedgerBlockMeta
+		if err := json.Unmarshal(b, &blockMeta); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SystemLedgerBlockMeta for height %d: %w", height, err)
+		}
+		entry, err := newSnapshotEntry(fmt.Sprintf("block:%d", height), &blockMeta)
+		if err != nil {
+			return nil, err
+		}
+		snap.Entries = append(snap.Entries, entry)
+	}
+
+	if err := finalizeSnapshot(snap, state, previousSize); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// GetAnchorLedgerSnapshot exports the anchor ledger metadata and per-target state
+func (s *LedgerStore) GetAnchorLedgerSnapshot(chainID string) (*LedgerSnapshot, error) {
+	state, err := s.GetAnchorLedger(chainID)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := s.loadAnchorMeta()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load anchor meta: %w", err)
+	}
+
+	snap := &LedgerSnapshot{
+		Type:    "anchorLedgerSnapshot",
+		ChainID: chainID,
+		Height:  meta.LastSequenceNumber,
+	}
+
+	entry, err := newSnapshotEntry("meta", meta)
+	if err != nil {
+		return nil, err
+	}
+	snap.Entries = append(snap.Entries, entry)
+
+	for _, url := range AnchorTargets {
+		tgt, err := s.loadAnchorTarget(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load anchor target %s: %w", url, err)
+		}
+		entry, err := newSnapshotEntry("target:"+url, tgt)
+		if err != nil {
+			return nil, err
+		}
+		snap.Entries = append(snap.Entries, entry)
+	}
+
+	if err := finalizeSnapshot(snap, state, 0); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// VerifyLedgerSnapshot recomputes a snapshot's leaf hashes, root, previous root and
+// state hash, returning an error describing the first mismatch
+func VerifyLedgerSnapshot(snap *LedgerSnapshot) error {
+	if snap == nil || len(snap.Entries) == 0 {
+		return errors.New("snapshot has no entries")
+	}
+	if snap.ConsistencyProof.TreeSize != len(snap.Entries) {
+		return fmt.Errorf("tree size %d does not match %d entries", snap.ConsistencyProof.TreeSize, len(snap.Entries))
+	}
+
+	for i, e := range snap.Entries {
+		if hex.EncodeToString(snapshotLeafHash(e.Key, e.Data)) != e.LeafHash {
+			return fmt.Errorf("leaf hash mismatch for entry %d (%s)", i, e.Key)
+		}
+	}
+
+	root, err := snapshotRoot(snap.Entries)
+	if err != nil {
+		return err
+	}
+	if root != snap.RootHash {
+		return fmt.Errorf("root mismatch: computed %s, snapshot has %s", root, snap.RootHash)
+	}
+
+	if size := snap.ConsistencyProof.PreviousSize; size > 0 {
+		if size > len(snap.Entries) {
+			return fmt.Errorf("previous size %d exceeds snapshot size %d", size, len(snap.Entries))
+		}
+		prevRoot, err := snapshotRoot(snap.Entries[:size])
+		if err != nil {
+			return err
+		}
+		if prevRoot != snap.ConsistencyProof.PreviousRoot {
+			return fmt.Errorf("previous root mismatch: computed %s, snapshot has %s", prevRoot, snap.ConsistencyProof.PreviousRoot)
+		}
+	}
+
+	stateHash := sha256.Sum256(snap.State)
+	if hex.EncodeToString(stateHash[:]) != snap.ConsistencyProof.StateHash {
+		return errors.New("state hash mismatch")
+	}
+
+	return nil
+}
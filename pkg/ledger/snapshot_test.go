@@ -0,0 +1,164 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Ledger Snapshots
+// Tests snapshot export, integrity verification, tamper detection and prefix consistency
+
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// memKV is an in-memory KV for tests
+type memKV map[string][]byte
+
+func (m memKV) Get(key []byte) ([]byte, error) { return m[string(key)], nil }
+func (m memKV) Set(key, value []byte) error {
+	m[string(key)] = append([]byte(nil), value...)
+	return nil
+}
+
+func newTestLedgerStore(t *testing.T, blocks int) *LedgerStore {
+	t.Helper()
+	store := NewLedgerStore(memKV{})
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for h := 1; h <= blocks; h++ {
+		if err := store.UpdateSystemLedgerOnCommit(uint64(h), "hash", base.Add(time.Duration(h)*time.Second), nil, "1.0.0", nil); err != nil {
+			t.Fatalf("UpdateSystemLedgerOnCommit(%d) failed: %v", h, err)
+		}
+	}
+	return store
+}
+
+func TestSystemLedgerSnapshot_Verifies(t *testing.T) {
+	store := newTestLedgerStore(t, 5)
+
+	snap, err := store.GetSystemLedgerSnapshot("certen-test", 0, 0, 0)
+	if err != nil {
+		t.Fatalf("GetSystemLedgerSnapshot failed: %v", err)
+	}
+	if len(snap.Entries) != 5 || snap.Height != 5 {
+		t.Fatalf("expected 5 entries at height 5, got %d at height %d", len(snap.Entries), snap.Height)
+	}
+	if err := VerifyLedgerSnapshot(snap); err != nil {
+		t.Fatalf("VerifyLedgerSnapshot failed: %v", err)
+	}
+
+	// Round trip through JSON as an archiving consumer would
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var archived LedgerSnapshot
+	if err := json.Unmarshal(data, &archived); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if err := VerifyLedgerSnapshot(&archived); err != nil {
+		t.Fatalf("VerifyLedgerSnapshot after round trip failed: %v", err)
+	}
+}
+
+func TestSystemLedgerSnapshot_DetectsTampering(t *testing.T) {
+	store := newTestLedgerStore(t, 4)
+	snap, err := store.GetSystemLedgerSnapshot("certen-test", 0, 0, 0)
+	if err != nil {
+		t.Fatalf("GetSystemLedgerSnapshot failed: %v", err)
+	}
+
+	snap.Entries[2].Data = json.RawMessage(`{"height":3,"hash":"forged","time":"2025-06-01T00:00:03Z"}`)
+	if err := VerifyLedgerSnapshot(snap); err == nil {
+		t.Error("expected tampered entry data to fail verification")
+	}
+
+	// Recomputing the leaf hash is not enough - the root no longer matches
+	fresh, _ := newSnapshotEntry(snap.Entries[2].Key, snap.Entries[2].Data)
+	snap.Entries[2].LeafHash = fresh.LeafHash
+	if err := VerifyLedgerSnapshot(snap); err == nil {
+		t.Error("expected tampered entry with recomputed leaf hash to fail root verification")
+	}
+}
+
+func TestSystemLedgerSnapshot_PrefixConsistency(t *testing.T) {
+	store := newTestLedgerStore(t, 3)
+	earlier, err := store.GetSystemLedgerSnapshot("certen-test", 0, 0, 0)
+	if err != nil {
+		t.Fatalf("GetSystemLedgerSnapshot failed: %v", err)
+	}
+
+	// The ledger grows; the new snapshot's previous root must match the archived root
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for h := 4; h <= 7; h++ {
+		if err := store.UpdateSystemLedgerOnCommit(uint64(h), "hash", base.Add(time.Duration(h)*time.Second), nil, "1.0.0", nil); err != nil {
+			t.Fatalf("UpdateSystemLedgerOnCommit(%d) failed: %v", h, err)
+		}
+	}
+
+	later, err := store.GetSystemLedgerSnapshot("certen-test", 0, 0, len(earlier.Entries))
+	if err != nil {
+		t.Fatalf("GetSystemLedgerSnapshot failed: %v", err)
+	}
+	if err := VerifyLedgerSnapshot(later); err != nil {
+		t.Fatalf("VerifyLedgerSnapshot failed: %v", err)
+	}
+	if later.ConsistencyProof.PreviousRoot != earlier.RootHash {
+		t.Errorf("previous root %s does not match archived root %s", later.ConsistencyProof.PreviousRoot, earlier.RootHash)
+	}
+
+	if _, err := store.GetSystemLedgerSnapshot("certen-test", 0, 0, 100); err == nil {
+		t.Error("expected error for previous size larger than the snapshot")
+	}
+
+	// A snapshot that does not start at height 1 cannot vouch for an earlier prefix
+	if _, err := store.GetSystemLedgerSnapshot("certen-test", 2, 0, 1); !errors.Is(err, ErrPreviousSizeRange) {
+		t.Errorf("expected ErrPreviousSizeRange, got %v", err)
+	}
+}
+
+func TestSystemLedgerSnapshot_Errors(t *testing.T) {
+	empty := NewLedgerStore(memKV{})
+	if _, err := empty.GetSystemLedgerSnapshot("certen-test", 0, 0, 0); !errors.Is(err, ErrMetaNotFound) {
+		t.Errorf("expected ErrMetaNotFound for empty ledger, got %v", err)
+	}
+
+	store := newTestLedgerStore(t, 3)
+	if _, err := store.GetSystemLedgerSnapshot("certen-test", 3, 2, 0); err == nil {
+		t.Error("expected error for inverted range")
+	}
+	if _, err := store.GetSystemLedgerSnapshot("certen-test", 1, MaxSnapshotEntries+1, 0); err != nil {
+		// to_height is clamped to the latest height, so a large upper bound is fine
+		t.Errorf("expected clamped range to succeed, got %v", err)
+	}
+}
+
+func TestAnchorLedgerSnapshot_Verifies(t *testing.T) {
+	store := NewLedgerStore(memKV{})
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := store.MarkAnchorProduced(10, "acc://dn.acme", "tx-1", now, 0, time.Time{}); err != nil {
+		t.Fatalf("MarkAnchorProduced failed: %v", err)
+	}
+	if err := store.MarkAnchorDelivered("acc://dn.acme", "tx-1", now); err != nil {
+		t.Fatalf("MarkAnchorDelivered failed: %v", err)
+	}
+
+	snap, err := store.GetAnchorLedgerSnapshot("certen-test")
+	if err != nil {
+		t.Fatalf("GetAnchorLedgerSnapshot failed: %v", err)
+	}
+	if len(snap.Entries) != 1+len(AnchorTargets) {
+		t.Fatalf("expected %d entries, got %d", 1+len(AnchorTargets), len(snap.Entries))
+	}
+	if snap.Height != 1 {
+		t.Errorf("expected sequence number 1, got %d", snap.Height)
+	}
+	if err := VerifyLedgerSnapshot(snap); err != nil {
+		t.Fatalf("VerifyLedgerSnapshot failed: %v", err)
+	}
+
+	snap.State = json.RawMessage(`{"type":"anchorLedger"}`)
+	if err := VerifyLedgerSnapshot(snap); err == nil {
+		t.Error("expected altered state to fail verification")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Certen Protocol
+//
+// Ledger State Export - Raw ledger contents carried by state sync snapshots
+//
+// A node that joins or falls behind restores the ledger from a peer's snapshot instead of
+// replaying every block. The export carries the system ledger (meta, latest block and every
+// committed block) and the anchor ledger (meta and per-target state) as raw KV pairs, so an
+// import reproduces the store byte for byte. Node-local state - intent discovery cursors
+// and the persisted ABCI state - is not exported; the restoring application writes its own.
+
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// StateEntry is one raw KV pair of exported ledger state
+type StateEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// exportedKeyPrefixes are the key spaces ExportState writes and ImportState accepts
+var exportedKeyPrefixes = [][]byte{
+	[]byte("sysledger:"),
+	[]byte("anchorledger:"),
+}
+
+// ExportState returns the system and anchor ledger contents as raw KV pairs.
+// An empty store exports no entries.
+func (s *LedgerStore) ExportState() ([]StateEntry, error) {
+	var entries []StateEntry
+	add := func(key []byte) error {
+		value, err := s.kv.Get(key)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		if len(value) > 0 {
+			entries = append(entries, StateEntry{Key: append([]byte(nil), key...), Value: value})
+		}
+		return nil
+	}
+
+	gm, err := s.loadSystemLedgerMeta()
+	if err != nil && !errors.Is(err, ErrMetaNotFound) {
+		return nil, err
+	}
+	if gm != nil {
+		if err := add(keySysMeta); err != nil {
+			return nil, err
+		}
+		if err := add(keySysLatestBlock); err != nil {
+			return nil, err
+		}
+		for height := uint64(1); height <= gm.LatestHeight; height++ {
+			if err := add(systemBlockKey(height)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := add(keyAnchorMeta); err != nil {
+		return nil, err
+	}
+	for _, url := range AnchorTargets {
+		if err := add(anchorTargetKey(url)); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// ImportState writes entries produced by ExportState. Entries outside the ledger key
+// spaces are rejected before anything is written.
+func (s *LedgerStore) ImportState(entries []StateEntry) error {
+	for _, e := range entries {
+		if !isExportedKey(e.Key) {
+			return fmt.Errorf("unexpected ledger key %q in state export", e.Key)
+		}
+		if len(e.Value) > 0 && !json.Valid(e.Value) {
+			return fmt.Errorf("invalid JSON value for ledger key %q", e.Key)
+		}
+	}
+	for _, e := range entries {
+		if err := s.kv.Set(e.Key, e.Value); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.Key, err)
+		}
+	}
+	return nil
+}
+
+// isExportedKey reports whether key belongs to an exported ledger key space
+func isExportedKey(key []byte) bool {
+	for _, prefix := range exportedKeyPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Ledger State Export
+// Tests that an import reproduces the exported ledgers and rejects foreign keys
+
+package ledger
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExportImportState_RoundTrip(t *testing.T) {
+	source := newTestLedgerStore(t, 3)
+	produced := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := source.MarkAnchorProduced(3, AnchorTargets[0], "txid-1", produced, 0, time.Time{}); err != nil {
+		t.Fatalf("MarkAnchorProduced failed: %v", err)
+	}
+	if err := source.SaveIntentLastBlock(42); err != nil {
+		t.Fatalf("SaveIntentLastBlock failed: %v", err)
+	}
+
+	entries, err := source.ExportState()
+	if err != nil {
+		t.Fatalf("ExportState failed: %v", err)
+	}
+
+	restored := NewLedgerStore(memKV{})
+	if err := restored.ImportState(entries); err != nil {
+		t.Fatalf("ImportState failed: %v", err)
+	}
+
+	want, _ := source.GetSystemLedgerSnapshot("certen-test", 0, 0, 0)
+	got, err := restored.GetSystemLedgerSnapshot("certen-test", 0, 0, 0)
+	if err != nil {
+		t.Fatalf("restored system ledger unreadable: %v", err)
+	}
+	if got.RootHash != want.RootHash || got.Height != 3 {
+		t.Errorf("restored system ledger differs: root %s height %d, want root %s height 3", got.RootHash, got.Height, want.RootHash)
+	}
+
+	wantAnchors, _ := source.GetAnchorLedger("certen-test")
+	gotAnchors, err := restored.GetAnchorLedger("certen-test")
+	if err != nil {
+		t.Fatalf("restored anchor ledger unreadable: %v", err)
+	}
+	if !reflect.DeepEqual(gotAnchors, wantAnchors) {
+		t.Errorf("restored anchor ledger differs:\n got %+v\nwant %+v", gotAnchors, wantAnchors)
+	}
+
+	// Intent discovery cursors are node-local and not exported
+	if height, _ := restored.LoadIntentLastBlock(); height != 0 {
+		t.Errorf("expected intent state not to be exported, got last block %d", height)
+	}
+}
+
+func TestImportState_RejectsForeignKeys(t *testing.T) {
+	kv := memKV{}
+	store := NewLedgerStore(kv)
+	err := store.ImportState([]StateEntry{
+		{Key: []byte("sysledger:meta"), Value: []byte(`{"latestHeight":1}`)},
+		{Key: []byte("abci:state"), Value: []byte(`{"lastBlockHeight":99}`)},
+	})
+	if err == nil {
+		t.Fatal("expected a key outside the ledger key spaces to be rejected")
+	}
+	if len(kv) != 0 {
+		t.Errorf("expected nothing to be written on rejection, got %d keys", len(kv))
+	}
+}
@@ -45,6 +45,8 @@ var (
 
 	// Intent discovery state keys
 	keyIntentLastBlock = []byte("intent:last_block")          // -> uint64 (last processed block height)
+	keyIntentDedupSet  = []byte("intent:dedup_set")           // -> JSON (processed intents within retention)
+	keyIntentCursors   = []byte("intent:partition_cursors")   // -> JSON map partition -> last processed block height
 
 	// ABCI state keys (for CometBFT state recovery)
 	keyABCIState = []byte("abci:state")                       // -> ABCIState (height + appHash)
@@ -367,6 +369,60 @@ func (s *LedgerStore) LoadIntentLastBlock() (uint64, error) {
 	return binary.BigEndian.Uint64(b), nil
 }
 
+// SaveIntentPartitionLastBlocks persists the last processed block height of each partition
+// for intent discovery. Partitions advance independently, so each resumes from its own height.
+func (s *LedgerStore) SaveIntentPartitionLastBlocks(heights map[string]uint64) error {
+	b, err := json.Marshal(heights)
+	if err != nil {
+		return fmt.Errorf("failed to marshal intent partition cursors: %w", err)
+	}
+	return s.kv.Set(keyIntentCursors, b)
+}
+
+// LoadIntentPartitionLastBlocks loads the last processed block height of each partition.
+//
+// MIGRATION: state written before per-partition cursors has only the single height saved by
+// SaveIntentLastBlock. When no cursors exist yet, that height becomes the cursor of every
+// partition in partitions and the migrated cursors are persisted. Returns an empty map if
+// nothing has been persisted yet.
+func (s *LedgerStore) LoadIntentPartitionLastBlocks(partitions []string) (map[string]uint64, error) {
+	b, err := s.kv.Get(keyIntentCursors)
+	if err == nil && len(b) > 0 {
+		heights := make(map[string]uint64)
+		if err := json.Unmarshal(b, &heights); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal intent partition cursors: %w", err)
+		}
+		return heights, nil
+	}
+
+	legacy, err := s.LoadIntentLastBlock()
+	if err != nil {
+		return nil, err
+	}
+	heights := make(map[string]uint64)
+	if legacy == 0 || len(partitions) == 0 {
+		return heights, nil
+	}
+	for _, partition := range partitions {
+		heights[partition] = legacy
+	}
+	if err := s.SaveIntentPartitionLastBlocks(heights); err != nil {
+		return nil, fmt.Errorf("failed to migrate intent last block: %w", err)
+	}
+	return heights, nil
+}
+
+// SaveIntentDedupSet persists the intent discovery dedup set (opaque JSON)
+func (s *LedgerStore) SaveIntentDedupSet(data []byte) error {
+	return s.kv.Set(keyIntentDedupSet, data)
+}
+
+// LoadIntentDedupSet loads the intent discovery dedup set
+// Returns nil if no set has been persisted yet
+func (s *LedgerStore) LoadIntentDedupSet() ([]byte, error) {
+	return s.kv.Get(keyIntentDedupSet)
+}
+
 // ====== ABCI State Persistence for CometBFT Recovery ======
 
 // SaveABCIState persists the ABCI application state for CometBFT recovery.
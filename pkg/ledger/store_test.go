@@ -0,0 +1,58 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Ledger Store
+// Tests per-partition intent discovery cursors and migration of the legacy single height
+
+package ledger
+
+import "testing"
+
+func TestIntentPartitionLastBlocks_RoundTrip(t *testing.T) {
+	store := NewLedgerStore(memKV{})
+
+	heights, err := store.LoadIntentPartitionLastBlocks([]string{"acc://dn.acme/ledger"})
+	if err != nil || len(heights) != 0 {
+		t.Fatalf("expected no cursors on a fresh store, got %v (err %v)", heights, err)
+	}
+
+	saved := map[string]uint64{"acc://dn.acme/ledger": 950000, "acc://BVN0.acme/ledger": 41200}
+	if err := store.SaveIntentPartitionLastBlocks(saved); err != nil {
+		t.Fatalf("SaveIntentPartitionLastBlocks failed: %v", err)
+	}
+	heights, err = store.LoadIntentPartitionLastBlocks(nil)
+	if err != nil || len(heights) != 2 || heights["acc://BVN0.acme/ledger"] != 41200 {
+		t.Errorf("unexpected cursors %v (err %v)", heights, err)
+	}
+}
+
+func TestIntentPartitionLastBlocks_MigratesLegacyHeight(t *testing.T) {
+	kv := memKV{}
+	store := NewLedgerStore(kv)
+	if err := store.SaveIntentLastBlock(948000); err != nil {
+		t.Fatalf("SaveIntentLastBlock failed: %v", err)
+	}
+
+	partitions := []string{"acc://dn.acme/ledger", "acc://BVN0.acme/ledger"}
+	heights, err := store.LoadIntentPartitionLastBlocks(partitions)
+	if err != nil {
+		t.Fatalf("LoadIntentPartitionLastBlocks failed: %v", err)
+	}
+	for _, partition := range partitions {
+		if heights[partition] != 948000 {
+			t.Errorf("%s: cursor %d, expected the legacy height 948000", partition, heights[partition])
+		}
+	}
+	if len(kv[string(keyIntentCursors)]) == 0 {
+		t.Error("migrated cursors were not persisted")
+	}
+
+	// Once migrated, cursors advance independently of the legacy height
+	heights["acc://BVN0.acme/ledger"] = 948010
+	if err := store.SaveIntentPartitionLastBlocks(heights); err != nil {
+		t.Fatalf("SaveIntentPartitionLastBlocks failed: %v", err)
+	}
+	heights, _ = store.LoadIntentPartitionLastBlocks(partitions)
+	if heights["acc://BVN0.acme/ledger"] != 948010 || heights["acc://dn.acme/ledger"] != 948000 {
+		t.Errorf("unexpected cursors after migration %v", heights)
+	}
+}
@@ -4,10 +4,16 @@
 // Per Whitepaper Section 3.4.2: Validators batch transactions and compute Merkle root
 //
 // This implementation provides:
-// - Binary Merkle tree construction from transaction hashes
+// - Binary Merkle tree construction from transaction hashes, all at once or
+//   one leaf at a time
 // - Inclusion proof generation for any leaf
 // - Verification of inclusion proofs
 // - Thread-safe operations for concurrent batch building
+//
+// Parents are SHA256(left || right) and an odd node at the end of a level is paired
+// with itself. This is the order batch proofs are verified in (see VerifyProof and
+// the anchor contract), so only change it with WithHashFunc or WithSortedPairs for
+// trees whose proofs are verified with the same options.
 
 package merkle
 
@@ -62,21 +68,74 @@ type Tree struct {
 	levels   [][][]byte // Tree organized by levels (for proof generation)
 	root     []byte     // The Merkle root (32 bytes)
 	built    bool       // Whether the tree has been built
+	options  treeOptions
+}
+
+// HashFunc hashes the concatenation of two child nodes into their parent.
+// It must return 32 bytes.
+type HashFunc func(data []byte) []byte
+
+// TreeOption configures how a tree combines nodes
+type TreeOption func(*treeOptions)
+
+// treeOptions holds the node hashing configuration
+type treeOptions struct {
+	hash      HashFunc
+	sortPairs bool
+}
+
+// WithHashFunc hashes parent nodes with hash instead of SHA256
+func WithHashFunc(hash HashFunc) TreeOption {
+	return func(o *treeOptions) {
+		if hash != nil {
+			o.hash = hash
+		}
+	}
+}
+
+// WithSortedPairs orders each pair of children by byte value before hashing, so a
+// parent does not depend on which side a child is on
+func WithSortedPairs() TreeOption {
+	return func(o *treeOptions) {
+		o.sortPairs = true
+	}
+}
+
+// newTreeOptions applies opts to the default SHA256(left || right) hashing
+func newTreeOptions(opts []TreeOption) treeOptions {
+	options := treeOptions{hash: HashData}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// hashPair combines two 32-byte child hashes into their parent, by default
+// SHA256(left || right) - standard Merkle tree construction
+func (o treeOptions) hashPair(left, right []byte) []byte {
+	if o.sortPairs && bytes.Compare(left, right) > 0 {
+		left, right = right, left
+	}
+	combined := make([]byte, 64)
+	copy(combined[:32], left)
+	copy(combined[32:], right)
+	return o.hash(combined)
 }
 
-// NewTree creates a new empty Merkle tree
-func NewTree() *Tree {
+// NewTree creates a new empty Merkle tree; add leaves with AddLeaf
+func NewTree(opts ...TreeOption) *Tree {
 	return &Tree{
-		leaves: make([][]byte, 0),
-		nodes:  make([][]byte, 0),
-		levels: make([][][]byte, 0),
-		built:  false,
+		leaves:  make([][]byte, 0),
+		nodes:   make([][]byte, 0),
+		levels:  make([][][]byte, 0),
+		built:   false,
+		options: newTreeOptions(opts),
 	}
 }
 
 // BuildTree creates a new Merkle tree from the given leaf hashes
 // Each leaf must be exactly 32 bytes (SHA256 hash)
-func BuildTree(leaves [][]byte) (*Tree, error) {
+func BuildTree(leaves [][]byte, opts ...TreeOption) (*Tree, error) {
 	if len(leaves) == 0 {
 		return nil, ErrEmptyTree
 	}
@@ -89,8 +148,9 @@ func BuildTree(leaves [][]byte) (*Tree, error) {
 	}
 
 	tree := &Tree{
-		leaves: make([][]byte, len(leaves)),
-		levels: make([][][]byte, 0),
+		leaves:  make([][]byte, len(leaves)),
+		levels:  make([][][]byte, 0),
+		options: newTreeOptions(opts),
 	}
 
 	// Copy leaves
@@ -133,10 +193,10 @@ func (t *Tree) build() error {
 
 			if i+1 < len(currentLevel) {
 				// Two nodes to combine
-				combined = hashPair(currentLevel[i], currentLevel[i+1])
+				combined = t.options.hashPair(currentLevel[i], currentLevel[i+1])
 			} else {
 				// Odd node - duplicate it (standard Merkle tree behavior)
-				combined = hashPair(currentLevel[i], currentLevel[i])
+				combined = t.options.hashPair(currentLevel[i], currentLevel[i])
 			}
 
 			nextLevel = append(nextLevel, combined)
@@ -153,14 +213,80 @@ func (t *Tree) build() error {
 	return nil
 }
 
-// hashPair combines two 32-byte hashes into one
-// Uses SHA256(left || right) - standard Merkle tree construction
-func hashPair(left, right []byte) []byte {
-	combined := make([]byte, 64)
-	copy(combined[:32], left)
-	copy(combined[32:], right)
-	hash := sha256.Sum256(combined)
-	return hash[:]
+// AddLeaf appends a leaf and updates the root, returning the leaf's index.
+// Only the nodes above the new leaf are rehashed, so building a tree one leaf at a
+// time gives the same root and proofs as BuildTree over all the leaves.
+func (t *Tree) AddLeaf(leaf []byte) (int, error) {
+	if len(leaf) != 32 {
+		return 0, fmt.Errorf("%w: got %d bytes", ErrInvalidLeafHash, len(leaf))
+	}
+	node := make([]byte, 32)
+	copy(node, leaf)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.addLeafLocked(node), nil
+}
+
+// addLeafLocked appends node and rehashes the nodes above it. t.mu must be held.
+func (t *Tree) addLeafLocked(node []byte) int {
+	if len(t.levels) == 0 {
+		t.levels = append(t.levels, make([][]byte, 0))
+	}
+	index := len(t.leaves)
+	t.leaves = append(t.leaves, node)
+	t.levels[0] = append(t.levels[0], node)
+
+	// Rehash the right edge of each level: the new node's parent is either new or
+	// was the hash of its sibling paired with itself
+	current := index
+	level := 0
+	for ; len(t.levels[level]) > 1; level++ {
+		nodes := t.levels[level]
+		left := current &^ 1
+		right := left
+		if left+1 < len(nodes) {
+			right = left + 1
+		}
+		parent := t.options.hashPair(nodes[left], nodes[right])
+
+		if level+1 == len(t.levels) {
+			t.levels = append(t.levels, make([][]byte, 0, 1))
+		}
+		current /= 2
+		if current < len(t.levels[level+1]) {
+			t.levels[level+1][current] = parent
+		} else {
+			t.levels[level+1] = append(t.levels[level+1], parent)
+		}
+	}
+
+	t.root = t.levels[level][0]
+	t.built = true
+	return index
+}
+
+// RemoveLastLeaf undoes the most recent AddLeaf, for callers that roll back a leaf
+// whose record could not be stored. The remaining leaves are rehashed from scratch.
+func (t *Tree) RemoveLastLeaf() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.leaves) == 0 {
+		return ErrEmptyTree
+	}
+
+	leaves := t.leaves[:len(t.leaves)-1]
+	t.leaves = make([][]byte, 0, len(leaves))
+	t.nodes = make([][]byte, 0)
+	t.levels = make([][][]byte, 0)
+	t.root = nil
+	t.built = false
+	for _, leaf := range leaves {
+		t.addLeafLocked(leaf)
+	}
+	return nil
 }
 
 // Root returns the Merkle root as a 32-byte slice
@@ -297,6 +423,13 @@ func (t *Tree) GenerateProofByHash(leafHash []byte) (*InclusionProof, error) {
 // This is a static function that doesn't require the full tree
 // Uses constant-time comparison to prevent timing attacks
 func VerifyProof(leafHash []byte, proof *InclusionProof, expectedRoot []byte) (bool, error) {
+	return VerifyProofWith(leafHash, proof, expectedRoot)
+}
+
+// VerifyProofWith verifies a proof from a tree built with opts
+func VerifyProofWith(leafHash []byte, proof *InclusionProof, expectedRoot []byte, opts ...TreeOption) (bool, error) {
+	options := newTreeOptions(opts)
+
 	if len(leafHash) != 32 {
 		return false, ErrInvalidLeafHash
 	}
@@ -327,10 +460,10 @@ func VerifyProof(leafHash []byte, proof *InclusionProof, expectedRoot []byte) (b
 
 		if node.Position == Left {
 			// Sibling is on the left
-			currentHash = hashPair(siblingHash, currentHash)
+			currentHash = options.hashPair(siblingHash, currentHash)
 		} else {
 			// Sibling is on the right
-			currentHash = hashPair(currentHash, siblingHash)
+			currentHash = options.hashPair(currentHash, siblingHash)
 		}
 	}
 
@@ -1,6 +1,8 @@
 // Copyright 2025 Certen Protocol
 //
 // Merkle Tree Tests
+// Includes property tests that every generated proof verifies, for trees built at
+// once, leaf by leaf and with custom hashing
 
 package merkle
 
@@ -8,7 +10,11 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"math/rand"
 	"testing"
+	"testing/quick"
+
+	"github.com/certen/independant-validator/accumulate-lite-client-2/liteclient/verifier"
 )
 
 func TestBuildTree_SingleLeaf(t *testing.T) {
@@ -369,3 +375,238 @@ func TestCombineHashes(t *testing.T) {
 		t.Error("combine order should matter")
 	}
 }
+
+// randomLeaves returns between 1 and 1+maxExtra random 32-byte leaves
+func randomLeaves(rng *rand.Rand, maxExtra int) [][]byte {
+	leaves := make([][]byte, 1+rng.Intn(maxExtra+1))
+	for i := range leaves {
+		leaves[i] = make([]byte, 32)
+		rng.Read(leaves[i])
+	}
+	return leaves
+}
+
+// checkAllProofs verifies the proof of every leaf in tree and that a proof does not
+// verify a different leaf
+func checkAllProofs(t *testing.T, tree *Tree, leaves [][]byte, opts ...TreeOption) bool {
+	t.Helper()
+	root := tree.Root()
+	for i, leaf := range leaves {
+		proof, err := tree.GenerateProof(i)
+		if err != nil {
+			t.Errorf("GenerateProof(%d) of %d leaves: %v", i, len(leaves), err)
+			return false
+		}
+		ok, err := VerifyProofWith(leaf, proof, root, opts...)
+		if err != nil || !ok {
+			t.Errorf("proof of leaf %d of %d does not verify (err %v)", i, len(leaves), err)
+			return false
+		}
+		other := leaves[(i+1)%len(leaves)]
+		if !bytes.Equal(other, leaf) {
+			if ok, _ := VerifyProofWith(other, proof, root, opts...); ok {
+				t.Errorf("proof of leaf %d of %d verifies leaf %d", i, len(leaves), (i+1)%len(leaves))
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestProperty_GeneratedProofsVerify(t *testing.T) {
+	property := func(seed int64) bool {
+		leaves := randomLeaves(rand.New(rand.NewSource(seed)), 130)
+		tree, err := BuildTree(leaves)
+		if err != nil {
+			t.Errorf("BuildTree: %v", err)
+			return false
+		}
+		return checkAllProofs(t, tree, leaves)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_AddLeafMatchesBuildTree(t *testing.T) {
+	property := func(seed int64) bool {
+		leaves := randomLeaves(rand.New(rand.NewSource(seed)), 70)
+		tree := NewTree()
+		for i, leaf := range leaves {
+			index, err := tree.AddLeaf(leaf)
+			if err != nil || index != i {
+				t.Errorf("AddLeaf(%d) = %d, %v", i, index, err)
+				return false
+			}
+			built, _ := BuildTree(leaves[:i+1])
+			if !bytes.Equal(tree.Root(), built.Root()) {
+				t.Errorf("root after %d leaves differs from BuildTree", i+1)
+				return false
+			}
+		}
+		return checkAllProofs(t, tree, leaves)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 50}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_RemoveLastLeaf(t *testing.T) {
+	property := func(seed int64) bool {
+		leaves := randomLeaves(rand.New(rand.NewSource(seed)), 40)
+		tree := NewTree()
+		for _, leaf := range leaves {
+			tree.AddLeaf(leaf)
+		}
+		extra := sha256.Sum256([]byte("rolled back"))
+		tree.AddLeaf(extra[:])
+		if err := tree.RemoveLastLeaf(); err != nil {
+			t.Errorf("RemoveLastLeaf: %v", err)
+			return false
+		}
+
+		built, _ := BuildTree(leaves)
+		if tree.LeafCount() != len(leaves) || !bytes.Equal(tree.Root(), built.Root()) {
+			t.Errorf("tree after rollback differs from BuildTree over %d leaves", len(leaves))
+			return false
+		}
+		return checkAllProofs(t, tree, leaves)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 50}); err != nil {
+		t.Error(err)
+	}
+
+	tree := NewTree()
+	leaf := sha256.Sum256([]byte("only"))
+	tree.AddLeaf(leaf[:])
+	if err := tree.RemoveLastLeaf(); err != nil || tree.Root() != nil || tree.LeafCount() != 0 {
+		t.Errorf("removing the only leaf: err %v, root %x, count %d", err, tree.Root(), tree.LeafCount())
+	}
+	if err := tree.RemoveLastLeaf(); err != ErrEmptyTree {
+		t.Errorf("RemoveLastLeaf on an empty tree = %v, want ErrEmptyTree", err)
+	}
+}
+
+// bptEntries converts an inclusion proof path into the receipt entries that
+// verifier.VerifyBPTProof walks
+func bptEntries(t *testing.T, proof *InclusionProof) []verifier.BPTReceiptEntry {
+	t.Helper()
+	entries := make([]verifier.BPTReceiptEntry, len(proof.Path))
+	for i, node := range proof.Path {
+		hash, err := hex.DecodeString(node.Hash)
+		if err != nil {
+			t.Fatalf("invalid path hash: %v", err)
+		}
+		entries[i] = verifier.BPTReceiptEntry{Hash: hash, Right: node.Position == Right}
+	}
+	return entries
+}
+
+func TestProperty_AgreesWithBPTVerifier(t *testing.T) {
+	property := func(seed int64) bool {
+		rng := rand.New(rand.NewSource(seed))
+		leaves := randomLeaves(rng, 70)
+		tree, _ := BuildTree(leaves)
+		root := tree.Root()
+
+		for i, leaf := range leaves {
+			proof, _ := tree.GenerateProof(i)
+			entries := bptEntries(t, proof)
+
+			ok, err := VerifyProof(leaf, proof, root)
+			if err != nil || !ok || !verifier.VerifyBPTProof(leaf, entries, root) {
+				t.Errorf("leaf %d of %d: VerifyProof %v (err %v), VerifyBPTProof %v",
+					i, len(leaves), ok, err, verifier.VerifyBPTProof(leaf, entries, root))
+				return false
+			}
+
+			// Both reject the same tampered root
+			tampered := append([]byte(nil), root...)
+			tampered[rng.Intn(32)] ^= 0x01
+			ok, _ = VerifyProof(leaf, proof, tampered)
+			if ok || verifier.VerifyBPTProof(leaf, entries, tampered) {
+				t.Errorf("leaf %d of %d: a tampered root verified", i, len(leaves))
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 100}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_TreeOptions(t *testing.T) {
+	doubleSHA := func(data []byte) []byte {
+		first := sha256.Sum256(data)
+		second := sha256.Sum256(first[:])
+		return second[:]
+	}
+	property := func(seed int64) bool {
+		leaves := randomLeaves(rand.New(rand.NewSource(seed)), 40)
+		for _, opts := range [][]TreeOption{
+			{WithSortedPairs()},
+			{WithHashFunc(doubleSHA)},
+			{WithHashFunc(doubleSHA), WithSortedPairs()},
+		} {
+			tree, err := BuildTree(leaves, opts...)
+			if err != nil {
+				t.Errorf("BuildTree: %v", err)
+				return false
+			}
+			incremental := NewTree(opts...)
+			for _, leaf := range leaves {
+				incremental.AddLeaf(leaf)
+			}
+			if !bytes.Equal(tree.Root(), incremental.Root()) {
+				t.Errorf("incremental root differs with options for %d leaves", len(leaves))
+				return false
+			}
+			if !checkAllProofs(t, tree, leaves, opts...) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 50}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTreeOptions_ChangeRoot(t *testing.T) {
+	leaves := make([][]byte, 3)
+	for i := range leaves {
+		hash := sha256.Sum256([]byte{byte(i)})
+		leaves[i] = hash[:]
+	}
+	defaultTree, _ := BuildTree(leaves)
+	sortedTree, _ := BuildTree(leaves, WithSortedPairs())
+	if bytes.Equal(defaultTree.Root(), sortedTree.Root()) && bytes.Compare(leaves[0], leaves[1]) > 0 {
+		t.Error("sorted pairs should change the root of unordered leaves")
+	}
+
+	// Default order is SHA256(left || right) with the odd node paired with itself
+	left := hashPairForTest(leaves[0], leaves[1])
+	right := hashPairForTest(leaves[2], leaves[2])
+	if want := hashPairForTest(left, right); !bytes.Equal(defaultTree.Root(), want) {
+		t.Errorf("default root %x, want %x", defaultTree.Root(), want)
+	}
+
+	// A proof only verifies with the options its tree was built with
+	proof, _ := sortedTree.GenerateProof(2)
+	if ok, _ := VerifyProofWith(leaves[2], proof, sortedTree.Root(), WithSortedPairs()); !ok {
+		t.Error("proof should verify with the tree's options")
+	}
+	if ok, _ := VerifyProof(leaves[2], proof, sortedTree.Root()); ok && !bytes.Equal(defaultTree.Root(), sortedTree.Root()) {
+		t.Error("proof should not verify with the default options")
+	}
+
+	if _, err := NewTree().AddLeaf([]byte("short")); err == nil {
+		t.Error("expected an error for a leaf that is not 32 bytes")
+	}
+}
+
+func hashPairForTest(left, right []byte) []byte {
+	hash := sha256.Sum256(append(append([]byte{}, left...), right...))
+	return hash[:]
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Certen Protocol
+//
+// Batch Metrics - Prometheus registry for batch, proof and anchor counters
+//
+// The registry is created when METRICS_ENABLED is set and injected into the batch
+// collector, batch processor, proof cycle orchestrator and attestation service through
+// their configs. Every method is safe on a nil *Registry and records nothing, so
+// components built without metrics need no checks. Metrics are served from a dedicated
+// Prometheus registry (not the global default) by Handler.
+
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds the batch, proof and anchor metrics
+type Registry struct {
+	registry *prometheus.Registry
+
+	batchesClosed    *prometheus.CounterVec // batch_type
+	proofsExecuted   *prometheus.CounterVec // batch_type
+	anchorTxFailures *prometheus.CounterVec // batch_type, step
+	proofCycleStage  *prometheus.GaugeVec   // stage
+
+	attestationsRejected *prometheus.CounterVec // reason
+
+	mu                sync.Mutex
+	onCadenceOpenedAt time.Time // Zero = no open on-cadence batch
+}
+
+// NewRegistry creates the metrics registry with Go runtime and process collectors
+func NewRegistry() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		batchesClosed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "certen",
+			Name:      "batches_closed_total",
+			Help:      "Total batches closed by the collector",
+		}, []string{"batch_type"}),
+		proofsExecuted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "certen",
+			Name:      "proofs_executed_total",
+			Help:      "Total comprehensive proofs executed on the anchor contract",
+		}, []string{"batch_type"}),
+		anchorTxFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "certen",
+			Name:      "anchor_tx_failures_total",
+			Help:      "Total failed anchor transactions by workflow step",
+		}, []string{"batch_type", "step"}), // step: create_anchor, execute_proof
+		proofCycleStage: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "certen",
+			Name:      "proof_cycle_stage",
+			Help:      "Proof cycles currently in each stage",
+		}, []string{"stage"}), // stage: observing, attesting, writing_back
+		attestationsRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "certen",
+			Name:      "attestations_rejected_total",
+			Help:      "Total peer attestations rejected before being counted, by reason",
+		}, []string{"reason"}), // reason: see attestation.RejectReason*
+	}
+
+	onCadenceAge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "certen",
+		Name:      "on_cadence_batch_age_seconds",
+		Help:      "Age of the open on-cadence batch (0 when none is open)",
+	}, r.onCadenceBatchAge)
+
+	r.registry.MustRegister(
+		r.batchesClosed,
+		r.proofsExecuted,
+		r.anchorTxFailures,
+		r.proofCycleStage,
+		r.attestationsRejected,
+		onCadenceAge,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return r
+}
+
+// Handler returns the Prometheus HTTP handler for the registry
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
+
+// Gatherer returns the underlying Prometheus gatherer
+func (r *Registry) Gatherer() prometheus.Gatherer {
+	return r.registry
+}
+
+// RecordBatchClosed counts a closed batch
+func (r *Registry) RecordBatchClosed(batchType string) {
+	if r == nil {
+		return
+	}
+	r.batchesClosed.WithLabelValues(batchType).Inc()
+}
+
+// RecordProofExecuted counts a comprehensive proof executed on-chain
+func (r *Registry) RecordProofExecuted(batchType string) {
+	if r == nil {
+		return
+	}
+	r.proofsExecuted.WithLabelValues(batchType).Inc()
+}
+
+// RecordAnchorTxFailure counts a failed anchor transaction at a workflow step
+func (r *Registry) RecordAnchorTxFailure(batchType, step string) {
+	if r == nil {
+		return
+	}
+	r.anchorTxFailures.WithLabelValues(batchType, step).Inc()
+}
+
+// SetOnCadenceBatchOpenedAt records when the open on-cadence batch was started;
+// the zero time means no batch is open
+func (r *Registry) SetOnCadenceBatchOpenedAt(t time.Time) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onCadenceOpenedAt = t
+}
+
+func (r *Registry) onCadenceBatchAge() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.onCadenceOpenedAt.IsZero() {
+		return 0
+	}
+	return time.Since(r.onCadenceOpenedAt).Seconds()
+}
+
+// MoveProofCycleStage moves a proof cycle between stages; an empty stage means the
+// cycle is entering (from) or leaving (to) the orchestrator
+func (r *Registry) MoveProofCycleStage(from, to string) {
+	if r == nil || from == to {
+		return
+	}
+	if from != "" {
+		r.proofCycleStage.WithLabelValues(from).Dec()
+	}
+	if to != "" {
+		r.proofCycleStage.WithLabelValues(to).Inc()
+	}
+}
+
+// RecordAttestationRejected counts a peer attestation rejected for reason
+func (r *Registry) RecordAttestationRejected(reason string) {
+	if r == nil {
+		return
+	}
+	r.attestationsRejected.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Batch Metrics
+// Tests that each metric is recorded and served by the gatherer, stage gauge bookkeeping
+// and that a nil registry records nothing
+
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// gatheredValue returns the value of the metric with the given name and labels, and
+// whether it was gathered
+func gatheredValue(t *testing.T, r *Registry, name string, labels map[string]string) (float64, bool) {
+	t.Helper()
+	families, err := r.Gatherer().Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if !labelsMatch(m.GetLabel(), labels) {
+				continue
+			}
+			switch {
+			case m.Counter != nil:
+				return m.Counter.GetValue(), true
+			case m.Gauge != nil:
+				return m.Gauge.GetValue(), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func labelsMatch(pairs []*dto.LabelPair, want map[string]string) bool {
+	if len(pairs) != len(want) {
+		return false
+	}
+	for _, p := range pairs {
+		if want[p.GetName()] != p.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRegistry_RecordsEachMetric(t *testing.T) {
+	r := NewRegistry()
+
+	r.RecordBatchClosed("on_cadence")
+	r.RecordBatchClosed("on_cadence")
+	r.RecordBatchClosed("on_demand")
+	r.RecordProofExecuted("on_demand")
+	r.RecordAnchorTxFailure("on_cadence", "create_anchor")
+	r.RecordAnchorTxFailure("on_cadence", "execute_proof")
+	r.RecordAnchorTxFailure("on_cadence", "execute_proof")
+	r.RecordAttestationRejected("unknown_validator")
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   float64
+	}{
+		{"certen_batches_closed_total", map[string]string{"batch_type": "on_cadence"}, 2},
+		{"certen_batches_closed_total", map[string]string{"batch_type": "on_demand"}, 1},
+		{"certen_proofs_executed_total", map[string]string{"batch_type": "on_demand"}, 1},
+		{"certen_anchor_tx_failures_total", map[string]string{"batch_type": "on_cadence", "step": "create_anchor"}, 1},
+		{"certen_anchor_tx_failures_total", map[string]string{"batch_type": "on_cadence", "step": "execute_proof"}, 2},
+		{"certen_attestations_rejected_total", map[string]string{"reason": "unknown_validator"}, 1},
+		{"certen_on_cadence_batch_age_seconds", map[string]string{}, 0},
+	}
+	for _, tt := range tests {
+		got, ok := gatheredValue(t, r, tt.name, tt.labels)
+		if !ok {
+			t.Errorf("%s%v not gathered", tt.name, tt.labels)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s%v = %v, want %v", tt.name, tt.labels, got, tt.want)
+		}
+	}
+
+	// The open on-cadence batch ages until it is closed
+	r.SetOnCadenceBatchOpenedAt(time.Now().Add(-time.Minute))
+	if age, _ := gatheredValue(t, r, "certen_on_cadence_batch_age_seconds", map[string]string{}); age < 59 {
+		t.Errorf("expected batch age of about 60s, got %v", age)
+	}
+	r.SetOnCadenceBatchOpenedAt(time.Time{})
+	if age, _ := gatheredValue(t, r, "certen_on_cadence_batch_age_seconds", map[string]string{}); age != 0 {
+		t.Errorf("expected 0 with no open batch, got %v", age)
+	}
+
+	// Runtime collectors are registered alongside
+	if _, ok := gatheredValue(t, r, "go_goroutines", map[string]string{}); !ok {
+		t.Error("Go runtime metrics not gathered")
+	}
+
+	// The HTTP handler serves the same registry
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), `certen_batches_closed_total{batch_type="on_cadence"} 2`) {
+		t.Errorf("handler output missing batch counter:\n%s", body)
+	}
+}
+
+func TestRegistry_ProofCycleStage(t *testing.T) {
+	r := NewRegistry()
+	stage := func(name string) float64 {
+		v, _ := gatheredValue(t, r, "certen_proof_cycle_stage", map[string]string{"stage": name})
+		return v
+	}
+
+	r.MoveProofCycleStage("", "observing")
+	r.MoveProofCycleStage("", "observing")
+	r.MoveProofCycleStage("observing", "attesting")
+	r.MoveProofCycleStage("attesting", "attesting") // No change
+	if stage("observing") != 1 || stage("attesting") != 1 {
+		t.Errorf("expected 1 observing and 1 attesting, got %v and %v", stage("observing"), stage("attesting"))
+	}
+
+	r.MoveProofCycleStage("attesting", "writing_back")
+	r.MoveProofCycleStage("writing_back", "")
+	r.MoveProofCycleStage("observing", "")
+	r.MoveProofCycleStage("", "") // No change
+	for _, name := range []string{"observing", "attesting", "writing_back"} {
+		if v := stage(name); v != 0 {
+			t.Errorf("stage %s = %v after every cycle left, want 0", name, v)
+		}
+	}
+}
+
+func TestRegistry_NilIsNoOp(t *testing.T) {
+	var r *Registry
+	r.RecordBatchClosed("on_cadence")
+	r.RecordProofExecuted("on_cadence")
+	r.RecordAnchorTxFailure("on_cadence", "create_anchor")
+	r.RecordAttestationRejected("unknown_validator")
+	r.SetOnCadenceBatchOpenedAt(time.Now())
+	r.MoveProofCycleStage("", "observing")
+}
@@ -115,7 +115,7 @@ func NewProofArtifactService(config *ArtifactServiceConfig) (*ProofArtifactServi
 		}
 		govProofGen = cliGen
 	} else {
-		// Use in-process generator (returns stub proofs until library is available)
+		// Use the in-process generator (native G0/G1/G2/G3, no CLI binary needed)
 		govProofGen = NewInProcessGovernanceGenerator(
 			config.V3Endpoint,
 			config.GovProofWorkDir,
@@ -199,6 +199,10 @@ func (w *GovernanceProofWrapper) GetTxHash() string {
 		if w.Proof.G2 != nil {
 			return w.Proof.G2.TxHash
 		}
+	case GovLevelG3:
+		if w.Proof.G3 != nil {
+			return w.Proof.G3.TxHash
+		}
 	}
 	return ""
 }
@@ -221,6 +225,10 @@ func (w *GovernanceProofWrapper) GetScope() string {
 		if w.Proof.G2 != nil {
 			return w.Proof.G2.Scope
 		}
+	case GovLevelG3:
+		if w.Proof.G3 != nil {
+			return w.Proof.G3.Scope
+		}
 	}
 	return ""
 }
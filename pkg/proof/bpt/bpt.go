@@ -0,0 +1,57 @@
+// Copyright 2025 Certen Protocol
+//
+// BPT Receipt Verification - Reusable Merkle receipt checks
+//
+// Accumulate receipts prove a start hash (an account state or transaction hash) up
+// to an anchor (a BPT or chain root) through an ordered path of sibling hashes. Each
+// entry says which side its sibling is on: SHA256(current || hash) when the sibling
+// is on the right, SHA256(hash || current) when it is on the left. The hashing itself
+// is shared with the lite client's verifier package so the validator and the
+// verify-bpt CLI can never disagree about what a valid receipt is.
+
+package bpt
+
+import (
+	"fmt"
+
+	"github.com/certen/independant-validator/accumulate-lite-client-2/liteclient/verifier"
+)
+
+// HashSize is the size of every hash in a receipt
+const HashSize = 32
+
+// ReceiptEntry is one sibling on the path from the start hash to the anchor
+type ReceiptEntry struct {
+	Hash  []byte // Sibling hash
+	Right bool   // Sibling is on the right: SHA256(current || hash)
+}
+
+// VerifyBPTReceipt reports whether the path leads from start to anchor. It returns an
+// error when the receipt is malformed (a missing or non-32-byte hash) and false when
+// it is well formed but the recomputed root differs from the anchor. An empty path is
+// valid only when start equals anchor.
+func VerifyBPTReceipt(start []byte, entries []ReceiptEntry, anchor []byte) (bool, error) {
+	if len(start) != HashSize {
+		return false, fmt.Errorf("start hash must be %d bytes, got %d", HashSize, len(start))
+	}
+	if len(anchor) != HashSize {
+		return false, fmt.Errorf("anchor hash must be %d bytes, got %d", HashSize, len(anchor))
+	}
+	path := make([]verifier.BPTReceiptEntry, len(entries))
+	for i, entry := range entries {
+		if len(entry.Hash) != HashSize {
+			return false, fmt.Errorf("entry %d hash must be %d bytes, got %d", i, HashSize, len(entry.Hash))
+		}
+		path[i] = verifier.BPTReceiptEntry{Hash: entry.Hash, Right: entry.Right}
+	}
+	return verifier.VerifyBPTProof(start, path, anchor), nil
+}
+
+// ComputeRoot applies a path to a start hash and returns the resulting root
+func ComputeRoot(start []byte, entries []ReceiptEntry) []byte {
+	path := make([]verifier.BPTReceiptEntry, len(entries))
+	for i, entry := range entries {
+		path[i] = verifier.BPTReceiptEntry{Hash: entry.Hash, Right: entry.Right}
+	}
+	return verifier.ComputeBPTRoot(start, path)
+}
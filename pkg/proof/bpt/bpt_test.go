@@ -0,0 +1,110 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for BPT Receipt Verification
+// Tests valid, tampered and malformed receipts
+
+package bpt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func hashOf(s string) []byte {
+	h := sha256.Sum256([]byte(s))
+	return h[:]
+}
+
+// testReceipt builds a three-step path from hashOf("start")
+func testReceipt() ([]byte, []ReceiptEntry, []byte) {
+	start := hashOf("start")
+	entries := []ReceiptEntry{
+		{Hash: hashOf("a"), Right: true},
+		{Hash: hashOf("b"), Right: false},
+		{Hash: hashOf("c"), Right: true},
+	}
+
+	current := start
+	for _, entry := range entries {
+		var sum [32]byte
+		if entry.Right {
+			sum = sha256.Sum256(append(append([]byte{}, current...), entry.Hash...))
+		} else {
+			sum = sha256.Sum256(append(append([]byte{}, entry.Hash...), current...))
+		}
+		current = sum[:]
+	}
+	return start, entries, current
+}
+
+func TestVerifyBPTReceipt_Valid(t *testing.T) {
+	start, entries, anchor := testReceipt()
+
+	valid, err := VerifyBPTReceipt(start, entries, anchor)
+	if err != nil || !valid {
+		t.Fatalf("expected a valid receipt, got %v (err %v)", valid, err)
+	}
+	if !bytes.Equal(ComputeRoot(start, entries), anchor) {
+		t.Error("ComputeRoot does not match the anchor")
+	}
+
+	// An empty path proves only the anchor itself
+	if valid, err := VerifyBPTReceipt(anchor, nil, anchor); err != nil || !valid {
+		t.Errorf("expected an empty path to verify start == anchor, got %v (err %v)", valid, err)
+	}
+}
+
+func TestVerifyBPTReceipt_Tampered(t *testing.T) {
+	start, entries, anchor := testReceipt()
+
+	flipped := append([]ReceiptEntry{}, entries...)
+	flipped[1].Right = !flipped[1].Right
+
+	reordered := []ReceiptEntry{entries[1], entries[0], entries[2]}
+
+	tests := []struct {
+		name    string
+		start   []byte
+		entries []ReceiptEntry
+		anchor  []byte
+	}{
+		{"tampered start", hashOf("other"), entries, anchor},
+		{"flipped direction", start, flipped, anchor},
+		{"reordered path", start, reordered, anchor},
+		{"truncated path", start, entries[:2], anchor},
+		{"wrong anchor", start, entries, hashOf("root")},
+	}
+	for _, tt := range tests {
+		valid, err := VerifyBPTReceipt(tt.start, tt.entries, tt.anchor)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if valid {
+			t.Errorf("%s: expected the receipt not to verify", tt.name)
+		}
+	}
+}
+
+func TestVerifyBPTReceipt_Malformed(t *testing.T) {
+	start, entries, anchor := testReceipt()
+
+	short := append([]ReceiptEntry{}, entries...)
+	short[2].Hash = short[2].Hash[:31]
+
+	tests := []struct {
+		name    string
+		start   []byte
+		entries []ReceiptEntry
+		anchor  []byte
+	}{
+		{"missing start", nil, entries, anchor},
+		{"missing anchor", start, entries, nil},
+		{"short entry hash", start, short, anchor},
+	}
+	for _, tt := range tests {
+		if valid, err := VerifyBPTReceipt(tt.start, tt.entries, tt.anchor); err == nil || valid {
+			t.Errorf("%s: expected an error, got %v (err %v)", tt.name, valid, err)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 // Copyright 2025 Certen Protocol
 //
-// GovernanceProofAdapter - Adapter for generating real G0/G1/G2 governance proofs
+// GovernanceProofAdapter - Adapter for generating real G0/G1/G2/G3 governance proofs
 //
 // This adapter interfaces with the consolidated_governance-proof system to generate
 // real governance proofs with cryptographic verification per CERTEN spec v3-governance-kpsw-exec-4.0
@@ -9,6 +9,7 @@
 // - G0: Inclusion and Finality Only
 // - G1: Governance Correctness (Authority Validated)
 // - G2: Governance + Outcome Binding
+// - G3: Outcome Binding + Cross-Chain Anchor Binding
 
 package proof
 
@@ -31,6 +32,8 @@ type GovernanceProofGenerator interface {
 	GenerateG1(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error)
 	// GenerateG2 generates G2 proof (Governance + Outcome Binding)
 	GenerateG2(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error)
+	// GenerateG3 generates G3 proof (Outcome Binding + Cross-Chain Anchor Binding)
+	GenerateG3(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error)
 	// GenerateAtLevel generates governance proof at specified level
 	GenerateAtLevel(ctx context.Context, level GovernanceLevel, req *GovernanceRequest) (*GovernanceProof, error)
 }
@@ -49,6 +52,22 @@ type GovernanceRequest struct {
 	V3Endpoint    string `json:"v3_endpoint,omitempty"`    // V3 RPC endpoint
 	WorkDir       string `json:"work_dir,omitempty"`       // Working directory for artifacts
 	SigningDomain string `json:"signing_domain,omitempty"` // Signing domain
+
+	// G3 fields (required for G3)
+	TargetChain       string `json:"target_chain,omitempty"`        // Anchor chain (e.g. "ethereum")
+	AnchorTxHash      string `json:"anchor_tx_hash,omitempty"`      // Target-chain anchor transaction hash
+	AnchorBlockNumber int64  `json:"anchor_block_number,omitempty"` // Block that mined the anchor transaction
+}
+
+// validateG3Request checks the anchor fields a G3 proof binds
+func validateG3Request(req *GovernanceRequest) error {
+	if req.KeyPage == "" {
+		return fmt.Errorf("G3 proof requires KeyPage")
+	}
+	if req.AnchorTxHash == "" || req.AnchorBlockNumber <= 0 {
+		return fmt.Errorf("G3 proof requires AnchorTxHash and AnchorBlockNumber")
+	}
+	return nil
 }
 
 // CLIGovernanceProofGenerator implements governance proof generation via CLI subprocess
@@ -58,6 +77,7 @@ type CLIGovernanceProofGenerator struct {
 	v3Endpoint   string        // Default V3 endpoint
 	workDir      string        // Base working directory
 	timeout      time.Duration // Command timeout
+	cache        *GovernanceResultCache
 	logger       *log.Logger
 }
 
@@ -86,6 +106,19 @@ func (g *CLIGovernanceProofGenerator) SetLogger(logger *log.Logger) {
 	g.logger = logger
 }
 
+// SetResultCache enables reuse of completed proofs; nil disables caching
+func (g *CLIGovernanceProofGenerator) SetResultCache(cache *GovernanceResultCache) {
+	g.cache = cache
+}
+
+// CacheStats returns result cache counters (zero when caching is disabled)
+func (g *CLIGovernanceProofGenerator) CacheStats() GovernanceCacheStats {
+	if g.cache == nil {
+		return GovernanceCacheStats{}
+	}
+	return g.cache.Stats()
+}
+
 // GenerateG0 generates G0 governance proof
 func (g *CLIGovernanceProofGenerator) GenerateG0(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
 	return g.GenerateAtLevel(ctx, GovLevelG0, req)
@@ -107,8 +140,23 @@ func (g *CLIGovernanceProofGenerator) GenerateG2(ctx context.Context, req *Gover
 	return g.GenerateAtLevel(ctx, GovLevelG2, req)
 }
 
-// GenerateAtLevel generates governance proof at specified level using CLI
+// GenerateG3 generates G3 governance proof
+func (g *CLIGovernanceProofGenerator) GenerateG3(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
+	if err := validateG3Request(req); err != nil {
+		return nil, err
+	}
+	return g.GenerateAtLevel(ctx, GovLevelG3, req)
+}
+
+// GenerateAtLevel generates governance proof at specified level using CLI, serving
+// completed proofs from the result cache when one is configured
 func (g *CLIGovernanceProofGenerator) GenerateAtLevel(ctx context.Context, level GovernanceLevel, req *GovernanceRequest) (*GovernanceProof, error) {
+	return cachedGovernanceProof(g.cache, level, req, func() (*GovernanceProof, error) {
+		return g.generateAtLevel(ctx, level, req)
+	})
+}
+
+func (g *CLIGovernanceProofGenerator) generateAtLevel(ctx context.Context, level GovernanceLevel, req *GovernanceRequest) (*GovernanceProof, error) {
 	if g.govProofPath == "" {
 		// Return stub proof if CLI not configured
 		g.logger.Printf("[GOV-PROOF] CLI not configured, returning stub proof for level %s", level)
@@ -154,11 +202,7 @@ func (g *CLIGovernanceProofGenerator) buildCLIArgs(level GovernanceLevel, req *G
 		endpoint = g.v3Endpoint
 	}
 	if endpoint != "" {
-		// Ensure endpoint ends with /v3
-		if !strings.HasSuffix(endpoint, "/v3") {
-			endpoint = strings.TrimSuffix(endpoint, "/") + "/v3"
-		}
-		args = append(args, "--endpoint", endpoint)
+		args = append(args, "--endpoint", normalizeV3Endpoint(endpoint))
 	}
 
 	// Key page (required for G1+)
@@ -180,11 +224,19 @@ func (g *CLIGovernanceProofGenerator) buildCLIArgs(level GovernanceLevel, req *G
 		args = append(args, "--signing-domain", req.SigningDomain)
 	}
 
-	// TxHash tool path for G2 payload verification
-	if level == GovLevelG2 && g.txhashPath != "" {
+	// TxHash tool path for G2+ payload verification
+	if (level == GovLevelG2 || level == GovLevelG3) && g.txhashPath != "" {
 		args = append(args, "--txhash", g.txhashPath)
 	}
 
+	// Anchor transaction for G3 cross-chain binding
+	if level == GovLevelG3 {
+		if req.TargetChain != "" {
+			args = append(args, "--target-chain", req.TargetChain)
+		}
+		args = append(args, "--anchor-tx", req.AnchorTxHash, "--anchor-block", fmt.Sprintf("%d", req.AnchorBlockNumber))
+	}
+
 	// Positional arguments: account chain txhash
 	chain := req.Chain
 	if chain == "" {
@@ -195,6 +247,14 @@ func (g *CLIGovernanceProofGenerator) buildCLIArgs(level GovernanceLevel, req *G
 	return args
 }
 
+// normalizeV3Endpoint ensures a non-empty endpoint ends with /v3
+func normalizeV3Endpoint(endpoint string) string {
+	if endpoint == "" || strings.HasSuffix(endpoint, "/v3") {
+		return endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/v3"
+}
+
 // extractJSON extracts JSON content from CLI output, skipping log lines
 // The CLI may output log lines like "[G0] Starting..." before the JSON
 func extractJSON(output []byte) []byte {
@@ -247,6 +307,12 @@ func (g *CLIGovernanceProofGenerator) parseOutput(level GovernanceLevel, output
 			return nil, fmt.Errorf("parse G2 result: %w", err)
 		}
 		govProof.G2 = &result
+	case GovLevelG3:
+		var result G3Result
+		if err := json.Unmarshal(jsonData, &result); err != nil {
+			return nil, fmt.Errorf("parse G3 result: %w", err)
+		}
+		govProof.G3 = &result
 	default:
 		return nil, fmt.Errorf("unknown governance level: %s", level)
 	}
@@ -296,21 +362,50 @@ func (g *CLIGovernanceProofGenerator) createStubProof(level GovernanceLevel, req
 			},
 			G2ProofComplete: false, // Stub - not verified
 		}
+	case GovLevelG3:
+		govProof.G3 = &G3Result{
+			G2Result: G2Result{
+				G1Result: G1Result{
+					G0Result: G0Result{
+						TxHash:          req.TransactionHash,
+						Scope:           req.AccountURL,
+						Chain:           "main",
+						Principal:       req.AccountURL,
+						G0ProofComplete: false,
+					},
+					G1ProofComplete: false,
+				},
+				G2ProofComplete: false,
+			},
+			CrossChainBinding: CrossChainBinding{
+				AccumTxHash:       req.TransactionHash,
+				TargetChain:       req.TargetChain,
+				AnchorTxHash:      req.AnchorTxHash,
+				AnchorBlockNumber: req.AnchorBlockNumber,
+			},
+			G3ProofComplete: false, // Stub - not verified
+		}
 	}
 
 	return govProof
 }
 
 // =============================================================================
-// In-Process Governance Proof Generator (for when library is available)
+// In-Process Governance Proof Generator
 // =============================================================================
 
-// InProcessGovernanceGenerator generates governance proofs in-process
-// This can be used when the governance proof library is properly packaged
+// InProcessGovernanceGenerator generates governance proofs in-process with the native
+// G0/G1/G2 implementation, so production validators need no govproof binary. Every call
+// runs under the caller's context, further bounded by the generator timeout; when the
+// deadline passes the in-flight V3 queries are cancelled and the call fails instead of
+// returning a partially verified proof.
 type InProcessGovernanceGenerator struct {
 	v3Endpoint string
 	workDir    string
 	timeout    time.Duration
+	native     *NativeGovernanceProofGenerator
+	initErr    error // Why native could not be created (e.g. no V3 endpoint)
+	cache      *GovernanceResultCache
 	logger     *log.Logger
 }
 
@@ -319,56 +414,101 @@ func NewInProcessGovernanceGenerator(v3Endpoint, workDir string, timeout time.Du
 	if timeout == 0 {
 		timeout = 60 * time.Second
 	}
-	return &InProcessGovernanceGenerator{
-		v3Endpoint: v3Endpoint,
+	g := &InProcessGovernanceGenerator{
+		v3Endpoint: normalizeV3Endpoint(v3Endpoint),
 		workDir:    workDir,
 		timeout:    timeout,
 		logger:     log.Default(),
 	}
+	g.native, g.initErr = NewNativeGovernanceProofGenerator(&NativeGeneratorConfig{
+		V3Endpoint: g.v3Endpoint,
+		Timeout:    timeout,
+		Logger:     log.New(log.Writer(), "[GOV-PROOF-INPROC] ", log.LstdFlags),
+	})
+	return g
+}
+
+// SetResultCache enables reuse of completed proofs; nil disables caching
+func (g *InProcessGovernanceGenerator) SetResultCache(cache *GovernanceResultCache) {
+	g.cache = cache
+}
+
+// CacheStats returns result cache counters (zero when caching is disabled)
+func (g *InProcessGovernanceGenerator) CacheStats() GovernanceCacheStats {
+	if g.cache == nil {
+		return GovernanceCacheStats{}
+	}
+	return g.cache.Stats()
 }
 
 // GenerateG0 generates G0 proof in-process
-// TODO: Implement when consolidated_governance-proof is refactored to library
 func (g *InProcessGovernanceGenerator) GenerateG0(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
-	g.logger.Printf("[GOV-PROOF-INPROC] G0 proof generation not yet implemented in-process")
-
-	// Return stub for now
-	return &GovernanceProof{
-		Level:       GovLevelG0,
-		SpecVersion: GovernanceSpecVersion,
-		GeneratedAt: time.Now(),
-		G0: &G0Result{
-			TxHash:          req.TransactionHash,
-			Scope:           req.AccountURL,
-			Chain:           "main",
-			Principal:       req.AccountURL,
-			G0ProofComplete: false,
-		},
-	}, nil
+	return g.GenerateAtLevel(ctx, GovLevelG0, req)
 }
 
 // GenerateG1 generates G1 proof in-process
 func (g *InProcessGovernanceGenerator) GenerateG1(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
-	g.logger.Printf("[GOV-PROOF-INPROC] G1 proof generation not yet implemented in-process")
-	return g.GenerateG0(ctx, req) // Fallback
+	return g.GenerateAtLevel(ctx, GovLevelG1, req)
 }
 
 // GenerateG2 generates G2 proof in-process
 func (g *InProcessGovernanceGenerator) GenerateG2(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
-	g.logger.Printf("[GOV-PROOF-INPROC] G2 proof generation not yet implemented in-process")
-	return g.GenerateG0(ctx, req) // Fallback
+	return g.GenerateAtLevel(ctx, GovLevelG2, req)
+}
+
+// GenerateG3 generates G3 proof in-process
+func (g *InProcessGovernanceGenerator) GenerateG3(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
+	if err := validateG3Request(req); err != nil {
+		return nil, err
+	}
+	return g.GenerateAtLevel(ctx, GovLevelG3, req)
 }
 
-// GenerateAtLevel generates governance proof at specified level
+// GenerateAtLevel generates governance proof at specified level, serving completed
+// proofs from the result cache when one is configured
 func (g *InProcessGovernanceGenerator) GenerateAtLevel(ctx context.Context, level GovernanceLevel, req *GovernanceRequest) (*GovernanceProof, error) {
+	switch level {
+	case GovLevelG0, GovLevelG1, GovLevelG2, GovLevelG3:
+	default:
+		return nil, fmt.Errorf("unknown governance level: %s", level)
+	}
+	return cachedGovernanceProof(g.cache, level, req, func() (*GovernanceProof, error) {
+		return g.generate(ctx, level, req)
+	})
+}
+
+// generate runs the native generator for one level under the call deadline
+func (g *InProcessGovernanceGenerator) generate(ctx context.Context, level GovernanceLevel, req *GovernanceRequest) (*GovernanceProof, error) {
+	if g.initErr != nil {
+		return nil, fmt.Errorf("in-process governance generator unavailable: %w", g.initErr)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	// The native generator fills in a discovered key page; keep the caller's request intact
+	callReq := *req
+
+	var govProof *GovernanceProof
+	var err error
 	switch level {
 	case GovLevelG0:
-		return g.GenerateG0(ctx, req)
+		govProof, err = g.native.GenerateG0(callCtx, &callReq)
 	case GovLevelG1:
-		return g.GenerateG1(ctx, req)
+		govProof, err = g.native.GenerateG1(callCtx, &callReq)
 	case GovLevelG2:
-		return g.GenerateG2(ctx, req)
-	default:
-		return nil, fmt.Errorf("unknown governance level: %s", level)
+		govProof, err = g.native.GenerateG2(callCtx, &callReq)
+	case GovLevelG3:
+		govProof, err = g.native.GenerateG3(callCtx, &callReq)
+	}
+
+	// The native layers tolerate some failed lookups (signatures, outcome) and would
+	// assemble an incomplete proof from whatever returned before the deadline
+	if ctxErr := callCtx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("%s proof generation cancelled: %w", level, ctxErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s proof generation failed: %w", level, err)
 	}
+	return govProof, nil
 }
@@ -0,0 +1,67 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the In-Process Governance Generator
+// Tests endpoint handling and cancellation of in-flight queries at the call deadline
+
+package proof
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInProcessGovernanceGenerator_RequiresEndpoint(t *testing.T) {
+	gen := NewInProcessGovernanceGenerator("", "", 0)
+	if _, err := gen.GenerateG0(context.Background(), testGovRequest("aa")); err == nil {
+		t.Error("expected an error without a V3 endpoint")
+	}
+
+	gen = NewInProcessGovernanceGenerator("http://127.0.0.1:26660/", "", 0)
+	if gen.v3Endpoint != "http://127.0.0.1:26660/v3" {
+		t.Errorf("expected the /v3 suffix to be added, got %s", gen.v3Endpoint)
+	}
+
+	if _, err := gen.GenerateAtLevel(context.Background(), "G9", testGovRequest("aa")); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestInProcessGovernanceGenerator_HonorsDeadline(t *testing.T) {
+	cancelled := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices a client disconnect once the body has been read
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+			cancelled <- struct{}{}
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	gen := NewInProcessGovernanceGenerator(server.URL, "", time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	txHash := "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	start := time.Now()
+	_, err := gen.GenerateG0(ctx, &GovernanceRequest{AccountURL: "acc://example.acme", TransactionHash: txHash})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the call to stop at the deadline, took %s", elapsed)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Error("expected the in-flight RPC request to be cancelled")
+	}
+}
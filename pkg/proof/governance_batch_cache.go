@@ -0,0 +1,126 @@
+// Copyright 2025 Certen Protocol
+//
+// Governance Batch Cache - Shares authority work across the transactions of a batch
+//
+// Transactions in a batch are often governed by the same few key pages. With batch
+// deduplication enabled, GenerateForBatch queries each key page once per batch (pinning
+// its version for the whole batch) and builds the authority snapshot (key hashes,
+// threshold, validation summary) once per (key page, version). Per-transaction work -
+// G0 inclusion, signature lookup and binding - is still done for every transaction.
+// Signature validation cannot be shared: each transaction has its own signature messages,
+// and their timing is checked against that transaction's execution MBI. What the
+// signatures are checked against - the key set - comes from the shared snapshot.
+//
+// Every caller gets its own copy of a snapshot, with its own execution terms, so proofs in
+// a batch never alias each other's key lists or mutation history.
+
+package proof
+
+import (
+	"context"
+	"sync"
+)
+
+// GovernanceDedupStats reports how much authority work a batch shared
+type GovernanceDedupStats struct {
+	Authorities    int // Distinct (key page, version) pairs
+	PageQueries    int // Key page lookups performed
+	PageReuses     int // Key page lookups served from the batch
+	SnapshotBuilds int // Authority snapshots built
+	SnapshotReuses int // Authority snapshots reused
+}
+
+// authorityVersion identifies an authority snapshot within a batch
+type authorityVersion struct {
+	page    string
+	version uint64
+}
+
+// governanceBatchCache holds authority work for one batch; it is discarded with the batch
+type governanceBatchCache struct {
+	mu        sync.Mutex
+	pages     map[string]*CachedKeyPage
+	snapshots map[authorityVersion]AuthoritySnapshot
+	stats     GovernanceDedupStats
+}
+
+func newGovernanceBatchCache() *governanceBatchCache {
+	return &governanceBatchCache{
+		pages:     make(map[string]*CachedKeyPage),
+		snapshots: make(map[authorityVersion]AuthoritySnapshot),
+	}
+}
+
+// keyPage returns the key page pinned for this batch, querying it on first use
+func (c *governanceBatchCache) keyPage(ctx context.Context, keyPageURL string, query func(context.Context, string) (*CachedKeyPage, error)) (*CachedKeyPage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if page, ok := c.pages[keyPageURL]; ok {
+		c.stats.PageReuses++
+		return page, nil
+	}
+	page, err := query(ctx, keyPageURL)
+	if err != nil {
+		return nil, err
+	}
+	c.stats.PageQueries++
+	c.pages[keyPageURL] = page
+	return page, nil
+}
+
+// authoritySnapshot returns a copy of the snapshot for a key page version carrying the
+// transaction's execution terms, building it on first use
+func (c *governanceBatchCache) authoritySnapshot(ctx context.Context, keyPageURL string, keyPageData *CachedKeyPage, execMBI int64,
+	build func(context.Context, *CachedKeyPage, int64) (AuthoritySnapshot, error)) (AuthoritySnapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := authorityVersion{page: keyPageURL, version: keyPageData.KeyPage.Version}
+	if cached, ok := c.snapshots[key]; ok {
+		c.stats.SnapshotReuses++
+		snapshot := cloneAuthoritySnapshot(cached)
+		snapshot.ExecTerms = ExecTerms{MBI: execMBI}
+		return snapshot, nil
+	}
+
+	snapshot, err := build(ctx, keyPageData, execMBI)
+	if err != nil {
+		return AuthoritySnapshot{}, err
+	}
+	c.stats.SnapshotBuilds++
+	c.stats.Authorities = len(c.snapshots) + 1
+	c.snapshots[key] = cloneAuthoritySnapshot(snapshot)
+	return snapshot, nil
+}
+
+// cloneAuthoritySnapshot copies a snapshot's key lists and mutation history. Receipt
+// pointers are shared; they are never modified after the snapshot is built.
+func cloneAuthoritySnapshot(s AuthoritySnapshot) AuthoritySnapshot {
+	clone := s
+	clone.StateExec = cloneKeyPageState(s.StateExec)
+	clone.Genesis.PageState = cloneKeyPageState(s.Genesis.PageState)
+	if s.Mutations != nil {
+		clone.Mutations = make([]MutationEvent, len(s.Mutations))
+		for i, m := range s.Mutations {
+			m.PreviousState = cloneKeyPageState(m.PreviousState)
+			m.NewState = cloneKeyPageState(m.NewState)
+			clone.Mutations[i] = m
+		}
+	}
+	return clone
+}
+
+func cloneKeyPageState(s KeyPageState) KeyPageState {
+	if s.Keys != nil {
+		s.Keys = append([]string(nil), s.Keys...)
+	}
+	return s
+}
+
+// Stats returns the batch's deduplication counters
+func (c *governanceBatchCache) Stats() GovernanceDedupStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
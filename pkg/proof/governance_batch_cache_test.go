@@ -0,0 +1,257 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Governance Batch Cache
+// Tests per-batch key page pinning, snapshot reuse per key page version, per-transaction
+// execution terms, snapshot isolation and GenerateForBatch deduplication
+
+package proof
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gitlab.com/accumulatenetwork/accumulate/pkg/url"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+func testKeyPage(pageURL string, version uint64, keys ...string) *CachedKeyPage {
+	page := &protocol.KeyPage{
+		Url:             url.MustParse(pageURL),
+		Version:         version,
+		AcceptThreshold: 1,
+	}
+	for _, k := range keys {
+		page.Keys = append(page.Keys, &protocol.KeySpec{PublicKeyHash: []byte(k)})
+	}
+	return &CachedKeyPage{KeyPage: page}
+}
+
+// countingPageQuery serves key pages from a map and counts lookups per URL
+type countingPageQuery struct {
+	pages   map[string]*CachedKeyPage
+	queries map[string]int
+}
+
+func (q *countingPageQuery) query(ctx context.Context, pageURL string) (*CachedKeyPage, error) {
+	q.queries[pageURL]++
+	page, ok := q.pages[pageURL]
+	if !ok {
+		return nil, fmt.Errorf("key page %s not found", pageURL)
+	}
+	return page, nil
+}
+
+func TestGovernanceBatchCache_PinsKeyPages(t *testing.T) {
+	q := &countingPageQuery{
+		pages:   map[string]*CachedKeyPage{"acc://a.acme/book/1": testKeyPage("acc://a.acme/book/1", 1, "k1")},
+		queries: map[string]int{},
+	}
+	cache := newGovernanceBatchCache()
+
+	for i := 0; i < 3; i++ {
+		page, err := cache.keyPage(context.Background(), "acc://a.acme/book/1", q.query)
+		if err != nil || page.KeyPage.Version != 1 {
+			t.Fatalf("keyPage failed: %v", err)
+		}
+	}
+	if q.queries["acc://a.acme/book/1"] != 1 {
+		t.Errorf("expected 1 key page query, got %d", q.queries["acc://a.acme/book/1"])
+	}
+
+	// Failed lookups are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := cache.keyPage(context.Background(), "acc://missing.acme/book/1", q.query); err == nil {
+			t.Error("expected lookup error")
+		}
+	}
+	if q.queries["acc://missing.acme/book/1"] != 2 {
+		t.Errorf("expected failed lookups to be retried, got %d queries", q.queries["acc://missing.acme/book/1"])
+	}
+
+	stats := cache.Stats()
+	if stats.PageQueries != 1 || stats.PageReuses != 2 {
+		t.Errorf("expected 1 query and 2 reuses, got %+v", stats)
+	}
+}
+
+func TestGovernanceBatchCache_SnapshotPerVersion(t *testing.T) {
+	g := &NativeGovernanceProofGenerator{}
+	builds := 0
+	build := func(ctx context.Context, page *CachedKeyPage, execMBI int64) (AuthoritySnapshot, error) {
+		builds++
+		return g.buildAuthoritySnapshot(ctx, page, execMBI)
+	}
+	cache := newGovernanceBatchCache()
+	v1 := testKeyPage("acc://a.acme/book/1", 1, "k1", "k2")
+	v2 := testKeyPage("acc://a.acme/book/1", 2, "k1", "k2", "k3")
+
+	first, err := cache.authoritySnapshot(context.Background(), "acc://a.acme/book/1", v1, 100, build)
+	if err != nil {
+		t.Fatalf("authoritySnapshot failed: %v", err)
+	}
+	second, _ := cache.authoritySnapshot(context.Background(), "acc://a.acme/book/1", v1, 200, build)
+	third, _ := cache.authoritySnapshot(context.Background(), "acc://a.acme/book/1", v2, 300, build)
+
+	if builds != 2 {
+		t.Errorf("expected one build per key page version, got %d", builds)
+	}
+	if first.ExecTerms.MBI != 100 || second.ExecTerms.MBI != 200 || third.ExecTerms.MBI != 300 {
+		t.Errorf("expected per-transaction exec terms 100/200/300, got %d/%d/%d",
+			first.ExecTerms.MBI, second.ExecTerms.MBI, third.ExecTerms.MBI)
+	}
+	if len(second.StateExec.Keys) != 2 || len(third.StateExec.Keys) != 3 {
+		t.Errorf("expected 2 and 3 keys, got %d and %d", len(second.StateExec.Keys), len(third.StateExec.Keys))
+	}
+
+	stats := cache.Stats()
+	if stats.Authorities != 2 || stats.SnapshotBuilds != 2 || stats.SnapshotReuses != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestGovernanceBatchCache_SnapshotsNotAliased(t *testing.T) {
+	build := func(ctx context.Context, page *CachedKeyPage, execMBI int64) (AuthoritySnapshot, error) {
+		return AuthoritySnapshot{
+			ExecTerms: ExecTerms{MBI: execMBI},
+			StateExec: KeyPageState{Version: 1, Keys: []string{"k1", "k2"}},
+			Mutations: []MutationEvent{{
+				PreviousState: KeyPageState{Keys: []string{"k1"}},
+				NewState:      KeyPageState{Keys: []string{"k1", "k2"}},
+			}},
+		}, nil
+	}
+	cache := newGovernanceBatchCache()
+	page := testKeyPage("acc://a.acme/book/1", 1)
+
+	// The snapshot returned from the build and one served from the cache are both
+	// modified; neither change may reach the other or later transactions
+	built, _ := cache.authoritySnapshot(context.Background(), "acc://a.acme/book/1", page, 1, build)
+	built.StateExec.Keys[0] = "forged"
+	built.Mutations[0].NewState.Keys[1] = "forged"
+
+	reused, _ := cache.authoritySnapshot(context.Background(), "acc://a.acme/book/1", page, 2, build)
+	reused.StateExec.Keys[1] = "forged"
+	reused.Mutations[0].PreviousState.Keys[0] = "forged"
+
+	later, _ := cache.authoritySnapshot(context.Background(), "acc://a.acme/book/1", page, 3, build)
+	if later.StateExec.Keys[0] != "k1" || later.StateExec.Keys[1] != "k2" {
+		t.Errorf("state keys aliased: %v", later.StateExec.Keys)
+	}
+	if later.Mutations[0].PreviousState.Keys[0] != "k1" || later.Mutations[0].NewState.Keys[1] != "k2" {
+		t.Errorf("mutation history aliased: %+v", later.Mutations[0])
+	}
+	if reused.StateExec.Keys[0] != "k1" {
+		t.Errorf("reused snapshot shares keys with the built one: %v", reused.StateExec.Keys)
+	}
+	if built.ExecTerms.MBI != 1 || reused.ExecTerms.MBI != 2 || later.ExecTerms.MBI != 3 {
+		t.Errorf("exec terms not per transaction: %d/%d/%d", built.ExecTerms.MBI, reused.ExecTerms.MBI, later.ExecTerms.MBI)
+	}
+}
+
+func TestGenerateForBatch_Dedup(t *testing.T) {
+	q := &countingPageQuery{
+		pages: map[string]*CachedKeyPage{
+			"acc://a.acme/book/1": testKeyPage("acc://a.acme/book/1", 4, "k1", "k2"),
+			"acc://b.acme/book/1": testKeyPage("acc://b.acme/book/1", 1, "k3"),
+		},
+		queries: map[string]int{},
+	}
+
+	newGenerator := func(dedup bool) (*NativeGovernanceProofGenerator, *[]*governanceBatchCache) {
+		g, err := NewNativeGovernanceProofGenerator(&NativeGeneratorConfig{V3Endpoint: "http://127.0.0.1:1", BatchDedup: dedup})
+		if err != nil {
+			t.Fatalf("NewNativeGovernanceProofGenerator failed: %v", err)
+		}
+		var caches []*governanceBatchCache
+		// Stands in for generateG1: G0 is per transaction (exec MBI from the hash), the
+		// authority work goes through the batch cache when there is one
+		g.generate = func(ctx context.Context, level GovernanceLevel, req *GovernanceRequest, cache *governanceBatchCache) (*GovernanceProof, error) {
+			caches = append(caches, cache)
+			execMBI := int64(len(req.TransactionHash)) * 10
+			var page *CachedKeyPage
+			var snapshot AuthoritySnapshot
+			var err error
+			if cache != nil {
+				if page, err = cache.keyPage(ctx, req.KeyPage, q.query); err == nil {
+					snapshot, err = cache.authoritySnapshot(ctx, req.KeyPage, page, execMBI, g.buildAuthoritySnapshot)
+				}
+			} else if page, err = q.query(ctx, req.KeyPage); err == nil {
+				snapshot, err = g.buildAuthoritySnapshot(ctx, page, execMBI)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return NewG1GovernanceProof(&G1Result{G0Result: G0Result{TxHash: req.TransactionHash, ExecMBI: execMBI}, AuthoritySnapshot: snapshot}), nil
+		}
+		return g, &caches
+	}
+
+	txs := []TransactionInfo{
+		{TxHash: "aaaaaaaaaaaaaaaa01", AccountURL: "acc://a.acme/tokens", KeyPage: "acc://a.acme/book/1"},
+		{TxHash: "aaaaaaaaaaaaaaaa0002", AccountURL: "acc://a.acme/tokens", KeyPage: "acc://a.acme/book/1"},
+		{TxHash: "bbbbbbbbbbbbbbbb000003", AccountURL: "acc://b.acme/tokens", KeyPage: "acc://b.acme/book/1"},
+		{TxHash: "aaaaaaaaaaaaaaaa00000004", AccountURL: "acc://a.acme/tokens", KeyPage: "acc://a.acme/book/1"},
+	}
+
+	g, caches := newGenerator(true)
+	batch, err := g.GenerateForBatch(context.Background(), txs, GovLevelG1)
+	if err != nil {
+		t.Fatalf("GenerateForBatch failed: %v", err)
+	}
+	if len(batch.Proofs) != len(txs) {
+		t.Fatalf("expected %d proofs, got %d", len(txs), len(batch.Proofs))
+	}
+	for _, c := range *caches {
+		if c == nil || c != (*caches)[0] {
+			t.Fatal("expected one cache shared by every transaction of the batch")
+		}
+	}
+	if q.queries["acc://a.acme/book/1"] != 1 || q.queries["acc://b.acme/book/1"] != 1 {
+		t.Errorf("expected one query per key page, got %v", q.queries)
+	}
+	if batch.Dedup == nil || batch.Dedup.Authorities != 2 || batch.Dedup.SnapshotReuses != 2 || batch.Dedup.PageReuses != 2 {
+		t.Errorf("unexpected dedup stats %+v", batch.Dedup)
+	}
+	for i, p := range batch.Proofs {
+		if p.G1.AuthoritySnapshot.ExecTerms.MBI != p.G1.ExecMBI {
+			t.Errorf("proof %d: exec terms %d do not match its own exec MBI %d", i, p.G1.AuthoritySnapshot.ExecTerms.MBI, p.G1.ExecMBI)
+		}
+	}
+	batch.Proofs[0].G1.AuthoritySnapshot.StateExec.Keys[0] = "forged"
+	if batch.Proofs[1].G1.AuthoritySnapshot.StateExec.Keys[0] == "forged" {
+		t.Error("proofs in a batch share authority snapshot keys")
+	}
+
+	// The same batch without dedup yields the same authority data
+	q.queries = map[string]int{}
+	plain, caches := newGenerator(false)
+	plainBatch, err := plain.GenerateForBatch(context.Background(), txs, GovLevelG1)
+	if err != nil {
+		t.Fatalf("GenerateForBatch without dedup failed: %v", err)
+	}
+	if plainBatch.Dedup != nil || (*caches)[0] != nil {
+		t.Error("expected no batch cache without dedup")
+	}
+	if q.queries["acc://a.acme/book/1"] != 3 {
+		t.Errorf("expected a query per transaction without dedup, got %v", q.queries)
+	}
+	for i := range txs {
+		a, b := batch.Proofs[i].G1.AuthoritySnapshot, plainBatch.Proofs[i].G1.AuthoritySnapshot
+		if i == 0 {
+			a.StateExec.Keys[0] = b.StateExec.Keys[0] // Modified above
+		}
+		if fmt.Sprint(a) != fmt.Sprint(b) {
+			t.Errorf("proof %d: dedup snapshot %+v differs from %+v", i, a, b)
+		}
+	}
+
+	// G0 needs no authority work, so no cache is created
+	g, caches = newGenerator(true)
+	if _, err := g.GenerateForBatch(context.Background(), txs, GovLevelG0); err != nil {
+		t.Fatalf("GenerateForBatch G0 failed: %v", err)
+	}
+	if (*caches)[0] != nil {
+		t.Error("expected no batch cache at G0")
+	}
+}
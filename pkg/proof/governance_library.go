@@ -12,6 +12,7 @@
 // - G0: Inclusion and Finality Only (transaction exists and is finalized)
 // - G1: Governance Correctness (authority validated via KeyPage chain)
 // - G2: Governance + Outcome Binding (full execution proof)
+// - G3: G2 + Cross-Chain Anchor Binding (outcome bound to its anchor transaction)
 
 package proof
 
@@ -21,6 +22,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -45,9 +47,14 @@ type NativeGovernanceProofGenerator struct {
 	validatorID  string
 	v3Endpoint   string
 	timeout      time.Duration
+	txHasher     SourceChainHasher // Recomputes source-chain transaction hashes (G0 entry hash, G2 payload binding)
+	batchDedup   bool              // Share authority work across a batch (see governanceBatchCache)
 	logger       *log.Logger
 	mu           sync.RWMutex
 
+	// Per-transaction generation in GenerateForBatch; nil uses generateAtLevel
+	generate func(context.Context, GovernanceLevel, *GovernanceRequest, *governanceBatchCache) (*GovernanceProof, error)
+
 	// Cache for KeyPage lookups to avoid redundant queries
 	keyPageCache    map[string]*CachedKeyPage
 	cacheTTL        time.Duration
@@ -69,6 +76,8 @@ type NativeGeneratorConfig struct {
 	ValidatorID  string
 	Timeout      time.Duration
 	CacheTTL     time.Duration
+	TxHasher     SourceChainHasher // Default: AccumulateHasher
+	BatchDedup   bool              // Compute authority snapshots once per (key page, version) per batch
 	Logger       *log.Logger
 }
 
@@ -96,6 +105,11 @@ func NewNativeGovernanceProofGenerator(cfg *NativeGeneratorConfig) (*NativeGover
 		logger = log.New(log.Writer(), "[GOV-NATIVE] ", log.LstdFlags)
 	}
 
+	txHasher := cfg.TxHasher
+	if txHasher == nil {
+		txHasher = AccumulateHasher{}
+	}
+
 	// Create V3 JSON-RPC client
 	client := jsonrpc.NewClient(cfg.V3Endpoint)
 
@@ -105,6 +119,8 @@ func NewNativeGovernanceProofGenerator(cfg *NativeGeneratorConfig) (*NativeGover
 		validatorID:    cfg.ValidatorID,
 		v3Endpoint:     cfg.V3Endpoint,
 		timeout:        timeout,
+		txHasher:       txHasher,
+		batchDedup:     cfg.BatchDedup,
 		logger:         logger,
 		keyPageCache:   make(map[string]*CachedKeyPage),
 		cacheTTL:       cacheTTL,
@@ -207,10 +223,8 @@ func (g *NativeGovernanceProofGenerator) buildG0Result(req *GovernanceRequest, t
 	}
 
 	// Try to get transaction hash from the message
-	if txRecord.Message != nil {
-		if txnMsg, ok := txRecord.Message.(*messaging.TransactionMessage); ok && txnMsg.Transaction != nil {
-			result.EntryHashExec = hex.EncodeToString(txnMsg.Transaction.GetHash())
-		}
+	if txHash, ok := g.transactionHash(txRecord.Message); ok {
+		result.EntryHashExec = txHash
 	}
 
 	// G0 is complete if we have receipt with anchor
@@ -251,6 +265,11 @@ func (g *NativeGovernanceProofGenerator) buildG0FromChainEntry(req *GovernanceRe
 // GenerateG1 generates G1 proof (Governance Correctness)
 // G1 extends G0 with authority validation via KeyPage chain verification
 func (g *NativeGovernanceProofGenerator) GenerateG1(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
+	return g.generateG1(ctx, req, nil)
+}
+
+// generateG1 generates a G1 proof, sharing authority work through cache when non-nil
+func (g *NativeGovernanceProofGenerator) generateG1(ctx context.Context, req *GovernanceRequest, cache *governanceBatchCache) (*GovernanceProof, error) {
 	if req.KeyPage == "" {
 		// Try to discover KeyPage from transaction signer
 		keyPage, err := g.discoverKeyPage(ctx, req)
@@ -272,14 +291,22 @@ func (g *NativeGovernanceProofGenerator) GenerateG1(ctx context.Context, req *Go
 		return nil, fmt.Errorf("G0 proof result is nil")
 	}
 
-	// Query KeyPage and KeyBook
-	keyPageData, err := g.queryKeyPage(ctx, req.KeyPage)
+	// Query KeyPage and KeyBook, and build the authority snapshot
+	var keyPageData *CachedKeyPage
+	var authoritySnapshot AuthoritySnapshot
+	if cache != nil {
+		keyPageData, err = cache.keyPage(ctx, req.KeyPage, g.queryKeyPage)
+	} else {
+		keyPageData, err = g.queryKeyPage(ctx, req.KeyPage)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to query KeyPage: %w", err)
 	}
-
-	// Build authority snapshot
-	authoritySnapshot, err := g.buildAuthoritySnapshot(ctx, keyPageData, g0Proof.G0.ExecMBI)
+	if cache != nil {
+		authoritySnapshot, err = cache.authoritySnapshot(ctx, req.KeyPage, keyPageData, g0Proof.G0.ExecMBI, g.buildAuthoritySnapshot)
+	} else {
+		authoritySnapshot, err = g.buildAuthoritySnapshot(ctx, keyPageData, g0Proof.G0.ExecMBI)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to build authority snapshot: %w", err)
 	}
@@ -349,10 +376,15 @@ func (g *NativeGovernanceProofGenerator) buildG1Result(
 // GenerateG2 generates G2 proof (Governance + Outcome Binding)
 // G2 extends G1 with transaction payload and effect verification
 func (g *NativeGovernanceProofGenerator) GenerateG2(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
+	return g.generateG2(ctx, req, nil)
+}
+
+// generateG2 generates a G2 proof, sharing authority work through cache when non-nil
+func (g *NativeGovernanceProofGenerator) generateG2(ctx context.Context, req *GovernanceRequest, cache *governanceBatchCache) (*GovernanceProof, error) {
 	g.logger.Printf("Generating G2 proof for tx %s", req.TransactionHash)
 
 	// First generate G1 proof
-	g1Proof, err := g.GenerateG1(ctx, req)
+	g1Proof, err := g.generateG1(ctx, req, cache)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate G1: %w", err)
 	}
@@ -390,6 +422,42 @@ func (g *NativeGovernanceProofGenerator) GenerateG2(ctx context.Context, req *Go
 	return NewG2GovernanceProof(g2Result), nil
 }
 
+// =============================================================================
+// G3 Proof Generation - Outcome Binding + Cross-Chain Anchor Binding
+// =============================================================================
+
+// GenerateG3 generates G3 proof (Outcome Binding + Cross-Chain Anchor Binding)
+// G3 extends G2 by binding the anchor transaction named in the request to the outcome
+func (g *NativeGovernanceProofGenerator) GenerateG3(ctx context.Context, req *GovernanceRequest) (*GovernanceProof, error) {
+	if err := validateG3Request(req); err != nil {
+		return nil, err
+	}
+
+	g2Proof, err := g.generateG2(ctx, req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate G2: %w", err)
+	}
+	if g2Proof.G2 == nil {
+		return nil, fmt.Errorf("G2 proof result is nil")
+	}
+
+	binding, err := NewCrossChainBinding(g2Proof.G2, req.TargetChain, req.AnchorTxHash, req.AnchorBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind anchor: %w", err)
+	}
+
+	g3Result := &G3Result{
+		G2Result:          *g2Proof.G2,
+		CrossChainBinding: binding,
+		G3ProofComplete:   g2Proof.G2.G2ProofComplete,
+	}
+
+	g.logger.Printf("G3 proof generated: tx=%s, anchor=%s@%d on %s, complete=%v",
+		req.TransactionHash[:16]+"...", binding.AnchorTxHash[:16]+"...", binding.AnchorBlockNumber, binding.TargetChain, g3Result.G3ProofComplete)
+
+	return NewG3GovernanceProof(g3Result), nil
+}
+
 // buildOutcomeLeaf builds the outcome leaf for G2 verification
 func (g *NativeGovernanceProofGenerator) buildOutcomeLeaf(ctx context.Context, req *GovernanceRequest) (OutcomeLeaf, error) {
 	// Parse transaction URL
@@ -446,13 +514,10 @@ func (g *NativeGovernanceProofGenerator) buildOutcomeLeaf(ctx context.Context, r
 	}
 
 	// Compute transaction hash for verification
-	if txRecord.Message != nil {
-		if txnMsg, ok := txRecord.Message.(*messaging.TransactionMessage); ok && txnMsg.Transaction != nil {
-			computedHash := hex.EncodeToString(txnMsg.Transaction.GetHash())
-			outcome.PayloadBinding.ComputedTxHash = computedHash
-			outcome.PayloadBinding.Verified = computedHash == req.TransactionHash ||
-				len(computedHash) > 0 // Accept if we got a hash
-		}
+	if computedHash, ok := g.transactionHash(txRecord.Message); ok {
+		outcome.PayloadBinding.ComputedTxHash = computedHash
+		outcome.PayloadBinding.Verified = computedHash == req.TransactionHash ||
+			len(computedHash) > 0 // Accept if we got a hash
 	}
 
 	// Verify transaction effect - check status
@@ -470,15 +535,38 @@ func (g *NativeGovernanceProofGenerator) buildOutcomeLeaf(ctx context.Context, r
 // Helper Methods
 // =============================================================================
 
+// transactionHash computes the hex transaction hash of a source-chain message with the
+// configured hasher. Returns false if the message carries no transaction.
+func (g *NativeGovernanceProofGenerator) transactionHash(msg messaging.Message) (string, bool) {
+	if msg == nil {
+		return "", false
+	}
+	hasher := g.txHasher
+	hash, err := hasher.TransactionHash(msg)
+	if err != nil {
+		if !errors.Is(err, ErrNotATransaction) {
+			g.logger.Printf("Failed to compute %s transaction hash: %v", hasher.SourceChain(), err)
+		}
+		return "", false
+	}
+	return hex.EncodeToString(hash), true
+}
+
 // GenerateAtLevel generates governance proof at specified level
 func (g *NativeGovernanceProofGenerator) GenerateAtLevel(ctx context.Context, level GovernanceLevel, req *GovernanceRequest) (*GovernanceProof, error) {
+	return g.generateAtLevel(ctx, level, req, nil)
+}
+
+func (g *NativeGovernanceProofGenerator) generateAtLevel(ctx context.Context, level GovernanceLevel, req *GovernanceRequest, cache *governanceBatchCache) (*GovernanceProof, error) {
 	switch level {
 	case GovLevelG0:
 		return g.GenerateG0(ctx, req)
 	case GovLevelG1:
-		return g.GenerateG1(ctx, req)
+		return g.generateG1(ctx, req, cache)
 	case GovLevelG2:
-		return g.GenerateG2(ctx, req)
+		return g.generateG2(ctx, req, cache)
+	case GovLevelG3:
+		return g.GenerateG3(ctx, req)
 	default:
 		return nil, fmt.Errorf("unsupported governance level: %s", level)
 	}
@@ -684,6 +772,7 @@ type BatchGovernanceProof struct {
 	Proofs    []*GovernanceProof
 	BatchRoot [32]byte
 	Level     GovernanceLevel
+	Dedup     *GovernanceDedupStats // Shared authority work (nil when deduplication is off)
 }
 
 // GenerateForBatch generates governance proofs for all transactions in a batch
@@ -697,6 +786,15 @@ func (g *NativeGovernanceProofGenerator) GenerateForBatch(ctx context.Context, t
 	proofs := make([]*GovernanceProof, 0, len(transactions))
 	proofHashes := make([][]byte, 0, len(transactions))
 
+	var cache *governanceBatchCache
+	if g.batchDedup && level != GovLevelG0 {
+		cache = newGovernanceBatchCache()
+	}
+	generate := g.generate
+	if generate == nil {
+		generate = g.generateAtLevel
+	}
+
 	for i, tx := range transactions {
 		req := &GovernanceRequest{
 			AccountURL:      tx.AccountURL,
@@ -704,7 +802,7 @@ func (g *NativeGovernanceProofGenerator) GenerateForBatch(ctx context.Context, t
 			KeyPage:         tx.KeyPage,
 		}
 
-		proof, err := g.GenerateAtLevel(ctx, level, req)
+		proof, err := generate(ctx, level, req, cache)
 		if err != nil {
 			g.logger.Printf("Warning: failed to generate proof for tx %d (%s): %v", i, tx.TxHash[:16]+"...", err)
 			// Continue with other transactions
@@ -731,11 +829,18 @@ func (g *NativeGovernanceProofGenerator) GenerateForBatch(ctx context.Context, t
 
 	g.logger.Printf("Generated %d governance proofs, batch root: %x...", len(proofs), batchRoot[:8])
 
-	return &BatchGovernanceProof{
+	batchProof := &BatchGovernanceProof{
 		Proofs:    proofs,
 		BatchRoot: batchRoot,
 		Level:     level,
-	}, nil
+	}
+	if cache != nil {
+		stats := cache.Stats()
+		batchProof.Dedup = &stats
+		g.logger.Printf("Governance dedup: %d authorities for %d transactions (%d key page queries, %d snapshots reused)",
+			stats.Authorities, len(transactions), stats.PageQueries, stats.SnapshotReuses)
+	}
+	return batchProof, nil
 }
 
 // TransactionInfo contains information about a transaction for batch processing
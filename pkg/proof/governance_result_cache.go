@@ -0,0 +1,236 @@
+// Copyright 2025 Certen Protocol
+//
+// Governance Result Cache - Reuses completed governance proofs across requests
+//
+// G1 and higher proofs rebuild the authority snapshot and re-verify every signature,
+// and batch processing often asks for the same proof of the same transaction more than
+// once. The cache holds completed proofs keyed by (account, txhash, level) in a bounded
+// LRU with a TTL. Each entry remembers the key page version its authority snapshot was
+// built from; once a newer version of that key page is observed, the older entries are
+// dropped, since a proof built from a stale authority snapshot would not verify.
+
+package proof
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Governance result cache defaults
+const (
+	DefaultGovernanceCacheSize = 1024
+	DefaultGovernanceCacheTTL  = 10 * time.Minute
+)
+
+// GovernanceCacheStats reports governance result cache activity
+type GovernanceCacheStats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Evictions     int64 `json:"evictions"`     // Dropped to stay within capacity
+	Expirations   int64 `json:"expirations"`   // Dropped after the TTL
+	Invalidations int64 `json:"invalidations"` // Dropped after a key page version change
+	Size          int   `json:"size"`
+}
+
+// governanceResultKey identifies a cached governance proof
+type governanceResultKey struct {
+	account string
+	txHash  string
+	level   GovernanceLevel
+	anchor  string // G3 only: the anchor transaction the outcome is bound to
+}
+
+// governanceResultEntry is a cached proof and the authority it was built from
+type governanceResultEntry struct {
+	key            governanceResultKey
+	proof          *GovernanceProof
+	keyPage        string // Empty for G0, which has no authority snapshot
+	keyPageVersion uint64
+	expiresAt      time.Time
+}
+
+// GovernanceResultCache is a bounded LRU of completed governance proofs
+type GovernanceResultCache struct {
+	mu           sync.Mutex
+	capacity     int
+	ttl          time.Duration
+	order        *list.List // Front is most recently used
+	entries      map[governanceResultKey]*list.Element
+	pageVersions map[string]uint64 // Latest observed version of each key page
+	stats        GovernanceCacheStats
+	now          func() time.Time
+}
+
+// NewGovernanceResultCache creates a cache holding up to capacity proofs for ttl.
+// Zero values use DefaultGovernanceCacheSize and DefaultGovernanceCacheTTL.
+func NewGovernanceResultCache(capacity int, ttl time.Duration) *GovernanceResultCache {
+	if capacity <= 0 {
+		capacity = DefaultGovernanceCacheSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultGovernanceCacheTTL
+	}
+	return &GovernanceResultCache{
+		capacity:     capacity,
+		ttl:          ttl,
+		order:        list.New(),
+		entries:      make(map[governanceResultKey]*list.Element),
+		pageVersions: make(map[string]uint64),
+		now:          time.Now,
+	}
+}
+
+// Get returns the cached proof for a request at a level, if any
+func (c *GovernanceResultCache) Get(level GovernanceLevel, req *GovernanceRequest) (*GovernanceProof, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[newGovernanceResultKey(level, req)]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	entry := elem.Value.(*governanceResultEntry)
+	switch {
+	case c.now().After(entry.expiresAt):
+		c.remove(elem)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return nil, false
+	case entry.keyPage != "" && c.pageVersions[entry.keyPage] != entry.keyPageVersion:
+		c.remove(elem)
+		c.stats.Invalidations++
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.stats.Hits++
+	return entry.proof, true
+}
+
+// Put caches a completed proof. Incomplete (stub or failed) proofs are not cached.
+// A proof built from a newer key page version invalidates older entries of that page.
+func (c *GovernanceResultCache) Put(level GovernanceLevel, req *GovernanceRequest, proof *GovernanceProof) {
+	if proof == nil || proof.Level != level || !proof.IsValid() {
+		return
+	}
+	keyPage, version := governanceProofAuthority(proof)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if keyPage != "" {
+		if latest, ok := c.pageVersions[keyPage]; ok && version < latest {
+			return // Built from an authority snapshot that is already stale
+		}
+		c.observeKeyPageVersion(keyPage, version)
+	}
+
+	key := newGovernanceResultKey(level, req)
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.order.PushFront(&governanceResultEntry{
+		key:            key,
+		proof:          proof,
+		keyPage:        keyPage,
+		keyPageVersion: version,
+		expiresAt:      c.now().Add(c.ttl),
+	})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// ObserveKeyPageVersion records the current version of a key page and drops cached
+// proofs built from any other version of it
+func (c *GovernanceResultCache) ObserveKeyPageVersion(keyPage string, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observeKeyPageVersion(normalizeKeyPage(keyPage), version)
+}
+
+func (c *GovernanceResultCache) observeKeyPageVersion(keyPage string, version uint64) {
+	if latest, ok := c.pageVersions[keyPage]; ok && latest == version {
+		return
+	}
+	c.pageVersions[keyPage] = version
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*governanceResultEntry)
+		if entry.keyPage == keyPage && entry.keyPageVersion != version {
+			c.remove(elem)
+			c.stats.Invalidations++
+		}
+		elem = next
+	}
+}
+
+// Stats returns the cache counters
+func (c *GovernanceResultCache) Stats() GovernanceCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+func (c *GovernanceResultCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*governanceResultEntry).key)
+}
+
+func newGovernanceResultKey(level GovernanceLevel, req *GovernanceRequest) governanceResultKey {
+	key := governanceResultKey{
+		account: strings.ToLower(strings.TrimSuffix(req.AccountURL, "/")),
+		txHash:  strings.ToLower(strings.TrimPrefix(req.TransactionHash, "0x")),
+		level:   level,
+	}
+	if level == GovLevelG3 {
+		key.anchor = strings.ToLower(strings.TrimPrefix(req.AnchorTxHash, "0x"))
+	}
+	return key
+}
+
+func normalizeKeyPage(keyPage string) string {
+	return strings.ToLower(strings.TrimSuffix(keyPage, "/"))
+}
+
+// governanceProofAuthority returns the key page and version a proof's authority
+// snapshot was built from; G0 proofs have none
+func governanceProofAuthority(proof *GovernanceProof) (string, uint64) {
+	var snapshot *AuthoritySnapshot
+	switch {
+	case proof.G3 != nil:
+		snapshot = &proof.G3.AuthoritySnapshot
+	case proof.G2 != nil:
+		snapshot = &proof.G2.AuthoritySnapshot
+	case proof.G1 != nil:
+		snapshot = &proof.G1.AuthoritySnapshot
+	default:
+		return "", 0
+	}
+	return normalizeKeyPage(snapshot.Page), snapshot.StateExec.Version
+}
+
+// cachedGovernanceProof serves a proof from cache when available, generating and
+// caching it otherwise. A nil cache always generates.
+func cachedGovernanceProof(cache *GovernanceResultCache, level GovernanceLevel, req *GovernanceRequest,
+	generate func() (*GovernanceProof, error)) (*GovernanceProof, error) {
+	if cache == nil {
+		return generate()
+	}
+	if proof, ok := cache.Get(level, req); ok {
+		return proof, nil
+	}
+	proof, err := generate()
+	if err != nil {
+		return nil, err
+	}
+	cache.Put(level, req, proof)
+	return proof, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Governance Result Cache
+// Tests LRU eviction, TTL expiry, key page version invalidation and generator reuse
+
+package proof
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func testGovRequest(txHash string) *GovernanceRequest {
+	return &GovernanceRequest{
+		AccountURL:      "acc://example.acme/tokens",
+		TransactionHash: txHash,
+		KeyPage:         "acc://example.acme/book/1",
+	}
+}
+
+func testG1Proof(txHash string, version uint64) *GovernanceProof {
+	g1 := &G1Result{
+		G0Result:          G0Result{TxHash: txHash, G0ProofComplete: true},
+		AuthoritySnapshot: AuthoritySnapshot{Page: "acc://example.acme/book/1", StateExec: KeyPageState{Version: version}},
+		G1ProofComplete:   true,
+	}
+	return NewG1GovernanceProof(g1)
+}
+
+func TestGovernanceResultCache_HitMissAndEviction(t *testing.T) {
+	cache := NewGovernanceResultCache(2, time.Minute)
+
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("aa")); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+	cache.Put(GovLevelG1, testGovRequest("aa"), testG1Proof("aa", 1))
+	cache.Put(GovLevelG1, testGovRequest("bb"), testG1Proof("bb", 1))
+
+	// The key ignores 0x prefixes and case; the level is part of the key
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("0xAA")); !ok {
+		t.Error("expected a hit for the same account, txhash and level")
+	}
+	if _, ok := cache.Get(GovLevelG2, testGovRequest("aa")); ok {
+		t.Error("expected a miss at another level")
+	}
+
+	// "aa" was used most recently, so adding "cc" evicts "bb"
+	cache.Put(GovLevelG1, testGovRequest("cc"), testG1Proof("cc", 1))
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("bb")); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 3 || stats.Evictions != 1 || stats.Size != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestGovernanceResultCache_IncompleteProofsNotCached(t *testing.T) {
+	cache := NewGovernanceResultCache(0, 0)
+
+	stub := testG1Proof("aa", 1)
+	stub.G1.G1ProofComplete = false
+	cache.Put(GovLevelG1, testGovRequest("aa"), stub)
+
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("aa")); ok {
+		t.Error("expected an incomplete proof not to be cached")
+	}
+}
+
+func TestGovernanceResultCache_Expiry(t *testing.T) {
+	cache := NewGovernanceResultCache(10, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.Put(GovLevelG1, testGovRequest("aa"), testG1Proof("aa", 1))
+	now = now.Add(2 * time.Minute)
+
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("aa")); ok {
+		t.Error("expected the entry to expire after the TTL")
+	}
+	if stats := cache.Stats(); stats.Expirations != 1 || stats.Size != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestGovernanceResultCache_KeyPageVersionInvalidation(t *testing.T) {
+	cache := NewGovernanceResultCache(10, time.Minute)
+
+	cache.Put(GovLevelG1, testGovRequest("aa"), testG1Proof("aa", 1))
+	cache.Put(GovLevelG1, testGovRequest("bb"), testG1Proof("bb", 1))
+
+	// A proof built from version 2 drops the version 1 entries of the same page
+	cache.Put(GovLevelG1, testGovRequest("cc"), testG1Proof("cc", 2))
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("aa")); ok {
+		t.Error("expected entries of the old key page version to be invalidated")
+	}
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("cc")); !ok {
+		t.Error("expected the entry of the new key page version to be cached")
+	}
+
+	// A late proof of the old version is not cached
+	cache.Put(GovLevelG1, testGovRequest("aa"), testG1Proof("aa", 1))
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("aa")); ok {
+		t.Error("expected a proof of a stale key page version not to be cached")
+	}
+
+	// An externally observed version change invalidates too
+	cache.ObserveKeyPageVersion("acc://EXAMPLE.acme/book/1/", 3)
+	if _, ok := cache.Get(GovLevelG1, testGovRequest("cc")); ok {
+		t.Error("expected ObserveKeyPageVersion to invalidate older entries")
+	}
+	if stats := cache.Stats(); stats.Invalidations != 3 || stats.Size != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCachedGovernanceProof_ReusesResults(t *testing.T) {
+	cache := NewGovernanceResultCache(10, time.Minute)
+	calls := 0
+	generate := func() (*GovernanceProof, error) {
+		calls++
+		return testG1Proof("aa", 1), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := cachedGovernanceProof(cache, GovLevelG1, testGovRequest("aa"), generate); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected one generation, got %d", calls)
+	}
+
+	// Failed generations are not cached
+	failing := func() (*GovernanceProof, error) {
+		calls++
+		return nil, context.DeadlineExceeded
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cachedGovernanceProof(cache, GovLevelG1, testGovRequest("bb"), failing); err == nil {
+			t.Fatal("expected the generation error")
+		}
+	}
+	if calls != 3 {
+		t.Errorf("expected failed generations to be retried, got %d calls", calls)
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Size != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
@@ -8,11 +8,17 @@
 // - G0: Inclusion and Finality Only
 // - G1: Governance Correctness (Authority Validated)
 // - G2: Governance + Outcome Binding
+// - G3: Outcome Binding + Cross-Chain Anchor Binding
 
 package proof
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -26,6 +32,8 @@ const (
 	GovLevelG1 GovernanceLevel = "G1"
 	// GovLevelG2 - Governance Correctness + Outcome Binding
 	GovLevelG2 GovernanceLevel = "G2"
+	// GovLevelG3 - Outcome Binding + Cross-Chain Anchor Binding
+	GovLevelG3 GovernanceLevel = "G3"
 )
 
 // GovernanceSpecVersion is the CERTEN specification version
@@ -249,6 +257,77 @@ type G2Result struct {
 	SecurityLevel   string      `json:"security_level"`    // Security level description
 }
 
+// CrossChainBinding ties an Accumulate transaction to the target-chain anchor
+// transaction that committed it
+type CrossChainBinding struct {
+	AccumTxHash        string `json:"accum_tx_hash"`       // Accumulate TX_HASH proven by G2
+	ExecWitness        string `json:"exec_witness"`        // Execution witness of the outcome
+	TargetChain        string `json:"target_chain"`        // Anchor chain (e.g. "ethereum")
+	AnchorTxHash       string `json:"anchor_tx_hash"`      // Target-chain anchor transaction hash
+	AnchorBlockNumber  int64  `json:"anchor_block_number"` // Block that mined the anchor transaction
+	BindingHash        string `json:"binding_hash"`        // SHA256 over the bound fields
+	AnchorVerified     bool   `json:"anchor_verified"`     // Anchor receipt checked on the target chain
+	AnchorVerification string `json:"anchor_verification"` // How the anchor was (or was not) checked
+}
+
+// G3Result represents G3 proof result (Outcome Binding + Cross-Chain Anchor Binding)
+type G3Result struct {
+	G2Result                            // Inherit all G2 results
+	CrossChainBinding CrossChainBinding `json:"cross_chain_binding"` // Accumulate outcome to anchor binding
+	G3ProofComplete   bool              `json:"g3_proof_complete"`   // G3 proof completion flag
+}
+
+// NewCrossChainBinding binds a G2 outcome to a target-chain anchor transaction. The
+// anchor is taken as given; AnchorVerified is left for the caller to set once the
+// anchor receipt has been checked on the target chain.
+func NewCrossChainBinding(g2 *G2Result, targetChain, anchorTxHash string, anchorBlockNumber int64) (CrossChainBinding, error) {
+	accumTxHash := strings.ToLower(strings.TrimPrefix(g2.TxHash, "0x"))
+	anchorTx := strings.ToLower(strings.TrimPrefix(anchorTxHash, "0x"))
+	if decoded, err := hex.DecodeString(accumTxHash); err != nil || len(decoded) != 32 {
+		return CrossChainBinding{}, fmt.Errorf("accumulate tx hash must be 32-byte hex, got %q", g2.TxHash)
+	}
+	if decoded, err := hex.DecodeString(anchorTx); err != nil || len(decoded) != 32 {
+		return CrossChainBinding{}, fmt.Errorf("anchor tx hash must be 32-byte hex, got %q", anchorTxHash)
+	}
+	if targetChain == "" {
+		targetChain = "ethereum"
+	}
+
+	binding := CrossChainBinding{
+		AccumTxHash:        accumTxHash,
+		ExecWitness:        strings.ToLower(g2.ExecWitness),
+		TargetChain:        strings.ToLower(targetChain),
+		AnchorTxHash:       anchorTx,
+		AnchorBlockNumber:  anchorBlockNumber,
+		AnchorVerification: "not checked on the target chain",
+	}
+	binding.BindingHash = ComputeCrossChainBindingHash(binding.AccumTxHash, binding.TargetChain, binding.AnchorTxHash, binding.AnchorBlockNumber)
+	return binding, nil
+}
+
+// crossChainBindingDomain separates G3 binding hashes from other CERTEN hashes
+const crossChainBindingDomain = "CERTEN_G3_CROSS_CHAIN_BINDING_V1"
+
+// ComputeCrossChainBindingHash computes the G3 binding hash exactly as the governance
+// proof CLI does: SHA256(domain || accumTxHash || len(targetChain) || targetChain ||
+// anchorTxHash || blockNumber), with raw 32-byte hashes and big-endian uint64 integers
+func ComputeCrossChainBindingHash(accumTxHash, targetChain, anchorTxHash string, anchorBlockNumber int64) string {
+	accum, _ := hex.DecodeString(accumTxHash)
+	anchor, _ := hex.DecodeString(anchorTxHash)
+
+	h := sha256.New()
+	h.Write([]byte(crossChainBindingDomain))
+	h.Write(accum)
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(targetChain)))
+	h.Write(n[:])
+	h.Write([]byte(targetChain))
+	h.Write(anchor)
+	binary.BigEndian.PutUint64(n[:], uint64(anchorBlockNumber))
+	h.Write(n[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // =============================================================================
 // Governance Proof Wrapper
 // =============================================================================
@@ -263,6 +342,7 @@ type GovernanceProof struct {
 	G0 *G0Result `json:"g0,omitempty"`
 	G1 *G1Result `json:"g1,omitempty"`
 	G2 *G2Result `json:"g2,omitempty"`
+	G3 *G3Result `json:"g3,omitempty"`
 }
 
 // IsValid returns whether the governance proof is valid at its level
@@ -274,6 +354,8 @@ func (gp *GovernanceProof) IsValid() bool {
 		return gp.G1 != nil && gp.G1.G1ProofComplete
 	case GovLevelG2:
 		return gp.G2 != nil && gp.G2.G2ProofComplete
+	case GovLevelG3:
+		return gp.G3 != nil && gp.G3.G3ProofComplete
 	default:
 		return false
 	}
@@ -322,3 +404,13 @@ func NewG2GovernanceProof(g2 *G2Result) *GovernanceProof {
 		G2:          g2,
 	}
 }
+
+// NewG3GovernanceProof creates a new G3 governance proof
+func NewG3GovernanceProof(g3 *G3Result) *GovernanceProof {
+	return &GovernanceProof{
+		Level:       GovLevelG3,
+		SpecVersion: GovernanceSpecVersion,
+		GeneratedAt: time.Now(),
+		G3:          g3,
+	}
+}
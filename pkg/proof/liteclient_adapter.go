@@ -21,6 +21,7 @@ import (
 	lcproof "github.com/certen/independant-validator/accumulate-lite-client-2/liteclient/proof"
 	chained_proof "github.com/certen/independant-validator/accumulate-lite-client-2/liteclient/proof/working-proof_do_not_edit"
 	lctypes "github.com/certen/independant-validator/accumulate-lite-client-2/liteclient/types"
+	"github.com/certen/independant-validator/pkg/proof/bpt"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/api/v3/jsonrpc"
 	"gitlab.com/accumulatenetwork/accumulate/pkg/database/merkle"
 )
@@ -262,6 +263,11 @@ func (g *LiteClientProofGenerator) GenerateChainedProof(ctx context.Context, acc
 		return nil, fmt.Errorf("build chained proof: %w", err)
 	}
 
+	// Check every receipt the builder fetched before the proof is used
+	if err := verifyChainedProofReceipts(chainedProof); err != nil {
+		return nil, fmt.Errorf("verify chained proof receipts: %w", err)
+	}
+
 	log.Printf("[PROOF] ✅ L1-L3 chained proof built successfully:")
 	log.Printf("[PROOF]    L1: TxChainIndex=%d, BVNMinorBlockIndex=%d", chainedProof.Layer1.TxChainIndex, chainedProof.Layer1.BVNMinorBlockIndex)
 	log.Printf("[PROOF]    L2: DNMinorBlockIndex=%d", chainedProof.Layer2.DNMinorBlockIndex)
@@ -571,35 +577,21 @@ func verifyMerklePathCryptographic(receipt *merkle.Receipt, name string) error {
 		return nil
 	}
 
-	// Start with the initial hash
-	current := make([]byte, 32)
-	copy(current, receipt.Start)
-
-	// Apply each entry in the Merkle path
+	entries := make([]bpt.ReceiptEntry, len(receipt.Entries))
 	for i, entry := range receipt.Entries {
 		if entry == nil || len(entry.Hash) != 32 {
 			return fmt.Errorf("%s receipt: invalid entry at index %d for cryptographic verification", name, i)
 		}
-
-		// Combine current hash with entry hash based on position (Left or Right)
-		// In Accumulate's Merkle trees: H(left || right)
-		var combined []byte
-		if entry.Right {
-			// Entry hash goes on the right: H(current || entry.Hash)
-			combined = append(current, entry.Hash...)
-		} else {
-			// Entry hash goes on the left: H(entry.Hash || current)
-			combined = append(entry.Hash, current...)
-		}
-
-		// Compute SHA256 of the combined hashes
-		newHash := sha256Hash(combined)
-		current = newHash[:]
+		entries[i] = bpt.ReceiptEntry{Hash: entry.Hash, Right: entry.Right}
 	}
 
 	// Verify the computed hash matches the anchor (if anchor is present)
 	if len(receipt.Anchor) == 32 {
-		if !bytesEqual(current, receipt.Anchor) {
+		valid, err := bpt.VerifyBPTReceipt(receipt.Start, entries, receipt.Anchor)
+		if err != nil {
+			return fmt.Errorf("%s receipt: %w", name, err)
+		}
+		if !valid {
 			return fmt.Errorf("%s receipt: cryptographic verification failed - computed anchor does not match", name)
 		}
 	}
@@ -607,26 +599,50 @@ func verifyMerklePathCryptographic(receipt *merkle.Receipt, name string) error {
 	return nil
 }
 
-// sha256Hash computes SHA256 hash of data
-func sha256Hash(data []byte) [32]byte {
-	// Use crypto/sha256 for hash computation
-	var result [32]byte
-	h := sha256.Sum256(data)
-	copy(result[:], h[:])
-	return result
-}
+// verifyChainedProofReceipts verifies the L1-L3 receipts of a chained proof. Receipts
+// the builder did not populate are skipped.
+func verifyChainedProofReceipts(cp *chained_proof.ChainedProof) error {
+	receipts := []struct {
+		name    string
+		receipt *chained_proof.Receipt
+	}{
+		{"L1", &cp.Layer1.Receipt},
+		{"L2 root", &cp.Layer2.RootReceipt},
+		{"L2 BPT", &cp.Layer2.BptReceipt},
+		{"L3 root", &cp.Layer3.RootReceipt},
+		{"L3 BPT", &cp.Layer3.BptReceipt},
+	}
+
+	for _, r := range receipts {
+		if r.receipt.Start == "" {
+			continue
+		}
+		start, err := hex.DecodeString(r.receipt.Start)
+		if err != nil {
+			return fmt.Errorf("%s receipt: invalid start hash: %w", r.name, err)
+		}
+		anchor, err := hex.DecodeString(r.receipt.Anchor)
+		if err != nil {
+			return fmt.Errorf("%s receipt: invalid anchor hash: %w", r.name, err)
+		}
+		entries := make([]bpt.ReceiptEntry, len(r.receipt.Entries))
+		for i, step := range r.receipt.Entries {
+			hash, err := hex.DecodeString(step.Hash)
+			if err != nil {
+				return fmt.Errorf("%s receipt: invalid hash at index %d: %w", r.name, i, err)
+			}
+			entries[i] = bpt.ReceiptEntry{Hash: hash, Right: step.Right}
+		}
 
-// bytesEqual compares two byte slices for equality
-func bytesEqual(a, b []byte) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
+		valid, err := bpt.VerifyBPTReceipt(start, entries, anchor)
+		if err != nil {
+			return fmt.Errorf("%s receipt: %w", r.name, err)
+		}
+		if !valid {
+			return fmt.Errorf("%s receipt: path does not lead to anchor %s", r.name, r.receipt.Anchor)
 		}
 	}
-	return true
+	return nil
 }
 
 // truncateString truncates a string to maxLen characters, adding "..." if truncated
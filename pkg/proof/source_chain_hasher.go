@@ -0,0 +1,66 @@
+// Copyright 2025 Certen Protocol
+//
+// Source Chain Hashers - Pluggable transaction-hash computation per source chain
+//
+// The G2 payload binding and general tx-hash checks recompute a transaction's hash from
+// the transaction data the source chain returns. That computation is chain-specific, so the
+// governance generator takes a SourceChainHasher (NativeGeneratorConfig.TxHasher) instead
+// of calling protocol.Transaction.GetHash directly. Accumulate is the only source chain
+// today and AccumulateHasher is the default; another source chain supplies its own hasher
+// without changing the proof logic.
+
+package proof
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.com/accumulatenetwork/accumulate/pkg/types/messaging"
+	"gitlab.com/accumulatenetwork/accumulate/protocol"
+)
+
+// SourceChainAccumulate is the source chain name of the Accumulate hasher
+const SourceChainAccumulate = "accumulate"
+
+// ErrNotATransaction is returned when the value given to a hasher carries no transaction
+// (e.g. a signature message); callers treat it as "no hash available" rather than a failure
+var ErrNotATransaction = errors.New("value does not contain a transaction")
+
+// SourceChainHasher computes canonical transaction hashes for one source chain
+type SourceChainHasher interface {
+	// SourceChain returns the source chain name, e.g. "accumulate"
+	SourceChain() string
+
+	// TransactionHash computes the hash of a transaction value as returned by the
+	// source chain's client library
+	TransactionHash(tx interface{}) ([]byte, error)
+}
+
+// AccumulateHasher computes Accumulate transaction hashes (protocol.Transaction.GetHash)
+type AccumulateHasher struct{}
+
+// SourceChain implements SourceChainHasher
+func (AccumulateHasher) SourceChain() string {
+	return SourceChainAccumulate
+}
+
+// TransactionHash implements SourceChainHasher. Accepts *protocol.Transaction,
+// *messaging.TransactionMessage or any messaging.Message wrapping a transaction.
+func (AccumulateHasher) TransactionHash(tx interface{}) ([]byte, error) {
+	switch v := tx.(type) {
+	case *protocol.Transaction:
+		if v == nil {
+			return nil, ErrNotATransaction
+		}
+		return v.GetHash(), nil
+	case *messaging.TransactionMessage:
+		if v == nil || v.Transaction == nil {
+			return nil, ErrNotATransaction
+		}
+		return v.Transaction.GetHash(), nil
+	case messaging.Message:
+		return nil, fmt.Errorf("%w: %T", ErrNotATransaction, v)
+	default:
+		return nil, fmt.Errorf("unsupported Accumulate transaction type: %T", tx)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Certen Protocol
+//
+// Admin API Handlers
+// Operator diagnostics guarded by the admin bearer token. The endpoints are registered
+// only when ADMIN_API_TOKEN is set.
+
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ConfigSnapshot returns the effective runtime configuration, with secrets redacted
+type ConfigSnapshot func() map[string]interface{}
+
+// AdminHandlers provides the admin diagnostics endpoints
+type AdminHandlers struct {
+	adminToken string
+	config     ConfigSnapshot
+	logger     *log.Logger
+}
+
+// NewAdminHandlers creates admin handlers
+func NewAdminHandlers(adminToken string, config ConfigSnapshot, logger *log.Logger) *AdminHandlers {
+	if logger == nil {
+		logger = log.New(log.Writer(), "[AdminAPI] ", log.LstdFlags)
+	}
+	return &AdminHandlers{
+		adminToken: adminToken,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// bearerTokenMatches checks the request's bearer token against token in constant time.
+// An empty token never matches.
+func bearerTokenMatches(r *http.Request, token string) bool {
+	presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+// HandleGetConfig handles GET /api/admin/config
+func (h *AdminHandlers) HandleGetConfig(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !bearerTokenMatches(r, h.adminToken) {
+		h.logger.Printf("⚠️ Rejected unauthorized config request from %s", r.RemoteAddr)
+		writeJSONError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.config == nil {
+		writeJSONError(w, "configuration snapshot not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"generated_at": time.Now().UTC(),
+		"config":       h.config(),
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Admin Handlers
+// Tests bearer token checks, the configuration endpoint and write-back key rotation
+
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/execution"
+	"github.com/certen/independant-validator/pkg/keys"
+)
+
+const testAdminToken = "0123456789abcdef0123456789abcdef"
+
+func TestBearerTokenMatches(t *testing.T) {
+	tests := []struct {
+		header string
+		token  string
+		want   bool
+	}{
+		{"Bearer " + testAdminToken, testAdminToken, true},
+		{"Bearer wrong", testAdminToken, false},
+		{testAdminToken, testAdminToken, false},
+		{"", testAdminToken, false},
+		{"Bearer ", "", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/config", nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		if got := bearerTokenMatches(req, tt.token); got != tt.want {
+			t.Errorf("header %q token %q: expected %v, got %v", tt.header, tt.token, tt.want, got)
+		}
+	}
+}
+
+func TestAdminHandlers_GetConfig(t *testing.T) {
+	h := NewAdminHandlers(testAdminToken, func() map[string]interface{} {
+		return map[string]interface{}{"collector": map[string]int{"max_batch_size": 1000}}
+	}, log.New(io.Discard, "", 0))
+
+	rec := httptest.NewRecorder()
+	h.HandleGetConfig(rec, httptest.NewRequest(http.MethodGet, "/api/admin/config", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	rec = httptest.NewRecorder()
+	h.HandleGetConfig(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Config map[string]map[string]int `json:"config"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Config["collector"]["max_batch_size"] != 1000 {
+		t.Errorf("unexpected config %+v", body.Config)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	rec = httptest.NewRecorder()
+	h.HandleGetConfig(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", rec.Code)
+	}
+}
+
+// fakeKeyRotator records the key it was asked to rotate to
+type fakeKeyRotator struct {
+	rotatedTo ed25519.PublicKey
+}
+
+func (f *fakeKeyRotator) RotateSigningKey(ctx context.Context, newKey keys.KeyProvider, keyPageIndex uint64, updateKeyPage bool) (*execution.KeyRotation, error) {
+	f.rotatedTo = newKey.PublicKey()
+	return &execution.KeyRotation{NewPublicKey: hex.EncodeToString(f.rotatedTo), KeyPageIndex: keyPageIndex}, nil
+}
+
+func (f *fakeKeyRotator) GetPublicKeyHex() string { return hex.EncodeToString(f.rotatedTo) }
+func (f *fakeKeyRotator) GetKeyPageIndex() uint64 { return 1 }
+
+func TestKeyRotationHandlers_Rotate(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	keyPath := filepath.Join(t.TempDir(), "writeback.key")
+	if err := os.WriteFile(keyPath, []byte(hex.EncodeToString(key)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rotator := &fakeKeyRotator{}
+	h := NewKeyRotationHandlers(rotator, testAdminToken, log.New(io.Discard, "", 0))
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/writeback-key", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		rec := httptest.NewRecorder()
+		h.HandleWriteBackKey(rec, req)
+		return rec
+	}
+
+	if rec := post(`{"key_path": "` + filepath.Join(t.TempDir(), "missing.key") + `"}`); rec.Code != http.StatusBadRequest || rotator.rotatedTo != nil {
+		t.Errorf("expected 400 for a missing key file, got %d", rec.Code)
+	}
+
+	rec := post(`{"key_path": "` + keyPath + `", "key_page_index": 2}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !rotator.rotatedTo.Equal(key.Public()) {
+		t.Error("expected rotation to the key read from key_path")
+	}
+}
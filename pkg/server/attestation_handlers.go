@@ -7,6 +7,8 @@
 // - Accept attestation requests from peer validators
 // - Return attestation status for ongoing collection
 // - Provide attestation bundle information
+// - Require a verified client certificate for attestation requests when mTLS is configured
+// - Reload the peer list for operators holding the admin token
 
 package server
 
@@ -27,6 +29,10 @@ type AttestationHandlers struct {
 	service     *attestation.Service
 	validatorID string
 	logger      *log.Logger
+
+	// Peer reload (disabled until SetPeerReloader)
+	reloadToken string
+	reloadPeers func() (attestation.PeerReloadResult, error)
 }
 
 // NewAttestationHandlers creates new attestation handlers
@@ -41,6 +47,13 @@ func NewAttestationHandlers(service *attestation.Service, validatorID string, lo
 	}
 }
 
+// SetPeerReloader enables POST /api/attestations/peers/reload for callers
+// presenting token; reload re-reads the peer list and applies it to the service
+func (h *AttestationHandlers) SetPeerReloader(token string, reload func() (attestation.PeerReloadResult, error)) {
+	h.reloadToken = token
+	h.reloadPeers = reload
+}
+
 // HandleAttestationRequest handles POST /api/attestations/request
 // This is called by peer validators requesting our attestation for a proof
 func (h *AttestationHandlers) HandleAttestationRequest(w http.ResponseWriter, r *http.Request) {
@@ -56,6 +69,13 @@ func (h *AttestationHandlers) HandleAttestationRequest(w http.ResponseWriter, r
 		return
 	}
 
+	// With mTLS only validators holding a certificate from the attestation CA may ask
+	if h.service.MutualTLS() && !attestation.HasVerifiedPeer(r.TLS) {
+		h.logger.Printf("Rejected attestation request from %s without a verified client certificate", r.RemoteAddr)
+		writeJSONError(w, "verified client certificate required", http.StatusUnauthorized)
+		return
+	}
+
 	// Parse the attestation request from peer
 	var req attestation.AttestationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -72,6 +92,10 @@ func (h *AttestationHandlers) HandleAttestationRequest(w http.ResponseWriter, r
 		writeJSONError(w, "anchor_tx_hash is required", http.StatusBadRequest)
 		return
 	}
+	if req.BatchID == uuid.Nil {
+		writeJSONError(w, "batch_id is required", http.StatusBadRequest)
+		return
+	}
 
 	h.logger.Printf("Received attestation request from %s for proof %s",
 		req.RequestingValidator, req.ProofID)
@@ -176,7 +200,7 @@ func (h *AttestationHandlers) HandleGetAttestationBundle(w http.ResponseWriter,
 }
 
 // HandleGetPeers handles GET /api/attestations/peers
-// Returns the configured peer validators for attestation
+// Returns the configured peer validators for attestation and their health
 func (h *AttestationHandlers) HandleGetPeers(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -193,12 +217,44 @@ func (h *AttestationHandlers) HandleGetPeers(w http.ResponseWriter, r *http.Requ
 	response := map[string]interface{}{
 		"validator_id": h.validatorID,
 		"peers":        h.service.GetPeers(),
+		"peer_health":  h.service.PeerHealth(),
 		"public_key":   h.service.GetPublicKey(),
 	}
 
 	json.NewEncoder(w).Encode(response)
 }
 
+// HandleReloadPeers handles POST /api/attestations/peers/reload
+// Re-reads the peer list from the configuration without restarting the validator
+func (h *AttestationHandlers) HandleReloadPeers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h.reloadPeers == nil || h.reloadToken == "" {
+		writeJSONError(w, "peer reload is not enabled", http.StatusNotFound)
+		return
+	}
+	if !bearerTokenMatches(r, h.reloadToken) {
+		h.logger.Printf("⚠️ Rejected unauthorized peer reload from %s", r.RemoteAddr)
+		writeJSONError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.reloadPeers()
+	if err != nil {
+		h.logger.Printf("⚠️ Peer reload failed: %v", err)
+		writeJSONError(w, "peer reload failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.logger.Printf("🔄 Peer list reloaded by %s: %d peers, %d attestations required",
+		r.RemoteAddr, len(result.Peers), result.RequiredCount)
+
+	json.NewEncoder(w).Encode(result)
+}
+
 // HandleAttestationInfo handles GET /api/attestations
 // Returns general attestation service information
 func (h *AttestationHandlers) HandleAttestationInfo(w http.ResponseWriter, r *http.Request) {
@@ -218,12 +274,14 @@ func (h *AttestationHandlers) HandleAttestationInfo(w http.ResponseWriter, r *ht
 			"POST /api/attestations/request - Receive attestation request from peer",
 			"GET /api/attestations/status/:proof_id - Get attestation collection status",
 			"GET /api/attestations/bundle/:proof_id - Get attestation bundle",
-			"GET /api/attestations/peers - Get configured peer validators",
+			"GET /api/attestations/peers - Get configured peer validators and their health",
+			"POST /api/attestations/peers/reload - Reload the peer list from configuration (admin)",
 		},
 	}
 
 	if h.service != nil {
 		response["peers_count"] = len(h.service.GetPeers())
+		response["rejected_attestations"] = h.service.RejectedAttestationCounts()
 	}
 
 	json.NewEncoder(w).Encode(response)
@@ -10,16 +10,21 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/certen/independant-validator/pkg/anchor_proof"
 	"github.com/certen/independant-validator/pkg/batch"
 	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/ethereum"
 )
 
 // BatchHandlers provides HTTP handlers for batch and proof operations
@@ -30,6 +35,41 @@ type BatchHandlers struct {
 	repos           *database.Repositories
 	validatorID     string
 	logger          *log.Logger
+
+	// Gas-aware on-cadence anchoring (nil = disabled)
+	gasWindow *batch.GasWindow
+
+	// Anchor receipts (nil signer = receipts disabled)
+	receiptSigner      *anchor_proof.AttestationSigner
+	receiptMinFinality database.AnchorFinality
+
+	// Abandoned on-demand batches (nil = abandonment disabled)
+	abandoner *batch.OnDemandAbandoner
+
+	// Anchor state reconciliation against the chain (nil = disabled)
+	stateReconciler *batch.AnchorStateReconciler
+
+	// ProofVerificationFailed contract events (nil = no event watching)
+	verificationFailures *batch.VerificationFailureRecorder
+
+	// On-cadence interval and stall grace period reported in /api/batches/current
+	batchInterval    time.Duration
+	stallGracePeriod time.Duration
+
+	// Live USD pricing for the cost endpoints (nil oracle = static price tiers)
+	feeOracle       ethereum.FeeOracle
+	batchGas        uint64 // Gas per anchored batch
+	onCadenceProofs int    // Typical proofs per on-cadence batch
+
+	// Bearer token guarding POST /api/batches/:id/retry (empty = retry disabled)
+	retryToken string
+
+	// Per-account/IP limit on POST /api/anchors/on-demand (nil = unlimited)
+	rateLimiter   OnDemandRateLimiter
+	rateLimitKeys []string
+
+	// Database circuit breaker; sets Retry-After while the database is unreachable (nil = none)
+	dbBreaker *database.CircuitBreaker
 }
 
 // NewBatchHandlers creates new batch operation handlers
@@ -45,13 +85,77 @@ func NewBatchHandlers(
 		logger = log.New(log.Writer(), "[BatchAPI] ", log.LstdFlags)
 	}
 	return &BatchHandlers{
-		collector:       collector,
-		processor:       processor,
-		onDemandHandler: onDemandHandler,
-		repos:           repos,
-		validatorID:     validatorID,
-		logger:          logger,
+		collector:        collector,
+		processor:        processor,
+		onDemandHandler:  onDemandHandler,
+		repos:            repos,
+		validatorID:      validatorID,
+		logger:           logger,
+		batchInterval:    batch.DefaultBatchInterval,
+		stallGracePeriod: batch.OnCadenceGracePeriod,
+	}
+}
+
+// SetGasWindow exposes the gas window decision and held batches in /api/batches/current
+func (h *BatchHandlers) SetGasWindow(gasWindow *batch.GasWindow) {
+	h.gasWindow = gasWindow
+}
+
+// SetReceiptSigner enables signed anchor receipts for anchors that reached minFinality
+func (h *BatchHandlers) SetReceiptSigner(signer *anchor_proof.AttestationSigner, minFinality database.AnchorFinality) {
+	h.receiptSigner = signer
+	h.receiptMinFinality = minFinality
+}
+
+// SetOnDemandAbandoner exposes abandoned on-demand requests at /api/batches/abandoned
+func (h *BatchHandlers) SetOnDemandAbandoner(abandoner *batch.OnDemandAbandoner) {
+	h.abandoner = abandoner
+}
+
+// SetAnchorStateReconciler exposes anchor state discrepancies at /api/anchors/reconciliation
+func (h *BatchHandlers) SetAnchorStateReconciler(reconciler *batch.AnchorStateReconciler) {
+	h.stateReconciler = reconciler
+}
+
+// SetVerificationFailureRecorder exposes on-chain verification failures at /api/anchors/failures
+func (h *BatchHandlers) SetVerificationFailureRecorder(recorder *batch.VerificationFailureRecorder) {
+	h.verificationFailures = recorder
+}
+
+// SetBatchTiming sets the on-cadence interval and stall grace period used for expected
+// completion times and health; non-positive values keep the defaults
+func (h *BatchHandlers) SetBatchTiming(interval, stallGracePeriod time.Duration) {
+	if interval > 0 {
+		h.batchInterval = interval
 	}
+	if stallGracePeriod > 0 {
+		h.stallGracePeriod = stallGracePeriod
+	}
+}
+
+// SetFeeOracle prices the cost endpoints from live gas and ETH/USD prices. batchGas is the
+// gas of one anchored batch, shared by onCadenceProofs proofs in an on-cadence batch.
+func (h *BatchHandlers) SetFeeOracle(oracle ethereum.FeeOracle, batchGas uint64, onCadenceProofs int) {
+	h.feeOracle = oracle
+	h.batchGas = batchGas
+	h.onCadenceProofs = onCadenceProofs
+}
+
+// SetRetryToken enables POST /api/batches/:id/retry for callers presenting token
+func (h *BatchHandlers) SetRetryToken(token string) {
+	h.retryToken = token
+}
+
+// SetOnDemandRateLimiter rate limits on-demand anchors by each of keys
+// (OnDemandRateLimitByAccount, OnDemandRateLimitByIP)
+func (h *BatchHandlers) SetOnDemandRateLimiter(limiter OnDemandRateLimiter, keys ...string) {
+	h.rateLimiter = limiter
+	h.rateLimitKeys = keys
+}
+
+// SetDatabaseBreaker tells clients rejected while the database is unreachable when to retry
+func (h *BatchHandlers) SetDatabaseBreaker(breaker *database.CircuitBreaker) {
+	h.dbBreaker = breaker
 }
 
 // ========================================
@@ -100,6 +204,9 @@ type OnDemandAnchorResponse struct {
 	AnchorBlockNumber int64 `json:"anchor_block_number,omitempty"`
 	// Merkle root (if batch was closed)
 	MerkleRoot string `json:"merkle_root,omitempty"`
+	// Set when the account is over its proof quota and the request was queued
+	Queued      bool       `json:"queued,omitempty"`
+	QueuedUntil *time.Time `json:"queued_until,omitempty"`
 	// Estimated cost per proof
 	EstimatedCost string `json:"estimated_cost"`
 	// Error message (if any)
@@ -138,18 +245,20 @@ type CurrentBatchesResponse struct {
 	OnCadenceBatch   *BatchInfoResponse `json:"on_cadence_batch,omitempty"`
 	OnDemandBatch    *BatchInfoResponse `json:"on_demand_batch,omitempty"`
 	OnDemandStats    interface{}        `json:"on_demand_stats,omitempty"`
+	GasWindow        *batch.GasWindowStatus `json:"gas_window,omitempty"`
 	SystemHealth     *BatchHealthInfo   `json:"system_health"`
 }
 
 // BatchHealthInfo provides batch system health status
 type BatchHealthInfo struct {
-	Status               string `json:"status"` // "healthy", "delayed", "stalled"
+	Status               string `json:"status"` // "healthy", "delayed", "stalled", "unavailable"
 	Message              string `json:"message"`
 	OnCadenceDelayNormal bool   `json:"on_cadence_delay_normal"`
 }
 
 // HandleOnDemandAnchor handles POST /api/anchors/on-demand
 // Per whitepaper: On-demand anchoring at ~$0.25/proof for immediate confirmation
+// With ?dryRun=true the anchor is only estimated (see handleEstimateOnDemand)
 func (h *BatchHandlers) HandleOnDemandAnchor(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -221,7 +330,32 @@ func (h *BatchHandlers) HandleOnDemandAnchor(w http.ResponseWriter, r *http.Requ
 	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
 	defer cancel()
 
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")); dryRun {
+		h.handleEstimateOnDemand(ctx, w, txData)
+		return
+	}
+
+	// Estimates are free; only requests that may anchor count against the limit
+	if !h.checkOnDemandRateLimit(w, r, req.AccountURL) {
+		return
+	}
+
 	result, err := h.onDemandHandler.ProcessTransaction(ctx, txData)
+	if errors.Is(err, batch.ErrQuotaExceeded) {
+		writeJSONError(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if errors.Is(err, batch.ErrTransactionAlreadyBatched) {
+		writeJSONError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if errors.Is(err, database.ErrDatabaseUnavailable) {
+		if h.dbBreaker != nil {
+			w.Header().Set("Retry-After", strconv.Itoa(int(h.dbBreaker.RetryAfter().Seconds())))
+		}
+		writeJSONError(w, "on-demand anchoring temporarily unavailable: database unreachable", http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		h.logger.Printf("On-demand anchor failed: %v", err)
 		writeJSONError(w, fmt.Sprintf("failed to process transaction: %v", err), http.StatusInternalServerError)
@@ -236,6 +370,15 @@ func (h *BatchHandlers) HandleOnDemandAnchor(w http.ResponseWriter, r *http.Requ
 		Anchored:        result.Anchored,
 	}
 
+	if result.TransactionResult != nil && result.TransactionResult.Queued {
+		resp.Queued = true
+		resp.QueuedUntil = result.TransactionResult.QueuedUntil
+		h.logger.Printf("On-demand anchor queued over quota: tx=%s, account=%s", req.AccumTxHash, req.AccountURL)
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
 	if result.TransactionResult != nil {
 		resp.TransactionID = result.TransactionResult.TransactionID
 		resp.BatchID = result.TransactionResult.BatchID.String()
@@ -245,6 +388,7 @@ func (h *BatchHandlers) HandleOnDemandAnchor(w http.ResponseWriter, r *http.Requ
 
 	if result.BatchResult != nil {
 		resp.MerkleRoot = result.BatchResult.MerkleRootHex
+		resp.BatchID = result.BatchResult.BatchID.String() // Renamed on close with deterministic batch IDs
 	}
 
 	h.logger.Printf("On-demand anchor processed: tx=%s, batch=%s, anchored=%v",
@@ -253,14 +397,89 @@ func (h *BatchHandlers) HandleOnDemandAnchor(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(resp)
 }
 
+// OnDemandEstimateResponse is the API response for a dry-run on-demand anchor
+type OnDemandEstimateResponse struct {
+	DryRun bool `json:"dry_run"`
+	// Batch the transaction would be anchored in, including the transaction
+	BatchID    string `json:"batch_id"`
+	BatchSize  int    `json:"batch_size"`
+	MerkleRoot string `json:"merkle_root"`
+	// createAnchor transaction cost
+	TargetChain  string `json:"target_chain"`
+	EstimatedGas uint64 `json:"estimated_gas"`
+	GasPriceWei  string `json:"gas_price_wei"`
+	TotalCostWei string `json:"total_cost_wei"`
+	// Cost of the proof, as GET /api/costs/estimate: with live pricing the anchor's
+	// USD cost shared across the batch, otherwise the static price tier
+	Pricing       string   `json:"pricing"` // "live" or "static"
+	PerProofCost  float64  `json:"per_proof_cost"`
+	TotalCostUSD  float64  `json:"total_cost_usd"`
+	AnchorCostUSD *float64 `json:"anchor_cost_usd,omitempty"`
+	EthPriceUSD   *float64 `json:"eth_price_usd,omitempty"`
+	Currency      string   `json:"currency"`
+}
+
+// handleEstimateOnDemand runs proof assembly and gas estimation for the anchor an on-demand
+// transaction would trigger, stopping before anything is sent. The transaction is not
+// added to a batch and the collector is left unchanged.
+func (h *BatchHandlers) handleEstimateOnDemand(ctx context.Context, w http.ResponseWriter, txData *batch.TransactionData) {
+	if h.processor == nil {
+		writeJSONError(w, "on-demand estimation not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	preview, err := h.onDemandHandler.PreviewTransaction(txData)
+	if err != nil {
+		writeJSONError(w, fmt.Sprintf("failed to assemble batch: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	estimate, err := h.processor.EstimateAnchor(ctx, preview)
+	if err != nil {
+		h.logger.Printf("On-demand estimate failed: %v", err)
+		writeJSONError(w, fmt.Sprintf("failed to estimate anchor: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	resp := OnDemandEstimateResponse{
+		DryRun:       true,
+		BatchID:      preview.BatchID.String(),
+		BatchSize:    preview.TxCount,
+		MerkleRoot:   preview.MerkleRootHex,
+		TargetChain:  estimate.TargetChain,
+		EstimatedGas: estimate.GasLimit,
+		GasPriceWei:  estimate.GasPriceWei,
+		TotalCostWei: estimate.TotalCostWei,
+		Pricing:      "static",
+		PerProofCost: perProofCostUSD("on-demand"),
+		Currency:     "USD",
+	}
+	totalWei, ok := new(big.Int).SetString(estimate.TotalCostWei, 10)
+	if quote := h.quoteFees(ctx); quote != nil && ok && preview.TxCount > 0 {
+		anchorCost := quote.WeiToUSD(totalWei)
+		resp.Pricing = "live"
+		resp.AnchorCostUSD = &anchorCost
+		resp.EthPriceUSD = &quote.EthUSD
+		resp.PerProofCost = anchorCost / float64(preview.TxCount)
+	}
+	resp.TotalCostUSD = resp.PerProofCost
+
+	json.NewEncoder(w).Encode(resp)
+}
+
 // ========================================
 // Batch Status API
 // ========================================
 
-// HandleBatchStatus handles GET /api/batches/:id
+// HandleBatchStatus handles GET /api/batches/:id (and POST /api/batches/:id/retry)
 func (h *BatchHandlers) HandleBatchStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
+	if idPart, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/api/batches/"), "/retry"); ok {
+		h.handleRetryBatch(w, r, idPart)
+		return
+	}
+
 	if r.Method != http.MethodGet {
 		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -288,13 +507,254 @@ func (h *BatchHandlers) HandleBatchStatus(w http.ResponseWriter, r *http.Request
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	batch, err := h.repos.Batches.GetBatch(ctx, batchID)
+	anchorBatch, err := h.repos.Batches.GetBatch(ctx, batchID)
 	if err != nil {
 		writeJSONError(w, fmt.Sprintf("batch not found: %v", err), http.StatusNotFound)
 		return
 	}
 
-	json.NewEncoder(w).Encode(batch)
+	// Surface why a failed batch failed (including anchor retry attempts) as a plain string,
+	// and where the batch can go next in the batch state machine
+	response := struct {
+		*database.AnchorBatch
+		LastError    string                 `json:"last_error,omitempty"`
+		Terminal     bool                   `json:"terminal"`
+		NextStatuses []database.BatchStatus `json:"next_statuses"`
+	}{
+		AnchorBatch:  anchorBatch,
+		Terminal:     batch.IsTerminalBatchStatus(anchorBatch.Status),
+		NextStatuses: batch.NextBatchStatuses(anchorBatch.Status),
+	}
+	if anchorBatch.Status == database.BatchStatusFailed && anchorBatch.ErrorMessage.Valid {
+		response.LastError = anchorBatch.ErrorMessage.String
+	}
+	if response.NextStatuses == nil {
+		response.NextStatuses = []database.BatchStatus{}
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleRetryBatch handles POST /api/batches/:id/retry
+// Re-anchors a failed batch; 409 if the batch is already anchored or not failed
+func (h *BatchHandlers) handleRetryBatch(w http.ResponseWriter, r *http.Request, idPart string) {
+	if h.retryToken == "" {
+		writeJSONError(w, "batch retry is not enabled", http.StatusNotFound)
+		return
+	}
+	if !bearerTokenMatches(r, h.retryToken) {
+		h.logger.Printf("⚠️ Rejected unauthorized batch retry from %s", r.RemoteAddr)
+		writeJSONError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.processor == nil || h.repos == nil {
+		writeJSONError(w, "batch processor not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	batchID, err := uuid.Parse(idPart)
+	if err != nil {
+		writeJSONError(w, "invalid batch ID", http.StatusBadRequest)
+		return
+	}
+
+	// Anchoring waits for the receipt, so allow as long as an anchor submission
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+
+	result, err := h.processor.RetryFailedBatch(ctx, batchID)
+	switch {
+	case err == nil:
+		h.logger.Printf("Batch %s retried: status=%s", batchID, result.Status)
+		json.NewEncoder(w).Encode(result)
+	case errors.Is(err, batch.ErrBatchNotFound):
+		writeJSONError(w, "batch not found", http.StatusNotFound)
+	case errors.Is(err, batch.ErrBatchAlreadyAnchored), errors.Is(err, batch.ErrBatchNotRetryable):
+		writeJSONError(w, err.Error(), http.StatusConflict)
+	case result != nil:
+		// The anchor path ran and failed again; the batch is marked failed
+		h.logger.Printf("Retry of batch %s failed: %v", batchID, err)
+		writeJSONError(w, fmt.Sprintf("retry failed: %v", err), http.StatusBadGateway)
+	default:
+		h.logger.Printf("Retry of batch %s failed: %v", batchID, err)
+		writeJSONError(w, fmt.Sprintf("failed to retry batch: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// HandleGetAbandonedOnDemand handles GET /api/batches/abandoned
+// Lists requests of on-demand batches that never anchored, newest first
+// Query params: account (optional), limit (default 50, max 1000), offset
+func (h *BatchHandlers) HandleGetAbandonedOnDemand(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.abandoner == nil {
+		writeJSONError(w, "on-demand abandonment not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 50
+	if s := query.Get("limit"); s != "" {
+		parsed, err := parseInt(s)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			writeJSONError(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+	offset := 0
+	if s := query.Get("offset"); s != "" {
+		parsed, err := parseInt(s)
+		if err != nil || parsed < 0 {
+			writeJSONError(w, "offset must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		offset = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	requests, err := h.abandoner.ListAbandoned(ctx, query.Get("account"), limit, offset)
+	if err != nil {
+		writeJSONError(w, fmt.Sprintf("failed to list abandoned requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if requests == nil {
+		requests = []database.AbandonedOnDemandRequest{}
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"requests": requests,
+		"count":    len(requests),
+		"limit":    limit,
+		"offset":   offset,
+		"policy":   h.abandoner.Status(),
+	})
+}
+
+// HandleGetAnchorFailures handles GET /api/anchors/failures
+// Returns ProofVerificationFailed events, newest first, with their reasons, how many of
+// them failed each check and the recorder's counters. Query: limit, offset.
+func (h *BatchHandlers) HandleGetAnchorFailures(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.verificationFailures == nil {
+		writeJSONError(w, "verification failure tracking not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 50
+	if s := query.Get("limit"); s != "" {
+		parsed, err := parseInt(s)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			writeJSONError(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+	offset := 0
+	if s := query.Get("offset"); s != "" {
+		parsed, err := parseInt(s)
+		if err != nil || parsed < 0 {
+			writeJSONError(w, "offset must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		offset = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	failures, err := h.verificationFailures.ListFailures(ctx, limit, offset)
+	if err != nil {
+		writeJSONError(w, fmt.Sprintf("failed to list verification failures: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"failures":      failures,
+		"count":         len(failures),
+		"limit":         limit,
+		"offset":        offset,
+		"failed_checks": batch.CountFailedChecks(failures),
+		"stats":         h.verificationFailures.Stats(),
+	})
+}
+
+// HandleGetAnchorReconciliation handles GET /api/anchors/reconciliation
+// Returns discrepancies between local anchor records and on-chain state, most recently
+// detected first, with the reconciler's status. Query: limit, offset, include_resolved.
+func (h *BatchHandlers) HandleGetAnchorReconciliation(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.stateReconciler == nil {
+		writeJSONError(w, "anchor state reconciliation not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 50
+	if s := query.Get("limit"); s != "" {
+		parsed, err := parseInt(s)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			writeJSONError(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+	offset := 0
+	if s := query.Get("offset"); s != "" {
+		parsed, err := parseInt(s)
+		if err != nil || parsed < 0 {
+			writeJSONError(w, "offset must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		offset = parsed
+	}
+	includeResolved := query.Get("include_resolved") == "true"
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	discrepancies, err := h.stateReconciler.ListDiscrepancies(ctx, includeResolved, limit, offset)
+	if err != nil {
+		writeJSONError(w, fmt.Sprintf("failed to list anchor discrepancies: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if discrepancies == nil {
+		discrepancies = []database.AnchorStateDiscrepancy{}
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"discrepancies":    discrepancies,
+		"count":            len(discrepancies),
+		"limit":            limit,
+		"offset":           offset,
+		"include_resolved": includeResolved,
+		"status":           h.stateReconciler.Status(),
+	})
 }
 
 // HandleBatchInfo handles GET /api/batches/current
@@ -308,8 +768,7 @@ func (h *BatchHandlers) HandleBatchInfo(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Default batch interval (15 minutes per whitepaper)
-	batchInterval := 15 * time.Minute
+	batchInterval := h.batchInterval
 
 	response := &CurrentBatchesResponse{
 		ValidatorID: h.validatorID,
@@ -358,7 +817,7 @@ func (h *BatchHandlers) HandleBatchInfo(w http.ResponseWriter, r *http.Request)
 			response.OnCadenceBatch = batchInfo
 
 			// Update system health message
-			response.SystemHealth.Message = "On-cadence batch collecting transactions. Delays up to 15 minutes are normal."
+			response.SystemHealth.Message = fmt.Sprintf("On-cadence batch collecting transactions. Delays up to %s are normal.", batchInterval)
 		}
 
 		// Process on-demand batch
@@ -390,6 +849,7 @@ func (h *BatchHandlers) HandleBatchInfo(w http.ResponseWriter, r *http.Request)
 			h.collector.GetOnCadenceBatchInfo(),
 			h.collector.GetOnDemandBatchInfo(),
 			batchInterval,
+			h.stallGracePeriod,
 		)
 		response.SystemHealth = &BatchHealthInfo{
 			Status:               healthStatus.OverallStatus,
@@ -398,10 +858,19 @@ func (h *BatchHandlers) HandleBatchInfo(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	if h.dbBreaker != nil && h.dbBreaker.State() == database.BreakerDisconnected {
+		response.SystemHealth.Status = "unavailable"
+		response.SystemHealth.Message = "Database unreachable: new transactions are rejected until it recovers."
+	}
+
 	if h.onDemandHandler != nil {
 		response.OnDemandStats = h.onDemandHandler.GetStats()
 	}
 
+	if h.gasWindow != nil {
+		response.GasWindow = h.gasWindow.Status()
+	}
+
 	json.NewEncoder(w).Encode(response)
 }
 
@@ -546,6 +1015,11 @@ func (h *BatchHandlers) HandleGetAnchor(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if idPart, ok := strings.CutSuffix(path, "/receipt"); ok {
+		h.handleGetAnchorReceipt(w, r, idPart)
+		return
+	}
+
 	anchorID, err := uuid.Parse(path)
 	if err != nil {
 		writeJSONError(w, "invalid anchor ID", http.StatusBadRequest)
@@ -605,6 +1079,78 @@ func (h *BatchHandlers) HandleGetAnchorByBatch(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(anchor)
 }
 
+// handleGetAnchorReceipt handles GET /api/anchors/:id/receipt
+func (h *BatchHandlers) handleGetAnchorReceipt(w http.ResponseWriter, r *http.Request, idPart string) {
+	if h.receiptSigner == nil {
+		writeJSONError(w, "anchor receipts are not enabled", http.StatusNotFound)
+		return
+	}
+
+	anchorID, err := uuid.Parse(idPart)
+	if err != nil {
+		writeJSONError(w, "invalid anchor ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	anchor, err := h.repos.Anchors.GetAnchor(ctx, anchorID)
+	if err != nil {
+		writeJSONError(w, fmt.Sprintf("anchor not found: %v", err), http.StatusNotFound)
+		return
+	}
+
+	if !anchorReachedFinality(anchor, h.receiptMinFinality) {
+		writeJSONError(w, fmt.Sprintf("anchor has not reached %s finality (confirmations=%d)",
+			h.receiptMinFinality, anchor.Confirmations), http.StatusConflict)
+		return
+	}
+
+	receipt := buildAnchorReceipt(anchor)
+	if err := h.receiptSigner.SignAnchorReceipt(receipt); err != nil {
+		h.logger.Printf("Failed to sign receipt for anchor %s: %v", anchorID, err)
+		writeJSONError(w, "failed to sign anchor receipt", http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(receipt)
+}
+
+// anchorReachedFinality reports whether an anchor is at least at the given finality level
+func anchorReachedFinality(anchor *database.AnchorRecord, min database.AnchorFinality) bool {
+	if anchor.IsFinal || anchor.Finality == database.AnchorFinalityFinal {
+		return true
+	}
+	return min == database.AnchorFinalityAvailable && anchor.Finality == database.AnchorFinalityAvailable
+}
+
+// buildAnchorReceipt copies the receipt fields from an anchor record
+func buildAnchorReceipt(anchor *database.AnchorRecord) *anchor_proof.AnchorReceipt {
+	finality := anchor.Finality
+	if anchor.IsFinal {
+		finality = database.AnchorFinalityFinal
+	}
+
+	receipt := &anchor_proof.AnchorReceipt{
+		AnchorID:        anchor.AnchorID.String(),
+		BatchID:         anchor.BatchID.String(),
+		MerkleRoot:      hex.EncodeToString(anchor.MerkleRoot),
+		TargetChain:     string(anchor.TargetChain),
+		ChainID:         anchor.ChainID.String,
+		ContractAddress: anchor.ContractAddress.String,
+		AnchorTxHash:    anchor.AnchorTxHash,
+		BlockNumber:     anchor.AnchorBlockNumber,
+		BlockHash:       anchor.AnchorBlockHash.String,
+		Confirmations:   anchor.Confirmations,
+		Finality:        string(finality),
+	}
+	if anchor.AnchorTimestamp.Valid {
+		receipt.AnchoredAt = anchor.AnchorTimestamp.Time.UTC().Format(time.RFC3339)
+	}
+	return receipt
+}
+
 // ========================================
 // Cost API
 // ========================================
@@ -636,6 +1182,27 @@ func (h *BatchHandlers) HandleGetCostStatistics(w http.ResponseWriter, r *http.R
 		},
 		"whitepaper_reference": "Section 3.4.2: Transaction Batching",
 		"note":                 "Actual costs may vary based on gas prices and batch sizes",
+		"pricing":              "static",
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	if quote := h.quoteFees(ctx); quote != nil {
+		live := map[string]interface{}{
+			"gas_price_wei": quote.GasPrice.String(),
+			"eth_price_usd": quote.EthUSD,
+			"price_source":  quote.Source,
+			"quoted_at":     quote.QuotedAt.UTC().Format(time.RFC3339),
+		}
+		for _, batchType := range []string{"on-cadence", "on-demand"} {
+			gas, _, costUSD := h.liveCost(quote, batchType, 1)
+			live[strings.ReplaceAll(batchType, "-", "_")] = map[string]interface{}{
+				"per_proof_usd": costUSD,
+				"per_proof_gas": gas,
+			}
+		}
+		response["pricing"] = "live"
+		response["live_cost"] = live
 	}
 
 	json.NewEncoder(w).Encode(response)
@@ -665,14 +1232,7 @@ func (h *BatchHandlers) HandleEstimateCost(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Calculate estimate
-	var perProofCost float64
-	switch batchType {
-	case "on-demand":
-		perProofCost = 0.25
-	default:
-		perProofCost = 0.05
-	}
-
+	perProofCost := perProofCostUSD(batchType)
 	totalCost := perProofCost * float64(txCount)
 
 	response := map[string]interface{}{
@@ -682,11 +1242,157 @@ func (h *BatchHandlers) HandleEstimateCost(w http.ResponseWriter, r *http.Reques
 		"total_cost_usd":    totalCost,
 		"currency":          "USD",
 		"estimate_validity": "Based on whitepaper Section 3.4.2",
+		"pricing":           "static",
+	}
+
+	// Live figure: estimated gas x gas price x ETH/USD, static tiers if the oracle is unreachable
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	if quote := h.quoteFees(ctx); quote != nil {
+		gas, costWei, costUSD := h.liveCost(quote, batchType, txCount)
+		response["pricing"] = "live"
+		response["static_per_proof_cost"] = perProofCost
+		response["per_proof_cost"] = costUSD / float64(txCount)
+		response["total_cost_usd"] = costUSD
+		response["estimated_gas"] = gas
+		response["gas_price_wei"] = quote.GasPrice.String()
+		response["total_cost_wei"] = costWei.String()
+		response["eth_price_usd"] = quote.EthUSD
+		response["price_source"] = quote.Source
+		response["estimate_validity"] = "Live gas and ETH/USD prices as of " + quote.QuotedAt.UTC().Format(time.RFC3339)
 	}
 
 	json.NewEncoder(w).Encode(response)
 }
 
+// maxCostHistoryRange caps the time range of a cost history query
+const maxCostHistoryRange = 366 * 24 * time.Hour
+
+// HandleGetCostHistory handles GET /api/costs/history?from=&to=&groupBy=day
+// Sums the recorded gas and cost of sent anchor transactions per day and validator
+// (groupBy=day, the default) or per validator (groupBy=validator). from and to are
+// RFC3339 timestamps and default to the last 30 days.
+func (h *BatchHandlers) HandleGetCostHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	to := time.Now().UTC()
+	from := to.Add(-30 * 24 * time.Hour)
+	for _, bound := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := params.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeJSONError(w, fmt.Sprintf("invalid %s timestamp (use RFC3339)", bound.name), http.StatusBadRequest)
+			return
+		}
+		*bound.dst = t
+	}
+	if !from.Before(to) {
+		writeJSONError(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+	if to.Sub(from) > maxCostHistoryRange {
+		writeJSONError(w, fmt.Sprintf("time range exceeds %d days", int(maxCostHistoryRange.Hours()/24)), http.StatusBadRequest)
+		return
+	}
+
+	groupBy := database.CostGroupByDay
+	if value := params.Get("groupBy"); value != "" {
+		groupBy = database.CostGrouping(value)
+	}
+	if !groupBy.IsValid() {
+		writeJSONError(w, "groupBy must be day or validator", http.StatusBadRequest)
+		return
+	}
+
+	if h.repos == nil || h.repos.Anchors == nil {
+		writeJSONError(w, "database not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	rollups, err := h.repos.Anchors.GetCostHistory(r.Context(), from, to, groupBy)
+	if err != nil {
+		h.logger.Printf("Failed to get cost history: %v", err)
+		writeJSONError(w, "failed to get cost history", http.StatusInternalServerError)
+		return
+	}
+
+	// Range totals across the rollups
+	anchorCount, pricedAnchors := 0, 0
+	var gasUsed int64
+	var costUSD float64
+	costWei := new(big.Int)
+	for _, rollup := range rollups {
+		if wei, ok := new(big.Int).SetString(rollup.TotalCostWei, 10); ok {
+			costWei.Add(costWei, wei)
+		}
+		anchorCount += rollup.AnchorCount
+		gasUsed += rollup.GasUsed
+		costUSD += rollup.TotalCostUSD
+		pricedAnchors += rollup.PricedAnchors
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"from":     from.UTC().Format(time.RFC3339),
+		"to":       to.UTC().Format(time.RFC3339),
+		"group_by": groupBy,
+		"rollups":  rollups,
+		"totals": map[string]interface{}{
+			"anchor_count":   anchorCount,
+			"gas_used":       gasUsed,
+			"total_cost_wei": costWei.String(),
+			"total_cost_usd": costUSD,
+			"priced_anchors": pricedAnchors,
+		},
+		"currency": "USD",
+	})
+}
+
+// quoteFees returns a live fee quote, or nil when no oracle is configured or it is
+// unreachable (callers then serve the static price tiers)
+func (h *BatchHandlers) quoteFees(ctx context.Context) *ethereum.FeeQuote {
+	if h.feeOracle == nil {
+		return nil
+	}
+	quote, err := h.feeOracle.Quote(ctx)
+	if err != nil {
+		h.logger.Printf("Fee oracle unavailable, using static price tiers: %v", err)
+		return nil
+	}
+	return quote
+}
+
+// liveCost prices txCount proofs of a batch type at a quote: on-demand proofs pay for a
+// batch of their own, on-cadence proofs share a batch with onCadenceProofs others
+func (h *BatchHandlers) liveCost(quote *ethereum.FeeQuote, batchType string, txCount int) (gas uint64, costWei *big.Int, costUSD float64) {
+	gas = h.batchGas
+	if batchType != "on-demand" && h.onCadenceProofs > 0 {
+		gas = h.batchGas * uint64(txCount) / uint64(h.onCadenceProofs)
+	}
+	costWei = quote.CostWei(gas)
+	return gas, costWei, quote.WeiToUSD(costWei)
+}
+
+// perProofCostUSD returns the whitepaper price of a proof for a batch type
+// ("on-demand" or "on-cadence")
+func perProofCostUSD(batchType string) float64 {
+	if batchType == "on-demand" {
+		return 0.25
+	}
+	return 0.05
+}
+
 func parseInt(s string) (int, error) {
 	var result int
 	_, err := fmt.Sscanf(s, "%d", &result)
@@ -0,0 +1,183 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Batch Handlers
+// Tests anchor receipt construction, signing and finality gating, batch retry guards
+// and cost history parameters
+
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/anchor_proof"
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func testAnchorRecord() *database.AnchorRecord {
+	return &database.AnchorRecord{
+		AnchorID:          uuid.New(),
+		BatchID:           uuid.New(),
+		TargetChain:       database.TargetChainEthereum,
+		ChainID:           sql.NullString{String: "11155111", Valid: true},
+		AnchorTxHash:      "0xabc123",
+		AnchorBlockNumber: 4200,
+		AnchorTimestamp:   sql.NullTime{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), Valid: true},
+		MerkleRoot:        make([]byte, 32),
+		Confirmations:     12,
+		Finality:          database.AnchorFinalityFinal,
+	}
+}
+
+func TestAnchorReceipt_SignAndVerify(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	signer, err := anchor_proof.NewAttestationSigner("validator-1", privateKey)
+	if err != nil {
+		t.Fatalf("NewAttestationSigner failed: %v", err)
+	}
+
+	anchor := testAnchorRecord()
+	receipt := buildAnchorReceipt(anchor)
+	if err := signer.SignAnchorReceipt(receipt); err != nil {
+		t.Fatalf("SignAnchorReceipt failed: %v", err)
+	}
+
+	if receipt.BatchID != anchor.BatchID.String() || receipt.BlockNumber != 4200 ||
+		receipt.AnchoredAt != "2025-06-01T12:00:00Z" || receipt.ValidatorID != "validator-1" {
+		t.Errorf("unexpected receipt contents: %+v", receipt)
+	}
+	if err := anchor_proof.VerifyAnchorReceipt(receipt); err != nil {
+		t.Fatalf("expected valid receipt, got %v", err)
+	}
+
+	receipt.BlockNumber++
+	if err := anchor_proof.VerifyAnchorReceipt(receipt); err == nil {
+		t.Error("expected tampered receipt to fail verification")
+	}
+}
+
+func TestAnchorReachedFinality(t *testing.T) {
+	anchor := testAnchorRecord()
+
+	anchor.Finality = database.AnchorFinalityAvailable
+	if !anchorReachedFinality(anchor, database.AnchorFinalityAvailable) {
+		t.Error("available anchor should satisfy available finality")
+	}
+	if anchorReachedFinality(anchor, database.AnchorFinalityFinal) {
+		t.Error("available anchor should not satisfy final finality")
+	}
+
+	anchor.Finality = database.AnchorFinalityPending
+	if anchorReachedFinality(anchor, database.AnchorFinalityAvailable) {
+		t.Error("pending anchor should not satisfy available finality")
+	}
+
+	anchor.IsFinal = true
+	if !anchorReachedFinality(anchor, database.AnchorFinalityFinal) {
+		t.Error("anchor flagged final should satisfy final finality")
+	}
+}
+
+func TestHandleGetAnchorReceipt_Disabled(t *testing.T) {
+	handlers := NewBatchHandlers(nil, nil, nil, &database.Repositories{}, "test", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/anchors/"+uuid.New().String()+"/receipt", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetAnchor(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected %d when receipts are disabled, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestHandleGetAbandonedOnDemand_Disabled(t *testing.T) {
+	handlers := NewBatchHandlers(nil, nil, nil, &database.Repositories{}, "test", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/batches/abandoned", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetAbandonedOnDemand(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected %d when abandonment is disabled, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestHandleGetAnchorReconciliation_Disabled(t *testing.T) {
+	handlers := NewBatchHandlers(nil, nil, nil, &database.Repositories{}, "test", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/anchors/reconciliation", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetAnchorReconciliation(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected %d when reconciliation is disabled, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestHandleRetryBatch_Guards(t *testing.T) {
+	path := "/api/batches/" + uuid.New().String() + "/retry"
+	retry := func(handlers *BatchHandlers, method, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handlers.HandleBatchStatus(rr, req)
+		return rr.Code
+	}
+
+	handlers := NewBatchHandlers(nil, nil, nil, &database.Repositories{}, "test", nil)
+	if code := retry(handlers, http.MethodPost, "secret"); code != http.StatusNotFound {
+		t.Errorf("expected %d when retry is disabled, got %d", http.StatusNotFound, code)
+	}
+
+	handlers.SetRetryToken("secret")
+	if code := retry(handlers, http.MethodPost, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("expected %d for a wrong token, got %d", http.StatusUnauthorized, code)
+	}
+	if code := retry(handlers, http.MethodGet, "secret"); code != http.StatusMethodNotAllowed {
+		t.Errorf("expected %d for GET, got %d", http.StatusMethodNotAllowed, code)
+	}
+	if code := retry(handlers, http.MethodPost, "secret"); code != http.StatusServiceUnavailable {
+		t.Errorf("expected %d without a processor, got %d", http.StatusServiceUnavailable, code)
+	}
+}
+
+func TestHandleGetCostHistory_Params(t *testing.T) {
+	handlers := NewBatchHandlers(nil, nil, nil, &database.Repositories{}, "test", nil)
+	history := func(method, query string) int {
+		req := httptest.NewRequest(method, "/api/costs/history"+query, nil)
+		rr := httptest.NewRecorder()
+		handlers.HandleGetCostHistory(rr, req)
+		return rr.Code
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		want   int
+	}{
+		{"post", http.MethodPost, "", http.StatusMethodNotAllowed},
+		{"bad from", http.MethodGet, "?from=yesterday", http.StatusBadRequest},
+		{"from after to", http.MethodGet, "?from=2025-06-02T00:00:00Z&to=2025-06-01T00:00:00Z", http.StatusBadRequest},
+		{"range too long", http.MethodGet, "?from=2023-01-01T00:00:00Z&to=2025-01-01T00:00:00Z", http.StatusBadRequest},
+		{"unknown grouping", http.MethodGet, "?groupBy=hour", http.StatusBadRequest},
+		{"no anchor repository", http.MethodGet, "?groupBy=validator", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		if code := history(tt.method, tt.query); code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, code)
+		}
+	}
+}
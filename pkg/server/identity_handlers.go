@@ -0,0 +1,95 @@
+// Copyright 2025 Certen Protocol
+//
+// Identity API Handlers
+// Self-description endpoint for network registries, monitoring dashboards and peer
+// validators: who this validator is, which keys it signs with, where it anchors and
+// what it can prove.
+
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// IdentityChain is a chain this validator is configured to anchor to
+type IdentityChain struct {
+	ChainID         string `json:"chain_id"`
+	Network         string `json:"network,omitempty"`
+	ContractAddress string `json:"contract_address,omitempty"`
+	Primary         bool   `json:"primary"` // Chain used for batch anchoring
+}
+
+// ValidatorIdentity describes this validator. Keys are hex encoded.
+type ValidatorIdentity struct {
+	ValidatorID      string          `json:"validator_id"`
+	Ed25519PublicKey string          `json:"ed25519_public_key"`
+	BLSPublicKey     string          `json:"bls_public_key"`
+	EthereumAddress  string          `json:"ethereum_address,omitempty"`
+	Chains           []IdentityChain `json:"chains"`
+	ProofClasses     []string        `json:"proof_classes"` // e.g. "on_cadence", "on_demand"
+	Capabilities     []string        `json:"capabilities"`  // Optional features enabled on this validator
+	Version          string          `json:"version"`
+}
+
+// IdentityHandlers provides the validator identity endpoint
+type IdentityHandlers struct {
+	identity ValidatorIdentity
+	health   func() interface{} // Current health summary (optional)
+	logger   *log.Logger
+}
+
+// NewIdentityHandlers creates identity handlers
+func NewIdentityHandlers(identity ValidatorIdentity, health func() interface{}, logger *log.Logger) *IdentityHandlers {
+	if logger == nil {
+		logger = log.New(log.Writer(), "[IdentityAPI] ", log.LstdFlags)
+	}
+	// Render empty lists as [] rather than null
+	if identity.Chains == nil {
+		identity.Chains = []IdentityChain{}
+	}
+	if identity.ProofClasses == nil {
+		identity.ProofClasses = []string{}
+	}
+	if identity.Capabilities == nil {
+		identity.Capabilities = []string{}
+	}
+	return &IdentityHandlers{
+		identity: identity,
+		health:   health,
+		logger:   logger,
+	}
+}
+
+// AddChain records a chain this validator can anchor to; duplicates are ignored
+func (id *ValidatorIdentity) AddChain(chain IdentityChain) {
+	for _, existing := range id.Chains {
+		if existing.ChainID == chain.ChainID {
+			return
+		}
+	}
+	id.Chains = append(id.Chains, chain)
+}
+
+// HandleGetIdentity handles GET /api/v1/identity
+func (h *IdentityHandlers) HandleGetIdentity(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := struct {
+		ValidatorIdentity
+		Health interface{} `json:"health,omitempty"`
+	}{ValidatorIdentity: h.identity}
+	if h.health != nil {
+		response.Health = h.health()
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Printf("Failed to encode identity response: %v", err)
+	}
+}
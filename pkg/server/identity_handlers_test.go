@@ -0,0 +1,112 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for Identity Handlers
+// Tests the identity response fields and that no secret key material is served
+
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/certen/independant-validator/pkg/crypto/bls"
+)
+
+func TestHandleGetIdentity(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	blsPriv, blsPub, err := bls.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("bls.GenerateKeyPair failed: %v", err)
+	}
+
+	identity := ValidatorIdentity{
+		ValidatorID:      "validator-1",
+		Ed25519PublicKey: hex.EncodeToString(edPub),
+		BLSPublicKey:     blsPub.Hex(),
+		EthereumAddress:  "0x8B18BE5EE7B4e1f33BAd6f5f0f31588F64F63A4e",
+		ProofClasses:     []string{"on_cadence", "on_demand"},
+		Version:          "test",
+	}
+	identity.AddChain(IdentityChain{ChainID: "11155111", Network: "sepolia", ContractAddress: "0xEb17eBd351D2e040a0cB3026a3D04BEc182d8b98", Primary: true})
+	identity.AddChain(IdentityChain{ChainID: "11155111"}) // Duplicate ignored
+
+	h := NewIdentityHandlers(identity, func() interface{} { return map[string]string{"status": "healthy"} }, nil)
+	rec := httptest.NewRecorder()
+	h.HandleGetIdentity(rec, httptest.NewRequest(http.MethodGet, "/api/v1/identity", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, field := range []string{"validator_id", "ed25519_public_key", "bls_public_key", "ethereum_address",
+		"chains", "proof_classes", "capabilities", "version", "health"} {
+		if _, ok := resp[field]; !ok {
+			t.Errorf("missing field %q", field)
+		}
+	}
+	if len(resp) != 9 {
+		t.Errorf("expected exactly 9 fields, got %d: %s", len(resp), body)
+	}
+
+	var decoded struct {
+		ValidatorIdentity
+		Health map[string]string `json:"health"`
+	}
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		t.Fatalf("failed to decode identity: %v", err)
+	}
+	if decoded.Ed25519PublicKey != hex.EncodeToString(edPub) || decoded.BLSPublicKey != blsPub.Hex() {
+		t.Error("public keys do not round trip")
+	}
+	if len(decoded.Chains) != 1 || !decoded.Chains[0].Primary {
+		t.Errorf("expected one primary chain, got %+v", decoded.Chains)
+	}
+	if decoded.Capabilities == nil || len(decoded.Capabilities) != 0 {
+		t.Errorf("expected empty capabilities list, got %v", decoded.Capabilities)
+	}
+	if decoded.Health["status"] != "healthy" {
+		t.Errorf("expected health summary, got %v", decoded.Health)
+	}
+
+	// Neither private key appears in any encoding
+	lower := strings.ToLower(body)
+	for name, secret := range map[string][]byte{
+		"ed25519 private key": edPriv,
+		"ed25519 seed":        edPriv.Seed(),
+		"bls private key":     blsPriv.Bytes(),
+	} {
+		if strings.Contains(lower, hex.EncodeToString(secret)) {
+			t.Errorf("response leaks the %s (hex)", name)
+		}
+		if b, _ := json.Marshal(secret); strings.Contains(body, strings.Trim(string(b), `"`)) {
+			t.Errorf("response leaks the %s (base64)", name)
+		}
+	}
+	for _, word := range []string{"private", "secret", "seed"} {
+		if strings.Contains(lower, word) {
+			t.Errorf("response mentions %q: %s", word, body)
+		}
+	}
+}
+
+func TestHandleGetIdentity_MethodNotAllowed(t *testing.T) {
+	h := NewIdentityHandlers(ValidatorIdentity{ValidatorID: "validator-1"}, nil, nil)
+	rec := httptest.NewRecorder()
+	h.HandleGetIdentity(rec, httptest.NewRequest(http.MethodPost, "/api/v1/identity", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
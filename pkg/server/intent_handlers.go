@@ -0,0 +1,71 @@
+// Copyright 2025 Certen Protocol
+//
+// Intent API Handlers
+// Serves proofs this validator generated as owner under proof-work partitioning,
+// so peer validators can reuse them instead of regenerating.
+
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/certen/independant-validator/pkg/intent"
+)
+
+// IntentHandlers provides HTTP handlers for intent proof sharing
+type IntentHandlers struct {
+	sharedProofs *intent.SharedProofCache
+	validatorID  string
+	logger       *log.Logger
+}
+
+// NewIntentHandlers creates new intent handlers
+func NewIntentHandlers(sharedProofs *intent.SharedProofCache, validatorID string, logger *log.Logger) *IntentHandlers {
+	if logger == nil {
+		logger = log.New(log.Writer(), "[IntentAPI] ", log.LstdFlags)
+	}
+	return &IntentHandlers{
+		sharedProofs: sharedProofs,
+		validatorID:  validatorID,
+		logger:       logger,
+	}
+}
+
+// HandleGetSharedProof handles GET /api/intents/shared-proofs/:intentID
+func (h *IntentHandlers) HandleGetSharedProof(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.sharedProofs == nil {
+		writeJSONError(w, "proof sharing not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/intents/shared-proofs/")
+	if path == "" || path == r.URL.Path {
+		writeJSONError(w, "intent ID required", http.StatusBadRequest)
+		return
+	}
+	intentID, err := url.PathUnescape(path)
+	if err != nil {
+		writeJSONError(w, "invalid intent ID", http.StatusBadRequest)
+		return
+	}
+
+	shared, ok := h.sharedProofs.Get(intentID)
+	if !ok {
+		writeJSONError(w, "shared proof not found", http.StatusNotFound)
+		return
+	}
+
+	h.logger.Printf("Serving shared proof for intent %s to %s", intentID, r.Header.Get("X-Validator-ID"))
+	json.NewEncoder(w).Encode(shared)
+}
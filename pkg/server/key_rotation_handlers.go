@@ -0,0 +1,113 @@
+// Copyright 2025 Certen Protocol
+//
+// Write-Back Key Rotation API Handlers
+// Admin-only endpoint that rotates the key the Accumulate write-back submitter signs with.
+// The new key is read from a file on the validator host, so private key material never
+// travels in the request. Registered only when ADMIN_API_TOKEN is set and write-back
+// uses a real Accumulate submitter.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/execution"
+	"github.com/certen/independant-validator/pkg/keys"
+)
+
+// WriteBackKeyRotator rotates the write-back signing key
+type WriteBackKeyRotator interface {
+	RotateSigningKey(ctx context.Context, newKey keys.KeyProvider, keyPageIndex uint64, updateKeyPage bool) (*execution.KeyRotation, error)
+	GetPublicKeyHex() string
+	GetKeyPageIndex() uint64
+}
+
+// KeyRotationRequest is the body of POST /api/admin/writeback-key
+type KeyRotationRequest struct {
+	KeyPath       string `json:"key_path"`        // Hex-encoded Ed25519 key file on the validator host
+	KeyPageIndex  uint64 `json:"key_page_index"`  // New key page index (0 keeps the current one)
+	UpdateKeyPage bool   `json:"update_key_page"` // Submit an UpdateKey transaction before switching
+}
+
+// KeyRotationHandlers provides the write-back key rotation endpoint
+type KeyRotationHandlers struct {
+	rotator    WriteBackKeyRotator
+	adminToken string
+	logger     *log.Logger
+}
+
+// NewKeyRotationHandlers creates key rotation handlers
+func NewKeyRotationHandlers(rotator WriteBackKeyRotator, adminToken string, logger *log.Logger) *KeyRotationHandlers {
+	if logger == nil {
+		logger = log.New(log.Writer(), "[KeyRotationAPI] ", log.LstdFlags)
+	}
+	return &KeyRotationHandlers{
+		rotator:    rotator,
+		adminToken: adminToken,
+		logger:     logger,
+	}
+}
+
+// HandleWriteBackKey handles GET and POST /api/admin/writeback-key
+func (h *KeyRotationHandlers) HandleWriteBackKey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !bearerTokenMatches(r, h.adminToken) {
+		h.logger.Printf("⚠️ Rejected unauthorized key rotation request from %s", r.RemoteAddr)
+		writeJSONError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"public_key":     h.rotator.GetPublicKeyHex(),
+			"key_page_index": h.rotator.GetKeyPageIndex(),
+		})
+	case http.MethodPost:
+		h.handleRotate(w, r)
+	default:
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *KeyRotationHandlers) handleRotate(w http.ResponseWriter, r *http.Request) {
+	var req KeyRotationRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		writeJSONError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.KeyPath) == "" {
+		writeJSONError(w, "key_path is required", http.StatusBadRequest)
+		return
+	}
+	newKey, err := keys.LoadFileKey(req.KeyPath)
+	if err != nil {
+		writeJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Printf("🔑 Write-back key rotation requested by %s (key page index %d, update key page %v)",
+		r.RemoteAddr, req.KeyPageIndex, req.UpdateKeyPage)
+
+	// Covers waiting for an in-flight submission and the key update confirmation
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+	rotation, err := h.rotator.RotateSigningKey(ctx, newKey, req.KeyPageIndex, req.UpdateKeyPage)
+	if err != nil {
+		h.logger.Printf("❌ Write-back key rotation failed: %v", err)
+		writeJSONError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "rotated",
+		"message":  "write-back now signs with the new key; set ACCUMULATE_WRITEBACK_PRIV_KEY to it before the next restart",
+		"rotation": rotation,
+	})
+}
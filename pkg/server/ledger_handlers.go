@@ -7,6 +7,7 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -97,6 +98,83 @@ func (h *LedgerHandlers) HandleAnchorLedger(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// HandleSystemLedgerSnapshot handles GET /api/system-ledger/snapshot requests
+// Optional query parameters: from_height, to_height, previous_size (only with from_height 1)
+func (h *LedgerHandlers) HandleSystemLedgerSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h.ledgerStore == nil {
+		http.Error(w, `{"error":"ledger store not available"}`, http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	var fromHeight, toHeight uint64
+	var previousSize int
+	var err error
+	if v := query.Get("from_height"); v != "" {
+		if fromHeight, err = strconv.ParseUint(v, 10, 64); err != nil {
+			http.Error(w, `{"error":"invalid from_height parameter"}`, http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("to_height"); v != "" {
+		if toHeight, err = strconv.ParseUint(v, 10, 64); err != nil {
+			http.Error(w, `{"error":"invalid to_height parameter"}`, http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("previous_size"); v != "" {
+		if previousSize, err = strconv.Atoi(v); err != nil || previousSize < 0 {
+			http.Error(w, `{"error":"invalid previous_size parameter"}`, http.StatusBadRequest)
+			return
+		}
+	}
+
+	snapshot, err := h.ledgerStore.GetSystemLedgerSnapshot(h.chainID, fromHeight, toHeight, previousSize)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ledger.ErrMetaNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, ledger.ErrSnapshotTooLarge), errors.Is(err, ledger.ErrPreviousSizeRange):
+			status = http.StatusBadRequest
+		}
+		errorMsg, _ := json.Marshal(map[string]string{"error": fmt.Sprintf("failed to snapshot system ledger: %s", err.Error())})
+		http.Error(w, string(errorMsg), status)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		http.Error(w, `{"error":"failed to encode response"}`, http.StatusInternalServerError)
+	}
+}
+
+// HandleAnchorLedgerSnapshot handles GET /api/anchor-ledger/snapshot requests
+func (h *LedgerHandlers) HandleAnchorLedgerSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h.ledgerStore == nil {
+		http.Error(w, `{"error":"ledger store not available"}`, http.StatusInternalServerError)
+		return
+	}
+
+	snapshot, err := h.ledgerStore.GetAnchorLedgerSnapshot(h.chainID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ledger.ErrAnchorMetaNotFound) {
+			status = http.StatusNotFound
+		}
+		errorMsg, _ := json.Marshal(map[string]string{"error": fmt.Sprintf("failed to snapshot anchor ledger: %s", err.Error())})
+		http.Error(w, string(errorMsg), status)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		http.Error(w, `{"error":"failed to encode response"}`, http.StatusInternalServerError)
+	}
+}
+
 // HandleLedgerStatus handles GET /api/ledger/status requests
 func (h *LedgerHandlers) HandleLedgerStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,127 @@
+// Copyright 2025 Certen Protocol
+//
+// Maintenance API Handlers
+// Admin-only endpoint that deregisters this validator from the on-chain validator set
+// and shuts it down for planned maintenance. It is registered only when maintenance
+// mode is enabled, requires the admin bearer token, and the request must name this
+// validator's address to confirm the operation.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/certen/independant-validator/pkg/anchor"
+)
+
+// MaintenanceController deregisters the validator for maintenance
+type MaintenanceController interface {
+	Deregister(ctx context.Context, reason string) (*anchor.MaintenanceState, error)
+	State() *anchor.MaintenanceState
+	ValidatorAddress() common.Address
+}
+
+// MaintenanceRequest is the body of POST /api/admin/maintenance
+type MaintenanceRequest struct {
+	ConfirmValidator string `json:"confirm_validator"` // Must equal this validator's address
+	Reason           string `json:"reason"`
+}
+
+// MaintenanceHandlers provides the admin maintenance endpoint
+type MaintenanceHandlers struct {
+	controller     MaintenanceController
+	adminToken     string
+	onDeregistered func() // Starts the graceful shutdown
+	logger         *log.Logger
+}
+
+// NewMaintenanceHandlers creates maintenance handlers; onDeregistered is called after a
+// successful deregistration has been reported to the caller
+func NewMaintenanceHandlers(controller MaintenanceController, adminToken string, onDeregistered func(), logger *log.Logger) *MaintenanceHandlers {
+	if logger == nil {
+		logger = log.New(log.Writer(), "[MaintenanceAPI] ", log.LstdFlags)
+	}
+	return &MaintenanceHandlers{
+		controller:     controller,
+		adminToken:     adminToken,
+		onDeregistered: onDeregistered,
+		logger:         logger,
+	}
+}
+
+// HandleMaintenance handles GET and POST /api/admin/maintenance
+func (h *MaintenanceHandlers) HandleMaintenance(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !bearerTokenMatches(r, h.adminToken) {
+		h.logger.Printf("⚠️ Rejected unauthorized maintenance request from %s", r.RemoteAddr)
+		writeJSONError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"validator_address": h.controller.ValidatorAddress().Hex(),
+			"in_maintenance":    h.controller.State() != nil,
+			"state":             h.controller.State(),
+		})
+	case http.MethodPost:
+		h.handleDeregister(w, r)
+	default:
+		writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *MaintenanceHandlers) handleDeregister(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		writeJSONError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	validator := h.controller.ValidatorAddress()
+	if !common.IsHexAddress(req.ConfirmValidator) || common.HexToAddress(req.ConfirmValidator) != validator {
+		writeJSONError(w, "confirm_validator must be this validator's address "+validator.Hex(), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		writeJSONError(w, "reason is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Printf("🛠️ Maintenance deregistration requested by %s: %s", r.RemoteAddr, req.Reason)
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Minute)
+	defer cancel()
+	state, err := h.controller.Deregister(ctx, req.Reason)
+	if err != nil {
+		h.logger.Printf("❌ Maintenance deregistration failed: %v", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, anchor.ErrMaintenanceNotPermitted) {
+			status = http.StatusConflict
+		}
+		writeJSONError(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "deregistered",
+		"message": "validator deregistered; shutting down for maintenance, it re-registers on restart",
+		"state":   state,
+	})
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	if h.onDeregistered != nil {
+		go h.onDeregistered()
+	}
+}
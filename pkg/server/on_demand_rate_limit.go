@@ -0,0 +1,158 @@
+// Copyright 2025 Certen Protocol
+//
+// On-Demand Rate Limiting
+//
+// On-demand anchors are expensive: each request can close a batch and submit an
+// anchor transaction. A single account (or client) flooding POST /api/anchors/on-demand
+// would drain the validator's gas budget long before usage quotas are reconciled, so
+// requests are rate limited per key before they reach the on-demand handler:
+//   - the normalized account URL of the request
+//   - the client IP address
+//
+// Limits are token buckets: each key may burst up to the bucket size and is then
+// limited to the refill rate. Rejected requests receive HTTP 429 with a Retry-After
+// header. OnDemandRateLimiter is the extension point for a shared backend (e.g. Redis)
+// when several validator nodes serve the same clients.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/batch"
+)
+
+// OnDemandRateLimiter decides whether a request for key may proceed
+// Implementations must be safe for concurrent use
+type OnDemandRateLimiter interface {
+	// Allow consumes one token for key. When the request is not allowed, retryAfter
+	// is how long until a token is available.
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// On-demand rate limit keys
+const (
+	OnDemandRateLimitByAccount = "account"
+	OnDemandRateLimitByIP      = "ip"
+)
+
+// onDemandBucket is the token bucket of one key
+type onDemandBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// TokenBucketRateLimiter is an in-memory OnDemandRateLimiter for a single node
+type TokenBucketRateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // Tokens per second
+	burst     float64
+	buckets   map[string]*onDemandBucket
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+// NewTokenBucketRateLimiter creates a limiter allowing ratePerMinute requests per key,
+// with bursts of up to burst requests. A non-positive burst allows one request at a time.
+func NewTokenBucketRateLimiter(ratePerMinute float64, burst int) *TokenBucketRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucketRateLimiter{
+		rate:    ratePerMinute / 60,
+		burst:   float64(burst),
+		buckets: make(map[string]*onDemandBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow consumes one token for key
+func (l *TokenBucketRateLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.pruneLocked(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &onDemandBucket{tokens: l.burst, lastFill: now}
+		l.buckets[key] = bucket
+	}
+
+	if elapsed := now.Sub(bucket.lastFill); elapsed > 0 {
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed.Seconds()*l.rate)
+		bucket.lastFill = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0, nil
+	}
+	if l.rate <= 0 {
+		return false, time.Minute, nil
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait, nil
+}
+
+// pruneLocked drops buckets that have refilled completely, at most once a minute, so
+// one-off clients do not accumulate. A full bucket behaves exactly like a new one.
+func (l *TokenBucketRateLimiter) pruneLocked(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute || l.rate <= 0 {
+		return
+	}
+	l.lastPrune = now
+
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.lastFill).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// onDemandRateLimitKeys returns the limiter keys of a request
+func (h *BatchHandlers) onDemandRateLimitKeys(r *http.Request, accountURL string) []string {
+	keys := make([]string, 0, len(h.rateLimitKeys))
+	for _, by := range h.rateLimitKeys {
+		switch by {
+		case OnDemandRateLimitByAccount:
+			keys = append(keys, "account:"+batch.NormalizeAccountURL(accountURL))
+		case OnDemandRateLimitByIP:
+			keys = append(keys, "ip:"+getClientIP(r))
+		}
+	}
+	return keys
+}
+
+// checkOnDemandRateLimit writes a 429 response and returns false when any key of the
+// request is over its limit. Limiter errors fail open so an unavailable shared backend
+// does not take the endpoint down.
+func (h *BatchHandlers) checkOnDemandRateLimit(w http.ResponseWriter, r *http.Request, accountURL string) bool {
+	if h.rateLimiter == nil {
+		return true
+	}
+
+	for _, key := range h.onDemandRateLimitKeys(r, accountURL) {
+		allowed, retryAfter, err := h.rateLimiter.Allow(r.Context(), key)
+		if err != nil {
+			h.logger.Printf("⚠️ On-demand rate limiter unavailable for %s, allowing request: %v", key, err)
+			continue
+		}
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", seconds))
+			writeJSONError(w, fmt.Sprintf("rate limit exceeded, retry after %ds", seconds), http.StatusTooManyRequests)
+			return false
+		}
+	}
+	return true
+}
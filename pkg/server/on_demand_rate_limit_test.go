@@ -0,0 +1,118 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for On-Demand Rate Limiting
+// Tests token bucket bursts, refill and pruning, and the 429 response with Retry-After
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/certen/independant-validator/pkg/database"
+)
+
+func TestTokenBucketRateLimiter_BurstAndRefill(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewTokenBucketRateLimiter(6, 2) // One token every 10s
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if allowed, _, _ := limiter.Allow(ctx, "a"); !allowed {
+			t.Fatalf("request %d within the burst was rejected", i+1)
+		}
+	}
+	allowed, retryAfter, err := limiter.Allow(ctx, "a")
+	if err != nil || allowed {
+		t.Fatalf("expected the request over the burst to be rejected, got allowed=%v err=%v", allowed, err)
+	}
+	if retryAfter != 10*time.Second {
+		t.Errorf("expected retry after 10s, got %s", retryAfter)
+	}
+
+	if allowed, _, _ := limiter.Allow(ctx, "b"); !allowed {
+		t.Error("keys must be limited independently")
+	}
+
+	now = now.Add(4 * time.Second)
+	if _, retryAfter, _ := limiter.Allow(ctx, "a"); retryAfter.Round(time.Millisecond) != 6*time.Second {
+		t.Errorf("expected retry after 6s once partially refilled, got %s", retryAfter)
+	}
+	now = now.Add(6 * time.Second)
+	if allowed, _, _ := limiter.Allow(ctx, "a"); !allowed {
+		t.Error("expected a request to be allowed once a token refilled")
+	}
+}
+
+func TestTokenBucketRateLimiter_PrunesFullBuckets(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewTokenBucketRateLimiter(60, 5)
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	limiter.Allow(ctx, "idle")
+	now = now.Add(2 * time.Minute)
+	limiter.Allow(ctx, "active")
+
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Error("expected the refilled bucket to be pruned")
+	}
+	if _, ok := limiter.buckets["active"]; !ok {
+		t.Error("expected the active bucket to be kept")
+	}
+}
+
+// failingRateLimiter is an OnDemandRateLimiter whose backend is unavailable
+type failingRateLimiter struct{}
+
+func (failingRateLimiter) Allow(context.Context, string) (bool, time.Duration, error) {
+	return false, 0, errors.New("backend unavailable")
+}
+
+func TestCheckOnDemandRateLimit(t *testing.T) {
+	handlers := NewBatchHandlers(nil, nil, nil, &database.Repositories{}, "test", nil)
+	check := func(account, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/anchors/on-demand", nil)
+		req.Header.Set("X-Real-IP", ip)
+		rr := httptest.NewRecorder()
+		if handlers.checkOnDemandRateLimit(rr, req, account) {
+			rr.WriteHeader(http.StatusOK)
+		}
+		return rr
+	}
+
+	if rr := check("acc://alice.acme", "10.0.0.1"); rr.Code != http.StatusOK {
+		t.Fatalf("expected no limit without a limiter, got %d", rr.Code)
+	}
+
+	handlers.SetOnDemandRateLimiter(NewTokenBucketRateLimiter(1, 1), OnDemandRateLimitByAccount, OnDemandRateLimitByIP)
+	if rr := check("acc://alice.acme", "10.0.0.1"); rr.Code != http.StatusOK {
+		t.Fatalf("expected the first request to be allowed, got %d", rr.Code)
+	}
+
+	// Account URLs are normalized, so case and trailing slashes do not reset the limit
+	rr := check("ACC://Alice.acme/", "10.0.0.2")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected %d for the same account, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	if got := rr.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("expected Retry-After 60, got %q", got)
+	}
+
+	if rr := check("acc://bob.acme", "10.0.0.1"); rr.Code != http.StatusTooManyRequests {
+		t.Errorf("expected %d for the same IP, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	if rr := check("acc://carol.acme", "10.0.0.3"); rr.Code != http.StatusOK {
+		t.Errorf("expected a new account and IP to be allowed, got %d", rr.Code)
+	}
+
+	handlers.SetOnDemandRateLimiter(failingRateLimiter{}, OnDemandRateLimitByAccount)
+	if rr := check("acc://alice.acme", "10.0.0.1"); rr.Code != http.StatusOK {
+		t.Errorf("expected requests to be allowed when the limiter fails, got %d", rr.Code)
+	}
+}
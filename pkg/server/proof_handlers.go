@@ -6,7 +6,10 @@
 package server
 
 import (
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -16,13 +19,38 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/certen/independant-validator/pkg/batch"
 	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/execution"
 )
 
+// proofStreamHeartbeat is the interval of keep-alive comments on idle proof streams
+const proofStreamHeartbeat = 15 * time.Second
+
+// maxAccountProofsPageSize caps the page size of account proof listings
+const maxAccountProofsPageSize = 200
+
+// Proof sync streams send defaultProofSyncLimit proofs unless asked for fewer or more
+// (up to maxProofSyncLimit), flushing every proofSyncFlushInterval proofs
+const (
+	defaultProofSyncLimit  = 10000
+	maxProofSyncLimit      = 100000
+	proofSyncFlushInterval = 100
+)
+
+// maxBlockRangeProofsPageSize caps the page size of block range proof listings
+const maxBlockRangeProofsPageSize = 500
+
 // ProofHandlers provides HTTP handlers for proof artifact operations
 type ProofHandlers struct {
 	repos       *database.Repositories
+	syncSource  ProofSyncSource
 	validatorID string
+	rechecker   *batch.InclusionRechecker          // Optional: on-chain verification and inclusion re-check
+	usageMeter  *batch.UsageMeter                  // Optional: per-account proof usage
+	failures    *batch.VerificationFailureRecorder // Optional: on-chain verification failure events
+	chain       *batch.ProofChainVerifier          // Optional: full proof chain verification
+	cycleEvents *execution.ProofCycleEventBus      // Optional: live proof cycle progress
 	logger      *log.Logger
 }
 
@@ -31,11 +59,40 @@ func NewProofHandlers(repos *database.Repositories, validatorID string, logger *
 	if logger == nil {
 		logger = log.New(log.Writer(), "[ProofAPI] ", log.LstdFlags)
 	}
-	return &ProofHandlers{
+	h := &ProofHandlers{
 		repos:       repos,
 		validatorID: validatorID,
 		logger:      logger,
 	}
+	if repos != nil {
+		h.syncSource = repos.ProofArtifacts
+	}
+	return h
+}
+
+// SetInclusionRechecker enables the on-chain verification endpoint
+func (h *ProofHandlers) SetInclusionRechecker(rechecker *batch.InclusionRechecker) {
+	h.rechecker = rechecker
+}
+
+// SetUsageMeter enables the account usage endpoint
+func (h *ProofHandlers) SetUsageMeter(meter *batch.UsageMeter) {
+	h.usageMeter = meter
+}
+
+// SetVerificationFailureRecorder enables the verification failures endpoint
+func (h *ProofHandlers) SetVerificationFailureRecorder(recorder *batch.VerificationFailureRecorder) {
+	h.failures = recorder
+}
+
+// SetProofChainVerifier enables the proof chain verification endpoint
+func (h *ProofHandlers) SetProofChainVerifier(verifier *batch.ProofChainVerifier) {
+	h.chain = verifier
+}
+
+// SetProofCycleEvents enables the proof progress stream endpoint
+func (h *ProofHandlers) SetProofCycleEvents(bus *execution.ProofCycleEventBus) {
+	h.cycleEvents = bus
 }
 
 // ============================================================================
@@ -74,7 +131,32 @@ func (h *ProofHandlers) HandleGetProofByTxHash(w http.ResponseWriter, r *http.Re
 }
 
 // HandleGetProofByID handles GET /api/v1/proofs/{proof_id}
+// GET /api/v1/proofs/{proof_id}/verify is dispatched to HandleCheckProofOnChain
+// POST /api/v1/proofs/{proof_id}/onchain-verify is dispatched to HandleVerifyProofOnChain
+// POST /api/v1/proofs/{proof_id}/verify-chain is dispatched to HandleVerifyProofChain
+// GET /api/v1/proofs/{proof_id}/bundle is dispatched to HandleGetProofMerkleBundle
 func (h *ProofHandlers) HandleGetProofByID(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/onchain-verify") {
+		h.HandleVerifyProofOnChain(w, r)
+		return
+	}
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/verify-chain") {
+		h.HandleVerifyProofChain(w, r)
+		return
+	}
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/verify") {
+		h.HandleCheckProofOnChain(w, r)
+		return
+	}
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/stream") {
+		h.HandleStreamProof(w, r)
+		return
+	}
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/bundle") {
+		h.HandleGetProofMerkleBundle(w, r)
+		return
+	}
+
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
 		return
@@ -98,6 +180,16 @@ func (h *ProofHandlers) HandleGetProofByID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if proof == nil && h.repos.Archive != nil {
+		// Proofs past the retention window are served from the archive
+		proof, err = h.repos.Archive.GetArchivedProof(ctx, proofID)
+		if err != nil && !errors.Is(err, database.ErrProofNotFound) {
+			h.logger.Printf("Error getting archived proof: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve proof")
+			return
+		}
+	}
+
 	if proof == nil {
 		h.writeError(w, http.StatusNotFound, "PROOF_NOT_FOUND", fmt.Sprintf("No proof found with ID: %s", proofID))
 		return
@@ -107,6 +199,9 @@ func (h *ProofHandlers) HandleGetProofByID(w http.ResponseWriter, r *http.Reques
 }
 
 // HandleGetProofsByAccount handles GET /api/v1/proofs/account/{account_url}
+//
+// Query params: limit (max 200), offset or cursor (the "next" token of the previous
+// page), from/to (RFC3339 creation time bounds) and status
 func (h *ProofHandlers) HandleGetProofsByAccount(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
@@ -121,27 +216,76 @@ func (h *ProofHandlers) HandleGetProofsByAccount(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Parse pagination params
-	limit := h.parseIntParam(r, "limit", 50)
-	offset := h.parseIntParam(r, "offset", 0)
-	if limit > 1000 {
-		limit = 1000
+	query := &database.AccountProofQuery{
+		AccountURL: accountURL,
+		Limit:      h.parseIntParam(r, "limit", 50),
+		Offset:     h.parseIntParam(r, "offset", 0),
+	}
+	if query.Limit <= 0 {
+		query.Limit = 50
+	}
+	if query.Limit > maxAccountProofsPageSize {
+		query.Limit = maxAccountProofsPageSize
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	params := r.URL.Query()
+	if cursor := params.Get("cursor"); cursor != "" {
+		after, err := database.ParseProofCursor(cursor)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_CURSOR", "Invalid pagination cursor")
+			return
+		}
+		query.After = after
+		query.Offset = 0
+	}
+	if status := params.Get("status"); status != "" {
+		proofStatus := database.ProofStatus(status)
+		if !proofStatus.IsValid() {
+			h.writeError(w, http.StatusBadRequest, "INVALID_STATUS", "Unknown proof status: "+status)
+			return
+		}
+		query.Status = &proofStatus
+	}
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &query.CreatedAfter}, {"to", &query.CreatedBefore}} {
+		value := params.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_TIMESTAMP",
+				fmt.Sprintf("Invalid %s timestamp format (use RFC3339)", bound.name))
+			return
+		}
+		*bound.dst = &t
 	}
 
 	ctx := r.Context()
-	proofs, err := h.repos.ProofArtifacts.GetProofsByAccount(ctx, accountURL, limit, offset)
+	page, err := h.repos.ProofArtifacts.QueryProofsByAccount(ctx, query)
 	if err != nil {
 		h.logger.Printf("Error getting proofs by account: %v", err)
 		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve proofs")
 		return
 	}
 
+	next := ""
+	if page.Next != nil {
+		next = page.Next.String()
+	}
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
 		"account_url": accountURL,
-		"proofs":      proofs,
-		"count":       len(proofs),
-		"limit":       limit,
-		"offset":      offset,
+		"proofs":      page.Proofs,
+		"count":       len(page.Proofs),
+		"total":       page.Total,
+		"limit":       query.Limit,
+		"offset":      query.Offset,
+		"next":        next,
 	})
 }
 
@@ -207,6 +351,70 @@ func (h *ProofHandlers) HandleGetProofsByAnchor(w http.ResponseWriter, r *http.R
 	})
 }
 
+// HandleGetProofsByBlockRange handles GET /api/v1/proofs/blocks?chain=&from=&to=
+// Lists proofs anchored on a chain within a block range, to cross-check against
+// on-chain AnchorCreated events
+func (h *ProofHandlers) HandleGetProofsByBlockRange(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
+		return
+	}
+
+	params := r.URL.Query()
+	chain := params.Get("chain")
+	if chain == "" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_CHAIN", "chain is required")
+		return
+	}
+	var fromBlock, toBlock int64
+	for _, bound := range []struct {
+		name string
+		dst  *int64
+	}{{"from", &fromBlock}, {"to", &toBlock}} {
+		block, err := strconv.ParseInt(params.Get(bound.name), 10, 64)
+		if err != nil || block < 0 {
+			h.writeError(w, http.StatusBadRequest, "INVALID_BLOCK",
+				fmt.Sprintf("%s must be a non-negative block number", bound.name))
+			return
+		}
+		*bound.dst = block
+	}
+	if fromBlock > toBlock {
+		h.writeError(w, http.StatusBadRequest, "INVALID_BLOCK", "from cannot be after to")
+		return
+	}
+
+	limit := h.parseIntParam(r, "limit", 100)
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > maxBlockRangeProofsPageSize {
+		limit = maxBlockRangeProofsPageSize
+	}
+	offset := h.parseIntParam(r, "offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	ctx := r.Context()
+	proofs, err := h.repos.ProofArtifacts.GetProofsByBlockRange(ctx, chain, fromBlock, toBlock, limit, offset)
+	if err != nil {
+		h.logger.Printf("Error getting proofs by block range: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve proofs")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"chain":      chain,
+		"from_block": fromBlock,
+		"to_block":   toBlock,
+		"proofs":     proofs,
+		"count":      len(proofs),
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
 // HandleQueryProofs handles POST /api/v1/proofs/query
 func (h *ProofHandlers) HandleQueryProofs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -457,6 +665,377 @@ func (h *ProofHandlers) HandleVerifyProofIntegrity(w http.ResponseWriter, r *htt
 	})
 }
 
+// HandleCheckProofOnChain handles GET /api/v1/proofs/{proof_id}/verify
+// Returns the contract's verifyCertenProofDetailed breakdown for the stored proof without
+// recording or regenerating it, so auditors need not trust the database
+func (h *ProofHandlers) HandleCheckProofOnChain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
+		return
+	}
+	if h.rechecker == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "NOT_CONFIGURED", "On-chain proof verification is not configured")
+		return
+	}
+
+	// Extract proof ID from path
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/proofs/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[1] != "verify" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PATH", "Invalid endpoint path")
+		return
+	}
+
+	proofID, err := uuid.Parse(parts[0])
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PROOF_ID", "Invalid proof ID format")
+		return
+	}
+
+	result, err := h.rechecker.CheckProof(r.Context(), proofID)
+	if errors.Is(err, batch.ErrProofNotFound) {
+		h.writeError(w, http.StatusNotFound, "PROOF_NOT_FOUND", fmt.Sprintf("No proof found with ID: %s", proofID))
+		return
+	}
+	if errors.Is(err, batch.ErrProofNotBatched) {
+		h.writeError(w, http.StatusConflict, "PROOF_NOT_BATCHED", "Proof has not been batched and anchored yet")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Error checking proof %s on-chain: %v", proofID, err)
+		h.writeError(w, http.StatusInternalServerError, "VERIFICATION_ERROR", err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// HandleVerifyProofOnChain handles POST /api/v1/proofs/{proof_id}/onchain-verify
+// Checks the stored proof with the contract's verifyCertenProofDetailed view; when enabled,
+// a failing proof's batch inclusion proof is rebuilt from current batch state and replaced
+// if it then passes. Lite client and governance proofs are not regenerated.
+func (h *ProofHandlers) HandleVerifyProofOnChain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only POST is allowed")
+		return
+	}
+	if h.rechecker == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "NOT_CONFIGURED", "On-chain proof verification is not configured")
+		return
+	}
+
+	// Extract proof ID from path
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/proofs/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[1] != "onchain-verify" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PATH", "Invalid endpoint path")
+		return
+	}
+
+	proofID, err := uuid.Parse(parts[0])
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PROOF_ID", "Invalid proof ID format")
+		return
+	}
+
+	report, err := h.rechecker.VerifyProof(r.Context(), proofID)
+	if errors.Is(err, batch.ErrProofNotFound) {
+		h.writeError(w, http.StatusNotFound, "PROOF_NOT_FOUND", fmt.Sprintf("No proof found with ID: %s", proofID))
+		return
+	}
+	if errors.Is(err, batch.ErrProofNotBatched) {
+		h.writeError(w, http.StatusConflict, "PROOF_NOT_BATCHED", "Proof has not been batched and anchored yet")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Error verifying proof %s on-chain: %v", proofID, err)
+		h.writeError(w, http.StatusInternalServerError, "VERIFICATION_ERROR", err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// HandleVerifyProofChain handles POST /api/v1/proofs/{proof_id}/verify-chain
+// Re-checks every link of the stored proof chain (L1 -> L2 -> L3 -> L4 -> anchor) and
+// returns a pass/fail result per link plus the overall result
+func (h *ProofHandlers) HandleVerifyProofChain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only POST is allowed")
+		return
+	}
+	if h.chain == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "NOT_CONFIGURED", "Proof chain verification is not configured")
+		return
+	}
+
+	// Extract proof ID from path
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/proofs/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[1] != "verify-chain" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PATH", "Invalid endpoint path")
+		return
+	}
+
+	proofID, err := uuid.Parse(parts[0])
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PROOF_ID", "Invalid proof ID format")
+		return
+	}
+
+	report, err := h.chain.VerifyChain(r.Context(), proofID)
+	if errors.Is(err, batch.ErrProofNotFound) {
+		h.writeError(w, http.StatusNotFound, "PROOF_NOT_FOUND", fmt.Sprintf("No proof found with ID: %s", proofID))
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Error verifying proof chain %s: %v", proofID, err)
+		h.writeError(w, http.StatusInternalServerError, "VERIFICATION_ERROR", err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// HandleGetProofMerkleBundle handles GET /api/v1/proofs/{proof_id}/bundle
+// Returns a self-contained batch Merkle proof (leaf, ordered sibling path, root and
+// anchor transaction) with a recipe for verifying it with plain SHA-256
+func (h *ProofHandlers) HandleGetProofMerkleBundle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
+		return
+	}
+
+	// Extract proof ID from path
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/proofs/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[1] != "bundle" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PATH", "Invalid endpoint path")
+		return
+	}
+
+	proofID, err := uuid.Parse(parts[0])
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PROOF_ID", "Invalid proof ID format")
+		return
+	}
+
+	ctx := r.Context()
+	inclusion, err := h.repos.ProofArtifacts.GetProofMerkleInclusion(ctx, proofID)
+	if err != nil {
+		h.logger.Printf("Error getting merkle inclusion for proof %s: %v", proofID, err)
+		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve proof")
+		return
+	}
+	if inclusion == nil {
+		h.writeError(w, http.StatusNotFound, "PROOF_NOT_FOUND", fmt.Sprintf("No proof found with ID: %s", proofID))
+		return
+	}
+
+	bundle, err := buildMerkleProofBundle(inclusion)
+	if errors.Is(err, errMerkleProofUnavailable) {
+		h.writeError(w, http.StatusConflict, "PROOF_NOT_ANCHORED", err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Printf("Error building merkle bundle for proof %s: %v", proofID, err)
+		h.writeError(w, http.StatusInternalServerError, "INCONSISTENT_PROOF", "Stored Merkle proof does not verify")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, bundle)
+}
+
+// HandleStreamProof handles GET /api/v1/proofs/{proof_id}/stream
+// Pushes the proof's cycle stage transitions as server-sent events, starting with the
+// current stage, and closes the stream after a terminal completed or failed event
+func (h *ProofHandlers) HandleStreamProof(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
+		return
+	}
+	if h.cycleEvents == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "NOT_CONFIGURED", "Proof progress streaming is not configured")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, http.StatusInternalServerError, "STREAMING_UNSUPPORTED", "Streaming is not supported")
+		return
+	}
+
+	// Extract proof ID from path
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/proofs/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[1] != "stream" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PATH", "Invalid endpoint path")
+		return
+	}
+
+	proofID, err := uuid.Parse(parts[0])
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PROOF_ID", "Invalid proof ID format")
+		return
+	}
+
+	// Subscribe before reading the proof so no transition is missed in between
+	events, current, unsubscribe := h.cycleEvents.Subscribe()
+	defer unsubscribe()
+
+	ctx := r.Context()
+	proof, err := h.repos.ProofArtifacts.GetProofByID(ctx, proofID)
+	if err != nil {
+		h.logger.Printf("Error getting proof: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve proof")
+		return
+	}
+	if proof == nil {
+		h.writeError(w, http.StatusNotFound, "PROOF_NOT_FOUND", fmt.Sprintf("No proof found with ID: %s", proofID))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// A proof whose cycle already finished gets its outcome straight away
+	switch proof.Status {
+	case database.ProofStatusVerified:
+		h.writeProofEvent(w, flusher, execution.ProofCycleEvent{Stage: execution.ProofCycleStageCompleted, Terminal: true, Timestamp: time.Now()})
+		return
+	case database.ProofStatusFailed:
+		h.writeProofEvent(w, flusher, execution.ProofCycleEvent{Stage: execution.ProofCycleStageFailed, Terminal: true, Timestamp: time.Now()})
+		return
+	}
+
+	for _, event := range current {
+		if proofCycleEventMatches(proof, event) {
+			h.writeProofEvent(w, flusher, event)
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(proofStreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case event := <-events:
+			if !proofCycleEventMatches(proof, event) {
+				continue
+			}
+			h.writeProofEvent(w, flusher, event)
+			if event.Terminal {
+				return
+			}
+		}
+	}
+}
+
+// writeProofEvent writes a proof cycle event as a server-sent event named after its
+// kind: "stage" for transitions, or the terminal stage
+func (h *ProofHandlers) writeProofEvent(w http.ResponseWriter, flusher http.Flusher, event execution.ProofCycleEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		h.logger.Printf("Error encoding proof event: %v", err)
+		return
+	}
+	name := "stage"
+	if event.Terminal {
+		name = event.Stage
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	flusher.Flush()
+}
+
+// proofCycleEventMatches reports whether an event belongs to the proof's cycle
+func proofCycleEventMatches(proof *database.ProofArtifact, event execution.ProofCycleEvent) bool {
+	if proof.IntentID != nil && *proof.IntentID != "" && event.IntentID == *proof.IntentID {
+		return true
+	}
+	return proof.AccumTxHash != "" && event.IntentTxHash == proof.AccumTxHash
+}
+
+// HandleGetVerificationFailures handles GET /api/v1/proofs/verification-failures
+// Returns stored ProofVerificationFailed contract events, newest first, with the
+// failure counters used by the health check
+func (h *ProofHandlers) HandleGetVerificationFailures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
+		return
+	}
+	if h.failures == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "NOT_CONFIGURED", "Contract event watching is not configured")
+		return
+	}
+
+	limit := h.parseIntParam(r, "limit", 50)
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+	offset := h.parseIntParam(r, "offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	failures, err := h.failures.ListFailures(r.Context(), limit, offset)
+	if err != nil {
+		h.logger.Printf("Error listing verification failures: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve verification failures")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"failures": failures,
+		"count":    len(failures),
+		"limit":    limit,
+		"offset":   offset,
+		"stats":    h.failures.Stats(),
+	})
+}
+
+// ============================================================================
+// ACCOUNT USAGE ENDPOINTS
+// ============================================================================
+
+// HandleGetAccountUsage handles GET /api/v1/accounts/{account_url}/usage
+// The account URL may be given with or without the acc:// scheme, or URL-encoded
+func (h *ProofHandlers) HandleGetAccountUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
+		return
+	}
+	if h.usageMeter == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "NOT_CONFIGURED", "Proof usage metering is not configured")
+		return
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/accounts/"), "/")
+	accountURL, ok := strings.CutSuffix(path, "/usage")
+	if !ok {
+		h.writeError(w, http.StatusBadRequest, "INVALID_PATH", "Invalid endpoint path")
+		return
+	}
+	if accountURL == "" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_ACCOUNT", "Account URL is required")
+		return
+	}
+
+	usage, err := h.usageMeter.GetUsage(r.Context(), accountURL)
+	if err != nil {
+		h.logger.Printf("Error getting usage for %s: %v", accountURL, err)
+		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve account usage")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, usage)
+}
+
 // ============================================================================
 // BATCH STATISTICS ENDPOINTS
 // ============================================================================
@@ -497,13 +1076,88 @@ func (h *ProofHandlers) HandleGetBatchStats(w http.ResponseWriter, r *http.Reque
 // SYNC ENDPOINTS (For Auditing Nodes)
 // ============================================================================
 
+// ProofSyncSource streams proofs in update order for the sync endpoint
+type ProofSyncSource interface {
+	StreamProofsSince(ctx context.Context, after *database.ProofSyncCursor, limit int, fn func(*database.ProofArtifact, database.ProofSyncCursor) error) (bool, error)
+}
+
 // HandleSyncProofs handles GET /api/v1/proofs/sync
+// Streams proofs in update order as NDJSON, one proof per line, starting after the
+// since cursor (a token from a previous sync, or an RFC3339 timestamp). A proof is sent
+// again each time it changes, e.g. once created and again once anchored, so clients
+// upsert by proof_id. The cursor to resume from, the number of proofs sent and whether
+// more follow are sent as trailers.
 func (h *ProofHandlers) HandleSyncProofs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
 		return
 	}
 
+	// Parse since cursor; without one the sync starts from the first proof
+	var after *database.ProofSyncCursor
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		if since, err := time.Parse(time.RFC3339, sinceStr); err == nil {
+			after = &database.ProofSyncCursor{UpdatedAt: since}
+		} else if after, err = database.ParseProofSyncCursor(sinceStr); err != nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_TIMESTAMP", "Invalid since value (use a sync cursor or an RFC3339 timestamp)")
+			return
+		}
+	}
+
+	limit := h.parseIntParam(r, "limit", defaultProofSyncLimit)
+	if limit <= 0 {
+		limit = defaultProofSyncLimit
+	}
+	if limit > maxProofSyncLimit {
+		limit = maxProofSyncLimit
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Trailer", "X-Next-Cursor, X-Sync-Count, X-Sync-Has-More, X-Sync-Error")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	ctx := r.Context()
+	encoder := json.NewEncoder(w)
+	next := after
+	count := 0
+	hasMore, err := h.syncSource.StreamProofsSince(ctx, after, limit, func(p *database.ProofArtifact, cursor database.ProofSyncCursor) error {
+		if err := encoder.Encode(p); err != nil {
+			return err
+		}
+		next = &cursor
+		count++
+		if flusher != nil && count%proofSyncFlushInterval == 0 {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		// The status line is already sent; the trailers tell the client where to resume
+		h.logger.Printf("Error syncing proofs after %d proofs: %v", count, err)
+		w.Header().Set("X-Sync-Error", "Failed to sync proofs")
+	}
+
+	if next != nil {
+		w.Header().Set("X-Next-Cursor", next.String())
+	}
+	w.Header().Set("X-Sync-Count", strconv.Itoa(count))
+	w.Header().Set("X-Sync-Has-More", strconv.FormatBool(hasMore))
+}
+
+// ============================================================================
+// REPORT ENDPOINTS
+// ============================================================================
+
+// HandleGetGasHistory handles GET /api/v1/reports/gas-history
+// Returns the gas price, fee breakdown and fee strategy of each anchor since the given
+// time as JSON, or as CSV with format=csv
+func (h *ProofHandlers) HandleGetGasHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET is allowed")
+		return
+	}
+
 	// Parse since timestamp
 	sinceStr := r.URL.Query().Get("since")
 	var since time.Time
@@ -515,27 +1169,51 @@ func (h *ProofHandlers) HandleSyncProofs(w http.ResponseWriter, r *http.Request)
 			return
 		}
 	} else {
-		// Default to 24 hours ago
-		since = time.Now().Add(-24 * time.Hour)
+		// Default to 7 days ago
+		since = time.Now().Add(-7 * 24 * time.Hour)
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		h.writeError(w, http.StatusBadRequest, "INVALID_FORMAT", "Format must be json or csv")
+		return
 	}
 
 	limit := h.parseIntParam(r, "limit", 1000)
-	if limit > 1000 {
-		limit = 1000
+	if limit <= 0 || limit > 10000 {
+		limit = 10000
 	}
 
-	ctx := r.Context()
-	proofs, err := h.repos.ProofArtifacts.GetProofsModifiedSince(ctx, since, limit)
+	points, err := h.repos.Anchors.GetGasHistory(r.Context(), since, limit)
 	if err != nil {
-		h.logger.Printf("Error syncing proofs: %v", err)
-		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to sync proofs")
+		h.logger.Printf("Error getting gas history: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve gas history")
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"gas_history.csv\"")
+		w.WriteHeader(http.StatusOK)
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"anchored_at", "anchor_id", "target_chain", "anchor_tx_hash", "block_number", "gas_used",
+			"gas_price_wei", "base_fee_wei", "priority_fee_wei", "total_cost_wei", "fee_strategy", "gas_bumped"})
+		for _, p := range points {
+			writer.Write([]string{
+				p.AnchoredAt.UTC().Format(time.RFC3339), p.AnchorID.String(), string(p.TargetChain), p.AnchorTxHash,
+				strconv.FormatInt(p.BlockNumber, 10), strconv.FormatInt(p.GasUsed, 10),
+				p.GasPriceWei, p.BaseFeeWei, p.PriorityFeeWei, p.TotalCostWei, p.FeeStrategy,
+				strconv.FormatBool(p.GasBumped),
+			})
+		}
+		writer.Flush()
 		return
 	}
 
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
 		"since":  since,
-		"proofs": proofs,
-		"count":  len(proofs),
+		"points": points,
+		"count":  len(points),
 		"limit":  limit,
 	})
 }
@@ -6,12 +6,27 @@
 package server
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/execution"
+	"github.com/certen/independant-validator/pkg/merkle"
 )
 
 // ============================================================================
@@ -135,6 +150,90 @@ func TestHandleGetProofsByAccount_MissingAccount(t *testing.T) {
 	}
 }
 
+func TestHandleGetProofsByAccount_InvalidFilters(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	tests := []struct {
+		query    string
+		wantCode string
+	}{
+		{"cursor=not-a-cursor", "INVALID_CURSOR"},
+		{"status=archived", "INVALID_STATUS"},
+		{"from=yesterday", "INVALID_TIMESTAMP"},
+		{"to=2025-13-01", "INVALID_TIMESTAMP"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/proofs/account/acc://alice.acme?"+tt.query, nil)
+		rr := httptest.NewRecorder()
+
+		handlers.HandleGetProofsByAccount(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected %d, got %d", tt.query, http.StatusBadRequest, rr.Code)
+			continue
+		}
+		var response map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&response)
+		errObj := response["error"].(map[string]interface{})
+		if errObj["code"] != tt.wantCode {
+			t.Errorf("%s: expected %s, got %v", tt.query, tt.wantCode, errObj["code"])
+		}
+	}
+}
+
+func TestHandleGetProofsByBlockRange_InvalidParams(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	tests := []struct {
+		query    string
+		wantCode string
+	}{
+		{"from=1&to=2", "INVALID_CHAIN"},
+		{"chain=ethereum&to=2", "INVALID_BLOCK"},
+		{"chain=ethereum&from=1&to=latest", "INVALID_BLOCK"},
+		{"chain=ethereum&from=-1&to=2", "INVALID_BLOCK"},
+		{"chain=ethereum&from=5&to=2", "INVALID_BLOCK"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/proofs/blocks?"+tt.query, nil)
+		rr := httptest.NewRecorder()
+
+		handlers.HandleGetProofsByBlockRange(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected %d, got %d", tt.query, http.StatusBadRequest, rr.Code)
+			continue
+		}
+		var response map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&response)
+		errObj := response["error"].(map[string]interface{})
+		if errObj["code"] != tt.wantCode {
+			t.Errorf("%s: expected %s, got %v", tt.query, tt.wantCode, errObj["code"])
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/proofs/blocks?chain=ethereum&from=1&to=2", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetProofsByBlockRange(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected %d for POST, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+func TestProofCursor_RoundTrip(t *testing.T) {
+	cursor := database.ProofCursor{
+		CreatedAt: time.Date(2025, 6, 1, 12, 30, 0, 123456000, time.UTC),
+		ProofID:   uuid.New(),
+	}
+	parsed, err := database.ParseProofCursor(cursor.String())
+	if err != nil {
+		t.Fatalf("ParseProofCursor failed: %v", err)
+	}
+	if !parsed.CreatedAt.Equal(cursor.CreatedAt) || parsed.ProofID != cursor.ProofID {
+		t.Errorf("expected %+v, got %+v", cursor, parsed)
+	}
+}
+
 func TestHandleGetProofsByBatch_InvalidBatchID(t *testing.T) {
 	handlers := NewProofHandlers(nil, "test", nil)
 
@@ -283,6 +382,19 @@ func TestHandleVerifyProofIntegrity_InvalidProofID(t *testing.T) {
 	}
 }
 
+func TestHandleCheckProofOnChain_NotConfigured(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/proofs/"+uuid.New().String()+"/verify", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.HandleGetProofByID(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
 func TestHandleGetBatchStats_InvalidBatchID(t *testing.T) {
 	handlers := NewProofHandlers(nil, "test", nil)
 
@@ -333,6 +445,473 @@ func TestHandleSyncProofs_InvalidTimestamp(t *testing.T) {
 	}
 }
 
+func TestHandleSyncProofs_InvalidCursor(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	// Well-formed encoding, but the proof ID part is not a UUID
+	token := base64.RawURLEncoding.EncodeToString([]byte("1700000000000000000:not-a-uuid"))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/proofs/sync?since="+token, nil)
+	rr := httptest.NewRecorder()
+
+	handlers.HandleSyncProofs(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct == "application/x-ndjson" {
+		t.Error("Rejected sync should not start an NDJSON stream")
+	}
+}
+
+// fakeProofSyncSource streams a fixed list of proofs in order, like the repository:
+// it reads one proof past the limit to tell whether more follow
+type fakeProofSyncSource struct {
+	proofs   []*database.ProofArtifact
+	err      error // Returned once errAfter proofs were streamed
+	errAfter int
+
+	after *database.ProofSyncCursor // Cursor of the last call
+	limit int                       // Limit of the last call
+}
+
+func (f *fakeProofSyncSource) cursorOf(p *database.ProofArtifact) database.ProofSyncCursor {
+	return database.ProofSyncCursor{UpdatedAt: p.CreatedAt, ProofID: p.ProofID}
+}
+
+func (f *fakeProofSyncSource) StreamProofsSince(ctx context.Context, after *database.ProofSyncCursor, limit int, fn func(*database.ProofArtifact, database.ProofSyncCursor) error) (bool, error) {
+	f.after, f.limit = after, limit
+
+	start := 0
+	if after != nil {
+		for i, p := range f.proofs {
+			if p.ProofID == after.ProofID {
+				start = i + 1
+			}
+		}
+	}
+	streamed := 0
+	for _, p := range f.proofs[start:] {
+		if f.err != nil && streamed == f.errAfter {
+			return false, f.err
+		}
+		if streamed == limit {
+			return true, nil
+		}
+		if err := fn(p, f.cursorOf(p)); err != nil {
+			return false, err
+		}
+		streamed++
+	}
+	if f.err != nil && streamed == f.errAfter {
+		return false, f.err
+	}
+	return false, nil
+}
+
+func newFakeSyncProofs(n int) []*database.ProofArtifact {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	proofs := make([]*database.ProofArtifact, n)
+	for i := range proofs {
+		proofs[i] = &database.ProofArtifact{
+			ProofID:     uuid.New(),
+			AccumTxHash: fmt.Sprintf("tx-%d", i),
+			CreatedAt:   base.Add(time.Duration(i) * time.Second),
+		}
+	}
+	return proofs
+}
+
+// syncProofs runs one sync request and returns the response, with trailers, and the
+// proof IDs of its NDJSON lines
+func syncProofs(t *testing.T, handlers *ProofHandlers, query string, w http.ResponseWriter) (*http.Response, []uuid.UUID) {
+	t.Helper()
+	rr, ok := w.(*httptest.ResponseRecorder)
+	if !ok {
+		rr = w.(*failingResponseWriter).ResponseRecorder
+	}
+
+	handlers.HandleSyncProofs(w, httptest.NewRequest(http.MethodGet, "/api/v1/proofs/sync"+query, nil))
+
+	resp := rr.Result()
+	var ids []uuid.UUID
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var p database.ProofArtifact
+		if err := decoder.Decode(&p); err != nil {
+			t.Fatalf("Invalid NDJSON line: %v", err)
+		}
+		ids = append(ids, p.ProofID)
+	}
+	return resp, ids
+}
+
+// failingResponseWriter fails every body write, like a client that went away
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w *failingResponseWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestHandleSyncProofs_StreamsPagesWithTrailers(t *testing.T) {
+	source := &fakeProofSyncSource{proofs: newFakeSyncProofs(3)}
+	handlers := NewProofHandlers(nil, "test", nil)
+	handlers.syncSource = source
+
+	// First page: limit + 1 proofs exist, so more follow
+	resp, ids := syncProofs(t, handlers, "?limit=2", httptest.NewRecorder())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Expected NDJSON content type, got %q", ct)
+	}
+	if len(ids) != 2 || ids[0] != source.proofs[0].ProofID || ids[1] != source.proofs[1].ProofID {
+		t.Fatalf("Expected the first two proofs, got %v", ids)
+	}
+	if source.after != nil || source.limit != 2 {
+		t.Errorf("Expected no cursor and limit 2, got %+v and %d", source.after, source.limit)
+	}
+	if got := resp.Trailer.Get("X-Sync-Count"); got != "2" {
+		t.Errorf("Expected X-Sync-Count 2, got %q", got)
+	}
+	if got := resp.Trailer.Get("X-Sync-Has-More"); got != "true" {
+		t.Errorf("Expected X-Sync-Has-More true, got %q", got)
+	}
+	if got := resp.Trailer.Get("X-Sync-Error"); got != "" {
+		t.Errorf("Expected no X-Sync-Error, got %q", got)
+	}
+	next := resp.Trailer.Get("X-Next-Cursor")
+	if want := source.cursorOf(source.proofs[1]).String(); next != want {
+		t.Fatalf("Expected X-Next-Cursor %q, got %q", want, next)
+	}
+
+	// Resuming from the cursor sends the rest, and nothing more follows
+	resp, ids = syncProofs(t, handlers, "?limit=2&since="+next, httptest.NewRecorder())
+	if len(ids) != 1 || ids[0] != source.proofs[2].ProofID {
+		t.Fatalf("Expected the third proof, got %v", ids)
+	}
+	if source.after == nil || source.after.ProofID != source.proofs[1].ProofID || !source.after.UpdatedAt.Equal(source.proofs[1].CreatedAt) {
+		t.Errorf("Expected the cursor of the second proof, got %+v", source.after)
+	}
+	if got := resp.Trailer.Get("X-Sync-Count"); got != "1" {
+		t.Errorf("Expected X-Sync-Count 1, got %q", got)
+	}
+	if got := resp.Trailer.Get("X-Sync-Has-More"); got != "false" {
+		t.Errorf("Expected X-Sync-Has-More false, got %q", got)
+	}
+	if want := source.cursorOf(source.proofs[2]).String(); resp.Trailer.Get("X-Next-Cursor") != want {
+		t.Errorf("Expected X-Next-Cursor %q, got %q", want, resp.Trailer.Get("X-Next-Cursor"))
+	}
+}
+
+func TestHandleSyncProofs_SinceTimestampAndLimits(t *testing.T) {
+	source := &fakeProofSyncSource{}
+	handlers := NewProofHandlers(nil, "test", nil)
+	handlers.syncSource = source
+
+	resp, ids := syncProofs(t, handlers, "?since=2025-06-01T12:00:00Z", httptest.NewRecorder())
+	if len(ids) != 0 {
+		t.Errorf("Expected no proofs, got %v", ids)
+	}
+	if want := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC); source.after == nil || !source.after.UpdatedAt.Equal(want) || source.after.ProofID != uuid.Nil {
+		t.Errorf("Expected a cursor at %v, got %+v", want, source.after)
+	}
+	if source.limit != defaultProofSyncLimit {
+		t.Errorf("Expected default limit %d, got %d", defaultProofSyncLimit, source.limit)
+	}
+	// With nothing new the client resumes from where it started
+	if got := resp.Trailer.Get("X-Next-Cursor"); got != source.after.String() {
+		t.Errorf("Expected X-Next-Cursor %q, got %q", source.after.String(), got)
+	}
+	if resp.Trailer.Get("X-Sync-Count") != "0" || resp.Trailer.Get("X-Sync-Has-More") != "false" {
+		t.Errorf("Unexpected trailers %v", resp.Trailer)
+	}
+
+	syncProofs(t, handlers, "?limit=1000000", httptest.NewRecorder())
+	if source.limit != maxProofSyncLimit {
+		t.Errorf("Expected limit capped at %d, got %d", maxProofSyncLimit, source.limit)
+	}
+}
+
+func TestHandleSyncProofs_ErrorTrailer(t *testing.T) {
+	tests := []struct {
+		name      string
+		errAfter  int
+		writeFail bool
+		wantIDs   int
+		wantNext  bool
+	}{
+		{name: "query fails", errAfter: 0},
+		{name: "query fails mid-stream", errAfter: 2, wantIDs: 2, wantNext: true},
+		{name: "write fails", writeFail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakeProofSyncSource{proofs: newFakeSyncProofs(3), errAfter: tt.errAfter}
+			if !tt.writeFail {
+				source.err = errors.New("connection lost")
+			}
+			handlers := NewProofHandlers(nil, "test", log.New(io.Discard, "", 0))
+			handlers.syncSource = source
+
+			var w http.ResponseWriter = httptest.NewRecorder()
+			if tt.writeFail {
+				w = &failingResponseWriter{httptest.NewRecorder()}
+			}
+			resp, ids := syncProofs(t, handlers, "?limit=10", w)
+
+			// The status line went out before the failure; only the trailers report it
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected %d, got %d", http.StatusOK, resp.StatusCode)
+			}
+			if len(ids) != tt.wantIDs {
+				t.Errorf("Expected %d proofs before the failure, got %d", tt.wantIDs, len(ids))
+			}
+			if got := resp.Trailer.Get("X-Sync-Error"); got != "Failed to sync proofs" {
+				t.Errorf("Expected X-Sync-Error, got %q", got)
+			}
+			if got := resp.Trailer.Get("X-Sync-Count"); got != strconv.Itoa(tt.wantIDs) {
+				t.Errorf("Expected X-Sync-Count %d, got %q", tt.wantIDs, got)
+			}
+			if got := resp.Trailer.Get("X-Sync-Has-More"); got != "false" {
+				t.Errorf("Expected X-Sync-Has-More false, got %q", got)
+			}
+			next := resp.Trailer.Get("X-Next-Cursor")
+			if tt.wantNext {
+				if want := source.cursorOf(source.proofs[tt.wantIDs-1]).String(); next != want {
+					t.Errorf("Expected X-Next-Cursor %q, got %q", want, next)
+				}
+			} else if next != "" {
+				t.Errorf("Expected no X-Next-Cursor, got %q", next)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Report Endpoint Tests
+// ============================================================================
+
+func TestHandleGetGasHistory_MethodNotAllowed(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/reports/gas-history", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.HandleGetGasHistory(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+func TestHandleGetGasHistory_InvalidParams(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	tests := []struct {
+		query string
+		code  string
+	}{
+		{"since=yesterday", "INVALID_TIMESTAMP"},
+		{"since=2026-01-01T00:00:00Z&format=xml", "INVALID_FORMAT"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/gas-history?"+tt.query, nil)
+		rr := httptest.NewRecorder()
+
+		handlers.HandleGetGasHistory(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected %d, got %d", tt.query, http.StatusBadRequest, rr.Code)
+			continue
+		}
+		var response map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&response)
+		errObj := response["error"].(map[string]interface{})
+		if errObj["code"] != tt.code {
+			t.Errorf("%s: expected %s, got %v", tt.query, tt.code, errObj["code"])
+		}
+	}
+}
+
+func TestHandleGetVerificationFailures_NotConfigured(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/proofs/verification-failures", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetVerificationFailures(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/proofs/verification-failures", nil)
+	rr = httptest.NewRecorder()
+	handlers.HandleGetVerificationFailures(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected %d without a recorder, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestHandleVerifyProofChain_Dispatch(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+	path := "/api/v1/proofs/6f1c2a0e-8d3b-4f5a-9c7e-1b2d3e4f5a6b/verify-chain"
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetProofByID(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, path, nil)
+	rr = httptest.NewRecorder()
+	handlers.HandleGetProofByID(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected %d without a chain verifier, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestHandleGetProofMerkleBundle_Dispatch(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/proofs/6f1c2a0e-8d3b-4f5a-9c7e-1b2d3e4f5a6b/bundle", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetProofByID(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/proofs/not-a-uuid/bundle", nil)
+	rr = httptest.NewRecorder()
+	handlers.HandleGetProofByID(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestBuildMerkleProofBundle(t *testing.T) {
+	var leaves [][]byte
+	for i := 0; i < 5; i++ {
+		leaf := sha256.Sum256([]byte{byte(i)})
+		leaves = append(leaves, leaf[:])
+	}
+	tree, err := merkle.BuildTree(leaves)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anchorTx := "0xabc123"
+	blockNumber := int64(7654321)
+	for leafIndex := range leaves {
+		proof, err := tree.GenerateProof(leafIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inclusion := &database.ProofMerkleInclusion{
+			ProofID:           uuid.New(),
+			LeafHash:          leaves[leafIndex],
+			LeafIndex:         &leafIndex,
+			MerkleRoot:        tree.Root(),
+			AnchorTxHash:      &anchorTx,
+			AnchorBlockNumber: &blockNumber,
+		}
+		for _, node := range proof.Path {
+			inclusion.MerklePath = append(inclusion.MerklePath, database.MerklePathNode{Hash: node.Hash, Position: string(node.Position)})
+		}
+
+		bundle, err := buildMerkleProofBundle(inclusion)
+		if err != nil {
+			t.Fatalf("leaf %d: buildMerkleProofBundle failed: %v", leafIndex, err)
+		}
+		if bundle.Anchor.TxHash != anchorTx || bundle.Anchor.BlockNumber != blockNumber {
+			t.Errorf("leaf %d: unexpected anchor %+v", leafIndex, bundle.Anchor)
+		}
+
+		// Follow the bundle's recipe with plain SHA-256
+		current, _ := hex.DecodeString(bundle.LeafHash)
+		for _, entry := range bundle.Path {
+			sibling, _ := hex.DecodeString(entry.Hash)
+			var sum [32]byte
+			if entry.Right {
+				sum = sha256.Sum256(append(append([]byte{}, current...), sibling...))
+			} else {
+				sum = sha256.Sum256(append(append([]byte{}, sibling...), current...))
+			}
+			current = sum[:]
+		}
+		if hex.EncodeToString(current) != bundle.MerkleRoot {
+			t.Errorf("leaf %d: recipe computed %x, bundle root %s", leafIndex, current, bundle.MerkleRoot)
+		}
+	}
+
+	// A path that does not lead to the root is rejected
+	proof, _ := tree.GenerateProof(0)
+	tampered := &database.ProofMerkleInclusion{
+		LeafHash:     leaves[1],
+		MerkleRoot:   tree.Root(),
+		AnchorTxHash: &anchorTx,
+	}
+	for _, node := range proof.Path {
+		tampered.MerklePath = append(tampered.MerklePath, database.MerklePathNode{Hash: node.Hash, Position: string(node.Position)})
+	}
+	if _, err := buildMerkleProofBundle(tampered); err == nil || errors.Is(err, errMerkleProofUnavailable) {
+		t.Errorf("Expected a verification error for a tampered path, got %v", err)
+	}
+
+	// Proofs whose batch is not anchored yet have no bundle
+	tampered.AnchorTxHash = nil
+	if _, err := buildMerkleProofBundle(tampered); !errors.Is(err, errMerkleProofUnavailable) {
+		t.Errorf("Expected errMerkleProofUnavailable, got %v", err)
+	}
+}
+
+func TestHandleStreamProof_Dispatch(t *testing.T) {
+	handlers := NewProofHandlers(nil, "test", nil)
+	path := "/api/v1/proofs/6f1c2a0e-8d3b-4f5a-9c7e-1b2d3e4f5a6b/stream"
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetProofByID(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected %d without an event bus, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	handlers.SetProofCycleEvents(execution.NewProofCycleEventBus())
+	req = httptest.NewRequest(http.MethodPost, path, nil)
+	rr = httptest.NewRecorder()
+	handlers.HandleGetProofByID(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/proofs/not-a-uuid/stream", nil)
+	rr = httptest.NewRecorder()
+	handlers.HandleGetProofByID(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected %d for invalid proof ID, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestProofCycleEventMatches(t *testing.T) {
+	intentID := "intent-1"
+	proof := &database.ProofArtifact{AccumTxHash: "0xabc", IntentID: &intentID}
+
+	if !proofCycleEventMatches(proof, execution.ProofCycleEvent{IntentID: "intent-1"}) {
+		t.Error("Expected a match on intent ID")
+	}
+	if !proofCycleEventMatches(proof, execution.ProofCycleEvent{IntentTxHash: "0xabc"}) {
+		t.Error("Expected a match on intent transaction hash")
+	}
+	if proofCycleEventMatches(proof, execution.ProofCycleEvent{IntentID: "intent-2", IntentTxHash: "0xdef"}) {
+		t.Error("Expected no match for another cycle")
+	}
+	if proofCycleEventMatches(&database.ProofArtifact{}, execution.ProofCycleEvent{}) {
+		t.Error("Expected empty identifiers never to match")
+	}
+}
+
 // ============================================================================
 // Helper Method Tests
 // ============================================================================
@@ -0,0 +1,140 @@
+// Copyright 2025 Certen Protocol
+//
+// Merkle Proof Bundle - Self-contained batch Merkle proof export
+//
+// A bundle carries everything a third party needs to check that a transaction was
+// anchored without trusting the validator: the leaf hash, the ordered sibling hashes
+// with left/right flags, the batch Merkle root and the anchor transaction that
+// committed the root. The path follows the Accumulate receipt convention used by
+// VerifyBPTProof in the lite client, so the root can be recomputed with plain SHA-256.
+
+package server
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/certen/independant-validator/pkg/database"
+	"github.com/certen/independant-validator/pkg/merkle"
+)
+
+// MerkleProofBundleFormat identifies the bundle layout
+const MerkleProofBundleFormat = "certen-merkle-proof/v1"
+
+// MerkleProofBundle is a self-contained batch Merkle inclusion proof
+type MerkleProofBundle struct {
+	Format       string                   `json:"format"`
+	ProofID      uuid.UUID                `json:"proof_id"`
+	AccumTxHash  string                   `json:"accum_tx_hash"`
+	AccountURL   string                   `json:"account_url"`
+	BatchID      *uuid.UUID               `json:"batch_id,omitempty"`
+	LeafHash     string                   `json:"leaf_hash"`
+	LeafIndex    *int                     `json:"leaf_index,omitempty"`
+	Path         []MerkleProofBundleEntry `json:"path"`
+	MerkleRoot   string                   `json:"merkle_root"`
+	Anchor       MerkleProofBundleAnchor  `json:"anchor"`
+	Verification MerkleProofBundleRecipe  `json:"verification"`
+}
+
+// MerkleProofBundleEntry is one sibling on the path from the leaf to the root
+type MerkleProofBundleEntry struct {
+	Hash  string `json:"hash"`  // 32-byte hex sibling hash
+	Right bool   `json:"right"` // Sibling is on the right: SHA256(current || hash)
+}
+
+// MerkleProofBundleAnchor is the external chain transaction that committed the root
+type MerkleProofBundleAnchor struct {
+	Chain       string `json:"chain,omitempty"`
+	TxHash      string `json:"tx_hash"`
+	BlockNumber int64  `json:"block_number"`
+}
+
+// MerkleProofBundleRecipe describes how to check a bundle with standard tooling
+type MerkleProofBundleRecipe struct {
+	HashFunction string   `json:"hash_function"`
+	Steps        []string `json:"steps"`
+	Script       string   `json:"script"` // Python 3 standard library only
+}
+
+// merkleProofBundleRecipe is the same for every bundle
+var merkleProofBundleRecipe = MerkleProofBundleRecipe{
+	HashFunction: "sha256",
+	Steps: []string{
+		"Hex-decode leaf_hash, merkle_root and every path[i].hash; each is 32 bytes.",
+		"Set current = leaf_hash.",
+		"For each path entry in order: if right is true, current = SHA-256(current || hash); otherwise current = SHA-256(hash || current).",
+		"The inclusion proof holds when current equals merkle_root.",
+		"Look up anchor.tx_hash on anchor.chain and check it was mined in anchor.block_number and commits merkle_root.",
+	},
+	Script: `import hashlib, json, sys
+b = json.load(open(sys.argv[1]))
+cur = bytes.fromhex(b["leaf_hash"])
+for e in b["path"]:
+    h = bytes.fromhex(e["hash"])
+    cur = hashlib.sha256(cur + h if e["right"] else h + cur).digest()
+print("valid" if cur.hex() == b["merkle_root"] else "INVALID")`,
+}
+
+// errMerkleProofUnavailable reports a proof whose batch Merkle proof is not complete yet
+var errMerkleProofUnavailable = errors.New("merkle proof unavailable")
+
+// buildMerkleProofBundle assembles and self-checks the bundle of a proof. It returns
+// errMerkleProofUnavailable while the proof is not batched and anchored, and an error
+// when the stored path does not lead to the stored root.
+func buildMerkleProofBundle(inclusion *database.ProofMerkleInclusion) (*MerkleProofBundle, error) {
+	if len(inclusion.LeafHash) == 0 || len(inclusion.MerkleRoot) == 0 {
+		return nil, fmt.Errorf("%w: proof has not been batched", errMerkleProofUnavailable)
+	}
+	if inclusion.AnchorTxHash == nil || *inclusion.AnchorTxHash == "" {
+		return nil, fmt.Errorf("%w: batch has not been anchored", errMerkleProofUnavailable)
+	}
+
+	receipt := &merkle.Receipt{
+		Start:   hex.EncodeToString(inclusion.LeafHash),
+		Anchor:  hex.EncodeToString(inclusion.MerkleRoot),
+		Entries: make([]merkle.ReceiptEntry, 0, len(inclusion.MerklePath)),
+	}
+	for i, node := range inclusion.MerklePath {
+		var right bool
+		switch merkle.Position(node.Position) {
+		case merkle.Right:
+			right = true
+		case merkle.Left:
+		default:
+			return nil, fmt.Errorf("merkle path entry %d has invalid position %q", i, node.Position)
+		}
+		hash := strings.ToLower(strings.TrimPrefix(node.Hash, "0x"))
+		receipt.Entries = append(receipt.Entries, merkle.ReceiptEntry{Hash: hash, Right: right})
+	}
+	if err := receipt.Validate(); err != nil {
+		return nil, fmt.Errorf("stored merkle path does not verify: %w", err)
+	}
+
+	bundle := &MerkleProofBundle{
+		Format:       MerkleProofBundleFormat,
+		ProofID:      inclusion.ProofID,
+		AccumTxHash:  inclusion.AccumTxHash,
+		AccountURL:   inclusion.AccountURL,
+		BatchID:      inclusion.BatchID,
+		LeafHash:     receipt.Start,
+		LeafIndex:    inclusion.LeafIndex,
+		Path:         make([]MerkleProofBundleEntry, 0, len(receipt.Entries)),
+		MerkleRoot:   receipt.Anchor,
+		Anchor:       MerkleProofBundleAnchor{TxHash: *inclusion.AnchorTxHash},
+		Verification: merkleProofBundleRecipe,
+	}
+	for _, entry := range receipt.Entries {
+		bundle.Path = append(bundle.Path, MerkleProofBundleEntry{Hash: entry.Hash, Right: entry.Right})
+	}
+	if inclusion.AnchorChain != nil {
+		bundle.Anchor.Chain = *inclusion.AnchorChain
+	}
+	if inclusion.AnchorBlockNumber != nil {
+		bundle.Anchor.BlockNumber = *inclusion.AnchorBlockNumber
+	}
+	return bundle, nil
+}
@@ -41,10 +41,23 @@ type RegistryConfig struct {
 	CertenContract   string
 	NetworkName      string
 
+	// Additional EVM anchor networks; each is registered when its RPC is set
+	Base    EVMNetworkSettings
+	Polygon EVMNetworkSettings
+
 	// Logger
 	Logger *log.Logger
 }
 
+// EVMNetworkSettings configures an additional EVM network that anchors through
+// CertenAnchorV3 with the validator's Ethereum key
+type EVMNetworkSettings struct {
+	RPC                   string
+	ChainID               int64 // 0 selects the network's mainnet
+	AnchorContract        string
+	RequiredConfirmations int // 0 selects the network default
+}
+
 // NewRegistryFromConfig creates a strategy registry from config
 func NewRegistryFromConfig(cfg *config.Config, blsKey []byte, ed25519Key ed25519.PrivateKey) (*Registry, error) {
 	regConfig := &RegistryConfig{
@@ -58,7 +71,19 @@ func NewRegistryFromConfig(cfg *config.Config, blsKey []byte, ed25519Key ed25519
 		AnchorContract:    cfg.AnchorContractAddress,
 		CertenContract:    cfg.CertenContractAddress,
 		NetworkName:       cfg.NetworkName,
-		Logger:            log.New(log.Writer(), "[StrategyRegistry] ", log.LstdFlags),
+		Base: EVMNetworkSettings{
+			RPC:                   cfg.BaseRPC,
+			ChainID:               cfg.BaseChainID,
+			AnchorContract:        cfg.BaseAnchorContract,
+			RequiredConfirmations: cfg.BaseConfirmations,
+		},
+		Polygon: EVMNetworkSettings{
+			RPC:                   cfg.PolygonRPC,
+			ChainID:               cfg.PolygonChainID,
+			AnchorContract:        cfg.PolygonAnchorContract,
+			RequiredConfirmations: cfg.PolygonConfirmations,
+		},
+		Logger: log.New(log.Writer(), "[StrategyRegistry] ", log.LstdFlags),
 	}
 
 	return InitializeRegistry(regConfig)
@@ -222,6 +247,10 @@ func initializeChainStrategies(registry *Registry, cfg *RegistryConfig) error {
 		}
 	}
 
+	// Register the additional EVM anchor networks
+	registerEVMNetworkStrategy(registry, cfg, "Base", cfg.Base, chain.NewBaseStrategy)
+	registerEVMNetworkStrategy(registry, cfg, "Polygon", cfg.Polygon, chain.NewPolygonStrategy)
+
 	// Register stub strategies for other chains (future implementation)
 	if err := registerStubChainStrategies(registry, cfg); err != nil {
 		if cfg.Logger != nil {
@@ -232,6 +261,47 @@ func initializeChainStrategies(registry *Registry, cfg *RegistryConfig) error {
 	return nil
 }
 
+// evmNetworkFactory creates the strategy of an additional EVM network
+type evmNetworkFactory func(rpcURL, privateKeyHex, contractAddress, validatorID string, chainID int64, confirmations int) (*chain.EVMStrategy, error)
+
+// registerEVMNetworkStrategy registers an additional EVM network under its chain ID and
+// network name. A network without an RPC endpoint is not configured; one that fails
+// to connect is skipped so it cannot stop the validator from anchoring elsewhere.
+func registerEVMNetworkStrategy(registry *Registry, cfg *RegistryConfig, label string, settings EVMNetworkSettings, factory evmNetworkFactory) {
+	if settings.RPC == "" {
+		return
+	}
+
+	evmStrategy, err := factory(settings.RPC, cfg.EthPrivateKey, settings.AnchorContract, cfg.ValidatorID,
+		settings.ChainID, settings.RequiredConfirmations)
+	if err != nil {
+		if cfg.Logger != nil {
+			cfg.Logger.Printf("⚠️ %s chain strategy not registered: %v", label, err)
+		}
+		return
+	}
+
+	chainID := evmStrategy.ChainID()
+	if err := registry.RegisterChainStrategy(chainID, evmStrategy.Config(), evmStrategy); err != nil {
+		if cfg.Logger != nil {
+			cfg.Logger.Printf("⚠️ %s chain strategy not registered: %v", label, err)
+		}
+		return
+	}
+	if cfg.Logger != nil {
+		cfg.Logger.Printf("✅ %s chain strategy registered: %s (%d confirmations)",
+			label, chainID, evmStrategy.GetRequiredConfirmations())
+	}
+
+	if name := evmStrategy.NetworkName(); name != "" && name != chainID {
+		if err := registry.RegisterChainStrategy(name, evmStrategy.Config(), evmStrategy); err != nil {
+			if cfg.Logger != nil {
+				cfg.Logger.Printf("⚠️ Could not register alias %s: %v", name, err)
+			}
+		}
+	}
+}
+
 // registerStubChainStrategies registers placeholder strategies for future chains
 func registerStubChainStrategies(registry *Registry, cfg *RegistryConfig) error {
 	// Solana Devnet (stub)
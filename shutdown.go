@@ -0,0 +1,133 @@
+// Copyright 2025 Certen Protocol
+//
+// Shutdown Sequence - Ordered, per-stage time-bounded validator shutdown
+//
+// Components register stop hooks into fixed stages as they are created. On shutdown the
+// stages run in order so no component outlives something it depends on:
+//
+//	stop intake → flush batches → finish anchoring → stop trackers → close database → close clients
+//
+// Hooks within a stage run in registration order. Each stage gets its own timeout; a stage
+// that overruns is logged and abandoned so a stuck component cannot block the rest.
+
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// ShutdownStage identifies a step of the shutdown sequence
+type ShutdownStage int
+
+const (
+	ShutdownStopIntake      ShutdownStage = iota // HTTP API, intent discovery, background context
+	ShutdownFlushBatches                         // Close open batches and hand them to the processor
+	ShutdownFinishAnchoring                      // Wait for in-flight anchor submissions
+	ShutdownStopTrackers                         // Confirmation tracker, event watcher, monitors
+	ShutdownCloseDatabase                        // PostgreSQL connection pool
+	ShutdownCloseClients                         // Firestore, Ethereum and Accumulate clients
+	numShutdownStages
+)
+
+var shutdownStageNames = [numShutdownStages]string{
+	"stop intake",
+	"flush batches",
+	"finish anchoring",
+	"stop trackers",
+	"close database",
+	"close clients",
+}
+
+// String returns the stage name
+func (s ShutdownStage) String() string {
+	if s < 0 || s >= numShutdownStages {
+		return "unknown"
+	}
+	return shutdownStageNames[s]
+}
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// ShutdownSequence collects stop hooks and runs them stage by stage
+type ShutdownSequence struct {
+	mu       sync.Mutex
+	hooks    [numShutdownStages][]shutdownHook
+	timeouts [numShutdownStages]time.Duration
+	logger   *log.Logger
+}
+
+// NewShutdownSequence creates a shutdown sequence with the same timeout for every stage
+func NewShutdownSequence(defaultTimeout time.Duration, logger *log.Logger) *ShutdownSequence {
+	if logger == nil {
+		logger = log.New(log.Writer(), "[Shutdown] ", log.LstdFlags)
+	}
+	s := &ShutdownSequence{logger: logger}
+	for i := range s.timeouts {
+		s.timeouts[i] = defaultTimeout
+	}
+	return s
+}
+
+// SetTimeout overrides the timeout of one stage
+func (s *ShutdownSequence) SetTimeout(stage ShutdownStage, timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timeouts[stage] = timeout
+}
+
+// Register adds a stop hook to a stage
+func (s *ShutdownSequence) Register(stage ShutdownStage, name string, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[stage] = append(s.hooks[stage], shutdownHook{name: name, fn: fn})
+}
+
+// Run executes all stages in order
+func (s *ShutdownSequence) Run() {
+	for stage := ShutdownStage(0); stage < numShutdownStages; stage++ {
+		s.mu.Lock()
+		hooks := append([]shutdownHook(nil), s.hooks[stage]...)
+		timeout := s.timeouts[stage]
+		s.mu.Unlock()
+
+		if len(hooks) == 0 {
+			continue
+		}
+		s.runStage(stage, hooks, timeout)
+	}
+}
+
+// runStage runs one stage's hooks sequentially, abandoning them when the stage times out
+func (s *ShutdownSequence) runStage(stage ShutdownStage, hooks []shutdownHook, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	s.logger.Printf("🛑 Stage %d/%d: %s (%d hooks, timeout %s)", stage+1, numShutdownStages, stage, len(hooks), timeout)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, h := range hooks {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := h.fn(ctx); err != nil {
+				s.logger.Printf("⚠️ %s: %s failed: %v", stage, h.name, err)
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+		s.logger.Printf("✅ Stage %s complete in %s", stage, time.Since(start).Round(time.Millisecond))
+	case <-ctx.Done():
+		s.logger.Printf("⚠️ Stage %s timed out after %s - continuing shutdown", stage, timeout)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Certen Protocol
+//
+// Unit tests for the Shutdown Sequence
+// Tests stage order, abandoning a stage that overruns its timeout and hook errors
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// shutdownRecorder records the hooks that ran, in order
+type shutdownRecorder struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+func (r *shutdownRecorder) hook(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.ran = append(r.ran, name)
+		return err
+	}
+}
+
+func (r *shutdownRecorder) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ran...)
+}
+
+func newTestShutdownSequence(timeout time.Duration) *ShutdownSequence {
+	return NewShutdownSequence(timeout, log.New(io.Discard, "", 0))
+}
+
+func TestShutdownSequence_StageOrder(t *testing.T) {
+	s := newTestShutdownSequence(time.Second)
+	r := &shutdownRecorder{}
+
+	// Registered out of order; stages run in the documented order, hooks of a stage in
+	// registration order
+	s.Register(ShutdownCloseClients, "clients", r.hook("clients", nil))
+	s.Register(ShutdownCloseDatabase, "database", r.hook("database", nil))
+	s.Register(ShutdownStopTrackers, "trackers", r.hook("trackers", nil))
+	s.Register(ShutdownFinishAnchoring, "anchoring", r.hook("anchoring", nil))
+	s.Register(ShutdownFlushBatches, "flush", r.hook("flush", nil))
+	s.Register(ShutdownStopIntake, "http", r.hook("http", nil))
+	s.Register(ShutdownStopIntake, "discovery", r.hook("discovery", nil))
+
+	s.Run()
+
+	want := []string{"http", "discovery", "flush", "anchoring", "trackers", "database", "clients"}
+	if got := r.names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("hooks ran in order %v, want %v", got, want)
+	}
+}
+
+func TestShutdownSequence_TimedOutStageAbandoned(t *testing.T) {
+	s := newTestShutdownSequence(time.Second)
+	s.SetTimeout(ShutdownFinishAnchoring, 20*time.Millisecond)
+	r := &shutdownRecorder{}
+
+	release := make(chan struct{})
+	defer close(release)
+	s.Register(ShutdownFinishAnchoring, "stuck", func(ctx context.Context) error {
+		<-release // ignores ctx, like a component that never returns
+		return nil
+	})
+	s.Register(ShutdownFinishAnchoring, "after-stuck", r.hook("after-stuck", nil))
+	s.Register(ShutdownCloseDatabase, "database", r.hook("database", nil))
+
+	done := make(chan struct{})
+	go func() {
+		s.Run()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run blocked on a stage past its timeout")
+	}
+
+	// The rest of the overrunning stage is abandoned, later stages still run
+	if got := r.names(); !reflect.DeepEqual(got, []string{"database"}) {
+		t.Errorf("hooks ran %v, want only the later stage's hook", got)
+	}
+}
+
+func TestShutdownSequence_ErrorDoesNotSkipHooks(t *testing.T) {
+	s := newTestShutdownSequence(time.Second)
+	r := &shutdownRecorder{}
+
+	s.Register(ShutdownStopTrackers, "watcher", r.hook("watcher", errors.New("already stopped")))
+	s.Register(ShutdownStopTrackers, "tracker", r.hook("tracker", nil))
+	s.Register(ShutdownCloseClients, "clients", r.hook("clients", nil))
+
+	s.Run()
+
+	want := []string{"watcher", "tracker", "clients"}
+	if got := r.names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("hooks ran %v, want %v", got, want)
+	}
+}